@@ -167,6 +167,23 @@ const (
 	AgentServiceName  = "AGENT_SERVICE_NAME"
 	MongoOplogSize    = "MONGO_OPLOG_SIZE"
 	NumaCtlPreference = "NUMA_CTL_PREFERENCE"
+	LogMaxSize        = "LOG_MAX_SIZE"
+	LogMaxBackups     = "LOG_MAX_BACKUPS"
+
+	// UnitAgentDeployment and UnitAgentImage select how a machine
+	// agent deploys unit agents; see worker/deployer.
+	UnitAgentDeployment = "UNIT_AGENT_DEPLOYMENT"
+	UnitAgentImage      = "UNIT_AGENT_IMAGE"
+)
+
+const (
+	// DefaultLogMaxSize is the size in megabytes at which an agent's
+	// log file is rotated, unless LogMaxSize is set.
+	DefaultLogMaxSize = 300
+
+	// DefaultLogMaxBackups is the number of rotated log files an agent
+	// keeps, unless LogMaxBackups is set.
+	DefaultLogMaxBackups = 2
 )
 
 // The Config interface is the sole way that the agent gets access to the
@@ -312,6 +329,19 @@ func LogFilename(c Config) string {
 	return filepath.Join(c.LogDir(), c.Tag().String()+".log")
 }
 
+// LogRotation returns the size in megabytes at which the Agent's log
+// file is rotated, and the number of rotated log files it keeps.
+func LogRotation(c Config) (maxSize, maxBackups int) {
+	maxSize, maxBackups = DefaultLogMaxSize, DefaultLogMaxBackups
+	if n, err := strconv.Atoi(c.Value(LogMaxSize)); err == nil && n > 0 {
+		maxSize = n
+	}
+	if n, err := strconv.Atoi(c.Value(LogMaxBackups)); err == nil && n > 0 {
+		maxBackups = n
+	}
+	return maxSize, maxBackups
+}
+
 type ConfigMutator func(ConfigSetter) error
 
 type ConfigWriter interface {
@@ -716,3 +716,17 @@ func (*suite) TestSetCACert(c *gc.C) {
 	conf.SetCACert("new ca cert")
 	c.Assert(conf.CACert(), gc.Equals, "new ca cert")
 }
+
+func (*suite) TestLogRotation(c *gc.C) {
+	conf, err := agent.NewAgentConfig(attributeParams)
+	c.Assert(err, jc.ErrorIsNil)
+	maxSize, maxBackups := agent.LogRotation(conf)
+	c.Assert(maxSize, gc.Equals, agent.DefaultLogMaxSize)
+	c.Assert(maxBackups, gc.Equals, agent.DefaultLogMaxBackups)
+
+	conf.SetValue(agent.LogMaxSize, "100")
+	conf.SetValue(agent.LogMaxBackups, "bogus")
+	maxSize, maxBackups = agent.LogRotation(conf)
+	c.Assert(maxSize, gc.Equals, 100)
+	c.Assert(maxBackups, gc.Equals, agent.DefaultLogMaxBackups)
+}
@@ -4,8 +4,13 @@
 package action
 
 import (
+	"net/url"
 	"time"
 
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -25,3 +30,48 @@ func (c *Client) Run(run params.RunParams) ([]params.ActionResult, error) {
 	err := c.facade.FacadeCall("Run", run, &results)
 	return results.Results, err
 }
+
+// StreamResults opens a stream which returns the result of each of the
+// given actions as soon as it finishes.
+func (c *Client) StreamResults(actionTags []names.ActionTag) (ResultsStream, error) {
+	attrs := url.Values{}
+	for _, tag := range actionTags {
+		attrs.Add("action", tag.Id())
+	}
+	stream, err := c.facade.RawAPICaller().ConnectStream("/action-results", attrs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot stream action results")
+	}
+	return &resultsStream{stream: stream}, nil
+}
+
+// ResultsStream returns the results of actions as they finish.
+type ResultsStream interface {
+	// Next returns the result of the next action to finish. It
+	// returns io.EOF once the results of all the actions have been
+	// returned.
+	Next() (params.ActionResult, error)
+
+	// Close closes the stream.
+	Close() error
+}
+
+// resultsStream implements ResultsStream by reading from the
+// /action-results API endpoint.
+type resultsStream struct {
+	stream base.Stream
+}
+
+// Next implements ResultsStream.
+func (s *resultsStream) Next() (params.ActionResult, error) {
+	var result params.ActionResult
+	if err := s.stream.ReadJSON(&result); err != nil {
+		return params.ActionResult{}, err
+	}
+	return result, nil
+}
+
+// Close implements ResultsStream.
+func (s *resultsStream) Close() error {
+	return s.stream.Close()
+}
@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type runSuite struct{}
+
+var _ = gc.Suite(&runSuite{})
+
+const (
+	streamActionId1 = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	streamActionId2 = "6ba7b810-9dad-41d1-80b4-00c04fd430c8"
+)
+
+func (s *runSuite) TestStreamResults(c *gc.C) {
+	var stub jujutesting.Stub
+	stream := &fakeStream{messages: []string{
+		`{"action":{"tag":"action-` + streamActionId2 + `","receiver":"unit-mysql-0"},"status":"completed","output":{"Stdout":"hello"}}`,
+	}}
+	client := action.NewClient(&streamConnector{stub: &stub, stream: stream})
+	results, err := client.StreamResults([]names.ActionTag{
+		names.NewActionTag(streamActionId1),
+		names.NewActionTag(streamActionId2),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{{
+		"ConnectStream", []interface{}{"/action-results", url.Values{
+			"action": {streamActionId1, streamActionId2},
+		}},
+	}})
+
+	result, err := results.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ActionResult{
+		Action: &params.Action{
+			Tag:      "action-" + streamActionId2,
+			Receiver: "unit-mysql-0",
+		},
+		Status: "completed",
+		Output: map[string]interface{}{"Stdout": "hello"},
+	})
+	_, err = results.Next()
+	c.Assert(err, gc.Equals, io.EOF)
+
+	c.Assert(results.Close(), jc.ErrorIsNil)
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *runSuite) TestStreamResultsError(c *gc.C) {
+	var stub jujutesting.Stub
+	stub.SetErrors(errors.New("boom"))
+	client := action.NewClient(&streamConnector{stub: &stub})
+	_, err := client.StreamResults([]names.ActionTag{names.NewActionTag(streamActionId1)})
+	c.Assert(err, gc.ErrorMatches, "cannot stream action results: boom")
+}
+
+type streamConnector struct {
+	apitesting.APICallerFunc
+	stub   *jujutesting.Stub
+	stream base.Stream
+}
+
+func (c *streamConnector) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	c.stub.AddCall("ConnectStream", path, attrs)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return c.stream, nil
+}
+
+type fakeStream struct {
+	base.Stream
+	messages []string
+	closed   bool
+}
+
+func (s *fakeStream) ReadJSON(v interface{}) error {
+	if len(s.messages) == 0 {
+		return io.EOF
+	}
+	message := s.messages[0]
+	s.messages = s.messages[1:]
+	return json.Unmarshal([]byte(message), v)
+}
+
+func (s *fakeStream) Close() error {
+	s.closed = true
+	return nil
+}
@@ -38,6 +38,11 @@ type ConnFacade interface {
 
 	// SetPassword returns nil, ErrDenied, or some other error.
 	SetPassword(names.Tag, string) error
+
+	// PasswordRotationRequired returns whether the entity's agent
+	// should replace its current password, ErrDenied, or some other
+	// error.
+	PasswordRotationRequired(names.Tag) (bool, error)
 }
 
 // ErrDenied is returned by Life and SetPassword to indicate that the
@@ -112,3 +117,25 @@ func (facade *connFacade) SetPassword(entity names.Tag, password string) error {
 	}
 	return nil
 }
+
+// PasswordRotationRequired is part of the ConnFacade interface.
+func (facade *connFacade) PasswordRotationRequired(entity names.Tag) (bool, error) {
+	var results params.BoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: entity.String()}},
+	}
+	err := facade.caller.FacadeCall("PasswordRotationRequired", args, &results)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		if params.IsCodeNotFoundOrCodeUnauthorized(err) {
+			return false, ErrDenied
+		}
+		return false, errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
@@ -201,6 +201,54 @@ func (s *FacadeSuite) TestSetPasswordSuccess(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *FacadeSuite) TestPasswordRotationRequiredCallError(c *gc.C) {
+	apiCaller := apiCaller(c, func(request string, arg, _ interface{}) error {
+		c.Check(request, gc.Equals, "PasswordRotationRequired")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-omg",
+			}},
+		})
+		return errors.New("splat")
+	})
+	facade, err := agent.NewConnFacade(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.PasswordRotationRequired(names.NewApplicationTag("omg"))
+	c.Check(err, gc.ErrorMatches, "splat")
+}
+
+func (s *FacadeSuite) TestPasswordRotationRequiredNoResult(c *gc.C) {
+	facade, err := agent.NewConnFacade(rotationChecker(c, params.BoolResults{}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.PasswordRotationRequired(names.NewApplicationTag("omg"))
+	c.Check(err, gc.ErrorMatches, "expected 1 result, got 0")
+}
+
+func (s *FacadeSuite) TestPasswordRotationRequiredErrUnauthorized(c *gc.C) {
+	facade, err := agent.NewConnFacade(rotationChecker(c, params.BoolResults{
+		Results: []params.BoolResult{{
+			Error: &params.Error{Code: params.CodeUnauthorized},
+		}},
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.PasswordRotationRequired(names.NewApplicationTag("omg"))
+	c.Check(err, gc.Equals, agent.ErrDenied)
+}
+
+func (s *FacadeSuite) TestPasswordRotationRequiredSuccess(c *gc.C) {
+	facade, err := agent.NewConnFacade(rotationChecker(c, params.BoolResults{
+		Results: []params.BoolResult{{Result: true}},
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	rotate, err := facade.PasswordRotationRequired(names.NewApplicationTag("omg"))
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(rotate, jc.IsTrue)
+}
+
 func testLifeAPIResult(c *gc.C, result params.AgentGetEntitiesResult) (agent.Life, error) {
 	facade, err := agent.NewConnFacade(lifeChecker(c, params.AgentGetEntitiesResults{
 		Entities: []params.AgentGetEntitiesResult{result},
@@ -237,6 +285,15 @@ func passwordChecker(c *gc.C, result params.ErrorResults) base.APICaller {
 	})
 }
 
+func rotationChecker(c *gc.C, result params.BoolResults) base.APICaller {
+	return apiCaller(c, func(_ string, _, out interface{}) error {
+		typed, ok := out.(*params.BoolResults)
+		c.Assert(ok, jc.IsTrue)
+		*typed = result
+		return nil
+	})
+}
+
 func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
 	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
 		c.Check(facade, gc.Equals, "Agent")
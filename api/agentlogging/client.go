@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentlogging
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the Juju client command uses to get and
+// set the log levels and log rotation settings of a model's agents.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AgentLogging")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AgentLogging returns the logging settings of the agent or model with
+// the given tag.
+func (c *Client) AgentLogging(tag names.Tag) (params.AgentLogging, error) {
+	var results params.AgentLoggingResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := c.facade.FacadeCall("AgentLogging", args, &results); err != nil {
+		return params.AgentLogging{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.AgentLogging{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.AgentLogging{}, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// SetAgentLogging replaces the logging settings of the agents, or the
+// default log rotation settings of the model, identified by the given
+// settings' tags.
+func (c *Client) SetAgentLogging(settings ...params.AgentLogging) error {
+	var results params.ErrorResults
+	args := params.AgentLoggingArgs{Args: settings}
+	if err := c.facade.FacadeCall("SetAgentLogging", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentlogging_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/agentlogging"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type AgentLoggingSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&AgentLoggingSuite{})
+
+func (s *AgentLoggingSuite) TestAgentLogging(c *gc.C) {
+	settings := params.AgentLogging{
+		Tag:           "machine-0",
+		LoggingConfig: "juju.worker=DEBUG",
+		MaxLogSize:    100,
+	}
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "AgentLogging")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AgentLogging")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AgentLoggingResults{})
+			*(result.(*params.AgentLoggingResults)) = params.AgentLoggingResults{
+				Results: []params.AgentLoggingResult{{Result: settings}},
+			}
+			called = true
+			return nil
+		},
+	)
+	client := agentlogging.NewClient(apiCaller)
+	result, err := client.AgentLogging(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, settings)
+}
+
+func (s *AgentLoggingSuite) TestSetAgentLogging(c *gc.C) {
+	settings := []params.AgentLogging{{
+		Tag:           "unit-mysql-0",
+		LoggingConfig: "unit=TRACE",
+	}, {
+		Tag:        "machine-0",
+		MaxLogSize: 50,
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "AgentLogging")
+			c.Check(request, gc.Equals, "SetAgentLogging")
+			c.Check(a, jc.DeepEquals, params.AgentLoggingArgs{Args: settings})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {
+					Error: &params.Error{Message: "machine 0 not found"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := agentlogging.NewClient(apiCaller)
+	err := client.SetAgentLogging(settings...)
+	c.Assert(err, gc.ErrorMatches, "machine 0 not found")
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the Juju client command uses to
+// manage the alert rules of a model, and list the alerts they raise.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Alerts")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddAlertRule adds the given alert rule to the model.
+func (c *Client) AddAlertRule(rule params.AlertRule) error {
+	args := params.AlertRuleArgs{Args: []params.AlertRule{rule}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddAlertRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveAlertRules removes the named alert rules from the model.
+func (c *Client) RemoveAlertRules(names ...string) error {
+	args := params.AlertRuleNames{Names: names}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveAlertRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// ListAlertRules returns the alert rules of the model.
+func (c *Client) ListAlertRules() ([]params.AlertRule, error) {
+	var results params.ListAlertRulesResults
+	if err := c.facade.FacadeCall("ListAlertRules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Rules, nil
+}
+
+// ListAlerts returns the alerts currently raised in the model.
+func (c *Client) ListAlerts() ([]params.Alert, error) {
+	var results params.ListAlertsResults
+	if err := c.facade.FacadeCall("ListAlerts", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Alerts, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/alerts"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type AlertsSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&AlertsSuite{})
+
+func (s *AlertsSuite) TestAddAlertRule(c *gc.C) {
+	rule := params.AlertRule{
+		Name:    "unit-errors",
+		Kind:    "unit-error",
+		For:     5 * time.Minute,
+		Webhook: "https://hooks.example.com/juju",
+	}
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Alerts")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AddAlertRules")
+			c.Check(a, jc.DeepEquals, params.AlertRuleArgs{
+				Args: []params.AlertRule{rule},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			called = true
+			return nil
+		},
+	)
+	client := alerts.NewClient(apiCaller)
+	err := client.AddAlertRule(rule)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *AlertsSuite) TestAddAlertRuleError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: `alert rule "down" already exists`},
+				}},
+			}
+			return nil
+		},
+	)
+	client := alerts.NewClient(apiCaller)
+	err := client.AddAlertRule(params.AlertRule{Name: "down", Kind: "machine-down"})
+	c.Assert(err, gc.ErrorMatches, `alert rule "down" already exists`)
+}
+
+func (s *AlertsSuite) TestRemoveAlertRules(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Alerts")
+			c.Check(request, gc.Equals, "RemoveAlertRules")
+			c.Check(a, jc.DeepEquals, params.AlertRuleNames{
+				Names: []string{"down", "nope"},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {
+					Error: &params.Error{Message: `alert rule "nope" not found`},
+				}},
+			}
+			return nil
+		},
+	)
+	client := alerts.NewClient(apiCaller)
+	err := client.RemoveAlertRules("down", "nope")
+	c.Assert(err, gc.ErrorMatches, `alert rule "nope" not found`)
+}
+
+func (s *AlertsSuite) TestListAlertRules(c *gc.C) {
+	rules := []params.AlertRule{{
+		Name:  "down",
+		Kind:  "machine-down",
+		For:   time.Minute,
+		Email: "ops@example.com",
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Alerts")
+			c.Check(request, gc.Equals, "ListAlertRules")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ListAlertRulesResults{})
+			*(result.(*params.ListAlertRulesResults)) = params.ListAlertRulesResults{
+				Rules: rules,
+			}
+			return nil
+		},
+	)
+	client := alerts.NewClient(apiCaller)
+	result, err := client.ListAlertRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, rules)
+}
+
+func (s *AlertsSuite) TestListAlerts(c *gc.C) {
+	since := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	alertList := []params.Alert{{
+		Rule:    "down",
+		Kind:    "machine-down",
+		Entity:  "machine-1",
+		Message: "agent is not communicating with the server",
+		Since:   since,
+		Raised:  since.Add(time.Minute),
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Alerts")
+			c.Check(request, gc.Equals, "ListAlerts")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ListAlertsResults{})
+			*(result.(*params.ListAlertsResults)) = params.ListAlertsResults{
+				Alerts: alertList,
+			}
+			return nil
+		},
+	)
+	client := alerts.NewClient(apiCaller)
+	result, err := client.ListAlerts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, alertList)
+}
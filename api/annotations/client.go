@@ -41,6 +41,25 @@ func (c *Client) Set(annotations map[string]map[string]string) ([]params.ErrorRe
 	return results.Results, nil
 }
 
+// Find returns the tags of the entities whose annotations include all
+// of the given key/value pairs. If kind is not empty, only entities
+// with tags of that kind are returned.
+func (c *Client) Find(kind string, annotations map[string]string) ([]string, error) {
+	args := params.AnnotationsFind{
+		Kind:        kind,
+		Annotations: annotations,
+	}
+	var result params.Entities
+	if err := c.facade.FacadeCall("Find", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tags := make([]string, len(result.Entities))
+	for i, entity := range result.Entities {
+		tags[i] = entity.Tag
+	}
+	return tags, nil
+}
+
 func entitiesFromTags(tags []string) params.Entities {
 	entities := []params.Entity{}
 	for _, tag := range tags {
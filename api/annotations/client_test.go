@@ -91,3 +91,30 @@ func (s *annotationsMockSuite) TestGetEntitiesAnnotations(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+func (s *annotationsMockSuite) TestFindEntities(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(
+			objType string,
+			version int,
+			id, request string,
+			a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Annotations")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Find")
+			c.Assert(a, jc.DeepEquals, params.AnnotationsFind{
+				Kind:        "machine",
+				Annotations: map[string]string{"env": "prod"},
+			})
+			result := response.(*params.Entities)
+			result.Entities = []params.Entity{{"machine-0"}, {"machine-2"}}
+			return nil
+		})
+	annotationsClient := annotations.NewClient(apiCaller)
+	found, err := annotationsClient.Find("machine", map[string]string{"env": "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(found, jc.DeepEquals, []string{"machine-0", "machine-2"})
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cert"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
@@ -125,6 +126,10 @@ type state struct {
 	// connections to the API endpoints.
 	tlsConfig *tls.Config
 
+	// dial, if non-nil, is used to establish connections to the
+	// API server instead of dialling it directly.
+	dial dialFunc
+
 	// certPool holds the cert pool that is used to authenticate the tls
 	// connections to the API.
 	certPool *x509.CertPool
@@ -132,6 +137,9 @@ type state struct {
 	// bakeryClient holds the client that will be used to
 	// authorize macaroon based login requests.
 	bakeryClient *httpbakery.Client
+
+	// callObserver, if set, is called after each API call.
+	callObserver CallObserver
 }
 
 // RedirectError is returned from Open when the controller
@@ -179,12 +187,20 @@ func open(
 	if clock == nil {
 		return nil, errors.NotValidf("nil clock")
 	}
+	dial, err := opts.dialer()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	conn, tlsConfig, err := connectWebsocket(info, opts)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	client := rpc.NewConn(jsoncodec.NewWebsocket(conn), observer.None())
+	if opts.CorrelationId != "" {
+		logger.Debugf("API requests correlated as %s", opts.CorrelationId)
+		client.SetCorrelationId(opts.CorrelationId)
+	}
 	client.Start()
 
 	bakeryClient := opts.BakeryClient
@@ -201,9 +217,13 @@ func open(
 	// Technically when there's no CACert, we don't need this
 	// machinery, because we could just use http.DefaultTransport
 	// for everything, but it's easier just to leave it in place.
+	primary := utils.NewHttpTLSTransport(tlsConfig)
+	if dial != nil {
+		primary.Dial = dial
+	}
 	bakeryClient.Client.Transport = &hostSwitchingTransport{
 		primaryHost: apiHost,
-		primary:     utils.NewHttpTLSTransport(tlsConfig),
+		primary:     primary,
 		fallback:    http.DefaultTransport,
 	}
 
@@ -228,8 +248,10 @@ func open(
 		macaroons:    info.Macaroons,
 		nonce:        info.Nonce,
 		tlsConfig:    tlsConfig,
+		dial:         dial,
 		bakeryClient: bakeryClient,
 		modelTag:     info.ModelTag,
+		callObserver: opts.CallObserver,
 	}
 	if !info.SkipLogin {
 		if err := loginFunc(st, info.Tag, info.Password, info.Nonce, info.Macaroons); err != nil {
@@ -292,14 +314,23 @@ func connectWebsocket(info *Info, opts DialOpts) (*websocket.Conn, *tls.Config,
 	tlsConfig := utils.SecureTLSConfig()
 	tlsConfig.InsecureSkipVerify = opts.InsecureSkipVerify
 
-	if info.CACert != "" && !tlsConfig.InsecureSkipVerify {
-		// We want to be specific here (rather than just using "anything".
-		// See commit 7fc118f015d8480dfad7831788e4b8c0432205e8 (PR 899).
-		tlsConfig.ServerName = "juju-apiserver"
-		certPool, err := CreateCertPool(info.CACert)
+	if (info.CACert != "" || len(opts.ExtraCACerts) > 0) && !tlsConfig.InsecureSkipVerify {
+		if info.CACert != "" {
+			// We want to be specific here (rather than just using "anything".
+			// See commit 7fc118f015d8480dfad7831788e4b8c0432205e8 (PR 899).
+			tlsConfig.ServerName = "juju-apiserver"
+		}
+		certPool, err := createRootCertPool(info.CACert)
 		if err != nil {
 			return nil, nil, errors.Annotate(err, "cert pool creation failed")
 		}
+		for _, caCert := range opts.ExtraCACerts {
+			xcert, err := cert.ParseCert(caCert)
+			if err != nil {
+				return nil, nil, errors.Annotate(err, "cannot parse extra CA certificate")
+			}
+			certPool.AddCert(xcert)
+		}
 		tlsConfig.RootCAs = certPool
 	}
 	path, err := apiPath(info.ModelTag, "/api")
@@ -398,9 +429,18 @@ func (st *state) connectStream(path string, attrs url.Values) (base.Stream, erro
 	st.addCookiesToHeader(cfg.Header)
 
 	cfg.TlsConfig = st.tlsConfig
-	connection, err := websocketDialConfig(cfg)
-	if err != nil {
-		return nil, err
+	var connection base.Stream
+	if st.dial != nil {
+		conn, err := dialWebsocketConfig(cfg, st.dial)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		connection = websocketStream{conn}
+	} else {
+		connection, err = websocketDialConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
 	}
 	if err := readInitialStreamError(connection); err != nil {
 		return nil, errors.Trace(err)
@@ -509,6 +549,10 @@ func createWebsocketDialer(cfg *websocket.Config, opts DialOpts) func(<-chan str
 		Delay: opts.RetryDelay,
 	}
 	return func(stop <-chan struct{}) (io.Closer, error) {
+		dial, err := opts.dialer()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		for a := openAttempt.Start(); a.Next(); {
 			select {
 			case <-stop:
@@ -516,7 +560,7 @@ func createWebsocketDialer(cfg *websocket.Config, opts DialOpts) func(<-chan str
 			default:
 			}
 			logger.Infof("dialing %q", cfg.Location)
-			conn, err := websocket.DialConfig(cfg)
+			conn, err := dialWebsocketConfig(cfg, dial)
 			if err == nil {
 				return conn, nil
 			}
@@ -625,6 +669,16 @@ func (s *state) APICall(facade string, version int, id, method string, args, res
 		Clock:       s.clock,
 	}
 	err := retry.Call(retrySpec)
+	if s.callObserver != nil {
+		s.callObserver(CallRecord{
+			ModelTag: s.modelTag,
+			Facade:   facade,
+			Version:  version,
+			Id:       id,
+			Method:   method,
+			Err:      err,
+		})
+	}
 	return errors.Trace(err)
 }
 
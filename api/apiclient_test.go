@@ -237,6 +237,38 @@ func (s *apiclientSuite) TestAPICallError(c *gc.C) {
 	c.Check(clock.waits, gc.HasLen, 0)
 }
 
+func (s *apiclientSuite) TestAPICallObserver(c *gc.C) {
+	var calls []api.CallRecord
+	conn := api.NewTestingState(api.TestingStateParams{
+		ModelTag: jtesting.ModelTag.String(),
+		RPCConnection: &fakeRPCConnection{
+			errors: []error{nil, errors.BadRequestf("boom")},
+		},
+		Clock: &fakeClock{},
+		CallObserver: func(call api.CallRecord) {
+			calls = append(calls, call)
+		},
+	})
+
+	err := conn.APICall("facade", 1, "id", "method", nil, nil)
+	c.Check(err, jc.ErrorIsNil)
+	err = conn.APICall("other", 2, "", "fail", nil, nil)
+	c.Check(err, gc.ErrorMatches, "boom")
+
+	c.Assert(calls, gc.HasLen, 2)
+	c.Check(calls[0], jc.DeepEquals, api.CallRecord{
+		ModelTag: jtesting.ModelTag,
+		Facade:   "facade",
+		Version:  1,
+		Id:       "id",
+		Method:   "method",
+	})
+	c.Check(calls[1].Facade, gc.Equals, "other")
+	c.Check(calls[1].Version, gc.Equals, 2)
+	c.Check(calls[1].Method, gc.Equals, "fail")
+	c.Check(calls[1].Err, gc.ErrorMatches, "boom")
+}
+
 func (s *apiclientSuite) TestAPICallRetries(c *gc.C) {
 	clock := &fakeClock{}
 	conn := api.NewTestingState(api.TestingStateParams{
@@ -8,6 +8,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -91,7 +93,27 @@ type DeployArgs struct {
 // it. Placement directives, if provided, specify the machine on which the charm
 // is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
-	deployArgs := params.ApplicationsDeploy{
+	var results params.ErrorResults
+	var err error
+	err = c.facade.FacadeCall("Deploy", deployParams(args), &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
+// ValidateDeploy checks whether the application described by args
+// could be deployed, without changing the model.
+func (c *Client) ValidateDeploy(args DeployArgs) error {
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ValidateDeploy", deployParams(args), &results); err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
+func deployParams(args DeployArgs) params.ApplicationsDeploy {
+	return params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			ApplicationName:  args.ApplicationName,
 			Series:           args.Series,
@@ -106,13 +128,6 @@ func (c *Client) Deploy(args DeployArgs) error {
 			Resources:        args.Resources,
 		}},
 	}
-	var results params.ErrorResults
-	var err error
-	err = c.facade.FacadeCall("Deploy", deployArgs, &results)
-	if err != nil {
-		return err
-	}
-	return results.OneError()
 }
 
 // GetCharmURL returns the charm URL the given service is
@@ -160,6 +175,34 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
+// SetCharms upgrades the charms of several applications together, so
+// that either every application is upgraded or none is. Each charm URL
+// must specify a revision. The results correspond to the supplied
+// configs; if any upgrade was refused, every result holds an error.
+func (c *Client) SetCharms(cfgs []SetCharmConfig) ([]params.ErrorResult, error) {
+	args := params.ApplicationsSetCharm{
+		Applications: make([]params.ApplicationSetCharm, len(cfgs)),
+	}
+	for i, cfg := range cfgs {
+		args.Applications[i] = params.ApplicationSetCharm{
+			ApplicationName: cfg.ApplicationName,
+			CharmUrl:        cfg.CharmID.URL.String(),
+			Channel:         string(cfg.CharmID.Channel),
+			ForceSeries:     cfg.ForceSeries,
+			ForceUnits:      cfg.ForceUnits,
+			ResourceIDs:     cfg.ResourceIDs,
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetCharms", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(cfgs) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(cfgs), n)
+	}
+	return results.Results, nil
+}
+
 // Update updates the application attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ApplicationUpdate) error {
@@ -181,7 +224,19 @@ func (c *Client) AddUnits(application string, numUnits int, placement []*instanc
 
 // DestroyUnits decreases the number of units dedicated to an application.
 func (c *Client) DestroyUnits(unitNames ...string) error {
-	params := params.DestroyApplicationUnits{unitNames}
+	params := params.DestroyApplicationUnits{UnitNames: unitNames}
+	return c.facade.FacadeCall("DestroyUnits", params, nil)
+}
+
+// ForceDestroyUnits removes the given units along with their subordinates
+// and storage attachments, even if the units are already dying, once their
+// agents have had maxWait to shut down cleanly.
+func (c *Client) ForceDestroyUnits(maxWait time.Duration, unitNames ...string) error {
+	params := params.DestroyApplicationUnits{
+		UnitNames: unitNames,
+		Force:     true,
+		MaxWait:   maxWait,
+	}
 	return c.facade.FacadeCall("DestroyUnits", params, nil)
 }
 
@@ -216,6 +271,21 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeEndpoints exposes the application, as Expose does, recording
+// the source CIDRs from which each of the given endpoints may be
+// reached. The empty endpoint name stands for all endpoints, and an
+// endpoint with no CIDRs may be reached from any address.
+func (c *Client) ExposeEndpoints(application string, exposed map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("exposing to source CIDRs")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposed,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -223,6 +293,16 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// SetHookSandbox changes the confinement in which the hooks and actions
+// of the application's units are run.
+func (c *Client) SetHookSandbox(application string, sandbox params.HookSandbox) error {
+	args := params.ApplicationSetHookSandbox{
+		ApplicationName: application,
+		Sandbox:         sandbox,
+	}
+	return c.facade.FacadeCall("SetHookSandbox", args, nil)
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
@@ -270,3 +350,21 @@ func (c *Client) DestroyRelation(endpoints ...string) error {
 	params := params.DestroyRelation{Endpoints: endpoints}
 	return c.facade.FacadeCall("DestroyRelation", params, nil)
 }
+
+// SetSubordinatePlacement sets the policy controlling which principal
+// units are given subordinate units by the relation between the
+// specified endpoints.
+func (c *Client) SetSubordinatePlacement(args params.SetSubordinatePlacement) error {
+	return c.facade.FacadeCall("SetSubordinatePlacement", args, nil)
+}
+
+// RelationScopes returns the lifecycle state of the relation between
+// the specified endpoints, and of the units in its scopes.
+func (c *Client) RelationScopes(endpoints ...string) (*params.RelationScopesResult, error) {
+	var result params.RelationScopesResult
+	args := params.RelationScopes{Endpoints: endpoints}
+	if err := c.facade.FacadeCall("RelationScopes", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
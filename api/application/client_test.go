@@ -4,11 +4,14 @@
 package application_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
@@ -76,6 +79,64 @@ func (s *serviceSuite) TestSetServiceMetricCredentialsNoMocks(c *gc.C) {
 	c.Assert(application.MetricCredentials(), gc.DeepEquals, []byte("creds"))
 }
 
+func (s *serviceSuite) TestForceDestroyUnits(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "DestroyUnits")
+		c.Assert(a, jc.DeepEquals, params.DestroyApplicationUnits{
+			UnitNames: []string{"mysql/0", "mysql/1"},
+			Force:     true,
+			MaxWait:   time.Minute,
+		})
+		return nil
+	})
+	err := s.client.ForceDestroyUnits(time.Minute, "mysql/0", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	exposed := map[string]params.ExposedEndpoint{
+		"www": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	}
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName:  "wordpress",
+			ExposedEndpoints: exposed,
+		})
+		return nil
+	})
+	err := s.client.ExposeEndpoints("wordpress", exposed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestExposeEndpointsNotSupported(c *gc.C) {
+	s.PatchValue(&s.client.ClientFacade, oldClientFacade{s.client.ClientFacade})
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := s.client.ExposeEndpoints("wordpress", map[string]params.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "exposing to source CIDRs not supported")
+}
+
+// oldClientFacade reports the version of the Application facade which
+// predates exposing to source CIDRs.
+type oldClientFacade struct {
+	base.ClientFacade
+}
+
+func (oldClientFacade) BestAPIVersion() int {
+	return 1
+}
+
 func (s *serviceSuite) TestSetServiceDeploy(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -119,6 +180,38 @@ func (s *serviceSuite) TestSetServiceDeploy(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestValidateDeploy(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ValidateDeploy")
+		args, ok := a.(params.ApplicationsDeploy)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.Applications, gc.HasLen, 1)
+		c.Assert(args.Applications[0].CharmUrl, gc.Equals, "cs:trusty/a-charm-1")
+		c.Assert(args.Applications[0].ApplicationName, gc.Equals, "serviceA")
+		c.Assert(args.Applications[0].Placement, gc.DeepEquals, []*instance.Placement{{"lxd", "0"}})
+
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{
+			Error: &params.Error{Message: "machine 0 not found"},
+		}}
+		return nil
+	})
+
+	args := application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		ApplicationName: "serviceA",
+		NumUnits:        1,
+		Placement:       []*instance.Placement{{"lxd", "0"}},
+	}
+	err := s.client.ValidateDeploy(args)
+	c.Assert(err, gc.ErrorMatches, "machine 0 not found")
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -163,3 +256,98 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestServiceSetCharms(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharms")
+		c.Assert(a, jc.DeepEquals, params.ApplicationsSetCharm{
+			Applications: []params.ApplicationSetCharm{{
+				ApplicationName: "application",
+				CharmUrl:        "cs:trusty/application-1",
+			}, {
+				ApplicationName: "other",
+				CharmUrl:        "cs:trusty/other-2",
+				ForceUnits:      true,
+			}},
+		})
+		result, ok := response.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		result.Results = []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	results, err := s.client.SetCharms([]application.SetCharmConfig{{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+	}, {
+		ApplicationName: "other",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/other-2"),
+		},
+		ForceUnits: true,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetHookSandbox(c *gc.C) {
+	var called bool
+	sandbox := params.HookSandbox{User: "hooks", CPUQuota: 50}
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetHookSandbox")
+		c.Assert(a, jc.DeepEquals, params.ApplicationSetHookSandbox{
+			ApplicationName: "wordpress",
+			Sandbox:         sandbox,
+		})
+		return nil
+	})
+	err := s.client.SetHookSandbox("wordpress", sandbox)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetSubordinatePlacement(c *gc.C) {
+	var called bool
+	args := params.SetSubordinatePlacement{
+		Endpoints:          []string{"wordpress", "logging"},
+		ExcludeControllers: true,
+		ExcludeMachines:    []string{"1"},
+	}
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetSubordinatePlacement")
+		c.Assert(a, jc.DeepEquals, args)
+		return nil
+	})
+	err := s.client.SetSubordinatePlacement(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestRelationScopes(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "RelationScopes")
+		c.Assert(a, jc.DeepEquals, params.RelationScopes{
+			Endpoints: []string{"wordpress", "mysql"},
+		})
+		result, ok := response.(*params.RelationScopesResult)
+		c.Assert(ok, jc.IsTrue)
+		result.Id = 1
+		result.Units = []params.RelationScopeUnit{{Unit: "mysql/0", Role: "provider"}}
+		return nil
+	})
+	result, err := s.client.RelationScopes("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, &params.RelationScopesResult{
+		Id:    1,
+		Units: []params.RelationScopeUnit{{Unit: "mysql/0", Role: "provider"}},
+	})
+}
@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -48,15 +49,7 @@ func (c *Client) SwitchBlockOn(blockType, msg string) error {
 		Type:    blockType,
 		Message: msg,
 	}
-	var result params.ErrorResult
-	if err := c.facade.FacadeCall("SwitchBlockOn", args, &result); err != nil {
-		return errors.Trace(err)
-	}
-	if result.Error != nil {
-		// cope with typed error
-		return errors.Trace(result.Error)
-	}
-	return nil
+	return c.switchBlock("SwitchBlockOn", args)
 }
 
 // SwitchBlockOff switches desired block off for the current model.
@@ -65,8 +58,35 @@ func (c *Client) SwitchBlockOff(blockType string) error {
 	args := params.BlockSwitchParams{
 		Type: blockType,
 	}
+	return c.switchBlock("SwitchBlockOff", args)
+}
+
+// SwitchEntityBlockOn switches desired block on for the application
+// or machine with the given tag only.
+// Valid block types are "BlockRemove" and "BlockChange".
+func (c *Client) SwitchEntityBlockOn(blockType string, tag names.Tag, msg string) error {
+	args := params.BlockSwitchParams{
+		Type:    blockType,
+		Message: msg,
+		Tag:     tag.String(),
+	}
+	return c.switchBlock("SwitchBlockOn", args)
+}
+
+// SwitchEntityBlockOff switches desired block off for the application
+// or machine with the given tag.
+// Valid block types are "BlockRemove" and "BlockChange".
+func (c *Client) SwitchEntityBlockOff(blockType string, tag names.Tag) error {
+	args := params.BlockSwitchParams{
+		Type: blockType,
+		Tag:  tag.String(),
+	}
+	return c.switchBlock("SwitchBlockOff", args)
+}
+
+func (c *Client) switchBlock(method string, args params.BlockSwitchParams) error {
 	var result params.ErrorResult
-	if err := c.facade.FacadeCall("SwitchBlockOff", args, &result); err != nil {
+	if err := c.facade.FacadeCall(method, args, &result); err != nil {
 		return errors.Trace(err)
 	}
 	if result.Error != nil {
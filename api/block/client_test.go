@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/block"
@@ -132,6 +133,60 @@ func (s *blockMockSuite) TestSwitchBlockOffError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, errmsg)
 }
 
+func (s *blockMockSuite) TestSwitchEntityBlockOn(c *gc.C) {
+	called := false
+	blockType := state.ChangeBlock.String()
+	tag := names.NewApplicationTag("mysql")
+	msg := "for test switch entity block on"
+
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Block")
+			c.Check(request, gc.Equals, "SwitchBlockOn")
+			c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+				Type:    blockType,
+				Message: msg,
+				Tag:     "application-mysql",
+			})
+			return nil
+		})
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOn(blockType, tag, msg)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockOff(c *gc.C) {
+	called := false
+	blockType := state.RemoveBlock.String()
+	tag := names.NewMachineTag("0")
+
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Block")
+			c.Check(request, gc.Equals, "SwitchBlockOff")
+			c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+				Type: blockType,
+				Tag:  "machine-0",
+			})
+			return nil
+		})
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOff(blockType, tag)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *blockMockSuite) TestList(c *gc.C) {
 	var called bool
 	one := params.BlockResult{
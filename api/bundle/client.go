@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundle provides access to the Bundle API facade, which
+// exports the contents of a model as a deployable bundle, and deploys
+// bundles on the controller.
+package bundle
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
+
+// Client allows access to the Bundle API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Bundle API.
+func NewClient(callCloser base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(callCloser, "Bundle")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ExportBundle returns a bundle, in YAML format, which deploys the
+// applications, machines and relations of the current model.
+func (c *Client) ExportBundle() (string, error) {
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// DeployArgs holds the arguments for DeployBundle.
+type DeployArgs struct {
+	// BundleDataYAML is the YAML-encoded bundle.
+	BundleDataYAML string
+
+	// Channel is the charm store channel charms are taken from.
+	Channel string
+
+	// Storage holds storage constraints for bundle applications,
+	// replacing or augmenting those in the bundle itself.
+	Storage map[string]map[string]storage.Constraints
+
+	// DryRun reports whether the bundle should only be planned and
+	// validated, without changing the model.
+	DryRun bool
+}
+
+// DeployResult describes a bundle deployment on the controller.
+type DeployResult struct {
+	// Plan describes the changes that deploy the bundle, in order.
+	Plan []string
+
+	// Events records the changes applied, in order.
+	Events []params.BundleDeployEvent
+}
+
+// DeployBundle deploys a bundle on the controller. If the deployment
+// fails, the events recording the changes made before it failed,
+// which have since been undone, are returned along with the error.
+func (c *Client) DeployBundle(args DeployArgs) (DeployResult, error) {
+	var result params.DeployBundleResult
+	err := c.facade.FacadeCall("DeployBundle", params.DeployBundleArgs{
+		BundleDataYAML: args.BundleDataYAML,
+		Channel:        args.Channel,
+		Storage:        args.Storage,
+		DryRun:         args.DryRun,
+	}, &result)
+	if err != nil {
+		return DeployResult{}, errors.Trace(err)
+	}
+	if len(result.Errors) > 0 {
+		return DeployResult{}, errors.New("the provided bundle has the following errors:\n" + strings.Join(result.Errors, "\n"))
+	}
+	deployResult := DeployResult{
+		Plan:   result.Plan,
+		Events: result.Events,
+	}
+	if result.Error != nil {
+		return deployResult, errors.Trace(result.Error)
+	}
+	return deployResult, nil
+}
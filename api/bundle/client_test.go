@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ClientSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestExportBundle(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		c.Check(id, gc.Equals, "")
+		*result.(*params.StringResult) = params.StringResult{Result: "series: xenial\n"}
+		return nil
+	})
+	client := bundle.NewClient(apiCaller)
+
+	result, err := client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, "series: xenial\n")
+	stub.CheckCalls(c, []jujutesting.StubCall{{"Bundle.ExportBundle", []interface{}{nil}}})
+}
+
+func (s *ClientSuite) TestExportBundleError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := bundle.NewClient(apiCaller)
+
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestExportBundleResultError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*result.(*params.StringResult) = params.StringResult{
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}
+		return nil
+	})
+	client := bundle.NewClient(apiCaller)
+
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ClientSuite) TestDeployBundle(c *gc.C) {
+	var stub jujutesting.Stub
+	event := params.BundleDeployEvent{Change: "addCharm-0", Message: "added charm cs:xenial/mysql-42"}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		*result.(*params.DeployBundleResult) = params.DeployBundleResult{
+			Plan:   []string{"add charm cs:xenial/mysql-42"},
+			Events: []params.BundleDeployEvent{event},
+		}
+		return nil
+	})
+	client := bundle.NewClient(apiCaller)
+
+	result, err := client.DeployBundle(bundle.DeployArgs{
+		BundleDataYAML: "applications: {}",
+		Channel:        "stable",
+		DryRun:         true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, bundle.DeployResult{
+		Plan:   []string{"add charm cs:xenial/mysql-42"},
+		Events: []params.BundleDeployEvent{event},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{{"Bundle.DeployBundle", []interface{}{params.DeployBundleArgs{
+		BundleDataYAML: "applications: {}",
+		Channel:        "stable",
+		DryRun:         true,
+	}}}})
+}
+
+func (s *ClientSuite) TestDeployBundleVerificationErrors(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*result.(*params.DeployBundleResult) = params.DeployBundleResult{
+			Errors: []string{"bad constraints", "bad storage"},
+		}
+		return nil
+	})
+	client := bundle.NewClient(apiCaller)
+
+	_, err := client.DeployBundle(bundle.DeployArgs{})
+	c.Assert(err, gc.ErrorMatches, "the provided bundle has the following errors:\nbad constraints\nbad storage")
+}
+
+func (s *ClientSuite) TestDeployBundleResultError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*result.(*params.DeployBundleResult) = params.DeployBundleResult{
+			Events: []params.BundleDeployEvent{{Message: "application mysql deployed"}},
+			Error:  &params.Error{Message: "not supported", Code: params.CodeNotSupported},
+		}
+		return nil
+	})
+	client := bundle.NewClient(apiCaller)
+
+	result, err := client.DeployBundle(bundle.DeployArgs{})
+	c.Assert(err, gc.ErrorMatches, "not supported")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotSupported)
+	c.Assert(result.Events, gc.HasLen, 1)
+}
@@ -40,6 +40,26 @@ func CreateCertPool(caCert string) (*x509.CertPool, error) {
 	return pool, nil
 }
 
+// createRootCertPool returns the pool of certificates used to verify
+// the API server. Without a CA certificate of the controller's own,
+// the server's certificate may be signed by a well-known CA, so the
+// pool starts from the system's certificates rather than being empty.
+func createRootCertPool(caCert string) (*x509.CertPool, error) {
+	if caCert != "" {
+		return CreateCertPool(caCert)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		logger.Debugf("cannot load system cert pool: %v", err)
+		pool = x509.NewCertPool()
+	}
+	count := processCertDir(pool)
+	if count >= 0 {
+		logger.Debugf("added %d certs to the pool from %s", count, certDir)
+	}
+	return pool, nil
+}
+
 // processCertDir iterates through the certDir looking for *.pem files.
 // Each pem file is read in turn and added to the pool.  A count of the number
 // of successful certificates processed is returned.
@@ -4,6 +4,7 @@
 package api_test
 
 import (
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
@@ -45,6 +46,24 @@ func (*certPoolSuite) TestCreateCertPoolTestCert(c *gc.C) {
 	c.Assert(pool.Subjects(), gc.HasLen, 1)
 }
 
+func (s *certPoolSuite) TestCreateRootCertPoolNoCertUsesSystemPool(c *gc.C) {
+	s.PatchValue(api.CertDir, c.MkDir())
+	system, err := x509.SystemCertPool()
+	if err != nil {
+		c.Skip("no system cert pool: " + err.Error())
+	}
+	pool, err := api.CreateRootCertPool("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Subjects(), jc.DeepEquals, system.Subjects())
+}
+
+func (s *certPoolSuite) TestCreateRootCertPoolTestCert(c *gc.C) {
+	s.PatchValue(api.CertDir, c.MkDir())
+	pool, err := api.CreateRootCertPool(testing.CACert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Subjects(), gc.HasLen, 1)
+}
+
 func (s *certPoolSuite) TestCreateCertPoolNoDir(c *gc.C) {
 	certDir := filepath.Join(c.MkDir(), "missing")
 	s.PatchValue(api.CertDir, certDir)
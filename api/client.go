@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils"
 	"github.com/juju/version"
 	"golang.org/x/net/websocket"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -170,6 +171,14 @@ func (c *Client) ForceDestroyMachines(machines ...string) error {
 	return c.facade.FacadeCall("DestroyMachines", params, nil)
 }
 
+// ForceDestroyMachinesAfter removes a given set of machines and all
+// associated units, once the units' agents have had maxWait to shut
+// down cleanly.
+func (c *Client) ForceDestroyMachinesAfter(maxWait time.Duration, machines ...string) error {
+	params := params.DestroyMachines{Force: true, MaxWait: maxWait, MachineNames: machines}
+	return c.facade.FacadeCall("DestroyMachines", params, nil)
+}
+
 // GetModelConstraints returns the constraints for the model.
 func (c *Client) GetModelConstraints() (constraints.Value, error) {
 	results := new(params.GetConstraintsResults)
@@ -245,6 +254,36 @@ func (c *Client) SetModelAgentVersion(version version.Number) error {
 	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
 }
 
+// StartCanaryUpgrade starts upgrading the model to the given version,
+// beginning with the given canary machines. The rest of the model is
+// upgraded once the canaries have been healthy for the soak period,
+// either automatically or when ProceedCanaryUpgrade is called.
+func (c *Client) StartCanaryUpgrade(version version.Number, canary params.CanaryUpgradeParams) error {
+	args := params.SetModelAgentVersion{
+		Version: version,
+		Canary:  &canary,
+	}
+	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
+}
+
+// CanaryUpgrade returns the canary upgrade in progress in the model.
+func (c *Client) CanaryUpgrade() (params.CanaryUpgradeStatus, error) {
+	var result params.CanaryUpgradeStatus
+	err := c.facade.FacadeCall("CanaryUpgrade", nil, &result)
+	return result, err
+}
+
+// ProceedCanaryUpgrade upgrades the rest of the model to the target
+// version of the canary upgrade in progress.
+func (c *Client) ProceedCanaryUpgrade() error {
+	return c.facade.FacadeCall("ProceedCanaryUpgrade", nil, nil)
+}
+
+// AbortCanaryUpgrade abandons the canary upgrade in progress.
+func (c *Client) AbortCanaryUpgrade() error {
+	return c.facade.FacadeCall("AbortCanaryUpgrade", nil, nil)
+}
+
 // AbortCurrentUpgrade aborts and archives the current upgrade
 // synchronisation record, if any.
 func (c *Client) AbortCurrentUpgrade() error {
@@ -263,10 +302,26 @@ func (c *Client) FindTools(majorVersion, minorVersion int, series, arch string)
 	return result, err
 }
 
+// charmUploadAttempt defines how an interrupted charm upload is
+// resumed.
+var charmUploadAttempt = utils.AttemptStrategy{
+	Total: time.Minute,
+	Delay: 5 * time.Second,
+}
+
 // AddLocalCharm prepares the given charm with a local: schema in its
 // URL, and uploads it via the API server, returning the assigned
 // charm URL.
 func (c *Client) AddLocalCharm(curl *charm.URL, ch charm.Charm) (*charm.URL, error) {
+	return c.AddLocalCharmWithProgress(curl, ch, nil)
+}
+
+// AddLocalCharmWithProgress is like AddLocalCharm, but also calls the
+// given function, if not nil, with the number of bytes sent so far and
+// the size of the charm archive as the archive is uploaded.
+func (c *Client) AddLocalCharmWithProgress(
+	curl *charm.URL, ch charm.Charm, progress func(sent, total int64),
+) (*charm.URL, error) {
 	if curl.Schema != "local" {
 		return nil, errors.Errorf("expected charm URL with local: schema, got %q", curl.String())
 	}
@@ -301,13 +356,119 @@ func (c *Client) AddLocalCharm(curl *charm.URL, ch charm.Charm) (*charm.URL, err
 		return nil, errors.Errorf("unknown charm type %T", ch)
 	}
 
-	curl, err := c.UploadCharm(curl, archive)
+	curl, err := c.uploadCharmArchive(curl, archive, progress)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return curl, nil
 }
 
+// uploadCharmArchive sends the charm archive to the API server as a
+// resumable upload. If the connection to the API server fails part way
+// through, the upload is resumed from where the API server left off.
+func (c *Client) uploadCharmArchive(
+	curl *charm.URL, archive *os.File, progress func(sent, total int64),
+) (*charm.URL, error) {
+	hash, size, err := utils.ReadSHA256(archive)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm archive")
+	}
+	args := url.Values{}
+	args.Add("series", curl.Series)
+	args.Add("schema", curl.Schema)
+	args.Add("revision", strconv.Itoa(curl.Revision))
+	args.Add("sha256", hash)
+	apiURI := url.URL{Path: "/charms", RawQuery: args.Encode()}
+
+	var offset int64
+	for a := charmUploadAttempt.Start(); a.Next(); {
+		if err != nil {
+			logger.Infof("resuming charm upload after error: %v", err)
+			if offset, err = c.charmUploadOffset(hash); err != nil {
+				return nil, errors.Annotate(err, "cannot resume charm upload")
+			}
+		}
+		var content io.ReadSeeker = io.NewSectionReader(archive, offset, size-offset)
+		if progress != nil {
+			content = &progressReader{
+				ReadSeeker: content,
+				offset:     offset,
+				total:      size,
+				progress:   progress,
+			}
+		}
+		header := make(http.Header)
+		header.Set(params.UploadOffsetHeader, strconv.FormatInt(offset, 10))
+		header.Set(params.UploadLengthHeader, strconv.FormatInt(size, 10))
+		var resp params.CharmsResponse
+		err = c.httpPostWithHeader(content, apiURI.String(), "application/zip", header, &resp)
+		if err == nil {
+			curl, err := charm.ParseURL(resp.CharmURL)
+			if err != nil {
+				return nil, errors.Annotatef(err, "bad charm URL in response")
+			}
+			return curl, nil
+		}
+		if _, ok := errors.Cause(err).(*params.Error); ok {
+			// The API server rejected the upload, so there
+			// is no point in resuming it.
+			return nil, errors.Trace(err)
+		}
+	}
+	return nil, errors.Trace(err)
+}
+
+// charmUploadOffset returns the number of bytes of the charm archive
+// with the given SHA256 hash received so far by the API server.
+func (c *Client) charmUploadOffset(hash string) (int64, error) {
+	args := url.Values{}
+	args.Add("upload", hash)
+	apiURI := url.URL{Path: "/charms", RawQuery: args.Encode()}
+	req, err := http.NewRequest("GET", apiURI.String(), nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	httpClient, err := c.st.HTTPClient()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var status params.UploadStatus
+	if err := httpClient.Do(req, nil, &status); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return status.Offset, nil
+}
+
+// progressReader wraps an io.ReadSeeker reading content from the given
+// offset, reporting the number of bytes of the content read so far as
+// the content is read.
+type progressReader struct {
+	io.ReadSeeker
+	offset   int64
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+// Read is part of the io.Reader interface.
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadSeeker.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.progress(r.offset+r.sent, r.total)
+	}
+	return n, err
+}
+
+// Seek is part of the io.Seeker interface.
+func (r *progressReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.ReadSeeker.Seek(offset, whence)
+	if err == nil {
+		r.sent = pos
+	}
+	return pos, err
+}
+
 // UploadCharm sends the content to the API server using an HTTP post.
 func (c *Client) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
 	args := url.Values{}
@@ -473,10 +634,17 @@ func (c *Client) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSer
 }
 
 func (c *Client) httpPost(content io.ReadSeeker, endpoint, contentType string, response interface{}) error {
+	return c.httpPostWithHeader(content, endpoint, contentType, nil, response)
+}
+
+func (c *Client) httpPostWithHeader(content io.ReadSeeker, endpoint, contentType string, header http.Header, response interface{}) error {
 	req, err := http.NewRequest("POST", endpoint, nil)
 	if err != nil {
 		return errors.Annotate(err, "cannot create upload request")
 	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
 	req.Header.Set("Content-Type", contentType)
 
 	// The returned httpClient sets the base url to /model/<uuid> if it can.
@@ -560,6 +728,17 @@ type DebugLogParams struct {
 	// starting filtering. If backlog is zero and replay is false, then there
 	// may be an initial delay until the next matching log message is written.
 	Backlog uint
+	// Offset tells the server to skip over this many of the most recent
+	// matching lines, so that the backlog may be paged through. If
+	// Offset is non-zero, the server does not wait for new lines.
+	Offset uint
+	// StartTime, if non-zero, tells the server to only send lines logged
+	// at or after the given time.
+	StartTime time.Time
+	// EndTime, if non-zero, tells the server to only send lines logged
+	// before the given time. If EndTime is non-zero, the server does
+	// not wait for new lines.
+	EndTime time.Time
 	// Level specifies the minimum logging level to be sent back in the response.
 	Level loggo.Level
 	// Replay tells the server to start at the start of the log file rather
@@ -589,6 +768,15 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if args.Backlog > 0 {
 		attrs.Set("backlog", fmt.Sprint(args.Backlog))
 	}
+	if args.Offset > 0 {
+		attrs.Set("offset", fmt.Sprint(args.Offset))
+	}
+	if !args.StartTime.IsZero() {
+		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
+	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
@@ -11,13 +11,16 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	"github.com/juju/version"
 	"golang.org/x/net/websocket"
 	gc "gopkg.in/check.v1"
@@ -117,6 +120,25 @@ func (s *clientSuite) TestAddLocalCharm(c *gc.C) {
 	c.Assert(savedURL.String(), gc.Equals, curl.WithRevision(43).String())
 }
 
+func (s *clientSuite) TestAddLocalCharmWithProgress(c *gc.C) {
+	charmArchive := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	curl := charm.MustParseURL(
+		fmt.Sprintf("local:quantal/%s-%d", charmArchive.Meta().Name, charmArchive.Revision()),
+	)
+	info, err := os.Stat(charmArchive.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.APIState.Client()
+
+	var lastSent, lastTotal int64
+	savedURL, err := client.AddLocalCharmWithProgress(curl, charmArchive, func(sent, total int64) {
+		lastSent, lastTotal = sent, total
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(savedURL.String(), gc.Equals, curl.String())
+	c.Assert(lastTotal, gc.Equals, info.Size())
+	c.Assert(lastSent, gc.Equals, info.Size())
+}
+
 func (s *clientSuite) TestAddLocalCharmOtherEnvironment(c *gc.C) {
 	charmArchive := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	curl := charm.MustParseURL(
@@ -170,6 +192,54 @@ func (s *clientSuite) TestAddLocalCharmError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `POST http://.+: the POST method is not allowed`)
 }
 
+func (s *clientSuite) TestAddLocalCharmResumesUpload(c *gc.C) {
+	s.PatchValue(api.CharmUploadAttempt, utils.AttemptStrategy{Min: 3})
+	client := s.APIState.Client()
+	charmArchive := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	curl := charm.MustParseURL(
+		fmt.Sprintf("local:quantal/%s-%d", charmArchive.Meta().Name, charmArchive.Revision()),
+	)
+	data, err := ioutil.ReadFile(charmArchive.Path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var received []byte
+	var offsets []string
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer lis.Close()
+	mux := http.NewServeMux()
+	mux.HandleFunc(envEndpoint(c, s.APIState, "charms"), func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			c.Check(r.URL.Query().Get("upload"), gc.Not(gc.Equals), "")
+			httprequest.WriteJSON(w, http.StatusOK, &params.UploadStatus{Offset: int64(len(received))})
+		case "POST":
+			offsets = append(offsets, r.Header.Get(params.UploadOffsetHeader))
+			body, err := ioutil.ReadAll(r.Body)
+			c.Check(err, jc.ErrorIsNil)
+			if len(received) == 0 {
+				// Keep half of the archive, and drop the
+				// connection as if it had failed.
+				received = body[:len(body)/2]
+				conn, _, err := w.(http.Hijacker).Hijack()
+				c.Check(err, jc.ErrorIsNil)
+				conn.Close()
+				return
+			}
+			received = append(received, body...)
+			httprequest.WriteJSON(w, http.StatusOK, &params.CharmsResponse{CharmURL: curl.String()})
+		}
+	})
+	go http.Serve(lis, mux)
+	api.SetServerAddress(client, "http", lis.Addr().String())
+
+	savedURL, err := client.AddLocalCharm(curl, charmArchive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(savedURL.String(), gc.Equals, curl.String())
+	c.Assert(received, jc.DeepEquals, data)
+	c.Assert(offsets, jc.DeepEquals, []string{"0", fmt.Sprint(len(data) / 2)})
+}
+
 func (s *clientSuite) TestMinVersionLocalCharm(c *gc.C) {
 	tests := []minverTest{
 		{"2.0.0", "1.0.0", true},
@@ -415,6 +485,9 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		ExcludeModule: []string{"g", "h"},
 		Limit:         100,
 		Backlog:       200,
+		Offset:        300,
+		StartTime:     time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC),
+		EndTime:       time.Date(2016, 8, 1, 11, 0, 0, 5e8, time.UTC),
 		Level:         loggo.ERROR,
 		Replay:        true,
 		NoTail:        true,
@@ -433,6 +506,9 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		"excludeModule": params.ExcludeModule,
 		"maxLines":      {"100"},
 		"backlog":       {"200"},
+		"offset":        {"300"},
+		"startTime":     {"2016-08-01T10:00:00Z"},
+		"endTime":       {"2016-08-01T11:00:00.5Z"},
 		"level":         {"ERROR"},
 		"replay":        {"true"},
 		"noTail":        {"true"},
@@ -519,6 +595,89 @@ func (s *clientSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Assert(err, gc.Equals, someErr) // Confirms that the correct facade was called
 }
 
+func (s *clientSuite) TestStartCanaryUpgrade(c *gc.C) {
+	client := s.APIState.Client()
+	someErr := errors.New("random")
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetModelAgentVersion")
+			c.Assert(args, jc.DeepEquals, params.SetModelAgentVersion{
+				Version: version.MustParse("9.8.7"),
+				Canary: &params.CanaryUpgradeParams{
+					Machines:    []string{"1", "2"},
+					SoakPeriod:  time.Hour,
+					AutoProceed: true,
+				},
+			})
+			c.Assert(response, gc.IsNil)
+			return someErr
+		},
+	)
+	defer cleanup()
+
+	err := client.StartCanaryUpgrade(version.MustParse("9.8.7"), params.CanaryUpgradeParams{
+		Machines:    []string{"1", "2"},
+		SoakPeriod:  time.Hour,
+		AutoProceed: true,
+	})
+	c.Assert(err, gc.Equals, someErr)
+}
+
+func (s *clientSuite) TestCanaryUpgrade(c *gc.C) {
+	client := s.APIState.Client()
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "CanaryUpgrade")
+			c.Assert(args, gc.IsNil)
+			if result, ok := response.(*params.CanaryUpgradeStatus); ok {
+				result.Machines = []string{"1"}
+			} else {
+				c.Fatalf("wrong response type %T", response)
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := client.CanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Machines, jc.DeepEquals, []string{"1"})
+}
+
+func (s *clientSuite) TestProceedCanaryUpgrade(c *gc.C) {
+	client := s.APIState.Client()
+	someErr := errors.New("random")
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "ProceedCanaryUpgrade")
+			c.Assert(args, gc.IsNil)
+			c.Assert(response, gc.IsNil)
+			return someErr
+		},
+	)
+	defer cleanup()
+
+	err := client.ProceedCanaryUpgrade()
+	c.Assert(err, gc.Equals, someErr)
+}
+
+func (s *clientSuite) TestAbortCanaryUpgrade(c *gc.C) {
+	client := s.APIState.Client()
+	someErr := errors.New("random")
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "AbortCanaryUpgrade")
+			c.Assert(args, gc.IsNil)
+			c.Assert(response, gc.IsNil)
+			return someErr
+		},
+	)
+	defer cleanup()
+
+	err := client.AbortCanaryUpgrade()
+	c.Assert(err, gc.Equals, someErr)
+}
+
 // badReader raises err when Read is called.
 type badReader struct {
 	err error
@@ -63,6 +63,39 @@ func (c *Client) Cloud(tag names.CloudTag) (jujucloud.Cloud, error) {
 	}, nil
 }
 
+// AddCloud adds a cloud definition with the given name to the
+// controller, so that models may be created in it.
+func (c *Client) AddCloud(name string, cloud jujucloud.Cloud) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.New("adding clouds not supported by the controller")
+	}
+	authTypes := make([]string, len(cloud.AuthTypes))
+	for i, authType := range cloud.AuthTypes {
+		authTypes[i] = string(authType)
+	}
+	regions := make([]params.CloudRegion, len(cloud.Regions))
+	for i, region := range cloud.Regions {
+		regions[i] = params.CloudRegion{
+			Name:             region.Name,
+			Endpoint:         region.Endpoint,
+			IdentityEndpoint: region.IdentityEndpoint,
+			StorageEndpoint:  region.StorageEndpoint,
+		}
+	}
+	args := params.AddCloudArgs{
+		Name: name,
+		Cloud: params.Cloud{
+			Type:             cloud.Type,
+			AuthTypes:        authTypes,
+			Endpoint:         cloud.Endpoint,
+			IdentityEndpoint: cloud.IdentityEndpoint,
+			StorageEndpoint:  cloud.StorageEndpoint,
+			Regions:          regions,
+		},
+	}
+	return errors.Trace(c.facade.FacadeCall("AddCloud", args, nil))
+}
+
 // DefaultCloud returns the tag of the cloud that models will be
 // created in by default.
 func (c *Client) DefaultCloud() (names.CloudTag, error) {
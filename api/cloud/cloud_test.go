@@ -155,3 +155,67 @@ func (s *cloudSuite) TestUpdateCredentials(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *cloudSuite) TestAddCloud(c *gc.C) {
+	var called bool
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Cloud")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AddCloud")
+			c.Check(a, jc.DeepEquals, params.AddCloudArgs{
+				Name: "foo",
+				Cloud: params.Cloud{
+					Type:      "dummy",
+					AuthTypes: []string{"userpass"},
+					Regions:   []params.CloudRegion{{Name: "nether", Endpoint: "endpoint"}},
+				},
+			})
+			called = true
+			return nil
+		},
+		version: 2,
+	}
+
+	client := cloudapi.NewClient(apiCaller)
+	err := client.AddCloud("foo", cloud.Cloud{
+		Type:      "dummy",
+		AuthTypes: []cloud.AuthType{cloud.UserPassAuthType},
+		Regions:   []cloud.Region{{Name: "nether", Endpoint: "endpoint"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestAddCloudNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call %q", request)
+			return nil
+		},
+	)
+
+	client := cloudapi.NewClient(apiCaller)
+	err := client.AddCloud("foo", cloud.Cloud{Type: "dummy"})
+	c.Assert(err, gc.ErrorMatches, "adding clouds not supported by the controller")
+}
+
+// versionedAPICaller is an APICallerFunc reporting a specific
+// facade version.
+type versionedAPICaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (v versionedAPICaller) BestFacadeVersion(facade string) int {
+	return v.version
+}
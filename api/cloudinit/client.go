@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the Juju client command uses to get and
+// set the extra cloud-init directives applied when provisioning the
+// machines of a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "CloudInit")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// CloudInitCustomization returns the cloud-init customization set for
+// the model or machine with the given tag. For a machine, the result
+// also holds the customization applied when it is provisioned.
+func (c *Client) CloudInitCustomization(tag names.Tag) (params.CloudInitCustomizationResult, error) {
+	var results params.CloudInitCustomizationResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := c.facade.FacadeCall("CloudInitCustomizations", args, &results); err != nil {
+		return params.CloudInitCustomizationResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.CloudInitCustomizationResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.CloudInitCustomizationResult{}, errors.Trace(result.Error)
+	}
+	return result, nil
+}
+
+// SetCloudInitCustomization replaces the cloud-init customization of
+// the model or machine with the given tag.
+func (c *Client) SetCloudInitCustomization(tag names.Tag, custom params.CloudInitCustomization) error {
+	var results params.ErrorResults
+	args := params.CloudInitCustomizationArgs{
+		Args: []params.CloudInitCustomizationArg{{
+			Tag:           tag.String(),
+			Customization: custom,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetCloudInitCustomizations", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
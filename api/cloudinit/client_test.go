@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/cloudinit"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type CloudInitSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&CloudInitSuite{})
+
+func (s *CloudInitSuite) TestCloudInitCustomization(c *gc.C) {
+	expected := params.CloudInitCustomizationResult{
+		Customization: params.CloudInitCustomization{
+			Packages: []string{"htop"},
+		},
+		Effective: &params.CloudInitCustomization{
+			Packages:  []string{"htop"},
+			AptMirror: "http://mirror.example.com/ubuntu",
+		},
+	}
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "CloudInit")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CloudInitCustomizations")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.CloudInitCustomizationResults{})
+			*(result.(*params.CloudInitCustomizationResults)) = params.CloudInitCustomizationResults{
+				Results: []params.CloudInitCustomizationResult{expected},
+			}
+			called = true
+			return nil
+		},
+	)
+	client := cloudinit.NewClient(apiCaller)
+	result, err := client.CloudInitCustomization(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *CloudInitSuite) TestSetCloudInitCustomization(c *gc.C) {
+	custom := params.CloudInitCustomization{
+		RunCmds: []string{"echo hello"},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "CloudInit")
+			c.Check(request, gc.Equals, "SetCloudInitCustomizations")
+			c.Check(a, jc.DeepEquals, params.CloudInitCustomizationArgs{
+				Args: []params.CloudInitCustomizationArg{{
+					Tag:           "machine-0",
+					Customization: custom,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "machine 0 not found"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := cloudinit.NewClient(apiCaller)
+	err := client.SetCloudInitCustomization(names.NewMachineTag("0"), custom)
+	c.Assert(err, gc.ErrorMatches, "machine 0 not found")
+}
@@ -0,0 +1,31 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// InMaintenance requests whether the given entity is in maintenance
+// mode from the given server-side API facade via the given caller.
+func InMaintenance(caller base.FacadeCaller, tag names.Tag) (bool, error) {
+	var result params.BoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := caller.FacadeCall("InMaintenance", args, &result); err != nil {
+		return false, err
+	}
+	if len(result.Results) != 1 {
+		return false, errors.Errorf("expected 1 result, got %d", len(result.Results))
+	}
+	if err := result.Results[0].Error; err != nil {
+		return false, err
+	}
+	return result.Results[0].Result, nil
+}
@@ -4,6 +4,8 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 )
 
 // Client provides methods that the Juju client command uses to interact
@@ -249,3 +252,42 @@ func (c *Client) InitiateModelMigration(spec ModelMigrationSpec) (string, error)
 	}
 	return result.MigrationId, nil
 }
+
+// MigrationStatus reports the progress of a model migration.
+type MigrationStatus struct {
+	MigrationId      string
+	Phase            migration.Phase
+	StatusMessage    string
+	StartTime        time.Time
+	PhaseChangedTime time.Time
+}
+
+// ModelMigrationStatus returns the progress of the latest migration
+// of the model with the given UUID.
+func (c *Client) ModelMigrationStatus(modelUUID string) (MigrationStatus, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.ModelMigrationStatusResults
+	if err := c.facade.FacadeCall("ModelMigrationStatus", args, &results); err != nil {
+		return MigrationStatus{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return MigrationStatus{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return MigrationStatus{}, errors.Trace(result.Error)
+	}
+	phase, ok := migration.ParsePhase(result.Status.Phase)
+	if !ok {
+		return MigrationStatus{}, errors.Errorf("unknown migration phase %q", result.Status.Phase)
+	}
+	return MigrationStatus{
+		MigrationId:      result.Status.MigrationId,
+		Phase:            phase,
+		StatusMessage:    result.Status.StatusMessage,
+		StartTime:        result.Status.StartTime,
+		PhaseChangedTime: result.Status.PhaseChangedTime,
+	}, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/observer/fakeobserver"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -180,6 +182,7 @@ func (s *controllerSuite) TestAPIServerCanShutdownWithOutstandingNext(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	srv, err := apiserver.NewServer(s.State, lis, apiserver.ServerConfig{
+		Clock:       clock.WallClock,
 		Cert:        []byte(testing.ServerCert),
 		Key:         []byte(testing.ServerKey),
 		Tag:         names.NewMachineTag("0"),
@@ -307,6 +310,39 @@ func (s *controllerSuite) TestInitiateModelMigrationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestModelMigrationStatus(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	sysManager := s.OpenAPI(c)
+	defer sysManager.Close()
+	id, err := sysManager.InitiateModelMigration(controller.ModelMigrationSpec{
+		ModelUUID:            st.ModelUUID(),
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := sysManager.ModelMigrationStatus(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.MigrationId, gc.Equals, id)
+	c.Check(status.Phase, gc.Equals, migration.QUIESCE)
+	c.Check(status.StartTime.IsZero(), jc.IsFalse)
+}
+
+func (s *controllerSuite) TestModelMigrationStatusNoMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	sysManager := s.OpenAPI(c)
+	defer sysManager.Close()
+	_, err := sysManager.ModelMigrationStatus(st.ModelUUID())
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
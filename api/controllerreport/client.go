@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerreport
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the Juju client command uses to assess
+// a controller's resource usage.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ControllerReport")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Report returns the controller's resource usage, with the soft limit
+// configured for each resource.
+func (c *Client) Report() ([]params.ControllerResource, error) {
+	var result params.ControllerReport
+	if err := c.facade.FacadeCall("Report", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Resources, nil
+}
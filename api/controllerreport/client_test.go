@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerreport_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controllerreport"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ControllerReportSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ControllerReportSuite{})
+
+func (s *ControllerReportSuite) TestReport(c *gc.C) {
+	resources := []params.ControllerResource{
+		{Name: "models", Usage: 3, Limit: 10},
+		{Name: "log-rate", Usage: 2.5},
+	}
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ControllerReport")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Report")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ControllerReport{})
+			*(result.(*params.ControllerReport)) = params.ControllerReport{
+				Resources: resources,
+			}
+			called = true
+			return nil
+		},
+	)
+	client := controllerreport.NewClient(apiCaller)
+	result, err := client.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, resources)
+}
+
+func (s *ControllerReportSuite) TestReportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		},
+	)
+	client := controllerreport.NewClient(apiCaller)
+	_, err := client.Report()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
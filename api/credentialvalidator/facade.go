@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// NewWatcherFunc exists to let us unit test Facade without patching.
+type NewWatcherFunc func(base.APICaller, params.NotifyWatchResult) watcher.NotifyWatcher
+
+// ModelCredential describes the cloud credential used by a model.
+type ModelCredential struct {
+	// CloudCredential is the tag of the model's cloud credential. It
+	// is only meaningful if Exists is true.
+	CloudCredential names.CloudCredentialTag
+
+	// Exists is false if the model does not use a cloud credential.
+	Exists bool
+
+	// Valid is false if the credential has been reported as invalid.
+	Valid bool
+}
+
+// NewFacade returns a Facade backed by the supplied api caller.
+func NewFacade(apiCaller base.APICaller, newWatcher NewWatcherFunc) *Facade {
+	facadeCaller := base.NewFacadeCaller(apiCaller, "CredentialValidator")
+	return &Facade{
+		caller:     facadeCaller,
+		newWatcher: newWatcher,
+	}
+}
+
+// Facade lets a model's workers check, watch and invalidate the
+// model's cloud credential.
+type Facade struct {
+	caller     base.FacadeCaller
+	newWatcher NewWatcherFunc
+}
+
+// ModelCredential returns the model's cloud credential, and whether it
+// is currently valid.
+func (facade *Facade) ModelCredential() (ModelCredential, error) {
+	var result params.ModelCredential
+	err := facade.caller.FacadeCall("ModelCredential", nil, &result)
+	if err != nil {
+		return ModelCredential{}, errors.Trace(err)
+	}
+	credential := ModelCredential{
+		Exists: result.Exists,
+		Valid:  result.Valid,
+	}
+	if result.Exists {
+		tag, err := names.ParseCloudCredentialTag(result.CloudCredential)
+		if err != nil {
+			return ModelCredential{}, errors.Trace(err)
+		}
+		credential.CloudCredential = tag
+	}
+	return credential, nil
+}
+
+// WatchModelCredential returns a NotifyWatcher that will inform of
+// potential changes to the result of ModelCredential.
+func (facade *Facade) WatchModelCredential() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := facade.caller.FacadeCall("WatchModelCredential", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	apiCaller := facade.caller.RawAPICaller()
+	return facade.newWatcher(apiCaller, result), nil
+}
+
+// InvalidateModelCredential reports that the model's cloud credential
+// is no longer valid, for the supplied reason.
+func (facade *Facade) InvalidateModelCredential(reason string) error {
+	var result params.ErrorResult
+	args := params.InvalidateCredentialArg{Reason: reason}
+	err := facade.caller.FacadeCall("InvalidateModelCredential", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/credentialvalidator"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (*FacadeSuite) TestModelCredentialCallError(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(interface{}) error {
+		return errors.New("bork")
+	})
+	facade := credentialvalidator.NewFacade(apiCaller, nil)
+
+	_, err := facade.ModelCredential()
+	c.Check(err, gc.ErrorMatches, "bork")
+	stub.CheckCalls(c, []testing.StubCall{{"ModelCredential", []interface{}{nil}}})
+}
+
+func (*FacadeSuite) TestModelCredentialSuccess(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		outPtr, ok := response.(*params.ModelCredential)
+		c.Assert(ok, jc.IsTrue)
+		*outPtr = params.ModelCredential{
+			Exists:          true,
+			CloudCredential: "cloudcred-dummy_fred_default",
+		}
+		return nil
+	})
+	facade := credentialvalidator.NewFacade(apiCaller, nil)
+
+	credential, err := facade.ModelCredential()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(credential, jc.DeepEquals, credentialvalidator.ModelCredential{
+		CloudCredential: names.NewCloudCredentialTag("dummy/fred/default"),
+		Exists:          true,
+		Valid:           false,
+	})
+}
+
+func (*FacadeSuite) TestModelCredentialBadTag(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		outPtr, ok := response.(*params.ModelCredential)
+		c.Assert(ok, jc.IsTrue)
+		*outPtr = params.ModelCredential{
+			Exists:          true,
+			CloudCredential: "machine-0",
+		}
+		return nil
+	})
+	facade := credentialvalidator.NewFacade(apiCaller, nil)
+
+	_, err := facade.ModelCredential()
+	c.Check(err, gc.ErrorMatches, `"machine-0" is not a valid cloudcred tag`)
+}
+
+func (*FacadeSuite) TestWatchModelCredentialError(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		outPtr, ok := response.(*params.NotifyWatchResult)
+		c.Assert(ok, jc.IsTrue)
+		outPtr.Error = &params.Error{Message: "snfl"}
+		return nil
+	})
+	facade := credentialvalidator.NewFacade(apiCaller, nil)
+
+	watch, err := facade.WatchModelCredential()
+	c.Check(err, gc.ErrorMatches, "snfl")
+	c.Check(watch, gc.IsNil)
+	stub.CheckCallNames(c, "WatchModelCredential")
+}
+
+func (*FacadeSuite) TestWatchModelCredentialSuccess(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		outPtr, ok := response.(*params.NotifyWatchResult)
+		c.Assert(ok, jc.IsTrue)
+		outPtr.NotifyWatcherId = "789"
+		return nil
+	})
+	expectWatch := &struct{ watcher.NotifyWatcher }{}
+	newWatcher := func(gotCaller base.APICaller, result params.NotifyWatchResult) watcher.NotifyWatcher {
+		c.Check(gotCaller, gc.NotNil) // uncomparable
+		c.Check(result, jc.DeepEquals, params.NotifyWatchResult{
+			NotifyWatcherId: "789",
+		})
+		return expectWatch
+	}
+	facade := credentialvalidator.NewFacade(apiCaller, newWatcher)
+
+	watch, err := facade.WatchModelCredential()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(watch, gc.Equals, expectWatch)
+	stub.CheckCallNames(c, "WatchModelCredential")
+}
+
+func (*FacadeSuite) TestInvalidateModelCredential(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		_, ok := response.(*params.ErrorResult)
+		c.Assert(ok, jc.IsTrue)
+		return nil
+	})
+	facade := credentialvalidator.NewFacade(apiCaller, nil)
+
+	err := facade.InvalidateModelCredential("revoked")
+	c.Check(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{{
+		"InvalidateModelCredential",
+		[]interface{}{params.InvalidateCredentialArg{Reason: "revoked"}},
+	}})
+}
+
+func (*FacadeSuite) TestInvalidateModelCredentialError(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		outPtr, ok := response.(*params.ErrorResult)
+		c.Assert(ok, jc.IsTrue)
+		outPtr.Error = &params.Error{Message: "splat"}
+		return nil
+	})
+	facade := credentialvalidator.NewFacade(apiCaller, nil)
+
+	err := facade.InvalidateModelCredential("revoked")
+	c.Check(err, gc.ErrorMatches, "splat")
+}
+
+func apiCaller(c *gc.C, stub *testing.Stub, set func(interface{}) error) base.APICaller {
+	return basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "CredentialValidator")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		return set(response)
+	})
+}
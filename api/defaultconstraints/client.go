@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package defaultconstraints
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+// Client provides methods that the Juju client command uses to get and
+// set the default constraints of the controller and of cloud regions,
+// and to resolve the effective constraints of a model or application.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "DefaultConstraints")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// DefaultConstraints returns the default constraints of the given cloud
+// region. An empty cloud denotes the controller, and an empty region
+// the whole cloud.
+func (c *Client) DefaultConstraints(cloud, region string) (constraints.Value, error) {
+	var results params.DefaultConstraintsResults
+	args := params.DefaultConstraintsScopes{
+		Scopes: []params.DefaultConstraintsScope{{
+			CloudTag:    cloudTag(cloud),
+			CloudRegion: region,
+		}},
+	}
+	if err := c.facade.FacadeCall("DefaultConstraints", args, &results); err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return constraints.Value{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return constraints.Value{}, errors.Trace(result.Error)
+	}
+	return result.Constraints, nil
+}
+
+// SetDefaultConstraints replaces the default constraints of the given
+// cloud region. Empty constraints remove the defaults.
+func (c *Client) SetDefaultConstraints(cloud, region string, cons constraints.Value) error {
+	var results params.ErrorResults
+	args := params.DefaultConstraintsArgs{
+		Args: []params.DefaultConstraintsArg{{
+			CloudTag:    cloudTag(cloud),
+			CloudRegion: region,
+			Constraints: cons,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetDefaultConstraints", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ResolveConstraints returns the effective constraints of the model or
+// application with the given tag, and the level from which each value
+// is taken.
+func (c *Client) ResolveConstraints(tag names.Tag) (params.ResolvedConstraintsResult, error) {
+	var results params.ResolvedConstraintsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := c.facade.FacadeCall("ResolveConstraints", args, &results); err != nil {
+		return params.ResolvedConstraintsResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ResolvedConstraintsResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ResolvedConstraintsResult{}, errors.Trace(result.Error)
+	}
+	return result, nil
+}
+
+func cloudTag(cloud string) string {
+	if cloud == "" {
+		return ""
+	}
+	return names.NewCloudTag(cloud).String()
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package defaultconstraints_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/defaultconstraints"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+type DefaultConstraintsSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&DefaultConstraintsSuite{})
+
+func (s *DefaultConstraintsSuite) TestDefaultConstraints(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "DefaultConstraints")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DefaultConstraints")
+			c.Check(a, jc.DeepEquals, params.DefaultConstraintsScopes{
+				Scopes: []params.DefaultConstraintsScope{{
+					CloudTag:    "cloud-aws",
+					CloudRegion: "us-east-1",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.DefaultConstraintsResults{})
+			*(result.(*params.DefaultConstraintsResults)) = params.DefaultConstraintsResults{
+				Results: []params.DefaultConstraintsResult{{
+					Constraints: constraints.MustParse("mem=4G"),
+				}},
+			}
+			called = true
+			return nil
+		},
+	)
+	client := defaultconstraints.NewClient(apiCaller)
+	cons, err := client.DefaultConstraints("aws", "us-east-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G"))
+}
+
+func (s *DefaultConstraintsSuite) TestSetDefaultConstraints(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "DefaultConstraints")
+			c.Check(request, gc.Equals, "SetDefaultConstraints")
+			c.Check(a, jc.DeepEquals, params.DefaultConstraintsArgs{
+				Args: []params.DefaultConstraintsArg{{
+					Constraints: constraints.MustParse("cpu-cores=2"),
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "permission denied"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := defaultconstraints.NewClient(apiCaller)
+	err := client.SetDefaultConstraints("", "", constraints.MustParse("cpu-cores=2"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *DefaultConstraintsSuite) TestResolveConstraints(c *gc.C) {
+	expected := params.ResolvedConstraintsResult{
+		Constraints: constraints.MustParse("mem=4G cpu-cores=2"),
+		Sources: map[string]string{
+			"mem":       "region",
+			"cpu-cores": "application",
+		},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "DefaultConstraints")
+			c.Check(request, gc.Equals, "ResolveConstraints")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-mysql"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ResolvedConstraintsResults{})
+			*(result.(*params.ResolvedConstraintsResults)) = params.ResolvedConstraintsResults{
+				Results: []params.ResolvedConstraintsResult{expected},
+			}
+			return nil
+		},
+	)
+	client := defaultconstraints.NewClient(apiCaller)
+	result, err := client.ResolveConstraints(names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
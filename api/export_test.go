@@ -20,6 +20,8 @@ var (
 	BestVersion           = bestVersion
 	FacadeVersions        = &facadeVersions
 	ConnectWebsocket      = connectWebsocket
+	CharmUploadAttempt    = &charmUploadAttempt
+	CreateRootCertPool    = createRootCertPool
 )
 
 // RPCConnection defines the methods that are called on the rpc.Conn instance.
@@ -48,6 +50,7 @@ type TestingStateParams struct {
 	ServerRoot     string
 	RPCConnection  RPCConnection
 	Clock          clock.Clock
+	CallObserver   CallObserver
 }
 
 // NewTestingState creates an api.State object that can be used for testing. It
@@ -71,6 +74,7 @@ func NewTestingState(params TestingStateParams) Connection {
 		facadeVersions:    params.FacadeVersions,
 		serverScheme:      params.ServerScheme,
 		serverRootAddress: params.ServerRoot,
+		callObserver:      params.CallObserver,
 	}
 	return st
 }
@@ -13,39 +13,49 @@ package api
 var facadeVersions = map[string]int{
 	"Action":                       2,
 	"Agent":                        2,
+	"AgentLogging":                 1,
 	"AgentTools":                   1,
+	"Alerts":                       1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  1,
+	"Application":                  2,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
+	"Bundle":                       1,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       1,
-	"Cloud":                        1,
+	"Cloud":                        2,
+	"CloudInit":                    1,
 	"Controller":                   3,
+	"ControllerReport":             1,
+	"CredentialValidator":          1,
+	"DefaultConstraints":           1,
 	"Deployer":                     1,
 	"DiscoverSpaces":               2,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
 	"Firewaller":                   3,
-	"HighAvailability":             2,
+	"FirewallRules":                1,
+	"HighAvailability":             3,
+	"HookTimings":                  1,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                2,
 	"InstancePoller":               3,
+	"InstanceTypes":                1,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
-	"Logger":                       1,
+	"Logger":                       2,
 	"MachineActions":               1,
-	"MachineManager":               2,
+	"MachineManager":               5,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
 	"MetricsAdder":                 2,
@@ -57,7 +67,8 @@ var facadeVersions = map[string]int{
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
 	"ModelConfig":                  1,
-	"ModelManager":                 2,
+	"ModelEvents":                  1,
+	"ModelManager":                 3,
 	"NotifyWatcher":                1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
@@ -70,6 +81,7 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"ScheduledOperations":          1,
 	"Singular":                     1,
 	"Spaces":                       2,
 	"SSHClient":                    1,
@@ -80,10 +92,14 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       4,
+	"Uniter":                       5,
+	"UpgradePrechecks":             1,
 	"Upgrader":                     1,
-	"UserManager":                  1,
+	"Usage":                        2,
+	"UsageReporter":                2,
+	"UserManager":                  3,
 	"VolumeAttachmentsWatcher":     2,
+	"WaitFor":                      1,
 }
 
 // bestVersion tries to find the newest version in the version list that we can
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/common/cloudspec"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
 
@@ -117,3 +118,33 @@ func (st *State) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// WatchFirewallRules returns a NotifyWatcher that notifies of changes
+// to the firewall rules of the current model.
+func (st *State) WatchFirewallRules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := st.facade.FacadeCall("WatchFirewallRules", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// FirewallRules returns the firewall rules of the current model.
+func (st *State) FirewallRules() ([]network.FirewallRule, error) {
+	var results params.ListFirewallRulesResults
+	if err := st.facade.FacadeCall("FirewallRules", nil, &results); err != nil {
+		return nil, err
+	}
+	rules := make([]network.FirewallRule, len(results.Rules))
+	for i, rule := range results.Rules {
+		rules[i] = network.FirewallRule{
+			WellKnownService: network.WellKnownServiceType(rule.KnownService),
+			WhitelistCIDRs:   rule.WhitelistCIDRs,
+		}
+	}
+	return rules, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
@@ -75,6 +76,12 @@ func (m *Machine) Life() params.Life {
 	return m.life
 }
 
+// InMaintenance returns whether the machine is in maintenance mode,
+// during which the firewaller should leave it alone.
+func (m *Machine) InMaintenance() (bool, error) {
+	return common.InMaintenance(m.st.facade, m.tag)
+}
+
 // ActiveSubnets returns a list of subnet tags for which the machine has opened
 // ports.
 func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
@@ -9,6 +9,7 @@ import (
 
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher/watchertest"
 )
@@ -100,3 +101,36 @@ func (s *stateSuite) TestWatchOpenedPorts(c *gc.C) {
 	wc.AssertChange("1:")
 	wc.AssertNoChange()
 }
+
+func (s *stateSuite) TestWatchFirewallRules(c *gc.C) {
+	w, err := s.firewaller.WatchFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = s.State.SaveFirewallRule(network.FirewallRule{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *stateSuite) TestFirewallRules(c *gc.C) {
+	rules, err := s.firewaller.FirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	rule := network.FirewallRule{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	}
+	err = s.State.SaveFirewallRule(rule)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.firewaller.FirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.FirewallRule{rule})
+}
@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallrules
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+// Client provides methods that the Juju client command uses to
+// interact with the firewall rules of a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "FirewallRules")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// SetFirewallRule restricts ingress for the given well-known service
+// to the given source CIDRs. An empty whitelist allows ingress from
+// any address.
+func (c *Client) SetFirewallRule(service string, whitelistCIDRs []string) error {
+	args := params.FirewallRuleArgs{
+		Args: []params.FirewallRule{{
+			KnownService:   service,
+			WhitelistCIDRs: whitelistCIDRs,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetFirewallRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListFirewallRules returns the firewall rules of the model.
+func (c *Client) ListFirewallRules() ([]network.FirewallRule, error) {
+	var results params.ListFirewallRulesResults
+	if err := c.facade.FacadeCall("ListFirewallRules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules := make([]network.FirewallRule, len(results.Rules))
+	for i, rule := range results.Rules {
+		rules[i] = network.FirewallRule{
+			WellKnownService: network.WellKnownServiceType(rule.KnownService),
+			WhitelistCIDRs:   rule.WhitelistCIDRs,
+		}
+	}
+	return rules, nil
+}
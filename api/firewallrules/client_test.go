@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallrules_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+type FirewallRulesSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&FirewallRulesSuite{})
+
+func (s *FirewallRulesSuite) TestSetFirewallRule(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetFirewallRules")
+			c.Check(a, jc.DeepEquals, params.FirewallRuleArgs{
+				Args: []params.FirewallRule{{
+					KnownService:   "ssh",
+					WhitelistCIDRs: []string{"10.0.0.0/8"},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			called = true
+			return nil
+		},
+	)
+	client := firewallrules.NewClient(apiCaller)
+	err := client.SetFirewallRule("ssh", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *FirewallRulesSuite) TestSetFirewallRuleError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "bad rule"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := firewallrules.NewClient(apiCaller)
+	err := client.SetFirewallRule("telnet", nil)
+	c.Assert(err, gc.ErrorMatches, "bad rule")
+}
+
+func (s *FirewallRulesSuite) TestListFirewallRules(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListFirewallRules")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ListFirewallRulesResults{})
+			results := result.(*params.ListFirewallRulesResults)
+			results.Rules = []params.FirewallRule{{
+				KnownService:   "ssh",
+				WhitelistCIDRs: []string{"10.0.0.0/8"},
+			}}
+			return nil
+		},
+	)
+	client := firewallrules.NewClient(apiCaller)
+	rules, err := client.ListFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.FirewallRule{{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	}})
+}
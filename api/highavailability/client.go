@@ -59,6 +59,33 @@ func (c *Client) EnableHA(
 	return result.Result, nil
 }
 
+// RemoveControllerMachines takes the machines with the given ids out of
+// the controller cluster.
+func (c *Client) RemoveControllerMachines(machineIds ...string) (params.ControllersChanges, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ControllersChanges{}, errors.NotSupportedf("removing controller machines")
+	}
+	arg := params.RemoveControllersArgs{
+		MachineTags: machineIdsToTags(machineIds),
+	}
+	var result params.ControllersChangeResult
+	if err := c.facade.FacadeCall("RemoveControllerMachines", arg, &result); err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.ControllersChanges{}, result.Error
+	}
+	return result.Result, nil
+}
+
+func machineIdsToTags(ids []string) []string {
+	tags := make([]string, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewMachineTag(id).String()
+	}
+	return tags
+}
+
 // MongoUpgradeMode will make all Slave members of the HA
 // to shut down their mongo server.
 func (c *Client) MongoUpgradeMode(v mongo.Version) (params.MongoUpgradeResults, error) {
@@ -77,5 +77,16 @@ func (s *clientSuite) TestClientEnableHA(c *gc.C) {
 
 func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
-	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
+	c.Assert(client.BestAPIVersion(), gc.Equals, 3)
+}
+
+func (s *clientSuite) TestClientRemoveControllerMachines(c *gc.C) {
+	assertEnableHA(c, &s.JujuConnSuite)
+	client := highavailability.NewClient(s.APIState)
+	_, err := client.RemoveControllerMachines("2")
+	c.Assert(err, gc.ErrorMatches, `cannot remove controller machines: number of remaining voting controllers must be odd \(got 2\)`)
+
+	result, err := client.RemoveControllerMachines("1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Demoted, gc.DeepEquals, []string{"machine-1", "machine-2"})
 }
@@ -0,0 +1,46 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktimings
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the Juju client command uses to query
+// the recent hook executions of units.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "HookTimings")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// HookTimings returns the most recent hook executions of the given
+// unit, oldest first.
+func (c *Client) HookTimings(unit names.UnitTag) ([]params.HookTiming, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: unit.String()}},
+	}
+	var results params.HookTimingsResults
+	if err := c.facade.FacadeCall("HookTimings", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Timings, nil
+}
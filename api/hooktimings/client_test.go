@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktimings_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/hooktimings"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type HookTimingsSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&HookTimingsSuite{})
+
+func (s *HookTimingsSuite) TestHookTimings(c *gc.C) {
+	when := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	timings := []params.HookTiming{{
+		Hook:     "install",
+		Queued:   when,
+		Started:  when.Add(time.Second),
+		Finished: when.Add(time.Minute),
+	}}
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "HookTimings")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "HookTimings")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.HookTimingsResults{})
+			*(result.(*params.HookTimingsResults)) = params.HookTimingsResults{
+				Results: []params.HookTimingsResult{{Timings: timings}},
+			}
+			called = true
+			return nil
+		},
+	)
+	client := hooktimings.NewClient(apiCaller)
+	result, err := client.HookTimings(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, timings)
+}
+
+func (s *HookTimingsSuite) TestHookTimingsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.HookTimingsResults)) = params.HookTimingsResults{
+				Results: []params.HookTimingsResult{{
+					Error: &params.Error{Message: `unit "mysql/0" not found`, Code: params.CodeNotFound},
+				}},
+			}
+			return nil
+		},
+	)
+	client := hooktimings.NewClient(apiCaller)
+	_, err := client.HookTimings(names.NewUnitTag("mysql/0"))
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" not found`)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
@@ -75,6 +75,12 @@ func (m *Machine) Status() (params.StatusResult, error) {
 	return result, nil
 }
 
+// InMaintenance returns whether the machine is in maintenance mode,
+// during which the instance poller should leave it alone.
+func (m *Machine) InMaintenance() (bool, error) {
+	return common.InMaintenance(m.facade, m.tag)
+}
+
 // IsManual returns whether the machine is manually provisioned.
 func (m *Machine) IsManual() (bool, error) {
 	var results params.BoolResults
@@ -72,6 +72,13 @@ var machineErrorTests = []struct {
 		return err
 	},
 	resultsRef: params.BoolResults{},
+}, {
+	method: "InMaintenance",
+	wrapper: func(m *instancepoller.Machine) error {
+		_, err := m.InMaintenance()
+		return err
+	},
+	resultsRef: params.BoolResults{},
 }, {
 	method: "InstanceId",
 	wrapper: func(m *instancepoller.Machine) error {
@@ -191,6 +198,19 @@ func (s *MachineSuite) TestIsManualSuccess(c *gc.C) {
 	c.Check(called, gc.Equals, 1)
 }
 
+func (s *MachineSuite) TestInMaintenanceSuccess(c *gc.C) {
+	var called int
+	results := params.BoolResults{
+		Results: []params.BoolResult{{Result: true}},
+	}
+	apiCaller := successAPICaller(c, "InMaintenance", entitiesArgs, results, &called)
+	machine := instancepoller.NewMachine(apiCaller, s.tag, params.Alive)
+	inMaintenance, err := machine.InMaintenance()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(inMaintenance, jc.IsTrue)
+	c.Check(called, gc.Equals, 1)
+}
+
 func (s *MachineSuite) TestInstanceIdSuccess(c *gc.C) {
 	var called int
 	results := params.StringResults{
@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instancetypes
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/instances"
+)
+
+// Client provides methods that the Juju client command uses to query
+// the instance types offered by a model's provider.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "InstanceTypes")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// InstanceTypes returns the model's instance types that satisfy the
+// given constraints, and the time at which the controller fetched them
+// from the provider.
+func (c *Client) InstanceTypes(cons constraints.Value) ([]instances.InstanceType, time.Time, error) {
+	args := params.InstanceTypesConstraints{
+		Constraints: []params.InstanceTypesConstraint{{Constraints: cons}},
+	}
+	var results params.InstanceTypesResults
+	if err := c.facade.FacadeCall("InstanceTypes", args, &results); err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, time.Time{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, time.Time{}, result.Error
+	}
+	instanceTypes := make([]instances.InstanceType, len(result.InstanceTypes))
+	for i, itype := range result.InstanceTypes {
+		instanceTypes[i] = instances.InstanceType{
+			Id:         itype.Id,
+			Name:       itype.Name,
+			Arches:     itype.Arches,
+			CpuCores:   itype.CpuCores,
+			Mem:        itype.Mem,
+			Cost:       itype.Cost,
+			RootDisk:   itype.RootDisk,
+			VirtType:   itype.VirtType,
+			CpuPower:   itype.CpuPower,
+			Tags:       itype.Tags,
+			Deprecated: itype.Deprecated,
+		}
+	}
+	return instanceTypes, result.Updated, nil
+}
+
+// RefreshInstanceTypes asks the controller to fetch the model's
+// instance types from the provider again, replacing those it has
+// cached.
+func (c *Client) RefreshInstanceTypes() error {
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("RefreshInstanceTypes", nil, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
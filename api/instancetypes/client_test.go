@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instancetypes_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/instancetypes"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/instances"
+)
+
+type InstanceTypesSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&InstanceTypesSuite{})
+
+func (s *InstanceTypesSuite) TestInstanceTypes(c *gc.C) {
+	updated := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "InstanceTypes")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "InstanceTypes")
+			c.Check(a, jc.DeepEquals, params.InstanceTypesConstraints{
+				Constraints: []params.InstanceTypesConstraint{{
+					Constraints: constraints.MustParse("mem=4G"),
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.InstanceTypesResults{})
+			*(result.(*params.InstanceTypesResults)) = params.InstanceTypesResults{
+				Results: []params.InstanceTypesResult{{
+					InstanceTypes: []params.InstanceType{{
+						Name:     "large",
+						Arches:   []string{"amd64"},
+						CpuCores: 4,
+						Mem:      16384,
+					}},
+					Updated: updated,
+				}},
+			}
+			called = true
+			return nil
+		},
+	)
+	client := instancetypes.NewClient(apiCaller)
+	instanceTypes, when, err := client.InstanceTypes(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(when, gc.Equals, updated)
+	c.Assert(instanceTypes, jc.DeepEquals, []instances.InstanceType{{
+		Name:     "large",
+		Arches:   []string{"amd64"},
+		CpuCores: 4,
+		Mem:      16384,
+	}})
+}
+
+func (s *InstanceTypesSuite) TestInstanceTypesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.InstanceTypesResults)) = params.InstanceTypesResults{
+				Results: []params.InstanceTypesResult{{
+					Error: &params.Error{Message: "no instance types"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := instancetypes.NewClient(apiCaller)
+	_, _, err := client.InstanceTypes(constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "no instance types")
+}
+
+func (s *InstanceTypesSuite) TestRefreshInstanceTypes(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "InstanceTypes")
+			c.Check(request, gc.Equals, "RefreshInstanceTypes")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+			*(result.(*params.ErrorResult)) = params.ErrorResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			called = true
+			return nil
+		},
+	)
+	client := instancetypes.NewClient(apiCaller)
+	err := client.RefreshInstanceTypes()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
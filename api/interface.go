@@ -4,6 +4,7 @@
 package api
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/juju/errors"
@@ -133,6 +134,47 @@ type DialOpts struct {
 	// be used in tests, or when verification cannot be
 	// performed and the communication need not be secure.
 	InsecureSkipVerify bool
+
+	// CallObserver, if set, is called after each API call made
+	// over the connection completes.
+	CallObserver CallObserver
+
+	// ProxyURL, if set, is the URL of a SOCKS5 ("socks5://") or
+	// HTTP ("http://") proxy through which all connections to
+	// the controller are made.
+	ProxyURL *url.URL
+
+	// ExtraCACerts holds PEM-encoded CA certificates which are
+	// trusted, in addition to the controller's own CA certificate,
+	// when verifying the controller's certificate.
+	ExtraCACerts []string
+
+	// CorrelationId, if set, is sent with every request made over
+	// the connection, so that the controller logs the requests made
+	// for a single user action under the same id. If it is not set,
+	// the controller assigns a new id to each request.
+	CorrelationId string
+}
+
+// CallObserver is called with the details of an API call made over
+// a connection once the call has completed.
+type CallObserver func(CallRecord)
+
+// CallRecord describes an API call made over a connection, and its
+// outcome.
+type CallRecord struct {
+	// ModelTag is the tag of the model the connection is for. It
+	// is empty for controller connections.
+	ModelTag names.ModelTag
+
+	// Facade, Version, Id and Method identify the call.
+	Facade  string
+	Version int
+	Id      string
+	Method  string
+
+	// Err holds the error returned by the call, if any.
+	Err error
 }
 
 // DefaultDialOpts returns a DialOpts representing the default
@@ -72,3 +72,25 @@ func (st *State) WatchLoggingConfig(agentTag names.Tag) (watcher.NotifyWatcher,
 	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// LogRotation returns the size in megabytes at which the log file of
+// the agent specified by agentTag is rotated, and the number of rotated
+// log files it keeps. Zero values mean the agent's defaults apply.
+func (st *State) LogRotation(agentTag names.Tag) (maxSize, maxBackups int, err error) {
+	var results params.LogRotationResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: agentTag.String()}},
+	}
+	err = st.facade.FacadeCall("LogRotation", args, &results)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(results.Results) != 1 {
+		return 0, 0, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if err := result.Error; err != nil {
+		return 0, 0, err
+	}
+	return result.MaxLogSize, result.MaxLogBackups, nil
+}
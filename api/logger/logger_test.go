@@ -77,3 +77,20 @@ func (s *loggerSuite) TestWatchLoggingConfig(c *gc.C) {
 	s.setLoggingConfig(c, loggingConfig)
 	wc.AssertOneChange()
 }
+
+func (s *loggerSuite) TestLogRotation(c *gc.C) {
+	err := s.BackingState.SetAgentLogging(s.rawMachine.Tag(), state.AgentLogging{
+		MaxLogSize:    100,
+		MaxLogBackups: 4,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	maxSize, maxBackups, err := s.logger.LogRotation(s.rawMachine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maxSize, gc.Equals, 100)
+	c.Assert(maxBackups, gc.Equals, 4)
+}
+
+func (s *loggerSuite) TestLogRotationWrongMachine(c *gc.C) {
+	_, _, err := s.logger.LogRotation(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -9,8 +9,9 @@ package machineactions
 // but until the implementations converge, it's saner to duplicate the code since
 // the "correct" abstraction over both is not obvious.
 type Action struct {
-	name   string
-	params map[string]interface{}
+	name          string
+	params        map[string]interface{}
+	correlationId string
 }
 
 // NewAction makes a new Action with specified name and params map.
@@ -27,3 +28,9 @@ func (a *Action) Name() string {
 func (a *Action) Params() map[string]interface{} {
 	return a.params
 }
+
+// CorrelationId retrieves the id of the API request that enqueued the
+// Action, if any.
+func (a *Action) CorrelationId() string {
+	return a.correlationId
+}
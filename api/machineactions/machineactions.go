@@ -81,8 +81,9 @@ func (c *Client) Action(tag names.ActionTag) (*Action, error) {
 		return nil, errors.Trace(err)
 	}
 	return &Action{
-		name:   result.Action.Name,
-		params: result.Action.Parameters,
+		name:          result.Action.Name,
+		params:        result.Action.Parameters,
+		correlationId: result.Action.CorrelationId,
 	}, nil
 }
 
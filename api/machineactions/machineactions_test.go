@@ -350,8 +350,9 @@ func (s *ClientSuite) TestGetActionSuccess(c *gc.C) {
 		*(result.(*params.ActionResults)) = params.ActionResults{
 			Results: []params.ActionResult{{
 				Action: &params.Action{
-					Name:       expectedName,
-					Parameters: expectedParams,
+					Name:          expectedName,
+					Parameters:    expectedParams,
+					CorrelationId: "deadbeef",
 				},
 			}},
 		}
@@ -363,6 +364,7 @@ func (s *ClientSuite) TestGetActionSuccess(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(action.Name(), gc.Equals, expectedName)
 	c.Assert(action.Params(), gc.DeepEquals, expectedParams)
+	c.Assert(action.CorrelationId(), gc.Equals, "deadbeef")
 	stub.CheckCalls(c, expectedCalls)
 }
 
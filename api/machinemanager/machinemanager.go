@@ -5,9 +5,11 @@ package machinemanager
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
 )
 
 const machineManagerFacade = "MachineManager"
@@ -36,3 +38,147 @@ func (client *Client) AddMachines(machineParams []params.AddMachineParams) ([]pa
 	}
 	return results.Machines, err
 }
+
+// SetMachineMaintenance sets or clears maintenance mode on the machines
+// with the given ids.
+func (client *Client) SetMachineMaintenance(maintenance bool, machineIds ...string) ([]params.ErrorResult, error) {
+	args := params.MachinesMaintenance{
+		Machines: make([]params.MachineMaintenance, len(machineIds)),
+	}
+	for i, id := range machineIds {
+		args.Machines[i] = params.MachineMaintenance{
+			MachineTag:  names.NewMachineTag(id).String(),
+			Maintenance: maintenance,
+		}
+	}
+	results := new(params.ErrorResults)
+	if err := client.facade.FacadeCall("SetMachineMaintenance", args, results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(machineIds) {
+		return nil, errors.Errorf("expected %d result, got %d", len(machineIds), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// RotateAgentPasswords asks the agents of the given machines and units
+// to replace their API passwords the next time they connect.
+func (client *Client) RotateAgentPasswords(tags ...names.Tag) ([]params.ErrorResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	results := new(params.ErrorResults)
+	if err := client.facade.FacadeCall("RotateAgentPasswords", args, results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result, got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ReplaceMachines adds a substitute for each of the machines with the
+// given ids, places the machine's units (or new units of the same
+// applications) on it, and retires the original machine.
+func (client *Client) ReplaceMachines(machineIds ...string) ([]params.ReplaceMachineResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(machineIds)),
+	}
+	for i, id := range machineIds {
+		args.Entities[i].Tag = names.NewMachineTag(id).String()
+	}
+	results := new(params.ReplaceMachineResults)
+	if err := client.facade.FacadeCall("ReplaceMachines", args, results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(machineIds) {
+		return nil, errors.Errorf("expected %d result, got %d", len(machineIds), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ConsoleLogs returns the console output recorded by the cloud for
+// the instances of the machines with the given ids.
+func (client *Client) ConsoleLogs(machineIds ...string) ([]params.StringResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(machineIds)),
+	}
+	for i, id := range machineIds {
+		args.Entities[i].Tag = names.NewMachineTag(id).String()
+	}
+	results := new(params.StringResults)
+	if err := client.facade.FacadeCall("ConsoleLogs", args, results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(machineIds) {
+		return nil, errors.Errorf("expected %d result, got %d", len(machineIds), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ProviderInstances returns the addresses and availability zones of
+// the instances in the model's cloud with the given ids.
+func (client *Client) ProviderInstances(ids ...instance.Id) ([]params.ProviderInstanceResult, error) {
+	args := params.ProviderInstances{
+		InstanceIds: make([]string, len(ids)),
+	}
+	for i, id := range ids {
+		args.InstanceIds[i] = string(id)
+	}
+	results := new(params.ProviderInstanceResults)
+	if err := client.facade.FacadeCall("ProviderInstances", args, results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result, got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// PrepareUpgradeSeries starts a series upgrade of the machine with the
+// given id, by asking the units on the machine to run their
+// pre-series-upgrade hooks.
+func (client *Client) PrepareUpgradeSeries(machineId, series string) error {
+	args := params.UpgradeSeriesPrepareArgs{
+		Args: []params.UpgradeSeriesPrepareArg{{
+			MachineTag: names.NewMachineTag(machineId).String(),
+			Series:     series,
+		}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("PrepareUpgradeSeries", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CompleteUpgradeSeries records that the operating system of the
+// machine with the given id has been upgraded, and asks the units on
+// the machine to run their post-series-upgrade hooks.
+func (client *Client) CompleteUpgradeSeries(machineId string) error {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("CompleteUpgradeSeries", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AbortUpgradeSeries abandons the series upgrade of the machine with
+// the given id, so long as its operating system has not yet been
+// upgraded.
+func (client *Client) AbortUpgradeSeries(machineId string) error {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("AbortUpgradeSeries", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -9,6 +9,7 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
@@ -116,3 +117,208 @@ func (s *MachinemanagerSuite) TestAddMachinesResultCountInvalid(c *gc.C) {
 		c.Check(err, gc.ErrorMatches, fmt.Sprintf("expected 1 result, got %d", n))
 	}
 }
+
+func (s *MachinemanagerSuite) TestSetMachineMaintenance(c *gc.C) {
+	apiResult := []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "MSG", Code: "621"}},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "SetMachineMaintenance")
+		c.Check(arg, jc.DeepEquals, params.MachinesMaintenance{
+			Machines: []params.MachineMaintenance{
+				{MachineTag: "machine-0", Maintenance: true},
+				{MachineTag: "machine-1-lxd-2", Maintenance: true},
+			},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: apiResult}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	results, err := st.SetMachineMaintenance(true, "0", "1/lxd/2")
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, apiResult)
+}
+
+func (s *MachinemanagerSuite) TestRotateAgentPasswords(c *gc.C) {
+	apiResult := []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "MSG", Code: "621"}},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "RotateAgentPasswords")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{Tag: "machine-0"},
+				{Tag: "unit-mysql-1"},
+			},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: apiResult}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	results, err := st.RotateAgentPasswords(names.NewMachineTag("0"), names.NewUnitTag("mysql/1"))
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, apiResult)
+}
+
+func (s *MachinemanagerSuite) TestSetMachineMaintenanceResultCountInvalid(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	_, err := st.SetMachineMaintenance(false, "0")
+	c.Check(err, gc.ErrorMatches, "expected 1 result, got 0")
+}
+
+func (s *MachinemanagerSuite) TestReplaceMachines(c *gc.C) {
+	apiResult := []params.ReplaceMachineResult{
+		{Machine: "2", Units: map[string]string{"mysql/0": "mysql/1"}},
+		{Error: &params.Error{Message: "MSG", Code: "621"}},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "ReplaceMachines")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{Tag: "machine-0"},
+				{Tag: "machine-1-lxd-2"},
+			},
+		})
+		*(result.(*params.ReplaceMachineResults)) = params.ReplaceMachineResults{Results: apiResult}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	results, err := st.ReplaceMachines("0", "1/lxd/2")
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, apiResult)
+}
+
+func (s *MachinemanagerSuite) TestReplaceMachinesResultCountInvalid(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	_, err := st.ReplaceMachines("0")
+	c.Check(err, gc.ErrorMatches, "expected 1 result, got 0")
+}
+
+func (s *MachinemanagerSuite) TestConsoleLogs(c *gc.C) {
+	apiResult := []params.StringResult{
+		{Result: "Booting..."},
+		{Error: &params.Error{Message: "MSG", Code: "621"}},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "ConsoleLogs")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+		})
+		*(result.(*params.StringResults)) = params.StringResults{Results: apiResult}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	results, err := st.ConsoleLogs("0", "1")
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, apiResult)
+}
+
+func (s *MachinemanagerSuite) TestConsoleLogsResultCountInvalid(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	_, err := st.ConsoleLogs("0")
+	c.Check(err, gc.ErrorMatches, "expected 1 result, got 0")
+}
+
+func (s *MachinemanagerSuite) TestProviderInstances(c *gc.C) {
+	apiResult := []params.ProviderInstanceResult{
+		{InstanceId: "i-1", AvailabilityZone: "zone-a"},
+		{InstanceId: "i-2", Error: &params.Error{Message: "MSG", Code: "621"}},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "ProviderInstances")
+		c.Check(arg, jc.DeepEquals, params.ProviderInstances{
+			InstanceIds: []string{"i-1", "i-2"},
+		})
+		*(result.(*params.ProviderInstanceResults)) = params.ProviderInstanceResults{Results: apiResult}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	results, err := st.ProviderInstances("i-1", "i-2")
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, apiResult)
+}
+
+func (s *MachinemanagerSuite) TestProviderInstancesResultCountInvalid(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	_, err := st.ProviderInstances("i-1")
+	c.Check(err, gc.ErrorMatches, "expected 1 result, got 0")
+}
+
+func (s *MachinemanagerSuite) TestPrepareUpgradeSeries(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "PrepareUpgradeSeries")
+		c.Check(arg, jc.DeepEquals, params.UpgradeSeriesPrepareArgs{
+			Args: []params.UpgradeSeriesPrepareArg{{MachineTag: "machine-1", Series: "xenial"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "MSG"}}},
+		}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.PrepareUpgradeSeries("1", "xenial")
+	c.Check(err, gc.ErrorMatches, "MSG")
+}
+
+func (s *MachinemanagerSuite) TestCompleteUpgradeSeries(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "CompleteUpgradeSeries")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-1"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.CompleteUpgradeSeries("1")
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *MachinemanagerSuite) TestCompleteUpgradeSeriesResultCountInvalid(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.CompleteUpgradeSeries("1")
+	c.Check(err, gc.ErrorMatches, "expected 1 result, got 0")
+}
+
+func (s *MachinemanagerSuite) TestAbortUpgradeSeries(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "AbortUpgradeSeries")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-1"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.AbortUpgradeSeries("1")
+	c.Check(err, jc.ErrorIsNil)
+}
@@ -89,6 +89,8 @@ func (c *Client) GetMigrationStatus() (migration.MigrationStatus, error) {
 			AuthTag:       authTag,
 			Password:      target.Password,
 		},
+		ArtifactsRemaining: status.ArtifactsRemaining,
+		AgentsUnreported:   status.AgentsUnreported,
 	}, nil
 }
 
@@ -109,6 +111,15 @@ func (c *Client) SetStatusMessage(message string) error {
 	return c.caller.FacadeCall("SetStatusMessage", args, nil)
 }
 
+// SetArtifactsRemaining records the number of charms and tools still
+// to be transferred to the target controller.
+func (c *Client) SetArtifactsRemaining(count int) error {
+	args := params.SetMigrationArtifactsRemainingArgs{
+		Count: count,
+	}
+	return c.caller.FacadeCall("SetArtifactsRemaining", args, nil)
+}
+
 // Export returns a serialized representation of the model associated
 // with the API connection. The charms used by the model are also
 // returned.
@@ -136,6 +147,20 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 	}, nil
 }
 
+// ModelUsage returns the number of machines and units in the model
+// associated with the API connection.
+func (c *Client) ModelUsage() (migration.ModelUsage, error) {
+	var usage params.ModelUsage
+	err := c.caller.FacadeCall("ModelUsage", nil, &usage)
+	if err != nil {
+		return migration.ModelUsage{}, errors.Trace(err)
+	}
+	return migration.ModelUsage{
+		Machines: usage.Machines,
+		Units:    usage.Units,
+	}, nil
+}
+
 // Reap removes the documents for the model associated with the API
 // connection.
 func (c *Client) Reap() error {
@@ -77,9 +77,11 @@ func (s *ClientSuite) TestGetMigrationStatus(c *gc.C) {
 					Password:      "secret",
 				},
 			},
-			MigrationId:      "id",
-			Phase:            "PRECHECK",
-			PhaseChangedTime: timestamp,
+			MigrationId:        "id",
+			Phase:              "PRECHECK",
+			PhaseChangedTime:   timestamp,
+			ArtifactsRemaining: 4,
+			AgentsUnreported:   2,
 		}
 		return nil
 	})
@@ -99,6 +101,8 @@ func (s *ClientSuite) TestGetMigrationStatus(c *gc.C) {
 			AuthTag:       names.NewUserTag("admin"),
 			Password:      "secret",
 		},
+		ArtifactsRemaining: 4,
+		AgentsUnreported:   2,
 	})
 }
 
@@ -150,6 +154,55 @@ func (s *ClientSuite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestSetArtifactsRemaining(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.SetArtifactsRemaining(3)
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.SetMigrationArtifactsRemainingArgs{Count: 3}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetArtifactsRemaining", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestSetArtifactsRemainingError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.SetArtifactsRemaining(3)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestModelUsage(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.ModelUsage)) = params.ModelUsage{Machines: 2, Units: 5}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	usage, err := client.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.ModelUsage", []interface{}{"", nil}},
+	})
+	c.Assert(usage, gc.Equals, migration.ModelUsage{Machines: 2, Units: 5})
+}
+
+func (s *ClientSuite) TestModelUsageError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	_, err := client.ModelUsage()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *ClientSuite) TestExport(c *gc.C) {
 	var stub jujutesting.Stub
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
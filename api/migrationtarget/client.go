@@ -8,12 +8,18 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 )
 
 // Client describes the client side API for the MigrationTarget
 // facade. It is called by the migration master worker to talk to the
 // target controller during a migration.
 type Client interface {
+	// Prechecks checks that the target controller has the capacity
+	// to host a model with the given usage, returning the resources
+	// whose limits would be exceeded.
+	Prechecks(coremigration.ModelUsage) ([]coremigration.CapacityShortfall, error)
+
 	// Import takes a serialized model and imports it into the target
 	// controller.
 	Import([]byte) error
@@ -36,6 +42,30 @@ type client struct {
 	caller base.FacadeCaller
 }
 
+// Prechecks implements Client.
+func (c *client) Prechecks(usage coremigration.ModelUsage) ([]coremigration.CapacityShortfall, error) {
+	args := params.TargetPrechecksArgs{
+		Usage: params.ModelUsage{
+			Machines: usage.Machines,
+			Units:    usage.Units,
+		},
+	}
+	var result params.TargetPrechecksResult
+	if err := c.caller.FacadeCall("Prechecks", args, &result); err != nil {
+		return nil, err
+	}
+	var shortfalls []coremigration.CapacityShortfall
+	for _, shortfall := range result.Shortfalls {
+		shortfalls = append(shortfalls, coremigration.CapacityShortfall{
+			Resource: shortfall.Resource,
+			Incoming: shortfall.Incoming,
+			Usage:    shortfall.Usage,
+			Limit:    shortfall.Limit,
+		})
+	}
+	return shortfalls, nil
+}
+
 // Import implements Client.
 func (c *client) Import(bytes []byte) error {
 	serialized := params.SerializedModel{Bytes: bytes}
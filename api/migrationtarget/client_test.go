@@ -6,12 +6,14 @@ package migrationtarget_test
 import (
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 )
 
 type ClientSuite struct {
@@ -30,6 +32,44 @@ func (s *ClientSuite) getClientAndStub(c *gc.C) (migrationtarget.Client, *jujute
 	return client, &stub
 }
 
+func (s *ClientSuite) TestPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.TargetPrechecksResult)) = params.TargetPrechecksResult{
+			Shortfalls: []params.TargetCapacityShortfall{{
+				Resource: "units",
+				Incoming: 5,
+				Usage:    98,
+				Limit:    100,
+			}},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	shortfalls, err := client.Prechecks(coremigration.ModelUsage{Machines: 2, Units: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.TargetPrechecksArgs{
+		Usage: params.ModelUsage{Machines: 2, Units: 5},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Prechecks", []interface{}{"", expectedArg}},
+	})
+	c.Assert(shortfalls, jc.DeepEquals, []coremigration.CapacityShortfall{{
+		Resource: "units",
+		Incoming: 5,
+		Usage:    98,
+		Limit:    100,
+	}})
+}
+
+func (s *ClientSuite) TestPrechecksError(c *gc.C) {
+	client, _ := s.getClientAndStub(c)
+	_, err := client.Prechecks(coremigration.ModelUsage{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -133,3 +133,39 @@ func (c *Client) UnsetModelDefaults(cloud, region string, keys ...string) error
 	}
 	return result.OneError()
 }
+
+// CreateConfigSnapshot records the current config of the model and of
+// its applications as a snapshot with the given name.
+func (c *Client) CreateConfigSnapshot(name string) error {
+	args := params.ConfigSnapshotArg{Name: name}
+	return c.facade.FacadeCall("CreateConfigSnapshot", args, nil)
+}
+
+// ConfigSnapshots returns the config snapshots of the model, ordered
+// by revision.
+func (c *Client) ConfigSnapshots() ([]params.ConfigSnapshot, error) {
+	var result params.ConfigSnapshotsResult
+	if err := c.facade.FacadeCall("ConfigSnapshots", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Snapshots, nil
+}
+
+// RestoreConfigSnapshot restores the config of the model and of its
+// applications to the values recorded in the named snapshot. It
+// returns the names of the applications recorded in the snapshot
+// which no longer exist, and so were not restored.
+func (c *Client) RestoreConfigSnapshot(name string) ([]string, error) {
+	args := params.ConfigSnapshotArg{Name: name}
+	var result params.RestoreConfigSnapshotResult
+	if err := c.facade.FacadeCall("RestoreConfigSnapshot", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Skipped, nil
+}
+
+// RemoveConfigSnapshot removes the named config snapshot.
+func (c *Client) RemoveConfigSnapshot(name string) error {
+	args := params.ConfigSnapshotArg{Name: name}
+	return c.facade.FacadeCall("RemoveConfigSnapshot", args, nil)
+}
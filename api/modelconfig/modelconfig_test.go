@@ -222,3 +222,99 @@ func (s *modelconfigSuite) TestUnsetModelDefaults(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *modelconfigSuite) TestCreateConfigSnapshot(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateConfigSnapshot")
+			c.Check(a, jc.DeepEquals, params.ConfigSnapshotArg{Name: "foo"})
+			called = true
+			return nil
+		},
+	)
+	client := modelconfig.NewClient(apiCaller)
+	err := client.CreateConfigSnapshot("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelconfigSuite) TestConfigSnapshots(c *gc.C) {
+	snapshots := []params.ConfigSnapshot{{
+		Name:         "foo",
+		Revision:     1,
+		CreatedBy:    "bob",
+		Applications: []string{"mysql"},
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ConfigSnapshots")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ConfigSnapshotsResult{})
+			results := result.(*params.ConfigSnapshotsResult)
+			results.Snapshots = snapshots
+			return nil
+		},
+	)
+	client := modelconfig.NewClient(apiCaller)
+	result, err := client.ConfigSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, snapshots)
+}
+
+func (s *modelconfigSuite) TestRestoreConfigSnapshot(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RestoreConfigSnapshot")
+			c.Check(a, jc.DeepEquals, params.ConfigSnapshotArg{Name: "foo"})
+			c.Assert(result, gc.FitsTypeOf, &params.RestoreConfigSnapshotResult{})
+			results := result.(*params.RestoreConfigSnapshotResult)
+			results.Skipped = []string{"mysql"}
+			return nil
+		},
+	)
+	client := modelconfig.NewClient(apiCaller)
+	skipped, err := client.RestoreConfigSnapshot("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(skipped, jc.DeepEquals, []string{"mysql"})
+}
+
+func (s *modelconfigSuite) TestRemoveConfigSnapshot(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RemoveConfigSnapshot")
+			c.Check(a, jc.DeepEquals, params.ConfigSnapshotArg{Name: "foo"})
+			called = true
+			return nil
+		},
+	)
+	client := modelconfig.NewClient(apiCaller)
+	err := client.RemoveConfigSnapshot("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the Juju client command uses to query
+// the timeline of events in a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelEvents")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ModelEvents returns the events in the model's timeline selected by
+// the given filter, oldest first.
+func (c *Client) ModelEvents(filter params.ModelEventsFilter) ([]params.ModelEvent, error) {
+	var result params.ModelEventsResult
+	if err := c.facade.FacadeCall("ModelEvents", filter, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Events, nil
+}
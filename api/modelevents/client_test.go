@@ -0,0 +1,62 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelevents"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ModelEventsSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ModelEventsSuite{})
+
+func (s *ModelEventsSuite) TestModelEvents(c *gc.C) {
+	when := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	events := []params.ModelEvent{{
+		Kind:    "deploy",
+		Actor:   "user-bob@local",
+		Entity:  "application-mysql",
+		Message: "deployed cs:mysql-1",
+		Time:    when,
+	}}
+	filter := params.ModelEventsFilter{
+		From:  &when,
+		Kinds: []string{"deploy"},
+		Limit: 5,
+	}
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelEvents")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelEvents")
+			c.Check(a, jc.DeepEquals, filter)
+			c.Assert(result, gc.FitsTypeOf, &params.ModelEventsResult{})
+			*(result.(*params.ModelEventsResult)) = params.ModelEventsResult{
+				Events: events,
+			}
+			called = true
+			return nil
+		},
+	)
+	client := modelevents.NewClient(apiCaller)
+	result, err := client.ModelEvents(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, events)
+}
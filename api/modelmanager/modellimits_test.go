@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type modelLimitsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&modelLimitsSuite{})
+
+func (s *modelLimitsSuite) TestModelLimits(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelLimits")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: testing.ModelTag.String()}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ModelLimitsResults{})
+			*(result.(*params.ModelLimitsResults)) = params.ModelLimitsResults{
+				Results: []params.ModelLimitsResult{{
+					Limits: params.ModelLimits{MaxMachines: 3},
+					Usage:  params.ModelUsage{Machines: 2, Units: 5},
+				}},
+			}
+			return nil
+		},
+	)
+	client := modelmanager.NewClient(apiCaller)
+	limits, usage, err := client.ModelLimits(testing.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits, jc.DeepEquals, params.ModelLimits{MaxMachines: 3})
+	c.Assert(usage, jc.DeepEquals, params.ModelUsage{Machines: 2, Units: 5})
+}
+
+func (s *modelLimitsSuite) TestModelLimitsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.ModelLimitsResults)) = params.ModelLimitsResults{
+				Results: []params.ModelLimitsResult{{
+					Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+				}},
+			}
+			return nil
+		},
+	)
+	client := modelmanager.NewClient(apiCaller)
+	_, _, err := client.ModelLimits(testing.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *modelLimitsSuite) TestSetModelLimits(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetModelLimits")
+			c.Check(a, jc.DeepEquals, params.SetModelLimitsArgs{
+				Models: []params.SetModelLimits{{
+					ModelTag: testing.ModelTag.String(),
+					Limits:   params.ModelLimits{MaxMachines: 3, MaxUnits: 10},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelLimits(testing.ModelTag, params.ModelLimits{MaxMachines: 3, MaxUnits: 10})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -34,10 +34,10 @@ func (c *Client) Close() error {
 	return c.ClientFacade.Close()
 }
 
-// CreateModel creates a new model using the model config,
+// CreateModel creates a new model using the model config, cloud,
 // cloud region and credential specified in the args.
 func (c *Client) CreateModel(
-	name, owner, cloudRegion string,
+	name, owner, cloud, cloudRegion string,
 	cloudCredential names.CloudCredentialTag,
 	config map[string]interface{},
 ) (params.ModelInfo, error) {
@@ -45,6 +45,16 @@ func (c *Client) CreateModel(
 	if !names.IsValidUser(owner) {
 		return result, errors.Errorf("invalid owner name %q", owner)
 	}
+	var cloudTag string
+	if cloud != "" {
+		if !names.IsValidCloud(cloud) {
+			return result, errors.Errorf("invalid cloud name %q", cloud)
+		}
+		if c.BestAPIVersion() < 3 {
+			return result, errors.New("adding models in other clouds not supported by the controller")
+		}
+		cloudTag = names.NewCloudTag(cloud).String()
+	}
 	var cloudCredentialTag string
 	if cloudCredential != (names.CloudCredentialTag{}) {
 		cloudCredentialTag = cloudCredential.String()
@@ -53,6 +63,7 @@ func (c *Client) CreateModel(
 		Name:               name,
 		OwnerTag:           names.NewUserTag(owner).String(),
 		Config:             config,
+		CloudTag:           cloudTag,
 		CloudRegion:        cloudRegion,
 		CloudCredentialTag: cloudCredentialTag,
 	}
@@ -152,6 +163,64 @@ func (c *Client) DestroyModel(tag names.ModelTag) error {
 	return nil
 }
 
+// ModelLimits returns the machine and unit limits of the model with
+// the given tag, and the numbers of machines and units counted against
+// them.
+func (c *Client) ModelLimits(tag names.ModelTag) (params.ModelLimits, params.ModelUsage, error) {
+	var results params.ModelLimitsResults
+	entities := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := c.facade.FacadeCall("ModelLimits", entities, &results); err != nil {
+		return params.ModelLimits{}, params.ModelUsage{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ModelLimits{}, params.ModelUsage{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ModelLimits{}, params.ModelUsage{}, errors.Trace(result.Error)
+	}
+	return result.Limits, result.Usage, nil
+}
+
+// SetModelLimits changes the machine and unit limits of the model with
+// the given tag. A limit of zero removes it.
+func (c *Client) SetModelLimits(tag names.ModelTag, limits params.ModelLimits) error {
+	var results params.ErrorResults
+	args := params.SetModelLimitsArgs{
+		Models: []params.SetModelLimits{{
+			ModelTag: tag.String(),
+			Limits:   limits,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetModelLimits", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ModelTeardownProgress returns the progress of the destruction of the
+// model with the given tag. Once the model has been removed, an error
+// satisfying params.IsCodeNotFound is returned.
+func (c *Client) ModelTeardownProgress(tag names.ModelTag) (params.ModelTeardownProgress, error) {
+	var results params.ModelTeardownProgressResults
+	entities := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := c.facade.FacadeCall("ModelTeardownProgress", entities, &results); err != nil {
+		return params.ModelTeardownProgress{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ModelTeardownProgress{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ModelTeardownProgress{}, errors.Trace(result.Error)
+	}
+	return *result.Result, nil
+}
+
 // ParseModelAccess parses an access permission argument into
 // a type suitable for making an API facade call.
 func ParseModelAccess(access string) (params.UserAccessPermission, error) {
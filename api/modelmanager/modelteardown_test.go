@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type modelTeardownSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&modelTeardownSuite{})
+
+func (s *modelTeardownSuite) TestModelTeardownProgress(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelTeardownProgress")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: testing.ModelTag.String()}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ModelTeardownProgressResults{})
+			*(result.(*params.ModelTeardownProgressResults)) = params.ModelTeardownProgressResults{
+				Results: []params.ModelTeardownProgressResult{{
+					Result: &params.ModelTeardownProgress{
+						Life:    params.Dying,
+						Units:   2,
+						Blocker: "waiting for 2 unit(s) to be removed",
+					},
+				}},
+			}
+			return nil
+		},
+	)
+	client := modelmanager.NewClient(apiCaller)
+	progress, err := client.ModelTeardownProgress(testing.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress, jc.DeepEquals, params.ModelTeardownProgress{
+		Life:    params.Dying,
+		Units:   2,
+		Blocker: "waiting for 2 unit(s) to be removed",
+	})
+}
+
+func (s *modelTeardownSuite) TestModelTeardownProgressNotFound(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.ModelTeardownProgressResults)) = params.ModelTeardownProgressResults{
+				Results: []params.ModelTeardownProgressResult{{
+					Error: &params.Error{Message: "model not found", Code: params.CodeNotFound},
+				}},
+			}
+			return nil
+		},
+	)
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ModelTeardownProgress(testing.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "model not found")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
@@ -8,6 +8,7 @@ import (
 
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
@@ -42,6 +43,12 @@ func (m *Machine) Life() params.Life {
 	return m.life
 }
 
+// InMaintenance returns whether the machine is in maintenance mode,
+// during which the provisioner should leave it alone.
+func (m *Machine) InMaintenance() (bool, error) {
+	return common.InMaintenance(m.st.facade, m.tag)
+}
+
 // Refresh updates the cached local copy of the machine's data.
 func (m *Machine) Refresh() error {
 	life, err := m.st.machineLife(m.tag)
@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/proxy"
+	"golang.org/x/net/websocket"
+)
+
+// dialFunc dials the given address, in the manner of net.Dial.
+type dialFunc func(network, addr string) (net.Conn, error)
+
+// dialer returns the dialFunc with which connections to the controller
+// should be made, or nil if they should be made directly.
+func (opts DialOpts) dialer() (dialFunc, error) {
+	if opts.ProxyURL == nil {
+		return nil, nil
+	}
+	dial, err := newProxyDialer(opts.ProxyURL)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot use proxy")
+	}
+	return dial, nil
+}
+
+// newProxyDialer returns a dialFunc which connects to addresses through
+// the proxy with the given URL. SOCKS5 proxies ("socks5://host:port")
+// and HTTP proxies supporting the CONNECT method ("http://host:port")
+// are supported.
+func newProxyDialer(proxyURL *url.URL) (dialFunc, error) {
+	switch proxyURL.Scheme {
+	case "socks5":
+		dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return dialer.Dial, nil
+	case "http":
+		return (&httpConnectDialer{proxyURL}).Dial, nil
+	}
+	return nil, errors.NotSupportedf("proxy scheme %q", proxyURL.Scheme)
+}
+
+// httpConnectDialer dials addresses by asking an HTTP proxy to
+// tunnel a connection to them.
+type httpConnectDialer struct {
+	proxyURL *url.URL
+}
+
+// Dial establishes a tunnelled connection to addr through the proxy.
+func (d *httpConnectDialer) Dial(network, addr string) (net.Conn, error) {
+	conn, err := net.Dial(network, d.proxyURL.Host)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req := &http.Request{
+		Method: "CONNECT",
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if user := d.proxyURL.User; user != nil {
+		password, _ := user.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, errors.Annotatef(err, "cannot send CONNECT request to proxy")
+	}
+	// The proxy will send nothing after the response until we
+	// start talking to addr, so it's safe to discard the reader.
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Annotatef(err, "cannot read CONNECT response from proxy")
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, errors.Errorf("proxy refused connection to %s: %s", addr, resp.Status)
+	}
+	return conn, nil
+}
+
+// dialWebsocketConfig is like websocket.DialConfig, except that if dial
+// is non-nil it is used to establish the underlying connection.
+func dialWebsocketConfig(cfg *websocket.Config, dial dialFunc) (*websocket.Conn, error) {
+	if dial == nil {
+		return websocket.DialConfig(cfg)
+	}
+	conn, err := dialTLS(dial, cfg.Location.Host, cfg.TlsConfig)
+	if err != nil {
+		return nil, &websocket.DialError{Config: cfg, Err: err}
+	}
+	ws, err := websocket.NewClient(cfg, conn)
+	if err != nil {
+		conn.Close()
+		return nil, &websocket.DialError{Config: cfg, Err: err}
+	}
+	return ws, nil
+}
+
+// dialTLS establishes a TLS connection to addr over a connection made
+// with the given dial function.
+func dialTLS(dial dialFunc, addr string, tlsConfig *tls.Config) (net.Conn, error) {
+	rawConn, err := dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig.ServerName == "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			rawConn.Close()
+			return nil, err
+		}
+		config := *tlsConfig
+		config.ServerName = host
+		tlsConfig = &config
+	}
+	conn := tls.Client(rawConn, tlsConfig)
+	if err := conn.Handshake(); err != nil {
+		rawConn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
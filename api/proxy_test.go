@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+)
+
+func (s *apiclientSuite) TestOpenThroughHTTPProxy(c *gc.C) {
+	proxy := newConnectProxy(c, http.StatusOK)
+	defer proxy.Close()
+
+	info := s.APIInfo(c)
+	st, err := api.Open(info, api.DialOpts{
+		ProxyURL: &url.URL{Scheme: "http", Host: proxy.Addr()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	c.Assert(st.Addr(), gc.Equals, info.Addrs[0])
+	c.Assert(proxy.Targets(), jc.DeepEquals, []string{info.Addrs[0]})
+}
+
+func (s *apiclientSuite) TestOpenThroughHTTPProxyRefused(c *gc.C) {
+	proxy := newConnectProxy(c, http.StatusForbidden)
+	defer proxy.Close()
+
+	info := s.APIInfo(c)
+	_, err := api.Open(info, api.DialOpts{
+		ProxyURL: &url.URL{Scheme: "http", Host: proxy.Addr()},
+	})
+	c.Assert(err, gc.ErrorMatches, `unable to connect to API: websocket.Dial wss://.*: proxy refused connection to .*: 403 Forbidden`)
+}
+
+func (s *apiclientSuite) TestOpenUnsupportedProxy(c *gc.C) {
+	info := s.APIInfo(c)
+	_, err := api.Open(info, api.DialOpts{
+		ProxyURL: &url.URL{Scheme: "ftp", Host: "10.0.0.1:21"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot use proxy: proxy scheme "ftp" not supported`)
+}
+
+func (s *apiclientSuite) TestOpenBadExtraCACert(c *gc.C) {
+	info := s.APIInfo(c)
+	_, err := api.Open(info, api.DialOpts{
+		ExtraCACerts: []string{"not a cert"},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot parse extra CA certificate: .*")
+}
+
+// connectProxy is a minimal HTTP proxy which tunnels connections
+// requested with the CONNECT method.
+type connectProxy struct {
+	listener net.Listener
+	status   int
+
+	mu      sync.Mutex
+	targets []string
+}
+
+func newConnectProxy(c *gc.C, status int) *connectProxy {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	p := &connectProxy{listener: listener, status: status}
+	go p.serve()
+	return p
+}
+
+func (p *connectProxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+func (p *connectProxy) Close() error {
+	return p.listener.Close()
+}
+
+func (p *connectProxy) Targets() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.targets...)
+}
+
+func (p *connectProxy) serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		go p.handle(conn)
+	}
+}
+
+func (p *connectProxy) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	req, err := http.ReadRequest(reader)
+	if err != nil || req.Method != "CONNECT" {
+		return
+	}
+	p.mu.Lock()
+	p.targets = append(p.targets, req.Host)
+	p.mu.Unlock()
+
+	if p.status != http.StatusOK {
+		fmt.Fprintf(conn, "HTTP/1.1 %d %s\r\n\r\n", p.status, http.StatusText(p.status))
+		return
+	}
+	target, err := net.Dial("tcp", req.Host)
+	if err != nil {
+		io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+		return
+	}
+	defer target.Close()
+	io.WriteString(conn, "HTTP/1.1 200 OK\r\n\r\n")
+	go io.Copy(target, reader)
+	io.Copy(conn, target)
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the Juju client command uses to
+// schedule operations on the applications of a model, and to list
+// and cancel them.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ScheduledOperations")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddScheduledOperation schedules the given operation, and returns
+// the id assigned to it.
+func (c *Client) AddScheduledOperation(op params.ScheduledOperation) (string, error) {
+	args := params.ScheduledOperationArgs{Args: []params.ScheduledOperation{op}}
+	var results params.AddScheduledOperationResults
+	if err := c.facade.FacadeCall("AddScheduledOperations", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Id, nil
+}
+
+// CancelScheduledOperations cancels the scheduled operations with the
+// given ids.
+func (c *Client) CancelScheduledOperations(ids ...string) error {
+	args := params.ScheduledOperationIds{Ids: ids}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("CancelScheduledOperations", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// ListScheduledOperations returns the scheduled operations of the
+// model, ordered by when they are due.
+func (c *Client) ListScheduledOperations() ([]params.ScheduledOperation, error) {
+	var results params.ListScheduledOperationsResults
+	if err := c.facade.FacadeCall("ListScheduledOperations", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Operations, nil
+}
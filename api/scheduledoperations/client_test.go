@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/scheduledoperations"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ScheduledOperationsSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ScheduledOperationsSuite{})
+
+func (s *ScheduledOperationsSuite) TestAddScheduledOperation(c *gc.C) {
+	op := params.ScheduledOperation{
+		Kind:        "upgrade-charm",
+		Application: "wordpress",
+		CharmURL:    "cs:quantal/wordpress-2",
+		Due:         time.Date(2016, 11, 5, 2, 0, 0, 0, time.UTC),
+	}
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ScheduledOperations")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AddScheduledOperations")
+			c.Check(a, jc.DeepEquals, params.ScheduledOperationArgs{
+				Args: []params.ScheduledOperation{op},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AddScheduledOperationResults{})
+			*(result.(*params.AddScheduledOperationResults)) = params.AddScheduledOperationResults{
+				Results: []params.AddScheduledOperationResult{{Id: "3"}},
+			}
+			called = true
+			return nil
+		},
+	)
+	client := scheduledoperations.NewClient(apiCaller)
+	id, err := client.AddScheduledOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "3")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *ScheduledOperationsSuite) TestAddScheduledOperationError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.AddScheduledOperationResults)) = params.AddScheduledOperationResults{
+				Results: []params.AddScheduledOperationResult{{
+					Error: &params.Error{Message: `application "mysql" not found`},
+				}},
+			}
+			return nil
+		},
+	)
+	client := scheduledoperations.NewClient(apiCaller)
+	_, err := client.AddScheduledOperation(params.ScheduledOperation{Kind: "scale-application", Application: "mysql"})
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
+
+func (s *ScheduledOperationsSuite) TestCancelScheduledOperations(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ScheduledOperations")
+			c.Check(request, gc.Equals, "CancelScheduledOperations")
+			c.Check(a, jc.DeepEquals, params.ScheduledOperationIds{
+				Ids: []string{"1", "9"},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {
+					Error: &params.Error{Message: "scheduled operation 9 not found"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := scheduledoperations.NewClient(apiCaller)
+	err := client.CancelScheduledOperations("1", "9")
+	c.Assert(err, gc.ErrorMatches, "scheduled operation 9 not found")
+}
+
+func (s *ScheduledOperationsSuite) TestListScheduledOperations(c *gc.C) {
+	ops := []params.ScheduledOperation{{
+		Id:          "1",
+		Kind:        "scale-application",
+		Application: "mysql",
+		Units:       1,
+		Due:         time.Date(2016, 11, 5, 2, 0, 0, 0, time.UTC),
+		Every:       7 * 24 * time.Hour,
+		Status:      "pending",
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ScheduledOperations")
+			c.Check(request, gc.Equals, "ListScheduledOperations")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ListScheduledOperationsResults{})
+			*(result.(*params.ListScheduledOperationsResults)) = params.ListScheduledOperationsResults{
+				Operations: ops,
+			}
+			return nil
+		},
+	)
+	client := scheduledoperations.NewClient(apiCaller)
+	result, err := client.ListScheduledOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, ops)
+}
@@ -18,6 +18,14 @@ import (
 // for claiming and observing administration responsibility for the apiCaller's
 // model, on behalf of the supplied controller machine.
 func NewAPI(apiCaller base.APICaller, controllerTag names.MachineTag) (*API, error) {
+	return NewWorkerAPI(apiCaller, controllerTag, "")
+}
+
+// NewWorkerAPI returns a new API client for the Singular facade, which
+// claims and observes the exclusive right to run the named worker for the
+// apiCaller's model, rather than responsibility for the whole model. If
+// workerName is empty, it behaves as NewAPI.
+func NewWorkerAPI(apiCaller base.APICaller, controllerTag names.MachineTag, workerName string) (*API, error) {
 	controllerId := controllerTag.Id()
 	if !names.IsValidMachine(controllerId) {
 		return nil, errors.NotValidf("controller tag")
@@ -30,6 +38,7 @@ func NewAPI(apiCaller base.APICaller, controllerTag names.MachineTag) (*API, err
 	return &API{
 		modelTag:      modelTag,
 		controllerTag: controllerTag,
+		workerName:    workerName,
 		facadeCaller:  facadeCaller,
 	}, nil
 }
@@ -39,6 +48,7 @@ func NewAPI(apiCaller base.APICaller, controllerTag names.MachineTag) (*API, err
 type API struct {
 	modelTag      names.ModelTag
 	controllerTag names.MachineTag
+	workerName    string
 	facadeCaller  base.FacadeCaller
 }
 
@@ -51,6 +61,7 @@ func (api *API) Claim(duration time.Duration) error {
 			ModelTag:      api.modelTag.String(),
 			ControllerTag: api.controllerTag.String(),
 			Duration:      duration,
+			Worker:        api.workerName,
 		}},
 	}
 	var results params.ErrorResults
@@ -74,6 +85,9 @@ func (api *API) Claim(duration time.Duration) error {
 // following the lease manager implementation underlying the original
 // leadership approach and it doesn't seem worth rewriting all that.
 func (api *API) Wait() error {
+	if api.workerName != "" {
+		return api.waitWorker()
+	}
 	args := params.Entities{
 		Entities: []params.Entity{{
 			Tag: api.modelTag.String(),
@@ -86,3 +100,19 @@ func (api *API) Wait() error {
 	}
 	return results.OneError()
 }
+
+// waitWorker blocks until nobody has the right to run the API's worker.
+func (api *API) waitWorker() error {
+	args := params.SingularWaits{
+		Waits: []params.SingularWait{{
+			ModelTag: api.modelTag.String(),
+			Worker:   api.workerName,
+		}},
+	}
+	var results params.ErrorResults
+	err := api.facadeCaller.FacadeCall("WaitWorkers", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
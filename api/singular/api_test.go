@@ -153,6 +153,46 @@ func (s *APISuite) TestWaitError(c *gc.C) {
 	})
 }
 
+func (s *APISuite) TestWorkerClaim(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(result *params.ErrorResults) error {
+		result.Results = []params.ErrorResult{{}}
+		return nil
+	})
+	api, err := singular.NewWorkerAPI(apiCaller, machine123, "storage-provisioner")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.Claim(time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+	checkCall(c, stub, "Claim", params.SingularClaims{
+		Claims: []params.SingularClaim{{
+			ModelTag:      "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			ControllerTag: "machine-123",
+			Duration:      time.Minute,
+			Worker:        "storage-provisioner",
+		}},
+	})
+}
+
+func (s *APISuite) TestWorkerWait(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(result *params.ErrorResults) error {
+		result.Results = []params.ErrorResult{{}}
+		return nil
+	})
+	api, err := singular.NewWorkerAPI(apiCaller, machine123, "storage-provisioner")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.Wait()
+	c.Check(err, jc.ErrorIsNil)
+	checkCall(c, stub, "WaitWorkers", params.SingularWaits{
+		Waits: []params.SingularWait{{
+			ModelTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Worker:   "storage-provisioner",
+		}},
+	})
+}
+
 type setResultFunc func(result *params.ErrorResults) error
 
 func apiCaller(c *gc.C, stub *testing.Stub, setResult setResultFunc) base.APICaller {
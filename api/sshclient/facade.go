@@ -4,6 +4,9 @@
 package sshclient
 
 import (
+	"io"
+	"net/url"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -90,6 +93,18 @@ func (facade *Facade) Proxy() (bool, error) {
 	return out.UseProxy, nil
 }
 
+// OpenTunnel returns a connection to the SSH port of the machine with
+// the given address, relayed through the controller. It allows SSH
+// connections to machines which the client cannot reach directly.
+func (facade *Facade) OpenTunnel(address string) (io.ReadWriteCloser, error) {
+	attrs := url.Values{"address": {address}}
+	conn, err := facade.caller.RawAPICaller().ConnectStream("/ssh-tunnel", attrs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot open SSH tunnel to %q", address)
+	}
+	return conn, nil
+}
+
 func targetToEntities(target string) (params.Entities, error) {
 	tag, err := targetToTag(target)
 	if err != nil {
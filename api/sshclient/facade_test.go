@@ -4,12 +4,15 @@
 package sshclient_test
 
 import (
+	"net/url"
+
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/base"
 	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/sshclient"
 	"github.com/juju/juju/apiserver/common"
@@ -223,3 +226,41 @@ func (s *FacadeSuite) TestProxyError(c *gc.C) {
 	_, err := facade.Proxy()
 	c.Check(err, gc.ErrorMatches, "boom")
 }
+
+func (s *FacadeSuite) TestOpenTunnel(c *gc.C) {
+	var stub jujutesting.Stub
+	stream := &fakeStream{}
+	facade := sshclient.NewFacade(&streamConnector{stub: &stub, stream: stream})
+	tunnel, err := facade.OpenTunnel("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tunnel, gc.Equals, stream)
+	stub.CheckCalls(c, []jujutesting.StubCall{{
+		"ConnectStream", []interface{}{"/ssh-tunnel", url.Values{"address": {"10.0.0.1"}}},
+	}})
+}
+
+func (s *FacadeSuite) TestOpenTunnelError(c *gc.C) {
+	var stub jujutesting.Stub
+	stub.SetErrors(errors.New("boom"))
+	facade := sshclient.NewFacade(&streamConnector{stub: &stub})
+	_, err := facade.OpenTunnel("10.0.0.1")
+	c.Check(err, gc.ErrorMatches, `cannot open SSH tunnel to "10.0.0.1": boom`)
+}
+
+type streamConnector struct {
+	apitesting.APICallerFunc
+	stub   *jujutesting.Stub
+	stream base.Stream
+}
+
+func (c *streamConnector) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	c.stub.AddCall("ConnectStream", path, attrs)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return c.stream, nil
+}
+
+type fakeStream struct {
+	base.Stream
+}
@@ -33,7 +33,8 @@ func (s *actionSuite) TestAction(c *gc.C) {
 	}, {
 		description: "An Action with nested parameters.",
 		action: params.Action{
-			Name: "fakeaction",
+			Name:          "fakeaction",
+			CorrelationId: "deadbeef",
 			Parameters: map[string]interface{}{
 				"outfile": "foo.bz2",
 				"compression": map[string]interface{}{
@@ -46,7 +47,8 @@ func (s *actionSuite) TestAction(c *gc.C) {
 
 	for i, actionTest := range actionTests {
 		c.Logf("test %d: %s", i, actionTest.description)
-		a, err := s.uniterSuite.wordpressUnit.AddAction(
+		a, err := s.uniterSuite.wordpressUnit.AddCorrelatedAction(
+			actionTest.action.CorrelationId,
 			actionTest.action.Name,
 			actionTest.action.Parameters)
 		c.Assert(err, jc.ErrorIsNil)
@@ -61,6 +63,7 @@ func (s *actionSuite) TestAction(c *gc.C) {
 
 		c.Assert(retrievedAction.Name(), gc.DeepEquals, actionTest.action.Name)
 		c.Assert(retrievedAction.Params(), gc.DeepEquals, actionTest.action.Parameters)
+		c.Assert(retrievedAction.CorrelationId(), gc.Equals, actionTest.action.CorrelationId)
 	}
 }
 
@@ -104,6 +104,63 @@ func (ru *RelationUnit) LeaveScope() error {
 	return result.OneError()
 }
 
+// PrepareLeaveScope causes the unit to be reported as departed by
+// watchers of its scope, but does not actually leave the scope. The
+// unit's counterparts will run relation-departed for it, and record
+// having done so; see PendingDepartures.
+func (ru *RelationUnit) PrepareLeaveScope() error {
+	var result params.ErrorResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("PrepareLeaveScope", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// SetCounterpartJoined records that the unit has run relation-joined
+// for the named counterpart unit.
+func (ru *RelationUnit) SetCounterpartJoined(uname string) error {
+	return ru.setCounterpart("SetCounterpartJoined", uname)
+}
+
+// SetCounterpartDeparted records that the unit has run relation-departed
+// for the named counterpart unit.
+func (ru *RelationUnit) SetCounterpartDeparted(uname string) error {
+	return ru.setCounterpart("SetCounterpartDeparted", uname)
+}
+
+func (ru *RelationUnit) setCounterpart(method, uname string) error {
+	if !names.IsValidUnit(uname) {
+		return errors.Errorf("%q is not a valid unit", uname)
+	}
+	var result params.ErrorResults
+	args := params.RelationUnitPairs{
+		RelationUnitPairs: []params.RelationUnitPair{{
+			Relation:   ru.relation.tag.String(),
+			LocalUnit:  ru.unit.tag.String(),
+			RemoteUnit: names.NewUnitTag(uname).String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall(method, args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// PendingDepartures returns the names of the counterpart units which
+// have run relation-joined for the unit, and not yet relation-departed.
+// The unit should not run relation-broken while any remain.
+func (ru *RelationUnit) PendingDepartures() ([]string, error) {
+	return ru.st.PendingDepartures(ru.relation.tag, ru.unit.tag)
+}
+
 // Settings returns a Settings which allows access to the unit's settings
 // within the relation.
 func (ru *RelationUnit) Settings() (*Settings, error) {
@@ -167,3 +224,9 @@ func (ru *RelationUnit) ReadSettings(uname string) (params.Settings, error) {
 func (ru *RelationUnit) Watch() (watcher.RelationUnitsWatcher, error) {
 	return ru.st.WatchRelationUnits(ru.relation.tag, ru.unit.tag)
 }
+
+// WatchPendingDepartures returns a watcher that notifies when the
+// unit's pending departures may have changed.
+func (ru *RelationUnit) WatchPendingDepartures() (watcher.NotifyWatcher, error) {
+	return ru.st.WatchPendingDepartures(ru.relation.tag, ru.unit.tag)
+}
@@ -289,3 +289,68 @@ func (s *relationUnitSuite) TestWatchRelationUnits(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertNoChange()
 }
+
+func (s *relationUnitSuite) TestPrepareLeaveScope(c *gc.C) {
+	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
+	err := wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = apiRelUnit.PrepareLeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertInScope(c, wpRelUnit, true)
+	joined, err := wpRelUnit.Joined()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(joined, jc.IsFalse)
+}
+
+func (s *relationUnitSuite) TestSetCounterpartJoinedAndDeparted(c *gc.C) {
+	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
+	err := wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	myRelUnit, err := s.stateRelation.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = myRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = apiRelUnit.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := myRelUnit.PendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.DeepEquals, []string{"wordpress/0"})
+
+	err = apiRelUnit.SetCounterpartDeparted("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err = myRelUnit.PendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+
+	err = apiRelUnit.SetCounterpartJoined("mysql")
+	c.Assert(err, gc.ErrorMatches, `"mysql" is not a valid unit`)
+}
+
+func (s *relationUnitSuite) TestPendingDepartures(c *gc.C) {
+	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
+	err := wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	myRelUnit, err := s.stateRelation.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = myRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := apiRelUnit.WatchPendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+	wc.AssertOneChange()
+
+	pending, err := apiRelUnit.PendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+
+	err = myRelUnit.SetCounterpartJoined("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	pending, err = apiRelUnit.PendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.DeepEquals, []string{"mysql/0"})
+}
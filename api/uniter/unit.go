@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
 )
@@ -129,6 +130,43 @@ func (u *Unit) AddMetrics(metrics []params.Metric) error {
 	return result.OneError()
 }
 
+// RecordHookTiming records an execution of a hook by the unit.
+func (u *Unit) RecordHookTiming(timing params.HookTiming) error {
+	var result params.ErrorResults
+	args := params.HookTimingArgs{
+		Args: []params.UnitHookTiming{{
+			Tag:    u.tag.String(),
+			Timing: timing,
+		}},
+	}
+	err := u.st.facade.FacadeCall("RecordHookTimings", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// HookSandbox returns the confinement in which the unit's hooks and
+// actions are run.
+func (u *Unit) HookSandbox() (params.HookSandbox, error) {
+	var results params.HookSandboxResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("HookSandbox", args, &results)
+	if err != nil {
+		return params.HookSandbox{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.HookSandbox{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.HookSandbox{}, result.Error
+	}
+	return result.Result, nil
+}
+
 // AddMetricsBatches makes an api call to the uniter requesting it to store metrics batches in state.
 func (u *Unit) AddMetricBatches(batches []params.MetricBatch) (map[string]error, error) {
 	p := params.MetricBatchParams{
@@ -304,6 +342,68 @@ func (u *Unit) AssignedMachine() (names.MachineTag, error) {
 	return names.ParseMachineTag(result.Result)
 }
 
+// MachineInMaintenance returns whether the machine the unit is assigned
+// to is in maintenance mode, during which nonessential hooks should not
+// be run.
+func (u *Unit) MachineInMaintenance() (bool, error) {
+	var results params.BoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("MachineInMaintenance", args, &results)
+	if err != nil {
+		return false, err
+	}
+	if len(results.Results) != 1 {
+		return false, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
+// UpgradeSeriesStatus returns the unit's progress through the series
+// upgrade of its machine. The status is empty if no series upgrade is
+// in progress, or the unit is not taking part in it.
+func (u *Unit) UpgradeSeriesStatus() (upgradeseries.Status, error) {
+	var results params.UpgradeSeriesStatusResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UpgradeSeriesUnitStatus", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return upgradeseries.Status(result.Status), nil
+}
+
+// SetUpgradeSeriesStatus records that the unit has run its
+// pre-series-upgrade hook, with status PrepareCompleted, or its
+// post-series-upgrade hook, with status Completed.
+func (u *Unit) SetUpgradeSeriesStatus(status upgradeseries.Status) error {
+	var results params.ErrorResults
+	args := params.UpgradeSeriesStatusArgs{
+		Args: []params.UpgradeSeriesStatusArg{{
+			Entity: params.Entity{Tag: u.tag.String()},
+			Status: string(status),
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetUpgradeSeriesUnitStatus", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
 // IsPrincipal returns whether the unit is deployed in its own container,
 // and can therefore have subordinate services deployed alongside it.
 //
@@ -548,6 +648,28 @@ func (u *Unit) WatchConfigSettings() (watcher.NotifyWatcher, error) {
 	return w, nil
 }
 
+// WatchUpgradeSeriesNotifications returns a watcher for observing
+// changes to the series upgrade of the unit's machine.
+func (u *Unit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WatchUpgradeSeriesNotifications", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // WatchAddresses returns a watcher for observing changes to the
 // unit's addresses. The unit must be assigned to a machine before
 // this method is called, and the returned watcher will be valid only
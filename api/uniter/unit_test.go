@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -261,6 +262,50 @@ func (s *unitSuite) TestAssignedMachine(c *gc.C) {
 	c.Assert(machineTag, gc.Equals, s.wordpressMachine.Tag())
 }
 
+func (s *unitSuite) TestMachineInMaintenance(c *gc.C) {
+	inMaintenance, err := s.apiUnit.MachineInMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inMaintenance, jc.IsFalse)
+
+	err = s.wordpressMachine.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	inMaintenance, err = s.apiUnit.MachineInMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inMaintenance, jc.IsTrue)
+}
+
+func (s *unitSuite) TestUpgradeSeriesStatus(c *gc.C) {
+	status, err := s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, upgradeseries.Status(""))
+
+	err = s.wordpressMachine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, upgradeseries.PrepareStarted)
+
+	err = s.apiUnit.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, upgradeseries.PrepareCompleted)
+}
+
+func (s *unitSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	w, err := s.apiUnit.WatchUpgradeSeriesNotifications()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = s.wordpressMachine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *unitSuite) TestIsPrincipal(c *gc.C) {
 	ok, err := s.apiUnit.IsPrincipal()
 	c.Assert(err, jc.ErrorIsNil)
@@ -676,6 +721,38 @@ func (s *unitSuite) TestAddMetricsResultError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error adding metrics")
 }
 
+func (s *unitSuite) TestRecordHookTiming(c *gc.C) {
+	queued := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	err := s.apiUnit.RecordHookTiming(params.HookTiming{
+		Hook:     "install",
+		Queued:   queued,
+		Started:  queued.Add(time.Second),
+		Finished: queued.Add(time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	timings, err := s.wordpressUnit.HookTimings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timings, jc.DeepEquals, []state.HookTiming{{
+		Hook:     "install",
+		Queued:   queued,
+		Started:  queued.Add(time.Second),
+		Finished: queued.Add(time.Minute),
+	}})
+}
+
+func (s *unitSuite) TestHookSandbox(c *gc.C) {
+	sandbox, err := s.apiUnit.HookSandbox()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sandbox, gc.Equals, params.HookSandbox{})
+
+	err = s.wordpressService.SetHookSandbox(state.HookSandbox{User: "hooks", CPUQuota: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	sandbox, err = s.apiUnit.HookSandbox()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sandbox, gc.Equals, params.HookSandbox{User: "hooks", CPUQuota: 50})
+}
+
 func (s *unitSuite) TestMeterStatus(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "GetMeterStatus",
 		func(results interface{}) error {
@@ -217,8 +217,9 @@ func (st *State) Action(tag names.ActionTag) (*Action, error) {
 		return nil, err
 	}
 	return &Action{
-		name:   result.Action.Name,
-		params: result.Action.Parameters,
+		name:          result.Action.Name,
+		params:        result.Action.Parameters,
+		correlationId: result.Action.CorrelationId,
 	}, nil
 }
 
@@ -379,6 +380,62 @@ func (st *State) WatchRelationUnits(
 	return w, nil
 }
 
+// PendingDepartures returns the names of the counterpart units which
+// have run relation-joined for the unit in the relation, and not yet
+// relation-departed.
+func (st *State) PendingDepartures(
+	relationTag names.RelationTag,
+	unitTag names.UnitTag,
+) ([]string, error) {
+	var results params.StringsResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: relationTag.String(),
+			Unit:     unitTag.String(),
+		}},
+	}
+	err := st.facade.FacadeCall("PendingDepartures", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
+// WatchPendingDepartures returns a watcher that notifies when the
+// pending departures of the unit in the relation may have changed.
+func (st *State) WatchPendingDepartures(
+	relationTag names.RelationTag,
+	unitTag names.UnitTag,
+) (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: relationTag.String(),
+			Unit:     unitTag.String(),
+		}},
+	}
+	err := st.facade.FacadeCall("WatchPendingDepartures", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // ErrIfNotVersionFn returns a function which can be used to check for
 // the minimum supported version, and, if appropriate, generate an
 // error.
@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeprechecks
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the upgrade-juju command uses to verify
+// that a model is fit to be upgraded.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "UpgradePrechecks")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Prechecks returns the outcome of the checks made before upgrading
+// the model's agents.
+func (c *Client) Prechecks() ([]params.UpgradePrecheck, error) {
+	var results params.UpgradePrecheckResults
+	if err := c.facade.FacadeCall("Prechecks", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Checks, nil
+}
+
+// SkipPrechecks records that an upgrade to the given version is being
+// started without the prechecks.
+func (c *Client) SkipPrechecks(vers version.Number) error {
+	args := params.SkipUpgradePrechecks{Version: vers}
+	return errors.Trace(c.facade.FacadeCall("SkipPrechecks", args, nil))
+}
@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeprechecks_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/upgradeprechecks"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type UpgradePrechecksSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&UpgradePrechecksSuite{})
+
+func (s *UpgradePrechecksSuite) TestPrechecks(c *gc.C) {
+	expected := []params.UpgradePrecheck{
+		{Name: "agents", Passed: true},
+		{Name: "disk", Message: "less than 1024MB free disk space on machines 0 (512 MiB free)"},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "UpgradePrechecks")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Prechecks")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.UpgradePrecheckResults{})
+			*(result.(*params.UpgradePrecheckResults)) = params.UpgradePrecheckResults{
+				Checks: expected,
+			}
+			return nil
+		},
+	)
+	client := upgradeprechecks.NewClient(apiCaller)
+	checks, err := client.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, expected)
+}
+
+func (s *UpgradePrechecksSuite) TestSkipPrechecks(c *gc.C) {
+	vers := version.MustParse("2.0.1")
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "UpgradePrechecks")
+			c.Check(request, gc.Equals, "SkipPrechecks")
+			c.Check(a, jc.DeepEquals, params.SkipUpgradePrechecks{Version: vers})
+			return errors.New("permission denied")
+		},
+	)
+	client := upgradeprechecks.NewClient(apiCaller)
+	err := client.SkipPrechecks(vers)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,48 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usage
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods that the Juju client command uses to
+// read the resource usage reported by the machines of a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Usage")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ModelUsage returns the resource usage last reported by each of the
+// machines of the model. Machines which have not yet reported their
+// usage are omitted.
+func (c *Client) ModelUsage() ([]params.MachineUsage, error) {
+	var result params.ModelUsageResult
+	if err := c.facade.FacadeCall("ModelUsage", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Machines, nil
+}
+
+// ApplicationTraffic returns the network traffic accounted for each
+// application of the model, and whether traffic is currently being
+// accounted. The traffic of each machine is included in the results
+// of ModelUsage.
+func (c *Client) ApplicationTraffic() ([]params.ApplicationTraffic, bool, error) {
+	var result params.ModelUsageResult
+	if err := c.facade.FacadeCall("ModelUsage", nil, &result); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return result.Applications, result.TrafficAccounting, nil
+}
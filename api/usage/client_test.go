@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usage_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/usage"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type UsageSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&UsageSuite{})
+
+func (s *UsageSuite) TestModelUsage(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Usage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelUsage")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ModelUsageResult{})
+			*(result.(*params.ModelUsageResult)) = params.ModelUsageResult{
+				Machines: []params.MachineUsage{{
+					Tag:        "machine-0",
+					CPUPercent: 12.5,
+				}},
+			}
+			return nil
+		},
+	)
+	client := usage.NewClient(apiCaller)
+	machines, err := client.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []params.MachineUsage{{
+		Tag:        "machine-0",
+		CPUPercent: 12.5,
+	}})
+}
+
+func (s *UsageSuite) TestModelUsageError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		},
+	)
+	client := usage.NewClient(apiCaller)
+	_, err := client.ModelUsage()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *UsageSuite) TestApplicationTraffic(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Usage")
+			c.Check(request, gc.Equals, "ModelUsage")
+			*(result.(*params.ModelUsageResult)) = params.ModelUsageResult{
+				TrafficAccounting: true,
+				Applications: []params.ApplicationTraffic{{
+					Tag:         "application-mysql",
+					Received:    1000,
+					Transmitted: 100,
+				}},
+			}
+			return nil
+		},
+	)
+	client := usage.NewClient(apiCaller)
+	applications, enabled, err := client.ApplicationTraffic()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enabled, jc.IsTrue)
+	c.Assert(applications, jc.DeepEquals, []params.ApplicationTraffic{{
+		Tag:         "application-mysql",
+		Received:    1000,
+		Transmitted: 100,
+	}})
+}
@@ -0,0 +1,39 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package usagereporter implements the client-side API facade used
+// by the usagereporter worker.
+package usagereporter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the UsageReporter API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side UsageReporter facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "UsageReporter"),
+	}
+}
+
+// ReportUsage reports the resource usage of a machine, and of the
+// units deployed to it, to the controller. The tag of the usage is
+// set from the machine id.
+func (f *Facade) ReportUsage(machineId string, usage params.MachineUsage) error {
+	usage.Tag = names.NewMachineTag(machineId).String()
+	args := params.MachineUsageSet{Usage: []params.MachineUsage{usage}}
+	var result params.ErrorResults
+	err := f.caller.FacadeCall("ReportUsage", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usagereporter_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/usagereporter"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestReportUsage(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "UsageReporter")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				(*params.Error)(nil),
+			}},
+		}
+		return nil
+	})
+	facade := usagereporter.NewFacade(apiCaller)
+
+	err := facade.ReportUsage("42", params.MachineUsage{
+		CPUPercent:  50,
+		MemoryUsed:  1024,
+		MemoryTotal: 2048,
+		Units: []params.UnitUsage{{
+			Tag:        "unit-mysql-0",
+			CPUPercent: 25,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"ReportUsage", []interface{}{params.MachineUsageSet{
+			Usage: []params.MachineUsage{{
+				Tag:         "machine-42",
+				CPUPercent:  50,
+				MemoryUsed:  1024,
+				MemoryTotal: 2048,
+				Units: []params.UnitUsage{{
+					Tag:        "unit-mysql-0",
+					CPUPercent: 25,
+				}},
+			}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := usagereporter.NewFacade(apiCaller)
+
+	err := facade.ReportUsage("42", params.MachineUsage{})
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestInnerError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				&params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := usagereporter.NewFacade(apiCaller)
+
+	err := facade.ReportUsage("42", params.MachineUsage{})
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
@@ -6,6 +6,7 @@ package usermanager
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"gopkg.in/macaroon.v1"
 
@@ -214,3 +215,49 @@ func (c *Client) CreateLocalLoginMacaroon(tag names.UserTag) (*macaroon.Macaroon
 	}
 	return result.Result, nil
 }
+
+// CreateRegistrationToken assigns the specified existing user a new
+// secret key with which they may register with the controller, and
+// returns it with the time after which it may no longer be used. If
+// lifetime is zero, the controller's default lifetime is used.
+func (c *Client) CreateRegistrationToken(username string, lifetime time.Duration) ([]byte, time.Time, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, time.Time{}, errors.NotSupportedf("creating registration tokens")
+	}
+	if !names.IsValidUser(username) {
+		return nil, time.Time{}, errors.Errorf("%q is not a valid username", username)
+	}
+	args := params.CreateRegistrationTokens{
+		Tokens: []params.CreateRegistrationToken{{
+			UserTag:  names.NewUserTag(username).String(),
+			Lifetime: lifetime,
+		}},
+	}
+	var results params.RegistrationTokenResults
+	if err := c.facade.FacadeCall("CreateRegistrationTokens", args, &results); err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		logger.Errorf("expected 1 result, got %#v", results)
+		return nil, time.Time{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, time.Time{}, errors.Trace(result.Error)
+	}
+	return result.SecretKey, result.Expiry, nil
+}
+
+// WhoAmI returns the identity with which the connection was
+// authenticated, the access it has been granted to the controller and
+// its models, and when the macaroons used to log in expire.
+func (c *Client) WhoAmI() (params.WhoAmIResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.WhoAmIResult{}, errors.NotSupportedf("whoami")
+	}
+	var result params.WhoAmIResult
+	if err := c.facade.FacadeCall("WhoAmI", nil, &result); err != nil {
+		return params.WhoAmIResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
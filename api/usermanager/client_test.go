@@ -4,6 +4,8 @@
 package usermanager_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -248,3 +250,34 @@ func (s *usermanagerSuite) TestSetUserPasswordBadName(c *gc.C) {
 	err := s.usermanager.SetPassword("not!good", "new-password")
 	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
 }
+
+func (s *usermanagerSuite) TestCreateRegistrationToken(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	secretKey, expiry, err := s.usermanager.CreateRegistrationToken("foobar", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secretKey, gc.HasLen, 32)
+
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.SecretKey(), jc.DeepEquals, secretKey)
+	c.Assert(user.SecretKeyExpiry().Equal(expiry), jc.IsTrue)
+}
+
+func (s *usermanagerSuite) TestCreateRegistrationTokenBadName(c *gc.C) {
+	_, _, err := s.usermanager.CreateRegistrationToken("not!good", time.Hour)
+	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
+}
+
+func (s *usermanagerSuite) TestWhoAmI(c *gc.C) {
+	result, err := s.usermanager.WhoAmI()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.UserTag, gc.Equals, s.AdminUserTag(c).String())
+	c.Assert(result.ControllerAccess, gc.Equals, "superuser")
+	c.Assert(result.Models, gc.HasLen, 1)
+	c.Assert(result.Models[0], jc.DeepEquals, params.WhoAmIModel{
+		ModelTag: s.State.ModelTag().String(),
+		Name:     "controller",
+		OwnerTag: s.AdminUserTag(c).String(),
+		Access:   "admin",
+	})
+}
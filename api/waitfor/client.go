@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package waitfor provides access to the WaitFor API facade, which
+// blocks until conditions about the entities in a model are met.
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the WaitFor API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the WaitFor API.
+func NewClient(callCloser base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(callCloser, "WaitFor")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// WaitFor blocks until all the supplied conditions are met, or until
+// the timeout expires. The controller caps the time a single call may
+// block, so the result may report unsatisfied conditions before the
+// timeout has passed.
+func (c *Client) WaitFor(conditions []params.WaitForCondition, timeout time.Duration) (params.WaitForResult, error) {
+	args := params.WaitForArgs{
+		Conditions: conditions,
+		Timeout:    timeout,
+	}
+	var result params.WaitForResult
+	if err := c.facade.FacadeCall("WaitFor", args, &result); err != nil {
+		return params.WaitForResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/waitfor"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ClientSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestWaitFor(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		c.Check(id, gc.Equals, "")
+		*result.(*params.WaitForResult) = params.WaitForResult{
+			Unsatisfied: []string{`unit-mysql-0 status == "active"`},
+		}
+		return nil
+	})
+	client := waitfor.NewClient(apiCaller)
+	conditions := []params.WaitForCondition{{
+		Tag:   "unit-mysql-0",
+		Field: "status",
+		Op:    "==",
+		Value: "active",
+	}}
+
+	result, err := client.WaitFor(conditions, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.WaitForResult{
+		Unsatisfied: []string{`unit-mysql-0 status == "active"`},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{{
+		"WaitFor.WaitFor", []interface{}{params.WaitForArgs{
+			Conditions: conditions,
+			Timeout:    time.Minute,
+		}},
+	}})
+}
+
+func (s *ClientSuite) TestWaitForError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := waitfor.NewClient(apiCaller)
+
+	_, err := client.WaitFor(nil, time.Minute)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
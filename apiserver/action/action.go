@@ -5,15 +5,20 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"golang.org/x/net/context"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/correlation"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/state"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.action")
+
 func init() {
 	common.RegisterStandardFacade("Action", 2, NewActionAPI)
 }
@@ -147,7 +152,7 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // the designated ActionReceiver, returning the params.Action for each
 // enqueued Action, or an error if there was a problem enqueueing the
 // Action.
-func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
+func (a *ActionAPI) Enqueue(ctx context.Context, arg params.Actions) (params.ActionResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
@@ -156,6 +161,7 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
+	logger := correlation.ContextLogger(ctx, logger)
 	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
 	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
 	for i, action := range arg.Actions {
@@ -165,11 +171,12 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := receiver.AddCorrelatedAction(correlation.Id(ctx), action.Name, action.Parameters)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
+		logger.Debugf("enqueued action %q for %s as %s", action.Name, action.Receiver, enqueued.Id())
 
 		response.Results[i] = common.MakeActionResult(receiver.Tag(), enqueued)
 	}
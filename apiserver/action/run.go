@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
+	"golang.org/x/net/context"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -43,7 +44,7 @@ func getAllUnitNames(st *state.State, units, services []string) (result []names.
 
 // Run the commands specified on the machines identified through the
 // list of machines, units and services.
-func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err error) {
+func (a *ActionAPI) Run(ctx context.Context, run params.RunParams) (results params.ActionResults, err error) {
 	if err := a.checkCanWrite(); err != nil {
 		return results, err
 	}
@@ -66,11 +67,11 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err
 
 	actionParams := a.createActionsParams(append(units, machines...), run.Commands, run.Timeout)
 
-	return queueActions(a, actionParams)
+	return queueActions(ctx, a, actionParams)
 }
 
 // RunOnAllMachines attempts to run the specified command on all the machines.
-func (a *ActionAPI) RunOnAllMachines(run params.RunParams) (results params.ActionResults, err error) {
+func (a *ActionAPI) RunOnAllMachines(ctx context.Context, run params.RunParams) (results params.ActionResults, err error) {
 	if err := a.checkCanWrite(); err != nil {
 		return results, err
 	}
@@ -90,7 +91,7 @@ func (a *ActionAPI) RunOnAllMachines(run params.RunParams) (results params.Actio
 
 	actionParams := a.createActionsParams(machineTags, run.Commands, run.Timeout)
 
-	return queueActions(a, actionParams)
+	return queueActions(ctx, a, actionParams)
 }
 
 func (a *ActionAPI) createActionsParams(actionReceiverTags []names.Tag, quotedCommands string, timeout time.Duration) params.Actions {
@@ -112,6 +113,6 @@ func (a *ActionAPI) createActionsParams(actionReceiverTags []names.Tag, quotedCo
 	return apiActionParams
 }
 
-var queueActions = func(a *ActionAPI, args params.Actions) (results params.ActionResults, err error) {
-	return a.Enqueue(args)
+var queueActions = func(ctx context.Context, a *ActionAPI, args params.Actions) (results params.ActionResults, err error) {
+	return a.Enqueue(ctx, args)
 }
@@ -6,6 +6,7 @@ package action_test
 import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/action"
@@ -149,7 +150,7 @@ func (s *runSuite) AssertBlocked(c *gc.C, err error, msg string) {
 func (s *runSuite) TestBlockRunOnAllMachines(c *gc.C) {
 	// block all changes
 	s.BlockAllChanges(c, "TestBlockRunOnAllMachines")
-	_, err := s.client.RunOnAllMachines(
+	_, err := s.client.RunOnAllMachines(context.Background(),
 		params.RunParams{
 			Commands: "hostname",
 			Timeout:  testing.LongWait,
@@ -160,7 +161,7 @@ func (s *runSuite) TestBlockRunOnAllMachines(c *gc.C) {
 func (s *runSuite) TestBlockRunMachineAndService(c *gc.C) {
 	// block all changes
 	s.BlockAllChanges(c, "TestBlockRunMachineAndService")
-	_, err := s.client.Run(
+	_, err := s.client.Run(context.Background(),
 		params.RunParams{
 			Commands:     "hostname",
 			Timeout:      testing.LongWait,
@@ -185,7 +186,7 @@ func (s *runSuite) TestRunMachineAndService(c *gc.C) {
 		},
 	}
 	called := false
-	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+	s.PatchValue(action.QueueActions, func(ctx context.Context, client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
 		called = true
 		c.Assert(args, jc.DeepEquals, expectedArgs)
 		return params.ActionResults{}, nil
@@ -199,7 +200,7 @@ func (s *runSuite) TestRunMachineAndService(c *gc.C) {
 	s.addUnit(c, magic)
 	s.addUnit(c, magic)
 
-	s.client.Run(
+	s.client.Run(context.Background(),
 		params.RunParams{
 			Commands:     "hostname",
 			Machines:     []string{"0"},
@@ -223,7 +224,7 @@ func (s *runSuite) TestRunOnAllMachines(c *gc.C) {
 		},
 	}
 	called := false
-	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+	s.PatchValue(action.QueueActions, func(ctx context.Context, client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
 		called = true
 		c.Assert(args, jc.DeepEquals, expectedArgs)
 		return params.ActionResults{}, nil
@@ -233,7 +234,7 @@ func (s *runSuite) TestRunOnAllMachines(c *gc.C) {
 	s.addMachine(c)
 	s.addMachine(c)
 
-	s.client.RunOnAllMachines(
+	s.client.RunOnAllMachines(context.Background(),
 		params.RunParams{
 			Commands: "hostname",
 			Timeout:  testing.LongWait,
@@ -0,0 +1,187 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"golang.org/x/net/websocket"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// actionResultsSource defines the state methods used to stream the
+// results of actions.
+type actionResultsSource interface {
+	ActionByTag(names.ActionTag) (state.Action, error)
+	WatchActionResults() state.StringsWatcher
+}
+
+// actionResultsHandler streams the results of actions to a client as
+// each action finishes. It allows "juju run" to report the output of
+// each target as soon as it is available, rather than polling for the
+// results of all targets.
+type actionResultsHandler struct {
+	ctxt httpContext
+}
+
+func newActionResultsHandler(ctxt httpContext) *actionResultsHandler {
+	return &actionResultsHandler{ctxt: ctxt}
+}
+
+// ServeHTTP will serve up connections as a websocket, sending a
+// params.ActionResult as a JSON message for each of the requested
+// actions when it finishes. The connection is closed once the results
+// of all the actions have been sent.
+//
+// Args for the HTTP request are as follows:
+//   action -> string - the id of an action; may be given more than once
+func (h *actionResultsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	server := websocket.Server{
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+
+			source, actionTags, err := h.parseRequest(req)
+			stream, initErr := initStream(conn, err)
+			if initErr != nil {
+				logger.Debugf("failed to send initial error (%v): %v", err, initErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			send := func(result params.ActionResult) error {
+				return stream.codec.Send(stream.conn, result)
+			}
+			if err := streamActionResults(source, actionTags, send, h.ctxt.stop()); err != nil {
+				if isBrokenPipe(err) {
+					logger.Tracef("action results stream stopped (client disconnected)")
+				} else {
+					logger.Errorf("action results stream error: %v", err)
+				}
+			}
+		},
+	}
+	server.ServeHTTP(w, req)
+}
+
+// parseRequest authenticates the request and returns the tags of the
+// actions it names.
+func (h *actionResultsHandler) parseRequest(req *http.Request) (actionResultsSource, []names.ActionTag, error) {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	canRead, err := hasPermission(st.UserAccess, entity.Tag(), description.ReadAccess, st.ModelTag())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if !canRead {
+		return nil, nil, common.ErrPerm
+	}
+
+	ids := req.URL.Query()["action"]
+	if len(ids) == 0 {
+		return nil, nil, errors.NotValidf("empty action list")
+	}
+	actionTags := make([]names.ActionTag, len(ids))
+	for i, id := range ids {
+		if !names.IsValidAction(id) {
+			return nil, nil, errors.NotValidf("action id %q", id)
+		}
+		actionTags[i] = names.NewActionTag(id)
+	}
+	return st, actionTags, nil
+}
+
+// streamActionResults calls send with the result of each of the given
+// actions once the action has finished. It returns when the results of
+// all the actions have been sent, or when stop is closed.
+func streamActionResults(
+	source actionResultsSource,
+	actionTags []names.ActionTag,
+	send func(params.ActionResult) error,
+	stop <-chan struct{},
+) error {
+	// Start watching before looking at the actions, so that no
+	// action can finish unnoticed.
+	w := source.WatchActionResults()
+	defer w.Stop()
+
+	pending := set.NewStrings()
+	for _, tag := range actionTags {
+		pending.Add(tag.Id())
+	}
+	sendFinished := func(ids []string) error {
+		for _, id := range ids {
+			if !pending.Contains(id) {
+				continue
+			}
+			result, finished, err := actionResult(source, names.NewActionTag(id))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !finished {
+				continue
+			}
+			pending.Remove(id)
+			if err := send(result); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
+
+	if err := sendFinished(pending.SortedValues()); err != nil {
+		return errors.Trace(err)
+	}
+	for !pending.IsEmpty() {
+		select {
+		case <-stop:
+			return nil
+		case ids, ok := <-w.Changes():
+			if !ok {
+				return errors.Trace(watcher.EnsureErr(w))
+			}
+			if err := sendFinished(ids); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// actionResult returns the result of the action with the given tag, and
+// whether the action has finished. Errors which relate to the action
+// alone are reported in the result.
+func actionResult(source actionResultsSource, tag names.ActionTag) (params.ActionResult, bool, error) {
+	action, err := source.ActionByTag(tag)
+	if errors.IsNotFound(err) {
+		return params.ActionResult{
+			Action: &params.Action{Tag: tag.String()},
+			Error:  common.ServerError(err),
+		}, true, nil
+	} else if err != nil {
+		return params.ActionResult{}, false, errors.Trace(err)
+	}
+	switch action.Status() {
+	case state.ActionPending, state.ActionRunning:
+		return params.ActionResult{}, false, nil
+	}
+	receiverTag, err := names.ActionReceiverTag(action.Receiver())
+	if err != nil {
+		return params.ActionResult{
+			Action: &params.Action{Tag: tag.String()},
+			Error:  common.ServerError(err),
+		}, true, nil
+	}
+	return common.MakeActionResult(receiverTag, action), true, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type actionResultsIntSuite struct {
+	coretesting.BaseSuite
+	source *fakeActionResultsSource
+}
+
+var _ = gc.Suite(&actionResultsIntSuite{})
+
+const (
+	actionId1 = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	actionId2 = "6ba7b810-9dad-41d1-80b4-00c04fd430c8"
+)
+
+func (s *actionResultsIntSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.source = &fakeActionResultsSource{
+		actions: map[string]*fakeAction{
+			actionId1: {id: actionId1, receiver: "mysql/0", status: state.ActionCompleted},
+			actionId2: {id: actionId2, receiver: "0", status: state.ActionRunning},
+		},
+		changes: make(chan []string, 1),
+	}
+}
+
+func (s *actionResultsIntSuite) TestStreamsAsActionsFinish(c *gc.C) {
+	var sent []params.ActionResult
+	send := func(result params.ActionResult) error {
+		sent = append(sent, result)
+		if len(sent) == 1 {
+			// The second action finishes after the first result
+			// has been sent.
+			s.source.actions[actionId2].status = state.ActionFailed
+			s.source.changes <- []string{actionId2}
+		}
+		return nil
+	}
+	tags := []names.ActionTag{names.NewActionTag(actionId1), names.NewActionTag(actionId2)}
+	err := streamActionResults(s.source, tags, send, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(sent, gc.HasLen, 2)
+	c.Check(sent[0].Action.Tag, gc.Equals, names.NewActionTag(actionId1).String())
+	c.Check(sent[0].Action.Receiver, gc.Equals, "unit-mysql-0")
+	c.Check(sent[0].Status, gc.Equals, "completed")
+	c.Check(sent[0].Output, jc.DeepEquals, map[string]interface{}{"Stdout": "hello"})
+	c.Check(sent[1].Action.Tag, gc.Equals, names.NewActionTag(actionId2).String())
+	c.Check(sent[1].Action.Receiver, gc.Equals, "machine-0")
+	c.Check(sent[1].Status, gc.Equals, "failed")
+	c.Check(s.source.stopped, jc.IsTrue)
+}
+
+func (s *actionResultsIntSuite) TestIgnoresOtherActions(c *gc.C) {
+	var sent []params.ActionResult
+	send := func(result params.ActionResult) error {
+		sent = append(sent, result)
+		return nil
+	}
+	s.source.changes <- []string{actionId1}
+	stop := make(chan struct{})
+	close(stop)
+	err := streamActionResults(s.source, []names.ActionTag{names.NewActionTag(actionId2)}, send, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sent, gc.HasLen, 0)
+}
+
+func (s *actionResultsIntSuite) TestActionNotFound(c *gc.C) {
+	delete(s.source.actions, actionId1)
+	var sent []params.ActionResult
+	send := func(result params.ActionResult) error {
+		sent = append(sent, result)
+		return nil
+	}
+	err := streamActionResults(s.source, []names.ActionTag{names.NewActionTag(actionId1)}, send, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sent, gc.HasLen, 1)
+	c.Assert(sent[0].Error, gc.ErrorMatches, "action .* not found")
+}
+
+func (s *actionResultsIntSuite) TestSendError(c *gc.C) {
+	send := func(result params.ActionResult) error {
+		return errors.New("boom")
+	}
+	err := streamActionResults(s.source, []names.ActionTag{names.NewActionTag(actionId1)}, send, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeActionResultsSource struct {
+	actions map[string]*fakeAction
+	changes chan []string
+	stopped bool
+}
+
+func (s *fakeActionResultsSource) ActionByTag(tag names.ActionTag) (state.Action, error) {
+	action, ok := s.actions[tag.Id()]
+	if !ok {
+		return nil, errors.NotFoundf("action %q", tag.Id())
+	}
+	return action, nil
+}
+
+func (s *fakeActionResultsSource) WatchActionResults() state.StringsWatcher {
+	return &fakeStringsWatcher{source: s}
+}
+
+type fakeStringsWatcher struct {
+	state.StringsWatcher
+	source *fakeActionResultsSource
+}
+
+func (w *fakeStringsWatcher) Changes() <-chan []string {
+	return w.source.changes
+}
+
+func (w *fakeStringsWatcher) Stop() error {
+	w.source.stopped = true
+	return nil
+}
+
+type fakeAction struct {
+	state.Action
+	id       string
+	receiver string
+	status   state.ActionStatus
+}
+
+func (a *fakeAction) ActionTag() names.ActionTag         { return names.NewActionTag(a.id) }
+func (a *fakeAction) Receiver() string                   { return a.receiver }
+func (a *fakeAction) Name() string                       { return "juju-run" }
+func (a *fakeAction) Parameters() map[string]interface{} { return nil }
+func (a *fakeAction) Enqueued() time.Time                { return time.Time{} }
+func (a *fakeAction) Started() time.Time                 { return time.Time{} }
+func (a *fakeAction) Completed() time.Time               { return time.Time{} }
+func (a *fakeAction) Status() state.ActionStatus         { return a.status }
+func (a *fakeAction) Results() (map[string]interface{}, string) {
+	return map[string]interface{}{"Stdout": "hello"}, ""
+}
@@ -171,6 +171,15 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 			logger.Debugf("model user %s is READ ONLY", entity.Tag())
 		}
 
+		// Record when the macaroons the user logged in with expire,
+		// so that facades can report it back to them.
+		if expiry, ok := authentication.MacaroonsExpiry(req.Macaroons); ok {
+			if err := a.root.getResources().RegisterNamed("loginMacaroonExpiry", common.ValueResource{
+				expiry,
+			}); err != nil {
+				return fail, errors.Trace(err)
+			}
+		}
 	}
 	// Fetch the API server addresses from state.
 	hostPorts, err := a.root.state.APIHostPorts()
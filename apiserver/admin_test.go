@@ -14,6 +14,7 @@ import (
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -582,6 +583,7 @@ func (s *baseLoginSuite) setupServerForEnvironmentWithValidator(c *gc.C, modelTa
 		s.State,
 		listener,
 		apiserver.ServerConfig{
+			Clock:       clock.WallClock,
 			Cert:        []byte(coretesting.ServerCert),
 			Key:         []byte(coretesting.ServerKey),
 			Validator:   validator,
@@ -7,6 +7,8 @@
 package agent
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -100,6 +102,53 @@ func (api *AgentAPIV2) getEntity(tag names.Tag) (result params.AgentGetEntitiesR
 	return
 }
 
+// passwordRotator is implemented by entities whose agents may be asked
+// to rotate their passwords.
+type passwordRotator interface {
+	PasswordRotationRequired(maxAge time.Duration) bool
+}
+
+// PasswordRotationRequired returns, for each of the supplied entities,
+// whether its agent should generate and set a new password: because
+// a rotation was requested, or because its current password is older
+// than the controller's agent-password-max-age. Only the agent itself
+// may ask.
+func (api *AgentAPIV2) PasswordRotationRequired(args params.Entities) (params.BoolResults, error) {
+	results := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	controllerConfig, err := api.st.ControllerConfig()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	maxAge := controllerConfig.AgentPasswordMaxAge()
+	for i, entity := range args.Entities {
+		required, err := api.passwordRotationRequired(entity.Tag, maxAge)
+		results.Results[i].Result = required
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *AgentAPIV2) passwordRotationRequired(tagString string, maxAge time.Duration) (bool, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return false, common.ErrPerm
+	}
+	if !api.auth.AuthOwner(tag) {
+		return false, common.ErrPerm
+	}
+	entity, err := api.st.FindEntity(tag)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	rotator, ok := entity.(passwordRotator)
+	if !ok {
+		return false, common.NotSupportedError(tag, "password rotation")
+	}
+	return rotator.PasswordRotationRequired(maxAge), nil
+}
+
 func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err error) {
 	if !api.auth.AuthModelManager() {
 		err = common.ErrPerm
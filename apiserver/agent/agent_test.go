@@ -238,3 +238,38 @@ func (s *agentSuite) TestClearReboot(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rFlag, jc.IsFalse)
 }
+
+func (s *agentSuite) TestPasswordRotationRequired(c *gc.C) {
+	api, err := agent.NewAgentAPIV2(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine1.SetPassword("yyy-12345678901234567890")
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machine0.Tag().String()},
+		{Tag: s.machine1.Tag().String()},
+		{Tag: "machine-42"},
+	}}
+	result, err := api.PasswordRotationRequired(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: false},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.machine1.RequestPasswordRotation()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = api.PasswordRotationRequired(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[1], gc.DeepEquals, params.BoolResult{Result: true})
+
+	// Setting a new password completes the rotation.
+	err = s.machine1.SetPassword("zzz-12345678901234567890")
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = api.PasswordRotationRequired(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[1], gc.DeepEquals, params.BoolResult{Result: false})
+}
@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentlogging provides the facade through which clients get
+// and set the log levels and log rotation settings of a model's
+// agents. The agents pick up changes through the Logger facade.
+package agentlogging
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("AgentLogging", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	common.BlockGetter
+	ModelTag() names.ModelTag
+	ModelConfig() (*config.Config, error)
+	UpdateModelConfig(map[string]interface{}, []string, state.ValidateConfigFunc) error
+	AgentLogging(names.Tag) (state.AgentLogging, error)
+	SetAgentLogging(names.Tag, state.AgentLogging) error
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// API is the endpoint which implements the AgentLogging facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+	check   *common.BlockChecker
+}
+
+// NewAPI creates a new instance of the AgentLogging facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+		check:   common.NewBlockChecker(backend),
+	}, nil
+}
+
+func (api *API) checkPermission(access description.Access) error {
+	ok, err := api.auth.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// AgentLogging returns the logging settings of the given agents or
+// model. The logging config of the model is its logging-config.
+func (api *API) AgentLogging(args params.Entities) (params.AgentLoggingResults, error) {
+	var results params.AgentLoggingResults
+	if err := api.checkPermission(description.ReadAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.AgentLoggingResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		result, err := api.agentLogging(entity.Tag)
+		results.Results[i].Result = result
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) agentLogging(tagString string) (params.AgentLogging, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return params.AgentLogging{}, errors.Trace(err)
+	}
+	settings, err := api.backend.AgentLogging(tag)
+	if err != nil {
+		return params.AgentLogging{}, errors.Trace(err)
+	}
+	if _, ok := tag.(names.ModelTag); ok {
+		modelConfig, err := api.backend.ModelConfig()
+		if err != nil {
+			return params.AgentLogging{}, errors.Trace(err)
+		}
+		settings.LoggingConfig = modelConfig.LoggingConfig()
+	}
+	return params.AgentLogging{
+		Tag:           tagString,
+		LoggingConfig: settings.LoggingConfig,
+		MaxLogSize:    settings.MaxLogSize,
+		MaxLogBackups: settings.MaxLogBackups,
+	}, nil
+}
+
+// SetAgentLogging replaces the logging settings of the given agents,
+// or the default log rotation settings of the model. Logging config
+// given for the model, if any, is set as its logging-config. Only
+// model admins may set logging settings.
+func (api *API) SetAgentLogging(args params.AgentLoggingArgs) (params.ErrorResults, error) {
+	var results params.ErrorResults
+	if err := api.checkPermission(description.AdminAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.setAgentLogging(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setAgentLogging(arg params.AgentLogging) error {
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settings := state.AgentLogging{
+		LoggingConfig: arg.LoggingConfig,
+		MaxLogSize:    arg.MaxLogSize,
+		MaxLogBackups: arg.MaxLogBackups,
+	}
+	if _, ok := tag.(names.ModelTag); ok && settings.LoggingConfig != "" {
+		if tag != api.backend.ModelTag() {
+			return common.ErrPerm
+		}
+		attrs := map[string]interface{}{"logging-config": settings.LoggingConfig}
+		if err := api.backend.UpdateModelConfig(attrs, nil, nil); err != nil {
+			return errors.Trace(err)
+		}
+		settings.LoggingConfig = ""
+	}
+	return errors.Trace(api.backend.SetAgentLogging(tag, settings))
+}
@@ -0,0 +1,229 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentlogging_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/agentlogging"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AgentLoggingSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *agentlogging.API
+}
+
+var _ = gc.Suite(&AgentLoggingSuite{})
+
+var modelTag = names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+
+func (s *AgentLoggingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{
+		settings: state.AgentLogging{
+			LoggingConfig: "juju.worker=DEBUG",
+			MaxLogSize:    100,
+		},
+	}
+	var err error
+	s.api, err = agentlogging.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AgentLoggingSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := agentlogging.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *AgentLoggingSuite) TestAgentLogging(c *gc.C) {
+	results, err := s.api.AgentLogging(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "foo"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.AgentLoggingResult{
+		Result: params.AgentLogging{
+			Tag:           "machine-0",
+			LoggingConfig: "juju.worker=DEBUG",
+			MaxLogSize:    100,
+		},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"foo" is not a valid tag`)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"AgentLogging", []interface{}{names.NewMachineTag("0")}},
+	})
+}
+
+func (s *AgentLoggingSuite) TestAgentLoggingModel(c *gc.C) {
+	s.backend.settings = state.AgentLogging{MaxLogBackups: 3}
+	results, err := s.api.AgentLogging(params.Entities{
+		Entities: []params.Entity{{Tag: modelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.AgentLoggingResult{{
+		Result: params.AgentLogging{
+			Tag:           modelTag.String(),
+			LoggingConfig: "<root>=WARNING;unit=DEBUG",
+			MaxLogBackups: 3,
+		},
+	}})
+}
+
+func (s *AgentLoggingSuite) TestAgentLoggingRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.AgentLogging(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *AgentLoggingSuite) TestSetAgentLogging(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.NotValidf(`logging config "juju=LOUD"`))
+	results, err := s.api.SetAgentLogging(params.AgentLoggingArgs{
+		Args: []params.AgentLogging{{
+			Tag:           "unit-mysql-0",
+			LoggingConfig: "unit=TRACE",
+			MaxLogBackups: 5,
+		}, {
+			Tag:           "machine-1",
+			LoggingConfig: "juju=LOUD",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `logging config "juju=LOUD" not valid`)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"SetAgentLogging", []interface{}{names.NewUnitTag("mysql/0"), state.AgentLogging{
+			LoggingConfig: "unit=TRACE",
+			MaxLogBackups: 5,
+		}}},
+		{"SetAgentLogging", []interface{}{names.NewMachineTag("1"), state.AgentLogging{
+			LoggingConfig: "juju=LOUD",
+		}}},
+	})
+}
+
+func (s *AgentLoggingSuite) TestSetAgentLoggingModel(c *gc.C) {
+	results, err := s.api.SetAgentLogging(params.AgentLoggingArgs{
+		Args: []params.AgentLogging{{
+			Tag:           modelTag.String(),
+			LoggingConfig: "juju=DEBUG",
+			MaxLogSize:    50,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"UpdateModelConfig", []interface{}{map[string]interface{}{"logging-config": "juju=DEBUG"}}},
+		{"SetAgentLogging", []interface{}{modelTag, state.AgentLogging{MaxLogSize: 50}}},
+	})
+}
+
+func (s *AgentLoggingSuite) TestSetAgentLoggingRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.SetAgentLogging(params.AgentLoggingArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *AgentLoggingSuite) TestSetAgentLoggingRequiresAdminNotWrite(c *gc.C) {
+	// Users with write access may read, but not set, logging settings.
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasWriteTag = names.NewUserTag("mary@local")
+	_, err := s.api.AgentLogging(params.Entities{Entities: []params.Entity{{Tag: "machine-0"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.SetAgentLogging(params.AgentLoggingArgs{
+		Args: []params.AgentLogging{{Tag: "machine-0", MaxLogSize: 50}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckCallNames(c, "AgentLogging")
+}
+
+func (s *AgentLoggingSuite) TestSetAgentLoggingOtherModel(c *gc.C) {
+	otherModelTag := names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	results, err := s.api.SetAgentLogging(params.AgentLoggingArgs{
+		Args: []params.AgentLogging{{
+			Tag:           otherModelTag.String(),
+			LoggingConfig: "juju=DEBUG",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+	}})
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *AgentLoggingSuite) TestSetAgentLoggingBlocked(c *gc.C) {
+	s.backend.block = state.ChangeBlock
+	_, err := s.api.SetAgentLogging(params.AgentLoggingArgs{
+		Args: []params.AgentLogging{{Tag: "machine-0", MaxLogSize: 50}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+	s.backend.stub.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	stub     gitjujutesting.Stub
+	block    state.BlockType
+	settings state.AgentLogging
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return modelTag
+}
+
+func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	if m.block == t {
+		return mockBlock{t: t}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (m *mockBackend) ModelConfig() (*config.Config, error) {
+	attrs := coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"logging-config": "<root>=WARNING",
+	})
+	return config.New(config.NoDefaults, attrs)
+}
+
+func (m *mockBackend) UpdateModelConfig(attrs map[string]interface{}, _ []string, _ state.ValidateConfigFunc) error {
+	m.stub.AddCall("UpdateModelConfig", attrs)
+	return m.stub.NextErr()
+}
+
+func (m *mockBackend) AgentLogging(tag names.Tag) (state.AgentLogging, error) {
+	m.stub.AddCall("AgentLogging", tag)
+	return m.settings, m.stub.NextErr()
+}
+
+func (m *mockBackend) SetAgentLogging(tag names.Tag, settings state.AgentLogging) error {
+	m.stub.AddCall("SetAgentLogging", tag, settings)
+	return m.stub.NextErr()
+}
+
+type mockBlock struct {
+	state.Block
+	t state.BlockType
+}
+
+func (m mockBlock) Type() state.BlockType { return m.t }
+
+func (m mockBlock) Message() string { return "blocked" }
@@ -0,0 +1,165 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package alerts provides the facade through which clients define the
+// alert rules of a model, and list the alerts which the controller has
+// raised for them.
+package alerts
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Alerts", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	common.BlockGetter
+	ModelTag() names.ModelTag
+	AddAlertRule(state.AlertRule) error
+	RemoveAlertRule(name string) error
+	AlertRules() ([]state.AlertRule, error)
+	Alerts() ([]state.Alert, error)
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// API is the endpoint which implements the Alerts facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+	check   *common.BlockChecker
+}
+
+// NewAPI creates a new instance of the Alerts facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+		check:   common.NewBlockChecker(backend),
+	}, nil
+}
+
+func (api *API) checkPermission(access description.Access) error {
+	ok, err := api.auth.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// AddAlertRules adds the given alert rules to the model. Only model
+// admins may add alert rules.
+func (api *API) AddAlertRules(args params.AlertRuleArgs) (params.ErrorResults, error) {
+	var results params.ErrorResults
+	if err := api.checkPermission(description.AdminAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.backend.AddAlertRule(state.AlertRule{
+			Name:    arg.Name,
+			Kind:    state.AlertRuleKind(arg.Kind),
+			For:     arg.For,
+			Webhook: arg.Webhook,
+			Email:   arg.Email,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveAlertRules removes the named alert rules, and the alerts they
+// have raised, from the model. Only model admins may remove alert
+// rules.
+func (api *API) RemoveAlertRules(args params.AlertRuleNames) (params.ErrorResults, error) {
+	var results params.ErrorResults
+	if err := api.checkPermission(description.AdminAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ErrorResult, len(args.Names))
+	for i, name := range args.Names {
+		err := api.backend.RemoveAlertRule(name)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListAlertRules returns the alert rules of the model.
+func (api *API) ListAlertRules() (params.ListAlertRulesResults, error) {
+	var result params.ListAlertRulesResults
+	if err := api.checkPermission(description.ReadAccess); err != nil {
+		return result, errors.Trace(err)
+	}
+	rules, err := api.backend.AlertRules()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Rules = make([]params.AlertRule, len(rules))
+	for i, rule := range rules {
+		result.Rules[i] = params.AlertRule{
+			Name:    rule.Name,
+			Kind:    string(rule.Kind),
+			For:     rule.For,
+			Webhook: rule.Webhook,
+			Email:   rule.Email,
+		}
+	}
+	return result, nil
+}
+
+// ListAlerts returns the alerts currently raised in the model.
+func (api *API) ListAlerts() (params.ListAlertsResults, error) {
+	var result params.ListAlertsResults
+	if err := api.checkPermission(description.ReadAccess); err != nil {
+		return result, errors.Trace(err)
+	}
+	rules, err := api.backend.AlertRules()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	kinds := make(map[string]state.AlertRuleKind)
+	for _, rule := range rules {
+		kinds[rule.Name] = rule.Kind
+	}
+	alerts, err := api.backend.Alerts()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Alerts = make([]params.Alert, len(alerts))
+	for i, alert := range alerts {
+		result.Alerts[i] = params.Alert{
+			Rule:    alert.Rule,
+			Kind:    string(kinds[alert.Rule]),
+			Entity:  alert.Entity,
+			Message: alert.Message,
+			Since:   alert.Since,
+			Raised:  alert.Raised,
+		}
+	}
+	return result, nil
+}
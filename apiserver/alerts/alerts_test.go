@@ -0,0 +1,261 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/alerts"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type AlertsSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *alerts.API
+}
+
+var _ = gc.Suite(&AlertsSuite{})
+
+func (s *AlertsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{}
+	var err error
+	s.api, err = alerts.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AlertsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := alerts.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *AlertsSuite) TestAddAlertRules(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.NotValidf(`alert rule kind "unit-sad"`))
+	results, err := s.api.AddAlertRules(params.AlertRuleArgs{
+		Args: []params.AlertRule{{
+			Name:    "unit-errors",
+			Kind:    "unit-error",
+			For:     5 * time.Minute,
+			Webhook: "https://hooks.example.com/juju",
+		}, {
+			Name: "sad",
+			Kind: "unit-sad",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `alert rule kind "unit-sad" not valid`)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"AddAlertRule", []interface{}{state.AlertRule{
+			Name:    "unit-errors",
+			Kind:    state.UnitErrorAlert,
+			For:     5 * time.Minute,
+			Webhook: "https://hooks.example.com/juju",
+		}}},
+		{"AddAlertRule", []interface{}{state.AlertRule{
+			Name: "sad",
+			Kind: "unit-sad",
+		}}},
+	})
+}
+
+func (s *AlertsSuite) TestAddAlertRulesRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.AddAlertRules(params.AlertRuleArgs{
+		Args: []params.AlertRule{{Name: "down", Kind: "machine-down"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *AlertsSuite) TestAddAlertRulesBlocked(c *gc.C) {
+	s.backend.block = state.ChangeBlock
+	_, err := s.api.AddAlertRules(params.AlertRuleArgs{
+		Args: []params.AlertRule{{Name: "down", Kind: "machine-down"}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *AlertsSuite) TestRemoveAlertRules(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.NotFoundf(`alert rule "nope"`))
+	results, err := s.api.RemoveAlertRules(params.AlertRuleNames{
+		Names: []string{"down", "nope"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `alert rule "nope" not found`)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RemoveAlertRule", []interface{}{"down"}},
+		{"RemoveAlertRule", []interface{}{"nope"}},
+	})
+}
+
+func (s *AlertsSuite) TestRemoveAlertRulesRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.RemoveAlertRules(params.AlertRuleNames{Names: []string{"down"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *AlertsSuite) TestRemoveAlertRulesBlocked(c *gc.C) {
+	s.backend.block = state.ChangeBlock
+	_, err := s.api.RemoveAlertRules(params.AlertRuleNames{Names: []string{"down"}})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *AlertsSuite) TestListAlertRules(c *gc.C) {
+	s.backend.rules = []state.AlertRule{{
+		Name:  "down",
+		Kind:  state.MachineDownAlert,
+		For:   time.Minute,
+		Email: "ops@example.com",
+	}}
+	result, err := s.api.ListAlertRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListAlertRulesResults{
+		Rules: []params.AlertRule{{
+			Name:  "down",
+			Kind:  "machine-down",
+			For:   time.Minute,
+			Email: "ops@example.com",
+		}},
+	})
+	s.backend.stub.CheckCallNames(c, "AlertRules")
+}
+
+func (s *AlertsSuite) TestListAlerts(c *gc.C) {
+	since := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	s.backend.rules = []state.AlertRule{{Name: "down", Kind: state.MachineDownAlert}}
+	s.backend.alerts = []state.Alert{{
+		Rule:    "down",
+		Entity:  "machine-1",
+		Message: "agent is not communicating with the server",
+		Since:   since,
+		Raised:  since.Add(time.Minute),
+	}}
+	result, err := s.api.ListAlerts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListAlertsResults{
+		Alerts: []params.Alert{{
+			Rule:    "down",
+			Kind:    "machine-down",
+			Entity:  "machine-1",
+			Message: "agent is not communicating with the server",
+			Since:   since,
+			Raised:  since.Add(time.Minute),
+		}},
+	})
+	s.backend.stub.CheckCallNames(c, "AlertRules", "Alerts")
+}
+
+func (s *AlertsSuite) TestListAlertRulesRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.ListAlertRules()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *AlertsSuite) TestWriteAccess(c *gc.C) {
+	// Users with write access may list alert rules and alerts, but
+	// only model admins may change the rules.
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasWriteTag = names.NewUserTag("mary@local")
+	_, err := s.api.ListAlertRules()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.ListAlerts()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.AddAlertRules(params.AlertRuleArgs{
+		Args: []params.AlertRule{{Name: "down", Kind: "machine-down"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = s.api.RemoveAlertRules(params.AlertRuleNames{Names: []string{"down"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckCallNames(c, "AlertRules", "AlertRules", "Alerts")
+}
+
+func (s *AlertsSuite) TestListAlertsRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.ListAlerts()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *AlertsSuite) TestListAlertsError(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.New("boom"))
+	_, err := s.api.ListAlerts()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	stub   gitjujutesting.Stub
+	block  state.BlockType
+	rules  []state.AlertRule
+	alerts []state.Alert
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+}
+
+func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	if m.block == t {
+		return mockBlock{t: t}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (m *mockBackend) AddAlertRule(rule state.AlertRule) error {
+	m.stub.AddCall("AddAlertRule", rule)
+	return m.stub.NextErr()
+}
+
+func (m *mockBackend) RemoveAlertRule(name string) error {
+	m.stub.AddCall("RemoveAlertRule", name)
+	return m.stub.NextErr()
+}
+
+func (m *mockBackend) AlertRules() ([]state.AlertRule, error) {
+	m.stub.AddCall("AlertRules")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.rules, nil
+}
+
+func (m *mockBackend) Alerts() ([]state.Alert, error) {
+	m.stub.AddCall("Alerts")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.alerts, nil
+}
+
+type mockBlock struct {
+	state.Block
+	t state.BlockType
+}
+
+func (m mockBlock) Type() state.BlockType { return m.t }
+
+func (m mockBlock) Message() string { return "blocked" }
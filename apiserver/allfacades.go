@@ -12,28 +12,38 @@ package apiserver
 import (
 	_ "github.com/juju/juju/apiserver/action" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/agent"
+	_ "github.com/juju/juju/apiserver/agentlogging" // ModelUser Admin (read access for AgentLogging)
 	_ "github.com/juju/juju/apiserver/agenttools"
+	_ "github.com/juju/juju/apiserver/alerts"      // ModelUser Admin (read access for listing)
 	_ "github.com/juju/juju/apiserver/annotations" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/application" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/applicationscaler"
 	_ "github.com/juju/juju/apiserver/backups" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/block"   // ModelUser Write
+	_ "github.com/juju/juju/apiserver/bundle"  // ModelUser Read
 	_ "github.com/juju/juju/apiserver/charmrevisionupdater"
 	_ "github.com/juju/juju/apiserver/charms" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/cleaner"
 	_ "github.com/juju/juju/apiserver/client"     // ModelUser Write
 	_ "github.com/juju/juju/apiserver/cloud"      // ModelUser Read
+	_ "github.com/juju/juju/apiserver/cloudinit"  // ModelUser Admin (read access for CloudInitCustomizations)
 	_ "github.com/juju/juju/apiserver/controller" // ModelUser Admin (although some methods check for read only)
+	_ "github.com/juju/juju/apiserver/controllerreport"
+	_ "github.com/juju/juju/apiserver/credentialvalidator"
+	_ "github.com/juju/juju/apiserver/defaultconstraints" // ModelUser Read (controller superuser for SetDefaultConstraints)
 	_ "github.com/juju/juju/apiserver/deployer"
 	_ "github.com/juju/juju/apiserver/discoverspaces"
 	_ "github.com/juju/juju/apiserver/diskmanager"
 	_ "github.com/juju/juju/apiserver/firewaller"
+	_ "github.com/juju/juju/apiserver/firewallrules"    // ModelUser Admin
 	_ "github.com/juju/juju/apiserver/highavailability" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/hooktimings"      // ModelUser Read
 	_ "github.com/juju/juju/apiserver/hostkeyreporter"
 	_ "github.com/juju/juju/apiserver/imagemanager" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/imagemetadata"
 	_ "github.com/juju/juju/apiserver/instancepoller"
-	_ "github.com/juju/juju/apiserver/keymanager" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/instancetypes" // ModelUser Read
+	_ "github.com/juju/juju/apiserver/keymanager"    // ModelUser Write
 	_ "github.com/juju/juju/apiserver/keyupdater"
 	_ "github.com/juju/juju/apiserver/leadership"
 	_ "github.com/juju/juju/apiserver/lifeflag"
@@ -51,12 +61,14 @@ import (
 	_ "github.com/juju/juju/apiserver/migrationminion"
 	_ "github.com/juju/juju/apiserver/migrationtarget" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/modelconfig"     // ModelUser Write
+	_ "github.com/juju/juju/apiserver/modelevents"     // ModelUser Read
 	_ "github.com/juju/juju/apiserver/modelmanager"    // ModelUser Write
 	_ "github.com/juju/juju/apiserver/provisioner"
 	_ "github.com/juju/juju/apiserver/proxyupdater"
 	_ "github.com/juju/juju/apiserver/reboot"
 	_ "github.com/juju/juju/apiserver/resumer"
 	_ "github.com/juju/juju/apiserver/retrystrategy"
+	_ "github.com/juju/juju/apiserver/scheduledoperations" // ModelUser Write (read access for listing)
 	_ "github.com/juju/juju/apiserver/singular"
 	_ "github.com/juju/juju/apiserver/spaces"    // ModelUser Write
 	_ "github.com/juju/juju/apiserver/sshclient" // ModelUser Write
@@ -67,6 +79,10 @@ import (
 	_ "github.com/juju/juju/apiserver/undertaker"
 	_ "github.com/juju/juju/apiserver/unitassigner"
 	_ "github.com/juju/juju/apiserver/uniter"
+	_ "github.com/juju/juju/apiserver/upgradeprechecks" // ModelUser Read (write access for SkipPrechecks)
 	_ "github.com/juju/juju/apiserver/upgrader"
+	_ "github.com/juju/juju/apiserver/usage" // ModelUser Read
+	_ "github.com/juju/juju/apiserver/usagereporter"
 	_ "github.com/juju/juju/apiserver/usermanager"
+	_ "github.com/juju/juju/apiserver/waitfor"
 )
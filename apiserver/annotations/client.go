@@ -26,6 +26,7 @@ var getState = func(st *state.State) annotationAccess {
 type Annotations interface {
 	Get(args params.Entities) params.AnnotationsGetResults
 	Set(args params.AnnotationsSet) params.ErrorResults
+	Find(args params.AnnotationsFind) (params.Entities, error)
 }
 
 // API implements the service interface and is the concrete
@@ -119,6 +120,24 @@ func (api *API) Set(args params.AnnotationsSet) params.ErrorResults {
 	return params.ErrorResults{Results: setErrors}
 }
 
+// Find returns the tags of the entities whose annotations include all
+// of the given key/value pairs, optionally restricted to one kind of
+// entity.
+func (api *API) Find(args params.AnnotationsFind) (params.Entities, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.Entities{}, errors.Trace(err)
+	}
+	tags, err := api.access.FindEntitiesByAnnotations(args.Kind, args.Annotations)
+	if err != nil {
+		return params.Entities{}, errors.Trace(err)
+	}
+	result := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		result.Entities[i].Tag = tag.String()
+	}
+	return result, nil
+}
+
 func annotateError(err error, tag, op string) *params.Error {
 	return common.ServerError(
 		errors.Trace(
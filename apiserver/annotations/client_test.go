@@ -126,6 +126,36 @@ func (s *annotationSuite) TestUnitAnnotations(c *gc.C) {
 	s.assertAnnotationsRemoval(c, wordpress.Tag())
 }
 
+func (s *annotationSuite) TestFindEntities(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobHostUnits},
+	})
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	labels := map[string]string{"env": "prod"}
+	setResult := s.annotationsAPI.Set(params.AnnotationsSet{
+		Annotations: constructSetParameters([]string{machine.Tag().String(), wordpress.Tag().String()}, labels),
+	})
+	c.Assert(setResult.OneError(), jc.ErrorIsNil)
+
+	found, err := s.annotationsAPI.Find(params.AnnotationsFind{Annotations: labels})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, params.Entities{Entities: []params.Entity{
+		{Tag: wordpress.Tag().String()},
+		{Tag: machine.Tag().String()},
+	}})
+
+	found, err = s.annotationsAPI.Find(params.AnnotationsFind{
+		Kind:        names.MachineTagKind,
+		Annotations: labels,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, params.Entities{Entities: []params.Entity{
+		{Tag: machine.Tag().String()},
+	}})
+}
+
 func (s *annotationSuite) makeRelation(c *gc.C) (*state.Application, *state.Relation) {
 	s1 := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Name: "service1",
@@ -13,6 +13,7 @@ type annotationAccess interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 	GetAnnotations(entity state.GlobalEntity) (map[string]string, error)
 	SetAnnotations(entity state.GlobalEntity, annotations map[string]string) error
+	FindEntitiesByAnnotations(kind string, annotations map[string]string) ([]names.Tag, error)
 	ModelTag() names.ModelTag
 }
 
@@ -32,6 +33,10 @@ func (s stateShim) SetAnnotations(entity state.GlobalEntity, annotations map[str
 	return s.state.SetAnnotations(entity, annotations)
 }
 
+func (s stateShim) FindEntitiesByAnnotations(kind string, annotations map[string]string) ([]names.Tag, error) {
+	return s.state.FindEntitiesByAnnotations(kind, annotations)
+}
+
 func (s stateShim) ModelTag() names.ModelTag {
 	return s.state.ModelTag()
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"golang.org/x/net/websocket"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/tomb.v1"
@@ -36,6 +37,16 @@ var logger = loggo.GetLogger("juju.apiserver")
 // accept
 const loginRateLimit = 10
 
+const (
+	// txnBatchFlushInterval is the longest time for which a batched
+	// update is held before it's applied, when BatchTxns is set.
+	txnBatchFlushInterval = 100 * time.Millisecond
+
+	// txnBatchMaxOps is the largest number of documents updated by a
+	// single batched transaction, when BatchTxns is set.
+	txnBatchMaxOps = 100
+)
+
 // Server holds the server side of the API.
 type Server struct {
 	tomb              tomb.Tomb
@@ -44,6 +55,7 @@ type Server struct {
 	statePool         *state.StatePool
 	lis               net.Listener
 	tag               names.Tag
+	clock             clock.Clock
 	dataDir           string
 	logDir            string
 	limiter           utils.Limiter
@@ -63,6 +75,7 @@ type LoginValidator func(params.LoginRequest) error
 
 // ServerConfig holds parameters required to set up an API server.
 type ServerConfig struct {
+	Clock       clock.Clock
 	Cert        []byte
 	Key         []byte
 	Tag         names.Tag
@@ -78,9 +91,22 @@ type ServerConfig struct {
 
 	// StatePool only exists to support testing.
 	StatePool *state.StatePool
+
+	// CacheReads causes read-only calls which support it, such as
+	// FullStatus, to be served from a per-model cache that is kept
+	// current by state watchers.
+	CacheReads bool
+
+	// BatchTxns causes frequent, small document updates made through
+	// the API, such as those of status, to be applied in fewer
+	// transactions. See state.State.EnableTxnBatching.
+	BatchTxns bool
 }
 
 func (c *ServerConfig) Validate() error {
+	if c.Clock == nil {
+		return errors.NotValidf("missing Clock")
+	}
 	if c.NewObserver == nil {
 		return errors.NotValidf("missing NewObserver")
 	}
@@ -217,9 +243,25 @@ func newServer(s *state.State, lis *net.TCPListener, cfg ServerConfig) (_ *Serve
 	if stPool == nil {
 		stPool = state.NewStatePool(s)
 	}
+	if cfg.CacheReads {
+		if err := stPool.EnableReadCache(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if cfg.BatchTxns {
+		err := stPool.EnableTxnBatching(state.TxnBatcherConfig{
+			Clock:         cfg.Clock,
+			FlushInterval: txnBatchFlushInterval,
+			MaxOps:        txnBatchMaxOps,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	srv := &Server{
 		newObserver: cfg.NewObserver,
+		clock:       cfg.Clock,
 		state:       s,
 		statePool:   stPool,
 		lis:         newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
@@ -339,10 +381,14 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	logSinkHandler := srv.trackRequests(newLogSinkHandler(httpCtxt, srv.logDir))
 	logStreamHandler := srv.trackRequests(newLogStreamEndpointHandler(strictCtxt))
 	debugLogHandler := srv.trackRequests(newDebugLogDBHandler(httpCtxt))
+	sshTunnelHandler := srv.trackRequests(newSSHTunnelHandler(httpCtxt))
+	actionResultsHandler := srv.trackRequests(newActionResultsHandler(httpCtxt))
 
 	add("/model/:modeluuid/logsink", logSinkHandler)
 	add("/model/:modeluuid/logstream", logStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
+	add("/model/:modeluuid/ssh-tunnel", sshTunnelHandler)
+	add("/model/:modeluuid/action-results", actionResultsHandler)
 	add("/model/:modeluuid/charms",
 		&charmsHandler{
 			ctxt:    httpCtxt,
@@ -372,6 +418,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/gui-version", &guiVersionHandler{
 		ctxt: httpCtxt,
 	})
+	add("/metrics", &metricsHandler{
+		ctxt: strictCtxt,
+	})
 
 	// For backwards compatibility we register all the old paths
 	add("/log", debugLogHandler)
@@ -395,6 +444,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/register",
 		&registerUserHandler{
 			httpCtxt,
+			srv.clock,
 			srv.authCtxt.userAuth.CreateLocalLoginMacaroon,
 		},
 	)
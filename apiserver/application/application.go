@@ -7,19 +7,26 @@
 package application
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	jjj "github.com/juju/juju/juju"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 	statestorage "github.com/juju/juju/state/storage"
 )
 
@@ -27,10 +34,13 @@ var (
 	logger = loggo.GetLogger("juju.apiserver.application")
 
 	newStateStorage = statestorage.NewStorage
+	getEnviron      = stateenvirons.GetNewEnvironFunc(environs.New)
 )
 
 func init() {
 	common.RegisterStandardFacade("Application", 1, NewAPI)
+	// Version 2 adds ExposedEndpoints to Expose.
+	common.RegisterStandardFacade("Application", 2, NewAPI)
 }
 
 // Application defines the methods on the application API end point.
@@ -126,10 +136,25 @@ func (api *API) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, err
 	for i, arg := range args.Applications {
 		err := deployApplication(api.state, arg)
 		result.Results[i].Error = common.ServerError(err)
+		if err == nil {
+			api.recordEvent(state.DeployEvent, arg.ApplicationName, deployMessage(arg))
+		}
 	}
 	return result, nil
 }
 
+// deployMessage describes the deployment of an application, for the
+// model's event timeline.
+func deployMessage(arg params.ApplicationDeploy) string {
+	switch arg.NumUnits {
+	case 0:
+		return fmt.Sprintf("deployed %s", arg.CharmUrl)
+	case 1:
+		return fmt.Sprintf("deployed %s with 1 unit", arg.CharmUrl)
+	}
+	return fmt.Sprintf("deployed %s with %d units", arg.CharmUrl, arg.NumUnits)
+}
+
 // deployApplication fetches the charm from the charm store and deploys it.
 // The logic has been factored out into a common function which is called by
 // both the legacy API on the client facade, as well as the new application facade.
@@ -193,6 +218,95 @@ func deployApplication(st *state.State, args params.ApplicationDeploy) error {
 	return errors.Trace(err)
 }
 
+// ValidateDeploy checks whether the specified applications could be
+// deployed, without changing the model. The charm name, constraints,
+// placement directives, storage pools and endpoint bindings of each
+// application are checked against the current model.
+func (api *API) ValidateDeploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Applications)),
+	}
+	for i, arg := range args.Applications {
+		err := validateDeployApplication(api.state, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// validateDeployApplication returns an error if the application
+// described by args could not be deployed into the model.
+func validateDeployApplication(st *state.State, args params.ApplicationDeploy) error {
+	if !names.IsValidApplication(args.ApplicationName) {
+		return errors.NotValidf("application name %q", args.ApplicationName)
+	}
+	if _, err := st.Application(args.ApplicationName); err == nil {
+		return errors.AlreadyExistsf("application %q", args.ApplicationName)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if _, err := charm.ParseURL(args.CharmUrl); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := st.ValidateConstraints(args.Constraints); err != nil {
+		return errors.Annotate(err, "invalid constraints")
+	}
+	for _, p := range args.Placement {
+		if err := validatePlacement(st, p); err != nil {
+			return errors.Annotatef(err, "cannot deploy %q", args.ApplicationName)
+		}
+	}
+	for name, cons := range args.Storage {
+		if cons.Pool == "" {
+			continue
+		}
+		if err := st.ValidateStoragePool(cons.Pool); err != nil {
+			return errors.Annotatef(err, "invalid storage %q", name)
+		}
+	}
+	for endpoint, space := range args.EndpointBindings {
+		if space == "" {
+			continue
+		}
+		if _, err := st.Space(space); err != nil {
+			return errors.Annotatef(err, "cannot bind %q", endpoint)
+		}
+	}
+	return nil
+}
+
+// validatePlacement returns an error if the machine named by a
+// machine or container placement directive does not exist, or
+// cannot host the requested container type.
+func validatePlacement(st *state.State, p *instance.Placement) error {
+	if p.Scope != instance.MachineScope {
+		if _, err := instance.ParseContainerType(p.Scope); err != nil || p.Directive == "" {
+			// Provider specific directives are checked by the
+			// provider when the machine is created.
+			return nil
+		}
+	}
+	m, err := st.Machine(p.Directive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if p.Scope == instance.MachineScope {
+		return nil
+	}
+	supported, known := m.SupportedContainers()
+	if !known {
+		return nil
+	}
+	for _, ctype := range supported {
+		if string(ctype) == p.Scope {
+			return nil
+		}
+	}
+	return errors.NotSupportedf("%s containers on machine %s", p.Scope, p.Directive)
+}
+
 // ApplicationSetSettingsStrings updates the settings for the given application,
 // taking the configuration from a map of strings.
 func ApplicationSetSettingsStrings(application *state.Application, settings map[string]string) error {
@@ -249,7 +363,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 		return err
 	}
 	if !args.ForceCharmUrl {
-		if err := api.check.ChangeAllowed(); err != nil {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -265,22 +379,27 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 		if err = api.applicationSetCharm(svc, args.CharmUrl, channel, args.ForceSeries, args.ForceCharmUrl, nil); err != nil {
 			return errors.Trace(err)
 		}
+		api.recordEvent(state.UpgradeCharmEvent, args.ApplicationName, "upgraded to "+args.CharmUrl)
 	}
-	// Set the minimum number of units for the given application.
-	if args.MinUnits != nil {
-		if err = svc.SetMinUnits(*args.MinUnits); err != nil {
-			return errors.Trace(err)
-		}
+	// Set the minimum and maximum number of units for the given application.
+	if err = setUnitLimits(svc, args.MinUnits, args.MaxUnits); err != nil {
+		return errors.Trace(err)
 	}
 	// Set up application's settings.
 	if args.SettingsYAML != "" {
 		if err = applicationSetSettingsYAML(svc, args.SettingsYAML); err != nil {
 			return errors.Annotate(err, "setting configuration from YAML")
 		}
+		api.recordConfigChange(args.ApplicationName, nil)
 	} else if len(args.SettingsStrings) > 0 {
 		if err = ApplicationSetSettingsStrings(svc, args.SettingsStrings); err != nil {
 			return errors.Trace(err)
 		}
+		keys := make([]string, 0, len(args.SettingsStrings))
+		for key := range args.SettingsStrings {
+			keys = append(keys, key)
+		}
+		api.recordConfigChange(args.ApplicationName, keys)
 	}
 	// Update application's constraints.
 	if args.Constraints != nil {
@@ -289,6 +408,34 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 	return nil
 }
 
+// setUnitLimits updates the minimum and/or maximum number of units for
+// the application. When both are supplied they are applied in whichever
+// order keeps the limits consistent with each other at every step.
+func setUnitLimits(application *state.Application, minUnits, maxUnits *int) error {
+	setMin := func() error {
+		if minUnits == nil {
+			return nil
+		}
+		return application.SetMinUnits(*minUnits)
+	}
+	setMax := func() error {
+		if maxUnits == nil {
+			return nil
+		}
+		return application.SetMaxUnits(*maxUnits)
+	}
+	first, second := setMin, setMax
+	if maxUnits != nil && (*maxUnits == 0 || *maxUnits >= application.MinUnits()) {
+		// The new maximum is compatible with the current minimum,
+		// so relax (or tighten) it before changing the minimum.
+		first, second = setMax, setMin
+	}
+	if err := first(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(second())
+}
+
 // SetCharm sets the charm for a given for the application.
 func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 	if err := api.checkCanWrite(); err != nil {
@@ -296,7 +443,7 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 	}
 	// when forced units in error, don't block
 	if !args.ForceUnits {
-		if err := api.check.ChangeAllowed(); err != nil {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -305,7 +452,100 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		return errors.Trace(err)
 	}
 	channel := csparams.Channel(args.Channel)
-	return api.applicationSetCharm(application, args.CharmUrl, channel, args.ForceSeries, args.ForceUnits, args.ResourceIDs)
+	if err := api.applicationSetCharm(application, args.CharmUrl, channel, args.ForceSeries, args.ForceUnits, args.ResourceIDs); err != nil {
+		return errors.Trace(err)
+	}
+	api.recordEvent(state.UpgradeCharmEvent, args.ApplicationName, "upgraded to "+args.CharmUrl)
+	return nil
+}
+
+// errNotUpgraded is reported for each application in a call to
+// SetCharms whose upgrade was valid, but not made because the upgrade
+// of another application was refused.
+var errNotUpgraded = errors.New("not upgraded, because the upgrade of another application failed")
+
+// SetCharms upgrades the charms of several applications together, so
+// that either every application is upgraded or none is. Each charm URL
+// must specify the revision the application is to be pinned to. If any
+// upgrade is refused, the result for each application reports either
+// why its own upgrade was refused, or that it was not upgraded.
+func (api *API) SetCharms(args params.ApplicationsSetCharm) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Applications)),
+	}
+	if err := api.checkCanWrite(); err != nil {
+		return results, err
+	}
+
+	failed := false
+	upgrades := make([]state.ApplicationCharmUpgrade, len(args.Applications))
+	for i, arg := range args.Applications {
+		upgrade, err := api.charmUpgrade(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			failed = true
+			continue
+		}
+		upgrades[i] = upgrade
+	}
+	if !failed {
+		err := api.state.SetCharms(upgrades)
+		if upgradeErr, ok := errors.Cause(err).(*state.CharmUpgradeError); ok {
+			for i, arg := range args.Applications {
+				if err, ok := upgradeErr.Errors[arg.ApplicationName]; ok {
+					results.Results[i].Error = common.ServerError(err)
+				}
+			}
+			failed = true
+		} else if err != nil {
+			return params.ErrorResults{}, errors.Trace(err)
+		}
+	}
+	if !failed {
+		for _, arg := range args.Applications {
+			api.recordEvent(state.UpgradeCharmEvent, arg.ApplicationName, "upgraded to "+arg.CharmUrl)
+		}
+	}
+	if failed {
+		for i, result := range results.Results {
+			if result.Error == nil {
+				results.Results[i].Error = common.ServerError(errNotUpgraded)
+			}
+		}
+	}
+	return results, nil
+}
+
+// charmUpgrade returns the upgrade of a single application's charm
+// described by the supplied arguments, for use with SetCharms.
+func (api *API) charmUpgrade(arg params.ApplicationSetCharm) (state.ApplicationCharmUpgrade, error) {
+	// when forced units in error, don't block
+	if !arg.ForceUnits {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(arg.ApplicationName)); err != nil {
+			return state.ApplicationCharmUpgrade{}, errors.Trace(err)
+		}
+	}
+	curl, err := charm.ParseURL(arg.CharmUrl)
+	if err != nil {
+		return state.ApplicationCharmUpgrade{}, errors.Trace(err)
+	}
+	if curl.Revision < 0 {
+		return state.ApplicationCharmUpgrade{}, errors.NotValidf("charm URL %q without revision", arg.CharmUrl)
+	}
+	sch, err := api.state.Charm(curl)
+	if err != nil {
+		return state.ApplicationCharmUpgrade{}, errors.Trace(err)
+	}
+	return state.ApplicationCharmUpgrade{
+		Application: arg.ApplicationName,
+		SetCharmConfig: state.SetCharmConfig{
+			Charm:       sch,
+			Channel:     csparams.Channel(arg.Channel),
+			ForceSeries: arg.ForceSeries,
+			ForceUnits:  arg.ForceUnits,
+			ResourceIDs: arg.ResourceIDs,
+		},
+	}, nil
 }
 
 // applicationSetCharm sets the charm for the given for the application.
@@ -406,7 +646,7 @@ func (api *API) Set(p params.ApplicationSet) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(p.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(p.ApplicationName)
@@ -423,8 +663,15 @@ func (api *API) Set(p params.ApplicationSet) error {
 		return err
 	}
 
-	return svc.UpdateConfigSettings(changes)
-
+	if err := svc.UpdateConfigSettings(changes); err != nil {
+		return errors.Trace(err)
+	}
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	api.recordConfigChange(p.ApplicationName, keys)
+	return nil
 }
 
 // Unset implements the server side of Client.Unset.
@@ -432,7 +679,7 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(p.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(p.ApplicationName)
@@ -443,7 +690,11 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	for _, option := range p.Options {
 		settings[option] = nil
 	}
-	return svc.UpdateConfigSettings(settings)
+	if err := svc.UpdateConfigSettings(settings); err != nil {
+		return errors.Trace(err)
+	}
+	api.recordConfigChange(p.ApplicationName, p.Options)
+	return nil
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
@@ -469,19 +720,79 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If exposed endpoints
+// are given, the source CIDRs from which they may be reached are
+// recorded, and the ports are only exposed to those sources.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	if len(args.ExposedEndpoints) == 0 {
+		return svc.SetExposed()
+	}
+	exposed := make(map[string][]string, len(args.ExposedEndpoints))
+	restricted := false
+	for endpoint, details := range args.ExposedEndpoints {
+		// The ports opened by units are not bound to endpoints, so
+		// exposure cannot be enforced for individual endpoints.
+		if endpoint != "" {
+			return errors.NotSupportedf("exposing endpoint %q alone", endpoint)
+		}
+		exposed[endpoint] = details.ExposeToCIDRs
+		restricted = restricted || len(details.ExposeToCIDRs) > 0
+	}
+	if restricted {
+		if err := api.checkPortSourcesSupported(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return svc.MergeExposeSettings(exposed)
+}
+
+// checkPortSourcesSupported returns an error satisfying
+// errors.IsNotSupported if the firewaller cannot restrict exposed
+// ports to particular source CIDRs in the model, rather than let it
+// open them to any address.
+func (api *API) checkPortSourcesSupported() error {
+	env, err := getEnviron(api.state)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !environs.SupportsPortSources(env) {
+		return errors.NotSupportedf(
+			"exposing to source CIDRs with firewall-mode %q on this cloud",
+			env.Config().FirewallMode(),
+		)
+	}
+	return nil
+}
+
+// SetHookSandbox changes the confinement in which the hooks and actions
+// of the application's units are run.
+func (api *API) SetHookSandbox(args params.ApplicationSetHookSandbox) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(args.ApplicationName)
 	if err != nil {
 		return err
 	}
-	return svc.SetExposed()
+	return svc.SetHookSandbox(state.HookSandbox{
+		User:         args.Sandbox.User,
+		MemoryLimit:  args.Sandbox.MemoryLimit,
+		CPUQuota:     args.Sandbox.CPUQuota,
+		LXDContainer: args.Sandbox.LXDContainer,
+	})
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -490,7 +801,7 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(args.ApplicationName)
@@ -517,7 +828,8 @@ func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplication
 	if err := api.checkCanWrite(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	blocked := append(placementMachineTags(args.Placement), names.NewApplicationTag(args.ApplicationName))
+	if err := api.check.ChangeAllowedFor(blocked...); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
 	units, err := addApplicationUnits(api.state, args)
@@ -528,15 +840,18 @@ func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplication
 	for i, unit := range units {
 		unitNames[i] = unit.String()
 	}
+	api.recordEvent(state.AddUnitEvent, args.ApplicationName, "added "+strings.Join(unitNames, ", "))
 	return params.AddApplicationUnitsResults{Units: unitNames}, nil
 }
 
-// DestroyUnits removes a given set of application units.
+// DestroyUnits removes a given set of application units. If args.Force
+// is set, the units are removed even if they are already dying, once
+// their agents have had args.MaxWait to shut down cleanly.
 func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.RemoveAllowed(); err != nil {
+	if err := api.check.RemoveAllowedFor(unitApplicationTags(args.UnitNames)...); err != nil {
 		return errors.Trace(err)
 	}
 	var errs []string
@@ -546,6 +861,8 @@ func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 		case errors.IsNotFound(err):
 			err = errors.Errorf("unit %q does not exist", name)
 		case err != nil:
+		case args.Force && unit.IsPrincipal():
+			err = unit.ForceDestroy(args.MaxWait)
 		case unit.Life() != state.Alive:
 			continue
 		case unit.IsPrincipal():
@@ -565,7 +882,7 @@ func (api *API) Destroy(args params.ApplicationDestroy) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.RemoveAllowed(); err != nil {
+	if err := api.check.RemoveAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(args.ApplicationName)
@@ -593,7 +910,7 @@ func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(args.ApplicationName)
@@ -608,7 +925,7 @@ func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults,
 	if err := api.checkCanWrite(); err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(endpointApplicationTags(args.Endpoints)...); err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
 	inEps, err := api.state.InferEndpoints(args.Endpoints...)
@@ -642,7 +959,7 @@ func (api *API) DestroyRelation(args params.DestroyRelation) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.RemoveAllowed(); err != nil {
+	if err := api.check.RemoveAllowedFor(endpointApplicationTags(args.Endpoints)...); err != nil {
 		return errors.Trace(err)
 	}
 	eps, err := api.state.InferEndpoints(args.Endpoints...)
@@ -655,3 +972,115 @@ func (api *API) DestroyRelation(args params.DestroyRelation) error {
 	}
 	return rel.Destroy()
 }
+
+// SetSubordinatePlacement sets the policy controlling which principal
+// units are given subordinate units by the relation between the
+// specified endpoints.
+func (api *API) SetSubordinatePlacement(args params.SetSubordinatePlacement) error {
+	if err := api.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowedFor(endpointApplicationTags(args.Endpoints)...); err != nil {
+		return errors.Trace(err)
+	}
+	eps, err := api.state.InferEndpoints(args.Endpoints...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rel, err := api.state.EndpointsRelation(eps...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return rel.SetSubordinatePlacement(state.SubordinatePlacement{
+		ExcludeControllers: args.ExcludeControllers,
+		ExcludeMachines:    args.ExcludeMachines,
+	})
+}
+
+// unitApplicationTags returns the tags of the applications of the named
+// units, for checking blocks on them. Invalid names are skipped.
+func unitApplicationTags(unitNames []string) []names.Tag {
+	var tags []names.Tag
+	seen := make(set.Strings)
+	for _, name := range unitNames {
+		appName, err := names.UnitApplication(name)
+		if err != nil || seen.Contains(appName) {
+			continue
+		}
+		seen.Add(appName)
+		tags = append(tags, names.NewApplicationTag(appName))
+	}
+	return tags
+}
+
+// endpointApplicationTags returns the tags of the applications of the
+// specified endpoints, for checking blocks on them.
+func endpointApplicationTags(endpoints []string) []names.Tag {
+	tags := make([]names.Tag, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		appName := strings.SplitN(endpoint, ":", 2)[0]
+		if names.IsValidApplication(appName) {
+			tags = append(tags, names.NewApplicationTag(appName))
+		}
+	}
+	return tags
+}
+
+// placementMachineTags returns the tags of the existing machines, or
+// hosts of new containers, named by the placement directives, for
+// checking blocks on them.
+func placementMachineTags(placement []*instance.Placement) []names.Tag {
+	var tags []names.Tag
+	for _, p := range placement {
+		if p == nil {
+			continue
+		}
+		if p.Scope != instance.MachineScope {
+			if _, err := instance.ParseContainerType(p.Scope); err != nil {
+				continue
+			}
+		}
+		if names.IsValidMachine(p.Directive) {
+			tags = append(tags, names.NewMachineTag(p.Directive))
+		}
+	}
+	return tags
+}
+
+// RelationScopes returns the lifecycle state of the relation between
+// the specified endpoints, and of the units in its scopes. A relation
+// cannot be removed until all of its units have left scope; a departing
+// unit waits to run relation-broken until every counterpart listing it
+// among its joined units has run relation-departed.
+func (api *API) RelationScopes(args params.RelationScopes) (params.RelationScopesResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RelationScopesResult{}, errors.Trace(err)
+	}
+	eps, err := api.state.InferEndpoints(args.Endpoints...)
+	if err != nil {
+		return params.RelationScopesResult{}, errors.Trace(err)
+	}
+	rel, err := api.state.EndpointsRelation(eps...)
+	if err != nil {
+		return params.RelationScopesResult{}, errors.Trace(err)
+	}
+	units, err := rel.ScopeUnits()
+	if err != nil {
+		return params.RelationScopesResult{}, errors.Trace(err)
+	}
+	result := params.RelationScopesResult{
+		Id:    rel.Id(),
+		Key:   rel.String(),
+		Life:  params.Life(rel.Life().String()),
+		Units: make([]params.RelationScopeUnit, len(units)),
+	}
+	for i, unit := range units {
+		result.Units[i] = params.RelationScopeUnit{
+			Unit:        unit.Unit,
+			Role:        string(unit.Role),
+			Departing:   unit.Departing,
+			JoinedUnits: unit.JoinedUnits,
+		}
+	}
+	return result, nil
+}
@@ -28,9 +28,12 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
@@ -356,6 +359,53 @@ func (s *serviceSuite) TestServiceDeployWithInvalidPlacement(c *gc.C) {
 	c.Assert(results.Results[0].Error.Error(), gc.Matches, ".* invalid placement is invalid")
 }
 
+func (s *serviceSuite) TestValidateDeploy(c *gc.C) {
+	s.AddTestingService(c, "existing", s.AddTestingCharm(c, "dummy"))
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetSupportedContainers([]instance.ContainerType{instance.KVM})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("a-space", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	valid := params.ApplicationDeploy{
+		ApplicationName:  "application",
+		CharmUrl:         "cs:quantal/dummy-1",
+		NumUnits:         2,
+		Placement:        []*instance.Placement{{Scope: instance.MachineScope, Directive: machine.Id()}},
+		Storage:          map[string]storage.Constraints{"data": {Pool: "loop", Count: 1}},
+		EndpointBindings: map[string]string{"endpoint": "a-space"},
+	}
+	existing := valid
+	existing.ApplicationName = "existing"
+	missingMachine := valid
+	missingMachine.Placement = []*instance.Placement{{Scope: instance.MachineScope, Directive: "42"}}
+	unsupportedContainer := valid
+	unsupportedContainer.Placement = []*instance.Placement{{Scope: "lxd", Directive: machine.Id()}}
+	missingPool := valid
+	missingPool.Storage = map[string]storage.Constraints{"data": {Pool: "foo", Count: 1}}
+	missingSpace := valid
+	missingSpace.EndpointBindings = map[string]string{"endpoint": "no-space"}
+
+	results, err := s.applicationAPI.ValidateDeploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{
+			valid, existing, missingMachine, unsupportedContainer, missingPool, missingSpace,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 6)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "existing" already exists`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `cannot deploy "application": machine 42 not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, fmt.Sprintf(`cannot deploy "application": lxd containers on machine %s not supported`, machine.Id()))
+	c.Check(results.Results[4].Error, gc.ErrorMatches, `invalid storage "data": .* pool "foo" not found`)
+	c.Check(results.Results[5].Error, gc.ErrorMatches, `cannot bind "endpoint": space "no-space" not found`)
+
+	// Nothing was deployed.
+	_, err = s.State.Application("application")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *serviceSuite) testClientServicesDeployWithBindings(c *gc.C, endpointBindings, expected map[string]string) {
 	curl, _ := s.UploadCharm(c, "utopic/riak-42", "riak")
 	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -502,6 +552,35 @@ func (s *serviceSuite) TestAddCharmWithAuthorization(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 }
 
+type charmRepositorySuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&charmRepositorySuite{})
+
+func (s *charmRepositorySuite) SetUpTest(c *gc.C) {
+	s.ControllerConfigAttrs = map[string]interface{}{
+		controller.CharmRepository: testcharms.Repo.Path(),
+	}
+	s.JujuConnSuite.SetUpTest(c)
+}
+
+func (s *charmRepositorySuite) TestAddCharmFromDirectoryRepository(c *gc.C) {
+	results, err := application.ResolveCharms(s.State, params.ResolveCharms{
+		References: []string{"cs:quantal/dummy"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.URLs, jc.DeepEquals, []params.ResolveCharmResult{{URL: "cs:quantal/dummy"}})
+
+	err = application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: "cs:quantal/dummy-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sch, err := s.State.Charm(charm.MustParseURL("cs:quantal/dummy-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.Meta().Name, gc.Equals, "dummy")
+}
+
 func (s *serviceSuite) TestAddCharmConcurrently(c *gc.C) {
 	if runtime.GOOS == "windows" {
 		c.Skip("bug 1596960: Skipping this on windows for now")
@@ -655,6 +734,79 @@ func (s *serviceSuite) setupServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *serviceSuite) setupServiceSetCharms(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			CharmUrl:        "cs:~who/precise/dummy-0",
+			ApplicationName: "another",
+			NumUnits:        1,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *serviceSuite) assertApplicationCharmURL(c *gc.C, name, expect string) {
+	application, err := s.State.Application(name)
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := application.CharmURL()
+	c.Assert(curl.String(), gc.Equals, expect)
+}
+
+func (s *serviceSuite) TestServiceSetCharms(c *gc.C) {
+	s.setupServiceSetCharms(c)
+	results, err := s.applicationAPI.SetCharms(params.ApplicationsSetCharm{
+		Applications: []params.ApplicationSetCharm{{
+			ApplicationName: "application",
+			CharmUrl:        "cs:~who/precise/wordpress-3",
+		}, {
+			ApplicationName: "another",
+			CharmUrl:        "cs:~who/precise/wordpress-3",
+			ForceUnits:      true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}, {}})
+	s.assertApplicationCharmURL(c, "application", "cs:~who/precise/wordpress-3")
+	s.assertApplicationCharmURL(c, "another", "cs:~who/precise/wordpress-3")
+}
+
+func (s *serviceSuite) TestServiceSetCharmsRequiresRevision(c *gc.C) {
+	s.setupServiceSetCharms(c)
+	results, err := s.applicationAPI.SetCharms(params.ApplicationsSetCharm{
+		Applications: []params.ApplicationSetCharm{{
+			ApplicationName: "application",
+			CharmUrl:        "cs:~who/precise/wordpress-3",
+		}, {
+			ApplicationName: "another",
+			CharmUrl:        "cs:~who/precise/wordpress",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "not upgraded, because the upgrade of another application failed")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `charm URL "cs:~who/precise/wordpress" without revision not valid`)
+	s.assertApplicationCharmURL(c, "application", "cs:~who/precise/dummy-0")
+	s.assertApplicationCharmURL(c, "another", "cs:~who/precise/dummy-0")
+}
+
+func (s *serviceSuite) TestServiceSetCharmsRefused(c *gc.C) {
+	s.setupServiceSetCharms(c)
+	results, err := s.applicationAPI.SetCharms(params.ApplicationsSetCharm{
+		Applications: []params.ApplicationSetCharm{{
+			ApplicationName: "application",
+			CharmUrl:        "cs:~who/precise/wordpress-3",
+		}, {
+			ApplicationName: "missing",
+			CharmUrl:        "cs:~who/precise/wordpress-3",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade charms: application "missing" not found`)
+	c.Assert(results.Results, gc.HasLen, 0)
+	s.assertApplicationCharmURL(c, "application", "cs:~who/precise/dummy-0")
+}
+
 func (s *serviceSuite) assertServiceSetCharm(c *gc.C, forceUnits bool) {
 	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "application",
@@ -1298,6 +1450,59 @@ func (s *serviceSuite) TestServiceUpdateSetMinUnitsError(c *gc.C) {
 	c.Assert(application.MinUnits(), gc.Equals, 0)
 }
 
+func (s *serviceSuite) TestServiceUpdateSetMaxUnits(c *gc.C) {
+	application := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	// Set maximum units for the application.
+	maxUnits := 3
+	args := params.ApplicationUpdate{
+		ApplicationName: "dummy",
+		MaxUnits:        &maxUnits,
+	}
+	err := s.applicationAPI.Update(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ensure the maximum number of units has been set.
+	c.Assert(application.Refresh(), gc.IsNil)
+	c.Assert(application.MaxUnits(), gc.Equals, maxUnits)
+}
+
+func (s *serviceSuite) TestServiceUpdateSetUnitLimitsOrdering(c *gc.C) {
+	application := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := application.SetMinUnits(1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.SetMaxUnits(2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, limits := range [][2]int{{4, 6}, {1, 1}, {0, 0}} {
+		c.Logf("test %d: min %d, max %d", i, limits[0], limits[1])
+		minUnits, maxUnits := limits[0], limits[1]
+		err := s.applicationAPI.Update(params.ApplicationUpdate{
+			ApplicationName: "dummy",
+			MinUnits:        &minUnits,
+			MaxUnits:        &maxUnits,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(application.Refresh(), gc.IsNil)
+		c.Assert(application.MinUnits(), gc.Equals, minUnits)
+		c.Assert(application.MaxUnits(), gc.Equals, maxUnits)
+	}
+}
+
+func (s *serviceSuite) TestServiceUpdateSetUnitLimitsError(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	minUnits, maxUnits := 3, 2
+	args := params.ApplicationUpdate{
+		ApplicationName: "dummy",
+		MinUnits:        &minUnits,
+		MaxUnits:        &maxUnits,
+	}
+	err := s.applicationAPI.Update(args)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot set minimum units for application "dummy": minimum units 3 is greater than maximum units 2`)
+}
+
 func (s *serviceSuite) TestServiceUpdateSetSettingsStrings(c *gc.C) {
 	application := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
 
@@ -1473,6 +1678,23 @@ func (s *serviceSuite) TestServiceSet(c *gc.C) {
 	})
 }
 
+func (s *serviceSuite) TestServiceSetRecordsEvent(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationAPI.Set(params.ApplicationSet{ApplicationName: "dummy", Options: map[string]string{
+		"username": validSetTestValue,
+		"title":    "foobar",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	events, err := s.State.ModelEvents(state.ModelEventFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Kind, gc.Equals, state.ConfigChangeEvent)
+	c.Assert(events[0].Actor, gc.Equals, s.AdminUserTag(c).String())
+	c.Assert(events[0].Entity, gc.Equals, "application-dummy")
+	c.Assert(events[0].Message, gc.Equals, "changed config: title, username")
+}
+
 func (s *serviceSuite) assertServiceSetBlocked(c *gc.C, dummy *state.Application, msg string) {
 	err := s.applicationAPI.Set(params.ApplicationSet{
 		ApplicationName: "dummy",
@@ -1750,6 +1972,23 @@ func (s *serviceSuite) assertAddServiceUnits(c *gc.C) {
 	c.Assert(assignedMachine, gc.Equals, "0")
 }
 
+func (s *serviceSuite) TestAddUnitsRecordsEvent(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "dummy",
+		NumUnits:        2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	events, err := s.State.ModelEvents(state.ModelEventFilter{
+		Kinds: []state.ModelEventKind{state.AddUnitEvent},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Actor, gc.Equals, s.AdminUserTag(c).String())
+	c.Assert(events[0].Entity, gc.Equals, "application-dummy")
+	c.Assert(events[0].Message, gc.Equals, "added dummy/0, dummy/1")
+}
+
 func (s *serviceSuite) TestServiceCharmRelations(c *gc.C) {
 	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
@@ -1818,7 +2057,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1830,6 +2069,61 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeEndpoints(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedEndpoints(), jc.DeepEquals, map[string][]string{
+		"": {"10.0.0.0/8"},
+	})
+
+	// Exposure cannot be enforced for individual endpoints.
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"url": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `exposing endpoint "url" alone not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *serviceSuite) TestServiceExposeToCIDRsNotSupported(c *gc.C) {
+	s.PatchValue(application.GetEnviron, func(st *state.State) (environs.Environ, error) {
+		env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+		// Hide the environ's support for restricting port sources.
+		return struct{ environs.Environ }{env}, err
+	})
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `exposing to source CIDRs with firewall-mode "instance" on this cloud not supported`)
+	application, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsFalse)
+
+	// Exposing to any address is still allowed.
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1870,7 +2164,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1885,7 +2179,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -1908,6 +2202,58 @@ func (s *serviceSuite) TestBlockChangesServiceExpose(c *gc.C) {
 	s.assertServiceExposeBlocked(c, "TestBlockChangesServiceExpose")
 }
 
+func (s *serviceSuite) TestBlockChangesApplicationExpose(c *gc.C) {
+	s.setupServiceExpose(c)
+	err := s.State.SwitchEntityBlockOn(state.ChangeBlock, names.NewApplicationTag("dummy-service"), "TestBlockChangesApplicationExpose")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: "dummy-service"})
+	s.AssertBlocked(c, err, "TestBlockChangesApplicationExpose")
+	err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: "exposed-service"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *serviceSuite) TestSetHookSandbox(c *gc.C) {
+	application := s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.SetHookSandbox(params.ApplicationSetHookSandbox{
+		ApplicationName: "dummy-service",
+		Sandbox: params.HookSandbox{
+			User:         "hooks",
+			MemoryLimit:  512,
+			CPUQuota:     50,
+			LXDContainer: "hook-jail",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.HookSandbox(), gc.Equals, state.HookSandbox{
+		User:         "hooks",
+		MemoryLimit:  512,
+		CPUQuota:     50,
+		LXDContainer: "hook-jail",
+	})
+}
+
+func (s *serviceSuite) TestSetHookSandboxInvalid(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.SetHookSandbox(params.ApplicationSetHookSandbox{
+		ApplicationName: "dummy-service",
+		Sandbox:         params.HookSandbox{CPUQuota: -1},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook sandbox for application "dummy-service": CPU quota -1% not valid`)
+}
+
+func (s *serviceSuite) TestBlockChangesSetHookSandbox(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangesSetHookSandbox")
+	err := s.applicationAPI.SetHookSandbox(params.ApplicationSetHookSandbox{
+		ApplicationName: "dummy-service",
+		Sandbox:         params.HookSandbox{User: "hooks"},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesSetHookSandbox")
+}
+
 var serviceUnexposeTests = []struct {
 	about    string
 	service  string
@@ -2167,6 +2513,31 @@ func (s *serviceSuite) setupDestroyPrincipalUnits(c *gc.C) []*state.Unit {
 	return units
 }
 
+func (s *serviceSuite) TestForceDestroyDyingUnits(c *gc.C) {
+	units := s.setupDestroyPrincipalUnits(c)
+	err := s.applicationAPI.DestroyUnits(params.DestroyApplicationUnits{
+		UnitNames: []string{"wordpress/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, units[0], state.Dying)
+
+	// Force the removal of the dying unit, and an alive one.
+	err = s.applicationAPI.DestroyUnits(params.DestroyApplicationUnits{
+		UnitNames: []string{"wordpress/0", "wordpress/1"},
+		Force:     true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 3; i++ {
+		err = s.State.Cleanup()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for _, unit := range units[:2] {
+		err = unit.Refresh()
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	assertLife(c, units[2], state.Alive)
+}
+
 func (s *serviceSuite) assertBlockedErrorAndLiveliness(
 	c *gc.C,
 	err error,
@@ -2539,6 +2910,93 @@ func (s *serviceSuite) TestNoRelation(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
 }
 
+func (s *serviceSuite) TestRelationScopes(c *gc.C) {
+	rel := s.setupDestroyRelationScenario(c, []string{"wordpress", "mysql"})
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress0, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(wordpress0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.PrepareLeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.applicationAPI.RelationScopes(params.RelationScopes{
+		Endpoints: []string{"mysql", "wordpress"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RelationScopesResult{
+		Id:   rel.Id(),
+		Key:  "wordpress:db mysql:server",
+		Life: params.Alive,
+		Units: []params.RelationScopeUnit{{
+			Unit:        "wordpress/0",
+			Role:        "requirer",
+			Departing:   true,
+			JoinedUnits: []string{"mysql/0"},
+		}},
+	})
+}
+
+func (s *serviceSuite) TestRelationScopesNoRelation(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err := s.applicationAPI.RelationScopes(params.RelationScopes{
+		Endpoints: []string{"wordpress", "mysql"},
+	})
+	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
+}
+
+func (s *serviceSuite) TestSetSubordinatePlacement(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.SetSubordinatePlacement(params.SetSubordinatePlacement{
+		Endpoints:          []string{"logging", "wordpress"},
+		ExcludeControllers: true,
+		ExcludeMachines:    []string{"1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.SubordinatePlacement(), jc.DeepEquals, state.SubordinatePlacement{
+		ExcludeControllers: true,
+		ExcludeMachines:    []string{"1"},
+	})
+}
+
+func (s *serviceSuite) TestSetSubordinatePlacementNoRelation(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	err := s.applicationAPI.SetSubordinatePlacement(params.SetSubordinatePlacement{
+		Endpoints:          []string{"wordpress", "logging"},
+		ExcludeControllers: true,
+	})
+	c.Assert(err, gc.ErrorMatches, `relation "logging:info wordpress:juju-info" not found`)
+}
+
+func (s *serviceSuite) TestBlockChangesSetSubordinatePlacement(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockAllChanges(c, "TestBlockChangesSetSubordinatePlacement")
+	err = s.applicationAPI.SetSubordinatePlacement(params.SetSubordinatePlacement{
+		Endpoints:          []string{"wordpress", "logging"},
+		ExcludeControllers: true,
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesSetSubordinatePlacement")
+}
+
 func (s *serviceSuite) TestAttemptDestroyingNonExistentRelation(c *gc.C) {
 	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// charmConfigFile is the file in which a charm declares its config
+// options.
+const charmConfigFile = "config.yaml"
+
+// charmConfigChoices returns the choices declared for the charm's
+// config options. Choices extend the charm config schema: an option
+// may list, under "choices", the only values it may be set to, for
+// example:
+//
+//     options:
+//       mode:
+//         type: string
+//         default: fast
+//         choices: [fast, safe]
+//
+// The charm package ignores the extension, so the choices are read
+// from the charm's config.yaml directly.
+func charmConfigChoices(ch charm.Charm) (map[string][]interface{}, error) {
+	var data []byte
+	switch ch := ch.(type) {
+	case *charm.CharmArchive:
+		var err error
+		if data, err = readArchiveFile(ch.Path, charmConfigFile); err != nil {
+			return nil, errors.Trace(err)
+		}
+	case *charm.CharmDir:
+		var err error
+		data, err = ioutil.ReadFile(filepath.Join(ch.Path, charmConfigFile))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Trace(err)
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return parseConfigChoices(ch.Config(), data)
+}
+
+// readArchiveFile returns the contents of the named file in the zip
+// archive at the given path, or nil if there is no such file.
+func readArchiveFile(archivePath, name string) ([]byte, error) {
+	zipr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer zipr.Close()
+	for _, f := range zipr.File {
+		if path.Clean(f.Name) != name {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+	return nil, nil
+}
+
+// parseConfigChoices returns the choices declared in the supplied
+// config.yaml data, converted to the types of their options. It is an
+// error for a choice not to be valid for its option, or for an option's
+// default not to be among its choices.
+func parseConfigChoices(config *charm.Config, data []byte) (map[string][]interface{}, error) {
+	var raw struct {
+		Options map[string]struct {
+			Choices []interface{} `yaml:"choices"`
+		} `yaml:"options"`
+	}
+	if err := goyaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Annotate(err, "cannot parse charm config")
+	}
+	var choices map[string][]interface{}
+	for name, option := range raw.Options {
+		if option.Choices == nil {
+			continue
+		}
+		if len(option.Choices) == 0 {
+			return nil, errors.NotValidf("empty choices for option %q", name)
+		}
+		values := make([]interface{}, len(option.Choices))
+		for i, choice := range option.Choices {
+			validated, err := config.ValidateSettings(charm.Settings{name: choice})
+			if err != nil {
+				return nil, errors.Annotatef(err, "invalid choice for option %q", name)
+			}
+			values[i] = validated[name]
+		}
+		if def := config.Options[name].Default; def != nil && !isChoice(def, values) {
+			return nil, errors.NotValidf("default %#v for option %q, not among its choices", def, name)
+		}
+		if choices == nil {
+			choices = make(map[string][]interface{})
+		}
+		choices[name] = values
+	}
+	return choices, nil
+}
+
+func isChoice(value interface{}, choices []interface{}) bool {
+	for _, choice := range choices {
+		if value == choice {
+			return true
+		}
+	}
+	return false
+}
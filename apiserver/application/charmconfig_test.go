@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type charmConfigSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&charmConfigSuite{})
+
+const choicesConfigYAML = `
+options:
+  mode:
+    type: string
+    default: fast
+    choices: [fast, safe]
+  level:
+    type: int
+    choices: [1, 2, 3]
+  title:
+    type: string
+`
+
+func (s *charmConfigSuite) parse(c *gc.C, data string) (map[string][]interface{}, error) {
+	config, err := charm.ReadConfig(strings.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	return application.ParseConfigChoices(config, []byte(data))
+}
+
+func (s *charmConfigSuite) TestParseConfigChoices(c *gc.C) {
+	choices, err := s.parse(c, choicesConfigYAML)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(choices, jc.DeepEquals, map[string][]interface{}{
+		"mode":  {"fast", "safe"},
+		"level": {int64(1), int64(2), int64(3)},
+	})
+}
+
+func (s *charmConfigSuite) TestParseConfigChoicesNone(c *gc.C) {
+	choices, err := s.parse(c, "options:\n  title:\n    type: string\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(choices, gc.IsNil)
+}
+
+func (s *charmConfigSuite) TestParseConfigChoicesInvalid(c *gc.C) {
+	for i, test := range []struct {
+		data string
+		err  string
+	}{{
+		data: "options:\n  level:\n    type: int\n    choices: [1, lots]\n",
+		err:  `invalid choice for option "level": option "level" expected int, got "lots"`,
+	}, {
+		data: "options:\n  mode:\n    type: string\n    default: slow\n    choices: [fast, safe]\n",
+		err:  `default "slow" for option "mode", not among its choices not valid`,
+	}, {
+		data: "options:\n  mode:\n    type: string\n    choices: []\n",
+		err:  `empty choices for option "mode" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.parse(c, test.data)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmrepository"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	jujuversion "github.com/juju/juju/version"
@@ -63,18 +64,15 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 		return nil
 	}
 
-	// Open a charm store client.
-	repo, err := openCSRepo(args)
+	// Open the controller's charm repository.
+	repo, err := openRepository(st, func() (charmrepo.Interface, error) {
+		return openCSRepo(args)
+	})
 	if err != nil {
-		return err
-	}
-	modelConfig, err := st.ModelConfig()
-	if err != nil {
-		return err
+		return errors.Trace(err)
 	}
-	repo = config.SpecializeCharmRepo(repo, modelConfig).(*charmrepo.CharmStore)
 
-	// Get the charm and its information from the store.
+	// Get the charm and its information from the repository.
 	downloadedCharm, err := repo.Get(charmURL)
 	if err != nil {
 		cause := errors.Cause(err)
@@ -121,6 +119,32 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	return StoreCharmArchive(st, ca)
 }
 
+// openRepository opens the charm repository configured for the
+// controller, using openCharmStore if that is the charm store. The
+// repository is controller configuration, rather than model
+// configuration, because opening it reads from the controller's
+// filesystem or network.
+func openRepository(st *state.State, openCharmStore func() (charmrepo.Interface, error)) (charmrepository.Repository, error) {
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelConfig, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charmrepository.Open(charmrepository.OpenParams{
+		Location: controllerConfig.CharmRepository(),
+		OpenCharmStore: func() (charmrepository.Repository, error) {
+			repo, err := openCharmStore()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return config.SpecializeCharmRepo(repo, modelConfig), nil
+		},
+	})
+}
+
 func openCSRepo(args params.AddCharmWithAuthorization) (charmrepo.Interface, error) {
 	csClient, err := openCSClient(args)
 	if err != nil {
@@ -198,6 +222,10 @@ type CharmArchive struct {
 
 // StoreCharmArchive stores a charm archive in environment storage.
 func StoreCharmArchive(st *state.State, archive CharmArchive) error {
+	configChoices, err := charmConfigChoices(archive.Charm)
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm config choices")
+	}
 	storage := newStateStorage(st.ModelUUID(), st.MongoSession())
 	storagePath, err := charmArchiveStoragePath(archive.ID)
 	if err != nil {
@@ -213,6 +241,8 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 		StoragePath: storagePath,
 		SHA256:      archive.SHA256,
 		Macaroon:    archive.Macaroon,
+
+		ConfigChoices: configChoices,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
@@ -253,13 +283,12 @@ func charmArchiveStoragePath(curl *charm.URL) (string, error) {
 func ResolveCharms(st *state.State, args params.ResolveCharms) (params.ResolveCharmResults, error) {
 	var results params.ResolveCharmResults
 
-	envConfig, err := st.ModelConfig()
+	repo, err := openRepository(st, func() (charmrepo.Interface, error) {
+		return NewCharmStoreRepo(csclient.New(csclient.Params{})), nil
+	})
 	if err != nil {
-		return params.ResolveCharmResults{}, err
+		return params.ResolveCharmResults{}, errors.Trace(err)
 	}
-	repo := config.SpecializeCharmRepo(
-		NewCharmStoreRepo(csclient.New(csclient.Params{})),
-		envConfig)
 
 	for _, ref := range args.References {
 		result := params.ResolveCharmResult{}
@@ -279,7 +308,27 @@ func ResolveCharms(st *state.State, args params.ResolveCharms) (params.ResolveCh
 	return results, nil
 }
 
-func resolveCharm(ref *charm.URL, repo charmrepo.Interface) (*charm.URL, error) {
+// ResolveCharmURL resolves the charm reference against the controller's
+// charm repository, returning the fully qualified charm URL,
+// including its revision, and the series the charm supports.
+func ResolveCharmURL(st *state.State, ref *charm.URL, channel string) (*charm.URL, []string, error) {
+	if ref.Schema != "cs" {
+		return nil, nil, errors.Errorf("only charm store charm references are supported, with cs: schema")
+	}
+	repo, err := openRepository(st, func() (charmrepo.Interface, error) {
+		return openCSRepo(params.AddCharmWithAuthorization{Channel: channel})
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	curl, supportedSeries, err := repo.Resolve(ref)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return curl, supportedSeries, nil
+}
+
+func resolveCharm(ref *charm.URL, repo charmrepository.Repository) (*charm.URL, error) {
 	if ref.Schema != "cs" {
 		return nil, fmt.Errorf("only charm store charm references are supported, with cs: schema")
 	}
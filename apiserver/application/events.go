@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// recordEvent records an event concerning the named application in
+// the model's timeline, attributed to the authenticated user. Failing
+// to record the event does not fail the change it describes.
+func (api *API) recordEvent(kind state.ModelEventKind, applicationName, message string) {
+	err := api.state.AddModelEvent(state.ModelEvent{
+		Kind:    kind,
+		Actor:   api.authorizer.GetAuthTag().String(),
+		Entity:  names.NewApplicationTag(applicationName).String(),
+		Message: message,
+	})
+	if err != nil {
+		logger.Errorf("failed to record %s event for %q: %v", kind, applicationName, err)
+	}
+}
+
+// recordConfigChange records the change of the named application's
+// settings with the given keys. Only the keys are recorded, as the
+// values may be secret.
+func (api *API) recordConfigChange(applicationName string, keys []string) {
+	message := "changed config"
+	if len(keys) > 0 {
+		keys = append([]string(nil), keys...)
+		sort.Strings(keys)
+		message += ": " + strings.Join(keys, ", ")
+	}
+	api.recordEvent(state.ConfigChangeEvent, applicationName, message)
+}
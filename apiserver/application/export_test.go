@@ -8,6 +8,8 @@ import "github.com/juju/errors"
 var (
 	ParseSettingsCompatible = parseSettingsCompatible
 	NewStateStorage         = &newStateStorage
+	GetEnviron              = &getEnviron
+	ParseConfigChoices      = parseConfigChoices
 )
 
 func IsMinJujuVersionError(err error) bool {
@@ -159,6 +159,40 @@ func allMacaroonsExpired(now time.Time, ms []macaroon.Slice) bool {
 	return true
 }
 
+// MacaroonsExpiry returns the latest time at which any of the macaroon
+// slices' primary macaroons expires. If none of them carries a
+// time-before caveat, false is returned.
+func MacaroonsExpiry(ms []macaroon.Slice) (time.Time, bool) {
+	var latest time.Time
+	for _, ms := range ms {
+		if len(ms) == 0 {
+			continue
+		}
+		// A macaroon expires at the earliest of its time-before caveats.
+		var expiry time.Time
+		for _, c := range ms[0].Caveats() {
+			if c.Location != "" {
+				continue
+			}
+			cond, arg, err := checkers.ParseCaveat(c.Id)
+			if err != nil || cond != checkers.CondTimeBefore {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339Nano, arg)
+			if err != nil {
+				continue
+			}
+			if expiry.IsZero() || t.Before(expiry) {
+				expiry = t
+			}
+		}
+		if expiry.After(latest) {
+			latest = expiry
+		}
+	}
+	return latest, !latest.IsZero()
+}
+
 // ExternalMacaroonAuthenticator performs authentication for external users using
 // macaroons. If the authentication fails because provided macaroons are invalid,
 // and macaroon authentiction is enabled, it will return a *common.DischargeRequiredError
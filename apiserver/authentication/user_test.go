@@ -196,6 +196,33 @@ func (s *userAuthenticatorSuite) TestMacaroonUserLoginExpired(c *gc.C) {
 	c.Assert(err, gc.Equals, common.ErrLoginExpired)
 }
 
+func (s *userAuthenticatorSuite) TestMacaroonsExpiry(c *gc.C) {
+	now := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	newMacaroon := func(expiries ...time.Time) *macaroon.Macaroon {
+		m := &macaroon.Macaroon{}
+		for _, t := range expiries {
+			err := m.AddFirstPartyCaveat(checkers.TimeBeforeCaveat(t).Condition)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+		return m
+	}
+
+	_, ok := authentication.MacaroonsExpiry(nil)
+	c.Assert(ok, jc.IsFalse)
+	_, ok = authentication.MacaroonsExpiry([]macaroon.Slice{{newMacaroon()}})
+	c.Assert(ok, jc.IsFalse)
+
+	// Each macaroon expires at its earliest time-before caveat, and
+	// the latest of those is reported.
+	expiry, ok := authentication.MacaroonsExpiry([]macaroon.Slice{
+		{newMacaroon(now.Add(time.Hour), now.Add(2*time.Hour))},
+		{newMacaroon(now.Add(3*time.Hour), now.Add(4*time.Hour))},
+		{newMacaroon()},
+	})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(expiry.Equal(now.Add(3*time.Hour)), jc.IsTrue)
+}
+
 func (s *userAuthenticatorSuite) TestCreateLocalLoginMacaroon(c *gc.C) {
 	service := mockBakeryService{}
 	clock := coretesting.NewClock(time.Time{})
@@ -172,6 +172,9 @@ type httpRequestParams struct {
 
 	// nonce holds the machine nonce to provide in the header.
 	nonce string
+
+	// header holds any additional headers to send.
+	header http.Header
 }
 
 func (s *authHttpSuite) sendRequest(c *gc.C, p httpRequestParams) *http.Response {
@@ -193,6 +196,9 @@ func (s *authHttpSuite) sendRequest(c *gc.C, p httpRequestParams) *http.Response
 	if p.nonce != "" {
 		hp.Header.Set(params.MachineNonceHeader, p.nonce)
 	}
+	for name, values := range p.header {
+		hp.Header[name] = values
+	}
 	if hp.Do == nil {
 		hp.Do = utils.GetNonValidatingHTTPClient().Do
 	}
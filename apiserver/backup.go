@@ -15,12 +15,15 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/httpattachment"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/storage"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
 )
 
 var newBackups = func(st *state.State) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(st)
+	stor := backups.NewStorageWithTarget(st, func() (storage.Storage, error) {
+		return backups.OpenTarget(st)
+	})
 	return backups.NewBackups(stor), stor
 }
 
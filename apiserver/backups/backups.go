@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/storage"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
@@ -37,6 +38,7 @@ type Backend interface {
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (state.StateServingInfo, error)
 	RestoreInfo() *state.RestoreInfo
+	BackupTarget() (storage.Storage, error)
 }
 
 // API serves backup-specific API methods.
@@ -105,7 +107,7 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 }
 
 var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(backend)
+	stor := backups.NewStorageWithTarget(backend, backend.BackupTarget)
 	return backups.NewBackups(stor), stor
 }
 
@@ -3,7 +3,10 @@
 
 package backups_test
 
-import "github.com/juju/juju/state"
+import (
+	"github.com/juju/juju/environs/storage"
+	"github.com/juju/juju/state"
+)
 
 type stateShim struct {
 	*state.State
@@ -12,3 +15,7 @@ type stateShim struct {
 func (s *stateShim) MachineSeries(id string) (string, error) {
 	return "xenial", nil
 }
+
+func (s *stateShim) BackupTarget() (storage.Storage, error) {
+	return nil, nil
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs/storage"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 )
 
 // This file contains untested shims to let us wrap state in a sensible
@@ -32,6 +34,11 @@ func (s *stateShim) MachineSeries(id string) (string, error) {
 	return m.Series(), nil
 }
 
+// BackupTarget implements backups.Backend
+func (s *stateShim) BackupTarget() (storage.Storage, error) {
+	return backups.OpenTarget(s.State)
+}
+
 func newAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	return NewAPI(&stateShim{st}, resources, authorizer)
 }
@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -23,11 +24,11 @@ type Block interface {
 	List() (params.BlockResults, error)
 
 	// SwitchBlockOn switches desired block type on for this
-	// environment.
+	// environment, or for an application or machine in it.
 	SwitchBlockOn(params.BlockSwitchParams) params.ErrorResult
 
 	// SwitchBlockOff switches desired block type off for this
-	// environment.
+	// environment, or for an application or machine in it.
 	SwitchBlockOff(params.BlockSwitchParams) params.ErrorResult
 }
 
@@ -120,7 +121,16 @@ func (a *API) SwitchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
 		return params.ErrorResult{Error: common.ServerError(err)}
 	}
 
-	err := a.access.SwitchBlockOn(state.ParseBlockType(args.Type), args.Message)
+	t := state.ParseBlockType(args.Type)
+	var err error
+	if args.Tag == "" {
+		err = a.access.SwitchBlockOn(t, args.Message)
+	} else {
+		var tag names.Tag
+		if tag, err = names.ParseTag(args.Tag); err == nil {
+			err = a.access.SwitchEntityBlockOn(t, tag, args.Message)
+		}
+	}
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
 
@@ -130,6 +140,15 @@ func (a *API) SwitchBlockOff(args params.BlockSwitchParams) params.ErrorResult {
 		return params.ErrorResult{Error: common.ServerError(err)}
 	}
 
-	err := a.access.SwitchBlockOff(state.ParseBlockType(args.Type))
+	t := state.ParseBlockType(args.Type)
+	var err error
+	if args.Tag == "" {
+		err = a.access.SwitchBlockOff(t)
+	} else {
+		var tag names.Tag
+		if tag, err = names.ParseTag(args.Tag); err == nil {
+			err = a.access.SwitchEntityBlockOff(t, tag)
+		}
+	}
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
@@ -79,3 +79,38 @@ func (s *blockSuite) TestSwitchBlockOff(c *gc.C) {
 	c.Assert(err.Error, gc.IsNil)
 	s.assertBlockList(c, 0)
 }
+
+func (s *blockSuite) TestSwitchEntityBlock(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	on := params.BlockSwitchParams{
+		Type:    state.ChangeBlock.String(),
+		Message: "for TestSwitchEntityBlock",
+		Tag:     app.Tag().String(),
+	}
+	err := s.api.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.IsNil)
+
+	all, listErr := s.api.List()
+	c.Assert(listErr, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	c.Assert(all.Results[0].Result.Tag, gc.Equals, app.Tag().String())
+	c.Assert(all.Results[0].Result.Message, gc.Equals, "for TestSwitchEntityBlock")
+
+	off := params.BlockSwitchParams{
+		Type: state.ChangeBlock.String(),
+		Tag:  app.Tag().String(),
+	}
+	err = s.api.SwitchBlockOff(off)
+	c.Assert(err.Error, gc.IsNil)
+	s.assertBlockList(c, 0)
+}
+
+func (s *blockSuite) TestSwitchEntityBlockInvalidTag(c *gc.C) {
+	on := params.BlockSwitchParams{
+		Type: state.ChangeBlock.String(),
+		Tag:  "wordpress",
+	}
+	err := s.api.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.ErrorMatches, `"wordpress" is not a valid tag`)
+	s.assertBlockList(c, 0)
+}
@@ -0,0 +1,182 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+// storageConstraintsFunc returns the storage constraints of the named
+// application.
+type storageConstraintsFunc func(application string) (map[string]state.StorageConstraints, error)
+
+// bundleData returns a bundle which deploys the applications, machines
+// and relations of the model. Units are placed on the same machines as
+// in the model; units in containers are placed in a new container of
+// the same type on the same machine.
+func bundleData(model description.Model, storageConstraints storageConstraintsFunc) (*charm.BundleData, error) {
+	defaultSeries, _ := model.Config()["default-series"].(string)
+	data := &charm.BundleData{
+		Series:       defaultSeries,
+		Applications: make(map[string]*charm.ApplicationSpec),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+
+	for _, machine := range model.Machines() {
+		spec := &charm.MachineSpec{
+			Constraints: constraintsString(machine.Constraints()),
+			Annotations: machine.Annotations(),
+		}
+		if machine.Series() != defaultSeries {
+			spec.Series = machine.Series()
+		}
+		data.Machines[machine.Id()] = spec
+	}
+
+	for _, app := range model.Applications() {
+		spec, err := applicationSpec(app, storageConstraints)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", app.Name())
+		}
+		data.Applications[app.Name()] = spec
+	}
+
+	for _, relation := range model.Relations() {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are established automatically.
+			continue
+		}
+		data.Relations = append(data.Relations, []string{
+			endpoints[0].ApplicationName() + ":" + endpoints[0].Name(),
+			endpoints[1].ApplicationName() + ":" + endpoints[1].Name(),
+		})
+	}
+	sort.Sort(relationsByEndpoints(data.Relations))
+	return data, nil
+}
+
+func applicationSpec(app description.Application, storageConstraints storageConstraintsFunc) (*charm.ApplicationSpec, error) {
+	curl, err := charm.ParseURL(app.CharmURL())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec := &charm.ApplicationSpec{
+		Charm:       app.CharmURL(),
+		Expose:      app.Exposed(),
+		Constraints: constraintsString(app.Constraints()),
+		Annotations: app.Annotations(),
+	}
+	if curl.Series == "" {
+		spec.Series = app.Series()
+	}
+	if settings := app.Settings(); len(settings) > 0 {
+		spec.Options = settings
+	}
+	if !app.Subordinate() {
+		units := app.Units()
+		sort.Sort(unitsByNumber(units))
+		spec.NumUnits = len(units)
+		for _, unit := range units {
+			spec.To = append(spec.To, unitPlacement(unit.Machine().Id()))
+		}
+	}
+
+	storage, err := storageConstraints(app.Name())
+	if err != nil {
+		return nil, errors.Annotate(err, "getting storage constraints")
+	}
+	for name, cons := range storage {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		spec.Storage[name] = fmt.Sprintf("%s,%d,%dM", cons.Pool, cons.Count, cons.Size)
+	}
+	return spec, nil
+}
+
+// unitPlacement returns the placement directive which places a unit
+// on the machine with the given id, or in a new container of the same
+// type on the same host if the machine is a container.
+func unitPlacement(machineId string) string {
+	parts := strings.Split(machineId, "/")
+	if len(parts) < 3 {
+		return machineId
+	}
+	n := len(parts)
+	return parts[n-2] + ":" + strings.Join(parts[:n-2], "/")
+}
+
+// constraintsString returns the constraints in the form accepted by
+// bundles.
+func constraintsString(cons description.Constraints) string {
+	if cons == nil {
+		return ""
+	}
+	var result constraints.Value
+	if arch := cons.Architecture(); arch != "" {
+		result.Arch = &arch
+	}
+	if container := instance.ContainerType(cons.Container()); container != "" {
+		result.Container = &container
+	}
+	if cores := cons.CpuCores(); cores != 0 {
+		result.CpuCores = &cores
+	}
+	if power := cons.CpuPower(); power != 0 {
+		result.CpuPower = &power
+	}
+	if inst := cons.InstanceType(); inst != "" {
+		result.InstanceType = &inst
+	}
+	if mem := cons.Memory(); mem != 0 {
+		result.Mem = &mem
+	}
+	if disk := cons.RootDisk(); disk != 0 {
+		result.RootDisk = &disk
+	}
+	if spaces := cons.Spaces(); len(spaces) > 0 {
+		result.Spaces = &spaces
+	}
+	if tags := cons.Tags(); len(tags) > 0 {
+		result.Tags = &tags
+	}
+	if virt := cons.VirtType(); virt != "" {
+		result.VirtType = &virt
+	}
+	return result.String()
+}
+
+type unitsByNumber []description.Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i]) < unitNumber(u[j])
+}
+
+func unitNumber(unit description.Unit) int {
+	name := unit.Name()
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return n
+}
+
+type relationsByEndpoints [][]string
+
+func (r relationsByEndpoints) Len() int      { return len(r) }
+func (r relationsByEndpoints) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoints) Less(i, j int) bool {
+	return strings.Join(r[i], " ") < strings.Join(r[j], " ")
+}
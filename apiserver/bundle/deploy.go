@@ -0,0 +1,635 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.bundle")
+
+// Deployer defines the model operations used to deploy a bundle.
+type Deployer interface {
+	// ResolveCharm resolves the charm reference against the model's
+	// charm repository, returning the fully qualified charm URL and
+	// the series the charm supports.
+	ResolveCharm(ref *charm.URL, channel string) (*charm.URL, []string, error)
+
+	// AddCharm adds the charm to the model, if it is not already
+	// present, and returns its metadata.
+	AddCharm(curl *charm.URL, channel string) (*charm.Meta, error)
+
+	// ApplicationCharm returns the charm URL of the named
+	// application, or an error satisfying errors.IsNotFound if there
+	// is no such application.
+	ApplicationCharm(name string) (*charm.URL, error)
+
+	// DeployApplication adds a new application, without units.
+	DeployApplication(args DeployApplicationArgs) error
+
+	// AddMachine adds a machine or container, returning its id.
+	AddMachine(args AddMachineArgs) (string, error)
+
+	// AddUnit adds a unit of the application to the given machine,
+	// or to a new machine if machineId is empty. It returns the name
+	// of the unit and the id of its machine.
+	AddUnit(application, machineId string) (string, string, error)
+
+	// AddRelation relates the given endpoints. If the relation
+	// already exists, an error satisfying errors.IsAlreadyExists is
+	// returned.
+	AddRelation(endpoint1, endpoint2 string) error
+
+	// Expose exposes the application.
+	Expose(application string) error
+
+	// SetAnnotations sets annotations on a machine or application.
+	SetAnnotations(entity names.Tag, annotations map[string]string) error
+
+	// DestroyApplication, DestroyMachine and DestroyRelation undo
+	// changes when a deployment fails.
+	DestroyApplication(name string) error
+	DestroyMachine(id string) error
+	DestroyRelation(endpoint1, endpoint2 string) error
+}
+
+// DeployApplicationArgs holds the arguments for Deployer.DeployApplication.
+type DeployApplicationArgs struct {
+	Name             string
+	CharmURL         *charm.URL
+	Channel          string
+	Series           string
+	Options          map[string]interface{}
+	Constraints      constraints.Value
+	Storage          map[string]storage.Constraints
+	EndpointBindings map[string]string
+}
+
+// AddMachineArgs holds the arguments for Deployer.AddMachine.
+type AddMachineArgs struct {
+	Series        string
+	Constraints   constraints.Value
+	ContainerType instance.ContainerType
+	ParentId      string
+}
+
+// DeployBundle deploys the given bundle into the model. The changes
+// required are computed on the controller and applied in order; if
+// any change fails, those already applied are undone. With DryRun
+// set, the changes are only planned and validated.
+//
+// Bundles referring to applications that already exist with the
+// same charm reuse them, adding neither the applications nor their
+// units. Errors satisfying params.IsCodeNotSupported are returned,
+// before the model is changed, for bundles that can only be deployed
+// by the client, such as those upgrading existing applications or
+// using charms with resources.
+func (f *Facade) DeployBundle(args params.DeployBundleArgs) (params.DeployBundleResult, error) {
+	if args.DryRun {
+		if err := f.checkCanRead(); err != nil {
+			return params.DeployBundleResult{}, errors.Trace(err)
+		}
+	} else {
+		if err := f.checkCanWrite(); err != nil {
+			return params.DeployBundleResult{}, errors.Trace(err)
+		}
+		if err := f.backend.ChangeAllowed(); err != nil {
+			return params.DeployBundleResult{}, errors.Trace(err)
+		}
+	}
+
+	var result params.DeployBundleResult
+	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
+	if err != nil {
+		return result, errors.Annotate(err, "cannot read bundle YAML")
+	}
+	if errs, err := verifyBundle(data); err != nil {
+		return result, errors.Trace(err)
+	} else if len(errs) > 0 {
+		result.Errors = errs
+		return result, nil
+	}
+
+	d := newDeployment(f.backend.Deployer(), data, args)
+	err = d.prepare()
+	result.Plan = d.plan
+	if err == nil && !args.DryRun {
+		err = d.apply()
+		result.Events = d.events
+	}
+	result.Error = common.ServerError(err)
+	return result, nil
+}
+
+// verifyBundle returns the verification errors of the bundle.
+func verifyBundle(data *charm.BundleData) ([]string, error) {
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	verifyStorage := func(s string) error {
+		_, err := storage.ParseConstraints(s)
+		return err
+	}
+	err := data.Verify(verifyConstraints, verifyStorage)
+	if err == nil {
+		return nil, nil
+	}
+	verr, ok := err.(*charm.VerificationError)
+	if !ok {
+		// This should never happen as Verify only returns verification errors.
+		return nil, errors.Annotate(err, "cannot verify bundle")
+	}
+	errs := make([]string, len(verr.Errors))
+	for i, e := range verr.Errors {
+		errs[i] = e.Error()
+	}
+	return errs, nil
+}
+
+// deployment holds the state of a single bundle deployment.
+type deployment struct {
+	deployer Deployer
+	data     *charm.BundleData
+	changes  []bundlechanges.Change
+	channel  string
+	storage  map[string]map[string]storage.Constraints
+
+	// names maps change ids to the names of the charms,
+	// applications and machines the changes produce. Before the
+	// changes are applied, machines are named after their plan
+	// description.
+	names map[string]string
+
+	// skipped records the changes not required because they refer
+	// to existing applications.
+	skipped map[string]bool
+
+	// charmSeries maps the charm URLs of the bundle to the series
+	// the charms support.
+	charmSeries map[string][]string
+
+	plan     []string
+	machines int
+	units    map[string]int
+
+	events []params.BundleDeployEvent
+	undo   []func() error
+}
+
+func newDeployment(deployer Deployer, data *charm.BundleData, args params.DeployBundleArgs) *deployment {
+	return &deployment{
+		deployer:    deployer,
+		data:        data,
+		changes:     bundlechanges.FromData(data),
+		channel:     args.Channel,
+		storage:     args.Storage,
+		names:       make(map[string]string),
+		skipped:     make(map[string]bool),
+		charmSeries: make(map[string][]string),
+		units:       make(map[string]int),
+	}
+}
+
+// prepare resolves the bundle's charms, checks its applications
+// against the model and computes the plan, without changing the
+// model.
+func (d *deployment) prepare() error {
+	for _, change := range d.changes {
+		switch change := change.(type) {
+		case *bundlechanges.AddCharmChange:
+			if err := d.resolveCharm(change.Id(), change.Params); err != nil {
+				return errors.Trace(err)
+			}
+		case *bundlechanges.AddApplicationChange:
+			if err := d.checkApplication(change.Id(), change.Params); err != nil {
+				return errors.Trace(err)
+			}
+		case *bundlechanges.AddUnitChange:
+			if d.skipped[placeholderId(change.Params.Application)] {
+				d.skipped[change.Id()] = true
+			}
+		}
+	}
+
+	// Machines are only required if they hold units or containers
+	// that are being added. Changes depending on a machine follow it,
+	// so they are decided first when working backwards.
+	for i := len(d.changes) - 1; i >= 0; i-- {
+		change, ok := d.changes[i].(*bundlechanges.AddMachineChange)
+		if !ok {
+			continue
+		}
+		placed := d.placedOn(change.Id())
+		if len(placed) == 0 {
+			continue
+		}
+		skip := true
+		for _, id := range placed {
+			skip = skip && d.skipped[id]
+		}
+		d.skipped[change.Id()] = skip
+	}
+
+	for _, change := range d.changes {
+		if d.skipped[change.Id()] {
+			continue
+		}
+		switch change := change.(type) {
+		case *bundlechanges.AddCharmChange:
+			d.addPlan("add charm %s", d.names[change.Id()])
+		case *bundlechanges.AddApplicationChange:
+			line := fmt.Sprintf("add application %s using %s", change.Params.Application, d.resolve(change.Params.Charm))
+			if change.Params.Constraints != "" {
+				line += " with constraints " + change.Params.Constraints
+			}
+			d.plan = append(d.plan, line)
+		case *bundlechanges.AddMachineChange:
+			d.planMachine(change.Id(), change.Params)
+		case *bundlechanges.AddUnitChange:
+			d.planUnit(change.Id(), change.Params)
+		case *bundlechanges.AddRelationChange:
+			d.addPlan("add relation %s - %s", d.resolveEndpoint(change.Params.Endpoint1), d.resolveEndpoint(change.Params.Endpoint2))
+		case *bundlechanges.ExposeChange:
+			d.addPlan("expose %s", d.resolve(change.Params.Application))
+		case *bundlechanges.SetAnnotationsChange:
+			if d.skipped[placeholderId(change.Params.Id)] {
+				d.skipped[change.Id()] = true
+				continue
+			}
+			d.addPlan("set annotations for %s", d.resolve(change.Params.Id))
+		default:
+			return errors.Errorf("unknown change type: %T", change)
+		}
+	}
+	return nil
+}
+
+func (d *deployment) resolveCharm(id string, p bundlechanges.AddCharmParams) error {
+	ref, err := charm.ParseURL(p.Charm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ref.Series == "" && p.Series != "" {
+		ref.Series = p.Series
+	}
+	curl, supportedSeries, err := d.deployer.ResolveCharm(ref, d.channel)
+	if err != nil {
+		return errors.Annotatef(err, "cannot resolve URL %q", p.Charm)
+	}
+	if curl.Series == "bundle" {
+		return errors.Errorf("expected charm URL, got bundle URL %q", p.Charm)
+	}
+	d.names[id] = curl.String()
+	d.charmSeries[curl.String()] = supportedSeries
+	return nil
+}
+
+func (d *deployment) checkApplication(id string, p bundlechanges.AddApplicationParams) error {
+	d.names[id] = p.Application
+	existing, err := d.deployer.ApplicationCharm(p.Application)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	curl := d.resolve(p.Charm)
+	if existing.String() != curl {
+		return errors.NotSupportedf("upgrading existing application %q from %q to %q", p.Application, existing, curl)
+	}
+	d.skipped[id] = true
+	d.addPlan("use existing application %s", p.Application)
+	return nil
+}
+
+// placedOn returns the ids of the unit and container changes placed
+// on the machine added by the given change.
+func (d *deployment) placedOn(machineChangeId string) []string {
+	var placed []string
+	for _, change := range d.changes {
+		switch change := change.(type) {
+		case *bundlechanges.AddUnitChange:
+			if placeholderId(change.Params.To) == machineChangeId {
+				placed = append(placed, change.Id())
+			}
+		case *bundlechanges.AddMachineChange:
+			if placeholderId(change.Params.ParentId) == machineChangeId {
+				placed = append(placed, change.Id())
+			}
+		}
+	}
+	return placed
+}
+
+func (d *deployment) planMachine(id string, p bundlechanges.AddMachineParams) {
+	var name string
+	if p.ContainerType == "" {
+		d.machines++
+		name = fmt.Sprintf("new machine #%d", d.machines)
+	} else {
+		name = fmt.Sprintf("new %s container on %s", containerType(p.ContainerType), d.resolve(p.ParentId))
+	}
+	d.names[id] = name
+	line := "add " + name
+	if p.Constraints != "" {
+		line += " with constraints " + p.Constraints
+	}
+	d.plan = append(d.plan, line)
+}
+
+func (d *deployment) planUnit(id string, p bundlechanges.AddUnitParams) {
+	application := d.resolve(p.Application)
+	unitName := fmt.Sprintf("%s/%d", application, d.units[application])
+	d.units[application]++
+	if p.To == "" {
+		d.names[id] = "the machine of " + unitName
+		d.addPlan("add unit %s to a new machine", unitName)
+		return
+	}
+	// Units placed alongside another unit share its machine.
+	d.names[id] = d.resolve(p.To)
+	d.addPlan("add unit %s to %s", unitName, d.names[id])
+}
+
+func (d *deployment) addPlan(format string, args ...interface{}) {
+	d.plan = append(d.plan, fmt.Sprintf(format, args...))
+}
+
+// apply applies the planned changes. Charms are added first, so that
+// charms the controller cannot deploy are found before the model is
+// otherwise changed. If a change fails, the changes already applied
+// are undone.
+func (d *deployment) apply() (err error) {
+	metas := make(map[string]*charm.Meta)
+	for _, change := range d.changes {
+		change, ok := change.(*bundlechanges.AddCharmChange)
+		if !ok {
+			continue
+		}
+		curl := charm.MustParseURL(d.names[change.Id()])
+		meta, err := d.deployer.AddCharm(curl, d.channel)
+		if err != nil {
+			return errors.Annotatef(err, "cannot add charm %q", curl)
+		}
+		if len(meta.Resources) > 0 {
+			return errors.NotSupportedf("deploying charm %q with resources", curl)
+		}
+		metas[curl.String()] = meta
+		d.addEvent(change.Id(), "added charm %s", curl)
+	}
+
+	defer func() {
+		if err != nil {
+			d.rollback()
+		}
+	}()
+	for _, change := range d.changes {
+		if d.skipped[change.Id()] {
+			continue
+		}
+		var err error
+		switch change := change.(type) {
+		case *bundlechanges.AddCharmChange:
+			continue
+		case *bundlechanges.AddApplicationChange:
+			err = d.deployApplication(change.Id(), change.Params, metas)
+		case *bundlechanges.AddMachineChange:
+			err = d.addMachine(change.Id(), change.Params)
+		case *bundlechanges.AddUnitChange:
+			err = d.addUnit(change.Id(), change.Params)
+		case *bundlechanges.AddRelationChange:
+			err = d.addRelation(change.Id(), change.Params)
+		case *bundlechanges.ExposeChange:
+			err = d.expose(change.Id(), change.Params)
+		case *bundlechanges.SetAnnotationsChange:
+			err = d.setAnnotations(change.Id(), change.Params)
+		}
+		if err != nil {
+			return errors.Annotate(err, "cannot deploy bundle")
+		}
+	}
+	return nil
+}
+
+func (d *deployment) deployApplication(id string, p bundlechanges.AddApplicationParams, metas map[string]*charm.Meta) error {
+	curl, err := charm.ParseURL(d.resolve(p.Charm))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cons, err := constraints.Parse(p.Constraints)
+	if err != nil {
+		// This should never happen, as the bundle is already verified.
+		return errors.Annotate(err, "invalid constraints for application")
+	}
+	storageConstraints := make(map[string]storage.Constraints)
+	for name, value := range p.Storage {
+		sc, err := storage.ParseConstraints(value)
+		if err != nil {
+			return errors.Annotate(err, "invalid storage constraints")
+		}
+		storageConstraints[name] = sc
+	}
+	for name, sc := range d.storage[p.Application] {
+		// Storage constraints overridden by the caller.
+		storageConstraints[name] = sc
+	}
+	supportedSeries := d.charmSeries[curl.String()]
+	if meta := metas[curl.String()]; meta != nil && len(meta.Series) > 0 {
+		supportedSeries = meta.Series
+	}
+	series := p.Series
+	if series == "" {
+		series = curl.Series
+	}
+	if series == "" && len(supportedSeries) > 0 {
+		series = supportedSeries[0]
+	}
+	err = d.deployer.DeployApplication(DeployApplicationArgs{
+		Name:             p.Application,
+		CharmURL:         curl,
+		Channel:          d.channel,
+		Series:           series,
+		Options:          p.Options,
+		Constraints:      cons,
+		Storage:          storageConstraints,
+		EndpointBindings: p.EndpointBindings,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot deploy application %q", p.Application)
+	}
+	d.addUndo(func() error { return d.deployer.DestroyApplication(p.Application) })
+	d.addEvent(id, "application %s deployed (charm %s)", p.Application, curl)
+	return nil
+}
+
+func (d *deployment) addMachine(id string, p bundlechanges.AddMachineParams) error {
+	cons, err := constraints.Parse(p.Constraints)
+	if err != nil {
+		// This should never happen, as the bundle is already verified.
+		return errors.Annotate(err, "invalid constraints for machine")
+	}
+	args := AddMachineArgs{
+		Series:      p.Series,
+		Constraints: cons,
+	}
+	if p.ContainerType != "" {
+		ctype, err := instance.ParseContainerType(containerType(p.ContainerType))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		args.ContainerType = ctype
+		if p.ParentId != "" {
+			args.ParentId = d.resolve(p.ParentId)
+		}
+	}
+	machineId, err := d.deployer.AddMachine(args)
+	if err != nil {
+		return errors.Annotate(err, "cannot add machine")
+	}
+	d.names[id] = machineId
+	d.addUndo(func() error { return d.deployer.DestroyMachine(machineId) })
+	switch {
+	case p.ContainerType == "":
+		d.addEvent(id, "created new machine %s", machineId)
+	case p.ParentId == "":
+		d.addEvent(id, "created %s container in new machine", machineId)
+	default:
+		d.addEvent(id, "created %s container in machine %s", machineId, args.ParentId)
+	}
+	return nil
+}
+
+func (d *deployment) addUnit(id string, p bundlechanges.AddUnitParams) error {
+	application := d.resolve(p.Application)
+	var placement string
+	if p.To != "" {
+		placement = d.resolve(p.To)
+	}
+	unit, machineId, err := d.deployer.AddUnit(application, placement)
+	if err != nil {
+		return errors.Annotatef(err, "cannot add unit for application %q", application)
+	}
+	// Units placed alongside this one share its machine.
+	d.names[id] = machineId
+	if placement == "" {
+		d.addUndo(func() error { return d.deployer.DestroyMachine(machineId) })
+		d.addEvent(id, "added %s unit to new machine %s", unit, machineId)
+	} else {
+		d.addEvent(id, "added %s unit to machine %s", unit, machineId)
+	}
+	return nil
+}
+
+func (d *deployment) addRelation(id string, p bundlechanges.AddRelationParams) error {
+	ep1 := d.resolveEndpoint(p.Endpoint1)
+	ep2 := d.resolveEndpoint(p.Endpoint2)
+	err := d.deployer.AddRelation(ep1, ep2)
+	if errors.IsAlreadyExists(err) {
+		d.addEvent(id, "%s and %s are already related", ep1, ep2)
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot add relation between %q and %q", ep1, ep2)
+	}
+	d.addUndo(func() error { return d.deployer.DestroyRelation(ep1, ep2) })
+	d.addEvent(id, "related %s and %s", ep1, ep2)
+	return nil
+}
+
+func (d *deployment) expose(id string, p bundlechanges.ExposeParams) error {
+	application := d.resolve(p.Application)
+	if err := d.deployer.Expose(application); err != nil {
+		return errors.Annotatef(err, "cannot expose application %s", application)
+	}
+	d.addEvent(id, "application %s exposed", application)
+	return nil
+}
+
+func (d *deployment) setAnnotations(id string, p bundlechanges.SetAnnotationsParams) error {
+	eid := d.resolve(p.Id)
+	var tag names.Tag
+	switch p.EntityType {
+	case bundlechanges.MachineType:
+		tag = names.NewMachineTag(eid)
+	case bundlechanges.ApplicationType:
+		tag = names.NewApplicationTag(eid)
+	default:
+		return errors.Errorf("unexpected annotation entity type %q", p.EntityType)
+	}
+	if err := d.deployer.SetAnnotations(tag, p.Annotations); err != nil {
+		return errors.Annotatef(err, "cannot set annotations for %s %q", p.EntityType, eid)
+	}
+	d.addEvent(id, "annotations set for %s %s", p.EntityType, eid)
+	return nil
+}
+
+func (d *deployment) addEvent(id, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	logger.Infof("bundle deployment: %s", message)
+	d.events = append(d.events, params.BundleDeployEvent{
+		Time:    time.Now().UTC(),
+		Change:  id,
+		Message: message,
+	})
+}
+
+func (d *deployment) addUndo(undo func() error) {
+	d.undo = append(d.undo, undo)
+}
+
+// rollback undoes the applied changes, most recent first. Failures
+// are logged, and do not stop the remaining changes being undone.
+func (d *deployment) rollback() {
+	for i := len(d.undo) - 1; i >= 0; i-- {
+		if err := d.undo[i](); err != nil {
+			logger.Errorf("cannot undo bundle change: %v", err)
+		}
+	}
+	d.undo = nil
+}
+
+// resolve returns the name recorded for the change referred to by
+// the given placeholder, such as "$deploy-1".
+func (d *deployment) resolve(placeholder string) string {
+	if name, ok := d.names[placeholderId(placeholder)]; ok {
+		return name
+	}
+	return placeholder
+}
+
+// resolveEndpoint resolves the application placeholder of a relation
+// endpoint.
+func (d *deployment) resolveEndpoint(endpoint string) string {
+	parts := strings.SplitN(endpoint, ":", 2)
+	parts[0] = d.resolve(parts[0])
+	return strings.Join(parts, ":")
+}
+
+// placeholderId returns the change id referred to by a placeholder.
+func placeholderId(placeholder string) string {
+	return strings.TrimPrefix(placeholder, "$")
+}
+
+// containerType returns the container type to use for bundle
+// containers: for compatibility with 1.x bundles, lxc containers
+// are deployed as lxd.
+func containerType(ctype string) string {
+	if ctype == "lxc" {
+		return string(instance.LXD)
+	}
+	return ctype
+}
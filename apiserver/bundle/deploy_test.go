@@ -0,0 +1,207 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/storage"
+)
+
+const wordpressBundle = `
+applications:
+  mysql:
+    charm: cs:xenial/mysql
+    num_units: 1
+  wordpress:
+    charm: cs:xenial/wordpress
+    num_units: 1
+    expose: true
+relations:
+- [wordpress:db, mysql:server]
+`
+
+func (s *facadeSuite) deployBundle(c *gc.C, dryRun bool) params.DeployBundleResult {
+	result, err := s.facade.DeployBundle(params.DeployBundleArgs{
+		BundleDataYAML: wordpressBundle,
+		Channel:        "stable",
+		Storage: map[string]map[string]storage.Constraints{
+			"mysql": {"data": {Pool: "ebs", Count: 1, Size: 1024}},
+		},
+		DryRun: dryRun,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+func (s *facadeSuite) deployerCalls() []string {
+	var names []string
+	for _, call := range s.backend.deployer.Calls() {
+		names = append(names, call.FuncName)
+	}
+	return names
+}
+
+func eventMessages(events []params.BundleDeployEvent) []string {
+	messages := make([]string, len(events))
+	for i, event := range events {
+		messages[i] = event.Message
+	}
+	return messages
+}
+
+func (s *facadeSuite) TestDeployBundleWriteAccessRequired(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	facade, err := bundle.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.DeployBundle(params.DeployBundleArgs{BundleDataYAML: wordpressBundle})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = facade.DeployBundle(params.DeployBundleArgs{BundleDataYAML: wordpressBundle, DryRun: true})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.deployer.CheckNoCalls(c)
+}
+
+func (s *facadeSuite) TestDeployBundleBlocked(c *gc.C) {
+	s.backend.SetErrors(errors.New("deployment blocked"))
+	_, err := s.facade.DeployBundle(params.DeployBundleArgs{BundleDataYAML: wordpressBundle})
+	c.Assert(err, gc.ErrorMatches, "deployment blocked")
+	s.backend.deployer.CheckNoCalls(c)
+}
+
+func (s *facadeSuite) TestDeployBundleVerificationErrors(c *gc.C) {
+	result, err := s.facade.DeployBundle(params.DeployBundleArgs{
+		BundleDataYAML: `
+applications:
+  mysql:
+    charm: cs:xenial/mysql
+    constraints: bad-wolf
+`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Errors, gc.HasLen, 1)
+	c.Assert(result.Errors[0], gc.Matches, `invalid constraints "bad-wolf" in application "mysql": .*`)
+	s.backend.deployer.CheckNoCalls(c)
+}
+
+func (s *facadeSuite) TestDeployBundleDryRun(c *gc.C) {
+	result := s.deployBundle(c, true)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Events, gc.HasLen, 0)
+	c.Assert(result.Plan, jc.SameContents, []string{
+		"add charm cs:xenial/mysql-42",
+		"add application mysql using cs:xenial/mysql-42",
+		"add charm cs:xenial/wordpress-42",
+		"add application wordpress using cs:xenial/wordpress-42",
+		"expose wordpress",
+		"add relation wordpress:db - mysql:server",
+		"add unit mysql/0 to a new machine",
+		"add unit wordpress/0 to a new machine",
+	})
+	for _, name := range s.deployerCalls() {
+		c.Check(name, gc.Matches, "ResolveCharm|ApplicationCharm")
+	}
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *facadeSuite) TestDeployBundle(c *gc.C) {
+	result := s.deployBundle(c, false)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(eventMessages(result.Events), jc.SameContents, []string{
+		"added charm cs:xenial/mysql-42",
+		"added charm cs:xenial/wordpress-42",
+		"application mysql deployed (charm cs:xenial/mysql-42)",
+		"application wordpress deployed (charm cs:xenial/wordpress-42)",
+		"application wordpress exposed",
+		"related wordpress:db and mysql:server",
+		"added mysql/0 unit to new machine 0",
+		"added wordpress/0 unit to new machine 1",
+	})
+	s.backend.CheckCallNames(c, "ChangeAllowed")
+
+	var deployed []bundle.DeployApplicationArgs
+	for _, call := range s.backend.deployer.Calls() {
+		if call.FuncName == "DeployApplication" {
+			deployed = append(deployed, call.Args[0].(bundle.DeployApplicationArgs))
+		}
+	}
+	c.Assert(deployed, gc.HasLen, 2)
+	c.Assert(deployed[0], jc.DeepEquals, bundle.DeployApplicationArgs{
+		Name:        "mysql",
+		CharmURL:    charm.MustParseURL("cs:xenial/mysql-42"),
+		Channel:     "stable",
+		Series:      "xenial",
+		Constraints: constraints.Value{},
+		Storage: map[string]storage.Constraints{
+			"data": {Pool: "ebs", Count: 1, Size: 1024},
+		},
+	})
+}
+
+func (s *facadeSuite) TestDeployBundleRollback(c *gc.C) {
+	s.backend.deployer.failures["AddRelation"] = errors.New("boom")
+	result := s.deployBundle(c, false)
+	c.Assert(result.Error, gc.ErrorMatches, `cannot deploy bundle: cannot add relation between "wordpress:db" and "mysql:server": boom`)
+
+	var destroyed []string
+	for _, call := range s.backend.deployer.Calls() {
+		if call.FuncName == "DestroyApplication" {
+			destroyed = append(destroyed, call.Args[0].(string))
+		}
+	}
+	c.Assert(destroyed, jc.DeepEquals, []string{"wordpress", "mysql"})
+}
+
+func (s *facadeSuite) TestDeployBundleExistingApplication(c *gc.C) {
+	s.backend.deployer.applications["mysql"] = charm.MustParseURL("cs:xenial/mysql-42")
+	result := s.deployBundle(c, false)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Plan, jc.SameContents, []string{
+		"use existing application mysql",
+		"add charm cs:xenial/mysql-42",
+		"add charm cs:xenial/wordpress-42",
+		"add application wordpress using cs:xenial/wordpress-42",
+		"expose wordpress",
+		"add relation wordpress:db - mysql:server",
+		"add unit wordpress/0 to a new machine",
+	})
+	for _, call := range s.backend.deployer.Calls() {
+		switch call.FuncName {
+		case "DeployApplication":
+			c.Check(call.Args[0].(bundle.DeployApplicationArgs).Name, gc.Equals, "wordpress")
+		case "AddUnit":
+			c.Check(call.Args[0], gc.Equals, "wordpress")
+		}
+	}
+}
+
+func (s *facadeSuite) TestDeployBundleUpgradeNotSupported(c *gc.C) {
+	s.backend.deployer.applications["mysql"] = charm.MustParseURL("cs:xenial/mysql-1")
+	result := s.deployBundle(c, false)
+	c.Assert(result.Error, gc.ErrorMatches, `upgrading existing application "mysql" from "cs:xenial/mysql-1" to "cs:xenial/mysql-42" not supported`)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+	for _, name := range s.deployerCalls() {
+		c.Check(name, gc.Matches, "ResolveCharm|ApplicationCharm")
+	}
+}
+
+func (s *facadeSuite) TestDeployBundleResourcesNotSupported(c *gc.C) {
+	s.backend.deployer.charms["cs:xenial/wordpress-42"] = &charm.Meta{
+		Name:      "wordpress",
+		Resources: map[string]resource.Meta{"theme": {Name: "theme"}},
+	}
+	result := s.deployBundle(c, false)
+	c.Assert(result.Error, gc.ErrorMatches, `deploying charm "cs:xenial/wordpress-42" with resources not supported`)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+	for _, name := range s.deployerCalls() {
+		c.Check(name, gc.Matches, "ResolveCharm|ApplicationCharm|AddCharm")
+	}
+}
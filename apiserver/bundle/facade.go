@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundle implements the API endpoint used to export the
+// contents of a model as a deployable bundle, and to deploy bundles
+// on the controller.
+package bundle
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+)
+
+func init() {
+	common.RegisterStandardFacade("Bundle", 1, newFacade)
+}
+
+// Facade implements the Bundle API.
+type Facade struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// New returns a new Bundle API facade.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (f *Facade) checkCanRead() error {
+	canRead, err := f.authorizer.HasPermission(description.ReadAccess, f.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (f *Facade) checkCanWrite() error {
+	canWrite, err := f.authorizer.HasPermission(description.WriteAccess, f.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canWrite {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ExportBundle returns a bundle, in YAML format, which deploys the
+// applications, machines and relations of the model.
+func (f *Facade) ExportBundle() (params.StringResult, error) {
+	if err := f.checkCanRead(); err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	model, err := f.backend.Export()
+	if err != nil {
+		return params.StringResult{}, errors.Annotate(err, "exporting model")
+	}
+	data, err := bundleData(model, f.backend.StorageConstraints)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	bytes, err := yaml.Marshal(data)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	return params.StringResult{Result: string(bytes)}, nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/apiserver/common"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *bundle.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{model: newModel(), deployer: newMockDeployer()}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("igor"),
+		AdminTag: names.NewUserTag("igor"),
+	}
+	facade, err := bundle.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestAgentAuthNotAllowed(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := bundle.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestReadAccessRequired(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	facade, err := bundle.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *facadeSuite) TestExportBundle(c *gc.C) {
+	s.backend.storage = map[string]map[string]state.StorageConstraints{
+		"mysql": {"data": {Pool: "ebs", Count: 1, Size: 10240}},
+	}
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	var data charm.BundleData
+	err = yaml.Unmarshal([]byte(result.Result), &data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, charm.BundleData{
+		Series: "xenial",
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {
+				Charm:       "cs:xenial/mysql-2",
+				NumUnits:    1,
+				To:          []string{"1"},
+				Options:     map[string]interface{}{"dataset-size": "80%"},
+				Constraints: "mem=4096M",
+				Storage:     map[string]string{"data": "ebs,1,10240M"},
+			},
+			"wordpress": {
+				Charm:    "cs:xenial/wordpress-1",
+				NumUnits: 2,
+				To:       []string{"0", "lxd:0"},
+				Expose:   true,
+			},
+			"logging": {
+				Charm:  "cs:logging-3",
+				Series: "trusty",
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			"0": {},
+			"1": {Series: "trusty", Constraints: "cpu-cores=4"},
+		},
+		Relations: [][]string{
+			{"logging:info", "wordpress:juju-info"},
+			{"wordpress:db", "mysql:db"},
+		},
+	})
+	s.backend.CheckCallNames(c, "Export", "StorageConstraints", "StorageConstraints", "StorageConstraints")
+}
+
+func (s *facadeSuite) TestExportBundleError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "exporting model: boom")
+}
+
+func (s *facadeSuite) TestExportBundleStorageError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	_, err := s.facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, `application "[a-z]+": getting storage constraints: boom`)
+}
+
+// newModel returns a model in which wordpress is related to mysql and
+// to the logging subordinate, with one wordpress unit in a container.
+func newModel() description.Model {
+	m := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":           "bundled",
+			"default-series": "xenial",
+		},
+		LatestToolsVersion: version.MustParse("2.0.0"),
+	})
+	machine0 := m.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "xenial",
+	})
+	machine0.AddContainer(description.MachineArgs{
+		Id:     names.NewMachineTag("0/lxd/0"),
+		Series: "xenial",
+	})
+	machine1 := m.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("1"),
+		Series: "trusty",
+	})
+	machine1.SetConstraints(description.ConstraintsArgs{CpuCores: 4})
+
+	wordpress := m.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		Series:   "xenial",
+		CharmURL: "cs:xenial/wordpress-1",
+		Exposed:  true,
+	})
+	// Units are added out of order to check that placement follows
+	// the unit numbers.
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/1"),
+		Machine: names.NewMachineTag("0/lxd/0"),
+	})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/0"),
+		Machine: names.NewMachineTag("0"),
+	})
+
+	mysql := m.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		Series:   "xenial",
+		CharmURL: "cs:xenial/mysql-2",
+		Settings: map[string]interface{}{"dataset-size": "80%"},
+	})
+	mysql.SetConstraints(description.ConstraintsArgs{Memory: 4096})
+	mysql.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("mysql/0"),
+		Machine: names.NewMachineTag("1"),
+	})
+
+	logging := m.AddApplication(description.ApplicationArgs{
+		Tag:         names.NewApplicationTag("logging"),
+		Series:      "trusty",
+		CharmURL:    "cs:logging-3",
+		Subordinate: true,
+	})
+	logging.AddUnit(description.UnitArgs{
+		Tag:       names.NewUnitTag("logging/0"),
+		Machine:   names.NewMachineTag("0"),
+		Principal: names.NewUnitTag("wordpress/0"),
+	})
+
+	addRelation(m, 1, "wordpress", "db", "mysql", "db")
+	addRelation(m, 2, "logging", "info", "wordpress", "juju-info")
+	peer := m.AddRelation(description.RelationArgs{Id: 3, Key: "mysql:cluster"})
+	peer.AddEndpoint(description.EndpointArgs{ApplicationName: "mysql", Name: "cluster"})
+	return m
+}
+
+func addRelation(m description.Model, id int, app1, name1, app2, name2 string) {
+	relation := m.AddRelation(description.RelationArgs{
+		Id:  id,
+		Key: app1 + ":" + name1 + " " + app2 + ":" + name2,
+	})
+	relation.AddEndpoint(description.EndpointArgs{ApplicationName: app1, Name: name1})
+	relation.AddEndpoint(description.EndpointArgs{ApplicationName: app2, Name: name2})
+}
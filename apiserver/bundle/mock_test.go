@@ -0,0 +1,181 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	model    description.Model
+	storage  map[string]map[string]state.StorageConstraints
+	deployer *mockDeployer
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Export() (description.Model, error) {
+	b.MethodCall(b, "Export")
+	return b.model, b.NextErr()
+}
+
+func (b *mockBackend) StorageConstraints(application string) (map[string]state.StorageConstraints, error) {
+	b.MethodCall(b, "StorageConstraints", application)
+	return b.storage[application], b.NextErr()
+}
+
+func (b *mockBackend) ChangeAllowed() error {
+	b.MethodCall(b, "ChangeAllowed")
+	return b.NextErr()
+}
+
+func (b *mockBackend) Deployer() bundle.Deployer {
+	return b.deployer
+}
+
+type mockDeployer struct {
+	testing.Stub
+	charms       map[string]*charm.Meta
+	applications map[string]*charm.URL
+	failures     map[string]error
+	machines     int
+	units        map[string]int
+}
+
+func newMockDeployer() *mockDeployer {
+	return &mockDeployer{
+		charms:       make(map[string]*charm.Meta),
+		applications: make(map[string]*charm.URL),
+		failures:     make(map[string]error),
+		units:        make(map[string]int),
+	}
+}
+
+// nextErr returns the error configured for the named method, if
+// any, or the next stub error.
+func (d *mockDeployer) nextErr(method string) error {
+	if err := d.failures[method]; err != nil {
+		return err
+	}
+	return d.NextErr()
+}
+
+func (d *mockDeployer) ResolveCharm(ref *charm.URL, channel string) (*charm.URL, []string, error) {
+	d.MethodCall(d, "ResolveCharm", ref.String(), channel)
+	if err := d.nextErr("ResolveCharm"); err != nil {
+		return nil, nil, err
+	}
+	curl := *ref
+	if curl.Series == "" {
+		curl.Series = "xenial"
+	}
+	if curl.Revision < 0 {
+		curl.Revision = 42
+	}
+	return &curl, []string{curl.Series}, nil
+}
+
+func (d *mockDeployer) AddCharm(curl *charm.URL, channel string) (*charm.Meta, error) {
+	d.MethodCall(d, "AddCharm", curl.String(), channel)
+	if err := d.nextErr("AddCharm"); err != nil {
+		return nil, err
+	}
+	if meta, ok := d.charms[curl.String()]; ok {
+		return meta, nil
+	}
+	return &charm.Meta{Name: curl.Name}, nil
+}
+
+func (d *mockDeployer) ApplicationCharm(name string) (*charm.URL, error) {
+	d.MethodCall(d, "ApplicationCharm", name)
+	if err := d.nextErr("ApplicationCharm"); err != nil {
+		return nil, err
+	}
+	curl, ok := d.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return curl, nil
+}
+
+func (d *mockDeployer) DeployApplication(args bundle.DeployApplicationArgs) error {
+	d.MethodCall(d, "DeployApplication", args)
+	return d.nextErr("DeployApplication")
+}
+
+func (d *mockDeployer) AddMachine(args bundle.AddMachineArgs) (string, error) {
+	d.MethodCall(d, "AddMachine", args)
+	if err := d.nextErr("AddMachine"); err != nil {
+		return "", err
+	}
+	return d.newMachine(args.ContainerType != "", args.ParentId), nil
+}
+
+func (d *mockDeployer) newMachine(container bool, parentId string) string {
+	id := fmt.Sprint(d.machines)
+	d.machines++
+	if !container {
+		return id
+	}
+	if parentId == "" {
+		parentId = d.newMachine(false, "")
+	}
+	return parentId + "/lxd/" + id
+}
+
+func (d *mockDeployer) AddUnit(application, machineId string) (string, string, error) {
+	d.MethodCall(d, "AddUnit", application, machineId)
+	if err := d.nextErr("AddUnit"); err != nil {
+		return "", "", err
+	}
+	unit := fmt.Sprintf("%s/%d", application, d.units[application])
+	d.units[application]++
+	if machineId == "" {
+		machineId = d.newMachine(false, "")
+	}
+	return unit, machineId, nil
+}
+
+func (d *mockDeployer) AddRelation(endpoint1, endpoint2 string) error {
+	d.MethodCall(d, "AddRelation", endpoint1, endpoint2)
+	return d.nextErr("AddRelation")
+}
+
+func (d *mockDeployer) Expose(application string) error {
+	d.MethodCall(d, "Expose", application)
+	return d.nextErr("Expose")
+}
+
+func (d *mockDeployer) SetAnnotations(entity names.Tag, annotations map[string]string) error {
+	d.MethodCall(d, "SetAnnotations", entity, annotations)
+	return d.nextErr("SetAnnotations")
+}
+
+func (d *mockDeployer) DestroyApplication(name string) error {
+	d.MethodCall(d, "DestroyApplication", name)
+	return d.nextErr("DestroyApplication")
+}
+
+func (d *mockDeployer) DestroyMachine(id string) error {
+	d.MethodCall(d, "DestroyMachine", id)
+	return d.nextErr("DestroyMachine")
+}
+
+func (d *mockDeployer) DestroyRelation(endpoint1, endpoint2 string) error {
+	d.MethodCall(d, "DestroyRelation", endpoint1, endpoint2)
+	return d.nextErr("DestroyRelation")
+}
@@ -0,0 +1,270 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs/config"
+	jjj "github.com/juju/juju/juju"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the State API used by the bundle facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Export() (description.Model, error)
+	StorageConstraints(application string) (map[string]state.StorageConstraints, error)
+
+	// ChangeAllowed returns an error if changes to the model are
+	// blocked.
+	ChangeAllowed() error
+
+	// Deployer returns the Deployer used to deploy bundles.
+	Deployer() Deployer
+}
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return New(&backend{st}, res, auth)
+}
+
+type backend struct {
+	*state.State
+}
+
+// StorageConstraints is part of the Backend interface.
+func (b *backend) StorageConstraints(name string) (map[string]state.StorageConstraints, error) {
+	app, err := b.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.StorageConstraints()
+}
+
+// ChangeAllowed is part of the Backend interface.
+func (b *backend) ChangeAllowed() error {
+	return common.NewBlockChecker(b.State).ChangeAllowed()
+}
+
+// Deployer is part of the Backend interface.
+func (b *backend) Deployer() Deployer {
+	return &stateDeployer{b.State}
+}
+
+// stateDeployer implements Deployer in terms of *state.State.
+type stateDeployer struct {
+	st *state.State
+}
+
+// ResolveCharm is part of the Deployer interface. Charms already in
+// the model, such as uploaded local charms, are used as they are.
+func (d *stateDeployer) ResolveCharm(ref *charm.URL, channel string) (*charm.URL, []string, error) {
+	if ref.Revision >= 0 {
+		if ch, err := d.st.Charm(ref); err == nil && ch.IsUploaded() {
+			return ref, ch.Meta().Series, nil
+		} else if err != nil && !errors.IsNotFound(err) {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+	return application.ResolveCharmURL(d.st, ref, channel)
+}
+
+// AddCharm is part of the Deployer interface.
+func (d *stateDeployer) AddCharm(curl *charm.URL, channel string) (*charm.Meta, error) {
+	if ch, err := d.st.Charm(curl); err == nil && ch.IsUploaded() {
+		return ch.Meta(), nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	err := application.AddCharmWithAuthorization(d.st, params.AddCharmWithAuthorization{
+		URL:     curl.String(),
+		Channel: channel,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, err := d.st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch.Meta(), nil
+}
+
+// ApplicationCharm is part of the Deployer interface.
+func (d *stateDeployer) ApplicationCharm(name string) (*charm.URL, error) {
+	app, err := d.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	curl, _ := app.CharmURL()
+	return curl, nil
+}
+
+// DeployApplication is part of the Deployer interface.
+func (d *stateDeployer) DeployApplication(args DeployApplicationArgs) error {
+	ch, err := d.st.Charm(args.CharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = jjj.DeployApplication(d.st, jjj.DeployApplicationParams{
+		ApplicationName:  args.Name,
+		Series:           args.Series,
+		Charm:            ch,
+		Channel:          csparams.Channel(args.Channel),
+		ConfigSettings:   charm.Settings(args.Options),
+		Constraints:      args.Constraints,
+		Storage:          args.Storage,
+		EndpointBindings: args.EndpointBindings,
+	})
+	return errors.Trace(err)
+}
+
+// AddMachine is part of the Deployer interface.
+func (d *stateDeployer) AddMachine(args AddMachineArgs) (string, error) {
+	series := args.Series
+	if series == "" {
+		modelConfig, err := d.st.ModelConfig()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		series = config.PreferredSeries(modelConfig)
+	}
+	template := state.MachineTemplate{
+		Series:      series,
+		Constraints: args.Constraints,
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+	}
+	var m *state.Machine
+	var err error
+	switch {
+	case args.ContainerType == "":
+		m, err = d.st.AddOneMachine(template)
+	case args.ParentId == "":
+		parentTemplate := state.MachineTemplate{
+			Series: series,
+			Jobs:   []state.MachineJob{state.JobHostUnits},
+		}
+		m, err = d.st.AddMachineInsideNewMachine(template, parentTemplate, args.ContainerType)
+	default:
+		m, err = d.st.AddMachineInsideMachine(template, args.ParentId, args.ContainerType)
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return m.Id(), nil
+}
+
+// AddUnit is part of the Deployer interface. Units are assigned to
+// their machines immediately, so that other units may be placed
+// alongside them.
+func (d *stateDeployer) AddUnit(applicationName, machineId string) (string, string, error) {
+	app, err := d.st.Application(applicationName)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	unit, err := app.AddUnit()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	if machineId == "" {
+		err = unit.AssignToNewMachine()
+	} else {
+		var m *state.Machine
+		if m, err = d.st.Machine(machineId); err == nil {
+			err = unit.AssignToMachine(m)
+		}
+	}
+	if err != nil {
+		if err := unit.Destroy(); err != nil {
+			logger.Errorf("cannot remove unassigned unit %s: %v", unit.Name(), err)
+		}
+		return "", "", errors.Trace(err)
+	}
+	assigned, err := unit.AssignedMachineId()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return unit.Name(), assigned, nil
+}
+
+// AddRelation is part of the Deployer interface.
+func (d *stateDeployer) AddRelation(endpoint1, endpoint2 string) error {
+	eps, err := d.st.InferEndpoints(endpoint1, endpoint2)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := d.st.EndpointsRelation(eps...); err == nil {
+		return errors.AlreadyExistsf("relation %s %s", endpoint1, endpoint2)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	_, err = d.st.AddRelation(eps...)
+	return errors.Trace(err)
+}
+
+// Expose is part of the Deployer interface.
+func (d *stateDeployer) Expose(applicationName string) error {
+	app, err := d.st.Application(applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetExposed()
+}
+
+// SetAnnotations is part of the Deployer interface.
+func (d *stateDeployer) SetAnnotations(tag names.Tag, annotations map[string]string) error {
+	entity, err := d.st.FindEntity(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	annotated, ok := entity.(state.GlobalEntity)
+	if !ok {
+		return errors.NotSupportedf("annotations on %s", names.ReadableString(tag))
+	}
+	return d.st.SetAnnotations(annotated, annotations)
+}
+
+// DestroyApplication is part of the Deployer interface.
+func (d *stateDeployer) DestroyApplication(name string) error {
+	app, err := d.st.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.Destroy()
+}
+
+// DestroyMachine is part of the Deployer interface. The machine is
+// removed along with any units assigned to it.
+func (d *stateDeployer) DestroyMachine(id string) error {
+	m, err := d.st.Machine(id)
+	if errors.IsNotFound(err) {
+		// Removed along with a container's host.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return m.ForceDestroy()
+}
+
+// DestroyRelation is part of the Deployer interface.
+func (d *stateDeployer) DestroyRelation(endpoint1, endpoint2 string) error {
+	eps, err := d.st.InferEndpoints(endpoint1, endpoint2)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rel, err := d.st.EndpointsRelation(eps...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return rel.Destroy()
+}
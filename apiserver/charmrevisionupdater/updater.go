@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmrepository"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/state"
 )
@@ -110,6 +111,21 @@ func retrieveLatestCharmInfo(st *state.State) ([]latestCharmInfo, error) {
 		return nil, err
 	}
 
+	// Charms deployed from a repository other than the charm store
+	// keep the cs: schema, but the charm store knows nothing of them.
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	location, err := charmrepository.ParseLocation(controllerConfig.CharmRepository())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if location.Kind != charmrepository.CharmStore {
+		logger.Debugf("not checking charm revisions: charms are deployed from %s repository", location.Kind)
+		return nil, nil
+	}
+
 	services, err := st.AllApplications()
 	if err != nil {
 		return nil, err
@@ -19,8 +19,10 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/controller"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
 )
 
 type charmVersionSuite struct {
@@ -185,3 +187,34 @@ func (s *charmVersionSuite) TestEnvironmentUUIDUsed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(header.Get(charmrepo.JujuMetadataHTTPHeader), gc.Equals, "environment_uuid="+env.UUID())
 }
+
+type charmRepositorySuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&charmRepositorySuite{})
+
+func (s *charmRepositorySuite) SetUpTest(c *gc.C) {
+	s.ControllerConfigAttrs = map[string]interface{}{
+		controller.CharmRepository: "/srv/charms",
+	}
+	s.JujuConnSuite.SetUpTest(c)
+}
+
+func (s *charmRepositorySuite) TestUpdateRevisionsSkipsCharmStore(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{URL: "cs:quantal/wordpress-3"})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	s.PatchValue(&charmrevisionupdater.NewCharmStoreClient, func(*state.State) (charmstore.Client, error) {
+		return charmstore.Client{}, errors.New("charm store used")
+	})
+
+	auth := apiservertesting.FakeAuthorizer{EnvironManager: true}
+	updater, err := charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, common.NewResources(), auth)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := updater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	_, err = s.State.LatestPlaceholderCharm(charm.MustParseURL("cs:quantal/wordpress"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
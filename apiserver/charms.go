@@ -21,6 +21,8 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/series"
 	ziputil "github.com/juju/utils/zip"
 	"gopkg.in/juju/charm.v6-unstable"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
+	jujuversion "github.com/juju/juju/version"
 )
 
 // charmsHandler handles charm upload through HTTPS in the API server.
@@ -75,6 +78,15 @@ func (h *charmsHandler) serveGet(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if hash := r.URL.Query().Get("upload"); hash != "" {
+		// The client requested the progress of a resumable upload.
+		offset, err := common.UploadOffset(charmUploadKey(st, hash))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sendStatusAndJSON(w, http.StatusOK, &params.UploadStatus{Offset: offset})
+		return nil
+	}
 	// Retrieve or list charm files.
 	// Requires "url" (charm URL) and an optional "file" (the path to the
 	// charm file) to be included in the query.
@@ -221,11 +233,39 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 		return nil, fmt.Errorf("expected Content-Type: application/zip, got: %v", contentType)
 	}
 
-	charmFileName, err := writeCharmToTempFile(r.Body)
-	if err != nil {
-		return nil, errors.Trace(err)
+	var (
+		charmFileName string
+		err           error
+	)
+	if hash := query.Get("sha256"); hash != "" {
+		// The archive is being sent in a resumable upload, and
+		// this request may carry only the remainder of it.
+		upload, ok, err := common.ParseResumableUpload(r)
+		if err != nil {
+			return nil, errors.Trace(err)
+		} else if !ok {
+			return nil, errors.Errorf("expected %s header with sha256", params.UploadOffsetHeader)
+		}
+		key := charmUploadKey(st, hash)
+		charmFileName, err = common.ReceiveUpload(key, upload, r.Body)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer common.DiscardUpload(key)
+		received, err := utils.ReadFileSHA256(charmFileName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if received != hash {
+			return nil, errors.Errorf("charm archive SHA256 mismatch: expected %s, got %s", hash, received)
+		}
+	} else {
+		charmFileName, err = writeCharmToTempFile(r.Body)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer os.Remove(charmFileName)
 	}
-	defer os.Remove(charmFileName)
 
 	err = h.processUploadedArchive(charmFileName)
 	if err != nil {
@@ -244,6 +284,9 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 		Series:   series,
 	}
 	if schema == "local" {
+		if err := validateCharm(archive); err != nil {
+			return nil, errors.Annotate(err, "invalid charm")
+		}
 		curl, err = st.PrepareLocalCharmUpload(curl)
 		if err != nil {
 			return nil, err
@@ -361,6 +404,54 @@ func (h *charmsHandler) findArchiveRootDir(zipr *zip.Reader) (string, error) {
 	return filepath.Dir(paths[0]), nil
 }
 
+// validateCharm checks the metadata, config and actions of a local
+// charm before it is added to the model, so that a broken charm is
+// rejected before any application is deployed from it.
+func validateCharm(ch charm.Charm) error {
+	meta := ch.Meta()
+	if !charm.IsValidName(meta.Name) {
+		return errors.NotValidf("charm name %q", meta.Name)
+	}
+	if meta.MinJujuVersion.Compare(jujuversion.Current) > 0 {
+		return errors.Errorf(
+			"charm's min version (%s) is higher than this juju environment's version (%s)",
+			meta.MinJujuVersion, jujuversion.Current,
+		)
+	}
+	for _, s := range meta.Series {
+		if _, err := series.GetOSFromSeries(s); err != nil {
+			return errors.NotValidf("series %q", s)
+		}
+	}
+	for name, res := range meta.Resources {
+		if res.Name != name {
+			return errors.NotValidf("resource %q with name %q", name, res.Name)
+		}
+		if err := res.Validate(); err != nil {
+			return errors.Annotatef(err, "resource %q", name)
+		}
+	}
+	if config := ch.Config(); config != nil {
+		if _, err := config.ValidateSettings(config.DefaultSettings()); err != nil {
+			return errors.Annotate(err, "config defaults")
+		}
+	}
+	if actions := ch.Actions(); actions != nil {
+		for name, spec := range actions.ActionSpecs {
+			if _, err := spec.InsertDefaults(map[string]interface{}{}); err != nil {
+				return errors.Annotatef(err, "action %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// charmUploadKey returns the key identifying a resumable upload of the
+// charm archive with the given SHA256 hash to the given model.
+func charmUploadKey(st *state.State, hash string) string {
+	return "charm/" + st.ModelUUID() + "/" + hash
+}
+
 func depth(path string) int {
 	return strings.Count(path, "/")
 }
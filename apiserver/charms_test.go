@@ -386,6 +386,91 @@ func (s *charmsSuite) TestNonLocalCharmUploadWithRevisionOverride(c *gc.C) {
 	c.Assert(sch.IsUploaded(), jc.IsTrue)
 }
 
+func (s *charmsSuite) TestUploadRejectsInvalidCharm(c *gc.C) {
+	dir := testcharms.Repo.ClonedDir(c.MkDir(), "dummy")
+	f, err := os.OpenFile(filepath.Join(dir.Path, "metadata.yaml"), os.O_WRONLY|os.O_APPEND, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = f.WriteString("series:\n  - bogus\n")
+	f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(c.MkDir(), "bogus.charm")
+	s.archiveCharmDir(c, dir.Path, path)
+
+	resp := s.uploadRequest(c, s.charmsURI(c, ""), "application/zip", path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `invalid charm: series "bogus" not valid`)
+	_, err = s.State.Charm(charm.MustParseURL("local:dummy-1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *charmsSuite) TestResumableUpload(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	data, err := ioutil.ReadFile(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	hash, _, err := utils.ReadSHA256(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	uri := s.charmsURI(c, "?series=quantal&sha256="+hash)
+	half := len(data) / 2
+
+	// Send the first half of the archive only, as if the
+	// connection had been dropped.
+	resp := s.resumableUploadRequest(c, uri, data[:half], 0, len(data))
+	s.assertErrorResponse(c, resp, http.StatusBadRequest,
+		fmt.Sprintf("upload incomplete: received %d of %d bytes", half, len(data)),
+	)
+
+	resp = s.authRequest(c, httpRequestParams{method: "GET", url: s.charmsURI(c, "?upload="+hash)})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var status params.UploadStatus
+	err = json.Unmarshal(body, &status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Offset, gc.Equals, int64(half))
+
+	resp = s.resumableUploadRequest(c, uri, data[half:], half, len(data))
+	s.assertUploadResponse(c, resp, "local:quantal/dummy-1")
+	sch, err := s.State.Charm(charm.MustParseURL("local:quantal/dummy-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.IsUploaded(), jc.IsTrue)
+
+	// The completed upload is no longer held.
+	resp = s.authRequest(c, httpRequestParams{method: "GET", url: s.charmsURI(c, "?upload="+hash)})
+	body = assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	err = json.Unmarshal(body, &status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Offset, gc.Equals, int64(0))
+}
+
+func (s *charmsSuite) TestResumableUploadChecksHash(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	data, err := ioutil.ReadFile(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	uri := s.charmsURI(c, "?series=quantal&sha256=abcd")
+	resp := s.resumableUploadRequest(c, uri, data, 0, len(data))
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "charm archive SHA256 mismatch: expected abcd, got .*")
+}
+
+func (s *charmsSuite) resumableUploadRequest(c *gc.C, uri string, data []byte, offset, length int) *http.Response {
+	header := make(http.Header)
+	header.Set(params.UploadOffsetHeader, fmt.Sprint(offset))
+	header.Set(params.UploadLengthHeader, fmt.Sprint(length))
+	return s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         uri,
+		contentType: "application/zip",
+		body:        bytes.NewReader(data),
+		header:      header,
+	})
+}
+
+func (s *charmsSuite) archiveCharmDir(c *gc.C, dirPath, path string) {
+	dir, err := charm.ReadCharmDir(dirPath)
+	c.Assert(err, jc.ErrorIsNil)
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	err = dir.ArchiveTo(f)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *charmsSuite) TestGetRequiresCharmURL(c *gc.C) {
 	uri := s.charmsURI(c, "?file=hooks/install")
 	resp := s.authRequest(c, httpRequestParams{method: "GET", url: uri})
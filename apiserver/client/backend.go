@@ -4,6 +4,8 @@
 package client
 
 import (
+	"time"
+
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -27,6 +29,7 @@ type Unit interface {
 	PrivateAddress() (network.Address, error)
 	Resolve(retryHooks bool) error
 	AgentHistory() status.StatusHistoryGetter
+	WorkloadVersionHistory() status.StatusHistoryGetter
 }
 
 // Backend contains the state.State methods used in this package,
@@ -64,6 +67,11 @@ type Backend interface {
 	RemoveUserAccess(names.UserTag, names.Tag) error
 	Watch() *state.Multiwatcher
 	AbortCurrentUpgrade() error
+	StartCanaryUpgrade(state.CanaryUpgradeArgs, time.Time) error
+	CanaryUpgrade() (state.CanaryUpgrade, error)
+	CanaryProblems() ([]string, error)
+	ProceedCanaryUpgrade(time.Time) error
+	AbortCanaryUpgrade() error
 	APIHostPorts() ([][]network.HostPort, error)
 	LatestModelMigration() (state.ModelMigration, error)
 }
@@ -5,6 +5,7 @@ package client
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -92,7 +93,7 @@ func newClient(st *state.State, resources facade.Resources, authorizer facade.Au
 		return environs.GetEnviron(configGetter, environs.New)
 	}
 	blockChecker := common.NewBlockChecker(st)
-	modelConfigAPI, err := modelconfig.NewModelConfigAPI(st, authorizer)
+	modelConfigAPI, err := modelconfig.NewModelConfigAPI(modelconfig.NewStateBackend(st), authorizer)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -152,7 +153,11 @@ func (c *Client) Resolved(p params.Resolved) error {
 	if err := c.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := c.check.ChangeAllowed(); err != nil {
+	var blocked []names.Tag
+	if appName, err := names.UnitApplication(p.UnitName); err == nil {
+		blocked = append(blocked, names.NewApplicationTag(appName))
+	}
+	if err := c.check.ChangeAllowedFor(blocked...); err != nil {
 		return errors.Trace(err)
 	}
 	unit, err := c.api.stateAccessor.Unit(p.UnitName)
@@ -408,11 +413,17 @@ func (c *Client) DestroyMachines(args params.DestroyMachines) error {
 		return err
 	}
 
-	if err := c.check.RemoveAllowed(); !args.Force && err != nil {
+	var blocked []names.Tag
+	for _, id := range args.MachineNames {
+		if names.IsValidMachine(id) {
+			blocked = append(blocked, names.NewMachineTag(id))
+		}
+	}
+	if err := c.check.RemoveAllowedFor(blocked...); !args.Force && err != nil {
 		return errors.Trace(err)
 	}
 
-	return common.DestroyMachines(c.api.stateAccessor, args.Force, args.MachineNames...)
+	return common.DestroyMachines(c.api.stateAccessor, args.Force, args.MaxWait, args.MachineNames...)
 }
 
 // ModelInfo returns information about the current model (default
@@ -510,9 +521,74 @@ func (c *Client) SetModelAgentVersion(args params.SetModelAgentVersion) error {
 	if err := environs.CheckProviderAPI(env); err != nil {
 		return err
 	}
+	if args.Canary != nil {
+		return c.api.stateAccessor.StartCanaryUpgrade(state.CanaryUpgradeArgs{
+			TargetVersion: args.Version,
+			Machines:      args.Canary.Machines,
+			SoakPeriod:    args.Canary.SoakPeriod,
+			AutoProceed:   args.Canary.AutoProceed,
+		}, time.Now())
+	}
 	return c.api.stateAccessor.SetModelAgentVersion(args.Version)
 }
 
+// CanaryUpgrade returns the canary upgrade in progress in the model,
+// with the problems keeping its canaries from being healthy. It
+// returns a not found error if there is none.
+func (c *Client) CanaryUpgrade() (params.CanaryUpgradeStatus, error) {
+	var result params.CanaryUpgradeStatus
+	if err := c.checkCanRead(); err != nil {
+		return result, err
+	}
+	upgrade, err := c.api.stateAccessor.CanaryUpgrade()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	problems, err := c.api.stateAccessor.CanaryProblems()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result = params.CanaryUpgradeStatus{
+		PreviousVersion: upgrade.PreviousVersion,
+		TargetVersion:   upgrade.TargetVersion,
+		Machines:        upgrade.Machines,
+		SoakPeriod:      upgrade.SoakPeriod,
+		AutoProceed:     upgrade.AutoProceed,
+		Started:         upgrade.Started,
+		Problems:        problems,
+	}
+	if !upgrade.HealthySince.IsZero() {
+		healthySince := upgrade.HealthySince
+		result.HealthySince = &healthySince
+	}
+	return result, nil
+}
+
+// ProceedCanaryUpgrade upgrades the rest of the model to the target
+// version of the canary upgrade in progress, once its canaries have
+// been healthy for its soak period.
+func (c *Client) ProceedCanaryUpgrade() error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.api.stateAccessor.ProceedCanaryUpgrade(time.Now())
+}
+
+// AbortCanaryUpgrade abandons the canary upgrade in progress, returning
+// its canaries to the model's agent version.
+func (c *Client) AbortCanaryUpgrade() error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.api.stateAccessor.AbortCanaryUpgrade()
+}
+
 // AbortCurrentUpgrade aborts and archives the current upgrade
 // synchronisation record, if any.
 func (c *Client) AbortCurrentUpgrade() error {
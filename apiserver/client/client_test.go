@@ -75,7 +75,7 @@ func (s *serverSuite) SetUpTest(c *gc.C) {
 		return s.newEnviron()
 	}
 	blockChecker := common.NewBlockChecker(s.State)
-	modelConfigAPI, err := modelconfig.NewModelConfigAPI(s.State, auth)
+	modelConfigAPI, err := modelconfig.NewModelConfigAPI(modelconfig.NewStateBackend(s.State), auth)
 	c.Assert(err, jc.ErrorIsNil)
 	s.client, err = client.NewClient(
 		client.NewStateBackend(s.State),
@@ -296,6 +296,83 @@ func (s *serverSuite) TestBlockChangesSetEnvironAgentVersion(c *gc.C) {
 	s.assertSetEnvironAgentVersionBlocked(c, "TestBlockChangesSetEnvironAgentVersion")
 }
 
+func (s *serverSuite) startCanaryUpgrade(c *gc.C) (*state.Machine, version.Number) {
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	current, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	machine := s.Factory.MakeMachine(c, nil)
+	err = machine.SetAgentVersion(version.Binary{Number: current, Series: "quantal", Arch: "amd64"})
+	c.Assert(err, jc.ErrorIsNil)
+	target := current
+	target.Patch++
+	err = s.client.SetModelAgentVersion(params.SetModelAgentVersion{
+		Version: target,
+		Canary: &params.CanaryUpgradeParams{
+			Machines:   []string{machine.Id()},
+			SoakPeriod: time.Hour,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return machine, current
+}
+
+func (s *serverSuite) TestSetModelAgentVersionCanary(c *gc.C) {
+	machine, current := s.startCanaryUpgrade(c)
+	upgrade, err := s.State.CanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Machines, jc.DeepEquals, []string{machine.Id()})
+	c.Assert(upgrade.SoakPeriod, gc.Equals, time.Hour)
+	c.Assert(upgrade.PreviousVersion, gc.Equals, current)
+
+	// The model's agent version is unchanged.
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	vers, _ := cfg.AgentVersion()
+	c.Assert(vers, gc.Equals, current)
+}
+
+func (s *serverSuite) TestCanaryUpgrade(c *gc.C) {
+	_, err := s.client.CanaryUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	machine, current := s.startCanaryUpgrade(c)
+	target := current
+	target.Patch++
+	result, err := s.client.CanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Started.IsZero(), jc.IsFalse)
+	result.Started = time.Time{}
+	c.Assert(result, jc.DeepEquals, params.CanaryUpgradeStatus{
+		PreviousVersion: current,
+		TargetVersion:   target,
+		Machines:        []string{machine.Id()},
+		SoakPeriod:      time.Hour,
+		Problems:        []string{fmt.Sprintf("machine %s is running %s", machine.Id(), current)},
+	})
+}
+
+func (s *serverSuite) TestProceedCanaryUpgradeUnhealthy(c *gc.C) {
+	s.startCanaryUpgrade(c)
+	err := s.client.ProceedCanaryUpgrade()
+	c.Assert(err, gc.ErrorMatches, "cannot proceed with canary upgrade: canaries are not healthy: .*")
+}
+
+func (s *serverSuite) TestAbortCanaryUpgrade(c *gc.C) {
+	s.startCanaryUpgrade(c)
+	err := s.client.AbortCanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CanaryUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *serverSuite) TestAbortCanaryUpgradeBlocked(c *gc.C) {
+	s.startCanaryUpgrade(c)
+	s.BlockAllChanges(c, "TestAbortCanaryUpgradeBlocked")
+	err := s.client.AbortCanaryUpgrade()
+	s.AssertBlocked(c, err, "TestAbortCanaryUpgradeBlocked")
+}
+
 func (s *serverSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	// Create a provisioned controller.
 	machine, err := s.State.AddMachine("series", state.JobManageModel)
@@ -50,7 +50,8 @@ func (s byTime) Less(i, j int) bool {
 	return s[i].Since.Before(*s[j].Since)
 }
 
-// unitStatusHistory returns a list of status history entries for unit agents or workloads.
+// unitStatusHistory returns a list of status history entries for unit
+// agents or workloads, or of the versions of the unit's workload.
 func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHistoryFilter, kind status.HistoryKind) ([]params.DetailedStatus, error) {
 	unit, err := c.api.stateAccessor.Unit(unitTag.Id())
 	if err != nil {
@@ -73,6 +74,14 @@ func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHi
 		statuses = append(statuses, agentStatusFromStatusInfo(agentStatuses, status.KindUnitAgent)...)
 	}
 
+	if kind == status.KindWorkloadVersion {
+		versions, err := unit.WorkloadVersionHistory().StatusHistory(filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses = agentStatusFromStatusInfo(versions, status.KindWorkloadVersion)
+	}
+
 	sort.Sort(byTime(statuses))
 	if kind == status.KindUnit && filter.Size > 0 {
 		if len(statuses) > filter.Size {
@@ -137,7 +146,7 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 		kind := status.HistoryKind(request.Kind)
 		err = errors.NotValidf("%q requires a unit, got %t", kind, request.Tag)
 		switch kind {
-		case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+		case status.KindUnit, status.KindWorkload, status.KindUnitAgent, status.KindWorkloadVersion:
 			var u names.UnitTag
 			if u, err = names.ParseUnitTag(request.Tag); err == nil {
 				hist, err = c.unitStatusHistory(u, filter, kind)
@@ -167,7 +176,17 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err := c.checkCanRead(); err != nil {
 		return params.FullStatus{}, err
 	}
+	key := fmt.Sprintf("full-status %q", args.Patterns)
+	result, err := common.CachedRead(c.api.stateAccessor, state.StatusReadCache, key, func() (interface{}, error) {
+		return c.fullStatus(args)
+	})
+	if err != nil {
+		return params.FullStatus{}, err
+	}
+	return result.(params.FullStatus), nil
+}
 
+func (c *Client) fullStatus(args params.StatusParams) (params.FullStatus, error) {
 	var noStatus params.FullStatus
 	var context statusContext
 	var err error
@@ -503,6 +522,7 @@ func makeMachineStatus(machine *state.Machine) (status params.MachineStatus) {
 	status.Jobs = paramsJobsFromJobs(machine.Jobs())
 	status.WantsVote = machine.WantsVote()
 	status.HasVote = machine.HasVote()
+	status.Maintenance = machine.InMaintenance()
 	sInfo, err := machine.InstanceStatus()
 	populateStatusFromStatusInfoAndErr(&status.InstanceStatus, sInfo, err)
 	instid, err := machine.InstanceId()
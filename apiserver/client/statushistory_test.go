@@ -218,10 +218,42 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryWorkloadVersion(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.StatusActive,
+			Message: "running",
+		},
+	})
+	s.st.versionHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.StatusActive,
+			Message: "5.7.13",
+		},
+		{
+			Status:  status.StatusActive,
+			Message: "5.6.30",
+		},
+	})
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindWorkloadVersion.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.versionHistory))
+	for _, s := range h.Results[0].History.Statuses {
+		c.Assert(s.Kind, gc.Equals, status.KindWorkloadVersion.String())
+	}
+}
+
 type mockState struct {
 	client.Backend
-	unitHistory  []status.StatusInfo
-	agentHistory []status.StatusInfo
+	unitHistory    []status.StatusInfo
+	agentHistory   []status.StatusInfo
+	versionHistory []status.StatusInfo
 }
 
 func (m *mockState) ModelUUID() string {
@@ -237,14 +269,16 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 		return nil, errors.NotFoundf("%v", name)
 	}
 	return &mockUnit{
-		status: m.unitHistory,
-		agent:  &mockUnitAgent{m.agentHistory},
+		status:   m.unitHistory,
+		agent:    &mockUnitAgent{m.agentHistory},
+		versions: m.versionHistory,
 	}, nil
 }
 
 type mockUnit struct {
-	status statuses
-	agent  *mockUnitAgent
+	status   statuses
+	agent    *mockUnitAgent
+	versions statuses
 	client.Unit
 }
 
@@ -256,6 +290,10 @@ func (m *mockUnit) AgentHistory() status.StatusHistoryGetter {
 	return m.agent
 }
 
+func (m *mockUnit) WorkloadVersionHistory() status.StatusHistoryGetter {
+	return m.versions
+}
+
 type mockUnitAgent struct {
 	statuses
 }
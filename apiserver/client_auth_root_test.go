@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/testing/factory"
 
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -94,6 +95,6 @@ func (*fakeCaller) ResultType() reflect.Type {
 	return reflect.TypeOf("")
 }
 
-func (*fakeCaller) Call(_ /*objId*/ string, _ /*arg*/ reflect.Value) (reflect.Value, error) {
+func (*fakeCaller) Call(_ context.Context, _ /*objId*/ string, _ /*arg*/ reflect.Value) (reflect.Value, error) {
 	return reflect.ValueOf(""), nil
 }
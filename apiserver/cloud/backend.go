@@ -11,6 +11,7 @@ import (
 )
 
 type Backend interface {
+	AddCloud(cloudName string, cloud cloud.Cloud) error
 	Cloud(cloudName string) (cloud.Cloud, error)
 	CloudCredentials(user names.UserTag, cloudName string) (map[names.CloudCredentialTag]cloud.Credential, error)
 	ControllerModel() (Model, error)
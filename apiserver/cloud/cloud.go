@@ -22,6 +22,8 @@ var logger = loggo.GetLogger("juju.apiserver.cloud")
 
 func init() {
 	common.RegisterStandardFacade("Cloud", 1, newFacade)
+	// Version 2 adds AddCloud.
+	common.RegisterStandardFacade("Cloud", 2, newFacade)
 }
 
 // CloudAPI implements the model manager interface and is
@@ -113,6 +115,43 @@ func (mm *CloudAPI) Cloud(args params.Entities) (params.CloudResults, error) {
 	return results, nil
 }
 
+// AddCloud adds a cloud definition to the controller, so that models
+// may be created in that cloud as well as in the controller's own.
+// Only controller superusers may add clouds.
+func (mm *CloudAPI) AddCloud(args params.AddCloudArgs) error {
+	isAdmin, err := mm.authorizer.HasPermission(description.SuperuserAccess, mm.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	if !names.IsValidCloud(args.Name) {
+		return errors.NotValidf("cloud name %q", args.Name)
+	}
+	authTypes := make([]cloud.AuthType, len(args.Cloud.AuthTypes))
+	for i, authType := range args.Cloud.AuthTypes {
+		authTypes[i] = cloud.AuthType(authType)
+	}
+	regions := make([]cloud.Region, len(args.Cloud.Regions))
+	for i, region := range args.Cloud.Regions {
+		regions[i] = cloud.Region{
+			Name:             region.Name,
+			Endpoint:         region.Endpoint,
+			IdentityEndpoint: region.IdentityEndpoint,
+			StorageEndpoint:  region.StorageEndpoint,
+		}
+	}
+	return errors.Trace(mm.backend.AddCloud(args.Name, cloud.Cloud{
+		Type:             args.Cloud.Type,
+		AuthTypes:        authTypes,
+		Endpoint:         args.Cloud.Endpoint,
+		IdentityEndpoint: args.Cloud.IdentityEndpoint,
+		StorageEndpoint:  args.Cloud.StorageEndpoint,
+		Regions:          regions,
+	}))
+}
+
 // DefaultCloud returns the tag of the cloud that models will be
 // created in by default.
 func (mm *CloudAPI) DefaultCloud() (params.StringResult, error) {
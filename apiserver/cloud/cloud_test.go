@@ -68,6 +68,43 @@ func (s *cloudSuite) TestCloud(c *gc.C) {
 	})
 }
 
+func (s *cloudSuite) TestAddCloud(c *gc.C) {
+	err := s.api.AddCloud(params.AddCloudArgs{
+		Name: "other",
+		Cloud: params.Cloud{
+			Type:      "dummy",
+			AuthTypes: []string{"userpass"},
+			Regions:   []params.CloudRegion{{Name: "elsewhere", Endpoint: "other-endpoint"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag", "AddCloud")
+	s.backend.CheckCall(c, 1, "AddCloud", "other", cloud.Cloud{
+		Type:      "dummy",
+		AuthTypes: []cloud.AuthType{cloud.UserPassAuthType},
+		Regions:   []cloud.Region{{Name: "elsewhere", Endpoint: "other-endpoint"}},
+	})
+}
+
+func (s *cloudSuite) TestAddCloudInvalidName(c *gc.C) {
+	err := s.api.AddCloud(params.AddCloudArgs{
+		Name:  "not/valid",
+		Cloud: params.Cloud{Type: "dummy"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cloud name "not/valid" not valid`)
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
+func (s *cloudSuite) TestAddCloudPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bruce@local")
+	err := s.api.AddCloud(params.AddCloudArgs{
+		Name:  "other",
+		Cloud: params.Cloud{Type: "dummy"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
 func (s *cloudSuite) TestDefaultCloud(c *gc.C) {
 	result, err := s.api.DefaultCloud()
 	c.Assert(err, jc.ErrorIsNil)
@@ -197,6 +234,11 @@ func (st *mockBackend) ModelTag() names.ModelTag {
 	return names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
 }
 
+func (st *mockBackend) AddCloud(name string, c cloud.Cloud) error {
+	st.MethodCall(st, "AddCloud", name, c)
+	return st.NextErr()
+}
+
 func (st *mockBackend) Cloud(name string) (cloud.Cloud, error) {
 	st.MethodCall(st, "Cloud", name)
 	return st.cloud, st.NextErr()
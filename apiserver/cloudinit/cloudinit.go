@@ -0,0 +1,194 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cloudinit provides the facade through which clients get and
+// set the extra cloud-init directives applied when provisioning the
+// machines of a model. The provisioner picks them up through its
+// ProvisioningInfo. Each change is recorded in the model's timeline.
+package cloudinit
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.cloudinit")
+
+func init() {
+	common.RegisterStandardFacade("CloudInit", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	common.BlockGetter
+	ModelTag() names.ModelTag
+	CloudInitCustomization(names.Tag) (state.CloudInitCustomization, error)
+	EffectiveCloudInitCustomization(string) (state.CloudInitCustomization, error)
+	SetCloudInitCustomization(names.Tag, state.CloudInitCustomization) error
+	AddModelEvent(state.ModelEvent) error
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// API is the endpoint which implements the CloudInit facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+	check   *common.BlockChecker
+}
+
+// NewAPI creates a new instance of the CloudInit facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+		check:   common.NewBlockChecker(backend),
+	}, nil
+}
+
+func (api *API) checkPermission(access description.Access) error {
+	ok, err := api.auth.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// CloudInitCustomizations returns the cloud-init customizations set for
+// the given model or machines. The result for a machine also holds the
+// customization that is applied when it is provisioned.
+func (api *API) CloudInitCustomizations(args params.Entities) (params.CloudInitCustomizationResults, error) {
+	var results params.CloudInitCustomizationResults
+	if err := api.checkPermission(description.ReadAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.CloudInitCustomizationResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		result, err := api.cloudInitCustomization(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *API) cloudInitCustomization(tagString string) (params.CloudInitCustomizationResult, error) {
+	var result params.CloudInitCustomizationResult
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	custom, err := api.backend.CloudInitCustomization(tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Customization = toParams(custom)
+	if tag, ok := tag.(names.MachineTag); ok {
+		effective, err := api.backend.EffectiveCloudInitCustomization(tag.Id())
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		p := toParams(effective)
+		result.Effective = &p
+	}
+	return result, nil
+}
+
+// SetCloudInitCustomizations replaces the cloud-init customizations of
+// the given model or machines. Only machines provisioned afterwards
+// are affected. Only model admins may set customizations.
+func (api *API) SetCloudInitCustomizations(args params.CloudInitCustomizationArgs) (params.ErrorResults, error) {
+	var results params.ErrorResults
+	if err := api.checkPermission(description.AdminAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.setCloudInitCustomization(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setCloudInitCustomization(arg params.CloudInitCustomizationArg) error {
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	custom := state.CloudInitCustomization{
+		Packages:  arg.Customization.Packages,
+		AptMirror: arg.Customization.AptMirror,
+		RunCmds:   arg.Customization.RunCmds,
+		SSHCAKeys: arg.Customization.SSHCAKeys,
+	}
+	if err := api.backend.SetCloudInitCustomization(tag, custom); err != nil {
+		return errors.Trace(err)
+	}
+	api.recordChange(tag, custom)
+	return nil
+}
+
+// recordChange records the change of the cloud-init customization of
+// the model or machine with the given tag in the model's timeline,
+// attributed to the authenticated user. Failing to record the event
+// does not fail the change.
+func (api *API) recordChange(tag names.Tag, custom state.CloudInitCustomization) {
+	var set []string
+	if len(custom.Packages) > 0 {
+		set = append(set, "packages")
+	}
+	if custom.AptMirror != "" {
+		set = append(set, "apt-mirror")
+	}
+	if len(custom.RunCmds) > 0 {
+		set = append(set, "runcmds")
+	}
+	if len(custom.SSHCAKeys) > 0 {
+		set = append(set, "ssh-ca-keys")
+	}
+	message := "cleared cloud-init customization"
+	if len(set) > 0 {
+		message = "set cloud-init customization: " + strings.Join(set, ", ")
+	}
+	err := api.backend.AddModelEvent(state.ModelEvent{
+		Kind:    state.CloudInitChangeEvent,
+		Actor:   api.auth.GetAuthTag().String(),
+		Entity:  tag.String(),
+		Message: message,
+	})
+	if err != nil {
+		logger.Errorf("failed to record %s event for %s: %v", state.CloudInitChangeEvent, tag, err)
+	}
+}
+
+func toParams(custom state.CloudInitCustomization) params.CloudInitCustomization {
+	return params.CloudInitCustomization{
+		Packages:  custom.Packages,
+		AptMirror: custom.AptMirror,
+		RunCmds:   custom.RunCmds,
+		SSHCAKeys: custom.SSHCAKeys,
+	}
+}
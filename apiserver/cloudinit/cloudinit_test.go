@@ -0,0 +1,212 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/cloudinit"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type CloudInitSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *cloudinit.API
+}
+
+var _ = gc.Suite(&CloudInitSuite{})
+
+var modelTag = names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+
+func (s *CloudInitSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{
+		custom: state.CloudInitCustomization{
+			Packages: []string{"htop"},
+			RunCmds:  []string{"echo machine"},
+		},
+		effective: state.CloudInitCustomization{
+			Packages:  []string{"tmux", "htop"},
+			AptMirror: "http://mirror.example.com/ubuntu",
+			RunCmds:   []string{"echo model", "echo machine"},
+		},
+	}
+	var err error
+	s.api, err = cloudinit.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CloudInitSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := cloudinit.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *CloudInitSuite) TestCloudInitCustomizations(c *gc.C) {
+	results, err := s.api.CloudInitCustomizations(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: modelTag.String()}, {Tag: "foo"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.CloudInitCustomizationResult{
+		Customization: params.CloudInitCustomization{
+			Packages: []string{"htop"},
+			RunCmds:  []string{"echo machine"},
+		},
+		Effective: &params.CloudInitCustomization{
+			Packages:  []string{"tmux", "htop"},
+			AptMirror: "http://mirror.example.com/ubuntu",
+			RunCmds:   []string{"echo model", "echo machine"},
+		},
+	})
+	c.Assert(results.Results[1].Effective, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"foo" is not a valid tag`)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CloudInitCustomization", []interface{}{names.NewMachineTag("0")}},
+		{"EffectiveCloudInitCustomization", []interface{}{"0"}},
+		{"CloudInitCustomization", []interface{}{modelTag}},
+	})
+}
+
+func (s *CloudInitSuite) TestCloudInitCustomizationsRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.CloudInitCustomizations(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *CloudInitSuite) TestSetCloudInitCustomizations(c *gc.C) {
+	s.backend.stub.SetErrors(nil, nil, errors.NotValidf(`package "a b"`))
+	results, err := s.api.SetCloudInitCustomizations(params.CloudInitCustomizationArgs{
+		Args: []params.CloudInitCustomizationArg{{
+			Tag: modelTag.String(),
+			Customization: params.CloudInitCustomization{
+				Packages:  []string{"htop"},
+				AptMirror: "http://mirror.example.com/ubuntu",
+			},
+		}, {
+			Tag: "machine-1",
+			Customization: params.CloudInitCustomization{
+				Packages: []string{"a b"},
+			},
+		}, {
+			Tag: "machine-2",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `package "a b" not valid`)
+	c.Assert(results.Results[2].Error, gc.IsNil)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"SetCloudInitCustomization", []interface{}{modelTag, state.CloudInitCustomization{
+			Packages:  []string{"htop"},
+			AptMirror: "http://mirror.example.com/ubuntu",
+		}}},
+		{"AddModelEvent", []interface{}{state.ModelEvent{
+			Kind:    state.CloudInitChangeEvent,
+			Actor:   "user-bruce@local",
+			Entity:  modelTag.String(),
+			Message: "set cloud-init customization: packages, apt-mirror",
+		}}},
+		{"SetCloudInitCustomization", []interface{}{names.NewMachineTag("1"), state.CloudInitCustomization{
+			Packages: []string{"a b"},
+		}}},
+		{"SetCloudInitCustomization", []interface{}{names.NewMachineTag("2"), state.CloudInitCustomization{}}},
+		{"AddModelEvent", []interface{}{state.ModelEvent{
+			Kind:    state.CloudInitChangeEvent,
+			Actor:   "user-bruce@local",
+			Entity:  "machine-2",
+			Message: "cleared cloud-init customization",
+		}}},
+	})
+}
+
+func (s *CloudInitSuite) TestSetCloudInitCustomizationsRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.SetCloudInitCustomizations(params.CloudInitCustomizationArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *CloudInitSuite) TestSetCloudInitCustomizationsRequiresAdminNotWrite(c *gc.C) {
+	// Users with write access may read, but not set, customizations.
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasWriteTag = names.NewUserTag("mary@local")
+	_, err := s.api.CloudInitCustomizations(params.Entities{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.SetCloudInitCustomizations(params.CloudInitCustomizationArgs{
+		Args: []params.CloudInitCustomizationArg{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *CloudInitSuite) TestSetCloudInitCustomizationsBlocked(c *gc.C) {
+	s.backend.block = state.ChangeBlock
+	_, err := s.api.SetCloudInitCustomizations(params.CloudInitCustomizationArgs{
+		Args: []params.CloudInitCustomizationArg{{Tag: "machine-0"}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+	s.backend.stub.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	stub      gitjujutesting.Stub
+	block     state.BlockType
+	custom    state.CloudInitCustomization
+	effective state.CloudInitCustomization
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return modelTag
+}
+
+func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	if m.block == t {
+		return mockBlock{t: t}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (m *mockBackend) CloudInitCustomization(tag names.Tag) (state.CloudInitCustomization, error) {
+	m.stub.AddCall("CloudInitCustomization", tag)
+	return m.custom, m.stub.NextErr()
+}
+
+func (m *mockBackend) EffectiveCloudInitCustomization(machineId string) (state.CloudInitCustomization, error) {
+	m.stub.AddCall("EffectiveCloudInitCustomization", machineId)
+	return m.effective, m.stub.NextErr()
+}
+
+func (m *mockBackend) SetCloudInitCustomization(tag names.Tag, custom state.CloudInitCustomization) error {
+	m.stub.AddCall("SetCloudInitCustomization", tag, custom)
+	return m.stub.NextErr()
+}
+
+func (m *mockBackend) AddModelEvent(event state.ModelEvent) error {
+	m.stub.AddCall("AddModelEvent", event)
+	return m.stub.NextErr()
+}
+
+type mockBlock struct {
+	state.Block
+	t state.BlockType
+}
+
+func (m mockBlock) Type() state.BlockType { return m.t }
+
+func (m mockBlock) Message() string { return "blocked" }
@@ -239,6 +239,8 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+
+			CorrelationId: action.CorrelationId(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
@@ -5,6 +5,7 @@ package common
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/state"
 )
@@ -13,6 +14,13 @@ type BlockGetter interface {
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
 
+// EntityBlockGetter is a BlockGetter which can also get blocks on
+// individual applications and machines.
+type EntityBlockGetter interface {
+	BlockGetter
+	GetBlockForTypeAndEntities(t state.BlockType, tags ...names.Tag) (state.Block, bool, error)
+}
+
 // BlockChecker checks for current blocks if any.
 type BlockChecker struct {
 	getter BlockGetter
@@ -54,11 +62,37 @@ func (c *BlockChecker) DestroyAllowed() error {
 	return c.checkBlock(state.ChangeBlock)
 }
 
-// checkBlock checks if specified operation must be blocked.
-// If it does, the method throws specific error that can be examined
-// to stop operation execution.
-func (c *BlockChecker) checkBlock(blockType state.BlockType) error {
-	aBlock, isEnabled, err := c.getter.GetBlockForType(blockType)
+// ChangeAllowedFor checks if change block is in place for the
+// current environment, or for any of the given applications or
+// machines.
+func (c *BlockChecker) ChangeAllowedFor(tags ...names.Tag) error {
+	return c.checkBlock(state.ChangeBlock, tags...)
+}
+
+// RemoveAllowedFor checks if remove or change block is in place for
+// the current environment, or for any of the given applications or
+// machines.
+func (c *BlockChecker) RemoveAllowedFor(tags ...names.Tag) error {
+	if err := c.checkBlock(state.RemoveBlock, tags...); err != nil {
+		return err
+	}
+	return c.checkBlock(state.ChangeBlock, tags...)
+}
+
+// checkBlock checks if specified operation must be blocked, for the
+// environment or any of the supplied entities. If it does, the method
+// throws specific error that can be examined to stop operation
+// execution. Entities are only checked if the getter supports blocks
+// on entities.
+func (c *BlockChecker) checkBlock(blockType state.BlockType, tags ...names.Tag) error {
+	var aBlock state.Block
+	var isEnabled bool
+	var err error
+	if getter, ok := c.getter.(EntityBlockGetter); ok && len(tags) > 0 {
+		aBlock, isEnabled, err = getter.GetBlockForTypeAndEntities(blockType, tags...)
+	} else {
+		aBlock, isEnabled, err = c.getter.GetBlockForType(blockType)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
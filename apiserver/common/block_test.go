@@ -98,3 +98,51 @@ func (s *blockCheckerSuite) assertErrorBlocked(c *gc.C, blocked bool, err error,
 		c.Assert(errors.Cause(err), jc.ErrorIsNil)
 	}
 }
+
+type mockEntityBlockGetter struct {
+	blocks map[string]state.Block
+}
+
+func (m mockEntityBlockGetter) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	return m.GetBlockForTypeAndEntities(t)
+}
+
+func (m mockEntityBlockGetter) GetBlockForTypeAndEntities(t state.BlockType, tags ...names.Tag) (state.Block, bool, error) {
+	keys := []string{"model"}
+	for _, tag := range tags {
+		keys = append(keys, tag.String())
+	}
+	for _, key := range keys {
+		if b, ok := m.blocks[key]; ok && b.Type() == t {
+			return b, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (s *blockCheckerSuite) TestEntityBlockChecker(c *gc.C) {
+	getter := mockEntityBlockGetter{map[string]state.Block{
+		"application-mysql": s.change,
+		"machine-0":         s.remove,
+	}}
+	checker := common.NewBlockChecker(getter)
+	mysql := names.NewApplicationTag("mysql")
+	wordpress := names.NewApplicationTag("wordpress")
+	machine0 := names.NewMachineTag("0")
+
+	s.assertErrorBlocked(c, false, checker.ChangeAllowed(), "")
+	s.assertErrorBlocked(c, false, checker.ChangeAllowedFor(wordpress), "")
+	s.assertErrorBlocked(c, true, checker.ChangeAllowedFor(wordpress, mysql), s.change.Message())
+	s.assertErrorBlocked(c, false, checker.ChangeAllowedFor(machine0), "")
+	s.assertErrorBlocked(c, true, checker.RemoveAllowedFor(machine0), s.remove.Message())
+	s.assertErrorBlocked(c, true, checker.RemoveAllowedFor(mysql), s.change.Message())
+}
+
+func (s *blockCheckerSuite) TestEntityBlockCheckerUnsupported(c *gc.C) {
+	// A getter which does not support entity blocks only reports
+	// blocks on the model.
+	s.aBlock = s.change
+	s.assertErrorBlocked(c, true, s.blockchecker.ChangeAllowedFor(names.NewApplicationTag("mysql")), s.change.Message())
+	s.aBlock = s.destroy
+	s.assertErrorBlocked(c, false, s.blockchecker.RemoveAllowedFor(names.NewApplicationTag("mysql")), "")
+}
@@ -233,6 +233,13 @@ func ServerError(err error) *params.Error {
 		code = params.CodeBadRequest
 	case errors.IsMethodNotAllowed(err):
 		code = params.CodeMethodNotAllowed
+	case state.IsConfigValidationError(err):
+		code = params.CodeInvalidConfig
+		info = &params.ErrorInfo{
+			ConfigErrors: configOptionErrors(err.(*state.ConfigValidationError)),
+		}
+	case state.IsQuotaExceededError(err):
+		code = params.CodeQuotaExceeded
 	default:
 		if err, ok := err.(*DischargeRequiredError); ok {
 			code = params.CodeDischargeRequired
@@ -252,6 +259,18 @@ func ServerError(err error) *params.Error {
 	}
 }
 
+func configOptionErrors(err *state.ConfigValidationError) []params.ConfigOptionError {
+	result := make([]params.ConfigOptionError, len(err.Errors))
+	for i, optionErr := range err.Errors {
+		result[i] = params.ConfigOptionError{
+			Option: optionErr.Option,
+			Value:  optionErr.Value,
+			Reason: optionErr.Reason,
+		}
+	}
+	return result
+}
+
 // Unfortunately there is no specific type of error for i/o timeout,
 // and the error that bubbles up from mgo is annotated and a string type,
 // so all we can do is look at the error suffix and see if it matches.
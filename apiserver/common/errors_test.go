@@ -163,6 +163,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeMethodNotAllowed,
 	status:     http.StatusMethodNotAllowed,
 	helperFunc: params.IsMethodNotAllowed,
+}, {
+	err:        &state.QuotaExceededError{Resource: "machines", Limit: 2, Usage: 2, Requested: 1},
+	code:       params.CodeQuotaExceeded,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeQuotaExceeded,
 }, {
 	err:    stderrors.New("an error"),
 	status: http.StatusInternalServerError,
@@ -243,7 +248,8 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeMachineHasAttachedStorage,
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
-			params.CodeRetry:
+			params.CodeRetry,
+			params.CodeQuotaExceeded:
 			continue
 		case params.CodeOperationBlocked:
 			// ServerError doesn't actually have a case for this code.
@@ -264,6 +270,29 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 	}
 }
 
+func (s *errorsSuite) TestConfigValidationError(c *gc.C) {
+	err := common.ServerError(errors.Annotate(&state.ConfigValidationError{
+		Application: "mysql",
+		Errors: []state.ConfigOptionError{{
+			Option: "dataset-size",
+			Value:  "lots",
+			Reason: `option "dataset-size" expected int, got "lots"`,
+		}},
+	}, "updating settings"))
+	c.Assert(err, gc.DeepEquals, &params.Error{
+		Message: `updating settings: invalid config for application "mysql": option "dataset-size" expected int, got "lots"`,
+		Code:    params.CodeInvalidConfig,
+		Info: &params.ErrorInfo{
+			ConfigErrors: []params.ConfigOptionError{{
+				Option: "dataset-size",
+				Value:  "lots",
+				Reason: `option "dataset-size" expected int, got "lots"`,
+			}},
+		},
+	})
+	c.Assert(err, jc.Satisfies, params.IsCodeInvalidConfig)
+}
+
 func (s *errorsSuite) TestUnknownModel(c *gc.C) {
 	err := common.UnknownModelError("dead-beef")
 	c.Check(err, gc.ErrorMatches, `unknown model: "dead-beef"`)
@@ -12,6 +12,8 @@ var (
 	EnvtoolsFindTools       = &envtoolsFindTools
 	SendMetrics             = &sendMetrics
 	MockableDestroyMachines = destroyMachines
+	APIWatchers             = apiWatchers
+	UploadDir               = &uploadDir
 )
 
 type Patcher interface {
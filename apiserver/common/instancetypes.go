@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/state"
+)
+
+// InstanceTypesCacheExpiry is how long a model's cached instance types
+// are used before they are fetched from the provider again.
+const InstanceTypesCacheExpiry = 24 * time.Hour
+
+// InstanceTypesCache defines the methods of *state.State used to
+// cache a model's instance types.
+type InstanceTypesCache interface {
+	InstanceTypes() (state.InstanceTypesInfo, error)
+	SetInstanceTypes([]instances.InstanceType) error
+}
+
+// CachedInstanceTypes returns the instance types cached for the model.
+// If none have been cached, or the cache is older than
+// InstanceTypesCacheExpiry, they are first refreshed from env. If env
+// cannot list its instance types, an error satisfying
+// errors.IsNotSupported is returned.
+func CachedInstanceTypes(cache InstanceTypesCache, env environs.Environ, clock clock.Clock) (state.InstanceTypesInfo, error) {
+	info, err := cache.InstanceTypes()
+	if err == nil && clock.Now().Sub(info.Updated) < InstanceTypesCacheExpiry {
+		return info, nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		return state.InstanceTypesInfo{}, errors.Trace(err)
+	}
+	return RefreshInstanceTypes(cache, env)
+}
+
+// RefreshInstanceTypes fetches the instance types from env, and
+// replaces the model's cached instance types with them. If env cannot
+// list its instance types, an error satisfying errors.IsNotSupported
+// is returned.
+func RefreshInstanceTypes(cache InstanceTypesCache, env environs.Environ) (state.InstanceTypesInfo, error) {
+	fetcher, ok := env.(environs.InstanceTypesFetcher)
+	if !ok {
+		return state.InstanceTypesInfo{}, errors.NotSupportedf("listing instance types")
+	}
+	instanceTypes, err := fetcher.InstanceTypes()
+	if err != nil {
+		return state.InstanceTypesInfo{}, errors.Annotate(err, "fetching instance types")
+	}
+	if err := cache.SetInstanceTypes(instanceTypes); err != nil {
+		return state.InstanceTypesInfo{}, errors.Trace(err)
+	}
+	info, err := cache.InstanceTypes()
+	return info, errors.Trace(err)
+}
+
+// InstanceTypeToParams converts an instances.InstanceType into its
+// params representation.
+func InstanceTypeToParams(itype instances.InstanceType) params.InstanceType {
+	return params.InstanceType{
+		Id:         itype.Id,
+		Name:       itype.Name,
+		Arches:     itype.Arches,
+		CpuCores:   itype.CpuCores,
+		Mem:        itype.Mem,
+		Cost:       itype.Cost,
+		RootDisk:   itype.RootDisk,
+		VirtType:   itype.VirtType,
+		CpuPower:   itype.CpuPower,
+		Tags:       itype.Tags,
+		Deprecated: itype.Deprecated,
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type instanceTypesSuite struct {
+	clock *coretesting.Clock
+	cache *fakeInstanceTypesCache
+	env   *fakeInstanceTypesEnviron
+}
+
+var _ = gc.Suite(&instanceTypesSuite{})
+
+var (
+	cachedInstanceTypes  = []instances.InstanceType{{Name: "cached", Arches: []string{"amd64"}}}
+	fetchedInstanceTypes = []instances.InstanceType{{Name: "fetched", Arches: []string{"amd64"}}}
+)
+
+func (s *instanceTypesSuite) SetUpTest(c *gc.C) {
+	s.clock = coretesting.NewClock(time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC))
+	s.cache = &fakeInstanceTypesCache{clock: s.clock}
+	s.env = &fakeInstanceTypesEnviron{instanceTypes: fetchedInstanceTypes}
+}
+
+func (s *instanceTypesSuite) TestCachedInstanceTypesFresh(c *gc.C) {
+	s.cache.info = &state.InstanceTypesInfo{
+		InstanceTypes: cachedInstanceTypes,
+		Updated:       s.clock.Now().Add(-time.Hour),
+	}
+	info, err := common.CachedInstanceTypes(s.cache, s.env, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.InstanceTypes, jc.DeepEquals, cachedInstanceTypes)
+	c.Assert(s.env.calls, gc.Equals, 0)
+}
+
+func (s *instanceTypesSuite) TestCachedInstanceTypesExpired(c *gc.C) {
+	s.cache.info = &state.InstanceTypesInfo{
+		InstanceTypes: cachedInstanceTypes,
+		Updated:       s.clock.Now().Add(-common.InstanceTypesCacheExpiry),
+	}
+	info, err := common.CachedInstanceTypes(s.cache, s.env, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.InstanceTypes, jc.DeepEquals, fetchedInstanceTypes)
+	c.Assert(info.Updated, gc.Equals, s.clock.Now())
+	c.Assert(s.env.calls, gc.Equals, 1)
+}
+
+func (s *instanceTypesSuite) TestCachedInstanceTypesEmpty(c *gc.C) {
+	info, err := common.CachedInstanceTypes(s.cache, s.env, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.InstanceTypes, jc.DeepEquals, fetchedInstanceTypes)
+	c.Assert(s.env.calls, gc.Equals, 1)
+}
+
+func (s *instanceTypesSuite) TestCachedInstanceTypesNotSupported(c *gc.C) {
+	_, err := common.CachedInstanceTypes(s.cache, plainEnviron{}, s.clock)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *instanceTypesSuite) TestRefreshInstanceTypes(c *gc.C) {
+	s.cache.info = &state.InstanceTypesInfo{
+		InstanceTypes: cachedInstanceTypes,
+		Updated:       s.clock.Now(),
+	}
+	info, err := common.RefreshInstanceTypes(s.cache, s.env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.InstanceTypes, jc.DeepEquals, fetchedInstanceTypes)
+	c.Assert(s.env.calls, gc.Equals, 1)
+}
+
+func (s *instanceTypesSuite) TestRefreshInstanceTypesError(c *gc.C) {
+	s.env.err = errors.New("boom")
+	_, err := common.RefreshInstanceTypes(s.cache, s.env)
+	c.Assert(err, gc.ErrorMatches, "fetching instance types: boom")
+	c.Assert(s.cache.info, gc.IsNil)
+}
+
+func (s *instanceTypesSuite) TestInstanceTypeToParams(c *gc.C) {
+	virtType := "hvm"
+	result := common.InstanceTypeToParams(instances.InstanceType{
+		Id:       "id",
+		Name:     "name",
+		Arches:   []string{"amd64"},
+		CpuCores: 2,
+		Mem:      4096,
+		Cost:     20,
+		RootDisk: 8192,
+		VirtType: &virtType,
+		CpuPower: instances.CpuPower(200),
+		Tags:     []string{"a"},
+	})
+	c.Assert(result.Name, gc.Equals, "name")
+	c.Assert(result.CpuCores, gc.Equals, uint64(2))
+	c.Assert(*result.VirtType, gc.Equals, "hvm")
+	c.Assert(*result.CpuPower, gc.Equals, uint64(200))
+}
+
+type fakeInstanceTypesCache struct {
+	clock *coretesting.Clock
+	info  *state.InstanceTypesInfo
+}
+
+func (f *fakeInstanceTypesCache) InstanceTypes() (state.InstanceTypesInfo, error) {
+	if f.info == nil {
+		return state.InstanceTypesInfo{}, errors.NotFoundf("instance types")
+	}
+	return *f.info, nil
+}
+
+func (f *fakeInstanceTypesCache) SetInstanceTypes(instanceTypes []instances.InstanceType) error {
+	f.info = &state.InstanceTypesInfo{
+		InstanceTypes: instanceTypes,
+		Updated:       f.clock.Now(),
+	}
+	return nil
+}
+
+type fakeInstanceTypesEnviron struct {
+	environs.Environ
+	instanceTypes []instances.InstanceType
+	err           error
+	calls         int
+}
+
+func (f *fakeInstanceTypesEnviron) InstanceTypes() ([]instances.InstanceType, error) {
+	f.calls++
+	return f.instanceTypes, f.err
+}
+
+// plainEnviron is an environ which cannot list its instance types.
+type plainEnviron struct {
+	environs.Environ
+}
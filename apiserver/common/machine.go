@@ -4,6 +4,8 @@
 package common
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/state"
@@ -53,15 +55,18 @@ func (st *stateShim) Machine(id string) (Machine, error) {
 
 type Machine interface {
 	Life() state.Life
-	ForceDestroy() error
+	ForceDestroyAfter(time.Duration) error
 	Destroy() error
 }
 
-func DestroyMachines(st origStateInterface, force bool, ids ...string) error {
-	return destroyMachines(&stateShim{st}, force, ids...)
+// DestroyMachines destroys the machines with the given ids. If force is
+// set, the machines are removed along with their units and containers,
+// whose agents are given up to maxWait to shut down cleanly.
+func DestroyMachines(st origStateInterface, force bool, maxWait time.Duration, ids ...string) error {
+	return destroyMachines(&stateShim{st}, force, maxWait, ids...)
 }
 
-func destroyMachines(st stateInterface, force bool, ids ...string) error {
+func destroyMachines(st stateInterface, force bool, maxWait time.Duration, ids ...string) error {
 	var errs []string
 	for _, id := range ids {
 		machine, err := st.Machine(id)
@@ -70,7 +75,7 @@ func destroyMachines(st stateInterface, force bool, ids ...string) error {
 			err = errors.Errorf("machine %s does not exist", id)
 		case err != nil:
 		case force:
-			err = machine.ForceDestroy()
+			err = machine.ForceDestroyAfter(maxWait)
 		case machine.Life() != state.Alive:
 			continue
 		default:
@@ -4,6 +4,8 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -50,7 +52,7 @@ func (s *machineSuite) TestDestroyMachines(c *gc.C) {
 			"3": {life: state.Dying},
 		},
 	}
-	err := common.MockableDestroyMachines(&st, false, "1", "2", "3", "4")
+	err := common.MockableDestroyMachines(&st, false, 0, "1", "2", "3", "4")
 
 	c.Assert(st.machines["1"].Life(), gc.Equals, state.Dying)
 	c.Assert(st.machines["1"].forceDestroyCalled, jc.IsFalse)
@@ -71,11 +73,13 @@ func (s *machineSuite) TestForceDestroyMachines(c *gc.C) {
 			"2": {life: state.Dying},
 		},
 	}
-	err := common.MockableDestroyMachines(&st, true, "1", "2")
+	err := common.MockableDestroyMachines(&st, true, time.Minute, "1", "2")
 
 	c.Assert(st.machines["1"].Life(), gc.Equals, state.Dying)
 	c.Assert(st.machines["1"].forceDestroyCalled, jc.IsTrue)
+	c.Assert(st.machines["1"].forceDestroyWait, gc.Equals, time.Minute)
 	c.Assert(st.machines["2"].forceDestroyCalled, jc.IsTrue)
+	c.Assert(st.machines["2"].forceDestroyWait, gc.Equals, time.Minute)
 
 	c.Assert(err, jc.ErrorIsNil)
 }
@@ -98,6 +102,7 @@ type mockMachine struct {
 	destroyErr         error
 	forceDestroyErr    error
 	forceDestroyCalled bool
+	forceDestroyWait   time.Duration
 	destroyCalled      bool
 }
 
@@ -105,8 +110,9 @@ func (m *mockMachine) Life() state.Life {
 	return m.life
 }
 
-func (m *mockMachine) ForceDestroy() error {
+func (m *mockMachine) ForceDestroyAfter(maxWait time.Duration) error {
 	m.forceDestroyCalled = true
+	m.forceDestroyWait = maxWait
 	if m.forceDestroyErr != nil {
 		return m.forceDestroyErr
 	}
@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// maintainer is implemented by entities which may be put into
+// maintenance mode.
+type maintainer interface {
+	InMaintenance() bool
+}
+
+// MaintenanceGetter implements a common InMaintenance method for use by
+// various facades.
+type MaintenanceGetter struct {
+	st         state.EntityFinder
+	getCanRead GetAuthFunc
+}
+
+// NewMaintenanceGetter returns a new MaintenanceGetter. The GetAuthFunc
+// will be used on each invocation of InMaintenance to determine current
+// permissions.
+func NewMaintenanceGetter(st state.EntityFinder, getCanRead GetAuthFunc) *MaintenanceGetter {
+	return &MaintenanceGetter{
+		st:         st,
+		getCanRead: getCanRead,
+	}
+}
+
+func (mg *MaintenanceGetter) oneInMaintenance(tag names.Tag) (bool, error) {
+	entity0, err := mg.st.FindEntity(tag)
+	if err != nil {
+		return false, err
+	}
+	entity, ok := entity0.(maintainer)
+	if !ok {
+		return false, NotSupportedError(tag, "maintenance mode")
+	}
+	return entity.InMaintenance(), nil
+}
+
+// InMaintenance returns whether each supplied entity is in maintenance
+// mode, where available.
+func (mg *MaintenanceGetter) InMaintenance(args params.Entities) (params.BoolResults, error) {
+	result := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	canRead, err := mg.getCanRead()
+	if err != nil {
+		return params.BoolResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = ServerError(ErrPerm)
+			continue
+		}
+		err = ErrPerm
+		if canRead(tag) {
+			result.Results[i].Result, err = mg.oneInMaintenance(tag)
+		}
+		result.Results[i].Error = ServerError(err)
+	}
+	return result, nil
+}
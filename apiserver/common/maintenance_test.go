@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type maintenanceSuite struct{}
+
+var _ = gc.Suite(&maintenanceSuite{})
+
+type fakeMaintainer struct {
+	state.Entity
+	maintenance bool
+	fetchError
+}
+
+func (m *fakeMaintainer) InMaintenance() bool {
+	return m.maintenance
+}
+
+func (*maintenanceSuite) TestInMaintenance(c *gc.C) {
+	st := &fakeState{
+		entities: map[names.Tag]entityWithError{
+			u("x/0"): &fakeMaintainer{maintenance: true},
+			u("x/1"): &fakeMaintainer{maintenance: true},
+			u("x/2"): &fakeMaintainer{},
+			u("x/3"): &fakeMaintainer{fetchError: "x3 error"},
+		},
+	}
+	getCanRead := func() (common.AuthFunc, error) {
+		x0 := u("x/0")
+		x2 := u("x/2")
+		x3 := u("x/3")
+		return func(tag names.Tag) bool {
+			return tag == x0 || tag == x2 || tag == x3
+		}, nil
+	}
+	mg := common.NewMaintenanceGetter(st, getCanRead)
+	entities := params.Entities{[]params.Entity{
+		{"unit-x-0"}, {"unit-x-1"}, {"unit-x-2"}, {"unit-x-3"}, {"unit-x-4"},
+	}}
+	results, err := mg.InMaintenance(entities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Result: true},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: false},
+			{Error: &params.Error{Message: "x3 error"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (*maintenanceSuite) TestInMaintenanceError(c *gc.C) {
+	getCanRead := func() (common.AuthFunc, error) {
+		return nil, fmt.Errorf("pow")
+	}
+	mg := common.NewMaintenanceGetter(&fakeState{}, getCanRead)
+	_, err := mg.InMaintenance(params.Entities{[]params.Entity{{"x0"}}})
+	c.Assert(err, gc.ErrorMatches, "pow")
+}
+
+func (*maintenanceSuite) TestInMaintenanceNoArgsNoError(c *gc.C) {
+	getCanRead := func() (common.AuthFunc, error) {
+		return nil, fmt.Errorf("pow")
+	}
+	mg := common.NewMaintenanceGetter(&fakeState{}, getCanRead)
+	result, err := mg.InMaintenance(params.Entities{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 0)
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/tools"
+)
+
+// HealthMachine defines the methods of a state.Machine used to report
+// the health of a model.
+type HealthMachine interface {
+	AgentPresence() (bool, error)
+	AgentTools() (*tools.Tools, error)
+}
+
+// ModelHealth summarises the health of a model with the given machines,
+// which was last connected to at the given time.
+func ModelHealth(machines []HealthMachine, lastConnection *time.Time) (params.ModelHealth, error) {
+	health := params.ModelHealth{
+		Machines:       len(machines),
+		LastConnection: lastConnection,
+	}
+	var versions []version.Number
+	seen := make(map[version.Number]bool)
+	for _, m := range machines {
+		alive, err := m.AgentPresence()
+		if err != nil {
+			return params.ModelHealth{}, errors.Trace(err)
+		}
+		if !alive {
+			health.MachinesDown++
+		}
+		agentTools, err := m.AgentTools()
+		if errors.IsNotFound(err) {
+			// The agent has not started yet.
+			continue
+		} else if err != nil {
+			return params.ModelHealth{}, errors.Trace(err)
+		}
+		if number := agentTools.Version.Number; !seen[number] {
+			seen[number] = true
+			versions = append(versions, number)
+		}
+	}
+	sort.Sort(versionNumbers(versions))
+	for _, number := range versions {
+		health.AgentVersions = append(health.AgentVersions, number.String())
+	}
+	return health, nil
+}
+
+type versionNumbers []version.Number
+
+func (v versionNumbers) Len() int           { return len(v) }
+func (v versionNumbers) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+func (v versionNumbers) Less(i, j int) bool { return v[i].Compare(v[j]) < 0 }
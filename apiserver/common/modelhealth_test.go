@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/tools"
+)
+
+type modelHealthSuite struct{}
+
+var _ = gc.Suite(&modelHealthSuite{})
+
+func (*modelHealthSuite) TestModelHealth(c *gc.C) {
+	lastConnection := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	health, err := common.ModelHealth([]common.HealthMachine{
+		&fakeHealthMachine{alive: true, agentVersion: "2.0.10"},
+		&fakeHealthMachine{alive: true, agentVersion: "2.0.9"},
+		&fakeHealthMachine{alive: false, agentVersion: "2.0.10"},
+		&fakeHealthMachine{alive: false},
+	}, &lastConnection)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, jc.DeepEquals, params.ModelHealth{
+		Machines:       4,
+		MachinesDown:   2,
+		AgentVersions:  []string{"2.0.9", "2.0.10"},
+		LastConnection: &lastConnection,
+	})
+}
+
+func (*modelHealthSuite) TestModelHealthNoMachines(c *gc.C) {
+	health, err := common.ModelHealth(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, jc.DeepEquals, params.ModelHealth{})
+}
+
+func (*modelHealthSuite) TestModelHealthPresenceError(c *gc.C) {
+	_, err := common.ModelHealth([]common.HealthMachine{
+		&fakeHealthMachine{err: errors.New("no presence")},
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "no presence")
+}
+
+type fakeHealthMachine struct {
+	alive        bool
+	agentVersion string
+	err          error
+}
+
+func (m *fakeHealthMachine) AgentPresence() (bool, error) {
+	return m.alive, m.err
+}
+
+func (m *fakeHealthMachine) AgentTools() (*tools.Tools, error) {
+	if m.agentVersion == "" {
+		return nil, errors.NotFoundf("agent tools for machine")
+	}
+	return &tools.Tools{
+		Version: version.MustParseBinary(m.agentVersion + "-xenial-amd64"),
+	}, nil
+}
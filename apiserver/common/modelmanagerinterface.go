@@ -49,6 +49,7 @@ type ModelManagerBackend interface {
 	Export() (description.Model, error)
 	SetUserAccess(subject names.UserTag, target names.Tag, access description.Access) (description.UserAccess, error)
 	LastModelConnection(user names.UserTag) (time.Time, error)
+	AllMachines() ([]HealthMachine, error)
 	Close() error
 }
 
@@ -67,6 +68,10 @@ type Model interface {
 	Users() ([]description.UserAccess, error)
 	Destroy() error
 	DestroyIncludingHosted() error
+	Limits() state.ModelLimits
+	Usage() (state.ModelUsage, error)
+	SetLimits(state.ModelLimits) error
+	TeardownProgress() (state.ModelTeardownProgress, error)
 }
 
 var _ ModelManagerBackend = (*modelManagerStateShim)(nil)
@@ -139,6 +144,19 @@ func (st modelManagerStateShim) AllModels() ([]Model, error) {
 	return all, nil
 }
 
+// AllMachines implements ModelManagerBackend.
+func (st modelManagerStateShim) AllMachines() ([]HealthMachine, error) {
+	allStateMachines, err := st.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	all := make([]HealthMachine, len(allStateMachines))
+	for i, m := range allStateMachines {
+		all[i] = m
+	}
+	return all, nil
+}
+
 type modelShim struct {
 	*state.Model
 }
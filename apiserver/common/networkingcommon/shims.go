@@ -91,6 +91,11 @@ type stateShim struct {
 	st *state.State
 }
 
+// CachedRead implements common.ReadCacher.
+func (s *stateShim) CachedRead(group state.ReadCacheGroup, key string, read func() (interface{}, error)) (interface{}, error) {
+	return s.st.CachedRead(group, key, read)
+}
+
 func (s *stateShim) AddSpace(name string, providerId network.Id, subnetIds []string, public bool) error {
 	_, err := s.st.AddSpace(name, providerId, subnetIds, public)
 	return err
@@ -0,0 +1,24 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/state"
+)
+
+// ReadCacher is implemented by backings which can cache the results of
+// read-only calls, such as *state.State.
+type ReadCacher interface {
+	CachedRead(group state.ReadCacheGroup, key string, read func() (interface{}, error)) (interface{}, error)
+}
+
+// CachedRead calls read through backing's read cache if backing
+// implements ReadCacher, and directly otherwise. The value returned
+// may be shared with other callers and must not be modified.
+func CachedRead(backing interface{}, group state.ReadCacheGroup, key string, read func() (interface{}, error)) (interface{}, error) {
+	if cacher, ok := backing.(ReadCacher); ok {
+		return cacher.CachedRead(group, key, read)
+	}
+	return read()
+}
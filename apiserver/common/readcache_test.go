@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+type readCacheSuite struct{}
+
+var _ = gc.Suite(&readCacheSuite{})
+
+type fakeReadCacher struct {
+	group state.ReadCacheGroup
+	key   string
+}
+
+func (f *fakeReadCacher) CachedRead(group state.ReadCacheGroup, key string, read func() (interface{}, error)) (interface{}, error) {
+	f.group = group
+	f.key = key
+	return "cached", nil
+}
+
+func (*readCacheSuite) TestCachedReadUsesCacher(c *gc.C) {
+	cacher := &fakeReadCacher{}
+	value, err := common.CachedRead(cacher, state.SpacesReadCache, "key", func() (interface{}, error) {
+		c.Fatalf("read called")
+		return nil, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "cached")
+	c.Assert(cacher.group, gc.Equals, state.SpacesReadCache)
+	c.Assert(cacher.key, gc.Equals, "key")
+}
+
+func (*readCacheSuite) TestCachedReadWithoutCacher(c *gc.C) {
+	value, err := common.CachedRead(struct{}{}, state.SpacesReadCache, "key", func() (interface{}, error) {
+		return "read", nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "read")
+}
@@ -8,9 +8,51 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/monitoring"
 )
 
+// apiWatchers counts the watchers held for API connections.
+var apiWatchers = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: monitoring.Namespace,
+	Subsystem: "api",
+	Name:      "watchers",
+	Help:      "Number of watchers held for API connections.",
+})
+
+func init() {
+	monitoring.MustRegister(apiWatchers)
+}
+
+// APIWatcherCount returns the number of watchers held for API
+// connections by this API server.
+func APIWatcherCount() int {
+	var m dto.Metric
+	if err := apiWatchers.Write(&m); err != nil {
+		logger.Errorf("cannot read API watcher count: %v", err)
+		return 0
+	}
+	return int(m.GetGauge().GetValue())
+}
+
+// watcherResource is implemented by resources which are watchers.
+type watcherResource interface {
+	facade.Resource
+	Kill()
+	Wait() error
+	Err() error
+}
+
+// isWatcher reports whether the given resource is a watcher, and so
+// should be counted in the API watchers metric.
+func isWatcher(r facade.Resource) bool {
+	_, ok := r.(watcherResource)
+	return ok
+}
+
 // Resources holds all the resources for a connection.
 // It allows the registration of resources that will be cleaned
 // up when a connection terminates.
@@ -51,6 +93,9 @@ func (rs *Resources) Register(r facade.Resource) string {
 	id := strconv.FormatUint(rs.maxId, 10)
 	rs.resources[id] = r
 	rs.stack = append(rs.stack, id)
+	if isWatcher(r) {
+		apiWatchers.Inc()
+	}
 	logger.Tracef("registered unnamed resource: %s", id)
 	return id
 }
@@ -73,6 +118,9 @@ func (rs *Resources) RegisterNamed(name string, r facade.Resource) error {
 	}
 	rs.resources[name] = r
 	rs.stack = append(rs.stack, name)
+	if isWatcher(r) {
+		apiWatchers.Inc()
+	}
 	logger.Tracef("registered named resource: %s", name)
 	return nil
 }
@@ -96,6 +144,13 @@ func (rs *Resources) Stop(id string) error {
 	err := r.Stop()
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
+	if _, ok := rs.resources[id]; !ok {
+		// Stopped concurrently, and already unregistered.
+		return err
+	}
+	if isWatcher(r) {
+		apiWatchers.Dec()
+	}
 	delete(rs.resources, id)
 	for pos := 0; pos < len(rs.stack); pos++ {
 		if rs.stack[pos] == id {
@@ -117,6 +172,9 @@ func (rs *Resources) StopAll() {
 		if err := r.Stop(); err != nil {
 			logger.Errorf("error stopping %T resource: %v", r, err)
 		}
+		if isWatcher(r) {
+			apiWatchers.Dec()
+		}
 	}
 	rs.resources = make(map[string]facade.Resource)
 	rs.stack = nil
@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	monitoringtesting "github.com/juju/juju/monitoring/testing"
 )
 
 type resourceSuite struct{}
@@ -25,6 +26,14 @@ func (r *fakeResource) Stop() error {
 	return nil
 }
 
+type fakeWatcher struct {
+	fakeResource
+}
+
+func (*fakeWatcher) Kill()       {}
+func (*fakeWatcher) Wait() error { return nil }
+func (*fakeWatcher) Err() error  { return nil }
+
 func (resourceSuite) TestRegisterGetCount(c *gc.C) {
 	rs := common.NewResources()
 	r1 := &fakeResource{}
@@ -152,6 +161,23 @@ func (resourceSuite) TestStopAll(c *gc.C) {
 	c.Assert(rs.Count(), gc.Equals, 0)
 }
 
+func (resourceSuite) TestWatchersMetric(c *gc.C) {
+	before := monitoringtesting.Value(c, common.APIWatchers)
+	rs := common.NewResources()
+	rs.Register(&fakeWatcher{})
+	rs.Register(&fakeResource{})
+	err := rs.RegisterNamed("watcher", &fakeWatcher{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(monitoringtesting.Value(c, common.APIWatchers), gc.Equals, before+2)
+	c.Assert(common.APIWatcherCount(), gc.Equals, int(before)+2)
+
+	err = rs.Stop("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(monitoringtesting.Value(c, common.APIWatchers), gc.Equals, before+1)
+	rs.StopAll()
+	c.Assert(monitoringtesting.Value(c, common.APIWatchers), gc.Equals, before)
+}
+
 func (resourceSuite) TestStringResource(c *gc.C) {
 	rs := common.NewResources()
 	r1 := common.StringResource("foobar")
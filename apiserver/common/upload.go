@@ -0,0 +1,160 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+var (
+	// uploadDir holds the content of resumable uploads until they
+	// are complete.
+	uploadDir = filepath.Join(os.TempDir(), "juju-uploads")
+
+	// uploadExpiry is how long an incomplete upload is kept
+	// without being resumed.
+	uploadExpiry = 24 * time.Hour
+
+	uploadsMu sync.Mutex
+	uploading = make(map[string]bool)
+)
+
+// ResumableUpload describes a request carrying part of the content
+// of a resumable upload.
+type ResumableUpload struct {
+	// Offset is the offset within the content at which the
+	// request body starts.
+	Offset int64
+
+	// Length is the total size of the content.
+	Length int64
+}
+
+// ParseResumableUpload returns the resumable upload described by the
+// headers of the given request, or false if the request does not
+// describe one.
+func ParseResumableUpload(req *http.Request) (ResumableUpload, bool, error) {
+	offsetStr := req.Header.Get(params.UploadOffsetHeader)
+	if offsetStr == "" {
+		return ResumableUpload{}, false, nil
+	}
+	offset, err := strconv.ParseInt(offsetStr, 10, 64)
+	if err != nil || offset < 0 {
+		return ResumableUpload{}, false, errors.NotValidf("upload offset %q", offsetStr)
+	}
+	lengthStr := req.Header.Get(params.UploadLengthHeader)
+	length, err := strconv.ParseInt(lengthStr, 10, 64)
+	if err != nil || length < offset {
+		return ResumableUpload{}, false, errors.NotValidf("upload length %q", lengthStr)
+	}
+	return ResumableUpload{Offset: offset, Length: length}, true, nil
+}
+
+// UploadOffset returns the number of bytes received so far for the
+// resumable upload with the given key.
+func UploadOffset(key string) (int64, error) {
+	info, err := os.Stat(uploadPath(key))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return info.Size(), nil
+}
+
+// ReceiveUpload appends the content read from r to the resumable
+// upload with the given key. If r ends before all the content has been
+// received, the data received so far is kept so that the upload may
+// be resumed and an error is returned. Otherwise the path of the file
+// holding the complete content is returned; the caller must call
+// DiscardUpload when done with it.
+func ReceiveUpload(key string, upload ResumableUpload, r io.Reader) (string, error) {
+	uploadsMu.Lock()
+	if uploading[key] {
+		uploadsMu.Unlock()
+		return "", errors.AlreadyExistsf("upload in progress")
+	}
+	uploading[key] = true
+	uploadsMu.Unlock()
+	defer func() {
+		uploadsMu.Lock()
+		delete(uploading, key)
+		uploadsMu.Unlock()
+	}()
+
+	pruneUploads()
+	if err := os.MkdirAll(uploadDir, 0700); err != nil {
+		return "", errors.Annotate(err, "cannot create upload directory")
+	}
+	path := uploadPath(key)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot open upload")
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if received := info.Size(); received != upload.Offset {
+		return "", errors.NotValidf("upload offset %d (%d bytes received)", upload.Offset, received)
+	}
+
+	remaining := upload.Length - upload.Offset
+	n, err := io.Copy(f, io.LimitReader(r, remaining+1))
+	if n > remaining {
+		DiscardUpload(key)
+		return "", errors.Errorf("upload exceeds %d bytes", upload.Length)
+	}
+	if err != nil {
+		return "", errors.Annotate(err, "processing upload")
+	}
+	if n < remaining {
+		return "", errors.Errorf("upload incomplete: received %d of %d bytes", upload.Offset+n, upload.Length)
+	}
+	return path, nil
+}
+
+// DiscardUpload removes any content held for the resumable upload
+// with the given key.
+func DiscardUpload(key string) {
+	if err := os.Remove(uploadPath(key)); err != nil && !os.IsNotExist(err) {
+		logger.Warningf("cannot remove upload: %v", err)
+	}
+}
+
+// uploadPath returns the path of the file holding the content of the
+// upload with the given key.
+func uploadPath(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(uploadDir, hex.EncodeToString(hash[:]))
+}
+
+// pruneUploads removes uploads that have not been resumed within
+// uploadExpiry.
+func pruneUploads() {
+	infos, err := ioutil.ReadDir(uploadDir)
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-uploadExpiry)
+	for _, info := range infos {
+		if info.ModTime().Before(cutoff) {
+			os.Remove(filepath.Join(uploadDir, info.Name()))
+		}
+	}
+}
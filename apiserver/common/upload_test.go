@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type uploadSuite struct {
+	testing.BaseSuite
+	dir string
+}
+
+var _ = gc.Suite(&uploadSuite{})
+
+func (s *uploadSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "uploads")
+	s.PatchValue(common.UploadDir, s.dir)
+}
+
+func (s *uploadSuite) TestParseResumableUpload(c *gc.C) {
+	req, err := http.NewRequest("POST", "/charms", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok, err := common.ParseResumableUpload(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsFalse)
+
+	req.Header.Set(params.UploadOffsetHeader, "3")
+	req.Header.Set(params.UploadLengthHeader, "10")
+	upload, ok, err := common.ParseResumableUpload(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(upload, jc.DeepEquals, common.ResumableUpload{Offset: 3, Length: 10})
+
+	req.Header.Set(params.UploadLengthHeader, "2")
+	_, _, err = common.ParseResumableUpload(req)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `upload length "2" not valid`)
+}
+
+func (s *uploadSuite) TestReceiveUploadResumes(c *gc.C) {
+	_, err := common.ReceiveUpload("key", common.ResumableUpload{Length: 10}, strings.NewReader("hello"))
+	c.Assert(err, gc.ErrorMatches, "upload incomplete: received 5 of 10 bytes")
+
+	offset, err := common.UploadOffset("key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offset, gc.Equals, int64(5))
+
+	path, err := common.ReceiveUpload("key", common.ResumableUpload{Offset: 5, Length: 10}, strings.NewReader("world"))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "helloworld")
+
+	common.DiscardUpload("key")
+	offset, err = common.UploadOffset("key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offset, gc.Equals, int64(0))
+}
+
+func (s *uploadSuite) TestReceiveUploadWrongOffset(c *gc.C) {
+	_, err := common.ReceiveUpload("key", common.ResumableUpload{Offset: 2, Length: 10}, strings.NewReader("hello"))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `upload offset 2 \(0 bytes received\) not valid`)
+}
+
+func (s *uploadSuite) TestReceiveUploadTooLong(c *gc.C) {
+	_, err := common.ReceiveUpload("key", common.ResumableUpload{Length: 3}, strings.NewReader("hello"))
+	c.Assert(err, gc.ErrorMatches, "upload exceeds 3 bytes")
+	offset, err := common.UploadOffset("key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offset, gc.Equals, int64(0))
+}
+
+func (s *uploadSuite) TestReceiveUploadPrunesExpired(c *gc.C) {
+	_, err := common.ReceiveUpload("old", common.ResumableUpload{Length: 10}, strings.NewReader("hello"))
+	c.Assert(err, gc.ErrorMatches, "upload incomplete: .*")
+	infos, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 1)
+	past := time.Now().Add(-48 * time.Hour)
+	err = os.Chtimes(filepath.Join(s.dir, infos[0].Name()), past, past)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = common.ReceiveUpload("new", common.ResumableUpload{Length: 5}, strings.NewReader("hello"))
+	c.Assert(err, jc.ErrorIsNil)
+	offset, err := common.UploadOffset("old")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offset, gc.Equals, int64(0))
+}
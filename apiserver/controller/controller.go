@@ -41,6 +41,7 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateModelMigration(params.InitiateModelMigrationArgs) (params.InitiateModelMigrationResults, error)
+	ModelMigrationStatus(params.Entities) (params.ModelMigrationStatusResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
 }
 
@@ -415,6 +416,59 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 	return mig.Id(), nil
 }
 
+// ModelMigrationStatus returns the progress of the latest migration
+// of each of the given models, so that clients may report on it while
+// the migration runs.
+func (c *ControllerAPI) ModelMigrationStatus(args params.Entities) (params.ModelMigrationStatusResults, error) {
+	out := params.ModelMigrationStatusResults{
+		Results: make([]params.ModelMigrationStatusResult, len(args.Entities)),
+	}
+	admin, err := c.hasAdminAccess()
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+	if !admin {
+		return out, common.ServerError(common.ErrPerm)
+	}
+	for i, entity := range args.Entities {
+		status, err := c.modelMigrationStatus(entity.Tag)
+		if err != nil {
+			out.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		out.Results[i].Status = status
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) modelMigrationStatus(tag string) (*params.ModelMigrationStatus, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	st, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Close()
+
+	mig, err := st.LatestModelMigration()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ModelMigrationStatus{
+		MigrationId:      mig.Id(),
+		Phase:            phase.String(),
+		StatusMessage:    mig.StatusMessage(),
+		StartTime:        mig.StartTime(),
+		PhaseChangedTime: mig.PhaseChangedTime(),
+	}, nil
+}
+
 func (c *ControllerAPI) environStatus(tag string) (params.ModelStatus, error) {
 	var status params.ModelStatus
 	modelTag, err := names.ParseModelTag(tag)
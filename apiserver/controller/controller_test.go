@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -419,6 +420,46 @@ func (s *controllerSuite) TestInitiateModelMigrationPartialFailure(c *gc.C) {
 	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestModelMigrationStatus(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	mig, err := st.CreateModelMigration(state.ModelMigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag: names.NewModelTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("admin"),
+			Password:      "secret",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.PRECHECK), jc.ErrorIsNil)
+	c.Assert(mig.SetStatusMessage("checking target"), jc.ErrorIsNil)
+
+	out, err := s.controller.ModelMigrationStatus(params.Entities{
+		Entities: []params.Entity{
+			{Tag: st.ModelTag().String()},
+			{Tag: s.State.ModelTag().String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Assert(out.Results[0].Error, gc.IsNil)
+	status := out.Results[0].Status
+	c.Assert(status, gc.NotNil)
+	c.Check(status.MigrationId, gc.Equals, mig.Id())
+	c.Check(status.Phase, gc.Equals, "PRECHECK")
+	c.Check(status.StatusMessage, gc.Equals, "checking target")
+	c.Check(status.StartTime.IsZero(), jc.IsFalse)
+	c.Check(status.PhaseChangedTime.IsZero(), jc.IsFalse)
+
+	c.Check(out.Results[1].Status, gc.IsNil)
+	c.Check(out.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
 func randomModelTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewModelTag(uuid).String()
@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerreport provides the facade through which
+// controller administrators assess a controller's resource usage
+// against its configured soft limits, before deciding to scale it up.
+package controllerreport
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("ControllerReport", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ControllerConfig() (controller.Config, error)
+	ControllerUsage() (state.ControllerUsage, error)
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth, common.APIWatcherCount)
+}
+
+// API is the endpoint which implements the ControllerReport facade.
+type API struct {
+	backend      Backend
+	watcherCount func() int
+}
+
+// NewAPI creates a new instance of the ControllerReport facade. The
+// watcherCount func returns the number of watchers held for API
+// connections by the API server.
+func NewAPI(backend Backend, authorizer facade.Authorizer, watcherCount func() int) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(description.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:      backend,
+		watcherCount: watcherCount,
+	}, nil
+}
+
+// Report returns the controller's resource usage, with the soft limit
+// configured for each resource. The API watcher count is that of the
+// API server handling the request; in a highly available controller,
+// each controller machine holds its own watchers.
+func (api *API) Report() (params.ControllerReport, error) {
+	usage, err := api.backend.ControllerUsage()
+	if err != nil {
+		return params.ControllerReport{}, errors.Trace(err)
+	}
+	cfg, err := api.backend.ControllerConfig()
+	if err != nil {
+		return params.ControllerReport{}, errors.Trace(err)
+	}
+	resources := []params.ControllerResource{{
+		Name:  "models",
+		Usage: float64(usage.Models),
+		Limit: cfg.SoftLimit(controller.SoftLimitModels),
+	}, {
+		Name:  "machines",
+		Usage: float64(usage.Machines),
+		Limit: cfg.SoftLimit(controller.SoftLimitMachines),
+	}, {
+		Name:  "units",
+		Usage: float64(usage.Units),
+		Limit: cfg.SoftLimit(controller.SoftLimitUnits),
+	}, {
+		Name:  "api-watchers",
+		Usage: float64(api.watcherCount()),
+		Limit: cfg.SoftLimit(controller.SoftLimitAPIWatchers),
+	}, {
+		Name:  "database-size-mb",
+		Usage: float64(usage.DatabaseSizeMB),
+		Limit: cfg.SoftLimit(controller.SoftLimitDatabaseSizeMB),
+	}, {
+		Name:  "logs-size-mb",
+		Usage: float64(usage.LogsSizeMB),
+	}, {
+		Name:  "log-rate",
+		Usage: usage.LogRate,
+		Limit: cfg.SoftLimit(controller.SoftLimitLogRate),
+	}}
+	return params.ControllerReport{Resources: resources}, nil
+}
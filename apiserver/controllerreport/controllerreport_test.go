@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerreport_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/controllerreport"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+type ControllerReportSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&ControllerReportSuite{})
+
+func (s *ControllerReportSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{
+		usage: state.ControllerUsage{
+			Models:         3,
+			Machines:       12,
+			Units:          40,
+			DatabaseSizeMB: 512,
+			LogsSizeMB:     128,
+			LogRate:        2.5,
+		},
+		config: controller.Config{
+			controller.SoftLimitModels:         10,
+			controller.SoftLimitUnits:          float64(1000),
+			controller.SoftLimitAPIWatchers:    500,
+			controller.SoftLimitDatabaseSizeMB: 4096,
+			controller.SoftLimitLogRate:        100,
+		},
+	}
+}
+
+func (s *ControllerReportSuite) newAPI(c *gc.C) *controllerreport.API {
+	api, err := controllerreport.NewAPI(s.backend, &s.authorizer, func() int { return 42 })
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *ControllerReportSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := controllerreport.NewAPI(s.backend, &s.authorizer, nil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ControllerReportSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := controllerreport.NewAPI(s.backend, &s.authorizer, nil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ControllerReportSuite) TestNewAPIModelWriterNotSuperuser(c *gc.C) {
+	// Write access to models does not give access to the
+	// controller's resource usage.
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasWriteTag = names.NewUserTag("mary@local")
+	_, err := controllerreport.NewAPI(s.backend, &s.authorizer, nil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ControllerReportSuite) TestReport(c *gc.C) {
+	report, err := s.newAPI(c).Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, params.ControllerReport{
+		Resources: []params.ControllerResource{
+			{Name: "models", Usage: 3, Limit: 10},
+			{Name: "machines", Usage: 12},
+			{Name: "units", Usage: 40, Limit: 1000},
+			{Name: "api-watchers", Usage: 42, Limit: 500},
+			{Name: "database-size-mb", Usage: 512, Limit: 4096},
+			{Name: "logs-size-mb", Usage: 128},
+			{Name: "log-rate", Usage: 2.5, Limit: 100},
+		},
+	})
+	s.backend.stub.CheckCallNames(c, "ControllerUsage", "ControllerConfig")
+}
+
+func (s *ControllerReportSuite) TestReportUsageError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.New("boom"))
+	_, err := s.newAPI(c).Report()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.backend.stub.CheckCallNames(c, "ControllerUsage")
+}
+
+type mockBackend struct {
+	stub   gitjujutesting.Stub
+	usage  state.ControllerUsage
+	config controller.Config
+}
+
+func (m *mockBackend) ControllerTag() names.ControllerTag {
+	return names.NewControllerTag("deadbeef-1bad-500d-9000-4b1d0d06f00d")
+}
+
+func (m *mockBackend) ControllerUsage() (state.ControllerUsage, error) {
+	m.stub.AddCall("ControllerUsage")
+	return m.usage, m.stub.NextErr()
+}
+
+func (m *mockBackend) ControllerConfig() (controller.Config, error) {
+	m.stub.AddCall("ControllerConfig")
+	return m.config, m.stub.NextErr()
+}
@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes information about the cloud credential used by a
+// model.
+type Backend interface {
+	// ModelCredential returns the tag of the model's cloud credential,
+	// and false if the model does not use one.
+	ModelCredential() (names.CloudCredentialTag, bool, error)
+	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
+	InvalidateCloudCredential(tag names.CloudCredentialTag, reason string) error
+	WatchCloudCredential(tag names.CloudCredentialTag) state.NotifyWatcher
+}
+
+// Facade lets model workers check, watch and invalidate the cloud
+// credential used by their model.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// New creates a Facade backed by backend and resources. If auth
+// doesn't identify the client as a controller machine agent, which
+// runs the model workers, it will return common.ErrPerm.
+func New(backend Backend, resources facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// ModelCredential returns the model's cloud credential tag, and
+// whether that credential is currently valid. A model that does not
+// use a credential is always considered valid.
+func (facade *Facade) ModelCredential() (params.ModelCredential, error) {
+	tag, exists, err := facade.backend.ModelCredential()
+	if err != nil {
+		return params.ModelCredential{}, errors.Trace(err)
+	}
+	if !exists {
+		return params.ModelCredential{Valid: true}, nil
+	}
+	credential, err := facade.backend.CloudCredential(tag)
+	if err != nil {
+		return params.ModelCredential{}, errors.Trace(err)
+	}
+	return params.ModelCredential{
+		Exists:          true,
+		CloudCredential: tag.String(),
+		Valid:           !credential.Invalid,
+	}, nil
+}
+
+// WatchModelCredential returns an id for use with the NotifyWatcher
+// facade, which will inform of changes to the model's cloud credential.
+func (facade *Facade) WatchModelCredential() (params.NotifyWatchResult, error) {
+	tag, exists, err := facade.backend.ModelCredential()
+	if err != nil {
+		return params.NotifyWatchResult{}, errors.Trace(err)
+	}
+	if !exists {
+		return params.NotifyWatchResult{}, errors.NotFoundf("model cloud credential")
+	}
+	watch := facade.backend.WatchCloudCredential(tag)
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: facade.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// InvalidateModelCredential marks the model's cloud credential as
+// invalid, which suspends every model that uses it until the
+// credential is updated.
+func (facade *Facade) InvalidateModelCredential(args params.InvalidateCredentialArg) (params.ErrorResult, error) {
+	tag, exists, err := facade.backend.ModelCredential()
+	if err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	if !exists {
+		err := errors.NotFoundf("model cloud credential")
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	err = facade.backend.InvalidateCloudCredential(tag, args.Reason)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
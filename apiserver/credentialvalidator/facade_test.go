@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/credentialvalidator"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (*FacadeSuite) TestAcceptsModelManager(c *gc.C) {
+	facade, err := credentialvalidator.New(nil, nil, authOK)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (*FacadeSuite) TestRejectsWorkloadMachineAgent(c *gc.C) {
+	facade, err := credentialvalidator.New(nil, nil, agentAuth{machine: true})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (*FacadeSuite) TestRejectsNonAgent(c *gc.C) {
+	facade, err := credentialvalidator.New(nil, nil, agentAuth{})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (*FacadeSuite) TestModelCredentialValid(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{stub: stub, exists: true}
+	facade, err := credentialvalidator.New(backend, nil, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.ModelCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.ModelCredential{
+		Exists:          true,
+		CloudCredential: credentialTag.String(),
+		Valid:           true,
+	})
+	stub.CheckCalls(c, []testing.StubCall{
+		{"ModelCredential", nil},
+		{"CloudCredential", []interface{}{credentialTag}},
+	})
+}
+
+func (*FacadeSuite) TestModelCredentialInvalid(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{stub: stub, exists: true, invalid: true}
+	facade, err := credentialvalidator.New(backend, nil, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.ModelCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Valid, jc.IsFalse)
+}
+
+func (*FacadeSuite) TestModelCredentialNotUsed(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{stub: stub}
+	facade, err := credentialvalidator.New(backend, nil, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.ModelCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.ModelCredential{Valid: true})
+	stub.CheckCallNames(c, "ModelCredential")
+}
+
+func (*FacadeSuite) TestModelCredentialError(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(errors.New("ouch"))
+	backend := &mockBackend{stub: stub, exists: true}
+	facade, err := credentialvalidator.New(backend, nil, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.ModelCredential()
+	c.Check(err, gc.ErrorMatches, "ouch")
+}
+
+func (*FacadeSuite) TestWatchModelCredentialSuccess(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{stub: stub, exists: true}
+	resources := common.NewResources()
+	facade, err := credentialvalidator.New(backend, resources, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.WatchModelCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.IsNil)
+	c.Check(resources.Get(result.NotifyWatcherId), gc.NotNil)
+	stub.CheckCallNames(c, "ModelCredential", "WatchCloudCredential")
+}
+
+func (*FacadeSuite) TestWatchModelCredentialClosed(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(nil, errors.New("blort")) // trigger channel closed error
+	backend := &mockBackend{stub: stub, exists: true}
+	resources := common.NewResources()
+	facade, err := credentialvalidator.New(backend, resources, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.WatchModelCredential()
+	c.Check(err, gc.ErrorMatches, "blort")
+	c.Check(resources.Count(), gc.Equals, 0)
+}
+
+func (*FacadeSuite) TestWatchModelCredentialNotUsed(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{stub: stub}
+	facade, err := credentialvalidator.New(backend, nil, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.WatchModelCredential()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (*FacadeSuite) TestInvalidateModelCredential(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{stub: stub, exists: true}
+	facade, err := credentialvalidator.New(backend, nil, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.InvalidateModelCredential(params.InvalidateCredentialArg{
+		Reason: "revoked",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.IsNil)
+	stub.CheckCalls(c, []testing.StubCall{
+		{"ModelCredential", nil},
+		{"InvalidateCloudCredential", []interface{}{credentialTag, "revoked"}},
+	})
+}
+
+func (*FacadeSuite) TestInvalidateModelCredentialError(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(nil, errors.New("splat"))
+	backend := &mockBackend{stub: stub, exists: true}
+	facade, err := credentialvalidator.New(backend, nil, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.InvalidateModelCredential(params.InvalidateCredentialArg{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.ErrorMatches, "splat")
+}
+
+func (*FacadeSuite) TestInvalidateModelCredentialNotUsed(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{stub: stub}
+	facade, err := credentialvalidator.New(backend, nil, authOK)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.InvalidateModelCredential(params.InvalidateCredentialArg{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, jc.Satisfies, params.IsCodeNotFound)
+	stub.CheckCallNames(c, "ModelCredential")
+}
@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("CredentialValidator", 1, newFacade)
+}
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, resources facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	facade, err := New(&backend{st}, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+// backend implements Backend by wrapping a *state.State.
+type backend struct {
+	*state.State
+}
+
+// ModelCredential is part of the Backend interface.
+func (shim *backend) ModelCredential() (names.CloudCredentialTag, bool, error) {
+	model, err := shim.State.Model()
+	if err != nil {
+		return names.CloudCredentialTag{}, false, errors.Trace(err)
+	}
+	tag, exists := model.CloudCredential()
+	return tag, exists, nil
+}
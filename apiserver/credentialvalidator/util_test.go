@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+)
+
+// agentAuth implements facade.Authorizer for use in the tests.
+type agentAuth struct {
+	facade.Authorizer
+	machine      bool
+	modelManager bool
+}
+
+// AuthMachineAgent is part of the facade.Authorizer interface.
+func (auth agentAuth) AuthMachineAgent() bool {
+	return auth.machine
+}
+
+// AuthModelManager is part of the facade.Authorizer interface.
+func (auth agentAuth) AuthModelManager() bool {
+	return auth.modelManager
+}
+
+// authOK will always authenticate successfully.
+var authOK = agentAuth{machine: true, modelManager: true}
+
+// credentialTag is the tag of the credential used by mock models.
+var credentialTag = names.NewCloudCredentialTag("dummy/fred@local/default")
+
+// mockBackend implements credentialvalidator.Backend for use in the
+// tests.
+type mockBackend struct {
+	stub    *testing.Stub
+	exists  bool
+	invalid bool
+}
+
+// ModelCredential is part of the credentialvalidator.Backend interface.
+func (mock *mockBackend) ModelCredential() (names.CloudCredentialTag, bool, error) {
+	mock.stub.AddCall("ModelCredential")
+	if err := mock.stub.NextErr(); err != nil {
+		return names.CloudCredentialTag{}, false, err
+	}
+	if !mock.exists {
+		return names.CloudCredentialTag{}, false, nil
+	}
+	return credentialTag, true, nil
+}
+
+// CloudCredential is part of the credentialvalidator.Backend interface.
+func (mock *mockBackend) CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error) {
+	mock.stub.AddCall("CloudCredential", tag)
+	if err := mock.stub.NextErr(); err != nil {
+		return cloud.Credential{}, err
+	}
+	credential := cloud.NewEmptyCredential()
+	credential.Invalid = mock.invalid
+	return credential, nil
+}
+
+// InvalidateCloudCredential is part of the credentialvalidator.Backend
+// interface.
+func (mock *mockBackend) InvalidateCloudCredential(tag names.CloudCredentialTag, reason string) error {
+	mock.stub.AddCall("InvalidateCloudCredential", tag, reason)
+	return mock.stub.NextErr()
+}
+
+// WatchCloudCredential is part of the credentialvalidator.Backend
+// interface.
+func (mock *mockBackend) WatchCloudCredential(tag names.CloudCredentialTag) state.NotifyWatcher {
+	mock.stub.AddCall("WatchCloudCredential", tag)
+	return newMockWatcher(mock.stub)
+}
+
+// newMockWatcher consumes an error from the supplied testing.Stub, and
+// returns a state.NotifyWatcher that either works or doesn't depending
+// on whether the error was nil.
+func newMockWatcher(stub *testing.Stub) *mockWatcher {
+	changes := make(chan struct{}, 1)
+	err := stub.NextErr()
+	if err == nil {
+		changes <- struct{}{}
+	} else {
+		close(changes)
+	}
+	return &mockWatcher{
+		err:     err,
+		changes: changes,
+	}
+}
+
+// mockWatcher implements state.NotifyWatcher for use in the tests.
+type mockWatcher struct {
+	state.NotifyWatcher
+	changes chan struct{}
+	err     error
+}
+
+// Changes is part of the state.NotifyWatcher interface.
+func (mock *mockWatcher) Changes() <-chan struct{} {
+	return mock.changes
+}
+
+// Err is part of the state.NotifyWatcher interface.
+func (mock *mockWatcher) Err() error {
+	return mock.err
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -62,6 +63,13 @@ func newDebugLogHandler(
 //   backlog -> uint
 //      - go back this many lines from the end before starting to filter
 //      - has no meaning if 'replay' is true
+//   offset -> uint
+//      - skip over this many of the most recent matching lines, so that
+//        the backlog may be paged through; implies noTail
+//   startTime -> string - RFC 3339 time; only lines logged at or after
+//      this time are sent
+//   endTime -> string - RFC 3339 time; only lines logged before this
+//      time are sent; implies noTail
 //   level -> string one of [TRACE, DEBUG, INFO, WARNING, ERROR]
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
@@ -149,6 +157,9 @@ type debugLogParams struct {
 	fromTheStart  bool
 	noTail        bool
 	backlog       uint
+	offset        uint
+	startTime     time.Time
+	endTime       time.Time
 	filterLevel   loggo.Level
 	includeEntity []string
 	excludeEntity []string
@@ -191,6 +202,35 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.backlog = uint(num)
 	}
 
+	if value := queryMap.Get("offset"); value != "" {
+		num, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("offset value %q is not a valid unsigned number", value)
+		}
+		params.offset = uint(num)
+	}
+
+	if value := queryMap.Get("startTime"); value != "" {
+		startTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("startTime value %q is not a valid time", value)
+		}
+		params.startTime = startTime
+	}
+
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("endTime value %q is not a valid time", value)
+		}
+		params.endTime = endTime
+	}
+
+	if !params.startTime.IsZero() && !params.endTime.IsZero() && !params.endTime.After(params.startTime) {
+		return nil, errors.Errorf("endTime %q is not after startTime %q",
+			queryMap.Get("endTime"), queryMap.Get("startTime"))
+	}
+
 	if value := queryMap.Get("level"); value != "" {
 		var ok bool
 		level, ok := loggo.ParseLevel(value)
@@ -56,9 +56,12 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 	params := &state.LogTailerParams{
+		StartTime:     reqParams.startTime,
+		EndTime:       reqParams.endTime,
 		MinLevel:      reqParams.filterLevel,
 		NoTail:        reqParams.noTail,
 		InitialLines:  int(reqParams.backlog),
+		Offset:        int(reqParams.offset),
 		IncludeEntity: reqParams.includeEntity,
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/loggo"
@@ -45,9 +46,8 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
 		c.Assert(params.StartTime.IsZero(), jc.IsTrue)
+		c.Assert(params.EndTime.IsZero(), jc.IsTrue)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -89,6 +89,74 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestParamConversionTimeRange(c *gc.C) {
+	startTime := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+	reqParams := &debugLogParams{
+		fromTheStart: true,
+		startTime:    startTime,
+		endTime:      endTime,
+		backlog:      20,
+		offset:       40,
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+
+		c.Assert(params.StartTime, gc.Equals, startTime)
+		c.Assert(params.EndTime, gc.Equals, endTime)
+		c.Assert(params.InitialLines, gc.Equals, 0)
+		c.Assert(params.Offset, gc.Equals, 40)
+
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsTimeRange(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"startTime": {"2016-08-01T10:00:00Z"},
+		"endTime":   {"2016-08-01T11:30:00.5+01:00"},
+		"offset":    {"100"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.startTime.Equal(time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)), jc.IsTrue)
+	c.Assert(params.endTime.Equal(time.Date(2016, 8, 1, 10, 30, 0, 5e8, time.UTC)), jc.IsTrue)
+	c.Assert(params.offset, gc.Equals, uint(100))
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		values   url.Values
+		errMatch string
+	}{{
+		values:   url.Values{"offset": {"-1"}},
+		errMatch: `offset value "-1" is not a valid unsigned number`,
+	}, {
+		values:   url.Values{"startTime": {"yesterday"}},
+		errMatch: `startTime value "yesterday" is not a valid time`,
+	}, {
+		values:   url.Values{"endTime": {"2016-08-01"}},
+		errMatch: `endTime value "2016-08-01" is not a valid time`,
+	}, {
+		values: url.Values{
+			"startTime": {"2016-08-01T10:00:00Z"},
+			"endTime":   {"2016-08-01T10:00:00Z"},
+		},
+		errMatch: `endTime "2016-08-01T10:00:00Z" is not after startTime "2016-08-01T10:00:00Z"`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := readDebugLogParams(test.values)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+	}
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
@@ -0,0 +1,176 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package defaultconstraints provides the facade through which clients
+// get and set the default constraints of the controller and of cloud
+// regions, and resolve the effective constraints of a model or
+// application along with the level each value comes from.
+package defaultconstraints
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("DefaultConstraints", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	common.BlockGetter
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
+	DefaultConstraints(cloud, region string) (constraints.Value, error)
+	SetDefaultConstraints(cloud, region string, cons constraints.Value) error
+	ResolveConstraints(application string) (state.EffectiveConstraints, error)
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// API is the endpoint which implements the DefaultConstraints facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+	check   *common.BlockChecker
+}
+
+// NewAPI creates a new instance of the DefaultConstraints facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+		check:   common.NewBlockChecker(backend),
+	}, nil
+}
+
+func (api *API) checkPermission(access description.Access, target names.Tag) error {
+	ok, err := api.auth.HasPermission(access, target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// DefaultConstraints returns the default constraints of the given
+// scopes.
+func (api *API) DefaultConstraints(args params.DefaultConstraintsScopes) (params.DefaultConstraintsResults, error) {
+	var results params.DefaultConstraintsResults
+	if err := api.checkPermission(description.ReadAccess, api.backend.ModelTag()); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.DefaultConstraintsResult, len(args.Scopes))
+	for i, scope := range args.Scopes {
+		cloud, err := cloudName(scope.CloudTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		cons, err := api.backend.DefaultConstraints(cloud, scope.CloudRegion)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Constraints = cons
+	}
+	return results, nil
+}
+
+// SetDefaultConstraints replaces the default constraints of the given
+// scopes. Only controller superusers may set default constraints.
+func (api *API) SetDefaultConstraints(args params.DefaultConstraintsArgs) (params.ErrorResults, error) {
+	var results params.ErrorResults
+	if err := api.checkPermission(description.SuperuserAccess, api.backend.ControllerTag()); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		cloud, err := cloudName(arg.CloudTag)
+		if err == nil {
+			err = api.backend.SetDefaultConstraints(cloud, arg.CloudRegion, arg.Constraints)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ResolveConstraints returns the effective constraints of the given
+// model or applications, and the level of the constraints hierarchy
+// from which each value is taken.
+func (api *API) ResolveConstraints(args params.Entities) (params.ResolvedConstraintsResults, error) {
+	var results params.ResolvedConstraintsResults
+	if err := api.checkPermission(description.ReadAccess, api.backend.ModelTag()); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ResolvedConstraintsResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		result, err := api.resolveConstraints(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *API) resolveConstraints(tagString string) (params.ResolvedConstraintsResult, error) {
+	var result params.ResolvedConstraintsResult
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var application string
+	switch tag := tag.(type) {
+	case names.ModelTag:
+		if tag != api.backend.ModelTag() {
+			return result, common.ErrPerm
+		}
+	case names.ApplicationTag:
+		application = tag.Id()
+	default:
+		return result, errors.NotValidf("tag %q", tagString)
+	}
+	effective, err := api.backend.ResolveConstraints(application)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Constraints = effective.Value
+	result.Sources = make(map[string]string, len(effective.Sources))
+	for attr, source := range effective.Sources {
+		result.Sources[attr] = string(source)
+	}
+	return result, nil
+}
+
+// cloudName returns the name of the cloud with the given tag. An empty
+// tag denotes the controller, and yields an empty name.
+func cloudName(tagString string) (string, error) {
+	if tagString == "" {
+		return "", nil
+	}
+	tag, err := names.ParseCloudTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return tag.Id(), nil
+}
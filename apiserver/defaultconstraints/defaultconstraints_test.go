@@ -0,0 +1,227 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package defaultconstraints_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/defaultconstraints"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+)
+
+type DefaultConstraintsSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *defaultconstraints.API
+}
+
+var _ = gc.Suite(&DefaultConstraintsSuite{})
+
+var (
+	modelTag      = names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+	controllerTag = names.NewControllerTag("deadbeef-1bad-500d-9000-4b1d0d06f00d")
+)
+
+func (s *DefaultConstraintsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{
+		cons: constraints.MustParse("mem=4G"),
+		effective: state.EffectiveConstraints{
+			Value: constraints.MustParse("mem=4G cpu-cores=2"),
+			Sources: map[string]state.ConstraintsSource{
+				"mem":       state.ControllerConstraintsSource,
+				"cpu-cores": state.ApplicationConstraintsSource,
+			},
+		},
+	}
+	var err error
+	s.api, err = defaultconstraints.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DefaultConstraintsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := defaultconstraints.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *DefaultConstraintsSuite) TestDefaultConstraints(c *gc.C) {
+	results, err := s.api.DefaultConstraints(params.DefaultConstraintsScopes{
+		Scopes: []params.DefaultConstraintsScope{
+			{},
+			{CloudTag: "cloud-aws", CloudRegion: "us-east-1"},
+			{CloudTag: "aws"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DefaultConstraintsResult{
+		{Constraints: constraints.MustParse("mem=4G")},
+		{Constraints: constraints.MustParse("mem=4G")},
+		{Error: &params.Error{Message: `"aws" is not a valid cloud tag`}},
+	})
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DefaultConstraints", []interface{}{"", ""}},
+		{"DefaultConstraints", []interface{}{"aws", "us-east-1"}},
+	})
+}
+
+func (s *DefaultConstraintsSuite) TestDefaultConstraintsRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.DefaultConstraints(params.DefaultConstraintsScopes{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *DefaultConstraintsSuite) TestSetDefaultConstraints(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.NotFoundf(`region "nowhere" in cloud "aws"`))
+	results, err := s.api.SetDefaultConstraints(params.DefaultConstraintsArgs{
+		Args: []params.DefaultConstraintsArg{{
+			Constraints: constraints.MustParse("mem=4G"),
+		}, {
+			CloudTag:    "cloud-aws",
+			CloudRegion: "nowhere",
+			Constraints: constraints.MustParse("cpu-cores=2"),
+		}, {
+			CloudTag: "aws",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `region "nowhere" in cloud "aws" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"aws" is not a valid cloud tag`)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"SetDefaultConstraints", []interface{}{"", "", constraints.MustParse("mem=4G")}},
+		{"SetDefaultConstraints", []interface{}{"aws", "nowhere", constraints.MustParse("cpu-cores=2")}},
+	})
+}
+
+func (s *DefaultConstraintsSuite) TestSetDefaultConstraintsRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.SetDefaultConstraints(params.DefaultConstraintsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *DefaultConstraintsSuite) TestSetDefaultConstraintsModelWriterNotSuperuser(c *gc.C) {
+	// Users with write access to the model may read and resolve
+	// default constraints, but only controller superusers may
+	// set them.
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasWriteTag = names.NewUserTag("mary@local")
+	_, err := s.api.DefaultConstraints(params.DefaultConstraintsScopes{
+		Scopes: []params.DefaultConstraintsScope{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.ResolveConstraints(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.SetDefaultConstraints(params.DefaultConstraintsArgs{
+		Args: []params.DefaultConstraintsArg{{Constraints: constraints.MustParse("mem=4G")}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckCallNames(c, "DefaultConstraints", "ResolveConstraints")
+}
+
+func (s *DefaultConstraintsSuite) TestSetDefaultConstraintsBlocked(c *gc.C) {
+	s.backend.block = state.ChangeBlock
+	_, err := s.api.SetDefaultConstraints(params.DefaultConstraintsArgs{
+		Args: []params.DefaultConstraintsArg{{}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *DefaultConstraintsSuite) TestResolveConstraints(c *gc.C) {
+	results, err := s.api.ResolveConstraints(params.Entities{
+		Entities: []params.Entity{
+			{Tag: modelTag.String()},
+			{Tag: "application-mysql"},
+			{Tag: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d").String()},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := params.ResolvedConstraintsResult{
+		Constraints: constraints.MustParse("mem=4G cpu-cores=2"),
+		Sources: map[string]string{
+			"mem":       "controller",
+			"cpu-cores": "application",
+		},
+	}
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0], jc.DeepEquals, expected)
+	c.Assert(results.Results[1], jc.DeepEquals, expected)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `tag "machine-0" not valid`)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ResolveConstraints", []interface{}{""}},
+		{"ResolveConstraints", []interface{}{"mysql"}},
+	})
+}
+
+func (s *DefaultConstraintsSuite) TestResolveConstraintsRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.ResolveConstraints(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	stub      gitjujutesting.Stub
+	block     state.BlockType
+	cons      constraints.Value
+	effective state.EffectiveConstraints
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return modelTag
+}
+
+func (m *mockBackend) ControllerTag() names.ControllerTag {
+	return controllerTag
+}
+
+func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	if m.block == t {
+		return mockBlock{t: t}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (m *mockBackend) DefaultConstraints(cloud, region string) (constraints.Value, error) {
+	m.stub.AddCall("DefaultConstraints", cloud, region)
+	return m.cons, m.stub.NextErr()
+}
+
+func (m *mockBackend) SetDefaultConstraints(cloud, region string, cons constraints.Value) error {
+	m.stub.AddCall("SetDefaultConstraints", cloud, region, cons)
+	return m.stub.NextErr()
+}
+
+func (m *mockBackend) ResolveConstraints(application string) (state.EffectiveConstraints, error) {
+	m.stub.AddCall("ResolveConstraints", application)
+	return m.effective, m.stub.NextErr()
+}
+
+type mockBlock struct {
+	state.Block
+	t state.BlockType
+}
+
+func (m mockBlock) Type() state.BlockType { return m.t }
+
+func (m mockBlock) Message() string { return "blocked" }
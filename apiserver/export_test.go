@@ -32,6 +32,7 @@ var (
 	JSMimeType                   = jsMimeType
 	SpritePath                   = spritePath
 	HasPermission                = hasPermission
+	SSHTunnelDial                = &sshTunnelDial
 )
 
 func ServerMacaroon(srv *Server) (*macaroon.Macaroon, error) {
@@ -30,6 +30,7 @@ type FirewallerAPI struct {
 	*common.UnitsWatcher
 	*common.ModelMachinesWatcher
 	*common.InstanceIdGetter
+	*common.MaintenanceGetter
 	cloudspec.CloudSpecAPI
 
 	st            *state.State
@@ -93,6 +94,11 @@ func NewFirewallerAPI(
 		st,
 		accessMachine,
 	)
+	// InMaintenance() is supported for machines.
+	maintenanceGetter := common.NewMaintenanceGetter(
+		st,
+		accessMachine,
+	)
 
 	environConfigGetter := stateenvirons.EnvironConfigGetter{st}
 	cloudSpecAPI := cloudspec.NewCloudSpec(environConfigGetter.CloudSpec, common.AuthFuncForTag(st.ModelTag()))
@@ -104,6 +110,7 @@ func NewFirewallerAPI(
 		UnitsWatcher:         unitsWatcher,
 		ModelMachinesWatcher: machinesWatcher,
 		InstanceIdGetter:     instanceIdGetter,
+		MaintenanceGetter:    maintenanceGetter,
 		CloudSpecAPI:         cloudSpecAPI,
 		st:                   st,
 		resources:            resources,
@@ -160,6 +167,37 @@ func (f *FirewallerAPI) watchOneEnvironOpenedPorts(tag names.Tag) (string, []str
 	return "", nil, watcher.EnsureErr(watch)
 }
 
+// WatchFirewallRules returns a NotifyWatcher which triggers whenever
+// the model's firewall rules change.
+func (f *FirewallerAPI) WatchFirewallRules() (params.NotifyWatchResult, error) {
+	watch := f.st.WatchFirewallRules()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: f.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// FirewallRules returns the model's firewall rules.
+func (f *FirewallerAPI) FirewallRules() (params.ListFirewallRulesResults, error) {
+	rules, err := f.st.FirewallRules()
+	if err != nil {
+		return params.ListFirewallRulesResults{}, errors.Trace(err)
+	}
+	result := params.ListFirewallRulesResults{
+		Rules: make([]params.FirewallRule, len(rules)),
+	}
+	for i, rule := range rules {
+		result.Rules[i] = params.FirewallRule{
+			KnownService:   string(rule.WellKnownService),
+			WhitelistCIDRs: rule.WhitelistCIDRs,
+		}
+	}
+	return result, nil
+}
+
 // GetMachinePorts returns the port ranges opened on a machine for the specified
 // subnet as a map mapping port ranges to the tags of the units that opened
 // them.
@@ -287,6 +325,33 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedSourceCIDRs returns, for each given application, the source
+// CIDRs from which the application's opened ports may be reached when
+// it is exposed. An empty result means they may be reached from any
+// address.
+func (f *FirewallerAPI) GetExposedSourceCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = service.ExposedSourceCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
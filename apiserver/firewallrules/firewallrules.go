@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package firewallrules provides the facade through which clients
+// list and set the model-level firewall rules, which restrict the
+// sources of ingress for well-known services such as SSH.
+package firewallrules
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("FirewallRules", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	common.BlockGetter
+	ModelTag() names.ModelTag
+	SaveFirewallRule(network.FirewallRule) error
+	FirewallRules() ([]network.FirewallRule, error)
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// API is the endpoint which implements the FirewallRules facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+	check   *common.BlockChecker
+}
+
+// NewAPI creates a new instance of the FirewallRules facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+		check:   common.NewBlockChecker(backend),
+	}, nil
+}
+
+func (api *API) checkPermission(access description.Access) error {
+	ok, err := api.auth.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// SetFirewallRules stores the given firewall rules, replacing any
+// rules previously set for the same services. Only model admins may
+// set firewall rules.
+func (api *API) SetFirewallRules(args params.FirewallRuleArgs) (params.ErrorResults, error) {
+	var results params.ErrorResults
+	if err := api.checkPermission(description.AdminAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.backend.SaveFirewallRule(network.FirewallRule{
+			WellKnownService: network.WellKnownServiceType(arg.KnownService),
+			WhitelistCIDRs:   arg.WhitelistCIDRs,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListFirewallRules returns the firewall rules of the model.
+func (api *API) ListFirewallRules() (params.ListFirewallRulesResults, error) {
+	var result params.ListFirewallRulesResults
+	if err := api.checkPermission(description.ReadAccess); err != nil {
+		return result, errors.Trace(err)
+	}
+	rules, err := api.backend.FirewallRules()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Rules = make([]params.FirewallRule, len(rules))
+	for i, rule := range rules {
+		result.Rules[i] = params.FirewallRule{
+			KnownService:   string(rule.WellKnownService),
+			WhitelistCIDRs: rule.WhitelistCIDRs,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallrules_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/firewallrules"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+type FirewallRulesSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *firewallrules.API
+}
+
+var _ = gc.Suite(&FirewallRulesSuite{})
+
+func (s *FirewallRulesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{}
+	var err error
+	s.api, err = firewallrules.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FirewallRulesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := firewallrules.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *FirewallRulesSuite) TestSetFirewallRules(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.NotValidf(`well known service type "telnet"`))
+	results, err := s.api.SetFirewallRules(params.FirewallRuleArgs{
+		Args: []params.FirewallRule{{
+			KnownService:   "ssh",
+			WhitelistCIDRs: []string{"10.0.0.0/8"},
+		}, {
+			KnownService: "telnet",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `well known service type "telnet" not valid`)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"SaveFirewallRule", []interface{}{network.FirewallRule{
+			WellKnownService: network.SSHRule,
+			WhitelistCIDRs:   []string{"10.0.0.0/8"},
+		}}},
+		{"SaveFirewallRule", []interface{}{network.FirewallRule{
+			WellKnownService: "telnet",
+		}}},
+	})
+}
+
+func (s *FirewallRulesSuite) TestSetFirewallRulesRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.SetFirewallRules(params.FirewallRuleArgs{
+		Args: []params.FirewallRule{{KnownService: "ssh"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *FirewallRulesSuite) TestSetFirewallRulesRequiresAdminNotWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasWriteTag = names.NewUserTag("mary@local")
+	_, err := s.api.SetFirewallRules(params.FirewallRuleArgs{
+		Args: []params.FirewallRule{{KnownService: "ssh"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *FirewallRulesSuite) TestSetFirewallRulesBlocked(c *gc.C) {
+	s.backend.block = state.ChangeBlock
+	_, err := s.api.SetFirewallRules(params.FirewallRuleArgs{
+		Args: []params.FirewallRule{{KnownService: "ssh"}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *FirewallRulesSuite) TestListFirewallRules(c *gc.C) {
+	s.backend.rules = []network.FirewallRule{{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8", "192.168.0.0/16"},
+	}}
+	result, err := s.api.ListFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListFirewallRulesResults{
+		Rules: []params.FirewallRule{{
+			KnownService:   "ssh",
+			WhitelistCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		}},
+	})
+	s.backend.stub.CheckCallNames(c, "FirewallRules")
+}
+
+func (s *FirewallRulesSuite) TestListFirewallRulesRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.ListFirewallRules()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+
+	s.authorizer.HasReadTag = names.NewUserTag("mary@local")
+	_, err = s.api.ListFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCallNames(c, "FirewallRules")
+}
+
+func (s *FirewallRulesSuite) TestListFirewallRulesError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.New("boom"))
+	_, err := s.api.ListFirewallRules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	stub  gitjujutesting.Stub
+	block state.BlockType
+	rules []network.FirewallRule
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+}
+
+func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	if m.block == t {
+		return mockBlock{t: t}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (m *mockBackend) SaveFirewallRule(rule network.FirewallRule) error {
+	m.stub.AddCall("SaveFirewallRule", rule)
+	return m.stub.NextErr()
+}
+
+func (m *mockBackend) FirewallRules() ([]network.FirewallRule, error) {
+	m.stub.AddCall("FirewallRules")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.rules, nil
+}
+
+type mockBlock struct {
+	state.Block
+	t state.BlockType
+}
+
+func (m mockBlock) Type() state.BlockType { return m.t }
+
+func (m mockBlock) Message() string { return "blocked" }
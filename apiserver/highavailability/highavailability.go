@@ -23,11 +23,14 @@ var logger = loggo.GetLogger("juju.apiserver.highavailability")
 
 func init() {
 	common.RegisterStandardFacade("HighAvailability", 2, NewHighAvailabilityAPI)
+	// Version 3 adds RemoveControllerMachines.
+	common.RegisterStandardFacade("HighAvailability", 3, NewHighAvailabilityAPI)
 }
 
 // HighAvailability defines the methods on the highavailability API end point.
 type HighAvailability interface {
 	EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error)
+	RemoveControllerMachines(args params.RemoveControllersArgs) (params.ControllersChangeResult, error)
 }
 
 // HighAvailabilityAPI implements the HighAvailability interface and is the concrete
@@ -73,6 +76,49 @@ func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.C
 	return results, nil
 }
 
+// RemoveControllerMachines takes the given machines out of the controller
+// cluster. Voting machines are demoted first; the peergrouper completes
+// their removal once their vote has been taken away.
+func (api *HighAvailabilityAPI) RemoveControllerMachines(args params.RemoveControllersArgs) (params.ControllersChangeResult, error) {
+	if api.authorizer.AuthClient() {
+		admin, err := api.authorizer.HasPermission(description.SuperuserAccess, api.state.ControllerTag())
+		if err != nil && !errors.IsNotFound(err) {
+			return params.ControllersChangeResult{}, errors.Trace(err)
+		}
+		if !admin {
+			return params.ControllersChangeResult{}, common.ServerError(common.ErrPerm)
+		}
+	}
+	changes, err := removeControllerMachines(api.state, args.MachineTags)
+	return params.ControllersChangeResult{
+		Result: changes,
+		Error:  common.ServerError(err),
+	}, nil
+}
+
+func removeControllerMachines(st *state.State, machineTags []string) (params.ControllersChanges, error) {
+	if !st.IsController() {
+		return params.ControllersChanges{}, errors.New("unsupported with hosted models")
+	}
+	blockChecker := common.NewBlockChecker(st)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	ids := make([]string, len(machineTags))
+	for i, machineTag := range machineTags {
+		tag, err := names.ParseMachineTag(machineTag)
+		if err != nil {
+			return params.ControllersChanges{}, errors.Trace(err)
+		}
+		ids[i] = tag.Id()
+	}
+	changes, err := st.RemoveControllerMachines(ids...)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	return controllersChanges(changes), nil
+}
+
 // Convert machine ids to tags.
 func machineIdsToTags(ids ...string) []string {
 	var result []string
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
@@ -360,6 +361,63 @@ func (s *clientSuite) TestEnableHAErrors(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to create new controller machines: cannot reduce controller count")
 }
 
+func (s *clientSuite) removeControllerMachines(c *gc.C, machineTags ...string) (params.ControllersChanges, error) {
+	result, err := s.haServer.RemoveControllerMachines(params.RemoveControllersArgs{
+		MachineTags: machineTags,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = nil
+	if result.Error != nil {
+		err = result.Error
+	}
+	return result.Result, err
+}
+
+func (s *clientSuite) TestRemoveControllerMachines(c *gc.C) {
+	_, err := s.enableHA(c, 5, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.removeControllerMachines(c, "machine-3", "machine-4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Demoted, gc.DeepEquals, []string{"machine-3", "machine-4"})
+	c.Assert(result.Removed, gc.HasLen, 0)
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.VotingMachineIds, jc.SameContents, []string{"0", "1", "2"})
+	c.Assert(info.RemovingMachineIds, jc.SameContents, []string{"3", "4"})
+}
+
+func (s *clientSuite) TestRemoveControllerMachinesErrors(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.removeControllerMachines(c, "machine-2")
+	c.Assert(err, gc.ErrorMatches, `cannot remove controller machines: number of remaining voting controllers must be odd \(got 2\)`)
+
+	_, err = s.removeControllerMachines(c, "unit-foo-0")
+	c.Assert(err, gc.ErrorMatches, `"unit-foo-0" is not a valid machine tag`)
+}
+
+func (s *clientSuite) TestBlockRemoveControllerMachines(c *gc.C) {
+	_, err := s.enableHA(c, 5, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockRemoveObject(c, "TestBlockRemoveControllerMachines")
+
+	_, err = s.removeControllerMachines(c, "machine-3", "machine-4")
+	s.AssertBlocked(c, err, "TestBlockRemoveControllerMachines")
+}
+
+func (s *clientSuite) TestRemoveControllerMachinesPermissionDenied(c *gc.C) {
+	s.authoriser.Tag = names.NewUserTag("bob")
+	haServer, err := highavailability.NewHighAvailabilityAPI(s.State, s.resources, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = haServer.RemoveControllerMachines(params.RemoveControllersArgs{
+		MachineTags: []string{"machine-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *clientSuite) TestEnableHAHostedEnvErrors(c *gc.C) {
 	st2 := s.Factory.MakeModel(c, &factory.ModelParams{ConfigAttrs: coretesting.Attrs{"controller": false}})
 	defer st2.Close()
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hooktimings provides the facade through which clients query
+// the recent hook executions of units.
+package hooktimings
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("HookTimings", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	ModelTag() names.ModelTag
+	UnitHookTimings(unitName string) ([]state.HookTiming, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// UnitHookTimings is part of the Backend interface.
+func (s stateShim) UnitHookTimings(unitName string) ([]state.HookTiming, error) {
+	unit, err := s.Unit(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit.HookTimings()
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, auth)
+}
+
+// API is the endpoint which implements the HookTimings facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+}
+
+// NewAPI creates a new instance of the HookTimings facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+	}, nil
+}
+
+// HookTimings returns the most recent hook executions of each of the
+// given units, oldest first.
+func (api *API) HookTimings(args params.Entities) (params.HookTimingsResults, error) {
+	canRead, err := api.auth.HasPermission(description.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.HookTimingsResults{}, errors.Trace(err)
+	}
+	if !canRead {
+		return params.HookTimingsResults{}, common.ErrPerm
+	}
+	results := params.HookTimingsResults{
+		Results: make([]params.HookTimingsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		timings, err := api.backend.UnitHookTimings(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Timings = make([]params.HookTiming, len(timings))
+		for j, timing := range timings {
+			results.Results[i].Timings[j] = params.HookTiming{
+				Hook:     timing.Hook,
+				Queued:   timing.Queued,
+				Started:  timing.Started,
+				Finished: timing.Finished,
+				ExitCode: timing.ExitCode,
+			}
+		}
+	}
+	return results, nil
+}
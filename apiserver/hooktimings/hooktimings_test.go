@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktimings_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/hooktimings"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type HookTimingsSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *hooktimings.API
+}
+
+var _ = gc.Suite(&HookTimingsSuite{})
+
+var hookTime = time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+
+func (s *HookTimingsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{
+		timings: []state.HookTiming{{
+			Hook:     "install",
+			Queued:   hookTime,
+			Started:  hookTime.Add(time.Second),
+			Finished: hookTime.Add(time.Minute),
+		}, {
+			Hook:     "config-changed",
+			Queued:   hookTime.Add(time.Minute),
+			Started:  hookTime.Add(time.Minute),
+			Finished: hookTime.Add(2 * time.Minute),
+			ExitCode: 1,
+		}},
+	}
+	var err error
+	s.api, err = hooktimings.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HookTimingsSuite) TestHookTimingsReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasReadTag = names.NewUserTag("mary@local")
+	_, err := s.api.HookTimings(params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCallNames(c, "UnitHookTimings")
+}
+
+func (s *HookTimingsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := hooktimings.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *HookTimingsSuite) TestHookTimings(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.NotFoundf(`unit "mysql/1"`))
+	results, err := s.api.HookTimings(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-mysql-1"},
+		{Tag: "application-mysql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.HookTimingsResult{
+		Timings: []params.HookTiming{{
+			Hook:     "install",
+			Queued:   hookTime,
+			Started:  hookTime.Add(time.Second),
+			Finished: hookTime.Add(time.Minute),
+		}, {
+			Hook:     "config-changed",
+			Queued:   hookTime.Add(time.Minute),
+			Started:  hookTime.Add(time.Minute),
+			Finished: hookTime.Add(2 * time.Minute),
+			ExitCode: 1,
+		}},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit "mysql/1" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"application-mysql" is not a valid unit tag`)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"UnitHookTimings", []interface{}{"mysql/0"}},
+		{"UnitHookTimings", []interface{}{"mysql/1"}},
+	})
+}
+
+func (s *HookTimingsSuite) TestHookTimingsRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.HookTimings(params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	stub    gitjujutesting.Stub
+	timings []state.HookTiming
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+}
+
+func (m *mockBackend) UnitHookTimings(unitName string) ([]state.HookTiming, error) {
+	m.stub.AddCall("UnitHookTimings", unitName)
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.timings, nil
+}
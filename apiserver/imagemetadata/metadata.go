@@ -220,6 +220,11 @@ func (api *API) parseMetadataFromParams(p params.CloudImageMetadata, cfg *config
 	if p.Source == "" {
 		result.Source = "custom"
 	}
+	if result.Source == "custom" && p.Priority == 0 {
+		// Custom image metadata is preferred over that from any
+		// simplestreams data source when provisioning.
+		result.Priority = simplestreams.CUSTOM_CLOUD_DATA
+	}
 	if result.Arch == "" {
 		result.Arch = "amd64"
 	}
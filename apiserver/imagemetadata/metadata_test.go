@@ -9,6 +9,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/state/cloudimagemetadata"
 )
 
@@ -133,6 +134,32 @@ func (s *metadataSuite) TestSave(c *gc.C) {
 	s.assertCalls(c, "ControllerTag", environConfig, "Model", saveMetadata, saveMetadata)
 }
 
+func (s *metadataSuite) TestSaveCustomPriority(c *gc.C) {
+	var saved []cloudimagemetadata.Metadata
+	s.state.saveMetadata = func(m []cloudimagemetadata.Metadata) error {
+		saved = append(saved, m...)
+		return nil
+	}
+
+	errs, err := s.api.Save(params.MetadataSaveParams{
+		Metadata: []params.CloudImageMetadataList{{
+			Metadata: []params.CloudImageMetadata{
+				{ImageId: "custom"},
+				{ImageId: "prioritised", Priority: 30},
+				{ImageId: "public", Source: "default cloud images"},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results, gc.HasLen, 1)
+	c.Assert(errs.Results[0].Error, gc.IsNil)
+	c.Assert(saved, gc.HasLen, 3)
+	c.Check(saved[0].Source, gc.Equals, "custom")
+	c.Check(saved[0].Priority, gc.Equals, simplestreams.CUSTOM_CLOUD_DATA)
+	c.Check(saved[1].Priority, gc.Equals, 30)
+	c.Check(saved[2].Priority, gc.Equals, 0)
+}
+
 func (s *metadataSuite) TestDeleteEmpty(c *gc.C) {
 	errs, err := s.api.Delete(params.MetadataImageIds{})
 	c.Assert(err, jc.ErrorIsNil)
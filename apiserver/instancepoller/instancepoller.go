@@ -27,6 +27,7 @@ type InstancePollerAPI struct {
 	*common.ModelMachinesWatcher
 	*common.InstanceIdGetter
 	*common.StatusGetter
+	*common.MaintenanceGetter
 
 	st            StateInterface
 	resources     facade.Resources
@@ -88,6 +89,11 @@ func NewInstancePollerAPI(
 		sti,
 		accessMachine,
 	)
+	// InMaintenance() is supported for machines.
+	maintenanceGetter := common.NewMaintenanceGetter(
+		sti,
+		accessMachine,
+	)
 
 	return &InstancePollerAPI{
 		LifeGetter:           lifeGetter,
@@ -95,6 +101,7 @@ func NewInstancePollerAPI(
 		ModelMachinesWatcher: machinesWatcher,
 		InstanceIdGetter:     instanceIdGetter,
 		StatusGetter:         statusGetter,
+		MaintenanceGetter:    maintenanceGetter,
 		st:                   sti,
 		resources:            resources,
 		authorizer:           authorizer,
@@ -0,0 +1,138 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package instancetypes provides the facade through which clients
+// query the instance types offered by a model's provider, and refresh
+// the controller's cache of them.
+package instancetypes
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+func init() {
+	common.RegisterStandardFacade("InstanceTypes", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	common.InstanceTypesCache
+	ModelTag() names.ModelTag
+}
+
+// NewEnvironFunc is the type of a function that returns the model's
+// environ.
+type NewEnvironFunc func() (environs.Environ, error)
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	newEnviron := func() (environs.Environ, error) {
+		return environs.GetEnviron(stateenvirons.EnvironConfigGetter{st}, environs.New)
+	}
+	return NewAPI(st, newEnviron, clock.WallClock, auth)
+}
+
+// API is the endpoint which implements the InstanceTypes facade.
+type API struct {
+	backend    Backend
+	newEnviron NewEnvironFunc
+	clock      clock.Clock
+	auth       facade.Authorizer
+}
+
+// NewAPI creates a new instance of the InstanceTypes facade.
+func NewAPI(backend Backend, newEnviron NewEnvironFunc, clock clock.Clock, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		newEnviron: newEnviron,
+		clock:      clock,
+		auth:       authorizer,
+	}, nil
+}
+
+func (api *API) checkPermission(access description.Access) error {
+	ok, err := api.auth.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// InstanceTypes returns, for each of the given constraints, the
+// model's instance types that satisfy them. The instance types are
+// taken from the controller's cache, which is refreshed from the
+// provider only if it is empty or has expired.
+func (api *API) InstanceTypes(args params.InstanceTypesConstraints) (params.InstanceTypesResults, error) {
+	var results params.InstanceTypesResults
+	if err := api.checkPermission(description.ReadAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	env, err := api.newEnviron()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	info, err := common.CachedInstanceTypes(api.backend, env, api.clock)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	var region string
+	if hasRegion, ok := env.(simplestreams.HasRegion); ok {
+		if cloudSpec, err := hasRegion.Region(); err == nil {
+			region = cloudSpec.Region
+		}
+	}
+	results.Results = make([]params.InstanceTypesResult, len(args.Constraints))
+	for i, arg := range args.Constraints {
+		results.Results[i] = instanceTypesResult(info, region, arg)
+	}
+	return results, nil
+}
+
+func instanceTypesResult(info state.InstanceTypesInfo, region string, arg params.InstanceTypesConstraint) params.InstanceTypesResult {
+	result := params.InstanceTypesResult{Updated: info.Updated}
+	matching, err := instances.MatchingInstanceTypes(info.InstanceTypes, region, arg.Constraints)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
+	result.InstanceTypes = make([]params.InstanceType, len(matching))
+	for i, itype := range matching {
+		result.InstanceTypes[i] = common.InstanceTypeToParams(itype)
+	}
+	return result
+}
+
+// RefreshInstanceTypes fetches the model's instance types from the
+// provider, replacing those in the controller's cache. Only model
+// admins may refresh the instance types.
+func (api *API) RefreshInstanceTypes() (params.ErrorResult, error) {
+	var result params.ErrorResult
+	if err := api.checkPermission(description.AdminAccess); err != nil {
+		return result, errors.Trace(err)
+	}
+	env, err := api.newEnviron()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	_, err = common.RefreshInstanceTypes(api.backend, env)
+	result.Error = common.ServerError(err)
+	return result, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instancetypes_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/instancetypes"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type InstanceTypesSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	env        *mockEnviron
+	clock      *coretesting.Clock
+	authorizer apiservertesting.FakeAuthorizer
+	api        *instancetypes.API
+}
+
+var _ = gc.Suite(&InstanceTypesSuite{})
+
+var (
+	smallInstanceType = instances.InstanceType{
+		Name:     "small",
+		Arches:   []string{"amd64"},
+		CpuCores: 1,
+		Mem:      2048,
+		Cost:     10,
+	}
+	largeInstanceType = instances.InstanceType{
+		Name:     "large",
+		Arches:   []string{"amd64"},
+		CpuCores: 4,
+		Mem:      16384,
+		Cost:     40,
+	}
+)
+
+func (s *InstanceTypesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC))
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{
+		info: &state.InstanceTypesInfo{
+			InstanceTypes: []instances.InstanceType{smallInstanceType, largeInstanceType},
+			Updated:       s.clock.Now(),
+		},
+	}
+	s.env = &mockEnviron{
+		instanceTypes: []instances.InstanceType{largeInstanceType},
+	}
+	var err error
+	s.api, err = instancetypes.NewAPI(s.backend, s.newEnviron, s.clock, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *InstanceTypesSuite) newEnviron() (environs.Environ, error) {
+	s.backend.stub.AddCall("NewEnviron")
+	if err := s.backend.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.env, nil
+}
+
+func (s *InstanceTypesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := instancetypes.NewAPI(s.backend, s.newEnviron, s.clock, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *InstanceTypesSuite) TestInstanceTypes(c *gc.C) {
+	results, err := s.api.InstanceTypes(params.InstanceTypesConstraints{
+		Constraints: []params.InstanceTypesConstraint{{
+			Constraints: constraints.MustParse("mem=1G"),
+		}, {
+			Constraints: constraints.MustParse("cores=2"),
+		}, {
+			Constraints: constraints.MustParse("cores=8"),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Updated, gc.Equals, s.clock.Now())
+	c.Assert(results.Results[0].InstanceTypes, jc.DeepEquals, []params.InstanceType{
+		common.InstanceTypeToParams(smallInstanceType),
+		common.InstanceTypeToParams(largeInstanceType),
+	})
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[1].InstanceTypes, jc.DeepEquals, []params.InstanceType{
+		common.InstanceTypeToParams(largeInstanceType),
+	})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `no instance types in .* matching constraints "cores=8"`)
+
+	// The cached instance types are fresh, so the
+	// provider is not asked for its instance types.
+	s.backend.stub.CheckCallNames(c, "NewEnviron", "InstanceTypes")
+	c.Assert(s.env.calls, gc.Equals, 0)
+}
+
+func (s *InstanceTypesSuite) TestInstanceTypesRefreshesExpiredCache(c *gc.C) {
+	s.clock.Advance(common.InstanceTypesCacheExpiry)
+	results, err := s.api.InstanceTypes(params.InstanceTypesConstraints{
+		Constraints: []params.InstanceTypesConstraint{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].InstanceTypes, jc.DeepEquals, []params.InstanceType{
+		common.InstanceTypeToParams(largeInstanceType),
+	})
+	c.Assert(s.env.calls, gc.Equals, 1)
+}
+
+func (s *InstanceTypesSuite) TestInstanceTypesRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.InstanceTypes(params.InstanceTypesConstraints{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *InstanceTypesSuite) TestRefreshInstanceTypes(c *gc.C) {
+	result, err := s.api.RefreshInstanceTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(s.env.calls, gc.Equals, 1)
+	c.Assert(s.backend.info.InstanceTypes, jc.DeepEquals, []instances.InstanceType{largeInstanceType})
+	s.backend.stub.CheckCallNames(c, "NewEnviron", "SetInstanceTypes", "InstanceTypes")
+}
+
+func (s *InstanceTypesSuite) TestRefreshInstanceTypesError(c *gc.C) {
+	s.env.err = errors.New("boom")
+	result, err := s.api.RefreshInstanceTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "fetching instance types: boom")
+}
+
+func (s *InstanceTypesSuite) TestRefreshInstanceTypesRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.RefreshInstanceTypes()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *InstanceTypesSuite) TestRefreshInstanceTypesRequiresAdminNotWrite(c *gc.C) {
+	// Users with write access may query instance types, but not
+	// refresh the cache of them.
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasWriteTag = names.NewUserTag("mary@local")
+	_, err := s.api.InstanceTypes(params.InstanceTypesConstraints{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.RefreshInstanceTypes()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	stub gitjujutesting.Stub
+	info *state.InstanceTypesInfo
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+}
+
+func (m *mockBackend) InstanceTypes() (state.InstanceTypesInfo, error) {
+	m.stub.AddCall("InstanceTypes")
+	if err := m.stub.NextErr(); err != nil {
+		return state.InstanceTypesInfo{}, err
+	}
+	if m.info == nil {
+		return state.InstanceTypesInfo{}, errors.NotFoundf("instance types")
+	}
+	return *m.info, nil
+}
+
+func (m *mockBackend) SetInstanceTypes(instanceTypes []instances.InstanceType) error {
+	m.stub.AddCall("SetInstanceTypes", instanceTypes)
+	if err := m.stub.NextErr(); err != nil {
+		return err
+	}
+	m.info = &state.InstanceTypesInfo{InstanceTypes: instanceTypes}
+	return nil
+}
+
+type mockEnviron struct {
+	environs.Environ
+	instanceTypes []instances.InstanceType
+	err           error
+	calls         int
+}
+
+func (m *mockEnviron) InstanceTypes() ([]instances.InstanceType, error) {
+	m.calls++
+	return m.instanceTypes, m.err
+}
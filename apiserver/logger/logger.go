@@ -4,17 +4,22 @@
 package logger
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
 func init() {
 	common.RegisterStandardFacade("Logger", 1, NewLoggerAPI)
+	// Version 2 adds LogRotation, and includes the logging settings
+	// set for each agent in its LoggingConfig.
+	common.RegisterStandardFacade("Logger", 2, NewLoggerAPI)
 }
 
 // Logger defines the methods on the logger API end point.  Unfortunately, the
@@ -24,6 +29,7 @@ func init() {
 type Logger interface {
 	WatchLoggingConfig(args params.Entities) params.NotifyWatchResults
 	LoggingConfig(args params.Entities) params.StringResults
+	LogRotation(args params.Entities) params.LogRotationResults
 }
 
 // LoggerAPI implements the Logger interface and is the concrete
@@ -49,9 +55,10 @@ func NewLoggerAPI(
 }
 
 // WatchLoggingConfig starts a watcher to track changes to the logging config
-// for the agents specified..  Unfortunately the current infrastruture makes
-// watching parts of the config non-trivial, so currently any change to the
-// config will cause the watcher to notify the client.
+// and log rotation settings for the agents specified.  Unfortunately the
+// current infrastruture makes watching parts of the config non-trivial, so
+// currently any change to the model config will cause the watcher to notify
+// the client.
 func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatchResults {
 	result := make([]params.NotifyWatchResult, len(arg.Entities))
 	for i, entity := range arg.Entities {
@@ -60,31 +67,36 @@ func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatch
 			result[i].Error = common.ServerError(err)
 			continue
 		}
-		err = common.ErrPerm
-		if api.authorizer.AuthOwner(tag) {
-			watch := api.state.WatchForModelConfigChanges()
-			// Consume the initial event. Technically, API calls to Watch
-			// 'transmit' the initial event in the Watch response. But
-			// NotifyWatchers have no state to transmit.
-			if _, ok := <-watch.Changes(); ok {
-				result[i].NotifyWatcherId = api.resources.Register(watch)
-				err = nil
-			} else {
-				err = watcher.EnsureErr(watch)
-			}
+		if !api.authorizer.AuthOwner(tag) {
+			result[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		watch, err := api.state.WatchAgentLogging(tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		// Consume the initial event. Technically, API calls to Watch
+		// 'transmit' the initial event in the Watch response. But
+		// NotifyWatchers have no state to transmit.
+		if _, ok := <-watch.Changes(); ok {
+			result[i].NotifyWatcherId = api.resources.Register(watch)
+		} else {
+			err = watcher.EnsureErr(watch)
 		}
 		result[i].Error = common.ServerError(err)
 	}
 	return params.NotifyWatchResults{Results: result}
 }
 
-// LoggingConfig reports the logging configuration for the agents specified.
+// LoggingConfig reports the logging configuration for the agents specified:
+// the model's logging-config, followed by any set for the agent itself.
 func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 	if len(arg.Entities) == 0 {
 		return params.StringResults{}
 	}
 	results := make([]params.StringResult, len(arg.Entities))
-	config, configErr := api.state.ModelConfig()
+	modelConfig, configErr := api.state.ModelConfig()
 	for i, entity := range arg.Entities {
 		tag, err := names.ParseTag(entity.Tag)
 		if err != nil {
@@ -94,8 +106,7 @@ func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 		err = common.ErrPerm
 		if api.authorizer.AuthOwner(tag) {
 			if configErr == nil {
-				results[i].Result = config.LoggingConfig()
-				err = nil
+				results[i].Result, err = api.loggingConfig(modelConfig, tag)
 			} else {
 				err = configErr
 			}
@@ -104,3 +115,61 @@ func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 	}
 	return params.StringResults{Results: results}
 }
+
+// loggingConfig returns the logging configuration for the agent with
+// the given tag. Any set for the agent itself follows the model's, as
+// loggo applies the last level given for each module.
+func (api *LoggerAPI) loggingConfig(modelConfig *config.Config, tag names.Tag) (string, error) {
+	settings, err := api.state.AgentLogging(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	loggingConfig := modelConfig.LoggingConfig()
+	if settings.LoggingConfig != "" {
+		if loggingConfig != "" {
+			loggingConfig += ";"
+		}
+		loggingConfig += settings.LoggingConfig
+	}
+	return loggingConfig, nil
+}
+
+// LogRotation reports the log rotation settings for the agents specified.
+// Settings not set for an agent are taken from those set for the model;
+// those not set for either are left zero, for the agent's default.
+func (api *LoggerAPI) LogRotation(arg params.Entities) params.LogRotationResults {
+	results := make([]params.LogRotationResult, len(arg.Entities))
+	for i, entity := range arg.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !api.authorizer.AuthOwner(tag) {
+			results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		results[i].MaxLogSize, results[i].MaxLogBackups, err = api.logRotation(tag)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.LogRotationResults{Results: results}
+}
+
+func (api *LoggerAPI) logRotation(tag names.Tag) (maxSize, maxBackups int, err error) {
+	settings, err := api.state.AgentLogging(tag)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	modelSettings, err := api.state.AgentLogging(api.state.ModelTag())
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	maxSize, maxBackups = settings.MaxLogSize, settings.MaxLogBackups
+	if maxSize == 0 {
+		maxSize = modelSettings.MaxLogSize
+	}
+	if maxBackups == 0 {
+		maxBackups = modelSettings.MaxLogBackups
+	}
+	return maxSize, maxBackups, nil
+}
@@ -144,3 +144,73 @@ func (s *loggerSuite) TestLoggingConfigForAgent(c *gc.C) {
 	c.Assert(result.Error, gc.IsNil)
 	c.Assert(result.Result, gc.Equals, newLoggingConfig)
 }
+
+func (s *loggerSuite) TestLoggingConfigIncludesAgentSettings(c *gc.C) {
+	s.setLoggingConfig(c, "<root>=WARN;unit=INFO")
+	err := s.State.SetAgentLogging(s.rawMachine.Tag(), state.AgentLogging{LoggingConfig: "juju.worker=DEBUG"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results := s.logger.LoggingConfig(args)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.Equals, "<root>=WARN;unit=INFO;juju.worker=DEBUG")
+}
+
+func (s *loggerSuite) TestWatchLoggingConfigAgentSettings(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results := s.logger.WatchLoggingConfig(args)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	w := s.resources.Get(results.Results[0].NotifyWatcherId).(state.NotifyWatcher)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	err := s.State.SetAgentLogging(s.rawMachine.Tag(), state.AgentLogging{MaxLogSize: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
+func (s *loggerSuite) TestLogRotation(c *gc.C) {
+	err := s.State.SetAgentLogging(s.State.ModelTag(), state.AgentLogging{MaxLogSize: 50, MaxLogBackups: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAgentLogging(s.rawMachine.Tag(), state.AgentLogging{MaxLogSize: 100})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results := s.logger.LogRotation(args)
+	c.Assert(results, jc.DeepEquals, params.LogRotationResults{
+		Results: []params.LogRotationResult{{
+			MaxLogSize:    100,
+			MaxLogBackups: 3,
+		}},
+	})
+}
+
+func (s *loggerSuite) TestLogRotationNotSet(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results := s.logger.LogRotation(args)
+	c.Assert(results, jc.DeepEquals, params.LogRotationResults{
+		Results: []params.LogRotationResult{{}},
+	})
+}
+
+func (s *loggerSuite) TestLogRotationRefusesWrongAgent(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: "machine-12354"}},
+	}
+	results := s.logger.LogRotation(args)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
@@ -3,7 +3,10 @@
 
 package machinemanager
 
-import "github.com/juju/juju/state"
+import (
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+)
 
 type StateInterface stateInterface
 
@@ -16,3 +19,9 @@ func PatchState(p Patcher, st StateInterface) {
 		return st
 	})
 }
+
+func PatchEnviron(p Patcher, env environs.Environ) {
+	p.PatchValue(&getEnviron, func(*state.State) (environs.Environ, error) {
+		return env, nil
+	})
+}
@@ -7,18 +7,30 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	providercommon "github.com/juju/juju/provider/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 func init() {
 	common.RegisterStandardFacade("MachineManager", 2, NewMachineManagerAPI)
+	// Version 3 adds ProviderInstances, to list the instances which
+	// may be adopted into a model.
+	common.RegisterStandardFacade("MachineManager", 3, NewMachineManagerAPI)
+	// Version 4 adds ConsoleLogs.
+	common.RegisterStandardFacade("MachineManager", 4, NewMachineManagerAPI)
+	// Version 5 adds PrepareUpgradeSeries, CompleteUpgradeSeries and
+	// AbortUpgradeSeries.
+	common.RegisterStandardFacade("MachineManager", 5, NewMachineManagerAPI)
 }
 
 // MachineManagerAPI provides access to the MachineManager API facade.
@@ -26,12 +38,15 @@ type MachineManagerAPI struct {
 	st         stateInterface
 	authorizer facade.Authorizer
 	check      *common.BlockChecker
+	newEnviron func() (environs.Environ, error)
 }
 
 var getState = func(st *state.State) stateInterface {
 	return stateShim{st}
 }
 
+var getEnviron = stateenvirons.GetNewEnvironFunc(environs.New)
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(
 	st *state.State,
@@ -48,6 +63,9 @@ func NewMachineManagerAPI(
 		st:         s,
 		authorizer: authorizer,
 		check:      common.NewBlockChecker(s),
+		newEnviron: func() (environs.Environ, error) {
+			return getEnviron(st)
+		},
 	}, nil
 }
 
@@ -78,6 +96,364 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	return results, nil
 }
 
+// SetMachineMaintenance sets or clears maintenance mode on each of the
+// given machines. While a machine is in maintenance mode, the workers
+// which manage it leave it alone, so that it can be worked on by hand.
+func (mm *MachineManagerAPI) SetMachineMaintenance(args params.MachinesMaintenance) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Machines)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(description.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Machines {
+		err := mm.setOneMachineMaintenance(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) setOneMachineMaintenance(arg params.MachineMaintenance) error {
+	tag, err := names.ParseMachineTag(arg.MachineTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.SetMaintenance(arg.Maintenance)
+}
+
+// RotateAgentPasswords asks the agents of each of the given machines
+// and units to replace their API passwords the next time they connect.
+// Their current passwords remain valid until they do so.
+func (mm *MachineManagerAPI) RotateAgentPasswords(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+
+	isAdmin, err := mm.authorizer.HasPermission(description.AdminAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !isAdmin {
+		return results, common.ErrPerm
+	}
+
+	for i, entity := range args.Entities {
+		err := mm.rotateOneAgentPassword(entity.Tag)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) rotateOneAgentPassword(tagString string) error {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		m, err := mm.st.Machine(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return m.RequestPasswordRotation()
+	case names.UnitTag:
+		u, err := mm.st.Unit(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return u.RequestPasswordRotation()
+	}
+	return errors.NotValidf("agent tag %q", tagString)
+}
+
+// ReplaceMachines adds a substitute for each of the given machines,
+// with the same series, jobs and constraints, places the machine's
+// units (or new units of the same applications) on the substitute, and
+// retires the original machine.
+func (mm *MachineManagerAPI) ReplaceMachines(args params.Entities) (params.ReplaceMachineResults, error) {
+	results := params.ReplaceMachineResults{
+		Results: make([]params.ReplaceMachineResult, len(args.Entities)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(description.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	if err := mm.check.RemoveAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		result, err := mm.replaceOneMachine(entity.Tag)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) replaceOneMachine(tagString string) (params.ReplaceMachineResult, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return params.ReplaceMachineResult{}, errors.Trace(err)
+	}
+	machineId, units, err := mm.st.ReplaceMachine(tag.Id())
+	if err != nil {
+		return params.ReplaceMachineResult{}, errors.Trace(err)
+	}
+	return params.ReplaceMachineResult{
+		Machine: machineId,
+		Units:   units,
+	}, nil
+}
+
+// ProviderInstances returns the addresses and availability zones of
+// the given instances in the model's cloud, so that instances which
+// were not started by Juju may be adopted into the model as machines.
+func (mm *MachineManagerAPI) ProviderInstances(args params.ProviderInstances) (params.ProviderInstanceResults, error) {
+	results := params.ProviderInstanceResults{
+		Results: make([]params.ProviderInstanceResult, len(args.InstanceIds)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(description.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+	if len(args.InstanceIds) == 0 {
+		return results, nil
+	}
+
+	env, err := mm.newEnviron()
+	if err != nil {
+		return results, errors.Annotate(err, "getting environ")
+	}
+	ids := make([]instance.Id, len(args.InstanceIds))
+	for i, id := range args.InstanceIds {
+		ids[i] = instance.Id(id)
+	}
+	insts, err := env.Instances(ids)
+	if err != nil && err != environs.ErrNoInstances && err != environs.ErrPartialInstances {
+		return results, errors.Trace(err)
+	}
+	var zones []string
+	if zonedEnv, ok := env.(providercommon.ZonedEnviron); ok {
+		// The zones are informational only, so failing to
+		// get them does not prevent adoption.
+		zones, err = zonedEnv.InstanceAvailabilityZoneNames(ids)
+		if err != nil && err != environs.ErrPartialInstances {
+			zones = nil
+		}
+	}
+
+	for i, id := range ids {
+		result := &results.Results[i]
+		result.InstanceId = string(id)
+		if insts == nil || insts[i] == nil {
+			result.Error = common.ServerError(errors.NotFoundf("instance %q", id))
+			continue
+		}
+		addrs, err := insts[i].Addresses()
+		if err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		result.Addresses = params.FromNetworkAddresses(addrs...)
+		if i < len(zones) {
+			result.AvailabilityZone = zones[i]
+		}
+	}
+	return results, nil
+}
+
+// ConsoleLogs returns the console output recorded by the cloud for
+// each of the given machines' instances. This is most useful when a
+// machine's agent never starts, so that its logs are unavailable.
+func (mm *MachineManagerAPI) ConsoleLogs(args params.Entities) (params.StringResults, error) {
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(description.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+	if len(args.Entities) == 0 {
+		return results, nil
+	}
+
+	env, err := mm.newEnviron()
+	if err != nil {
+		return results, errors.Annotate(err, "getting environ")
+	}
+	consoleLogger, ok := env.(environs.ConsoleLogger)
+	for i, entity := range args.Entities {
+		if !ok {
+			err := errors.NotSupportedf("console logs in this cloud")
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		output, err := mm.oneConsoleLog(consoleLogger, entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = output
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) oneConsoleLog(consoleLogger environs.ConsoleLogger, tagString string) (string, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	m, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	instId, err := m.InstanceId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return consoleLogger.ConsoleLog(instId)
+}
+
+// PrepareUpgradeSeries starts a series upgrade of each of the given
+// machines, by asking the units on the machine to run their
+// pre-series-upgrade hooks.
+func (mm *MachineManagerAPI) PrepareUpgradeSeries(args params.UpgradeSeriesPrepareArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(description.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := mm.prepareOneUpgradeSeries(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) prepareOneUpgradeSeries(arg params.UpgradeSeriesPrepareArg) error {
+	tag, err := names.ParseMachineTag(arg.MachineTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.PrepareUpgradeSeries(arg.Series)
+}
+
+// CompleteUpgradeSeries records that the operating system of each of
+// the given machines has been upgraded, and asks the units on the
+// machine to run their post-series-upgrade hooks.
+func (mm *MachineManagerAPI) CompleteUpgradeSeries(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(description.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		err := mm.completeOneUpgradeSeries(entity.Tag)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) completeOneUpgradeSeries(tagString string) error {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.CompleteUpgradeSeries()
+}
+
+// AbortUpgradeSeries abandons the series upgrade of each of the given
+// machines, so long as its operating system has not yet been upgraded.
+func (mm *MachineManagerAPI) AbortUpgradeSeries(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(description.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		err := mm.abortOneUpgradeSeries(entity.Tag)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) abortOneUpgradeSeries(tagString string) error {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.AbortUpgradeSeries()
+}
+
 func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Machine, error) {
 	if p.ParentId != "" && p.ContainerType == "" {
 		return nil, fmt.Errorf("parent machine specified without container type")
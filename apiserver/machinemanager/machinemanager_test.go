@@ -4,8 +4,7 @@
 package machinemanager_test
 
 import (
-	"errors"
-
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -14,8 +13,10 @@ import (
 	"github.com/juju/juju/apiserver/machinemanager"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -118,10 +119,371 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestSetMachineMaintenance(c *gc.C) {
+	s.st.machine = &mockMachine{}
+	results, err := s.api.SetMachineMaintenance(params.MachinesMaintenance{
+		Machines: []params.MachineMaintenance{
+			{MachineTag: "machine-1", Maintenance: true},
+			{MachineTag: "unit-mysql-0", Maintenance: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.machineIds, jc.DeepEquals, []string{"1"})
+	c.Assert(s.st.machine.maintenance, jc.IsTrue)
+}
+
+func (s *MachineManagerSuite) TestSetMachineMaintenanceStateError(c *gc.C) {
+	s.st.err = errors.New("boom")
+	results, err := s.api.SetMachineMaintenance(params.MachinesMaintenance{
+		Machines: []params.MachineMaintenance{{MachineTag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: "boom"}},
+		},
+	})
+}
+
+func (s *MachineManagerSuite) TestSetMachineMaintenancePermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.api.SetMachineMaintenance(params.MachinesMaintenance{
+		Machines: []params.MachineMaintenance{{MachineTag: "machine-1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestRotateAgentPasswords(c *gc.C) {
+	s.st.machine = &mockMachine{}
+	s.st.unit = &mockUnit{}
+	results, err := s.api.RotateAgentPasswords(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-1"},
+			{Tag: "unit-mysql-0"},
+			{Tag: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Message: `agent tag "application-mysql" not valid`}},
+		},
+	})
+	c.Assert(s.st.machineIds, jc.DeepEquals, []string{"1"})
+	c.Assert(s.st.unitNames, jc.DeepEquals, []string{"mysql/0"})
+	c.Assert(s.st.machine.rotationRequested, jc.IsTrue)
+	c.Assert(s.st.unit.rotationRequested, jc.IsTrue)
+}
+
+func (s *MachineManagerSuite) TestRotateAgentPasswordsPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.api.RotateAgentPasswords(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestReplaceMachines(c *gc.C) {
+	results, err := s.api.ReplaceMachines(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-1"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ReplaceMachineResults{
+		Results: []params.ReplaceMachineResult{
+			{Machine: "2", Units: map[string]string{"mysql/0": "mysql/1"}},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.replaced, jc.DeepEquals, []string{"1"})
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesStateError(c *gc.C) {
+	s.st.err = errors.New("boom")
+	results, err := s.api.ReplaceMachines(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ReplaceMachineResults{
+		Results: []params.ReplaceMachineResult{
+			{Error: &params.Error{Message: "boom"}},
+		},
+	})
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.api.ReplaceMachines(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.replaced, gc.HasLen, 0)
+}
+
+func (s *MachineManagerSuite) TestProviderInstances(c *gc.C) {
+	env := &mockEnviron{
+		instances: map[instance.Id]*mockInstance{
+			"i-1": {id: "i-1", addrs: network.NewAddresses("10.0.0.1")},
+		},
+	}
+	machinemanager.PatchEnviron(s, env)
+	results, err := s.api.ProviderInstances(params.ProviderInstances{
+		InstanceIds: []string{"i-1", "i-2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ProviderInstanceResults{
+		Results: []params.ProviderInstanceResult{{
+			InstanceId: "i-1",
+			Addresses:  params.FromNetworkAddresses(network.NewAddress("10.0.0.1")),
+		}, {
+			InstanceId: "i-2",
+			Error:      &params.Error{Message: `instance "i-2" not found`, Code: params.CodeNotFound},
+		}},
+	})
+}
+
+func (s *MachineManagerSuite) TestProviderInstancesEnvironError(c *gc.C) {
+	env := &mockEnviron{err: errors.New("boom")}
+	machinemanager.PatchEnviron(s, env)
+	_, err := s.api.ProviderInstances(params.ProviderInstances{
+		InstanceIds: []string{"i-1"},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachineManagerSuite) TestProviderInstancesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.api.ProviderInstances(params.ProviderInstances{
+		InstanceIds: []string{"i-1"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestConsoleLogs(c *gc.C) {
+	s.st.machine = &mockMachine{instanceId: "i-1"}
+	env := &mockConsoleEnviron{output: map[instance.Id]string{"i-1": "Booting..."}}
+	machinemanager.PatchEnviron(s, env)
+	results, err := s.api.ConsoleLogs(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "Booting..."},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.machineIds, jc.DeepEquals, []string{"1"})
+}
+
+func (s *MachineManagerSuite) TestConsoleLogsNotProvisioned(c *gc.C) {
+	s.st.machine = &mockMachine{}
+	machinemanager.PatchEnviron(s, &mockConsoleEnviron{})
+	results, err := s.api.ConsoleLogs(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{{
+			Error: &params.Error{Message: "machine 1 not provisioned", Code: params.CodeNotProvisioned},
+		}},
+	})
+}
+
+func (s *MachineManagerSuite) TestConsoleLogsNotSupported(c *gc.C) {
+	machinemanager.PatchEnviron(s, &mockEnviron{})
+	results, err := s.api.ConsoleLogs(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{{
+			Error: &params.Error{Message: "console logs in this cloud not supported", Code: params.CodeNotSupported},
+		}},
+	})
+}
+
+func (s *MachineManagerSuite) TestConsoleLogsPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.api.ConsoleLogs(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestPrepareUpgradeSeries(c *gc.C) {
+	s.st.machine = &mockMachine{}
+	results, err := s.api.PrepareUpgradeSeries(params.UpgradeSeriesPrepareArgs{
+		Args: []params.UpgradeSeriesPrepareArg{
+			{MachineTag: "machine-1", Series: "xenial"},
+			{MachineTag: "unit-mysql-0", Series: "xenial"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.machineIds, jc.DeepEquals, []string{"1"})
+	c.Assert(s.st.machine.upgradeSeries, gc.Equals, "xenial")
+}
+
+func (s *MachineManagerSuite) TestPrepareUpgradeSeriesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.api.PrepareUpgradeSeries(params.UpgradeSeriesPrepareArgs{
+		Args: []params.UpgradeSeriesPrepareArg{{MachineTag: "machine-1", Series: "xenial"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestCompleteUpgradeSeries(c *gc.C) {
+	s.st.machine = &mockMachine{}
+	results, err := s.api.CompleteUpgradeSeries(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.machineIds, jc.DeepEquals, []string{"1"})
+	c.Assert(s.st.machine.upgradeSeriesCompleted, jc.IsTrue)
+}
+
+func (s *MachineManagerSuite) TestCompleteUpgradeSeriesStateError(c *gc.C) {
+	s.st.err = errors.New("boom")
+	results, err := s.api.CompleteUpgradeSeries(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: "boom"}},
+		},
+	})
+}
+
+func (s *MachineManagerSuite) TestAbortUpgradeSeries(c *gc.C) {
+	s.st.machine = &mockMachine{}
+	results, err := s.api.AbortUpgradeSeries(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.machineIds, jc.DeepEquals, []string{"1"})
+	c.Assert(s.st.machine.upgradeSeriesAborted, jc.IsTrue)
+}
+
+func (s *MachineManagerSuite) TestAbortUpgradeSeriesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.api.AbortUpgradeSeries(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type mockState struct {
-	calls    int
-	machines []state.MachineTemplate
-	err      error
+	calls      int
+	machines   []state.MachineTemplate
+	machineIds []string
+	machine    *mockMachine
+	unitNames  []string
+	unit       *mockUnit
+	replaced   []string
+	err        error
+}
+
+func (st *mockState) ReplaceMachine(id string) (string, map[string]string, error) {
+	st.replaced = append(st.replaced, id)
+	if st.err != nil {
+		return "", nil, st.err
+	}
+	return "2", map[string]string{"mysql/0": "mysql/1"}, nil
+}
+
+func (st *mockState) Unit(name string) (machinemanager.Unit, error) {
+	st.unitNames = append(st.unitNames, name)
+	if st.err != nil {
+		return nil, st.err
+	}
+	return st.unit, nil
+}
+
+type mockUnit struct {
+	rotationRequested bool
+}
+
+func (u *mockUnit) RequestPasswordRotation() error {
+	u.rotationRequested = true
+	return nil
+}
+
+func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
+	st.machineIds = append(st.machineIds, id)
+	if st.err != nil {
+		return nil, st.err
+	}
+	return st.machine, nil
+}
+
+type mockMachine struct {
+	instanceId             instance.Id
+	maintenance            bool
+	rotationRequested      bool
+	upgradeSeries          string
+	upgradeSeriesCompleted bool
+	upgradeSeriesAborted   bool
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine 1")
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) SetMaintenance(maintenance bool) error {
+	m.maintenance = maintenance
+	return nil
+}
+
+func (m *mockMachine) RequestPasswordRotation() error {
+	m.rotationRequested = true
+	return nil
+}
+
+func (m *mockMachine) PrepareUpgradeSeries(toSeries string) error {
+	m.upgradeSeries = toSeries
+	return nil
+}
+
+func (m *mockMachine) CompleteUpgradeSeries() error {
+	m.upgradeSeriesCompleted = true
+	return nil
+}
+
+func (m *mockMachine) AbortUpgradeSeries() error {
+	m.upgradeSeriesAborted = true
+	return nil
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -178,3 +540,48 @@ func (st *mockBlock) Message() string {
 func (st *mockBlock) ModelUUID() string {
 	return "uuid"
 }
+
+type mockEnviron struct {
+	environs.Environ
+	instances map[instance.Id]*mockInstance
+	err       error
+}
+
+func (env *mockEnviron) Instances(ids []instance.Id) ([]instance.Instance, error) {
+	if env.err != nil {
+		return nil, env.err
+	}
+	insts := make([]instance.Instance, len(ids))
+	var err error
+	for i, id := range ids {
+		if inst, ok := env.instances[id]; ok {
+			insts[i] = inst
+		} else {
+			err = environs.ErrPartialInstances
+		}
+	}
+	return insts, err
+}
+
+type mockConsoleEnviron struct {
+	mockEnviron
+	output map[instance.Id]string
+}
+
+func (env *mockConsoleEnviron) ConsoleLog(id instance.Id) (string, error) {
+	return env.output[id], nil
+}
+
+type mockInstance struct {
+	instance.Instance
+	id    instance.Id
+	addrs []network.Address
+}
+
+func (inst *mockInstance) Id() instance.Id {
+	return inst.id
+}
+
+func (inst *mockInstance) Addresses() ([]network.Address, error) {
+	return inst.addrs, nil
+}
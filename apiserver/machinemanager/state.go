@@ -18,6 +18,24 @@ type stateInterface interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	Machine(id string) (Machine, error)
+	Unit(name string) (Unit, error)
+	ReplaceMachine(id string) (machineId string, units map[string]string, err error)
+}
+
+// Machine describes the machine methods used by the facade.
+type Machine interface {
+	InstanceId() (instance.Id, error)
+	SetMaintenance(maintenance bool) error
+	RequestPasswordRotation() error
+	PrepareUpgradeSeries(toSeries string) error
+	CompleteUpgradeSeries() error
+	AbortUpgradeSeries() error
+}
+
+// Unit describes the unit methods used by the facade.
+type Unit interface {
+	RequestPasswordRotation() error
 }
 
 type stateShim struct {
@@ -50,3 +68,19 @@ func (s stateShim) AddMachineInsideNewMachine(template, parentTemplate state.Mac
 func (s stateShim) AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error) {
 	return s.State.AddMachineInsideMachine(template, parentId, containerType)
 }
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	return s.State.Machine(id)
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	return s.State.Unit(name)
+}
+
+func (s stateShim) ReplaceMachine(id string) (string, map[string]string, error) {
+	replacement, err := s.State.ReplaceMachine(id)
+	if err != nil {
+		return "", nil, err
+	}
+	return replacement.Machine.Id(), replacement.Units, nil
+}
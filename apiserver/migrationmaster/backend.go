@@ -16,4 +16,5 @@ type Backend interface {
 	WatchForModelMigration() state.NotifyWatcher
 	LatestModelMigration() (state.ModelMigration, error)
 	RemoveExportingModelDocs() error
+	ModelUsage() (state.ModelUsage, error)
 }
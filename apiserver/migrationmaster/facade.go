@@ -82,6 +82,11 @@ func (api *API) GetMigrationStatus() (params.MasterMigrationStatus, error) {
 		return empty, errors.Annotate(err, "retrieving phase")
 	}
 
+	reports, err := mig.GetMinionReports()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving minion reports")
+	}
+
 	return params.MasterMigrationStatus{
 		Spec: params.ModelMigrationSpec{
 			ModelTag: names.NewModelTag(mig.ModelUUID()).String(),
@@ -93,9 +98,11 @@ func (api *API) GetMigrationStatus() (params.MasterMigrationStatus, error) {
 				Password:      target.Password,
 			},
 		},
-		MigrationId:      mig.Id(),
-		Phase:            phase.String(),
-		PhaseChangedTime: mig.PhaseChangedTime(),
+		MigrationId:        mig.Id(),
+		Phase:              phase.String(),
+		PhaseChangedTime:   mig.PhaseChangedTime(),
+		ArtifactsRemaining: mig.ArtifactsRemaining(),
+		AgentsUnreported:   len(reports.Unknown),
 	}, nil
 }
 
@@ -129,6 +136,32 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 	return errors.Annotate(err, "failed to set status message")
 }
 
+// SetArtifactsRemaining records the number of charms and tools still
+// to be transferred to the target controller. This is reported by
+// GetMigrationStatus so that progress can be estimated.
+func (api *API) SetArtifactsRemaining(args params.SetMigrationArtifactsRemainingArgs) error {
+	mig, err := api.backend.LatestModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.SetArtifactsRemaining(args.Count)
+	return errors.Annotate(err, "failed to set remaining artifacts")
+}
+
+// ModelUsage returns the number of machines and units in the model
+// associated with the API connection, so that the target controller
+// can check it has the capacity to host the model.
+func (api *API) ModelUsage() (params.ModelUsage, error) {
+	usage, err := api.backend.ModelUsage()
+	if err != nil {
+		return params.ModelUsage{}, errors.Trace(err)
+	}
+	return params.ModelUsage{
+		Machines: usage.Machines,
+		Units:    usage.Units,
+	}, nil
+}
+
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
@@ -87,6 +87,14 @@ func (s *Suite) TestWatch(c *gc.C) {
 }
 
 func (s *Suite) TestGetMigrationStatus(c *gc.C) {
+	s.backend.migration.artifactsRemaining = 3
+	s.backend.migration.minionReports = &state.MinionReports{
+		Succeeded: []names.Tag{names.NewMachineTag("0")},
+		Unknown: []names.Tag{
+			names.NewMachineTag("1"),
+			names.NewUnitTag("foo/0"),
+		},
+	}
 	api := s.mustMakeAPI(c)
 
 	status, err := api.GetMigrationStatus()
@@ -102,9 +110,11 @@ func (s *Suite) TestGetMigrationStatus(c *gc.C) {
 				Password:      "secret",
 			},
 		},
-		MigrationId:      "id",
-		Phase:            "PRECHECK",
-		PhaseChangedTime: s.backend.migration.PhaseChangedTime(),
+		MigrationId:        "id",
+		Phase:              "PRECHECK",
+		PhaseChangedTime:   s.backend.migration.PhaseChangedTime(),
+		ArtifactsRemaining: 3,
+		AgentsUnreported:   2,
 	})
 }
 
@@ -164,6 +174,48 @@ func (s *Suite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
 }
 
+func (s *Suite) TestSetArtifactsRemaining(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	err := api.SetArtifactsRemaining(params.SetMigrationArtifactsRemainingArgs{Count: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.migration.artifactsRemaining, gc.Equals, 5)
+}
+
+func (s *Suite) TestSetArtifactsRemainingNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetArtifactsRemaining(params.SetMigrationArtifactsRemainingArgs{Count: 5})
+	c.Check(err, gc.ErrorMatches, "could not get migration: boom")
+}
+
+func (s *Suite) TestSetArtifactsRemainingError(c *gc.C) {
+	s.backend.migration.setArtifactsErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetArtifactsRemaining(params.SetMigrationArtifactsRemainingArgs{Count: 5})
+	c.Assert(err, gc.ErrorMatches, "failed to set remaining artifacts: blam")
+}
+
+func (s *Suite) TestModelUsage(c *gc.C) {
+	s.backend.usage = state.ModelUsage{Machines: 3, Units: 7}
+	api := s.mustMakeAPI(c)
+
+	usage, err := api.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage, gc.Equals, params.ModelUsage{Machines: 3, Units: 7})
+	s.stub.CheckCallNames(c, "ModelUsage")
+}
+
+func (s *Suite) TestModelUsageError(c *gc.C) {
+	s.backend.usageErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.ModelUsage()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *Suite) TestExport(c *gc.C) {
 	s.model.AddApplication(description.ApplicationArgs{
 		Tag:      names.NewApplicationTag("foo"),
@@ -293,6 +345,8 @@ type stubBackend struct {
 	removeErr error
 	migration *stubMigration
 	model     description.Model
+	usage     state.ModelUsage
+	usageErr  error
 }
 
 func (b *stubBackend) WatchForModelMigration() state.NotifyWatcher {
@@ -313,6 +367,11 @@ func (b *stubBackend) RemoveExportingModelDocs() error {
 	return b.removeErr
 }
 
+func (b *stubBackend) ModelUsage() (state.ModelUsage, error) {
+	b.stub.AddCall("ModelUsage")
+	return b.usage, b.usageErr
+}
+
 func (b *stubBackend) Export() (description.Model, error) {
 	b.stub.AddCall("Export")
 	return b.model, nil
@@ -321,12 +380,14 @@ func (b *stubBackend) Export() (description.Model, error) {
 type stubMigration struct {
 	state.ModelMigration
 
-	stub          *testing.Stub
-	setPhaseErr   error
-	phaseSet      coremigration.Phase
-	setMessageErr error
-	messageSet    string
-	minionReports *state.MinionReports
+	stub               *testing.Stub
+	setPhaseErr        error
+	phaseSet           coremigration.Phase
+	setMessageErr      error
+	messageSet         string
+	setArtifactsErr    error
+	artifactsRemaining int
+	minionReports      *state.MinionReports
 }
 
 func (m *stubMigration) Id() string {
@@ -375,6 +436,18 @@ func (m *stubMigration) SetStatusMessage(message string) error {
 	return nil
 }
 
+func (m *stubMigration) ArtifactsRemaining() int {
+	return m.artifactsRemaining
+}
+
+func (m *stubMigration) SetArtifactsRemaining(count int) error {
+	if m.setArtifactsErr != nil {
+		return m.setArtifactsErr
+	}
+	m.artifactsRemaining = count
+	return nil
+}
+
 func (m *stubMigration) WatchMinionReports() (state.NotifyWatcher, error) {
 	m.stub.AddCall("ModelMigration.WatchMinionReports")
 	return apiservertesting.NewFakeNotifyWatcher(), nil
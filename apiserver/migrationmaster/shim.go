@@ -4,6 +4,8 @@
 package migrationmaster
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/state"
 )
@@ -15,5 +17,19 @@ func newAPIForRegistration(
 	resources facade.Resources,
 	authorizer facade.Authorizer,
 ) (*API, error) {
-	return NewAPI(st, resources, authorizer)
+	return NewAPI(backendShim{st}, resources, authorizer)
+}
+
+// backendShim adds the methods of Backend which *state.State lacks.
+type backendShim struct {
+	*state.State
+}
+
+// ModelUsage implements Backend.
+func (s backendShim) ModelUsage() (state.ModelUsage, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return state.ModelUsage{}, errors.Trace(err)
+	}
+	return model.Usage()
 }
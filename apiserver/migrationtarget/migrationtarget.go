@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -57,6 +58,30 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 	return nil
 }
 
+// Prechecks checks that the controller has the capacity to host the
+// model to be migrated, before any of it is transferred. Each resource
+// whose configured soft limit would be exceeded by the model is
+// reported as a shortfall.
+func (api *API) Prechecks(args params.TargetPrechecksArgs) (params.TargetPrechecksResult, error) {
+	var result params.TargetPrechecksResult
+	shortfalls, err := migration.TargetPrecheck(api.state, coremigration.ModelUsage{
+		Machines: args.Usage.Machines,
+		Units:    args.Usage.Units,
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, shortfall := range shortfalls {
+		result.Shortfalls = append(result.Shortfalls, params.TargetCapacityShortfall{
+			Resource: shortfall.Resource,
+			Incoming: shortfall.Incoming,
+			Usage:    shortfall.Usage,
+			Limit:    shortfall.Limit,
+		})
+	}
+	return result, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
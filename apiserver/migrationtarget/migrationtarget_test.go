@@ -73,6 +73,15 @@ func (s *Suite) TestNotControllerAdmin(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
 }
 
+func (s *Suite) TestPrechecksNoLimits(c *gc.C) {
+	api := s.mustNewAPI(c)
+	result, err := api.Prechecks(params.TargetPrechecksArgs{
+		Usage: params.ModelUsage{Machines: 1000, Units: 5000},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Shortfalls, gc.HasLen, 0)
+}
+
 func (s *Suite) importModel(c *gc.C, api *migrationtarget.API) names.ModelTag {
 	uuid, bytes := s.makeExportedModel(c)
 	err := api.Import(params.SerializedModel{Bytes: bytes})
@@ -4,6 +4,11 @@
 package modelconfig
 
 import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
@@ -19,6 +24,20 @@ type Backend interface {
 	ModelConfigDefaultValues() (config.ConfigValues, error)
 	UpdateModelConfigDefaultValues(map[string]interface{}, []string) error
 	UpdateModelConfig(map[string]interface{}, []string, state.ValidateConfigFunc) error
+	CreateConfigSnapshot(name string, createdBy names.UserTag) error
+	AllConfigSnapshots() ([]ConfigSnapshot, error)
+	RestoreConfigSnapshot(name string) ([]string, error)
+	RemoveConfigSnapshot(name string) error
+}
+
+// ConfigSnapshot contains the state.ConfigSnapshot methods used in
+// this package.
+type ConfigSnapshot interface {
+	Name() string
+	Revision() int
+	Created() time.Time
+	CreatedBy() names.UserTag
+	ApplicationConfig() map[string]charm.Settings
 }
 
 type stateShim struct {
@@ -29,3 +48,20 @@ type stateShim struct {
 func NewStateBackend(st *state.State) Backend {
 	return stateShim{st}
 }
+
+func (s stateShim) CreateConfigSnapshot(name string, createdBy names.UserTag) error {
+	_, err := s.State.CreateConfigSnapshot(name, createdBy)
+	return errors.Trace(err)
+}
+
+func (s stateShim) AllConfigSnapshots() ([]ConfigSnapshot, error) {
+	snapshots, err := s.State.AllConfigSnapshots()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ConfigSnapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		result[i] = snapshot
+	}
+	return result, nil
+}
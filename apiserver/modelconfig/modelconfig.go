@@ -4,7 +4,10 @@
 package modelconfig
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -54,6 +57,17 @@ func (c *ModelConfigAPI) checkCanWrite() error {
 	return nil
 }
 
+func (c *ModelConfigAPI) checkCanRead() error {
+	canRead, err := c.auth.HasPermission(description.ReadAccess, c.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // ModelGet implements the server-side part of the
 // get-model-config CLI command.
 func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
@@ -215,3 +229,78 @@ func (c *ModelConfigAPI) UnsetModelDefaults(args params.UnsetModelDefaults) (par
 	}
 	return results, nil
 }
+
+// CreateConfigSnapshot records the current config of the model and of
+// its applications as a snapshot with the given name, so that it may
+// later be restored.
+func (c *ModelConfigAPI) CreateConfigSnapshot(args params.ConfigSnapshotArg) error {
+	if err := c.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	user, ok := c.auth.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	return c.backend.CreateConfigSnapshot(args.Name, user)
+}
+
+// ConfigSnapshots returns the config snapshots of the model, ordered
+// by revision.
+func (c *ModelConfigAPI) ConfigSnapshots() (params.ConfigSnapshotsResult, error) {
+	result := params.ConfigSnapshotsResult{}
+	if err := c.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	snapshots, err := c.backend.AllConfigSnapshots()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Snapshots = make([]params.ConfigSnapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		var applications []string
+		for name := range snapshot.ApplicationConfig() {
+			applications = append(applications, name)
+		}
+		sort.Strings(applications)
+		result.Snapshots[i] = params.ConfigSnapshot{
+			Name:         snapshot.Name(),
+			Revision:     snapshot.Revision(),
+			Created:      snapshot.Created(),
+			CreatedBy:    snapshot.CreatedBy().Id(),
+			Applications: applications,
+		}
+	}
+	return result, nil
+}
+
+// RestoreConfigSnapshot restores the config of the model and of its
+// applications to the values recorded in the named snapshot.
+func (c *ModelConfigAPI) RestoreConfigSnapshot(args params.ConfigSnapshotArg) (params.RestoreConfigSnapshotResult, error) {
+	result := params.RestoreConfigSnapshotResult{}
+	if err := c.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	skipped, err := c.backend.RestoreConfigSnapshot(args.Name)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Skipped = skipped
+	return result, nil
+}
+
+// RemoveConfigSnapshot removes the named config snapshot.
+func (c *ModelConfigAPI) RemoveConfigSnapshot(args params.ConfigSnapshotArg) error {
+	if err := c.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.check.RemoveAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.backend.RemoveConfigSnapshot(args.Name)
+}
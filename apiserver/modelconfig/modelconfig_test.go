@@ -4,12 +4,16 @@
 package modelconfig_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -225,12 +229,123 @@ func (s *modelconfigSuite) TestUnsetModelDefaultsMissing(c *gc.C) {
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestCreateConfigSnapshot(c *gc.C) {
+	err := s.api.CreateConfigSnapshot(params.ConfigSnapshotArg{Name: "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CreateConfigSnapshot", []interface{}{"foo", names.NewUserTag("bruce@local")}},
+	})
+}
+
+func (s *modelconfigSuite) TestBlockCreateConfigSnapshot(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockCreateConfigSnapshot")
+	err := s.api.CreateConfigSnapshot(params.ConfigSnapshotArg{Name: "foo"})
+	s.assertBlocked(c, err, "TestBlockCreateConfigSnapshot")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *modelconfigSuite) TestCreateConfigSnapshotReadOnlyUser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	err := s.api.CreateConfigSnapshot(params.ConfigSnapshotArg{Name: "foo"})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *modelconfigSuite) TestConfigSnapshots(c *gc.C) {
+	created := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.backend.snapshots = []modelconfig.ConfigSnapshot{
+		&mockConfigSnapshot{
+			name:      "one",
+			revision:  1,
+			created:   created,
+			createdBy: names.NewUserTag("bruce@local"),
+			appConfig: map[string]charm.Settings{
+				"wordpress": {"title": "x"},
+				"mysql":     {},
+			},
+		},
+		&mockConfigSnapshot{
+			name:      "two",
+			revision:  2,
+			created:   created.Add(time.Hour),
+			createdBy: names.NewUserTag("alice@local"),
+		},
+	}
+	result, err := s.api.ConfigSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ConfigSnapshotsResult{
+		Snapshots: []params.ConfigSnapshot{{
+			Name:         "one",
+			Revision:     1,
+			Created:      created,
+			CreatedBy:    "bruce@local",
+			Applications: []string{"mysql", "wordpress"},
+		}, {
+			Name:      "two",
+			Revision:  2,
+			Created:   created.Add(time.Hour),
+			CreatedBy: "alice@local",
+		}},
+	})
+}
+
+func (s *modelconfigSuite) TestConfigSnapshotsReadOnlyUser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	s.authorizer.HasReadTag = names.NewUserTag("read")
+	_, err := s.api.ConfigSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCallNames(c, "AllConfigSnapshots")
+}
+
+func (s *modelconfigSuite) TestConfigSnapshotsNoAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.api.ConfigSnapshots()
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *modelconfigSuite) TestRestoreConfigSnapshot(c *gc.C) {
+	s.backend.skipped = []string{"mysql"}
+	result, err := s.api.RestoreConfigSnapshot(params.ConfigSnapshotArg{Name: "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RestoreConfigSnapshotResult{
+		Skipped: []string{"mysql"},
+	})
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RestoreConfigSnapshot", []interface{}{"foo"}},
+	})
+}
+
+func (s *modelconfigSuite) TestRestoreConfigSnapshotError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.NotFoundf("config snapshot %q", "foo"))
+	_, err := s.api.RestoreConfigSnapshot(params.ConfigSnapshotArg{Name: "foo"})
+	c.Assert(err, gc.ErrorMatches, `config snapshot "foo" not found`)
+}
+
+func (s *modelconfigSuite) TestBlockRestoreConfigSnapshot(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockRestoreConfigSnapshot")
+	_, err := s.api.RestoreConfigSnapshot(params.ConfigSnapshotArg{Name: "foo"})
+	s.assertBlocked(c, err, "TestBlockRestoreConfigSnapshot")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *modelconfigSuite) TestRemoveConfigSnapshot(c *gc.C) {
+	err := s.api.RemoveConfigSnapshot(params.ConfigSnapshotArg{Name: "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RemoveConfigSnapshot", []interface{}{"foo"}},
+	})
+}
+
 type mockBackend struct {
 	cfg         config.ConfigValues
 	cfgDefaults config.ConfigValues
 	old         *config.Config
 	b           state.BlockType
 	msg         string
+	stub        gitjujutesting.Stub
+	snapshots   []modelconfig.ConfigSnapshot
+	skipped     []string
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
@@ -294,3 +409,44 @@ func (m mockBlock) Type() state.BlockType { return m.t }
 func (m mockBlock) Message() string { return m.m }
 
 func (m mockBlock) ModelUUID() string { return "" }
+
+func (m *mockBackend) CreateConfigSnapshot(name string, createdBy names.UserTag) error {
+	m.stub.AddCall("CreateConfigSnapshot", name, createdBy)
+	return m.stub.NextErr()
+}
+
+func (m *mockBackend) AllConfigSnapshots() ([]modelconfig.ConfigSnapshot, error) {
+	m.stub.AddCall("AllConfigSnapshots")
+	return m.snapshots, m.stub.NextErr()
+}
+
+func (m *mockBackend) RestoreConfigSnapshot(name string) ([]string, error) {
+	m.stub.AddCall("RestoreConfigSnapshot", name)
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.skipped, nil
+}
+
+func (m *mockBackend) RemoveConfigSnapshot(name string) error {
+	m.stub.AddCall("RemoveConfigSnapshot", name)
+	return m.stub.NextErr()
+}
+
+type mockConfigSnapshot struct {
+	name      string
+	revision  int
+	created   time.Time
+	createdBy names.UserTag
+	appConfig map[string]charm.Settings
+}
+
+func (m *mockConfigSnapshot) Name() string { return m.name }
+
+func (m *mockConfigSnapshot) Revision() int { return m.revision }
+
+func (m *mockConfigSnapshot) Created() time.Time { return m.created }
+
+func (m *mockConfigSnapshot) CreatedBy() names.UserTag { return m.createdBy }
+
+func (m *mockConfigSnapshot) ApplicationConfig() map[string]charm.Settings { return m.appConfig }
@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelevents provides the facade through which clients query
+// the timeline of high-level events in a model.
+package modelevents
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("ModelEvents", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ModelEvents(state.ModelEventFilter) ([]state.ModelEvent, error)
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// API is the endpoint which implements the ModelEvents facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+}
+
+// NewAPI creates a new instance of the ModelEvents facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+	}, nil
+}
+
+// ModelEvents returns the events in the model's timeline selected by
+// the given filter, oldest first.
+func (api *API) ModelEvents(args params.ModelEventsFilter) (params.ModelEventsResult, error) {
+	var result params.ModelEventsResult
+	canRead, err := api.auth.HasPermission(description.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !canRead {
+		return result, common.ErrPerm
+	}
+	filter := state.ModelEventFilter{
+		Entity: args.Entity,
+		Limit:  args.Limit,
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return result, errors.NotValidf("time range ending before it starts")
+	}
+	for _, kind := range args.Kinds {
+		filter.Kinds = append(filter.Kinds, state.ModelEventKind(kind))
+	}
+	events, err := api.backend.ModelEvents(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Events = make([]params.ModelEvent, len(events))
+	for i, event := range events {
+		result.Events[i] = params.ModelEvent{
+			Kind:    string(event.Kind),
+			Actor:   event.Actor,
+			Entity:  event.Entity,
+			Message: event.Message,
+			Time:    event.Time,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type ModelEventsSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *modelevents.API
+}
+
+var _ = gc.Suite(&ModelEventsSuite{})
+
+var eventTime = time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *ModelEventsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{
+		events: []state.ModelEvent{{
+			Kind:    state.DeployEvent,
+			Actor:   "user-bruce@local",
+			Entity:  "application-mysql",
+			Message: "deployed cs:mysql-1",
+			Time:    eventTime,
+		}},
+	}
+	var err error
+	s.api, err = modelevents.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelEventsSuite) TestModelEventsReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasReadTag = names.NewUserTag("mary@local")
+	_, err := s.api.ModelEvents(params.ModelEventsFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCallNames(c, "ModelEvents")
+}
+
+func (s *ModelEventsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelevents.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ModelEventsSuite) TestModelEvents(c *gc.C) {
+	from := eventTime.Add(-time.Hour)
+	result, err := s.api.ModelEvents(params.ModelEventsFilter{
+		From:   &from,
+		Kinds:  []string{"deploy", "add-unit"},
+		Entity: "application-mysql",
+		Limit:  10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Events, jc.DeepEquals, []params.ModelEvent{{
+		Kind:    "deploy",
+		Actor:   "user-bruce@local",
+		Entity:  "application-mysql",
+		Message: "deployed cs:mysql-1",
+		Time:    eventTime,
+	}})
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{{
+		"ModelEvents", []interface{}{state.ModelEventFilter{
+			From:   from,
+			Kinds:  []state.ModelEventKind{state.DeployEvent, state.AddUnitEvent},
+			Entity: "application-mysql",
+			Limit:  10,
+		}},
+	}})
+}
+
+func (s *ModelEventsSuite) TestModelEventsInvalidRange(c *gc.C) {
+	from := eventTime
+	to := eventTime.Add(-time.Hour)
+	_, err := s.api.ModelEvents(params.ModelEventsFilter{From: &from, To: &to})
+	c.Assert(err, gc.ErrorMatches, "time range ending before it starts not valid")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *ModelEventsSuite) TestModelEventsRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.ModelEvents(params.ModelEventsFilter{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	stub   gitjujutesting.Stub
+	events []state.ModelEvent
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+}
+
+func (m *mockBackend) ModelEvents(filter state.ModelEventFilter) ([]state.ModelEvent, error) {
+	m.stub.AddCall("ModelEvents", filter)
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.events, nil
+}
@@ -11,6 +11,7 @@ import (
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/series"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
 )
 
 type modelInfoSuite struct {
@@ -89,6 +91,12 @@ func (s *modelInfoSuite) SetUpTest(c *gc.C) {
 			access:      description.ReadAccess,
 		}},
 	}
+	s.st.machines = []common.HealthMachine{
+		&mockMachine{alive: true, agentVersion: "2.0.1"},
+		&mockMachine{alive: false, agentVersion: "2.0.0"},
+		&mockMachine{alive: true, agentVersion: "2.0.1"},
+		&mockMachine{alive: false},
+	}
 	var err error
 	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, nil, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
@@ -133,6 +141,12 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 			LastConnection: &time.Time{},
 			Access:         params.ModelReadAccess,
 		}},
+		Health: &params.ModelHealth{
+			Machines:       4,
+			MachinesDown:   2,
+			AgentVersions:  []string{"2.0.0", "2.0.1"},
+			LastConnection: &time.Time{},
+		},
 	})
 	s.st.CheckCalls(c, []gitjujutesting.StubCall{
 		{"ControllerTag", nil},
@@ -143,6 +157,7 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		{"LastModelConnection", []interface{}{names.NewUserTag("admin")}},
 		{"LastModelConnection", []interface{}{names.NewLocalUserTag("bob")}},
 		{"LastModelConnection", []interface{}{names.NewLocalUserTag("charlotte")}},
+		{"AllMachines", nil},
 		{"Close", nil},
 	})
 	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
@@ -173,6 +188,31 @@ func (s *modelInfoSuite) TestModelInfoNonOwner(c *gc.C) {
 	c.Assert(info.Users[0].UserName, gc.Equals, "charlotte@local")
 }
 
+func (s *modelInfoSuite) TestModelInfoHealthNoMachines(c *gc.C) {
+	s.st.machines = nil
+	s.st.SetErrors(
+		nil, // ForModel
+		nil, // Model
+		nil, // ControllerConfig
+		state.NeverConnectedError("admin"),
+		state.NeverConnectedError("bob"),
+		state.NeverConnectedError("charlotte"),
+	)
+	info := s.getModelInfo(c)
+	c.Assert(info.Health, jc.DeepEquals, &params.ModelHealth{})
+}
+
+func (s *modelInfoSuite) TestModelInfoErrorAllMachines(c *gc.C) {
+	s.st.SetErrors(
+		nil,           // ForModel
+		nil,           // Model
+		nil,           // ControllerConfig
+		nil, nil, nil, // LastModelConnection
+		errors.New("no machines for you"),
+	)
+	s.testModelInfoError(c, coretesting.ModelTag.String(), `no machines for you`)
+}
+
 func (s *modelInfoSuite) getModelInfo(c *gc.C) params.ModelInfo {
 	results, err := s.modelmanager.ModelInfo(params.Entities{
 		Entities: []params.Entity{{
@@ -240,6 +280,7 @@ type mockState struct {
 	controllerModel *mockModel
 	users           []description.UserAccess
 	cred            cloud.Credential
+	machines        []common.HealthMachine
 }
 
 type fakeModelDescription struct {
@@ -386,6 +427,11 @@ func (st *mockState) LastModelConnection(user names.UserTag) (time.Time, error)
 	return time.Time{}, st.NextErr()
 }
 
+func (st *mockState) AllMachines() ([]common.HealthMachine, error) {
+	st.MethodCall(st, "AllMachines")
+	return st.machines, st.NextErr()
+}
+
 func (st *mockState) RemoveUserAccess(subject names.UserTag, target names.Tag) error {
 	st.MethodCall(st, "RemoveUserAccess", subject, target)
 	return st.NextErr()
@@ -398,12 +444,15 @@ func (st *mockState) SetUserAccess(subject names.UserTag, target names.Tag, acce
 
 type mockModel struct {
 	gitjujutesting.Stub
-	owner  names.UserTag
-	life   state.Life
-	tag    names.ModelTag
-	status status.StatusInfo
-	cfg    *config.Config
-	users  []*mockModelUser
+	owner    names.UserTag
+	life     state.Life
+	tag      names.ModelTag
+	status   status.StatusInfo
+	cfg      *config.Config
+	users    []*mockModelUser
+	limits   state.ModelLimits
+	usage    state.ModelUsage
+	teardown state.ModelTeardownProgress
 }
 
 func (m *mockModel) Config() (*config.Config, error) {
@@ -481,6 +530,45 @@ func (m *mockModel) DestroyIncludingHosted() error {
 	return m.NextErr()
 }
 
+func (m *mockModel) Limits() state.ModelLimits {
+	m.MethodCall(m, "Limits")
+	m.PopNoErr()
+	return m.limits
+}
+
+func (m *mockModel) Usage() (state.ModelUsage, error) {
+	m.MethodCall(m, "Usage")
+	return m.usage, m.NextErr()
+}
+
+func (m *mockModel) SetLimits(limits state.ModelLimits) error {
+	m.MethodCall(m, "SetLimits", limits)
+	return m.NextErr()
+}
+
+func (m *mockModel) TeardownProgress() (state.ModelTeardownProgress, error) {
+	m.MethodCall(m, "TeardownProgress")
+	return m.teardown, m.NextErr()
+}
+
+type mockMachine struct {
+	alive        bool
+	agentVersion string
+}
+
+func (m *mockMachine) AgentPresence() (bool, error) {
+	return m.alive, nil
+}
+
+func (m *mockMachine) AgentTools() (*tools.Tools, error) {
+	if m.agentVersion == "" {
+		return nil, errors.NotFoundf("agent tools for machine")
+	}
+	return &tools.Tools{
+		Version: version.MustParseBinary(m.agentVersion + "-xenial-amd64"),
+	}, nil
+}
+
 type mockModelUser struct {
 	gitjujutesting.Stub
 	userName       string
@@ -37,6 +37,9 @@ var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
 func init() {
 	common.RegisterStandardFacade("ModelManager", 2, newFacade)
+	// Version 3 allows CreateModel to create models in clouds
+	// other than the controller's.
+	common.RegisterStandardFacade("ModelManager", 3, newFacade)
 }
 
 // ModelManager defines the methods on the modelmanager API endpoint.
@@ -195,10 +198,18 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 	}
 
 	cloudName := controllerModel.Cloud()
+	if args.CloudTag != "" {
+		cloudTag, err := names.ParseCloudTag(args.CloudTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		cloudName = cloudTag.Id()
+	}
 	cloud, err := mm.state.Cloud(cloudName)
 	if err != nil {
 		return result, errors.Annotate(err, "getting cloud definition")
 	}
+	controllerCloud := cloudName == controllerModel.Cloud()
 
 	var cloudCredentialTag names.CloudCredentialTag
 	if args.CloudCredentialTag != "" {
@@ -208,7 +219,7 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 			return result, errors.Trace(err)
 		}
 	} else {
-		if ownerTag.Canonical() == controllerModel.Owner().Canonical() {
+		if controllerCloud && ownerTag.Canonical() == controllerModel.Owner().Canonical() {
 			cloudCredentialTag, _ = controllerModel.CloudCredential()
 		} else {
 			// TODO(axw) check if the user has one and only one
@@ -231,7 +242,12 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 
 	cloudRegionName := args.CloudRegion
 	if cloudRegionName == "" {
-		cloudRegionName = controllerModel.CloudRegion()
+		if controllerCloud {
+			cloudRegionName = controllerModel.CloudRegion()
+		} else if len(cloud.Regions) > 0 {
+			// The first region of a cloud is its default.
+			cloudRegionName = cloud.Regions[0].Name
+		}
 	}
 
 	var credential *jujucloud.Credential
@@ -544,18 +560,26 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 	}
 
 	authorizedOwner := m.authCheck(owner) == nil
+	var lastConnection *time.Time
 	for _, user := range users {
+		userInfo, err := common.ModelUserInfo(user, st)
+		if err != nil {
+			return params.ModelInfo{}, errors.Trace(err)
+		}
+		// The model was last used when any of its users last
+		// connected, whether or not the authenticated user may
+		// know about that user.
+		if userInfo.LastConnection != nil {
+			if lastConnection == nil || userInfo.LastConnection.After(*lastConnection) {
+				lastConnection = userInfo.LastConnection
+			}
+		}
 		if !authorizedOwner && m.authCheck(user.UserTag) != nil {
 			// The authenticated user is neither the owner
 			// nor administrator, nor the model user, so
 			// has no business knowing about the model user.
 			continue
 		}
-
-		userInfo, err := common.ModelUserInfo(user, st)
-		if err != nil {
-			return params.ModelInfo{}, errors.Trace(err)
-		}
 		info.Users = append(info.Users, userInfo)
 	}
 
@@ -565,9 +589,159 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		return params.ModelInfo{}, common.ErrPerm
 	}
 
+	machines, err := st.AllMachines()
+	if err != nil {
+		return params.ModelInfo{}, errors.Trace(err)
+	}
+	health, err := common.ModelHealth(machines, lastConnection)
+	if err != nil {
+		return params.ModelInfo{}, errors.Annotate(err, "getting model health")
+	}
+	info.Health = &health
+
 	return info, nil
 }
 
+// ModelLimits returns the machine and unit limits of the specified
+// models, along with the numbers of machines and units counted against
+// them. The limits of a model may be read by controller administrators
+// and by users with access to the model.
+func (m *ModelManagerAPI) ModelLimits(args params.Entities) (params.ModelLimitsResults, error) {
+	results := params.ModelLimitsResults{
+		Results: make([]params.ModelLimitsResult, len(args.Entities)),
+	}
+
+	getModelLimits := func(arg params.Entity) (params.ModelLimitsResult, error) {
+		tag, err := names.ParseModelTag(arg.Tag)
+		if err != nil {
+			return params.ModelLimitsResult{}, errors.Trace(err)
+		}
+		canRead, err := m.authorizer.HasPermission(description.ReadAccess, tag)
+		if err != nil {
+			return params.ModelLimitsResult{}, errors.Trace(err)
+		}
+		if !canRead && !m.isAdmin {
+			return params.ModelLimitsResult{}, common.ErrPerm
+		}
+		model, err := m.state.GetModel(tag)
+		if err != nil {
+			return params.ModelLimitsResult{}, errors.Trace(err)
+		}
+		usage, err := model.Usage()
+		if err != nil {
+			return params.ModelLimitsResult{}, errors.Trace(err)
+		}
+		limits := model.Limits()
+		return params.ModelLimitsResult{
+			Limits: params.ModelLimits{
+				MaxMachines: limits.MaxMachines,
+				MaxUnits:    limits.MaxUnits,
+			},
+			Usage: params.ModelUsage{
+				Machines: usage.Machines,
+				Units:    usage.Units,
+			},
+		}, nil
+	}
+
+	for i, arg := range args.Entities {
+		result, err := getModelLimits(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// ModelTeardownProgress returns, for each of the specified models, the
+// entities that remain to be removed before the model can die, and
+// what its destruction is currently waiting for. Once a model has
+// been removed entirely, a not found error is returned for it.
+func (m *ModelManagerAPI) ModelTeardownProgress(args params.Entities) (params.ModelTeardownProgressResults, error) {
+	results := params.ModelTeardownProgressResults{
+		Results: make([]params.ModelTeardownProgressResult, len(args.Entities)),
+	}
+
+	getProgress := func(arg params.Entity) (*params.ModelTeardownProgress, error) {
+		tag, err := names.ParseModelTag(arg.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		canRead, err := m.authorizer.HasPermission(description.ReadAccess, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !canRead && !m.isAdmin {
+			return nil, common.ErrPerm
+		}
+		model, err := m.state.GetModel(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		progress, err := model.TeardownProgress()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result := &params.ModelTeardownProgress{
+			Life:         params.Life(progress.Life.String()),
+			Machines:     progress.Machines,
+			Applications: progress.Applications,
+			Units:        progress.Units,
+			Volumes:      progress.Volumes,
+			Filesystems:  progress.Filesystems,
+			HostedModels: progress.HostedModels,
+			Blocker:      progress.Blocker,
+		}
+		if !progress.TimeOfDying.IsZero() {
+			timeOfDying := progress.TimeOfDying
+			result.TimeOfDying = &timeOfDying
+		}
+		return result, nil
+	}
+
+	for i, arg := range args.Entities {
+		progress, err := getProgress(arg)
+		results.Results[i].Result = progress
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetModelLimits changes the machine and unit limits of the specified
+// models. Only controller administrators may set limits.
+func (m *ModelManagerAPI) SetModelLimits(args params.SetModelLimitsArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Models)),
+	}
+	if !m.isAdmin {
+		return results, common.ErrPerm
+	}
+
+	setModelLimits := func(arg params.SetModelLimits) error {
+		tag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		model, err := m.state.GetModel(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return model.SetLimits(state.ModelLimits{
+			MaxMachines: arg.Limits.MaxMachines,
+			MaxUnits:    arg.Limits.MaxUnits,
+		})
+	}
+
+	for i, arg := range args.Models {
+		if err := setModelLimits(arg); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
 // ModifyModelAccess changes the model access granted to users.
 func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest) (result params.ErrorResults, _ error) {
 	result = params.ErrorResults{
@@ -208,6 +208,49 @@ func (s *modelManagerSuite) TestCreateModelDefaultRegion(c *gc.C) {
 	c.Assert(newModelArgs.CloudRegion, gc.Equals, "some-region")
 }
 
+func (s *modelManagerSuite) TestCreateModelOtherCloud(c *gc.C) {
+	s.st.cloud.AuthTypes = []cloud.AuthType{"userpass"}
+	args := params.ModelCreateArgs{
+		Name:               "foo",
+		OwnerTag:           "user-admin@local",
+		CloudTag:           "cloud-other-cloud",
+		CloudCredentialTag: "cloudcred-other-cloud_admin@local_some-credential",
+	}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var newModelArgs state.ModelArgs
+	for _, v := range s.st.Calls() {
+		if v.Args == nil {
+			continue
+		}
+		if v.FuncName == "Cloud" {
+			c.Assert(v.Args, jc.DeepEquals, []interface{}{"other-cloud"})
+		}
+		var ok bool
+		if newModelArgs, ok = v.Args[0].(state.ModelArgs); ok {
+			break
+		}
+	}
+
+	c.Assert(newModelArgs.CloudName, gc.Equals, "other-cloud")
+	c.Assert(newModelArgs.CloudRegion, gc.Equals, "some-region")
+	c.Assert(newModelArgs.CloudCredential, gc.Equals, names.NewCloudCredentialTag(
+		"other-cloud/admin@local/some-credential",
+	))
+}
+
+func (s *modelManagerSuite) TestCreateModelOtherCloudNoDefaultCredential(c *gc.C) {
+	s.st.cloud.AuthTypes = []cloud.AuthType{"userpass"}
+	args := params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-admin@local",
+		CloudTag: "cloud-other-cloud",
+	}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, "no credential specified")
+}
+
 func (s *modelManagerSuite) TestCreateModelDefaultCredentialAdmin(c *gc.C) {
 	s.testCreateModelDefaultCredentialAdmin(c, "user-admin@local")
 }
@@ -720,6 +763,113 @@ func (s *modelManagerStateSuite) TestDestroyModelErrors(c *gc.C) {
 	c.Assert(model.Life(), gc.Equals, state.Alive)
 }
 
+func (s *modelManagerStateSuite) TestSetModelLimits(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	s.Factory.MakeMachine(c, nil)
+	modelTag := s.State.ModelTag().String()
+
+	results, err := s.modelmanager.SetModelLimits(params.SetModelLimitsArgs{
+		Models: []params.SetModelLimits{{
+			ModelTag: modelTag,
+			Limits:   params.ModelLimits{MaxMachines: 3, MaxUnits: 10},
+		}, {
+			ModelTag: modelTag,
+			Limits:   params.ModelLimits{MaxMachines: -1},
+		}, {
+			ModelTag: "machine-42",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot set limits for model "controller": limits may not be negative`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"machine-42" is not a valid model tag`)
+
+	limits, err := s.modelmanager.ModelLimits(params.Entities{
+		Entities: []params.Entity{{Tag: modelTag}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits.Results, jc.DeepEquals, []params.ModelLimitsResult{{
+		Limits: params.ModelLimits{MaxMachines: 3, MaxUnits: 10},
+		Usage:  params.ModelUsage{Machines: 1},
+	}})
+}
+
+func (s *modelManagerStateSuite) TestSetModelLimitsNonAdmin(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("external@remote"))
+	_, err := s.modelmanager.SetModelLimits(params.SetModelLimitsArgs{
+		Models: []params.SetModelLimits{{
+			ModelTag: s.State.ModelTag().String(),
+			Limits:   params.ModelLimits{MaxMachines: 3},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Limits(), jc.DeepEquals, state.ModelLimits{})
+}
+
+func (s *modelManagerStateSuite) TestModelLimitsNoAccess(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("external@remote"))
+	results, err := s.modelmanager.ModelLimits(params.Entities{
+		Entities: []params.Entity{{Tag: s.State.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ModelLimitsResult{{
+		Error: &params.Error{
+			Message: "permission denied",
+			Code:    params.CodeUnauthorized,
+		},
+	}})
+}
+
+func (s *modelManagerStateSuite) TestModelTeardownProgress(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	factory.NewFactory(st).MakeUnit(c, nil)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.modelmanager.ModelTeardownProgress(params.Entities{
+		Entities: []params.Entity{
+			{Tag: model.ModelTag().String()},
+			{Tag: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	progress := results.Results[0].Result
+	c.Assert(progress.TimeOfDying, gc.NotNil)
+	progress.TimeOfDying = nil
+	c.Assert(progress, jc.DeepEquals, &params.ModelTeardownProgress{
+		Life:         params.Dying,
+		Machines:     1,
+		Applications: 1,
+		Units:        1,
+		Blocker:      "waiting for 1 unit(s) to be removed",
+	})
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *modelManagerStateSuite) TestModelTeardownProgressNoAccess(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("external@remote"))
+	results, err := s.modelmanager.ModelTeardownProgress(params.Entities{
+		Entities: []params.Entity{{Tag: s.State.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ModelTeardownProgressResult{{
+		Error: &params.Error{
+			Message: "permission denied",
+			Code:    params.CodeUnauthorized,
+		},
+	}})
+}
+
 func (s *modelManagerStateSuite) modifyAccess(c *gc.C, user names.UserTag, action params.ModelAction, access params.UserAccessPermission, model names.ModelTag) error {
 	args := params.ModifyModelAccessRequest{
 		Changes: []params.ModifyModelAccess{{
@@ -0,0 +1,51 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/monitoring"
+)
+
+// metricsHandler serves the controller's metrics, in the Prometheus
+// exposition format, to controller superusers.
+type metricsHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements http.Handler.
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		return
+	}
+	if err := h.authenticate(req); err != nil {
+		sendError(w, errors.Trace(err))
+		return
+	}
+	monitoring.Handler().ServeHTTP(w, req)
+}
+
+// authenticate checks that the request was made by a controller
+// superuser. The metrics describe the whole controller, and so are
+// not available to the administrators of individual models.
+func (h *metricsHandler) authenticate(req *http.Request) error {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	isSuperuser, err := hasPermission(st.UserAccess, entity.Tag(), description.SuperuserAccess, st.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isSuperuser {
+		return common.ErrPerm
+	}
+	return nil
+}
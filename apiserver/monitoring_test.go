@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+)
+
+type metricsSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) metricsURL(c *gc.C) string {
+	return s.makeURL(c, "https", "/metrics", nil).String()
+}
+
+func (s *metricsSuite) assertError(c *gc.C, resp *http.Response, statusCode int, msg string) {
+	body := assertResponse(c, resp, statusCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, msg)
+}
+
+func (s *metricsSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	s.assertError(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *metricsSuite) TestRequiresSuperuser(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	s.assertError(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *metricsSuite) TestInvalidMethod(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "POST", url: s.metricsURL(c)})
+	s.assertError(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
+
+func (s *metricsSuite) TestMetrics(c *gc.C) {
+	_, err := s.State.SetUserAccess(s.userTag, s.State.ControllerTag(), description.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), jc.HasPrefix, "text/plain; version=0.0.4")
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range []string{
+		"juju_api_watchers",
+		"juju_mongo_txns_total",
+	} {
+		c.Check(string(body), jc.Contains, "# TYPE "+name+" ")
+	}
+}
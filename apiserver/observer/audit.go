@@ -104,7 +104,10 @@ func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
 
 	auditEntry.OriginType = "API request"
 	auditEntry.Operation = rpcRequestToOperation(hdr.Request)
-	auditEntry.Data = map[string]interface{}{"request-body": body}
+	auditEntry.Data = map[string]interface{}{
+		"request-body":   body,
+		"correlation-id": hdr.CorrelationId,
+	}
 	err := a.handleAuditEntry(auditEntry)
 	if err != nil {
 		a.errorHandler(errors.Trace(err))
@@ -0,0 +1,14 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// APIRequests returns the counter of the API requests served with the
+// given facade, method and error code.
+func APIRequests(facade, method, errorCode string) prometheus.Counter {
+	return apiRequests.WithLabelValues(facade, method, errorCode)
+}
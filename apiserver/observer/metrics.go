@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/monitoring"
+	"github.com/juju/juju/rpc"
+)
+
+var (
+	// apiRequests counts the API requests served, by facade,
+	// method and the code of the error returned, if any.
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: monitoring.Namespace,
+		Subsystem: "api",
+		Name:      "requests_total",
+		Help:      "Number of API requests served.",
+	}, []string{"facade", "method", "error_code"})
+
+	// apiRequestDuration records how long API requests take to
+	// serve, by facade and method.
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: monitoring.Namespace,
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken to serve API requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"facade", "method"})
+)
+
+func init() {
+	monitoring.MustRegister(apiRequests, apiRequestDuration)
+}
+
+// MetricsObserver records the number and duration of the API requests
+// served in the controller's metrics.
+type MetricsObserver struct {
+	clock clock.Clock
+}
+
+// NewMetricsObserver returns a new MetricsObserver.
+func NewMetricsObserver(clock clock.Clock) *MetricsObserver {
+	return &MetricsObserver{clock: clock}
+}
+
+// Login implements Observer.
+func (*MetricsObserver) Login(names.Tag, names.ModelTag, bool, string) {}
+
+// Join implements Observer.
+func (*MetricsObserver) Join(*http.Request, uint64) {}
+
+// Leave implements Observer.
+func (*MetricsObserver) Leave() {}
+
+// RPCObserver implements Observer.
+func (o *MetricsObserver) RPCObserver() rpc.Observer {
+	return &metricsRPCObserver{clock: o.clock}
+}
+
+// metricsRPCObserver records the metrics of a single API request.
+type metricsRPCObserver struct {
+	clock        clock.Clock
+	requestStart time.Time
+}
+
+// ServerRequest implements rpc.Observer.
+func (o *metricsRPCObserver) ServerRequest(*rpc.Header, interface{}) {
+	o.requestStart = o.clock.Now()
+}
+
+// ServerReply implements rpc.Observer.
+func (o *metricsRPCObserver) ServerReply(req rpc.Request, hdr *rpc.Header, _ interface{}) {
+	errorCode := hdr.ErrorCode
+	if errorCode == "" && hdr.Error != "" {
+		// Distinguish errors without a code from success.
+		errorCode = "unknown"
+	}
+	apiRequests.WithLabelValues(req.Type, req.Action, errorCode).Inc()
+	duration := o.clock.Now().Sub(o.requestStart)
+	apiRequestDuration.WithLabelValues(req.Type, req.Action).Observe(duration.Seconds())
+}
@@ -0,0 +1,49 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/observer"
+	monitoringtesting "github.com/juju/juju/monitoring/testing"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type metricsObserverSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&metricsObserverSuite{})
+
+func (*metricsObserverSuite) serve(c *gc.C, hdr *rpc.Header) {
+	o := observer.NewMetricsObserver(coretesting.NewClock(time.Now()))
+	rpcObserver := o.RPCObserver()
+	req := rpc.Request{Type: "Client", Action: "FullStatus"}
+	rpcObserver.ServerRequest(&rpc.Header{Request: req}, nil)
+	rpcObserver.ServerReply(req, hdr, nil)
+}
+
+func (s *metricsObserverSuite) TestCountsRequests(c *gc.C) {
+	counter := observer.APIRequests("Client", "FullStatus", "")
+	before := monitoringtesting.Value(c, counter)
+	s.serve(c, &rpc.Header{})
+	c.Assert(monitoringtesting.Value(c, counter), gc.Equals, before+1)
+}
+
+func (s *metricsObserverSuite) TestCountsErrors(c *gc.C) {
+	unauthorized := observer.APIRequests("Client", "FullStatus", "unauthorized access")
+	unknown := observer.APIRequests("Client", "FullStatus", "unknown")
+	beforeUnauthorized := monitoringtesting.Value(c, unauthorized)
+	beforeUnknown := monitoringtesting.Value(c, unknown)
+
+	s.serve(c, &rpc.Header{Error: "permission denied", ErrorCode: "unauthorized access"})
+	s.serve(c, &rpc.Header{Error: "boom"})
+	c.Assert(monitoringtesting.Value(c, unauthorized), gc.Equals, beforeUnauthorized+1)
+	c.Assert(monitoringtesting.Value(c, unknown), gc.Equals, beforeUnknown+1)
+}
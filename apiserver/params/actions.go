@@ -38,6 +38,10 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// CorrelationId is the id of the API request that enqueued the
+	// action, if any.
+	CorrelationId string `json:"correlation-id,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// AgentLogging holds the logging settings of an agent, or the default
+// log rotation settings of the agents in a model.
+type AgentLogging struct {
+	// Tag is the tag of the agent or model.
+	Tag string `json:"tag"`
+
+	// LoggingConfig holds loggo configuration applied by the agent on
+	// top of the model's logging-config. It must be empty for a model.
+	LoggingConfig string `json:"logging-config,omitempty"`
+
+	// MaxLogSize is the size in megabytes at which the agent's log
+	// file is rotated, or zero to use the default.
+	MaxLogSize int `json:"max-log-size,omitempty"`
+
+	// MaxLogBackups is the number of rotated log files kept by the
+	// agent, or zero to use the default.
+	MaxLogBackups int `json:"max-log-backups,omitempty"`
+}
+
+// AgentLoggingArgs holds the arguments to
+// AgentLogging.SetAgentLogging.
+type AgentLoggingArgs struct {
+	Args []AgentLogging `json:"args"`
+}
+
+// AgentLoggingResult holds the logging settings of an agent or model,
+// or an error.
+type AgentLoggingResult struct {
+	Result AgentLogging `json:"result"`
+	Error  *Error       `json:"error,omitempty"`
+}
+
+// AgentLoggingResults holds the results of
+// AgentLogging.AgentLogging.
+type AgentLoggingResults struct {
+	Results []AgentLoggingResult `json:"results"`
+}
+
+// LogRotationResult holds the log rotation settings which apply to an
+// agent, or an error. Zero values mean the agent's defaults apply.
+type LogRotationResult struct {
+	MaxLogSize    int    `json:"max-log-size,omitempty"`
+	MaxLogBackups int    `json:"max-log-backups,omitempty"`
+	Error         *Error `json:"error,omitempty"`
+}
+
+// LogRotationResults holds the results of Logger.LogRotation.
+type LogRotationResults struct {
+	Results []LogRotationResult `json:"results"`
+}
@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// AlertRule defines when an alert is raised for the entities in a
+// model, and where notifications of it are sent.
+type AlertRule struct {
+	// Name identifies the rule within its model.
+	Name string `json:"name"`
+
+	// Kind identifies the condition which raises the alert, such as
+	// "unit-error" or "machine-down".
+	Kind string `json:"kind"`
+
+	// For is how long an entity must meet the condition before the
+	// alert is raised.
+	For time.Duration `json:"for"`
+
+	// Webhook, if set, is the URL to which notifications are posted.
+	Webhook string `json:"webhook,omitempty"`
+
+	// Email, if set, is the address to which notifications are sent.
+	Email string `json:"email,omitempty"`
+}
+
+// AlertRuleArgs holds the arguments to Alerts.AddAlertRules.
+type AlertRuleArgs struct {
+	Args []AlertRule `json:"args"`
+}
+
+// AlertRuleNames holds the arguments to Alerts.RemoveAlertRules.
+type AlertRuleNames struct {
+	Names []string `json:"names"`
+}
+
+// ListAlertRulesResults holds the alert rules of a model.
+type ListAlertRulesResults struct {
+	Rules []AlertRule `json:"rules"`
+}
+
+// Alert describes an alert currently raised in a model.
+type Alert struct {
+	Rule    string    `json:"rule"`
+	Kind    string    `json:"kind"`
+	Entity  string    `json:"entity"`
+	Message string    `json:"message,omitempty"`
+	Since   time.Time `json:"since"`
+	Raised  time.Time `json:"raised"`
+}
+
+// ListAlertsResults holds the alerts currently raised in a model.
+type ListAlertsResults struct {
+	Alerts []Alert `json:"alerts"`
+}
@@ -25,3 +25,12 @@ type EntityAnnotations struct {
 	EntityTag   string            `json:"entity"`
 	Annotations map[string]string `json:"annotations"`
 }
+
+// AnnotationsFind holds the parameters for finding entities by their
+// annotations.
+type AnnotationsFind struct {
+	// Kind, if not empty, restricts the results to entities
+	// whose tags are of that kind.
+	Kind        string            `json:"kind,omitempty"`
+	Annotations map[string]string `json:"annotations"`
+}
@@ -39,6 +39,19 @@ type ErrorInfo struct {
 	// If it is empty, the macaroon will be associated with
 	// the original URL from which the error was returned.
 	MacaroonPath string `json:"macaroon-path,omitempty"`
+
+	// ConfigErrors holds the reasons for which each invalid
+	// application config setting was rejected. This field is
+	// associated with the CodeInvalidConfig error code.
+	ConfigErrors []ConfigOptionError `json:"config-errors,omitempty"`
+}
+
+// ConfigOptionError describes an invalid value for an application
+// config option.
+type ConfigOptionError struct {
+	Option string      `json:"option"`
+	Value  interface{} `json:"value,omitempty"`
+	Reason string      `json:"reason"`
 }
 
 func (e Error) Error() string {
@@ -89,6 +102,8 @@ const (
 	CodeDischargeRequired         = "macaroon discharge required"
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
+	CodeInvalidConfig             = "invalid config"
+	CodeQuotaExceeded             = "quota exceeded"
 )
 
 // ErrCode returns the error code associated with
@@ -230,3 +245,11 @@ func IsMethodNotAllowed(err error) bool {
 func IsRedirect(err error) bool {
 	return ErrCode(err) == CodeRedirect
 }
+
+func IsCodeInvalidConfig(err error) bool {
+	return ErrCode(err) == CodeInvalidConfig
+}
+
+func IsCodeQuotaExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaExceeded
+}
@@ -31,6 +31,10 @@ type BlockSwitchParams struct {
 	// Message is a descriptive or an explanatory message
 	// that accompanies the switch.
 	Message string `json:"message,omitempty"`
+
+	// Tag holds the tag of the application or machine to switch
+	// the block for. If empty, the block applies to the whole model.
+	Tag string `json:"tag,omitempty"`
 }
 
 // BlockResult holds the result of an API call to retrieve details
@@ -32,6 +32,13 @@ type CloudResults struct {
 	Results []CloudResult `json:"results,omitempty"`
 }
 
+// AddCloudArgs holds a cloud definition to add to a controller,
+// along with the name to give it.
+type AddCloudArgs struct {
+	Name  string `json:"name"`
+	Cloud Cloud  `json:"cloud"`
+}
+
 // CloudCredential contains a cloud credential.
 type CloudCredential struct {
 	AuthType   string            `json:"auth-type"`
@@ -83,3 +90,17 @@ type CloudSpecResult struct {
 type CloudSpecResults struct {
 	Results []CloudSpecResult `json:"results,omitempty"`
 }
+
+// ModelCredential holds the cloud credential used by a model, and
+// whether that credential is currently valid.
+type ModelCredential struct {
+	Exists          bool   `json:"exists,omitempty"`
+	CloudCredential string `json:"credential-tag,omitempty"`
+	Valid           bool   `json:"valid,omitempty"`
+}
+
+// InvalidateCredentialArg holds the reason a model's cloud credential
+// is being reported as invalid.
+type InvalidateCredentialArg struct {
+	Reason string `json:"reason,omitempty"`
+}
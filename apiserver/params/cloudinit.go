@@ -0,0 +1,54 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// CloudInitCustomization holds extra cloud-init directives applied
+// when provisioning machines.
+type CloudInitCustomization struct {
+	// Packages holds the names of extra packages to install, each
+	// optionally pinned to a version as "name=version".
+	Packages []string `json:"packages,omitempty"`
+
+	// AptMirror, if set, overrides the APT mirror from which
+	// packages are installed.
+	AptMirror string `json:"apt-mirror,omitempty"`
+
+	// RunCmds holds shell commands run before the machine agent is
+	// installed.
+	RunCmds []string `json:"runcmds,omitempty"`
+
+	// SSHCAKeys holds the public keys of certificate authorities
+	// trusted by sshd to sign user certificates.
+	SSHCAKeys []string `json:"ssh-ca-keys,omitempty"`
+}
+
+// CloudInitCustomizationArg holds the cloud-init customization of a
+// model or machine.
+type CloudInitCustomizationArg struct {
+	// Tag is the tag of the model or machine.
+	Tag           string                 `json:"tag"`
+	Customization CloudInitCustomization `json:"customization"`
+}
+
+// CloudInitCustomizationArgs holds the arguments to
+// CloudInit.SetCloudInitCustomizations.
+type CloudInitCustomizationArgs struct {
+	Args []CloudInitCustomizationArg `json:"args"`
+}
+
+// CloudInitCustomizationResult holds the cloud-init customization set
+// for a model or machine, or an error. For a machine, Effective holds
+// the customization applied when it is provisioned, which merges that
+// of the machine with that of its model.
+type CloudInitCustomizationResult struct {
+	Customization CloudInitCustomization  `json:"customization"`
+	Effective     *CloudInitCustomization `json:"effective,omitempty"`
+	Error         *Error                  `json:"error,omitempty"`
+}
+
+// CloudInitCustomizationResults holds the results of
+// CloudInit.CloudInitCustomizations.
+type CloudInitCustomizationResults struct {
+	Results []CloudInitCustomizationResult `json:"results"`
+}
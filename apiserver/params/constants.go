@@ -32,3 +32,13 @@ const (
 )
 
 const MachineNonceHeader = "X-Juju-Nonce"
+
+const (
+	// UploadOffsetHeader is sent with a resumable upload to give the
+	// offset within the content at which the request body starts.
+	UploadOffsetHeader = "X-Juju-Upload-Offset"
+
+	// UploadLengthHeader is sent with a resumable upload to give the
+	// total size of the content being uploaded.
+	UploadLengthHeader = "X-Juju-Upload-Length"
+)
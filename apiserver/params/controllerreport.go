@@ -0,0 +1,24 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// ControllerReport holds the resource usage of a controller, compared
+// with the soft limits configured for it.
+type ControllerReport struct {
+	Resources []ControllerResource `json:"resources"`
+}
+
+// ControllerResource holds the usage of one resource of a controller.
+type ControllerResource struct {
+	// Name identifies the resource, such as "models" or
+	// "database-size-mb".
+	Name string `json:"name"`
+
+	// Usage is the amount of the resource in use.
+	Usage float64 `json:"usage"`
+
+	// Limit is the soft limit configured for the resource, or zero
+	// if there is none.
+	Limit int `json:"limit,omitempty"`
+}
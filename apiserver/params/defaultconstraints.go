@@ -0,0 +1,68 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"github.com/juju/juju/constraints"
+)
+
+// DefaultConstraintsScope identifies the controller, a cloud, or a
+// region of a cloud. An empty CloudTag denotes the controller, and an
+// empty CloudRegion the whole cloud.
+type DefaultConstraintsScope struct {
+	CloudTag    string `json:"cloud-tag,omitempty"`
+	CloudRegion string `json:"cloud-region,omitempty"`
+}
+
+// DefaultConstraintsScopes holds the scopes whose default constraints
+// are requested.
+type DefaultConstraintsScopes struct {
+	Scopes []DefaultConstraintsScope `json:"scopes"`
+}
+
+// DefaultConstraintsResult holds the default constraints of a scope,
+// or an error.
+type DefaultConstraintsResult struct {
+	Constraints constraints.Value `json:"constraints"`
+	Error       *Error            `json:"error,omitempty"`
+}
+
+// DefaultConstraintsResults holds the results of a DefaultConstraints
+// call.
+type DefaultConstraintsResults struct {
+	Results []DefaultConstraintsResult `json:"results"`
+}
+
+// DefaultConstraintsArg holds the default constraints to set for a
+// scope. Empty constraints remove the defaults.
+type DefaultConstraintsArg struct {
+	CloudTag    string            `json:"cloud-tag,omitempty"`
+	CloudRegion string            `json:"cloud-region,omitempty"`
+	Constraints constraints.Value `json:"constraints"`
+}
+
+// DefaultConstraintsArgs holds the arguments of a SetDefaultConstraints
+// call.
+type DefaultConstraintsArgs struct {
+	Args []DefaultConstraintsArg `json:"args"`
+}
+
+// ResolvedConstraintsResult holds the effective constraints of a model
+// or application, or an error.
+type ResolvedConstraintsResult struct {
+	Constraints constraints.Value `json:"constraints"`
+
+	// Sources maps the name of each constraint attribute to the
+	// level from which its value is taken: "controller", "cloud",
+	// "region", "model" or "application".
+	Sources map[string]string `json:"sources,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// ResolvedConstraintsResults holds the results of a ResolveConstraints
+// call.
+type ResolvedConstraintsResults struct {
+	Results []ResolvedConstraintsResult `json:"results"`
+}
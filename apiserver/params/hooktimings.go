@@ -0,0 +1,48 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// HookTiming describes a single execution of a hook by a unit.
+type HookTiming struct {
+	// Hook is the name of the hook that was run.
+	Hook string `json:"hook"`
+
+	// Queued is the time at which the unit decided to run the hook.
+	Queued time.Time `json:"queued"`
+
+	// Started is the time at which the hook started running.
+	Started time.Time `json:"started"`
+
+	// Finished is the time at which the hook exited.
+	Finished time.Time `json:"finished"`
+
+	// ExitCode is the exit code of the hook's process, or -1 if the
+	// hook failed without exiting.
+	ExitCode int `json:"exit-code"`
+}
+
+// UnitHookTiming holds a hook execution recorded by a unit.
+type UnitHookTiming struct {
+	Tag    string     `json:"tag"`
+	Timing HookTiming `json:"timing"`
+}
+
+// HookTimingArgs holds the arguments to Uniter.RecordHookTimings.
+type HookTimingArgs struct {
+	Args []UnitHookTiming `json:"args"`
+}
+
+// HookTimingsResult holds the most recent hook executions of a unit,
+// oldest first, or an error.
+type HookTimingsResult struct {
+	Timings []HookTiming `json:"timings,omitempty"`
+	Error   *Error       `json:"error,omitempty"`
+}
+
+// HookTimingsResults holds the results of HookTimings.HookTimings.
+type HookTimingsResults struct {
+	Results []HookTimingsResult `json:"results"`
+}
@@ -0,0 +1,51 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+
+	"github.com/juju/juju/constraints"
+)
+
+// InstanceType holds the description of an instance type offered
+// by a model's provider.
+type InstanceType struct {
+	Id         string   `json:"id,omitempty"`
+	Name       string   `json:"name"`
+	Arches     []string `json:"arches"`
+	CpuCores   uint64   `json:"cpu-cores"`
+	Mem        uint64   `json:"mem"`
+	Cost       uint64   `json:"cost,omitempty"`
+	RootDisk   uint64   `json:"root-disk,omitempty"`
+	VirtType   *string  `json:"virt-type,omitempty"`
+	CpuPower   *uint64  `json:"cpu-power,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Deprecated bool     `json:"deprecated,omitempty"`
+}
+
+// InstanceTypesConstraint holds constraints used to select instance
+// types from a model's cached instance types.
+type InstanceTypesConstraint struct {
+	Constraints constraints.Value `json:"constraints"`
+}
+
+// InstanceTypesConstraints holds a collection of InstanceTypesConstraint.
+type InstanceTypesConstraints struct {
+	Constraints []InstanceTypesConstraint `json:"constraints"`
+}
+
+// InstanceTypesResult holds the instance types matching a set of
+// constraints, and the time at which they were fetched from the
+// provider.
+type InstanceTypesResult struct {
+	InstanceTypes []InstanceType `json:"instance-types,omitempty"`
+	Updated       time.Time      `json:"updated"`
+	Error         *Error         `json:"error,omitempty"`
+}
+
+// InstanceTypesResults holds a collection of InstanceTypesResult.
+type InstanceTypesResults struct {
+	Results []InstanceTypesResult `json:"results"`
+}
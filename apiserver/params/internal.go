@@ -111,17 +111,25 @@ type ModelCreateArgs struct {
 	// creation of the model.
 	Config map[string]interface{} `json:"config,omitempty"`
 
+	// CloudTag is the tag of the cloud to create the model in.
+	// The cloud must be known to the controller. If this is
+	// empty, the model will be created in the same cloud as the
+	// controller model.
+	CloudTag string `json:"cloud-tag,omitempty"`
+
 	// CloudRegion is the name of the cloud region to create the
 	// model in. If the cloud does not support regions, this must
 	// be empty. If this is empty, the model will be created in
-	// the same region as the controller model.
+	// the same region as the controller model, or in the default
+	// region of a cloud other than the controller's.
 	CloudRegion string `json:"region,omitempty"`
 
 	// CloudCredentialTag is the tag of the cloud credential to use
 	// for managing the model's resources. If the cloud does not
 	// require credentials, this may be empty. If this is empty,
 	// and the owner is the controller owner, the same credential
-	// used for the controller model will be used.
+	// used for the controller model will be used if the model is
+	// in the same cloud.
 	CloudCredentialTag string `json:"credential,omitempty"`
 }
 
@@ -564,6 +572,14 @@ type CharmsResponse struct {
 	Files    []string `json:"files,omitempty"`
 }
 
+// UploadStatus holds the progress of a resumable upload, as reported
+// by the API server so that an interrupted upload may be resumed.
+type UploadStatus struct {
+	// Offset holds the number of bytes of the content received by
+	// the API server so far.
+	Offset int64 `json:"offset"`
+}
+
 // RunParams is used to provide the parameters to the Run method.
 // Commands and Timeout are expected to have values, and one or more
 // values should be in the Machines, Applications, or Units slices.
@@ -611,6 +627,11 @@ type ProvisioningInfo struct {
 	ImageMetadata    []CloudImageMetadata      `json:"image-metadata,omitempty"`
 	EndpointBindings map[string]string         `json:"endpoint-bindings,omitempty"`
 	ControllerConfig map[string]interface{}    `json:"controller-config,omitempty"`
+	InstanceTypes    []InstanceType            `json:"instance-types,omitempty"`
+
+	// CloudInitCustomization holds the extra cloud-init directives
+	// set for the machine and its model, if any.
+	CloudInitCustomization *CloudInitCustomization `json:"cloudinit-customization,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -679,6 +700,11 @@ type SingularClaim struct {
 	ModelTag      string        `json:"model-tag"`
 	ControllerTag string        `json:"controller-tag"`
 	Duration      time.Duration `json:"duration"`
+
+	// Worker, if set, restricts the claim to the exclusive right to
+	// run the named worker for the model, rather than to administer
+	// the model as a whole.
+	Worker string `json:"worker,omitempty"`
 }
 
 // SingularClaims holds any number of SingularClaim~s.
@@ -686,6 +712,19 @@ type SingularClaims struct {
 	Claims []SingularClaim `json:"claims"`
 }
 
+// SingularWait identifies a singular lease to wait for the expiry of:
+// that for the named worker in the model, or the model-wide lease if
+// Worker is empty.
+type SingularWait struct {
+	ModelTag string `json:"model-tag"`
+	Worker   string `json:"worker,omitempty"`
+}
+
+// SingularWaits holds any number of SingularWait~s.
+type SingularWaits struct {
+	Waits []SingularWait `json:"waits"`
+}
+
 // GUIArchiveVersion holds information on a specific GUI archive version.
 type GUIArchiveVersion struct {
 	// Version holds the Juju GUI version number.
@@ -42,6 +42,29 @@ type InitiateModelMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// ModelMigrationStatusResults is used to return the progress of the
+// latest migration of one or more models.
+type ModelMigrationStatusResults struct {
+	Results []ModelMigrationStatusResult `json:"results"`
+}
+
+// ModelMigrationStatusResult is used to return the progress of the
+// latest migration of a single model.
+type ModelMigrationStatusResult struct {
+	Status *ModelMigrationStatus `json:"status,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ModelMigrationStatus reports the progress of a model migration, for
+// display to the user.
+type ModelMigrationStatus struct {
+	MigrationId      string    `json:"migration-id"`
+	Phase            string    `json:"phase"`
+	StatusMessage    string    `json:"status-message"`
+	StartTime        time.Time `json:"start-time"`
+	PhaseChangedTime time.Time `json:"phase-changed-time"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -54,6 +77,36 @@ type SetMigrationStatusMessageArgs struct {
 	Message string `json:"message"`
 }
 
+// TargetPrechecksArgs describes the model to be migrated to the
+// migrationtarget.Prechecks API method.
+type TargetPrechecksArgs struct {
+	Usage ModelUsage `json:"usage"`
+}
+
+// TargetCapacityShortfall describes a resource of the target
+// controller whose capacity limit would be exceeded by importing a
+// model.
+type TargetCapacityShortfall struct {
+	Resource string `json:"resource"`
+	Incoming int    `json:"incoming"`
+	Usage    int    `json:"usage"`
+	Limit    int    `json:"limit"`
+}
+
+// TargetPrechecksResult holds the result of a
+// migrationtarget.Prechecks API call. The migration should not
+// proceed if there are any shortfalls.
+type TargetPrechecksResult struct {
+	Shortfalls []TargetCapacityShortfall `json:"shortfalls,omitempty"`
+}
+
+// SetMigrationArtifactsRemainingArgs provides the number of charms and
+// tools still to be transferred to the
+// migrationmaster.SetArtifactsRemaining API method.
+type SetMigrationArtifactsRemainingArgs struct {
+	Count int `json:"count"`
+}
+
 // SerializedModel wraps a buffer contain a serialised Juju model. It
 // also contains lists of the charms and tools used in the model.
 type SerializedModel struct {
@@ -87,6 +140,14 @@ type MasterMigrationStatus struct {
 	MigrationId      string             `json:"migration-id"`
 	Phase            string             `json:"phase"`
 	PhaseChangedTime time.Time          `json:"phase-changed-time"`
+
+	// ArtifactsRemaining holds the number of charms and tools still
+	// to be transferred to the target controller.
+	ArtifactsRemaining int `json:"artifacts-remaining"`
+
+	// AgentsUnreported holds the number of agents which have not yet
+	// reported on the current migration phase.
+	AgentsUnreported int `json:"agents-unreported"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -62,10 +62,69 @@ type UnsetModelDefaults struct {
 	Keys []ModelUnsetKeys `json:"keys"`
 }
 
+// ConfigSnapshotArg holds the name of a model config snapshot.
+type ConfigSnapshotArg struct {
+	Name string `json:"name"`
+}
+
+// ConfigSnapshot holds the details of a snapshot of the config of a
+// model and its applications.
+type ConfigSnapshot struct {
+	Name         string    `json:"name"`
+	Revision     int       `json:"revision"`
+	Created      time.Time `json:"created"`
+	CreatedBy    string    `json:"created-by"`
+	Applications []string  `json:"applications,omitempty"`
+}
+
+// ConfigSnapshotsResult holds the config snapshots of a model.
+type ConfigSnapshotsResult struct {
+	Snapshots []ConfigSnapshot `json:"snapshots"`
+}
+
+// RestoreConfigSnapshotResult holds the result of restoring a model
+// config snapshot.
+type RestoreConfigSnapshotResult struct {
+	// Skipped holds the names of the applications recorded in the
+	// snapshot which no longer exist, and so were not restored.
+	Skipped []string `json:"skipped,omitempty"`
+}
+
 // SetModelAgentVersion contains the arguments for
 // SetModelAgentVersion client API call.
 type SetModelAgentVersion struct {
 	Version version.Number `json:"version"`
+
+	// Canary, if set, starts a canary upgrade to Version rather
+	// than upgrading the whole model at once.
+	Canary *CanaryUpgradeParams `json:"canary,omitempty"`
+}
+
+// CanaryUpgradeParams holds the parameters of a canary upgrade: the
+// machines upgraded first, and how long they must remain healthy
+// before the rest of the model is upgraded.
+type CanaryUpgradeParams struct {
+	Machines    []string      `json:"machines"`
+	SoakPeriod  time.Duration `json:"soak-period"`
+	AutoProceed bool          `json:"auto-proceed,omitempty"`
+}
+
+// CanaryUpgradeStatus describes the canary upgrade in progress in a
+// model.
+type CanaryUpgradeStatus struct {
+	PreviousVersion version.Number `json:"previous-version"`
+	TargetVersion   version.Number `json:"target-version"`
+	Machines        []string       `json:"machines"`
+	SoakPeriod      time.Duration  `json:"soak-period"`
+	AutoProceed     bool           `json:"auto-proceed,omitempty"`
+	Started         time.Time      `json:"started"`
+
+	// HealthySince is when the canaries were found healthy. It is
+	// nil while they are not.
+	HealthySince *time.Time `json:"healthy-since,omitempty"`
+
+	// Problems holds the reasons why the canaries are not healthy.
+	Problems []string `json:"problems,omitempty"`
 }
 
 // ModelInfo holds information about the Juju model.
@@ -95,6 +154,30 @@ type ModelInfo struct {
 	// to the model. Owners and administrators can see all users
 	// that have access; other users can only see their own details.
 	Users []ModelUserInfo `json:"users"`
+
+	// Health summarises the health of the model, as seen by the
+	// controller.
+	Health *ModelHealth `json:"health,omitempty"`
+}
+
+// ModelHealth summarises the health of a model: whether the agents
+// of its machines are connected to the controller, which agent
+// versions they run, and when the model was last used.
+type ModelHealth struct {
+	// Machines is the number of machines in the model.
+	Machines int `json:"machines"`
+
+	// MachinesDown is the number of machines in the model whose
+	// agents are not connected to the controller.
+	MachinesDown int `json:"machines-down"`
+
+	// AgentVersions holds the distinct versions of the machine
+	// agents in the model, in ascending order.
+	AgentVersions []string `json:"agent-versions,omitempty"`
+
+	// LastConnection is the most recent time any user connected
+	// to the model, or nil if no user has ever connected.
+	LastConnection *time.Time `json:"last-connection,omitempty"`
 }
 
 // ModelInfoResult holds the result of a ModelInfo call.
@@ -126,6 +209,70 @@ type ModelInfoListResults struct {
 	Results []ModelInfoListResult `json:"results"`
 }
 
+// ModelLimits holds the caps on the number of machines and units in a
+// model. A zero value means that the resource is not limited.
+type ModelLimits struct {
+	MaxMachines int `json:"max-machines"`
+	MaxUnits    int `json:"max-units"`
+}
+
+// ModelUsage holds the number of machines and units in a model, as
+// counted against its limits.
+type ModelUsage struct {
+	Machines int `json:"machines"`
+	Units    int `json:"units"`
+}
+
+// ModelLimitsResult holds the result of a ModelLimits call.
+type ModelLimitsResult struct {
+	Limits ModelLimits `json:"limits"`
+	Usage  ModelUsage  `json:"usage"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// ModelLimitsResults holds the result of a bulk ModelLimits call.
+type ModelLimitsResults struct {
+	Results []ModelLimitsResult `json:"results"`
+}
+
+// SetModelLimits holds the limits to set on a model.
+type SetModelLimits struct {
+	ModelTag string      `json:"model-tag"`
+	Limits   ModelLimits `json:"limits"`
+}
+
+// SetModelLimitsArgs holds the arguments of a bulk SetModelLimits call.
+type SetModelLimitsArgs struct {
+	Models []SetModelLimits `json:"models"`
+}
+
+// ModelTeardownProgress describes how far the destruction of a model
+// has got.
+type ModelTeardownProgress struct {
+	Life         Life       `json:"life"`
+	TimeOfDying  *time.Time `json:"time-of-dying,omitempty"`
+	Machines     int        `json:"machines"`
+	Applications int        `json:"applications"`
+	Units        int        `json:"units"`
+	Volumes      int        `json:"volumes"`
+	Filesystems  int        `json:"filesystems"`
+	HostedModels int        `json:"hosted-models"`
+	Blocker      string     `json:"blocker,omitempty"`
+}
+
+// ModelTeardownProgressResult holds the result of a
+// ModelTeardownProgress call.
+type ModelTeardownProgressResult struct {
+	Result *ModelTeardownProgress `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// ModelTeardownProgressResults holds the result of a bulk
+// ModelTeardownProgress call.
+type ModelTeardownProgressResults struct {
+	Results []ModelTeardownProgressResult `json:"results"`
+}
+
 // ModelUserInfo holds information on a user who has access to a
 // model. Owners of a model can see this information for all users
 // who have access, so it should not include sensitive information.
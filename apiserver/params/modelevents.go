@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// ModelEventsFilter selects the events returned by
+// ModelEvents.ModelEvents.
+type ModelEventsFilter struct {
+	// From, if set, excludes events which happened before it.
+	From *time.Time `json:"from,omitempty"`
+
+	// To, if set, excludes events which happened at or after it.
+	To *time.Time `json:"to,omitempty"`
+
+	// Kinds, if not empty, excludes events of other kinds.
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Entity, if set, holds the tag of the only entity whose
+	// events are returned.
+	Entity string `json:"entity,omitempty"`
+
+	// Limit, if positive, restricts the result to that number of
+	// the most recent events selected.
+	Limit int `json:"limit,omitempty"`
+}
+
+// ModelEvent describes a high-level event in the life of a model.
+type ModelEvent struct {
+	Kind    string    `json:"kind"`
+	Actor   string    `json:"actor,omitempty"`
+	Entity  string    `json:"entity"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// ModelEventsResult holds the events selected by a
+// ModelEventsFilter, oldest first.
+type ModelEventsResult struct {
+	Events []ModelEvent `json:"events"`
+}
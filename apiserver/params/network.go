@@ -620,3 +620,20 @@ type ProxyConfigResult struct {
 type ProxyConfigResults struct {
 	Results []ProxyConfigResult `json:"results"`
 }
+
+// FirewallRule restricts ingress for a well-known service to the
+// given source CIDRs.
+type FirewallRule struct {
+	KnownService   string   `json:"known-service"`
+	WhitelistCIDRs []string `json:"whitelist-cidrs,omitempty"`
+}
+
+// FirewallRuleArgs holds the firewall rules to be set.
+type FirewallRuleArgs struct {
+	Args []FirewallRule `json:"args"`
+}
+
+// ListFirewallRulesResults holds the firewall rules of a model.
+type ListFirewallRulesResults struct {
+	Rules []FirewallRule `json:"rules"`
+}
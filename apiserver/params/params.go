@@ -117,6 +117,41 @@ type DestroyRelation struct {
 	Endpoints []string `json:"endpoints"`
 }
 
+// SetSubordinatePlacement holds the parameters for making the
+// SetSubordinatePlacement call. The endpoints specified are unordered,
+// and must identify a relation with container scope.
+type SetSubordinatePlacement struct {
+	Endpoints          []string `json:"endpoints"`
+	ExcludeControllers bool     `json:"exclude-controllers,omitempty"`
+	ExcludeMachines    []string `json:"exclude-machines,omitempty"`
+}
+
+// RelationScopes holds the parameters for making the RelationScopes
+// call. The endpoints specified are unordered.
+type RelationScopes struct {
+	Endpoints []string `json:"endpoints"`
+}
+
+// RelationScopesResult holds the lifecycle state of a relation, and of
+// the units in its scopes, for diagnosing relations which are slow to
+// be removed.
+type RelationScopesResult struct {
+	Id    int                 `json:"id"`
+	Key   string              `json:"key"`
+	Life  Life                `json:"life"`
+	Units []RelationScopeUnit `json:"units"`
+}
+
+// RelationScopeUnit describes a unit in a relation scope. JoinedUnits
+// holds the counterpart units which have been joined by the unit, and
+// for which it has not yet run relation-departed.
+type RelationScopeUnit struct {
+	Unit        string   `json:"unit"`
+	Role        string   `json:"role"`
+	Departing   bool     `json:"departing,omitempty"`
+	JoinedUnits []string `json:"joined-units,omitempty"`
+}
+
 // AddCharm holds the arguments for making an AddCharm API call.
 type AddCharm struct {
 	URL     string `json:"url"`
@@ -181,6 +216,18 @@ type AddMachinesResults struct {
 	Machines []AddMachinesResult `json:"machines"`
 }
 
+// MachineMaintenance holds the maintenance mode to set on a machine.
+type MachineMaintenance struct {
+	MachineTag  string `json:"machine-tag"`
+	Maintenance bool   `json:"maintenance"`
+}
+
+// MachinesMaintenance holds the parameters for a SetMachineMaintenance
+// call.
+type MachinesMaintenance struct {
+	Machines []MachineMaintenance `json:"machines"`
+}
+
 // AddMachinesResult holds the name of a machine added by the
 // api.client.AddMachine call for a single machine.
 type AddMachinesResult struct {
@@ -188,10 +235,53 @@ type AddMachinesResult struct {
 	Error   *Error `json:"error,omitempty"`
 }
 
+// ReplaceMachineResult holds the result of replacing a single machine.
+type ReplaceMachineResult struct {
+	// Machine holds the id of the substitute machine.
+	Machine string `json:"machine"`
+
+	// Units maps the name of each principal unit of the replaced
+	// machine to the name of the unit that takes its place on the
+	// substitute; the names are the same if the unit was moved.
+	Units map[string]string `json:"units,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// ReplaceMachineResults holds the results of a ReplaceMachines call.
+type ReplaceMachineResults struct {
+	Results []ReplaceMachineResult `json:"results"`
+}
+
+// ProviderInstances holds the parameters for a ProviderInstances call.
+type ProviderInstances struct {
+	InstanceIds []string `json:"instance-ids"`
+}
+
+// ProviderInstanceResult holds the details of a single instance in
+// the model's cloud, as reported by the provider.
+type ProviderInstanceResult struct {
+	InstanceId       string    `json:"instance-id"`
+	Addresses        []Address `json:"addresses,omitempty"`
+	AvailabilityZone string    `json:"availability-zone,omitempty"`
+	Error            *Error    `json:"error,omitempty"`
+}
+
+// ProviderInstanceResults holds the results of a ProviderInstances
+// call.
+type ProviderInstanceResults struct {
+	Results []ProviderInstanceResult `json:"results"`
+}
+
 // DestroyMachines holds parameters for the DestroyMachines call.
 type DestroyMachines struct {
 	MachineNames []string `json:"machine-names"`
 	Force        bool     `json:"force"`
+
+	// MaxWait is, when Force is set, how long the agents of units on
+	// the machines are given to shut down cleanly before the machines
+	// are removed. If zero, they are removed immediately.
+	MaxWait time.Duration `json:"max-wait,omitempty"`
 }
 
 // ApplicationsDeploy holds the parameters for deploying one or more applications.
@@ -222,6 +312,7 @@ type ApplicationUpdate struct {
 	ForceCharmUrl   bool               `json:"force-charm-url"`
 	ForceSeries     bool               `json:"force-series"`
 	MinUnits        *int               `json:"min-units,omitempty"`
+	MaxUnits        *int               `json:"max-units,omitempty"`
 	SettingsStrings map[string]string  `json:"settings,omitempty"`
 	SettingsYAML    string             `json:"settings-yaml"` // Takes precedence over SettingsStrings if both are present.
 	Constraints     *constraints.Value `json:"constraints,omitempty"`
@@ -245,9 +336,64 @@ type ApplicationSetCharm struct {
 	ResourceIDs map[string]string `json:"resource-ids,omitempty"`
 }
 
+// ApplicationsSetCharm holds the parameters for upgrading the charms of
+// several applications together.
+type ApplicationsSetCharm struct {
+	Applications []ApplicationSetCharm `json:"applications"`
+}
+
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints, if set, holds the source CIDRs from which each
+	// of the given endpoints may be reached, keyed by endpoint name.
+	// The empty endpoint name stands for all endpoints.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint holds the source CIDRs from which an exposed endpoint
+// may be reached. An endpoint with no CIDRs may be reached from any
+// address.
+type ExposedEndpoint struct {
+	ExposeToCIDRs []string `json:"expose-to-cidrs,omitempty"`
+}
+
+// HookSandbox describes the confinement in which the hooks and actions
+// of an application's units are run.
+type HookSandbox struct {
+	// User is the name of the user the hooks are run as.
+	User string `json:"user,omitempty"`
+
+	// MemoryLimit is the maximum memory, in MiB, available to a
+	// running hook. Zero means no limit.
+	MemoryLimit uint64 `json:"memory-limit,omitempty"`
+
+	// CPUQuota is the percentage of a single CPU's time available
+	// to a running hook. Zero means no limit.
+	CPUQuota int `json:"cpu-quota,omitempty"`
+
+	// LXDContainer is the name of an LXD container on the unit's
+	// machine in which the hooks are run.
+	LXDContainer string `json:"lxd-container,omitempty"`
+}
+
+// ApplicationSetHookSandbox holds the parameters for making the
+// application SetHookSandbox call.
+type ApplicationSetHookSandbox struct {
+	ApplicationName string      `json:"application"`
+	Sandbox         HookSandbox `json:"sandbox"`
+}
+
+// HookSandboxResult holds a hook sandbox or an error.
+type HookSandboxResult struct {
+	Result HookSandbox `json:"result"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// HookSandboxResults holds the results of a bulk HookSandbox call.
+type HookSandboxResults struct {
+	Results []HookSandboxResult `json:"results"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -355,6 +501,15 @@ type AddApplicationUnits struct {
 // DestroyApplicationUnits holds parameters for the DestroyUnits call.
 type DestroyApplicationUnits struct {
 	UnitNames []string `json:"unit-names"`
+
+	// Force, if set, causes the units to be removed along with their
+	// subordinates and storage attachments even if their agents do
+	// not shut down, as when their hooks are failing.
+	Force bool `json:"force,omitempty"`
+
+	// MaxWait is, when Force is set, how long the units' agents are
+	// given to shut down cleanly before the units are removed.
+	MaxWait time.Duration `json:"max-wait,omitempty"`
 }
 
 // ApplicationDestroy holds the parameters for making the application Destroy call.
@@ -676,6 +831,12 @@ type ControllersSpecs struct {
 	Specs []ControllersSpec `json:"specs"`
 }
 
+// RemoveControllersArgs contains the arguments
+// for the RemoveControllerMachines API call.
+type RemoveControllersArgs struct {
+	MachineTags []string `json:"machine-tags"`
+}
+
 // ControllersChangeResult contains the results
 // of a single EnableHA API call or
 // an error.
@@ -806,6 +967,40 @@ type BundleChangesChange struct {
 	Requires []string `json:"requires"`
 }
 
+// DeployBundleArgs holds parameters for making DeployBundle calls.
+type DeployBundleArgs struct {
+	// BundleDataYAML is the YAML-encoded charm bundle data.
+	BundleDataYAML string `json:"yaml"`
+	// Channel is the charm store channel charms are taken from.
+	Channel string `json:"channel,omitempty"`
+	// Storage holds storage constraints for bundle applications,
+	// replacing or augmenting those in the bundle itself.
+	Storage map[string]map[string]storage.Constraints `json:"storage,omitempty"`
+	// DryRun reports whether the plan should only be computed and
+	// validated, without changing the model.
+	DryRun bool `json:"dry-run,omitempty"`
+}
+
+// DeployBundleResult holds the result of a DeployBundle call.
+type DeployBundleResult struct {
+	// Errors holds possible bundle verification errors.
+	Errors []string `json:"errors,omitempty"`
+	// Plan describes the changes that deploy the bundle, in order.
+	Plan []string `json:"plan,omitempty"`
+	// Events records the changes applied, in order. If the
+	// deployment failed, the changes have been rolled back.
+	Events []BundleDeployEvent `json:"events,omitempty"`
+	// Error holds the error that stopped the deployment, if any.
+	Error *Error `json:"error,omitempty"`
+}
+
+// BundleDeployEvent records the application of a single bundle change.
+type BundleDeployEvent struct {
+	Time    time.Time `json:"time"`
+	Change  string    `json:"change"`
+	Message string    `json:"message"`
+}
+
 // UpgradeMongoParams holds the arguments required to
 // enter upgrade mongo mode.
 type UpgradeMongoParams struct {
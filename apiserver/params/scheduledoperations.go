@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// ScheduledOperation describes an operation on an application which
+// the controller runs at a given time, and optionally repeats.
+type ScheduledOperation struct {
+	// Id identifies the operation within its model. It is ignored
+	// when the operation is added.
+	Id string `json:"id,omitempty"`
+
+	// Kind identifies what the operation does: "upgrade-charm" or
+	// "scale-application".
+	Kind string `json:"kind"`
+
+	// Application is the name of the application operated on.
+	Application string `json:"application"`
+
+	// CharmURL is the URL of the charm to upgrade the application
+	// to, for an "upgrade-charm" operation.
+	CharmURL string `json:"charm-url,omitempty"`
+
+	// Units is the number of units to scale the application to, for
+	// a "scale-application" operation.
+	Units int `json:"units,omitempty"`
+
+	// Due is when the operation is next to run.
+	Due time.Time `json:"due"`
+
+	// Every, if non-zero, is the interval at which the operation
+	// repeats.
+	Every time.Duration `json:"every,omitempty"`
+
+	// Status is "pending" or "failed". It is ignored when the
+	// operation is added.
+	Status string `json:"status,omitempty"`
+
+	// LastRun is when the operation last ran, if it has.
+	LastRun *time.Time `json:"last-run,omitempty"`
+
+	// LastError describes why the operation last failed, if it did.
+	LastError string `json:"last-error,omitempty"`
+}
+
+// ScheduledOperationArgs holds the arguments to
+// ScheduledOperations.AddScheduledOperations.
+type ScheduledOperationArgs struct {
+	Args []ScheduledOperation `json:"args"`
+}
+
+// AddScheduledOperationResult holds the id assigned to a scheduled
+// operation, or the error which prevented it from being added.
+type AddScheduledOperationResult struct {
+	Id    string `json:"id,omitempty"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// AddScheduledOperationResults holds the results of
+// ScheduledOperations.AddScheduledOperations.
+type AddScheduledOperationResults struct {
+	Results []AddScheduledOperationResult `json:"results"`
+}
+
+// ScheduledOperationIds holds the arguments to
+// ScheduledOperations.CancelScheduledOperations.
+type ScheduledOperationIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ListScheduledOperationsResults holds the scheduled operations of a
+// model.
+type ListScheduledOperationsResults struct {
+	Operations []ScheduledOperation `json:"operations"`
+}
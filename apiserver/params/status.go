@@ -51,6 +51,9 @@ type MachineStatus struct {
 	Jobs       []multiwatcher.MachineJob `json:"jobs"`
 	HasVote    bool                      `json:"has-vote"`
 	WantsVote  bool                      `json:"wants-vote"`
+
+	// Maintenance is true if the machine is in maintenance mode.
+	Maintenance bool `json:"maintenance,omitempty"`
 }
 
 // ApplicationStatus holds status info about an application.
@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"github.com/juju/version"
+)
+
+// UpgradePrecheck holds the outcome of one of the checks made before
+// an upgrade of a model's agents is allowed to proceed.
+type UpgradePrecheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// UpgradePrecheckResults holds the outcome of the checks made before
+// an upgrade.
+type UpgradePrecheckResults struct {
+	Checks []UpgradePrecheck `json:"checks"`
+}
+
+// SkipUpgradePrechecks records that an upgrade to Version is started
+// without the upgrade prechecks.
+type SkipUpgradePrechecks struct {
+	Version version.Number `json:"version"`
+}
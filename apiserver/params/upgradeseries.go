@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// UpgradeSeriesPrepareArg holds the machine to prepare for a series
+// upgrade, and the series it is to be upgraded to.
+type UpgradeSeriesPrepareArg struct {
+	MachineTag string `json:"machine-tag"`
+	Series     string `json:"series"`
+}
+
+// UpgradeSeriesPrepareArgs holds the parameters for a
+// PrepareUpgradeSeries call.
+type UpgradeSeriesPrepareArgs struct {
+	Args []UpgradeSeriesPrepareArg `json:"args"`
+}
+
+// UpgradeSeriesStatusResult holds a unit's progress through the series
+// upgrade of its machine. The status is empty if the unit is not
+// taking part in a series upgrade.
+type UpgradeSeriesStatusResult struct {
+	Status string `json:"status,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// UpgradeSeriesStatusResults holds the results of an
+// UpgradeSeriesUnitStatus call.
+type UpgradeSeriesStatusResults struct {
+	Results []UpgradeSeriesStatusResult `json:"results"`
+}
+
+// UpgradeSeriesStatusArg holds the series upgrade status to set on a
+// unit.
+type UpgradeSeriesStatusArg struct {
+	Entity Entity `json:"entity"`
+	Status string `json:"status"`
+}
+
+// UpgradeSeriesStatusArgs holds the parameters for a
+// SetUpgradeSeriesUnitStatus call.
+type UpgradeSeriesStatusArgs struct {
+	Args []UpgradeSeriesStatusArg `json:"args"`
+}
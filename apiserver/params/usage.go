@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// MachineUsageSet holds the resource usage of one or more machines,
+// as reported by their agents.
+type MachineUsageSet struct {
+	Usage []MachineUsage `json:"usage"`
+}
+
+// MachineUsage holds the resource usage of a machine, and of the
+// units deployed to it. Memory and disk sizes are in bytes.
+type MachineUsage struct {
+	Tag         string      `json:"tag"`
+	CPUPercent  float64     `json:"cpu-percent"`
+	MemoryUsed  uint64      `json:"memory-used"`
+	MemoryTotal uint64      `json:"memory-total"`
+	DiskUsed    uint64      `json:"disk-used"`
+	DiskTotal   uint64      `json:"disk-total"`
+	Units       []UnitUsage `json:"units,omitempty"`
+
+	// Received and Transmitted are the numbers of bytes received
+	// and transmitted by the machine's network devices. When usage
+	// is reported, they are the bytes since the previous report;
+	// when it is returned to clients, the totals accounted since
+	// TrafficSince.
+	Received     uint64    `json:"received,omitempty"`
+	Transmitted  uint64    `json:"transmitted,omitempty"`
+	TrafficSince time.Time `json:"traffic-since,omitempty"`
+
+	// Updated is the time at which the usage was recorded by the
+	// controller. It is ignored when the usage is reported.
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+// UnitUsage holds the resource usage of a unit. Memory and disk sizes
+// are in bytes.
+type UnitUsage struct {
+	Tag        string  `json:"tag"`
+	CPUPercent float64 `json:"cpu-percent"`
+	MemoryUsed uint64  `json:"memory-used"`
+	DiskUsed   uint64  `json:"disk-used"`
+}
+
+// ApplicationTraffic holds the numbers of bytes received and
+// transmitted by the machines hosting an application's units.
+type ApplicationTraffic struct {
+	Tag         string `json:"tag"`
+	Received    uint64 `json:"received"`
+	Transmitted uint64 `json:"transmitted"`
+}
+
+// ModelUsageResult holds the resource usage last reported by each of
+// the machines of a model, and the network traffic accounted for the
+// model's applications.
+type ModelUsageResult struct {
+	Machines []MachineUsage `json:"machines"`
+
+	// TrafficAccounting reports whether the model's network
+	// traffic is currently being accounted.
+	TrafficAccounting bool                 `json:"traffic-accounting"`
+	Applications      []ApplicationTraffic `json:"applications,omitempty"`
+}
@@ -70,3 +70,57 @@ type AddUserResult struct {
 	SecretKey []byte `json:"secret-key,omitempty"`
 	Error     *Error `json:"error,omitempty"`
 }
+
+// CreateRegistrationTokens holds the parameters for creating
+// registration tokens for existing users.
+type CreateRegistrationTokens struct {
+	Tokens []CreateRegistrationToken `json:"tokens"`
+}
+
+// CreateRegistrationToken holds the parameters for creating a
+// registration token for one user.
+type CreateRegistrationToken struct {
+	UserTag string `json:"user-tag"`
+
+	// Lifetime is how long the token may be used to register. If
+	// it is zero, the controller's default lifetime is used.
+	Lifetime time.Duration `json:"lifetime,omitempty"`
+}
+
+// RegistrationTokenResults holds the results of the bulk
+// CreateRegistrationTokens API call.
+type RegistrationTokenResults struct {
+	Results []RegistrationTokenResult `json:"results"`
+}
+
+// RegistrationTokenResult returns the secret key with which a user
+// may register, and the time after which it may no longer be used,
+// or an error.
+type RegistrationTokenResult struct {
+	SecretKey []byte    `json:"secret-key,omitempty"`
+	Expiry    time.Time `json:"expiry,omitempty"`
+	Error     *Error    `json:"error,omitempty"`
+}
+
+// WhoAmIResult holds the identity with which an API connection was
+// authenticated, and the access that identity has been granted.
+type WhoAmIResult struct {
+	UserTag          string        `json:"user-tag"`
+	DisplayName      string        `json:"display-name,omitempty"`
+	ControllerAccess string        `json:"controller-access,omitempty"`
+	Models           []WhoAmIModel `json:"models"`
+	LastConnection   *time.Time    `json:"last-connection,omitempty"`
+
+	// MacaroonExpiry holds the time at which the macaroons used to
+	// log in expire. It is nil if the login did not use macaroons,
+	// or if they do not expire.
+	MacaroonExpiry *time.Time `json:"macaroon-expiry,omitempty"`
+}
+
+// WhoAmIModel holds the access a user has been granted to a model.
+type WhoAmIModel struct {
+	ModelTag string `json:"model-tag"`
+	Name     string `json:"name"`
+	OwnerTag string `json:"owner-tag"`
+	Access   string `json:"access"`
+}
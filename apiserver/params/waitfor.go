@@ -0,0 +1,45 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// WaitForCondition describes a single condition about an entity in
+// the model, such as `status == active` for a unit.
+type WaitForCondition struct {
+	// Tag identifies the application, unit or machine that the
+	// condition applies to.
+	Tag string `json:"tag"`
+
+	// Field names the attribute of the entity to compare, for
+	// example "status" or "unit-count".
+	Field string `json:"field"`
+
+	// Op is the comparison operator: one of ==, !=, <, <=, > or >=.
+	Op string `json:"op"`
+
+	// Value is the value the field is compared against.
+	Value string `json:"value"`
+}
+
+// WaitForArgs holds the arguments to the WaitFor.WaitFor API call.
+type WaitForArgs struct {
+	// Conditions must all be satisfied at the same time for the
+	// call to report success.
+	Conditions []WaitForCondition `json:"conditions"`
+
+	// Timeout is the maximum time the server will wait before
+	// returning. The server may impose a shorter limit.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// WaitForResult holds the result of the WaitFor.WaitFor API call.
+type WaitForResult struct {
+	// Satisfied reports whether all the conditions were met.
+	Satisfied bool `json:"satisfied"`
+
+	// Unsatisfied describes the conditions that were not met when
+	// the call returned.
+	Unsatisfied []string `json:"unsatisfied,omitempty"`
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/provisioner"
 	"github.com/juju/juju/environs/imagemetadata"
 	imagetesting "github.com/juju/juju/environs/imagemetadata/testing"
+	"github.com/juju/juju/environs/simplestreams"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/juju/keys"
 	"github.com/juju/juju/state/cloudimagemetadata"
@@ -113,6 +114,38 @@ func (s *ImageMetadataSuite) TestMetadataFromState(c *gc.C) {
 	s.assertImageMetadataResults(c, result, expected...)
 }
 
+func (s *ImageMetadataSuite) TestMetadataCustomPreferred(c *gc.C) {
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	public := s.expectedDataSoureImageMetadata()
+	custom := make([]params.CloudImageMetadata, len(public[0]))
+	for i, m := range public[0] {
+		m.ImageId = "custom-" + m.ImageId
+		m.Source = "custom"
+		m.Priority = simplestreams.CUSTOM_CLOUD_DATA
+		custom[i] = m
+	}
+
+	// Write public metadata to state before the custom metadata for
+	// the same images, which should nonetheless be preferred.
+	for _, ms := range [][]params.CloudImageMetadata{public[0], custom} {
+		err := s.State.CloudImageMetadataStorage.SaveMetadata(s.convertCloudImageMetadata(ms))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(s.machines))
+	for _, one := range result.Results {
+		c.Assert(one.Result.ImageMetadata, gc.HasLen, 4)
+		c.Check(one.Result.ImageMetadata[0].Source, gc.Equals, "custom")
+		c.Check(one.Result.ImageMetadata[1].Source, gc.Equals, "custom")
+		c.Check(one.Result.ImageMetadata[2].Source, gc.Equals, "default cloud images")
+		c.Check(one.Result.ImageMetadata[3].Source, gc.Equals, "default cloud images")
+	}
+}
+
 func (s *ImageMetadataSuite) getTestMachinesTags(c *gc.C) params.Entities {
 
 	testMachines := make([]params.Entity, len(s.machines))
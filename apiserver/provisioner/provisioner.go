@@ -44,6 +44,7 @@ type ProvisionerAPI struct {
 	*common.DeadEnsurer
 	*common.PasswordChanger
 	*common.LifeGetter
+	*common.MaintenanceGetter
 	*common.StateAddresser
 	*common.APIAddresser
 	*common.ModelWatcher
@@ -116,6 +117,7 @@ func NewProvisionerAPI(st *state.State, resources facade.Resources, authorizer f
 		DeadEnsurer:             common.NewDeadEnsurer(st, getAuthFunc),
 		PasswordChanger:         common.NewPasswordChanger(st, getAuthFunc),
 		LifeGetter:              common.NewLifeGetter(st, getAuthFunc),
+		MaintenanceGetter:       common.NewMaintenanceGetter(st, getAuthFunc),
 		StateAddresser:          common.NewStateAddresser(st),
 		APIAddresser:            common.NewAPIAddresser(st, resources),
 		ModelWatcher:            common.NewModelWatcher(st, resources, authorizer),
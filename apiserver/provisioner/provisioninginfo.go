@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
@@ -89,6 +90,11 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
+	instanceTypes := p.availableInstanceTypes()
+	cloudInit, err := p.machineCloudInitCustomization(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get cloud-init customization")
+	}
 
 	return &params.ProvisioningInfo{
 		Constraints:      cons,
@@ -101,6 +107,27 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		EndpointBindings: endpointBindings,
 		ImageMetadata:    imageMetadata,
 		ControllerConfig: controllerCfg,
+		InstanceTypes:    instanceTypes,
+
+		CloudInitCustomization: cloudInit,
+	}, nil
+}
+
+// machineCloudInitCustomization returns the extra cloud-init directives
+// to apply when provisioning the machine, or nil if there are none.
+func (p *ProvisionerAPI) machineCloudInitCustomization(m *state.Machine) (*params.CloudInitCustomization, error) {
+	custom, err := p.st.EffectiveCloudInitCustomization(m.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(custom.Packages) == 0 && custom.AptMirror == "" && len(custom.RunCmds) == 0 && len(custom.SSHCAKeys) == 0 {
+		return nil, nil
+	}
+	return &params.CloudInitCustomization{
+		Packages:  custom.Packages,
+		AptMirror: custom.AptMirror,
+		RunCmds:   custom.RunCmds,
+		SSHCAKeys: custom.SSHCAKeys,
 	}, nil
 }
 
@@ -370,6 +397,30 @@ func (p *ProvisionerAPI) availableImageMetadata(m *state.Machine) ([]params.Clou
 	return data, nil
 }
 
+// availableInstanceTypes returns the instance types cached for the
+// model, which the provider may use to match the machine's constraints
+// without querying the cloud. Failure to get the instance types is not
+// fatal; the provider will query the cloud itself.
+func (p *ProvisionerAPI) availableInstanceTypes() []params.InstanceType {
+	env, err := environs.GetEnviron(p.configGetter, environs.New)
+	if err != nil {
+		logger.Warningf("cannot get instance types: %v", err)
+		return nil
+	}
+	info, err := common.CachedInstanceTypes(p.st, env, clock.WallClock)
+	if errors.IsNotSupported(err) {
+		return nil
+	} else if err != nil {
+		logger.Warningf("cannot get instance types: %v", err)
+		return nil
+	}
+	result := make([]params.InstanceType, len(info.InstanceTypes))
+	for i, itype := range info.InstanceTypes {
+		result[i] = common.InstanceTypeToParams(itype)
+	}
+	return result
+}
+
 // constructImageConstraint returns model-specific criteria used to look for image metadata.
 func (p *ProvisionerAPI) constructImageConstraint(m *state.Machine) (*imagemetadata.ImageConstraint, environs.Environ, error) {
 	// If we can determine current region,
@@ -565,7 +616,9 @@ func (p *ProvisionerAPI) imageMetadataFromDataSources(env environs.Environ, cons
 }
 
 // metadataList is a convenience type enabling to sort
-// a collection of CloudImageMetadata in order of priority.
+// a collection of CloudImageMetadata in order of priority,
+// highest first, so that custom image metadata is preferred
+// over that from upstream simplestreams.
 type metadataList []params.CloudImageMetadata
 
 // Implements sort.Interface
@@ -575,7 +628,7 @@ func (m metadataList) Len() int {
 
 // Implements sort.Interface and sorts image metadata by priority.
 func (m metadataList) Less(i, j int) bool {
-	return m[i].Priority < m[j].Priority
+	return m[i].Priority > m[j].Priority
 }
 
 // Implements sort.Interface
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver/provisioner"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
@@ -324,6 +325,56 @@ func (s *withoutControllerSuite) TestStorageProviderFallbackToType(c *gc.C) {
 	})
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithCachedInstanceTypes(c *gc.C) {
+	err := s.State.SetInstanceTypes([]instances.InstanceType{{
+		Name:     "large",
+		Arches:   []string{"amd64"},
+		CpuCores: 4,
+		Mem:      16384,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.InstanceTypes, jc.DeepEquals, []params.InstanceType{{
+		Name:     "large",
+		Arches:   []string{"amd64"},
+		CpuCores: 4,
+		Mem:      16384,
+	}})
+}
+
+func (s *withoutControllerSuite) TestProvisioningInfoWithCloudInitCustomization(c *gc.C) {
+	err := s.State.SetCloudInitCustomization(s.State.ModelTag(), state.CloudInitCustomization{
+		Packages: []string{"htop"},
+		RunCmds:  []string{"echo model"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetCloudInitCustomization(s.machines[0].Tag(), state.CloudInitCustomization{
+		AptMirror: "http://mirror.example.com/ubuntu",
+		RunCmds:   []string{"echo machine"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.CloudInitCustomization, jc.DeepEquals, &params.CloudInitCustomization{
+		Packages:  []string{"htop"},
+		AptMirror: "http://mirror.example.com/ubuntu",
+		RunCmds:   []string{"echo model", "echo machine"},
+	})
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoPermissions(c *gc.C) {
 	// Login as a machine agent for machine 0.
 	anAuthorizer := s.authorizer
@@ -18,17 +18,23 @@ var readOnlyCalls = set.NewStrings(
 	"Action.ListRunning",
 	"Action.ListCompleted",
 	"Action.ApplicationsCharmsActions",
+	"AgentLogging.AgentLogging",
+	"Alerts.ListAlertRules",
+	"Alerts.ListAlerts",
 	"AllWatcher.Next",
 	"Annotations.Get",
 	"Application.GetConstraints",
 	"Application.CharmRelations",
 	"Application.Get",
+	"Application.ValidateDeploy",
 	"Block.List",
+	"Bundle.ExportBundle",
 	"Charms.CharmInfo",
 	"Charms.IsMetered",
 	"Charms.List",
 	"Client.AgentVersion",
 	"Client.APIHostPorts",
+	"Client.CanaryUpgrade",
 	"Client.ModelGet",
 	"Client.ModelInfo",
 	"Client.ModelUserInfo",
@@ -48,10 +54,16 @@ var readOnlyCalls = set.NewStrings(
 	"Client.WatchAll",
 	"Cloud.Cloud",
 	"Cloud.Credentials",
+	"CloudInit.CloudInitCustomizations",
+	"DefaultConstraints.DefaultConstraints",
+	"DefaultConstraints.ResolveConstraints",
 	// TODO: add controller work.
+	"HookTimings.HookTimings",
 	"KeyManager.ListKeys",
+	"ModelEvents.ModelEvents",
 	"ModelManager.ModelInfo",
 	"Pinger.Ping",
+	"ScheduledOperations.ListScheduledOperations",
 	"Spaces.ListSpaces",
 	"Storage.ListStorageDetails",
 	"Storage.ListFilesystems",
@@ -60,8 +72,11 @@ var readOnlyCalls = set.NewStrings(
 	"Subnets.AllSpaces",
 	"Subnets.AllZones",
 	"Subnets.ListSubnets",
+	"UpgradePrechecks.Prechecks",
 	"UserManager.UserInfo",
 	"UserManager.CreateLocalLoginMacaroon",
+	"UserManager.WhoAmI",
+	"WaitFor.WaitFor",
 )
 
 // isCallReadOnly returns whether or not the method on the facade
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"golang.org/x/crypto/nacl/secretbox"
 	"gopkg.in/juju/names.v2"
 
@@ -29,6 +30,7 @@ const (
 // login credentials.
 type registerUserHandler struct {
 	ctxt                     httpContext
+	clock                    clock.Clock
 	createLocalLoginMacaroon func(names.UserTag) (*macaroon.Macaroon, error)
 }
 
@@ -98,6 +100,9 @@ func (h *registerUserHandler) processPost(req *http.Request, st *state.State) (*
 	if len(user.SecretKey()) != secretboxKeyLength {
 		return nil, errors.NotFoundf("secret key for user %q", user.Name())
 	}
+	if user.SecretKeyExpired(h.clock.Now()) {
+		return nil, errors.Errorf("secret key for user %q has expired", user.Name())
+	}
 	var key [secretboxKeyLength]byte
 	var nonce [secretboxNonceLength]byte
 	copy(key[:], user.SecretKey())
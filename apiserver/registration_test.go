@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/testing/httptesting"
@@ -169,6 +170,24 @@ func (s *registrationSuite) TestRegisterNoSecretKey(c *gc.C) {
 	)
 }
 
+func (s *registrationSuite) TestRegisterExpiredSecretKey(c *gc.C) {
+	secretKey, err := s.bob.ResetSecretKey(time.Now().Add(-time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	validNonce := []byte(strings.Repeat("X", 24))
+	ciphertext := s.sealBox(c, validNonce, secretKey, `{"password": "hunter2"}`)
+	s.testInvalidRequest(c,
+		fmt.Sprintf(
+			`{"user": "user-bob", "nonce": "%s", "cipher-text": "%s"}`,
+			base64.StdEncoding.EncodeToString(validNonce),
+			base64.StdEncoding.EncodeToString(ciphertext),
+		), `secret key for user "bob" has expired`, "",
+		http.StatusInternalServerError,
+	)
+	err = s.bob.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.bob.PasswordValid("hunter2"), jc.IsFalse)
+}
+
 func (s *registrationSuite) TestRegisterInvalidRequestPayload(c *gc.C) {
 	validNonce := []byte(strings.Repeat("X", 24))
 	ciphertext := s.sealBox(c, validNonce, s.bob.SecretKey(), "[]")
@@ -20,6 +20,7 @@ var controllerFacadeNames = set.NewStrings(
 	"AllModelWatcher",
 	"Cloud",
 	"Controller",
+	"ControllerReport",
 	"MigrationTarget",
 	"ModelManager",
 	"UserManager",
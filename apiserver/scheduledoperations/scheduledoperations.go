@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package scheduledoperations provides the facade through which
+// clients schedule operations on a model's applications, such as charm
+// upgrades and scaling, for the controller to run at a later time.
+package scheduledoperations
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("ScheduledOperations", 1, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	common.BlockGetter
+	ModelTag() names.ModelTag
+	AddScheduledOperation(state.ScheduledOperation) (string, error)
+	CancelScheduledOperation(id string) error
+	ScheduledOperations() ([]state.ScheduledOperation, error)
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// API is the endpoint which implements the ScheduledOperations facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+	check   *common.BlockChecker
+}
+
+// NewAPI creates a new instance of the ScheduledOperations facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+		check:   common.NewBlockChecker(backend),
+	}, nil
+}
+
+func (api *API) checkPermission(access description.Access) error {
+	ok, err := api.auth.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// AddScheduledOperations schedules the given operations, returning
+// the id assigned to each. Only users who may change the model may
+// schedule operations on it.
+func (api *API) AddScheduledOperations(args params.ScheduledOperationArgs) (params.AddScheduledOperationResults, error) {
+	var results params.AddScheduledOperationResults
+	if err := api.checkPermission(description.WriteAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.AddScheduledOperationResult, len(args.Args))
+	for i, arg := range args.Args {
+		id, err := api.backend.AddScheduledOperation(state.ScheduledOperation{
+			Kind:        state.ScheduledOperationKind(arg.Kind),
+			Application: arg.Application,
+			CharmURL:    arg.CharmURL,
+			Units:       arg.Units,
+			Due:         arg.Due,
+			Every:       arg.Every,
+		})
+		results.Results[i] = params.AddScheduledOperationResult{
+			Id:    id,
+			Error: common.ServerError(err),
+		}
+	}
+	return results, nil
+}
+
+// CancelScheduledOperations removes the given scheduled operations,
+// so that they do not run again. Only users who may change the model
+// may cancel its scheduled operations.
+func (api *API) CancelScheduledOperations(args params.ScheduledOperationIds) (params.ErrorResults, error) {
+	var results params.ErrorResults
+	if err := api.checkPermission(description.WriteAccess); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		err := api.backend.CancelScheduledOperation(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListScheduledOperations returns the scheduled operations of the
+// model, ordered by when they are due.
+func (api *API) ListScheduledOperations() (params.ListScheduledOperationsResults, error) {
+	var result params.ListScheduledOperationsResults
+	if err := api.checkPermission(description.ReadAccess); err != nil {
+		return result, errors.Trace(err)
+	}
+	ops, err := api.backend.ScheduledOperations()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Operations = make([]params.ScheduledOperation, len(ops))
+	for i, op := range ops {
+		result.Operations[i] = params.ScheduledOperation{
+			Id:          op.Id,
+			Kind:        string(op.Kind),
+			Application: op.Application,
+			CharmURL:    op.CharmURL,
+			Units:       op.Units,
+			Due:         op.Due,
+			Every:       op.Every,
+			Status:      string(op.Status),
+			LastError:   op.LastError,
+		}
+		if !op.LastRun.IsZero() {
+			lastRun := op.LastRun
+			result.Operations[i].LastRun = &lastRun
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/scheduledoperations"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type ScheduledOperationsSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *scheduledoperations.API
+	due        time.Time
+}
+
+var _ = gc.Suite(&ScheduledOperationsSuite{})
+
+func (s *ScheduledOperationsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{}
+	s.due = time.Date(2016, 11, 5, 2, 0, 0, 0, time.UTC)
+	var err error
+	s.api, err = scheduledoperations.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ScheduledOperationsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := scheduledoperations.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ScheduledOperationsSuite) TestReadAccess(c *gc.C) {
+	// Users with read access may list operations, but not change them.
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasReadTag = names.NewUserTag("mary@local")
+	_, err := s.api.ListScheduledOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.AddScheduledOperations(params.ScheduledOperationArgs{
+		Args: []params.ScheduledOperation{{Kind: "scale-application", Application: "mysql", Due: s.due}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = s.api.CancelScheduledOperations(params.ScheduledOperationIds{Ids: []string{"1"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckCallNames(c, "ScheduledOperations")
+}
+
+func (s *ScheduledOperationsSuite) TestWriteAccess(c *gc.C) {
+	// Model admin access isn't needed to schedule operations.
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasWriteTag = names.NewUserTag("mary@local")
+	_, err := s.api.AddScheduledOperations(params.ScheduledOperationArgs{
+		Args: []params.ScheduledOperation{{Kind: "scale-application", Application: "mysql", Due: s.due}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.CancelScheduledOperations(params.ScheduledOperationIds{Ids: []string{"1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCallNames(c, "AddScheduledOperation", "CancelScheduledOperation")
+}
+
+func (s *ScheduledOperationsSuite) TestAddScheduledOperations(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.NotFoundf(`application "mysql"`))
+	results, err := s.api.AddScheduledOperations(params.ScheduledOperationArgs{
+		Args: []params.ScheduledOperation{{
+			Kind:        "upgrade-charm",
+			Application: "wordpress",
+			CharmURL:    "cs:quantal/wordpress-2",
+			Due:         s.due,
+		}, {
+			Kind:        "scale-application",
+			Application: "mysql",
+			Units:       1,
+			Due:         s.due,
+			Every:       7 * 24 * time.Hour,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Id, gc.Equals, "1")
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Id, gc.Equals, "")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "mysql" not found`)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"AddScheduledOperation", []interface{}{state.ScheduledOperation{
+			Kind:        state.UpgradeCharmOperation,
+			Application: "wordpress",
+			CharmURL:    "cs:quantal/wordpress-2",
+			Due:         s.due,
+		}}},
+		{"AddScheduledOperation", []interface{}{state.ScheduledOperation{
+			Kind:        state.ScaleApplicationOperation,
+			Application: "mysql",
+			Units:       1,
+			Due:         s.due,
+			Every:       7 * 24 * time.Hour,
+		}}},
+	})
+}
+
+func (s *ScheduledOperationsSuite) TestAddScheduledOperationsRequiresWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.AddScheduledOperations(params.ScheduledOperationArgs{
+		Args: []params.ScheduledOperation{{Kind: "scale-application", Application: "mysql", Due: s.due}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *ScheduledOperationsSuite) TestAddScheduledOperationsBlocked(c *gc.C) {
+	s.backend.block = state.ChangeBlock
+	_, err := s.api.AddScheduledOperations(params.ScheduledOperationArgs{
+		Args: []params.ScheduledOperation{{Kind: "scale-application", Application: "mysql", Due: s.due}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *ScheduledOperationsSuite) TestCancelScheduledOperations(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.NotFoundf("scheduled operation 9"))
+	results, err := s.api.CancelScheduledOperations(params.ScheduledOperationIds{
+		Ids: []string{"1", "9"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "scheduled operation 9 not found")
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CancelScheduledOperation", []interface{}{"1"}},
+		{"CancelScheduledOperation", []interface{}{"9"}},
+	})
+}
+
+func (s *ScheduledOperationsSuite) TestCancelScheduledOperationsRequiresWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.CancelScheduledOperations(params.ScheduledOperationIds{Ids: []string{"1"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *ScheduledOperationsSuite) TestListScheduledOperations(c *gc.C) {
+	lastRun := s.due.Add(-7 * 24 * time.Hour)
+	s.backend.ops = []state.ScheduledOperation{{
+		Id:          "1",
+		Kind:        state.UpgradeCharmOperation,
+		Application: "wordpress",
+		CharmURL:    "cs:quantal/wordpress-2",
+		Due:         s.due,
+		Status:      state.ScheduledOperationPending,
+	}, {
+		Id:          "2",
+		Kind:        state.ScaleApplicationOperation,
+		Application: "mysql",
+		Units:       1,
+		Due:         s.due.Add(time.Hour),
+		Every:       7 * 24 * time.Hour,
+		Status:      state.ScheduledOperationPending,
+		LastRun:     lastRun,
+		LastError:   "boom",
+	}}
+	result, err := s.api.ListScheduledOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListScheduledOperationsResults{
+		Operations: []params.ScheduledOperation{{
+			Id:          "1",
+			Kind:        "upgrade-charm",
+			Application: "wordpress",
+			CharmURL:    "cs:quantal/wordpress-2",
+			Due:         s.due,
+			Status:      "pending",
+		}, {
+			Id:          "2",
+			Kind:        "scale-application",
+			Application: "mysql",
+			Units:       1,
+			Due:         s.due.Add(time.Hour),
+			Every:       7 * 24 * time.Hour,
+			Status:      "pending",
+			LastRun:     &lastRun,
+			LastError:   "boom",
+		}},
+	})
+	s.backend.stub.CheckCallNames(c, "ScheduledOperations")
+}
+
+func (s *ScheduledOperationsSuite) TestListScheduledOperationsRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.ListScheduledOperations()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ScheduledOperationsSuite) TestListScheduledOperationsError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.New("boom"))
+	_, err := s.api.ListScheduledOperations()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	stub  gitjujutesting.Stub
+	block state.BlockType
+	ops   []state.ScheduledOperation
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+}
+
+func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	if m.block == t {
+		return mockBlock{t: t}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (m *mockBackend) AddScheduledOperation(op state.ScheduledOperation) (string, error) {
+	m.stub.AddCall("AddScheduledOperation", op)
+	if err := m.stub.NextErr(); err != nil {
+		return "", err
+	}
+	return "1", nil
+}
+
+func (m *mockBackend) CancelScheduledOperation(id string) error {
+	m.stub.AddCall("CancelScheduledOperation", id)
+	return m.stub.NextErr()
+}
+
+func (m *mockBackend) ScheduledOperations() ([]state.ScheduledOperation, error) {
+	m.stub.AddCall("ScheduledOperations")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.ops, nil
+}
+
+type mockBlock struct {
+	state.Block
+	t state.BlockType
+}
+
+func (m mockBlock) Type() state.BlockType { return m.t }
+
+func (m mockBlock) Message() string { return "blocked" }
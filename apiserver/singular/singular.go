@@ -48,7 +48,7 @@ func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
 }
 
 // Facade allows controller machines to request exclusive rights to administer
-// some specific model for a limited time.
+// some specific model, or to run some named worker for it, for a limited time.
 type Facade struct {
 	auth    facade.Authorizer
 	model   names.ModelTag
@@ -88,13 +88,40 @@ func (facade *Facade) Claim(args params.SingularClaims) (result params.ErrorResu
 		case !allowedDuration(claim.Duration):
 			err = common.ErrPerm
 		default:
-			err = facade.claimer.Claim(facade.model.Id(), claim.ControllerTag, claim.Duration)
+			err = facade.claimer.Claim(facade.leaseName(claim.Worker), claim.ControllerTag, claim.Duration)
 		}
 		result.Results[i].Error = common.ServerError(err)
 	}
 	return result
 }
 
+// WaitWorkers waits for each of the supplied singular leases -- those of
+// named workers, or the model-wide lease -- to expire. (In practice, any
+// requests that do not refer to the connection's model will be rejected.)
+func (facade *Facade) WaitWorkers(args params.SingularWaits) (result params.ErrorResults) {
+	result.Results = make([]params.ErrorResult, len(args.Waits))
+	for i, wait := range args.Waits {
+		var err error
+		switch {
+		case wait.ModelTag != facade.model.String():
+			err = common.ErrPerm
+		default:
+			err = facade.claimer.WaitUntilExpired(facade.leaseName(wait.Worker))
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
+
+// leaseName returns the name of the singular lease for the named worker
+// in the facade's model, or of the model-wide lease if worker is empty.
+func (facade *Facade) leaseName(worker string) string {
+	if worker == "" {
+		return facade.model.Id()
+	}
+	return state.SingularWorkerLease(facade.model.Id(), worker)
+}
+
 // allowedDuration returns true if the supplied duration is at least one second,
 // and no more than one minute. (We expect to refine the lease-length times, but
 // these seem like reasonable bounds.)
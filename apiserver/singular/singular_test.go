@@ -170,6 +170,72 @@ func (s *SingularSuite) TestWait(c *gc.C) {
 	}})
 }
 
+func (s *SingularSuite) TestWorkerClaims(c *gc.C) {
+	claims := params.SingularClaims{
+		Claims: []params.SingularClaim{{
+			ModelTag:      coretesting.ModelTag.String(),
+			ControllerTag: "machine-123",
+			Duration:      time.Minute,
+			Worker:        "storage-provisioner",
+		}, {
+			ModelTag:      coretesting.ModelTag.String(),
+			ControllerTag: "machine-456", // rejected
+			Duration:      time.Minute,
+			Worker:        "storage-provisioner",
+		}},
+	}
+
+	backend := &mockBackend{}
+	facade, err := singular.NewFacade(backend, mockAuth{})
+	c.Assert(err, jc.ErrorIsNil)
+	result := facade.Claim(claims)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	checkDenied(c, result.Results[1])
+
+	backend.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "Claim",
+		Args: []interface{}{
+			coretesting.ModelTag.Id() + ":storage-provisioner",
+			"machine-123",
+			time.Minute,
+		},
+	}})
+}
+
+func (s *SingularSuite) TestWaitWorkers(c *gc.C) {
+	waits := params.SingularWaits{
+		Waits: []params.SingularWait{{
+			ModelTag: "machine-123", // rejected
+			Worker:   "storage-provisioner",
+		}, {
+			ModelTag: coretesting.ModelTag.String(), // stub-error
+			Worker:   "storage-provisioner",
+		}, {
+			ModelTag: coretesting.ModelTag.String(), // success
+		}},
+	}
+
+	backend := &mockBackend{}
+	backend.stub.SetErrors(errors.New("zap!"), nil)
+	facade, err := singular.NewFacade(backend, mockAuth{})
+	c.Assert(err, jc.ErrorIsNil)
+	result := facade.WaitWorkers(waits)
+	c.Assert(result.Results, gc.HasLen, 3)
+
+	checkDenied(c, result.Results[0])
+	c.Check(result.Results[1].Error, gc.ErrorMatches, "zap!")
+	c.Check(result.Results[2].Error, gc.IsNil)
+
+	backend.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "WaitUntilExpired",
+		Args:     []interface{}{coretesting.ModelTag.Id() + ":storage-provisioner"},
+	}, {
+		FuncName: "WaitUntilExpired",
+		Args:     []interface{}{coretesting.ModelTag.Id()},
+	}})
+}
+
 func checkDenied(c *gc.C, result params.ErrorResult) {
 	c.Check(result.Error, gc.ErrorMatches, "permission denied")
 	c.Check(result.Error, jc.Satisfies, params.IsCodeUnauthorized)
@@ -80,6 +80,17 @@ func (api *spacesAPI) ListSpaces() (results params.ListSpacesResults, err error)
 		return results, common.ServerError(errors.Trace(err))
 	}
 
+	result, err := common.CachedRead(api.backing, state.SpacesReadCache, "list-spaces", func() (interface{}, error) {
+		return api.listSpaces()
+	})
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	return result.(params.ListSpacesResults), nil
+}
+
+// listSpaces reads all the model's spaces and their subnets.
+func (api *spacesAPI) listSpaces() (results params.ListSpacesResults, err error) {
 	spaces, err := api.backing.AllSpaces()
 	if err != nil {
 		return results, errors.Trace(err)
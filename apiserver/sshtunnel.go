@@ -0,0 +1,138 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/websocket"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+// sshTunnelPort is the port on the target machine to which SSH tunnels
+// are connected.
+const sshTunnelPort = "22"
+
+// sshTunnelDial is used to connect to the SSH server of the target
+// machine. It is a variable so that it can be replaced in tests.
+var sshTunnelDial = func(address string) (net.Conn, error) {
+	return net.DialTimeout("tcp", address, 30*time.Second)
+}
+
+// sshTunnelHandler relays an SSH connection from a client to a machine
+// in the model. It allows "juju ssh --proxy-api" to reach machines
+// which only the controller can connect to, such as those behind NAT.
+type sshTunnelHandler struct {
+	ctxt httpContext
+}
+
+func newSSHTunnelHandler(ctxt httpContext) *sshTunnelHandler {
+	return &sshTunnelHandler{ctxt: ctxt}
+}
+
+// ServeHTTP will serve up connections as a websocket, relaying the
+// data sent over it to and from the SSH port of a machine. Only model
+// administrators may open a tunnel.
+//
+// Args for the HTTP request are as follows:
+//   address -> string - the address of a machine in the model to connect to
+func (h *sshTunnelHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	server := websocket.Server{
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+
+			target, err := h.dialTarget(req)
+			if err != nil {
+				sendJSON(conn, &params.ErrorResult{Error: common.ServerError(err)})
+				return
+			}
+			defer target.Close()
+			sendJSON(conn, &params.ErrorResult{})
+
+			conn.PayloadType = websocket.BinaryFrame
+			if err := relaySSHTunnel(conn, target, h.ctxt.stop()); err != nil {
+				if isBrokenPipe(err) {
+					logger.Tracef("ssh tunnel stopped (client disconnected)")
+				} else {
+					logger.Errorf("ssh tunnel error: %v", err)
+				}
+			}
+		},
+	}
+	server.ServeHTTP(w, req)
+}
+
+// dialTarget authenticates the request and connects to the SSH port
+// of the machine it names.
+func (h *sshTunnelHandler) dialTarget(req *http.Request) (net.Conn, error) {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	isAdmin, err := hasPermission(st.UserAccess, entity.Tag(), description.AdminAccess, st.ModelTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+
+	address := req.URL.Query().Get("address")
+	if address == "" {
+		return nil, errors.NotValidf("empty address")
+	}
+	// Only connect to the machines of the model, so that the tunnel
+	// cannot be used to reach arbitrary hosts from the controller.
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !machinesHaveAddress(machines, address) {
+		return nil, errors.NotFoundf("machine with address %q", address)
+	}
+	target, err := sshTunnelDial(net.JoinHostPort(address, sshTunnelPort))
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot connect to %q", address)
+	}
+	return target, nil
+}
+
+func machinesHaveAddress(machines []*state.Machine, address string) bool {
+	for _, m := range machines {
+		for _, addr := range m.Addresses() {
+			if addr.Value == address {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// relaySSHTunnel copies data between the client and the target until
+// either side closes its connection or the server is stopped.
+func relaySSHTunnel(client io.ReadWriter, target io.ReadWriter, stop <-chan struct{}) error {
+	done := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(target, client)
+		done <- err
+	}()
+	go func() {
+		_, err := io.Copy(client, target)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		return errors.Trace(err)
+	case <-stop:
+		return nil
+	}
+}
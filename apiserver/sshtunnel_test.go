@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"golang.org/x/net/websocket"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing/factory"
+)
+
+type sshTunnelSuite struct {
+	authHttpSuite
+	dialed []string
+}
+
+var _ = gc.Suite(&sshTunnelSuite{})
+
+func (s *sshTunnelSuite) SetUpTest(c *gc.C) {
+	s.authHttpSuite.SetUpTest(c)
+	s.dialed = nil
+
+	// Connect tunnels to an echo server rather than the SSH
+	// server of the machine.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	s.PatchValue(apiserver.SSHTunnelDial, func(address string) (net.Conn, error) {
+		s.dialed = append(s.dialed, address)
+		return net.Dial("tcp", listener.Addr().String())
+	})
+
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Addresses: []network.Address{network.NewScopedAddress("10.0.0.9", network.ScopeCloudLocal)},
+	})
+}
+
+func (s *sshTunnelSuite) openTunnel(c *gc.C, header http.Header, address string) *websocket.Conn {
+	uri := s.makeURL(c, "wss", "/ssh-tunnel", url.Values{"address": {address}})
+	conn := dialWebsocketFromURL(c, uri.String(), header)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn
+}
+
+func (s *sshTunnelSuite) userHeader() http.Header {
+	return utils.BasicAuthHeader(s.userTag.String(), s.password)
+}
+
+func (s *sshTunnelSuite) TestRelay(c *gc.C) {
+	conn := s.openTunnel(c, s.userHeader(), "10.0.0.9")
+	reader := bufio.NewReader(conn)
+
+	errResult := readJSONErrorLine(c, reader)
+	c.Assert(errResult.Error, gc.IsNil)
+
+	_, err := conn.Write([]byte("SSH-2.0-OpenSSH\r\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	line, err := reader.ReadString('\n')
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(line, gc.Equals, "SSH-2.0-OpenSSH\r\n")
+	c.Assert(s.dialed, jc.DeepEquals, []string{"10.0.0.9:22"})
+}
+
+func (s *sshTunnelSuite) TestUnknownAddress(c *gc.C) {
+	reader := bufio.NewReader(s.openTunnel(c, s.userHeader(), "10.0.0.10"))
+	assertJSONError(c, reader, `machine with address "10.0.0.10" not found`)
+	assertWebsocketClosed(c, reader)
+	c.Assert(s.dialed, gc.HasLen, 0)
+}
+
+func (s *sshTunnelSuite) TestEmptyAddress(c *gc.C) {
+	reader := bufio.NewReader(s.openTunnel(c, s.userHeader(), ""))
+	assertJSONError(c, reader, `empty address not valid`)
+	assertWebsocketClosed(c, reader)
+}
+
+func (s *sshTunnelSuite) TestNoAuth(c *gc.C) {
+	reader := bufio.NewReader(s.openTunnel(c, nil, "10.0.0.9"))
+	assertJSONError(c, reader, "no credentials provided")
+	assertWebsocketClosed(c, reader)
+}
+
+func (s *sshTunnelSuite) TestRequiresModelAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "secret",
+		Access:   description.WriteAccess,
+	})
+	header := utils.BasicAuthHeader(user.UserTag().String(), "secret")
+	reader := bufio.NewReader(s.openTunnel(c, header, "10.0.0.9"))
+	assertJSONError(c, reader, "permission denied")
+	assertWebsocketClosed(c, reader)
+	c.Assert(s.dialed, gc.HasLen, 0)
+}
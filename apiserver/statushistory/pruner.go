@@ -4,9 +4,12 @@
 package statushistory
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
@@ -30,10 +33,24 @@ func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API,
 
 // Prune endpoint removes status history entries until
 // only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
+// the history is smaller than p.MaxHistoryMB. If the controller
+// config specifies max-status-history-age, it takes precedence
+// over p.MaxHistoryTime. Each entity then keeps at most
+// max-status-history-entries entries.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthModelManager() {
 		return common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	cfg, err := api.st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	maxHistoryTime := p.MaxHistoryTime
+	if _, ok := cfg[controller.MaxStatusHistoryAge]; ok {
+		maxHistoryTime = cfg.MaxStatusHistoryAge()
+	}
+	if err := state.PruneStatusHistory(api.st, maxHistoryTime, p.MaxHistoryMB); err != nil {
+		return errors.Trace(err)
+	}
+	return state.PruneStatusHistoryEntries(api.st, cfg.MaxStatusHistoryEntries())
 }
@@ -126,6 +126,18 @@ func (api *API) listStorageDetails(filter params.StorageFilter) ([]params.Storag
 		// this code.
 		return nil, errors.NotSupportedf("storage filters")
 	}
+	result, err := common.CachedRead(api.storage, state.StorageReadCache, "list-storage-details", func() (interface{}, error) {
+		return api.allStorageDetails()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]params.StorageDetails), nil
+}
+
+// allStorageDetails returns the details of all the model's storage
+// instances.
+func (api *API) allStorageDetails() ([]params.StorageDetails, error) {
 	stateInstances, err := api.storage.AllStorageInstances()
 	if err != nil {
 		return nil, common.ServerError(err)
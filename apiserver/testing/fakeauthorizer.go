@@ -15,6 +15,11 @@ type FakeAuthorizer struct {
 	EnvironManager bool
 	ModelUUID      string
 	AdminTag       names.UserTag
+
+	// HasReadTag and HasWriteTag, if set, name users which have
+	// read access, and read and write access, to any target.
+	HasReadTag  names.UserTag
+	HasWriteTag names.UserTag
 }
 
 func (fa FakeAuthorizer) AuthOwner(tag names.Tag) bool {
@@ -60,6 +65,12 @@ func (fa FakeAuthorizer) HasPermission(operation description.Access, target name
 		if fa.AdminTag != emptyTag && ut == fa.AdminTag {
 			return true, nil
 		}
+		if fa.HasWriteTag != emptyTag && ut == fa.HasWriteTag {
+			return operation == description.ReadAccess || operation == description.WriteAccess, nil
+		}
+		if fa.HasReadTag != emptyTag && ut == fa.HasReadTag {
+			return operation == description.ReadAccess, nil
+		}
 		return false, nil
 	}
 	return true, nil
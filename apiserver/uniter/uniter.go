@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/meterstatus"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -29,6 +30,9 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+	// Version 5 adds UpgradeSeriesUnitStatus, SetUpgradeSeriesUnitStatus
+	// and WatchUpgradeSeriesNotifications.
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV4)
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -192,10 +196,177 @@ func (u *UniterAPIV3) AssignedMachine(args params.Entities) (params.StringResult
 	return result, nil
 }
 
+// MachineInMaintenance returns whether the machine each given unit is
+// assigned to is in maintenance mode, during which the unit defers
+// nonessential hooks. An unassigned unit's machine is not.
+func (u *UniterAPIV3) MachineInMaintenance(args params.Entities) (params.BoolResults, error) {
+	result := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.BoolResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		inMaintenance, err := u.unitMachineInMaintenance(tag)
+		result.Results[i].Result = inMaintenance
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) unitMachineInMaintenance(tag names.UnitTag) (bool, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return false, err
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	machine, err := u.getMachine(names.NewMachineTag(machineId))
+	if err != nil {
+		return false, err
+	}
+	return machine.InMaintenance(), nil
+}
+
 func (u *UniterAPIV3) getMachine(tag names.MachineTag) (*state.Machine, error) {
 	return u.st.Machine(tag.Id())
 }
 
+// UpgradeSeriesUnitStatus returns each given unit's progress through
+// the series upgrade of its machine. The status is empty if no series
+// upgrade of the unit's machine is in progress, or the unit is not
+// taking part in it.
+func (u *UniterAPIV3) UpgradeSeriesUnitStatus(args params.Entities) (params.UpgradeSeriesStatusResults, error) {
+	result := params.UpgradeSeriesStatusResults{
+		Results: make([]params.UpgradeSeriesStatusResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UpgradeSeriesStatusResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		status, err := u.unitUpgradeSeriesStatus(tag)
+		result.Results[i].Status = string(status)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) unitUpgradeSeriesStatus(tag names.UnitTag) (upgradeseries.Status, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	status, err := unit.UpgradeSeriesStatus()
+	if errors.IsNotFound(err) || errors.IsNotAssigned(err) {
+		return "", nil
+	}
+	return status, err
+}
+
+// SetUpgradeSeriesUnitStatus records that each given unit has run its
+// pre-series-upgrade or post-series-upgrade hook.
+func (u *UniterAPIV3) SetUpgradeSeriesUnitStatus(args params.UpgradeSeriesStatusArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			err = unit.SetUpgradeSeriesStatus(upgradeseries.Status(arg.Status))
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher for observing
+// changes to the series upgrade of each given unit's machine.
+func (u *UniterAPIV3) WatchUpgradeSeriesNotifications(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchOneUnitUpgradeSeries(tag)
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) watchOneUnitUpgradeSeries(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil {
+		return "", err
+	}
+	machine, err := u.getMachine(names.NewMachineTag(machineId))
+	if err != nil {
+		return "", err
+	}
+	watch := machine.WatchUpgradeSeries()
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
 func (u *UniterAPIV3) getOneMachinePorts(canAccess common.AuthFunc, machineTag string) params.MachinePortsResult {
 	tag, err := names.ParseMachineTag(machineTag)
 	if err != nil {
@@ -576,6 +747,60 @@ func (u *UniterAPIV3) charmModifiedVersion(tagStr string, canAccess func(names.T
 	return service.CharmModifiedVersion(), nil
 }
 
+// HookSandbox returns the confinement in which hooks are run for all
+// given units or applications.
+func (u *UniterAPIV3) HookSandbox(args params.Entities) (params.HookSandboxResults, error) {
+	results := params.HookSandboxResults{
+		Results: make([]params.HookSandboxResult, len(args.Entities)),
+	}
+	accessUnitOrService := common.AuthEither(u.accessUnit, u.accessService)
+	canAccess, err := accessUnitOrService()
+	if err != nil {
+		return results, err
+	}
+	for i, entity := range args.Entities {
+		sandbox, err := u.hookSandbox(entity.Tag, canAccess)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = params.HookSandbox{
+			User:         sandbox.User,
+			MemoryLimit:  sandbox.MemoryLimit,
+			CPUQuota:     sandbox.CPUQuota,
+			LXDContainer: sandbox.LXDContainer,
+		}
+	}
+	return results, nil
+}
+
+func (u *UniterAPIV3) hookSandbox(tagStr string, canAccess func(names.Tag) bool) (state.HookSandbox, error) {
+	tag, err := names.ParseTag(tagStr)
+	if err != nil {
+		return state.HookSandbox{}, common.ErrPerm
+	}
+	if !canAccess(tag) {
+		return state.HookSandbox{}, common.ErrPerm
+	}
+	unitOrService, err := u.st.FindEntity(tag)
+	if err != nil {
+		return state.HookSandbox{}, err
+	}
+	var service *state.Application
+	switch entity := unitOrService.(type) {
+	case *state.Application:
+		service = entity
+	case *state.Unit:
+		service, err = entity.Application()
+		if err != nil {
+			return state.HookSandbox{}, err
+		}
+	default:
+		return state.HookSandbox{}, errors.BadRequestf("type %T does not have a hook sandbox", entity)
+	}
+	return service.HookSandbox(), nil
+}
+
 // CharmURL returns the charm URL for all given units or services.
 func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults, error) {
 	result := params.StringBoolResults{
@@ -1086,6 +1311,32 @@ func (u *UniterAPIV3) LeaveScope(args params.RelationUnits) (params.ErrorResults
 	return result, nil
 }
 
+// PrepareLeaveScope causes each unit to be reported as departed by
+// watchers of its scope in the relation, for all of the given
+// relation/unit pairs. See also state.RelationUnit.PrepareLeaveScope().
+func (u *UniterAPIV3) PrepareLeaveScope(args params.RelationUnits) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			err = relUnit.PrepareLeaveScope()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // ReadSettings returns the local settings of each given set of
 // relation/unit.
 func (u *UniterAPIV3) ReadSettings(args params.RelationUnits) (params.SettingsResults, error) {
@@ -1160,6 +1411,11 @@ func (u *UniterAPIV3) UpdateSettings(args params.RelationUnitsSettings) (params.
 	if err != nil {
 		return params.ErrorResults{}, err
 	}
+	controllerConfig, err := u.st.ControllerConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	maxSize := controllerConfig.MaxRelationSettingsSize()
 	for i, arg := range args.RelationUnits {
 		unit, err := names.ParseUnitTag(arg.Unit)
 		if err != nil {
@@ -1178,6 +1434,9 @@ func (u *UniterAPIV3) UpdateSettings(args params.RelationUnitsSettings) (params.
 						settings.Set(k, v)
 					}
 				}
+				err = checkRelationSettingsSize(settings.Map(), maxSize)
+			}
+			if err == nil {
 				_, err = settings.Write()
 			}
 		}
@@ -1186,6 +1445,23 @@ func (u *UniterAPIV3) UpdateSettings(args params.RelationUnitsSettings) (params.
 	return result, nil
 }
 
+// checkRelationSettingsSize returns an error if the total size of the
+// keys and values of the given relation settings exceeds maxSize bytes.
+// A maxSize of zero means there is no limit.
+func checkRelationSettingsSize(settings map[string]interface{}, maxSize int) error {
+	if maxSize == 0 {
+		return nil
+	}
+	size := 0
+	for k, v := range settings {
+		size += len(k) + len(fmt.Sprint(v))
+	}
+	if size > maxSize {
+		return errors.Errorf("relation settings of %d bytes exceed the limit of %d bytes", size, maxSize)
+	}
+	return nil
+}
+
 // WatchRelationUnits returns a RelationUnitsWatcher for observing
 // changes to every unit in the supplied relation that is visible to
 // the supplied unit. See also state/watcher.go:RelationUnit.Watch().
@@ -1212,6 +1488,113 @@ func (u *UniterAPIV3) WatchRelationUnits(args params.RelationUnits) (params.Rela
 	return result, nil
 }
 
+// SetCounterpartJoined records, for each given relation/local
+// unit/remote unit, that the local unit has run relation-joined for
+// the remote unit. See also state.RelationUnit.SetCounterpartJoined().
+func (u *UniterAPIV3) SetCounterpartJoined(args params.RelationUnitPairs) (params.ErrorResults, error) {
+	return u.setCounterparts(args, (*state.RelationUnit).SetCounterpartJoined)
+}
+
+// SetCounterpartDeparted records, for each given relation/local
+// unit/remote unit, that the local unit has run relation-departed for
+// the remote unit. See also state.RelationUnit.SetCounterpartDeparted().
+func (u *UniterAPIV3) SetCounterpartDeparted(args params.RelationUnitPairs) (params.ErrorResults, error) {
+	return u.setCounterparts(args, (*state.RelationUnit).SetCounterpartDeparted)
+}
+
+func (u *UniterAPIV3) setCounterparts(
+	args params.RelationUnitPairs, set func(*state.RelationUnit, string) error,
+) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationUnitPairs)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.RelationUnitPairs {
+		unit, err := names.ParseUnitTag(arg.LocalUnit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			var remoteUnit string
+			remoteUnit, err = u.checkRemoteUnit(relUnit, arg.RemoteUnit)
+			if err == nil {
+				err = set(relUnit, remoteUnit)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// PendingDepartures returns, for each given relation/unit, the
+// counterpart units which have joined the unit and not yet departed
+// it. See also state.RelationUnit.PendingDepartures().
+func (u *UniterAPIV3) PendingDepartures(args params.RelationUnits) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			result.Results[i].Result, err = relUnit.PendingDepartures()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchPendingDepartures returns, for each given relation/unit, a
+// NotifyWatcher which fires when the unit's pending departures may
+// have changed. See also state.RelationUnit.WatchPendingDepartures().
+func (u *UniterAPIV3) WatchPendingDepartures(args params.RelationUnits) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			result.Results[i].NotifyWatcherId, err = u.watchOnePendingDepartures(relUnit)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) watchOnePendingDepartures(relUnit *state.RelationUnit) (string, error) {
+	watch := relUnit.WatchPendingDepartures()
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
 // WatchUnitAddresses returns a NotifyWatcher for observing changes
 // to each unit's addresses.
 func (u *UniterAPIV3) WatchUnitAddresses(args params.Entities) (params.NotifyWatchResults, error) {
@@ -1552,6 +1935,41 @@ func (u *UniterAPIV3) AddMetricBatches(args params.MetricBatchParams) (params.Er
 	return result, nil
 }
 
+// RecordHookTimings records hook executions of the given units, so
+// that slow or failing hooks can be identified.
+func (u *UniterAPIV3) RecordHookTimings(args params.HookTimingArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			err = unit.RecordHookTiming(state.HookTiming{
+				Hook:     arg.Timing.Hook,
+				Queued:   arg.Timing.Queued,
+				Started:  arg.Timing.Started,
+				Finished: arg.Timing.Finished,
+				ExitCode: arg.Timing.ExitCode,
+			})
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // NetworkConfig returns information about all given relation/unit pairs,
 // including their id, key and the local endpoint.
 func (u *UniterAPIV3) NetworkConfig(args params.UnitsNetworkConfig) (params.UnitNetworkConfigResults, error) {
@@ -5,6 +5,7 @@ package uniter_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -19,6 +20,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/apiserver/uniter"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -835,6 +838,43 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestRecordHookTimings(c *gc.C) {
+	queued := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	timing := params.HookTiming{
+		Hook:     "config-changed",
+		Queued:   queued,
+		Started:  queued.Add(time.Second),
+		Finished: queued.Add(3 * time.Second),
+		ExitCode: 1,
+	}
+	args := params.HookTimingArgs{Args: []params.UnitHookTiming{
+		{Tag: "unit-mysql-0", Timing: timing},
+		{Tag: "unit-wordpress-0", Timing: timing},
+		{Tag: "unit-foo-42", Timing: timing},
+		{Tag: "application-wordpress", Timing: timing},
+	}}
+	result, err := s.uniter.RecordHookTimings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	timings, err := s.wordpressUnit.HookTimings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timings, jc.DeepEquals, []state.HookTiming{{
+		Hook:     "config-changed",
+		Queued:   timing.Queued,
+		Started:  timing.Started,
+		Finished: timing.Finished,
+		ExitCode: 1,
+	}})
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -854,6 +894,29 @@ func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestHookSandbox(c *gc.C) {
+	err := s.wordpress.SetHookSandbox(state.HookSandbox{User: "hooks", MemoryLimit: 512})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "application-wordpress"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-foo"},
+	}}
+	result, err := s.uniter.HookSandbox(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sandbox := params.HookSandbox{User: "hooks", MemoryLimit: 512}
+	c.Assert(result, gc.DeepEquals, params.HookSandboxResults{
+		Results: []params.HookSandboxResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: sandbox},
+			{Result: sandbox},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	openedPorts, err := s.wordpressUnit.OpenedPorts()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1560,6 +1623,35 @@ func (s *uniterSuite) TestLeaveScope(c *gc.C) {
 	c.Assert(readSettings, gc.DeepEquals, settings)
 }
 
+func (s *uniterSuite) TestPrepareLeaveScope(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: "relation-42", Unit: "unit-foo-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0"},
+	}}
+	result, err := s.uniter.PrepareLeaveScope(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// The unit remains in scope, but is no longer joined.
+	s.assertInScope(c, relUnit, true)
+	joined, err := relUnit.Joined()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(joined, jc.IsFalse)
+}
+
 func (s *uniterSuite) TestJoinedRelations(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	relUnit, err := rel.Unit(s.wordpressUnit)
@@ -1838,6 +1930,33 @@ func (s *uniterSuite) TestUpdateSettings(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestUpdateSettingsSizeLimit(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{"some": "settings"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{
+			"huge": strings.Repeat("x", controller.DefaultMaxRelationSettingsSize),
+		},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `relation settings of 262160 bytes exceed the limit of 262144 bytes`)
+
+	// The settings were left unchanged.
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"some": "settings",
+	})
+}
+
 func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 	// Add a relation between wordpress and mysql and enter scope with
 	// mysqlUnit.
@@ -1962,6 +2081,115 @@ func (s *uniterSuite) TestWatchUnitAddresses(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *uniterSuite) TestSetCounterpartJoinedAndDeparted(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	wpRelUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlRelUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitPairs{RelationUnitPairs: []params.RelationUnitPair{
+		{Relation: "relation-42", LocalUnit: "unit-foo-0", RemoteUnit: "foo"},
+		{Relation: rel.Tag().String(), LocalUnit: "unit-wordpress-0", RemoteUnit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), LocalUnit: "unit-wordpress-0", RemoteUnit: "unit-mysql-0"},
+		{Relation: rel.Tag().String(), LocalUnit: "unit-mysql-0", RemoteUnit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), LocalUnit: "unit-wordpress-0", RemoteUnit: "application-mysql"},
+	}}
+	expect := params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	}
+	result, err := s.uniter.SetCounterpartJoined(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, expect)
+	pending, err := mysqlRelUnit.PendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.DeepEquals, []string{"wordpress/0"})
+
+	result, err = s.uniter.SetCounterpartDeparted(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, expect)
+	pending, err = mysqlRelUnit.PendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+}
+
+func (s *uniterSuite) TestPendingDepartures(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	wpRelUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlRelUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlRelUnit.SetCounterpartJoined("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: "relation-42", Unit: "unit-foo-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0"},
+		{Relation: rel.Tag().String(), Unit: "application-wordpress"},
+	}}
+	result, err := s.uniter.PendingDepartures(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: []string{"mysql/0"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestWatchPendingDepartures(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	rel := s.addRelation(c, "wordpress", "mysql")
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: "relation-42", Unit: "unit-foo-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0"},
+	}}
+	result, err := s.uniter.WatchPendingDepartures(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	mysqlRelUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *uniterSuite) TestGetMeterStatusUnauthenticated(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{{s.mysqlUnit.Tag().String()}}}
 	result, err := s.uniter.GetMeterStatus(args)
@@ -2214,6 +2442,115 @@ func (s *uniterSuite) TestAssignedMachine(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestMachineInMaintenance(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+		{Tag: "machine-0"},
+	}}
+	result, err := s.uniter.MachineInMaintenance(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: false},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.machine0.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.MachineInMaintenance(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.BoolResult{{Result: true}})
+}
+
+func (s *uniterSuite) TestUpgradeSeriesUnitStatus(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.UpgradeSeriesUnitStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UpgradeSeriesStatusResults{
+		Results: []params.UpgradeSeriesStatusResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Status: ""},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.machine0.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.UpgradeSeriesUnitStatus(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.UpgradeSeriesStatusResult{
+		{Status: string(upgradeseries.PrepareStarted)},
+	})
+}
+
+func (s *uniterSuite) TestSetUpgradeSeriesUnitStatus(c *gc.C) {
+	err := s.machine0.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.SetUpgradeSeriesUnitStatus(params.UpgradeSeriesStatusArgs{
+		Args: []params.UpgradeSeriesStatusArg{
+			{Entity: params.Entity{Tag: "unit-mysql-0"}, Status: string(upgradeseries.PrepareCompleted)},
+			{Entity: params.Entity{Tag: "unit-wordpress-0"}, Status: string(upgradeseries.PrepareCompleted)},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{},
+		},
+	})
+	status, err := s.wordpressUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, upgradeseries.PrepareCompleted)
+}
+
+func (s *uniterSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.WatchUpgradeSeriesNotifications(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.machine0.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *uniterSuite) TestAllMachinePorts(c *gc.C) {
 	// Verify no ports are opened yet on the machine or unit.
 	machinePorts, err := s.machine0.AllPorts()
@@ -0,0 +1,192 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeprechecks provides the facade through which clients
+// verify that a model is fit to be upgraded before upgrade-juju changes
+// its agent version. Upgrades started without the checks are recorded
+// through SkipPrechecks, so that they appear in the audit log.
+package upgradeprechecks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.upgradeprechecks")
+
+// MinFreeDiskMB is the free space, in megabytes, that each machine in
+// the model must have on its root filesystem to download and unpack
+// the new agent binaries.
+const MinFreeDiskMB = 1024
+
+// The names of the upgrade prechecks.
+const (
+	AgentsCheck   = "agents"
+	DatabaseCheck = "database"
+	DiskCheck     = "disk"
+	ProviderCheck = "provider"
+)
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllMachines() ([]Machine, error)
+	AllMachineUsage() ([]state.MachineUsage, error)
+	Ping() error
+}
+
+// Machine contains the state.Machine methods used in this package.
+type Machine interface {
+	Id() string
+	Life() state.Life
+	AgentPresence() (bool, error)
+}
+
+// API is the endpoint which implements the UpgradePrechecks facade.
+type API struct {
+	backend    Backend
+	newEnviron func() (environs.Environ, error)
+	auth       facade.Authorizer
+}
+
+// NewAPI creates a new instance of the UpgradePrechecks facade. The
+// newEnviron func returns the model's environ, whose provider API is
+// checked.
+func NewAPI(backend Backend, newEnviron func() (environs.Environ, error), authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		newEnviron: newEnviron,
+		auth:       authorizer,
+	}, nil
+}
+
+func (api *API) checkPermission(access description.Access) error {
+	ok, err := api.auth.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Prechecks verifies that the agents of the model's machines are
+// connected, that the database is reachable, that each machine has
+// enough free disk space, and that the provider API is reachable. The
+// upgrade should only proceed if every check passes.
+func (api *API) Prechecks() (params.UpgradePrecheckResults, error) {
+	if err := api.checkPermission(description.ReadAccess); err != nil {
+		return params.UpgradePrecheckResults{}, errors.Trace(err)
+	}
+	checks := []struct {
+		name  string
+		check func() error
+	}{
+		{AgentsCheck, api.checkAgents},
+		{DatabaseCheck, api.backend.Ping},
+		{DiskCheck, api.checkDisk},
+		{ProviderCheck, api.checkProvider},
+	}
+	results := params.UpgradePrecheckResults{
+		Checks: make([]params.UpgradePrecheck, len(checks)),
+	}
+	for i, check := range checks {
+		results.Checks[i].Name = check.name
+		if err := check.check(); err != nil {
+			results.Checks[i].Message = err.Error()
+			continue
+		}
+		results.Checks[i].Passed = true
+	}
+	return results, nil
+}
+
+// checkAgents returns an error naming the alive machines whose agents
+// are not connected.
+func (api *API) checkAgents() error {
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var down []string
+	for _, m := range machines {
+		if m.Life() != state.Alive {
+			continue
+		}
+		alive, err := m.AgentPresence()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !alive {
+			down = append(down, m.Id())
+		}
+	}
+	if len(down) > 0 {
+		return errors.Errorf("agents not connected on machines %s", strings.Join(down, ", "))
+	}
+	return nil
+}
+
+// checkDisk returns an error naming the machines whose last reported
+// free disk space is below MinFreeDiskMB. Machines which have not
+// reported their usage are not checked.
+func (api *API) checkDisk() error {
+	usage, err := api.backend.AllMachineUsage()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var low []string
+	for _, u := range usage {
+		if u.DiskTotal == 0 || u.DiskUsed > u.DiskTotal {
+			continue
+		}
+		free := u.DiskTotal - u.DiskUsed
+		if free < MinFreeDiskMB*humanize.MiByte {
+			low = append(low, fmt.Sprintf("%s (%s free)", u.MachineId, humanize.IBytes(free)))
+		}
+	}
+	if len(low) > 0 {
+		sort.Strings(low)
+		return errors.Errorf("less than %dMB free disk space on machines %s", MinFreeDiskMB, strings.Join(low, ", "))
+	}
+	return nil
+}
+
+func (api *API) checkProvider() error {
+	env, err := api.newEnviron()
+	if err != nil {
+		return errors.Annotate(err, "cannot open environ")
+	}
+	return environs.CheckProviderAPI(env)
+}
+
+// SkipPrechecks records that the authenticated user is starting an
+// upgrade to the given version without the prechecks. The request
+// itself is recorded in the audit log; it is also logged here so that
+// it appears in the controller's logs.
+func (api *API) SkipPrechecks(args params.SkipUpgradePrechecks) error {
+	if err := api.checkPermission(description.WriteAccess); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Warningf("%s is upgrading model %s to %s without upgrade prechecks",
+		names.ReadableString(api.auth.GetAuthTag()), api.backend.ModelTag().Id(), args.Version)
+	return nil
+}
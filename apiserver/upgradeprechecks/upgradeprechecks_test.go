@@ -0,0 +1,210 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeprechecks_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/upgradeprechecks"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type UpgradePrechecksSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	environ    *mockEnviron
+	authorizer apiservertesting.FakeAuthorizer
+	api        *upgradeprechecks.API
+}
+
+var _ = gc.Suite(&UpgradePrechecksSuite{})
+
+var modelTag = names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+
+const gigabyte = 1 << 30
+
+func (s *UpgradePrechecksSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{
+		machines: []upgradeprechecks.Machine{
+			&mockMachine{id: "0", life: state.Alive, alive: true},
+			&mockMachine{id: "1", life: state.Alive, alive: true},
+		},
+		usage: []state.MachineUsage{
+			{MachineId: "0", DiskUsed: 2 * gigabyte, DiskTotal: 8 * gigabyte},
+			{MachineId: "1", DiskUsed: 4 * gigabyte, DiskTotal: 8 * gigabyte},
+		},
+	}
+	s.environ = &mockEnviron{}
+	var err error
+	s.api, err = upgradeprechecks.NewAPI(s.backend, func() (environs.Environ, error) {
+		s.backend.stub.AddCall("NewEnviron")
+		return s.environ, s.backend.stub.NextErr()
+	}, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradePrechecksSuite) TestReadAccess(c *gc.C) {
+	// Users with read access may run the checks, but not skip them.
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasReadTag = names.NewUserTag("mary@local")
+	_, err := s.api.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.api.SkipPrechecks(params.SkipUpgradePrechecks{Version: version.MustParse("2.0.1")})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *UpgradePrechecksSuite) TestWriteAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasWriteTag = names.NewUserTag("mary@local")
+	err := s.api.SkipPrechecks(params.SkipUpgradePrechecks{Version: version.MustParse("2.0.1")})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradePrechecksSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := upgradeprechecks.NewAPI(s.backend, nil, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *UpgradePrechecksSuite) TestPrechecksPass(c *gc.C) {
+	results, err := s.api.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UpgradePrecheckResults{
+		Checks: []params.UpgradePrecheck{
+			{Name: "agents", Passed: true},
+			{Name: "database", Passed: true},
+			{Name: "disk", Passed: true},
+			{Name: "provider", Passed: true},
+		},
+	})
+	s.backend.stub.CheckCallNames(c, "AllMachines", "Ping", "AllMachineUsage", "NewEnviron")
+}
+
+func (s *UpgradePrechecksSuite) TestPrechecksFail(c *gc.C) {
+	s.backend.machines = append(s.backend.machines,
+		&mockMachine{id: "2", life: state.Alive},
+		&mockMachine{id: "3", life: state.Dying},
+		&mockMachine{id: "4", life: state.Alive},
+	)
+	s.backend.usage = append(s.backend.usage,
+		state.MachineUsage{MachineId: "2", DiskUsed: 7.5 * gigabyte, DiskTotal: 8 * gigabyte},
+		state.MachineUsage{MachineId: "3"},
+	)
+	s.backend.stub.SetErrors(nil, errors.New("no reachable servers"))
+	s.environ.err = errors.New("401 Unauthorized")
+
+	results, err := s.api.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UpgradePrecheckResults{
+		Checks: []params.UpgradePrecheck{{
+			Name:    "agents",
+			Message: "agents not connected on machines 2, 4",
+		}, {
+			Name:    "database",
+			Message: "no reachable servers",
+		}, {
+			Name:    "disk",
+			Message: "less than 1024MB free disk space on machines 2 (512 MiB free)",
+		}, {
+			Name:    "provider",
+			Message: "cannot make API call to provider: 401 Unauthorized",
+		}},
+	})
+}
+
+func (s *UpgradePrechecksSuite) TestPrechecksEnvironError(c *gc.C) {
+	s.backend.stub.SetErrors(nil, nil, nil, errors.New("boom"))
+	results, err := s.api.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Checks[3], jc.DeepEquals, params.UpgradePrecheck{
+		Name:    "provider",
+		Message: "cannot open environ: boom",
+	})
+}
+
+func (s *UpgradePrechecksSuite) TestPrechecksRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.Prechecks()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *UpgradePrechecksSuite) TestSkipPrechecks(c *gc.C) {
+	err := s.api.SkipPrechecks(params.SkipUpgradePrechecks{Version: version.MustParse("2.0.1")})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *UpgradePrechecksSuite) TestSkipPrechecksRequiresWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	err := s.api.SkipPrechecks(params.SkipUpgradePrechecks{Version: version.MustParse("2.0.1")})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	stub     gitjujutesting.Stub
+	machines []upgradeprechecks.Machine
+	usage    []state.MachineUsage
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return modelTag
+}
+
+func (m *mockBackend) AllMachines() ([]upgradeprechecks.Machine, error) {
+	m.stub.AddCall("AllMachines")
+	return m.machines, m.stub.NextErr()
+}
+
+func (m *mockBackend) AllMachineUsage() ([]state.MachineUsage, error) {
+	m.stub.AddCall("AllMachineUsage")
+	return m.usage, m.stub.NextErr()
+}
+
+func (m *mockBackend) Ping() error {
+	m.stub.AddCall("Ping")
+	return m.stub.NextErr()
+}
+
+type mockMachine struct {
+	id    string
+	life  state.Life
+	alive bool
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *mockMachine) AgentPresence() (bool, error) {
+	return m.alive, nil
+}
+
+type mockEnviron struct {
+	environs.Environ
+	err error
+}
+
+func (m *mockEnviron) AllInstances() ([]instance.Instance, error) {
+	return nil, m.err
+}
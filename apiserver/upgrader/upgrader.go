@@ -107,7 +107,12 @@ func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchR
 		}
 		err = common.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
-			watch := u.st.WatchForModelConfigChanges()
+			// The desired version changes with the model's agent
+			// version, and when a canary upgrade starts or ends.
+			watch := common.NewMultiNotifyWatcher(
+				u.st.WatchForModelConfigChanges(),
+				u.st.WatchCanaryUpgrade(),
+			)
 			// Consume the initial event. Technically, API
 			// calls to Watch 'transmit' the initial event
 			// in the Watch response. But NotifyWatchers
@@ -159,12 +164,15 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 	if len(args.Entities) == 0 {
 		return params.VersionResults{}, nil
 	}
-	agentVersion, _, err := u.getGlobalAgentVersion()
+	modelVersion, _, err := u.getGlobalAgentVersion()
 	if err != nil {
 		return params.VersionResults{}, common.ServerError(err)
 	}
-	// Is the desired version greater than the current API server version?
-	isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
+	canary, err := u.st.CanaryUpgrade()
+	inCanaryUpgrade := err == nil
+	if err != nil && !errors.IsNotFound(err) {
+		return params.VersionResults{}, common.ServerError(err)
+	}
 	for i, entity := range args.Entities {
 		tag, err := names.ParseTag(entity.Tag)
 		if err != nil {
@@ -173,6 +181,15 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 		}
 		err = common.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
+			// While a canary upgrade is in progress, only the
+			// canaries and the controller machines are upgraded
+			// to its target version.
+			agentVersion := modelVersion
+			if inCanaryUpgrade && (canary.IsCanary(tag.Id()) || u.entityIsManager(tag)) {
+				agentVersion = canary.TargetVersion
+			}
+			// Is the desired version greater than the current API server version?
+			isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
 			// Only return the globally desired agent version if the
 			// asking entity is a machine agent with JobManageModel or
 			// if this API server is running the globally desired agent
@@ -5,6 +5,7 @@ package upgrader_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -341,3 +342,90 @@ func (s *upgraderSuite) TestDesiredVersionRestrictedForNonAPIAgents(c *gc.C) {
 	c.Assert(agentVersion, gc.NotNil)
 	c.Check(*agentVersion, gc.DeepEquals, jujuversion.Current)
 }
+
+func (s *upgraderSuite) startCanaryUpgrade(c *gc.C) version.Number {
+	current := version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: series.HostSeries(),
+	}
+	s.apiMachine.SetAgentVersion(current)
+	s.rawMachine.SetAgentVersion(current)
+	newer := current.Number
+	newer.Patch++
+	err := s.State.StartCanaryUpgrade(state.CanaryUpgradeArgs{
+		TargetVersion: newer,
+		Machines:      []string{s.rawMachine.Id()},
+	}, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	return newer
+}
+
+func (s *upgraderSuite) TestDesiredVersionCanaryUpgrade(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.SetAgentVersion(version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: series.HostSeries(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	previous := jujuversion.Current
+	newVersion := s.startCanaryUpgrade(c)
+	// The API server has been upgraded already.
+	s.PatchValue(&jujuversion.Current, newVersion)
+
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: other.Tag(),
+	}
+	otherUpgrader, err := upgrader.NewUpgraderAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, test := range []struct {
+		upgrader *upgrader.UpgraderAPI
+		tag      names.Tag
+		expected version.Number
+	}{
+		{s.upgrader, s.rawMachine.Tag(), newVersion},
+		{otherUpgrader, other.Tag(), previous},
+	} {
+		args := params.Entities{Entities: []params.Entity{{Tag: test.tag.String()}}}
+		results, err := test.upgrader.DesiredVersion(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results.Results, gc.HasLen, 1)
+		c.Assert(results.Results[0].Error, gc.IsNil)
+		c.Assert(results.Results[0].Version, gc.NotNil)
+		c.Check(*results.Results[0].Version, gc.Equals, test.expected, gc.Commentf("%s", test.tag))
+	}
+}
+
+func (s *upgraderSuite) TestDesiredVersionCanaryRestrictedForNonAPIAgents(c *gc.C) {
+	s.startCanaryUpgrade(c)
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err := s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Version, gc.NotNil)
+	c.Check(*results.Results[0].Version, gc.Equals, jujuversion.Current)
+}
+
+func (s *upgraderSuite) TestWatchAPIVersionCanaryUpgrade(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results, err := s.upgrader.WatchAPIVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	w := s.resources.Get(results.Results[0].NotifyWatcherId).(state.NotifyWatcher)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	s.startCanaryUpgrade(c)
+	wc.AssertOneChange()
+	err = s.State.AbortCanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
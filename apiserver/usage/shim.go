@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usage
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// stateShim implements Backend in terms of *state.State.
+type stateShim struct {
+	*state.State
+}
+
+// MachineApplications is part of the Backend interface.
+func (s stateShim) MachineApplications(machineId string) ([]string, error) {
+	machine, err := s.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := machine.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var appNames []string
+	for _, unit := range units {
+		if unit.IsPrincipal() {
+			appNames = append(appNames, unit.ApplicationName())
+		}
+	}
+	containers, err := machine.Containers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, id := range containers {
+		containerAppNames, err := s.MachineApplications(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appNames = append(appNames, containerAppNames...)
+	}
+	return appNames, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package usage provides the facade through which clients read the
+// resource usage reported by the machine agents of a model, and the
+// network traffic accounted for it, to help attribute cloud egress
+// costs.
+package usage
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Usage", 1, newFacade)
+	// Version 2 adds the network traffic of machines and
+	// applications.
+	common.RegisterStandardFacade("Usage", 2, newFacade)
+}
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ModelConfig() (*config.Config, error)
+	AllMachineUsage() ([]state.MachineUsage, error)
+
+	// MachineApplications returns the names of the applications
+	// with principal units on the given machine or its containers.
+	MachineApplications(machineId string) ([]string, error)
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, auth)
+}
+
+// API is the endpoint which implements the Usage facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+}
+
+// NewAPI creates a new instance of the Usage facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+	}, nil
+}
+
+// ModelUsage returns the resource usage last reported by each of the
+// machines in the model, and the network traffic accounted for each
+// of the model's applications.
+//
+// The traffic of a machine is attributed in full to each application
+// with principal units on the machine or its containers, so the
+// traffic of the applications may add up to more than that of the
+// machines.
+func (api *API) ModelUsage() (params.ModelUsageResult, error) {
+	var result params.ModelUsageResult
+	ok, err := api.auth.HasPermission(description.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !ok {
+		return result, common.ErrPerm
+	}
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.TrafficAccounting = cfg.NetworkTrafficAccounting()
+
+	all, err := api.backend.AllMachineUsage()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Machines = make([]params.MachineUsage, len(all))
+	for i, usage := range all {
+		result.Machines[i] = machineUsageToParams(usage)
+	}
+	result.Applications, err = api.applicationTraffic(all)
+	if err != nil {
+		return params.ModelUsageResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// applicationTraffic returns the network traffic accounted for each
+// application hosted by the machines with the given usage.
+func (api *API) applicationTraffic(all []state.MachineUsage) ([]params.ApplicationTraffic, error) {
+	applications := make(map[string]*params.ApplicationTraffic)
+	appNames := set.NewStrings()
+	for _, usage := range all {
+		if usage.Received == 0 && usage.Transmitted == 0 {
+			continue
+		}
+		machineAppNames, err := api.backend.MachineApplications(usage.MachineId)
+		if errors.IsNotFound(err) {
+			// The machine is being removed.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, appName := range set.NewStrings(machineAppNames...).Values() {
+			app, ok := applications[appName]
+			if !ok {
+				app = &params.ApplicationTraffic{
+					Tag: names.NewApplicationTag(appName).String(),
+				}
+				applications[appName] = app
+				appNames.Add(appName)
+			}
+			app.Received += usage.Received
+			app.Transmitted += usage.Transmitted
+		}
+	}
+	var result []params.ApplicationTraffic
+	for _, appName := range appNames.SortedValues() {
+		result = append(result, *applications[appName])
+	}
+	return result, nil
+}
+
+func machineUsageToParams(usage state.MachineUsage) params.MachineUsage {
+	result := params.MachineUsage{
+		Tag:          names.NewMachineTag(usage.MachineId).String(),
+		CPUPercent:   usage.CPUPercent,
+		MemoryUsed:   usage.MemoryUsed,
+		MemoryTotal:  usage.MemoryTotal,
+		DiskUsed:     usage.DiskUsed,
+		DiskTotal:    usage.DiskTotal,
+		Received:     usage.Received,
+		Transmitted:  usage.Transmitted,
+		TrafficSince: usage.TrafficSince,
+		Updated:      usage.Updated,
+	}
+	for _, unit := range usage.Units {
+		result.Units = append(result.Units, params.UnitUsage{
+			Tag:        names.NewUnitTag(unit.Unit).String(),
+			CPUPercent: unit.CPUPercent,
+			MemoryUsed: unit.MemoryUsed,
+			DiskUsed:   unit.DiskUsed,
+		})
+	}
+	return result
+}
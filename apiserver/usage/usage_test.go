@@ -0,0 +1,210 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/usage"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type UsageSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *usage.API
+}
+
+var _ = gc.Suite(&UsageSuite{})
+
+func (s *UsageSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bruce@local"),
+		AdminTag: names.NewUserTag("bruce@local"),
+	}
+	s.backend = &mockBackend{
+		cfg: coretesting.ModelConfig(c),
+	}
+	var err error
+	s.api, err = usage.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UsageSuite) TestModelUsageReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	s.authorizer.HasReadTag = names.NewUserTag("mary@local")
+	_, err := s.api.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCallNames(c, "ModelConfig", "AllMachineUsage")
+}
+
+func (s *UsageSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := usage.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *UsageSuite) TestModelUsage(c *gc.C) {
+	updated := time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.usage = []state.MachineUsage{{
+		MachineId:   "0",
+		CPUPercent:  12.5,
+		MemoryUsed:  1024,
+		MemoryTotal: 4096,
+		DiskUsed:    2048,
+		DiskTotal:   8192,
+		Units: []state.UnitUsage{{
+			Unit:       "mysql/0",
+			CPUPercent: 10,
+			MemoryUsed: 512,
+			DiskUsed:   256,
+		}},
+		Updated: updated,
+	}, {
+		MachineId: "0/lxd/1",
+		Updated:   updated,
+	}}
+	result, err := s.api.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelUsageResult{
+		Machines: []params.MachineUsage{{
+			Tag:         "machine-0",
+			CPUPercent:  12.5,
+			MemoryUsed:  1024,
+			MemoryTotal: 4096,
+			DiskUsed:    2048,
+			DiskTotal:   8192,
+			Units: []params.UnitUsage{{
+				Tag:        "unit-mysql-0",
+				CPUPercent: 10,
+				MemoryUsed: 512,
+				DiskUsed:   256,
+			}},
+			Updated: updated,
+		}, {
+			Tag:     "machine-0-lxd-1",
+			Updated: updated,
+		}},
+	})
+	s.backend.stub.CheckCallNames(c, "ModelConfig", "AllMachineUsage")
+}
+
+func (s *UsageSuite) TestModelUsageTraffic(c *gc.C) {
+	s.backend.cfg = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"network-traffic-accounting": true,
+	})
+	since := time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.usage = []state.MachineUsage{{
+		MachineId:    "0",
+		Received:     1000,
+		Transmitted:  100,
+		TrafficSince: since,
+	}, {
+		MachineId:    "1",
+		Received:     2000,
+		Transmitted:  200,
+		TrafficSince: since,
+	}, {
+		MachineId:    "2",
+		Received:     4000,
+		Transmitted:  400,
+		TrafficSince: since,
+	}, {
+		MachineId: "3",
+	}}
+	s.backend.applications = map[string][]string{
+		"0": {"mysql", "wordpress", "mysql"},
+		"1": {"wordpress"},
+	}
+	result, err := s.api.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.TrafficAccounting, jc.IsTrue)
+	c.Assert(result.Machines, gc.HasLen, 4)
+	c.Assert(result.Machines[0], jc.DeepEquals, params.MachineUsage{
+		Tag:          "machine-0",
+		Received:     1000,
+		Transmitted:  100,
+		TrafficSince: since,
+	})
+	c.Assert(result.Applications, jc.DeepEquals, []params.ApplicationTraffic{{
+		Tag:         "application-mysql",
+		Received:    1000,
+		Transmitted: 100,
+	}, {
+		Tag:         "application-wordpress",
+		Received:    3000,
+		Transmitted: 300,
+	}})
+	s.backend.stub.CheckCallNames(c,
+		"ModelConfig", "AllMachineUsage",
+		"MachineApplications", "MachineApplications", "MachineApplications",
+	)
+}
+
+func (s *UsageSuite) TestModelUsageTrafficMachineRemoved(c *gc.C) {
+	s.backend.usage = []state.MachineUsage{{MachineId: "0", Received: 1}}
+	s.backend.stub.SetErrors(nil, nil, errors.NotFoundf("machine 0"))
+	result, err := s.api.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Machines[0].Received, gc.Equals, uint64(1))
+	c.Assert(result.Applications, gc.HasLen, 0)
+}
+
+func (s *UsageSuite) TestModelUsageRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary@local")
+	_, err := s.api.ModelUsage()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckNoCalls(c)
+}
+
+func (s *UsageSuite) TestModelUsageError(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.New("boom"))
+	_, err := s.api.ModelUsage()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	stub         gitjujutesting.Stub
+	cfg          *config.Config
+	usage        []state.MachineUsage
+	applications map[string][]string
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+}
+
+func (m *mockBackend) AllMachineUsage() ([]state.MachineUsage, error) {
+	m.stub.AddCall("AllMachineUsage")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.usage, nil
+}
+
+func (m *mockBackend) ModelConfig() (*config.Config, error) {
+	m.stub.AddCall("ModelConfig")
+	return m.cfg, m.stub.NextErr()
+}
+
+func (m *mockBackend) MachineApplications(machineId string) ([]string, error) {
+	m.stub.AddCall("MachineApplications", machineId)
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.applications[machineId], nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package usagereporter implements the API facade used by the
+// usagereporter worker.
+package usagereporter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("UsageReporter", 1, newFacade)
+	// Version 2 accounts the network traffic of machines.
+	common.RegisterStandardFacade("UsageReporter", 2, newFacade)
+}
+
+// Backend defines the State API used by the usagereporter facade.
+type Backend interface {
+	ModelConfig() (*config.Config, error)
+	SetMachineUsage(names.MachineTag, state.MachineUsage) error
+}
+
+// Facade implements the API required by the usagereporter worker.
+type Facade struct {
+	backend      Backend
+	getCanModify common.GetAuthFunc
+}
+
+// New returns a new API facade for the usagereporter worker.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend: backend,
+		getCanModify: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// ReportUsage records the resource usage of one or more machines. The
+// network traffic reported with the usage is discarded when network
+// traffic accounting is disabled in the model config.
+func (facade *Facade) ReportUsage(args params.MachineUsageSet) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Usage)),
+	}
+
+	canModify, err := facade.getCanModify()
+	if err != nil {
+		return results, err
+	}
+	cfg, err := facade.backend.ModelConfig()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	accountTraffic := cfg.NetworkTrafficAccounting()
+
+	for i, arg := range args.Usage {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canModify(tag) {
+			err = facade.setUsage(tag, arg, accountTraffic)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (facade *Facade) setUsage(tag names.MachineTag, arg params.MachineUsage, accountTraffic bool) error {
+	usage := state.MachineUsage{
+		CPUPercent:  arg.CPUPercent,
+		MemoryUsed:  arg.MemoryUsed,
+		MemoryTotal: arg.MemoryTotal,
+		DiskUsed:    arg.DiskUsed,
+		DiskTotal:   arg.DiskTotal,
+	}
+	if accountTraffic {
+		usage.Received = arg.Received
+		usage.Transmitted = arg.Transmitted
+	}
+	for _, unit := range arg.Units {
+		unitTag, err := names.ParseUnitTag(unit.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		usage.Units = append(usage.Units, state.UnitUsage{
+			Unit:       unitTag.Id(),
+			CPUPercent: unit.CPUPercent,
+			MemoryUsed: unit.MemoryUsed,
+			DiskUsed:   unit.DiskUsed,
+		})
+	}
+	return facade.backend.SetMachineUsage(tag, usage)
+}
@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usagereporter_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/usagereporter"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *usagereporter.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		cfg: testing.CustomModelConfig(c, testing.Attrs{
+			"network-traffic-accounting": true,
+		}),
+	}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	facade, err := usagereporter.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := usagereporter.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestReportUsage(c *gc.C) {
+	args := params.MachineUsageSet{
+		Usage: []params.MachineUsage{{
+			Tag:        names.NewMachineTag("0").String(),
+			CPUPercent: 10,
+		}, {
+			Tag:         names.NewMachineTag("1").String(),
+			CPUPercent:  25,
+			MemoryUsed:  1024,
+			MemoryTotal: 4096,
+			DiskUsed:    2048,
+			DiskTotal:   8192,
+			Units: []params.UnitUsage{{
+				Tag:        names.NewUnitTag("mysql/0").String(),
+				CPUPercent: 5,
+				MemoryUsed: 512,
+				DiskUsed:   256,
+			}},
+		}, {
+			Tag: names.NewApplicationTag("mysql").String(),
+		}},
+	}
+	result, err := s.facade.ReportUsage(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{nil},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"ModelConfig", nil,
+	}, {
+		"SetMachineUsage",
+		[]interface{}{
+			names.NewMachineTag("1"),
+			state.MachineUsage{
+				CPUPercent:  25,
+				MemoryUsed:  1024,
+				MemoryTotal: 4096,
+				DiskUsed:    2048,
+				DiskTotal:   8192,
+				Units: []state.UnitUsage{{
+					Unit:       "mysql/0",
+					CPUPercent: 5,
+					MemoryUsed: 512,
+					DiskUsed:   256,
+				}},
+			},
+		},
+	}})
+}
+
+func (s *facadeSuite) TestReportUsageInvalidUnit(c *gc.C) {
+	result, err := s.facade.ReportUsage(params.MachineUsageSet{
+		Usage: []params.MachineUsage{{
+			Tag:   names.NewMachineTag("1").String(),
+			Units: []params.UnitUsage{{Tag: "machine-2"}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `"machine-2" is not a valid unit tag`)
+	s.backend.stub.CheckCallNames(c, "ModelConfig")
+}
+
+var trafficArgs = params.MachineUsageSet{
+	Usage: []params.MachineUsage{{
+		Tag:         names.NewMachineTag("1").String(),
+		CPUPercent:  25,
+		Received:    1024,
+		Transmitted: 256,
+	}},
+}
+
+func (s *facadeSuite) TestReportUsageTraffic(c *gc.C) {
+	result, err := s.facade.ReportUsage(trafficArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"ModelConfig", nil,
+	}, {
+		"SetMachineUsage",
+		[]interface{}{
+			names.NewMachineTag("1"),
+			state.MachineUsage{
+				CPUPercent:  25,
+				Received:    1024,
+				Transmitted: 256,
+			},
+		},
+	}})
+}
+
+func (s *facadeSuite) TestReportUsageTrafficDisabled(c *gc.C) {
+	s.backend.cfg = testing.ModelConfig(c)
+	result, err := s.facade.ReportUsage(trafficArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"ModelConfig", nil,
+	}, {
+		"SetMachineUsage",
+		[]interface{}{
+			names.NewMachineTag("1"),
+			state.MachineUsage{CPUPercent: 25},
+		},
+	}})
+}
+
+type mockBackend struct {
+	stub jujutesting.Stub
+	cfg  *config.Config
+}
+
+func (backend *mockBackend) ModelConfig() (*config.Config, error) {
+	backend.stub.AddCall("ModelConfig")
+	return backend.cfg, backend.stub.NextErr()
+}
+
+func (backend *mockBackend) SetMachineUsage(tag names.MachineTag, usage state.MachineUsage) error {
+	backend.stub.AddCall("SetMachineUsage", tag, usage)
+	return backend.stub.NextErr()
+}
@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usagereporter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	facade, err := New(backendShim{st}, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+// backendShim implements Backend in terms of *state.State.
+type backendShim struct {
+	st *state.State
+}
+
+// ModelConfig is part of the Backend interface.
+func (b backendShim) ModelConfig() (*config.Config, error) {
+	return b.st.ModelConfig()
+}
+
+// SetMachineUsage is part of the Backend interface.
+func (b backendShim) SetMachineUsage(tag names.MachineTag, usage state.MachineUsage) error {
+	machine, err := b.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.SetUsage(usage)
+}
@@ -24,8 +24,16 @@ var logger = loggo.GetLogger("juju.apiserver.usermanager")
 
 func init() {
 	common.RegisterStandardFacade("UserManager", 1, NewUserManagerAPI)
+	// Version 2 adds CreateRegistrationTokens.
+	common.RegisterStandardFacade("UserManager", 2, NewUserManagerAPI)
+	// Version 3 adds WhoAmI.
+	common.RegisterStandardFacade("UserManager", 3, NewUserManagerAPI)
 }
 
+// DefaultRegistrationLifetime is how long a registration token created
+// by CreateRegistrationTokens may be used, if no lifetime is requested.
+const DefaultRegistrationLifetime = 24 * time.Hour
+
 // UserManagerAPI implements the user manager interface and is the concrete
 // implementation of the api end point.
 type UserManagerAPI struct {
@@ -35,6 +43,7 @@ type UserManagerAPI struct {
 	check                    *common.BlockChecker
 	apiUser                  names.UserTag
 	isAdmin                  bool
+	loginMacaroonExpiry      *time.Time
 }
 
 func NewUserManagerAPI(
@@ -65,13 +74,23 @@ func NewUserManagerAPI(
 		return nil, errors.NotValidf("userAuth resource")
 	}
 
+	// The login macaroon expiry is only recorded for logins
+	// with macaroons that expire.
+	var loginMacaroonExpiry *time.Time
+	if resource, ok := resources.Get("loginMacaroonExpiry").(common.ValueResource); ok {
+		if expiry, ok := resource.Value.(time.Time); ok {
+			loginMacaroonExpiry = &expiry
+		}
+	}
+
 	return &UserManagerAPI{
 		state:                    st,
 		authorizer:               authorizer,
 		createLocalLoginMacaroon: createLocalLoginMacaroon,
-		check:   common.NewBlockChecker(st),
-		apiUser: apiUser,
-		isAdmin: isAdmin,
+		check:                    common.NewBlockChecker(st),
+		apiUser:                  apiUser,
+		isAdmin:                  isAdmin,
+		loginMacaroonExpiry:      loginMacaroonExpiry,
 	}, nil
 }
 
@@ -165,6 +184,61 @@ func (api *UserManagerAPI) AddUser(args params.AddUsers) (params.AddUserResults,
 	return result, nil
 }
 
+// CreateRegistrationTokens assigns each of the given existing users a
+// new secret key, which expires after the requested lifetime, with
+// which they may register with the controller using "juju register".
+// Any secret key the user already had is replaced.
+func (api *UserManagerAPI) CreateRegistrationTokens(args params.CreateRegistrationTokens) (params.RegistrationTokenResults, error) {
+	var result params.RegistrationTokenResults
+
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isSuperUser {
+		return result, common.ErrPerm
+	}
+
+	result.Results = make([]params.RegistrationTokenResult, len(args.Tokens))
+	for i, arg := range args.Tokens {
+		secretKey, expiry, err := api.createRegistrationToken(arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i] = params.RegistrationTokenResult{
+			SecretKey: secretKey,
+			Expiry:    expiry,
+		}
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) createRegistrationToken(arg params.CreateRegistrationToken) ([]byte, time.Time, error) {
+	lifetime := arg.Lifetime
+	if lifetime < 0 {
+		return nil, time.Time{}, errors.NotValidf("negative lifetime")
+	}
+	if lifetime == 0 {
+		lifetime = DefaultRegistrationLifetime
+	}
+	user, err := api.getUser(arg.UserTag)
+	if err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	if user.IsDisabled() {
+		return nil, time.Time{}, errors.Errorf("user %q is disabled", user.Name())
+	}
+	secretKey, err := user.ResetSecretKey(time.Now().Add(lifetime))
+	if err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	return secretKey, user.SecretKeyExpiry(), nil
+}
+
 // RemoveUser permanently removes a user from the current controller for each
 // entity provided. While the user is permanently removed we keep it's
 // information around for auditing purposes.
@@ -437,3 +511,53 @@ func (api *UserManagerAPI) CreateLocalLoginMacaroon(args params.Entities) (param
 	}
 	return results, nil
 }
+
+// WhoAmI returns the identity of the authenticated user, the access
+// they have been granted to the controller and its models, and when
+// the macaroons they logged in with expire.
+func (api *UserManagerAPI) WhoAmI() (params.WhoAmIResult, error) {
+	result := params.WhoAmIResult{
+		UserTag:        api.apiUser.String(),
+		MacaroonExpiry: api.loginMacaroonExpiry,
+		Models:         []params.WhoAmIModel{},
+	}
+	controllerAccess, err := state.ControllerAccess(api.state, api.apiUser)
+	if err != nil && !errors.IsNotFound(err) {
+		return params.WhoAmIResult{}, errors.Trace(err)
+	}
+	result.ControllerAccess = string(controllerAccess.Access)
+	result.DisplayName = controllerAccess.DisplayName
+
+	if api.apiUser.IsLocal() {
+		user, err := api.state.User(api.apiUser)
+		if err != nil {
+			return params.WhoAmIResult{}, errors.Trace(err)
+		}
+		result.DisplayName = user.DisplayName()
+		if lastLogin, err := user.LastLogin(); err == nil {
+			result.LastConnection = &lastLogin
+		} else if !state.IsNeverLoggedInError(err) {
+			logger.Debugf("error getting last login: %v", err)
+		}
+	}
+
+	models, err := api.state.ModelsForUser(api.apiUser)
+	if err != nil {
+		return params.WhoAmIResult{}, errors.Trace(err)
+	}
+	for _, model := range models {
+		modelAccess, err := api.state.UserAccess(api.apiUser, model.ModelTag())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return params.WhoAmIResult{}, errors.Trace(err)
+		}
+		result.Models = append(result.Models, params.WhoAmIModel{
+			ModelTag: model.ModelTag().String(),
+			Name:     model.Name(),
+			OwnerTag: model.Owner().String(),
+			Access:   string(modelAccess.Access),
+		})
+	}
+	return result, nil
+}
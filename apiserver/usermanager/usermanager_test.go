@@ -380,6 +380,80 @@ func (s *userManagerSuite) TestEnableUserAsNormalUser(c *gc.C) {
 	c.Assert(barb.IsDisabled(), jc.IsTrue)
 }
 
+func (s *userManagerSuite) TestCreateRegistrationTokens(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	barb := s.Factory.MakeUser(c, &factory.UserParams{Name: "barb", Disabled: true})
+
+	before := time.Now().Add(time.Hour).Round(time.Second)
+	result, err := s.usermanager.CreateRegistrationTokens(params.CreateRegistrationTokens{
+		Tokens: []params.CreateRegistrationToken{
+			{UserTag: alex.Tag().String(), Lifetime: time.Hour},
+			{UserTag: barb.Tag().String()},
+			{UserTag: alex.Tag().String(), Lifetime: -time.Hour},
+			{UserTag: names.NewLocalUserTag("ellie").String()},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].SecretKey, gc.HasLen, 32)
+	c.Assert(result.Results[0].Expiry.Before(before), jc.IsFalse)
+	c.Assert(result.Results[0].Expiry.After(time.Now().Add(time.Hour+time.Second)), jc.IsFalse)
+	c.Assert(result.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `user "barb" is disabled`,
+	})
+	c.Assert(result.Results[2].Error, jc.DeepEquals, &params.Error{
+		Message: "negative lifetime not valid",
+		Code:    params.CodeNotValid,
+	})
+	c.Assert(result.Results[3].Error, gc.NotNil)
+
+	err = alex.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alex.SecretKey(), jc.DeepEquals, result.Results[0].SecretKey)
+	c.Assert(alex.SecretKeyExpiry().Equal(result.Results[0].Expiry), jc.IsTrue)
+}
+
+func (s *userManagerSuite) TestCreateRegistrationTokensDefaultLifetime(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+
+	before := time.Now().Add(usermanager.DefaultRegistrationLifetime).Round(time.Second)
+	result, err := s.usermanager.CreateRegistrationTokens(params.CreateRegistrationTokens{
+		Tokens: []params.CreateRegistrationToken{{UserTag: alex.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Expiry.Before(before), jc.IsFalse)
+}
+
+func (s *userManagerSuite) TestCreateRegistrationTokensAsNormalUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	barb := s.Factory.MakeUser(c, &factory.UserParams{Name: "barb"})
+	_, err = usermanager.CreateRegistrationTokens(params.CreateRegistrationTokens{
+		Tokens: []params.CreateRegistrationToken{{UserTag: barb.Tag().String()}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	err = barb.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(barb.SecretKeyExpiry().IsZero(), jc.IsTrue)
+}
+
+func (s *userManagerSuite) TestBlockCreateRegistrationTokens(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+
+	s.BlockAllChanges(c, "TestBlockCreateRegistrationTokens")
+	_, err := s.usermanager.CreateRegistrationTokens(params.CreateRegistrationTokens{
+		Tokens: []params.CreateRegistrationToken{{UserTag: alex.Tag().String()}},
+	})
+	s.AssertBlocked(c, err, "TestBlockCreateRegistrationTokens")
+}
+
 func (s *userManagerSuite) TestUserInfo(c *gc.C) {
 	userFoo := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", DisplayName: "Foo Bar"})
 	userBar := s.Factory.MakeUser(c, &factory.UserParams{Name: "barfoo", DisplayName: "Bar Foo", Disabled: true})
@@ -781,3 +855,44 @@ func (s *userManagerSuite) TestRemoveUserBulkSharedModels(c *gc.C) {
 	c.Assert(alice.IsDeleted(), jc.IsTrue)
 
 }
+
+func (s *userManagerSuite) TestWhoAmI(c *gc.C) {
+	alice := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:        "alice",
+		DisplayName: "Alice",
+		Access:      description.ReadAccess,
+	})
+	expiry := time.Date(2016, 10, 2, 12, 0, 0, 0, time.UTC)
+	s.resources.RegisterNamed("loginMacaroonExpiry", common.ValueResource{expiry})
+	api, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alice.Tag()},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.WhoAmI()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.MacaroonExpiry, gc.NotNil)
+	c.Assert(result.MacaroonExpiry.Equal(expiry), jc.IsTrue)
+	result.MacaroonExpiry = nil
+	c.Assert(result, jc.DeepEquals, params.WhoAmIResult{
+		UserTag:          "user-alice@local",
+		DisplayName:      "Alice",
+		ControllerAccess: "login",
+		Models: []params.WhoAmIModel{{
+			ModelTag: s.State.ModelTag().String(),
+			Name:     "controller",
+			OwnerTag: s.AdminUserTag(c).String(),
+			Access:   "read",
+		}},
+	})
+}
+
+func (s *userManagerSuite) TestWhoAmINoMacaroonExpiry(c *gc.C) {
+	result, err := s.usermanager.WhoAmI()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.UserTag, gc.Equals, s.AdminUserTag(c).String())
+	c.Assert(result.ControllerAccess, gc.Equals, "superuser")
+	c.Assert(result.MacaroonExpiry, gc.IsNil)
+	c.Assert(result.Models, gc.HasLen, 1)
+	c.Assert(result.Models[0].Access, gc.Equals, "admin")
+}
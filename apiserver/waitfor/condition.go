@@ -0,0 +1,222 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// Fields that may be named in a condition.
+const (
+	fieldStatus          = "status"
+	fieldAgentStatus     = "agent-status"
+	fieldInstanceStatus  = "instance-status"
+	fieldWorkloadVersion = "workload-version"
+	fieldUnitCount       = "unit-count"
+)
+
+// validFields records, for each kind of entity, the fields that a
+// condition may refer to.
+var validFields = map[string][]string{
+	names.ApplicationTagKind: {fieldStatus, fieldUnitCount},
+	names.UnitTagKind:        {fieldStatus, fieldAgentStatus, fieldWorkloadVersion},
+	names.MachineTagKind:     {fieldStatus, fieldInstanceStatus},
+}
+
+// condition is a validated params.WaitForCondition.
+type condition struct {
+	tag   names.Tag
+	field string
+	op    string
+	value string
+}
+
+// newCondition validates the supplied condition and returns it in a
+// form that can be evaluated.
+func newCondition(arg params.WaitForCondition) (condition, error) {
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return condition{}, errors.Trace(err)
+	}
+	fields, ok := validFields[tag.Kind()]
+	if !ok {
+		return condition{}, errors.NotSupportedf("conditions on %s", tag.Kind())
+	}
+	if !containsString(fields, arg.Field) {
+		return condition{}, errors.NotValidf("field %q for %s", arg.Field, tag.Kind())
+	}
+	switch arg.Op {
+	case "==", "!=":
+	case "<", "<=", ">", ">=":
+		if arg.Field != fieldUnitCount {
+			return condition{}, errors.NotValidf("operator %q for field %q", arg.Op, arg.Field)
+		}
+	default:
+		return condition{}, errors.NotValidf("operator %q", arg.Op)
+	}
+	if arg.Field == fieldUnitCount {
+		if _, err := strconv.Atoi(arg.Value); err != nil {
+			return condition{}, errors.NotValidf("unit count %q", arg.Value)
+		}
+	}
+	return condition{
+		tag:   tag,
+		field: arg.Field,
+		op:    arg.Op,
+		value: arg.Value,
+	}, nil
+}
+
+// String returns a human readable form of the condition.
+func (c condition) String() string {
+	return fmt.Sprintf("%s %s %s %q", c.tag, c.field, c.op, c.value)
+}
+
+// satisfied reports whether the condition holds for the supplied
+// model contents. A condition on an entity which does not exist is
+// never satisfied.
+func (c condition) satisfied(m *model) bool {
+	actual, ok := m.field(c.tag, c.field)
+	if !ok {
+		return false
+	}
+	if c.field == fieldUnitCount {
+		// The value was validated in newCondition.
+		want, _ := strconv.Atoi(c.value)
+		have, _ := strconv.Atoi(actual)
+		return compareInts(have, c.op, want)
+	}
+	switch c.op {
+	case "==":
+		return actual == c.value
+	case "!=":
+		return actual != c.value
+	}
+	return false
+}
+
+func compareInts(a int, op string, b int) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// model holds the parts of the model's contents, as reported by
+// the multiwatcher, that conditions can be evaluated against.
+type model struct {
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	machines     map[string]*multiwatcher.MachineInfo
+}
+
+func newModel() *model {
+	return &model{
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+	}
+}
+
+// apply updates the model with the supplied deltas.
+func (m *model) apply(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(m.applications, info.Name)
+			} else {
+				m.applications[info.Name] = info
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(m.units, info.Name)
+			} else {
+				m.units[info.Name] = info
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(m.machines, info.Id)
+			} else {
+				m.machines[info.Id] = info
+			}
+		}
+	}
+}
+
+// field returns the current value of the named field of the entity
+// with the given tag, and whether the entity exists.
+func (m *model) field(tag names.Tag, field string) (string, bool) {
+	switch tag.Kind() {
+	case names.ApplicationTagKind:
+		info, ok := m.applications[tag.Id()]
+		if !ok {
+			return "", false
+		}
+		switch field {
+		case fieldStatus:
+			return string(info.Status.Current), true
+		case fieldUnitCount:
+			count := 0
+			for _, unit := range m.units {
+				if unit.Application == info.Name {
+					count++
+				}
+			}
+			return strconv.Itoa(count), true
+		}
+	case names.UnitTagKind:
+		info, ok := m.units[tag.Id()]
+		if !ok {
+			return "", false
+		}
+		switch field {
+		case fieldStatus:
+			return string(info.WorkloadStatus.Current), true
+		case fieldAgentStatus:
+			return string(info.AgentStatus.Current), true
+		case fieldWorkloadVersion:
+			return info.WorkloadVersion, true
+		}
+	case names.MachineTagKind:
+		info, ok := m.machines[tag.Id()]
+		if !ok {
+			return "", false
+		}
+		switch field {
+		case fieldStatus:
+			return string(info.AgentStatus.Current), true
+		case fieldInstanceStatus:
+			return string(info.InstanceStatus.Current), true
+		}
+	}
+	return "", false
+}
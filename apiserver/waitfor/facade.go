@@ -0,0 +1,139 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package waitfor implements the API endpoint used by clients that
+// wish to block until conditions about the entities in a model are
+// met. Conditions are evaluated by the controller as the model
+// changes, so clients need not poll for status.
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+func init() {
+	common.RegisterStandardFacade("WaitFor", 1, newFacade)
+}
+
+// MaxTimeout is the longest time a single WaitFor call will block.
+// Clients wishing to wait longer should call WaitFor repeatedly.
+const MaxTimeout = 10 * time.Minute
+
+// Facade implements the WaitFor API.
+type Facade struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	clock      clock.Clock
+}
+
+// New returns a new WaitFor API facade.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer, clock clock.Clock) (*Facade, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:    backend,
+		authorizer: authorizer,
+		clock:      clock,
+	}, nil
+}
+
+func (f *Facade) checkCanRead() error {
+	canRead, err := f.authorizer.HasPermission(description.ReadAccess, f.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// WaitFor blocks until all the supplied conditions hold at the same
+// time, or until the timeout expires. The timeout is capped at
+// MaxTimeout; a zero timeout also means MaxTimeout. Expiry of the
+// timeout is not an error: the result reports the conditions that
+// were not satisfied.
+func (f *Facade) WaitFor(args params.WaitForArgs) (params.WaitForResult, error) {
+	if err := f.checkCanRead(); err != nil {
+		return params.WaitForResult{}, errors.Trace(err)
+	}
+	if len(args.Conditions) == 0 {
+		return params.WaitForResult{}, errors.New("no conditions specified")
+	}
+	conditions := make([]condition, len(args.Conditions))
+	for i, arg := range args.Conditions {
+		cond, err := newCondition(arg)
+		if err != nil {
+			return params.WaitForResult{}, errors.Trace(err)
+		}
+		conditions[i] = cond
+	}
+	timeout := args.Timeout
+	if timeout <= 0 || timeout > MaxTimeout {
+		timeout = MaxTimeout
+	}
+
+	w := f.backend.WatchAll()
+	defer w.Stop()
+
+	// Next blocks, so read the deltas in a separate goroutine;
+	// stopping the watcher on return unblocks it.
+	deltasc := make(chan []multiwatcher.Delta)
+	errc := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			deltas, err := w.Next()
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case deltasc <- deltas:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	m := newModel()
+	timedOut := f.clock.After(timeout)
+	for {
+		select {
+		case deltas := <-deltasc:
+			m.apply(deltas)
+			if unsatisfied := unsatisfiedConditions(conditions, m); len(unsatisfied) == 0 {
+				return params.WaitForResult{Satisfied: true}, nil
+			}
+		case err := <-errc:
+			return params.WaitForResult{}, errors.Trace(err)
+		case <-timedOut:
+			return params.WaitForResult{
+				Unsatisfied: unsatisfiedConditions(conditions, m),
+			}, nil
+		}
+	}
+}
+
+// unsatisfiedConditions returns descriptions of the conditions which
+// do not hold for the supplied model.
+func unsatisfiedConditions(conditions []condition, m *model) []string {
+	var unsatisfied []string
+	for _, cond := range conditions {
+		if !cond.satisfied(m) {
+			unsatisfied = append(unsatisfied, cond.String())
+		}
+	}
+	return unsatisfied
+}
@@ -0,0 +1,287 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/waitfor"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	clock      *coretesting.Clock
+	facade     *waitfor.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{watcher: newMockWatcher()}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("igor"),
+		AdminTag: names.NewUserTag("igor"),
+	}
+	s.clock = coretesting.NewClock(time.Now())
+	facade, err := waitfor.New(s.backend, nil, s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestAgentAuthNotAllowed(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := waitfor.New(s.backend, nil, s.authorizer, s.clock)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestReadAccessRequired(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	facade, err := waitfor.New(s.backend, nil, s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.WaitFor(params.WaitForArgs{
+		Conditions: []params.WaitForCondition{unitStatus("active")},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestNoConditions(c *gc.C) {
+	_, err := s.facade.WaitFor(params.WaitForArgs{})
+	c.Assert(err, gc.ErrorMatches, "no conditions specified")
+}
+
+func (s *facadeSuite) TestInvalidConditions(c *gc.C) {
+	for i, test := range []struct {
+		cond   params.WaitForCondition
+		expect string
+	}{{
+		cond:   params.WaitForCondition{Tag: "foo", Field: "status", Op: "==", Value: "active"},
+		expect: `"foo" is not a valid tag`,
+	}, {
+		cond:   params.WaitForCondition{Tag: "user-bob", Field: "status", Op: "==", Value: "active"},
+		expect: `conditions on user not supported`,
+	}, {
+		cond:   params.WaitForCondition{Tag: "unit-mysql-0", Field: "unit-count", Op: "==", Value: "1"},
+		expect: `field "unit-count" for unit not valid`,
+	}, {
+		cond:   params.WaitForCondition{Tag: "unit-mysql-0", Field: "status", Op: "=~", Value: "active"},
+		expect: `operator "=~" not valid`,
+	}, {
+		cond:   params.WaitForCondition{Tag: "unit-mysql-0", Field: "status", Op: ">", Value: "active"},
+		expect: `operator ">" for field "status" not valid`,
+	}, {
+		cond:   params.WaitForCondition{Tag: "application-mysql", Field: "unit-count", Op: ">", Value: "many"},
+		expect: `unit count "many" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.facade.WaitFor(params.WaitForArgs{
+			Conditions: []params.WaitForCondition{test.cond},
+		})
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *facadeSuite) TestSatisfiedByInitialState(c *gc.C) {
+	result := s.waitFor(c, params.WaitForArgs{
+		Conditions: []params.WaitForCondition{
+			unitStatus("active"),
+			{Tag: "application-mysql", Field: "unit-count", Op: ">=", Value: "1"},
+			{Tag: "unit-mysql-0", Field: "workload-version", Op: "==", Value: "5.7"},
+		},
+	}, [][]multiwatcher.Delta{{
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", status.StatusActive, "5.7"),
+	}})
+	c.Assert(result, jc.DeepEquals, params.WaitForResult{Satisfied: true})
+}
+
+func (s *facadeSuite) TestSatisfiedAfterChanges(c *gc.C) {
+	result := s.waitFor(c, params.WaitForArgs{
+		Conditions: []params.WaitForCondition{
+			unitStatus("active"),
+			{Tag: "application-mysql", Field: "unit-count", Op: "==", Value: "2"},
+		},
+	}, [][]multiwatcher.Delta{{
+		applicationDelta("mysql"),
+	}, {
+		unitDelta("mysql/0", status.StatusMaintenance, ""),
+	}, {
+		unitDelta("mysql/1", status.StatusMaintenance, ""),
+	}, {
+		unitDelta("mysql/0", status.StatusActive, ""),
+	}})
+	c.Assert(result, jc.DeepEquals, params.WaitForResult{Satisfied: true})
+}
+
+func (s *facadeSuite) TestRemovedEntity(c *gc.C) {
+	result := s.waitFor(c, params.WaitForArgs{
+		Conditions: []params.WaitForCondition{
+			{Tag: "application-mysql", Field: "unit-count", Op: "==", Value: "0"},
+		},
+	}, [][]multiwatcher.Delta{{
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", status.StatusActive, ""),
+	}, {{
+		Removed: true,
+		Entity:  &multiwatcher.UnitInfo{Name: "mysql/0", Application: "mysql"},
+	}}})
+	c.Assert(result, jc.DeepEquals, params.WaitForResult{Satisfied: true})
+}
+
+func (s *facadeSuite) TestTimeout(c *gc.C) {
+	resultc := make(chan params.WaitForResult)
+	go func() {
+		result, err := s.facade.WaitFor(params.WaitForArgs{
+			Conditions: []params.WaitForCondition{
+				unitStatus("active"),
+				{Tag: "machine-0", Field: "status", Op: "==", Value: "started"},
+			},
+			Timeout: time.Minute,
+		})
+		c.Check(err, jc.ErrorIsNil)
+		resultc <- result
+	}()
+	s.sendDeltas(c, []multiwatcher.Delta{
+		unitDelta("mysql/0", status.StatusBlocked, ""),
+	})
+	s.waitAlarm(c)
+	s.clock.Advance(time.Minute)
+
+	select {
+	case result := <-resultc:
+		c.Assert(result, jc.DeepEquals, params.WaitForResult{
+			Unsatisfied: []string{
+				`unit-mysql-0 status == "active"`,
+				`machine-0 status == "started"`,
+			},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+	s.checkStopped(c)
+}
+
+func (s *facadeSuite) TestTimeoutCapped(c *gc.C) {
+	resultc := make(chan params.WaitForResult)
+	go func() {
+		result, err := s.facade.WaitFor(params.WaitForArgs{
+			Conditions: []params.WaitForCondition{unitStatus("active")},
+			Timeout:    24 * time.Hour,
+		})
+		c.Check(err, jc.ErrorIsNil)
+		resultc <- result
+	}()
+	s.waitAlarm(c)
+	s.clock.Advance(waitfor.MaxTimeout)
+
+	select {
+	case result := <-resultc:
+		c.Assert(result.Satisfied, jc.IsFalse)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+}
+
+func (s *facadeSuite) TestWatcherError(c *gc.C) {
+	errc := make(chan error)
+	go func() {
+		_, err := s.facade.WaitFor(params.WaitForArgs{
+			Conditions: []params.WaitForCondition{unitStatus("active")},
+		})
+		errc <- err
+	}()
+	close(s.backend.watcher.deltas)
+
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.ErrorMatches, "watcher failed")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+}
+
+// waitFor calls WaitFor with the supplied arguments, feeding the
+// given batches of deltas to the watcher, and returns the result.
+func (s *facadeSuite) waitFor(c *gc.C, args params.WaitForArgs, batches [][]multiwatcher.Delta) params.WaitForResult {
+	resultc := make(chan params.WaitForResult, 1)
+	go func() {
+		result, err := s.facade.WaitFor(args)
+		c.Check(err, jc.ErrorIsNil)
+		resultc <- result
+	}()
+	for _, deltas := range batches {
+		s.sendDeltas(c, deltas)
+	}
+	select {
+	case result := <-resultc:
+		s.checkStopped(c)
+		return result
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+	panic("unreachable")
+}
+
+func (s *facadeSuite) sendDeltas(c *gc.C, deltas []multiwatcher.Delta) {
+	select {
+	case s.backend.watcher.deltas <- deltas:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending deltas")
+	}
+}
+
+func (s *facadeSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for timeout to be set")
+	}
+}
+
+func (s *facadeSuite) checkStopped(c *gc.C) {
+	select {
+	case <-s.backend.watcher.stopped:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("watcher not stopped")
+	}
+}
+
+func unitStatus(value string) params.WaitForCondition {
+	return params.WaitForCondition{
+		Tag:   "unit-mysql-0",
+		Field: "status",
+		Op:    "==",
+		Value: value,
+	}
+}
+
+func applicationDelta(name string) multiwatcher.Delta {
+	return multiwatcher.Delta{
+		Entity: &multiwatcher.ApplicationInfo{Name: name},
+	}
+}
+
+func unitDelta(name string, current status.Status, version string) multiwatcher.Delta {
+	application, _ := names.UnitApplication(name)
+	return multiwatcher.Delta{
+		Entity: &multiwatcher.UnitInfo{
+			Name:            name,
+			Application:     application,
+			WorkloadStatus:  multiwatcher.StatusInfo{Current: current},
+			WorkloadVersion: version,
+		},
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/waitfor"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	watcher *mockWatcher
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) WatchAll() waitfor.AllWatcher {
+	return b.watcher
+}
+
+// mockWatcher returns the deltas sent on its channel from Next, and
+// an error once stopped.
+type mockWatcher struct {
+	deltas  chan []multiwatcher.Delta
+	stopped chan struct{}
+}
+
+func newMockWatcher() *mockWatcher {
+	return &mockWatcher{
+		deltas:  make(chan []multiwatcher.Delta),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (w *mockWatcher) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case deltas, ok := <-w.deltas:
+		if !ok {
+			return nil, errors.New("watcher failed")
+		}
+		return deltas, nil
+	case <-w.stopped:
+		return nil, errors.New("watcher was stopped")
+	}
+}
+
+func (w *mockWatcher) Stop() error {
+	select {
+	case <-w.stopped:
+	default:
+		close(w.stopped)
+	}
+	return nil
+}
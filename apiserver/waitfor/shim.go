@@ -0,0 +1,40 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// Backend defines the State API used by the waitfor facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	WatchAll() AllWatcher
+}
+
+// AllWatcher defines the methods of state.Multiwatcher used by the
+// waitfor facade.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return New(&backend{st}, res, auth, clock.WallClock)
+}
+
+type backend struct {
+	*state.State
+}
+
+// WatchAll is part of the Backend interface.
+func (b *backend) WatchAll() AllWatcher {
+	return b.State.Watch()
+}
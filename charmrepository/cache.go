@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+)
+
+// cachedArchivePath returns the path at which the archive for the
+// given charm is cached. Charm store downloads share the same cache
+// directory.
+func cachedArchivePath(curl *charm.URL) string {
+	return filepath.Join(charmrepo.CacheDir, charm.Quote(curl.String())+".charm")
+}
+
+// cacheArchive writes the archive for the given charm into the cache
+// using the write function, and returns its path. The archive only
+// appears in the cache once written completely.
+func cacheArchive(curl *charm.URL, write func(io.Writer) error) (_ string, err error) {
+	if err := os.MkdirAll(charmrepo.CacheDir, 0755); err != nil {
+		return "", errors.Trace(err)
+	}
+	f, err := ioutil.TempFile(charmrepo.CacheDir, "charm-download")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	if err := write(f); err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Trace(err)
+	}
+	path := cachedArchivePath(curl)
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", errors.Trace(err)
+	}
+	return path, nil
+}
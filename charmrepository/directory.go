@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+)
+
+// directoryRepository serves charms from a directory on the
+// controller. Charms are referred to with the same URLs as they would
+// be in the charm store; the directory is laid out as for a local
+// charm repository.
+type directoryRepository struct {
+	local charmrepo.Interface
+}
+
+func newDirectoryRepository(path string) *directoryRepository {
+	return &directoryRepository{
+		local: &charmrepo.LocalRepository{Path: path},
+	}
+}
+
+// Resolve is part of the Repository interface.
+func (r *directoryRepository) Resolve(ref *charm.URL) (*charm.URL, []string, error) {
+	resolved, supportedSeries, err := r.local.Resolve(localURL(ref))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	result := *ref
+	result.Series = resolved.Series
+	result.Revision = resolved.Revision
+	return &result, supportedSeries, nil
+}
+
+// Get is part of the Repository interface.
+func (r *directoryRepository) Get(curl *charm.URL) (charm.Charm, error) {
+	ch, err := r.local.Get(localURL(curl))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch ch := ch.(type) {
+	case *charm.CharmArchive:
+		return ch, nil
+	case *charm.CharmDir:
+		// Callers expect an archive, so bundle up the directory.
+		path, err := cacheArchive(curl, func(w io.Writer) error {
+			return ch.ArchiveTo(w)
+		})
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot archive charm %q", curl)
+		}
+		return charm.ReadCharmArchive(path)
+	}
+	return nil, errors.Errorf("unexpected charm type %T for %q", ch, curl)
+}
+
+// localURL returns the URL under which the charm with the given URL
+// is found in a local repository.
+func localURL(curl *charm.URL) *charm.URL {
+	result := *curl
+	result.Schema = "local"
+	result.User = ""
+	return &result
+}
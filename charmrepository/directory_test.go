@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/testcharms"
+)
+
+type directorySuite struct {
+	testing.IsolationSuite
+	repo charmrepository.Repository
+}
+
+var _ = gc.Suite(&directorySuite{})
+
+func (s *directorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(&charmrepo.CacheDir, c.MkDir())
+	repo, err := charmrepository.Open(charmrepository.OpenParams{
+		Location: testcharms.Repo.Path(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.repo = repo
+}
+
+func (s *directorySuite) TestResolve(c *gc.C) {
+	curl, _, err := s.repo.Resolve(charm.MustParseURL("cs:quantal/dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/dummy-1")
+}
+
+func (s *directorySuite) TestResolveNotFound(c *gc.C) {
+	_, _, err := s.repo.Resolve(charm.MustParseURL("cs:quantal/no-such-charm"))
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *directorySuite) TestGetArchivesDirectory(c *gc.C) {
+	ch, err := s.repo.Get(charm.MustParseURL("cs:quantal/dummy-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	archive, ok := ch.(*charm.CharmArchive)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(archive.Meta().Name, gc.Equals, "dummy")
+	c.Assert(archive.Revision(), gc.Equals, 1)
+
+	// The archive is written to the charm cache.
+	c.Assert(archive.Path, jc.IsNonEmptyFile)
+	c.Assert(archive.Path, jc.HasPrefix, charmrepo.CacheDir)
+}
+
+func (s *directorySuite) TestGetWrongRevision(c *gc.C) {
+	_, err := s.repo.Get(charm.MustParseURL("cs:quantal/dummy-99"))
+	c.Assert(err, gc.NotNil)
+}
@@ -0,0 +1,227 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+)
+
+// IndexFile is the name of the file, at the root of an HTTPS
+// repository, that describes the charms the repository holds.
+const IndexFile = "index.yaml"
+
+// Index describes the charms held by an HTTPS repository.
+type Index struct {
+	Charms []IndexEntry `yaml:"charms"`
+}
+
+// IndexEntry describes a single charm revision held by an HTTPS
+// repository.
+type IndexEntry struct {
+	// Name and User identify the charm, as in a charm store URL.
+	Name string `yaml:"name"`
+	User string `yaml:"user,omitempty"`
+
+	// Revision is the revision of the charm.
+	Revision int `yaml:"revision"`
+
+	// Series lists the series supported by the charm, preferred
+	// series first.
+	Series []string `yaml:"series"`
+
+	// Archive is the location of the charm archive, relative to the
+	// root of the repository.
+	Archive string `yaml:"archive"`
+
+	// SHA256 is the hex-encoded SHA256 hash of the charm archive.
+	SHA256 string `yaml:"sha256"`
+}
+
+func (e IndexEntry) supportsSeries(series string) bool {
+	for _, s := range e.Series {
+		if s == series {
+			return true
+		}
+	}
+	return false
+}
+
+// httpsRepository serves charms from a plain HTTPS server. The index
+// file is fetched once, on first use.
+type httpsRepository struct {
+	baseURL *url.URL
+	client  *http.Client
+	index   *Index
+}
+
+func newHTTPSRepository(baseURL *url.URL, client *http.Client) *httpsRepository {
+	base := *baseURL
+	if n := len(base.Path); n == 0 || base.Path[n-1] != '/' {
+		// Make sure relative references resolve within the repository.
+		base.Path += "/"
+	}
+	return &httpsRepository{
+		baseURL: &base,
+		client:  client,
+	}
+}
+
+// Resolve is part of the Repository interface. If the reference has
+// no revision, the latest revision is chosen; if it has no series,
+// the preferred series of that revision is used.
+func (r *httpsRepository) Resolve(ref *charm.URL) (*charm.URL, []string, error) {
+	entry, err := r.find(ref)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	result := *ref
+	if result.Series == "" {
+		result.Series = entry.Series[0]
+	}
+	result.Revision = entry.Revision
+	return &result, entry.Series, nil
+}
+
+// Get is part of the Repository interface.
+func (r *httpsRepository) Get(curl *charm.URL) (charm.Charm, error) {
+	if curl.Revision < 0 {
+		return nil, errors.Errorf("charm URL %q must include revision", curl)
+	}
+	entry, err := r.find(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	path := cachedArchivePath(curl)
+	if ok, err := hashMatches(path, entry.SHA256); err != nil {
+		return nil, errors.Trace(err)
+	} else if !ok {
+		if path, err = r.download(curl, entry); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return charm.ReadCharmArchive(path)
+}
+
+// find returns the latest index entry matching the given reference.
+func (r *httpsRepository) find(ref *charm.URL) (IndexEntry, error) {
+	index, err := r.getIndex()
+	if err != nil {
+		return IndexEntry{}, errors.Trace(err)
+	}
+	var found *IndexEntry
+	for i, entry := range index.Charms {
+		if entry.Name != ref.Name || entry.User != ref.User {
+			continue
+		}
+		if ref.Revision >= 0 && entry.Revision != ref.Revision {
+			continue
+		}
+		if ref.Series != "" && !entry.supportsSeries(ref.Series) {
+			continue
+		}
+		if found == nil || entry.Revision > found.Revision {
+			found = &index.Charms[i]
+		}
+	}
+	if found == nil {
+		return IndexEntry{}, errors.NotFoundf("charm %q in repository %q", ref, r.baseURL)
+	}
+	return *found, nil
+}
+
+func (r *httpsRepository) getIndex() (*Index, error) {
+	if r.index != nil {
+		return r.index, nil
+	}
+	indexURL := r.resolveURL(IndexFile)
+	body, err := r.fetch(indexURL)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot fetch charm repository index")
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm repository index")
+	}
+	var index Index
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse charm repository index %q", indexURL)
+	}
+	for i, entry := range index.Charms {
+		if entry.Name == "" || entry.Archive == "" || entry.SHA256 == "" || len(entry.Series) == 0 {
+			return nil, errors.NotValidf("charm repository index entry %d", i)
+		}
+	}
+	r.index = &index
+	return r.index, nil
+}
+
+// download fetches the archive for the given entry into the cache,
+// verifying its hash.
+func (r *httpsRepository) download(curl *charm.URL, entry IndexEntry) (string, error) {
+	archiveURL := r.resolveURL(entry.Archive)
+	logger.Debugf("downloading %q from %q", curl, archiveURL)
+	body, err := r.fetch(archiveURL)
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot download charm %q", curl)
+	}
+	defer body.Close()
+	return cacheArchive(curl, func(w io.Writer) error {
+		hash := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(w, hash), body); err != nil {
+			return errors.Annotatef(err, "cannot download charm %q", curl)
+		}
+		if sum := hex.EncodeToString(hash.Sum(nil)); sum != entry.SHA256 {
+			return errors.Errorf("charm %q has SHA256 %s, expected %s", curl, sum, entry.SHA256)
+		}
+		return nil
+	})
+}
+
+func (r *httpsRepository) resolveURL(ref string) string {
+	u, err := url.Parse(ref)
+	if err != nil {
+		// Let the request report the bad location.
+		return ref
+	}
+	return r.baseURL.ResolveReference(u).String()
+}
+
+func (r *httpsRepository) fetch(location string) (io.ReadCloser, error) {
+	resp, err := r.client.Get(location)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, errors.NotFoundf("%q", location)
+		}
+		return nil, errors.Errorf("cannot fetch %q: %s", location, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// hashMatches reports whether the file at the given path exists and
+// has the given hex-encoded SHA256 hash.
+func hashMatches(path, expected string) (bool, error) {
+	sum, _, err := utils.ReadFileSHA256(path)
+	if os.IsNotExist(errors.Cause(err)) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return sum == expected, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/testcharms"
+)
+
+type httpsSuite struct {
+	testing.IsolationSuite
+	server    *httptest.Server
+	index     string
+	archive   string
+	downloads int
+}
+
+var _ = gc.Suite(&httpsSuite{})
+
+func (s *httpsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(&charmrepo.CacheDir, c.MkDir())
+	s.downloads = 0
+
+	s.archive = testcharms.Repo.CharmArchivePath(c.MkDir(), "dummy")
+	sha256, _, err := utils.ReadFileSHA256(s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+	s.index = fmt.Sprintf(`
+charms:
+- name: dummy
+  revision: 1
+  series: [quantal, trusty]
+  archive: archives/dummy-1.charm
+  sha256: %s
+- name: dummy
+  revision: 2
+  series: [trusty]
+  archive: archives/dummy-2.charm
+  sha256: %s
+`[1:], sha256, sha256)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repo/index.yaml", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, s.index)
+	})
+	mux.HandleFunc("/repo/archives/dummy-1.charm", func(w http.ResponseWriter, req *http.Request) {
+		s.downloads++
+		http.ServeFile(w, req, s.archive)
+	})
+	s.server = httptest.NewTLSServer(mux)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *httpsSuite) open(c *gc.C) charmrepository.Repository {
+	repo, err := charmrepository.Open(charmrepository.OpenParams{
+		Location:   s.server.URL + "/repo",
+		HTTPClient: utils.GetNonValidatingHTTPClient(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return repo
+}
+
+func (s *httpsSuite) TestResolve(c *gc.C) {
+	repo := s.open(c)
+	for i, test := range []struct {
+		ref             string
+		expect          string
+		supportedSeries []string
+	}{{
+		ref:             "cs:dummy",
+		expect:          "cs:trusty/dummy-2",
+		supportedSeries: []string{"trusty"},
+	}, {
+		ref:             "cs:quantal/dummy",
+		expect:          "cs:quantal/dummy-1",
+		supportedSeries: []string{"quantal", "trusty"},
+	}, {
+		ref:             "cs:dummy-1",
+		expect:          "cs:quantal/dummy-1",
+		supportedSeries: []string{"quantal", "trusty"},
+	}} {
+		c.Logf("test %d: %s", i, test.ref)
+		curl, supportedSeries, err := repo.Resolve(charm.MustParseURL(test.ref))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(curl.String(), gc.Equals, test.expect)
+		c.Check(supportedSeries, jc.DeepEquals, test.supportedSeries)
+	}
+}
+
+func (s *httpsSuite) TestResolveNotFound(c *gc.C) {
+	_, _, err := s.open(c).Resolve(charm.MustParseURL("cs:precise/dummy"))
+	c.Assert(err, gc.ErrorMatches, `charm "cs:precise/dummy" in repository ".*/repo/" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *httpsSuite) TestGet(c *gc.C) {
+	repo := s.open(c)
+	ch, err := repo.Get(charm.MustParseURL("cs:quantal/dummy-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.Meta().Name, gc.Equals, "dummy")
+	c.Assert(s.downloads, gc.Equals, 1)
+
+	// The cached archive is reused.
+	_, err = s.open(c).Get(charm.MustParseURL("cs:quantal/dummy-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.downloads, gc.Equals, 1)
+}
+
+func (s *httpsSuite) TestGetMissingArchive(c *gc.C) {
+	_, err := s.open(c).Get(charm.MustParseURL("cs:trusty/dummy-2"))
+	c.Assert(err, gc.ErrorMatches, `cannot download charm "cs:trusty/dummy-2": .* not found`)
+}
+
+func (s *httpsSuite) TestGetHashMismatch(c *gc.C) {
+	err := os.Truncate(s.archive, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.open(c).Get(charm.MustParseURL("cs:quantal/dummy-1"))
+	c.Assert(err, gc.ErrorMatches, `charm "cs:quantal/dummy-1" has SHA256 .*, expected .*`)
+	cached, err := filepath.Glob(filepath.Join(charmrepo.CacheDir, "*"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached, gc.HasLen, 0)
+}
+
+func (s *httpsSuite) TestInvalidIndex(c *gc.C) {
+	s.index = "charms:\n- name: dummy\n"
+	_, _, err := s.open(c).Resolve(charm.MustParseURL("cs:dummy"))
+	c.Assert(err, gc.ErrorMatches, `charm repository index entry 0 not valid`)
+}
@@ -0,0 +1,135 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrepository provides access to the repositories a
+// controller may deploy charms from: the charm store, a directory on the
+// controller, or a plain HTTPS server publishing an index file. The
+// latter two allow sites without access to the charm store to host
+// their own charms.
+package charmrepository
+
+import (
+	"net/http"
+	"net/url"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+var logger = loggo.GetLogger("juju.charmrepository")
+
+// Repository represents a source of charms for deployment.
+type Repository interface {
+	// Resolve resolves the given reference to a fully qualified
+	// charm URL, also returning the series the charm supports.
+	Resolve(ref *charm.URL) (*charm.URL, []string, error)
+
+	// Get returns the charm with the given fully qualified URL. The
+	// returned charm is always a *charm.CharmArchive.
+	Get(curl *charm.URL) (charm.Charm, error)
+}
+
+// Kind identifies a type of charm repository.
+type Kind string
+
+const (
+	// CharmStore identifies the charm store.
+	CharmStore Kind = "charmstore"
+
+	// Directory identifies a directory on the controller laid out
+	// as <series>/<charm name>, each entry being a charm directory
+	// or archive.
+	Directory Kind = "directory"
+
+	// HTTPS identifies a repository served over HTTPS, described by
+	// an index file at the root of the repository.
+	HTTPS Kind = "https"
+)
+
+// Location describes where a charm repository may be found.
+type Location struct {
+	// Kind identifies the type of repository.
+	Kind Kind
+
+	// Path holds the absolute path of a Directory repository.
+	Path string
+
+	// URL holds the base URL of an HTTPS repository.
+	URL *url.URL
+}
+
+// ParseLocation parses a charm repository location as held in
+// controller config. An empty location, or "charmstore", refers to the charm
+// store; an absolute path or file URL refers to a directory; and an
+// https URL refers to an HTTPS repository.
+func ParseLocation(location string) (Location, error) {
+	if location == "" || location == string(CharmStore) {
+		return Location{Kind: CharmStore}, nil
+	}
+	if filepath.IsAbs(location) {
+		return Location{Kind: Directory, Path: filepath.Clean(location)}, nil
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return Location{}, errors.NotValidf("charm repository location %q", location)
+	}
+	switch u.Scheme {
+	case "file":
+		if !filepath.IsAbs(u.Path) || u.Host != "" {
+			return Location{}, errors.NotValidf("charm repository location %q (path must be absolute)", location)
+		}
+		return Location{Kind: Directory, Path: filepath.Clean(u.Path)}, nil
+	case "https":
+		if u.Host == "" {
+			return Location{}, errors.NotValidf("charm repository location %q (missing host)", location)
+		}
+		return Location{Kind: HTTPS, URL: u}, nil
+	}
+	return Location{}, errors.NotValidf("charm repository location %q", location)
+}
+
+// OpenParams holds the parameters for opening a charm repository.
+type OpenParams struct {
+	// Location is the location of the repository, as accepted by
+	// ParseLocation.
+	Location string
+
+	// OpenCharmStore is called to open the charm store when
+	// Location refers to it.
+	OpenCharmStore func() (Repository, error)
+
+	// HTTPClient is used to fetch from HTTPS repositories. If nil, a
+	// client validating server certificates is used.
+	HTTPClient *http.Client
+}
+
+// Open returns the repository at the given location.
+func Open(p OpenParams) (Repository, error) {
+	loc, err := ParseLocation(p.Location)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch loc.Kind {
+	case CharmStore:
+		if p.OpenCharmStore == nil {
+			return nil, errors.New("charm store not available")
+		}
+		repo, err := p.OpenCharmStore()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return repo, nil
+	case Directory:
+		return newDirectoryRepository(loc.Path), nil
+	case HTTPS:
+		client := p.HTTPClient
+		if client == nil {
+			client = utils.GetValidatingHTTPClient()
+		}
+		return newHTTPSRepository(loc.URL, client), nil
+	}
+	return nil, errors.NotSupportedf("charm repository kind %q", loc.Kind)
+}
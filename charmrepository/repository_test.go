@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmrepository"
+)
+
+type repositorySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&repositorySuite{})
+
+func (s *repositorySuite) TestParseLocation(c *gc.C) {
+	for i, test := range []struct {
+		location string
+		kind     charmrepository.Kind
+		path     string
+		url      string
+		err      string
+	}{{
+		location: "",
+		kind:     charmrepository.CharmStore,
+	}, {
+		location: "charmstore",
+		kind:     charmrepository.CharmStore,
+	}, {
+		location: "/srv/charms/",
+		kind:     charmrepository.Directory,
+		path:     "/srv/charms",
+	}, {
+		location: "file:///srv/charms",
+		kind:     charmrepository.Directory,
+		path:     "/srv/charms",
+	}, {
+		location: "https://charms.example.com/repo",
+		kind:     charmrepository.HTTPS,
+		url:      "https://charms.example.com/repo",
+	}, {
+		location: "srv/charms",
+		err:      `charm repository location "srv/charms" not valid`,
+	}, {
+		location: "file://host/srv/charms",
+		err:      `charm repository location "file://host/srv/charms" \(path must be absolute\) not valid`,
+	}, {
+		location: "https:///repo",
+		err:      `charm repository location "https:///repo" \(missing host\) not valid`,
+	}, {
+		location: "http://charms.example.com/repo",
+		err:      `charm repository location "http://charms.example.com/repo" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.location)
+		loc, err := charmrepository.ParseLocation(test.location)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(loc.Kind, gc.Equals, test.kind)
+		c.Check(loc.Path, gc.Equals, test.path)
+		if test.url == "" {
+			c.Check(loc.URL, gc.IsNil)
+		} else {
+			c.Check(loc.URL.String(), gc.Equals, test.url)
+		}
+	}
+}
+
+type stubRepository struct {
+	charmrepository.Repository
+}
+
+func (s *repositorySuite) TestOpenCharmStore(c *gc.C) {
+	store := &stubRepository{}
+	repo, err := charmrepository.Open(charmrepository.OpenParams{
+		OpenCharmStore: func() (charmrepository.Repository, error) {
+			return store, nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(repo, gc.Equals, store)
+}
+
+func (s *repositorySuite) TestOpenCharmStoreError(c *gc.C) {
+	_, err := charmrepository.Open(charmrepository.OpenParams{
+		OpenCharmStore: func() (charmrepository.Repository, error) {
+			return nil, errors.New("boom")
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *repositorySuite) TestOpenInvalidLocation(c *gc.C) {
+	_, err := charmrepository.Open(charmrepository.OpenParams{
+		Location: "ftp://charms.example.com",
+		OpenCharmStore: func() (charmrepository.Repository, error) {
+			c.Fatalf("unexpected charm store")
+			return nil, nil
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `charm repository location "ftp://charms.example.com" not valid`)
+}
@@ -35,6 +35,14 @@ type Credential struct {
 
 	// Label is optionally set to describe the credentials to a user.
 	Label string
+
+	// Invalid is true if the credential has been reported as no
+	// longer valid, e.g. because it was revoked in the cloud.
+	Invalid bool
+
+	// InvalidReason holds the reason the credential was reported
+	// as invalid.
+	InvalidReason string
 }
 
 // AuthType returns the authentication type.
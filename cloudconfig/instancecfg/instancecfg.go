@@ -145,6 +145,11 @@ type InstanceConfig struct {
 	// instances. If enabled, the OS will perform any upgrades
 	// available as part of its provisioning.
 	EnableOSUpgrade bool
+
+	// CloudInitCustomization holds extra cloud-init directives set
+	// for the machine and its model, which are applied on top of
+	// the configuration above. It is only honoured on unix systems.
+	CloudInitCustomization *params.CloudInitCustomization
 }
 
 // ControllerConfig represents controller-specific initialization information
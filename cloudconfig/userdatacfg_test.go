@@ -1216,6 +1216,36 @@ func (s *cloudinitSuite) testAptMirror(c *gc.C, cfg *config.Config, expect strin
 	//c.Assert(ok, gc.Equals, expect != "")
 }
 
+func (s *cloudinitSuite) TestCloudInitCustomization(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	environConfig, err := environConfig.Apply(map[string]interface{}{
+		"apt-mirror": "http://my.archive.ubuntu.com/ubuntu",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	instanceCfg.CloudInitCustomization = &params.CloudInitCustomization{
+		Packages:  []string{"htop", "tmux"},
+		AptMirror: "http://local.example.com/ubuntu",
+		RunCmds:   []string{"echo hello > /tmp/hello"},
+		SSHCAKeys: []string{"ssh-rsa AAAA ca@example.com"},
+	}
+	cloudcfg, err := cloudinit.New("quantal")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cloudcfg.PackageMirror(), gc.Equals, "http://local.example.com/ubuntu")
+	packages := set.NewStrings(cloudcfg.Packages()...)
+	c.Assert(packages.Contains("htop"), jc.IsTrue)
+	c.Assert(packages.Contains("tmux"), jc.IsTrue)
+	script := strings.Join(cloudcfg.RunCmds(), "\n")
+	c.Assert(script, jc.Contains, "ssh-rsa AAAA ca@example.com")
+	c.Assert(script, jc.Contains, "TrustedUserCAKeys /etc/ssh/juju_user_ca_keys")
+	c.Assert(script, jc.Contains, "echo hello > /tmp/hello")
+}
+
 var serverCert = []byte(`
 SERVER CERT
 -----BEGIN CERTIFICATE-----
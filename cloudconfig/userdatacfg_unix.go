@@ -55,6 +55,11 @@ while true; do
     sleep {{.ToolsDownloadWaitTime}}
     n=$((n+1))
 done`
+
+	// sshUserCAKeysFile is the file in which the public keys of the
+	// certificate authorities trusted to sign SSH user certificates
+	// are written.
+	sshUserCAKeysFile = "/etc/ssh/juju_user_ca_keys"
 )
 
 var (
@@ -181,12 +186,17 @@ func (w *unixConfigure) ConfigureJuju() error {
 		w.conf.AddBootCmd(cloudinit.LogProgressCmd("Logging to %s on remote host", w.icfg.CloudInitOutputLog))
 	}
 
+	aptMirror := w.icfg.AptMirror
+	if custom := w.icfg.CloudInitCustomization; custom != nil && custom.AptMirror != "" {
+		aptMirror = custom.AptMirror
+	}
 	w.conf.AddPackageCommands(
 		w.icfg.AptProxySettings,
-		w.icfg.AptMirror,
+		aptMirror,
 		w.icfg.EnableOSRefreshUpdate,
 		w.icfg.EnableOSUpgrade,
 	)
+	w.addCloudInitCustomization()
 
 	// Write out the normal proxy settings so that the settings are
 	// sourced by bash, and ssh through that.
@@ -272,6 +282,31 @@ func (w *unixConfigure) ConfigureJuju() error {
 	return w.addMachineAgentToBoot()
 }
 
+// addCloudInitCustomization adds the extra packages, SSH user CA keys
+// and commands set for the machine and its model. The commands run
+// before the machine agent is installed, and fail its installation if
+// they fail.
+func (w *unixConfigure) addCloudInitCustomization() {
+	custom := w.icfg.CloudInitCustomization
+	if custom == nil {
+		return
+	}
+	for _, pkg := range custom.Packages {
+		w.conf.AddPackage(pkg)
+	}
+	if len(custom.SSHCAKeys) > 0 {
+		w.conf.AddRunTextFile(sshUserCAKeysFile, strings.Join(custom.SSHCAKeys, "\n")+"\n", 0644)
+		w.conf.AddScripts(
+			fmt.Sprintf(
+				`grep -q '^TrustedUserCAKeys' /etc/ssh/sshd_config || printf '\nTrustedUserCAKeys %s\n' >> /etc/ssh/sshd_config`,
+				sshUserCAKeysFile,
+			),
+			"service ssh reload || service sshd reload || true",
+		)
+	}
+	w.conf.AddScripts(custom.RunCmds...)
+}
+
 func (w *unixConfigure) configureBootstrap() error {
 	// Add the Juju GUI to the bootstrap node.
 	cleanup, err := w.setUpGUI()
@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/alerts"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const addRuleHelpDoc = `
+Adds an alert rule to the model. The controller raises an alert for each
+entity which has matched the rule's kind for longer than the duration
+given with --for, and resolves it once the entity no longer matches.
+
+The kinds of alert rule are:
+
+    unit-error      a unit's workload is in an error state
+    machine-down    a started machine's agent is not communicating
+                    with the server
+
+When an alert is raised or resolved, a JSON notification is POSTed to
+the --webhook URL, and an email is sent to the --email address using
+the controller's alert-smtp-server. The webhook's host must be one of
+the controller's alert-webhook-hosts.
+
+Examples:
+
+    juju add-alert-rule unit-errors --kind unit-error --for 5m \
+        --webhook https://hooks.example.com/juju
+    juju add-alert-rule machines-down --kind machine-down --for 10m \
+        --email ops@example.com
+
+See also:
+    alert-rules
+    remove-alert-rule
+    alerts
+`
+
+// NewAddRuleCommand returns a command which adds an alert rule to a
+// model.
+func NewAddRuleCommand() cmd.Command {
+	return modelcmd.Wrap(&addRuleCommand{})
+}
+
+type addRuleCommand struct {
+	modelcmd.ModelCommandBase
+	api  AddRuleAPI
+	rule params.AlertRule
+}
+
+// AddRuleAPI defines the API methods that the add-alert-rule command
+// uses.
+type AddRuleAPI interface {
+	Close() error
+	AddAlertRule(params.AlertRule) error
+}
+
+// Info implements Command.Info.
+func (c *addRuleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-alert-rule",
+		Args:    "<name> --kind <kind> [--for <duration>] [--webhook <url>] [--email <address>]",
+		Purpose: "Adds an alert rule to a model.",
+		Doc:     addRuleHelpDoc[1:],
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addRuleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.rule.Kind, "kind", "", "the kind of condition to alert on")
+	f.DurationVar(&c.rule.For, "for", 0, "how long the condition must hold before alerting")
+	f.StringVar(&c.rule.Webhook, "webhook", "", "URL to POST notifications to")
+	f.StringVar(&c.rule.Email, "email", "", "address to email notifications to")
+}
+
+// Init implements Command.Init.
+func (c *addRuleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no alert rule name specified")
+	}
+	c.rule.Name = args[0]
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+	if c.rule.Kind == "" {
+		return errors.New("no alert rule kind specified")
+	}
+	if c.rule.For < 0 {
+		return errors.Errorf("negative duration %v not valid", c.rule.For)
+	}
+	return nil
+}
+
+func (c *addRuleCommand) getAPI() (AddRuleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return alerts.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *addRuleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.AddAlertRule(c.rule); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
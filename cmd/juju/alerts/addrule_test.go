@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/alerts"
+	"github.com/juju/juju/testing"
+)
+
+type AddRuleSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeAlertsAPI
+}
+
+var _ = gc.Suite(&AddRuleSuite{})
+
+func (s *AddRuleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeAlertsAPI{}
+}
+
+func (s *AddRuleSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no alert rule name specified",
+	}, {
+		args: []string{"unit-errors", "bar", "--kind", "unit-error"},
+		err:  `unrecognized args: \["bar"\]`,
+	}, {
+		args: []string{"unit-errors"},
+		err:  "no alert rule kind specified",
+	}, {
+		args: []string{"unit-errors", "--kind", "unit-error", "--for", "-5m"},
+		err:  "negative duration -5m0s not valid",
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(alerts.NewAddRuleCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AddRuleSuite) TestAddRule(c *gc.C) {
+	_, err := testing.RunCommand(c, alerts.NewAddRuleCommandForTest(s.fake),
+		"unit-errors", "--kind", "unit-error", "--for", "5m",
+		"--webhook", "https://hooks.example.com/juju", "--email", "ops@example.com",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "AddAlertRule", "Close")
+	s.fake.CheckCall(c, 0, "AddAlertRule", params.AlertRule{
+		Name:    "unit-errors",
+		Kind:    "unit-error",
+		For:     5 * time.Minute,
+		Webhook: "https://hooks.example.com/juju",
+		Email:   "ops@example.com",
+	})
+}
+
+func (s *AddRuleSuite) TestAddRuleError(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Message: `alert rule "unit-errors" already exists`})
+	_, err := testing.RunCommand(c, alerts.NewAddRuleCommandForTest(s.fake),
+		"unit-errors", "--kind", "unit-error",
+	)
+	c.Assert(err, gc.ErrorMatches, `alert rule "unit-errors" already exists`)
+}
+
+func (s *AddRuleSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockedError"))
+	_, err := testing.RunCommand(c, alerts.NewAddRuleCommandForTest(s.fake),
+		"unit-errors", "--kind", "unit-error",
+	)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
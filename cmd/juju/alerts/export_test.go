@@ -0,0 +1,34 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewListAlertsCommandForTest returns an alerts command with the api
+// provided as specified.
+func NewListAlertsCommandForTest(api ListAlertsAPI) cmd.Command {
+	return modelcmd.Wrap(&listAlertsCommand{api: api})
+}
+
+// NewListRulesCommandForTest returns an alert-rules command with the
+// api provided as specified.
+func NewListRulesCommandForTest(api ListRulesAPI) cmd.Command {
+	return modelcmd.Wrap(&listRulesCommand{api: api})
+}
+
+// NewAddRuleCommandForTest returns an add-alert-rule command with the
+// api provided as specified.
+func NewAddRuleCommandForTest(api AddRuleAPI) cmd.Command {
+	return modelcmd.Wrap(&addRuleCommand{api: api})
+}
+
+// NewRemoveRuleCommandForTest returns a remove-alert-rule command with
+// the api provided as specified.
+func NewRemoveRuleCommandForTest(api RemoveRuleAPI) cmd.Command {
+	return modelcmd.Wrap(&removeRuleCommand{api: api})
+}
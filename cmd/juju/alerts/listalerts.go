@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package alerts provides the commands which manage the alert rules of
+// a model, and list the alerts raised for them.
+package alerts
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/alerts"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const listAlertsHelpDoc = `
+Lists the alerts currently raised in the model. An alert is raised by
+the controller when the condition described by one of the model's alert
+rules has held for longer than the rule allows, and is resolved once the
+condition no longer holds.
+
+Examples:
+
+    juju alerts
+    juju alerts --format yaml
+
+See also:
+    add-alert-rule
+    alert-rules
+`
+
+// NewListAlertsCommand returns a command which lists the alerts raised
+// in a model.
+func NewListAlertsCommand() cmd.Command {
+	return modelcmd.Wrap(&listAlertsCommand{})
+}
+
+type listAlertsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ListAlertsAPI
+}
+
+// ListAlertsAPI defines the API methods that the alerts command uses.
+type ListAlertsAPI interface {
+	Close() error
+	ListAlerts() ([]params.Alert, error)
+}
+
+// alertInfo holds the details of a raised alert, for output.
+type alertInfo struct {
+	Rule    string    `yaml:"rule" json:"rule"`
+	Kind    string    `yaml:"kind" json:"kind"`
+	Entity  string    `yaml:"entity" json:"entity"`
+	Message string    `yaml:"message,omitempty" json:"message,omitempty"`
+	Since   time.Time `yaml:"since" json:"since"`
+	Raised  time.Time `yaml:"raised" json:"raised"`
+}
+
+// Info implements Command.Info.
+func (c *listAlertsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "alerts",
+		Purpose: "Lists the alerts raised in a model.",
+		Doc:     listAlertsHelpDoc[1:],
+		Aliases: []string{"list-alerts"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listAlertsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAlertsTabular,
+	})
+}
+
+func (c *listAlertsCommand) getAPI() (ListAlertsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return alerts.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *listAlertsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	raised, err := client.ListAlerts()
+	if err != nil {
+		return err
+	}
+	if len(raised) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No alerts raised.")
+		return nil
+	}
+	infos := make([]alertInfo, len(raised))
+	for i, alert := range raised {
+		infos[i] = alertInfo{
+			Rule:    alert.Rule,
+			Kind:    alert.Kind,
+			Entity:  alert.Entity,
+			Message: alert.Message,
+			Since:   alert.Since.UTC(),
+			Raised:  alert.Raised.UTC(),
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+// formatAlertsTabular takes an interface{} to adhere to the
+// cmd.Formatter interface.
+func formatAlertsTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.([]alertInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "RULE\tKIND\tENTITY\tSINCE\tMESSAGE\n")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			info.Rule, info.Kind, info.Entity,
+			common.FormatTime(&info.Since, true), info.Message,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/alerts"
+	"github.com/juju/juju/testing"
+)
+
+type ListAlertsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeAlertsAPI
+}
+
+var _ = gc.Suite(&ListAlertsSuite{})
+
+func (s *ListAlertsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	since := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	s.fake = &fakeAlertsAPI{
+		alerts: []params.Alert{{
+			Rule:    "machines-down",
+			Kind:    "machine-down",
+			Entity:  "machine-1",
+			Message: "agent is not communicating with the server",
+			Since:   since,
+			Raised:  since.Add(10 * time.Minute),
+		}},
+	}
+}
+
+func (s *ListAlertsSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(alerts.NewListAlertsCommandForTest(s.fake), []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ListAlertsSuite) TestTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, alerts.NewListAlertsCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"RULE           KIND          ENTITY     SINCE                 MESSAGE\n"+
+		"machines-down  machine-down  machine-1  2016-10-03 12:00:00Z  agent is not communicating with the server\n"+
+		"\n",
+	)
+	s.fake.CheckCallNames(c, "ListAlerts", "Close")
+}
+
+func (s *ListAlertsSuite) TestYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, alerts.NewListAlertsCommandForTest(s.fake), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- rule: machines-down
+  kind: machine-down
+  entity: machine-1
+  message: agent is not communicating with the server
+  since: 2016-10-03T12:00:00Z
+  raised: 2016-10-03T12:10:00Z
+`[1:])
+}
+
+func (s *ListAlertsSuite) TestNoAlerts(c *gc.C) {
+	s.fake.alerts = nil
+	ctx, err := testing.RunCommand(c, alerts.NewListAlertsCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No alerts raised.\n")
+}
+
+type fakeAlertsAPI struct {
+	gitjujutesting.Stub
+	rules  []params.AlertRule
+	alerts []params.Alert
+}
+
+func (f *fakeAlertsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeAlertsAPI) ListAlerts() ([]params.Alert, error) {
+	f.MethodCall(f, "ListAlerts")
+	return f.alerts, f.NextErr()
+}
+
+func (f *fakeAlertsAPI) ListAlertRules() ([]params.AlertRule, error) {
+	f.MethodCall(f, "ListAlertRules")
+	return f.rules, f.NextErr()
+}
+
+func (f *fakeAlertsAPI) AddAlertRule(rule params.AlertRule) error {
+	f.MethodCall(f, "AddAlertRule", rule)
+	return f.NextErr()
+}
+
+func (f *fakeAlertsAPI) RemoveAlertRules(names ...string) error {
+	f.MethodCall(f, "RemoveAlertRules", names)
+	return f.NextErr()
+}
@@ -0,0 +1,149 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/alerts"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const listRulesHelpDoc = `
+Lists the alert rules of the model. The controller raises an alert for
+each entity of the model which matches the kind of a rule for longer
+than the rule's duration, and notifies the rule's webhook and email
+address when the alert is raised and when it is resolved.
+
+Examples:
+
+    juju alert-rules
+    juju alert-rules --format yaml
+
+See also:
+    add-alert-rule
+    remove-alert-rule
+    alerts
+`
+
+// NewListRulesCommand returns a command which lists the alert rules of
+// a model.
+func NewListRulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listRulesCommand{})
+}
+
+type listRulesCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ListRulesAPI
+}
+
+// ListRulesAPI defines the API methods that the alert-rules command
+// uses.
+type ListRulesAPI interface {
+	Close() error
+	ListAlertRules() ([]params.AlertRule, error)
+}
+
+// alertRuleInfo holds the details of an alert rule, for output.
+type alertRuleInfo struct {
+	Name    string `yaml:"name" json:"name"`
+	Kind    string `yaml:"kind" json:"kind"`
+	For     string `yaml:"for" json:"for"`
+	Webhook string `yaml:"webhook,omitempty" json:"webhook,omitempty"`
+	Email   string `yaml:"email,omitempty" json:"email,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *listRulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "alert-rules",
+		Purpose: "Lists the alert rules of a model.",
+		Doc:     listRulesHelpDoc[1:],
+		Aliases: []string{"list-alert-rules"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatRulesTabular,
+	})
+}
+
+func (c *listRulesCommand) getAPI() (ListRulesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return alerts.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *listRulesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	rules, err := client.ListAlertRules()
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No alert rules to display.")
+		return nil
+	}
+	infos := make([]alertRuleInfo, len(rules))
+	for i, rule := range rules {
+		infos[i] = alertRuleInfo{
+			Name:    rule.Name,
+			Kind:    rule.Kind,
+			For:     rule.For.String(),
+			Webhook: rule.Webhook,
+			Email:   rule.Email,
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+// formatRulesTabular takes an interface{} to adhere to the
+// cmd.Formatter interface.
+func formatRulesTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.([]alertRuleInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "NAME\tKIND\tFOR\tWEBHOOK\tEMAIL\n")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			info.Name, info.Kind, info.For, info.Webhook, info.Email,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/alerts"
+	"github.com/juju/juju/testing"
+)
+
+type ListRulesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeAlertsAPI
+}
+
+var _ = gc.Suite(&ListRulesSuite{})
+
+func (s *ListRulesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeAlertsAPI{
+		rules: []params.AlertRule{{
+			Name:  "machines-down",
+			Kind:  "machine-down",
+			For:   10 * time.Minute,
+			Email: "ops@example.com",
+		}, {
+			Name:    "unit-errors",
+			Kind:    "unit-error",
+			For:     5 * time.Minute,
+			Webhook: "https://hooks.example.com/juju",
+		}},
+	}
+}
+
+func (s *ListRulesSuite) TestTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, alerts.NewListRulesCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"NAME           KIND          FOR    WEBHOOK                         EMAIL\n"+
+		"machines-down  machine-down  10m0s                                  ops@example.com\n"+
+		"unit-errors    unit-error    5m0s   https://hooks.example.com/juju  \n"+
+		"\n",
+	)
+	s.fake.CheckCallNames(c, "ListAlertRules", "Close")
+}
+
+func (s *ListRulesSuite) TestYAML(c *gc.C) {
+	s.fake.rules = s.fake.rules[:1]
+	ctx, err := testing.RunCommand(c, alerts.NewListRulesCommandForTest(s.fake), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- name: machines-down
+  kind: machine-down
+  for: 10m0s
+  email: ops@example.com
+`[1:])
+}
+
+func (s *ListRulesSuite) TestNoRules(c *gc.C) {
+	s.fake.rules = nil
+	ctx, err := testing.RunCommand(c, alerts.NewListRulesCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No alert rules to display.\n")
+}
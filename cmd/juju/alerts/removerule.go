@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/alerts"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const removeRuleHelpDoc = `
+Removes alert rules from the model. Any alerts raised for the removed
+rules are removed with them, without notification.
+
+Examples:
+
+    juju remove-alert-rule unit-errors
+    juju remove-alert-rule unit-errors machines-down
+
+See also:
+    add-alert-rule
+    alert-rules
+`
+
+// NewRemoveRuleCommand returns a command which removes alert rules
+// from a model.
+func NewRemoveRuleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeRuleCommand{})
+}
+
+type removeRuleCommand struct {
+	modelcmd.ModelCommandBase
+	api   RemoveRuleAPI
+	names []string
+}
+
+// RemoveRuleAPI defines the API methods that the remove-alert-rule
+// command uses.
+type RemoveRuleAPI interface {
+	Close() error
+	RemoveAlertRules(names ...string) error
+}
+
+// Info implements Command.Info.
+func (c *removeRuleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-alert-rule",
+		Args:    "<name> [<name>...]",
+		Purpose: "Removes alert rules from a model.",
+		Doc:     removeRuleHelpDoc[1:],
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeRuleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no alert rule names specified")
+	}
+	c.names = args
+	return nil
+}
+
+func (c *removeRuleCommand) getAPI() (RemoveRuleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return alerts.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *removeRuleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.RemoveAlertRules(c.names...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
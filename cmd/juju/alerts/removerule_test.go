@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerts_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/alerts"
+	"github.com/juju/juju/testing"
+)
+
+type RemoveRuleSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeAlertsAPI
+}
+
+var _ = gc.Suite(&RemoveRuleSuite{})
+
+func (s *RemoveRuleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeAlertsAPI{}
+}
+
+func (s *RemoveRuleSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(alerts.NewRemoveRuleCommandForTest(s.fake), nil)
+	c.Assert(err, gc.ErrorMatches, "no alert rule names specified")
+}
+
+func (s *RemoveRuleSuite) TestRemoveRules(c *gc.C) {
+	_, err := testing.RunCommand(c, alerts.NewRemoveRuleCommandForTest(s.fake),
+		"unit-errors", "machines-down",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "RemoveAlertRules", "Close")
+	s.fake.CheckCall(c, 0, "RemoveAlertRules", []string{"unit-errors", "machines-down"})
+}
+
+func (s *RemoveRuleSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockedError"))
+	_, err := testing.RunCommand(c, alerts.NewRemoveRuleCommandForTest(s.fake), "unit-errors")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
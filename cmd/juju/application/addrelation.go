@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
@@ -24,17 +25,41 @@ func NewAddRelationCommand() cmd.Command {
 type addRelationCommand struct {
 	modelcmd.ModelCommandBase
 	Endpoints []string
+
+	// ExcludeControllers and ExcludeMachines restrict the principal
+	// units given subordinate units by a relation with container scope.
+	ExcludeControllers bool
+	ExcludeMachines    []string
 }
 
+var usageAddRelationDetails = `
+Relations with container scope, between a principal and a subordinate
+application, create a subordinate unit alongside each principal unit.
+The --exclude-controllers and --exclude-machines options prevent
+subordinate units being created for principal units on controller
+machines or on the given machines, including containers on them.
+
+Examples:
+    juju add-relation mysql logging
+    juju add-relation mysql logging --exclude-controllers --exclude-machines 3,4
+`
+
 func (c *addRelationCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-relation",
 		Aliases: []string{"relate"},
 		Args:    "<application1>[:<relation name1>] <application2>[:<relation name2>]",
 		Purpose: "Add a relation between two applications.",
+		Doc:     usageAddRelationDetails,
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *addRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.ExcludeControllers, "exclude-controllers", false, "Do not create subordinate units on controller machines")
+	f.Var(cmd.NewStringsValue(nil, &c.ExcludeMachines), "exclude-machines", "Do not create subordinate units on the given machines")
+}
+
 func (c *addRelationCommand) Init(args []string) error {
 	if len(args) != 2 {
 		return fmt.Errorf("a relation must involve two applications")
@@ -46,6 +71,7 @@ func (c *addRelationCommand) Init(args []string) error {
 type serviceAddRelationAPI interface {
 	Close() error
 	AddRelation(endpoints ...string) (*params.AddRelationResults, error)
+	SetSubordinatePlacement(args params.SetSubordinatePlacement) error
 }
 
 func (c *addRelationCommand) getAPI() (serviceAddRelationAPI, error) {
@@ -63,5 +89,16 @@ func (c *addRelationCommand) Run(_ *cmd.Context) error {
 	}
 	defer client.Close()
 	_, err = client.AddRelation(c.Endpoints...)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if !c.ExcludeControllers && len(c.ExcludeMachines) == 0 {
+		return nil
+	}
+	err = client.SetSubordinatePlacement(params.SetSubordinatePlacement{
+		Endpoints:          c.Endpoints,
+		ExcludeControllers: c.ExcludeControllers,
+		ExcludeMachines:    c.ExcludeMachines,
+	})
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/cmd/juju/common"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
 )
@@ -161,6 +162,26 @@ func (s *AddRelationSuite) TestAddRelation(c *gc.C) {
 	}
 }
 
+func (s *AddRelationSuite) TestAddRelationSubordinatePlacement(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "mysql")
+	err := runDeploy(c, ch, "ms", "--series", "quantal")
+	c.Assert(err, jc.ErrorIsNil)
+	ch = testcharms.Repo.CharmArchivePath(s.CharmsPath, "logging")
+	err = runDeploy(c, ch, "lg", "--series", "quantal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runAddRelation(c, "ms", "lg", "--exclude-controllers", "--exclude-machines", "1,2")
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("ms", "lg")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.EndpointsRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.SubordinatePlacement(), jc.DeepEquals, state.SubordinatePlacement{
+		ExcludeControllers: true,
+		ExcludeMachines:    []string{"1", "2"},
+	})
+}
+
 func (s *AddRelationSuite) TestBlockAddRelation(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "wordpress")
 	err := runDeploy(c, ch, "wp", "--series", "quantal")
@@ -50,19 +50,9 @@ type deploymentLogger interface {
 	Infof(string, ...interface{})
 }
 
-// deployBundle deploys the given bundle data using the given API client and
-// charm store client. The deployment is not transactional, and its progress is
-// notified using the given deployment logger.
-func deployBundle(
-	bundleFilePath string,
-	data *charm.BundleData,
-	channel csparams.Channel,
-	client *api.Client,
-	serviceDeployer *applicationDeployer,
-	resolver *charmURLResolver,
-	log deploymentLogger,
-	bundleStorage map[string]map[string]storage.Constraints,
-) (map[*charm.URL]*macaroon.Macaroon, error) {
+// verifyBundle checks that the given bundle data is well formed. Local
+// bundles are also checked against the bundle directory.
+func verifyBundle(bundleFilePath string, data *charm.BundleData) error {
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
 		return err
@@ -83,9 +73,28 @@ func deployBundle(
 			for i, err := range verr.Errors {
 				errs[i] = err.Error()
 			}
-			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+			return errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
 		}
-		return nil, errors.Annotate(verifyError, "cannot deploy bundle")
+		return errors.Annotate(verifyError, "cannot deploy bundle")
+	}
+	return nil
+}
+
+// deployBundle deploys the given bundle data using the given API client and
+// charm store client. The deployment is not transactional, and its progress is
+// notified using the given deployment logger.
+func deployBundle(
+	bundleFilePath string,
+	data *charm.BundleData,
+	channel csparams.Channel,
+	client *api.Client,
+	serviceDeployer *applicationDeployer,
+	resolver *charmURLResolver,
+	log deploymentLogger,
+	bundleStorage map[string]map[string]storage.Constraints,
+) (map[*charm.URL]*macaroon.Macaroon, error) {
+	if err := verifyBundle(bundleFilePath, data); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	// Retrieve bundle changes.
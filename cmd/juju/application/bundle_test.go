@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/watcher"
@@ -83,6 +84,49 @@ deployment of bundle "cs:bundle/wordpress-simple-1" completed`
 	})
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleOnController(c *gc.C) {
+	s.SetFeatureFlags(feature.ControllerBundles)
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	output, err := runDeployCommand(c, "bundle/wordpress-simple")
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(output, "\n")
+	c.Assert(lines, jc.SameContents, []string{
+		"added charm cs:xenial/mysql-42",
+		"added charm cs:xenial/wordpress-47",
+		"application mysql deployed (charm cs:xenial/mysql-42)",
+		"application wordpress deployed (charm cs:xenial/wordpress-47)",
+		"related wordpress:db and mysql:server",
+		"added mysql/0 unit to new machine 0",
+		"added wordpress/0 unit to new machine 1",
+		`deployment of bundle "cs:bundle/wordpress-simple-1" completed`,
+	})
+	s.assertCharmsUploaded(c, "cs:xenial/mysql-42", "cs:xenial/wordpress-47")
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{
+		"mysql":     {charm: "cs:xenial/mysql-42"},
+		"wordpress": {charm: "cs:xenial/wordpress-47"},
+	})
+	s.assertRelationsEstablished(c, "wordpress:db mysql:server")
+	s.assertUnitsCreated(c, map[string]string{
+		"mysql/0":     "0",
+		"wordpress/0": "1",
+	})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleOnControllerDryRun(c *gc.C) {
+	s.SetFeatureFlags(feature.ControllerBundles)
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	ctx, err := coretesting.RunCommand(c, NewDeployCommand(), "bundle/wordpress-simple", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), jc.HasPrefix, "Deploying bundle \"cs:bundle/wordpress-simple-1\" would:\n")
+	c.Check(coretesting.Stdout(ctx), jc.Contains, "  - add relation wordpress:db - mysql:server\n")
+	s.assertCharmsUploaded(c)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{})
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleWithTermsSuccess(c *gc.C) {
 	testcharms.UploadCharm(c, s.client, "xenial/terms1-17", "terms1")
 	testcharms.UploadCharm(c, s.client, "xenial/terms2-42", "terms2")
@@ -285,6 +329,48 @@ deployment of bundle %q completed`, path)
 	})
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleDryRun(c *gc.C) {
+	dir := c.MkDir()
+	testcharms.Repo.ClonedDir(dir, "mysql")
+	testcharms.Repo.ClonedDir(dir, "wordpress")
+	path := filepath.Join(dir, "mybundle")
+	data := `
+        series: xenial
+        applications:
+            wordpress:
+                charm: ./wordpress
+                num_units: 1
+                to: ["lxd:0"]
+            mysql:
+                charm: ./mysql
+                num_units: 1
+                constraints: mem=4G
+                to: ["0"]
+        machines:
+            0:
+        relations:
+            - ["wordpress:db", "mysql:server"]
+    `
+	err := ioutil.WriteFile(path, []byte(data), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err := coretesting.RunCommand(c, NewDeployCommand(), path, "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+Deploying bundle %q would:
+  - add charm local:xenial/mysql-1
+  - add application mysql using local:xenial/mysql-1 with constraints mem=4G
+  - add charm local:xenial/wordpress-3
+  - add application wordpress using local:xenial/wordpress-3
+  - add relation wordpress:db - mysql:server
+  - add new machine #1
+  - add unit mysql/0 to new machine #1
+  - add new lxd container on new machine #1
+  - add unit wordpress/0 to new lxd container on new machine #1
+`[1:], path))
+	s.assertCharmsUploaded(c)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{})
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleNoSeriesInCharmURL(c *gc.C) {
 	testcharms.UploadCharmMultiSeries(c, s.client, "~who/multi-series", "multi-series")
 	dir := c.MkDir()
@@ -0,0 +1,415 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageConfigSummary = `
+Gets, sets or resets configuration for a deployed application.`[1:]
+
+var usageConfigDetails = `
+With only an application name, all configuration settings of the application
+are displayed, along with their source: "default" for settings taken from the
+charm's defaults, and "user" for settings which have been set explicitly.
+Given one or more keys, only those settings are displayed; a single key
+displays just its value.
+
+Given key=value pairs, the settings are changed. Settings may also be read
+from a YAML file with --file, in the format used by "juju deploy --config";
+key=value pairs given on the command line take precedence over the file.
+The --reset option returns a setting to the charm's default, and may be
+repeated. If a value begins with '@', it is read from the named file.
+
+With --dry-run, the changes which would be made are displayed, and the
+application is left unchanged.
+
+Examples:
+    juju config mysql
+    juju config mysql dataset-size
+    juju config mysql dataset-size=80% backup_dir=/vol1/mysql/backups
+    juju config mysql --file mysql.yaml --reset tuning-level
+    juju config mysql --dry-run dataset-size=50%
+
+See also:
+    get-config
+    set-config
+    deploy`
+
+// NewConfigCommand returns a command used to get, set and reset
+// application configuration.
+func NewConfigCommand() cmd.Command {
+	return modelcmd.Wrap(&configCommand{})
+}
+
+// configCommand gets, sets and resets the configuration of an
+// application.
+type configCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api serviceAPI
+
+	applicationName string
+	keys            []string
+	values          map[string]string
+	settingsFile    cmd.FileVar
+	reset           []string
+	dryRun          bool
+}
+
+// Info implements Command.Info.
+func (c *configCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "config",
+		Args:    "<application name> [<key>[=<value>] ...]",
+		Purpose: usageConfigSummary,
+		Doc:     usageConfigDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatConfigTabular,
+	})
+	f.Var(&c.settingsFile, "file", "Path to a yaml-formatted file of settings to apply")
+	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset a setting to its default value")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Show the changes which would be made without making them")
+}
+
+// Init implements Command.Init.
+func (c *configCommand) Init(args []string) error {
+	if len(args) == 0 || strings.Contains(args[0], "=") {
+		return errors.New("no application name specified")
+	}
+	c.applicationName, args = args[0], args[1:]
+
+	var assignments []string
+	for _, arg := range args {
+		if strings.Contains(arg, "=") {
+			assignments = append(assignments, arg)
+		} else {
+			c.keys = append(c.keys, arg)
+		}
+	}
+	values, err := keyvalues.Parse(assignments, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.values = values
+
+	changing := len(c.values) > 0 || len(c.reset) > 0 || c.settingsFile.Path != ""
+	if changing && len(c.keys) > 0 {
+		return errors.New("cannot get and change settings at the same time")
+	}
+	if c.dryRun && !changing {
+		return errors.New("--dry-run requires settings to change")
+	}
+	for _, key := range c.reset {
+		if _, ok := c.values[key]; ok {
+			return errors.Errorf("cannot set and reset %q at the same time", key)
+		}
+	}
+	return nil
+}
+
+func (c *configCommand) getAPI() (serviceAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// ConfigSetting describes a single configuration setting of an
+// application.
+type ConfigSetting struct {
+	Value       interface{} `yaml:"value,omitempty" json:"value,omitempty"`
+	Source      string      `yaml:"source" json:"source"`
+	Type        string      `yaml:"type" json:"type"`
+	Description string      `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// ApplicationConfig describes the configuration of an application.
+type ApplicationConfig struct {
+	Application string                   `yaml:"application" json:"application"`
+	Charm       string                   `yaml:"charm" json:"charm"`
+	Settings    map[string]ConfigSetting `yaml:"settings" json:"settings"`
+}
+
+// Run implements Command.Run.
+func (c *configCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	results, err := apiclient.Get(c.applicationName)
+	if err != nil {
+		return err
+	}
+	config := applicationConfig(results)
+	if len(c.values) == 0 && len(c.reset) == 0 && c.settingsFile.Path == "" {
+		return c.show(ctx, config)
+	}
+
+	changes, err := c.changes(ctx, config, results.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.dryRun {
+		writeConfigDiff(ctx, c.applicationName, config, changes, c.reset)
+		ctx.Infof("No changes were made to the application.")
+		return nil
+	}
+	if len(changes) > 0 {
+		data, err := goyaml.Marshal(map[string]charm.Settings{c.applicationName: changes})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = apiclient.Update(params.ApplicationUpdate{
+			ApplicationName: c.applicationName,
+			SettingsYAML:    string(data),
+		})
+		if writeConfigErrors(ctx, err) {
+			return cmd.ErrSilent
+		}
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+	}
+	if len(c.reset) > 0 {
+		err := apiclient.Unset(c.applicationName, c.reset)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+	}
+	return nil
+}
+
+// writeConfigErrors writes each invalid setting reported by a
+// CodeInvalidConfig error to stderr, and returns whether it did so.
+func writeConfigErrors(ctx *cmd.Context, err error) bool {
+	perr, ok := errors.Cause(err).(*params.Error)
+	if !ok || perr.Code != params.CodeInvalidConfig || perr.Info == nil || len(perr.Info.ConfigErrors) == 0 {
+		return false
+	}
+	for _, optionErr := range perr.Info.ConfigErrors {
+		fmt.Fprintf(ctx.Stderr, "ERROR %s\n", optionErr.Reason)
+	}
+	return true
+}
+
+// show writes the settings named by c.keys, or all settings if no
+// keys were given.
+func (c *configCommand) show(ctx *cmd.Context, config ApplicationConfig) error {
+	if len(c.keys) == 0 {
+		return c.out.Write(ctx, config)
+	}
+	selected := make(map[string]ConfigSetting)
+	for _, key := range c.keys {
+		setting, ok := config.Settings[key]
+		if !ok {
+			return errors.Errorf("key %q not found in %q application settings", key, c.applicationName)
+		}
+		selected[key] = setting
+	}
+	if len(c.keys) == 1 {
+		out, err := cmd.FormatSmart(selected[c.keys[0]].Value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(ctx.Stdout, "%s\n", out)
+		return nil
+	}
+	config.Settings = selected
+	return c.out.Write(ctx, config)
+}
+
+// changes returns the settings to be applied to the application,
+// validated against the charm's config options.
+func (c *configCommand) changes(ctx *cmd.Context, config ApplicationConfig, described map[string]interface{}) (charm.Settings, error) {
+	charmConfig := charmConfigFromDescription(described)
+	for _, key := range c.reset {
+		if _, ok := charmConfig.Options[key]; !ok {
+			return nil, errors.Errorf("unknown option %q", key)
+		}
+	}
+
+	changes := make(charm.Settings)
+	if c.settingsFile.Path != "" {
+		data, err := c.settingsFile.Read(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		settings, err := charmConfig.ParseSettingsYAML(data, c.applicationName)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", c.settingsFile.Path)
+		}
+		for key, value := range settings {
+			changes[key] = value
+		}
+	}
+
+	values := make(map[string]string)
+	for key, value := range c.values {
+		if strings.HasPrefix(value, "@") {
+			var err error
+			if value, err = readValue(ctx, value[1:]); err != nil {
+				return nil, err
+			}
+		}
+		if !utf8.ValidString(value) {
+			return nil, errors.Errorf("value for option %q contains non-UTF-8 sequences", key)
+		}
+		values[key] = value
+	}
+	settings, err := charmConfig.ParseSettingsStrings(values)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for key, value := range settings {
+		changes[key] = value
+	}
+	return changes, nil
+}
+
+// applicationConfig converts the results of the Get API call into an
+// ApplicationConfig.
+func applicationConfig(results *params.ApplicationGetResults) ApplicationConfig {
+	config := ApplicationConfig{
+		Application: results.Application,
+		Charm:       results.Charm,
+		Settings:    make(map[string]ConfigSetting),
+	}
+	for key, info := range results.Config {
+		info, _ := info.(map[string]interface{})
+		setting := ConfigSetting{
+			Value:  info["value"],
+			Source: "user",
+		}
+		if isDefault, _ := info["default"].(bool); isDefault {
+			setting.Source = "default"
+		}
+		setting.Type, _ = info["type"].(string)
+		setting.Description, _ = info["description"].(string)
+		config.Settings[key] = setting
+	}
+	return config
+}
+
+// charmConfigFromDescription returns a charm.Config with the options
+// described in the results of the Get API call, so that new settings
+// can be checked before they are sent to the controller.
+func charmConfigFromDescription(described map[string]interface{}) *charm.Config {
+	config := charm.NewConfig()
+	for key, info := range described {
+		info, _ := info.(map[string]interface{})
+		option := charm.Option{}
+		option.Type, _ = info["type"].(string)
+		option.Description, _ = info["description"].(string)
+		config.Options[key] = option
+	}
+	return config
+}
+
+// writeConfigDiff writes the changes which would be made to the
+// configuration of the application.
+func writeConfigDiff(ctx *cmd.Context, applicationName string, config ApplicationConfig, changes charm.Settings, reset []string) {
+	lines := make(map[string]string)
+	for key, value := range changes {
+		old := config.Settings[key]
+		if old.Source == "user" && fmt.Sprint(old.Value) == fmt.Sprint(value) {
+			lines[key] = fmt.Sprintf("%s (unchanged)", formatConfigValue(value))
+			continue
+		}
+		lines[key] = fmt.Sprintf("%s -> %s", formatConfigSetting(old), formatConfigValue(value))
+	}
+	for _, key := range reset {
+		old := config.Settings[key]
+		if old.Source == "default" {
+			lines[key] = fmt.Sprintf("%s (unchanged)", formatConfigSetting(old))
+			continue
+		}
+		lines[key] = fmt.Sprintf("%s -> (default)", formatConfigSetting(old))
+	}
+	keys := make([]string, 0, len(lines))
+	for key := range lines {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(ctx.Stdout, "Changing the configuration of %q would set:\n", applicationName)
+	for _, key := range keys {
+		fmt.Fprintf(ctx.Stdout, "  %s: %s\n", key, lines[key])
+	}
+}
+
+func formatConfigSetting(setting ConfigSetting) string {
+	value := formatConfigValue(setting.Value)
+	if setting.Source == "default" {
+		return value + " (default)"
+	}
+	return value
+}
+
+func formatConfigValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "<unset>"
+	case string:
+		return fmt.Sprintf("%q", value)
+	}
+	return fmt.Sprint(value)
+}
+
+// formatConfigTabular writes the settings of an application as a
+// table of keys, values and sources.
+func formatConfigTabular(value interface{}) ([]byte, error) {
+	config, ok := value.(ApplicationConfig)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", config, value)
+	}
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE")
+	keys := make([]string, 0, len(config.Settings))
+	for key := range config.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		setting := config.Settings[key]
+		value := ""
+		if setting.Value != nil {
+			value = strings.Replace(fmt.Sprint(setting.Value), "\n", `\n`, -1)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", key, value, setting.Source)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	dir  string
+	fake *fakeConfigAPI
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.fake = &fakeConfigAPI{
+		options: map[string]string{
+			"username":    "string",
+			"skill-level": "int",
+			"outlook":     "string",
+		},
+		defaults: map[string]interface{}{
+			"skill-level": int64(10),
+			"outlook":     "fine",
+		},
+		values: map[string]interface{}{
+			"username": "admin001",
+		},
+	}
+}
+
+func (s *ConfigSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx := coretesting.ContextForDir(c, s.dir)
+	command := application.NewConfigCommandForTest(s.fake)
+	err := coretesting.InitCommand(command, append([]string{"dummy-application"}, args...))
+	if err != nil {
+		return "", err
+	}
+	err = command.Run(ctx)
+	return coretesting.Stdout(ctx), err
+}
+
+func (s *ConfigSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no application name specified",
+	}, {
+		args: []string{"username=foo"},
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql", "username", "outlook=fine"},
+		err:  "cannot get and change settings at the same time",
+	}, {
+		args: []string{"mysql", "--reset", "outlook", "username"},
+		err:  "cannot get and change settings at the same time",
+	}, {
+		args: []string{"mysql", "--dry-run"},
+		err:  "--dry-run requires settings to change",
+	}, {
+		args: []string{"mysql", "--reset", "outlook", "outlook=fine"},
+		err:  `cannot set and reset "outlook" at the same time`,
+	}, {
+		args: []string{"mysql", "=fine"},
+		err:  `expected "key=value", got "=fine"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := coretesting.InitCommand(application.NewConfigCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestShowAll(c *gc.C) {
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+application: dummy-application
+charm: dummy
+settings:
+  outlook:
+    value: fine
+    source: default
+    type: string
+    description: Specifies outlook
+  skill-level:
+    value: 10
+    source: default
+    type: int
+    description: Specifies skill-level
+  username:
+    value: admin001
+    source: user
+    type: string
+    description: Specifies username
+`[1:])
+}
+
+func (s *ConfigSuite) TestShowTabular(c *gc.C) {
+	out, err := s.run(c, "--format", "tabular")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+KEY          VALUE     SOURCE
+outlook      fine      default
+skill-level  10        default
+username     admin001  user
+
+`[1:])
+}
+
+func (s *ConfigSuite) TestShowKeys(c *gc.C) {
+	out, err := s.run(c, "skill-level")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "10\n")
+
+	out, err = s.run(c, "--format", "json", "username", "outlook")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `{"application":"dummy-application","charm":"dummy","settings":{`+
+		`"outlook":{"value":"fine","source":"default","type":"string","description":"Specifies outlook"},`+
+		`"username":{"value":"admin001","source":"user","type":"string","description":"Specifies username"}}}`+"\n")
+
+	_, err = s.run(c, "missing")
+	c.Assert(err, gc.ErrorMatches, `key "missing" not found in "dummy-application" application settings`)
+}
+
+func (s *ConfigSuite) TestSet(c *gc.C) {
+	_, err := s.run(c, "skill-level=9000", "outlook=sunny")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.updated, jc.DeepEquals, map[string]map[string]interface{}{
+		"dummy-application": {"skill-level": 9000, "outlook": "sunny"},
+	})
+	c.Assert(s.fake.unset, gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestSetInvalidValue(c *gc.C) {
+	_, err := s.run(c, "skill-level=lots")
+	c.Assert(err, gc.ErrorMatches, `option "skill-level" expected int, got "lots"`)
+	_, err = s.run(c, "colour=blue")
+	c.Assert(err, gc.ErrorMatches, `unknown option "colour"`)
+	c.Assert(s.fake.updated, gc.IsNil)
+}
+
+func (s *ConfigSuite) TestSetFromFile(c *gc.C) {
+	path := filepath.Join(s.dir, "config.yaml")
+	err := ioutil.WriteFile(path, []byte("dummy-application:\n  skill-level: 9000\n  username: admin002\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, "--file", "config.yaml", "username=admin003")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.updated, jc.DeepEquals, map[string]map[string]interface{}{
+		"dummy-application": {"skill-level": 9000, "username": "admin003"},
+	})
+
+	err = ioutil.WriteFile(path, []byte("mysql:\n  skill-level: 9000\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, "--file", "config.yaml")
+	c.Assert(err, gc.ErrorMatches, `reading config.yaml: no settings found for "dummy-application"`)
+}
+
+func (s *ConfigSuite) TestReset(c *gc.C) {
+	_, err := s.run(c, "--reset", "username", "--reset", "outlook", "skill-level=1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.updated, jc.DeepEquals, map[string]map[string]interface{}{
+		"dummy-application": {"skill-level": 1},
+	})
+	c.Assert(s.fake.unset, jc.DeepEquals, []string{"username", "outlook"})
+
+	_, err = s.run(c, "--reset", "colour")
+	c.Assert(err, gc.ErrorMatches, `unknown option "colour"`)
+}
+
+func (s *ConfigSuite) TestDryRun(c *gc.C) {
+	out, err := s.run(c, "--dry-run", "--reset", "username", "--reset", "outlook", "skill-level=9000")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+Changing the configuration of "dummy-application" would set:
+  outlook: "fine" (default) (unchanged)
+  skill-level: 10 (default) -> 9000
+  username: "admin001" -> (default)
+`[1:])
+	c.Assert(s.fake.updated, gc.IsNil)
+	c.Assert(s.fake.unset, gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestUpdateError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := s.run(c, "username=admin002")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ConfigSuite) TestUpdateInvalidConfig(c *gc.C) {
+	s.fake.err = &params.Error{
+		Code:    params.CodeInvalidConfig,
+		Message: `invalid config for application "dummy-application": ...`,
+		Info: &params.ErrorInfo{
+			ConfigErrors: []params.ConfigOptionError{{
+				Option: "outlook",
+				Value:  "grim",
+				Reason: `option "outlook" must be one of [fine sunny], got "grim"`,
+			}, {
+				Option: "username",
+				Value:  "root",
+				Reason: `option "username" must be one of [admin001 admin002], got "root"`,
+			}},
+		},
+	}
+	ctx := coretesting.ContextForDir(c, s.dir)
+	command := application.NewConfigCommandForTest(s.fake)
+	err := coretesting.InitCommand(command, []string{"dummy-application", "outlook=grim", "username=root"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Run(ctx)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, `
+ERROR option "outlook" must be one of [fine sunny], got "grim"
+ERROR option "username" must be one of [admin001 admin002], got "root"
+`[1:])
+}
+
+// fakeConfigAPI is the fake application API for testing the config
+// command.
+type fakeConfigAPI struct {
+	options  map[string]string
+	defaults map[string]interface{}
+	values   map[string]interface{}
+	err      error
+
+	updated map[string]map[string]interface{}
+	unset   []string
+}
+
+func (f *fakeConfigAPI) Close() error {
+	return nil
+}
+
+func (f *fakeConfigAPI) Get(application string) (*params.ApplicationGetResults, error) {
+	if application != "dummy-application" {
+		return nil, errors.NotFoundf("application %q", application)
+	}
+	config := make(map[string]interface{})
+	for name, optionType := range f.options {
+		info := map[string]interface{}{
+			"description": "Specifies " + name,
+			"type":        optionType,
+		}
+		if value, ok := f.values[name]; ok {
+			info["value"] = value
+		} else {
+			info["value"] = f.defaults[name]
+			info["default"] = true
+		}
+		config[name] = info
+	}
+	return &params.ApplicationGetResults{
+		Application: application,
+		Charm:       "dummy",
+		Config:      config,
+	}, nil
+}
+
+func (f *fakeConfigAPI) Update(args params.ApplicationUpdate) error {
+	if f.err != nil {
+		return f.err
+	}
+	return goyaml.Unmarshal([]byte(args.SettingsYAML), &f.updated)
+}
+
+func (f *fakeConfigAPI) Set(string, map[string]string) error {
+	return errors.NotImplementedf("Set")
+}
+
+func (f *fakeConfigAPI) Unset(application string, options []string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.unset = options
+	return nil
+}
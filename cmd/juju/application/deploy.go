@@ -13,6 +13,8 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/featureflag"
 	"gopkg.in/juju/charm.v6-unstable"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/charmrepo.v2-unstable"
@@ -21,17 +23,22 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/api"
 	apiannotations "github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/application"
+	apibundle "github.com/juju/juju/api/bundle"
 	apicharms "github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/progress"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/storage"
@@ -91,6 +98,14 @@ type DeployCommand struct {
 	Bindings map[string]string
 	Steps    []DeployStep
 
+	// DryRun is used to report what would be deployed, without
+	// making any changes to the model.
+	DryRun bool
+
+	// Progress is how the progress of uploading a local charm is
+	// reported.
+	Progress progress.Mode
+
 	flagSet *gnuflag.FlagSet
 }
 
@@ -166,6 +181,11 @@ When deploying an application or adding machines, the 'spaces' constraint can
 be used to define a comma-delimited list of required and forbidden spaces (the
 latter prefixed with "^", similar to the 'tags' constraint).
 
+The '--dry-run' option resolves the charm or bundle and checks the
+constraints, placement directives, storage and endpoint bindings against the
+model, then lists the machines, containers and units that would be created.
+No charms are uploaded and the model is not changed.
+
 
 Examples:
     juju deploy mysql --to 23       (deploy to machine 23)
@@ -186,6 +206,9 @@ Examples:
     (deploy 2 units to machines that are part of the 'dmz' space but not of the
     'cmd' or the 'database' spaces)
 
+    juju deploy mysql -n 2 --to lxd:3 --dry-run
+    (show what deploying 2 units would do, without changing the model)
+
 See also:
     spaces
     constraints
@@ -244,6 +267,8 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show what would be deployed without changing the model")
+	progress.AddFlag(f, &c.Progress)
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -301,6 +326,18 @@ func (c *DeployCommand) deployBundle(
 	resolver *charmURLResolver,
 	bundleStorage map[string]map[string]storage.Constraints,
 ) error {
+	if featureflag.Enabled(feature.ControllerBundles) && !bundleHasLocalCharms(data) {
+		if err := verifyBundle(filePath, data); err != nil {
+			return errors.Trace(err)
+		}
+		deployed, err := c.deployBundleOnController(ctx, ident, data, channel, appDeployer, bundleStorage)
+		if err != nil || deployed {
+			return errors.Trace(err)
+		}
+	}
+	if c.DryRun {
+		return errors.Trace(c.dryRunBundle(ctx, ident, filePath, data, appDeployer, resolver, bundleStorage))
+	}
 	// TODO(ericsnow) Do something with the CS macaroons that were returned?
 	if _, err := deployBundle(
 		filePath,
@@ -318,6 +355,68 @@ func (c *DeployCommand) deployBundle(
 	return nil
 }
 
+// deployBundleOnController deploys the bundle on the controller,
+// which computes and applies the changes required, undoing them if
+// any fails. It reports false, having changed nothing, if the
+// controller cannot deploy the bundle, so that the client deploys it
+// instead.
+func (c *DeployCommand) deployBundleOnController(
+	ctx *cmd.Context,
+	ident string,
+	data *charm.BundleData,
+	channel csclientparams.Channel,
+	appDeployer *applicationDeployer,
+	bundleStorage map[string]map[string]storage.Constraints,
+) (bool, error) {
+	bundleYAML, err := yaml.Marshal(data)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	client, err := appDeployer.newBundleAPIClient()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer client.Close()
+	result, err := client.DeployBundle(apibundle.DeployArgs{
+		BundleDataYAML: string(bundleYAML),
+		Channel:        string(channel),
+		Storage:        bundleStorage,
+		DryRun:         c.DryRun,
+	})
+	switch {
+	case params.IsCodeNotImplemented(err), params.IsCodeNotSupported(err), params.IsCodeUnauthorized(err):
+		logger.Debugf("deploying bundle %q from the client: %v", ident, err)
+		return false, nil
+	case err != nil:
+		for _, event := range result.Events {
+			ctx.Infof("%s (undone)", event.Message)
+		}
+		return true, errors.Annotate(err, "cannot deploy bundle")
+	}
+	if c.DryRun {
+		fmt.Fprintf(ctx.Stdout, "Deploying bundle %q would:\n", ident)
+		writePlan(ctx, result.Plan)
+		return true, nil
+	}
+	for _, event := range result.Events {
+		ctx.Infof("%s", event.Message)
+	}
+	ctx.Infof("deployment of bundle %q completed", ident)
+	return true, nil
+}
+
+// bundleHasLocalCharms reports whether any of the bundle's
+// applications use a charm from the local filesystem, which only
+// the client can upload.
+func bundleHasLocalCharms(data *charm.BundleData) bool {
+	for _, app := range data.Applications {
+		if strings.HasPrefix(app.Charm, ".") || filepath.IsAbs(app.Charm) {
+			return true
+		}
+	}
+	return false
+}
+
 type deployCharmArgs struct {
 	id       charmstore.CharmID
 	csMac    *macaroon.Macaroon
@@ -338,16 +437,9 @@ func (c *DeployCommand) deployCharm(args deployCharmArgs) (rErr error) {
 		return err
 	}
 
-	numUnits := c.NumUnits
-	if charmInfo.Meta.Subordinate {
-		if !constraints.IsEmpty(&c.Constraints) {
-			return errors.New("cannot use --constraints with subordinate application")
-		}
-		if numUnits == 1 && c.PlacementSpec == "" {
-			numUnits = 0
-		} else {
-			return errors.New("cannot use --num-units or --to with subordinate application")
-		}
+	numUnits, err := c.numUnitsForCharm(charmInfo.Meta)
+	if err != nil {
+		return errors.Trace(err)
 	}
 	serviceName := c.ApplicationName
 	if serviceName == "" {
@@ -423,6 +515,22 @@ func (c *DeployCommand) deployCharm(args deployCharmArgs) (rErr error) {
 	return args.deployer.applicationDeploy(params)
 }
 
+// numUnitsForCharm returns the number of units to deploy for the charm
+// with the given metadata. Subordinate charms are deployed without
+// units, so may not be given constraints, units or placement.
+func (c *DeployCommand) numUnitsForCharm(meta *charm.Meta) (int, error) {
+	if !meta.Subordinate {
+		return c.NumUnits, nil
+	}
+	if !constraints.IsEmpty(&c.Constraints) {
+		return 0, errors.New("cannot use --constraints with subordinate application")
+	}
+	if c.NumUnits != 1 || c.PlacementSpec != "" {
+		return 0, errors.New("cannot use --num-units or --to with subordinate application")
+	}
+	return 0, nil
+}
+
 type APICmd interface {
 	NewAPIRoot() (api.Connection, error)
 }
@@ -532,6 +640,14 @@ func (d *applicationDeployer) newAnnotationsAPIClient() (*apiannotations.Client,
 	return apiannotations.NewClient(root), nil
 }
 
+func (d *applicationDeployer) newBundleAPIClient() (*apibundle.Client, error) {
+	root, err := d.api.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apibundle.NewClient(root), nil
+}
+
 func (d *applicationDeployer) newCharmsAPIClient() (*apicharms.Client, error) {
 	root, err := d.api.NewAPIRoot()
 	if err != nil {
@@ -728,7 +844,18 @@ func (c *DeployCommand) maybeReadLocalCharm() (deployFn, error) {
 	}
 
 	return func(ctx *cmd.Context, apiClient *api.Client, deployer *applicationDeployer) error {
-		if curl, err = apiClient.AddLocalCharm(curl, ch); err != nil {
+		if c.DryRun {
+			return errors.Trace(c.dryRunCharm(ctx, deployer, dryRunCharmArgs{
+				id:     charmstore.CharmID{URL: curl},
+				meta:   ch.Meta(),
+				config: ch.Config(),
+				series: curl.Series,
+			}))
+		}
+		reporter := c.Progress.NewReporter(ctx.Stderr, clock.WallClock)
+		curl, err = apiClient.AddLocalCharmWithProgress(curl, ch, progress.Func(reporter, "uploading charm"))
+		reporter.Close()
+		if err != nil {
 			return errors.Trace(err)
 		}
 
@@ -838,6 +965,19 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 			return errors.Errorf("%v. Use --force to deploy the charm anyway.", err)
 		}
 
+		if c.DryRun {
+			meta, config, err := charmStoreCharmMeta(csClient, storeCharmOrBundleURL)
+			if err != nil {
+				return errors.Annotatef(err, "getting metadata for charm %q", storeCharmOrBundleURL)
+			}
+			return errors.Trace(c.dryRunCharm(ctx, deployer, dryRunCharmArgs{
+				id:     charmstore.CharmID{URL: storeCharmOrBundleURL, Channel: channel},
+				meta:   meta,
+				config: config,
+				series: series,
+			}))
+		}
+
 		// Store the charm in the controller
 		curl, csMac, err := addCharmFromURL(apiClient, storeCharmOrBundleURL, channel, csClient)
 		if err != nil {
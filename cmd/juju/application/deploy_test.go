@@ -376,6 +376,45 @@ func (s *DeploySuite) TestNumUnitsSubordinate(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `application "dummy" not found`)
 }
 
+func (s *DeploySuite) TestDryRun(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	machine, err := s.State.AddMachine(series.LatestLts(), state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err := coretesting.RunCommand(c, NewDeployCommand(),
+		ch, "-n", "3", "--to", machine.Id()+",lxd:"+machine.Id(), "--series", "trusty", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+Deploying charm "local:trusty/dummy-1" would:
+  - add application dummy
+  - add unit dummy/0 to machine %[1]s
+  - add unit dummy/1 to a new lxd container on machine %[1]s
+  - add unit dummy/2 to a new machine
+`[1:], machine.Id()))
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "No changes were made to the model.\n")
+
+	_, err = s.State.Application("dummy")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	charms, err := s.State.AllCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charms, gc.HasLen, 0)
+}
+
+func (s *DeploySuite) TestDryRunInvalidPlacement(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, ch, "--to", "42", "--series", "trusty", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `cannot deploy "dummy": machine 42 not found`)
+}
+
+func (s *DeploySuite) TestDryRunSubordinate(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "logging")
+	ctx, err := coretesting.RunCommand(c, NewDeployCommand(), ch, "--series", "quantal", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, `
+Deploying charm "local:quantal/logging-1" would:
+  - add application logging
+`[1:])
+}
+
 func (s *DeploySuite) assertForceMachine(c *gc.C, machineId string) {
 	svc, err := s.State.Application("portlandia")
 	c.Assert(err, jc.ErrorIsNil)
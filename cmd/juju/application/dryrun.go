@@ -0,0 +1,372 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+// dryRunCharmArgs holds the resolved charm for a dry run of a charm
+// deployment.
+type dryRunCharmArgs struct {
+	id     charmstore.CharmID
+	meta   *charm.Meta
+	config *charm.Config
+	series string
+}
+
+// dryRunCharm checks that the charm could be deployed with the
+// command's flags, and prints the units and machines that would be
+// added, without changing the model.
+func (c *DeployCommand) dryRunCharm(ctx *cmd.Context, deployer *applicationDeployer, args dryRunCharmArgs) error {
+	numUnits, err := c.numUnitsForCharm(args.meta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	applicationName := c.ApplicationName
+	if applicationName == "" {
+		applicationName = args.meta.Name
+	}
+	if c.Config.Path != "" && args.config != nil {
+		configYAML, err := c.Config.Read(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := args.config.ParseSettingsYAML(configYAML, applicationName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	client, err := deployer.newApplicationAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	err = client.ValidateDeploy(application.DeployArgs{
+		CharmID:          args.id,
+		ApplicationName:  applicationName,
+		Series:           args.series,
+		NumUnits:         numUnits,
+		Cons:             c.Constraints,
+		Placement:        c.Placement,
+		Storage:          c.Storage,
+		EndpointBindings: c.Bindings,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	fmt.Fprintf(ctx.Stdout, "Deploying charm %q would:\n", args.id.URL)
+	writePlan(ctx, charmDeployPlan(applicationName, numUnits, c.Placement, c.Constraints))
+	return nil
+}
+
+// charmDeployPlan returns a description of the changes made by
+// deploying numUnits units of an application with the given
+// placement directives. As with a real deployment, any units
+// without a placement directive are added to new machines.
+func charmDeployPlan(applicationName string, numUnits int, placement []*instance.Placement, cons constraints.Value) []string {
+	line := "add application " + applicationName
+	if !constraints.IsEmpty(&cons) {
+		line += " with constraints " + cons.String()
+	}
+	plan := []string{line}
+	for i := 0; i < numUnits; i++ {
+		var p *instance.Placement
+		if i < len(placement) {
+			p = placement[i]
+		}
+		plan = append(plan, fmt.Sprintf("add unit %s/%d to %s", applicationName, i, describePlacement(p)))
+	}
+	return plan
+}
+
+// describePlacement returns a description of the machine on which a
+// unit with the given placement directive would be deployed.
+func describePlacement(p *instance.Placement) string {
+	switch {
+	case p == nil:
+		return "a new machine"
+	case p.Scope == instance.MachineScope:
+		return "machine " + p.Directive
+	case isContainerScope(p.Scope) && p.Directive != "":
+		return fmt.Sprintf("a new %s container on machine %s", p.Scope, p.Directive)
+	case isContainerScope(p.Scope):
+		return fmt.Sprintf("a new %s container on a new machine", p.Scope)
+	}
+	return fmt.Sprintf("a new machine with placement %q", p.Directive)
+}
+
+func isContainerScope(scope string) bool {
+	_, err := instance.ParseContainerType(scope)
+	return err == nil
+}
+
+// dryRunBundle checks that the bundle could be deployed, and prints
+// the changes that deploying it would make, without changing the
+// model.
+func (c *DeployCommand) dryRunBundle(
+	ctx *cmd.Context,
+	ident string,
+	bundleFilePath string,
+	data *charm.BundleData,
+	deployer *applicationDeployer,
+	resolver *charmURLResolver,
+	bundleStorage map[string]map[string]storage.Constraints,
+) error {
+	if err := verifyBundle(bundleFilePath, data); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := deployer.newApplicationAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	plan, err := planBundle(
+		bundlechanges.FromData(data),
+		func(p bundlechanges.AddCharmParams) (*charm.URL, error) {
+			return dryRunBundleCharm(bundleFilePath, data.Series, p, resolver)
+		},
+		func(charmURL string, p bundlechanges.AddApplicationParams) error {
+			return validateBundleApplication(client, charmURL, p, bundleStorage)
+		},
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	fmt.Fprintf(ctx.Stdout, "Deploying bundle %q would:\n", ident)
+	writePlan(ctx, plan)
+	return nil
+}
+
+// planBundle returns a description of the given bundle changes. The
+// resolveCharm function is used to find the URL of each charm, and the
+// validate function to check each application against the model.
+func planBundle(
+	changes []bundlechanges.Change,
+	resolveCharm func(bundlechanges.AddCharmParams) (*charm.URL, error),
+	validate func(charmURL string, p bundlechanges.AddApplicationParams) error,
+) ([]string, error) {
+	plan := newBundlePlan()
+	for _, change := range changes {
+		switch change := change.(type) {
+		case *bundlechanges.AddCharmChange:
+			curl, err := resolveCharm(change.Params)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			plan.addCharm(change.Id(), curl)
+		case *bundlechanges.AddApplicationChange:
+			err := validate(plan.resolve(change.Params.Charm), change.Params)
+			if params.IsCodeAlreadyExists(err) {
+				plan.useApplication(change.Id(), change.Params)
+				continue
+			} else if err != nil {
+				return nil, errors.Annotatef(err, "cannot deploy application %q", change.Params.Application)
+			}
+			plan.addApplication(change.Id(), change.Params)
+		case *bundlechanges.AddMachineChange:
+			plan.addMachine(change.Id(), change.Params)
+		case *bundlechanges.AddUnitChange:
+			plan.addUnit(change.Id(), change.Params)
+		case *bundlechanges.AddRelationChange:
+			plan.addRelation(change.Params)
+		case *bundlechanges.ExposeChange:
+			plan.add("expose %s", plan.resolve(change.Params.Application))
+		case *bundlechanges.SetAnnotationsChange:
+			plan.add("set annotations for %s", plan.resolve(change.Params.Id))
+		default:
+			return nil, errors.Errorf("unknown change type: %T", change)
+		}
+	}
+	return plan.lines, nil
+}
+
+// dryRunBundleCharm resolves the URL of a bundle charm, without
+// adding the charm to the model.
+func dryRunBundleCharm(
+	bundleFilePath, bundleSeries string,
+	p bundlechanges.AddCharmParams,
+	resolver *charmURLResolver,
+) (*charm.URL, error) {
+	if strings.HasPrefix(p.Charm, ".") || filepath.IsAbs(p.Charm) {
+		charmPath := p.Charm
+		if !filepath.IsAbs(charmPath) {
+			charmPath = filepath.Join(bundleFilePath, charmPath)
+		}
+		series := p.Series
+		if series == "" {
+			series = bundleSeries
+		}
+		_, curl, err := charmrepo.NewCharmAtPath(charmPath, series)
+		if err == nil {
+			return curl, nil
+		} else if !os.IsNotExist(err) {
+			return nil, errors.Annotatef(err, "cannot deploy local charm at %q", charmPath)
+		}
+	}
+	ch, err := charm.ParseURL(p.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	curl, _, _, _, err := resolver.resolve(ch)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot resolve URL %q", p.Charm)
+	}
+	if curl.Series == "bundle" {
+		return nil, errors.Errorf("expected charm URL, got bundle URL %q", p.Charm)
+	}
+	return curl, nil
+}
+
+// validateBundleApplication checks that the application described by
+// a bundle change could be deployed.
+func validateBundleApplication(
+	client *application.Client,
+	charmURL string,
+	p bundlechanges.AddApplicationParams,
+	bundleStorage map[string]map[string]storage.Constraints,
+) error {
+	curl, err := charm.ParseURL(charmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cons, err := constraints.Parse(p.Constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	storageConstraints := make(map[string]storage.Constraints)
+	for name, value := range p.Storage {
+		sc, err := storage.ParseConstraints(value)
+		if err != nil {
+			return errors.Annotate(err, "invalid storage constraints")
+		}
+		storageConstraints[name] = sc
+	}
+	for name, sc := range bundleStorage[p.Application] {
+		storageConstraints[name] = sc
+	}
+	return client.ValidateDeploy(application.DeployArgs{
+		CharmID:          charmstore.CharmID{URL: curl},
+		ApplicationName:  p.Application,
+		Series:           p.Series,
+		Cons:             cons,
+		Storage:          storageConstraints,
+		EndpointBindings: p.EndpointBindings,
+	})
+}
+
+// bundlePlan records the changes a bundle deployment would make,
+// replacing the placeholders used by bundle changes with the names
+// of charms, applications and machines.
+type bundlePlan struct {
+	lines    []string
+	names    map[string]string
+	units    map[string]int
+	machines int
+}
+
+func newBundlePlan() *bundlePlan {
+	return &bundlePlan{
+		names: make(map[string]string),
+		units: make(map[string]int),
+	}
+}
+
+func (p *bundlePlan) add(format string, args ...interface{}) {
+	p.lines = append(p.lines, fmt.Sprintf(format, args...))
+}
+
+// resolve returns the name recorded for the given placeholder.
+func (p *bundlePlan) resolve(placeholder string) string {
+	id := strings.TrimPrefix(placeholder, "$")
+	if name, ok := p.names[id]; ok {
+		return name
+	}
+	return placeholder
+}
+
+func (p *bundlePlan) addCharm(id string, curl *charm.URL) {
+	p.names[id] = curl.String()
+	p.add("add charm %s", curl)
+}
+
+func (p *bundlePlan) addApplication(id string, args bundlechanges.AddApplicationParams) {
+	p.names[id] = args.Application
+	line := fmt.Sprintf("add application %s using %s", args.Application, p.resolve(args.Charm))
+	if args.Constraints != "" {
+		line += " with constraints " + args.Constraints
+	}
+	p.lines = append(p.lines, line)
+}
+
+func (p *bundlePlan) useApplication(id string, args bundlechanges.AddApplicationParams) {
+	p.names[id] = args.Application
+	p.add("use existing application %s", args.Application)
+}
+
+func (p *bundlePlan) addMachine(id string, args bundlechanges.AddMachineParams) {
+	var name string
+	if args.ContainerType == "" {
+		p.machines++
+		name = fmt.Sprintf("new machine #%d", p.machines)
+	} else {
+		name = fmt.Sprintf("new %s container on %s", args.ContainerType, p.resolve(args.ParentId))
+	}
+	p.names[id] = name
+	line := "add " + name
+	if args.Constraints != "" {
+		line += " with constraints " + args.Constraints
+	}
+	p.lines = append(p.lines, line)
+}
+
+func (p *bundlePlan) addUnit(id string, args bundlechanges.AddUnitParams) {
+	applicationName := p.resolve(args.Application)
+	unitName := fmt.Sprintf("%s/%d", applicationName, p.units[applicationName])
+	p.units[applicationName]++
+	if args.To == "" {
+		p.names[id] = "the machine of " + unitName
+		p.add("add unit %s to a new machine", unitName)
+		return
+	}
+	// Units placed alongside another unit share its machine.
+	p.names[id] = p.resolve(args.To)
+	p.add("add unit %s to %s", unitName, p.names[id])
+}
+
+func (p *bundlePlan) addRelation(args bundlechanges.AddRelationParams) {
+	endpoint := func(ep string) string {
+		parts := strings.SplitN(ep, ":", 2)
+		parts[0] = p.resolve(parts[0])
+		return strings.Join(parts, ":")
+	}
+	p.add("add relation %s - %s", endpoint(args.Endpoint1), endpoint(args.Endpoint2))
+}
+
+// writePlan writes the dry run plan lines to the context.
+func writePlan(ctx *cmd.Context, plan []string) {
+	for _, line := range plan {
+		fmt.Fprintf(ctx.Stdout, "  - %s\n", line)
+	}
+	ctx.Infof("No changes were made to the model.")
+}
@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
+
+type DryRunSuite struct{}
+
+var _ = gc.Suite(&DryRunSuite{})
+
+func (s *DryRunSuite) TestCharmDeployPlan(c *gc.C) {
+	placement := []*instance.Placement{
+		instance.MustParsePlacement("3"),
+		instance.MustParsePlacement("lxd:3"),
+		instance.MustParsePlacement("kvm"),
+		instance.MustParsePlacement("3/lxd/1"),
+		{Scope: "model-uuid", Directive: "zone=us-east-1a"},
+	}
+	plan := charmDeployPlan("mysql", 6, placement, constraints.MustParse("mem=4G"))
+	c.Assert(plan, jc.DeepEquals, []string{
+		"add application mysql with constraints mem=4096M",
+		"add unit mysql/0 to machine 3",
+		"add unit mysql/1 to a new lxd container on machine 3",
+		"add unit mysql/2 to a new kvm container on a new machine",
+		"add unit mysql/3 to machine 3/lxd/1",
+		`add unit mysql/4 to a new machine with placement "zone=us-east-1a"`,
+		"add unit mysql/5 to a new machine",
+	})
+}
+
+func (s *DryRunSuite) TestCharmDeployPlanNoUnits(c *gc.C) {
+	plan := charmDeployPlan("logging", 0, nil, constraints.Value{})
+	c.Assert(plan, jc.DeepEquals, []string{"add application logging"})
+}
+
+func (s *DryRunSuite) TestPlanBundle(c *gc.C) {
+	data := readTestBundle(c, `
+        series: xenial
+        applications:
+            wordpress:
+                charm: cs:xenial/wordpress-3
+                num_units: 2
+                expose: true
+                to: ["lxd:0", "mysql/0"]
+            mysql:
+                charm: cs:xenial/mysql
+                num_units: 1
+                constraints: mem=4G
+                to: ["0"]
+            haproxy:
+                charm: cs:xenial/haproxy-1
+                num_units: 1
+        machines:
+            0:
+                constraints: cpu-cores=4
+        relations:
+            - ["wordpress:db", "mysql:server"]
+            - ["wordpress", "haproxy"]
+    `)
+	var validated []string
+	plan, err := planBundle(
+		bundlechanges.FromData(data),
+		resolveTestCharm,
+		func(charmURL string, p bundlechanges.AddApplicationParams) error {
+			validated = append(validated, p.Application+" "+charmURL)
+			if p.Application == "haproxy" {
+				return &params.Error{Code: params.CodeAlreadyExists}
+			}
+			return nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(validated, jc.DeepEquals, []string{
+		"haproxy cs:xenial/haproxy-1",
+		"mysql cs:xenial/mysql-42",
+		"wordpress cs:xenial/wordpress-3",
+	})
+	c.Check(plan, jc.DeepEquals, []string{
+		"add charm cs:xenial/haproxy-1",
+		"use existing application haproxy",
+		"add charm cs:xenial/mysql-42",
+		"add application mysql using cs:xenial/mysql-42 with constraints mem=4G",
+		"add charm cs:xenial/wordpress-3",
+		"add application wordpress using cs:xenial/wordpress-3",
+		"expose wordpress",
+		"add new machine #1 with constraints cpu-cores=4",
+		"add relation wordpress:db - mysql:server",
+		"add relation wordpress - haproxy",
+		"add unit haproxy/0 to a new machine",
+		"add unit mysql/0 to new machine #1",
+		"add unit wordpress/0 to new machine #1",
+		"add new lxd container on new machine #1",
+		"add unit wordpress/1 to new lxd container on new machine #1",
+	})
+}
+
+func (s *DryRunSuite) TestPlanBundleValidationError(c *gc.C) {
+	data := readTestBundle(c, `
+        applications:
+            mysql:
+                charm: cs:xenial/mysql-1
+                num_units: 1
+    `)
+	_, err := planBundle(
+		bundlechanges.FromData(data),
+		resolveTestCharm,
+		func(string, bundlechanges.AddApplicationParams) error {
+			return errors.New(`space "db" not found`)
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot deploy application "mysql": space "db" not found`)
+}
+
+// resolveTestCharm resolves charm URLs without a revision to
+// revision 42.
+func resolveTestCharm(p bundlechanges.AddCharmParams) (*charm.URL, error) {
+	curl, err := charm.ParseURL(p.Charm)
+	if err != nil {
+		return nil, err
+	}
+	if curl.Revision == -1 {
+		curl = curl.WithRevision(42)
+	}
+	return curl, nil
+}
+
+func readTestBundle(c *gc.C, content string) *charm.BundleData {
+	data, err := charm.ReadBundleData(strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
@@ -25,6 +25,13 @@ func NewGetCommandForTest(api getServiceAPI) cmd.Command {
 	})
 }
 
+// NewConfigCommandForTest returns a ConfigCommand with the api provided as specified.
+func NewConfigCommandForTest(api serviceAPI) cmd.Command {
+	return modelcmd.Wrap(&configCommand{
+		api: api,
+	})
+}
+
 // NewAddUnitCommandForTest returns an AddUnitCommand with the api provided as specified.
 func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	return modelcmd.Wrap(&addUnitCommand{
@@ -32,6 +39,13 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewSetLimitsCommandForTest returns a SetLimitsCommand with the api provided as specified.
+func NewSetLimitsCommandForTest(api setLimitsAPI) cmd.Command {
+	return modelcmd.Wrap(&setLimitsCommand{
+		api: api,
+	})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -4,10 +4,14 @@
 package application
 
 import (
+	"net"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +23,15 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+Access may be restricted to the given comma-separated source CIDRs
+with --to, replacing any sources previously given. This requires a
+cloud which can restrict the sources of ingress to each machine, and
+the "instance" firewall-mode. Since the ports opened by units are not
+bound to endpoints, an application is exposed on all its endpoints.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to 10.0.0.0/8,192.168.0.0/16
 
 See also: 
     unexpose`[1:]
@@ -34,6 +45,7 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	ToCIDRs         []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +57,28 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(cmd.NewStringsValue(nil, &c.ToCIDRs), "to", "Expose only to the given source CIDRs")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	for _, cidr := range c.ToCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeEndpoints(serviceName string, exposed map[string]params.ExposedEndpoint) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +98,12 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.ToCIDRs) == 0 {
+		err = client.Expose(c.ApplicationName)
+	} else {
+		err = client.ExposeEndpoints(c.ApplicationName, map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: c.ToCIDRs},
+		})
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -5,6 +5,7 @@ package application
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -72,6 +73,7 @@ type ServiceAPI interface {
 	Close() error
 	Destroy(serviceName string) error
 	DestroyUnits(unitNames ...string) error
+	ForceDestroyUnits(maxWait time.Duration, unitNames ...string) error
 	GetCharmURL(serviceName string) (*charm.URL, error)
 	ModelUUID() string
 }
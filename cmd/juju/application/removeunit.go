@@ -5,9 +5,11 @@ package application
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
@@ -24,8 +26,14 @@ func NewRemoveUnitCommand() cmd.Command {
 type removeUnitCommand struct {
 	modelcmd.ModelCommandBase
 	UnitNames []string
+	Force     bool
+	NoWait    bool
 }
 
+// forceWait is how long the agents of units removed with --force are
+// given to shut down cleanly before the units are removed.
+const forceWait = time.Minute
+
 const removeUnitDoc = `
 Remove application units from the model.
 
@@ -40,10 +48,19 @@ on that machine (including units in containers).
 Removing all units of a service is not equivalent to removing the service
 itself; for that, the ` + "`juju remove-service`" + ` command is used.
 
+Units whose agents cannot shut down cleanly, for example because a hook
+is failing or the agent is unreachable, remain in the model as dying.
+Such units can be removed using the '--force' option; this will also
+remove their subordinate units and storage attachments. Their agents are
+given up to a minute to shut down before the units are removed; with
+'--no-wait', they are removed immediately.
+
 Examples:
 
     juju remove-unit wordpress/2 wordpress/3 wordpress/4
 
+    juju remove-unit wordpress/5 --force --no-wait
+
 See also: remove-service
 `
 
@@ -57,11 +74,20 @@ func (c *removeUnitCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Force, "force", false, "Remove the units even if they do not shut down cleanly")
+	f.BoolVar(&c.NoWait, "no-wait", false, "With --force, remove without waiting for agents to shut down")
+}
+
 func (c *removeUnitCommand) Init(args []string) error {
 	c.UnitNames = args
 	if len(c.UnitNames) == 0 {
 		return fmt.Errorf("no units specified")
 	}
+	if c.NoWait && !c.Force {
+		return fmt.Errorf("--no-wait requires --force")
+	}
 	for _, name := range c.UnitNames {
 		if !names.IsValidUnit(name) {
 			return fmt.Errorf("invalid unit name %q", name)
@@ -86,5 +112,14 @@ func (c *removeUnitCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.DestroyUnits(c.UnitNames...), block.BlockRemove)
+	if c.Force {
+		maxWait := forceWait
+		if c.NoWait {
+			maxWait = 0
+		}
+		err = client.ForceDestroyUnits(maxWait, c.UnitNames...)
+	} else {
+		err = client.DestroyUnits(c.UnitNames...)
+	}
+	return block.ProcessBlockedError(err, block.BlockRemove)
 }
@@ -6,6 +6,7 @@ package application
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/series"
 	gc "gopkg.in/check.v1"
@@ -57,6 +58,24 @@ func (s *RemoveUnitSuite) TestRemoveUnit(c *gc.C) {
 		c.Assert(u.Life(), gc.Equals, state.Dying)
 	}
 }
+
+func (s *RemoveUnitSuite) TestRemoveUnitForce(c *gc.C) {
+	s.setupUnitForRemove(c)
+
+	err := runRemoveUnit(c, "--force", "--no-wait", "dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Unit("dummy/0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	u, err := s.State.Unit("dummy/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.Life(), gc.Equals, state.Alive)
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitNoWaitWithoutForce(c *gc.C) {
+	err := runRemoveUnit(c, "--no-wait", "dummy/0")
+	c.Assert(err, gc.ErrorMatches, "--no-wait requires --force")
+}
+
 func (s *RemoveUnitSuite) TestBlockRemoveUnit(c *gc.C) {
 	svc := s.setupUnitForRemove(c)
 
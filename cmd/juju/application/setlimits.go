@@ -0,0 +1,150 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSetLimitsSummary = `
+Sets the minimum and maximum number of units for an application.`[1:]
+
+var usageSetLimitsDetails = `
+Juju keeps at least the minimum number of units alive for an application,
+adding new units when existing ones are removed. An application may not
+grow beyond its maximum number of units; attempts to add further units
+fail. A value of 0 removes the corresponding limit.
+
+The maximum may not be set lower than the minimum, nor lower than the
+number of units the application currently has.
+
+Examples:
+    juju set-application-limits mysql --min-units 3
+    juju set-application-limits wordpress --min-units 2 --max-units 5
+    juju set-application-limits wordpress --max-units 0
+
+See also:
+    add-unit
+    remove-unit`[1:]
+
+// NewSetLimitsCommand returns a command which sets the minimum and
+// maximum number of units for an application.
+func NewSetLimitsCommand() cmd.Command {
+	return modelcmd.Wrap(&setLimitsCommand{})
+}
+
+// setLimitsCommand is responsible for setting the unit limits of an
+// application.
+type setLimitsCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	MinUnits        *int
+	MaxUnits        *int
+	api             setLimitsAPI
+}
+
+func (c *setLimitsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-application-limits",
+		Args:    "<application name>",
+		Purpose: usageSetLimitsSummary,
+		Doc:     usageSetLimitsDetails,
+	}
+}
+
+func (c *setLimitsCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(optionalIntValue{&c.MinUnits}, "min-units", "Minimum number of units to keep alive")
+	f.Var(optionalIntValue{&c.MaxUnits}, "max-units", "Maximum number of units allowed")
+}
+
+func (c *setLimitsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	if c.MinUnits == nil && c.MaxUnits == nil {
+		return errors.New("no limits specified: use --min-units and/or --max-units")
+	}
+	if c.MinUnits != nil && *c.MinUnits < 0 {
+		return errors.New("--min-units must not be negative")
+	}
+	if c.MaxUnits != nil && *c.MaxUnits < 0 {
+		return errors.New("--max-units must not be negative")
+	}
+	if c.MinUnits != nil && c.MaxUnits != nil && *c.MaxUnits > 0 && *c.MinUnits > *c.MaxUnits {
+		return errors.New("--min-units must not be greater than --max-units")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// setLimitsAPI defines the methods on the client API that the
+// set-application-limits command calls.
+type setLimitsAPI interface {
+	Close() error
+	Update(args params.ApplicationUpdate) error
+}
+
+func (c *setLimitsCommand) getAPI() (setLimitsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run updates the unit limits of the application.
+func (c *setLimitsCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.Update(params.ApplicationUpdate{
+		ApplicationName: c.ApplicationName,
+		MinUnits:        c.MinUnits,
+		MaxUnits:        c.MaxUnits,
+	})
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// optionalIntValue implements gnuflag.Value for an integer flag
+// which is left nil when not specified on the command line.
+type optionalIntValue struct {
+	value **int
+}
+
+// Set implements gnuflag.Value.
+func (v optionalIntValue) Set(s string) error {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return errors.Errorf("expected integer, got %q", s)
+	}
+	*v.value = &i
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (v optionalIntValue) String() string {
+	if *v.value == nil {
+		return ""
+	}
+	return strconv.Itoa(**v.value)
+}
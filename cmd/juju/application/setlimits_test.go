@@ -0,0 +1,129 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type SetLimitsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSetLimitsAPI
+}
+
+var _ = gc.Suite(&SetLimitsSuite{})
+
+func (s *SetLimitsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSetLimitsAPI{}
+}
+
+func (s *SetLimitsSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no application name specified`,
+	}, {
+		args: []string{"mysql-0", "--min-units", "1"},
+		err:  `invalid application name "mysql-0"`,
+	}, {
+		args: []string{"mysql"},
+		err:  `no limits specified: use --min-units and/or --max-units`,
+	}, {
+		args: []string{"mysql", "--min-units=-1"},
+		err:  `--min-units must not be negative`,
+	}, {
+		args: []string{"mysql", "--max-units=-1"},
+		err:  `--max-units must not be negative`,
+	}, {
+		args: []string{"mysql", "--max-units", "many"},
+		err:  `invalid value "many" for flag --max-units: expected integer, got "many"`,
+	}, {
+		args: []string{"mysql", "--min-units", "3", "--max-units", "2"},
+		err:  `--min-units must not be greater than --max-units`,
+	}, {
+		args: []string{"mysql", "--min-units", "1", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql", "--min-units", "3", "--max-units", "0"},
+	}, {
+		args: []string{"mysql", "--max-units", "2"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(application.NewSetLimitsCommandForTest(s.fake), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *SetLimitsSuite) TestRun(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewSetLimitsCommandForTest(s.fake),
+		"mysql", "--min-units", "2", "--max-units", "5")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "Update", "Close")
+	minUnits, maxUnits := 2, 5
+	s.fake.CheckCall(c, 0, "Update", params.ApplicationUpdate{
+		ApplicationName: "mysql",
+		MinUnits:        &minUnits,
+		MaxUnits:        &maxUnits,
+	})
+}
+
+func (s *SetLimitsSuite) TestRunOnlyMaxUnits(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewSetLimitsCommandForTest(s.fake),
+		"mysql", "--max-units", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	maxUnits := 0
+	s.fake.CheckCall(c, 0, "Update", params.ApplicationUpdate{
+		ApplicationName: "mysql",
+		MaxUnits:        &maxUnits,
+	})
+}
+
+func (s *SetLimitsSuite) TestBlockRun(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockRun"))
+	_, err := testing.RunCommand(c, application.NewSetLimitsCommandForTest(s.fake),
+		"mysql", "--min-units", "1")
+	c.Assert(err, gc.NotNil)
+
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockRun.*")
+}
+
+func (s *SetLimitsSuite) TestRunError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, application.NewSetLimitsCommandForTest(s.fake),
+		"mysql", "--min-units", "1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeSetLimitsAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeSetLimitsAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeSetLimitsAPI) Update(args params.ApplicationUpdate) error {
+	f.AddCall("Update", args)
+	return f.NextErr()
+}
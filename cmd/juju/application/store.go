@@ -142,6 +142,23 @@ func addCharmFromURL(client *api.Client, curl *charm.URL, channel csparams.Chann
 	return curl, csMac, nil
 }
 
+// charmStoreCharmMeta returns the metadata and config of the charm with
+// the given URL from the charm store, without adding the charm to the
+// model.
+func charmStoreCharmMeta(csClient *csclient.Client, curl *charm.URL) (*charm.Meta, *charm.Config, error) {
+	var result struct {
+		CharmMetadata *charm.Meta
+		CharmConfig   *charm.Config
+	}
+	if _, err := csClient.Meta(curl, &result); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if result.CharmMetadata == nil {
+		return nil, nil, errors.NotFoundf("metadata for charm %q", curl)
+	}
+	return result.CharmMetadata, result.CharmConfig, nil
+}
+
 // newCharmStoreClient is called to obtain a charm store client.
 // It is defined as a variable so it can be changed for testing purposes.
 var newCharmStoreClient = func(client *httpbakery.Client) *csclient.Client {
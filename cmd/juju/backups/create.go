@@ -12,7 +12,10 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/progress"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/state/backups"
 )
@@ -28,6 +31,14 @@ create-backup requests that juju create a backup of its state and print the
 backup's unique ID.  You may provide a note to associate with the backup.
 
 The backup archive and associated metadata are stored remotely by juju.
+If the controller's backup-target is "cloud", the archive is stored in
+the cloud's object storage (such as an S3 bucket) rather than in the
+controller itself.
+
+The controller also creates backups on its own when its backup-interval
+is set, keeping the most recent backup-retention-count of them:
+
+    juju bootstrap --config backup-interval=24h --config backup-retention-count=7 ...
 
 The --download option may be used without the --filename option.  In
 that case, the backup archive will be stored in the current working
@@ -60,6 +71,8 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// Progress is how the progress of the backup is reported.
+	Progress progress.Mode
 }
 
 // Info implements Command.Info.
@@ -77,6 +90,7 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	progress.AddFlag(f, &c.Progress)
 }
 
 // Init implements Command.Init.
@@ -110,10 +124,14 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	reporter := c.Progress.NewReporter(ctx.Stderr, clock.WallClock)
+	defer reporter.Close()
+	reporter.Report(progress.Event{Operation: "creating backup"})
 	result, err := client.Create(c.Notes)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	reporter.Report(progress.Event{Operation: "creating backup", Done: true})
 
 	if c.Log != nil && !c.Log.Quiet {
 		if c.NoDownload {
@@ -127,7 +145,7 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	// Handle download.
 	filename := c.decideFilename(ctx, c.Filename, result.Started)
 	if filename != "" {
-		if err := c.download(ctx, result.ID, filename); err != nil {
+		if err := c.download(ctx, reporter, result, filename); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -147,7 +165,7 @@ func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timest
 	return timestamp.Format(backups.FilenameTemplate)
 }
 
-func (c *createCommand) download(ctx *cmd.Context, id string, filename string) error {
+func (c *createCommand) download(ctx *cmd.Context, reporter progress.Reporter, result *params.BackupsMetadataResult, filename string) error {
 	fmt.Fprintln(ctx.Stdout, "downloading to "+filename)
 
 	// TODO(ericsnow) lp-1399722 This needs further investigation:
@@ -160,7 +178,7 @@ func (c *createCommand) download(ctx *cmd.Context, id string, filename string) e
 	}
 	defer client.Close()
 
-	archive, err := client.Download(id)
+	archive, err := client.Download(result.ID)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -172,6 +190,6 @@ func (c *createCommand) download(ctx *cmd.Context, id string, filename string) e
 	}
 	defer outfile.Close()
 
-	_, err = io.Copy(outfile, archive)
+	_, err = io.Copy(outfile, progress.NewReader(archive, result.Size, "downloading backup", reporter))
 	return errors.Trace(err)
 }
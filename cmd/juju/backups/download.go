@@ -11,7 +11,9 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 
+	"github.com/juju/juju/cmd/juju/progress"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/state/backups"
 )
@@ -35,6 +37,8 @@ type downloadCommand struct {
 	Filename string
 	// ID is the backup ID to download.
 	ID string
+	// Progress is how the progress of the download is reported.
+	Progress progress.Mode
 }
 
 // Info implements Command.Info.
@@ -51,6 +55,7 @@ func (c *downloadCommand) Info() *cmd.Info {
 func (c *downloadCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Download target")
+	progress.AddFlag(f, &c.Progress)
 }
 
 // Init implements Command.Init.
@@ -95,7 +100,9 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 	defer archive.Close()
 
 	// Write out the archive.
-	_, err = io.Copy(archive, resultArchive)
+	reporter := c.Progress.NewReporter(ctx.Stderr, clock.WallClock)
+	defer reporter.Close()
+	_, err = io.Copy(archive, progress.NewReader(resultArchive, 0, "downloading backup", reporter))
 	if err != nil {
 		return errors.Annotate(err, "while creating local archive file")
 	}
@@ -66,6 +66,18 @@ func (s *downloadSuite) TestFilename(c *gc.C) {
 	s.checkArchive(c)
 }
 
+func (s *downloadSuite) TestProgress(c *gc.C) {
+	s.setSuccess()
+	ctx, err := testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--progress", "json")
+	c.Check(err, jc.ErrorIsNil)
+
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz"
+	c.Check(testing.Stdout(ctx), gc.Equals, s.filename+"\n")
+	c.Check(testing.Stderr(ctx), gc.Matches,
+		`{"operation":"downloading backup","current":25,"unit":"bytes","time":".*"}\n`)
+	s.checkArchive(c)
+}
+
 func (s *downloadSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
@@ -8,8 +8,11 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/progress"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -28,6 +31,8 @@ type uploadCommand struct {
 	CommandBase
 	// Filename is where to find the archive to upload.
 	Filename string
+	// Progress is how the progress of the upload is reported.
+	Progress progress.Mode
 }
 
 // Info implements Command.Info.
@@ -40,6 +45,12 @@ func (c *uploadCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *uploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	progress.AddFlag(f, &c.Progress)
+}
+
 // Init implements Command.Init.
 func (c *uploadCommand) Init(args []string) error {
 	if len(args) == 0 {
@@ -79,7 +90,9 @@ func (c *uploadCommand) Run(ctx *cmd.Context) error {
 	}
 
 	// Upload the archive.
-	id, err := client.Upload(archive, *meta)
+	reporter := c.Progress.NewReporter(ctx.Stderr, clock.WallClock)
+	defer reporter.Close()
+	id, err := client.Upload(progress.NewReader(archive, meta.Size, "uploading backup", reporter), *meta)
 	if err != nil {
 		return errors.Trace(err)
 	}
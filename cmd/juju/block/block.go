@@ -7,6 +7,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -15,6 +16,7 @@ import (
 // commands that enable blocks.
 type BaseBlockCommand struct {
 	modelcmd.ModelCommandBase
+	entityFlags
 	desc string
 }
 
@@ -28,7 +30,7 @@ func (c *BaseBlockCommand) Init(args []string) error {
 	if len(args) == 1 {
 		c.desc = args[0]
 	}
-	return nil
+	return c.entityFlags.validate()
 }
 
 // internalRun blocks commands from running successfully.
@@ -39,18 +41,23 @@ func (c *BaseBlockCommand) internalRun(operation string) error {
 	}
 	defer client.Close()
 
+	if tag := c.entityFlags.tag(); tag != nil {
+		return client.SwitchEntityBlockOn(TypeFromOperation(operation), tag, c.desc)
+	}
 	return client.SwitchBlockOn(TypeFromOperation(operation), c.desc)
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *BaseBlockCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	c.entityFlags.setFlags(f, "Block")
 }
 
 // BlockClientAPI defines the client API methods that block command uses.
 type BlockClientAPI interface {
 	Close() error
 	SwitchBlockOn(blockType, msg string) error
+	SwitchEntityBlockOn(blockType string, tag names.Tag, msg string) error
 }
 
 var getBlockClientAPI = func(p *BaseBlockCommand) (BlockClientAPI, error) {
@@ -80,6 +87,12 @@ Examples:
 
 `
 
+// SetFlags implements Command.SetFlags. Only the model as a whole
+// can be protected from destruction, so the entity flags are omitted.
+func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+}
+
 // Info provides information about command.
 // Satisfying Command interface.
 func (c *destroyCommand) Info() *cmd.Info {
@@ -118,10 +131,17 @@ To by-pass the block, where available, run desired remove command with --force o
     remove-application
     remove-unit
    
+The block may be restricted to a single application or machine with the
+--application or --machine option. Only operations on that application,
+its units and relations, or that machine, are then blocked.
+
 Examples:
     # To prevent the machines, applications, units and relations from being removed:
     juju block remove-object
 
+    # To prevent only the mysql application and its units from being removed:
+    juju block remove-object --application mysql
+
 `
 
 // Info provides information about command.
@@ -187,10 +207,17 @@ To by-pass the block, where available, run desired remove command with --force o
     disable-user
     enable-user
    
+The block may be restricted to a single application or machine with the
+--application or --machine option. Only operations on that application,
+its units and relations, or that machine, are then blocked.
+
 Examples:
     # To prevent changes to the model:
     juju block all-changes
 
+    # To prevent changes to the mysql application only:
+    juju block all-changes --application mysql "production database"
+
 `
 
 // Info provides information about command.
@@ -207,3 +234,40 @@ func (c *changeCommand) Info() *cmd.Info {
 func (c *changeCommand) Run(_ *cmd.Context) error {
 	return c.internalRun(c.Info().Name)
 }
+
+// entityFlags holds the options which restrict a block to a single
+// application or machine.
+type entityFlags struct {
+	application string
+	machine     string
+}
+
+func (e *entityFlags) setFlags(f *gnuflag.FlagSet, verb string) {
+	f.StringVar(&e.application, "application", "", verb+" the operation for the named application only")
+	f.StringVar(&e.machine, "machine", "", verb+" the operation for the given machine only")
+}
+
+func (e *entityFlags) validate() error {
+	if e.application != "" && e.machine != "" {
+		return errors.New("cannot specify both --application and --machine")
+	}
+	if e.application != "" && !names.IsValidApplication(e.application) {
+		return errors.NotValidf("application name %q", e.application)
+	}
+	if e.machine != "" && !names.IsValidMachine(e.machine) {
+		return errors.NotValidf("machine id %q", e.machine)
+	}
+	return nil
+}
+
+// tag returns the tag of the entity to which the block is restricted,
+// or nil if it applies to the whole model.
+func (e *entityFlags) tag() names.Tag {
+	switch {
+	case e.application != "":
+		return names.NewApplicationTag(e.application)
+	case e.machine != "":
+		return names.NewMachineTag(e.machine)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/cmd/juju/block"
@@ -97,3 +98,29 @@ func (s *BlockCommandSuite) TestProcessErrAny(c *gc.C) {
 	err := errors.New("Test error Processing")
 	s.processErrorTest(c, err, block.BlockDestroy, err, "")
 }
+
+func (s *BlockCommandSuite) TestBlockChangeApplication(c *gc.C) {
+	command := block.NewChangeCommand()
+	_, err := testing.RunCommand(c, command, "--application", "mysql", "TestBlockChangeApplication")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlock(c, command.Info().Name, "TestBlockChangeApplication")
+	c.Assert(s.mockClient.Tag, gc.Equals, names.NewApplicationTag("mysql"))
+}
+
+func (s *BlockCommandSuite) TestBlockRemoveMachine(c *gc.C) {
+	command := block.NewRemoveCommand()
+	_, err := testing.RunCommand(c, command, "--machine", "0/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlock(c, command.Info().Name, "")
+	c.Assert(s.mockClient.Tag, gc.Equals, names.NewMachineTag("0/lxd/1"))
+}
+
+func (s *BlockCommandSuite) TestBlockInvalidApplication(c *gc.C) {
+	_, err := testing.RunCommand(c, block.NewChangeCommand(), "--application", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
+
+func (s *BlockCommandSuite) TestBlockDestroyNoEntity(c *gc.C) {
+	_, err := testing.RunCommand(c, block.NewDestroyCommand(), "--application", "mysql")
+	c.Assert(err, gc.ErrorMatches, `flag provided but not defined: --application`)
+}
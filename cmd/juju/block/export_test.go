@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/cmd"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -24,6 +25,7 @@ var (
 type MockBlockClient struct {
 	BlockType string
 	Msg       string
+	Tag       names.Tag
 }
 
 func (c *MockBlockClient) Close() error {
@@ -42,13 +44,32 @@ func (c *MockBlockClient) SwitchBlockOff(blockType string) error {
 	return nil
 }
 
+func (c *MockBlockClient) SwitchEntityBlockOn(blockType string, tag names.Tag, msg string) error {
+	c.BlockType = blockType
+	c.Tag = tag
+	c.Msg = msg
+	return nil
+}
+
+func (c *MockBlockClient) SwitchEntityBlockOff(blockType string, tag names.Tag) error {
+	c.BlockType = blockType
+	c.Tag = tag
+	c.Msg = ""
+	return nil
+}
+
 func (c *MockBlockClient) List() ([]params.Block, error) {
 	if c.BlockType == "" {
 		return []params.Block{}, nil
 	}
 
+	var tag string
+	if c.Tag != nil {
+		tag = c.Tag.String()
+	}
 	return []params.Block{
 		params.Block{
+			Tag:     tag,
 			Type:    c.BlockType,
 			Message: c.Msg,
 		},
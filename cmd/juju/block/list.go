@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -24,6 +25,8 @@ const listCommandDoc = `
 List blocks for Juju model.
 This command shows if each block type is enabled. 
 For enabled blocks, block message is shown if it was specified.
+Blocks restricted to a single application or machine are listed
+after the model blocks, with the entity they apply to.
 `
 
 // listCommand list blocks.
@@ -86,6 +89,7 @@ type BlockInfo struct {
 	Operation string  `yaml:"block" json:"block"`
 	Enabled   bool    `yaml:"enabled" json:"enabled"`
 	Message   *string `yaml:"message,omitempty" json:"message,omitempty"`
+	Entity    string  `yaml:"entity,omitempty" json:"entity,omitempty"`
 }
 
 // formatBlockInfo takes a set of Block and creates a
@@ -94,14 +98,21 @@ func formatBlockInfo(all []params.Block) []BlockInfo {
 	output := make([]BlockInfo, len(blockArgs))
 
 	info := make(map[string]BlockInfo, len(all))
+	var entityBlocks []BlockInfo
 	// not all block types may be returned from client
 	for _, one := range all {
 		op := OperationFromType(one.Type)
+		message := one.Message
 		bi := BlockInfo{
 			Operation: op,
 			// If client returned it, it means that it is enabled
 			Enabled: true,
-			Message: &one.Message,
+			Message: &message,
+		}
+		if tag, err := names.ParseTag(one.Tag); err == nil && tag.Kind() != names.ModelTagKind {
+			bi.Entity = tag.String()
+			entityBlocks = append(entityBlocks, bi)
+			continue
 		}
 		info[op] = bi
 	}
@@ -114,7 +125,7 @@ func formatBlockInfo(all []params.Block) []BlockInfo {
 		output[i] = BlockInfo{Operation: aType}
 	}
 
-	return output
+	return append(output, entityBlocks...)
 }
 
 // formatBlocks returns block list representation.
@@ -133,7 +144,11 @@ func formatBlocks(value interface{}) ([]byte, error) {
 		if ablock.Enabled {
 			switched = "on"
 		}
-		fmt.Fprintf(tw, "%v\t", ablock.Operation)
+		if ablock.Entity != "" {
+			fmt.Fprintf(tw, "%v (%v)\t", ablock.Operation, ablock.Entity)
+		} else {
+			fmt.Fprintf(tw, "%v\t", ablock.Operation)
+		}
 		if ablock.Message != nil {
 			fmt.Fprintf(tw, "\t=%v, %v", switched, *ablock.Message)
 			continue
@@ -6,6 +6,7 @@ package block_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -71,3 +72,15 @@ func (s *listCommandSuite) TestListJson(c *gc.C) {
 	c.Assert(testing.Stdout(ctx), gc.Equals, `[{"block":"destroy-model","enabled":false},{"block":"remove-object","enabled":true,"message":"Test this one"},{"block":"all-changes","enabled":false}]
 `)
 }
+
+func (s *listCommandSuite) TestListEntityBlock(c *gc.C) {
+	s.mockClient.SwitchEntityBlockOn(string(multiwatcher.BlockChange), names.NewApplicationTag("mysql"), "Test this one")
+	ctx, err := testing.RunCommand(c, block.NewListCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+destroy-model                    =off
+remove-object                    =off
+all-changes                      =off
+all-changes (application-mysql)  =on, Test this one
+`)
+}
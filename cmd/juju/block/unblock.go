@@ -10,6 +10,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -27,6 +28,7 @@ func NewUnblockCommand() cmd.Command {
 // unblockCommand removes the block from desired operation.
 type unblockCommand struct {
 	modelcmd.ModelCommandBase
+	entityFlags
 	operation string
 	getClient func() (UnblockClientAPI, error)
 }
@@ -85,6 +87,9 @@ all-changes includes all alteration commands
     disable-user
     enable-user
 
+Blocks restricted to a single application or machine are removed by
+specifying the same --application or --machine option.
+
 Examples:
     # To allow the model to be destroyed:
     juju unblock destroy-model
@@ -95,6 +100,9 @@ Examples:
     # To allow changes to the model:
     juju unblock all-changes
 
+    # To allow changes to the mysql application:
+    juju unblock all-changes --application mysql
+
 See Also:
    juju block
 `
@@ -143,12 +151,16 @@ func (c *unblockCommand) Init(args []string) error {
 		return errors.Trace(errors.New("can only specify block type"))
 	}
 
-	return c.assignValidOperation("unblock", args)
+	if err := c.assignValidOperation("unblock", args); err != nil {
+		return err
+	}
+	return c.entityFlags.validate()
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *unblockCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	c.entityFlags.setFlags(f, "Unblock")
 }
 
 // Run unblocks previously blocked commands.
@@ -160,6 +172,9 @@ func (c *unblockCommand) Run(_ *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if tag := c.entityFlags.tag(); tag != nil {
+		return client.SwitchEntityBlockOff(TypeFromOperation(c.operation), tag)
+	}
 	return client.SwitchBlockOff(TypeFromOperation(c.operation))
 }
 
@@ -167,6 +182,7 @@ func (c *unblockCommand) Run(_ *cmd.Context) error {
 type UnblockClientAPI interface {
 	Close() error
 	SwitchBlockOff(blockType string) error
+	SwitchEntityBlockOff(blockType string, tag names.Tag) error
 }
 
 var getUnblockClientAPI = func(p *unblockCommand) (UnblockClientAPI, error) {
@@ -8,6 +8,7 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/testing"
@@ -65,3 +66,15 @@ func (s *UnblockCommandSuite) TestUnblockCmdValidDestroyEnvOperationUpperCase(c
 func (s *UnblockCommandSuite) TestUnblockCmdValidDestroyEnvOperation(c *gc.C) {
 	s.assertRunUnblock(c, "destroy-model")
 }
+
+func (s *UnblockCommandSuite) TestUnblockCmdApplication(c *gc.C) {
+	err := s.runUnblockCommand(c, "all-changes", "--application", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockClient.BlockType, gc.Equals, block.TypeFromOperation("all-changes"))
+	c.Assert(s.mockClient.Tag, gc.Equals, names.NewApplicationTag("mysql"))
+}
+
+func (s *UnblockCommandSuite) TestUnblockCmdApplicationAndMachine(c *gc.C) {
+	err := s.runUnblockCommand(c, "all-changes", "--application", "mysql", "--machine", "0")
+	s.assertErrorMatches(c, err, `cannot specify both --application and --machine`)
+}
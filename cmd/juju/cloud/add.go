@@ -30,11 +30,16 @@ overwrite its configuration.
 Known cloud types: azure, cloudsigma, ec2, gce, joyent, lxd, maas, manual,
 openstack, rackspace
 
+Models may be added to a user-defined cloud in an existing controller with
+` + "`juju add-model --cloud`" + `; the cloud definition is uploaded to the
+controller as needed.
+
 Examples:
     juju add-cloud mycloud ~/mycloud.yaml
 
 See also: 
-    clouds`
+    clouds
+    add-model`
 
 type addCloudCommand struct {
 	cmd.CommandBase
@@ -17,6 +17,7 @@ import (
 
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/juju/interact"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/jujuclient"
 )
@@ -42,6 +43,13 @@ credentials:
       subscription-id: <uuid2>
       tenant-id: <uuid3>
 
+For some clouds, the interactive prompts are replaced by a cloud-specific
+flow. For Azure, you sign in with a web browser and the subscription and
+tenant are looked up for you; for Google, the JSON file downloaded from
+the Google Cloud console is read and its credentials stored. Credentials
+added interactively are checked with the cloud before they are stored,
+unless ` + "`--no-validate`" + ` is specified.
+
 A "credential name" is arbitrary and is used solely to represent a set of
 credentials, of which there may be multiple per cloud.
 The ` + "`--replace`" + ` option is required if credential information for the named
@@ -53,6 +61,11 @@ For credentials which are already in use by tools other than Juju, ` + "`juju \n
 When Juju needs credentials for a cloud, i) if there are multiple
 available; ii) there's no set default; iii) and one is not specified ('--
 credential'), an error will be emitted.
+Credentials are stored in the Juju data directory's credentials.yaml
+file, unless the client.yaml file there contains
+"credential-backend: keyring", in which case their attributes are kept
+in the operating system's keyring (the Secret Service on Linux, or the
+Keychain on macOS) instead.
 
 Examples:
     juju add-credential google
@@ -78,6 +91,10 @@ type addCredentialCommand struct {
 	// CredentialsFile is the name of the credentials YAML file.
 	CredentialsFile string
 
+	// NoValidate, if true, credentials added interactively are stored
+	// without being checked with the cloud.
+	NoValidate bool
+
 	cloud *jujucloud.Cloud
 }
 
@@ -101,6 +118,7 @@ func (c *addCredentialCommand) Info() *cmd.Info {
 func (c *addCredentialCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Replace, "replace", false, "Overwrite existing credential information")
 	f.StringVar(&c.CredentialsFile, "f", "", "The YAML file containing credentials to add")
+	f.BoolVar(&c.NoValidate, "no-validate", false, "Do not check credentials added interactively with the cloud")
 }
 
 func (c *addCredentialCommand) Init(args []string) (err error) {
@@ -205,17 +223,44 @@ func (c *addCredentialCommand) interactiveAddCredential(ctxt *cmd.Context, schem
 	if err != nil {
 		return errors.Trace(err)
 	}
-	schema, ok := schemas[authType]
-	if !ok {
-		return errors.NotSupportedf("auth type %q for cloud %q", authType, c.CloudName)
-	}
 
-	attrs, err := c.promptCredentialAttributes(ctxt, ctxt.Stderr, ctxt.Stdin, authType, schema)
-	if err != nil {
+	// Use the cloud-specific flow for the auth type if there is one,
+	// and otherwise prompt for each attribute of the auth type.
+	prompter, err := environs.CredentialPrompterFor(c.cloud.Type)
+	if err != nil && !errors.IsNotFound(err) {
 		return errors.Trace(err)
 	}
-	newCredential := jujucloud.NewCredential(authType, attrs)
-	existingCredentials.AuthCredentials[credentialName] = newCredential
+	var newCredential *jujucloud.Credential
+	if prompter != nil {
+		promptContext, err := c.promptContext(ctxt)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		newCredential, err = prompter.PromptCredential(promptContext, authType)
+		if err != nil && !errors.IsNotSupported(err) {
+			return errors.Trace(err)
+		}
+	}
+	if newCredential == nil {
+		schema, ok := schemas[authType]
+		if !ok {
+			return errors.NotSupportedf("auth type %q for cloud %q", authType, c.CloudName)
+		}
+		attrs, err := c.promptCredentialAttributes(ctxt, ctxt.Stderr, ctxt.Stdin, authType, schema)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		credential := jujucloud.NewCredential(authType, attrs)
+		newCredential = &credential
+	}
+
+	if prompter != nil && !c.NoValidate {
+		fmt.Fprintf(ctxt.Stderr, "Checking credentials with cloud %s...\n", c.CloudName)
+		if err := c.validateCredential(prompter, *newCredential, schemas); err != nil {
+			return errors.Annotate(err, "checking credentials")
+		}
+	}
+	existingCredentials.AuthCredentials[credentialName] = *newCredential
 	err = c.store.UpdateCredential(c.CloudName, *existingCredentials)
 	if err != nil {
 		return errors.Trace(err)
@@ -224,6 +269,70 @@ func (c *addCredentialCommand) interactiveAddCredential(ctxt *cmd.Context, schem
 	return nil
 }
 
+// validateCredential checks the given credential with the cloud, using
+// the cloud's first region if it has any.
+func (c *addCredentialCommand) validateCredential(
+	prompter environs.CredentialPrompter,
+	credential jujucloud.Credential,
+	schemas map[jujucloud.AuthType]jujucloud.CredentialSchema,
+) error {
+	finalized, err := jujucloud.FinalizeCredential(credential, schemas, ioutil.ReadFile)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	spec, err := c.cloudSpec(finalized)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return prompter.ValidateCredential(spec)
+}
+
+// cloudSpec returns the spec of the cloud's first region, if it has
+// any, with the given credential.
+func (c *addCredentialCommand) cloudSpec(credential *jujucloud.Credential) (environs.CloudSpec, error) {
+	var regionName string
+	if len(c.cloud.Regions) > 0 {
+		regionName = c.cloud.Regions[0].Name
+	}
+	return environs.MakeCloudSpec(*c.cloud, c.CloudName, regionName, credential)
+}
+
+// promptContext returns the context in which a cloud-specific flow
+// interacts with the user.
+func (c *addCredentialCommand) promptContext(ctxt *cmd.Context) (environs.CredentialPromptContext, error) {
+	spec, err := c.cloudSpec(nil)
+	if err != nil {
+		return environs.CredentialPromptContext{}, errors.Trace(err)
+	}
+	return environs.CredentialPromptContext{
+		Cloud: spec,
+		Out:   ctxt.Stderr,
+		Prompt: func(question string, hidden bool) (string, error) {
+			fmt.Fprintf(ctxt.Stderr, "%s: ", question)
+			var input string
+			var err error
+			if hidden {
+				input, err = c.readHiddenField(ctxt.Stdin)
+				fmt.Fprintln(ctxt.Stderr)
+			} else {
+				input, err = readLine(ctxt.Stdin)
+			}
+			if err != nil {
+				return "", errors.Trace(err)
+			}
+			return strings.TrimSpace(input), nil
+		},
+		Select: func(question string, options []string) (string, error) {
+			return interact.Select(interact.Selection{
+				Options:  options,
+				Question: question,
+				Default:  options[0],
+				Invalid:  errors.New("Invalid selection."),
+			}, interact.NewLineScanner(ctxt.Stdin), ctxt.Stderr)
+		},
+	}, nil
+}
+
 func (c *addCredentialCommand) promptCredentialName(out io.Writer, in io.Reader) (string, error) {
 	fmt.Fprint(out, "Enter credential name: ")
 	input, err := readLine(in)
@@ -234,16 +343,14 @@ func (c *addCredentialCommand) promptCredentialName(out io.Writer, in io.Reader)
 }
 
 func (c *addCredentialCommand) promptReplace(out io.Writer, in io.Reader) (bool, error) {
-	fmt.Fprint(out, `
-A credential with that name already exists.
-
-Replace the existing credential? (y/N): `[1:])
-
-	input, err := readLine(in)
+	fmt.Fprint(out, "A credential with that name already exists.\n\n")
+	replace, err := interact.QueryYesNo(
+		"Replace the existing credential?", false, interact.NewLineScanner(in), out,
+	)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
-	return strings.ToLower(strings.TrimSpace(input)) == "y", nil
+	return replace, nil
 }
 
 func (c *addCredentialCommand) promptAuthType(out io.Writer, in io.Reader, authTypes []jujucloud.AuthType) (jujucloud.AuthType, error) {
@@ -251,36 +358,19 @@ func (c *addCredentialCommand) promptAuthType(out io.Writer, in io.Reader, authT
 		fmt.Fprintf(out, "Using auth-type %q.\n", authTypes[0])
 		return authTypes[0], nil
 	}
-	authType := ""
 	choices := make([]string, len(authTypes))
 	for i, a := range authTypes {
 		choices[i] = string(a)
-		if i == 0 {
-			choices[i] += "*"
-		}
 	}
-	for {
-		fmt.Fprintf(out, "Auth Types\n%s\n\nSelect auth-type: ",
-			strings.Join(choices, "\n"))
-		input, err := readLine(in)
-		if err != nil {
-			return "", errors.Trace(err)
-		}
-		authType = strings.ToLower(strings.TrimSpace(input))
-		if authType == "" {
-			authType = string(authTypes[0])
-		}
-		isValid := false
-		for _, a := range authTypes {
-			if string(a) == authType {
-				isValid = true
-				break
-			}
-		}
-		if isValid {
-			break
-		}
-		fmt.Fprintf(out, "Invalid auth type %q.\n", authType)
+	authType, err := interact.Select(interact.Selection{
+		Heading:  "Auth Types",
+		Options:  choices,
+		Question: "Select auth-type",
+		Default:  choices[0],
+		Invalid:  errors.New("Invalid auth type."),
+	}, interact.NewLineScanner(in), out)
+	if err != nil {
+		return "", errors.Trace(err)
 	}
 	return jujucloud.AuthType(authType), nil
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -207,6 +208,16 @@ func (s *addCredentialSuite) TestAddCredentialMultipleAuthType(c *gc.C) {
 	s.assertAddUserpassCredential(c, "fred\nuserpass\nuser\npassword\n", nil)
 }
 
+func (s *addCredentialSuite) TestAddCredentialMultipleAuthTypeDefault(c *gc.C) {
+	s.authTypes = []jujucloud.AuthType{jujucloud.UserPassAuthType, jujucloud.AccessKeyAuthType}
+	s.assertAddUserpassCredential(c, "fred\n\nuser\npassword\n", nil)
+}
+
+func (s *addCredentialSuite) TestAddCredentialMultipleAuthTypeRetryOnInvalid(c *gc.C) {
+	s.authTypes = []jujucloud.AuthType{jujucloud.AccessKeyAuthType, jujucloud.UserPassAuthType}
+	s.assertAddUserpassCredential(c, "fred\nbogus\nUserPass\nuser\npassword\n", nil)
+}
+
 func (s *addCredentialSuite) TestAddCredentialReplace(c *gc.C) {
 	s.store.Credentials = map[string]jujucloud.CloudCredential{
 		"somecloud": {
@@ -346,3 +357,108 @@ func (s *addCredentialSuite) TestAddMAASCredential(c *gc.C) {
 		},
 	})
 }
+
+func (s *addCredentialSuite) registerPrompter(c *gc.C, prompter *fakeCredentialPrompter) {
+	environs.RegisterCredentialPrompter("mock-addcredential-provider", prompter)
+	s.AddCleanup(func(*gc.C) {
+		environs.UnregisterCredentialPrompter("mock-addcredential-provider")
+	})
+	s.authTypes = []jujucloud.AuthType{jujucloud.UserPassAuthType}
+	s.schema = map[jujucloud.AuthType]jujucloud.CredentialSchema{
+		jujucloud.UserPassAuthType: {
+			{
+				"username", jujucloud.CredentialAttr{},
+			}, {
+				"password", jujucloud.CredentialAttr{Hidden: true},
+			},
+		},
+	}
+}
+
+func (s *addCredentialSuite) TestAddCredentialPrompter(c *gc.C) {
+	prompter := &fakeCredentialPrompter{}
+	s.registerPrompter(c, prompter)
+	ctx, err := s.run(c, strings.NewReader("fred\nuser\n"), "somecloud")
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := jujucloud.NewCredential(jujucloud.UserPassAuthType, map[string]string{
+		"username": "user",
+		"password": "secret",
+	})
+	c.Assert(s.store.Credentials, jc.DeepEquals, map[string]jujucloud.CloudCredential{
+		"somecloud": {
+			AuthCredentials: map[string]jujucloud.Credential{"fred": expected},
+		},
+	})
+	prompter.CheckCallNames(c, "PromptCredential", "ValidateCredential")
+	prompter.CheckCall(c, 1, "ValidateCredential", environs.CloudSpec{
+		Type:       "mock-addcredential-provider",
+		Name:       "somecloud",
+		Credential: &expected,
+	})
+	c.Assert(testing.Stderr(ctx), gc.Matches, `(?s).*Enter username: Checking credentials with cloud somecloud...\n`)
+}
+
+func (s *addCredentialSuite) TestAddCredentialPrompterNotSupported(c *gc.C) {
+	prompter := &fakeCredentialPrompter{}
+	prompter.SetErrors(errors.NotSupportedf("interactive"))
+	s.registerPrompter(c, prompter)
+	_, err := s.run(c, strings.NewReader("fred\nuser\npassword\n"), "somecloud")
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := jujucloud.NewCredential(jujucloud.UserPassAuthType, map[string]string{
+		"username": "user",
+		"password": "password",
+	})
+	c.Assert(s.store.Credentials["somecloud"].AuthCredentials, jc.DeepEquals, map[string]jujucloud.Credential{
+		"fred": expected,
+	})
+	prompter.CheckCallNames(c, "PromptCredential", "ValidateCredential")
+}
+
+func (s *addCredentialSuite) TestAddCredentialValidateFails(c *gc.C) {
+	prompter := &fakeCredentialPrompter{}
+	prompter.SetErrors(nil, errors.New("authentication failed"))
+	s.registerPrompter(c, prompter)
+	_, err := s.run(c, strings.NewReader("fred\nuser\n"), "somecloud")
+	c.Assert(err, gc.ErrorMatches, "checking credentials: authentication failed")
+	c.Assert(s.store.Credentials, gc.HasLen, 0)
+}
+
+func (s *addCredentialSuite) TestAddCredentialNoValidate(c *gc.C) {
+	prompter := &fakeCredentialPrompter{}
+	s.registerPrompter(c, prompter)
+	_, err := s.run(c, strings.NewReader("fred\nuser\n"), "somecloud", "--no-validate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.Credentials["somecloud"].AuthCredentials, gc.HasLen, 1)
+	prompter.CheckCallNames(c, "PromptCredential")
+}
+
+// fakeCredentialPrompter prompts for a username, and returns a userpass
+// credential with that username.
+type fakeCredentialPrompter struct {
+	gitjujutesting.Stub
+}
+
+func (p *fakeCredentialPrompter) PromptCredential(
+	ctx environs.CredentialPromptContext, authType jujucloud.AuthType,
+) (*jujucloud.Credential, error) {
+	p.MethodCall(p, "PromptCredential", ctx.Cloud, authType)
+	if err := p.NextErr(); err != nil {
+		return nil, err
+	}
+	username, err := ctx.Prompt("Enter username", false)
+	if err != nil {
+		return nil, err
+	}
+	credential := jujucloud.NewCredential(authType, map[string]string{
+		"username": username,
+		"password": "secret",
+	})
+	return &credential, nil
+}
+
+func (p *fakeCredentialPrompter) ValidateCredential(spec environs.CloudSpec) error {
+	p.MethodCall(p, "ValidateCredential", spec)
+	return p.NextErr()
+}
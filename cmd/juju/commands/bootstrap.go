@@ -6,8 +6,10 @@ package commands
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/user"
+	"sort"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -48,6 +50,12 @@ Used without arguments, bootstrap will step you through the process of
 initializing a Juju cloud environment. Initialization consists of creating
 a 'controller' model and provisioning a machine to act as controller.
 
+With '--interactive', bootstrap prompts for the cloud, region, credential,
+controller name and default model name, even when other options such as
+'--config' are also given. Each answer is checked against the clouds and
+credentials known to the client, and the equivalent non-interactive
+command is displayed before bootstrapping.
+
 We recommend you call your controller ‘username-region’ e.g. ‘fred-us-east-1’
 See --clouds for a list of clouds and credentials.
 See --regions <cloud> for a list of available regions for a given cloud.
@@ -93,6 +101,7 @@ used. Otherwise, by default, the version used is that of the client.
 
 Examples:
     juju bootstrap
+    juju bootstrap --interactive --config=~/config-rs.yaml
     juju bootstrap --clouds
     juju bootstrap --regions aws
     juju bootstrap joe-us-east1 google
@@ -179,6 +188,7 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.noGUI, "no-gui", false, "Do not install the Juju GUI in the controller when bootstrapping")
 	f.BoolVar(&c.showClouds, "clouds", false, "Print the available clouds which can be used to bootstrap a Juju environment")
 	f.StringVar(&c.showRegionsForCloud, "regions", "", "Print the available regions for the specified cloud")
+	f.BoolVar(&c.interactive, "interactive", false, "Prompt for the cloud, region, credential and controller name")
 }
 
 func (c *bootstrapCommand) Init(args []string) (err error) {
@@ -197,6 +207,14 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 			// some other flag was set, which means non-interactive.
 		}
 	}
+	if c.interactive {
+		if len(args) > 0 {
+			return errors.New("--interactive cannot be used with a controller or cloud name")
+		}
+		if c.showClouds || c.showRegionsForCloud != "" {
+			return errors.New("--interactive cannot be used with --clouds or --regions")
+		}
+	}
 	if c.showClouds && c.showRegionsForCloud != "" {
 		return errors.New("--clouds and --regions can't be used together")
 	}
@@ -252,6 +270,11 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 		return errors.New("requested agent version major.minor mismatch")
 	}
 
+	if c.interactive {
+		// The controller and cloud names will be prompted for.
+		return nil
+	}
+
 	// The user must specify two positional arguments: the controller name,
 	// and the cloud name (optionally with region specified).
 	if len(args) < 2 {
@@ -764,19 +787,85 @@ func (c *bootstrapCommand) runInteractive(ctx *cmd.Context) error {
 		}
 	}
 
+	store := c.ClientStore()
+	if err := c.interactiveCredential(store, scanner, ctx.Stdout); err != nil {
+		return errors.Trace(err)
+	}
+
 	var username string
 	if u, err := user.Current(); err == nil {
 		username = u.Username
 	}
 	defName := defaultControllerName(username, c.Cloud, c.Region, cloud)
 
-	c.controllerName, err = queryName(defName, scanner, ctx.Stdout)
+	c.controllerName, err = queryName(defName, store, scanner, ctx.Stdout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.hostedModelName, err = queryModelName(c.hostedModelName, scanner, ctx.Stdout)
 	if err != nil {
 		return errors.Trace(err)
 	}
+
+	fmt.Fprintf(ctx.Stdout, "Bootstrapping with the equivalent of:\n    juju bootstrap %s\n\n",
+		strings.Join(c.interactiveArgs(), " "),
+	)
 	return nil
 }
 
+// interactiveCredential checks that the credential specified with
+// --credential exists for the chosen cloud or, if none was specified and
+// there is more than one credential for the cloud, asks the user to choose
+// one. Otherwise the credential is chosen or detected as it would be for a
+// non-interactive bootstrap.
+func (c *bootstrapCommand) interactiveCredential(store jujuclient.CredentialGetter, scanner *bufio.Scanner, w io.Writer) error {
+	credentials, err := store.CredentialForCloud(c.Cloud)
+	if errors.IsNotFound(err) {
+		credentials = &jujucloud.CloudCredential{}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if c.CredentialName != "" {
+		if _, ok := credentials.AuthCredentials[c.CredentialName]; !ok {
+			return errors.Errorf(
+				"credential %q not found for cloud %q, please add it with %q",
+				c.CredentialName, c.Cloud, "juju add-credential "+c.Cloud,
+			)
+		}
+		return nil
+	}
+	if len(credentials.AuthCredentials) < 2 {
+		return nil
+	}
+	credentialNames := make([]string, 0, len(credentials.AuthCredentials))
+	for name := range credentials.AuthCredentials {
+		credentialNames = append(credentialNames, name)
+	}
+	sort.Strings(credentialNames)
+	c.CredentialName, err = queryCredential(c.Cloud, credentialNames, credentials.DefaultCredential, scanner, w)
+	return errors.Trace(err)
+}
+
+// interactiveArgs returns the arguments to a non-interactive bootstrap
+// command equivalent to the choices made interactively.
+func (c *bootstrapCommand) interactiveArgs() []string {
+	var args []string
+	if c.BuildAgent {
+		args = append(args, "--build-agent")
+	}
+	if c.CredentialName != "" {
+		args = append(args, "--credential", c.CredentialName)
+	}
+	if c.hostedModelName != defaultHostedModelName {
+		args = append(args, "--default-model", c.hostedModelName)
+	}
+	cloud := c.Cloud
+	if c.Region != "" {
+		cloud += "/" + c.Region
+	}
+	return append(args, c.controllerName, cloud)
+}
+
 // getRegion returns the cloud.Region to use, based on the specified
 // region name.  If no region name is specified, and there is at least
 // one region, we use the first region in the list.
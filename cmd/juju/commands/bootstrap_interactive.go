@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"io"
 	"sort"
-	"strings"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/juju/interact"
+	"github.com/juju/juju/jujuclient"
 )
 
 // assembleClouds
@@ -33,62 +35,52 @@ func assembleClouds() ([]string, error) {
 
 // queryCloud asks the user to choose a cloud.
 func queryCloud(clouds []string, defCloud string, scanner *bufio.Scanner, w io.Writer) (string, error) {
-	list := strings.Join(clouds, "\n")
-	if _, err := fmt.Fprint(w, "Clouds\n", list, "\n\n"); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// add support for a default (empty) selection.
-	clouds = append(clouds, "")
-
-	verify := interact.MatchOptions(clouds, errors.Errorf("Invalid cloud."))
-
-	query := fmt.Sprintf("Select a cloud [%s]: ", defCloud)
-	cloud, err := interact.QueryVerify([]byte(query), scanner, w, verify)
+	cloud, err := interact.Select(interact.Selection{
+		Heading:  "Clouds",
+		Options:  clouds,
+		Question: "Select a cloud",
+		Default:  defCloud,
+		Invalid:  errors.Errorf("Invalid cloud."),
+	}, scanner, w)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-	if cloud == "" {
-		return defCloud, nil
-	}
-
-	cloudName, ok := interact.FindMatch(cloud, clouds)
-	if !ok {
-		// should be impossible
-		return "", errors.Errorf("invalid cloud name chosen: %s", cloud)
-	}
-
-	return cloudName, nil
+	return cloud, nil
 }
 
 // queryRegion asks the user to pick a region of the ones passed in. The first
 // region in the list will be the default.
 func queryRegion(cloud string, regions []jujucloud.Region, scanner *bufio.Scanner, w io.Writer) (string, error) {
-	fmt.Fprintf(w, "Regions in %s:\n", cloud)
-	names := jujucloud.RegionNames(regions)
-	// add an empty string to allow for a default value. Also gives us an extra
-	// line return after the list of names.
-	names = append(names, "")
-	if _, err := fmt.Fprintln(w, strings.Join(names, "\n")); err != nil {
-		return "", errors.Trace(err)
-	}
-	verify := interact.MatchOptions(names, errors.Errorf("Invalid region."))
-	defaultRegion := regions[0].Name
-	query := fmt.Sprintf("Select a region in %s [%s]: ", cloud, defaultRegion)
-	region, err := interact.QueryVerify([]byte(query), scanner, w, verify)
+	region, err := interact.Select(interact.Selection{
+		Heading:  fmt.Sprintf("Regions in %s:", cloud),
+		Options:  jujucloud.RegionNames(regions),
+		Question: fmt.Sprintf("Select a region in %s", cloud),
+		Default:  regions[0].Name,
+		Invalid:  errors.Errorf("Invalid region."),
+	}, scanner, w)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-	if region == "" {
-		return defaultRegion, nil
+	return region, nil
+}
+
+// queryCredential asks the user to pick one of the named credentials. The
+// default credential, if any, is used when no choice is made.
+func queryCredential(cloud string, credentials []string, defCredential string, scanner *bufio.Scanner, w io.Writer) (string, error) {
+	if defCredential == "" {
+		defCredential = credentials[0]
 	}
-	regionName, ok := interact.FindMatch(region, names)
-	if !ok {
-		// should be impossible
-		return "", errors.Errorf("invalid region name chosen: %s", region)
+	credential, err := interact.Select(interact.Selection{
+		Heading:  fmt.Sprintf("Credentials for %s:", cloud),
+		Options:  credentials,
+		Question: "Select a credential",
+		Default:  defCredential,
+		Invalid:  errors.Errorf("Invalid credential."),
+	}, scanner, w)
+	if err != nil {
+		return "", errors.Trace(err)
 	}
-
-	return regionName, nil
+	return credential, nil
 }
 
 func defaultControllerName(username, cloudname, region string, cloud *jujucloud.Cloud) string {
@@ -102,14 +94,38 @@ func defaultControllerName(username, cloudname, region string, cloud *jujucloud.
 	return username + "-" + name
 }
 
-func queryName(defName string, scanner *bufio.Scanner, w io.Writer) (string, error) {
-	query := fmt.Sprintf("Enter a name for the Controller [%s]: ", defName)
-	name, err := interact.QueryVerify([]byte(query), scanner, w, nil)
+// queryName asks the user for the name of the new controller. The name must
+// be valid and must not already be used by a controller known to the store.
+func queryName(defName string, store jujuclient.ControllerGetter, scanner *bufio.Scanner, w io.Writer) (string, error) {
+	verify := func(name string) error {
+		if !names.IsValidControllerName(name) {
+			return errors.Errorf("%q is not a valid controller name.", name)
+		}
+		if _, err := store.ControllerByName(name); err == nil {
+			return errors.Errorf("A controller called %q already exists.", name)
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+	name, err := interact.QueryDefault("Enter a name for the Controller", defName, scanner, w, verify)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-	if name == "" {
-		return defName, nil
+	return name, nil
+}
+
+// queryModelName asks the user for the name of the default hosted model.
+func queryModelName(defName string, scanner *bufio.Scanner, w io.Writer) (string, error) {
+	verify := func(name string) error {
+		if !names.IsValidModelName(name) {
+			return errors.Errorf("%q is not a valid model name.", name)
+		}
+		return nil
+	}
+	name, err := interact.QueryDefault("Enter a name for the default model", defName, scanner, w, verify)
+	if err != nil {
+		return "", errors.Trace(err)
 	}
 	return name, nil
 }
@@ -14,6 +14,8 @@ import (
 	gc "gopkg.in/check.v1"
 
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	jujutesting "github.com/juju/juju/testing"
 )
 
@@ -59,6 +61,25 @@ func (BSInteractSuite) TestInitOtherFlag(c *gc.C) {
 	c.Assert(cmd.interactive, jc.IsFalse)
 }
 
+func (BSInteractSuite) TestInitInteractiveFlag(c *gc.C) {
+	cmd := &bootstrapCommand{}
+	err := jujutesting.InitCommand(cmd, []string{"--interactive", "--config", "foo=bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.interactive, jc.IsTrue)
+}
+
+func (BSInteractSuite) TestInitInteractiveFlagWithArgs(c *gc.C) {
+	cmd := &bootstrapCommand{}
+	err := jujutesting.InitCommand(cmd, []string{"--interactive", "foo", "bar"})
+	c.Assert(err, gc.ErrorMatches, "--interactive cannot be used with a controller or cloud name")
+}
+
+func (BSInteractSuite) TestInitInteractiveFlagWithClouds(c *gc.C) {
+	cmd := &bootstrapCommand{}
+	err := jujutesting.InitCommand(cmd, []string{"--interactive", "--clouds"})
+	c.Assert(err, gc.ErrorMatches, "--interactive cannot be used with --clouds or --regions")
+}
+
 func (BSInteractSuite) TestQueryCloud(c *gc.C) {
 	input := "search\n"
 
@@ -141,7 +162,7 @@ func (BSInteractSuite) TestQueryName(c *gc.C) {
 
 	scanner := bufio.NewScanner(strings.NewReader(input))
 	buf := bytes.Buffer{}
-	name, err := queryName("default-cloud", scanner, &buf)
+	name, err := queryName("default-cloud", jujuclienttesting.NewMemStore(), scanner, &buf)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(name, gc.Equals, "awesome-cloud")
 
@@ -155,7 +176,110 @@ func (BSInteractSuite) TestQueryNameDefault(c *gc.C) {
 	input := "\n"
 
 	scanner := bufio.NewScanner(strings.NewReader(input))
-	name, err := queryName("default-cloud", scanner, ioutil.Discard)
+	name, err := queryName("default-cloud", jujuclienttesting.NewMemStore(), scanner, ioutil.Discard)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(name, gc.Equals, "default-cloud")
 }
+
+func (BSInteractSuite) TestQueryNameInvalid(c *gc.C) {
+	input := "bad name\nexisting\nnew\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	store := jujuclienttesting.NewMemStore()
+	store.Controllers["existing"] = jujuclient.ControllerDetails{}
+	buf := bytes.Buffer{}
+	name, err := queryName("default-cloud", store, scanner, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "new")
+
+	expected := `
+Enter a name for the Controller [default-cloud]: "bad name" is not a valid controller name.
+
+Enter a name for the Controller [default-cloud]: A controller called "existing" already exists.
+
+Enter a name for the Controller [default-cloud]: 
+`[1:]
+	c.Assert(buf.String(), gc.Equals, expected)
+}
+
+func (BSInteractSuite) TestQueryModelName(c *gc.C) {
+	input := "Bad\nmy-model\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	buf := bytes.Buffer{}
+	name, err := queryModelName("default", scanner, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "my-model")
+
+	expected := `
+Enter a name for the default model [default]: "Bad" is not a valid model name.
+
+Enter a name for the default model [default]: 
+`[1:]
+	c.Assert(buf.String(), gc.Equals, expected)
+}
+
+func (BSInteractSuite) TestQueryCredential(c *gc.C) {
+	input := "\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	buf := bytes.Buffer{}
+	credential, err := queryCredential("goggles", []string{"alice", "bob"}, "bob", scanner, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, gc.Equals, "bob")
+
+	expected := `
+Credentials for goggles:
+alice
+bob
+
+Select a credential [bob]: 
+`[1:]
+	c.Assert(buf.String(), gc.Equals, expected)
+}
+
+func (BSInteractSuite) TestQueryCredentialNoDefault(c *gc.C) {
+	input := "\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	credential, err := queryCredential("goggles", []string{"alice", "bob"}, "", scanner, ioutil.Discard)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, gc.Equals, "alice")
+}
+
+func (BSInteractSuite) TestInteractiveCredential(c *gc.C) {
+	store := jujuclienttesting.NewMemStore()
+	store.Credentials["goggles"] = jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"bob":   jujucloud.NewEmptyCredential(),
+			"alice": jujucloud.NewEmptyCredential(),
+		},
+	}
+	cmd := &bootstrapCommand{Cloud: "goggles", hostedModelName: defaultHostedModelName}
+	scanner := bufio.NewScanner(strings.NewReader("bob\n"))
+	err := cmd.interactiveCredential(store, scanner, ioutil.Discard)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.CredentialName, gc.Equals, "bob")
+	c.Assert(cmd.interactiveArgs(), jc.DeepEquals, []string{"--credential", "bob", "", "goggles"})
+}
+
+func (BSInteractSuite) TestInteractiveCredentialSingle(c *gc.C) {
+	store := jujuclienttesting.NewMemStore()
+	store.Credentials["goggles"] = jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"bob": jujucloud.NewEmptyCredential(),
+		},
+	}
+	cmd := &bootstrapCommand{Cloud: "goggles"}
+	scanner := bufio.NewScanner(strings.NewReader(""))
+	err := cmd.interactiveCredential(store, scanner, ioutil.Discard)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.CredentialName, gc.Equals, "")
+}
+
+func (BSInteractSuite) TestInteractiveCredentialNotFound(c *gc.C) {
+	cmd := &bootstrapCommand{Cloud: "goggles", CredentialName: "bob"}
+	scanner := bufio.NewScanner(strings.NewReader(""))
+	err := cmd.interactiveCredential(jujuclienttesting.NewMemStore(), scanner, ioutil.Discard)
+	c.Assert(err, gc.ErrorMatches, `credential "bob" not found for cloud "goggles", please add it with "juju add-credential goggles"`)
+}
@@ -822,6 +822,7 @@ func (s *BootstrapSuite) TestInteractiveBootstrap(c *gc.C) {
 dummy-cloud
 region-1
 my-dummy-cloud
+my-model
 `[1:])
 	ctx.Stdout = &out
 	err = cmd.Run(ctx)
@@ -835,6 +836,13 @@ my-dummy-cloud
 	controller := s.store.Controllers[name]
 	c.Assert(controller.Cloud, gc.Equals, "dummy-cloud")
 	c.Assert(controller.CloudRegion, gc.Equals, "region-1")
+	modelName, err := s.store.CurrentModel(name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelName, gc.Equals, "admin@local/my-model")
+	c.Assert(out.String(), jc.Contains, `
+Bootstrapping with the equivalent of:
+    juju bootstrap --default-model my-model my-dummy-cloud dummy-cloud/region-1
+`)
 }
 
 func (s *BootstrapSuite) setupAutoUploadTest(c *gc.C, vers, ser string) {
@@ -0,0 +1,365 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageCompletionSummary = `
+Generates a shell completion script for juju.`[1:]
+
+var usageCompletionDetails = `
+Writes a completion script for the given shell, either bash or zsh, to
+stdout. The script completes command names and flags, and the names of
+controllers, models and applications where commands expect them.
+
+Controller and model names are read from the local client store;
+application names are read from the current model. The script obtains them
+by running "juju completion --list <kind>", where kind is one of
+"controllers", "models" or "applications".
+
+Examples:
+Enable completion in the current bash session:
+
+    source <(juju completion bash)
+
+Install completion for zsh:
+
+    juju completion zsh > ~/.zsh/completion/_juju
+
+See also:
+    help`
+
+// completionShells lists the shells for which a completion script
+// can be generated.
+var completionShells = []string{"bash", "zsh"}
+
+// completionLists lists the kinds of name which may be listed with
+// --list.
+var completionLists = []string{"applications", "controllers", "models"}
+
+func newCompletionCommand() cmd.Command {
+	return modelcmd.Wrap(&completionCommand{})
+}
+
+// completionCommand writes shell completion scripts, and lists the
+// names those scripts complete.
+type completionCommand struct {
+	modelcmd.ModelCommandBase
+	shell string
+	list  string
+	api   completionAPI
+
+	// registerCommands registers the commands to be completed. It
+	// is a field so that it can be replaced in tests.
+	registerCommands func(commandRegistry)
+}
+
+// completionAPI defines the API methods used to list application names.
+type completionAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+func (c *completionCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "completion",
+		Args:    "bash|zsh",
+		Purpose: usageCompletionSummary,
+		Doc:     usageCompletionDetails,
+	}
+}
+
+func (c *completionCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.list, "list", "", "List the names of controllers, models or applications")
+}
+
+func (c *completionCommand) Init(args []string) error {
+	if c.list != "" {
+		if !stringIn(c.list, completionLists) {
+			return errors.NotValidf("list %q, expected one of %s,", c.list, strings.Join(completionLists, ", "))
+		}
+		return cmd.CheckEmpty(args)
+	}
+	if len(args) == 0 {
+		return errors.New("no shell specified")
+	}
+	c.shell, args = args[0], args[1:]
+	if !stringIn(c.shell, completionShells) {
+		return errors.NotValidf("shell %q, expected one of %s,", c.shell, strings.Join(completionShells, ", "))
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func stringIn(s string, values []string) bool {
+	for _, value := range values {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *completionCommand) Run(ctx *cmd.Context) error {
+	switch c.list {
+	case "controllers":
+		return c.listControllers(ctx)
+	case "models":
+		return c.listModels(ctx)
+	case "applications":
+		return c.listApplications(ctx)
+	}
+	register := c.registerCommands
+	if register == nil {
+		register = func(r commandRegistry) { registerCommands(r, ctx) }
+	}
+	// The help and version commands are provided by the supercommand
+	// itself, rather than registered.
+	registry := &completionRegistry{
+		completed: []completedCommand{
+			{names: []string{"help"}},
+			{names: []string{"version"}},
+		},
+	}
+	register(registry)
+	if c.shell == "zsh" {
+		// zsh can use bash completion scripts once bashcompinit
+		// has been loaded.
+		fmt.Fprintln(ctx.Stdout, "#compdef juju")
+		fmt.Fprintln(ctx.Stdout, "autoload -U +X bashcompinit && bashcompinit")
+	}
+	return writeBashCompletion(ctx.Stdout, registry.commands())
+}
+
+func (c *completionCommand) listControllers(ctx *cmd.Context) error {
+	controllers, err := c.ClientStore().AllControllers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	names := make([]string, 0, len(controllers))
+	for name := range controllers {
+		names = append(names, name)
+	}
+	return writeNames(ctx.Stdout, names)
+}
+
+// listModels lists the models of every controller qualified by the
+// controller name, and the models of the current controller without.
+func (c *completionCommand) listModels(ctx *cmd.Context) error {
+	store := c.ClientStore()
+	controllers, err := store.AllControllers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var names []string
+	for controllerName := range controllers {
+		models, err := store.AllModels(controllerName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		for modelName := range models {
+			names = append(names, modelcmd.JoinModelName(controllerName, modelName))
+			if controllerName == c.ControllerName() {
+				names = append(names, modelName)
+			}
+		}
+	}
+	return writeNames(ctx.Stdout, names)
+}
+
+func (c *completionCommand) listApplications(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	status, err := client.Status(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	names := make([]string, 0, len(status.Applications))
+	for name := range status.Applications {
+		names = append(names, name)
+	}
+	return writeNames(ctx.Stdout, names)
+}
+
+func (c *completionCommand) getAPI() (completionAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+func writeNames(w io.Writer, names []string) error {
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// completedCommand holds what is completed for a single command.
+type completedCommand struct {
+	names []string
+	flags []string
+
+	// args is the kind of name expected as the first argument of
+	// the command, if any.
+	args string
+}
+
+// completionRegistry implements commandRegistry, recording the
+// commands to be completed.
+type completionRegistry struct {
+	completed []completedCommand
+}
+
+func (r *completionRegistry) Register(subcmd cmd.Command) {
+	info := subcmd.Info()
+	f := gnuflag.NewFlagSet(info.Name, gnuflag.ContinueOnError)
+	subcmd.SetFlags(f)
+	var flags []string
+	f.VisitAll(func(flag *gnuflag.Flag) {
+		if len(flag.Name) == 1 {
+			flags = append(flags, "-"+flag.Name)
+		} else {
+			flags = append(flags, "--"+flag.Name)
+		}
+	})
+	sort.Strings(flags)
+	r.completed = append(r.completed, completedCommand{
+		names: append([]string{info.Name}, info.Aliases...),
+		flags: flags,
+		args:  completedArgs(info.Args),
+	})
+}
+
+func (r *completionRegistry) RegisterSuperAlias(name, super, forName string, check cmd.DeprecationCheck) {
+	if check != nil && check.Obsolete() {
+		return
+	}
+	r.completed = append(r.completed, completedCommand{names: []string{name}})
+}
+
+func (r *completionRegistry) RegisterDeprecated(subcmd cmd.Command, check cmd.DeprecationCheck) {
+	if check != nil && check.Obsolete() {
+		return
+	}
+	r.Register(subcmd)
+}
+
+// commands returns the recorded commands, sorted by name.
+func (r *completionRegistry) commands() []completedCommand {
+	sort.Sort(completedCommandsByName(r.completed))
+	return r.completed
+}
+
+type completedCommandsByName []completedCommand
+
+func (c completedCommandsByName) Len() int           { return len(c) }
+func (c completedCommandsByName) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c completedCommandsByName) Less(i, j int) bool { return c[i].names[0] < c[j].names[0] }
+
+// completedArgs returns the kind of name a command expects as its first
+// argument, judging by its documented arguments.
+func completedArgs(args string) string {
+	first := strings.Fields(args + " ")
+	if len(first) == 0 {
+		return ""
+	}
+	switch arg := strings.Trim(first[0], "[]<>"); {
+	case strings.HasPrefix(arg, "application"):
+		return "applications"
+	case strings.HasPrefix(arg, "controller"):
+		return "controllers"
+	case strings.HasPrefix(arg, "model"):
+		return "models"
+	}
+	return ""
+}
+
+const bashCompletionHeader = `# juju completion script, generated by "juju completion".
+
+_juju_list() {
+    juju completion --list "$1" 2>/dev/null
+}
+
+_juju() {
+    local cur prev command flags args
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    command="${COMP_WORDS[1]}"
+
+    case "$prev" in
+    -m|--model)
+        COMPREPLY=($(compgen -W "$(_juju_list models)" -- "$cur"))
+        return 0
+        ;;
+    -c|--controller)
+        COMPREPLY=($(compgen -W "$(_juju_list controllers)" -- "$cur"))
+        return 0
+        ;;
+    esac
+
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=($(compgen -W "$_juju_commands" -- "$cur"))
+        return 0
+    fi
+
+    case "$command" in
+`
+
+const bashCompletionFooter = `    esac
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+    elif [ -n "$args" ] && [ "$COMP_CWORD" -eq 2 ]; then
+        COMPREPLY=($(compgen -W "$(_juju_list $args)" -- "$cur"))
+    fi
+    return 0
+}
+
+complete -F _juju juju
+`
+
+// writeBashCompletion writes a bash completion script for the given
+// commands.
+func writeBashCompletion(w io.Writer, commands []completedCommand) error {
+	var names []string
+	for _, command := range commands {
+		names = append(names, command.names...)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(w, "_juju_commands=%q\n\n", strings.Join(names, " "))
+	fmt.Fprint(w, bashCompletionHeader)
+	for _, command := range commands {
+		if len(command.flags) == 0 && command.args == "" {
+			continue
+		}
+		fmt.Fprintf(w, "    %s)\n", strings.Join(command.names, "|"))
+		fmt.Fprintf(w, "        flags=%q\n", strings.Join(command.flags, " "))
+		if command.args != "" {
+			fmt.Fprintf(w, "        args=%s\n", command.args)
+		}
+		fmt.Fprintf(w, "        ;;\n")
+	}
+	_, err := fmt.Fprint(w, bashCompletionFooter)
+	return errors.Trace(err)
+}
@@ -0,0 +1,242 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type CompletionSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclienttesting.MemStore
+	api   *fakeCompletionAPI
+}
+
+var _ = gc.Suite(&CompletionSuite{})
+
+func (s *CompletionSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["kontroll"] = jujuclient.ControllerDetails{}
+	s.store.Controllers["other"] = jujuclient.ControllerDetails{}
+	s.store.CurrentControllerName = "kontroll"
+	s.store.Models["kontroll"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"default": {"deadbeef"},
+			"staging": {"cafebabe"},
+		},
+		CurrentModel: "default",
+	}
+	s.store.Models["other"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"prod": {"feedface"},
+		},
+	}
+	s.api = &fakeCompletionAPI{
+		status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"wordpress": {},
+				"mysql":     {},
+			},
+		},
+	}
+}
+
+func (s *CompletionSuite) run(c *gc.C, register func(commandRegistry), args ...string) (string, error) {
+	command := &completionCommand{
+		api:              s.api,
+		registerCommands: register,
+	}
+	command.SetClientStore(s.store)
+	ctx, err := testing.RunCommand(c, modelcmd.Wrap(command), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stdout(ctx), nil
+}
+
+func (s *CompletionSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		errMatch: "no shell specified",
+	}, {
+		args:     []string{"fish"},
+		errMatch: `shell "fish", expected one of bash, zsh, not valid`,
+	}, {
+		args:     []string{"bash", "zsh"},
+		errMatch: `unrecognized args: \["zsh"\]`,
+	}, {
+		args:     []string{"--list", "units"},
+		errMatch: `list "units", expected one of applications, controllers, models, not valid`,
+	}, {
+		args:     []string{"--list", "models", "bash"},
+		errMatch: `unrecognized args: \["bash"\]`,
+	}, {
+		args: []string{"bash"},
+	}, {
+		args: []string{"--list", "controllers"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(modelcmd.Wrap(&completionCommand{}), test.args)
+		if test.errMatch != "" {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *CompletionSuite) TestListControllers(c *gc.C) {
+	out, err := s.run(c, nil, "--list", "controllers")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "kontroll\nother\n")
+}
+
+func (s *CompletionSuite) TestListModels(c *gc.C) {
+	out, err := s.run(c, nil, "--list", "models")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+default
+kontroll:default
+kontroll:staging
+other:prod
+staging
+`[1:])
+}
+
+func (s *CompletionSuite) TestListApplications(c *gc.C) {
+	out, err := s.run(c, nil, "--list", "applications")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "mysql\nwordpress\n")
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *CompletionSuite) TestListApplicationsError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.run(c, nil, "--list", "applications")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func registerCompletionTestCommands(r commandRegistry) {
+	r.Register(&completionTestCommand{
+		info: cmd.Info{Name: "expose", Args: "<application name>"},
+	})
+	r.Register(&completionTestCommand{
+		info:  cmd.Info{Name: "list-models", Aliases: []string{"models"}},
+		flags: []string{"format", "o"},
+	})
+	r.RegisterSuperAlias("remove-model", "model", "destroy", nil)
+	r.RegisterDeprecated(&completionTestCommand{
+		info: cmd.Info{Name: "destroy-environment"},
+	}, obsoleteCheck{})
+}
+
+func (s *CompletionSuite) TestBash(c *gc.C) {
+	out, err := s.run(c, registerCompletionTestCommands, "bash")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.HasPrefix, `_juju_commands="expose help list-models models remove-model version"`+"\n")
+	c.Assert(out, jc.Contains, `
+    expose)
+        flags=""
+        args=applications
+        ;;
+    list-models|models)
+        flags="--format -o"
+        ;;
+    esac
+`)
+	c.Assert(out, gc.Not(jc.Contains), "destroy-environment")
+	c.Assert(out, jc.HasSuffix, "complete -F _juju juju\n")
+}
+
+func (s *CompletionSuite) TestZsh(c *gc.C) {
+	out, err := s.run(c, registerCompletionTestCommands, "zsh")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.HasPrefix, "#compdef juju\nautoload -U +X bashcompinit && bashcompinit\n_juju_commands=")
+	c.Assert(out, jc.HasSuffix, "complete -F _juju juju\n")
+}
+
+func (s *CompletionSuite) TestBashRegisteredCommands(c *gc.C) {
+	out, err := s.run(c, nil, "bash")
+	c.Assert(err, jc.ErrorIsNil)
+	firstLine := strings.SplitN(out, "\n", 2)[0]
+	firstLine = strings.TrimPrefix(firstLine, `_juju_commands="`)
+	names := set.NewStrings(strings.Fields(strings.TrimSuffix(firstLine, `"`))...)
+	for _, name := range []string{"bootstrap", "completion", "deploy", "help", "status"} {
+		c.Check(names.Contains(name), jc.IsTrue, gc.Commentf("command %q", name))
+	}
+	c.Assert(out, jc.Contains, "    expose)\n")
+}
+
+func (s *CompletionSuite) TestCompletedArgs(c *gc.C) {
+	for args, expected := range map[string]string{
+		"":                              "",
+		"<application name>":            "applications",
+		"[<controller name>]":           "controllers",
+		"<model name> [<controller>]":   "models",
+		"<unit or machine> <key=value>": "",
+	} {
+		c.Check(completedArgs(args), gc.Equals, expected, gc.Commentf("args %q", args))
+	}
+}
+
+type completionTestCommand struct {
+	cmd.CommandBase
+	info  cmd.Info
+	flags []string
+}
+
+func (c *completionTestCommand) Info() *cmd.Info {
+	return &c.info
+}
+
+func (c *completionTestCommand) SetFlags(f *gnuflag.FlagSet) {
+	for _, name := range c.flags {
+		f.String(name, "", "")
+	}
+}
+
+func (c *completionTestCommand) Run(*cmd.Context) error {
+	return nil
+}
+
+type obsoleteCheck struct{}
+
+func (obsoleteCheck) Deprecated() (bool, string) {
+	return true, ""
+}
+
+func (obsoleteCheck) Obsolete() bool {
+	return true
+}
+
+type fakeCompletionAPI struct {
+	status *params.FullStatus
+	err    error
+	closed bool
+}
+
+func (f *fakeCompletionAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return f.status, f.err
+}
+
+func (f *fakeCompletionAPI) Close() error {
+	f.closed = true
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
 
@@ -43,6 +44,21 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--from' and '--to' options restrict the messages shown to those logged
+within a time range. Times may be given in RFC 3339 format (for example,
+2016-08-01T10:00:00+01:00), or in the format of the log timestamps, as
+"YYYY-MM-DD HH:MM:SS" or "YYYY-MM-DD", in which case they are taken to be
+in UTC. The range includes the '--from' time, but not the '--to' time. The
+'--from' option implies '--replay', and the '--to' option implies
+'--no-tail'.
+
+The '--offset' option skips over the given number of the most recent
+(possibly filtered) messages, so that earlier messages may be paged
+through with '--lines'. It implies '--no-tail'.
+
+All filtering is performed by the controller, so only the messages which
+are shown are downloaded.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
@@ -88,6 +104,15 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all messages logged between 10:00 and 11:00 UTC on 1 August 2016:
+
+    juju debug-log --from "2016-08-01 10:00:00" --to "2016-08-01 11:00:00"
+
+Show the 100 messages preceding the 100 most recent messages, and then
+exit:
+
+    juju debug-log --lines 100 --offset 100
+
 See also: 
     status
     ssh`
@@ -108,6 +133,8 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	from   string
+	to     string
 	params api.DebugLogParams
 }
 
@@ -124,7 +151,10 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	f.UintVar(&c.params.Backlog, "n", defaultLineCount, "Show this many of the most recent (possibly filtered) lines, and continue to append")
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
+	f.UintVar(&c.params.Offset, "offset", 0, "Skip over this many of the most recent (possibly filtered) lines, and then exit")
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
+	f.StringVar(&c.from, "from", "", "Only show log messages logged at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show log messages logged before this time, and then exit")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
 	f.BoolVar(&c.params.NoTail, "T", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.params.NoTail, "no-tail", false, "")
@@ -139,9 +169,57 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	if c.from != "" {
+		from, err := parseLogTime(c.from)
+		if err != nil {
+			return errors.Annotate(err, "invalid --from value")
+		}
+		c.params.StartTime = from
+		c.params.Replay = true
+	}
+	if c.to != "" {
+		to, err := parseLogTime(c.to)
+		if err != nil {
+			return errors.Annotate(err, "invalid --to value")
+		}
+		if !c.params.StartTime.IsZero() && !to.After(c.params.StartTime) {
+			return errors.New("--to time must be after --from time")
+		}
+		c.params.EndTime = to
+		c.params.NoTail = true
+	}
+	if c.params.Offset > 0 {
+		c.params.NoTail = true
+	}
 	return cmd.CheckEmpty(args)
 }
 
+// logTimeFormats holds the formats, other than RFC 3339, in which the
+// bounds of a time range may be specified. Times in these formats are
+// taken to be in UTC, as are the timestamps of the log output.
+var logTimeFormats = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseLogTime parses the bound of a time range given on the command
+// line.
+func parseLogTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	for _, format := range logTimeFormats {
+		if t, err := time.Parse(format, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf(
+		"%q is not a time in RFC 3339, %q or %q format",
+		value, "YYYY-MM-DD HH:MM:SS", "YYYY-MM-DD",
+	)
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params api.DebugLogParams) (<-chan api.LogMessage, error)
 	Close() error
@@ -4,6 +4,8 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -87,6 +89,45 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--offset", "100"},
+			expected: api.DebugLogParams{
+				Backlog: 10,
+				Offset:  100,
+				NoTail:  true,
+			},
+		}, {
+			args: []string{"--from", "2016-08-01T10:00:00+01:00"},
+			expected: api.DebugLogParams{
+				Backlog:   10,
+				StartTime: time.Date(2016, 8, 1, 9, 0, 0, 0, time.UTC),
+				Replay:    true,
+			},
+		}, {
+			args: []string{"--from", "2016-08-01 10:00:00", "--to", "2016-08-02"},
+			expected: api.DebugLogParams{
+				Backlog:   10,
+				StartTime: time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2016, 8, 2, 0, 0, 0, 0, time.UTC),
+				Replay:    true,
+				NoTail:    true,
+			},
+		}, {
+			args: []string{"--to", "2016-08-01T10:00:00"},
+			expected: api.DebugLogParams{
+				Backlog: 10,
+				EndTime: time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC),
+				NoTail:  true,
+			},
+		}, {
+			args:     []string{"--from", "yesterday"},
+			errMatch: `invalid --from value: "yesterday" is not a time in RFC 3339, "YYYY-MM-DD HH:MM:SS" or "YYYY-MM-DD" format`,
+		}, {
+			args:     []string{"--to", "10:00"},
+			errMatch: `invalid --to value: "10:00" is not a time in RFC 3339, "YYYY-MM-DD HH:MM:SS" or "YYYY-MM-DD" format`,
+		}, {
+			args:     []string{"--from", "2016-08-02", "--to", "2016-08-01"},
+			errMatch: `--to time must be after --from time`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -94,7 +135,12 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 		err := testing.InitCommand(modelcmd.Wrap(command), test.args)
 		if test.errMatch == "" {
 			c.Check(err, jc.ErrorIsNil)
-			c.Check(command.params, jc.DeepEquals, test.expected)
+			c.Check(command.params.StartTime.Equal(test.expected.StartTime), jc.IsTrue)
+			c.Check(command.params.EndTime.Equal(test.expected.EndTime), jc.IsTrue)
+			params := command.params
+			params.StartTime, params.EndTime = time.Time{}, time.Time{}
+			test.expected.StartTime, test.expected.EndTime = time.Time{}, time.Time{}
+			c.Check(params, jc.DeepEquals, test.expected)
 		} else {
 			c.Check(err, gc.ErrorMatches, test.errMatch)
 		}
@@ -85,6 +85,12 @@ Examples:
     # server2 used first, and if necessary, newly created controller
     # machines having at least 8GB RAM.
     juju enable-ha -n 7 --to server1,server2 --constraints mem=8G
+
+The number of controllers cannot be reduced with enable-ha; use
+"juju remove-controller-machine" to take controllers out of the cluster.
+
+See also:
+    remove-controller-machine
 `
 
 // formatSimple marshals value to a yaml-formatted []byte, unless value is nil.
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	rcmd "github.com/juju/romulus/cmd/commands"
 	"github.com/juju/utils/featureflag"
@@ -17,8 +20,10 @@ import (
 	"github.com/juju/utils/series"
 	"github.com/juju/version"
 
+	"github.com/juju/juju/api"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/cmd/juju/alerts"
 	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/cmd/juju/block"
@@ -26,10 +31,14 @@ import (
 	"github.com/juju/juju/cmd/juju/charmcmd"
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/cmd/juju/firewall"
 	"github.com/juju/juju/cmd/juju/gui"
+	"github.com/juju/juju/cmd/juju/helptopics"
+	"github.com/juju/juju/cmd/juju/imagemetadata"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
 	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/scheduledoperations"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
@@ -61,6 +70,12 @@ juju provides easy, intelligent application orchestration on top of cloud
 infrastructure providers such as Amazon EC2, HP Cloud, MaaS, OpenStack, Windows
 Azure, or your local machine.
 
+If the JUJU_RECORD_OPERATIONS environment variable is set to true, each juju
+command run is appended to the operations.log file in the juju data
+directory, along with the API calls it made and its exit status. The log is
+written as a shell script, so the recorded commands can be replayed with a
+shell; the API calls are recorded as comments, without their arguments.
+
 https://juju.ubuntu.com/
 `
 
@@ -154,7 +169,56 @@ func (m main) Run(args []string) int {
 	}
 
 	jcmd := NewJujuCommand(ctx)
-	return cmd.Main(jcmd, ctx, args[1:])
+	record, err := recordOperations()
+	if err != nil {
+		fmt.Fprintf(ctx.Stderr, "error: %v\n", err)
+		return 2
+	}
+	if !record {
+		return cmd.Main(jcmd, ctx, args[1:])
+	}
+	operationLog := jujuclient.NewOperationLog(
+		jujuclient.JujuOperationLogPath(), ctx.Dir, args[1:], time.Now(),
+	)
+	modelcmd.SetAPICallObserver(func(call api.CallRecord) {
+		operationLog.AddCall(operationCall(call))
+	})
+	defer modelcmd.SetAPICallObserver(nil)
+	code := cmd.Main(jcmd, ctx, args[1:])
+	if err := operationLog.Write(code); err != nil {
+		fmt.Fprintf(ctx.Stderr, "cannot record operation: %v\n", err)
+	}
+	return code
+}
+
+// recordOperations reports whether commands should be recorded in the
+// operation log.
+func recordOperations() (bool, error) {
+	value := os.Getenv(osenv.JujuRecordOperationsEnvKey)
+	if value == "" {
+		return false, nil
+	}
+	record, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.Errorf("invalid %s env var, expected true|false", osenv.JujuRecordOperationsEnvKey)
+	}
+	return record, nil
+}
+
+// operationCall converts an API call record to its form in the
+// operation log.
+func operationCall(call api.CallRecord) jujuclient.OperationCall {
+	result := jujuclient.OperationCall{
+		Facade:    call.Facade,
+		Version:   call.Version,
+		Id:        call.Id,
+		Method:    call.Method,
+		ModelUUID: call.ModelTag.Id(),
+	}
+	if call.Err != nil {
+		result.Error = call.Err.Error()
+	}
+	return result
 }
 
 func (m main) maybeWarnJuju1x() (newInstall bool) {
@@ -208,13 +272,17 @@ func juju2xConfigDataExists() bool {
 
 // NewJujuCommand ...
 func NewJujuCommand(ctx *cmd.Context) cmd.Command {
+	topics := helptopics.NewTopics()
 	jcmd := jujucmd.NewSuperCommand(cmd.SuperCommandParams{
 		Name:                "juju",
 		Doc:                 jujuDoc,
 		MissingCallback:     RunPlugin,
 		UserAliasesFilename: osenv.JujuXDGDataHomePath("aliases"),
+		NotifyHelp:          topics.NotifyHelp,
 	})
 	jcmd.AddHelpTopic("basics", "Basic Help Summary", usageHelp)
+	jcmd.AddHelpTopicCallback("plugins", "Show Juju plugins", PluginHelpTopic)
+	topics.AddTo(jcmd)
 	registerCommands(jcmd, ctx)
 	return jcmd
 }
@@ -242,12 +310,17 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Reporting commands.
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
+	r.Register(newCompletionCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewWaitForCommand())
+	r.Register(status.NewTopCommand())
+	r.Register(status.NewShowUnitCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newRunCommand())
 	r.Register(newSCPCommand())
 	r.Register(newSSHCommand())
+	r.Register(newSSHTunnelCommand())
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
 	r.Register(newDebugHooksCommand())
@@ -280,6 +353,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage users and access
 	r.Register(user.NewAddCommand())
+	r.Register(user.NewCreateRegistrationCommand())
 	r.Register(user.NewChangePasswordCommand())
 	r.Register(user.NewShowUserCommand())
 	r.Register(user.NewListCommand())
@@ -288,6 +362,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewLoginCommand())
 	r.Register(user.NewLogoutCommand())
 	r.Register(user.NewRemoveCommand())
+	r.Register(user.NewWhoAmICommand())
 
 	// Manage cached images
 	r.Register(cachedimages.NewRemoveCommand())
@@ -298,6 +373,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewMaintenanceCommand())
+	r.Register(machine.NewReplaceCommand())
+	r.Register(machine.NewUpgradeSeriesCommand())
 
 	// Manage model
 	r.Register(model.NewGetCommand())
@@ -312,6 +390,16 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewDiffCommand())
+	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewSnapshotConfigCommand())
+	r.Register(model.NewConfigSnapshotsCommand())
+	r.Register(model.NewRestoreConfigCommand())
+	r.Register(model.NewRemoveConfigSnapshotCommand())
+	r.Register(model.NewSetModelAliasCommand())
+	r.Register(model.NewRemoveModelAliasCommand())
+	r.Register(model.NewModelAliasesCommand())
+	r.Register(model.NewShowEventsCommand())
 
 	if featureflag.Enabled(feature.Migration) {
 		r.Register(newMigrateCommand())
@@ -320,6 +408,24 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 		r.Register(model.NewDumpCommand())
 	}
 
+	// Manage custom image metadata
+	r.Register(imagemetadata.NewAddCommand())
+
+	// Manage firewall rules
+	r.Register(firewall.NewListRulesCommand())
+	r.Register(firewall.NewSetRuleCommand())
+
+	// Manage alert rules and alerts
+	r.Register(alerts.NewListAlertsCommand())
+	r.Register(alerts.NewListRulesCommand())
+	r.Register(alerts.NewAddRuleCommand())
+	r.Register(alerts.NewRemoveRuleCommand())
+
+	// Manage scheduled operations
+	r.Register(scheduledoperations.NewScheduleCommand())
+	r.Register(scheduledoperations.NewListCommand())
+	r.Register(scheduledoperations.NewCancelCommand())
+
 	// Manage and control actions
 	r.Register(action.NewStatusCommand())
 	r.Register(action.NewRunCommand())
@@ -328,16 +434,19 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
+	r.Register(newRemoveControllerMachineCommand())
 
 	// Manage and control services
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewGetCommand())
 	r.Register(application.NewSetCommand())
+	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
+	r.Register(application.NewSetLimitsCommand())
 
 	// Operation protection commands
 	r.Register(block.NewSuperBlockCommand())
@@ -379,6 +488,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewRemoveBlocksCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewControllerReportCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
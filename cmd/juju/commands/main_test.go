@@ -25,10 +25,12 @@ import (
 	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/cloud"
+	"github.com/juju/juju/cmd/juju/helptopics"
 	"github.com/juju/juju/cmd/modelcmd"
 	cmdtesting "github.com/juju/juju/cmd/testing"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
@@ -142,6 +144,11 @@ func (s *MainSuite) TestRunMain(c *gc.C) {
 		args:    []string{"unblock"},
 		code:    0,
 		out:     "error: must specify one of [destroy-model | remove-object | all-changes] to unblock\n",
+	}, {
+		summary: "juju help examples add-storage shows the examples of add-storage",
+		args:    []string{"help", "examples", "add-storage"},
+		code:    0,
+		out:     strings.TrimSpace(helptopics.FormatExamples("add-storage", helptopics.Examples("add-storage"))) + "\n",
 	},
 	} {
 		c.Logf("test %d: %s", i, t.summary)
@@ -372,6 +379,35 @@ func (s *MainSuite) TestRunNoUpdateCloud(c *gc.C) {
 	s.assertRunCommandUpdateCloud(c, "Info")
 }
 
+func (s *MainSuite) TestRecordOperations(c *gc.C) {
+	s.PatchEnvironment(osenv.JujuRecordOperationsEnvKey, "true")
+	var code int
+	gitjujutesting.CaptureOutput(c, func() {
+		code = main{
+			execCommand: s.GetExecCommand(gitjujutesting.PatchExecConfig{}),
+		}.Run([]string{"juju", "version"})
+	})
+	c.Assert(code, gc.Equals, 0)
+
+	data, err := ioutil.ReadFile(jujuclient.JujuOperationLogPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Matches, `(?s)# .*\njuju 'version'\n# exit status 0\n\n`)
+}
+
+func (s *MainSuite) TestRecordOperationsInvalid(c *gc.C) {
+	s.PatchEnvironment(osenv.JujuRecordOperationsEnvKey, "perhaps")
+	var code int
+	_, stderr := gitjujutesting.CaptureOutput(c, func() {
+		code = main{
+			execCommand: s.GetExecCommand(gitjujutesting.PatchExecConfig{}),
+		}.Run([]string{"juju", "version"})
+	})
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(string(stderr), jc.Contains, "invalid JUJU_RECORD_OPERATIONS env var, expected true|false")
+	_, err := os.Stat(jujuclient.JujuOperationLogPath())
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
 func makeValidOldHome(c *gc.C) {
 	oldhome := osenv.OldJujuHomeDir()
 	err := os.MkdirAll(oldhome, 0700)
@@ -402,8 +438,10 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 
 var commandNames = []string{
 	"actions",
+	"add-alert-rule",
 	"add-cloud",
 	"add-credential",
+	"add-image-metadata",
 	"add-machine",
 	"add-machines",
 	"add-model",
@@ -418,6 +456,8 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"agreements",
+	"alert-rules",
+	"alerts",
 	"allocate",
 	"autoload-credentials",
 	"backups",
@@ -426,13 +466,19 @@ var commandNames = []string{
 	"bootstrap",
 	"budgets",
 	"cached-images",
+	"cancel-scheduled-operation",
 	"change-user-password",
 	"charm",
 	"clouds",
 	"collect-metrics",
+	"completion",
+	"config",
+	"config-snapshots",
+	"controller-report",
 	"controllers",
 	"create-backup",
 	"create-budget",
+	"create-registration-token",
 	"create-storage-pool",
 	"credentials",
 	"debug-hooks",
@@ -445,11 +491,14 @@ var commandNames = []string{
 	"destroy-relation",
 	"destroy-application",
 	"destroy-unit",
+	"diff-model",
 	"disable-user",
 	"download-backup",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
 	"expose",
+	"firewall-rules",
 	"get-config",
 	"get-configs",
 	"get-constraints",
@@ -465,18 +514,24 @@ var commandNames = []string{
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
+	"list-alert-rules",
+	"list-alerts",
 	"list-all-blocks",
 	"list-backups",
 	"list-blocks",
 	"list-budgets",
 	"list-cached-images",
 	"list-clouds",
+	"list-config-snapshots",
 	"list-controllers",
 	"list-credentials",
+	"list-firewall-rules",
 	"list-machine",
 	"list-machines",
+	"list-model-aliases",
 	"list-models",
 	"list-plans",
+	"list-scheduled-operations",
 	"list-shares",
 	"list-ssh-key",
 	"list-ssh-keys",
@@ -489,6 +544,7 @@ var commandNames = []string{
 	"logout",
 	"machine",
 	"machines",
+	"model-aliases",
 	"model-config",
 	"model-defaults",
 	"models",
@@ -496,37 +552,50 @@ var commandNames = []string{
 	"register",
 	"relate", //alias for add-relation
 	"remove-all-blocks",
+	"remove-alert-rule",
 	"remove-application", // alias for destroy-application
 	"remove-backup",
 	"remove-cached-images",
 	"remove-cloud",
+	"remove-config-snapshot",
+	"remove-controller-machine",
 	"remove-credential",
 	"remove-machine",
 	"remove-machines",
+	"remove-model-alias",
 	"remove-relation", // alias for destroy-relation
 	"remove-ssh-key",
 	"remove-ssh-keys",
 	"remove-unit", // alias for destroy-unit
+	"replace-machine",
 	"resolved",
+	"restore-config",
 	"restore-backup",
 	"retry-provisioning",
 	"revoke",
 	"run",
 	"run-action",
+	"schedule-operation",
+	"scheduled-operations",
 	"scp",
+	"set-application-limits",
 	"set-budget",
 	"set-config",
 	"set-configs",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-firewall-rule",
+	"set-machine-maintenance",
 	"set-meter-status",
+	"set-model-alias",
 	"set-model-config",
 	"set-model-constraints",
 	"set-model-default",
 	"set-plan",
 	"ssh-key",
 	"ssh-keys",
+	"ssh-tunnel",
 	"shares",
 	"show-action-output",
 	"show-action-status",
@@ -535,12 +604,16 @@ var commandNames = []string{
 	"show-cloud",
 	"show-controller",
 	"show-controllers",
+	"show-events",
 	"show-machine",
 	"show-machines",
 	"show-model",
 	"show-status",
+	"show-status-log",
 	"show-storage",
+	"show-unit",
 	"show-user",
+	"snapshot-config",
 	"spaces",
 	"ssh",
 	"status",
@@ -550,6 +623,7 @@ var commandNames = []string{
 	"subnets",
 	"switch",
 	"sync-tools",
+	"top",
 	"unblock",
 	"unexpose",
 	"update-allocation",
@@ -561,8 +635,11 @@ var commandNames = []string{
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"upgrade-series",
 	"users",
 	"version",
+	"wait-for",
+	"whoami",
 }
 
 // devFeatures are feature flags that impact registration of commands.
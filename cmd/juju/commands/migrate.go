@@ -4,28 +4,56 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/progress"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/migration"
 )
 
+// migrationPollInterval is the time between successive checks of the
+// progress of a migration when waiting for it to complete.
+const migrationPollInterval = 2 * time.Second
+
+// migrationPhases holds the phases of a successful migration, in
+// order.
+var migrationPhases = []migration.Phase{
+	migration.QUIESCE,
+	migration.PRECHECK,
+	migration.IMPORT,
+	migration.VALIDATION,
+	migration.SUCCESS,
+	migration.LOGTRANSFER,
+	migration.REAP,
+	migration.DONE,
+}
+
 func newMigrateCommand() cmd.Command {
-	return modelcmd.WrapController(&migrateCommand{})
+	return modelcmd.WrapController(&migrateCommand{clock: clock.WallClock})
 }
 
 // migrateCommand initiates a model migration.
 type migrateCommand struct {
 	modelcmd.ControllerCommandBase
-	api migrateAPI
+	api   migrateAPI
+	clock clock.Clock
 
 	model            string
 	targetController string
+	wait             bool
+	progress         progress.Mode
 }
 
 type migrateAPI interface {
 	InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error)
+	ModelMigrationStatus(modelUUID string) (controller.MigrationStatus, error)
 }
 
 const migrateDoc = `
@@ -47,9 +75,17 @@ In order to start a migration, the target controller must be in the
 juju client's local configuration cache. See the juju "login" command
 for details of how to do this.
 
-This command only starts a model migration - it does not wait for its
-completion. The progress of a migration can be tracked using the
-"status" command and by consulting the logs.
+By default this command only starts a model migration - it does not
+wait for its completion. The progress of a migration can be tracked
+using the "status" command and by consulting the logs. With --wait, the
+command reports the progress of the migration as it moves through its
+phases, and returns once the migration has completed or failed.
+
+Examples:
+
+    juju migrate mymodel target-controller
+    juju migrate mymodel target-controller --wait
+    juju migrate mymodel target-controller --wait --progress json
 
 See also:
     login
@@ -67,6 +103,13 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.wait, "wait", false, "Wait for the migration to complete, reporting its progress")
+	progress.AddFlag(f, &c.progress)
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -128,7 +171,65 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	ctx.Infof("Migration started with ID %q", id)
-	return nil
+	if !c.wait {
+		return nil
+	}
+	return c.waitForMigration(ctx, api, spec.ModelUUID, id)
+}
+
+// waitForMigration reports the progress of the migration with the
+// given ID until it completes, returning an error if it fails.
+func (c *migrateCommand) waitForMigration(ctx *cmd.Context, api migrateAPI, modelUUID, id string) error {
+	reporter := c.progress.NewReporter(ctx.Stderr, c.clock)
+	defer reporter.Close()
+
+	succeeded := false
+	for {
+		status, err := api.ModelMigrationStatus(modelUUID)
+		if params.IsCodeNotFound(err) && succeeded {
+			// The model has been removed from this controller
+			// at the end of the migration.
+			reporter.Report(progress.Event{
+				Operation: "migrating",
+				Stage:     migration.DONE.String(),
+				Current:   int64(len(migrationPhases)),
+				Total:     int64(len(migrationPhases)),
+				Done:      true,
+			})
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "getting migration status")
+		}
+		if status.MigrationId != id {
+			return errors.Errorf("migration %q is no longer the latest migration of the model", id)
+		}
+
+		event := progress.Event{
+			Operation: "migrating",
+			Stage:     status.Phase.String(),
+			Message:   status.StatusMessage,
+			Done:      status.Phase.IsTerminal(),
+		}
+		for i, phase := range migrationPhases {
+			if status.Phase == phase {
+				event.Current = int64(i + 1)
+				event.Total = int64(len(migrationPhases))
+			}
+		}
+		reporter.Report(event)
+
+		switch status.Phase {
+		case migration.SUCCESS, migration.LOGTRANSFER, migration.REAP:
+			succeeded = true
+		case migration.DONE:
+			return nil
+		case migration.REAPFAILED:
+			return errors.Errorf("migration succeeded but the model could not be removed from the source controller: %s", status.StatusMessage)
+		case migration.ABORTDONE:
+			return errors.Errorf("migration aborted: %s", status.StatusMessage)
+		}
+		<-c.clock.After(migrationPollInterval)
+	}
 }
 
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
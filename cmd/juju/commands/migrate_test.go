@@ -4,13 +4,18 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -125,13 +130,61 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestWait(c *gc.C) {
+	s.api.statuses = []controller.MigrationStatus{
+		{MigrationId: "uuid:0", Phase: migration.QUIESCE},
+		{MigrationId: "uuid:0", Phase: migration.IMPORT, StatusMessage: "uploading model"},
+		{MigrationId: "uuid:0", Phase: migration.DONE},
+	}
+	ctx, err := s.makeAndRun(c, "model", "target", "--wait", "--progress", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Matches, ``+
+		`Migration started with ID "uuid:0"\n`+
+		`{"operation":"migrating","stage":"QUIESCE","current":1,"total":8,"time":".*"}\n`+
+		`{"operation":"migrating","stage":"IMPORT","message":"uploading model","current":3,"total":8,"time":".*"}\n`+
+		`{"operation":"migrating","stage":"DONE","current":8,"total":8,"done":true,"time":".*"}\n`,
+	)
+	c.Check(s.api.statusCalls, gc.Equals, 3)
+}
+
+func (s *MigrateSuite) TestWaitModelRemoved(c *gc.C) {
+	s.api.statuses = []controller.MigrationStatus{
+		{MigrationId: "uuid:0", Phase: migration.REAP},
+	}
+	s.api.statusErr = &params.Error{Code: params.CodeNotFound, Message: "model not found"}
+	ctx, err := s.makeAndRun(c, "model", "target", "--wait", "--progress", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Matches, `(?s).*"stage":"DONE","current":8,"total":8,"done":true.*`)
+}
+
+func (s *MigrateSuite) TestWaitAborted(c *gc.C) {
+	s.api.statuses = []controller.MigrationStatus{
+		{MigrationId: "uuid:0", Phase: migration.ABORT},
+		{MigrationId: "uuid:0", Phase: migration.ABORTDONE, StatusMessage: "prechecks failed"},
+	}
+	_, err := s.makeAndRun(c, "model", "target", "--wait")
+	c.Assert(err, gc.ErrorMatches, "migration aborted: prechecks failed")
+}
+
+func (s *MigrateSuite) TestWaitStatusError(c *gc.C) {
+	s.api.statusErr = errors.New("boom")
+	_, err := s.makeAndRun(c, "model", "target", "--wait")
+	c.Assert(err, gc.ErrorMatches, "getting migration status: boom")
+}
+
+func (s *MigrateSuite) TestInvalidProgressMode(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--progress", "dots")
+	c.Assert(err, gc.ErrorMatches, `invalid value "dots" for flag --progress: progress mode "dots" not valid`)
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return s.run(c, s.makeCommand(), args...)
 }
 
 func (s *MigrateSuite) makeCommand() *migrateCommand {
 	cmd := &migrateCommand{
-		api: s.api,
+		api:   s.api,
+		clock: instantClock{testing.NewClock(time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC))},
 	}
 	cmd.SetClientStore(s.store)
 	return cmd
@@ -142,7 +195,10 @@ func (s *MigrateSuite) run(c *gc.C, cmd *migrateCommand, args ...string) (*cmd.C
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.ModelMigrationSpec
+	specSeen    *controller.ModelMigrationSpec
+	statuses    []controller.MigrationStatus
+	statusErr   error
+	statusCalls int
 }
 
 func (a *fakeMigrateAPI) InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error) {
@@ -150,6 +206,28 @@ func (a *fakeMigrateAPI) InitiateModelMigration(spec controller.ModelMigrationSp
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) ModelMigrationStatus(modelUUID string) (controller.MigrationStatus, error) {
+	a.statusCalls++
+	if len(a.statuses) == 0 {
+		return controller.MigrationStatus{}, a.statusErr
+	}
+	status := a.statuses[0]
+	a.statuses = a.statuses[1:]
+	return status, nil
+}
+
+// instantClock is a clock whose timers expire immediately, so that
+// polling loops run without delay.
+type instantClock struct {
+	*testing.Clock
+}
+
+func (instantClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
 type fakeModelAPI struct {
 	model string
 }
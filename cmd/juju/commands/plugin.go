@@ -5,6 +5,7 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -14,10 +15,13 @@ import (
 	"sort"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
 )
@@ -96,6 +100,13 @@ func (c *PluginCommand) Run(ctx *cmd.Context) error {
 	command.Env = append(os.Environ(), []string{
 		osenv.JujuModelEnvKey + "=" + c.ConnectionName()}...,
 	)
+	if metadata, err := cachedPluginMetadata(c.name); err == nil && metadata.RequiresController {
+		descriptor, err := c.apiConnectionDescriptor()
+		if err != nil {
+			return errors.Annotatef(err, "connecting to controller for %s", c.name)
+		}
+		command.Env = append(command.Env, osenv.JujuAPIConnectionEnvKey+"="+descriptor)
+	}
 
 	// Now hook up stdin, stdout, stderr
 	command.Stdin = ctx.Stdin
@@ -113,9 +124,170 @@ func (c *PluginCommand) Run(ctx *cmd.Context) error {
 	return err
 }
 
+// PluginAPIConnection describes the API connection made on behalf of
+// a plugin which requires a controller. It is passed to the plugin as
+// JSON in the JUJU_API_CONNECTION environment variable, so that the
+// plugin need not resolve the controller and model itself.
+type PluginAPIConnection struct {
+	ControllerName string   `json:"controller-name"`
+	ControllerUUID string   `json:"controller-uuid"`
+	ModelName      string   `json:"model-name"`
+	ModelUUID      string   `json:"model-uuid"`
+	User           string   `json:"user"`
+	Addresses      []string `json:"addresses"`
+	CACert         string   `json:"ca-cert"`
+}
+
+// apiConnectionDescriptor connects to the model the plugin is run
+// against, and returns the JSON encoded PluginAPIConnection for it.
+func (c *PluginCommand) apiConnectionDescriptor() (string, error) {
+	conn, err := c.NewAPIRoot()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer conn.Close()
+	controller, err := c.ClientStore().ControllerByName(c.ControllerName())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	descriptor := PluginAPIConnection{
+		ControllerName: c.ControllerName(),
+		ControllerUUID: controller.ControllerUUID,
+		ModelName:      c.ModelName(),
+		Addresses:      apiConnectionAddresses(conn),
+		CACert:         controller.CACert,
+	}
+	if modelTag, ok := conn.ModelTag(); ok {
+		descriptor.ModelUUID = modelTag.Id()
+	}
+	if authTag := conn.AuthTag(); authTag != nil {
+		descriptor.User = authTag.Id()
+	}
+	data, err := json.Marshal(descriptor)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// apiConnectionAddresses returns the addresses of the API servers, with
+// the address the connection was made to first.
+func apiConnectionAddresses(conn api.Connection) []string {
+	addrs := []string{conn.Addr()}
+	for _, server := range conn.APIHostPorts() {
+		for _, hp := range server {
+			if addr := hp.NetAddr(); addr != conn.Addr() {
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	return addrs
+}
+
 type PluginDescription struct {
-	name        string
-	description string
+	name               string
+	description        string
+	flags              []PluginFlag
+	requiresController bool
+}
+
+// PluginMetadata describes a plugin. Plugins may report their metadata
+// as JSON when run with "--metadata"; plugins which do not are asked
+// for their description with "--description" instead.
+type PluginMetadata struct {
+	// Description is a one line description of the plugin.
+	Description string `json:"description"`
+
+	// Flags describes the flags the plugin accepts.
+	Flags []PluginFlag `json:"flags,omitempty"`
+
+	// RequiresController is true if the plugin needs a connection
+	// to a controller. Such plugins are passed a description of the
+	// API connection in JUJU_API_CONNECTION.
+	RequiresController bool `json:"requires-controller,omitempty"`
+}
+
+// PluginFlag describes a flag accepted by a plugin.
+type PluginFlag struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// getPluginMetadata runs the plugin with "--metadata", and returns the
+// metadata it reports. An error is returned if the plugin does not
+// support the metadata handshake.
+func getPluginMetadata(plugin string) (*PluginMetadata, error) {
+	output, err := exec.Command(plugin, "--metadata").Output()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var metadata PluginMetadata
+	if err := json.Unmarshal(output, &metadata); err != nil {
+		return nil, errors.NotSupportedf("%s --metadata", plugin)
+	}
+	if metadata.Description == "" {
+		return nil, errors.NotValidf("%s metadata without description", plugin)
+	}
+	return &metadata, nil
+}
+
+// pluginMetadataCacheFile is the name of the file, in the Juju data
+// directory, in which the metadata reported by plugins is cached.
+const pluginMetadataCacheFile = "plugin-metadata.json"
+
+// pluginMetadataCacheEntry records the metadata reported by a plugin
+// executable, identified by its path, modification time and size.
+type pluginMetadataCacheEntry struct {
+	Path    string    `json:"path"`
+	ModTime time.Time `json:"mod-time"`
+	Size    int64     `json:"size"`
+
+	// Metadata is nil if the plugin does not support the
+	// metadata handshake.
+	Metadata *PluginMetadata `json:"metadata,omitempty"`
+}
+
+// cachedPluginMetadata returns the metadata reported by the plugin, as
+// getPluginMetadata does, but only runs the plugin with "--metadata" if
+// its executable has changed since the result was last cached.
+func cachedPluginMetadata(plugin string) (*PluginMetadata, error) {
+	path, err := exec.LookPath(plugin)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cacheFile := osenv.JujuXDGDataHomePath(pluginMetadataCacheFile)
+	cache := make(map[string]pluginMetadataCacheEntry)
+	if data, err := ioutil.ReadFile(cacheFile); err == nil {
+		if err := json.Unmarshal(data, &cache); err != nil {
+			logger.Debugf("ignoring invalid plugin metadata cache: %v", err)
+			cache = make(map[string]pluginMetadataCacheEntry)
+		}
+	}
+	entry, ok := cache[plugin]
+	if ok && entry.Path == path && entry.ModTime.Equal(info.ModTime()) && entry.Size == info.Size() {
+		if entry.Metadata == nil {
+			return nil, errors.NotSupportedf("%s --metadata", plugin)
+		}
+		return entry.Metadata, nil
+	}
+
+	metadata, err := getPluginMetadata(plugin)
+	cache[plugin] = pluginMetadataCacheEntry{
+		Path:     path,
+		ModTime:  info.ModTime(),
+		Size:     info.Size(),
+		Metadata: metadata,
+	}
+	if data, err := json.Marshal(cache); err != nil {
+		logger.Debugf("cannot encode plugin metadata cache: %v", err)
+	} else if err := ioutil.WriteFile(cacheFile, data, 0600); err != nil {
+		logger.Debugf("cannot write plugin metadata cache: %v", err)
+	}
+	return metadata, err
 }
 
 const PluginTopicText = `Juju Plugins
@@ -123,6 +295,19 @@ const PluginTopicText = `Juju Plugins
 Plugins are implemented as stand-alone executable files somewhere in the user's PATH.
 The executable command must be of the format juju-<plugin name>.
 
+A plugin may describe itself by writing JSON metadata to stdout when run with
+--metadata, for example:
+
+    {"description": "back up a model",
+     "flags": [{"name": "output", "description": "file to write the backup to"}],
+     "requires-controller": true}
+
+Plugins which require a controller are passed a description of the API
+connection to the current model, as JSON, in $JUJU_API_CONNECTION. Plugins
+which do not report metadata are asked for a one line description with
+--description instead. The metadata is cached, and a plugin is only asked
+for it again when its executable changes.
+
 `
 
 func PluginHelpTopic() string {
@@ -141,16 +326,24 @@ func PluginHelpTopic() string {
 			}
 		}
 		for _, plugin := range existingPlugins {
-			fmt.Fprintf(output, "%-*s  %s\n", longest, plugin.name, plugin.description)
+			description := plugin.description
+			if plugin.requiresController {
+				description += " (requires a controller)"
+			}
+			fmt.Fprintf(output, "%-*s  %s\n", longest, plugin.name, description)
+			for _, flag := range plugin.flags {
+				fmt.Fprintf(output, "%-*s    --%s: %s\n", longest, "", flag.Name, flag.Description)
+			}
 		}
 	}
 
 	return output.String()
 }
 
-// GetPluginDescriptions runs each plugin with "--description".  The calls to
-// the plugins are run in parallel, so the function should only take as long
-// as the longest call.
+// GetPluginDescriptions runs each plugin with "--metadata", falling back
+// to "--description" for plugins which do not report metadata. The calls
+// to the plugins are run in parallel, so the function should only take
+// as long as the longest call.
 func GetPluginDescriptions() []PluginDescription {
 	plugins := findPlugins()
 	results := []PluginDescription{}
@@ -167,6 +360,12 @@ func GetPluginDescriptions() []PluginDescription {
 			defer func() {
 				description <- result
 			}()
+			if metadata, err := getPluginMetadata(plugin); err == nil {
+				result.description = metadata.Description
+				result.flags = metadata.Flags
+				result.requiresController = metadata.RequiresController
+				return
+			}
 			desccmd := exec.Command(plugin, "--description")
 			output, err := desccmd.CombinedOutput()
 
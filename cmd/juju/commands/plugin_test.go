@@ -5,10 +5,12 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"runtime"
+	"strings"
 	"text/template"
 	"time"
 
@@ -16,8 +18,13 @@ import (
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
 
@@ -128,6 +135,160 @@ func (suite *PluginSuite) TestGatherDescriptionsInParallel(c *gc.C) {
 	c.Assert(results[3].description, gc.Equals, "foo description")
 }
 
+func (suite *PluginSuite) TestGetPluginDescriptionsMetadata(c *gc.C) {
+	suite.makeFullPlugin(PluginParams{Name: "foo"})
+	suite.makeFullPlugin(PluginParams{
+		Name:     "backup",
+		Metadata: `{"description": "back up a model", "flags": [{"name": "output", "description": "file to write"}], "requires-controller": true}`,
+	})
+	suite.makeFullPlugin(PluginParams{
+		Name:     "empty",
+		Metadata: `{"flags": []}`,
+	})
+	results := GetPluginDescriptions()
+	c.Assert(results, jc.DeepEquals, []PluginDescription{{
+		name:               "backup",
+		description:        "back up a model",
+		flags:              []PluginFlag{{Name: "output", Description: "file to write"}},
+		requiresController: true,
+	}, {
+		name:        "empty",
+		description: "empty description",
+	}, {
+		name:        "foo",
+		description: "foo description",
+	}})
+}
+
+func (suite *PluginSuite) TestPluginHelpTopic(c *gc.C) {
+	suite.makeFullPlugin(PluginParams{Name: "foo"})
+	suite.makeFullPlugin(PluginParams{
+		Name:     "backup",
+		Metadata: `{"description": "back up a model", "flags": [{"name": "output", "description": "file to write"}], "requires-controller": true}`,
+	})
+	output := PluginHelpTopic()
+	c.Assert(output, jc.HasPrefix, "Juju Plugins\n")
+	c.Assert(output, jc.HasSuffix, `
+backup  back up a model (requires a controller)
+          --output: file to write
+foo     foo description
+`)
+}
+
+func (suite *PluginSuite) TestPluginHelpTopicNoPlugins(c *gc.C) {
+	c.Assert(PluginHelpTopic(), jc.HasSuffix, "\nNo plugins found.\n")
+}
+
+func (suite *PluginSuite) TestRunPluginRequiresController(c *gc.C) {
+	suite.makeFullPlugin(PluginParams{
+		Name:     "backup",
+		Metadata: `{"description": "back up a model", "requires-controller": true}`,
+	})
+	ctx := testing.Context(c)
+	err := RunPlugin(ctx, "backup", nil)
+	c.Assert(err, gc.ErrorMatches, "connecting to controller for juju-backup: no model in focus(.|\n)*")
+}
+
+func (suite *PluginSuite) TestRunPluginCachesMetadata(c *gc.C) {
+	probes := gitjujutesting.HomePath("probes")
+	script := fmt.Sprintf(`if [ "$1" = "--metadata" ]; then
+  echo probed >> %s
+  echo '{"description": "counted"}'
+  exit 0
+fi
+echo %%s $*
+`, probes)
+	suite.makePlugin("juju-counted", fmt.Sprintf(script, "first"), 0755)
+	assertProbes := func(expect int) {
+		data, err := ioutil.ReadFile(probes)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(strings.Count(string(data), "probed\n"), gc.Equals, expect)
+	}
+
+	for i := 0; i < 2; i++ {
+		ctx := testing.Context(c)
+		err := RunPlugin(ctx, "counted", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(testing.Stdout(ctx), gc.Equals, "first\n")
+	}
+	assertProbes(1)
+
+	// Changing the plugin invalidates the cached metadata.
+	suite.makePlugin("juju-counted", fmt.Sprintf(script, "second"), 0755)
+	ctx := testing.Context(c)
+	err := RunPlugin(ctx, "counted", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "second\n")
+	assertProbes(2)
+}
+
+func (suite *PluginSuite) TestAPIConnectionDescriptor(c *gc.C) {
+	store := jujuclienttesting.NewMemStore()
+	store.Controllers["myctrl"] = jujuclient.ControllerDetails{
+		ControllerUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "fake",
+	}
+	store.Models["myctrl"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin@local/mymodel": {ModelUUID: testing.ModelTag.Id()},
+		},
+	}
+	store.Accounts["myctrl"] = jujuclient.AccountDetails{User: "admin@local"}
+	command := &PluginCommand{name: "juju-backup"}
+	command.SetClientStore(store)
+	err := command.SetModelName("myctrl:admin@local/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	command.SetAPIOpener(modelcmd.OpenFunc(func(_ jujuclient.ClientStore, controllerName, modelName string) (api.Connection, error) {
+		c.Check(controllerName, gc.Equals, "myctrl")
+		c.Check(modelName, gc.Equals, "admin@local/mymodel")
+		return &pluginAPIConnection{}, nil
+	}))
+
+	descriptor, err := command.apiConnectionDescriptor()
+	c.Assert(err, jc.ErrorIsNil)
+	var conn PluginAPIConnection
+	err = json.Unmarshal([]byte(descriptor), &conn)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conn, jc.DeepEquals, PluginAPIConnection{
+		ControllerName: "myctrl",
+		ControllerUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ModelName:      "admin@local/mymodel",
+		ModelUUID:      testing.ModelTag.Id(),
+		User:           "admin@local",
+		Addresses:      []string{"10.0.0.1:17070", "10.0.0.2:17070"},
+		CACert:         "fake",
+	})
+}
+
+// pluginAPIConnection is an api.Connection for testing the API
+// connection descriptor passed to plugins.
+type pluginAPIConnection struct {
+	api.Connection
+}
+
+func (*pluginAPIConnection) Addr() string {
+	return "10.0.0.1:17070"
+}
+
+func (*pluginAPIConnection) APIHostPorts() [][]network.HostPort {
+	return [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+	}
+}
+
+func (*pluginAPIConnection) ModelTag() (names.ModelTag, bool) {
+	return testing.ModelTag, true
+}
+
+func (*pluginAPIConnection) AuthTag() names.Tag {
+	return names.NewUserTag("admin@local")
+}
+
+func (*pluginAPIConnection) Close() error {
+	return nil
+}
+
 func (suite *PluginSuite) TestHelpPluginName(c *gc.C) {
 	suite.makeFullPlugin(PluginParams{Name: "foo"})
 	output := badrun(c, 0, "help", "foo")
@@ -205,10 +366,16 @@ type PluginParams struct {
 	ExitStatus int
 	Creates    string
 	DependsOn  string
+	Metadata   string
 }
 
 const pluginTemplate = `#!/bin/bash --norc
 
+if [ "$1" = "--metadata" ] && [ -n '{{.Metadata}}' ]; then
+  echo '{{.Metadata}}'
+  exit 0
+fi
+
 if [ "$1" = "--description" ]; then
   if [ -n "{{.Creates}}" ]; then
     touch "{{.Creates}}"
@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newRemoveControllerMachineCommand() cmd.Command {
+	command := &removeControllerMachineCommand{}
+	command.newHAClientFunc = func() (RemoveControllerMachinesClient, error) {
+		root, err := command.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get API connection")
+		}
+		return highavailability.NewClient(root), nil
+	}
+	return modelcmd.Wrap(command)
+}
+
+// removeControllerMachineCommand takes machines out of the
+// controller cluster.
+type removeControllerMachineCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	// newHAClientFunc returns HA Client to be used by the command.
+	newHAClientFunc func() (RemoveControllerMachinesClient, error)
+
+	// MachineIds holds the ids of the controller machines to remove.
+	MachineIds []string
+}
+
+const removeControllerMachineDoc = `
+Takes one or more machines out of the controller cluster, reducing the
+number of controllers. An odd number of controllers must remain.
+
+Voting controllers are first demoted; once the replica set has taken
+their vote away, their controller job is removed and they stop being
+advertised to agents as API servers. The machines themselves are not
+destroyed; use "juju remove-machine" to remove them once they have
+left the cluster.
+
+Examples:
+    # Reduce a cluster of 5 controllers to 3.
+    juju remove-controller-machine 3 4
+
+See also:
+    enable-ha
+    remove-machine
+`
+
+func (c *removeControllerMachineCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-controller-machine",
+		Args:    "<machine> ...",
+		Purpose: "Removes machines from the controller cluster.",
+		Doc:     removeControllerMachineDoc,
+	}
+}
+
+func (c *removeControllerMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "simple", map[string]cmd.Formatter{
+		"yaml":   cmd.FormatYaml,
+		"json":   cmd.FormatJson,
+		"simple": formatSimple,
+	})
+}
+
+func (c *removeControllerMachineCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machines specified")
+	}
+	for _, id := range args {
+		if !names.IsValidMachine(id) || names.IsContainerMachine(id) {
+			return errors.Errorf("invalid controller machine id %q", id)
+		}
+	}
+	c.MachineIds = args
+	return nil
+}
+
+// RemoveControllerMachinesClient defines the methods on the
+// client API that the remove-controller-machine command calls.
+type RemoveControllerMachinesClient interface {
+	Close() error
+	RemoveControllerMachines(machineIds ...string) (params.ControllersChanges, error)
+}
+
+// Run connects to the controller and removes the machines
+// from the controller cluster.
+func (c *removeControllerMachineCommand) Run(ctx *cmd.Context) error {
+	haClient, err := c.newHAClientFunc()
+	if err != nil {
+		return err
+	}
+	defer haClient.Close()
+
+	changes, err := haClient.RemoveControllerMachines(c.MachineIds...)
+	if errors.IsNotSupported(err) {
+		return errors.New("cannot remove controller machines: not supported by the API server")
+	}
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	result := availabilityInfo{
+		Removed:    machineTagsToIds(changes.Removed...),
+		Maintained: machineTagsToIds(changes.Maintained...),
+		Demoted:    machineTagsToIds(changes.Demoted...),
+	}
+	return c.out.Write(ctx, result)
+}
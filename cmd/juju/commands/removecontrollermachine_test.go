@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RemoveControllerMachineSuite struct {
+	testing.JujuConnSuite
+	fake *fakeRemoveControllerMachinesClient
+}
+
+var _ = gc.Suite(&RemoveControllerMachineSuite{})
+
+func (s *RemoveControllerMachineSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.fake = &fakeRemoveControllerMachinesClient{}
+}
+
+type fakeRemoveControllerMachinesClient struct {
+	machineIds []string
+	result     params.ControllersChanges
+	err        error
+}
+
+func (f *fakeRemoveControllerMachinesClient) Close() error {
+	return nil
+}
+
+func (f *fakeRemoveControllerMachinesClient) RemoveControllerMachines(machineIds ...string) (params.ControllersChanges, error) {
+	f.machineIds = machineIds
+	return f.result, f.err
+}
+
+func (s *RemoveControllerMachineSuite) runRemoveControllerMachine(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &removeControllerMachineCommand{
+		newHAClientFunc: func() (RemoveControllerMachinesClient, error) { return s.fake, nil },
+	}
+	return coretesting.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *RemoveControllerMachineSuite) TestRemoveControllerMachine(c *gc.C) {
+	s.fake.result = params.ControllersChanges{
+		Demoted: []string{"machine-3", "machine-4"},
+	}
+	ctx, err := s.runRemoveControllerMachine(c, "3", "4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.machineIds, gc.DeepEquals, []string{"3", "4"})
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "demoting machines: 3, 4\n\n")
+}
+
+func (s *RemoveControllerMachineSuite) TestRemoveControllerMachineRemoved(c *gc.C) {
+	s.fake.result = params.ControllersChanges{
+		Maintained: []string{"machine-3"},
+		Removed:    []string{"machine-4"},
+	}
+	ctx, err := s.runRemoveControllerMachine(c, "3", "4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals,
+		"maintaining machines: 3\n"+
+			"removing machines: 4\n\n")
+}
+
+func (s *RemoveControllerMachineSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runRemoveControllerMachine(c)
+	c.Assert(err, gc.ErrorMatches, "no machines specified")
+	_, err = s.runRemoveControllerMachine(c, "0/lxd/1")
+	c.Assert(err, gc.ErrorMatches, `invalid controller machine id "0/lxd/1"`)
+	_, err = s.runRemoveControllerMachine(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `invalid controller machine id "foo"`)
+	c.Assert(s.fake.machineIds, gc.IsNil)
+}
+
+func (s *RemoveControllerMachineSuite) TestNotSupported(c *gc.C) {
+	s.fake.err = errors.NotSupportedf("removing controller machines")
+	_, err := s.runRemoveControllerMachine(c, "3")
+	c.Assert(err, gc.ErrorMatches, "cannot remove controller machines: not supported by the API server")
+}
+
+func (s *RemoveControllerMachineSuite) TestBlockRemoveControllerMachine(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockRemoveControllerMachine")
+	_, err := s.runRemoveControllerMachine(c, "3")
+	c.Assert(err, gc.ErrorMatches, cmd.ErrSilent.Error())
+
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockRemoveControllerMachine.*")
+}
@@ -6,13 +6,17 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	actionapi "github.com/juju/juju/api/action"
@@ -32,6 +36,7 @@ type runCommand struct {
 	out      cmd.Output
 	all      bool
 	timeout  time.Duration
+	parallel int
 	machines []string
 	services []string
 	units    []string
@@ -64,10 +69,37 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+--parallel limits the number of targets on which the commands are run at
+the same time. Applications are expanded into their units, and --all into
+the machines of the model, and the commands are started on the next target
+as soon as they have finished on a previous one. By default, the commands
+are started on all the targets at once.
+
+The output of each target is reported as soon as the commands have
+finished on it. With the default format and more than one target, each
+line of output is prefixed by the target it came from. With
+--format=json-lines, the result of each target is written as a JSON object
+on its own line; the yaml and json formats report the results of all the
+targets once they have all finished.
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
+
+Examples:
+
+    juju run --application mysql --machine 0 --unit wordpress/0 uptime
+    juju run --all --parallel 5 --format=json-lines "apt-get -y upgrade"
 `
 
+// runFormatters holds the formatters which may be used to write the
+// results of the run command.
+var runFormatters = map[string]cmd.Formatter{
+	"smart":      cmd.FormatSmart,
+	"yaml":       cmd.FormatYaml,
+	"json":       cmd.FormatJson,
+	"json-lines": cmd.FormatJson,
+}
+
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run",
@@ -78,9 +110,10 @@ func (c *runCommand) Info() *cmd.Info {
 }
 
 func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
-	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	c.out.AddFlags(f, "smart", runFormatters)
 	f.BoolVar(&c.all, "all", false, "Run the commands on all the machines")
 	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait before the remote command is considered to have failed")
+	f.IntVar(&c.parallel, "parallel", 0, "Run the commands on at most this many targets at a time (0 for no limit)")
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
@@ -92,6 +125,10 @@ func (c *runCommand) Init(args []string) error {
 	}
 	c.commands, args = args[0], args[1:]
 
+	if c.parallel < 0 {
+		return fmt.Errorf("--parallel must not be negative")
+	}
+
 	if c.all {
 		if len(c.machines) != 0 {
 			return fmt.Errorf("You cannot specify --all and individual machines")
@@ -187,6 +224,21 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.parallel > 0 {
+		targets, err := c.runTargets()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(targets) == 0 {
+			return errors.New("no targets to run the commands on")
+		}
+		reporter := newRunReporter(ctx, &c.out, len(targets))
+		if err := c.runParallel(client, targets, reporter); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		return reporter.finish()
+	}
+
 	var runResults []params.ActionResult
 	if c.all {
 		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
@@ -205,21 +257,189 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
 
-	actionsToQuery := []actionQuery{}
+	actionsToQuery, warnings := actionQueries(runResults)
+	for _, warning := range warnings {
+		fmt.Fprint(ctx.GetStderr(), warning)
+	}
+	if len(actionsToQuery) == 0 {
+		return errors.New("no actions were successfully enqueued, aborting")
+	}
+
+	reporter := newRunReporter(ctx, &c.out, len(actionsToQuery))
+	index := make(map[names.ActionTag]int)
+	for i, query := range actionsToQuery {
+		index[query.actionTag] = i
+	}
+	err = waitForActions(client, actionsToQuery, func(query actionQuery, result params.ActionResult) {
+		reporter.report(index[query.actionTag], ConvertActionResults(result, query))
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return reporter.finish()
+}
+
+// runTargets returns the parameters with which to run the commands on
+// each target individually. Applications are expanded into their units,
+// and --all into the machines of the model.
+func (c *runCommand) runTargets() ([]params.RunParams, error) {
+	machines := c.machines
+	units := set.NewStrings(c.units...)
+	if c.all || len(c.services) > 0 {
+		client, err := getRunStatusClient(c)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer client.Close()
+		status, err := client.Status(nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if c.all {
+			machines = statusMachineIds(status.Machines)
+		}
+		for _, name := range c.services {
+			application, ok := status.Applications[name]
+			if !ok {
+				return nil, errors.NotFoundf("application %q", name)
+			}
+			for unitName := range application.Units {
+				units.Add(unitName)
+			}
+		}
+	}
+	var targets []params.RunParams
+	for _, machineId := range machines {
+		targets = append(targets, params.RunParams{
+			Commands: c.commands,
+			Timeout:  c.timeout,
+			Machines: []string{machineId},
+		})
+	}
+	for _, unitName := range units.SortedValues() {
+		targets = append(targets, params.RunParams{
+			Commands: c.commands,
+			Timeout:  c.timeout,
+			Units:    []string{unitName},
+		})
+	}
+	return targets, nil
+}
+
+// statusMachineIds returns the ids of the given machines and their
+// containers.
+func statusMachineIds(machines map[string]params.MachineStatus) []string {
+	var ids []string
+	for id, machine := range machines {
+		ids = append(ids, id)
+		ids = append(ids, statusMachineIds(machine.Containers)...)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// runEvent reports the outcome of running the commands on a single
+// target.
+type runEvent struct {
+	index   int
+	value   map[string]interface{}
+	warning string
+	err     error
+}
+
+// runParallel runs the commands on each of the given targets, running
+// them on at most c.parallel targets at a time.
+func (c *runCommand) runParallel(client RunClient, targets []params.RunParams, reporter *runReporter) error {
+	indexes := make(chan int)
+	events := make(chan runEvent)
+	abort := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < c.parallel && i < len(targets); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				runTarget(client, index, targets[index], events, abort)
+			}
+		}()
+	}
+	go func() {
+		defer close(indexes)
+		for i := range targets {
+			select {
+			case indexes <- i:
+			case <-abort:
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	var firstErr error
+	for event := range events {
+		switch {
+		case event.err != nil:
+			if firstErr == nil {
+				firstErr = event.err
+				close(abort)
+			}
+		case event.warning != "":
+			fmt.Fprint(reporter.ctx.GetStderr(), event.warning)
+		default:
+			reporter.report(event.index, event.value)
+		}
+	}
+	return firstErr
+}
+
+// runTarget runs the commands on a single target, sending the outcome
+// to events.
+func runTarget(client RunClient, index int, target params.RunParams, events chan<- runEvent, abort <-chan struct{}) {
+	send := func(event runEvent) {
+		select {
+		case events <- event:
+		case <-abort:
+		}
+	}
+	runResults, err := client.Run(target)
+	if err != nil {
+		send(runEvent{err: err})
+		return
+	}
+	queries, warnings := actionQueries(runResults)
+	for _, warning := range warnings {
+		send(runEvent{warning: warning})
+	}
+	err = waitForActions(client, queries, func(query actionQuery, result params.ActionResult) {
+		send(runEvent{index: index, value: ConvertActionResults(result, query)})
+	})
+	if err != nil {
+		send(runEvent{err: errors.Trace(err)})
+	}
+}
+
+// actionQueries returns the queries for the actions enqueued by a run
+// command, along with warnings for those which could not be enqueued.
+func actionQueries(runResults []params.ActionResult) ([]actionQuery, []string) {
+	var queries []actionQuery
+	var warnings []string
 	for _, result := range runResults {
 		if result.Error != nil {
-			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
+			warnings = append(warnings, fmt.Sprintf("couldn't queue one action: %v", result.Error))
 			continue
 		}
 		actionTag, err := names.ParseActionTag(result.Action.Tag)
 		if err != nil {
-			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver)
+			warnings = append(warnings, fmt.Sprintf("got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver))
 			continue
 		}
 
 		receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
 		if err != nil {
-			fmt.Fprintf(ctx.GetStderr(), "got invalid action receiver tag %v for action %v", result.Action.Receiver, result.Action.Tag)
+			warnings = append(warnings, fmt.Sprintf("got invalid action receiver tag %v for action %v", result.Action.Receiver, result.Action.Tag))
 			continue
 		}
 		var receiverType string
@@ -231,19 +451,75 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		default:
 			receiverType = "ReceiverId"
 		}
-		actionsToQuery = append(actionsToQuery, actionQuery{
+		queries = append(queries, actionQuery{
 			actionTag: actionTag,
 			receiver: actionReceiver{
 				receiverType: receiverType,
 				tag:          receiverTag,
 			}})
 	}
+	return queries, warnings
+}
 
+// waitForActions calls handle with the result of each of the given
+// actions as soon as it finishes. The results are streamed from the
+// controller where possible; otherwise, or if the stream fails, the
+// actions are polled until they have finished.
+func waitForActions(client RunClient, actionsToQuery []actionQuery, handle func(actionQuery, params.ActionResult)) error {
 	if len(actionsToQuery) == 0 {
-		return errors.New("no actions were successfully enqueued, aborting")
+		return nil
 	}
+	tags := make([]names.ActionTag, len(actionsToQuery))
+	for i, query := range actionsToQuery {
+		tags[i] = query.actionTag
+	}
+	stream, err := client.StreamResults(tags)
+	if err != nil {
+		logger.Debugf("polling for action results: %v", err)
+	} else {
+		actionsToQuery, err = readActionResults(stream, actionsToQuery, handle)
+		stream.Close()
+		if err != nil {
+			logger.Debugf("action results stream failed, polling for remaining results: %v", err)
+		}
+	}
+	return pollActions(client, actionsToQuery, handle)
+}
 
-	values := []interface{}{}
+// readActionResults reads the results of the given actions from the
+// stream, returning those actions whose results could not be read.
+func readActionResults(stream actionapi.ResultsStream, actionsToQuery []actionQuery, handle func(actionQuery, params.ActionResult)) ([]actionQuery, error) {
+	pending := make(map[string]actionQuery)
+	for _, query := range actionsToQuery {
+		pending[query.actionTag.String()] = query
+	}
+	for len(pending) > 0 {
+		result, err := stream.Next()
+		if err != nil {
+			var remaining []actionQuery
+			for _, query := range actionsToQuery {
+				if _, ok := pending[query.actionTag.String()]; ok {
+					remaining = append(remaining, query)
+				}
+			}
+			return remaining, errors.Trace(err)
+		}
+		if result.Action == nil {
+			continue
+		}
+		query, ok := pending[result.Action.Tag]
+		if !ok {
+			continue
+		}
+		delete(pending, result.Action.Tag)
+		handle(query, result)
+	}
+	return nil, nil
+}
+
+// pollActions polls the given actions until they have all finished,
+// calling handle with the result of each.
+func pollActions(client RunClient, actionsToQuery []actionQuery, handle func(actionQuery, params.ActionResult)) error {
 	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
 		if err != nil {
@@ -259,20 +535,76 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 					continue
 				}
 			}
-
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			handle(actionsToQuery[i], result)
 		}
 
 		actionsToQuery = newActionsToQuery
+		if len(actionsToQuery) == 0 {
+			break
+		}
 
 		// TODO: use a watcher instead of sleeping
 		// this should be easier once we implement action grouping
 		<-afterFunc(1 * time.Second)
 	}
+	return nil
+}
+
+// runReporter writes the results of a run command. When there are
+// several targets, the output of each is written as soon as it has
+// finished, either as a line of JSON (with --format json-lines) or,
+// with the smart format, with each line prefixed by the target. The
+// yaml and json formats write the results of all targets once they
+// have all finished.
+type runReporter struct {
+	ctx     *cmd.Context
+	out     *cmd.Output
+	targets int
+	values  map[int]interface{}
+}
+
+func newRunReporter(ctx *cmd.Context, out *cmd.Output, targets int) *runReporter {
+	return &runReporter{
+		ctx:     ctx,
+		out:     out,
+		targets: targets,
+		values:  make(map[int]interface{}),
+	}
+}
+
+// report records the result of the target with the given index.
+func (r *runReporter) report(index int, value map[string]interface{}) {
+	switch format := r.out.Name(); {
+	case format == "json-lines":
+		if err := r.out.Write(r.ctx, value); err != nil {
+			logger.Errorf("cannot write result: %v", err)
+		}
+	case format == "smart" && r.targets > 1:
+		writeTargetOutput(r.ctx, value)
+	default:
+		r.values[index] = value
+	}
+}
+
+// finish writes any results which were held back until all the targets
+// had finished.
+func (r *runReporter) finish() error {
+	if len(r.values) == 0 {
+		return nil
+	}
+	indexes := make([]int, 0, len(r.values))
+	for index := range r.values {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	values := make([]interface{}, len(indexes))
+	for i, index := range indexes {
+		values[i] = r.values[index]
+	}
 
 	// If we are just dealing with one result, AND we are using the smart
 	// format, then pretend we were running it locally.
-	if len(values) == 1 && c.out.Name() == "smart" {
+	if len(values) == 1 && r.out.Name() == "smart" {
 		result, ok := values[0].(map[string]interface{})
 		if !ok {
 			return errors.New("couldn't read action output")
@@ -280,20 +612,53 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		if res, ok := result["Error"].(string); ok {
 			return errors.New(res)
 		}
-		ctx.Stdout.Write(formatOutput(result, "Stdout"))
-		ctx.Stderr.Write(formatOutput(result, "Stderr"))
+		r.ctx.Stdout.Write(formatOutput(result, "Stdout"))
+		r.ctx.Stderr.Write(formatOutput(result, "Stderr"))
 		if code, ok := result["ReturnCode"].(int); ok && code != 0 {
 			return cmd.NewRcPassthroughError(code)
 		}
 		// Message should always contain only errors.
 		if res, ok := result["Message"].(string); ok && res != "" {
-			ctx.Stderr.Write([]byte(res))
+			r.ctx.Stderr.Write([]byte(res))
 		}
 
 		return nil
 	}
 
-	return c.out.Write(ctx, values)
+	return r.out.Write(r.ctx, values)
+}
+
+// writeTargetOutput writes the output of a single target, prefixing
+// each line with the target's id.
+func writeTargetOutput(ctx *cmd.Context, result map[string]interface{}) {
+	var target string
+	for _, key := range []string{"UnitId", "MachineId", "ReceiverId"} {
+		if id, ok := result[key].(string); ok {
+			target = id
+			break
+		}
+	}
+	if res, ok := result["Error"].(string); ok {
+		fmt.Fprintf(ctx.Stderr, "%s: error: %s\n", target, res)
+		return
+	}
+	writePrefixedLines(ctx.Stdout, target, formatOutput(result, "Stdout"))
+	writePrefixedLines(ctx.Stderr, target, formatOutput(result, "Stderr"))
+	if res, ok := result["Message"].(string); ok && res != "" {
+		writePrefixedLines(ctx.Stderr, target, []byte(res))
+	}
+	if code, ok := result["ReturnCode"].(int); ok && code != 0 {
+		fmt.Fprintf(ctx.Stderr, "%s: exit status %d\n", target, code)
+	}
+}
+
+func writePrefixedLines(w io.Writer, prefix string, output []byte) {
+	if len(output) == 0 {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(string(output), "\n"), "\n") {
+		fmt.Fprintf(w, "%s: %s\n", prefix, line)
+	}
 }
 
 type actionReceiver struct {
@@ -311,6 +676,7 @@ type RunClient interface {
 	action.APIClient
 	RunOnAllMachines(commands string, timeout time.Duration) ([]params.ActionResult, error)
 	Run(params.RunParams) ([]params.ActionResult, error)
+	StreamResults([]names.ActionTag) (actionapi.ResultsStream, error)
 }
 
 // In order to be able to easily mock out the API side for testing,
@@ -323,6 +689,18 @@ var getRunAPIClient = func(c *runCommand) (RunClient, error) {
 	return actionapi.NewClient(root), errors.Trace(err)
 }
 
+// runStatusClient defines the API method used to find the units of
+// applications, and the machines of the model, when running commands
+// on a limited number of targets at a time.
+type runStatusClient interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+var getRunStatusClient = func(c *runCommand) (runStatusClient, error) {
+	return c.NewAPIClient()
+}
+
 // getActionResult abstracts over the action CLI function that we use here to fetch results
 var getActionResult = func(c RunClient, actionId string, wait *time.Timer) (params.ActionResult, error) {
 	return action.GetActionResult(c, actionId, wait)
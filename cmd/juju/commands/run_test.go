@@ -5,17 +5,22 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/utils/exec"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	actionapi "github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
@@ -401,6 +406,136 @@ func (s *RunSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func (*RunSuite) TestParallelArgParsing(c *gc.C) {
+	cmd := &runCommand{}
+	testing.TestInit(c, modelcmd.Wrap(cmd), []string{"--parallel=3", "--all", "uptime"}, "")
+	c.Check(cmd.parallel, gc.Equals, 3)
+
+	testing.TestInit(c, modelcmd.Wrap(&runCommand{}), []string{"--parallel=-1", "--all", "uptime"},
+		"--parallel must not be negative")
+}
+
+func (s *RunSuite) setupStreamedResponses(c *gc.C) *mockRunAPI {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{
+		stdout:     "megatron\n",
+		machineTag: "machine-0",
+	})
+	mock.setResponse("unit/0", mockResponse{
+		stdout:  "bumblebee\nstarscream\n",
+		stderr:  "oops",
+		code:    "2",
+		unitTag: "unit-unit-0",
+	})
+	// The unit finishes first.
+	mock.streamResults = []params.ActionResult{
+		mock.runResponses["unit/0"],
+		mock.runResponses["0"],
+	}
+	return mock
+}
+
+func (s *RunSuite) TestStreamedSmartOutput(c *gc.C) {
+	s.setupStreamedResponses(c)
+	context, err := testing.RunCommand(c, newRunCommand(), "--machine=0", "--unit=unit/0", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, `
+unit/0: bumblebee
+unit/0: starscream
+0: megatron
+`[1:])
+	c.Check(testing.Stderr(context), gc.Equals, `
+unit/0: oops
+unit/0: exit status 2
+`[1:])
+}
+
+func (s *RunSuite) TestStreamedJSONLines(c *gc.C) {
+	mock := s.setupStreamedResponses(c)
+	context, err := testing.RunCommand(c, newRunCommand(), "--format=json-lines", "--machine=0", "--unit=unit/0", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitQuery := makeActionQuery(mock.receiverIdMap["unit/0"], "UnitId", names.NewUnitTag("unit/0"))
+	machineQuery := makeActionQuery(mock.receiverIdMap["0"], "MachineId", names.NewMachineTag("0"))
+	unitJSON, err := cmd.FormatJson(ConvertActionResults(mock.runResponses["unit/0"], unitQuery))
+	c.Assert(err, jc.ErrorIsNil)
+	machineJSON, err := cmd.FormatJson(ConvertActionResults(mock.runResponses["0"], machineQuery))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, string(unitJSON)+"\n"+string(machineJSON)+"\n")
+}
+
+func (s *RunSuite) TestStreamedJSONInTargetOrder(c *gc.C) {
+	mock := s.setupStreamedResponses(c)
+	context, err := testing.RunCommand(c, newRunCommand(), "--format=json", "--machine=0", "--unit=unit/0", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+
+	machineQuery := makeActionQuery(mock.receiverIdMap["0"], "MachineId", names.NewMachineTag("0"))
+	unitQuery := makeActionQuery(mock.receiverIdMap["unit/0"], "UnitId", names.NewUnitTag("unit/0"))
+	jsonFormatted, err := cmd.FormatJson([]interface{}{
+		ConvertActionResults(mock.runResponses["0"], machineQuery),
+		ConvertActionResults(mock.runResponses["unit/0"], unitQuery),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, string(jsonFormatted)+"\n")
+}
+
+func (s *RunSuite) TestParallel(c *gc.C) {
+	mock := s.setupMockAPI()
+	for _, id := range []string{"0", "0/lxd/0", "1"} {
+		mock.setResponse(id, mockResponse{stdout: "machine " + id + "\n", machineTag: names.NewMachineTag(id).String()})
+	}
+	mock.streamResults = []params.ActionResult{
+		mock.runResponses["1"],
+		mock.runResponses["0/lxd/0"],
+		mock.runResponses["0"],
+	}
+	s.PatchValue(&getRunStatusClient, func(*runCommand) (runStatusClient, error) {
+		return &mockRunStatusAPI{status: &params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"0": {Containers: map[string]params.MachineStatus{"0/lxd/0": {}}},
+				"1": {},
+			},
+		}}, nil
+	})
+
+	context, err := testing.RunCommand(c, newRunCommand(), "--format=yaml", "--parallel=2", "--all", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Each target is run individually.
+	sort.Sort(runParamsByMachine(mock.runCalls))
+	c.Check(mock.runCalls, jc.DeepEquals, []params.RunParams{
+		{Commands: "hostname", Timeout: 5 * time.Minute, Machines: []string{"0"}},
+		{Commands: "hostname", Timeout: 5 * time.Minute, Machines: []string{"0/lxd/0"}},
+		{Commands: "hostname", Timeout: 5 * time.Minute, Machines: []string{"1"}},
+	})
+	var values []interface{}
+	for _, id := range []string{"0", "0/lxd/0", "1"} {
+		query := makeActionQuery(mock.receiverIdMap[id], "MachineId", names.NewMachineTag(id))
+		values = append(values, ConvertActionResults(mock.runResponses[id], query))
+	}
+	yamlFormatted, err := cmd.FormatYaml(values)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, string(yamlFormatted)+"\n")
+}
+
+func (s *RunSuite) TestParallelUnknownApplication(c *gc.C) {
+	s.setupMockAPI()
+	s.PatchValue(&getRunStatusClient, func(*runCommand) (runStatusClient, error) {
+		return &mockRunStatusAPI{status: &params.FullStatus{}}, nil
+	})
+	_, err := testing.RunCommand(c, newRunCommand(), "--parallel=2", "--application=mysql", "hostname")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
+
+func (s *RunSuite) TestBlockParallel(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.block = true
+	_, err := testing.RunCommand(c, newRunCommand(), "--parallel=1", "--machine=0,1", "hostname")
+	c.Assert(err, gc.ErrorMatches, cmd.ErrSilent.Error())
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*To unblock changes.*")
+}
+
 func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	mock := &mockRunAPI{}
 	s.PatchValue(&getRunAPIClient, func(_ *runCommand) (RunClient, error) {
@@ -420,6 +555,13 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+
+	// streamResults, if set, holds the results returned by
+	// StreamResults; otherwise results must be polled.
+	streamResults []params.ActionResult
+
+	mu       sync.Mutex
+	runCalls []params.RunParams
 }
 
 type mockResponse struct {
@@ -525,6 +667,9 @@ func (m *mockRunAPI) RunOnAllMachines(commands string, timeout time.Duration) ([
 
 func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, error) {
 	var result []params.ActionResult
+	m.mu.Lock()
+	m.runCalls = append(m.runCalls, runParams)
+	m.mu.Unlock()
 
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
@@ -566,5 +711,57 @@ func (m *mockRunAPI) Actions(actionTags params.Entities) (params.ActionResults,
 	return results, nil
 }
 
+func (m *mockRunAPI) StreamResults(actionTags []names.ActionTag) (actionapi.ResultsStream, error) {
+	if m.streamResults == nil {
+		return nil, errors.NotSupportedf("streaming action results")
+	}
+	requested := set.NewStrings()
+	for _, tag := range actionTags {
+		requested.Add(tag.String())
+	}
+	stream := &mockResultsStream{}
+	for _, result := range m.streamResults {
+		if requested.Contains(result.Action.Tag) {
+			stream.results = append(stream.results, result)
+		}
+	}
+	return stream, nil
+}
+
+type mockResultsStream struct {
+	results []params.ActionResult
+}
+
+func (s *mockResultsStream) Next() (params.ActionResult, error) {
+	if len(s.results) == 0 {
+		return params.ActionResult{}, io.EOF
+	}
+	result := s.results[0]
+	s.results = s.results[1:]
+	return result, nil
+}
+
+func (s *mockResultsStream) Close() error {
+	return nil
+}
+
+type runParamsByMachine []params.RunParams
+
+func (r runParamsByMachine) Len() int           { return len(r) }
+func (r runParamsByMachine) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r runParamsByMachine) Less(i, j int) bool { return r[i].Machines[0] < r[j].Machines[0] }
+
+type mockRunStatusAPI struct {
+	status *params.FullStatus
+}
+
+func (m *mockRunStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return m.status, nil
+}
+
+func (m *mockRunStatusAPI) Close() error {
+	return nil
+}
+
 // validUUID is a UUID used in tests
 var validUUID = "01234567-89ab-cdef-0123-456789abcdef"
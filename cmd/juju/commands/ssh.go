@@ -29,6 +29,11 @@ The SSH host keys of the target are verified. The --no-host-key-checks option
 can be used to disable these checks. Use of this option is not recommended as
 it opens up the possibility of a man-in-the-middle attack.
 
+Machines without an address the client can reach, such as those behind NAT,
+can be connected to with '--proxy-api'. The SSH connection is then relayed by
+the controller over the existing API connection, so only the controller needs
+to be able to reach the machine. This requires admin access to the model.
+
 Examples:
 Connect to machine 0:
 
@@ -46,8 +51,13 @@ Connect to a jenkins unit as user jenkins:
 
     juju ssh jenkins@jenkins/0
 
+Connect to machine 2 through the API connection:
+
+    juju ssh --proxy-api 2
+
 See also: 
-    scp`
+    scp
+    ssh-tunnel`
 
 func newSSHCommand() cmd.Command {
 	return modelcmd.Wrap(&sshCommand{})
@@ -30,6 +30,7 @@ import (
 type SSHCommon struct {
 	modelcmd.ModelCommandBase
 	proxy           bool
+	proxyAPI        bool
 	pty             bool
 	noHostKeyChecks bool
 	Target          string
@@ -90,6 +91,7 @@ var sshHostFromTargetAttemptStrategy attemptStarter = attemptStrategy{
 
 func (c *SSHCommon) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.proxy, "proxy", false, "Proxy through the API server")
+	f.BoolVar(&c.proxyAPI, "proxy-api", false, "Tunnel through the API connection, for machines without a reachable address")
 	f.BoolVar(&c.pty, "pty", true, "Enable pseudo-tty allocation")
 	f.BoolVar(&c.noHostKeyChecks, "no-host-key-checks", false, "Skip host key checking (INSECURE)")
 }
@@ -101,9 +103,17 @@ func (c *SSHCommon) SetFlags(f *gnuflag.FlagSet) {
 // The apiClient, apiAddr and proxy fields are initialized after this
 // call.
 func (c *SSHCommon) initRun() error {
+	if c.proxy && c.proxyAPI {
+		return errors.New("cannot use --proxy and --proxy-api together")
+	}
 	if err := c.ensureAPIClient(); err != nil {
 		return errors.Trace(err)
 	}
+	if c.proxyAPI {
+		// The tunnel through the API connection replaces any
+		// proxying through the controller host.
+		return nil
+	}
 	if proxy, err := c.proxySSH(); err != nil {
 		return errors.Trace(err)
 	} else {
@@ -166,6 +176,11 @@ func (c *SSHCommon) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (*
 			return nil, err
 		}
 	}
+	if c.proxyAPI {
+		if err := c.setTunnelCommand(&options); err != nil {
+			return nil, err
+		}
+	}
 
 	return &options, nil
 }
@@ -256,6 +271,21 @@ func (c *SSHCommon) setProxyCommand(options *ssh.Options) error {
 	return nil
 }
 
+// setTunnelCommand sets the proxy command option to relay the SSH
+// connection through the API connection, using "juju ssh-tunnel".
+func (c *SSHCommon) setTunnelCommand(options *ssh.Options) error {
+	juju, err := getJujuExecutable()
+	if err != nil {
+		return fmt.Errorf("failed to get juju executable path: %v", err)
+	}
+	options.SetProxyCommand(
+		juju, "ssh-tunnel",
+		"-m", c.ControllerName()+":"+c.ModelName(),
+		"%h",
+	)
+	return nil
+}
+
 func (c *SSHCommon) ensureAPIClient() error {
 	if c.apiClient != nil {
 		return nil
@@ -294,7 +324,7 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 	// a loop.
 	var err error
 	for a := sshHostFromTargetAttemptStrategy.Start(); a.Next(); {
-		if c.proxy {
+		if c.proxy || c.proxyAPI {
 			out.host, err = c.apiClient.PrivateAddress(out.entity)
 		} else {
 			out.host, err = c.apiClient.PublicAddress(out.entity)
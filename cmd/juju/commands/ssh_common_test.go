@@ -30,6 +30,10 @@ type argsSpec struct {
 	// expected.
 	withProxy bool
 
+	// withTunnel specifies if the "juju ssh-tunnel" ProxyCommand
+	// option is expected.
+	withTunnel bool
+
 	// enablePty specifies if the forced PTY allocation switches are
 	// expected.
 	enablePty bool
@@ -69,6 +73,9 @@ func (s *argsSpec) check(c *gc.C, output string) {
 		expect("-o ProxyCommand juju ssh --proxy=false --no-host-key-checks " +
 			"--pty=false ubuntu@localhost -q \"nc %h %p\"")
 	}
+	if s.withTunnel {
+		expect("-o ProxyCommand juju ssh-tunnel -m \\S+ %h")
+	}
 	expect("-o PasswordAuthentication no -o ServerAliveInterval 30")
 	if s.enablePty {
 		expect("-t -t")
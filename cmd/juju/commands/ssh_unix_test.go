@@ -127,6 +127,22 @@ var sshTests = []struct {
 			args:            "ubuntu@0.private",
 		},
 	},
+	{
+		about: "connect to unit mysql/0 through the API connection",
+		args:  []string{"--proxy-api", "mysql/0"},
+		expected: argsSpec{
+			hostKeyChecking: "yes",
+			knownHosts:      "0",
+			enablePty:       true,
+			withTunnel:      true,
+			args:            "ubuntu@0.private",
+		},
+	},
+	{
+		about:       "connect with both --proxy and --proxy-api",
+		args:        []string{"--proxy", "--proxy-api", "mysql/0"},
+		expectedErr: "cannot use --proxy and --proxy-api together",
+	},
 }
 
 func (s *SSHSuite) TestSSHCommand(c *gc.C) {
@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/sshclient"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSSHTunnelSummary = `
+Relays an SSH connection to a machine through the controller.`[1:]
+
+var usageSSHTunnelDetails = `
+Connects standard input and output to the SSH port of the machine with the
+given address, relaying the connection over the API connection to the
+controller. Only model administrators may open a tunnel, and only to the
+addresses of machines in the model.
+
+This command is used as the SSH ProxyCommand by "juju ssh --proxy-api" and
+"juju scp --proxy-api", to reach machines which have no address the client
+can connect to, such as those behind NAT. It is not normally run directly.
+
+Examples:
+
+    ssh -o ProxyCommand="juju ssh-tunnel %h" ubuntu@10.0.0.5
+
+See also:
+    ssh
+    scp`
+
+func newSSHTunnelCommand() cmd.Command {
+	return modelcmd.Wrap(&sshTunnelCommand{})
+}
+
+// sshTunnelCommand relays standard input and output to the SSH port
+// of a machine, through the API connection.
+type sshTunnelCommand struct {
+	modelcmd.ModelCommandBase
+	address string
+	api     sshTunnelAPI
+}
+
+type sshTunnelAPI interface {
+	OpenTunnel(address string) (io.ReadWriteCloser, error)
+	Close() error
+}
+
+func (c *sshTunnelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "ssh-tunnel",
+		Args:    "<address>",
+		Purpose: usageSSHTunnelSummary,
+		Doc:     usageSSHTunnelDetails,
+	}
+}
+
+func (c *sshTunnelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no address specified")
+	}
+	c.address, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *sshTunnelCommand) getAPI() (sshTunnelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sshclient.NewFacade(root), nil
+}
+
+// Run opens a tunnel to the machine and copies data between it and
+// standard input and output until the machine closes the connection.
+func (c *sshTunnelCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	tunnel, err := client.OpenTunnel(c.address)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer tunnel.Close()
+
+	// The end of standard input does not end the connection, as
+	// the websocket cannot be half closed; ssh will stop this
+	// command once it has finished with the connection.
+	go io.Copy(tunnel, ctx.Stdin)
+	_, err = io.Copy(ctx.Stdout, tunnel)
+	return errors.Trace(err)
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testing"
+)
+
+type SSHTunnelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&SSHTunnelSuite{})
+
+func (s *SSHTunnelSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		errMatch: "no address specified",
+	}, {
+		args:     []string{"10.0.0.1", "22"},
+		errMatch: `unrecognized args: \["22"\]`,
+	}, {
+		args: []string{"10.0.0.1"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := &sshTunnelCommand{}
+		err := testing.InitCommand(modelcmd.Wrap(command), test.args)
+		if test.errMatch != "" {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(command.address, gc.Equals, "10.0.0.1")
+	}
+}
+
+func (s *SSHTunnelSuite) TestRelay(c *gc.C) {
+	tunnel := &fakeTunnel{
+		Reader: strings.NewReader("SSH-2.0-OpenSSH\r\n"),
+	}
+	api := &fakeSSHTunnelAPI{tunnel: tunnel}
+	ctx := testing.Context(c)
+	stdin, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+	ctx.Stdin = stdin
+
+	command := modelcmd.Wrap(&sshTunnelCommand{api: api})
+	c.Assert(testing.InitCommand(command, []string{"10.0.0.1"}), jc.ErrorIsNil)
+	err := command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stdout(ctx), gc.Equals, "SSH-2.0-OpenSSH\r\n")
+	c.Check(api.address, gc.Equals, "10.0.0.1")
+	c.Check(tunnel.closed, jc.IsTrue)
+	c.Check(api.closed, jc.IsTrue)
+}
+
+func (s *SSHTunnelSuite) TestOpenTunnelError(c *gc.C) {
+	api := &fakeSSHTunnelAPI{err: errors.New("permission denied")}
+	command := modelcmd.Wrap(&sshTunnelCommand{api: api})
+	_, err := testing.RunCommand(c, command, "10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Check(api.closed, jc.IsTrue)
+}
+
+type fakeSSHTunnelAPI struct {
+	tunnel  io.ReadWriteCloser
+	err     error
+	address string
+	closed  bool
+}
+
+func (f *fakeSSHTunnelAPI) OpenTunnel(address string) (io.ReadWriteCloser, error) {
+	f.address = address
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.tunnel, nil
+}
+
+func (f *fakeSSHTunnelAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+type fakeTunnel struct {
+	io.Reader
+	closed bool
+}
+
+func (t *fakeTunnel) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (t *fakeTunnel) Close() error {
+	t.closed = true
+	return nil
+}
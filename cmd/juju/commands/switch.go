@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
@@ -29,6 +30,7 @@ type switchCommand struct {
 
 	Store  jujuclient.ClientStore
 	Target string
+	Local  bool
 }
 
 var usageSummary = `
@@ -44,11 +46,21 @@ the controller and then to the model.
 The `[1:] + "`juju models`" + ` command can be used to determine the active model
 (of any controller). An asterisk denotes it.
 
+A model may also be selected for a single directory tree by switching with
+--local, which records the model in a .juju-model file in the current
+directory. Commands run in that directory, or any directory beneath it,
+operate on the recorded model instead of the current model. The file may be
+committed alongside a project so that everyone working on it targets the
+same model; if the model is named by an alias (see set-model-alias), each
+user may map the alias to their own model. Setting JUJU_MODEL overrides any
+.juju-model file.
+
 Examples:
     juju switch
     juju switch mymodel
     juju switch mycontroller
     juju switch mycontroller:mymodel
+    juju switch --local mymodel
 
 See also: 
     controllers
@@ -64,10 +76,20 @@ func (c *switchCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *switchCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Local, "local", false, "Select the model for the current directory only")
+}
+
 func (c *switchCommand) Init(args []string) error {
 	var err error
 	c.Target, err = cmd.ZeroOrOneArgs(args)
-	return err
+	if err != nil {
+		return err
+	}
+	if c.Local && c.Target == "" {
+		return errors.New("--local requires a model to be specified")
+	}
+	return nil
 }
 
 func (c *switchCommand) Run(ctx *cmd.Context) (resultErr error) {
@@ -81,11 +103,21 @@ func (c *switchCommand) Run(ctx *cmd.Context) (resultErr error) {
 	} else if err != nil {
 		return errors.Trace(err)
 	}
+	localModel, localModelPath, err := findLocalModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	if c.Target == "" {
 		currentName, err := c.name(store, currentControllerName, true)
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if localModel != "" {
+			currentName, err = resolveModelName(store, localModel, currentControllerName)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
 		if currentName == "" {
 			return errors.New("no currently specified model")
 		}
@@ -96,6 +128,12 @@ func (c *switchCommand) Run(ctx *cmd.Context) (resultErr error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.Local && localModel != "" {
+		currentName, err = resolveModelName(store, localModel, currentControllerName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	var newName string
 	defer func() {
@@ -103,6 +141,9 @@ func (c *switchCommand) Run(ctx *cmd.Context) (resultErr error) {
 			return
 		}
 		logSwitch(ctx, currentName, &newName)
+		if !c.Local && localModel != "" {
+			ctx.Infof("warning: %s selects model %q for this directory", localModelPath, localModel)
+		}
 	}()
 
 	// Switch is an alternative way of dealing with environments than using
@@ -116,6 +157,9 @@ func (c *switchCommand) Run(ctx *cmd.Context) (resultErr error) {
 	// If the target identifies a controller, then set that as the current controller.
 	var newControllerName = c.Target
 	if _, err = store.ControllerByName(c.Target); err == nil {
+		if c.Local {
+			return errors.Errorf("cannot switch to controller %q with --local; specify a model", c.Target)
+		}
 		if newControllerName == currentControllerName {
 			newName = currentName
 			return nil
@@ -133,8 +177,13 @@ func (c *switchCommand) Run(ctx *cmd.Context) (resultErr error) {
 	// The target is not a controller, so check for a model with
 	// the given name. The name can be qualified with the controller
 	// name (<controller>:<model>), or unqualified; in the latter
-	// case, the model must exist in the current controller.
-	newControllerName, modelName := modelcmd.SplitModelName(c.Target)
+	// case, the model must exist in the current controller unless
+	// the name is a model alias.
+	target, isAlias, err := resolveModelAlias(store, c.Target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newControllerName, modelName := modelcmd.SplitModelName(target)
 	if newControllerName != "" {
 		if _, err = store.ControllerByName(newControllerName); err != nil {
 			return errors.Trace(err)
@@ -151,6 +200,23 @@ func (c *switchCommand) Run(ctx *cmd.Context) (resultErr error) {
 	}
 	newName = modelcmd.JoinModelName(newControllerName, modelName)
 
+	if c.Local {
+		if err := c.checkModelExists(store, newControllerName, modelName); err != nil {
+			return errors.Trace(err)
+		}
+		// Record aliases as they are, so that the file means
+		// the same thing to everyone that shares it.
+		recorded := newName
+		if isAlias {
+			recorded = c.Target
+		}
+		dir, err := os.Getwd()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(modelcmd.WriteLocalModel(dir, recorded))
+	}
+
 	err = store.SetCurrentModel(newControllerName, modelName)
 	if errors.IsNotFound(err) {
 		// The model isn't known locally, so we must query the controller.
@@ -174,6 +240,76 @@ func (c *switchCommand) Run(ctx *cmd.Context) (resultErr error) {
 	return nil
 }
 
+// checkModelExists returns an error if the specified model is not
+// known, either locally or by the controller.
+func (c *switchCommand) checkModelExists(store jujuclient.ClientStore, controllerName, modelName string) error {
+	_, err := store.ModelByName(controllerName, modelName)
+	if errors.IsNotFound(err) {
+		// The model isn't known locally, so we must query the controller.
+		if err := c.RefreshModels(store, controllerName); err != nil {
+			return errors.Annotate(err, "refreshing models cache")
+		}
+		_, err = store.ModelByName(controllerName, modelName)
+		if errors.IsNotFound(err) {
+			return unknownSwitchTargetError(c.Target)
+		}
+	}
+	return errors.Trace(err)
+}
+
+// findLocalModel returns the model named in the local model file
+// governing the current directory, and the file's path, or empty
+// strings if there is none.
+func findLocalModel() (modelName, path string, _ error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	modelName, path, err = modelcmd.FindLocalModel(dir)
+	if errors.IsNotFound(err) {
+		return "", "", nil
+	}
+	return modelName, path, errors.Trace(err)
+}
+
+// resolveModelAlias returns the "<controller>:<model>" name of the
+// model to which the given name refers if it is a model alias, or the
+// name unchanged otherwise.
+func resolveModelAlias(store jujuclient.ModelAliasGetter, name string) (string, bool, error) {
+	if jujuclient.ValidateModelAlias(name) != nil {
+		return name, false, nil
+	}
+	controllerName, modelName, err := store.ModelAlias(name)
+	if errors.IsNotFound(err) {
+		return name, false, nil
+	} else if err != nil {
+		return "", false, errors.Trace(err)
+	}
+	return modelcmd.JoinModelName(controllerName, modelName), true, nil
+}
+
+// resolveModelName returns the fully qualified "<controller>:<model>"
+// name of the model with the given name, which may be a model alias
+// or a model in the current controller.
+func resolveModelName(store modelcmd.QualifyingClientStore, name, currentControllerName string) (string, error) {
+	name, _, err := resolveModelAlias(store, name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	controllerName, modelName := modelcmd.SplitModelName(name)
+	if controllerName == "" {
+		if currentControllerName == "" {
+			return name, nil
+		}
+		controllerName = currentControllerName
+	}
+	modelName, err = store.QualifiedModelName(controllerName, modelName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return modelcmd.JoinModelName(controllerName, modelName), nil
+}
+
 func unknownSwitchTargetError(name string) error {
 	return errors.Errorf("%q is not the name of a model or controller", name)
 }
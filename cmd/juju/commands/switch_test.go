@@ -5,7 +5,9 @@ package commands
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/juju/cmd"
 	"github.com/juju/testing"
@@ -140,6 +142,7 @@ func (s *SwitchSimpleSuite) TestSwitchControllerToModel(c *gc.C) {
 		{"CurrentController", nil},
 		{"CurrentModel", []interface{}{"ctrl"}},
 		{"ControllerByName", []interface{}{"mymodel"}},
+		{"ModelAlias", []interface{}{"mymodel"}},
 		{"AccountDetails", []interface{}{"ctrl"}},
 		{"SetCurrentModel", []interface{}{"ctrl", "admin@local/mymodel"}},
 	})
@@ -231,6 +234,7 @@ func (s *SwitchSimpleSuite) TestSwitchUnknownNoCurrentController(c *gc.C) {
 	s.stubStore.CheckCalls(c, []testing.StubCall{
 		{"CurrentController", nil},
 		{"ControllerByName", []interface{}{"unknown"}},
+		{"ModelAlias", []interface{}{"unknown"}},
 	})
 }
 
@@ -276,6 +280,111 @@ func (s *SwitchSimpleSuite) TestTooManyParams(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: ."bar".`)
 }
 
+func (s *SwitchSimpleSuite) TestSwitchModelAlias(c *gc.C) {
+	s.store.CurrentControllerName = "old"
+	s.addController(c, "new")
+	s.store.Models["new"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{"bob@local/mymodel": {}},
+	}
+	s.store.ModelAliases["mine"] = jujuclient.ModelAlias{Controller: "new", Model: "bob@local/mymodel"}
+	context, err := s.run(c, "mine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(context), gc.Equals, "old (controller) -> new:bob@local/mymodel\n")
+	c.Assert(s.store.CurrentControllerName, gc.Equals, "new")
+	c.Assert(s.store.Models["new"].CurrentModel, gc.Equals, "bob@local/mymodel")
+}
+
+func (s *SwitchSimpleSuite) TestSwitchLocalRequiresModel(c *gc.C) {
+	_, err := s.run(c, "--local")
+	c.Assert(err, gc.ErrorMatches, "--local requires a model to be specified")
+}
+
+func (s *SwitchSimpleSuite) TestSwitchLocalController(c *gc.C) {
+	s.addController(c, "ctrl")
+	s.chdir(c, c.MkDir())
+	_, err := s.run(c, "--local", "ctrl")
+	c.Assert(err, gc.ErrorMatches, `cannot switch to controller "ctrl" with --local; specify a model`)
+}
+
+func (s *SwitchSimpleSuite) TestSwitchLocalModel(c *gc.C) {
+	s.store.CurrentControllerName = "ctrl"
+	s.addController(c, "ctrl")
+	s.store.Models["ctrl"] = &jujuclient.ControllerModels{
+		Models:       map[string]jujuclient.ModelDetails{"admin@local/mymodel": {}, "admin@local/other": {}},
+		CurrentModel: "admin@local/other",
+	}
+	dir := c.MkDir()
+	s.chdir(c, dir)
+	context, err := s.run(c, "--local", "mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(context), gc.Equals, "ctrl:admin@local/other -> ctrl:admin@local/mymodel\n")
+	s.checkLocalModel(c, dir, "ctrl:admin@local/mymodel\n")
+
+	// The current model is unchanged, but is not in effect here.
+	c.Assert(s.store.Models["ctrl"].CurrentModel, gc.Equals, "admin@local/other")
+	context, err = s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(context), gc.Equals, "ctrl:admin@local/mymodel\n")
+}
+
+func (s *SwitchSimpleSuite) TestSwitchLocalModelAlias(c *gc.C) {
+	s.addController(c, "ctrl")
+	s.store.Models["ctrl"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{"bob@local/mymodel": {}},
+	}
+	s.store.ModelAliases["mine"] = jujuclient.ModelAlias{Controller: "ctrl", Model: "bob@local/mymodel"}
+	dir := c.MkDir()
+	s.chdir(c, dir)
+	_, err := s.run(c, "--local", "mine")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkLocalModel(c, dir, "mine\n")
+
+	context, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(context), gc.Equals, "ctrl:bob@local/mymodel\n")
+}
+
+func (s *SwitchSimpleSuite) TestSwitchLocalUnknownModel(c *gc.C) {
+	s.store.CurrentControllerName = "ctrl"
+	s.addController(c, "ctrl")
+	dir := c.MkDir()
+	s.chdir(c, dir)
+	_, err := s.run(c, "--local", "unknown")
+	c.Assert(err, gc.ErrorMatches, `"unknown" is not the name of a model or controller`)
+	s.CheckCallNames(c, "RefreshModels")
+	_, err = os.Stat(filepath.Join(dir, ".juju-model"))
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *SwitchSimpleSuite) TestSwitchWarnsOfLocalModel(c *gc.C) {
+	s.store.CurrentControllerName = "old"
+	s.addController(c, "new")
+	dir := c.MkDir()
+	err := modelcmd.WriteLocalModel(dir, "project")
+	c.Assert(err, jc.ErrorIsNil)
+	s.chdir(c, dir)
+	context, err := s.run(c, "new")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(context), gc.Equals, ""+
+		"old (controller) -> new (controller)\n"+
+		"warning: "+filepath.Join(dir, ".juju-model")+" selects model \"project\" for this directory\n",
+	)
+}
+
+func (s *SwitchSimpleSuite) chdir(c *gc.C, dir string) {
+	wd, err := os.Getwd()
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Chdir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { os.Chdir(wd) })
+}
+
+func (s *SwitchSimpleSuite) checkLocalModel(c *gc.C, dir, expect string) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, ".juju-model"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, expect)
+}
+
 func (s *SwitchSimpleSuite) addController(c *gc.C, name string) {
 	s.store.Controllers[name] = jujuclient.ControllerDetails{}
 	s.store.Accounts[name] = jujuclient.AccountDetails{
@@ -11,16 +11,21 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/api/upgradeprechecks"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/progress"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/sync"
@@ -52,10 +57,27 @@ controllers in a high availability model failed to upgrade).
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
 Backups are recommended prior to upgrading.
+Before upgrading, the command checks that the agents of the model's
+machines are connected, that the controller's database is healthy, that
+each machine has enough free disk space, and that the cloud provider's
+API is reachable. If any check fails the upgrade does not proceed, unless
+'--skip-prechecks' is given; skipping the checks is recorded in the
+controller's audit log.
+To limit the impact of a bad upgrade, '--canary' names the machines to
+upgrade first. The rest of the model keeps running its current version
+until the canary machines and their units have remained healthy for the
+'--soak-period'. The upgrade then proceeds automatically if
+'--auto-proceed' was given; otherwise it waits for the upgrade to be
+confirmed with '--proceed'. A canary upgrade can be abandoned with
+'--abort-canary', which returns the canary machines to the model's version.
 
 Examples:
     juju upgrade-juju --dry-run
     juju upgrade-juju --version 2.0.1
+    juju upgrade-juju --version 2.0.1 --skip-prechecks
+    juju upgrade-juju --canary 3,7 --soak-period 2h
+    juju upgrade-juju --canary 3 --auto-proceed
+    juju upgrade-juju --proceed
     
 See also: 
     sync-tools`
@@ -76,6 +98,18 @@ type upgradeJujuCommand struct {
 	DryRun        bool
 	ResetPrevious bool
 	AssumeYes     bool
+	SkipPrechecks bool
+	Progress      progress.Mode
+
+	// Canary holds the ids of the machines to upgrade first, if
+	// any. The rest of the model is upgraded once they have been
+	// healthy for SoakPeriod.
+	Canary      []string
+	canaryIds   string
+	SoakPeriod  time.Duration
+	AutoProceed bool
+	Proceed     bool
+	AbortCanary bool
 
 	// minMajorUpgradeVersion maps known major numbers to
 	// the minimum version that can be upgraded to that
@@ -99,6 +133,13 @@ func (c *upgradeJujuCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.ResetPrevious, "reset-previous-upgrade", false, "Clear the previous (incomplete) upgrade status (use with care)")
 	f.BoolVar(&c.AssumeYes, "y", false, "Answer 'yes' to confirmation prompts")
 	f.BoolVar(&c.AssumeYes, "yes", false, "")
+	f.BoolVar(&c.SkipPrechecks, "skip-prechecks", false, "Upgrade without first checking that the model is fit to be upgraded")
+	f.StringVar(&c.canaryIds, "canary", "", "Upgrade these machines (comma-separated ids) before the rest of the model")
+	f.DurationVar(&c.SoakPeriod, "soak-period", time.Hour, "How long the canary machines must remain healthy before the rest of the model is upgraded")
+	f.BoolVar(&c.AutoProceed, "auto-proceed", false, "Upgrade the rest of the model as soon as the soak period has passed")
+	f.BoolVar(&c.Proceed, "proceed", false, "Upgrade the rest of the model once the canary machines have soaked")
+	f.BoolVar(&c.AbortCanary, "abort-canary", false, "Abandon the canary upgrade in progress")
+	progress.AddFlag(f, &c.Progress)
 }
 
 func (c *upgradeJujuCommand) Init(args []string) error {
@@ -118,9 +159,41 @@ func (c *upgradeJujuCommand) Init(args []string) error {
 		}
 		c.Version = vers
 	}
+	if err := c.initCanary(); err != nil {
+		return errors.Trace(err)
+	}
 	return cmd.CheckEmpty(args)
 }
 
+func (c *upgradeJujuCommand) initCanary() error {
+	if c.Proceed && c.AbortCanary {
+		return errors.New("cannot specify both --proceed and --abort-canary")
+	}
+	if c.Proceed || c.AbortCanary {
+		if c.vers != "" || c.canaryIds != "" || c.BuildAgent || c.DryRun || c.ResetPrevious || c.SkipPrechecks {
+			return errors.New("--proceed and --abort-canary cannot be combined with other upgrade options")
+		}
+		return nil
+	}
+	if c.canaryIds == "" {
+		if c.AutoProceed {
+			return errors.New("--auto-proceed requires --canary")
+		}
+		return nil
+	}
+	for _, id := range strings.Split(c.canaryIds, ",") {
+		id = strings.TrimSpace(id)
+		if !names.IsValidMachine(id) {
+			return errors.NotValidf("machine id %q", id)
+		}
+		c.Canary = append(c.Canary, id)
+	}
+	if c.SoakPeriod < 0 {
+		return errors.NotValidf("negative soak period")
+	}
+	return nil
+}
+
 var (
 	errUpToDate            = stderrors.New("no upgrades available")
 	downgradeErrMsg        = "cannot change version from %s to %s"
@@ -169,6 +242,10 @@ type upgradeJujuAPI interface {
 	UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (coretools.List, error)
 	AbortCurrentUpgrade() error
 	SetModelAgentVersion(version version.Number) error
+	StartCanaryUpgrade(version version.Number, canary params.CanaryUpgradeParams) error
+	CanaryUpgrade() (params.CanaryUpgradeStatus, error)
+	ProceedCanaryUpgrade() error
+	AbortCanaryUpgrade() error
 	Close() error
 }
 
@@ -181,6 +258,20 @@ var getUpgradeJujuAPI = func(c *upgradeJujuCommand) (upgradeJujuAPI, error) {
 	return c.NewAPIClient()
 }
 
+type upgradePrechecksAPI interface {
+	Prechecks() ([]params.UpgradePrecheck, error)
+	SkipPrechecks(version version.Number) error
+	Close() error
+}
+
+var getUpgradePrechecksAPI = func(c *upgradeJujuCommand) (upgradePrechecksAPI, error) {
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return upgradeprechecks.NewClient(api), nil
+}
+
 var getModelConfigAPI = func(c *upgradeJujuCommand) (modelConfigAPI, error) {
 	api, err := c.NewAPIRoot()
 	if err != nil {
@@ -197,6 +288,9 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 		return err
 	}
 	defer client.Close()
+	if c.Proceed || c.AbortCanary {
+		return c.finishCanaryUpgrade(ctx, client)
+	}
 	modelConfigClient, err := getModelConfigAPI(c)
 	if err != nil {
 		return err
@@ -303,7 +397,10 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	// to be built, upload a local jujud binary if possible.
 	uploadLocalBinary := isControllerModel && tryImplicitUpload(agentVersion)
 	if !warnCompat && (uploadLocalBinary || c.BuildAgent) && !c.DryRun {
-		if err := context.uploadTools(c.BuildAgent); err != nil {
+		reporter := c.Progress.NewReporter(ctx.Stderr, clock.WallClock)
+		err := context.uploadTools(c.BuildAgent, reporter)
+		reporter.Close()
+		if err != nil {
 			// If we've explicitly asked to build an agent binary, or the upload failed
 			// because changes were blocked, we'll return an error.
 			// Otherwise, we'll try and find a pre-packaged upgraded binary to use below.
@@ -326,6 +423,9 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	if c.DryRun {
 		ctx.Infof("upgrade to this version by running\n    juju upgrade-juju --version=\"%s\"\n", context.chosen)
 	} else {
+		if err := c.runPrechecks(ctx, context.chosen); err != nil {
+			return err
+		}
 		if c.ResetPrevious {
 			if ok, err := c.confirmResetPreviousUpgrade(ctx); !ok || err != nil {
 				const message = "previous upgrade not reset and no new upgrade triggered"
@@ -338,7 +438,16 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 				return block.ProcessBlockedError(err, block.BlockChange)
 			}
 		}
-		if err := client.SetModelAgentVersion(context.chosen); err != nil {
+		if len(c.Canary) > 0 {
+			err = client.StartCanaryUpgrade(context.chosen, params.CanaryUpgradeParams{
+				Machines:    c.Canary,
+				SoakPeriod:  c.SoakPeriod,
+				AutoProceed: c.AutoProceed,
+			})
+		} else {
+			err = client.SetModelAgentVersion(context.chosen)
+		}
+		if err != nil {
 			if params.IsCodeUpgradeInProgress(err) {
 				return errors.Errorf("%s\n\n"+
 					"Please wait for the upgrade to complete or if there was a problem with\n"+
@@ -349,7 +458,71 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 				return block.ProcessBlockedError(err, block.BlockChange)
 			}
 		}
-		logger.Infof("started upgrade to %s", context.chosen)
+		if len(c.Canary) == 0 {
+			logger.Infof("started upgrade to %s", context.chosen)
+		} else if c.AutoProceed {
+			ctx.Infof("started upgrade of machines %s to %s; the rest of the model will be upgraded once they have been healthy for %v",
+				strings.Join(c.Canary, ", "), context.chosen, c.SoakPeriod)
+		} else {
+			ctx.Infof("started upgrade of machines %s to %s; once they have been healthy for %v, upgrade the rest of the model by running\n    juju upgrade-juju --proceed",
+				strings.Join(c.Canary, ", "), context.chosen, c.SoakPeriod)
+		}
+	}
+	return nil
+}
+
+// finishCanaryUpgrade proceeds with, or aborts, the canary upgrade in
+// progress.
+func (c *upgradeJujuCommand) finishCanaryUpgrade(ctx *cmd.Context, client upgradeJujuAPI) error {
+	upgrade, err := client.CanaryUpgrade()
+	if params.IsCodeNotFound(err) {
+		return errors.New("no canary upgrade in progress")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if c.AbortCanary {
+		if err := client.AbortCanaryUpgrade(); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ctx.Infof("aborted canary upgrade to %s", upgrade.TargetVersion)
+		return nil
+	}
+	if err := client.ProceedCanaryUpgrade(); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("started upgrade of the rest of the model to %s", upgrade.TargetVersion)
+	return nil
+}
+
+// runPrechecks returns an error if any of the checks made before an
+// upgrade to the given version fails. If the checks are skipped, the
+// controller is told so that it can record it.
+func (c *upgradeJujuCommand) runPrechecks(ctx *cmd.Context, vers version.Number) error {
+	client, err := getUpgradePrechecksAPI(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if c.SkipPrechecks {
+		if err := client.SkipPrechecks(vers); err != nil {
+			return errors.Annotate(err, "cannot record skipped upgrade prechecks")
+		}
+		ctx.Infof("skipping upgrade prechecks")
+		return nil
+	}
+	checks, err := client.Prechecks()
+	if err != nil {
+		return errors.Annotate(err, "cannot run upgrade prechecks")
+	}
+	var failed int
+	for _, check := range checks {
+		if !check.Passed {
+			ctx.Infof("upgrade precheck %q failed: %s", check.Name, check.Message)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("%d upgrade prechecks failed; fix the problems, or upgrade anyway with --skip-prechecks", failed)
 	}
 	return nil
 }
@@ -444,7 +617,7 @@ type upgradeContext struct {
 // than that of any otherwise-matching available envtools.
 // uploadTools resets the chosen version and replaces the available tools
 // with the ones just uploaded.
-func (context *upgradeContext) uploadTools(buildAgent bool) (err error) {
+func (context *upgradeContext) uploadTools(buildAgent bool, reporter progress.Reporter) (err error) {
 	// TODO(fwereade): this is kinda crack: we should not assume that
 	// jujuversion.Current matches whatever source happens to be built. The
 	// ideal would be:
@@ -466,10 +639,12 @@ func (context *upgradeContext) uploadTools(buildAgent bool) (err error) {
 	}
 	context.chosen = uploadVersion(context.chosen, context.tools)
 
+	reporter.Report(progress.Event{Operation: "preparing agent binary"})
 	builtTools, err := sync.BuildAgentTarball(buildAgent, &context.chosen, "upgrade")
 	if err != nil {
 		return errors.Trace(err)
 	}
+	reporter.Report(progress.Event{Operation: "preparing agent binary", Done: true})
 	defer os.RemoveAll(builtTools.Dir)
 
 	uploadToolsVersion := builtTools.Version
@@ -486,7 +661,8 @@ func (context *upgradeContext) uploadTools(buildAgent bool) (err error) {
 		return errors.Trace(err)
 	}
 	additionalSeries := series.OSSupportedSeries(os)
-	uploaded, err := context.apiClient.UploadTools(f, uploadToolsVersion, additionalSeries...)
+	r := progress.NewReader(f, builtTools.Size, "uploading agent binary", reporter)
+	uploaded, err := context.apiClient.UploadTools(r, uploadToolsVersion, additionalSeries...)
 	if err != nil {
 		return errors.Trace(err)
 	}
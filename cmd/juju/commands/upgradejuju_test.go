@@ -10,6 +10,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -116,6 +117,36 @@ var upgradeJujuTests = []struct {
 	currentVersion: "3.2.7-quantal-amd64",
 	args:           []string{"--build-agent", "--version", "3.2.8.4"},
 	expectInitErr:  "cannot specify build number when building an agent",
+}, {
+	about:          "invalid canary machine",
+	currentVersion: "2.0.0-quantal-amd64",
+	args:           []string{"--canary", "1,foo"},
+	expectInitErr:  `machine id "foo" not valid`,
+}, {
+	about:          "negative soak period",
+	currentVersion: "2.0.0-quantal-amd64",
+	args:           []string{"--canary", "1", "--soak-period", "-1h"},
+	expectInitErr:  "negative soak period not valid",
+}, {
+	about:          "--auto-proceed without --canary",
+	currentVersion: "2.0.0-quantal-amd64",
+	args:           []string{"--auto-proceed"},
+	expectInitErr:  "--auto-proceed requires --canary",
+}, {
+	about:          "--proceed and --abort-canary",
+	currentVersion: "2.0.0-quantal-amd64",
+	args:           []string{"--proceed", "--abort-canary"},
+	expectInitErr:  "cannot specify both --proceed and --abort-canary",
+}, {
+	about:          "--proceed with other options",
+	currentVersion: "2.0.0-quantal-amd64",
+	args:           []string{"--proceed", "--version", "2.0.1"},
+	expectInitErr:  "--proceed and --abort-canary cannot be combined with other upgrade options",
+}, {
+	about:          "--abort-canary with --skip-prechecks",
+	currentVersion: "2.0.0-quantal-amd64",
+	args:           []string{"--abort-canary", "--skip-prechecks"},
+	expectInitErr:  "--proceed and --abort-canary cannot be combined with other upgrade options",
 }, {
 	about:          "latest supported stable release",
 	tools:          []string{"2.1.0-quantal-amd64", "2.1.2-quantal-i386", "2.1.3-quantal-amd64", "2.1-dev1-quantal-amd64"},
@@ -911,6 +942,108 @@ func (s *UpgradeJujuSuite) TestResetPreviousUpgrade(c *gc.C) {
 	}
 }
 
+func (s *UpgradeJujuSuite) TestCanaryUpgrade(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--canary", "1, 2", "--soak-period", "2h"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := coretesting.Context(c)
+	err = modelcmd.Wrap(cmd).Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+	c.Assert(fakeAPI.canaryCalledWith, jc.DeepEquals, &params.CanaryUpgradeParams{
+		Machines:   []string{"1", "2"},
+		SoakPeriod: 2 * time.Hour,
+	})
+	c.Assert(fakeAPI.canaryVersion, gc.Equals, fakeAPI.nextVersion.Number)
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "juju upgrade-juju --proceed")
+}
+
+func (s *UpgradeJujuSuite) TestProceedCanaryUpgrade(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.canary = &params.CanaryUpgradeStatus{TargetVersion: version.MustParse("2.0.1")}
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--proceed"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := coretesting.Context(c)
+	err = modelcmd.Wrap(cmd).Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.proceedCalled, jc.IsTrue)
+	c.Assert(fakeAPI.findToolsCalled, jc.IsFalse)
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "started upgrade of the rest of the model to 2.0.1\n")
+}
+
+func (s *UpgradeJujuSuite) TestAbortCanaryUpgrade(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.canary = &params.CanaryUpgradeStatus{TargetVersion: version.MustParse("2.0.1")}
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--abort-canary"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := coretesting.Context(c)
+	err = modelcmd.Wrap(cmd).Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.abortCanaryCalled, jc.IsTrue)
+	c.Assert(fakeAPI.proceedCalled, jc.IsFalse)
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "aborted canary upgrade to 2.0.1\n")
+}
+
+func (s *UpgradeJujuSuite) TestProceedNoCanaryUpgrade(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--proceed"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = modelcmd.Wrap(cmd).Run(coretesting.Context(c))
+	c.Assert(err, gc.ErrorMatches, "no canary upgrade in progress")
+	c.Assert(fakeAPI.proceedCalled, jc.IsFalse)
+}
+
+func (s *UpgradeJujuSuite) TestUpgradePrechecksFail(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.prechecks = []params.UpgradePrecheck{
+		{Name: "agents", Message: "agents not connected on machines 3"},
+		{Name: "database", Passed: true},
+		{Name: "disk", Message: "less than 1024MB free disk space on machines 1 (512 MiB free)"},
+	}
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := coretesting.Context(c)
+	err = modelcmd.Wrap(cmd).Run(ctx)
+	c.Assert(err, gc.ErrorMatches, "2 upgrade prechecks failed; fix the problems, or upgrade anyway with --skip-prechecks")
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+	stderr := coretesting.Stderr(ctx)
+	c.Assert(stderr, jc.Contains, `upgrade precheck "agents" failed: agents not connected on machines 3`)
+	c.Assert(stderr, jc.Contains, `upgrade precheck "disk" failed: less than 1024MB free disk space on machines 1 (512 MiB free)`)
+}
+
+func (s *UpgradeJujuSuite) TestSkipUpgradePrechecks(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.prechecks = []params.UpgradePrecheck{
+		{Name: "agents", Message: "agents not connected on machines 3"},
+	}
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--skip-prechecks"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := coretesting.Context(c)
+	err = modelcmd.Wrap(cmd).Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.skipPrechecksCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "skipping upgrade prechecks")
+}
+
 func NewFakeUpgradeJujuAPI(c *gc.C, st *state.State) *fakeUpgradeJujuAPI {
 	nextVersion := version.Binary{
 		Number: jujuversion.Current,
@@ -934,6 +1067,13 @@ type fakeUpgradeJujuAPI struct {
 	setVersionCalledWith      version.Number
 	tools                     []string
 	findToolsCalled           bool
+	canary                    *params.CanaryUpgradeStatus
+	canaryVersion             version.Number
+	canaryCalledWith          *params.CanaryUpgradeParams
+	proceedCalled             bool
+	abortCanaryCalled         bool
+	prechecks                 []params.UpgradePrecheck
+	skipPrechecksCalledWith   version.Number
 }
 
 func (a *fakeUpgradeJujuAPI) reset() {
@@ -942,6 +1082,12 @@ func (a *fakeUpgradeJujuAPI) reset() {
 	a.setVersionCalledWith = version.Number{}
 	a.tools = []string{}
 	a.findToolsCalled = false
+	a.canaryVersion = version.Number{}
+	a.canaryCalledWith = nil
+	a.proceedCalled = false
+	a.abortCanaryCalled = false
+	a.prechecks = nil
+	a.skipPrechecksCalledWith = version.Number{}
 }
 
 func (a *fakeUpgradeJujuAPI) patch(s *UpgradeJujuSuite) {
@@ -951,6 +1097,9 @@ func (a *fakeUpgradeJujuAPI) patch(s *UpgradeJujuSuite) {
 	s.PatchValue(&getModelConfigAPI, func(*upgradeJujuCommand) (modelConfigAPI, error) {
 		return a, nil
 	})
+	s.PatchValue(&getUpgradePrechecksAPI, func(*upgradeJujuCommand) (upgradePrechecksAPI, error) {
+		return a, nil
+	})
 }
 
 func (a *fakeUpgradeJujuAPI) addTools(tools ...string) {
@@ -993,6 +1142,38 @@ func (a *fakeUpgradeJujuAPI) SetModelAgentVersion(v version.Number) error {
 	return a.setVersionErr
 }
 
+func (a *fakeUpgradeJujuAPI) StartCanaryUpgrade(v version.Number, canary params.CanaryUpgradeParams) error {
+	a.canaryVersion = v
+	a.canaryCalledWith = &canary
+	return a.setVersionErr
+}
+
+func (a *fakeUpgradeJujuAPI) CanaryUpgrade() (params.CanaryUpgradeStatus, error) {
+	if a.canary == nil {
+		return params.CanaryUpgradeStatus{}, common.ServerError(errors.NotFoundf("canary upgrade"))
+	}
+	return *a.canary, nil
+}
+
+func (a *fakeUpgradeJujuAPI) ProceedCanaryUpgrade() error {
+	a.proceedCalled = true
+	return nil
+}
+
+func (a *fakeUpgradeJujuAPI) AbortCanaryUpgrade() error {
+	a.abortCanaryCalled = true
+	return nil
+}
+
+func (a *fakeUpgradeJujuAPI) Prechecks() ([]params.UpgradePrecheck, error) {
+	return a.prechecks, nil
+}
+
+func (a *fakeUpgradeJujuAPI) SkipPrechecks(v version.Number) error {
+	a.skipPrechecksCalledWith = v
+	return nil
+}
+
 func (a *fakeUpgradeJujuAPI) Close() error {
 	return nil
 }
@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// fieldsFormats lists the output formats which support field
+// selection.
+var fieldsFormats = map[string]bool{
+	"yaml": true,
+	"json": true,
+}
+
+// FieldSelector selects fields from the structured output of a
+// command, so that scripts can ask for just the values they need.
+//
+// A field is given as a dot separated path of keys, in which "*"
+// matches any key. Lists are traversed transparently, so the path is
+// applied to each element of a list. For example, with the output of
+// "juju models", "models.name" selects the name of every model, and
+// with the output of "juju machines", "machines.*.dns-name" selects
+// the DNS name of every machine.
+type FieldSelector struct {
+	fields           string
+	flags            *gnuflag.FlagSet
+	defaultFormatter string
+}
+
+// AddOutputFlags adds the --fields flag to the flag set, along with the
+// output flags for the given formatters. The yaml and json formatters
+// write only the selected fields; other formatters may not be used with
+// --fields. If --fields is given without --format, and the default
+// format does not support field selection, yaml is written instead.
+func (s *FieldSelector) AddOutputFlags(
+	f *gnuflag.FlagSet,
+	out *cmd.Output,
+	defaultFormatter string,
+	formatters map[string]cmd.Formatter,
+) {
+	s.flags = f
+	s.defaultFormatter = defaultFormatter
+	wrapped := make(map[string]cmd.Formatter, len(formatters))
+	for name, formatter := range formatters {
+		wrapped[name] = s.wrapFormatter(name, formatter)
+	}
+	out.AddFlags(f, defaultFormatter, wrapped)
+	f.StringVar(&s.fields, "fields", "", "Comma separated list of fields to output, e.g. name,status (yaml and json only; a default tabular format switches to yaml)")
+}
+
+func (s *FieldSelector) wrapFormatter(name string, formatter cmd.Formatter) cmd.Formatter {
+	return func(value interface{}) ([]byte, error) {
+		if s.fields == "" {
+			return formatter(value)
+		}
+		if !fieldsFormats[name] {
+			if name != s.defaultFormatter || s.formatChosen() {
+				return nil, errors.Errorf("--fields cannot be used with %s format", name)
+			}
+			formatter = cmd.FormatYaml
+		}
+		selected, err := SelectFields(value, s.fields)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return formatter(selected)
+	}
+}
+
+// formatChosen reports whether the output format was given explicitly.
+func (s *FieldSelector) formatChosen() bool {
+	chosen := false
+	s.flags.Visit(func(flag *gnuflag.Flag) {
+		if flag.Name == "format" || flag.Name == "o" {
+			chosen = true
+		}
+	})
+	return chosen
+}
+
+// SelectFields returns the parts of value named by the given comma
+// separated list of fields. See FieldSelector for the syntax of
+// fields. The value is first converted to its generic JSON form, so
+// the field names are those used in the json and yaml output.
+func SelectFields(value interface{}, fields string) (interface{}, error) {
+	var paths [][]string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		paths = append(paths, strings.Split(field, "."))
+	}
+	if len(paths) == 0 {
+		return nil, errors.NotValidf("empty --fields")
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, errors.Trace(err)
+	}
+	selected, ok := selectPaths(generic, paths)
+	if m, isMap := selected.(map[string]interface{}); !ok || isMap && len(m) == 0 {
+		return nil, errors.Errorf("no output matches --fields %q", fields)
+	}
+	return selected, nil
+}
+
+// selectPaths returns the parts of value matched by the given paths,
+// and whether any path could be matched.
+func selectPaths(value interface{}, paths [][]string) (interface{}, bool) {
+	for _, path := range paths {
+		if len(path) == 0 {
+			// The whole of the value was asked for.
+			return value, true
+		}
+	}
+	switch value := value.(type) {
+	case []interface{}:
+		result := make([]interface{}, 0, len(value))
+		for _, elem := range value {
+			if selected, ok := selectPaths(elem, paths); ok {
+				result = append(result, selected)
+			}
+		}
+		return result, true
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for key, elem := range value {
+			var matched [][]string
+			for _, path := range paths {
+				if path[0] == "*" || path[0] == key {
+					matched = append(matched, path[1:])
+				}
+			}
+			if len(matched) == 0 {
+				continue
+			}
+			if selected, ok := selectPaths(elem, matched); ok {
+				result[key] = selected
+			}
+		}
+		return result, true
+	}
+	return nil, false
+}
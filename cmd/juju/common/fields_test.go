@@ -0,0 +1,140 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/gnuflag"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/testing"
+)
+
+type FieldsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&FieldsSuite{})
+
+type fieldsMachine struct {
+	DNSName string   `json:"dns-name"`
+	Series  string   `json:"series"`
+	Units   []string `json:"units,omitempty"`
+}
+
+type fieldsOutput struct {
+	Model    string                   `json:"model"`
+	Machines map[string]fieldsMachine `json:"machines"`
+	Models   []map[string]string      `json:"models"`
+}
+
+var fieldsValue = fieldsOutput{
+	Model: "admin",
+	Machines: map[string]fieldsMachine{
+		"0": {DNSName: "10.0.0.1", Series: "xenial", Units: []string{"mysql/0"}},
+		"1": {DNSName: "10.0.0.2", Series: "trusty"},
+	},
+	Models: []map[string]string{
+		{"name": "admin", "owner": "admin@local"},
+		{"name": "default", "owner": "bob@local"},
+	},
+}
+
+func (s *FieldsSuite) TestSelectFields(c *gc.C) {
+	for i, test := range []struct {
+		fields   string
+		expected interface{}
+	}{{
+		fields:   "model",
+		expected: map[string]interface{}{"model": "admin"},
+	}, {
+		fields: "machines.*.dns-name",
+		expected: map[string]interface{}{
+			"machines": map[string]interface{}{
+				"0": map[string]interface{}{"dns-name": "10.0.0.1"},
+				"1": map[string]interface{}{"dns-name": "10.0.0.2"},
+			},
+		},
+	}, {
+		fields: "model, machines.0.units",
+		expected: map[string]interface{}{
+			"model": "admin",
+			"machines": map[string]interface{}{
+				"0": map[string]interface{}{"units": []interface{}{"mysql/0"}},
+			},
+		},
+	}, {
+		fields: "models.name",
+		expected: map[string]interface{}{
+			"models": []interface{}{
+				map[string]interface{}{"name": "admin"},
+				map[string]interface{}{"name": "default"},
+			},
+		},
+	}} {
+		c.Logf("test %d: %s", i, test.fields)
+		selected, err := common.SelectFields(fieldsValue, test.fields)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(selected, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *FieldsSuite) TestSelectFieldsNoMatch(c *gc.C) {
+	_, err := common.SelectFields(fieldsValue, "machines.*.instance-id")
+	c.Check(err, jc.ErrorIsNil)
+	_, err = common.SelectFields(fieldsValue, "controller")
+	c.Check(err, gc.ErrorMatches, `no output matches --fields "controller"`)
+	_, err = common.SelectFields(fieldsValue, " , ")
+	c.Check(err, gc.ErrorMatches, `empty --fields not valid`)
+}
+
+func (s *FieldsSuite) TestAddOutputFlags(c *gc.C) {
+	command := &fieldsCommand{}
+	ctx, err := testing.RunCommand(c, command, "--format", "json", "--fields", "machines.*.series")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `{"machines":{"0":{"series":"xenial"},"1":{"series":"trusty"}}}`+"\n")
+
+	ctx, err = testing.RunCommand(c, &fieldsCommand{}, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), jc.Contains, "dnsname: 10.0.0.1")
+
+	_, err = testing.RunCommand(c, &fieldsCommand{}, "--format", "tabular", "--fields", "model")
+	c.Assert(err, gc.ErrorMatches, "--fields cannot be used with tabular format")
+}
+
+func (s *FieldsSuite) TestAddOutputFlagsDefaultFormat(c *gc.C) {
+	// The default tabular format does not support --fields,
+	// so yaml is written instead.
+	ctx, err := testing.RunCommand(c, &fieldsCommand{}, "--fields", "model")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "model: admin\n")
+}
+
+// fieldsCommand writes fieldsValue using the output flags added by a
+// FieldSelector.
+type fieldsCommand struct {
+	cmd.CommandBase
+	out    cmd.Output
+	fields common.FieldSelector
+}
+
+func (c *fieldsCommand) Info() *cmd.Info {
+	return &cmd.Info{Name: "fields"}
+}
+
+func (c *fieldsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.fields.AddOutputFlags(f, &c.out, "tabular", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+		"tabular": func(interface{}) ([]byte, error) {
+			return []byte("MODEL\nadmin"), nil
+		},
+	})
+}
+
+func (c *fieldsCommand) Run(ctx *cmd.Context) error {
+	return c.out.Write(ctx, fieldsValue)
+}
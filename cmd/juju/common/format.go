@@ -51,6 +51,16 @@ func UserFriendlyDuration(when, now time.Time) string {
 	return "just now"
 }
 
+// CachedDataNotice returns a notice warning that the named data being
+// displayed was cached from the controller at the given time, rather
+// than fetched from it, and so may be out of date.
+func CachedDataNotice(what string, cached, now time.Time) string {
+	return fmt.Sprintf(
+		"Showing %s cached %s (%s); it may be out of date.",
+		what, UserFriendlyDuration(cached, now), cached.UTC().Format(time.RFC3339),
+	)
+}
+
 // FormatTime returns a string with the local time formatted
 // in an arbitrary format used for status or and localized tz
 // or in UTC timezone and format RFC3339 if u is specified.
@@ -179,3 +179,9 @@ func (*userFriendlyDurationSuite) TestFormat(c *gc.C) {
 		c.Check(obtained, gc.Equals, test.expected)
 	}
 }
+
+func (*userFriendlyDurationSuite) TestCachedDataNotice(c *gc.C) {
+	now := time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)
+	notice := common.CachedDataNotice("status", now.Add(-2*time.Hour), now)
+	c.Assert(notice, gc.Equals, "Showing status cached 2 hours ago (2016-08-01T10:00:00Z); it may be out of date.")
+}
@@ -24,6 +24,15 @@ type ModelInfo struct {
 	Life           string                   `json:"life" yaml:"life"`
 	Status         ModelStatus              `json:"status" yaml:"status"`
 	Users          map[string]ModelUserInfo `json:"users" yaml:"users"`
+	Health         *ModelHealth             `json:"health,omitempty" yaml:"health,omitempty"`
+}
+
+// ModelHealth contains a summary of the health of a model.
+type ModelHealth struct {
+	Machines       int      `json:"machines" yaml:"machines"`
+	MachinesDown   int      `json:"machines-down" yaml:"machines-down"`
+	AgentVersions  []string `json:"agent-versions,omitempty" yaml:"agent-versions,omitempty"`
+	LastConnection string   `json:"last-connection" yaml:"last-connection"`
 }
 
 // ModelStatus contains the current status of a model.
@@ -54,6 +63,15 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 	if info.Status.Since != nil {
 		status.Since = UserFriendlyDuration(*info.Status.Since, now)
 	}
+	var health *ModelHealth
+	if info.Health != nil {
+		health = &ModelHealth{
+			Machines:       info.Health.Machines,
+			MachinesDown:   info.Health.MachinesDown,
+			AgentVersions:  info.Health.AgentVersions,
+			LastConnection: lastConnectionString(info.Health.LastConnection, now),
+		}
+	}
 	return ModelInfo{
 		Name:           info.Name,
 		UUID:           info.UUID,
@@ -65,6 +83,7 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 		CloudRegion:    info.CloudRegion,
 		ProviderType:   info.ProviderType,
 		Users:          ModelUserInfoFromParams(info.Users, now),
+		Health:         health,
 	}, nil
 }
 
@@ -77,12 +96,15 @@ func ModelUserInfoFromParams(users []params.ModelUserInfo, now time.Time) map[st
 			DisplayName: info.DisplayName,
 			Access:      string(info.Access),
 		}
-		if info.LastConnection != nil {
-			outInfo.LastConnection = UserFriendlyDuration(*info.LastConnection, now)
-		} else {
-			outInfo.LastConnection = "never connected"
-		}
+		outInfo.LastConnection = lastConnectionString(info.LastConnection, now)
 		output[names.NewUserTag(info.UserName).Canonical()] = outInfo
 	}
 	return output
 }
+
+func lastConnectionString(lastConnection *time.Time, now time.Time) string {
+	if lastConnection == nil {
+		return "never connected"
+	}
+	return UserFriendlyDuration(*lastConnection, now)
+}
@@ -45,6 +45,7 @@ type addModelCommand struct {
 
 	Name           string
 	Owner          string
+	CloudName      string
 	CredentialName string
 	CloudRegion    string
 	Config         common.ConfigFlag
@@ -52,10 +53,16 @@ type addModelCommand struct {
 
 const addModelHelpDoc = `
 Adding a model is typically done in order to run a specific workload. The
-model is of the same cloud type as the controller and is managed by that
-controller. By default, the controller is the current controller. The
-credentials used to add the model are the ones used to create any future
-resources within the model (` + "`juju deploy`, `juju add-unit`" + `).
+model is managed by the controller, and is by default added to the same
+cloud as the controller. By default, the controller is the current
+controller. The credentials used to add the model are the ones used to
+create any future resources within the model (` + "`juju deploy`, `juju add-unit`" + `).
+
+A model may be added to a cloud other than the controller's with the
+--cloud option. If the controller does not yet know of the cloud, its
+definition is uploaded from the client, so the cloud may be any public
+cloud or one added with ` + "`juju add-cloud`" + `. A credential for the
+cloud must then also be specified.
 
 Model names can be duplicated across controllers but must be unique for
 any given controller. Model names may only contain lowercase letters,
@@ -72,6 +79,7 @@ Examples:
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
     juju add-model mymodel --region us-east-1
+    juju add-model mymodel --cloud aws --region us-west-2 --credential credential_name
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
@@ -85,6 +93,7 @@ func (c *addModelCommand) Info() *cmd.Info {
 
 func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.Owner, "owner", "", "The owner of the new model if not the current user")
+	f.StringVar(&c.CloudName, "cloud", "", "Cloud to add the model to, if not the controller's")
 	f.StringVar(&c.CredentialName, "credential", "", "Credential used to add the model")
 	f.StringVar(&c.CloudRegion, "region", "", "Cloud region to add the model to")
 	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
@@ -104,12 +113,16 @@ func (c *addModelCommand) Init(args []string) error {
 		return errors.Errorf("%q is not a valid user", c.Owner)
 	}
 
+	if c.CloudName != "" && !names.IsValidCloud(c.CloudName) {
+		return errors.Errorf("%q is not a valid cloud name", c.CloudName)
+	}
+
 	return cmd.CheckEmpty(args)
 }
 
 type AddModelAPI interface {
 	CreateModel(
-		name, owner, cloudRegion string,
+		name, owner, cloud, cloudRegion string,
 		cloudCredential names.CloudCredentialTag,
 		config map[string]interface{},
 	) (params.ModelInfo, error)
@@ -118,6 +131,7 @@ type AddModelAPI interface {
 type CloudAPI interface {
 	DefaultCloud() (names.CloudTag, error)
 	Cloud(names.CloudTag) (cloud.Cloud, error)
+	AddCloud(string, cloud.Cloud) error
 	Credentials(names.UserTag, names.CloudTag) ([]names.CloudCredentialTag, error)
 	UpdateCredential(names.CloudCredentialTag, cloud.Credential) error
 }
@@ -161,6 +175,16 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	// If the user has specified a cloud, then we will upload its
+	// definition if it doesn't already exist in the controller, and
+	// it exists locally.
+	if c.CloudName != "" {
+		cloudClient := c.newCloudAPI(api)
+		if err := c.maybeUploadCloud(ctx, cloudClient); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// If the user has specified a credential, then we will upload it if
 	// it doesn't already exist in the controller, and it exists locally.
 	var credentialTag names.CloudCredentialTag
@@ -174,7 +198,7 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	}
 
 	addModelClient := c.newAddModelAPI(api)
-	model, err := addModelClient.CreateModel(c.Name, modelOwner, c.CloudRegion, credentialTag, attrs)
+	model, err := addModelClient.CreateModel(c.Name, modelOwner, c.CloudName, c.CloudRegion, credentialTag, attrs)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -195,8 +219,12 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	}
 
 	if model.CloudRegion != "" {
+		modelCloud := model.Cloud
+		if modelCloud == "" {
+			modelCloud = controllerDetails.Cloud
+		}
 		messageFormat += " on %s/%s"
-		messageArgs = append(messageArgs, controllerDetails.Cloud, model.CloudRegion)
+		messageArgs = append(messageArgs, modelCloud, model.CloudRegion)
 	}
 	if model.CloudCredentialTag != "" {
 		tag, err := names.ParseCloudCredentialTag(model.CloudCredentialTag)
@@ -229,15 +257,36 @@ before "juju ssh", "juju scp", or "juju debug-hooks" will work.`)
 	return nil
 }
 
+// maybeUploadCloud adds the cloud specified by the user to the
+// controller, if the controller does not already know of it.
+func (c *addModelCommand) maybeUploadCloud(ctx *cmd.Context, cloudClient CloudAPI) error {
+	_, err := cloudClient.Cloud(names.NewCloudTag(c.CloudName))
+	if err == nil {
+		return nil
+	} else if !params.IsCodeNotFound(err) {
+		return errors.Trace(err)
+	}
+	cloudDetails, err := cloud.CloudByName(c.CloudName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("uploading cloud '%s' to controller", c.CloudName)
+	return errors.Trace(cloudClient.AddCloud(c.CloudName, *cloudDetails))
+}
+
 func (c *addModelCommand) maybeUploadCredential(
 	ctx *cmd.Context,
 	cloudClient CloudAPI,
 	modelOwner string,
 ) (names.CloudCredentialTag, error) {
 
-	cloudTag, err := cloudClient.DefaultCloud()
-	if err != nil {
-		return names.CloudCredentialTag{}, errors.Trace(err)
+	cloudTag := names.NewCloudTag(c.CloudName)
+	if c.CloudName == "" {
+		var err error
+		cloudTag, err = cloudClient.DefaultCloud()
+		if err != nil {
+			return names.CloudCredentialTag{}, errors.Trace(err)
+		}
 	}
 	modelOwnerTag := names.NewUserTag(modelOwner)
 	credentialTag, err := common.ResolveCloudCredentialTag(
@@ -117,6 +117,9 @@ func (s *addSuite) TestInit(c *gc.C) {
 			args:   []string{"new-model", "--config", "key=value", "--config", "key2=value2"},
 			name:   "new-model",
 			values: map[string]interface{}{"key": "value", "key2": "value2"},
+		}, {
+			args: []string{"new-model", "--cloud", "not/valid"},
+			err:  `"not/valid" is not a valid cloud name`,
 		}, {
 			args: []string{"new-model", "extra", "args"},
 			err:  `unrecognized args: \["extra" "args"\]`,
@@ -170,6 +173,45 @@ func (s *addSuite) TestCredentialsPassedThrough(c *gc.C) {
 	c.Assert(s.fakeAddModelAPI.config["type"], gc.Equals, "ec2")
 }
 
+func (s *addSuite) TestCloudPassedThrough(c *gc.C) {
+	_, err := s.run(c, "test", "--cloud", "aws")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeAddModelAPI.cloud, gc.Equals, "aws")
+	c.Assert(s.fakeCloundAPI.addedClouds, gc.HasLen, 0)
+}
+
+func (s *addSuite) TestCloudUploaded(c *gc.C) {
+	s.fakeCloundAPI.cloudErr = &params.Error{
+		Code:    params.CodeNotFound,
+		Message: "cloud aws not found",
+	}
+	ctx, err := s.run(c, "test", "--cloud", "aws")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(testing.Stderr(ctx), jc.Contains, "uploading cloud 'aws' to controller")
+	c.Assert(s.fakeCloundAPI.addedClouds["aws"].Type, gc.Equals, "ec2")
+	c.Assert(s.fakeAddModelAPI.cloud, gc.Equals, "aws")
+}
+
+func (s *addSuite) TestCloudUnknown(c *gc.C) {
+	s.fakeCloundAPI.cloudErr = &params.Error{
+		Code:    params.CodeNotFound,
+		Message: "cloud unknown not found",
+	}
+	_, err := s.run(c, "test", "--cloud", "unknown")
+	c.Assert(err, gc.ErrorMatches, "cloud unknown not found")
+	c.Assert(s.fakeCloundAPI.addedClouds, gc.HasLen, 0)
+}
+
+func (s *addSuite) TestAddedOnModelCloud(c *gc.C) {
+	s.fakeAddModelAPI.model.Cloud = "aws"
+	s.fakeAddModelAPI.model.CloudRegion = "us-west-2"
+	ctx, err := s.run(c, "test", "--cloud", "aws", "--region", "us-west-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), jc.Contains, "Added 'test' model on aws/us-west-2 for user 'bob'")
+}
+
 func (s *addSuite) TestComandLineConfigPassedThrough(c *gc.C) {
 	_, err := s.run(c, "test", "--config", "account=magic", "--config", "cloud=special")
 	c.Assert(err, jc.ErrorIsNil)
@@ -304,6 +346,7 @@ func (s *addSuite) TestNoEnvCacheOtherUser(c *gc.C) {
 // AddModel command.
 type fakeAddClient struct {
 	owner           string
+	cloud           string
 	cloudRegion     string
 	cloudCredential names.CloudCredentialTag
 	config          map[string]interface{}
@@ -317,11 +360,12 @@ func (*fakeAddClient) Close() error {
 	return nil
 }
 
-func (f *fakeAddClient) CreateModel(name, owner, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}) (params.ModelInfo, error) {
+func (f *fakeAddClient) CreateModel(name, owner, cloud, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}) (params.ModelInfo, error) {
 	if f.err != nil {
 		return params.ModelInfo{}, f.err
 	}
 	f.owner = owner
+	f.cloud = cloud
 	f.cloudCredential = cloudCredential
 	f.cloudRegion = cloudRegion
 	f.config = config
@@ -331,6 +375,23 @@ func (f *fakeAddClient) CreateModel(name, owner, cloudRegion string, cloudCreden
 // TODO(wallyworld) - improve this stub and add test asserts
 type fakeCloudAPI struct {
 	controller.CloudAPI
+	cloudErr    error
+	addedClouds map[string]cloud.Cloud
+}
+
+func (c *fakeCloudAPI) Cloud(names.CloudTag) (cloud.Cloud, error) {
+	if c.cloudErr != nil {
+		return cloud.Cloud{}, c.cloudErr
+	}
+	return cloud.Cloud{Type: "ec2"}, nil
+}
+
+func (c *fakeCloudAPI) AddCloud(name string, details cloud.Cloud) error {
+	if c.addedClouds == nil {
+		c.addedClouds = make(map[string]cloud.Cloud)
+	}
+	c.addedClouds[name] = details
+	return nil
 }
 
 func (c *fakeCloudAPI) Credentials(names.UserTag, names.CloudTag) ([]names.CloudCredentialTag, error) {
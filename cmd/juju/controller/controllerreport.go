@@ -0,0 +1,192 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/controllerreport"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// warningThreshold is the fraction of a resource's soft limit above
+// which the resource is reported with a warning.
+const warningThreshold = 0.8
+
+const controllerReportDoc = `
+Reports the resources used by the controller: the numbers of models,
+machines and units it hosts, the number of watchers held by its API
+server, the size of its database and stored logs, and the average
+number of log records written per minute over the last hour.
+
+Each resource is compared against the soft limit configured for it in
+the controller config (soft-limit-models, soft-limit-machines,
+soft-limit-units, soft-limit-api-watchers, soft-limit-database-size-mb
+and soft-limit-log-rate). A resource using more than 80% of its limit
+is reported with a warning; one using more than its limit is reported
+as exceeded. Soft limits are advisory only: nothing is refused when
+they are exceeded, so the report can be used to decide when to scale
+up the controller.
+
+In a highly available controller, the API watcher count is that of the
+controller machine which handled the request.
+
+Examples:
+
+    juju controller-report
+    juju controller-report --format yaml
+
+See also:
+    get-controller-config
+    enable-ha
+`
+
+// NewControllerReportCommand returns a command which reports the
+// controller's resource usage against its configured soft limits.
+func NewControllerReportCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerReportCommand{})
+}
+
+// controllerReportCommand reports the controller's resource usage
+// against its configured soft limits.
+type controllerReportCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api controllerReportAPI
+}
+
+// controllerReportAPI defines the methods on the ControllerReport API
+// endpoint that the controller-report command calls.
+type controllerReportAPI interface {
+	Close() error
+	Report() ([]params.ControllerResource, error)
+}
+
+// Info implements Command.Info.
+func (c *controllerReportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "controller-report",
+		Purpose: "Reports the controller's resource usage against its soft limits.",
+		Doc:     controllerReportDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerReportCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatControllerReportTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *controllerReportCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *controllerReportCommand) getAPI() (controllerReportAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controllerreport.NewClient(root), nil
+}
+
+// controllerResource holds the usage of a controller resource
+// displayed by controller-report.
+type controllerResource struct {
+	Name   string  `yaml:"name" json:"name"`
+	Usage  float64 `yaml:"usage" json:"usage"`
+	Limit  int     `yaml:"limit,omitempty" json:"limit,omitempty"`
+	Status string  `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *controllerReportCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	resources, err := client.Report()
+	if params.IsCodeNotImplemented(err) {
+		return errors.New("controller-report is not supported by this controller")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	report := make([]controllerResource, len(resources))
+	for i, resource := range resources {
+		report[i] = controllerResource{
+			Name:   resource.Name,
+			Usage:  resource.Usage,
+			Limit:  resource.Limit,
+			Status: limitStatus(resource.Usage, resource.Limit),
+		}
+	}
+	return c.out.Write(ctx, report)
+}
+
+// limitStatus describes how a resource's usage compares to its soft
+// limit. No status is given for resources without a limit.
+func limitStatus(usage float64, limit int) string {
+	switch {
+	case limit <= 0:
+		return ""
+	case usage > float64(limit):
+		return "exceeded"
+	case usage >= warningThreshold*float64(limit):
+		return "warning"
+	}
+	return "ok"
+}
+
+func formatControllerReportTabular(value interface{}) ([]byte, error) {
+	resources, ok := value.([]controllerResource)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", resources, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "RESOURCE\tUSAGE\tLIMIT\tSTATUS\n")
+	for _, resource := range resources {
+		limit := "-"
+		if resource.Limit > 0 {
+			limit = strconv.Itoa(resource.Limit)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", resource.Name, formatUsage(resource.Usage), limit, resource.Status)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+// formatUsage formats a resource's usage, which is fractional only
+// for rates.
+func formatUsage(usage float64) string {
+	if usage == float64(int64(usage)) {
+		return strconv.FormatInt(int64(usage), 10)
+	}
+	return strconv.FormatFloat(usage, 'f', 2, 64)
+}
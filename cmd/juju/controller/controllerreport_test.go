@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/testing"
+)
+
+type ControllerReportSuite struct {
+	baseControllerSuite
+	api *fakeControllerReportAPI
+}
+
+var _ = gc.Suite(&ControllerReportSuite{})
+
+func (s *ControllerReportSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeControllerReportAPI{
+		resources: []params.ControllerResource{
+			{Name: "models", Usage: 9, Limit: 10},
+			{Name: "machines", Usage: 12},
+			{Name: "units", Usage: 1200, Limit: 1000},
+			{Name: "log-rate", Usage: 2.5, Limit: 100},
+		},
+	}
+}
+
+func (s *ControllerReportSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewControllerReportCommandForTest(s.api, s.store)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *ControllerReportSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(controller.NewControllerReportCommandForTest(s.api, s.store), []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ControllerReportSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"RESOURCE  USAGE  LIMIT  STATUS\n"+
+		"models    9      10     warning\n"+
+		"machines  12     -      \n"+
+		"units     1200   1000   exceeded\n"+
+		"log-rate  2.50   100    ok\n")
+	s.api.CheckCallNames(c, "Report", "Close")
+}
+
+func (s *ControllerReportSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- name: models
+  usage: 9
+  limit: 10
+  status: warning
+- name: machines
+  usage: 12
+- name: units
+  usage: 1200
+  limit: 1000
+  status: exceeded
+- name: log-rate
+  usage: 2.5
+  limit: 100
+  status: ok
+`[1:])
+}
+
+func (s *ControllerReportSuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ControllerReportSuite) TestNotSupported(c *gc.C) {
+	s.api.SetErrors(&params.Error{Code: params.CodeNotImplemented})
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "controller-report is not supported by this controller")
+}
+
+type fakeControllerReportAPI struct {
+	gitjujutesting.Stub
+	resources []params.ControllerResource
+}
+
+func (f *fakeControllerReportAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeControllerReportAPI) Report() ([]params.ControllerResource, error) {
+	f.MethodCall(f, "Report")
+	return f.resources, f.NextErr()
+}
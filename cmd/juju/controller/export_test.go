@@ -21,6 +21,15 @@ func NewListControllersCommandForTest(testStore jujuclient.ClientStore) *listCon
 	}
 }
 
+// NewListControllersCommandWithModelAPIForTest returns a listControllersCommand
+// with the clientstore and model manager API provided as specified.
+func NewListControllersCommandWithModelAPIForTest(testStore jujuclient.ClientStore, modelAPI func(string) (ModelManagerAPI, error)) *listControllersCommand {
+	return &listControllersCommand{
+		store:    testStore,
+		modelAPI: modelAPI,
+	}
+}
+
 // NewShowControllerCommandForTest returns a showControllerCommand with the clientstore provided
 // as specified.
 func NewShowControllerCommandForTest(testStore jujuclient.ClientStore) *showControllerCommand {
@@ -158,3 +167,11 @@ func FmtModelStatus(data ModelData) string {
 func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, error) {
 	return newData(api, ctrUUID)
 }
+
+// NewControllerReportCommandForTest returns a controllerReportCommand
+// with the api provided as specified.
+func NewControllerReportCommandForTest(api controllerReportAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &controllerReportCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
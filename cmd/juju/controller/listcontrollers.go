@@ -6,11 +6,15 @@ package controller
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
 )
@@ -22,8 +26,15 @@ var helpControllersDetails = `
 The output format may be selected with the '--format' option. In the
 default tabular output, the current controller is marked with an asterisk.
 
+The --health option connects to each controller and adds columns
+summarising the models you can access there: whether the controller is
+reachable, the number of models and machines, how many machines have
+agents which are not connected, the distinct agent versions in use, and
+when any user last connected to one of the models.
+
 Examples:
     juju controllers
+    juju controllers --health
     juju controllers --format json --output ~/tmp/controllers.json
 
 See also:
@@ -51,6 +62,7 @@ func (c *listControllersCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *listControllersCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.JujuCommandBase.SetFlags(f)
+	f.BoolVar(&c.health, "health", false, "Connect to each controller to report the health of its models")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
@@ -74,6 +86,16 @@ func (c *listControllersCommand) Run(ctx *cmd.Context) error {
 	} else if err != nil {
 		return errors.Annotate(err, "getting current controller")
 	}
+	if c.health {
+		now := time.Now()
+		for name, item := range details {
+			item.Health = c.controllerHealth(name, now)
+			if item.Health.Error != "" {
+				fmt.Fprintf(ctx.Stderr, "cannot get health of controller %q: %s\n", name, item.Health.Error)
+			}
+			details[name] = item
+		}
+	}
 	controllerSet := ControllerSet{
 		Controllers:       details,
 		CurrentController: currentController,
@@ -84,6 +106,66 @@ func (c *listControllersCommand) Run(ctx *cmd.Context) error {
 type listControllersCommand struct {
 	modelcmd.JujuCommandBase
 
-	out   cmd.Output
-	store jujuclient.ClientStore
+	out    cmd.Output
+	store  jujuclient.ClientStore
+	health bool
+
+	// modelAPI returns the model manager API for the named
+	// controller. It is a field so that it can be replaced in tests.
+	modelAPI func(controllerName string) (ModelManagerAPI, error)
+}
+
+func (c *listControllersCommand) getModelManagerAPI(controllerName string) (ModelManagerAPI, error) {
+	if c.modelAPI != nil {
+		return c.modelAPI(controllerName)
+	}
+	conn, err := c.NewAPIRoot(c.store, controllerName, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(conn), nil
+}
+
+// controllerHealth summarises the health of the models the logged-in
+// user can access on the named controller. Failures are reported in
+// the result rather than returned, so that one unreachable controller
+// does not prevent the others being listed.
+func (c *listControllersCommand) controllerHealth(controllerName string, now time.Time) *ControllerHealth {
+	accountDetails, err := c.store.AccountDetails(controllerName)
+	if errors.IsNotFound(err) {
+		return &ControllerHealth{Error: "not logged in"}
+	} else if err != nil {
+		return &ControllerHealth{Error: err.Error()}
+	}
+	client, err := c.getModelManagerAPI(controllerName)
+	if err != nil {
+		return &ControllerHealth{Error: err.Error()}
+	}
+	defer client.Close()
+
+	models, err := client.ListModels(accountDetails.User)
+	if err != nil {
+		return &ControllerHealth{Error: err.Error()}
+	}
+	tags := make([]names.ModelTag, len(models))
+	for i, m := range models {
+		tags[i] = names.NewModelTag(m.UUID)
+	}
+	results, err := client.ModelInfo(tags)
+	if err != nil {
+		return &ControllerHealth{Error: err.Error()}
+	}
+	modelHealth := make([]params.ModelHealth, 0, len(results))
+	for _, result := range results {
+		// Models may be removed between listing them and
+		// getting their details, and older controllers do
+		// not report model health.
+		if result.Error == nil && result.Result.Health != nil {
+			modelHealth = append(modelHealth, *result.Result.Health)
+		}
+	}
+	health := combineModelHealth(modelHealth, now)
+	health.Reachable = true
+	health.Models = len(models)
+	return health
 }
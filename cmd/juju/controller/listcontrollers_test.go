@@ -4,6 +4,7 @@
 package controller_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -12,6 +13,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/testing"
@@ -124,6 +126,64 @@ func (s *ListControllersSuite) TestListControllersJson(c *gc.C) {
 	})
 }
 
+func (s *ListControllersSuite) TestListControllersHealth(c *gc.C) {
+	s.createTestClientStore(c)
+	s.expectedOutput = `
+CONTROLLER           MODEL     USER         CLOUD/REGION        REACHABLE  MODELS  MACHINES    AGENTS       LAST ACTIVITY
+aws-test             admin     admin@local  aws/us-east-1       no         -       -           -            -
+mallards*            my-model  admin@local  mallards/mallards1  yes        3       3 (1 down)  2.0.0,2.0.1  2015-03-20
+mark-test-prodstack  -         admin@local  prodstack           yes        0       0           -            -
+
+`[1:]
+	context, err := s.runListControllersHealth(c, "--health")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, s.expectedOutput)
+	c.Assert(testing.Stderr(context), gc.Equals, `cannot get health of controller "aws-test": connection refused`+"\n")
+}
+
+func (s *ListControllersSuite) TestListControllersHealthJson(c *gc.C) {
+	s.createTestClientStore(c)
+	context, err := s.runListControllersHealth(c, "--health", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	var result controller.ControllerSet
+	err = json.Unmarshal(context.Stdout.(*bytes.Buffer).Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Controllers["aws-test"].Health, jc.DeepEquals, &controller.ControllerHealth{
+		Error: "connection refused",
+	})
+	c.Assert(result.Controllers["mallards"].Health, jc.DeepEquals, &controller.ControllerHealth{
+		Reachable:      true,
+		Models:         3,
+		Machines:       3,
+		MachinesDown:   1,
+		AgentVersions:  []string{"2.0.0", "2.0.1"},
+		LastConnection: "2015-03-20",
+	})
+	c.Assert(result.Controllers["mark-test-prodstack"].Health, jc.DeepEquals, &controller.ControllerHealth{
+		Reachable: true,
+	})
+}
+
+func (s *ListControllersSuite) runListControllersHealth(c *gc.C, args ...string) (*cmd.Context, error) {
+	modelAPI := func(controllerName string) (controller.ModelManagerAPI, error) {
+		switch controllerName {
+		case "mallards":
+			return &fakeModelMgrAPIClient{
+				models: []base.UserModel{
+					{Name: "test-model1", Owner: "admin@local", UUID: "test-model1-UUID"},
+					{Name: "test-model2", Owner: "admin@local", UUID: "test-model2-UUID"},
+					{Name: "test-model3", Owner: "admin@local", UUID: "test-model3-UUID"},
+				},
+			}, nil
+		case "mark-test-prodstack":
+			return &fakeModelMgrAPIClient{}, nil
+		}
+		return nil, errors.New("connection refused")
+	}
+	command := controller.NewListControllersCommandWithModelAPIForTest(s.store, modelAPI)
+	return testing.RunCommand(c, command, args...)
+}
+
 func (s *ListControllersSuite) TestListControllersReadFromStoreErr(c *gc.C) {
 	msg := "fail getting all controllers"
 	errStore := jujuclienttesting.NewStubStore()
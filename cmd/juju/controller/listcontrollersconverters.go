@@ -5,10 +5,15 @@ package controller
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -29,8 +34,66 @@ type ControllerItem struct {
 	CACert         string   `yaml:"ca-cert" json:"ca-cert"`
 	Cloud          string   `yaml:"cloud" json:"cloud"`
 	CloudRegion    string   `yaml:"region,omitempty" json:"region,omitempty"`
+
+	// Health is only reported when requested, as it requires
+	// connecting to the controller.
+	Health *ControllerHealth `yaml:"health,omitempty" json:"health,omitempty"`
+}
+
+// ControllerHealth defines the serialization behaviour of the health of
+// the models a user can access on a controller.
+type ControllerHealth struct {
+	Reachable      bool     `yaml:"reachable" json:"reachable"`
+	Error          string   `yaml:"error,omitempty" json:"error,omitempty"`
+	Models         int      `yaml:"models" json:"models"`
+	Machines       int      `yaml:"machines" json:"machines"`
+	MachinesDown   int      `yaml:"machines-down" json:"machines-down"`
+	AgentVersions  []string `yaml:"agent-versions,omitempty" json:"agent-versions,omitempty"`
+	LastConnection string   `yaml:"last-connection,omitempty" json:"last-connection,omitempty"`
 }
 
+// combineModelHealth sums the health of a controller's models.
+func combineModelHealth(models []params.ModelHealth, now time.Time) *ControllerHealth {
+	health := &ControllerHealth{}
+	var versions []version.Number
+	seen := make(map[version.Number]bool)
+	var lastConnection *time.Time
+	for _, model := range models {
+		health.Machines += model.Machines
+		health.MachinesDown += model.MachinesDown
+		for _, v := range model.AgentVersions {
+			number, err := version.Parse(v)
+			if err != nil {
+				logger.Warningf("ignoring invalid agent version %q: %v", v, err)
+				continue
+			}
+			if !seen[number] {
+				seen[number] = true
+				versions = append(versions, number)
+			}
+		}
+		if t := model.LastConnection; t != nil && (lastConnection == nil || t.After(*lastConnection)) {
+			lastConnection = t
+		}
+	}
+	sort.Sort(versionNumbers(versions))
+	for _, number := range versions {
+		health.AgentVersions = append(health.AgentVersions, number.String())
+	}
+	if lastConnection != nil {
+		health.LastConnection = common.UserFriendlyDuration(*lastConnection, now)
+	} else if len(models) > 0 {
+		health.LastConnection = "never connected"
+	}
+	return health
+}
+
+type versionNumbers []version.Number
+
+func (v versionNumbers) Len() int           { return len(v) }
+func (v versionNumbers) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+func (v versionNumbers) Less(i, j int) bool { return v[i].Compare(v[j]) < 0 }
+
 // convertControllerDetails takes a map of Controllers and
 // the recently used model for each and creates a list of
 // amalgamated controller and model details.
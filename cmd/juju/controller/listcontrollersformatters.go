@@ -41,14 +41,21 @@ func formatControllersTabular(set ControllerSet) ([]byte, error) {
 		fmt.Fprintln(tw, strings.Join(values, "\t"))
 	}
 
-	print("CONTROLLER", "MODEL", "USER", "CLOUD/REGION")
-
+	// Health is only reported when requested.
+	showHealth := false
 	names := []string{}
-	for name, _ := range set.Controllers {
+	for name, c := range set.Controllers {
 		names = append(names, name)
+		showHealth = showHealth || c.Health != nil
 	}
 	sort.Strings(names)
 
+	headers := []string{"CONTROLLER", "MODEL", "USER", "CLOUD/REGION"}
+	if showHealth {
+		headers = append(headers, "REACHABLE", "MODELS", "MACHINES", "AGENTS", "LAST ACTIVITY")
+	}
+	print(headers...)
+
 	for _, name := range names {
 		c := set.Controllers[name]
 		modelName := noValueDisplay
@@ -66,9 +73,33 @@ func formatControllersTabular(set ControllerSet) ([]byte, error) {
 		if c.CloudRegion != "" {
 			cloudRegion += "/" + c.CloudRegion
 		}
-		print(name, modelName, userName, cloudRegion)
+		values := []string{name, modelName, userName, cloudRegion}
+		if showHealth {
+			values = append(values, formatControllerHealth(c.Health)...)
+		}
+		print(values...)
 	}
 	tw.Flush()
 
 	return out.Bytes(), nil
 }
+
+// formatControllerHealth returns the health columns for a controller.
+func formatControllerHealth(health *ControllerHealth) []string {
+	if health == nil || !health.Reachable {
+		return []string{"no", noValueDisplay, noValueDisplay, noValueDisplay, noValueDisplay}
+	}
+	machines := fmt.Sprint(health.Machines)
+	if health.MachinesDown > 0 {
+		machines += fmt.Sprintf(" (%d down)", health.MachinesDown)
+	}
+	agents := noValueDisplay
+	if len(health.AgentVersions) > 0 {
+		agents = strings.Join(health.AgentVersions, ",")
+	}
+	lastConnection := noValueDisplay
+	if health.LastConnection != "" {
+		lastConnection = health.LastConnection
+	}
+	return []string{"yes", fmt.Sprint(health.Models), machines, agents, lastConnection}
+}
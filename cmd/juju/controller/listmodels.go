@@ -6,6 +6,7 @@ package controller
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -31,11 +32,14 @@ func NewListModelsCommand() cmd.Command {
 type modelsCommand struct {
 	modelcmd.ControllerCommandBase
 	out          cmd.Output
+	fields       common.FieldSelector
 	all          bool
 	loggedInUser string
 	user         string
 	listUUID     bool
+	health       bool
 	exactTime    bool
+	cached       bool
 	modelAPI     ModelManagerAPI
 	sysAPI       ModelsSysAPI
 }
@@ -46,10 +50,23 @@ models which have been shared with you. Default values for user and
 controller are, respectively, the current user and the current controller.
 The active model is denoted by an asterisk.
 
+The --health option adds columns summarising the health of each model,
+as seen by the controller: the number of machines and how many of them
+have agents which are not connected, the distinct versions of those agents,
+and when any user last connected to the model.
+
+Each time models are listed, the details of the models are also cached on
+the client. With --cached, the cached details are listed instead of
+contacting the controller, so that the last-seen models are available when
+the controller is unreachable. The time at which the details were cached is
+shown with them.
+
 Examples:
 
     juju models
     juju models --user bob
+    juju models --health
+    juju models --cached
 
 See also: add-model
           share-model
@@ -100,8 +117,10 @@ func (c *modelsCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.user, "user", "", "The user to list models for (administrative users only)")
 	f.BoolVar(&c.all, "all", false, "Lists all models, regardless of user accessibility (administrative users only)")
 	f.BoolVar(&c.listUUID, "uuid", false, "Display UUID for models")
+	f.BoolVar(&c.health, "health", false, "Display machine, agent version and activity columns for models")
 	f.BoolVar(&c.exactTime, "exact-time", false, "Use full timestamps")
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	f.BoolVar(&c.cached, "cached", false, "List the models last cached on this client, without contacting the controller")
+	c.fields.AddOutputFlags(f, &c.out, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": c.formatTabular,
@@ -122,6 +141,10 @@ type ModelSet struct {
 	// CurrentModelQualified is the fully qualified name for the current
 	// model, i.e. having the format $owner/$model.
 	CurrentModelQualified string `yaml:"-" json:"-"`
+
+	// Cached is the time at which the models were cached, if they
+	// were not fetched from the controller.
+	Cached string `yaml:"cached,omitempty" json:"cached,omitempty"`
 }
 
 // Run implements Command.Run
@@ -132,29 +155,34 @@ func (c *modelsCommand) Run(ctx *cmd.Context) error {
 	}
 	c.loggedInUser = accountDetails.User
 
-	// First get a list of the models.
-	var models []base.UserModel
-	if c.all {
-		models, err = c.getAllModels()
-	} else {
-		if c.user == "" {
-			c.user = accountDetails.User
-		}
-		models, err = c.getUserModels()
-	}
-	if err != nil {
-		return errors.Annotate(err, "cannot list models")
+	if !c.all && c.user == "" {
+		c.user = accountDetails.User
 	}
 
-	// And now get the full details of the models.
-	paramsModelInfo, err := c.getModelInfo(models)
-	if err != nil {
-		return errors.Annotate(err, "cannot get model details")
+	var paramsModelInfo []params.ModelInfo
+	var cached time.Time
+	if c.cached {
+		cached, err = jujuclient.ReadCachedData(c.ControllerName(), c.modelsCacheName(), &paramsModelInfo)
+		if errors.IsNotFound(err) {
+			return errors.Errorf("no models cached for controller %s", c.ControllerName())
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("%s", common.CachedDataNotice("models", cached, time.Now()))
+	} else {
+		paramsModelInfo, err = c.fetchModelInfo()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err := jujuclient.WriteCachedData(c.ControllerName(), c.modelsCacheName(), paramsModelInfo)
+		if err != nil {
+			logger.Debugf("cannot cache models: %v", err)
+		}
 	}
 
 	// TODO(perrito666) 2016-05-02 lp:1558657
 	now := time.Now()
-	modelInfo := make([]common.ModelInfo, 0, len(models))
+	modelInfo := make([]common.ModelInfo, 0, len(paramsModelInfo))
 	for _, info := range paramsModelInfo {
 		model, err := common.ModelInfoFromParams(info, now)
 		if err != nil {
@@ -164,6 +192,9 @@ func (c *modelsCommand) Run(ctx *cmd.Context) error {
 	}
 
 	modelSet := ModelSet{Models: modelInfo}
+	if c.cached {
+		modelSet.Cached = cached.UTC().Format(time.RFC3339)
+	}
 	current, err := c.ClientStore().CurrentModel(c.ControllerName())
 	if err != nil && !errors.IsNotFound(err) {
 		return err
@@ -183,7 +214,7 @@ func (c *modelsCommand) Run(ctx *cmd.Context) error {
 	if err := c.out.Write(ctx, modelSet); err != nil {
 		return err
 	}
-	if len(models) == 0 && c.out.Name() == "tabular" {
+	if len(modelInfo) == 0 && c.out.Name() == "tabular" {
 		// When the output is tabular, we inform the user when there
 		// are no models available, and tell them how to go about
 		// creating or granting access to them.
@@ -192,6 +223,37 @@ func (c *modelsCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// fetchModelInfo returns the details of the models being listed.
+func (c *modelsCommand) fetchModelInfo() ([]params.ModelInfo, error) {
+	// First get a list of the models.
+	var models []base.UserModel
+	var err error
+	if c.all {
+		models, err = c.getAllModels()
+	} else {
+		models, err = c.getUserModels()
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list models")
+	}
+
+	// And now get the full details of the models.
+	paramsModelInfo, err := c.getModelInfo(models)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get model details")
+	}
+	return paramsModelInfo, nil
+}
+
+// modelsCacheName returns the name under which the details of the
+// models being listed are cached.
+func (c *modelsCommand) modelsCacheName() string {
+	if c.all {
+		return "models-all"
+	}
+	return "models-" + names.NewUserTag(c.user).Canonical()
+}
+
 func (c *modelsCommand) getModelInfo(userModels []base.UserModel) ([]params.ModelInfo, error) {
 	client, err := c.getModelManagerAPI()
 	if err != nil {
@@ -277,7 +339,11 @@ func (c *modelsCommand) formatTabular(value interface{}) ([]byte, error) {
 	if c.listUUID {
 		fmt.Fprintf(tw, "\tMODEL UUID")
 	}
-	fmt.Fprintf(tw, "\tOWNER\tSTATUS\tLAST CONNECTION\n")
+	fmt.Fprintf(tw, "\tOWNER\tSTATUS")
+	if c.health {
+		fmt.Fprintf(tw, "\tMACHINES\tAGENTS\tLAST ACTIVITY")
+	}
+	fmt.Fprintf(tw, "\tLAST CONNECTION\n")
 	for _, model := range modelSet.Models {
 		owner := names.NewUserTag(model.Owner)
 		name := ownerQualifiedModelName(model.Name, owner, userForListing)
@@ -292,12 +358,33 @@ func (c *modelsCommand) formatTabular(value interface{}) ([]byte, error) {
 		if lastConnection == "" {
 			lastConnection = "never connected"
 		}
-		fmt.Fprintf(tw, "\t%s\t%s\t%s\n", model.Owner, model.Status.Current, lastConnection)
+		fmt.Fprintf(tw, "\t%s\t%s", model.Owner, model.Status.Current)
+		if c.health {
+			fmt.Fprintf(tw, "\t%s", formatModelHealth(model.Health))
+		}
+		fmt.Fprintf(tw, "\t%s\n", lastConnection)
 	}
 	tw.Flush()
 	return out.Bytes(), nil
 }
 
+// formatModelHealth returns the tab-separated machine, agent version
+// and last activity columns for a model with the given health.
+func formatModelHealth(health *common.ModelHealth) string {
+	if health == nil {
+		return "-\t-\t-"
+	}
+	machines := fmt.Sprint(health.Machines)
+	if health.MachinesDown > 0 {
+		machines += fmt.Sprintf(" (%d down)", health.MachinesDown)
+	}
+	agents := "-"
+	if len(health.AgentVersions) > 0 {
+		agents = strings.Join(health.AgentVersions, ",")
+	}
+	return fmt.Sprintf("%s\t%s\t%s", machines, agents, health.LastConnection)
+}
+
 // ownerQualifiedModelName returns the model name qualified with the
 // model owner if the owner is not the same as the given canonical
 // user name. If the owner is a local user, we omit the domain.
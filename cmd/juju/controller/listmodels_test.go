@@ -79,6 +79,12 @@ func (f *fakeModelMgrAPIClient) ModelInfo(tags []names.ModelTag) ([]params.Model
 						LastConnection: &last1,
 					}}
 				}
+				result.Health = &params.ModelHealth{
+					Machines:       3,
+					MachinesDown:   1,
+					AgentVersions:  []string{"2.0.0", "2.0.1"},
+					LastConnection: &last1,
+				}
 			case "test-model2":
 				last2 := time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)
 				result.Status.Status = status.StatusActive
@@ -88,6 +94,7 @@ func (f *fakeModelMgrAPIClient) ModelInfo(tags []names.ModelTag) ([]params.Model
 						LastConnection: &last2,
 					}}
 				}
+				result.Health = &params.ModelHealth{}
 			case "test-model3":
 				result.Status.Status = status.StatusDestroying
 			}
@@ -195,6 +202,52 @@ func (s *ModelsSuite) TestModelsUUID(c *gc.C) {
 		"\n")
 }
 
+func (s *ModelsSuite) TestModelsHealth(c *gc.C) {
+	context, err := testing.RunCommand(c, s.newCommand(), "--health")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"MODEL                        OWNER            STATUS      MACHINES    AGENTS       LAST ACTIVITY    LAST CONNECTION\n"+
+		"test-model1*                 admin@local      active      3 (1 down)  2.0.0,2.0.1  2015-03-20       2015-03-20\n"+
+		"carlotta/test-model2         carlotta@local   active      0           -            never connected  2015-03-01\n"+
+		"daiwik@external/test-model3  daiwik@external  destroying  -           -            -                never connected\n"+
+		"\n")
+}
+
+func (s *ModelsSuite) TestModelsHealthYAML(c *gc.C) {
+	context, err := testing.RunCommand(c, s.newCommand(), "--format", "yaml", "--fields", "models.name,models.health")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, `
+models:
+- health:
+    agent-versions:
+    - 2.0.0
+    - 2.0.1
+    last-connection: 2015-03-20
+    machines: 3
+    machines-down: 1
+  name: test-model1
+- health:
+    last-connection: never connected
+    machines: 0
+    machines-down: 0
+  name: test-model2
+- name: test-model3
+`[1:])
+}
+
+func (s *ModelsSuite) TestModelsFields(c *gc.C) {
+	context, err := testing.RunCommand(c, s.newCommand(), "--format", "yaml", "--fields", "models.name,models.owner")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"models:\n"+
+		"- name: test-model1\n"+
+		"  owner: admin@local\n"+
+		"- name: test-model2\n"+
+		"  owner: carlotta@local\n"+
+		"- name: test-model3\n"+
+		"  owner: daiwik@external\n")
+}
+
 func (s *ModelsSuite) TestUnrecognizedArg(c *gc.C) {
 	_, err := testing.RunCommand(c, s.newCommand(), "whoops")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
@@ -205,3 +258,40 @@ func (s *ModelsSuite) TestModelsError(c *gc.C) {
 	_, err := testing.RunCommand(c, s.newCommand())
 	c.Assert(err, gc.ErrorMatches, "cannot list models: permission denied")
 }
+
+func (s *ModelsSuite) TestModelsCached(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The controller is not contacted for cached models.
+	s.api.err = common.ErrPerm
+	context, err := testing.RunCommand(c, s.newCommand(), "--cached")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"MODEL                        OWNER            STATUS      LAST CONNECTION\n"+
+		"test-model1*                 admin@local      active      2015-03-20\n"+
+		"carlotta/test-model2         carlotta@local   active      2015-03-01\n"+
+		"daiwik@external/test-model3  daiwik@external  destroying  never connected\n"+
+		"\n")
+	c.Assert(testing.Stderr(context), gc.Matches, `Showing models cached just now \(.*\); it may be out of date.\n`)
+}
+
+func (s *ModelsSuite) TestModelsCachedYAML(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "--all")
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := testing.RunCommand(c, s.newCommand(), "--all", "--cached", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Matches, `(?s).*\ncached: "?\d{4}-\d\d-\d\dT\d\d:\d\d:\d\dZ"?\n.*`)
+}
+
+func (s *ModelsSuite) TestModelsCachedNone(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "--cached")
+	c.Assert(err, gc.ErrorMatches, "no models cached for controller fake")
+
+	// Models cached for one user are not listed for another.
+	_, err = testing.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, s.newCommand(), "--user", "bob", "--cached")
+	c.Assert(err, gc.ErrorMatches, "no models cached for controller fake")
+}
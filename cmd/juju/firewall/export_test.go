@@ -0,0 +1,22 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewListRulesCommandForTest returns a firewall-rules command with the
+// api provided as specified.
+func NewListRulesCommandForTest(api ListRulesAPI) cmd.Command {
+	return modelcmd.Wrap(&listRulesCommand{api: api})
+}
+
+// NewSetRuleCommandForTest returns a set-firewall-rule command with the
+// api provided as specified.
+func NewSetRuleCommandForTest(api SetRuleAPI) cmd.Command {
+	return modelcmd.Wrap(&setRuleCommand{api: api})
+}
@@ -0,0 +1,142 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package firewall provides the commands which manage the model-level
+// firewall rules.
+package firewall
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+const listRulesHelpDoc = `
+Lists the firewall rules of the model. A firewall rule restricts the
+source addresses from which the machines of the model may be reached
+for a well-known service, such as SSH. Services without a rule may be
+reached from any address.
+
+Examples:
+
+    juju firewall-rules
+    juju firewall-rules --format yaml
+
+See also:
+    set-firewall-rule
+`
+
+// NewListRulesCommand returns a command which lists the firewall rules
+// of a model.
+func NewListRulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listRulesCommand{})
+}
+
+type listRulesCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ListRulesAPI
+}
+
+// ListRulesAPI defines the API methods that the firewall-rules command
+// uses.
+type ListRulesAPI interface {
+	Close() error
+	ListFirewallRules() ([]network.FirewallRule, error)
+}
+
+// firewallRuleInfo holds the details of a firewall rule, for output.
+type firewallRuleInfo struct {
+	KnownService   string   `yaml:"known-service" json:"known-service"`
+	WhitelistCIDRs []string `yaml:"whitelist-cidrs" json:"whitelist-cidrs"`
+}
+
+// Info implements Command.Info.
+func (c *listRulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "firewall-rules",
+		Purpose: "Lists the firewall rules of a model.",
+		Doc:     listRulesHelpDoc[1:],
+		Aliases: []string{"list-firewall-rules"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatRulesTabular,
+	})
+}
+
+func (c *listRulesCommand) getAPI() (ListRulesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return firewallrules.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *listRulesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	rules, err := client.ListFirewallRules()
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No firewall rules to display.")
+		return nil
+	}
+	infos := make([]firewallRuleInfo, len(rules))
+	for i, rule := range rules {
+		infos[i] = firewallRuleInfo{
+			KnownService:   string(rule.WellKnownService),
+			WhitelistCIDRs: rule.SourceCIDRs(),
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+// formatRulesTabular takes an interface{} to adhere to the
+// cmd.Formatter interface.
+func formatRulesTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.([]firewallRuleInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "SERVICE\tWHITELIST SUBNETS\n")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%s\n", info.KnownService, strings.Join(info.WhitelistCIDRs, ","))
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type ListRulesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeFirewallRulesAPI
+}
+
+var _ = gc.Suite(&ListRulesSuite{})
+
+func (s *ListRulesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeFirewallRulesAPI{
+		rules: []network.FirewallRule{{
+			WellKnownService: network.SSHRule,
+			WhitelistCIDRs:   []string{"10.0.0.0/8", "192.168.1.0/24"},
+		}},
+	}
+}
+
+func (s *ListRulesSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(firewall.NewListRulesCommandForTest(s.fake), []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ListRulesSuite) TestTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, firewall.NewListRulesCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"SERVICE  WHITELIST SUBNETS\n"+
+		"ssh      10.0.0.0/8,192.168.1.0/24\n"+
+		"\n",
+	)
+	s.fake.CheckCallNames(c, "ListFirewallRules", "Close")
+}
+
+func (s *ListRulesSuite) TestYAML(c *gc.C) {
+	s.fake.rules[0].WhitelistCIDRs = nil
+	ctx, err := testing.RunCommand(c, firewall.NewListRulesCommandForTest(s.fake), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- known-service: ssh
+  whitelist-cidrs:
+  - 0.0.0.0/0
+`[1:])
+}
+
+func (s *ListRulesSuite) TestNoRules(c *gc.C) {
+	s.fake.rules = nil
+	ctx, err := testing.RunCommand(c, firewall.NewListRulesCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No firewall rules to display.\n")
+}
+
+type fakeFirewallRulesAPI struct {
+	gitjujutesting.Stub
+	rules []network.FirewallRule
+}
+
+func (f *fakeFirewallRulesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeFirewallRulesAPI) ListFirewallRules() ([]network.FirewallRule, error) {
+	f.MethodCall(f, "ListFirewallRules")
+	return f.rules, f.NextErr()
+}
+
+func (f *fakeFirewallRulesAPI) SetFirewallRule(service string, whitelistCIDRs []string) error {
+	f.MethodCall(f, "SetFirewallRule", service, whitelistCIDRs)
+	return f.NextErr()
+}
@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+const setRuleHelpDoc = `
+Restricts the source addresses from which the machines of the model
+may be reached for a well-known service to the given comma separated
+list of CIDRs. The rule is enforced by the firewaller, replacing any
+rule previously set for the service. Specifying an empty whitelist
+allows the service to be reached from any address.
+
+The well-known services are:
+
+    ssh    SSH access to all machines of the model
+
+Examples:
+
+    juju set-firewall-rule ssh --whitelist 192.168.1.0/24,10.0.0.0/8
+    juju set-firewall-rule ssh --whitelist ""
+
+See also:
+    firewall-rules
+`
+
+// NewSetRuleCommand returns a command which sets a firewall rule of a
+// model.
+func NewSetRuleCommand() cmd.Command {
+	return modelcmd.Wrap(&setRuleCommand{})
+}
+
+type setRuleCommand struct {
+	modelcmd.ModelCommandBase
+	api       SetRuleAPI
+	service   network.WellKnownServiceType
+	whitelist string
+	cidrs     []string
+}
+
+// SetRuleAPI defines the API methods that the set-firewall-rule
+// command uses.
+type SetRuleAPI interface {
+	Close() error
+	SetFirewallRule(service string, whitelistCIDRs []string) error
+}
+
+// Info implements Command.Info.
+func (c *setRuleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-firewall-rule",
+		Args:    "<service> --whitelist <cidr>[,<cidr>...]",
+		Purpose: "Sets a firewall rule of a model.",
+		Doc:     setRuleHelpDoc[1:],
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setRuleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.whitelist, "whitelist", "", "comma separated list of source CIDRs")
+}
+
+// Init implements Command.Init.
+func (c *setRuleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no well known service specified")
+	}
+	c.service = network.WellKnownServiceType(args[0])
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+	c.cidrs = nil
+	for _, cidr := range strings.Split(c.whitelist, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			c.cidrs = append(c.cidrs, cidr)
+		}
+	}
+	rule := network.FirewallRule{WellKnownService: c.service, WhitelistCIDRs: c.cidrs}
+	return rule.Validate()
+}
+
+func (c *setRuleCommand) getAPI() (SetRuleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return firewallrules.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *setRuleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.SetFirewallRule(string(c.service), c.cidrs); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
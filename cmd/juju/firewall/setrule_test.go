@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/testing"
+)
+
+type SetRuleSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeFirewallRulesAPI
+}
+
+var _ = gc.Suite(&SetRuleSuite{})
+
+func (s *SetRuleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeFirewallRulesAPI{}
+}
+
+func (s *SetRuleSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no well known service specified",
+	}, {
+		args: []string{"ssh", "bar"},
+		err:  `unrecognized args: \["bar"\]`,
+	}, {
+		args: []string{"telnet", "--whitelist", "10.0.0.0/8"},
+		err:  `well known service type "telnet" not valid`,
+	}, {
+		args: []string{"ssh", "--whitelist", "10.0.0.0/8,10.0.0.1"},
+		err:  `CIDR "10.0.0.1" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(firewall.NewSetRuleCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SetRuleSuite) TestSetRule(c *gc.C) {
+	_, err := testing.RunCommand(c, firewall.NewSetRuleCommandForTest(s.fake),
+		"ssh", "--whitelist", "10.0.0.0/8, 192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "SetFirewallRule", "Close")
+	s.fake.CheckCall(c, 0, "SetFirewallRule", "ssh", []string{"10.0.0.0/8", "192.168.1.0/24"})
+}
+
+func (s *SetRuleSuite) TestSetRuleEmptyWhitelist(c *gc.C) {
+	_, err := testing.RunCommand(c, firewall.NewSetRuleCommandForTest(s.fake), "ssh", "--whitelist", "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetFirewallRule", "ssh", []string(nil))
+}
+
+func (s *SetRuleSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockedError"))
+	_, err := testing.RunCommand(c, firewall.NewSetRuleCommandForTest(s.fake), "ssh")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
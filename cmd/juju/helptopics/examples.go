@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package helptopics
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// Example is a worked example of using a command.
+type Example struct {
+	// Description describes what the example does.
+	Description string
+
+	// Commands holds the command lines making up the example.
+	Commands []string
+}
+
+// ExampleRegistry holds the worked examples of commands.
+type ExampleRegistry struct {
+	mu       sync.Mutex
+	examples map[string][]Example
+}
+
+// NewExampleRegistry returns a new, empty ExampleRegistry.
+func NewExampleRegistry() *ExampleRegistry {
+	return &ExampleRegistry{examples: make(map[string][]Example)}
+}
+
+// Register adds examples for the named command. Examples are shown in
+// the order in which they are registered.
+func (r *ExampleRegistry) Register(command string, examples ...Example) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.examples[command] = append(r.examples[command], examples...)
+}
+
+// Examples returns the examples registered for the named command.
+func (r *ExampleRegistry) Examples(command string) []Example {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Example(nil), r.examples[command]...)
+}
+
+// Commands returns the names of the commands which have examples,
+// sorted by name.
+func (r *ExampleRegistry) Commands() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	commands := make([]string, 0, len(r.examples))
+	for command := range r.examples {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// examples holds the examples registered by the commands of the juju
+// client.
+var examples = NewExampleRegistry()
+
+// RegisterExamples adds examples for the named juju command. It is
+// intended to be called from the init function of the package which
+// implements the command.
+func RegisterExamples(command string, commandExamples ...Example) {
+	examples.Register(command, commandExamples...)
+}
+
+// Examples returns the examples registered for the named juju command.
+func Examples(command string) []Example {
+	return examples.Examples(command)
+}
+
+// FormatExamples renders the given examples of the named command as
+// help text.
+func FormatExamples(command string, commandExamples []Example) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Examples of %s:\n", command)
+	for _, example := range commandExamples {
+		fmt.Fprintf(&buf, "\n%s\n\n", example.Description)
+		for _, line := range example.Commands {
+			fmt.Fprintf(&buf, "    %s\n", line)
+		}
+	}
+	return buf.String()
+}
+
+// SeeExamples returns a note referring the user to the examples of the
+// named command, or the empty string if it has none.
+func SeeExamples(command string) string {
+	if len(Examples(command)) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("see: juju help examples %s", command)
+}
+
+// WithExamples returns an error which adds a note referring the user to
+// the examples of the named command to the message of err. If err is
+// nil, or the command has no examples, err is returned unchanged.
+func WithExamples(err error, command string) error {
+	if err == nil {
+		return nil
+	}
+	note := SeeExamples(command)
+	if note == "" {
+		return err
+	}
+	return &examplesError{err: err, note: note}
+}
+
+type examplesError struct {
+	err  error
+	note string
+}
+
+// Error is part of the error interface.
+func (e *examplesError) Error() string {
+	return fmt.Sprintf("%v\n%s", e.err, e.note)
+}
+
+// Cause returns the cause of the wrapped error, so that the error may
+// be tested with functions such as errors.IsNotValid.
+func (e *examplesError) Cause() error {
+	return errors.Cause(e.err)
+}
+
+// examplesTopic returns the text of the examples help topic. Given the
+// name of a command, it shows the examples of that command; otherwise
+// it lists the commands which have examples.
+func examplesTopic(registry *ExampleRegistry, args []string) string {
+	if len(args) > 0 {
+		command := args[0]
+		commandExamples := registry.Examples(command)
+		if len(commandExamples) == 0 {
+			return fmt.Sprintf("No examples for %q.\n\nSee also: juju help %s", command, command)
+		}
+		return FormatExamples(command, commandExamples)
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`
+Worked examples are available for the following commands. To show the
+examples of a command, run
+
+    juju help examples <command>
+
+`[1:])
+	for _, command := range registry.Commands() {
+		fmt.Fprintf(&buf, "    %s\n", command)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package helptopics_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/helptopics"
+	"github.com/juju/juju/testing"
+)
+
+type examplesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&examplesSuite{})
+
+var deployExamples = []helptopics.Example{{
+	Description: "Deploy mysql:",
+	Commands:    []string{"juju deploy mysql"},
+}, {
+	Description: "Deploy two units of wordpress and relate them to mysql:",
+	Commands: []string{
+		"juju deploy -n 2 wordpress",
+		"juju add-relation wordpress mysql",
+	},
+}}
+
+func (s *examplesSuite) TestRegistry(c *gc.C) {
+	registry := helptopics.NewExampleRegistry()
+	c.Assert(registry.Commands(), gc.HasLen, 0)
+	c.Assert(registry.Examples("deploy"), gc.HasLen, 0)
+
+	registry.Register("deploy", deployExamples[0])
+	registry.Register("deploy", deployExamples[1])
+	registry.Register("add-unit", helptopics.Example{Description: "Add a unit:"})
+	c.Assert(registry.Commands(), jc.DeepEquals, []string{"add-unit", "deploy"})
+	c.Assert(registry.Examples("deploy"), jc.DeepEquals, deployExamples)
+}
+
+func (s *examplesSuite) TestFormatExamples(c *gc.C) {
+	c.Assert(helptopics.FormatExamples("deploy", deployExamples), gc.Equals, `
+Examples of deploy:
+
+Deploy mysql:
+
+    juju deploy mysql
+
+Deploy two units of wordpress and relate them to mysql:
+
+    juju deploy -n 2 wordpress
+    juju add-relation wordpress mysql
+`[1:])
+}
+
+func (s *examplesSuite) TestExamplesTopicCommand(c *gc.C) {
+	registry := helptopics.NewExampleRegistry()
+	registry.Register("deploy", deployExamples...)
+	c.Assert(
+		helptopics.ExamplesTopic(registry, []string{"deploy"}),
+		gc.Equals,
+		helptopics.FormatExamples("deploy", deployExamples),
+	)
+}
+
+func (s *examplesSuite) TestExamplesTopicUnknownCommand(c *gc.C) {
+	registry := helptopics.NewExampleRegistry()
+	c.Assert(helptopics.ExamplesTopic(registry, []string{"foo"}), gc.Equals, `
+No examples for "foo".
+
+See also: juju help foo`[1:])
+}
+
+func (s *examplesSuite) TestExamplesTopicList(c *gc.C) {
+	registry := helptopics.NewExampleRegistry()
+	registry.Register("deploy", deployExamples...)
+	registry.Register("add-unit", helptopics.Example{Description: "Add a unit:"})
+	c.Assert(helptopics.ExamplesTopic(registry, nil), gc.Equals, `
+Worked examples are available for the following commands. To show the
+examples of a command, run
+
+    juju help examples <command>
+
+    add-unit
+    deploy`[1:])
+}
+
+func (s *examplesSuite) TestWithExamples(c *gc.C) {
+	helptopics.RegisterExamples("test-with-examples", deployExamples...)
+	err := helptopics.WithExamples(errors.NotValidf("unit name %q", "foo"), "test-with-examples")
+	c.Assert(err, gc.ErrorMatches, `unit name "foo" not valid
+see: juju help examples test-with-examples`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *examplesSuite) TestWithExamplesNoExamples(c *gc.C) {
+	original := errors.New("boom")
+	err := helptopics.WithExamples(original, "test-without-examples")
+	c.Assert(err, gc.Equals, original)
+	c.Assert(helptopics.WithExamples(nil, "test-without-examples"), jc.ErrorIsNil)
+}
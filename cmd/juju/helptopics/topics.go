@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package helptopics provides the help topics of the juju client which
+// go beyond the help of individual commands, such as the worked
+// examples of commands shown by "juju help examples <command>".
+package helptopics
+
+import (
+	"github.com/juju/cmd"
+)
+
+// Topic is a help topic whose text may depend on the arguments which
+// follow the topic name, as in "juju help examples deploy".
+type Topic struct {
+	// Name is the name of the topic.
+	Name string
+
+	// Short is the one line description of the topic.
+	Short string
+
+	// Long returns the text of the topic, given the arguments which
+	// followed the topic name.
+	Long func(args []string) string
+}
+
+// Topics holds a set of help topics for a super command. As juju/cmd
+// does not pass topic arguments to topic callbacks, Topics records the
+// arguments of the help command through NotifyHelp.
+type Topics struct {
+	topics []Topic
+	args   []string
+}
+
+// NewTopics returns the help topics of the juju client.
+func NewTopics() *Topics {
+	return &Topics{
+		topics: []Topic{{
+			Name:  "examples",
+			Short: "Worked examples of commands",
+			Long: func(args []string) string {
+				return examplesTopic(examples, args)
+			},
+		}},
+	}
+}
+
+// Add adds a topic.
+func (t *Topics) Add(topic Topic) {
+	t.topics = append(t.topics, topic)
+}
+
+// NotifyHelp records the arguments of the help command. It should be
+// passed as the NotifyHelp parameter of the super command to which the
+// topics are added.
+func (t *Topics) NotifyHelp(args []string) {
+	t.args = append([]string(nil), args...)
+}
+
+// AddTo adds the topics to the given super command.
+func (t *Topics) AddTo(super *cmd.SuperCommand) {
+	for _, topic := range t.topics {
+		topic := topic
+		super.AddHelpTopicCallback(topic.Name, topic.Short, func() string {
+			return topic.Long(t.topicArgs(topic.Name))
+		})
+	}
+}
+
+// topicArgs returns the arguments which followed the named topic in
+// the arguments of the help command.
+func (t *Topics) topicArgs(name string) []string {
+	if len(t.args) == 0 || t.args[0] != name {
+		return nil
+	}
+	return t.args[1:]
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package helptopics_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/helptopics"
+	"github.com/juju/juju/testing"
+)
+
+type topicsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&topicsSuite{})
+
+func (s *topicsSuite) runHelp(c *gc.C, topics *helptopics.Topics, args ...string) string {
+	super := cmd.NewSuperCommand(cmd.SuperCommandParams{
+		Name:            "juju",
+		MissingCallback: func(*cmd.Context, string, []string) error { return nil },
+		NotifyHelp:      topics.NotifyHelp,
+	})
+	topics.AddTo(super)
+	ctx, err := testing.RunCommand(c, super, append([]string{"help"}, args...)...)
+	c.Assert(err, jc.ErrorIsNil)
+	return testing.Stdout(ctx)
+}
+
+func (s *topicsSuite) TestTopicArgs(c *gc.C) {
+	var topicArgs [][]string
+	topics := helptopics.NewTopics()
+	topics.Add(helptopics.Topic{
+		Name:  "echo",
+		Short: "Echo the topic arguments",
+		Long: func(args []string) string {
+			topicArgs = append(topicArgs, args)
+			return strings.Join(args, " ")
+		},
+	})
+	out := s.runHelp(c, topics, "echo", "foo", "bar")
+	c.Assert(out, gc.Equals, "foo bar\n")
+	c.Assert(topicArgs, jc.DeepEquals, [][]string{{"foo", "bar"}})
+}
+
+func (s *topicsSuite) TestTopicsListed(c *gc.C) {
+	out := s.runHelp(c, helptopics.NewTopics(), "topics")
+	c.Assert(out, gc.Matches, `(?s).*examples +Worked examples of commands.*`)
+}
+
+func (s *topicsSuite) TestExamplesTopic(c *gc.C) {
+	helptopics.RegisterExamples("test-topic", deployExamples...)
+	out := s.runHelp(c, helptopics.NewTopics(), "examples", "test-topic")
+	c.Assert(out, gc.Equals, helptopics.FormatExamples("test-topic", deployExamples))
+}
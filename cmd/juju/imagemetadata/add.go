@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagemetadata
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/series"
+
+	"github.com/juju/juju/api/imagemetadata"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const addHelpDoc = `
+Adds custom metadata for a cloud image to the model. The provisioner
+prefers custom image metadata over that published in the upstream
+simplestreams when choosing the image for a new machine of the model.
+
+Image metadata properties vary between providers. Consequently, some
+properties are optional for this command, but they may still be needed
+by your provider. Properties which are not specified default to those
+of the model: the region of the model, its default series and its
+image stream.
+
+Examples:
+
+    juju add-image-metadata ami-0123abcd --series xenial --arch amd64
+    juju add-image-metadata ami-4567efgh --region us-west-1 --virt-type hvm
+`
+
+// NewAddCommand returns a command which adds custom image metadata to
+// a model.
+func NewAddCommand() cmd.Command {
+	return modelcmd.Wrap(&addCommand{})
+}
+
+type addCommand struct {
+	modelcmd.ModelCommandBase
+	api AddAPI
+
+	imageId         string
+	region          string
+	series          string
+	arch            string
+	virtType        string
+	rootStorageType string
+	rootStorageSize uint64
+	stream          string
+}
+
+// AddAPI defines the API methods that the add-image-metadata command
+// uses.
+type AddAPI interface {
+	Close() error
+	Save(metadata []params.CloudImageMetadata) error
+}
+
+// Info implements Command.Info.
+func (c *addCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-image-metadata",
+		Args:    "<image-id>",
+		Purpose: "Adds custom image metadata to a model.",
+		Doc:     addHelpDoc[1:],
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.region, "region", "", "image cloud region")
+	f.StringVar(&c.series, "series", "", "image series")
+	f.StringVar(&c.arch, "arch", "amd64", "image architecture")
+	f.StringVar(&c.virtType, "virt-type", "", "image virtualisation type")
+	f.StringVar(&c.rootStorageType, "storage-type", "", "image root storage type")
+	f.Uint64Var(&c.rootStorageSize, "storage-size", 0, "image root storage size")
+	f.StringVar(&c.stream, "stream", "", "image stream")
+}
+
+// Init implements Command.Init.
+func (c *addCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no image id specified")
+	}
+	c.imageId = args[0]
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+	if c.series != "" {
+		if _, err := series.SeriesVersion(c.series); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (c *addCommand) getAPI() (AddAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return imagemetadata.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *addCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	metadata := params.CloudImageMetadata{
+		ImageId:         c.imageId,
+		Region:          c.region,
+		Series:          c.series,
+		Arch:            c.arch,
+		VirtType:        c.virtType,
+		RootStorageType: c.rootStorageType,
+		Stream:          c.stream,
+		Source:          "custom",
+	}
+	if c.rootStorageSize != 0 {
+		metadata.RootStorageSize = &c.rootStorageSize
+	}
+	if err := client.Save([]params.CloudImageMetadata{metadata}); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagemetadata_test
+
+import (
+	"github.com/juju/cmd"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/imagemetadata"
+	"github.com/juju/juju/testing"
+)
+
+type AddSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeImageMetadataAPI
+}
+
+var _ = gc.Suite(&AddSuite{})
+
+func (s *AddSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeImageMetadataAPI{}
+}
+
+func (s *AddSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no image id specified",
+	}, {
+		args: []string{"ami-1234", "bar"},
+		err:  `unrecognized args: \["bar"\]`,
+	}, {
+		args: []string{"ami-1234", "--series", "nonsense"},
+		err:  `.*unknown version for series: "nonsense"`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(imagemetadata.NewAddCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AddSuite) TestAdd(c *gc.C) {
+	_, err := testing.RunCommand(c, imagemetadata.NewAddCommandForTest(s.fake),
+		"ami-1234", "--series", "xenial", "--region", "us-west-1",
+		"--virt-type", "hvm", "--storage-type", "ebs", "--storage-size", "8",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	size := uint64(8)
+	s.fake.CheckCallNames(c, "Save", "Close")
+	s.fake.CheckCall(c, 0, "Save", []params.CloudImageMetadata{{
+		ImageId:         "ami-1234",
+		Region:          "us-west-1",
+		Series:          "xenial",
+		Arch:            "amd64",
+		VirtType:        "hvm",
+		RootStorageType: "ebs",
+		RootStorageSize: &size,
+		Source:          "custom",
+	}})
+}
+
+func (s *AddSuite) TestAddDefaults(c *gc.C) {
+	_, err := testing.RunCommand(c, imagemetadata.NewAddCommandForTest(s.fake), "ami-1234")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "Save", []params.CloudImageMetadata{{
+		ImageId: "ami-1234",
+		Arch:    "amd64",
+		Source:  "custom",
+	}})
+}
+
+func (s *AddSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockedError"))
+	_, err := testing.RunCommand(c, imagemetadata.NewAddCommandForTest(s.fake), "ami-1234")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
+
+type fakeImageMetadataAPI struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeImageMetadataAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImageMetadataAPI) Save(metadata []params.CloudImageMetadata) error {
+	f.MethodCall(f, "Save", metadata)
+	return f.NextErr()
+}
@@ -0,0 +1,16 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagemetadata
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAddCommandForTest returns an add-image-metadata command with the
+// api provided as specified.
+func NewAddCommandForTest(api AddAPI) cmd.Command {
+	return modelcmd.Wrap(&addCommand{api: api})
+}
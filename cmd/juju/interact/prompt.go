@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package interact
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// NewLineScanner returns a scanner that reads lines from r one byte at a time,
+// so that no input past the end of a line is consumed.  This allows prompts
+// using the scanner to be interleaved with other reads from r.
+func NewLineScanner(r io.Reader) *bufio.Scanner {
+	return bufio.NewScanner(byteAtATimeReader{r})
+}
+
+type byteAtATimeReader struct {
+	io.Reader
+}
+
+func (r byteAtATimeReader) Read(out []byte) (int, error) {
+	return r.Reader.Read(out[:1])
+}
+
+// QueryDefault asks the question with the default value shown in square
+// brackets, returning def if the user enters an empty answer.  Verify, if
+// non-nil, is called on any non-empty answer, as for QueryVerify.
+func QueryDefault(question, def string, scanner *bufio.Scanner, w io.Writer, verify func(string) error) (string, error) {
+	query := fmt.Sprintf("%s [%s]: ", question, def)
+	answer, err := QueryVerify([]byte(query), scanner, w, func(s string) error {
+		if s == "" || verify == nil {
+			return nil
+		}
+		return verify(s)
+	})
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// Selection describes a list of options from which the user is asked to
+// choose one.
+type Selection struct {
+	// Heading is written on the line before the options.
+	Heading string
+
+	// Options holds the options to choose from, in the order in which they
+	// are written.
+	Options []string
+
+	// Question is written after the options.  The default is appended to it.
+	Question string
+
+	// Default is the option chosen if the user enters an empty answer.
+	Default string
+
+	// Invalid is the error reported to the user if the answer does not
+	// match any of the options.
+	Invalid error
+}
+
+// Select writes out the selection's options and asks the user to choose one
+// of them, repeating the question until a valid option is chosen.  Matching is
+// case insensitive, and the option is returned as it appears in the selection.
+func Select(sel Selection, scanner *bufio.Scanner, w io.Writer) (string, error) {
+	if _, err := fmt.Fprint(w, sel.Heading, "\n", strings.Join(sel.Options, "\n"), "\n\n"); err != nil {
+		return "", errors.Trace(err)
+	}
+	invalid := sel.Invalid
+	if invalid == nil {
+		invalid = errors.New("Invalid choice.")
+	}
+	// allow an empty answer to select the default.
+	options := append([]string{""}, sel.Options...)
+	answer, err := QueryDefault(sel.Question, sel.Default, scanner, w, MatchOptions(options, invalid))
+	if err != nil {
+		return "", err
+	}
+	if answer == sel.Default {
+		return sel.Default, nil
+	}
+	match, ok := FindMatch(answer, sel.Options)
+	if !ok {
+		// should be impossible
+		return "", errors.Errorf("invalid option chosen: %s", answer)
+	}
+	return match, nil
+}
+
+// QueryYesNo asks a question which expects a yes or no answer, returning def
+// if the user enters an empty answer.
+func QueryYesNo(question string, def bool, scanner *bufio.Scanner, w io.Writer) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+	query := fmt.Sprintf("%s (%s): ", question, choices)
+	verify := MatchOptions([]string{"", "y", "yes", "n", "no"}, errors.New("Please answer y or n."))
+	answer, err := QueryVerify([]byte(query), scanner, w, verify)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return def, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package interact
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type PromptSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(PromptSuite{})
+
+func (PromptSuite) TestLineScannerLeavesRemainingInput(c *gc.C) {
+	r := strings.NewReader("first\nsecond\n")
+	scanner := NewLineScanner(r)
+	c.Assert(scanner.Scan(), jc.IsTrue)
+	c.Assert(scanner.Text(), gc.Equals, "first")
+	rest, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(rest), gc.Equals, "second\n")
+}
+
+func (PromptSuite) TestQueryDefault(c *gc.C) {
+	scanner := NewLineScanner(strings.NewReader("\n"))
+	out := bytes.Buffer{}
+	answer, err := QueryDefault("Enter a name", "bob", scanner, &out, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(answer, gc.Equals, "bob")
+	c.Assert(out.String(), gc.Equals, "Enter a name [bob]: \n")
+}
+
+func (PromptSuite) TestQueryDefaultVerify(c *gc.C) {
+	scanner := NewLineScanner(strings.NewReader("bad\ngood\n"))
+	out := bytes.Buffer{}
+	verify := func(s string) error {
+		if s == "good" {
+			return nil
+		}
+		return errors.New("No!")
+	}
+	answer, err := QueryDefault("Enter a name", "bob", scanner, &out, verify)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(answer, gc.Equals, "good")
+	expected := `
+Enter a name [bob]: No!
+
+Enter a name [bob]: 
+`[1:]
+	c.Assert(out.String(), gc.Equals, expected)
+}
+
+func (PromptSuite) TestSelect(c *gc.C) {
+	scanner := NewLineScanner(strings.NewReader("mars\nJUPITER\n"))
+	out := bytes.Buffer{}
+	answer, err := Select(Selection{
+		Heading:  "Planets",
+		Options:  []string{"mercury", "jupiter"},
+		Question: "Select a planet",
+		Default:  "mercury",
+		Invalid:  errors.New("Invalid planet."),
+	}, scanner, &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(answer, gc.Equals, "jupiter")
+	expected := `
+Planets
+mercury
+jupiter
+
+Select a planet [mercury]: Invalid planet.
+
+Select a planet [mercury]: 
+`[1:]
+	c.Assert(out.String(), gc.Equals, expected)
+}
+
+func (PromptSuite) TestSelectDefault(c *gc.C) {
+	scanner := NewLineScanner(strings.NewReader("\n"))
+	answer, err := Select(Selection{
+		Options: []string{"mercury", "jupiter"},
+		Default: "jupiter",
+	}, scanner, ioutil.Discard)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(answer, gc.Equals, "jupiter")
+}
+
+func (PromptSuite) TestQueryYesNo(c *gc.C) {
+	for i, test := range []struct {
+		input    string
+		def      bool
+		expected bool
+	}{
+		{"y\n", false, true},
+		{"Yes\n", false, true},
+		{"n\n", true, false},
+		{"\n", false, false},
+		{"\n", true, true},
+		{"maybe\ny\n", false, true},
+	} {
+		c.Logf("test %d: %q", i, test.input)
+		scanner := NewLineScanner(strings.NewReader(test.input))
+		answer, err := QueryYesNo("Continue?", test.def, scanner, ioutil.Discard)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(answer, gc.Equals, test.expected)
+	}
+}
+
+func (PromptSuite) TestQueryYesNoOutput(c *gc.C) {
+	scanner := NewLineScanner(strings.NewReader("maybe\n\n"))
+	out := bytes.Buffer{}
+	_, err := QueryYesNo("Continue?", true, scanner, &out)
+	c.Assert(err, jc.ErrorIsNil)
+	expected := `
+Continue? (Y/n): Please answer y or n.
+
+Continue? (Y/n): 
+`[1:]
+	c.Assert(out.String(), gc.Equals, expected)
+}
+
+func (PromptSuite) TestQueryYesNoEOF(c *gc.C) {
+	scanner := NewLineScanner(strings.NewReader(""))
+	_, err := QueryYesNo("Continue?", true, scanner, ioutil.Discard)
+	c.Assert(err, gc.Equals, io.EOF)
+}
@@ -5,6 +5,7 @@ package machine
 
 import (
 	"fmt"
+	"io/ioutil"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
 )
@@ -51,6 +53,21 @@ and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
 the same network as the API server.
 
+Several machines may be provisioned manually at once, either by separating
+the hosts with commas in the "ssh:" placement, or by listing them, one
+[user@]host per line, in a file passed with --hosts-file. Before any agents
+are provisioned, each host is checked in parallel for connectivity, a
+supported series and architecture, and enough free disk space; hosts that
+fail the checks are reported and skipped.
+
+Instances which were started in the model's cloud without Juju may be
+adopted into the model with the "instance:" placement, naming one or
+more comma-separated instance ids. Juju looks up the addresses of each
+instance, and then provisions it over SSH as for manual provisioning.
+The resulting machine is recorded with the instance's real id, hardware
+characteristics and availability zone, and is managed like any other
+machine, except that removing it leaves the instance running.
+
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
 information about how to allocate the machine. For example, one can direct the
@@ -65,6 +82,11 @@ Examples:
    juju add-machine lxd:4                (starts a new lxd container on machine 4)
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions a machine with ssh)
+   juju add-machine ssh:10.10.0.3,10.10.0.4
+                                         (manually provisions two machines with ssh)
+   juju add-machine --hosts-file hosts.txt
+                                         (manually provisions the hosts listed in hosts.txt)
+   juju add-machine instance:i-0123abcd  (adopts an existing EC2 instance)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
 
@@ -106,12 +128,14 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// HostsFile is the path to a file listing hosts to provision manually.
+	HostsFile string
 }
 
 func (c *addCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-machine",
-		Args:    "[<container>:machine | <container> | ssh:[user@]host | placement]",
+		Args:    "[<container>:machine | <container> | ssh:[user@]host[,...] | instance:id[,...] | placement]",
 		Purpose: "Start a new, empty machine and optionally a container, or add a container to a machine.",
 		Doc:     addMachineDoc,
 		Aliases: []string{"add-machines"},
@@ -123,6 +147,7 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.Var(constraints.ConstraintsValue{Target: &c.Constraints}, "constraints", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.HostsFile, "hosts-file", "", "Path to a file listing [user@]hosts to provision manually, one per line")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -133,6 +158,15 @@ func (c *addCommand) Init(args []string) error {
 	if err != nil {
 		return err
 	}
+	if c.HostsFile != "" {
+		if placement != "" {
+			return errors.New("cannot specify a placement with --hosts-file")
+		}
+		if c.NumMachines > 1 {
+			return errors.New("cannot use -n with --hosts-file")
+		}
+		return nil
+	}
 	c.Placement, err = instance.ParsePlacement(placement)
 	if err == instance.ErrPlacementScopeMissing {
 		placement = "model-uuid" + ":" + placement
@@ -155,6 +189,10 @@ type AddMachineAPI interface {
 	ProvisioningScript(params.ProvisioningScriptParams) (script string, err error)
 }
 
+// instanceScope is the placement scope used to name existing instances
+// in the model's cloud to adopt as machines.
+const instanceScope = "instance"
+
 type ModelConfigAPI interface {
 	ModelGet() (map[string]interface{}, error)
 	Close() error
@@ -164,9 +202,13 @@ type MachineManagerAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
 	BestAPIVersion() int
 	Close() error
+	ProviderInstances(ids ...instance.Id) ([]params.ProviderInstanceResult, error)
 }
 
-var manualProvisioner = manual.ProvisionMachine
+var (
+	manualProvisioner  = manual.ProvisionMachine
+	manualHostsChecker = manual.CheckHosts
+)
 
 func (c *addCommand) getClientAPI() (AddMachineAPI, error) {
 	if c.api != nil {
@@ -209,16 +251,20 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	adopting := c.Placement != nil && c.Placement.Scope == instanceScope
 	var machineManager MachineManagerAPI
-	if len(c.Disks) > 0 {
+	if len(c.Disks) > 0 || adopting {
 		machineManager, err = c.getMachineManagerAPI()
 		if err != nil {
 			return errors.Trace(err)
 		}
 		defer machineManager.Close()
-		if machineManager.BestAPIVersion() < 1 {
+		if len(c.Disks) > 0 && machineManager.BestAPIVersion() < 1 {
 			return errors.New("cannot add machines with disks: not supported by the API server")
 		}
+		if adopting && machineManager.BestAPIVersion() < 3 {
+			return errors.New("cannot adopt instances: not supported by the API server")
+		}
 	}
 
 	logger.Infof("load config")
@@ -236,29 +282,11 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	if c.Placement != nil && c.Placement.Scope == "ssh" {
-		logger.Infof("manual provisioning")
-		authKeys, err := common.ReadAuthorizedKeys(ctx, "")
-		if err != nil {
-			return errors.Annotate(err, "reading authorized-keys")
-		}
-		args := manual.ProvisionMachineArgs{
-			Host:           c.Placement.Directive,
-			Client:         client,
-			Stdin:          ctx.Stdin,
-			Stdout:         ctx.Stdout,
-			Stderr:         ctx.Stderr,
-			AuthorizedKeys: authKeys,
-			UpdateBehavior: &params.UpdateBehavior{
-				config.EnableOSRefreshUpdate(),
-				config.EnableOSUpgrade(),
-			},
-		}
-		machineId, err := manualProvisioner(args)
-		if err == nil {
-			ctx.Infof("created machine %v", machineId)
-		}
-		return err
+	if c.HostsFile != "" || c.Placement != nil && c.Placement.Scope == "ssh" {
+		return c.manualProvision(ctx, client, config)
+	}
+	if adopting {
+		return c.adoptInstances(ctx, client, machineManager, config)
 	}
 
 	logger.Infof("model provisioning")
@@ -331,3 +359,172 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	}
 	return nil
 }
+
+// manualHosts returns the [user@]hosts to provision manually, taken
+// either from the ssh placement directive or from the hosts file.
+func (c *addCommand) manualHosts(ctx *cmd.Context) ([]string, error) {
+	var hosts []string
+	if c.HostsFile != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.HostsFile))
+		if err != nil {
+			return nil, errors.Annotate(err, "reading hosts file")
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			hosts = append(hosts, line)
+		}
+	} else {
+		for _, host := range strings.Split(c.Placement.Directive, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	if len(hosts) == 0 {
+		return nil, errors.New("no hosts specified for manual provisioning")
+	}
+	return hosts, nil
+}
+
+// manualProvision checks each of the manual hosts, and then provisions
+// a machine agent on each host that passed the checks.
+func (c *addCommand) manualProvision(ctx *cmd.Context, client AddMachineAPI, cfg *config.Config) error {
+	logger.Infof("manual provisioning")
+	hosts, err := c.manualHosts(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.provisionHosts(ctx, client, cfg, hosts, nil, nil)
+}
+
+// adoptInstances looks up the addresses of each of the instances named
+// by the "instance:" placement, and then provisions a machine agent on
+// each instance that was found, as for manual provisioning.
+func (c *addCommand) adoptInstances(
+	ctx *cmd.Context,
+	client AddMachineAPI,
+	machineManager MachineManagerAPI,
+	cfg *config.Config,
+) error {
+	logger.Infof("adopting instances")
+	var ids []instance.Id
+	for _, id := range strings.Split(c.Placement.Directive, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, instance.Id(id))
+		}
+	}
+	if len(ids) == 0 {
+		return errors.New("no instances specified for adoption")
+	}
+	results, err := machineManager.ProviderInstances(ids...)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+
+	var errs []error
+	var hosts []string
+	adopted := make(map[string]params.ProviderInstanceResult)
+	for _, result := range results {
+		var err error
+		if result.Error != nil {
+			err = result.Error
+		} else if addr, ok := network.SelectPublicAddress(params.NetworkAddresses(result.Addresses...)); ok {
+			hosts = append(hosts, addr.Value)
+			adopted[addr.Value] = result
+			continue
+		} else {
+			err = errors.New("no address found")
+		}
+		ctx.Infof("instance %s cannot be adopted: %v", result.InstanceId, err)
+		errs = append(errs, errors.Annotatef(err, "instance %s", result.InstanceId))
+	}
+	if len(hosts) == 0 {
+		return c.provisionErrors(ctx, len(ids), errs)
+	}
+	return c.provisionHosts(ctx, client, cfg, hosts, adopted, errs)
+}
+
+// provisionHosts checks each of the hosts, and then provisions a
+// machine agent on each host that passed the checks. Hosts found in
+// adopted are recorded as the instances they are the addresses of. Any
+// errors are reported along with the given earlier errors.
+func (c *addCommand) provisionHosts(
+	ctx *cmd.Context,
+	client AddMachineAPI,
+	cfg *config.Config,
+	hosts []string,
+	adopted map[string]params.ProviderInstanceResult,
+	errs []error,
+) error {
+	count := len(hosts) + len(errs)
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotate(err, "reading authorized-keys")
+	}
+
+	var checked []string
+	for _, result := range manualHostsChecker(hosts) {
+		if result.Error != nil {
+			ctx.Infof("host %s failed checks: %v", result.Host, result.Error)
+			errs = append(errs, errors.Annotatef(result.Error, "host %s", result.Host))
+			continue
+		}
+		ctx.Infof(
+			"host %s passed checks (series=%s arch=%s disk-free=%dM)",
+			result.Host, result.Series, result.Arch, result.DiskFreeMB,
+		)
+		checked = append(checked, result.Host)
+	}
+
+	for _, host := range checked {
+		args := manual.ProvisionMachineArgs{
+			Host:           host,
+			Client:         client,
+			Stdin:          ctx.Stdin,
+			Stdout:         ctx.Stdout,
+			Stderr:         ctx.Stderr,
+			AuthorizedKeys: authKeys,
+			UpdateBehavior: &params.UpdateBehavior{
+				cfg.EnableOSRefreshUpdate(),
+				cfg.EnableOSUpgrade(),
+			},
+		}
+		if inst, ok := adopted[host]; ok {
+			args.InstanceId = instance.Id(inst.InstanceId)
+			args.AvailabilityZone = inst.AvailabilityZone
+		}
+		machineId, err := manualProvisioner(args)
+		if err != nil {
+			if len(hosts) > 1 {
+				err = errors.Annotatef(err, "host %s", host)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		ctx.Infof("created machine %v", machineId)
+	}
+	return c.provisionErrors(ctx, count, errs)
+}
+
+// provisionErrors reports the errors that occurred while provisioning
+// the given number of hosts or instances.
+func (c *addCommand) provisionErrors(ctx *cmd.Context, count int, errs []error) error {
+	if len(errs) == 1 {
+		if count > 1 {
+			fmt.Fprint(ctx.Stderr, "failed to create 1 machine\n")
+		}
+		return errs[0]
+	}
+	if len(errs) > 1 {
+		fmt.Fprintf(ctx.Stderr, "failed to create %d machines\n", len(errs))
+		returnErr := []string{}
+		for _, e := range errs {
+			returnErr = append(returnErr, e.Error())
+		}
+		return errors.New(strings.Join(returnErr, ", "))
+	}
+	return nil
+}
@@ -4,6 +4,8 @@
 package machine_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -16,6 +18,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -77,6 +81,15 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"ssh:user@10.10.0.3"},
 			count:     1,
 			placement: "ssh:user@10.10.0.3",
+		}, {
+			args:  []string{"--hosts-file", "hosts.txt"},
+			count: 1,
+		}, {
+			args:        []string{"--hosts-file", "hosts.txt", "ssh:10.10.0.3"},
+			errorString: "cannot specify a placement with --hosts-file",
+		}, {
+			args:        []string{"--hosts-file", "hosts.txt", "-n", "2"},
+			errorString: "cannot use -n with --hosts-file",
 		}, {
 			args:      []string{"zone=us-east-1a"},
 			count:     1,
@@ -130,22 +143,152 @@ func (s *AddMachineSuite) TestAddMachine(c *gc.C) {
 	})
 }
 
+func (s *AddMachineSuite) patchHostsChecker(failed map[string]error) *[]string {
+	var checked []string
+	s.PatchValue(machine.ManualHostsChecker, func(hosts []string) []manual.HostCheckResult {
+		checked = append(checked, hosts...)
+		results := make([]manual.HostCheckResult, len(hosts))
+		for i, host := range hosts {
+			results[i] = manual.HostCheckResult{
+				Host:       host,
+				Series:     "xenial",
+				Arch:       "amd64",
+				DiskFreeMB: 4096,
+				Error:      failed[host],
+			}
+		}
+		return results
+	})
+	return &checked
+}
+
 func (s *AddMachineSuite) TestSSHPlacement(c *gc.C) {
+	s.patchHostsChecker(nil)
 	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
 		return "42", nil
 	})
 	context, err := s.run(c, "ssh:10.1.2.3")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(testing.Stderr(context), gc.Equals, "created machine 42\n")
+	c.Assert(testing.Stderr(context), gc.Equals, ""+
+		"host 10.1.2.3 passed checks (series=xenial arch=amd64 disk-free=4096M)\n"+
+		"created machine 42\n")
 }
 
 func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
+	s.patchHostsChecker(nil)
 	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
 		return "", errors.New("failed to initialize warp core")
 	})
 	context, err := s.run(c, "ssh:10.1.2.3")
 	c.Assert(err, gc.ErrorMatches, "failed to initialize warp core")
-	c.Assert(testing.Stderr(context), gc.Equals, "")
+	c.Assert(testing.Stderr(context), gc.Equals, ""+
+		"host 10.1.2.3 passed checks (series=xenial arch=amd64 disk-free=4096M)\n")
+}
+
+func (s *AddMachineSuite) TestSSHPlacementCheckFailed(c *gc.C) {
+	s.patchHostsChecker(map[string]error{"10.1.2.3": errors.New("cannot connect")})
+	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Fatalf("unexpected provisioning of %q", args.Host)
+		return "", nil
+	})
+	context, err := s.run(c, "ssh:10.1.2.3")
+	c.Assert(err, gc.ErrorMatches, "host 10.1.2.3: cannot connect")
+	c.Assert(testing.Stderr(context), gc.Equals, "host 10.1.2.3 failed checks: cannot connect\n")
+}
+
+func (s *AddMachineSuite) TestSSHPlacementMultipleHosts(c *gc.C) {
+	checked := s.patchHostsChecker(map[string]error{
+		"10.1.2.4": errors.New(`architecture "mips" not supported`),
+	})
+	var provisioned []string
+	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		provisioned = append(provisioned, args.Host)
+		return strconv.Itoa(len(provisioned) - 1), nil
+	})
+	context, err := s.run(c, "ssh:10.1.2.3,ubuntu@10.1.2.4,10.1.2.5")
+	c.Assert(err, gc.ErrorMatches, `host 10.1.2.4: architecture "mips" not supported`)
+	c.Assert(*checked, jc.DeepEquals, []string{"10.1.2.3", "ubuntu@10.1.2.4", "10.1.2.5"})
+	c.Assert(provisioned, jc.DeepEquals, []string{"10.1.2.3", "10.1.2.5"})
+	c.Assert(testing.Stderr(context), gc.Equals, ""+
+		"host 10.1.2.3 passed checks (series=xenial arch=amd64 disk-free=4096M)\n"+
+		"host ubuntu@10.1.2.4 failed checks: architecture \"mips\" not supported\n"+
+		"host 10.1.2.5 passed checks (series=xenial arch=amd64 disk-free=4096M)\n"+
+		"created machine 0\n"+
+		"created machine 1\n"+
+		"failed to create 1 machine\n")
+}
+
+func (s *AddMachineSuite) TestInstancePlacement(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 3
+	s.fakeMachineManager.instances = []params.ProviderInstanceResult{{
+		InstanceId:       "i-1",
+		Addresses:        params.FromNetworkAddresses(network.NewAddress("10.1.2.3")),
+		AvailabilityZone: "zone-a",
+	}, {
+		InstanceId: "i-2",
+		Error:      &params.Error{Message: `instance "i-2" not found`, Code: params.CodeNotFound},
+	}}
+	checked := s.patchHostsChecker(nil)
+	var provisioned []manual.ProvisionMachineArgs
+	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		provisioned = append(provisioned, args)
+		return "42", nil
+	})
+	context, err := s.run(c, "instance:i-1,i-2")
+	c.Assert(err, gc.ErrorMatches, `instance i-2: instance "i-2" not found`)
+	c.Assert(s.fakeMachineManager.instanceIds, jc.DeepEquals, []instance.Id{"i-1", "i-2"})
+	c.Assert(*checked, jc.DeepEquals, []string{"10.1.2.3"})
+	c.Assert(provisioned, gc.HasLen, 1)
+	c.Assert(provisioned[0].Host, gc.Equals, "10.1.2.3")
+	c.Assert(provisioned[0].InstanceId, gc.Equals, instance.Id("i-1"))
+	c.Assert(provisioned[0].AvailabilityZone, gc.Equals, "zone-a")
+	c.Assert(testing.Stderr(context), gc.Equals, ""+
+		"instance i-2 cannot be adopted: instance \"i-2\" not found\n"+
+		"host 10.1.2.3 passed checks (series=xenial arch=amd64 disk-free=4096M)\n"+
+		"created machine 42\n"+
+		"failed to create 1 machine\n")
+}
+
+func (s *AddMachineSuite) TestInstancePlacementNoAddress(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 3
+	s.fakeMachineManager.instances = []params.ProviderInstanceResult{{InstanceId: "i-1"}}
+	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Fatalf("unexpected provisioning of %q", args.Host)
+		return "", nil
+	})
+	_, err := s.run(c, "instance:i-1")
+	c.Assert(err, gc.ErrorMatches, "instance i-1: no address found")
+}
+
+func (s *AddMachineSuite) TestInstancePlacementUnsupported(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 2
+	_, err := s.run(c, "instance:i-1")
+	c.Assert(err, gc.ErrorMatches, "cannot adopt instances: not supported by the API server")
+}
+
+func (s *AddMachineSuite) TestHostsFile(c *gc.C) {
+	hostsFile := filepath.Join(c.MkDir(), "hosts.txt")
+	err := ioutil.WriteFile(hostsFile, []byte("# web servers\n10.1.2.3\n\n  ubuntu@10.1.2.4  \n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	checked := s.patchHostsChecker(nil)
+	var provisioned []string
+	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		provisioned = append(provisioned, args.Host)
+		return strconv.Itoa(len(provisioned) - 1), nil
+	})
+	_, err = s.run(c, "--hosts-file", hostsFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*checked, jc.DeepEquals, []string{"10.1.2.3", "ubuntu@10.1.2.4"})
+	c.Assert(provisioned, jc.DeepEquals, []string{"10.1.2.3", "ubuntu@10.1.2.4"})
+}
+
+func (s *AddMachineSuite) TestHostsFileEmpty(c *gc.C) {
+	hostsFile := filepath.Join(c.MkDir(), "hosts.txt")
+	err := ioutil.WriteFile(hostsFile, []byte("# nothing here\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, "--hosts-file", hostsFile)
+	c.Assert(err, gc.ErrorMatches, "no hosts specified for manual provisioning")
 }
 
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
@@ -264,10 +407,17 @@ func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 }
 
 type fakeMachineManagerAPI struct {
-	apiVersion int
+	apiVersion  int
+	instances   []params.ProviderInstanceResult
+	instanceIds []instance.Id
 	fakeAddMachineAPI
 }
 
+func (f *fakeMachineManagerAPI) ProviderInstances(ids ...instance.Id) ([]params.ProviderInstanceResult, error) {
+	f.instanceIds = append(f.instanceIds, ids...)
+	return f.instances, nil
+}
+
 func (f *fakeMachineManagerAPI) BestAPIVersion() int {
 	return f.apiVersion
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -25,6 +26,7 @@ type statusAPI interface {
 type baselistMachinesCommand struct {
 	modelcmd.ModelCommandBase
 	out           cmd.Output
+	fields        common.FieldSelector
 	isoTime       bool
 	api           statusAPI
 	machineIds    []string
@@ -34,7 +36,7 @@ type baselistMachinesCommand struct {
 // SetFlags sets utc and format flags based on user specified options.
 func (c *baselistMachinesCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
-	c.out.AddFlags(f, c.defaultFormat, map[string]cmd.Formatter{
+	c.fields.AddOutputFlags(f, &c.out, c.defaultFormat, map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": status.FormatMachineTabular,
@@ -11,7 +11,8 @@ import (
 )
 
 var (
-	ManualProvisioner = &manualProvisioner
+	ManualProvisioner  = &manualProvisioner
+	ManualHostsChecker = &manualHostsChecker
 )
 
 type AddCommand struct {
@@ -34,12 +35,28 @@ func NewListCommandForTest(api statusAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewListCommandWithLabelsForTest returns a listMachineCommand with
+// the specified status and labels apis.
+func NewListCommandWithLabelsForTest(api statusAPI, labelsAPI LabelsAPI) cmd.Command {
+	cmd := newListMachinesCommand(api)
+	cmd.labelsAPI = labelsAPI
+	return modelcmd.Wrap(cmd)
+}
+
 // NewShowCommandForTest returns a showMachineCommand with specified api
 func NewShowCommandForTest(api statusAPI) cmd.Command {
 	cmd := newShowMachineCommand(api)
 	return modelcmd.Wrap(cmd)
 }
 
+// NewShowCommandWithConsoleLogForTest returns a showMachineCommand
+// with the specified status and console log apis.
+func NewShowCommandWithConsoleLogForTest(api statusAPI, consoleLogAPI ConsoleLogAPI) cmd.Command {
+	cmd := newShowMachineCommand(api)
+	cmd.consoleLogAPI = consoleLogAPI
+	return modelcmd.Wrap(cmd)
+}
+
 type RemoveCommand struct {
 	*removeCommand
 }
@@ -52,6 +69,49 @@ func NewRemoveCommandForTest(api RemoveMachineAPI) (cmd.Command, *RemoveCommand)
 	return modelcmd.Wrap(cmd), &RemoveCommand{cmd}
 }
 
+type MaintenanceCommand struct {
+	*maintenanceCommand
+}
+
+// NewMaintenanceCommandForTest returns a MaintenanceCommand with the api
+// provided as specified.
+func NewMaintenanceCommandForTest(api MaintenanceAPI) (cmd.Command, *MaintenanceCommand) {
+	cmd := &maintenanceCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd), &MaintenanceCommand{cmd}
+}
+
+type ReplaceCommand struct {
+	*replaceCommand
+}
+
+// NewReplaceCommandForTest returns a ReplaceCommand with the api
+// provided as specified.
+func NewReplaceCommandForTest(api ReplaceMachineAPI) (cmd.Command, *ReplaceCommand) {
+	cmd := &replaceCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd), &ReplaceCommand{cmd}
+}
+
+type UpgradeSeriesCommand struct {
+	*upgradeSeriesCommand
+}
+
+// NewUpgradeSeriesCommandForTest returns an UpgradeSeriesCommand with
+// the api provided as specified.
+func NewUpgradeSeriesCommandForTest(api UpgradeSeriesAPI) (cmd.Command, *UpgradeSeriesCommand) {
+	cmd := &upgradeSeriesCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd), &UpgradeSeriesCommand{cmd}
+}
+
+func NewLabelsFlag(labels *map[string]string) *labelsFlag {
+	return &labelsFlag{labels}
+}
+
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
@@ -5,6 +5,7 @@ package machine
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -36,3 +37,31 @@ func (f disksFlag) String() string {
 	}
 	return strings.Join(strs, " ")
 }
+
+// labelsFlag accumulates key=value labels from repeated uses of a flag.
+type labelsFlag struct {
+	labels *map[string]string
+}
+
+// Set implements gnuflag.Value.Set.
+func (f labelsFlag) Set(s string) error {
+	kv := strings.SplitN(s, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return errors.Errorf("expected key=value, got %q", s)
+	}
+	if *f.labels == nil {
+		*f.labels = make(map[string]string)
+	}
+	(*f.labels)[kv[0]] = kv[1]
+	return nil
+}
+
+// String implements gnuflag.Value.String.
+func (f labelsFlag) String() string {
+	strs := make([]string, 0, len(*f.labels))
+	for key, value := range *f.labels {
+		strs = append(strs, key+"="+value)
+	}
+	sort.Strings(strs)
+	return strings.Join(strs, ",")
+}
@@ -38,3 +38,27 @@ func (*FlagsSuite) TestDisksFlag(c *gc.C) {
 		{Size: 2048, Count: 2},
 	})
 }
+
+func (*FlagsSuite) TestLabelsFlag(c *gc.C) {
+	var labels map[string]string
+	f := machine.NewLabelsFlag(&labels)
+	err := f.Set("env=prod")
+	c.Assert(err, jc.ErrorIsNil)
+	err = f.Set("tier=web=frontend")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(labels, jc.DeepEquals, map[string]string{
+		"env":  "prod",
+		"tier": "web=frontend",
+	})
+	c.Assert(f.String(), gc.Equals, "env=prod,tier=web=frontend")
+}
+
+func (*FlagsSuite) TestLabelsFlagErrors(c *gc.C) {
+	var labels map[string]string
+	f := machine.NewLabelsFlag(&labels)
+	err := f.Set("env")
+	c.Assert(err, gc.ErrorMatches, `expected key=value, got "env"`)
+	err = f.Set("=prod")
+	c.Assert(err, gc.ErrorMatches, `expected key=value, got "=prod"`)
+	c.Assert(labels, gc.HasLen, 0)
+}
@@ -5,7 +5,11 @@ package machine
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -17,8 +21,13 @@ By default, the tabular format is used.
 The following sections are included: ID, STATE, DNS, INS-ID, SERIES, AZ
 Note: AZ above is the cloud region's availability zone.
 
+The --label option restricts the output to machines annotated with
+the given key=value pair. It may be repeated, in which case only
+machines having all of the labels are listed.
+
 Examples:
      juju machines
+     juju machines --label env=prod
 
 See also: 
     status`
@@ -35,9 +44,18 @@ func newListMachinesCommand(api statusAPI) *listMachinesCommand {
 	return listCmd
 }
 
+// LabelsAPI defines the API methods used by the machines command to
+// find machines by label.
+type LabelsAPI interface {
+	Find(kind string, annotations map[string]string) ([]string, error)
+	Close() error
+}
+
 // listMachineCommand holds infomation about machines in a model.
 type listMachinesCommand struct {
 	baselistMachinesCommand
+	labels    map[string]string
+	labelsAPI LabelsAPI
 }
 
 // Info implements Command.Info.
@@ -50,7 +68,52 @@ func (c *listMachinesCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *listMachinesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baselistMachinesCommand.SetFlags(f)
+	f.Var(labelsFlag{&c.labels}, "label", "Only list machines with the given key=value label")
+}
+
 // Init ensures the machines Command does not take arguments.
 func (c *listMachinesCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
+
+func (c *listMachinesCommand) getLabelsAPI() (LabelsAPI, error) {
+	if c.labelsAPI != nil {
+		return c.labelsAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return annotations.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *listMachinesCommand) Run(ctx *cmd.Context) error {
+	if len(c.labels) > 0 {
+		client, err := c.getLabelsAPI()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer client.Close()
+		tags, err := client.Find(names.MachineTagKind, c.labels)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(tags) == 0 {
+			ctx.Infof("No machines have label(s) %s.", labelsFlag{&c.labels})
+			return nil
+		}
+		c.machineIds = make([]string, len(tags))
+		for i, tag := range tags {
+			machineTag, err := names.ParseMachineTag(tag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			c.machineIds[i] = machineTag.Id()
+		}
+	}
+	return c.baselistMachinesCommand.Run(ctx)
+}
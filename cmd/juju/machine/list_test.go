@@ -121,6 +121,55 @@ func (s *MachineListCommandSuite) TestListMachineJson(c *gc.C) {
 		"{\"model\":\"dummyenv\",\"machines\":{\"0\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.1\",\"instance-id\":\"juju-badd06-0\",\"machine-status\":{},\"series\":\"trusty\",\"hardware\":\"availability-zone=us-east-1\"},\"1\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.2\",\"instance-id\":\"juju-badd06-1\",\"machine-status\":{},\"series\":\"trusty\",\"containers\":{\"1/lxd/0\":{\"juju-status\":{\"current\":\"pending\"},\"dns-name\":\"10.0.0.3\",\"instance-id\":\"juju-badd06-1-lxd-0\",\"machine-status\":{},\"series\":\"trusty\"}}}}}\n")
 }
 
+func (s *MachineListCommandSuite) TestListMachineFields(c *gc.C) {
+	context, err := testing.RunCommand(c, newMachineListCommand(), "--format", "yaml", "--fields", "machines.*.dns-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"machines:\n"+
+		"  \"0\":\n"+
+		"    dns-name: 10.0.0.1\n"+
+		"  \"1\":\n"+
+		"    dns-name: 10.0.0.2\n")
+}
+
+type fakeLabelsAPI struct {
+	kind   string
+	labels map[string]string
+	tags   []string
+}
+
+func (f *fakeLabelsAPI) Find(kind string, labels map[string]string) ([]string, error) {
+	f.kind = kind
+	f.labels = labels
+	return f.tags, nil
+}
+
+func (*fakeLabelsAPI) Close() error {
+	return nil
+}
+
+func (s *MachineListCommandSuite) TestListMachineLabels(c *gc.C) {
+	labelsAPI := &fakeLabelsAPI{tags: []string{"machine-1"}}
+	command := machine.NewListCommandWithLabelsForTest(&fakeStatusAPI{}, labelsAPI)
+	context, err := testing.RunCommand(c, command, "--label", "env=prod", "--label", "tier=web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(labelsAPI.kind, gc.Equals, "machine")
+	c.Assert(labelsAPI.labels, jc.DeepEquals, map[string]string{"env": "prod", "tier": "web"})
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"MACHINE    STATE    DNS       INS-ID               SERIES  AZ\n"+
+		"1          started  10.0.0.2  juju-badd06-1        trusty  \n"+
+		"  1/lxd/0  pending  10.0.0.3  juju-badd06-1-lxd-0  trusty  \n"+
+		"\n")
+}
+
+func (s *MachineListCommandSuite) TestListMachineLabelsNoMatch(c *gc.C) {
+	command := machine.NewListCommandWithLabelsForTest(&fakeStatusAPI{}, &fakeLabelsAPI{})
+	context, err := testing.RunCommand(c, command, "--label", "env=prod")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, "")
+	c.Assert(testing.Stderr(context), gc.Equals, "No machines have label(s) env=prod.\n")
+}
+
 func (s *MachineListCommandSuite) TestListMachineArgsError(c *gc.C) {
 	_, err := testing.RunCommand(c, newMachineListCommand(), "0")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["0"\]`)
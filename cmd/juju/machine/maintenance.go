@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewMaintenanceCommand returns a command used to set or clear
+// maintenance mode on machines.
+func NewMaintenanceCommand() cmd.Command {
+	return modelcmd.Wrap(&maintenanceCommand{})
+}
+
+// maintenanceCommand sets or clears maintenance mode on machines.
+type maintenanceCommand struct {
+	modelcmd.ModelCommandBase
+	api        MaintenanceAPI
+	MachineIds []string
+	Off        bool
+}
+
+const maintenanceDoc = `
+While a machine is in maintenance mode, Juju leaves it alone so that it
+can safely be worked on by hand: it is not provisioned, stopped or
+removed, its firewall ports and instance details are not updated, and
+the units on it do not run update-status hooks. Maintenance mode is
+shown in the output of ` + "`juju status`" + `.
+
+Machines are specified by their numbers, which may be retrieved from the
+output of ` + "`juju status`." + `
+
+Examples:
+
+Put machines 3 and 4 into maintenance mode:
+
+    juju set-machine-maintenance 3 4
+
+Take machine 3 out of maintenance mode:
+
+    juju set-machine-maintenance --off 3
+
+See also:
+    status
+`
+
+// Info implements Command.Info.
+func (c *maintenanceCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-machine-maintenance",
+		Args:    "<machine number> ...",
+		Purpose: "Puts machines into, or takes them out of, maintenance mode.",
+		Doc:     maintenanceDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *maintenanceCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Off, "off", false, "Take the machines out of maintenance mode")
+}
+
+// Init implements Command.Init.
+func (c *maintenanceCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no machines specified")
+	}
+	for _, id := range args {
+		if !names.IsValidMachine(id) {
+			return fmt.Errorf("invalid machine id %q", id)
+		}
+	}
+	c.MachineIds = args
+	return nil
+}
+
+// MaintenanceAPI defines the API methods used by the
+// set-machine-maintenance command.
+type MaintenanceAPI interface {
+	SetMachineMaintenance(maintenance bool, machineIds ...string) ([]params.ErrorResult, error)
+	Close() error
+}
+
+func (c *maintenanceCommand) getMaintenanceAPI() (MaintenanceAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *maintenanceCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getMaintenanceAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	results, err := client.SetMachineMaintenance(!c.Off, c.MachineIds...)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	failed := false
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "machine %s: %v\n", c.MachineIds[i], result.Error)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
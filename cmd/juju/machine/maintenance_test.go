@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type MaintenanceSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeMaintenanceAPI
+}
+
+var _ = gc.Suite(&MaintenanceSuite{})
+
+func (s *MaintenanceSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeMaintenanceAPI{}
+}
+
+func (s *MaintenanceSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command, _ := machine.NewMaintenanceCommandForTest(s.fake)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *MaintenanceSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		machines    []string
+		off         bool
+		errorString string
+	}{
+		{
+			errorString: "no machines specified",
+		}, {
+			args:     []string{"1", "2/lxd/0"},
+			machines: []string{"1", "2/lxd/0"},
+		}, {
+			args:     []string{"--off", "1"},
+			machines: []string{"1"},
+			off:      true,
+		}, {
+			args:        []string{"lxd"},
+			errorString: `invalid machine id "lxd"`,
+		},
+	} {
+		c.Logf("test %d", i)
+		wrappedCommand, maintenanceCmd := machine.NewMaintenanceCommandForTest(s.fake)
+		err := testing.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(maintenanceCmd.Off, gc.Equals, test.off)
+			c.Check(maintenanceCmd.MachineIds, jc.DeepEquals, test.machines)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *MaintenanceSuite) TestSetMaintenance(c *gc.C) {
+	_, err := s.run(c, "1", "2/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.maintenance, jc.IsTrue)
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1", "2/lxd/1"})
+}
+
+func (s *MaintenanceSuite) TestClearMaintenance(c *gc.C) {
+	_, err := s.run(c, "--off", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.maintenance, jc.IsFalse)
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1"})
+}
+
+func (s *MaintenanceSuite) TestMachineError(c *gc.C) {
+	s.fake.results = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `machine 2 not found`, Code: params.CodeNotFound}},
+	}
+	ctx, err := s.run(c, "1", "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "machine 2: machine 2 not found\n")
+}
+
+func (s *MaintenanceSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Assert(stripped, gc.Matches, ".*TestBlockedError.*")
+}
+
+type fakeMaintenanceAPI struct {
+	maintenance bool
+	machines    []string
+	results     []params.ErrorResult
+	err         error
+}
+
+func (f *fakeMaintenanceAPI) Close() error {
+	return nil
+}
+
+func (f *fakeMaintenanceAPI) SetMachineMaintenance(maintenance bool, machines ...string) ([]params.ErrorResult, error) {
+	f.maintenance = maintenance
+	f.machines = machines
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.results != nil {
+		return f.results, nil
+	}
+	return make([]params.ErrorResult, len(machines)), nil
+}
@@ -5,6 +5,7 @@ package machine
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/gnuflag"
@@ -25,15 +26,22 @@ type removeCommand struct {
 	api        RemoveMachineAPI
 	MachineIds []string
 	Force      bool
+	NoWait     bool
 }
 
+// forceWait is how long the agents of units on a machine removed with
+// --force are given to shut down cleanly before the machine is removed.
+const forceWait = time.Minute
+
 const destroyMachineDoc = `
 Machines are specified by their numbers, which may be retrieved from the
 output of ` + "`juju status`." + `
 Machines responsible for the model cannot be removed.
 Machines running units or containers can be removed using the '--force'
-option; this will also remove those units and containers without giving
-them an opportunity to shut down cleanly.
+option; this will also remove those units and containers, whether or not
+they shut down cleanly. Their agents are given up to a minute to shut
+down before they are removed; with '--no-wait', they are removed
+immediately, without an opportunity to shut down cleanly.
 
 Examples:
 
@@ -45,6 +53,10 @@ Remove machine 6 and any running units or containers:
 
     juju remove-machine 6 --force
 
+Remove machine 7, which has an unreachable agent, without waiting:
+
+    juju remove-machine 7 --force --no-wait
+
 See also:
     add-machine
 `
@@ -63,12 +75,16 @@ func (c *removeCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Force, "force", false, "Completely remove a machine and all its dependencies")
+	f.BoolVar(&c.NoWait, "no-wait", false, "With --force, remove without waiting for agents to shut down")
 }
 
 func (c *removeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("no machines specified")
 	}
+	if c.NoWait && !c.Force {
+		return fmt.Errorf("--no-wait requires --force")
+	}
 	for _, id := range args {
 		if !names.IsValidMachine(id) {
 			return fmt.Errorf("invalid machine id %q", id)
@@ -81,6 +97,7 @@ func (c *removeCommand) Init(args []string) error {
 type RemoveMachineAPI interface {
 	DestroyMachines(machines ...string) error
 	ForceDestroyMachines(machines ...string) error
+	ForceDestroyMachinesAfter(maxWait time.Duration, machines ...string) error
 	Close() error
 }
 
@@ -98,9 +115,12 @@ func (c *removeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	if c.Force {
+	switch {
+	case c.Force && c.NoWait:
 		err = client.ForceDestroyMachines(c.MachineIds...)
-	} else {
+	case c.Force:
+		err = client.ForceDestroyMachinesAfter(forceWait, c.MachineIds...)
+	default:
 		err = client.DestroyMachines(c.MachineIds...)
 	}
 	return block.ProcessBlockedError(err, block.BlockRemove)
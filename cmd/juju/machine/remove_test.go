@@ -5,6 +5,7 @@ package machine_test
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	jc "github.com/juju/testing/checkers"
@@ -37,6 +38,7 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		args        []string
 		machines    []string
 		force       bool
+		noWait      bool
 		errorString string
 	}{
 		{
@@ -55,6 +57,14 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 			args:     []string{"--force", "1", "2"},
 			machines: []string{"1", "2"},
 			force:    true,
+		}, {
+			args:     []string{"--force", "--no-wait", "1"},
+			machines: []string{"1"},
+			force:    true,
+			noWait:   true,
+		}, {
+			args:        []string{"--no-wait", "1"},
+			errorString: "--no-wait requires --force",
 		}, {
 			args:        []string{"lxd"},
 			errorString: `invalid machine id "lxd"`,
@@ -69,6 +79,7 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
 			c.Check(removeCmd.Force, gc.Equals, test.force)
+			c.Check(removeCmd.NoWait, gc.Equals, test.noWait)
 			c.Check(removeCmd.MachineIds, jc.DeepEquals, test.machines)
 		} else {
 			c.Check(err, gc.ErrorMatches, test.errorString)
@@ -87,9 +98,18 @@ func (s *RemoveMachineSuite) TestRemoveForce(c *gc.C) {
 	_, err := s.run(c, "--force", "1", "2/lxd/1")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.fake.forced, jc.IsTrue)
+	c.Assert(s.fake.maxWait, gc.Equals, time.Minute)
 	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1", "2/lxd/1"})
 }
 
+func (s *RemoveMachineSuite) TestRemoveForceNoWait(c *gc.C) {
+	_, err := s.run(c, "--force", "--no-wait", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.forced, jc.IsTrue)
+	c.Assert(s.fake.maxWait, gc.Equals, time.Duration(0))
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1"})
+}
+
 func (s *RemoveMachineSuite) TestBlockedError(c *gc.C) {
 	s.fake.removeError = common.OperationBlockedError("TestBlockedError")
 	_, err := s.run(c, "1")
@@ -112,6 +132,7 @@ func (s *RemoveMachineSuite) TestForceBlockedError(c *gc.C) {
 
 type fakeRemoveMachineAPI struct {
 	forced      bool
+	maxWait     time.Duration
 	machines    []string
 	removeError error
 }
@@ -131,3 +152,10 @@ func (f *fakeRemoveMachineAPI) ForceDestroyMachines(machines ...string) error {
 	f.machines = machines
 	return f.removeError
 }
+
+func (f *fakeRemoveMachineAPI) ForceDestroyMachinesAfter(maxWait time.Duration, machines ...string) error {
+	f.forced = true
+	f.maxWait = maxWait
+	f.machines = machines
+	return f.removeError
+}
@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewReplaceCommand returns a command used to replace machines.
+func NewReplaceCommand() cmd.Command {
+	return modelcmd.Wrap(&replaceCommand{})
+}
+
+// replaceCommand replaces machines with new ones.
+type replaceCommand struct {
+	modelcmd.ModelCommandBase
+	api        ReplaceMachineAPI
+	MachineIds []string
+}
+
+const replaceMachineDoc = `
+Adds a substitute for each specified machine, with the same series and
+constraints, and moves the machine's units to it. The original machine
+is then removed.
+
+If the original machine has not yet been provisioned, its units are
+simply reassigned to the substitute. Otherwise, a new unit of each
+application is deployed to the substitute, and the units on the
+original machine are removed along with it.
+
+Controller machines, and machines hosting containers, cannot be
+replaced.
+
+Examples:
+
+Replace machine 3:
+
+    juju replace-machine 3
+
+See also:
+    add-machine
+    remove-machine
+`
+
+// Info implements Command.Info.
+func (c *replaceCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "replace-machine",
+		Args:    "<machine number> ...",
+		Purpose: "Replaces machines with new ones, moving their units.",
+		Doc:     replaceMachineDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *replaceCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no machines specified")
+	}
+	for _, id := range args {
+		if !names.IsValidMachine(id) {
+			return fmt.Errorf("invalid machine id %q", id)
+		}
+	}
+	c.MachineIds = args
+	return nil
+}
+
+// ReplaceMachineAPI defines the API methods used by the
+// replace-machine command.
+type ReplaceMachineAPI interface {
+	ReplaceMachines(machineIds ...string) ([]params.ReplaceMachineResult, error)
+	Close() error
+}
+
+func (c *replaceCommand) getReplaceMachineAPI() (ReplaceMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *replaceCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getReplaceMachineAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	results, err := client.ReplaceMachines(c.MachineIds...)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	failed := false
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "machine %s: %v\n", c.MachineIds[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("replaced machine %s with machine %s", c.MachineIds[i], result.Machine)
+		units := make([]string, 0, len(result.Units))
+		for unit := range result.Units {
+			units = append(units, unit)
+		}
+		sort.Strings(units)
+		for _, unit := range units {
+			if replacement := result.Units[unit]; replacement == unit {
+				ctx.Infof("  moved unit %s", unit)
+			} else {
+				ctx.Infof("  replaced unit %s with unit %s", unit, replacement)
+			}
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type ReplaceMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeReplaceMachineAPI
+}
+
+var _ = gc.Suite(&ReplaceMachineSuite{})
+
+func (s *ReplaceMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeReplaceMachineAPI{}
+}
+
+func (s *ReplaceMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command, _ := machine.NewReplaceCommandForTest(s.fake)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *ReplaceMachineSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		machines    []string
+		errorString string
+	}{
+		{
+			errorString: "no machines specified",
+		}, {
+			args:     []string{"1", "2/lxd/0"},
+			machines: []string{"1", "2/lxd/0"},
+		}, {
+			args:        []string{"lxd"},
+			errorString: `invalid machine id "lxd"`,
+		},
+	} {
+		c.Logf("test %d", i)
+		wrappedCommand, replaceCmd := machine.NewReplaceCommandForTest(s.fake)
+		err := testing.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(replaceCmd.MachineIds, jc.DeepEquals, test.machines)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *ReplaceMachineSuite) TestReplace(c *gc.C) {
+	s.fake.results = []params.ReplaceMachineResult{{
+		Machine: "4",
+		Units: map[string]string{
+			"mysql/0":     "mysql/3",
+			"wordpress/1": "wordpress/1",
+		},
+	}}
+	ctx, err := s.run(c, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, ""+
+		"replaced machine 1 with machine 4\n"+
+		"  replaced unit mysql/0 with unit mysql/3\n"+
+		"  moved unit wordpress/1\n",
+	)
+}
+
+func (s *ReplaceMachineSuite) TestMachineError(c *gc.C) {
+	s.fake.results = []params.ReplaceMachineResult{
+		{Machine: "3"},
+		{Error: &params.Error{Message: `machine 2 not found`, Code: params.CodeNotFound}},
+	}
+	ctx, err := s.run(c, "1", "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Equals, ""+
+		"replaced machine 1 with machine 3\n"+
+		"machine 2: machine 2 not found\n",
+	)
+}
+
+func (s *ReplaceMachineSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Assert(stripped, gc.Matches, ".*TestBlockedError.*")
+}
+
+type fakeReplaceMachineAPI struct {
+	machines []string
+	results  []params.ReplaceMachineResult
+	err      error
+}
+
+func (f *fakeReplaceMachineAPI) Close() error {
+	return nil
+}
+
+func (f *fakeReplaceMachineAPI) ReplaceMachines(machines ...string) ([]params.ReplaceMachineResult, error) {
+	f.machines = machines
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.results != nil {
+		return f.results, nil
+	}
+	return make([]params.ReplaceMachineResult, len(machines)), nil
+}
@@ -4,8 +4,15 @@
 package machine
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -21,6 +28,14 @@ Examples:
     # Display status for machines 1, 2 & 3
     juju show-machine 1 2 3
 
+    # Display the console output of machine 1's instance
+    juju show-machine --console-log 1
+
+The --console-log option shows the output written to the console of each
+machine's instance, as recorded by the cloud. This is helpful when a
+machine never reaches the agent-running state, since its agent logs are
+then unavailable. Not all clouds support it.
+
 `
 
 // NewShowMachineCommand returns a command that shows details on the specified machine[s].
@@ -38,6 +53,16 @@ func newShowMachineCommand(api statusAPI) *showMachineCommand {
 // showMachineCommand struct holds details on the specified machine[s].
 type showMachineCommand struct {
 	baselistMachinesCommand
+	consoleLog    bool
+	consoleLogAPI ConsoleLogAPI
+}
+
+// ConsoleLogAPI defines the API methods used by the show-machine
+// command to show console logs.
+type ConsoleLogAPI interface {
+	BestAPIVersion() int
+	ConsoleLogs(machineIds ...string) ([]params.StringResult, error)
+	Close() error
 }
 
 // Info implements Command.Info.
@@ -51,8 +76,70 @@ func (c *showMachineCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *showMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baselistMachinesCommand.SetFlags(f)
+	f.BoolVar(&c.consoleLog, "console-log", false, "Show the console output of the machines' instances")
+}
+
 // Init captures machineId's to show from CL args.
 func (c *showMachineCommand) Init(args []string) error {
+	if c.consoleLog {
+		if len(args) == 0 {
+			return errors.New("no machines specified")
+		}
+		for _, id := range args {
+			if !names.IsValidMachine(id) {
+				return errors.Errorf("invalid machine id %q", id)
+			}
+		}
+	}
 	c.machineIds = args
 	return nil
 }
+
+func (c *showMachineCommand) getConsoleLogAPI() (ConsoleLogAPI, error) {
+	if c.consoleLogAPI != nil {
+		return c.consoleLogAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showMachineCommand) Run(ctx *cmd.Context) error {
+	if !c.consoleLog {
+		return c.baselistMachinesCommand.Run(ctx)
+	}
+	client, err := c.getConsoleLogAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 4 {
+		return errors.New("cannot show console logs: not supported by the API server")
+	}
+	results, err := client.ConsoleLogs(c.machineIds...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	failed := false
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "machine %s: %v\n", c.machineIds[i], result.Error)
+			failed = true
+			continue
+		}
+		if len(results) > 1 {
+			fmt.Fprintf(ctx.Stdout, "==> machine %s <==\n", c.machineIds[i])
+		}
+		fmt.Fprint(ctx.Stdout, result.Result)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
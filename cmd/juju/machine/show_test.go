@@ -8,6 +8,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/testing"
 )
@@ -85,3 +86,59 @@ func (s *MachineShowCommandSuite) TestShowJsonMachine(c *gc.C) {
 	c.Assert(testing.Stdout(context), gc.Equals, ""+
 		"{\"model\":\"dummyenv\",\"machines\":{\"0\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.1\",\"instance-id\":\"juju-badd06-0\",\"machine-status\":{},\"series\":\"trusty\",\"hardware\":\"availability-zone=us-east-1\"},\"1\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.2\",\"instance-id\":\"juju-badd06-1\",\"machine-status\":{},\"series\":\"trusty\",\"containers\":{\"1/lxd/0\":{\"juju-status\":{\"current\":\"pending\"},\"dns-name\":\"10.0.0.3\",\"instance-id\":\"juju-badd06-1-lxd-0\",\"machine-status\":{},\"series\":\"trusty\"}}}}}\n")
 }
+
+func (s *MachineShowCommandSuite) TestShowConsoleLog(c *gc.C) {
+	fake := &fakeConsoleLogAPI{
+		apiVersion: 4,
+		results:    []params.StringResult{{Result: "Booting...\n"}},
+	}
+	context, err := testing.RunCommand(c, machine.NewShowCommandWithConsoleLogForTest(&fakeStatusAPI{}, fake), "--console-log", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.machineIds, jc.DeepEquals, []string{"1"})
+	c.Assert(testing.Stdout(context), gc.Equals, "Booting...\n")
+}
+
+func (s *MachineShowCommandSuite) TestShowConsoleLogMultiple(c *gc.C) {
+	fake := &fakeConsoleLogAPI{
+		apiVersion: 4,
+		results: []params.StringResult{
+			{Result: "Booting...\n"},
+			{Error: &params.Error{Message: "console logs in this cloud not supported"}},
+		},
+	}
+	context, err := testing.RunCommand(c, machine.NewShowCommandWithConsoleLogForTest(&fakeStatusAPI{}, fake), "--console-log", "0", "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stdout(context), gc.Equals, "==> machine 0 <==\nBooting...\n")
+	c.Assert(testing.Stderr(context), gc.Equals, "machine 1: console logs in this cloud not supported\n")
+}
+
+func (s *MachineShowCommandSuite) TestShowConsoleLogNoMachines(c *gc.C) {
+	_, err := testing.RunCommand(c, machine.NewShowCommandWithConsoleLogForTest(&fakeStatusAPI{}, &fakeConsoleLogAPI{}), "--console-log")
+	c.Assert(err, gc.ErrorMatches, "no machines specified")
+}
+
+func (s *MachineShowCommandSuite) TestShowConsoleLogUnsupported(c *gc.C) {
+	fake := &fakeConsoleLogAPI{apiVersion: 3}
+	_, err := testing.RunCommand(c, machine.NewShowCommandWithConsoleLogForTest(&fakeStatusAPI{}, fake), "--console-log", "1")
+	c.Assert(err, gc.ErrorMatches, "cannot show console logs: not supported by the API server")
+	c.Assert(fake.machineIds, gc.HasLen, 0)
+}
+
+type fakeConsoleLogAPI struct {
+	apiVersion int
+	machineIds []string
+	results    []params.StringResult
+}
+
+func (f *fakeConsoleLogAPI) BestAPIVersion() int {
+	return f.apiVersion
+}
+
+func (f *fakeConsoleLogAPI) ConsoleLogs(machineIds ...string) ([]params.StringResult, error) {
+	f.machineIds = machineIds
+	return f.results, nil
+}
+
+func (f *fakeConsoleLogAPI) Close() error {
+	return nil
+}
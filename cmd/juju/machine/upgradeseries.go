@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	// PrepareCommand starts a machine's series upgrade.
+	PrepareCommand = "prepare"
+
+	// CompleteCommand finishes a machine's series upgrade.
+	CompleteCommand = "complete"
+
+	// AbortCommand abandons a machine's series upgrade.
+	AbortCommand = "abort"
+)
+
+// NewUpgradeSeriesCommand returns a command used to coordinate the
+// upgrade of a machine's operating system with the units on it.
+func NewUpgradeSeriesCommand() cmd.Command {
+	return modelcmd.Wrap(&upgradeSeriesCommand{})
+}
+
+// upgradeSeriesCommand prepares a machine for, or completes, an
+// upgrade of its operating system series.
+type upgradeSeriesCommand struct {
+	modelcmd.ModelCommandBase
+	api          UpgradeSeriesAPI
+	Command      string
+	MachineId    string
+	TargetSeries string
+}
+
+const upgradeSeriesDoc = `
+Juju does not upgrade the operating system of a machine itself, but
+coordinates the upgrade with the units on the machine, so that their
+workloads can be made ready for it and brought back up afterwards.
+
+The "prepare" subcommand asks each unit on the machine to run its
+pre-series-upgrade hook. Subordinate units run the hook before the
+principal unit they are deployed with. Once every unit has done so, the
+operating system may be upgraded to the given series by hand; the
+"complete" subcommand fails until then.
+
+The "complete" subcommand records that the operating system has been
+upgraded, and asks each unit on the machine to run its
+post-series-upgrade hook. Principal units run the hook before their
+subordinates.
+
+The "abort" subcommand abandons a series upgrade that has been prepared
+but not completed, so that units may again be placed on the machine.
+Units that have already run their pre-series-upgrade hook are not asked
+to undo it, and may need to be restarted by hand.
+
+Units cannot be placed on a machine while its series upgrade is in
+progress, and every unit's charm must support the new series.
+
+Examples:
+
+Prepare machine 3 to be upgraded to xenial:
+
+    juju upgrade-series prepare 3 xenial
+
+Complete the series upgrade of machine 3, once xenial is installed:
+
+    juju upgrade-series complete 3
+
+Abandon the series upgrade of machine 3 before xenial is installed:
+
+    juju upgrade-series abort 3
+
+See also:
+    set-machine-maintenance
+`
+
+// Info implements Command.Info.
+func (c *upgradeSeriesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "upgrade-series",
+		Args:    "prepare <machine number> <series> | complete <machine number> | abort <machine number>",
+		Purpose: "Coordinates the upgrade of a machine's series with its units.",
+		Doc:     upgradeSeriesDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *upgradeSeriesCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no upgrade-series subcommand specified")
+	}
+	c.Command, args = args[0], args[1:]
+	switch c.Command {
+	case PrepareCommand:
+		if len(args) != 2 {
+			return errors.Errorf("expected a machine number and series")
+		}
+		c.MachineId, c.TargetSeries = args[0], args[1]
+	case CompleteCommand, AbortCommand:
+		if len(args) != 1 {
+			return errors.Errorf("expected a machine number")
+		}
+		c.MachineId = args[0]
+	default:
+		return errors.Errorf("unknown upgrade-series subcommand %q", c.Command)
+	}
+	if !names.IsValidMachine(c.MachineId) {
+		return errors.Errorf("invalid machine id %q", c.MachineId)
+	}
+	return nil
+}
+
+// UpgradeSeriesAPI defines the API methods used by the upgrade-series
+// command.
+type UpgradeSeriesAPI interface {
+	PrepareUpgradeSeries(machineId, series string) error
+	CompleteUpgradeSeries(machineId string) error
+	AbortUpgradeSeries(machineId string) error
+	Close() error
+}
+
+func (c *upgradeSeriesCommand) getUpgradeSeriesAPI() (UpgradeSeriesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *upgradeSeriesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getUpgradeSeriesAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	switch c.Command {
+	case PrepareCommand:
+		err = client.PrepareUpgradeSeries(c.MachineId, c.TargetSeries)
+	case CompleteCommand:
+		err = client.CompleteUpgradeSeries(c.MachineId)
+	case AbortCommand:
+		err = client.AbortUpgradeSeries(c.MachineId)
+	}
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	switch c.Command {
+	case PrepareCommand:
+		fmt.Fprintf(ctx.Stderr, "machine %s: preparing units for upgrade to %s\n", c.MachineId, c.TargetSeries)
+	case CompleteCommand:
+		fmt.Fprintf(ctx.Stderr, "machine %s: completing units' series upgrade\n", c.MachineId)
+	case AbortCommand:
+		fmt.Fprintf(ctx.Stderr, "machine %s: series upgrade abandoned\n", c.MachineId)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeUpgradeSeriesAPI
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeUpgradeSeriesAPI{}
+}
+
+func (s *UpgradeSeriesSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command, _ := machine.NewUpgradeSeriesCommandForTest(s.fake)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *UpgradeSeriesSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		command     string
+		machine     string
+		series      string
+		errorString string
+	}{
+		{
+			errorString: "no upgrade-series subcommand specified",
+		}, {
+			args:    []string{"prepare", "1", "xenial"},
+			command: machine.PrepareCommand,
+			machine: "1",
+			series:  "xenial",
+		}, {
+			args:    []string{"complete", "2/lxd/0"},
+			command: machine.CompleteCommand,
+			machine: "2/lxd/0",
+		}, {
+			args:    []string{"abort", "3"},
+			command: machine.AbortCommand,
+			machine: "3",
+		}, {
+			args:        []string{"prepare", "1"},
+			errorString: "expected a machine number and series",
+		}, {
+			args:        []string{"complete", "1", "xenial"},
+			errorString: "expected a machine number",
+		}, {
+			args:        []string{"upgrade", "1"},
+			errorString: `unknown upgrade-series subcommand "upgrade"`,
+		}, {
+			args:        []string{"complete", "lxd"},
+			errorString: `invalid machine id "lxd"`,
+		},
+	} {
+		c.Logf("test %d", i)
+		wrappedCommand, upgradeSeriesCmd := machine.NewUpgradeSeriesCommandForTest(s.fake)
+		err := testing.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(upgradeSeriesCmd.Command, gc.Equals, test.command)
+			c.Check(upgradeSeriesCmd.MachineId, gc.Equals, test.machine)
+			c.Check(upgradeSeriesCmd.TargetSeries, gc.Equals, test.series)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *UpgradeSeriesSuite) TestPrepare(c *gc.C) {
+	ctx, err := s.run(c, "prepare", "1", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"prepare 1 xenial"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "machine 1: preparing units for upgrade to xenial\n")
+}
+
+func (s *UpgradeSeriesSuite) TestComplete(c *gc.C) {
+	ctx, err := s.run(c, "complete", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"complete 1"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "machine 1: completing units' series upgrade\n")
+}
+
+func (s *UpgradeSeriesSuite) TestAbort(c *gc.C) {
+	ctx, err := s.run(c, "abort", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"abort 1"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "machine 1: series upgrade abandoned\n")
+}
+
+func (s *UpgradeSeriesSuite) TestError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := s.run(c, "complete", "1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *UpgradeSeriesSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "prepare", "1", "xenial")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Assert(stripped, gc.Matches, ".*TestBlockedError.*")
+}
+
+type fakeUpgradeSeriesAPI struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeUpgradeSeriesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeUpgradeSeriesAPI) PrepareUpgradeSeries(machineId, series string) error {
+	f.calls = append(f.calls, "prepare "+machineId+" "+series)
+	return f.err
+}
+
+func (f *fakeUpgradeSeriesAPI) CompleteUpgradeSeries(machineId string) error {
+	f.calls = append(f.calls, "complete "+machineId)
+	return f.err
+}
+
+func (f *fakeUpgradeSeriesAPI) AbortUpgradeSeries(machineId string) error {
+	f.calls = append(f.calls, "abort "+machineId)
+	return f.err
+}
@@ -0,0 +1,277 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+const setModelAliasHelpDoc = `
+Creates or updates a client-side alias for a model. Wherever a model name
+is accepted, including in a .juju-model file written by "juju switch
+--local", the alias may be used in its place to refer to the model.
+
+Aliases are local to the client; they are never sent to the controller.
+If the model is not qualified with a controller name, it is assumed to be
+in the current controller.
+
+Examples:
+
+    juju set-model-alias web mycontroller:admin/web-staging
+    juju switch --local web
+
+See also:
+    remove-model-alias
+    model-aliases
+    switch
+`
+
+const removeModelAliasHelpDoc = `
+Removes a client-side model alias. The model to which the alias refers is
+not affected.
+
+Examples:
+
+    juju remove-model-alias web
+
+See also:
+    set-model-alias
+    model-aliases
+`
+
+const modelAliasesHelpDoc = `
+Lists the client-side model aliases, and the models to which they refer.
+
+Examples:
+
+    juju model-aliases
+    juju model-aliases --format yaml
+
+See also:
+    set-model-alias
+    remove-model-alias
+`
+
+// NewSetModelAliasCommand returns a command which creates or updates
+// a model alias.
+func NewSetModelAliasCommand() cmd.Command {
+	c := &setModelAliasCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+	c.refreshModels = c.JujuCommandBase.RefreshModels
+	return modelcmd.WrapBase(c)
+}
+
+type setModelAliasCommand struct {
+	modelcmd.JujuCommandBase
+	store         jujuclient.ClientStore
+	refreshModels func(jujuclient.ClientStore, string) error
+
+	alias  string
+	target string
+}
+
+// Info implements Command.Info.
+func (c *setModelAliasCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-model-alias",
+		Args:    "<alias> [<controller>:]<model>",
+		Purpose: "Sets a client-side alias for a model.",
+		Doc:     setModelAliasHelpDoc[1:],
+	}
+}
+
+// Init implements Command.Init.
+func (c *setModelAliasCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("an alias and a model must be specified")
+	}
+	c.alias, c.target, args = args[0], args[1], args[2:]
+	if err := jujuclient.ValidateModelAlias(c.alias); err != nil {
+		return err
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *setModelAliasCommand) Run(ctx *cmd.Context) error {
+	store := modelcmd.QualifyingClientStore{c.store}
+	controllerName, modelName := modelcmd.SplitModelName(c.target)
+	if controllerName == "" {
+		currentController, err := store.CurrentController()
+		if errors.IsNotFound(err) {
+			return errors.New("no current controller, and none specified")
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		controllerName = currentController
+	} else if _, err := store.ControllerByName(controllerName); err != nil {
+		return errors.Trace(err)
+	}
+	modelName, err := store.QualifiedModelName(controllerName, modelName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	_, err = store.ModelByName(controllerName, modelName)
+	if errors.IsNotFound(err) {
+		// The model isn't known locally, so we must query the controller.
+		if err := c.refreshModels(store, controllerName); err != nil {
+			return errors.Annotate(err, "refreshing models cache")
+		}
+		_, err = store.ModelByName(controllerName, modelName)
+		if errors.IsNotFound(err) {
+			return errors.Errorf("model %s not found", modelcmd.JoinModelName(controllerName, modelName))
+		}
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(store.SetModelAlias(c.alias, controllerName, modelName))
+}
+
+// NewRemoveModelAliasCommand returns a command which removes a model
+// alias.
+func NewRemoveModelAliasCommand() cmd.Command {
+	return modelcmd.WrapBase(&removeModelAliasCommand{
+		store: jujuclient.NewFileClientStore(),
+	})
+}
+
+type removeModelAliasCommand struct {
+	modelcmd.JujuCommandBase
+	store jujuclient.ClientStore
+	alias string
+}
+
+// Info implements Command.Info.
+func (c *removeModelAliasCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-model-alias",
+		Args:    "<alias>",
+		Purpose: "Removes a client-side model alias.",
+		Doc:     removeModelAliasHelpDoc[1:],
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeModelAliasCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no alias specified")
+	}
+	c.alias, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *removeModelAliasCommand) Run(ctx *cmd.Context) error {
+	return errors.Trace(c.store.RemoveModelAlias(c.alias))
+}
+
+// NewModelAliasesCommand returns a command which lists model aliases.
+func NewModelAliasesCommand() cmd.Command {
+	return modelcmd.WrapBase(&modelAliasesCommand{
+		store: jujuclient.NewFileClientStore(),
+	})
+}
+
+type modelAliasesCommand struct {
+	modelcmd.JujuCommandBase
+	store jujuclient.ClientStore
+	out   cmd.Output
+}
+
+// modelAliasInfo holds the details of a model alias, for output.
+type modelAliasInfo struct {
+	Alias      string `yaml:"alias" json:"alias"`
+	Controller string `yaml:"controller" json:"controller"`
+	Model      string `yaml:"model" json:"model"`
+}
+
+// Info implements Command.Info.
+func (c *modelAliasesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "model-aliases",
+		Purpose: "Lists client-side model aliases.",
+		Doc:     modelAliasesHelpDoc[1:],
+		Aliases: []string{"list-model-aliases"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *modelAliasesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatModelAliasesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *modelAliasesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *modelAliasesCommand) Run(ctx *cmd.Context) error {
+	aliases, err := c.store.AllModelAliases()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(aliases) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No model aliases to display.")
+		return nil
+	}
+	infos := make([]modelAliasInfo, 0, len(aliases))
+	for alias, target := range aliases {
+		infos = append(infos, modelAliasInfo{
+			Alias:      alias,
+			Controller: target.Controller,
+			Model:      target.Model,
+		})
+	}
+	sort.Sort(modelAliasInfosByAlias(infos))
+	return c.out.Write(ctx, infos)
+}
+
+type modelAliasInfosByAlias []modelAliasInfo
+
+func (s modelAliasInfosByAlias) Len() int           { return len(s) }
+func (s modelAliasInfosByAlias) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s modelAliasInfosByAlias) Less(i, j int) bool { return s[i].Alias < s[j].Alias }
+
+// formatModelAliasesTabular takes an interface{} to adhere to the
+// cmd.Formatter interface.
+func formatModelAliasesTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.([]modelAliasInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "ALIAS\tMODEL\n")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%s\n", info.Alias, modelcmd.JoinModelName(info.Controller, info.Model))
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ModelAliasesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	gitjujutesting.Stub
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ModelAliasesSuite{})
+
+func (s *ModelAliasesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.Stub.ResetCalls()
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{
+		User: "admin@local",
+	}
+	s.store.Models["ctrl"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin@local/web": {"web-uuid"},
+		},
+	}
+}
+
+func (s *ModelAliasesSuite) refreshModels(store jujuclient.ClientStore, controllerName string) error {
+	s.MethodCall(s, "RefreshModels", store, controllerName)
+	return s.NextErr()
+}
+
+func (s *ModelAliasesSuite) TestSetModelAliasInit(c *gc.C) {
+	cmd := model.NewSetModelAliasCommandForTest(s.store, s.refreshModels)
+	err := testing.InitCommand(cmd, []string{"web"})
+	c.Assert(err, gc.ErrorMatches, "an alias and a model must be specified")
+
+	cmd = model.NewSetModelAliasCommandForTest(s.store, s.refreshModels)
+	err = testing.InitCommand(cmd, []string{"a:b", "web"})
+	c.Assert(err, gc.ErrorMatches, `model alias "a:b" not valid`)
+}
+
+func (s *ModelAliasesSuite) TestSetModelAlias(c *gc.C) {
+	_, err := testing.RunCommand(c, model.NewSetModelAliasCommandForTest(s.store, s.refreshModels), "w", "web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.ModelAliases, jc.DeepEquals, map[string]jujuclient.ModelAlias{
+		"w": {Controller: "ctrl", Model: "admin@local/web"},
+	})
+	s.CheckNoCalls(c)
+}
+
+func (s *ModelAliasesSuite) TestSetModelAliasRefreshesModels(c *gc.C) {
+	_, err := testing.RunCommand(c, model.NewSetModelAliasCommandForTest(s.store, s.refreshModels), "w", "ctrl:bob/db")
+	c.Assert(err, gc.ErrorMatches, "model ctrl:bob@local/db not found")
+	s.CheckCallNames(c, "RefreshModels")
+	c.Assert(s.store.ModelAliases, gc.HasLen, 0)
+}
+
+func (s *ModelAliasesSuite) TestRemoveModelAlias(c *gc.C) {
+	s.store.ModelAliases["w"] = jujuclient.ModelAlias{Controller: "ctrl", Model: "admin@local/web"}
+	_, err := testing.RunCommand(c, model.NewRemoveModelAliasCommandForTest(s.store), "w")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.ModelAliases, gc.HasLen, 0)
+
+	_, err = testing.RunCommand(c, model.NewRemoveModelAliasCommandForTest(s.store), "w")
+	c.Assert(err, gc.ErrorMatches, "model alias w not found")
+}
+
+func (s *ModelAliasesSuite) TestModelAliasesTabular(c *gc.C) {
+	s.store.ModelAliases["web"] = jujuclient.ModelAlias{Controller: "ctrl", Model: "admin@local/web"}
+	s.store.ModelAliases["db"] = jujuclient.ModelAlias{Controller: "ctrl", Model: "bob@local/db"}
+	ctx, err := testing.RunCommand(c, model.NewModelAliasesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"ALIAS  MODEL\n"+
+		"db     ctrl:bob@local/db\n"+
+		"web    ctrl:admin@local/web\n"+
+		"\n",
+	)
+}
+
+func (s *ModelAliasesSuite) TestModelAliasesNone(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewModelAliasesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No model aliases to display.\n")
+}
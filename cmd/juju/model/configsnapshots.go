@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const configSnapshotsHelpDoc = `
+Lists the configuration snapshots of the model, oldest first. Snapshots
+are created with snapshot-config.
+
+Examples:
+
+    juju config-snapshots
+    juju config-snapshots --format yaml
+
+See also:
+    snapshot-config
+    restore-config
+    remove-config-snapshot
+`
+
+// NewConfigSnapshotsCommand returns a command which lists the config
+// snapshots of a model.
+func NewConfigSnapshotsCommand() cmd.Command {
+	return modelcmd.Wrap(&configSnapshotsCommand{})
+}
+
+type configSnapshotsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ConfigSnapshotsAPI
+}
+
+// ConfigSnapshotsAPI defines the API methods that the config-snapshots
+// command uses.
+type ConfigSnapshotsAPI interface {
+	Close() error
+	ConfigSnapshots() ([]params.ConfigSnapshot, error)
+}
+
+// configSnapshotInfo holds the details of a config snapshot, for
+// output.
+type configSnapshotInfo struct {
+	Name         string    `yaml:"name" json:"name"`
+	Revision     int       `yaml:"revision" json:"revision"`
+	Created      time.Time `yaml:"created" json:"created"`
+	CreatedBy    string    `yaml:"created-by" json:"created-by"`
+	Applications []string  `yaml:"applications,omitempty" json:"applications,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *configSnapshotsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "config-snapshots",
+		Purpose: "Lists the configuration snapshots of a model.",
+		Doc:     configSnapshotsHelpDoc[1:],
+		Aliases: []string{"list-config-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *configSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatConfigSnapshotsTabular,
+	})
+}
+
+func (c *configSnapshotsCommand) getAPI() (ConfigSnapshotsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelconfig.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *configSnapshotsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	snapshots, err := client.ConfigSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No config snapshots to display.")
+		return nil
+	}
+	infos := make([]configSnapshotInfo, len(snapshots))
+	for i, snapshot := range snapshots {
+		infos[i] = configSnapshotInfo{
+			Name:         snapshot.Name,
+			Revision:     snapshot.Revision,
+			Created:      snapshot.Created,
+			CreatedBy:    snapshot.CreatedBy,
+			Applications: snapshot.Applications,
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+// formatConfigSnapshotsTabular takes an interface{} to adhere to the
+// cmd.Formatter interface.
+func formatConfigSnapshotsTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.([]configSnapshotInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "NAME\tREVISION\tCREATED\tCREATED BY\tAPPLICATIONS\n")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n",
+			info.Name,
+			info.Revision,
+			info.Created.In(time.UTC).Format("2006-01-02 15:04:05"),
+			info.CreatedBy,
+			strings.Join(info.Applications, ","),
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
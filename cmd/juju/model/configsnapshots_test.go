@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type ConfigSnapshotsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeConfigSnapshotsAPI
+}
+
+var _ = gc.Suite(&ConfigSnapshotsSuite{})
+
+func (s *ConfigSnapshotsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	created := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.fake = &fakeConfigSnapshotsAPI{
+		snapshots: []params.ConfigSnapshot{{
+			Name:         "before-proxy",
+			Revision:     3,
+			Created:      created,
+			CreatedBy:    "admin@local",
+			Applications: []string{"mysql", "wordpress"},
+		}, {
+			Name:      "after-proxy",
+			Revision:  4,
+			Created:   created.Add(time.Hour),
+			CreatedBy: "bob@local",
+		}},
+	}
+}
+
+func (s *ConfigSnapshotsSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(model.NewConfigSnapshotsCommandForTest(s.fake), []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ConfigSnapshotsSuite) TestTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewConfigSnapshotsCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"NAME          REVISION  CREATED              CREATED BY   APPLICATIONS\n"+
+		"before-proxy  3         2016-08-01 10:00:00  admin@local  mysql,wordpress\n"+
+		"after-proxy   4         2016-08-01 11:00:00  bob@local    \n"+
+		"\n",
+	)
+	s.fake.CheckCallNames(c, "ConfigSnapshots", "Close")
+}
+
+func (s *ConfigSnapshotsSuite) TestYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewConfigSnapshotsCommandForTest(s.fake), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- name: before-proxy
+  revision: 3
+  created: 2016-08-01T10:00:00Z
+  created-by: admin@local
+  applications:
+  - mysql
+  - wordpress
+- name: after-proxy
+  revision: 4
+  created: 2016-08-01T11:00:00Z
+  created-by: bob@local
+`[1:])
+}
+
+func (s *ConfigSnapshotsSuite) TestNoSnapshots(c *gc.C) {
+	s.fake.snapshots = nil
+	ctx, err := testing.RunCommand(c, model.NewConfigSnapshotsCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No config snapshots to display.\n")
+}
+
+type fakeConfigSnapshotsAPI struct {
+	gitjujutesting.Stub
+	snapshots []params.ConfigSnapshot
+	skipped   []string
+}
+
+func (f *fakeConfigSnapshotsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeConfigSnapshotsAPI) CreateConfigSnapshot(name string) error {
+	f.MethodCall(f, "CreateConfigSnapshot", name)
+	return f.NextErr()
+}
+
+func (f *fakeConfigSnapshotsAPI) ConfigSnapshots() ([]params.ConfigSnapshot, error) {
+	f.MethodCall(f, "ConfigSnapshots")
+	return f.snapshots, f.NextErr()
+}
+
+func (f *fakeConfigSnapshotsAPI) RestoreConfigSnapshot(name string) ([]string, error) {
+	f.MethodCall(f, "RestoreConfigSnapshot", name)
+	return f.skipped, f.NextErr()
+}
+
+func (f *fakeConfigSnapshotsAPI) RemoveConfigSnapshot(name string) error {
+	f.MethodCall(f, "RemoveConfigSnapshot", name)
+	return f.NextErr()
+}
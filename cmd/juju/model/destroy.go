@@ -5,11 +5,13 @@ package model
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/modelmanager"
@@ -35,9 +37,15 @@ type destroyCommand struct {
 	modelcmd.ModelCommandBase
 	envName   string
 	assumeYes bool
+	watch     bool
 	api       DestroyModelAPI
+	clock     clock.Clock
 }
 
+// teardownPollInterval is the time between successive checks of the
+// progress of a model's destruction, when watching it.
+const teardownPollInterval = 2 * time.Second
+
 var destroyDoc = `
 Destroys the specified model. This will result in the non-recoverable
 removal of all the units operating in the model and any resources stored
@@ -45,10 +53,15 @@ there. Due to the irreversible nature of the command, it will prompt for
 confirmation (unless overridden with the '-y' option) before taking any
 action.
 
+With --watch, the command waits until the model has been removed,
+reporting the machines, applications, units and storage that remain,
+and what the teardown is currently waiting for.
+
 Examples:
 
       juju destroy-model test
       juju destroy-model -y mymodel
+      juju destroy-model -y --watch mymodel
 
 See also: destroy-controller
 `
@@ -63,6 +76,7 @@ Continue [y/N]? `[1:]
 type DestroyModelAPI interface {
 	Close() error
 	DestroyModel(names.ModelTag) error
+	ModelTeardownProgress(names.ModelTag) (params.ModelTeardownProgress, error)
 }
 
 // Info implements Command.Info.
@@ -79,6 +93,7 @@ func (c *destroyCommand) Info() *cmd.Info {
 func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
 	f.BoolVar(&c.assumeYes, "yes", false, "")
+	f.BoolVar(&c.watch, "watch", false, "Wait for the model to be removed, reporting progress")
 }
 
 // Init implements Command.Init.
@@ -138,7 +153,8 @@ func (c *destroyCommand) Run(ctx *cmd.Context) error {
 	defer api.Close()
 
 	// Attempt to destroy the model.
-	err = api.DestroyModel(names.NewModelTag(modelDetails.ModelUUID))
+	modelTag := names.NewModelTag(modelDetails.ModelUUID)
+	err = api.DestroyModel(modelTag)
 	if err != nil {
 		return c.handleError(errors.Annotate(err, "cannot destroy model"), modelName)
 	}
@@ -147,9 +163,54 @@ func (c *destroyCommand) Run(ctx *cmd.Context) error {
 	if err != nil && !errors.IsNotFound(err) {
 		return errors.Trace(err)
 	}
+	if c.watch {
+		return c.watchTeardown(ctx, api, modelTag, modelName)
+	}
 	return nil
 }
 
+// watchTeardown reports the progress of the destruction of the model
+// whenever it changes, until the model is dead or removed.
+func (c *destroyCommand) watchTeardown(ctx *cmd.Context, api DestroyModelAPI, modelTag names.ModelTag, modelName string) error {
+	clk := c.clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	var last string
+	for {
+		progress, err := api.ModelTeardownProgress(modelTag)
+		if params.IsCodeNotFound(err) {
+			break
+		} else if err != nil {
+			return errors.Annotate(err, "cannot get model teardown progress")
+		}
+		if progress.Life == params.Dead {
+			break
+		}
+		if report := formatTeardownProgress(progress); report != last {
+			ctx.Infof("%s", report)
+			last = report
+		}
+		<-clk.After(teardownPollInterval)
+	}
+	ctx.Infof("Model %q destroyed", modelName)
+	return nil
+}
+
+// formatTeardownProgress returns a one line summary of the progress
+// of a model's destruction.
+func formatTeardownProgress(progress params.ModelTeardownProgress) string {
+	report := fmt.Sprintf(
+		"machines: %d, applications: %d, units: %d, volumes: %d, filesystems: %d",
+		progress.Machines, progress.Applications, progress.Units,
+		progress.Volumes, progress.Filesystems,
+	)
+	if progress.Blocker != "" {
+		report += "; " + progress.Blocker
+	}
+	return report
+}
+
 func (c *destroyCommand) handleError(err error, modelName string) error {
 	if err == nil {
 		return nil
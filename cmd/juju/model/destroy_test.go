@@ -33,8 +33,9 @@ var _ = gc.Suite(&DestroySuite{})
 
 // fakeDestroyAPI mocks out the cient API
 type fakeDestroyAPI struct {
-	err error
-	env map[string]interface{}
+	err      error
+	env      map[string]interface{}
+	progress []params.ModelTeardownProgress
 }
 
 func (f *fakeDestroyAPI) Close() error { return nil }
@@ -43,6 +44,18 @@ func (f *fakeDestroyAPI) DestroyModel(names.ModelTag) error {
 	return f.err
 }
 
+func (f *fakeDestroyAPI) ModelTeardownProgress(names.ModelTag) (params.ModelTeardownProgress, error) {
+	if len(f.progress) == 0 {
+		return params.ModelTeardownProgress{}, &params.Error{
+			Message: "model not found",
+			Code:    params.CodeNotFound,
+		}
+	}
+	progress := f.progress[0]
+	f.progress = f.progress[1:]
+	return progress, nil
+}
+
 func (s *DestroySuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.api = &fakeDestroyAPI{}
@@ -124,6 +137,35 @@ func (s *DestroySuite) TestDestroy(c *gc.C) {
 	checkModelRemovedFromStore(c, "test1:admin@local/test2", s.store)
 }
 
+func (s *DestroySuite) TestDestroyWatch(c *gc.C) {
+	dying := params.ModelTeardownProgress{
+		Life:     params.Dying,
+		Machines: 1,
+		Units:    2,
+		Blocker:  "waiting for 2 unit(s) to be removed",
+	}
+	noUnits := dying
+	noUnits.Units = 0
+	noUnits.Blocker = "waiting for 1 machine(s) to be removed"
+	s.api.progress = []params.ModelTeardownProgress{dying, dying, noUnits}
+
+	ctx, err := s.runDestroyCommand(c, "test2", "-y", "--watch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, ""+
+		"machines: 1, applications: 0, units: 2, volumes: 0, filesystems: 0; waiting for 2 unit(s) to be removed\n"+
+		"machines: 1, applications: 0, units: 0, volumes: 0, filesystems: 0; waiting for 1 machine(s) to be removed\n"+
+		"Model \"test2\" destroyed\n",
+	)
+	checkModelRemovedFromStore(c, "test1:admin@local/test2", s.store)
+}
+
+func (s *DestroySuite) TestDestroyWatchDead(c *gc.C) {
+	s.api.progress = []params.ModelTeardownProgress{{Life: params.Dead}}
+	ctx, err := s.runDestroyCommand(c, "test2", "-y", "--watch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Model \"test2\" destroyed\n")
+}
+
 func (s *DestroySuite) TestFailedDestroyModel(c *gc.C) {
 	s.api.err = errors.New("permission denied")
 	_, err := s.runDestroyCommand(c, "test1:test2", "-y")
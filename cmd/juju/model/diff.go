@@ -0,0 +1,185 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/description"
+)
+
+const diffModelHelpDoc = `
+Compares the current model with another model in the same controller,
+or with a bundle file, and displays the differences between them.
+
+The applications (charm, number of units, exposure, constraints, config
+and storage), machines (series and constraints) and relations of each
+are compared. When comparing two models, model config is also compared.
+Differing values are shown under the name of the model or bundle in
+which they appear.
+
+Charm revisions are only compared if both sides specify one, so a
+bundle which does not pin charm revisions matches any deployed revision.
+
+Examples:
+
+    juju diff-model staging
+    juju diff-model -m production staging
+    juju diff-model ./bundle.yaml
+
+See also:
+    dump-model
+    deploy
+`
+
+// NewDiffCommand returns a fully constructed diff-model command.
+func NewDiffCommand() cmd.Command {
+	return modelcmd.Wrap(&diffCommand{})
+}
+
+type diffCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api DumpModelAPI
+
+	other string
+}
+
+// Info implements Command.
+func (c *diffCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-model",
+		Args:    "<model name>|<bundle file>",
+		Purpose: "Displays the differences between a model and another model or a bundle.",
+		Doc:     diffModelHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *diffCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements Command.
+func (c *diffCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model or bundle specified")
+	}
+	c.other = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *diffCommand) getAPI() (DumpModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *diffCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	current, err := c.summarizeModel(client, c.ModelName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var other *modelSummary
+	bundlePath := ctx.AbsPath(c.other)
+	if _, err := os.Stat(bundlePath); err == nil {
+		other, err = readBundleSummary(bundlePath)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		controllerName, modelName := modelcmd.SplitModelName(c.other)
+		if controllerName != "" && controllerName != c.ControllerName() {
+			return errors.Errorf("cannot compare models in different controllers")
+		}
+		other, err = c.summarizeModel(client, modelName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	diff := diffSummaries(c.ModelName(), current, c.other, other)
+	if diff.empty() {
+		ctx.Infof("no differences found")
+		return nil
+	}
+	return c.out.Write(ctx, diff)
+}
+
+// summarizeModel exports the named model and returns its summary.
+func (c *diffCommand) summarizeModel(client DumpModelAPI, modelName string) (*modelSummary, error) {
+	store := c.ClientStore()
+	modelDetails, err := store.ModelByName(c.ControllerName(), modelName)
+	if errors.IsNotFound(err) {
+		// The model isn't known locally, so refresh the
+		// models known to the controller and try again.
+		if err := c.RefreshModels(store, c.ControllerName()); err != nil {
+			return nil, errors.Annotate(err, "refreshing models")
+		}
+		modelDetails, err = store.ModelByName(c.ControllerName(), modelName)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting model %q details", modelName)
+	}
+
+	dumped, err := client.DumpModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return nil, errors.Annotatef(err, "exporting model %q", modelName)
+	}
+	// The exported model is sent as a generic map; round trip it
+	// through YAML to recover the model description.
+	bytes, err := yaml.Marshal(dumped)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading model %q", modelName)
+	}
+	return summarizeModel(model), nil
+}
+
+// readBundleSummary reads the bundle at the given path and returns
+// its summary.
+func readBundleSummary(path string) (*modelSummary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	bundle, err := charm.ReadBundleData(f)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading bundle %q", path)
+	}
+	summary, err := summarizeBundle(bundle)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading bundle %q", path)
+	}
+	return summary, nil
+}
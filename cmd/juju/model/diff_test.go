@@ -0,0 +1,295 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type DiffCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeDiffClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&DiffCommandSuite{})
+
+const otherModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+
+func (s *DiffCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeDiffClient{models: make(map[string]description.Model)}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin@local",
+	}
+	err := s.store.UpdateModel("testing", "admin@local/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateModel("testing", "admin@local/other", jujuclient.ModelDetails{
+		otherModelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin@local/mymodel"
+}
+
+func (s *DiffCommandSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, model.NewDiffCommandForTest(s.fake, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stdout(ctx) + testing.Stderr(ctx), nil
+}
+
+func (s *DiffCommandSuite) TestInit(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no model or bundle specified")
+	_, err = s.run(c, "other", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *DiffCommandSuite) TestIdenticalModels(c *gc.C) {
+	s.fake.models[testing.ModelTag.Id()] = newDiffModel("mymodel")
+	s.fake.models[otherModelUUID] = newDiffModel("other")
+
+	out, err := s.run(c, "other")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, "no differences found\n")
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DumpModel", []interface{}{testing.ModelTag}},
+		{"DumpModel", []interface{}{names.NewModelTag(otherModelUUID)}},
+		{"Close", nil},
+	})
+}
+
+func (s *DiffCommandSuite) TestDifferentModels(c *gc.C) {
+	s.fake.models[testing.ModelTag.Id()] = newDiffModel("mymodel")
+	other := newEmptyDiffModel("other", "trusty")
+	addDiffMachine(other, "0")
+	addDiffMachine(other, "1")
+	mysql := addDiffApplication(other, "mysql", "cs:xenial/mysql-2", 2, nil)
+	mysql.SetConstraints(description.ConstraintsArgs{Memory: 4096})
+	s.fake.models[otherModelUUID] = other
+
+	out, err := s.run(c, "other")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, `
+model-config:
+  default-series:
+    admin@local/mymodel: xenial
+    other: trusty
+applications:
+  mysql:
+    charm:
+      admin@local/mymodel: cs:xenial/mysql-1
+      other: cs:xenial/mysql-2
+    num-units:
+      admin@local/mymodel: 1
+      other: 2
+    constraints:
+      admin@local/mymodel: ""
+      other: mem=4096M
+    config:
+      dataset-size:
+        admin@local/mymodel: 80%
+        other: null
+  wordpress:
+    missing: other
+machines:
+  "1":
+    missing: admin@local/mymodel
+relations:
+  admin@local/mymodel:
+  - mysql:db wordpress:db
+`[1:])
+}
+
+func (s *DiffCommandSuite) TestBundle(c *gc.C) {
+	s.fake.models[testing.ModelTag.Id()] = newDiffModel("mymodel")
+	bundlePath := filepath.Join(c.MkDir(), "bundle.yaml")
+	err := ioutil.WriteFile(bundlePath, []byte(`
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql
+    num_units: 1
+    options:
+      dataset-size: 80%
+  wordpress:
+    charm: cs:xenial/wordpress-3
+    num_units: 2
+    expose: true
+machines:
+  "0": {}
+relations:
+- [wordpress, mysql]
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.run(c, bundlePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, fmt.Sprintf(`
+applications:
+  wordpress:
+    charm:
+      %[1]s: cs:xenial/wordpress-3
+      admin@local/mymodel: cs:xenial/wordpress-1
+    num-units:
+      %[1]s: 2
+      admin@local/mymodel: 1
+`[1:], bundlePath))
+	s.fake.CheckCallNames(c, "DumpModel", "Close")
+}
+
+func (s *DiffCommandSuite) TestBundleRelationMissing(c *gc.C) {
+	s.fake.models[testing.ModelTag.Id()] = newDiffModel("mymodel")
+	bundlePath := filepath.Join(c.MkDir(), "bundle.yaml")
+	err := ioutil.WriteFile(bundlePath, []byte(`
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-1
+    num_units: 1
+    options:
+      dataset-size: 80%
+  wordpress:
+    charm: cs:xenial/wordpress-1
+    num_units: 1
+    expose: true
+machines:
+  "0": {}
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.run(c, bundlePath, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, `{"relations":{"admin@local/mymodel":["mysql:db wordpress:db"]}}`+"\n")
+}
+
+func (s *DiffCommandSuite) TestDifferentController(c *gc.C) {
+	s.fake.models[testing.ModelTag.Id()] = newDiffModel("mymodel")
+	_, err := s.run(c, "elsewhere:other")
+	c.Assert(err, gc.ErrorMatches, "cannot compare models in different controllers")
+}
+
+type fakeDiffClient struct {
+	gitjujutesting.Stub
+	models map[string]description.Model
+}
+
+func (f *fakeDiffClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeDiffClient) DumpModel(tag names.ModelTag) (map[string]interface{}, error) {
+	f.MethodCall(f, "DumpModel", tag)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	bytes, err := description.Serialize(f.models[tag.Id()])
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newDiffModel returns a model with a wordpress application related
+// to a mysql application, each with one unit on machine 0.
+func newDiffModel(name string) description.Model {
+	m := newEmptyDiffModel(name, "xenial")
+	addDiffMachine(m, "0")
+	wordpress := addDiffApplication(m, "wordpress", "cs:xenial/wordpress-1", 1, nil)
+	wordpress.SetStatus(diffStatusArgs())
+	addDiffApplication(m, "mysql", "cs:xenial/mysql-1", 1, map[string]interface{}{
+		"dataset-size": "80%",
+	})
+	relation := m.AddRelation(description.RelationArgs{
+		Id:  1,
+		Key: "wordpress:db mysql:db",
+	})
+	relation.AddEndpoint(description.EndpointArgs{ApplicationName: "wordpress", Name: "db"})
+	relation.AddEndpoint(description.EndpointArgs{ApplicationName: "mysql", Name: "db"})
+	return m
+}
+
+func newEmptyDiffModel(name, series string) description.Model {
+	return description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":           name,
+			"uuid":           name + "-uuid",
+			"default-series": series,
+		},
+		LatestToolsVersion: version.MustParse("2.0.0"),
+	})
+}
+
+func addDiffMachine(m description.Model, id string) {
+	machine := m.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag(id),
+		Series: "xenial",
+	})
+	machine.SetStatus(diffStatusArgs())
+	machine.SetTools(diffToolsArgs())
+}
+
+func addDiffApplication(
+	m description.Model, name, charmURL string, numUnits int, settings map[string]interface{},
+) description.Application {
+	app := m.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag(name),
+		Series:   "xenial",
+		CharmURL: charmURL,
+		Exposed:  name == "wordpress",
+		Settings: settings,
+	})
+	app.SetStatus(diffStatusArgs())
+	for i := 0; i < numUnits; i++ {
+		unit := app.AddUnit(description.UnitArgs{
+			Tag:     names.NewUnitTag(fmt.Sprintf("%s/%d", name, i)),
+			Machine: names.NewMachineTag("0"),
+		})
+		unit.SetAgentStatus(diffStatusArgs())
+		unit.SetWorkloadStatus(diffStatusArgs())
+		unit.SetTools(diffToolsArgs())
+	}
+	return app
+}
+
+func diffStatusArgs() description.StatusArgs {
+	return description.StatusArgs{
+		Value:   "active",
+		Updated: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func diffToolsArgs() description.AgentToolsArgs {
+	return description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.0-xenial-amd64"),
+	}
+}
@@ -4,10 +4,13 @@
 package model
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
 )
 
 // NewGetCommandForTest returns a GetCommand with the api provided as specified.
@@ -87,10 +90,20 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.Wrap(cmd)
 }
 
+// NewDiffCommandForTest returns a diff-model command with the api
+// provided as specified.
+func NewDiffCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &diffCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the api provided as specified.
 func NewDestroyCommandForTest(api DestroyModelAPI, store jujuclient.ClientStore) cmd.Command {
+	clk := coretesting.NewClock(time.Time{})
 	cmd := &destroyCommand{
-		api: api,
+		api:   api,
+		clock: &coretesting.AutoAdvancingClock{Clock: clk, Advance: clk.Advance},
 	}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(
@@ -125,3 +138,54 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+// NewExportBundleCommandForTest returns an export-bundle command with
+// the api provided as specified.
+func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportBundleCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSnapshotConfigCommandForTest returns a SnapshotConfigCommand with the api provided as specified.
+func NewSnapshotConfigCommandForTest(api SnapshotConfigAPI) cmd.Command {
+	return modelcmd.Wrap(&snapshotConfigCommand{api: api})
+}
+
+// NewConfigSnapshotsCommandForTest returns a ConfigSnapshotsCommand with the api provided as specified.
+func NewConfigSnapshotsCommandForTest(api ConfigSnapshotsAPI) cmd.Command {
+	return modelcmd.Wrap(&configSnapshotsCommand{api: api})
+}
+
+// NewRestoreConfigCommandForTest returns a RestoreConfigCommand with the api provided as specified.
+func NewRestoreConfigCommandForTest(api RestoreConfigAPI) cmd.Command {
+	return modelcmd.Wrap(&restoreConfigCommand{api: api})
+}
+
+// NewRemoveConfigSnapshotCommandForTest returns a RemoveConfigSnapshotCommand with the api provided as specified.
+func NewRemoveConfigSnapshotCommandForTest(api RemoveConfigSnapshotAPI) cmd.Command {
+	return modelcmd.Wrap(&removeConfigSnapshotCommand{api: api})
+}
+
+// NewSetModelAliasCommandForTest returns a SetModelAliasCommand with the store and
+// model refresher provided as specified.
+func NewSetModelAliasCommandForTest(store jujuclient.ClientStore, refresh func(jujuclient.ClientStore, string) error) cmd.Command {
+	return modelcmd.WrapBase(&setModelAliasCommand{store: store, refreshModels: refresh})
+}
+
+// NewRemoveModelAliasCommandForTest returns a RemoveModelAliasCommand with the store provided as specified.
+func NewRemoveModelAliasCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	return modelcmd.WrapBase(&removeModelAliasCommand{store: store})
+}
+
+// NewModelAliasesCommandForTest returns a ModelAliasesCommand with the store provided as specified.
+func NewModelAliasesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	return modelcmd.WrapBase(&modelAliasesCommand{store: store})
+}
+
+// NewShowEventsCommandForTest returns a ShowEventsCommand with the api provided as specified.
+func NewShowEventsCommandForTest(api ShowEventsAPI) cmd.Command {
+	return modelcmd.Wrap(&showEventsCommand{api: api})
+}
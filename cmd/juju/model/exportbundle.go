@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewExportBundleCommand returns a fully constructed export-bundle command.
+func NewExportBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{})
+}
+
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	api ExportBundleAPI
+
+	filename string
+}
+
+const exportBundleHelpDoc = `
+Exports the current model as a bundle, which may be deployed with
+"juju deploy" to recreate the model's applications, machines and
+relations.
+
+For each application the bundle records the charm, the number of units
+and the machines they are placed on, whether it is exposed, and its
+constraints, storage constraints and config options that have been
+changed from the charm's defaults. Units in containers are placed in a
+new container of the same type on the same machine.
+
+The bundle is written to stdout unless --filename is specified.
+
+Examples:
+
+    juju export-bundle
+    juju export-bundle -m mymodel --filename mymodel.yaml
+
+See also:
+    deploy
+    diff-model
+    dump-model
+`
+
+// Info implements Command.
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: "Exports the current model as a bundle.",
+		Doc:     exportBundleHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.filename, "filename", "", "File to write the bundle to")
+}
+
+// Init implements Command.
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// ExportBundleAPI specifies the used function calls of the Bundle API.
+type ExportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+func (c *exportBundleCommand) getAPI() (ExportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ExportBundle()
+	if err != nil {
+		return errors.Annotate(err, "exporting bundle")
+	}
+	if c.filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, result)
+		return err
+	}
+	path := ctx.AbsPath(c.filename)
+	if err := ioutil.WriteFile(path, []byte(result), 0644); err != nil {
+		return errors.Annotate(err, "writing bundle")
+	}
+	ctx.Infof("Bundle successfully exported to %s", path)
+	return nil
+}
@@ -0,0 +1,481 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/instance"
+)
+
+// modelSummary holds the parts of a model, or of a bundle, that are
+// compared by diff-model. Models and bundles are both reduced to a
+// summary so that either may be compared with the other.
+type modelSummary struct {
+	// config holds the model config. It is nil for bundles, which
+	// have no model config.
+	config       map[string]interface{}
+	applications map[string]applicationSummary
+	machines     map[string]machineSummary
+	relations    [][]string
+}
+
+type applicationSummary struct {
+	charm       string
+	numUnits    int
+	exposed     bool
+	constraints string
+	config      map[string]interface{}
+	storage     []string
+}
+
+type machineSummary struct {
+	series      string
+	constraints string
+}
+
+// ignoredModelConfig holds the model config attributes which always
+// differ between models, and so are not compared.
+var ignoredModelConfig = []string{"name", "uuid"}
+
+// summarizeModel returns a summary of the supplied model description.
+func summarizeModel(model description.Model) *modelSummary {
+	summary := &modelSummary{
+		config:       make(map[string]interface{}),
+		applications: make(map[string]applicationSummary),
+		machines:     make(map[string]machineSummary),
+	}
+	for key, value := range model.Config() {
+		summary.config[key] = value
+	}
+	for _, key := range ignoredModelConfig {
+		delete(summary.config, key)
+	}
+
+	storage := make(map[string][]string)
+	for _, storageInstance := range model.Storages() {
+		owner, err := storageInstance.Owner()
+		if err != nil {
+			continue
+		}
+		application := owner.Id()
+		if i := strings.IndexRune(application, '/'); i >= 0 {
+			application = application[:i]
+		}
+		if !containsString(storage[application], storageInstance.Name()) {
+			storage[application] = append(storage[application], storageInstance.Name())
+		}
+	}
+
+	for _, application := range model.Applications() {
+		numUnits := len(application.Units())
+		if application.Subordinate() {
+			// Bundles do not specify the number of units of
+			// subordinates, as it depends on their principals.
+			numUnits = 0
+		}
+		storageNames := storage[application.Name()]
+		sort.Strings(storageNames)
+		summary.applications[application.Name()] = applicationSummary{
+			charm:       application.CharmURL(),
+			numUnits:    numUnits,
+			exposed:     application.Exposed(),
+			constraints: descriptionConstraints(application.Constraints()).String(),
+			config:      application.Settings(),
+			storage:     storageNames,
+		}
+	}
+
+	var addMachines func([]description.Machine)
+	addMachines = func(machines []description.Machine) {
+		for _, machine := range machines {
+			summary.machines[machine.Id()] = machineSummary{
+				series:      machine.Series(),
+				constraints: descriptionConstraints(machine.Constraints()).String(),
+			}
+			addMachines(machine.Containers())
+		}
+	}
+	addMachines(model.Machines())
+
+	for _, relation := range model.Relations() {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are created implicitly, and
+			// do not appear in bundles.
+			continue
+		}
+		summary.relations = append(summary.relations, []string{
+			endpoints[0].ApplicationName() + ":" + endpoints[0].Name(),
+			endpoints[1].ApplicationName() + ":" + endpoints[1].Name(),
+		})
+	}
+	return summary
+}
+
+// summarizeBundle returns a summary of the supplied bundle.
+func summarizeBundle(bundle *charm.BundleData) (*modelSummary, error) {
+	summary := &modelSummary{
+		applications: make(map[string]applicationSummary),
+		machines:     make(map[string]machineSummary),
+	}
+	for name, spec := range bundle.Applications {
+		if spec == nil {
+			return nil, errors.Errorf("application %q has no specification", name)
+		}
+		cons, err := normalizeConstraints(spec.Constraints)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", name)
+		}
+		var storage []string
+		for storageName := range spec.Storage {
+			storage = append(storage, storageName)
+		}
+		sort.Strings(storage)
+		summary.applications[name] = applicationSummary{
+			charm:       spec.Charm,
+			numUnits:    spec.NumUnits,
+			exposed:     spec.Expose,
+			constraints: cons,
+			config:      spec.Options,
+			storage:     storage,
+		}
+	}
+	for id, spec := range bundle.Machines {
+		var machine machineSummary
+		if spec != nil {
+			cons, err := normalizeConstraints(spec.Constraints)
+			if err != nil {
+				return nil, errors.Annotatef(err, "machine %q", id)
+			}
+			machine.series = spec.Series
+			machine.constraints = cons
+		}
+		if machine.series == "" {
+			machine.series = bundle.Series
+		}
+		summary.machines[id] = machine
+	}
+	for _, relation := range bundle.Relations {
+		if len(relation) != 2 {
+			return nil, errors.Errorf("relation %q does not have two endpoints", relation)
+		}
+		summary.relations = append(summary.relations, relation)
+	}
+	return summary, nil
+}
+
+// modelDiff holds the differences between two model summaries. The
+// differing values are recorded in valueDiffs, keyed by the label of
+// each side of the comparison.
+type modelDiff struct {
+	Config       map[string]valueDiff       `yaml:"model-config,omitempty" json:"model-config,omitempty"`
+	Applications map[string]applicationDiff `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Machines     map[string]machineDiff     `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Relations    map[string][]string        `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// valueDiff records the differing values of an attribute, keyed by
+// the label of each side of the comparison.
+type valueDiff map[string]interface{}
+
+type applicationDiff struct {
+	Missing     string               `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       valueDiff            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	NumUnits    valueDiff            `yaml:"num-units,omitempty" json:"num-units,omitempty"`
+	Exposed     valueDiff            `yaml:"exposed,omitempty" json:"exposed,omitempty"`
+	Constraints valueDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Config      map[string]valueDiff `yaml:"config,omitempty" json:"config,omitempty"`
+	Storage     valueDiff            `yaml:"storage,omitempty" json:"storage,omitempty"`
+}
+
+type machineDiff struct {
+	Missing     string    `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Series      valueDiff `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints valueDiff `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+}
+
+// empty reports whether no differences were found.
+func (d *modelDiff) empty() bool {
+	return len(d.Config) == 0 &&
+		len(d.Applications) == 0 &&
+		len(d.Machines) == 0 &&
+		len(d.Relations) == 0
+}
+
+// diffSummaries compares the two summaries, which are identified in
+// the result by the supplied labels.
+func diffSummaries(labelA string, a *modelSummary, labelB string, b *modelSummary) *modelDiff {
+	d := &diffContext{labelA: labelA, labelB: labelB}
+	result := &modelDiff{}
+	if a.config != nil && b.config != nil {
+		result.Config = d.maps(a.config, b.config)
+	}
+
+	for _, name := range unionKeys(a.applications, b.applications) {
+		appA, okA := a.applications[name]
+		appB, okB := b.applications[name]
+		var diff applicationDiff
+		if missing := d.missing(okA, okB); missing != "" {
+			diff.Missing = missing
+		} else {
+			if !charmsMatch(appA.charm, appB.charm) {
+				diff.Charm = d.values(appA.charm, appB.charm)
+			}
+			diff.NumUnits = d.compare(appA.numUnits, appB.numUnits)
+			diff.Exposed = d.compare(appA.exposed, appB.exposed)
+			diff.Constraints = d.compare(appA.constraints, appB.constraints)
+			diff.Config = d.maps(appA.config, appB.config)
+			diff.Storage = d.compare(appA.storage, appB.storage)
+			if reflect.DeepEqual(diff, applicationDiff{}) {
+				continue
+			}
+		}
+		if result.Applications == nil {
+			result.Applications = make(map[string]applicationDiff)
+		}
+		result.Applications[name] = diff
+	}
+
+	for _, id := range unionKeys(a.machines, b.machines) {
+		machineA, okA := a.machines[id]
+		machineB, okB := b.machines[id]
+		var diff machineDiff
+		if missing := d.missing(okA, okB); missing != "" {
+			diff.Missing = missing
+		} else {
+			diff.Series = d.compare(machineA.series, machineB.series)
+			diff.Constraints = d.compare(machineA.constraints, machineB.constraints)
+			if reflect.DeepEqual(diff, machineDiff{}) {
+				continue
+			}
+		}
+		if result.Machines == nil {
+			result.Machines = make(map[string]machineDiff)
+		}
+		result.Machines[id] = diff
+	}
+
+	onlyA, onlyB := diffRelations(a.relations, b.relations)
+	if len(onlyA) > 0 || len(onlyB) > 0 {
+		result.Relations = make(map[string][]string)
+		if len(onlyA) > 0 {
+			result.Relations[labelA] = onlyA
+		}
+		if len(onlyB) > 0 {
+			result.Relations[labelB] = onlyB
+		}
+	}
+	return result
+}
+
+// diffContext holds the labels of the two sides of a comparison.
+type diffContext struct {
+	labelA, labelB string
+}
+
+// values returns a valueDiff holding the two values.
+func (d *diffContext) values(a, b interface{}) valueDiff {
+	return valueDiff{d.labelA: a, d.labelB: b}
+}
+
+// compare returns a valueDiff holding the two values if they differ,
+// and nil otherwise.
+func (d *diffContext) compare(a, b interface{}) valueDiff {
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	return d.values(a, b)
+}
+
+// missing returns the label of the side that lacks an entity, given
+// whether each side has it.
+func (d *diffContext) missing(okA, okB bool) string {
+	switch {
+	case !okA:
+		return d.labelA
+	case !okB:
+		return d.labelB
+	}
+	return ""
+}
+
+// maps compares two maps of settings. Values are compared by their
+// string representations, so that numbers decoded from YAML and from
+// JSON compare equal.
+func (d *diffContext) maps(a, b map[string]interface{}) map[string]valueDiff {
+	var result map[string]valueDiff
+	for _, key := range unionKeys(a, b) {
+		valueA, valueB := a[key], b[key]
+		if fmt.Sprint(valueA) == fmt.Sprint(valueB) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]valueDiff)
+		}
+		result[key] = d.values(valueA, valueB)
+	}
+	return result
+}
+
+// charmsMatch reports whether the two charm URLs refer to the same
+// charm. Bundles often omit the revision of a charm, in which case
+// any revision matches.
+func charmsMatch(a, b string) bool {
+	if a == b {
+		return true
+	}
+	urlA, errA := charm.ParseURL(a)
+	urlB, errB := charm.ParseURL(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	if urlA.Revision == -1 || urlB.Revision == -1 {
+		urlA = urlA.WithRevision(-1)
+		urlB = urlB.WithRevision(-1)
+	}
+	return *urlA == *urlB
+}
+
+// diffRelations returns the relations found only in a, and those
+// found only in b. An endpoint without a relation name, as is
+// allowed in bundles, matches any endpoint of the same application.
+func diffRelations(a, b [][]string) (onlyA, onlyB []string) {
+	matched := make([]bool, len(b))
+	for _, relA := range a {
+		found := false
+		for i, relB := range b {
+			if !matched[i] && relationsMatch(relA, relB) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			onlyA = append(onlyA, formatRelation(relA))
+		}
+	}
+	for i, relB := range b {
+		if !matched[i] {
+			onlyB = append(onlyB, formatRelation(relB))
+		}
+	}
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	return onlyA, onlyB
+}
+
+func relationsMatch(a, b []string) bool {
+	return endpointsMatch(a[0], b[0]) && endpointsMatch(a[1], b[1]) ||
+		endpointsMatch(a[0], b[1]) && endpointsMatch(a[1], b[0])
+}
+
+func endpointsMatch(a, b string) bool {
+	appA, relA := splitEndpoint(a)
+	appB, relB := splitEndpoint(b)
+	if appA != appB {
+		return false
+	}
+	return relA == "" || relB == "" || relA == relB
+}
+
+func splitEndpoint(endpoint string) (application, relation string) {
+	if i := strings.IndexRune(endpoint, ':'); i >= 0 {
+		return endpoint[:i], endpoint[i+1:]
+	}
+	return endpoint, ""
+}
+
+func formatRelation(relation []string) string {
+	endpoints := append([]string(nil), relation...)
+	sort.Strings(endpoints)
+	return strings.Join(endpoints, " ")
+}
+
+// unionKeys returns the sorted union of the keys of the two maps,
+// which must have string keys.
+func unionKeys(a, b interface{}) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []reflect.Value{reflect.ValueOf(a), reflect.ValueOf(b)} {
+		for _, key := range m.MapKeys() {
+			if !seen[key.String()] {
+				seen[key.String()] = true
+				keys = append(keys, key.String())
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeConstraints parses and reformats the supplied constraints
+// so that equivalent constraints compare equal.
+func normalizeConstraints(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	cons, err := constraints.Parse(s)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cons.String(), nil
+}
+
+// descriptionConstraints converts constraints from a model
+// description into a constraints.Value.
+func descriptionConstraints(cons description.Constraints) constraints.Value {
+	var result constraints.Value
+	if cons == nil {
+		return result
+	}
+	if arch := cons.Architecture(); arch != "" {
+		result.Arch = &arch
+	}
+	if container := instance.ContainerType(cons.Container()); container != "" {
+		result.Container = &container
+	}
+	if cores := cons.CpuCores(); cores != 0 {
+		result.CpuCores = &cores
+	}
+	if power := cons.CpuPower(); power != 0 {
+		result.CpuPower = &power
+	}
+	if inst := cons.InstanceType(); inst != "" {
+		result.InstanceType = &inst
+	}
+	if mem := cons.Memory(); mem != 0 {
+		result.Mem = &mem
+	}
+	if disk := cons.RootDisk(); disk != 0 {
+		result.RootDisk = &disk
+	}
+	if spaces := cons.Spaces(); len(spaces) > 0 {
+		result.Spaces = &spaces
+	}
+	if tags := cons.Tags(); len(tags) > 0 {
+		result.Tags = &tags
+	}
+	if virt := cons.VirtType(); virt != "" {
+		result.VirtType = &virt
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const removeConfigSnapshotHelpDoc = `
+Removes the named configuration snapshot of the model. The configuration
+of the model and its applications is not changed.
+
+Examples:
+
+    juju remove-config-snapshot before-proxy-change
+
+See also:
+    snapshot-config
+    config-snapshots
+`
+
+// NewRemoveConfigSnapshotCommand returns a command which removes a
+// config snapshot of a model.
+func NewRemoveConfigSnapshotCommand() cmd.Command {
+	return modelcmd.Wrap(&removeConfigSnapshotCommand{})
+}
+
+type removeConfigSnapshotCommand struct {
+	modelcmd.ModelCommandBase
+	api  RemoveConfigSnapshotAPI
+	name string
+}
+
+// RemoveConfigSnapshotAPI defines the API methods that the
+// remove-config-snapshot command uses.
+type RemoveConfigSnapshotAPI interface {
+	Close() error
+	RemoveConfigSnapshot(name string) error
+}
+
+// Info implements Command.Info.
+func (c *removeConfigSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-config-snapshot",
+		Args:    "<snapshot name>",
+		Purpose: "Removes a configuration snapshot of a model.",
+		Doc:     removeConfigSnapshotHelpDoc[1:],
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeConfigSnapshotCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no snapshot name specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *removeConfigSnapshotCommand) getAPI() (RemoveConfigSnapshotAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelconfig.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *removeConfigSnapshotCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.RemoveConfigSnapshot(c.name), block.BlockRemove)
+}
@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type RemoveConfigSnapshotSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeConfigSnapshotsAPI
+}
+
+var _ = gc.Suite(&RemoveConfigSnapshotSuite{})
+
+func (s *RemoveConfigSnapshotSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeConfigSnapshotsAPI{}
+}
+
+func (s *RemoveConfigSnapshotSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(model.NewRemoveConfigSnapshotCommandForTest(s.fake), nil)
+	c.Assert(err, gc.ErrorMatches, "no snapshot name specified")
+}
+
+func (s *RemoveConfigSnapshotSuite) TestRemoveConfigSnapshot(c *gc.C) {
+	_, err := testing.RunCommand(c, model.NewRemoveConfigSnapshotCommandForTest(s.fake), "before-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "RemoveConfigSnapshot", "Close")
+	s.fake.CheckCall(c, 0, "RemoveConfigSnapshot", "before-proxy")
+}
+
+func (s *RemoveConfigSnapshotSuite) TestRemoveConfigSnapshotNotFound(c *gc.C) {
+	s.fake.SetErrors(errors.NotFoundf("config snapshot %q", "before-proxy"))
+	_, err := testing.RunCommand(c, model.NewRemoveConfigSnapshotCommandForTest(s.fake), "before-proxy")
+	c.Assert(err, gc.ErrorMatches, `config snapshot "before-proxy" not found`)
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const restoreConfigHelpDoc = `
+Rolls the configuration of the model, and of its applications, back to
+the values recorded in the named snapshot. The agent version of the
+model is not changed.
+
+Applications deployed since the snapshot was created keep their current
+configuration. Applications recorded in the snapshot which have since
+been removed are skipped.
+
+Examples:
+
+    juju restore-config before-proxy-change
+
+See also:
+    snapshot-config
+    config-snapshots
+`
+
+// NewRestoreConfigCommand returns a command which restores the config
+// of a model and its applications from a named snapshot.
+func NewRestoreConfigCommand() cmd.Command {
+	return modelcmd.Wrap(&restoreConfigCommand{})
+}
+
+type restoreConfigCommand struct {
+	modelcmd.ModelCommandBase
+	api  RestoreConfigAPI
+	name string
+}
+
+// RestoreConfigAPI defines the API methods that the restore-config
+// command uses.
+type RestoreConfigAPI interface {
+	Close() error
+	RestoreConfigSnapshot(name string) ([]string, error)
+}
+
+// Info implements Command.Info.
+func (c *restoreConfigCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "restore-config",
+		Args:    "<snapshot name>",
+		Purpose: "Rolls back the configuration of a model and its applications to a snapshot.",
+		Doc:     restoreConfigHelpDoc[1:],
+	}
+}
+
+// Init implements Command.Init.
+func (c *restoreConfigCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no snapshot name specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *restoreConfigCommand) getAPI() (RestoreConfigAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelconfig.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *restoreConfigCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	skipped, err := client.RestoreConfigSnapshot(c.name)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if len(skipped) > 0 {
+		ctx.Infof("Skipped removed applications: %s", strings.Join(skipped, ", "))
+	}
+	ctx.Infof("Restored config snapshot %q.", c.name)
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type RestoreConfigSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeConfigSnapshotsAPI
+}
+
+var _ = gc.Suite(&RestoreConfigSuite{})
+
+func (s *RestoreConfigSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeConfigSnapshotsAPI{}
+}
+
+func (s *RestoreConfigSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(model.NewRestoreConfigCommandForTest(s.fake), nil)
+	c.Assert(err, gc.ErrorMatches, "no snapshot name specified")
+}
+
+func (s *RestoreConfigSuite) TestRestoreConfig(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewRestoreConfigCommandForTest(s.fake), "before-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Restored config snapshot \"before-proxy\".\n")
+	s.fake.CheckCallNames(c, "RestoreConfigSnapshot", "Close")
+	s.fake.CheckCall(c, 0, "RestoreConfigSnapshot", "before-proxy")
+}
+
+func (s *RestoreConfigSuite) TestRestoreConfigSkipped(c *gc.C) {
+	s.fake.skipped = []string{"mysql", "wordpress"}
+	ctx, err := testing.RunCommand(c, model.NewRestoreConfigCommandForTest(s.fake), "before-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, ""+
+		"Skipped removed applications: mysql, wordpress\n"+
+		"Restored config snapshot \"before-proxy\".\n",
+	)
+}
+
+func (s *RestoreConfigSuite) TestRestoreConfigNotFound(c *gc.C) {
+	s.fake.SetErrors(errors.NotFoundf("config snapshot %q", "before-proxy"))
+	_, err := testing.RunCommand(c, model.NewRestoreConfigCommandForTest(s.fake), "before-proxy")
+	c.Assert(err, gc.ErrorMatches, `config snapshot "before-proxy" not found`)
+}
+
+func (s *RestoreConfigSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockedError"))
+	_, err := testing.RunCommand(c, model.NewRestoreConfigCommandForTest(s.fake), "before-proxy")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
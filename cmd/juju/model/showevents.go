@@ -0,0 +1,255 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showEventsHelpDoc = `
+Shows the timeline of high-level events in the model, oldest first:
+deployments, charm upgrades, added units, configuration changes and
+machine failures, along with the user who caused them.
+
+The --from and --to options restrict the events to a time range. Each
+accepts either an RFC3339 timestamp or a duration, which is taken to
+mean that long ago. The --kind option restricts the events to those of
+the given comma-separated kinds, and --entity to those concerning the
+named application, unit or machine.
+
+Examples:
+
+    juju show-events
+    juju show-events --from 2h
+    juju show-events --from 2016-10-01T09:00:00Z --to 2016-10-01T10:00:00Z
+    juju show-events --kind deploy,upgrade-charm --entity mysql
+    juju show-events -n 20 --format yaml
+
+See also:
+    show-status-log
+`
+
+// NewShowEventsCommand returns a command which shows the event
+// timeline of a model.
+func NewShowEventsCommand() cmd.Command {
+	return modelcmd.Wrap(&showEventsCommand{})
+}
+
+type showEventsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ShowEventsAPI
+
+	from   string
+	to     string
+	kinds  string
+	entity string
+	limit  int
+}
+
+// ShowEventsAPI defines the API methods that the show-events command
+// uses.
+type ShowEventsAPI interface {
+	Close() error
+	ModelEvents(params.ModelEventsFilter) ([]params.ModelEvent, error)
+}
+
+// eventInfo holds the details of a model event, for output.
+type eventInfo struct {
+	Time    time.Time `yaml:"time" json:"time"`
+	Kind    string    `yaml:"kind" json:"kind"`
+	Entity  string    `yaml:"entity,omitempty" json:"entity,omitempty"`
+	Actor   string    `yaml:"actor,omitempty" json:"actor,omitempty"`
+	Message string    `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *showEventsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-events",
+		Purpose: "Shows the timeline of events in a model.",
+		Doc:     showEventsHelpDoc[1:],
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showEventsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatEventsTabular,
+	})
+	f.StringVar(&c.from, "from", "", "Show events from this time or duration ago")
+	f.StringVar(&c.to, "to", "", "Show events before this time or duration ago")
+	f.StringVar(&c.kinds, "kind", "", "Show only events of these comma-separated kinds")
+	f.StringVar(&c.entity, "entity", "", "Show only events concerning this application, unit or machine")
+	f.IntVar(&c.limit, "n", 100, "Show at most this many of the most recent events")
+	f.IntVar(&c.limit, "limit", 100, "")
+}
+
+// Init implements Command.Init.
+func (c *showEventsCommand) Init(args []string) error {
+	if c.limit <= 0 {
+		return errors.Errorf("invalid number of events %d", c.limit)
+	}
+	if c.entity != "" {
+		if _, err := parseEventEntity(c.entity); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// parseEventEntity returns the tag of the application, unit or machine
+// with the given name, or the tag given in its place.
+func parseEventEntity(entity string) (names.Tag, error) {
+	switch {
+	case names.IsValidUnit(entity):
+		return names.NewUnitTag(entity), nil
+	case names.IsValidMachine(entity):
+		return names.NewMachineTag(entity), nil
+	case names.IsValidApplication(entity):
+		return names.NewApplicationTag(entity), nil
+	}
+	tag, err := names.ParseTag(entity)
+	if err != nil {
+		return nil, errors.NotValidf("entity %q", entity)
+	}
+	return tag, nil
+}
+
+// parseEventTime returns the time described by the value of the named
+// option, which is either an RFC3339 timestamp or a duration before now.
+func parseEventTime(option, value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return nil, errors.Errorf("invalid --%s value %q, expected a time or duration", option, value)
+	}
+	t := now.Add(-d)
+	return &t, nil
+}
+
+func (c *showEventsCommand) getAPI() (ShowEventsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelevents.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *showEventsCommand) Run(ctx *cmd.Context) error {
+	now := time.Now()
+	filter := params.ModelEventsFilter{Limit: c.limit}
+	var err error
+	if filter.From, err = parseEventTime("from", c.from, now); err != nil {
+		return errors.Trace(err)
+	}
+	if filter.To, err = parseEventTime("to", c.to, now); err != nil {
+		return errors.Trace(err)
+	}
+	if c.kinds != "" {
+		for _, kind := range strings.Split(c.kinds, ",") {
+			if kind = strings.TrimSpace(kind); kind != "" {
+				filter.Kinds = append(filter.Kinds, kind)
+			}
+		}
+	}
+	if c.entity != "" {
+		tag, err := parseEventEntity(c.entity)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filter.Entity = tag.String()
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	events, err := client.ModelEvents(filter)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No events to display.")
+		return nil
+	}
+	infos := make([]eventInfo, len(events))
+	for i, event := range events {
+		infos[i] = eventInfo{
+			Time:    event.Time,
+			Kind:    event.Kind,
+			Entity:  event.Entity,
+			Actor:   event.Actor,
+			Message: event.Message,
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+// formatEventsTabular takes an interface{} to adhere to the
+// cmd.Formatter interface.
+func formatEventsTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.([]eventInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tKIND\tENTITY\tACTOR\tMESSAGE\n")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			info.Time.In(time.UTC).Format("2006-01-02 15:04:05"),
+			info.Kind,
+			displayTag(info.Entity),
+			displayTag(info.Actor),
+			info.Message,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+// displayTag returns the id of the entity with the given tag, or the
+// tag itself if it cannot be parsed.
+func displayTag(tagString string) string {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return tagString
+	}
+	return tag.Id()
+}
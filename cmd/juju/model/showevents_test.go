@@ -0,0 +1,152 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type ShowEventsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeShowEventsAPI
+}
+
+var _ = gc.Suite(&ShowEventsSuite{})
+
+func (s *ShowEventsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	at := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.fake = &fakeShowEventsAPI{
+		events: []params.ModelEvent{{
+			Kind:    "deploy",
+			Actor:   "user-admin@local",
+			Entity:  "application-mysql",
+			Message: "deployed cs:mysql-1 with 1 unit",
+			Time:    at,
+		}, {
+			Kind:    "machine-failure",
+			Entity:  "machine-0",
+			Message: "no matching tools available",
+			Time:    at.Add(time.Minute),
+		}},
+	}
+}
+
+func (s *ShowEventsSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"foo"},
+		err:  `unrecognized args: \["foo"\]`,
+	}, {
+		args: []string{"-n", "0"},
+		err:  "invalid number of events 0",
+	}, {
+		args: []string{"--entity", "foo bar"},
+		err:  `entity "foo bar" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(model.NewShowEventsCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ShowEventsSuite) TestTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewShowEventsCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                 KIND             ENTITY  ACTOR        MESSAGE\n"+
+		"2016-10-01 12:00:00  deploy           mysql   admin@local  deployed cs:mysql-1 with 1 unit\n"+
+		"2016-10-01 12:01:00  machine-failure  0                    no matching tools available\n"+
+		"\n",
+	)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelEvents", []interface{}{params.ModelEventsFilter{Limit: 100}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ShowEventsSuite) TestYAML(c *gc.C) {
+	s.fake.events = s.fake.events[:1]
+	ctx, err := testing.RunCommand(c, model.NewShowEventsCommandForTest(s.fake), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- time: 2016-10-01T12:00:00Z
+  kind: deploy
+  entity: application-mysql
+  actor: user-admin@local
+  message: deployed cs:mysql-1 with 1 unit
+`[1:])
+}
+
+func (s *ShowEventsSuite) TestFilter(c *gc.C) {
+	_, err := testing.RunCommand(c, model.NewShowEventsCommandForTest(s.fake),
+		"--from", "2016-10-01T09:00:00Z",
+		"--to", "2016-10-01T10:00:00Z",
+		"--kind", "deploy, upgrade-charm",
+		"--entity", "mysql/0",
+		"-n", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2016, 10, 1, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2016, 10, 1, 10, 0, 0, 0, time.UTC)
+	s.fake.CheckCall(c, 0, "ModelEvents", params.ModelEventsFilter{
+		From:   &from,
+		To:     &to,
+		Kinds:  []string{"deploy", "upgrade-charm"},
+		Entity: "unit-mysql-0",
+		Limit:  5,
+	})
+}
+
+func (s *ShowEventsSuite) TestFromDuration(c *gc.C) {
+	before := time.Now()
+	_, err := testing.RunCommand(c, model.NewShowEventsCommandForTest(s.fake), "--from", "2h", "--entity", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "ModelEvents", "Close")
+	filter := s.fake.Calls()[0].Args[0].(params.ModelEventsFilter)
+	c.Assert(filter.From, gc.NotNil)
+	c.Assert(filter.From.Before(before.Add(-2*time.Hour)), jc.IsFalse)
+	c.Assert(filter.From.After(time.Now().Add(-2*time.Hour)), jc.IsFalse)
+	c.Assert(filter.To, gc.IsNil)
+	c.Assert(filter.Entity, gc.Equals, "machine-0")
+}
+
+func (s *ShowEventsSuite) TestInvalidTime(c *gc.C) {
+	_, err := testing.RunCommand(c, model.NewShowEventsCommandForTest(s.fake), "--to", "yesterday")
+	c.Assert(err, gc.ErrorMatches, `invalid --to value "yesterday", expected a time or duration`)
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *ShowEventsSuite) TestNoEvents(c *gc.C) {
+	s.fake.events = nil
+	ctx, err := testing.RunCommand(c, model.NewShowEventsCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No events to display.\n")
+}
+
+type fakeShowEventsAPI struct {
+	gitjujutesting.Stub
+	events []params.ModelEvent
+}
+
+func (f *fakeShowEventsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeShowEventsAPI) ModelEvents(filter params.ModelEventsFilter) ([]params.ModelEvent, error) {
+	f.MethodCall(f, "ModelEvents", filter)
+	return f.events, f.NextErr()
+}
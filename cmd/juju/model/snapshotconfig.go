@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const snapshotConfigHelpDoc = `
+Records the current configuration of the model, and of each of its
+applications, as a snapshot with the given name. The configuration may
+later be rolled back to the snapshot with restore-config.
+
+A model keeps a limited number of snapshots. When the limit is reached,
+the oldest snapshot is removed as a new one is created.
+
+Examples:
+
+    juju snapshot-config before-proxy-change
+
+See also:
+    config-snapshots
+    restore-config
+    remove-config-snapshot
+`
+
+// NewSnapshotConfigCommand returns a command which records the config of
+// a model and its applications as a named snapshot.
+func NewSnapshotConfigCommand() cmd.Command {
+	return modelcmd.Wrap(&snapshotConfigCommand{})
+}
+
+type snapshotConfigCommand struct {
+	modelcmd.ModelCommandBase
+	api  SnapshotConfigAPI
+	name string
+}
+
+// SnapshotConfigAPI defines the API methods that the snapshot-config
+// command uses.
+type SnapshotConfigAPI interface {
+	Close() error
+	CreateConfigSnapshot(name string) error
+}
+
+// Info implements Command.Info.
+func (c *snapshotConfigCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "snapshot-config",
+		Args:    "<snapshot name>",
+		Purpose: "Records the configuration of a model and its applications.",
+		Doc:     snapshotConfigHelpDoc[1:],
+	}
+}
+
+// Init implements Command.Init.
+func (c *snapshotConfigCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no snapshot name specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *snapshotConfigCommand) getAPI() (SnapshotConfigAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelconfig.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *snapshotConfigCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.CreateConfigSnapshot(c.name); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Created config snapshot %q.", c.name)
+	return nil
+}
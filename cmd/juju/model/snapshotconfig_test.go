@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type SnapshotConfigSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeConfigSnapshotsAPI
+}
+
+var _ = gc.Suite(&SnapshotConfigSuite{})
+
+func (s *SnapshotConfigSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeConfigSnapshotsAPI{}
+}
+
+func (s *SnapshotConfigSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no snapshot name specified",
+	}, {
+		args: []string{"foo", "bar"},
+		err:  `unrecognized args: \["bar"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(model.NewSnapshotConfigCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SnapshotConfigSuite) TestSnapshotConfig(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewSnapshotConfigCommandForTest(s.fake), "before-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Created config snapshot \"before-proxy\".\n")
+	s.fake.CheckCallNames(c, "CreateConfigSnapshot", "Close")
+	s.fake.CheckCall(c, 0, "CreateConfigSnapshot", "before-proxy")
+}
+
+func (s *SnapshotConfigSuite) TestSnapshotConfigError(c *gc.C) {
+	s.fake.SetErrors(errors.AlreadyExistsf("snapshot %q", "before-proxy"))
+	_, err := testing.RunCommand(c, model.NewSnapshotConfigCommandForTest(s.fake), "before-proxy")
+	c.Assert(err, gc.ErrorMatches, `snapshot "before-proxy" already exists`)
+}
+
+func (s *SnapshotConfigSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockedError"))
+	_, err := testing.RunCommand(c, model.NewSnapshotConfigCommandForTest(s.fake), "before-proxy")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
@@ -0,0 +1,49 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/juju/utils/clock"
+)
+
+// jsonEvent is an Event as reported by a jsonReporter.
+type jsonEvent struct {
+	Event
+	Time time.Time `json:"time"`
+}
+
+// jsonReporter reports progress as a stream of JSON encoded events,
+// one per line, for consumption by scripts.
+type jsonReporter struct {
+	encoder  *json.Encoder
+	clock    clock.Clock
+	throttle throttle
+}
+
+func newJSONReporter(w io.Writer, clock clock.Clock) *jsonReporter {
+	return &jsonReporter{
+		encoder:  json.NewEncoder(w),
+		clock:    clock,
+		throttle: throttle{clock: clock},
+	}
+}
+
+// Report is part of the Reporter interface.
+func (r *jsonReporter) Report(ev Event) {
+	if !r.throttle.allow(ev) {
+		return
+	}
+	// Progress reporting is best effort, so a failure to write an
+	// event must not fail the operation being reported on.
+	_ = r.encoder.Encode(jsonEvent{Event: ev, Time: r.clock.Now().UTC()})
+}
+
+// Close is part of the Reporter interface.
+func (r *jsonReporter) Close() error {
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package progress_test
+
+import (
+	"bytes"
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/progress"
+	"github.com/juju/juju/testing"
+)
+
+type JSONSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&JSONSuite{})
+
+func (s *JSONSuite) TestReport(c *gc.C) {
+	var buf bytes.Buffer
+	clock := testing.NewClock(time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC))
+	rep := progress.ModeJSON.NewReporter(&buf, clock)
+	rep.Report(progress.Event{Operation: "migrating", Stage: "QUIESCE", Current: 1, Total: 8})
+	rep.Report(progress.Event{Operation: "migrating", Stage: "QUIESCE", Current: 1, Total: 8})
+	clock.Advance(time.Minute)
+	rep.Report(progress.Event{Operation: "migrating", Stage: "DONE", Current: 8, Total: 8, Done: true})
+	c.Assert(rep.Close(), jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, ""+
+		`{"operation":"migrating","stage":"QUIESCE","current":1,"total":8,"time":"2016-10-01T00:00:00Z"}`+"\n"+
+		`{"operation":"migrating","stage":"DONE","current":8,"total":8,"done":true,"time":"2016-10-01T00:01:00Z"}`+"\n",
+	)
+}
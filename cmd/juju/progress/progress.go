@@ -0,0 +1,192 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package progress provides the reporting of progress of long running
+// CLI operations, such as uploads, downloads and model migrations, as
+// a spinner, a progress bar or a stream of JSON events.
+package progress
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// redrawInterval is the minimum time between successive reports of
+// the same stage of an operation.
+const redrawInterval = 100 * time.Millisecond
+
+// Unit describes what the counts of an Event measure.
+type Unit string
+
+const (
+	// UnitSteps indicates that an event counts discrete steps.
+	UnitSteps Unit = ""
+
+	// UnitBytes indicates that an event counts bytes transferred.
+	UnitBytes Unit = "bytes"
+)
+
+// Event describes the progress of an operation at a point in time.
+type Event struct {
+	// Operation is a short description of the operation in
+	// progress, e.g. "uploading charm".
+	Operation string `json:"operation"`
+
+	// Stage is the name of the stage of the operation in progress,
+	// if the operation has distinct stages.
+	Stage string `json:"stage,omitempty"`
+
+	// Message holds any human readable detail of the stage.
+	Message string `json:"message,omitempty"`
+
+	// Current and Total hold the work done so far and the total
+	// amount of work to do, as measured by Unit. Total is zero if
+	// the amount of work is not known.
+	Current int64 `json:"current"`
+	Total   int64 `json:"total,omitempty"`
+	Unit    Unit  `json:"unit,omitempty"`
+
+	// Done is set when the operation has completed.
+	Done bool `json:"done,omitempty"`
+}
+
+// Reporter is implemented by types which report the progress of an
+// operation to the user.
+type Reporter interface {
+	// Report reports the given progress event. Reporters may drop
+	// events which arrive faster than they can usefully be shown,
+	// but never drop events which change the stage of, or complete,
+	// an operation.
+	Report(ev Event)
+
+	// Close finishes any report in progress.
+	Close() error
+}
+
+// Mode is the way in which progress is reported.
+type Mode string
+
+const (
+	// ModeAuto reports progress as a bar or a spinner if the output
+	// is a terminal, and not at all otherwise.
+	ModeAuto Mode = "auto"
+
+	// ModeSpinner reports progress with a spinner.
+	ModeSpinner Mode = "spinner"
+
+	// ModeBar reports progress with a bar and an estimated time to
+	// completion if the total work is known, and with a spinner
+	// otherwise.
+	ModeBar Mode = "bar"
+
+	// ModeJSON reports progress as a stream of JSON encoded events,
+	// one per line.
+	ModeJSON Mode = "json"
+
+	// ModeNone does not report progress.
+	ModeNone Mode = "none"
+)
+
+var modes = []Mode{ModeAuto, ModeSpinner, ModeBar, ModeJSON, ModeNone}
+
+// AddFlag adds the --progress flag, which sets the given mode, to the
+// flag set.
+func AddFlag(f *gnuflag.FlagSet, mode *Mode) {
+	f.Var(mode, "progress", "How to report progress: auto, spinner, bar, json or none")
+}
+
+// String implements gnuflag.Value.
+func (m *Mode) String() string {
+	if *m == "" {
+		return string(ModeAuto)
+	}
+	return string(*m)
+}
+
+// Set implements gnuflag.Value.
+func (m *Mode) Set(value string) error {
+	for _, mode := range modes {
+		if Mode(value) == mode {
+			*m = mode
+			return nil
+		}
+	}
+	return errors.NotValidf("progress mode %q", value)
+}
+
+// NewReporter returns a Reporter which reports progress to the given
+// writer in the mode.
+func (m Mode) NewReporter(w io.Writer, clock clock.Clock) Reporter {
+	switch m {
+	case ModeSpinner:
+		return newTextReporter(w, clock, false)
+	case ModeBar:
+		return newTextReporter(w, clock, true)
+	case ModeJSON:
+		return newJSONReporter(w, clock)
+	case ModeNone:
+		return nopReporter{}
+	}
+	if !isTerminal(w) {
+		return nopReporter{}
+	}
+	return newTextReporter(w, clock, true)
+}
+
+// Func returns a function which reports the progress of a transfer
+// of bytes for the operation, suitable for use as an upload progress
+// callback.
+func Func(rep Reporter, operation string) func(sent, total int64) {
+	return func(sent, total int64) {
+		rep.Report(Event{
+			Operation: operation,
+			Current:   sent,
+			Total:     total,
+			Unit:      UnitBytes,
+		})
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
+
+// nopReporter is a Reporter which reports nothing.
+type nopReporter struct{}
+
+// Report is part of the Reporter interface.
+func (nopReporter) Report(Event) {}
+
+// Close is part of the Reporter interface.
+func (nopReporter) Close() error { return nil }
+
+// throttle decides which of a sequence of events are worth reporting.
+type throttle struct {
+	clock clock.Clock
+	last  Event
+	when  time.Time
+	seen  bool
+}
+
+// allow reports whether the event should be reported, recording it as
+// the last reported event if so.
+func (t *throttle) allow(ev Event) bool {
+	now := t.clock.Now()
+	changed := !t.seen || ev.Done ||
+		ev.Total > 0 && ev.Current >= ev.Total ||
+		ev.Operation != t.last.Operation ||
+		ev.Stage != t.last.Stage ||
+		ev.Message != t.last.Message
+	if !changed && now.Sub(t.when) < redrawInterval {
+		return false
+	}
+	t.last, t.when, t.seen = ev, now, true
+	return true
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package progress_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/gnuflag"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/progress"
+	"github.com/juju/juju/testing"
+)
+
+type ModeSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ModeSuite{})
+
+func (s *ModeSuite) TestFlag(c *gc.C) {
+	var mode progress.Mode
+	f := gnuflag.NewFlagSet("test", gnuflag.ContinueOnError)
+	progress.AddFlag(f, &mode)
+	c.Assert(mode.String(), gc.Equals, "auto")
+	err := f.Parse(true, []string{"--progress", "json"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mode, gc.Equals, progress.ModeJSON)
+}
+
+func (s *ModeSuite) TestFlagInvalid(c *gc.C) {
+	var mode progress.Mode
+	err := mode.Set("dots")
+	c.Assert(err, gc.ErrorMatches, `progress mode "dots" not valid`)
+	c.Assert(mode, gc.Equals, progress.Mode(""))
+}
+
+func (s *ModeSuite) TestAutoNotTerminal(c *gc.C) {
+	var buf bytes.Buffer
+	clock := testing.NewClock(time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC))
+	for _, mode := range []progress.Mode{"", progress.ModeAuto, progress.ModeNone} {
+		rep := mode.NewReporter(&buf, clock)
+		rep.Report(progress.Event{Operation: "uploading", Current: 10, Total: 20})
+		rep.Report(progress.Event{Operation: "uploading", Done: true})
+		c.Assert(rep.Close(), jc.ErrorIsNil)
+	}
+	c.Assert(buf.String(), gc.Equals, "")
+}
+
+func (s *ModeSuite) TestFunc(c *gc.C) {
+	var buf bytes.Buffer
+	clock := testing.NewClock(time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC))
+	rep := progress.ModeJSON.NewReporter(&buf, clock)
+	progress.Func(rep, "uploading charm")(512, 1024)
+	c.Assert(buf.String(), gc.Equals, `{"operation":"uploading charm","current":512,"total":1024,"unit":"bytes","time":"2016-10-01T00:00:00Z"}`+"\n")
+}
@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package progress
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+)
+
+// Reader wraps an io.Reader, reporting the progress of the operation
+// as the content is read.
+type Reader struct {
+	r        io.Reader
+	reporter Reporter
+	event    Event
+}
+
+// NewReader returns a Reader which reports the progress of reading
+// the given content, of the given total size in bytes, as the
+// operation. A total of zero means that the size is not known.
+func NewReader(r io.Reader, total int64, operation string, reporter Reporter) *Reader {
+	return &Reader{
+		r:        r,
+		reporter: reporter,
+		event: Event{
+			Operation: operation,
+			Total:     total,
+			Unit:      UnitBytes,
+		},
+	}
+}
+
+// Read is part of the io.Reader interface.
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.event.Current += int64(n)
+		r.reporter.Report(r.event)
+	}
+	return n, err
+}
+
+// Seek is part of the io.Seeker interface. It fails if the wrapped
+// reader is not also an io.Seeker.
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := r.r.(io.Seeker)
+	if !ok {
+		return 0, errors.NotSupportedf("seeking %T", r.r)
+	}
+	pos, err := seeker.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+	r.event.Current = pos
+	return pos, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package progress_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/progress"
+)
+
+type ReaderSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ReaderSuite{})
+
+func (s *ReaderSuite) TestRead(c *gc.C) {
+	var rep recordingReporter
+	r := progress.NewReader(strings.NewReader("hello world"), 11, "uploading", &rep)
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "hello world")
+	c.Assert(rep.events, gc.Not(gc.HasLen), 0)
+	c.Assert(rep.events[len(rep.events)-1], jc.DeepEquals, progress.Event{
+		Operation: "uploading",
+		Current:   11,
+		Total:     11,
+		Unit:      progress.UnitBytes,
+	})
+}
+
+func (s *ReaderSuite) TestSeek(c *gc.C) {
+	var rep recordingReporter
+	r := progress.NewReader(strings.NewReader("hello world"), 11, "uploading", &rep)
+	pos, err := r.Seek(6, os.SEEK_SET)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pos, gc.Equals, int64(6))
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "world")
+	c.Assert(rep.events[len(rep.events)-1].Current, gc.Equals, int64(11))
+}
+
+func (s *ReaderSuite) TestSeekNotSupported(c *gc.C) {
+	var rep recordingReporter
+	r := progress.NewReader(bytes.NewBufferString("hello"), 5, "uploading", &rep)
+	_, err := r.Seek(0, os.SEEK_SET)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type recordingReporter struct {
+	events []progress.Event
+}
+
+func (r *recordingReporter) Report(ev progress.Event) {
+	r.events = append(r.events, ev)
+}
+
+func (r *recordingReporter) Close() error {
+	return nil
+}
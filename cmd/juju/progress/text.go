@@ -0,0 +1,166 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/utils/clock"
+)
+
+// barWidth is the number of characters in a progress bar.
+const barWidth = 20
+
+// spinnerFrames holds the characters drawn in turn by a spinner.
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// textReporter reports progress on a single line which is redrawn
+// in place, as a spinner or as a bar.
+type textReporter struct {
+	out      io.Writer
+	clock    clock.Clock
+	bar      bool
+	throttle throttle
+
+	frame   int
+	lineLen int
+	pending bool
+	started time.Time
+}
+
+func newTextReporter(w io.Writer, clock clock.Clock, bar bool) *textReporter {
+	return &textReporter{
+		out:      w,
+		clock:    clock,
+		bar:      bar,
+		throttle: throttle{clock: clock},
+	}
+}
+
+// Report is part of the Reporter interface.
+func (r *textReporter) Report(ev Event) {
+	previous, seen := r.throttle.last, r.throttle.seen
+	if !r.throttle.allow(ev) {
+		return
+	}
+	if !seen || ev.Operation != previous.Operation {
+		// Leave the report of any previous operation
+		// on its own line.
+		r.finishLine()
+		r.started = r.clock.Now()
+	}
+	var line string
+	if r.bar && ev.Total > 0 {
+		line = r.formatBar(ev)
+	} else {
+		line = r.formatSpinner(ev)
+	}
+	padding := ""
+	if n := r.lineLen - len(line); n > 0 {
+		padding = strings.Repeat(" ", n)
+	}
+	fmt.Fprintf(r.out, "\r%s%s", line, padding)
+	r.lineLen = len(line)
+	r.pending = true
+	if ev.Done {
+		r.finishLine()
+	}
+}
+
+// Close is part of the Reporter interface.
+func (r *textReporter) Close() error {
+	r.finishLine()
+	return nil
+}
+
+func (r *textReporter) finishLine() {
+	if r.pending {
+		fmt.Fprintln(r.out)
+	}
+	r.pending = false
+	r.lineLen = 0
+}
+
+func (r *textReporter) formatSpinner(ev Event) string {
+	prefix := ""
+	if !ev.Done {
+		prefix = spinnerFrames[r.frame%len(spinnerFrames)] + " "
+		r.frame++
+	}
+	line := prefix + describe(ev)
+	if ev.Current > 0 || ev.Total > 0 {
+		line += " (" + formatCount(ev) + ")"
+	}
+	if ev.Done {
+		line += " done"
+	}
+	return line
+}
+
+func (r *textReporter) formatBar(ev Event) string {
+	current := ev.Current
+	if current > ev.Total {
+		current = ev.Total
+	}
+	filled := int(current * barWidth / ev.Total)
+	line := fmt.Sprintf("%s [%s%s] %3d%% %s",
+		describe(ev),
+		strings.Repeat("#", filled),
+		strings.Repeat(".", barWidth-filled),
+		current*100/ev.Total,
+		formatCount(ev),
+	)
+	if ev.Done {
+		return line + " done"
+	}
+	if eta, ok := r.eta(current, ev.Total); ok {
+		line += " ETA " + eta.String()
+	}
+	return line
+}
+
+// eta estimates the time remaining until the operation completes,
+// assuming that the remaining work proceeds at the rate seen so far.
+func (r *textReporter) eta(current, total int64) (time.Duration, bool) {
+	elapsed := r.clock.Now().Sub(r.started)
+	if current <= 0 || elapsed <= 0 {
+		return 0, false
+	}
+	remaining := time.Duration(float64(elapsed) * float64(total-current) / float64(current))
+	return remaining - remaining%time.Second, true
+}
+
+// describe returns a description of the operation and stage of the
+// event.
+func describe(ev Event) string {
+	text := ev.Operation
+	if ev.Stage != "" {
+		text += ": " + ev.Stage
+	}
+	if ev.Message != "" {
+		text += " - " + ev.Message
+	}
+	return text
+}
+
+// formatCount returns the work done so far, and the total work if it
+// is known, in the units of the event.
+func formatCount(ev Event) string {
+	format := func(n int64) string {
+		return fmt.Sprint(n)
+	}
+	if ev.Unit == UnitBytes {
+		format = func(n int64) string {
+			return humanize.IBytes(uint64(n))
+		}
+	}
+	if ev.Total > 0 {
+		return format(ev.Current) + "/" + format(ev.Total)
+	}
+	return format(ev.Current)
+}
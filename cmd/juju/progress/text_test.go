@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package progress_test
+
+import (
+	"bytes"
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/progress"
+	"github.com/juju/juju/testing"
+)
+
+type TextSuite struct {
+	jujutesting.IsolationSuite
+	clock *testing.Clock
+	buf   bytes.Buffer
+}
+
+var _ = gc.Suite(&TextSuite{})
+
+func (s *TextSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC))
+	s.buf.Reset()
+}
+
+func (s *TextSuite) TestSpinner(c *gc.C) {
+	rep := progress.ModeSpinner.NewReporter(&s.buf, s.clock)
+	rep.Report(progress.Event{Operation: "migrating", Stage: "QUIESCE"})
+	rep.Report(progress.Event{Operation: "migrating", Stage: "IMPORT", Message: "uploading model"})
+	rep.Report(progress.Event{Operation: "migrating", Stage: "DONE", Done: true})
+	c.Assert(rep.Close(), jc.ErrorIsNil)
+	c.Assert(s.buf.String(), gc.Equals, ""+
+		"\r| migrating: QUIESCE"+
+		"\r/ migrating: IMPORT - uploading model"+
+		"\rmigrating: DONE done                 \n",
+	)
+}
+
+func (s *TextSuite) TestSpinnerThrottled(c *gc.C) {
+	rep := progress.ModeSpinner.NewReporter(&s.buf, s.clock)
+	rep.Report(progress.Event{Operation: "downloading", Current: 1024, Unit: progress.UnitBytes})
+	rep.Report(progress.Event{Operation: "downloading", Current: 2048, Unit: progress.UnitBytes})
+	s.clock.Advance(time.Second)
+	rep.Report(progress.Event{Operation: "downloading", Current: 4096, Unit: progress.UnitBytes})
+	c.Assert(rep.Close(), jc.ErrorIsNil)
+	c.Assert(s.buf.String(), gc.Equals, ""+
+		"\r| downloading (1.0KiB)"+
+		"\r/ downloading (4.0KiB)\n",
+	)
+}
+
+func (s *TextSuite) TestBar(c *gc.C) {
+	rep := progress.ModeBar.NewReporter(&s.buf, s.clock)
+	rep.Report(progress.Event{Operation: "uploading", Total: 4096, Unit: progress.UnitBytes})
+	s.clock.Advance(10 * time.Second)
+	rep.Report(progress.Event{Operation: "uploading", Current: 1024, Total: 4096, Unit: progress.UnitBytes})
+	s.clock.Advance(10 * time.Second)
+	rep.Report(progress.Event{Operation: "uploading", Current: 4096, Total: 4096, Unit: progress.UnitBytes})
+	c.Assert(rep.Close(), jc.ErrorIsNil)
+	c.Assert(s.buf.String(), gc.Equals, ""+
+		"\ruploading [....................]   0% 0B/4.0KiB"+
+		"\ruploading [#####...............]  25% 1.0KiB/4.0KiB ETA 30s"+
+		"\ruploading [####################] 100% 4.0KiB/4.0KiB ETA 0s \n",
+	)
+}
+
+func (s *TextSuite) TestBarUnknownTotal(c *gc.C) {
+	rep := progress.ModeBar.NewReporter(&s.buf, s.clock)
+	rep.Report(progress.Event{Operation: "creating backup"})
+	rep.Report(progress.Event{Operation: "downloading", Current: 3, Total: 4})
+	c.Assert(rep.Close(), jc.ErrorIsNil)
+	c.Assert(s.buf.String(), gc.Equals, ""+
+		"\r| creating backup\n"+
+		"\rdownloading [###############.....]  75% 3/4\n",
+	)
+}
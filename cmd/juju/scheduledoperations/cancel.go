@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/scheduledoperations"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const cancelHelpDoc = `
+Cancels scheduled operations, so that they do not run again. An
+operation which is already running is not interrupted.
+
+Examples:
+
+    juju cancel-scheduled-operation 3
+    juju cancel-scheduled-operation 3 4
+
+See also:
+    schedule-operation
+    scheduled-operations
+`
+
+// NewCancelCommand returns a command which cancels scheduled
+// operations.
+func NewCancelCommand() cmd.Command {
+	return modelcmd.Wrap(&cancelCommand{})
+}
+
+type cancelCommand struct {
+	modelcmd.ModelCommandBase
+	api CancelAPI
+	ids []string
+}
+
+// CancelAPI defines the API methods that the
+// cancel-scheduled-operation command uses.
+type CancelAPI interface {
+	Close() error
+	CancelScheduledOperations(ids ...string) error
+}
+
+// Info implements Command.Info.
+func (c *cancelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cancel-scheduled-operation",
+		Args:    "<id> [<id>...]",
+		Purpose: "Cancels scheduled operations.",
+		Doc:     cancelHelpDoc[1:],
+	}
+}
+
+// Init implements Command.Init.
+func (c *cancelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no scheduled operation ids specified")
+	}
+	c.ids = args
+	return nil
+}
+
+func (c *cancelCommand) getAPI() (CancelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return scheduledoperations.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *cancelCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return client.CancelScheduledOperations(c.ids...)
+}
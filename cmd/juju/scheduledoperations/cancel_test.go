@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/scheduledoperations"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeScheduledOperationsAPI
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeScheduledOperationsAPI{}
+}
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(scheduledoperations.NewCancelCommandForTest(s.fake), nil)
+	c.Assert(err, gc.ErrorMatches, "no scheduled operation ids specified")
+}
+
+func (s *CancelSuite) TestCancel(c *gc.C) {
+	_, err := testing.RunCommand(c, scheduledoperations.NewCancelCommandForTest(s.fake), "3", "4")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "CancelScheduledOperations", "Close")
+	s.fake.CheckCall(c, 0, "CancelScheduledOperations", []string{"3", "4"})
+}
+
+func (s *CancelSuite) TestCancelError(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Message: "scheduled operation 9 not found"})
+	_, err := testing.RunCommand(c, scheduledoperations.NewCancelCommandForTest(s.fake), "9")
+	c.Assert(err, gc.ErrorMatches, "scheduled operation 9 not found")
+}
@@ -0,0 +1,29 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewScheduleCommandForTest returns a schedule-operation command with
+// the api and clock provided as specified.
+func NewScheduleCommandForTest(api ScheduleAPI, clock clock.Clock) cmd.Command {
+	return modelcmd.Wrap(&scheduleCommand{api: api, clock: clock})
+}
+
+// NewListCommandForTest returns a scheduled-operations command with
+// the api provided as specified.
+func NewListCommandForTest(api ListAPI) cmd.Command {
+	return modelcmd.Wrap(&listCommand{api: api})
+}
+
+// NewCancelCommandForTest returns a cancel-scheduled-operation command
+// with the api provided as specified.
+func NewCancelCommandForTest(api CancelAPI) cmd.Command {
+	return modelcmd.Wrap(&cancelCommand{api: api})
+}
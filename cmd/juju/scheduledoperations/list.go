@@ -0,0 +1,176 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/scheduledoperations"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const listHelpDoc = `
+Lists the operations scheduled on the applications of the model, in the
+order they are due. A one-off operation is removed once it has run
+successfully; one which failed is listed with its error until it is
+cancelled. A repeating operation is listed with the time it next runs.
+
+Examples:
+
+    juju scheduled-operations
+    juju scheduled-operations --format yaml
+
+See also:
+    schedule-operation
+    cancel-scheduled-operation
+`
+
+// NewListCommand returns a command which lists the scheduled
+// operations of a model.
+func NewListCommand() cmd.Command {
+	return modelcmd.Wrap(&listCommand{})
+}
+
+type listCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ListAPI
+}
+
+// ListAPI defines the API methods that the scheduled-operations
+// command uses.
+type ListAPI interface {
+	Close() error
+	ListScheduledOperations() ([]params.ScheduledOperation, error)
+}
+
+// operationInfo holds the details of a scheduled operation, for
+// output.
+type operationInfo struct {
+	Id          string     `yaml:"id" json:"id"`
+	Kind        string     `yaml:"kind" json:"kind"`
+	Application string     `yaml:"application" json:"application"`
+	CharmURL    string     `yaml:"charm-url,omitempty" json:"charm-url,omitempty"`
+	Units       *int       `yaml:"units,omitempty" json:"units,omitempty"`
+	Due         time.Time  `yaml:"due" json:"due"`
+	Every       string     `yaml:"every,omitempty" json:"every,omitempty"`
+	Status      string     `yaml:"status" json:"status"`
+	LastRun     *time.Time `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+	LastError   string     `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *listCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "scheduled-operations",
+		Purpose: "Lists the scheduled operations of a model.",
+		Doc:     listHelpDoc[1:],
+		Aliases: []string{"list-scheduled-operations"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatTabular,
+	})
+}
+
+func (c *listCommand) getAPI() (ListAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return scheduledoperations.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ops, err := client.ListScheduledOperations()
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No scheduled operations to display.")
+		return nil
+	}
+	infos := make([]operationInfo, len(ops))
+	for i, op := range ops {
+		infos[i] = operationInfo{
+			Id:          op.Id,
+			Kind:        op.Kind,
+			Application: op.Application,
+			CharmURL:    op.CharmURL,
+			Due:         op.Due.UTC(),
+			Status:      op.Status,
+			LastError:   op.LastError,
+		}
+		if op.Kind == "scale-application" {
+			units := op.Units
+			infos[i].Units = &units
+		}
+		if op.Every > 0 {
+			infos[i].Every = op.Every.String()
+		}
+		if op.LastRun != nil {
+			lastRun := op.LastRun.UTC()
+			infos[i].LastRun = &lastRun
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+// formatTabular takes an interface{} to adhere to the cmd.Formatter
+// interface.
+func formatTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.([]operationInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "ID\tKIND\tAPPLICATION\tTARGET\tDUE\tEVERY\tSTATUS\tMESSAGE\n")
+	for _, info := range infos {
+		target := info.CharmURL
+		if info.Units != nil {
+			target = strconv.Itoa(*info.Units) + " units"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			info.Id, info.Kind, info.Application, target,
+			common.FormatTime(&info.Due, true), info.Every,
+			info.Status, info.LastError,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
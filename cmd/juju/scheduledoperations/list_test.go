@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/scheduledoperations"
+	"github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeScheduledOperationsAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	lastRun := time.Date(2016, 10, 29, 0, 0, 0, 0, time.UTC)
+	s.fake = &fakeScheduledOperationsAPI{
+		ops: []params.ScheduledOperation{{
+			Id:          "3",
+			Kind:        "upgrade-charm",
+			Application: "mysql",
+			CharmURL:    "cs:xenial/mysql-57",
+			Due:         time.Date(2016, 11, 5, 2, 0, 0, 0, time.UTC),
+			Status:      "pending",
+		}, {
+			Id:          "4",
+			Kind:        "scale-application",
+			Application: "wordpress",
+			Units:       0,
+			Due:         time.Date(2016, 11, 5, 23, 0, 0, 0, time.UTC),
+			Every:       168 * time.Hour,
+			Status:      "pending",
+			LastRun:     &lastRun,
+			LastError:   "boom",
+		}},
+	}
+}
+
+func (s *ListSuite) TestTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, scheduledoperations.NewListCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"ID  KIND               APPLICATION  TARGET              DUE                   EVERY     STATUS   MESSAGE\n"+
+		"3   upgrade-charm      mysql        cs:xenial/mysql-57  2016-11-05 02:00:00Z            pending  \n"+
+		"4   scale-application  wordpress    0 units             2016-11-05 23:00:00Z  168h0m0s  pending  boom\n"+
+		"\n",
+	)
+	s.fake.CheckCallNames(c, "ListScheduledOperations", "Close")
+}
+
+func (s *ListSuite) TestYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, scheduledoperations.NewListCommandForTest(s.fake), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- id: "3"
+  kind: upgrade-charm
+  application: mysql
+  charm-url: cs:xenial/mysql-57
+  due: 2016-11-05T02:00:00Z
+  status: pending
+- id: "4"
+  kind: scale-application
+  application: wordpress
+  units: 0
+  due: 2016-11-05T23:00:00Z
+  every: 168h0m0s
+  status: pending
+  last-run: 2016-10-29T00:00:00Z
+  last-error: boom
+`[1:])
+}
+
+func (s *ListSuite) TestNoOperations(c *gc.C) {
+	s.fake.ops = nil
+	ctx, err := testing.RunCommand(c, scheduledoperations.NewListCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No scheduled operations to display.\n")
+}
+
+type fakeScheduledOperationsAPI struct {
+	gitjujutesting.Stub
+	id  string
+	ops []params.ScheduledOperation
+}
+
+func (f *fakeScheduledOperationsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeScheduledOperationsAPI) AddCharm(curl *charm.URL, channel csparams.Channel) error {
+	f.MethodCall(f, "AddCharm", curl, channel)
+	return f.NextErr()
+}
+
+func (f *fakeScheduledOperationsAPI) AddScheduledOperation(op params.ScheduledOperation) (string, error) {
+	f.MethodCall(f, "AddScheduledOperation", op)
+	return f.id, f.NextErr()
+}
+
+func (f *fakeScheduledOperationsAPI) ListScheduledOperations() ([]params.ScheduledOperation, error) {
+	f.MethodCall(f, "ListScheduledOperations")
+	return f.ops, f.NextErr()
+}
+
+func (f *fakeScheduledOperationsAPI) CancelScheduledOperations(ids ...string) error {
+	f.MethodCall(f, "CancelScheduledOperations", ids)
+	return f.NextErr()
+}
@@ -0,0 +1,217 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/scheduledoperations"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const scheduleHelpDoc = `
+Schedules an operation on an application, for the controller to run at
+the time given with --at. The time is either an RFC3339 timestamp, or a
+local time of day as HH:MM, meaning the next time the clock shows it.
+With --every, the operation repeats at the given interval until it is
+cancelled; otherwise it runs once.
+
+The kinds of operation are:
+
+    upgrade-charm <application> <charm-url>
+        upgrades the application to the given revision of a charm.
+        Charm store charms are added to the model when the operation
+        is scheduled; local charms must already have been added.
+
+    scale-application <application> <units>
+        adds units, each on a new machine, or removes the most
+        recently added units, until the application has the given
+        number of units.
+
+An operation which fails is kept, with its error, until it is
+cancelled; a repeating operation is retried at its next time.
+
+Examples:
+
+    juju schedule-operation upgrade-charm mysql cs:xenial/mysql-57 --at 02:00
+    juju schedule-operation scale-application wordpress 2 \
+        --at 2016-11-05T00:00:00Z --every 168h
+
+See also:
+    scheduled-operations
+    cancel-scheduled-operation
+`
+
+// NewScheduleCommand returns a command which schedules an operation on
+// an application.
+func NewScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduleCommand{})
+}
+
+type scheduleCommand struct {
+	modelcmd.ModelCommandBase
+	api   ScheduleAPI
+	clock clock.Clock
+	at    string
+	op    params.ScheduledOperation
+}
+
+// ScheduleAPI defines the API methods that the schedule-operation
+// command uses.
+type ScheduleAPI interface {
+	Close() error
+	AddCharm(*charm.URL, csparams.Channel) error
+	AddScheduledOperation(params.ScheduledOperation) (string, error)
+}
+
+// Info implements Command.Info.
+func (c *scheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "schedule-operation",
+		Args:    "<kind> <application> (<charm-url> | <units>) --at <time> [--every <duration>]",
+		Purpose: "Schedules an operation on an application.",
+		Doc:     scheduleHelpDoc[1:],
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *scheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.at, "at", "", "when to run the operation, as RFC3339 or HH:MM")
+	f.DurationVar(&c.op.Every, "every", 0, "how often to repeat the operation")
+}
+
+// Init implements Command.Init.
+func (c *scheduleCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no operation kind specified")
+	case 1:
+		return errors.New("no application name specified")
+	}
+	c.op.Kind, c.op.Application = args[0], args[1]
+	switch c.op.Kind {
+	case "upgrade-charm":
+		if len(args) < 3 {
+			return errors.New("no charm URL specified")
+		}
+		curl, err := charm.ParseURL(args[2])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if curl.Revision < 0 {
+			return errors.Errorf("charm URL %q must include a revision", args[2])
+		}
+		c.op.CharmURL = curl.String()
+	case "scale-application":
+		if len(args) < 3 {
+			return errors.New("no unit count specified")
+		}
+		units, err := strconv.Atoi(args[2])
+		if err != nil || units < 0 {
+			return errors.Errorf("unit count %q not valid", args[2])
+		}
+		c.op.Units = units
+	default:
+		return errors.Errorf("operation kind %q not valid", c.op.Kind)
+	}
+	if err := cmd.CheckEmpty(args[3:]); err != nil {
+		return err
+	}
+	if c.at == "" {
+		return errors.New("no time specified with --at")
+	}
+	if c.op.Every < 0 {
+		return errors.Errorf("negative interval %v not valid", c.op.Every)
+	}
+	return nil
+}
+
+// parseAt returns the time given by at, relative to now. A time of
+// day is taken to mean the next time the clock shows it, in now's
+// time zone.
+func parseAt(at string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, at); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("15:04", at)
+	if err != nil {
+		return time.Time{}, errors.Errorf("time %q not valid: expected RFC3339 or HH:MM", at)
+	}
+	due := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !due.After(now) {
+		due = due.AddDate(0, 0, 1)
+	}
+	return due, nil
+}
+
+func (c *scheduleCommand) getAPI() (ScheduleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return scheduleClient{
+		Client: api.NewClient(root),
+		ops:    scheduledoperations.NewClient(root),
+	}, nil
+}
+
+// scheduleClient combines the client facade, through which charms are
+// added, with the ScheduledOperations facade. Both share a connection,
+// which Close closes.
+type scheduleClient struct {
+	*api.Client
+	ops *scheduledoperations.Client
+}
+
+// AddScheduledOperation is part of the ScheduleAPI interface.
+func (c scheduleClient) AddScheduledOperation(op params.ScheduledOperation) (string, error) {
+	return c.ops.AddScheduledOperation(op)
+}
+
+// Run implements Command.Run.
+func (c *scheduleCommand) Run(ctx *cmd.Context) error {
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
+	due, err := parseAt(c.at, c.clock.Now())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.op.Due = due
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if c.op.CharmURL != "" {
+		curl := charm.MustParseURL(c.op.CharmURL)
+		if curl.Schema == "cs" {
+			if err := client.AddCharm(curl, csparams.StableChannel); err != nil {
+				return block.ProcessBlockedError(err, block.BlockChange)
+			}
+		}
+	}
+	id, err := client.AddScheduledOperation(c.op)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Scheduled operation %s for %s.", id, due.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scheduledoperations_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/scheduledoperations"
+	"github.com/juju/juju/testing"
+)
+
+type ScheduleSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeScheduledOperationsAPI
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeScheduledOperationsAPI{}
+	s.clock = testing.NewClock(time.Date(2016, 11, 4, 15, 30, 0, 0, time.UTC))
+}
+
+func (s *ScheduleSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no operation kind specified",
+	}, {
+		args: []string{"upgrade-charm"},
+		err:  "no application name specified",
+	}, {
+		args: []string{"explode", "mysql", "1", "--at", "02:00"},
+		err:  `operation kind "explode" not valid`,
+	}, {
+		args: []string{"upgrade-charm", "mysql", "--at", "02:00"},
+		err:  "no charm URL specified",
+	}, {
+		args: []string{"upgrade-charm", "mysql", "cs:xenial/mysql", "--at", "02:00"},
+		err:  `charm URL "cs:xenial/mysql" must include a revision`,
+	}, {
+		args: []string{"scale-application", "mysql", "--at", "02:00"},
+		err:  "no unit count specified",
+	}, {
+		args: []string{"scale-application", "mysql", "-1", "--at", "02:00"},
+		err:  `unit count "-1" not valid`,
+	}, {
+		args: []string{"scale-application", "mysql", "1", "2", "--at", "02:00"},
+		err:  `unrecognized args: \["2"\]`,
+	}, {
+		args: []string{"scale-application", "mysql", "1"},
+		err:  "no time specified with --at",
+	}, {
+		args: []string{"scale-application", "mysql", "1", "--at", "02:00", "--every", "-1h"},
+		err:  "negative interval -1h0m0s not valid",
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(scheduledoperations.NewScheduleCommandForTest(s.fake, s.clock), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ScheduleSuite) TestScheduleUpgradeCharm(c *gc.C) {
+	s.fake.id = "3"
+	ctx, err := testing.RunCommand(c, scheduledoperations.NewScheduleCommandForTest(s.fake, s.clock),
+		"upgrade-charm", "mysql", "cs:xenial/mysql-57", "--at", "02:00",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Scheduled operation 3 for 2016-11-05T02:00:00Z.\n")
+	s.fake.CheckCallNames(c, "AddCharm", "AddScheduledOperation", "Close")
+	s.fake.CheckCall(c, 0, "AddCharm", charm.MustParseURL("cs:xenial/mysql-57"), csparams.StableChannel)
+	s.fake.CheckCall(c, 1, "AddScheduledOperation", params.ScheduledOperation{
+		Kind:        "upgrade-charm",
+		Application: "mysql",
+		CharmURL:    "cs:xenial/mysql-57",
+		Due:         time.Date(2016, 11, 5, 2, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *ScheduleSuite) TestScheduleLocalCharm(c *gc.C) {
+	_, err := testing.RunCommand(c, scheduledoperations.NewScheduleCommandForTest(s.fake, s.clock),
+		"upgrade-charm", "mysql", "local:xenial/mysql-2", "--at", "16:00",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "AddScheduledOperation", "Close")
+	s.fake.CheckCall(c, 0, "AddScheduledOperation", params.ScheduledOperation{
+		Kind:        "upgrade-charm",
+		Application: "mysql",
+		CharmURL:    "local:xenial/mysql-2",
+		Due:         time.Date(2016, 11, 4, 16, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *ScheduleSuite) TestScheduleScaleApplication(c *gc.C) {
+	_, err := testing.RunCommand(c, scheduledoperations.NewScheduleCommandForTest(s.fake, s.clock),
+		"scale-application", "wordpress", "2",
+		"--at", "2016-11-05T00:00:00+01:00", "--every", "168h",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "AddScheduledOperation", "Close")
+	args := s.fake.Calls()[0].Args
+	c.Assert(args, gc.HasLen, 1)
+	op := args[0].(params.ScheduledOperation)
+	c.Assert(op.Due.Equal(time.Date(2016, 11, 4, 23, 0, 0, 0, time.UTC)), jc.IsTrue)
+	op.Due = time.Time{}
+	c.Assert(op, jc.DeepEquals, params.ScheduledOperation{
+		Kind:        "scale-application",
+		Application: "wordpress",
+		Units:       2,
+		Every:       168 * time.Hour,
+	})
+}
+
+func (s *ScheduleSuite) TestInvalidTime(c *gc.C) {
+	_, err := testing.RunCommand(c, scheduledoperations.NewScheduleCommandForTest(s.fake, s.clock),
+		"scale-application", "wordpress", "2", "--at", "tomorrow",
+	)
+	c.Assert(err, gc.ErrorMatches, `time "tomorrow" not valid: expected RFC3339 or HH:MM`)
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *ScheduleSuite) TestScheduleError(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Message: `application "mysql" not found`})
+	_, err := testing.RunCommand(c, scheduledoperations.NewScheduleCommandForTest(s.fake, s.clock),
+		"scale-application", "mysql", "1", "--at", "02:00",
+	)
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
+
+func (s *ScheduleSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockedError"))
+	_, err := testing.RunCommand(c, scheduledoperations.NewScheduleCommandForTest(s.fake, s.clock),
+		"scale-application", "mysql", "1", "--at", "02:00",
+	)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
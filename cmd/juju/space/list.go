@@ -13,6 +13,7 @@ import (
 	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -24,8 +25,9 @@ func NewListCommand() cmd.Command {
 // listCommand displays a list of all spaces known to Juju.
 type listCommand struct {
 	SpaceCommandBase
-	Short bool
-	out   cmd.Output
+	Short  bool
+	out    cmd.Output
+	fields common.FieldSelector
 }
 
 const listCommandDoc = `
@@ -49,7 +51,7 @@ func (c *listCommand) Info() *cmd.Info {
 // SetFlags is defined on the cmd.Command interface.
 func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.SpaceCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+	c.fields.AddOutputFlags(f, &c.out, "yaml", map[string]cmd.Formatter{
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
 	})
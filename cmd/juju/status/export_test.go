@@ -0,0 +1,42 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewWaitForCommandForTest returns a wait-for command which uses the
+// supplied API and clock.
+func NewWaitForCommandForTest(api waitForAPI, clock clock.Clock) cmd.Command {
+	cmd := &waitForCommand{api: api, clock: clock}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewTopCommandForTest returns a top command which uses the supplied
+// API and clock, and treats its output as a terminal if terminal is
+// true.
+func NewTopCommandForTest(api topAPI, clock clock.Clock, terminal bool) cmd.Command {
+	cmd := &topCommand{
+		api:   api,
+		clock: clock,
+		isTerminal: func(*cmd.Context) bool {
+			return terminal
+		},
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowUnitCommandForTest returns a show-unit command which uses the
+// supplied APIs.
+func NewShowUnitCommandForTest(statusAPI statusAPI, hookTimingsAPI hookTimingsAPI) cmd.Command {
+	cmd := &showUnitCommand{
+		statusAPI:      statusAPI,
+		hookTimingsAPI: hookTimingsAPI,
+	}
+	return modelcmd.Wrap(cmd)
+}
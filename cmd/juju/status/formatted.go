@@ -7,13 +7,15 @@ import (
 	"encoding/json"
 
 	"github.com/juju/juju/instance"
+	payloadstatus "github.com/juju/juju/payload/status"
 	"github.com/juju/juju/status"
 )
 
 type formattedStatus struct {
-	Model        modelStatus                  `json:"model"`
-	Machines     map[string]machineStatus     `json:"machines"`
-	Applications map[string]applicationStatus `json:"applications"`
+	Model        modelStatus                      `json:"model"`
+	Machines     map[string]machineStatus         `json:"machines"`
+	Applications map[string]applicationStatus     `json:"applications"`
+	Payloads     []payloadstatus.FormattedPayload `json:"payloads,omitempty" yaml:"payloads,omitempty"`
 }
 
 type formattedMachineStatus struct {
@@ -33,6 +35,7 @@ type modelStatus struct {
 	Version          string `json:"version" yaml:"version"`
 	AvailableVersion string `json:"upgrade-available,omitempty" yaml:"upgrade-available,omitempty"`
 	Migration        string `json:"migration,omitempty" yaml:"migration,omitempty"`
+	Cached           string `json:"cached,omitempty" yaml:"cached,omitempty"`
 }
 
 type machineStatus struct {
@@ -46,6 +49,7 @@ type machineStatus struct {
 	Containers    map[string]machineStatus `json:"containers,omitempty" yaml:"containers,omitempty"`
 	Hardware      string                   `json:"hardware,omitempty" yaml:"hardware,omitempty"`
 	HAStatus      string                   `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
+	Maintenance   bool                     `json:"maintenance,omitempty" yaml:"maintenance,omitempty"`
 }
 
 // A goyaml bug means we can't declare these types
@@ -111,11 +115,12 @@ type unitStatus struct {
 	JujuStatusInfo     statusInfoContents `json:"juju-status,omitempty" yaml:"juju-status"`
 	MeterStatus        *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 
-	Charm         string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
-	Machine       string                `json:"machine,omitempty" yaml:"machine,omitempty"`
-	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
-	PublicAddress string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
-	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
+	Charm           string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
+	WorkloadVersion string                `json:"workload-version,omitempty" yaml:"workload-version,omitempty"`
+	Machine         string                `json:"machine,omitempty" yaml:"machine,omitempty"`
+	OpenedPorts     []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
+	PublicAddress   string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
+	Subordinates    map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
 }
 
 type statusInfoContents struct {
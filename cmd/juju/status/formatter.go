@@ -102,6 +102,7 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		Id:            machine.Id,
 		Containers:    make(map[string]machineStatus),
 		Hardware:      machine.Hardware,
+		Maintenance:   machine.Maintenance,
 	}
 
 	for k, m := range machine.Containers {
@@ -201,6 +202,7 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		OpenedPorts:        info.unit.OpenedPorts,
 		PublicAddress:      info.unit.PublicAddress,
 		Charm:              info.unit.Charm,
+		WorkloadVersion:    info.unit.WorkloadVersion,
 		Subordinates:       make(map[string]unitStatus),
 	}
 
@@ -46,17 +46,23 @@ The statuses are available for the following types.
     juju-unit: will show statuses for the unit's juju agent.
     workload: will show statuses for the unit's workload.
     unit: will show workload and juju agent combined for the specified unit.
+    workload-version: will show the versions of the unit's workload.
     juju-machine: will show statuses for machine's juju agent.
     machine: will show statuses for machines.
     juju-container: will show statuses for the container's juju agent.
     container: will show statuses for containers.
  and sorted by time of occurrence.
  The default is unit.
+
+The amount of history kept for each entity is bounded by the
+max-status-history-age and max-status-history-entries controller
+config values.
 `
 
 func (c *statusHistoryCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "status-history",
+		Aliases: []string{"show-status-log"},
 		Args:    "<entity name>",
 		Purpose: "Output past statuses for the specified entity.",
 		Doc:     statusHistoryDoc,
@@ -137,7 +143,7 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 	}
 	var tag names.Tag
 	switch kind {
-	case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+	case status.KindUnit, status.KindWorkload, status.KindUnitAgent, status.KindWorkloadVersion:
 		if !names.IsValidUnit(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
@@ -219,6 +219,13 @@ func FormatTabular(value interface{}) ([]byte, error) {
 		}
 	}
 
+	if len(fs.Payloads) > 0 {
+		outputHeaders("PAYLOAD", "UNIT", "MACHINE", "TYPE", "ID", "STATUS", "TAGS")
+		for _, pl := range fs.Payloads {
+			p(pl.Class, pl.Unit, pl.Machine, pl.Type, pl.ID, pl.Status, strings.Join(pl.Labels, " "))
+		}
+	}
+
 	var pMachine func(machineStatus)
 	pMachine = func(m machineStatus) {
 		// We want to display availability zone so extract from hardware info".
@@ -245,6 +252,8 @@ func FormatTabular(value interface{}) ([]byte, error) {
 func getModelMessage(model modelStatus) string {
 	// Select the most important message about the model (if any).
 	switch {
+	case model.Cached != "":
+		return "cached: " + model.Cached
 	case model.Migration != "":
 		return "migrating: " + model.Migration
 	case model.AvailableVersion != "":
@@ -272,7 +281,11 @@ func printMachine(p func(...interface{}), m machineStatus, prefix string) {
 	if hw.AvailabilityZone != nil {
 		az = *hw.AvailabilityZone
 	}
-	p(prefix+m.Id, m.JujuStatus.Current, m.DNSName, m.InstanceId, m.Series, az)
+	state := string(m.JujuStatus.Current)
+	if m.Maintenance {
+		state = "maintenance"
+	}
+	p(prefix+m.Id, state, m.DNSName, m.InstanceId, m.Series, az)
 	for _, name := range utils.SortStringsNaturally(stringKeysFromMap(m.Containers)) {
 		printMachine(p, m.Containers[name], prefix+"  ")
 	}
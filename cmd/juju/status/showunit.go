@@ -0,0 +1,241 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/hooktimings"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/osenv"
+)
+
+var usageShowUnitSummary = `
+Shows the status of a unit, and optionally its recent hook executions.`[1:]
+
+var usageShowUnitDetails = `
+The unit's workload and agent status, machine and addresses are shown.
+
+With --hook-timings, the unit's most recent hook executions are also
+shown, oldest first. For each hook, the time it was queued, how long it
+waited for the machine lock before starting, how long it ran and its
+exit code are reported, which helps to identify slow or repeatedly
+failing hooks. An exit code of -1 means the hook failed without
+exiting. Only a bounded number of executions are kept for each unit.
+
+Examples:
+    juju show-unit mysql/0
+    juju show-unit mysql/0 --hook-timings
+
+See also:
+    status
+    status-history`[1:]
+
+// NewShowUnitCommand returns a command which shows the status of a
+// unit, and optionally its recent hook executions.
+func NewShowUnitCommand() cmd.Command {
+	return modelcmd.Wrap(&showUnitCommand{})
+}
+
+// showUnitCommand shows the status of a unit, and optionally its recent
+// hook executions.
+type showUnitCommand struct {
+	modelcmd.ModelCommandBase
+	out            cmd.Output
+	statusAPI      statusAPI
+	hookTimingsAPI hookTimingsAPI
+
+	unitName    string
+	hookTimings bool
+	isoTime     bool
+}
+
+// hookTimingsAPI defines the methods on the HookTimings API that the
+// show-unit command calls.
+type hookTimingsAPI interface {
+	Close() error
+	HookTimings(unit names.UnitTag) ([]params.HookTiming, error)
+}
+
+func (c *showUnitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-unit",
+		Args:    "<unit name>",
+		Purpose: usageShowUnitSummary,
+		Doc:     usageShowUnitDetails,
+	}
+}
+
+func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.hookTimings, "hook-timings", false, "Show the unit's recent hook executions")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *showUnitCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit name specified")
+	}
+	c.unitName, args = args[0], args[1:]
+	if !names.IsValidUnit(c.unitName) {
+		return errors.Errorf("invalid unit name %q", c.unitName)
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		if value := os.Getenv(osenv.JujuStatusIsoTimeEnvKey); value != "" {
+			var err error
+			if c.isoTime, err = strconv.ParseBool(value); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showUnitCommand) getStatusAPI() (statusAPI, error) {
+	if c.statusAPI != nil {
+		return c.statusAPI, nil
+	}
+	return c.NewAPIClient()
+}
+
+func (c *showUnitCommand) getHookTimingsAPI() (hookTimingsAPI, error) {
+	if c.hookTimingsAPI != nil {
+		return c.hookTimingsAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return hooktimings.NewClient(root), nil
+}
+
+// shownUnit holds the details of a unit displayed by show-unit.
+type shownUnit struct {
+	WorkloadStatus  statusInfoContents `json:"workload-status" yaml:"workload-status"`
+	JujuStatus      statusInfoContents `json:"juju-status" yaml:"juju-status"`
+	WorkloadVersion string             `json:"workload-version,omitempty" yaml:"workload-version,omitempty"`
+	Machine         string             `json:"machine,omitempty" yaml:"machine,omitempty"`
+	OpenedPorts     []string           `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
+	PublicAddress   string             `json:"public-address,omitempty" yaml:"public-address,omitempty"`
+	HookTimings     []shownHookTiming  `json:"hook-timings,omitempty" yaml:"hook-timings,omitempty"`
+}
+
+// shownHookTiming holds a hook execution displayed by show-unit.
+type shownHookTiming struct {
+	Hook     string `json:"hook" yaml:"hook"`
+	Queued   string `json:"queued" yaml:"queued"`
+	Wait     string `json:"wait" yaml:"wait"`
+	Duration string `json:"duration" yaml:"duration"`
+	ExitCode int    `json:"exit-code" yaml:"exit-code"`
+}
+
+func (c *showUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getStatusAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	fullStatus, err := client.Status([]string{c.unitName})
+	if err != nil {
+		if fullStatus == nil {
+			return errors.Trace(err)
+		}
+		// Display any error, but continue to show the unit if
+		// its status was returned.
+		fmt.Fprintf(ctx.Stderr, "%v\n", err)
+	}
+	formatter := NewStatusFormatter(fullStatus, c.isoTime)
+	unit, ok := formatter.findUnit(c.unitName)
+	if !ok {
+		return errors.NotFoundf("unit %q", c.unitName)
+	}
+	shown := shownUnit{
+		WorkloadStatus:  unit.WorkloadStatusInfo,
+		JujuStatus:      unit.JujuStatusInfo,
+		WorkloadVersion: unit.WorkloadVersion,
+		Machine:         unit.Machine,
+		OpenedPorts:     unit.OpenedPorts,
+		PublicAddress:   unit.PublicAddress,
+	}
+	if c.hookTimings {
+		if shown.HookTimings, err = c.getHookTimings(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return c.out.Write(ctx, map[string]shownUnit{c.unitName: shown})
+}
+
+func (c *showUnitCommand) getHookTimings() ([]shownHookTiming, error) {
+	client, err := c.getHookTimingsAPI()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	timings, err := client.HookTimings(names.NewUnitTag(c.unitName))
+	if params.IsCodeNotImplemented(err) {
+		return nil, errors.New("cannot show hook timings: not supported by the API server")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	shown := make([]shownHookTiming, len(timings))
+	for i, timing := range timings {
+		shown[i] = shownHookTiming{
+			Hook:     timing.Hook,
+			Queued:   common.FormatTime(&timing.Queued, c.isoTime),
+			Wait:     timing.Started.Sub(timing.Queued).String(),
+			Duration: timing.Finished.Sub(timing.Started).String(),
+			ExitCode: timing.ExitCode,
+		}
+	}
+	return shown, nil
+}
+
+// findUnit returns the formatted status of the named unit, which may be
+// a principal or a subordinate unit.
+func (sf *statusFormatter) findUnit(unitName string) (unitStatus, bool) {
+	if sf.status == nil {
+		return unitStatus{}, false
+	}
+	for appName, app := range sf.status.Applications {
+		for name, unit := range app.Units {
+			if name == unitName {
+				return sf.formatUnit(unitFormatInfo{
+					unit:            unit,
+					unitName:        name,
+					applicationName: appName,
+					meterStatuses:   app.MeterStatuses,
+				}), true
+			}
+			for subName, sub := range unit.Subordinates {
+				if subName == unitName {
+					subAppName, _ := names.UnitApplication(subName)
+					return sf.formatUnit(unitFormatInfo{
+						unit:            sub,
+						unitName:        subName,
+						applicationName: subAppName,
+						meterStatuses:   sf.status.Applications[subAppName].MeterStatuses,
+					}), true
+				}
+			}
+		}
+	}
+	return unitStatus{}, false
+}
@@ -0,0 +1,206 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type ShowUnitSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	statusAPI      *fakeShowUnitStatusAPI
+	hookTimingsAPI *fakeHookTimingsAPI
+}
+
+var _ = gc.Suite(&ShowUnitSuite{})
+
+func (s *ShowUnitSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	since := time.Date(2016, 10, 3, 11, 0, 0, 0, time.UTC)
+	s.statusAPI = &fakeShowUnitStatusAPI{
+		status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {
+							WorkloadStatus: params.DetailedStatus{
+								Status: "active",
+								Info:   "ready",
+								Since:  &since,
+							},
+							AgentStatus: params.DetailedStatus{
+								Status: "idle",
+								Since:  &since,
+							},
+							Machine:       "0",
+							PublicAddress: "10.0.0.1",
+							Subordinates: map[string]params.UnitStatus{
+								"logging/0": {
+									WorkloadStatus: params.DetailedStatus{Status: "unknown"},
+									AgentStatus:    params.DetailedStatus{Status: "idle"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	queued := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	s.hookTimingsAPI = &fakeHookTimingsAPI{
+		timings: []params.HookTiming{{
+			Hook:     "install",
+			Queued:   queued,
+			Started:  queued.Add(2 * time.Second),
+			Finished: queued.Add(90 * time.Second),
+		}, {
+			Hook:     "config-changed",
+			Queued:   queued.Add(2 * time.Minute),
+			Started:  queued.Add(2 * time.Minute),
+			Finished: queued.Add(2*time.Minute + 500*time.Millisecond),
+			ExitCode: 1,
+		}},
+	}
+}
+
+func (s *ShowUnitSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no unit name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `invalid unit name "mysql"`,
+	}, {
+		args: []string{"mysql/0", "mysql/1"},
+		err:  `unrecognized args: \["mysql/1"\]`,
+	}, {
+		args: []string{"mysql/0", "--hook-timings"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(status.NewShowUnitCommandForTest(s.statusAPI, s.hookTimingsAPI), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *ShowUnitSuite) TestShowUnit(c *gc.C) {
+	ctx, err := testing.RunCommand(c, status.NewShowUnitCommandForTest(s.statusAPI, s.hookTimingsAPI), "mysql/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+mysql/0:
+  workload-status:
+    current: active
+    message: ready
+    since: 2016-10-03 11:00:00Z
+  juju-status:
+    current: idle
+    since: 2016-10-03 11:00:00Z
+  machine: "0"
+  public-address: 10.0.0.1
+`[1:])
+	s.statusAPI.CheckCallNames(c, "Status", "Close")
+	s.statusAPI.CheckCall(c, 0, "Status", []string{"mysql/0"})
+	s.hookTimingsAPI.CheckNoCalls(c)
+}
+
+func (s *ShowUnitSuite) TestShowUnitHookTimings(c *gc.C) {
+	ctx, err := testing.RunCommand(c, status.NewShowUnitCommandForTest(s.statusAPI, s.hookTimingsAPI),
+		"mysql/0", "--hook-timings", "--utc",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+mysql/0:
+  workload-status:
+    current: active
+    message: ready
+    since: 2016-10-03 11:00:00Z
+  juju-status:
+    current: idle
+    since: 2016-10-03 11:00:00Z
+  machine: "0"
+  public-address: 10.0.0.1
+  hook-timings:
+  - hook: install
+    queued: 2016-10-03 12:00:00Z
+    wait: 2s
+    duration: 1m28s
+    exit-code: 0
+  - hook: config-changed
+    queued: 2016-10-03 12:02:00Z
+    wait: 0s
+    duration: 500ms
+    exit-code: 1
+`[1:])
+	s.hookTimingsAPI.CheckCallNames(c, "HookTimings", "Close")
+	s.hookTimingsAPI.CheckCall(c, 0, "HookTimings", names.NewUnitTag("mysql/0"))
+}
+
+func (s *ShowUnitSuite) TestShowSubordinateUnit(c *gc.C) {
+	ctx, err := testing.RunCommand(c, status.NewShowUnitCommandForTest(s.statusAPI, s.hookTimingsAPI), "logging/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+logging/0:
+  workload-status:
+    current: unknown
+  juju-status:
+    current: idle
+`[1:])
+}
+
+func (s *ShowUnitSuite) TestUnitNotFound(c *gc.C) {
+	_, err := testing.RunCommand(c, status.NewShowUnitCommandForTest(s.statusAPI, s.hookTimingsAPI), "mysql/1")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/1" not found`)
+}
+
+func (s *ShowUnitSuite) TestHookTimingsNotSupported(c *gc.C) {
+	s.hookTimingsAPI.SetErrors(&params.Error{Code: params.CodeNotImplemented})
+	_, err := testing.RunCommand(c, status.NewShowUnitCommandForTest(s.statusAPI, s.hookTimingsAPI),
+		"mysql/0", "--hook-timings",
+	)
+	c.Assert(err, gc.ErrorMatches, "cannot show hook timings: not supported by the API server")
+}
+
+type fakeShowUnitStatusAPI struct {
+	jujutesting.Stub
+	status *params.FullStatus
+}
+
+func (f *fakeShowUnitStatusAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeShowUnitStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.MethodCall(f, "Status", patterns)
+	return f.status, f.NextErr()
+}
+
+type fakeHookTimingsAPI struct {
+	jujutesting.Stub
+	timings []params.HookTiming
+}
+
+func (f *fakeHookTimingsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeHookTimingsAPI) HookTimings(unit names.UnitTag) ([]params.HookTiming, error) {
+	f.MethodCall(f, "HookTimings", unit)
+	return f.timings, f.NextErr()
+}
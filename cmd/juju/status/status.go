@@ -6,16 +6,25 @@ package status
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/payload"
+	payloadclient "github.com/juju/juju/payload/api/client"
+	payloadstatus "github.com/juju/juju/payload/status"
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.status")
@@ -25,6 +34,12 @@ type statusAPI interface {
 	Close() error
 }
 
+// payloadAPI lists the payloads registered by the charms in a model.
+type payloadAPI interface {
+	ListFull(patterns ...string) ([]payload.FullPayloadInfo, error)
+	Close() error
+}
+
 // NewStatusCommand returns a new command, which reports on the
 // runtime state of various system entities.
 func NewStatusCommand() cmd.Command {
@@ -36,7 +51,13 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	watch    bool
+	deltas   bool
+	cached   bool
 	api      statusAPI
+
+	includePayloads bool
+	payloadAPI      payloadAPI
 }
 
 var usageSummary = `
@@ -70,10 +91,30 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
 
+With --watch, the command keeps a connection to the controller open and
+displays the status again each time the model changes, until interrupted.
+Adding --deltas instead writes each change to the model as a JSON delta,
+one per line, starting with the current contents of the model; filter
+patterns and --format are not used with --deltas.
+
+Each time the full status of a model is displayed, it is also cached on
+the client. With --cached, the cached status is displayed instead of
+contacting the controller, so that the last-seen status is available when
+the controller is unreachable. The time at which the status was cached is
+shown with it. Filter patterns cannot be used with --cached.
+
+With --include-payloads, the payloads that charms have registered with
+the payload-register hook tool, such as the containers and processes
+they run, are displayed with the units that registered them.
+
 Examples:
     juju status
     juju status mysql
     juju status nova-*
+    juju status --watch
+    juju status --watch --deltas
+    juju status --cached
+    juju status --include-payloads
 
 See Also:
     juju show-model
@@ -93,6 +134,10 @@ func (c *statusCommand) Info() *cmd.Info {
 
 func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.watch, "watch", false, "Redisplay the status whenever the model changes")
+	f.BoolVar(&c.deltas, "deltas", false, "With --watch, output model changes as JSON deltas")
+	f.BoolVar(&c.cached, "cached", false, "Display the status last cached on this client, without contacting the controller")
+	f.BoolVar(&c.includePayloads, "include-payloads", false, "Display the payloads registered by charms")
 
 	defaultFormat := "tabular"
 
@@ -109,6 +154,28 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.deltas {
+		if !c.watch {
+			return errors.New("--deltas can only be used with --watch")
+		}
+		if len(c.patterns) > 0 {
+			return errors.New("filter patterns cannot be used with --deltas")
+		}
+		if c.includePayloads {
+			return errors.New("--include-payloads cannot be used with --deltas")
+		}
+	}
+	if c.cached {
+		if c.watch {
+			return errors.New("--cached cannot be used with --watch")
+		}
+		if len(c.patterns) > 0 {
+			return errors.New("filter patterns cannot be used with --cached")
+		}
+		if c.includePayloads {
+			return errors.New("--include-payloads cannot be used with --cached")
+		}
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -127,13 +194,52 @@ var newApiClientForStatus = func(c *statusCommand) (statusAPI, error) {
 	return c.NewAPIClient()
 }
 
+var newPayloadAPIForStatus = func(c *statusCommand) (payloadAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	caller := base.NewFacadeCallerForVersion(root, payload.FacadeName, 1)
+	return payloadclient.NewPublicClient(&payloadFacadeCaller{
+		FacadeCaller: caller,
+		closeFunc:    root.Close,
+	}), nil
+}
+
+type payloadFacadeCaller struct {
+	base.FacadeCaller
+	closeFunc func() error
+}
+
+func (c payloadFacadeCaller) Close() error {
+	return c.closeFunc()
+}
+
 func (c *statusCommand) Run(ctx *cmd.Context) error {
+	if c.cached {
+		return c.writeCachedStatus(ctx)
+	}
 	apiclient, err := newApiClientForStatus(c)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer apiclient.Close()
 
+	if c.includePayloads {
+		c.payloadAPI, err = newPayloadAPIForStatus(c)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer c.payloadAPI.Close()
+	}
+
+	if c.watch {
+		return c.runWatch(ctx, apiclient)
+	}
+	return c.writeStatus(ctx, apiclient)
+}
+
+func (c *statusCommand) writeStatus(ctx *cmd.Context, apiclient statusAPI) error {
 	status, err := apiclient.Status(c.patterns)
 	if err != nil {
 		if status == nil {
@@ -148,5 +254,140 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 
 	formatter := newStatusFormatter(status, c.ControllerName(), c.isoTime)
 	formatted := formatter.format()
+	if c.payloadAPI != nil {
+		payloads, err := c.unitPayloads(formatted)
+		if err != nil {
+			return errors.Annotate(err, "cannot list payloads")
+		}
+		formatted.Payloads = payloads
+	}
+	if err := c.out.Write(ctx, formatted); err != nil {
+		return err
+	}
+	if err == nil && len(c.patterns) == 0 {
+		c.cacheStatus(status)
+	}
+	return nil
+}
+
+// unitPayloads returns the formatted payloads of the units in the
+// given status, ordered by unit.
+func (c *statusCommand) unitPayloads(formatted formattedStatus) ([]payloadstatus.FormattedPayload, error) {
+	payloads, err := c.payloadAPI.ListFull()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units := set.NewStrings()
+	addUnit := func(name string, _ unitStatus, _ int) {
+		units.Add(name)
+	}
+	for _, app := range formatted.Applications {
+		for name, unit := range app.Units {
+			addUnit(name, unit, 0)
+			recurseUnits(unit, 0, addUnit)
+		}
+	}
+	var result []payloadstatus.FormattedPayload
+	for _, pl := range payloads {
+		if units.Contains(pl.Unit) {
+			result = append(result, payloadstatus.FormatPayload(pl))
+		}
+	}
+	sort.Sort(payloadsByUnit(result))
+	return result, nil
+}
+
+type payloadsByUnit []payloadstatus.FormattedPayload
+
+func (p payloadsByUnit) Len() int      { return len(p) }
+func (p payloadsByUnit) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p payloadsByUnit) Less(i, j int) bool {
+	if p[i].Unit != p[j].Unit {
+		return p[i].Unit < p[j].Unit
+	}
+	if p[i].Class != p[j].Class {
+		return p[i].Class < p[j].Class
+	}
+	return p[i].ID < p[j].ID
+}
+
+// statusCacheName returns the name under which the status of the
+// command's model is cached.
+func (c *statusCommand) statusCacheName() (string, error) {
+	details, err := c.ClientStore().ModelByName(c.ControllerName(), c.ModelName())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return "status-" + details.ModelUUID, nil
+}
+
+// cacheStatus records the full status of the model, so that it can be
+// displayed later with --cached. Failure to do so is not fatal.
+//
+// Errors cannot be cached, so they are first removed from the status;
+// it must not be used again afterwards.
+func (c *statusCommand) cacheStatus(status *params.FullStatus) {
+	clearStatusErrors(status)
+	name, err := c.statusCacheName()
+	if err == nil {
+		err = jujuclient.WriteCachedData(c.ControllerName(), name, status)
+	}
+	if err != nil {
+		logger.Debugf("cannot cache status: %v", err)
+	}
+}
+
+// writeCachedStatus writes the status of the model last cached by
+// cacheStatus.
+func (c *statusCommand) writeCachedStatus(ctx *cmd.Context) error {
+	name, err := c.statusCacheName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var status params.FullStatus
+	cached, err := jujuclient.ReadCachedData(c.ControllerName(), name, &status)
+	if errors.IsNotFound(err) {
+		return errors.Errorf("no status cached for model %s", modelcmd.JoinModelName(c.ControllerName(), c.ModelName()))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("%s", common.CachedDataNotice("status", cached, time.Now()))
+
+	formatter := newStatusFormatter(&status, c.ControllerName(), c.isoTime)
+	formatted := formatter.format()
+	formatted.Model.Cached = common.FormatTime(&cached, c.isoTime)
 	return c.out.Write(ctx, formatted)
 }
+
+// clearStatusErrors removes all errors from the given status.
+func clearStatusErrors(status *params.FullStatus) {
+	for id, machine := range status.Machines {
+		status.Machines[id] = clearMachineErrors(machine)
+	}
+	for name, app := range status.Applications {
+		app.Err = nil
+		app.Status.Err = nil
+		for unitName, unit := range app.Units {
+			app.Units[unitName] = clearUnitErrors(unit)
+		}
+		status.Applications[name] = app
+	}
+}
+
+func clearMachineErrors(machine params.MachineStatus) params.MachineStatus {
+	machine.AgentStatus.Err = nil
+	machine.InstanceStatus.Err = nil
+	for id, container := range machine.Containers {
+		machine.Containers[id] = clearMachineErrors(container)
+	}
+	return machine
+}
+
+func clearUnitErrors(unit params.UnitStatus) params.UnitStatus {
+	unit.AgentStatus.Err = nil
+	unit.WorkloadStatus.Err = nil
+	for name, subordinate := range unit.Subordinates {
+		unit.Subordinates[name] = clearUnitErrors(subordinate)
+	}
+	return unit
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/version"
@@ -31,6 +32,8 @@ import (
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
+	payloadstatus "github.com/juju/juju/payload/status"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/presence"
@@ -2435,7 +2438,8 @@ var statusTests = []testCase{
 									"current": "allocating",
 									"since":   "01 Apr 15 01:23+10:00",
 								},
-								"public-address": "controller-1.dns",
+								"public-address":   "controller-1.dns",
+								"workload-version": "the best!",
 							},
 						},
 					}),
@@ -2496,7 +2500,8 @@ var statusTests = []testCase{
 									"current": "allocating",
 									"since":   "01 Apr 15 01:23+10:00",
 								},
-								"public-address": "controller-1.dns",
+								"public-address":   "controller-1.dns",
+								"workload-version": "the best!",
 							},
 							"mysql/1": M{
 								"machine": "2",
@@ -2509,7 +2514,8 @@ var statusTests = []testCase{
 									"current": "allocating",
 									"since":   "01 Apr 15 01:23+10:00",
 								},
-								"public-address": "controller-2.dns",
+								"public-address":   "controller-2.dns",
+								"workload-version": "not as good",
 							},
 						},
 					}),
@@ -3282,6 +3288,32 @@ func (a *fakeApiClient) Close() error {
 	return nil
 }
 
+type fakePayloadAPI struct {
+	payloads    []payload.FullPayloadInfo
+	closeCalled bool
+}
+
+func (a *fakePayloadAPI) ListFull(patterns ...string) ([]payload.FullPayloadInfo, error) {
+	return a.payloads, nil
+}
+
+func (a *fakePayloadAPI) Close() error {
+	a.closeCalled = true
+	return nil
+}
+
+func newFakePayload(class, id, unit, machine string) payload.FullPayloadInfo {
+	return payload.FullPayloadInfo{
+		Payload: payload.Payload{
+			PayloadClass: charm.PayloadClass{Name: class, Type: "docker"},
+			ID:           id,
+			Status:       payload.StateRunning,
+			Unit:         unit,
+		},
+		Machine: machine,
+	}
+}
+
 func (s *StatusSuite) TestStatusWithFormatSummary(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
@@ -3624,6 +3656,158 @@ func (s *StatusSuite) TestStatusWithNilStatusApi(c *gc.C) {
 	c.Check(string(stderr), gc.Equals, "error: unable to obtain the current status\n")
 }
 
+func (s *StatusSuite) TestStatusCached(c *gc.C) {
+	ctx := s.newContext(c)
+	defer s.resetContext(c, ctx)
+	steps := []stepper{
+		addMachine{machineId: "0", job: state.JobManageModel},
+		setAddresses{"0", network.NewAddresses("controller-0.dns")},
+		startAliveMachine{"0"},
+		setMachineStatus{"0", status.StatusStarted, ""},
+	}
+	for _, s := range steps {
+		s.step(c, ctx)
+	}
+
+	code, stdout, stderr := runStatus(c, "--format", "yaml")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("status failed: %s", stderr))
+	var live M
+	err := goyaml.Unmarshal(stdout, &live)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The controller is not contacted for cached status.
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return nil, errors.New("controller unreachable")
+	})
+	code, stdout, stderr = runStatus(c, "--cached", "--format", "yaml")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("status failed: %s", stderr))
+	c.Check(string(stderr), gc.Matches, `Showing status cached just now \(.*\); it may be out of date.\n`)
+	var cached M
+	err = goyaml.Unmarshal(stdout, &cached)
+	c.Assert(err, jc.ErrorIsNil)
+	cachedModel := cached["model"].(map[interface{}]interface{})
+	c.Check(cachedModel["cached"], gc.Not(gc.Equals), "")
+	delete(cachedModel, "cached")
+	c.Check(cached, jc.DeepEquals, live)
+}
+
+func (s *StatusSuite) TestStatusCachedNone(c *gc.C) {
+	code, _, stderr := runStatus(c, "--cached")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Matches, "error: no status cached for model kontroll:.*\n")
+}
+
+func (s *StatusSuite) TestStatusCachedInvalidArgs(c *gc.C) {
+	code, _, stderr := runStatus(c, "--cached", "--watch")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "error: --cached cannot be used with --watch\n")
+
+	code, _, stderr = runStatus(c, "--cached", "mysql")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "error: filter patterns cannot be used with --cached\n")
+}
+
+func (s *StatusSuite) TestStatusIncludePayloads(c *gc.C) {
+	client := &fakeApiClient{statusReturn: &params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "payloads"},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine: "1",
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {},
+						},
+					},
+				},
+			},
+		},
+	}}
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	payloadClient := &fakePayloadAPI{payloads: []payload.FullPayloadInfo{
+		newFakePayload("logger", "idlogger", "logging/0", "1"),
+		newFakePayload("db", "iddb", "mysql/0", "1"),
+		newFakePayload("web", "idweb", "wordpress/0", "2"),
+	}}
+	s.PatchValue(&newPayloadAPIForStatus, func(_ *statusCommand) (payloadAPI, error) {
+		return payloadClient, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--include-payloads", "--format", "yaml")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("status failed: %s", stderr))
+	c.Check(payloadClient.closeCalled, jc.IsTrue)
+	var out struct {
+		Payloads []payloadstatus.FormattedPayload `yaml:"payloads"`
+	}
+	err := goyaml.Unmarshal(stdout, &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.Payloads, jc.DeepEquals, []payloadstatus.FormattedPayload{{
+		Unit:    "logging/0",
+		Machine: "1",
+		ID:      "idlogger",
+		Type:    "docker",
+		Class:   "logger",
+		Status:  "running",
+	}, {
+		Unit:    "mysql/0",
+		Machine: "1",
+		ID:      "iddb",
+		Type:    "docker",
+		Class:   "db",
+		Status:  "running",
+	}})
+}
+
+func (s *StatusSuite) TestStatusWithoutPayloads(c *gc.C) {
+	s.PatchValue(&newPayloadAPIForStatus, func(_ *statusCommand) (payloadAPI, error) {
+		return nil, errors.New("payloads not expected")
+	})
+	code, stdout, stderr := runStatus(c, "--format", "yaml")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("status failed: %s", stderr))
+	c.Check(string(stdout), gc.Not(jc.Contains), "payloads")
+}
+
+func (s *StatusSuite) TestStatusIncludePayloadsInvalidArgs(c *gc.C) {
+	code, _, stderr := runStatus(c, "--include-payloads", "--cached")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "error: --include-payloads cannot be used with --cached\n")
+
+	code, _, stderr = runStatus(c, "--include-payloads", "--watch", "--deltas")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "error: --include-payloads cannot be used with --deltas\n")
+}
+
+func (s *StatusSuite) TestFormatTabularPayloads(c *gc.C) {
+	status := formattedStatus{
+		Payloads: []payloadstatus.FormattedPayload{{
+			Unit:    "foo/0",
+			Machine: "0",
+			ID:      "idspam",
+			Type:    "docker",
+			Class:   "spam",
+			Labels:  []string{"a-tag", "b-tag"},
+			Status:  "running",
+		}},
+	}
+	out, err := FormatTabular(status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+MODEL  CONTROLLER  CLOUD/REGION  VERSION
+                                 
+
+APP  VERSION  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS
+
+UNIT  WORKLOAD  AGENT  MACHINE  PUBLIC-ADDRESS  PORTS  MESSAGE
+
+PAYLOAD  UNIT   MACHINE  TYPE    ID      STATUS   TAGS
+spam     foo/0  0        docker  idspam  running  a-tag b-tag
+
+MACHINE  STATE  DNS  INS-ID  SERIES  AZ
+`[1:])
+}
+
 func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
@@ -3666,6 +3850,42 @@ MACHINE  STATE  DNS  INS-ID  SERIES  AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularMachineMaintenance(c *gc.C) {
+	status := formattedStatus{
+		Machines: map[string]machineStatus{
+			"0": {
+				Id:         "0",
+				JujuStatus: statusInfoContents{Current: status.StatusStarted},
+				DNSName:    "10.0.0.1",
+				InstanceId: "i-0",
+				Series:     "xenial",
+			},
+			"1": {
+				Id:          "1",
+				JujuStatus:  statusInfoContents{Current: status.StatusStarted},
+				DNSName:     "10.0.0.2",
+				InstanceId:  "i-1",
+				Series:      "xenial",
+				Maintenance: true,
+			},
+		},
+	}
+	out, err := FormatTabular(status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+MODEL  CONTROLLER  CLOUD/REGION  VERSION
+                                 
+
+APP  VERSION  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS
+
+UNIT  WORKLOAD  AGENT  MACHINE  PUBLIC-ADDRESS  PORTS  MESSAGE
+
+MACHINE  STATE        DNS       INS-ID  SERIES  AZ
+0        started      10.0.0.1  i-0     xenial  
+1        maintenance  10.0.0.2  i-1     xenial  
+`[1:])
+}
+
 //
 // Filtering Feature
 //
@@ -0,0 +1,210 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/usage"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/osenv"
+)
+
+var usageTopSummary = `
+Displays the resource usage of the machines and units in a model.`[1:]
+
+var usageTopDetails = `
+The CPU, memory and disk usage of each machine, and of each unit
+deployed to it, is reported periodically by the machine's agent.
+When the output is a terminal the display is refreshed at the given
+interval until the command is interrupted; otherwise the usage is
+written once.
+
+CPU usage is shown as a percentage of the machine's total capacity.
+Unit memory is the resident memory of the unit agent and the
+processes it has started, and unit disk is the size of the unit's
+agent directory, including its charm.
+
+Machines which have not yet reported their usage are not shown.
+
+Examples:
+    juju top
+    juju top --interval 30s
+
+See also:
+    status`[1:]
+
+// NewTopCommand returns a command which displays the resource usage of
+// the machines and units in a model.
+func NewTopCommand() cmd.Command {
+	return modelcmd.Wrap(&topCommand{
+		clock:      clock.WallClock,
+		isTerminal: isTerminal,
+	})
+}
+
+// topCommand displays the resource usage of the machines and units in
+// a model.
+type topCommand struct {
+	modelcmd.ModelCommandBase
+	api        topAPI
+	clock      clock.Clock
+	isTerminal func(*cmd.Context) bool
+
+	interval time.Duration
+	isoTime  bool
+}
+
+func (c *topCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "top",
+		Purpose: usageTopSummary,
+		Doc:     usageTopDetails,
+	}
+}
+
+func (c *topCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.interval, "interval", 5*time.Second, "How often to refresh the display")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+func (c *topCommand) Init(args []string) error {
+	if c.interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		if value := os.Getenv(osenv.JujuStatusIsoTimeEnvKey); value != "" {
+			var err error
+			if c.isoTime, err = strconv.ParseBool(value); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// topAPI defines the methods on the Usage API that the top command
+// calls.
+type topAPI interface {
+	Close() error
+	ModelUsage() ([]params.MachineUsage, error)
+}
+
+func (c *topCommand) getAPI() (topAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return usage.NewClient(root), nil
+}
+
+// Run displays the resource usage of the model, refreshing it at the
+// configured interval while the output is a terminal.
+func (c *topCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if !c.isTerminal(ctx) {
+		return c.writeUsage(ctx.Stdout, client)
+	}
+	interrupt := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupt)
+	defer ctx.StopInterruptNotify(interrupt)
+	for {
+		fmt.Fprint(ctx.Stdout, clearScreen)
+		if err := c.writeUsage(ctx.Stdout, client); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-interrupt:
+			return nil
+		case <-c.clock.After(c.interval):
+		}
+	}
+}
+
+// writeUsage writes a table of the resource usage of each machine,
+// ordered by machine id, with the units deployed to each machine
+// listed beneath it.
+func (c *topCommand) writeUsage(out io.Writer, client topAPI) error {
+	machines, err := client.ModelUsage()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ids := make([]string, 0, len(machines))
+	byId := make(map[string]params.MachineUsage)
+	for _, machine := range machines {
+		tag, err := names.ParseMachineTag(machine.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ids = append(ids, tag.Id())
+		byId[tag.Id()] = machine
+	}
+	utils.SortStringsNaturally(ids)
+
+	tw := tabwriter.NewWriter(out, 0, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "MACHINE/UNIT\tCPU\tMEMORY\tDISK\tUPDATED")
+	for _, id := range ids {
+		machine := byId[id]
+		fmt.Fprintf(tw, "%s\t%.1f%%\t%s\t%s\t%s\n",
+			id,
+			machine.CPUPercent,
+			formatUsed(machine.MemoryUsed, machine.MemoryTotal),
+			formatUsed(machine.DiskUsed, machine.DiskTotal),
+			common.FormatTime(&machine.Updated, c.isoTime),
+		)
+		unitNames := make([]string, 0, len(machine.Units))
+		units := make(map[string]params.UnitUsage)
+		for _, unit := range machine.Units {
+			tag, err := names.ParseUnitTag(unit.Tag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			unitNames = append(unitNames, tag.Id())
+			units[tag.Id()] = unit
+		}
+		utils.SortStringsNaturally(unitNames)
+		for _, name := range unitNames {
+			unit := units[name]
+			fmt.Fprintf(tw, "  %s\t%.1f%%\t%s\t%s\t\n",
+				name,
+				unit.CPUPercent,
+				humanize.IBytes(unit.MemoryUsed),
+				humanize.IBytes(unit.DiskUsed),
+			)
+		}
+	}
+	return errors.Trace(tw.Flush())
+}
+
+// formatUsed returns the used and total size of a resource, in human
+// readable units.
+func formatUsed(used, total uint64) string {
+	return humanize.IBytes(used) + "/" + humanize.IBytes(total)
+}
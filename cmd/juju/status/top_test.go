@@ -0,0 +1,164 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type TopSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	clock *testing.Clock
+	fake  *fakeTopAPI
+}
+
+var _ = gc.Suite(&TopSuite{})
+
+func (s *TopSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC))
+	updated := time.Date(2016, 10, 1, 12, 30, 0, 0, time.UTC)
+	s.fake = &fakeTopAPI{usage: []params.MachineUsage{{
+		Tag:         "machine-10",
+		CPUPercent:  1,
+		MemoryUsed:  512 * 1024 * 1024,
+		MemoryTotal: 2 * 1024 * 1024 * 1024,
+		DiskUsed:    1024 * 1024 * 1024,
+		DiskTotal:   8 * 1024 * 1024 * 1024,
+		Updated:     updated,
+	}, {
+		Tag:         "machine-2",
+		CPUPercent:  42.25,
+		MemoryUsed:  3 * 1024 * 1024 * 1024,
+		MemoryTotal: 4 * 1024 * 1024 * 1024,
+		DiskUsed:    5 * 1024 * 1024 * 1024,
+		DiskTotal:   20 * 1024 * 1024 * 1024,
+		Units: []params.UnitUsage{{
+			Tag:        "unit-mysql-10",
+			CPUPercent: 2.5,
+			MemoryUsed: 10 * 1024 * 1024,
+			DiskUsed:   2 * 1024 * 1024,
+		}, {
+			Tag:        "unit-mysql-9",
+			CPUPercent: 30,
+			MemoryUsed: 1024 * 1024 * 1024,
+			DiskUsed:   4 * 1024 * 1024,
+		}},
+		Updated: updated,
+	}}}
+}
+
+var expectedTopOutput = `
+MACHINE/UNIT  CPU    MEMORY         DISK           UPDATED
+2             42.2%  3.0GiB/4.0GiB  5.0GiB/20GiB   2016-10-01 12:30:00Z
+  mysql/9     30.0%  1.0GiB         4.0MiB         
+  mysql/10    2.5%   10MiB          2.0MiB         
+10            1.0%   512MiB/2.0GiB  1.0GiB/8.0GiB  2016-10-01 12:30:00Z
+`[1:]
+
+func (s *TopSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--interval", "0s"},
+		err:  `--interval must be positive`,
+	}, {
+		args: []string{"foo"},
+		err:  `unrecognized args: \["foo"\]`,
+	}, {
+		args: []string{"--interval", "1m", "--utc"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(status.NewTopCommandForTest(s.fake, s.clock, false), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *TopSuite) TestOnce(c *gc.C) {
+	ctx, err := testing.RunCommand(c, status.NewTopCommandForTest(s.fake, s.clock, false), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, expectedTopOutput)
+	s.fake.CheckCallNames(c, "ModelUsage", "Close")
+}
+
+func (s *TopSuite) TestNoUsage(c *gc.C) {
+	s.fake.usage = nil
+	ctx, err := testing.RunCommand(c, status.NewTopCommandForTest(s.fake, s.clock, false))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "MACHINE/UNIT  CPU  MEMORY  DISK  UPDATED\n")
+}
+
+func (s *TopSuite) TestError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, status.NewTopCommandForTest(s.fake, s.clock, false))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *TopSuite) TestRefresh(c *gc.C) {
+	// The command refreshes until interrupted, or until it fails.
+	s.fake.SetErrors(nil, nil, errors.New("boom"))
+	command := status.NewTopCommandForTest(s.fake, s.clock, true)
+	err := testing.InitCommand(command, []string{"--utc", "--interval", "10s"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	done := make(chan error, 1)
+	go func() {
+		done <- command.Run(ctx)
+	}()
+
+	s.waitAlarm(c)
+	s.clock.Advance(10 * time.Second)
+	s.waitAlarm(c)
+	s.clock.Advance(10 * time.Second)
+
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, "boom")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+	clear := "\x1b[H\x1b[2J"
+	c.Check(testing.Stdout(ctx), gc.Equals, clear+expectedTopOutput+clear+expectedTopOutput+clear)
+	s.fake.CheckCallNames(c, "ModelUsage", "ModelUsage", "ModelUsage", "Close")
+}
+
+func (s *TopSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for refresh")
+	}
+}
+
+type fakeTopAPI struct {
+	jujutesting.Stub
+	usage []params.MachineUsage
+}
+
+func (f *fakeTopAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeTopAPI) ModelUsage() ([]params.MachineUsage, error) {
+	f.AddCall("ModelUsage")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.usage, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/waitfor"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageWaitForSummary = `
+Waits until conditions about an application, unit or machine are met.`[1:]
+
+var usageWaitForDetails = `
+The command blocks until all of the given conditions hold at the same
+time, or until the timeout expires, in which case it fails and reports
+the conditions that were not met. Conditions are evaluated by the
+controller as the model changes, so there is no need to poll
+"juju status" from scripts.
+
+Each condition has the form <field><operator><value>. The fields
+available depend on the kind of entity:
+
+    application: status, unit-count
+    unit:        status, agent-status, workload-version
+    machine:     status, instance-status
+
+The operators == and != may be used with any field; unit-count may
+also be compared with <, <=, > and >=.
+
+Examples:
+    juju wait-for mysql/0 status==active
+    juju wait-for wordpress unit-count>=3 --timeout 30m
+    juju wait-for mysql/0 status==active workload-version==5.7
+    juju wait-for 0 status==started
+
+See also:
+    status`[1:]
+
+// waitForOperators lists the condition operators, with the longer
+// operators first so that they are matched in preference.
+var waitForOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// NewWaitForCommand returns a command which blocks until conditions
+// about an entity in the model are met.
+func NewWaitForCommand() cmd.Command {
+	return modelcmd.Wrap(&waitForCommand{clock: clock.WallClock})
+}
+
+// waitForCommand blocks until conditions about an entity are met.
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+	api   waitForAPI
+	clock clock.Clock
+
+	timeout    time.Duration
+	conditions []params.WaitForCondition
+}
+
+func (c *waitForCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait-for",
+		Args:    "<application|unit|machine> <condition> [<condition>...]",
+		Purpose: usageWaitForSummary,
+		Doc:     usageWaitForDetails,
+	}
+}
+
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.timeout, "timeout", 10*time.Minute, "How long to wait for the conditions to be met")
+}
+
+func (c *waitForCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application, unit or machine specified")
+	}
+	tag, err := waitForEntityTag(args[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(args) == 1 {
+		return errors.New("no conditions specified")
+	}
+	if c.timeout <= 0 {
+		return errors.New("--timeout must be positive")
+	}
+	c.conditions = nil
+	for _, arg := range args[1:] {
+		cond, err := parseWaitForCondition(tag, arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.conditions = append(c.conditions, cond)
+	}
+	return nil
+}
+
+// waitForEntityTag returns the tag of the application, unit or
+// machine with the supplied name.
+func waitForEntityTag(name string) (names.Tag, error) {
+	switch {
+	case names.IsValidUnit(name):
+		return names.NewUnitTag(name), nil
+	case names.IsValidMachine(name):
+		return names.NewMachineTag(name), nil
+	case names.IsValidApplication(name):
+		return names.NewApplicationTag(name), nil
+	}
+	return nil, errors.NotValidf("application, unit or machine name %q", name)
+}
+
+// parseWaitForCondition parses a condition of the form
+// <field><operator><value>.
+func parseWaitForCondition(tag names.Tag, arg string) (params.WaitForCondition, error) {
+	for _, op := range waitForOperators {
+		i := strings.Index(arg, op)
+		if i == -1 {
+			continue
+		}
+		field := strings.TrimSpace(arg[:i])
+		if field == "" {
+			break
+		}
+		return params.WaitForCondition{
+			Tag:   tag.String(),
+			Field: field,
+			Op:    op,
+			Value: strings.TrimSpace(arg[i+len(op):]),
+		}, nil
+	}
+	return params.WaitForCondition{}, errors.Errorf("invalid condition %q: expected <field><operator><value>", arg)
+}
+
+// waitForAPI defines the methods on the WaitFor API that the
+// wait-for command calls.
+type waitForAPI interface {
+	Close() error
+	WaitFor([]params.WaitForCondition, time.Duration) (params.WaitForResult, error)
+}
+
+func (c *waitForCommand) getAPI() (waitForAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return waitfor.NewClient(root), nil
+}
+
+// Run waits for the conditions to be met. The controller limits how
+// long a single call may block, so the call is repeated until the
+// conditions are met or the timeout expires.
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	deadline := c.clock.Now().Add(c.timeout)
+	for {
+		remaining := deadline.Sub(c.clock.Now())
+		result, err := client.WaitFor(c.conditions, remaining)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if result.Satisfied {
+			ctx.Infof("conditions met")
+			return nil
+		}
+		if !c.clock.Now().Before(deadline) {
+			return errors.Errorf(
+				"timed out after %v waiting for:\n    %s",
+				c.timeout, strings.Join(result.Unsatisfied, "\n    "),
+			)
+		}
+	}
+}
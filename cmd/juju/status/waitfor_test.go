@@ -0,0 +1,149 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type WaitForSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	clock *testing.Clock
+	fake  *fakeWaitForAPI
+}
+
+var _ = gc.Suite(&WaitForSuite{})
+
+func (s *WaitForSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC))
+	s.fake = &fakeWaitForAPI{clock: s.clock}
+}
+
+func (s *WaitForSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no application, unit or machine specified`,
+	}, {
+		args: []string{"mysql"},
+		err:  `no conditions specified`,
+	}, {
+		args: []string{"foo_bar", "status==active"},
+		err:  `application, unit or machine name "foo_bar" not valid`,
+	}, {
+		args: []string{"mysql/0", "status=active"},
+		err:  `invalid condition "status=active": expected <field><operator><value>`,
+	}, {
+		args: []string{"mysql/0", "==active"},
+		err:  `invalid condition "==active": expected <field><operator><value>`,
+	}, {
+		args: []string{"mysql", "unit-count>=2", "--timeout", "0s"},
+		err:  `--timeout must be positive`,
+	}, {
+		args: []string{"mysql/0", "status==active", "workload-version!=1.0"},
+	}, {
+		args: []string{"mysql", "unit-count<=2", "--timeout", "1h"},
+	}, {
+		args: []string{"0/lxd/1", "instance-status==running"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(status.NewWaitForCommandForTest(s.fake, s.clock), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *WaitForSuite) TestSatisfied(c *gc.C) {
+	s.fake.results = []params.WaitForResult{{Satisfied: true}}
+	ctx, err := testing.RunCommand(c, status.NewWaitForCommandForTest(s.fake, s.clock),
+		"mysql", "status==active", "unit-count>=3", "--timeout", "5m")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "conditions met\n")
+	s.fake.CheckCalls(c, []jujutesting.StubCall{{
+		"WaitFor", []interface{}{[]params.WaitForCondition{
+			{Tag: "application-mysql", Field: "status", Op: "==", Value: "active"},
+			{Tag: "application-mysql", Field: "unit-count", Op: ">=", Value: "3"},
+		}, 5 * time.Minute},
+	}, {
+		"Close", nil,
+	}})
+}
+
+func (s *WaitForSuite) TestRetriesUntilSatisfied(c *gc.C) {
+	s.fake.blockFor = 10 * time.Minute
+	s.fake.results = []params.WaitForResult{
+		{Unsatisfied: []string{`unit-mysql-0 status == "active"`}},
+		{Satisfied: true},
+	}
+	_, err := testing.RunCommand(c, status.NewWaitForCommandForTest(s.fake, s.clock),
+		"mysql/0", "status==active", "--timeout", "1h")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "WaitFor", "WaitFor", "Close")
+	c.Check(s.fake.Calls()[1].Args[1], gc.Equals, 50*time.Minute)
+}
+
+func (s *WaitForSuite) TestTimeout(c *gc.C) {
+	s.fake.blockFor = 10 * time.Minute
+	s.fake.results = []params.WaitForResult{
+		{Unsatisfied: []string{`unit-mysql-0 status == "active"`}},
+		{Unsatisfied: []string{`unit-mysql-0 status == "active"`}},
+	}
+	_, err := testing.RunCommand(c, status.NewWaitForCommandForTest(s.fake, s.clock),
+		"mysql/0", "status==active", "--timeout", "15m")
+	c.Assert(err, gc.ErrorMatches, `timed out after 15m0s waiting for:
+    unit-mysql-0 status == "active"`)
+	s.fake.CheckCallNames(c, "WaitFor", "WaitFor", "Close")
+}
+
+func (s *WaitForSuite) TestError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, status.NewWaitForCommandForTest(s.fake, s.clock),
+		"mysql/0", "status==active")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.fake.CheckCallNames(c, "WaitFor", "Close")
+}
+
+// fakeWaitForAPI returns the configured results in turn, advancing
+// the clock on each call to simulate the controller blocking.
+type fakeWaitForAPI struct {
+	jujutesting.Stub
+	clock    *testing.Clock
+	blockFor time.Duration
+	results  []params.WaitForResult
+}
+
+func (f *fakeWaitForAPI) Close() error {
+	f.AddCall("Close")
+	return f.NextErr()
+}
+
+func (f *fakeWaitForAPI) WaitFor(conditions []params.WaitForCondition, timeout time.Duration) (params.WaitForResult, error) {
+	f.AddCall("WaitFor", conditions, timeout)
+	if err := f.NextErr(); err != nil {
+		return params.WaitForResult{}, err
+	}
+	if f.blockFor < timeout {
+		f.clock.Advance(f.blockFor)
+	} else {
+		f.clock.Advance(timeout)
+	}
+	result := f.results[0]
+	f.results = f.results[1:]
+	return result, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// clearScreen moves the cursor to the top left of the terminal and
+// clears it, so that each status display replaces the last.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// allWatcher defines the methods of the model's all watcher used by
+// status --watch.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var watchAllForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status")
+	}
+	return client.WatchAll()
+}
+
+// runWatch displays the status, or writes the deltas, each time the
+// model changes, until the command is interrupted.
+func (c *statusCommand) runWatch(ctx *cmd.Context, apiclient statusAPI) error {
+	watcher, err := watchAllForStatus(apiclient)
+	if err != nil {
+		return errors.Annotate(err, "watching model")
+	}
+
+	// Stopping the watcher causes any blocked call to Next to return,
+	// so we do that when interrupted, or when we're done.
+	interrupt := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupt)
+	defer ctx.StopInterruptNotify(interrupt)
+	interrupted := make(chan struct{})
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		select {
+		case <-interrupt:
+			close(interrupted)
+		case <-done:
+		}
+		if err := watcher.Stop(); err != nil {
+			logger.Debugf("stopping watcher: %v", err)
+		}
+	}()
+
+	for {
+		deltas, err := watcher.Next()
+		if err != nil {
+			select {
+			case <-interrupted:
+				return nil
+			default:
+			}
+			return errors.Annotate(err, "watching model")
+		}
+		if c.deltas {
+			err = writeDeltas(ctx, deltas)
+		} else {
+			if isTerminal(ctx) {
+				fmt.Fprint(ctx.Stdout, clearScreen)
+			}
+			err = c.writeStatus(ctx, apiclient)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// writeDeltas writes each delta as JSON on a line of its own.
+func writeDeltas(ctx *cmd.Context, deltas []multiwatcher.Delta) error {
+	for i := range deltas {
+		data, err := json.Marshal(&deltas[i])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := fmt.Fprintf(ctx.Stdout, "%s\n", data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func isTerminal(ctx *cmd.Context) bool {
+	f, ok := ctx.Stdout.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WatchSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	client  *fakeApiClient
+	watcher *fakeAllWatcher
+}
+
+var _ = gc.Suite(&WatchSuite{})
+
+func (s *WatchSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.client = &fakeApiClient{statusReturn: &params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "watched", Version: "2.0.0"},
+	}}
+	s.watcher = &fakeAllWatcher{}
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return s.client, nil
+	})
+	s.PatchValue(&watchAllForStatus, func(statusAPI) (allWatcher, error) {
+		return s.watcher, nil
+	})
+}
+
+func (s *WatchSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := coretesting.RunCommand(c, modelcmd.Wrap(&statusCommand{}), args...)
+	return coretesting.Stdout(ctx), err
+}
+
+func (s *WatchSuite) TestInit(c *gc.C) {
+	_, err := s.run(c, "--deltas")
+	c.Assert(err, gc.ErrorMatches, "--deltas can only be used with --watch")
+	_, err = s.run(c, "--watch", "--deltas", "mysql")
+	c.Assert(err, gc.ErrorMatches, "filter patterns cannot be used with --deltas")
+}
+
+func (s *WatchSuite) TestWatchRedisplaysStatus(c *gc.C) {
+	s.watcher.batches = [][]multiwatcher.Delta{
+		{{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}}},
+		{{Removed: true, Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}}},
+	}
+	out, err := s.run(c, "--watch", "--format", "yaml", "mysql")
+	c.Assert(err, gc.ErrorMatches, "watching model: watcher exhausted")
+	c.Assert(strings.Count(out, "name: watched"), gc.Equals, 2)
+	c.Assert(s.client.patternsUsed, jc.DeepEquals, []string{"mysql"})
+	c.Assert(s.client.closeCalled, jc.IsTrue)
+	c.Assert(s.watcher.stopped, jc.IsTrue)
+}
+
+func (s *WatchSuite) TestWatchDeltas(c *gc.C) {
+	s.watcher.batches = [][]multiwatcher.Delta{{
+		{Entity: &multiwatcher.ApplicationInfo{ModelUUID: "uuid", Name: "mysql"}},
+		{Entity: &multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "0"}},
+	}, {
+		{Removed: true, Entity: &multiwatcher.ApplicationInfo{ModelUUID: "uuid", Name: "mysql"}},
+	}}
+	out, err := s.run(c, "--watch", "--deltas")
+	c.Assert(err, gc.ErrorMatches, "watching model: watcher exhausted")
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 3)
+	c.Assert(lines[0], jc.HasPrefix, `["application","change",{"model-uuid":"uuid","name":"mysql"`)
+	c.Assert(lines[1], jc.HasPrefix, `["machine","change",{"model-uuid":"uuid","id":"0"`)
+	c.Assert(lines[2], jc.HasPrefix, `["application","remove",{"model-uuid":"uuid","name":"mysql"`)
+	c.Assert(s.client.patternsUsed, gc.IsNil)
+}
+
+func (s *WatchSuite) TestWatchNotSupported(c *gc.C) {
+	s.PatchValue(&watchAllForStatus, func(statusAPI) (allWatcher, error) {
+		return nil, errors.NotSupportedf("watching status")
+	})
+	_, err := s.run(c, "--watch")
+	c.Assert(err, gc.ErrorMatches, "watching model: watching status not supported")
+}
+
+// fakeAllWatcher returns each of its batches of deltas in turn, and
+// then an error.
+type fakeAllWatcher struct {
+	batches [][]multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.batches) == 0 {
+		return nil, errors.New("watcher exhausted")
+	}
+	deltas := w.batches[0]
+	w.batches = w.batches[1:]
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
@@ -14,10 +14,30 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/helptopics"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/storage"
 )
 
+func init() {
+	helptopics.RegisterExamples("add-storage",
+		helptopics.Example{
+			Description: `Add 3 ebs storage instances for "data" storage to unit u/0:`,
+			Commands:    []string{"juju add-storage u/0 data=ebs,1024,3"},
+		},
+		helptopics.Example{
+			Description: `Add 1 storage instance for "data" storage to unit u/0, using the
+default model provider pool:`,
+			Commands: []string{"juju add-storage u/0 data=1"},
+		},
+		helptopics.Example{
+			Description: `Add 2 storage instances of at least 10G for "logs" storage, and one
+"data" storage instance from the "ebs-ssd" pool, to unit mysql/1:`,
+			Commands: []string{"juju add-storage mysql/1 logs=2,10G data=ebs-ssd"},
+		},
+	)
+}
+
 // NewAddCommand returns a command used to add unit storage.
 func NewAddCommand() cmd.Command {
 	cmd := &addCommand{}
@@ -95,6 +115,9 @@ type addCommand struct {
 
 // Init implements Command.Init.
 func (c *addCommand) Init(args []string) (err error) {
+	defer func() {
+		err = helptopics.WithExamples(err, "add-storage")
+	}()
 	if len(args) < 2 {
 		return errors.New("add-storage requires a unit and a storage directive")
 	}
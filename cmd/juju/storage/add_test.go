@@ -78,11 +78,13 @@ var errorTsts = []tstData{
 	},
 }
 
+const seeExamples = "\nsee: juju help examples add-storage"
+
 func (s *addSuite) TestAddArgs(c *gc.C) {
 	for i, t := range errorTsts {
 		c.Logf("test %d for %q", i, t.args)
 		s.args = t.args
-		s.assertAddErrorOutput(c, t.expectedErr, "", visibleErrorMessage(t.visibleErr))
+		s.assertAddErrorOutput(c, t.expectedErr, "", visibleErrorMessage(t.visibleErr+seeExamples))
 	}
 }
 
@@ -90,7 +92,7 @@ func (s *addSuite) TestAddInvalidUnit(c *gc.C) {
 	s.args = []string{"tst-123", "data=676"}
 
 	expectedErr := `unit name "tst-123" not valid`
-	s.assertAddErrorOutput(c, expectedErr, "", visibleErrorMessage(expectedErr))
+	s.assertAddErrorOutput(c, expectedErr, "", visibleErrorMessage(expectedErr+seeExamples))
 }
 
 var validArgs = [][]string{
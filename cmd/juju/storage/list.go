@@ -9,6 +9,7 @@ import (
 	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -29,6 +30,7 @@ List information about storage instances.
 type listCommand struct {
 	StorageCommandBase
 	out        cmd.Output
+	fields     common.FieldSelector
 	ids        []string
 	filesystem bool
 	volume     bool
@@ -55,7 +57,7 @@ func (c *listCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.StorageCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.fields.AddOutputFlags(f, &c.out, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": formatListTabular,
@@ -91,6 +91,19 @@ storage:
 `[1:])
 }
 
+func (s *ListSuite) TestListFields(c *gc.C) {
+	s.assertValidList(
+		c,
+		[]string{"--format", "json", "--fields", "storage.*.kind,storage.shared-fs/0.persistent"},
+		`{"storage":{"db-dir/1000":{"kind":"block"},"db-dir/1100":{"kind":"block"},"shared-fs/0":{"kind":"filesystem","persistent":true}}}
+`)
+}
+
+func (s *ListSuite) TestListFieldsTabular(c *gc.C) {
+	_, err := s.runList(c, []string{"--format", "tabular", "--fields", "storage"})
+	c.Assert(err, gc.ErrorMatches, "--fields cannot be used with tabular format")
+}
+
 func (s *ListSuite) TestListOwnerStorageIdSort(c *gc.C) {
 	s.assertValidList(
 		c,
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -62,6 +63,7 @@ type poolListCommand struct {
 	Providers  []string
 	Names      []string
 	out        cmd.Output
+	fields     common.FieldSelector
 }
 
 // Init implements Command.Init.
@@ -85,7 +87,7 @@ func (c *poolListCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.Providers), "provider", "Only show pools of these provider types")
 	f.Var(cmd.NewAppendStringsValue(&c.Names), "name", "Only show pools with these names")
 
-	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+	c.fields.AddOutputFlags(f, &c.out, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"tabular": formatPoolListTabular,
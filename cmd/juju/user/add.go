@@ -38,6 +38,7 @@ Examples:
 
 See also: 
     register
+    create-registration-token
     grant
     users
     show-user
@@ -138,39 +139,20 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		displayName = fmt.Sprintf("%s (%s)", c.DisplayName, c.User)
 	}
 
-	// Generate the base64-encoded string for the user to pass to
-	// "juju register". We marshal the information using ASN.1
-	// to keep the size down, since we need to encode binary data.
 	controllerDetails, err := c.ClientStore().ControllerByName(c.ControllerName())
 	if err != nil {
 		return errors.Trace(err)
 	}
-	registrationInfo := jujuclient.RegistrationInfo{
+	base64RegistrationData, err := encodeRegistrationInfo(jujuclient.RegistrationInfo{
 		User:           c.User,
 		Addrs:          controllerDetails.APIEndpoints,
 		SecretKey:      secretKey,
 		ControllerName: c.ControllerName(),
-	}
-	registrationData, err := asn1.Marshal(registrationInfo)
+	})
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	// Use URLEncoding so we don't get + or / in the string,
-	// and pad with zero bytes so we don't get =; this all
-	// makes it easier to copy & paste in a terminal.
-	//
-	// The embedded ASN.1 data is length-encoded, so the
-	// padding will not complicate decoding.
-	remainder := len(registrationData) % 3
-	for remainder > 0 {
-		registrationData = append(registrationData, 0)
-		remainder--
-	}
-	base64RegistrationData := base64.URLEncoding.EncodeToString(
-		registrationData,
-	)
-
 	fmt.Fprintf(ctx.Stdout, "User %q added\n", displayName)
 	for _, modelName := range modelNames {
 		fmt.Fprintf(ctx.Stdout, "User %q granted %s access to model %q\n", displayName, c.ModelAccess, modelName)
@@ -187,3 +169,26 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 
 	return nil
 }
+
+// encodeRegistrationInfo generates the base64-encoded string for a user
+// to pass to "juju register". We marshal the information using ASN.1
+// to keep the size down, since we need to encode binary data.
+func encodeRegistrationInfo(info jujuclient.RegistrationInfo) (string, error) {
+	registrationData, err := asn1.Marshal(info)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Use URLEncoding so we don't get + or / in the string,
+	// and pad with zero bytes so we don't get =; this all
+	// makes it easier to copy & paste in a terminal.
+	//
+	// The embedded ASN.1 data is length-encoded, so the
+	// padding will not complicate decoding.
+	remainder := len(registrationData) % 3
+	for remainder > 0 {
+		registrationData = append(registrationData, 0)
+		remainder--
+	}
+	return base64.URLEncoding.EncodeToString(registrationData), nil
+}
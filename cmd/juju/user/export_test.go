@@ -15,6 +15,10 @@ type AddCommand struct {
 	*addCommand
 }
 
+type CreateRegistrationCommand struct {
+	*createRegistrationCommand
+}
+
 type RemoveCommand struct {
 	*removeCommand
 }
@@ -42,6 +46,12 @@ func NewAddCommandForTest(api AddUserAPI, store jujuclient.ClientStore, modelApi
 	return modelcmd.WrapController(c), &AddCommand{c}
 }
 
+func NewCreateRegistrationCommandForTest(api CreateRegistrationAPI, store jujuclient.ClientStore) (cmd.Command, *CreateRegistrationCommand) {
+	c := &createRegistrationCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c), &CreateRegistrationCommand{c}
+}
+
 func NewRemoveCommandForTest(api RemoveUserAPI, store jujuclient.ClientStore) (cmd.Command, *RemoveCommand) {
 	c := &removeCommand{api: api}
 	c.SetClientStore(store)
@@ -103,3 +113,11 @@ func NewListCommandForTest(api UserInfoAPI, store jujuclient.ClientStore) cmd.Co
 	c.SetClientStore(store)
 	return modelcmd.WrapController(c)
 }
+
+// NewWhoAmICommandForTest returns a whoami command which uses the given
+// function to obtain the API for each controller.
+func NewWhoAmICommandForTest(newAPIFunc func(string) (WhoAmIAPI, error), store jujuclient.ClientStore) cmd.Command {
+	c := &whoAmICommand{newAPIFunc: newAPIFunc}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
@@ -22,9 +23,17 @@ After login, a token ("macaroon") will become active. It has an expiration
 time of 24 hours. Upon expiration, no further Juju commands can be issued
 and the user will be prompted to log in again.
 
+If the client store is encrypted, the credentials it holds cannot be used
+until it is unlocked with the --unlock option, which prompts for the store's
+passphrase. The store stays unlocked until the user's session ends. If the
+store is not yet encrypted, --unlock prompts for a new passphrase and
+encrypts it. Alternatively, the store's key may be provided by a keychain or
+agent in the JUJU_STORE_KEY environment variable.
+
 Examples:
 
     juju login bob
+    juju login --unlock
 
 See also: enable-user
           disable-user
@@ -50,6 +59,7 @@ type loginCommand struct {
 	modelcmd.ControllerCommandBase
 	newLoginAPI func(juju.NewAPIConnectionParams) (LoginAPI, error)
 	User        string
+	Unlock      bool
 }
 
 // Info implements Command.Info.
@@ -62,6 +72,11 @@ func (c *loginCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *loginCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Unlock, "unlock", false, "Unlock the encrypted client store")
+}
+
 // Init implements Command.Init.
 func (c *loginCommand) Init(args []string) error {
 	var err error
@@ -69,6 +84,9 @@ func (c *loginCommand) Init(args []string) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.Unlock && c.User != "" {
+		return errors.New("cannot specify a user with --unlock")
+	}
 	return nil
 }
 
@@ -80,6 +98,9 @@ type LoginAPI interface {
 
 // Run implements Command.Run.
 func (c *loginCommand) Run(ctx *cmd.Context) error {
+	if c.Unlock {
+		return c.unlockStore(ctx)
+	}
 	controllerName := c.ControllerName()
 	store := c.ClientStore()
 
@@ -156,3 +177,49 @@ Run "juju logout" first before attempting to log in as a different user.
 	ctx.Infof("You are now logged in to %q as %q.", controllerName, userTag.Canonical())
 	return nil
 }
+
+// unlockStore unlocks the encrypted client store, or encrypts it if it
+// is not yet encrypted.
+func (c *loginCommand) unlockStore(ctx *cmd.Context) error {
+	encrypted, err := jujuclient.StoreEncrypted()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if encrypted {
+		fmt.Fprint(ctx.Stderr, "store passphrase: ")
+		passphrase, err := readPassword(ctx.Stdin)
+		fmt.Fprintln(ctx.Stderr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := jujuclient.UnlockStore(passphrase); err != nil {
+			return errors.Annotate(err, "cannot unlock client store")
+		}
+		ctx.Infof("The client store is now unlocked.")
+		return nil
+	}
+
+	fmt.Fprint(ctx.Stderr, "new store passphrase: ")
+	passphrase, err := readPassword(ctx.Stdin)
+	fmt.Fprintln(ctx.Stderr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if passphrase == "" {
+		return errors.New("you must enter a passphrase")
+	}
+	fmt.Fprint(ctx.Stderr, "type store passphrase again: ")
+	verify, err := readPassword(ctx.Stdin)
+	fmt.Fprintln(ctx.Stderr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if passphrase != verify {
+		return errors.New("passphrases do not match")
+	}
+	if err := jujuclient.EncryptStore(passphrase); err != nil {
+		return errors.Annotate(err, "cannot encrypt client store")
+	}
+	ctx.Infof("The client store is now encrypted and unlocked.")
+	return nil
+}
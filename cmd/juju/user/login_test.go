@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/cmd/juju/user"
 	"github.com/juju/juju/juju"
+	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -69,6 +70,9 @@ func (s *LoginCommandSuite) TestInit(c *gc.C) {
 		}, {
 			args:        []string{"foobar", "extra"},
 			errorString: `unrecognized args: \["extra"\]`,
+		}, {
+			args:        []string{"--unlock", "foobar"},
+			errorString: "cannot specify a user with --unlock",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -139,6 +143,55 @@ func (s *LoginCommandSuite) TestLoginFail(c *gc.C) {
 	s.assertStoreMacaroon(c, "current-user@local", nil)
 }
 
+func (s *LoginCommandSuite) TestUnlockEncryptsStore(c *gc.C) {
+	s.PatchEnvironment(osenv.XDGRuntimeDir, c.MkDir())
+	context, _, err := s.run(c, "passphrase\npassphrase\n", "--unlock")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(context), gc.Equals, `
+new store passphrase: 
+type store passphrase again: 
+The client store is now encrypted and unlocked.
+`[1:],
+	)
+	encrypted, err := jujuclient.StoreEncrypted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(encrypted, jc.IsTrue)
+}
+
+func (s *LoginCommandSuite) TestUnlockPassphrasesDiffer(c *gc.C) {
+	s.PatchEnvironment(osenv.XDGRuntimeDir, c.MkDir())
+	_, _, err := s.run(c, "passphrase\nfoobar\n", "--unlock")
+	c.Assert(err, gc.ErrorMatches, "passphrases do not match")
+	encrypted, err := jujuclient.StoreEncrypted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(encrypted, jc.IsFalse)
+}
+
+func (s *LoginCommandSuite) TestUnlock(c *gc.C) {
+	s.PatchEnvironment(osenv.XDGRuntimeDir, c.MkDir())
+	err := jujuclient.EncryptStore("passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuclient.LockStore()
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, _, err := s.run(c, "passphrase\n", "--unlock")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(context), gc.Equals, `
+store passphrase: 
+The client store is now unlocked.
+`[1:],
+	)
+}
+
+func (s *LoginCommandSuite) TestUnlockIncorrectPassphrase(c *gc.C) {
+	s.PatchEnvironment(osenv.XDGRuntimeDir, c.MkDir())
+	err := jujuclient.EncryptStore("passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.run(c, "foobar\n", "--unlock")
+	c.Assert(err, gc.ErrorMatches, "cannot unlock client store: incorrect passphrase")
+}
+
 type mockLoginAPI struct {
 	mockChangePasswordAPI
 }
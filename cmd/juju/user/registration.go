@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+var createRegistrationSummary = `
+Creates a new registration token for an existing Juju user.`[1:]
+
+var createRegistrationDetails = `
+A ` + "`juju register`" + ` command will be printed, which may be executed by the
+user to register the controller with their client and set a new password.
+The command may only be used until the token expires, which by default is
+24 hours after it is created; any token previously created for the user
+can no longer be used.
+
+This is useful when a user has lost their password, or did not complete
+the registration process started by ` + "`juju add-user`" + ` before their
+token expired.
+
+Examples:
+    juju create-registration-token bob
+    juju create-registration-token --expires 1h bob
+
+See also:
+    add-user
+    register
+    change-user-password`[1:]
+
+// CreateRegistrationAPI defines the usermanager API methods that the
+// create-registration-token command uses.
+type CreateRegistrationAPI interface {
+	CreateRegistrationToken(username string, lifetime time.Duration) ([]byte, time.Time, error)
+	Close() error
+}
+
+// NewCreateRegistrationCommand returns a command which creates a
+// registration token for an existing user.
+func NewCreateRegistrationCommand() cmd.Command {
+	return modelcmd.WrapController(&createRegistrationCommand{})
+}
+
+// createRegistrationCommand creates registration tokens for existing
+// users of a Juju controller.
+type createRegistrationCommand struct {
+	modelcmd.ControllerCommandBase
+	api     CreateRegistrationAPI
+	User    string
+	Expires time.Duration
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *createRegistrationCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.DurationVar(&c.Expires, "expires", 0, "How long the registration token may be used for")
+}
+
+// Info implements Command.Info.
+func (c *createRegistrationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-registration-token",
+		Args:    "<user name>",
+		Purpose: createRegistrationSummary,
+		Doc:     createRegistrationDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *createRegistrationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no username supplied")
+	}
+	if c.Expires < 0 {
+		return errors.New("--expires must not be negative")
+	}
+	c.User, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *createRegistrationCommand) Run(ctx *cmd.Context) error {
+	api := c.api
+	if api == nil {
+		var err error
+		api, err = c.NewUserManagerAPIClient()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer api.Close()
+	}
+
+	secretKey, expiry, err := api.CreateRegistrationToken(c.User, c.Expires)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+
+	controllerDetails, err := c.ClientStore().ControllerByName(c.ControllerName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	base64RegistrationData, err := encodeRegistrationInfo(jujuclient.RegistrationInfo{
+		User:           c.User,
+		Addrs:          controllerDetails.APIEndpoints,
+		SecretKey:      secretKey,
+		ControllerName: c.ControllerName(),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	fmt.Fprintf(ctx.Stdout, "Please send this command to %v:\n", c.User)
+	fmt.Fprintf(ctx.Stdout, "    juju register %s\n", base64RegistrationData)
+	fmt.Fprintf(ctx.Stdout, "\nThe command may be used until %s.\n", expiry.UTC().Format(time.RFC1123))
+	return nil
+}
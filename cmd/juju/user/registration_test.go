@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/testing"
+)
+
+type CreateRegistrationCommandSuite struct {
+	BaseSuite
+	mockAPI *mockCreateRegistrationAPI
+}
+
+var _ = gc.Suite(&CreateRegistrationCommandSuite{})
+
+func (s *CreateRegistrationCommandSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mockAPI = &mockCreateRegistrationAPI{
+		secretKey: []byte(strings.Repeat("X", 32)),
+		expiry:    time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *CreateRegistrationCommandSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command, _ := user.NewCreateRegistrationCommandForTest(s.mockAPI, s.store)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *CreateRegistrationCommandSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		user        string
+		expires     time.Duration
+		errorString string
+	}{{
+		errorString: "no username supplied",
+	}, {
+		args: []string{"foobar"},
+		user: "foobar",
+	}, {
+		args:    []string{"foobar", "--expires", "1h"},
+		user:    "foobar",
+		expires: time.Hour,
+	}, {
+		args:        []string{"foobar", "--expires", "-1h"},
+		errorString: "--expires must not be negative",
+	}, {
+		args:        []string{"foobar", "extra"},
+		errorString: `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d (%q)", i, test.args)
+		wrappedCommand, command := user.NewCreateRegistrationCommandForTest(s.mockAPI, s.store)
+		err := testing.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(command.User, gc.Equals, test.user)
+			c.Check(command.Expires, gc.Equals, test.expires)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *CreateRegistrationCommandSuite) TestCreateRegistration(c *gc.C) {
+	context, err := s.run(c, "foobar", "--expires", "1h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.username, gc.Equals, "foobar")
+	c.Assert(s.mockAPI.lifetime, gc.Equals, time.Hour)
+	expected := `
+Please send this command to foobar:
+    juju register MEYTBmZvb2JhcjAREw8xMjcuMC4wLjE6MTIzNDUEIFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYEwd0ZXN0aW5n
+
+The command may be used until Sat, 01 Oct 2016 12:00:00 UTC.
+`[1:]
+	c.Assert(testing.Stdout(context), gc.Equals, expected)
+	c.Assert(testing.Stderr(context), gc.Equals, "")
+}
+
+func (s *CreateRegistrationCommandSuite) TestBlockCreateRegistration(c *gc.C) {
+	s.mockAPI.blocked = true
+	_, err := s.run(c, "foobar")
+	c.Assert(err, gc.ErrorMatches, cmd.ErrSilent.Error())
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*To unblock changes.*")
+}
+
+func (s *CreateRegistrationCommandSuite) TestCreateRegistrationErrorResponse(c *gc.C) {
+	s.mockAPI.failMessage = `user "foobar" is disabled`
+	_, err := s.run(c, "foobar")
+	c.Assert(err, gc.ErrorMatches, s.mockAPI.failMessage)
+}
+
+type mockCreateRegistrationAPI struct {
+	failMessage string
+	blocked     bool
+	secretKey   []byte
+	expiry      time.Time
+
+	username string
+	lifetime time.Duration
+}
+
+func (m *mockCreateRegistrationAPI) CreateRegistrationToken(username string, lifetime time.Duration) ([]byte, time.Time, error) {
+	if m.blocked {
+		return nil, time.Time{}, common.OperationBlockedError("the operation has been blocked")
+	}
+	m.username = username
+	m.lifetime = lifetime
+	if m.failMessage != "" {
+		return nil, time.Time{}, errors.New(m.failMessage)
+	}
+	return m.secretKey, m.expiry, nil
+}
+
+func (*mockCreateRegistrationAPI) Close() error {
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/usermanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+var usageWhoAmISummary = `
+Shows the identity with which you are logged in to controllers.`[1:]
+
+var usageWhoAmIDetails = `
+Asks the controller who you are logged in as, and reports the access you
+have been granted to the controller and to each of its models, along with
+when your login expires.
+
+By default, the current controller is queried. With --all, every
+registered controller is queried in turn; controllers that cannot be
+reached, or that you are not logged in to, are reported without
+stopping the others.
+
+Examples:
+    juju whoami
+    juju whoami -c mycontroller --format yaml
+    juju whoami --all
+
+See also:
+    login
+    logout
+    show-user`[1:]
+
+// WhoAmIAPI defines the API methods that the whoami command uses.
+type WhoAmIAPI interface {
+	WhoAmI() (params.WhoAmIResult, error)
+	Close() error
+}
+
+// NewWhoAmICommand returns a command which reports the identity with
+// which the client is logged in to controllers.
+func NewWhoAmICommand() cmd.Command {
+	c := &whoAmICommand{}
+	c.newAPIFunc = c.newAPI
+	return modelcmd.WrapController(c)
+}
+
+// whoAmICommand reports the identity of the logged in user.
+type whoAmICommand struct {
+	modelcmd.ControllerCommandBase
+	out        cmd.Output
+	all        bool
+	newAPIFunc func(controllerName string) (WhoAmIAPI, error)
+}
+
+// WhoAmIInfo defines the serialization behaviour of the identity
+// reported by a controller.
+type WhoAmIInfo struct {
+	User             string            `yaml:"user,omitempty" json:"user,omitempty"`
+	DisplayName      string            `yaml:"display-name,omitempty" json:"display-name,omitempty"`
+	ControllerAccess string            `yaml:"controller-access,omitempty" json:"controller-access,omitempty"`
+	Models           map[string]string `yaml:"models,omitempty" json:"models,omitempty"`
+	LastConnection   string            `yaml:"last-connection,omitempty" json:"last-connection,omitempty"`
+	LoginExpiry      string            `yaml:"login-expiry,omitempty" json:"login-expiry,omitempty"`
+	Error            string            `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *whoAmICommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "whoami",
+		Purpose: usageWhoAmISummary,
+		Doc:     usageWhoAmIDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *whoAmICommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.all, "all", false, "Query all registered controllers")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatWhoAmITabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *whoAmICommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *whoAmICommand) newAPI(controllerName string) (WhoAmIAPI, error) {
+	root, err := c.JujuCommandBase.NewAPIRoot(c.ClientStore(), controllerName, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return usermanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *whoAmICommand) Run(ctx *cmd.Context) error {
+	controllerNames := []string{c.ControllerName()}
+	if c.all {
+		controllers, err := c.ClientStore().AllControllers()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		controllerNames = controllerNames[:0]
+		for name := range controllers {
+			controllerNames = append(controllerNames, name)
+		}
+		sort.Strings(controllerNames)
+	}
+
+	output := make(map[string]WhoAmIInfo)
+	for _, controllerName := range controllerNames {
+		info, err := c.whoAmI(controllerName)
+		if err != nil {
+			if !c.all {
+				return errors.Trace(err)
+			}
+			info = WhoAmIInfo{Error: err.Error()}
+		}
+		output[controllerName] = info
+	}
+	return c.out.Write(ctx, output)
+}
+
+// whoAmI queries the named controller for the identity with which the
+// client is logged in to it.
+func (c *whoAmICommand) whoAmI(controllerName string) (WhoAmIInfo, error) {
+	// Don't connect to controllers we're not logged in to, as doing
+	// so may prompt for credentials.
+	if _, err := c.ClientStore().AccountDetails(controllerName); errors.IsNotFound(err) {
+		return WhoAmIInfo{}, errors.New("not logged in")
+	} else if err != nil {
+		return WhoAmIInfo{}, errors.Trace(err)
+	}
+	api, err := c.newAPIFunc(controllerName)
+	if err != nil {
+		return WhoAmIInfo{}, errors.Trace(err)
+	}
+	defer api.Close()
+	result, err := api.WhoAmI()
+	if err != nil {
+		return WhoAmIInfo{}, errors.Trace(err)
+	}
+
+	userTag, err := names.ParseUserTag(result.UserTag)
+	if err != nil {
+		return WhoAmIInfo{}, errors.Trace(err)
+	}
+	info := WhoAmIInfo{
+		User:             userTag.Canonical(),
+		DisplayName:      result.DisplayName,
+		ControllerAccess: result.ControllerAccess,
+	}
+	for _, model := range result.Models {
+		ownerTag, err := names.ParseUserTag(model.OwnerTag)
+		if err != nil {
+			return WhoAmIInfo{}, errors.Trace(err)
+		}
+		if info.Models == nil {
+			info.Models = make(map[string]string)
+		}
+		info.Models[jujuclient.JoinOwnerModelName(ownerTag, model.Name)] = model.Access
+	}
+	if result.LastConnection != nil {
+		info.LastConnection = result.LastConnection.UTC().Format(time.RFC3339)
+	}
+	if result.MacaroonExpiry != nil {
+		info.LoginExpiry = result.MacaroonExpiry.UTC().Format(time.RFC3339)
+	}
+	return info, nil
+}
+
+// formatWhoAmITabular takes an interface{} to adhere to the
+// cmd.Formatter interface.
+func formatWhoAmITabular(value interface{}) ([]byte, error) {
+	infos, ok := value.(map[string]WhoAmIInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	controllerNames := make([]string, 0, len(infos))
+	for name := range infos {
+		controllerNames = append(controllerNames, name)
+	}
+	sort.Strings(controllerNames)
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "CONTROLLER\tUSER\tACCESS\tLOGIN EXPIRES\n")
+	var models []string
+	for _, controllerName := range controllerNames {
+		info := infos[controllerName]
+		if info.Error != "" {
+			fmt.Fprintf(tw, "%s\t-\t-\terror: %s\n", controllerName, info.Error)
+			continue
+		}
+		expiry := info.LoginExpiry
+		if expiry == "" {
+			expiry = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", controllerName, info.User, info.ControllerAccess, expiry)
+		for modelName, access := range info.Models {
+			models = append(models, fmt.Sprintf("%s\t%s\n", modelcmd.JoinModelName(controllerName, modelName), access))
+		}
+	}
+	if len(models) > 0 {
+		sort.Strings(models)
+		fmt.Fprintf(tw, "\nMODEL\tACCESS\n")
+		for _, model := range models {
+			fmt.Fprint(tw, model)
+		}
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type WhoAmICommandSuite struct {
+	BaseSuite
+	apis map[string]*fakeWhoAmIAPI
+}
+
+var _ = gc.Suite(&WhoAmICommandSuite{})
+
+func (s *WhoAmICommandSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	expiry := time.Date(2016, 10, 2, 12, 0, 0, 0, time.UTC)
+	s.apis = map[string]*fakeWhoAmIAPI{
+		"testing": {result: params.WhoAmIResult{
+			UserTag:          "user-current-user@local",
+			DisplayName:      "Current User",
+			ControllerAccess: "login",
+			Models: []params.WhoAmIModel{{
+				ModelTag: testing.ModelTag.String(),
+				Name:     "web",
+				OwnerTag: "user-bob@local",
+				Access:   "write",
+			}},
+			LastConnection: &lastConnection,
+			MacaroonExpiry: &expiry,
+		}},
+		"other": {err: errors.New("connection refused")},
+	}
+	s.store.Controllers["other"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["other"] = jujuclient.AccountDetails{User: "admin@local"}
+	s.store.Controllers["unknown"] = jujuclient.ControllerDetails{}
+}
+
+func (s *WhoAmICommandSuite) newWhoAmICommand() cmd.Command {
+	return user.NewWhoAmICommandForTest(func(controllerName string) (user.WhoAmIAPI, error) {
+		api, ok := s.apis[controllerName]
+		if !ok {
+			return nil, errors.NotFoundf("controller %s", controllerName)
+		}
+		return api, nil
+	}, s.store)
+}
+
+func (s *WhoAmICommandSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(s.newWhoAmICommand(), []string{"extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *WhoAmICommandSuite) TestWhoAmI(c *gc.C) {
+	context, err := testing.RunCommand(c, s.newWhoAmICommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"CONTROLLER  USER                ACCESS  LOGIN EXPIRES\n"+
+		"testing     current-user@local  login   2016-10-02T12:00:00Z\n"+
+		"\n"+
+		"MODEL                  ACCESS\n"+
+		"testing:bob@local/web  write\n"+
+		"\n",
+	)
+	c.Assert(s.apis["testing"].closed, jc.IsTrue)
+}
+
+func (s *WhoAmICommandSuite) TestWhoAmIYAML(c *gc.C) {
+	context, err := testing.RunCommand(c, s.newWhoAmICommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	var output map[string]user.WhoAmIInfo
+	err = goyaml.Unmarshal([]byte(testing.Stdout(context)), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.DeepEquals, map[string]user.WhoAmIInfo{
+		"testing": {
+			User:             "current-user@local",
+			DisplayName:      "Current User",
+			ControllerAccess: "login",
+			Models:           map[string]string{"bob@local/web": "write"},
+			LastConnection:   "2014-01-01T00:00:00Z",
+			LoginExpiry:      "2016-10-02T12:00:00Z",
+		},
+	})
+}
+
+func (s *WhoAmICommandSuite) TestWhoAmIError(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newWhoAmICommand(), "-c", "other")
+	c.Assert(err, gc.ErrorMatches, "connection refused")
+}
+
+func (s *WhoAmICommandSuite) TestWhoAmIAll(c *gc.C) {
+	context, err := testing.RunCommand(c, s.newWhoAmICommand(), "--all", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		`{"other":{"error":"connection refused"},`+
+		`"testing":{"user":"current-user@local","display-name":"Current User","controller-access":"login",`+
+		`"models":{"bob@local/web":"write"},"last-connection":"2014-01-01T00:00:00Z","login-expiry":"2016-10-02T12:00:00Z"},`+
+		`"unknown":{"error":"not logged in"}}`+"\n",
+	)
+}
+
+func (s *WhoAmICommandSuite) TestWhoAmIAllTabular(c *gc.C) {
+	context, err := testing.RunCommand(c, s.newWhoAmICommand(), "--all")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"CONTROLLER  USER                ACCESS  LOGIN EXPIRES\n"+
+		"other       -                   -       error: connection refused\n"+
+		"testing     current-user@local  login   2016-10-02T12:00:00Z\n"+
+		"unknown     -                   -       error: not logged in\n"+
+		"\n"+
+		"MODEL                  ACCESS\n"+
+		"testing:bob@local/web  write\n"+
+		"\n",
+	)
+}
+
+type fakeWhoAmIAPI struct {
+	result params.WhoAmIResult
+	err    error
+	closed bool
+}
+
+func (f *fakeWhoAmIAPI) WhoAmI() (params.WhoAmIResult, error) {
+	return f.result, f.err
+}
+
+func (f *fakeWhoAmIAPI) Close() error {
+	f.closed = true
+	return nil
+}
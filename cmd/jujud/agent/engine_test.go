@@ -40,6 +40,7 @@ var (
 	aliveModelWorkers = []string{
 		"charm-revision-updater",
 		"compute-provisioner",
+		"credential-valid-flag",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -52,13 +53,16 @@ var (
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
+		"storage-provisioner-flag",
 		"unit-assigner",
 	}
 	migratingModelWorkers = []string{
+		"credential-valid-flag",
 		"environ-tracker",
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"storage-provisioner-flag",
 	}
 	// ReallyLongTimeout should be long enough for the model-tracker
 	// tests that depend on a hosted model; its backing state is not
@@ -62,6 +62,7 @@ import (
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/storage/looputil"
@@ -69,7 +70,10 @@ import (
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/alerter"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/backupscheduler"
+	"github.com/juju/juju/worker/canaryupgrader"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
@@ -80,8 +84,10 @@ import (
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/mongoupgrader"
+	"github.com/juju/juju/worker/operationscheduler"
 	"github.com/juju/juju/worker/peergrouper"
 	"github.com/juju/juju/worker/provisioner"
+	"github.com/juju/juju/worker/schemaupgrader"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/txnpruner"
 	"github.com/juju/juju/worker/upgradesteps"
@@ -209,10 +215,12 @@ func (a *machineAgentCmd) Init(args []string) error {
 	}
 
 	// the context's stderr is set as the loggo writer in github.com/juju/cmd/logging.go
+	agentConfig := a.currentConfig.CurrentConfig()
+	maxSize, maxBackups := agent.LogRotation(agentConfig)
 	a.ctx.Stderr = &lumberjack.Logger{
-		Filename:   agent.LogFilename(a.currentConfig.CurrentConfig()),
-		MaxSize:    300, // megabytes
-		MaxBackups: 2,
+		Filename:   agent.LogFilename(agentConfig),
+		MaxSize:    maxSize, // megabytes
+		MaxBackups: maxBackups,
 	}
 
 	return nil
@@ -854,6 +862,12 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 		return nil, errors.Annotate(err, "machine lookup")
 	}
 
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot fetch the controller config")
+	}
+	state.SetSlowOperationThreshold(controllerConfig.SlowOperationThreshold())
+
 	runner := newConnRunner(st)
 	singularRunner, err := newSingularStateRunner(runner, st, m)
 	if err != nil {
@@ -939,6 +953,10 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 				return newCertificateUpdater(m, agentConfig, st, st, stateServingSetter), nil
 			})
 
+			a.startWorkerAfterUpgrade(singularRunner, "schemaupgrader", func() (worker.Worker, error) {
+				return schemaupgrader.New(st), nil
+			})
+
 			a.startWorkerAfterUpgrade(singularRunner, "dblogpruner", func() (worker.Worker, error) {
 				return dblogpruner.New(st, dblogpruner.NewLogPruneParams()), nil
 			})
@@ -946,6 +964,44 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+				backupPaths := backups.Paths{
+					DataDir: agentConfig.DataDir(),
+					LogsDir: agentConfig.LogDir(),
+				}
+				return backupscheduler.New(backupscheduler.Config{
+					Backend:      st,
+					Backups:      backupscheduler.NewStateBackups(st, backupPaths, a.machineId),
+					Clock:        clock.WallClock,
+					PollInterval: 10 * time.Minute,
+				})
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "alerter", func() (worker.Worker, error) {
+				return alerter.New(alerter.Config{
+					Backend:  alerter.NewStateBackend(st),
+					Notifier: alerter.NewNotifier(st),
+					Clock:    clock.WallClock,
+					Interval: time.Minute,
+				})
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "canaryupgrader", func() (worker.Worker, error) {
+				return canaryupgrader.New(canaryupgrader.Config{
+					Backend:  canaryupgrader.NewStateBackend(st),
+					Clock:    clock.WallClock,
+					Interval: time.Minute,
+				})
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "operationscheduler", func() (worker.Worker, error) {
+				return operationscheduler.New(operationscheduler.Config{
+					Backend:  operationscheduler.NewStateBackend(st),
+					Clock:    clock.WallClock,
+					Interval: time.Minute,
+				})
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1055,6 +1111,7 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 	}
 
 	server, err := apiserver.NewServer(st, listener, apiserver.ServerConfig{
+		Clock:       clock.WallClock,
 		Cert:        cert,
 		Key:         key,
 		Tag:         tag,
@@ -1062,6 +1119,8 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 		LogDir:      logDir,
 		Validator:   a.limitLogins,
 		CertChanged: certChanged,
+		CacheReads:  true,
+		BatchTxns:   true,
 		NewObserver: newObserverFn(
 			controllerConfig,
 			clock.WallClock,
@@ -1123,6 +1182,11 @@ func newObserverFn(
 		return observer.NewRequestObserver(ctx)
 	})
 
+	// Metrics of RPC requests, exposed by the API server.
+	observerFactories = append(observerFactories, func() observer.Observer {
+		return observer.NewMetricsObserver(clock)
+	})
+
 	// Auditing observer
 	// TODO(katco): Auditing needs feature tests (lp:1604551)
 	if controllerConfig.AuditingEnabled() {
@@ -1508,6 +1572,6 @@ func metricAPI(st api.Connection) (metricsmanager.MetricsManagerClient, error) {
 // running the tests and (2) get access to the *State used internally, so that
 // tests can be run without waiting for the 5s watcher refresh time to which we would
 // otherwise be restricted.
-var newDeployContext = func(st *apideployer.State, agentConfig agent.Config) deployer.Context {
-	return deployer.NewSimpleContext(agentConfig, st)
+var newDeployContext = func(st *apideployer.State, agentConfig agent.Config) (deployer.Context, error) {
+	return deployer.NewDeployContext(agentConfig, st)
 }
@@ -49,6 +49,7 @@ import (
 	"github.com/juju/juju/worker/toolsversionchecker"
 	"github.com/juju/juju/worker/upgrader"
 	"github.com/juju/juju/worker/upgradesteps"
+	"github.com/juju/juju/worker/usagereporter"
 	"github.com/juju/utils/clock"
 	"github.com/juju/version"
 )
@@ -116,7 +117,7 @@ type ManifoldsConfig struct {
 	// running the tests and (2) get access to the *State used internally, so that
 	// tests can be run without waiting for the 5s watcher refresh time to which we would
 	// otherwise be restricted.
-	NewDeployContext func(st *apideployer.State, agentConfig coreagent.Config) deployer.Context
+	NewDeployContext func(st *apideployer.State, agentConfig coreagent.Config) (deployer.Context, error)
 
 	// Clock supplies timekeeping services to various workers.
 	Clock clock.Clock
@@ -158,9 +159,11 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		agentName: agent.Manifold(config.Agent),
 
 		// The introspection worker provides debugging information over
-		// an abstract domain socket - linux only (for now).
+		// an abstract domain socket - linux only (for now). On
+		// controllers, it also checks the integrity of state.
 		introspectionName: introspection.Manifold(introspection.ManifoldConfig{
 			AgentName:  agentName,
+			StateName:  stateName,
 			WorkerFunc: introspection.NewWorker,
 		}),
 
@@ -439,9 +442,25 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
 			RootDir:       config.RootDir,
+			Clock:         config.Clock,
+			PollInterval:  5 * time.Minute,
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+
+		// The usageReporter worker reports the resource usage of
+		// the machine and its units, for display by `juju top`,
+		// and the traffic of the machine's network devices, which
+		// the controller accounts if network-traffic-accounting is
+		// enabled.
+		usageReporterName: ifNotMigrating(usagereporter.Manifold(usagereporter.ManifoldConfig{
+			AgentName:      agentName,
+			APICallerName:  apiCallerName,
+			Clock:          config.Clock,
+			ReportInterval: 30 * time.Second,
+			NewFacade:      usagereporter.NewFacade,
+			NewWorker:      usagereporter.NewWorker,
+		})),
 		logForwarderName: ifFullyUpgraded(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			StateName:     stateName,
 			APICallerName: apiCallerName,
@@ -505,5 +524,6 @@ const (
 	toolsVersionCheckerName  = "tools-version-checker"
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
+	usageReporterName        = "usage-reporter"
 	logForwarderName         = "log-forwarder"
 )
@@ -77,6 +77,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"upgrade-steps-gate",
 		"upgrade-steps-runner",
 		"upgrader",
+		"usage-reporter",
 	}
 	c.Assert(keys, jc.SameContents, expectedKeys)
 }
@@ -324,6 +324,10 @@ func (s *MachineSuite) TestManageModel(c *gc.C) {
 	c.Logf("started test agent, waiting for workers...")
 	r0 := s.singularRecord.nextRunner(c)
 	r0.waitForWorker(c, "txnpruner")
+	r0.waitForWorker(c, "backupscheduler")
+	r0.waitForWorker(c, "alerter")
+	r0.waitForWorker(c, "canaryupgrader")
+	r0.waitForWorker(c, "operationscheduler")
 
 	// Check that the provisioner and firewaller are alive by doing
 	// a rudimentary check that it responds to state changes.
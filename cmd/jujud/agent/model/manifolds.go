@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
 	"github.com/juju/juju/worker/cleaner"
+	"github.com/juju/juju/worker/credentialvalidator"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/discoverspaces"
 	"github.com/juju/juju/worker/environ"
@@ -149,6 +150,20 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker: singular.NewWorker,
 		}),
 
+		// The storage-provisioner flag holds the lease that grants
+		// this controller the exclusive right to run the model's
+		// storage provisioner, which fails over to another
+		// controller independently of the model-wide lease.
+		storageProvisionerFlagName: ifNotDead(singular.Manifold(singular.ManifoldConfig{
+			ClockName:     clockName,
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Duration:      config.RunFlagDuration,
+
+			NewFacade: singular.NewWorkerFacade(storageProvisionerName),
+			NewWorker: singular.NewWorker,
+		})),
+
 		// The migration workers collaborate to run migrations;
 		// and to create a mechanism for running other workers
 		// so they can't accidentally interfere with a migration
@@ -178,6 +193,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     migrationmaster.NewWorker,
 		})),
 
+		// The credential-valid flag is set while the model's cloud
+		// credential has not been reported as invalid; workers that
+		// provision cloud resources are suspended while it is unset,
+		// and resume when the credential is updated.
+		credentialValidFlagName: ifNotDead(credentialvalidator.Manifold(credentialvalidator.ManifoldConfig{
+			APICallerName: apiCallerName,
+			NewFacade:     credentialvalidator.NewFacade,
+			NewWorker:     credentialvalidator.NewWorker,
+		})),
+
 		// Everything else should be wrapped in ifResponsible,
 		// ifNotAlive, ifNotDead, or ifNotMigrating (which also
 		// implies NotDead), to ensure that only a single
@@ -226,18 +251,18 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade: discoverspaces.NewFacade,
 			NewWorker: discoverspaces.NewWorker,
 		})),
-		computeProvisionerName: ifNotMigrating(provisioner.Manifold(provisioner.ManifoldConfig{
+		computeProvisionerName: ifNotMigrating(ifCredentialValid(provisioner.Manifold(provisioner.ManifoldConfig{
 			AgentName:          agentName,
 			APICallerName:      apiCallerName,
 			EnvironName:        environTrackerName,
 			NewProvisionerFunc: provisioner.NewEnvironProvisioner,
-		})),
-		storageProvisionerName: ifNotMigrating(storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
+		}))),
+		storageProvisionerName: ifNotMigrating(ifCredentialValid(ifStorageProvisioner(storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
 			APICallerName: apiCallerName,
 			ClockName:     clockName,
 			EnvironName:   environTrackerName,
 			Scope:         modelTag,
-		})),
+		})))),
 		firewallerName: ifNotMigrating(firewaller.Manifold(firewaller.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
@@ -299,6 +324,14 @@ var (
 		},
 	}.Decorate
 
+	// ifStorageProvisioner wraps a manifold such that it only runs
+	// while this controller holds the storage provisioner's lease.
+	ifStorageProvisioner = engine.Housing{
+		Flags: []string{
+			storageProvisionerFlagName,
+		},
+	}.Decorate
+
 	// ifNotAlive wraps a manifold such that it only runs if the
 	// responsibility flag is set and the model is Dying or Dead.
 	ifNotAlive = engine.Housing{
@@ -317,6 +350,14 @@ var (
 		},
 	}.Decorate
 
+	// ifCredentialValid wraps a manifold such that it only runs while
+	// the model's cloud credential is valid.
+	ifCredentialValid = engine.Housing{
+		Flags: []string{
+			credentialValidFlagName,
+		},
+	}.Decorate
+
 	// ifNotMigrating wraps a manifold such that it only runs if the
 	// migration-inactive flag is set; and then runs workers only
 	// within Visits to the migration fortress. To avoid redundancy,
@@ -342,6 +383,9 @@ const (
 	notDeadFlagName        = "not-dead-flag"
 	notAliveFlagName       = "not-alive-flag"
 
+	storageProvisionerFlagName = "storage-provisioner-flag"
+	credentialValidFlagName    = "credential-valid-flag"
+
 	migrationFortressName     = "migration-fortress"
 	migrationInactiveFlagName = "migration-inactive-flag"
 	migrationMasterName       = "migration-master"
@@ -38,6 +38,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"credential-valid-flag",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -53,6 +54,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
+		"storage-provisioner-flag",
 		"undertaker",
 		"unit-assigner",
 	})
@@ -99,10 +99,11 @@ func (a *UnitAgent) Init(args []string) error {
 		agentConfig := a.CurrentConfig()
 
 		// the writer in ctx.stderr gets set as the loggo writer in github.com/juju/cmd/logging.go
+		maxSize, maxBackups := agent.LogRotation(agentConfig)
 		a.ctx.Stderr = &lumberjack.Logger{
 			Filename:   agent.LogFilename(agentConfig),
-			MaxSize:    300, // megabytes
-			MaxBackups: 2,
+			MaxSize:    maxSize, // megabytes
+			MaxBackups: maxBackups,
 		}
 
 	}
@@ -222,11 +222,11 @@ func patchDeployContext(c *gc.C, st *state.State) (*fakeContext, func()) {
 		deployed: make(set.Strings),
 	}
 	orig := newDeployContext
-	newDeployContext = func(dst *apideployer.State, agentConfig agent.Config) deployer.Context {
+	newDeployContext = func(dst *apideployer.State, agentConfig agent.Config) (deployer.Context, error) {
 		ctx.st = st
 		ctx.agentConfig = agentConfig
 		ctx.inited.trigger()
-		return ctx
+		return ctx, nil
 	}
 	return ctx, func() { newDeployContext = orig }
 }
@@ -520,6 +520,10 @@ func (FakeConfig) Tag() names.Tag {
 	return names.NewMachineTag("42")
 }
 
+func (FakeConfig) Value(string) string {
+	return ""
+}
+
 type FakeAgentConfig struct {
 	AgentConf
 }
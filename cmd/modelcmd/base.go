@@ -22,10 +22,28 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju"
 	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc"
 )
 
 var errNoNameSpecified = errors.New("no name specified")
 
+// apiCallObserver, if set, is passed to every API connection opened
+// by a command; see SetAPICallObserver.
+var apiCallObserver api.CallObserver
+
+// SetAPICallObserver sets a function to be called after each API call
+// made over connections subsequently opened by commands. It is used to
+// record the operations performed by the juju client. Passing nil
+// stops calls being observed.
+func SetAPICallObserver(observer api.CallObserver) {
+	apiCallObserver = observer
+}
+
+// correlationId is sent with every API request made by commands run in
+// this process, so that the controller logs all the requests made for
+// a single command under the same id.
+var correlationId = rpc.NewCorrelationId()
+
 // CommandBase extends cmd.Command with a closeContext method.
 // It is implicitly implemented by any type that embeds JujuCommandBase.
 type CommandBase interface {
@@ -299,6 +317,8 @@ func newAPIConnectionParams(
 	}
 	dialOpts := api.DefaultDialOpts()
 	dialOpts.BakeryClient = bakery
+	dialOpts.CallObserver = apiCallObserver
+	dialOpts.CorrelationId = correlationId
 
 	openAPI := func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
 		conn, err := apiOpen(info, opts)
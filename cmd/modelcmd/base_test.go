@@ -6,6 +6,7 @@ package modelcmd_test
 import (
 	"strings"
 
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/errors"
@@ -61,6 +62,42 @@ To log back in, run the following command:
 `)
 }
 
+func (s *BaseCommandSuite) TestAPICallObserver(c *gc.C) {
+	var observed []api.CallRecord
+	modelcmd.SetAPICallObserver(func(call api.CallRecord) {
+		observed = append(observed, call)
+	})
+	defer modelcmd.SetAPICallObserver(nil)
+
+	apiOpen := func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Assert(opts.CallObserver, gc.NotNil)
+		opts.CallObserver(api.CallRecord{Facade: "Client", Method: "FullStatus"})
+		return nil, errors.New("boom")
+	}
+	var cmd modelcmd.JujuCommandBase
+	cmd.SetAPIOpen(apiOpen)
+	_, err := cmd.NewAPIRoot(s.store, "foo", "")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(observed, jc.DeepEquals, []api.CallRecord{{Facade: "Client", Method: "FullStatus"}})
+}
+
+func (s *BaseCommandSuite) TestCorrelationId(c *gc.C) {
+	var ids []string
+	apiOpen := func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		ids = append(ids, opts.CorrelationId)
+		return nil, errors.New("boom")
+	}
+	var cmd modelcmd.JujuCommandBase
+	cmd.SetAPIOpen(apiOpen)
+	for i := 0; i < 2; i++ {
+		_, err := cmd.NewAPIRoot(s.store, "foo", "")
+		c.Assert(err, gc.ErrorMatches, "boom")
+	}
+	c.Assert(ids, gc.HasLen, 2)
+	c.Assert(ids[0], gc.Not(gc.Equals), "")
+	c.Assert(ids[1], gc.Equals, ids[0])
+}
+
 func (s *BaseCommandSuite) assertUnknownModel(c *gc.C, current, expectedCurrent string) {
 	s.store.Models["foo"].CurrentModel = current
 	apiOpen := func(*api.Info, api.DialOpts) (api.Connection, error) {
@@ -77,3 +77,12 @@ func (s QualifyingClientStore) RemoveModel(controllerName, modelName string) err
 	}
 	return s.ClientStore.RemoveModel(controllerName, modelName)
 }
+
+// Implements jujuclient.ModelAliasUpdater.
+func (s QualifyingClientStore) SetModelAlias(alias, controllerName, modelName string) error {
+	modelName, err := s.QualifiedModelName(controllerName, modelName)
+	if err != nil {
+		return errors.Annotatef(err, "setting model alias %q", alias)
+	}
+	return s.ClientStore.SetModelAlias(alias, controllerName, modelName)
+}
@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// LocalModelFileName is the name of the file which, when found in the
+// current directory or any of its parents, names the model that juju
+// commands run there should operate on.
+const LocalModelFileName = ".juju-model"
+
+// FindLocalModel searches the given directory and its parents for a
+// local model file, and returns the model named in the first one found
+// along with the file's path. If no local model file is found, an
+// error satisfying errors.IsNotFound is returned.
+func FindLocalModel(dir string) (modelName, path string, _ error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	for {
+		path := filepath.Join(dir, LocalModelFileName)
+		data, err := ioutil.ReadFile(path)
+		if err == nil {
+			modelName := strings.TrimSpace(string(data))
+			if modelName == "" {
+				return "", "", errors.NotValidf("empty model name in %s", path)
+			}
+			return modelName, path, nil
+		} else if !os.IsNotExist(err) {
+			return "", "", errors.Annotate(err, "reading local model file")
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", errors.NotFoundf("local model file")
+		}
+		dir = parent
+	}
+}
+
+// WriteLocalModel writes a local model file to the given directory,
+// naming the given model.
+func WriteLocalModel(dir, modelName string) error {
+	path := filepath.Join(dir, LocalModelFileName)
+	if err := ioutil.WriteFile(path, []byte(modelName+"\n"), 0644); err != nil {
+		return errors.Annotate(err, "writing local model file")
+	}
+	return nil
+}
+
+// localModel returns the model named in the local model file governing
+// the current working directory, or the empty string if there is none.
+func localModel() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	modelName, _, err := FindLocalModel(dir)
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return modelName, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testing"
+)
+
+type LocalModelSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&LocalModelSuite{})
+
+func (s *LocalModelSuite) TestFindLocalModel(c *gc.C) {
+	dir := c.MkDir()
+	err := modelcmd.WriteLocalModel(dir, "ctrl:admin@local/project")
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelName, path, err := modelcmd.FindLocalModel(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelName, gc.Equals, "ctrl:admin@local/project")
+	c.Assert(path, gc.Equals, filepath.Join(dir, ".juju-model"))
+}
+
+func (s *LocalModelSuite) TestFindLocalModelParent(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, ".juju-model"), []byte("  project \n\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	subdir := filepath.Join(dir, "a", "b")
+	err = os.MkdirAll(subdir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelName, path, err := modelcmd.FindLocalModel(subdir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelName, gc.Equals, "project")
+	c.Assert(path, gc.Equals, filepath.Join(dir, ".juju-model"))
+}
+
+func (s *LocalModelSuite) TestFindLocalModelNearest(c *gc.C) {
+	dir := c.MkDir()
+	err := modelcmd.WriteLocalModel(dir, "outer")
+	c.Assert(err, jc.ErrorIsNil)
+	subdir := filepath.Join(dir, "inner")
+	err = os.Mkdir(subdir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = modelcmd.WriteLocalModel(subdir, "inner")
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelName, _, err := modelcmd.FindLocalModel(subdir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelName, gc.Equals, "inner")
+}
+
+func (s *LocalModelSuite) TestFindLocalModelEmpty(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, ".juju-model"), []byte("\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = modelcmd.FindLocalModel(dir)
+	c.Assert(err, gc.ErrorMatches, "empty model name in .* not valid")
+}
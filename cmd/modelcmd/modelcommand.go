@@ -33,7 +33,9 @@ or specify any other model on the command line using the "-m" flag.
 
 // GetCurrentModel returns the name of the current Juju model.
 //
-// If $JUJU_MODEL is set, use that. Otherwise, get the current
+// If $JUJU_MODEL is set, use that. Otherwise, if a local model file
+// (.juju-model) is found in the current directory or one of its
+// parents, use the model named in it. Otherwise, get the current
 // controller from controllers.yaml, and then identify the current
 // model for that controller in models.yaml. If there is no current
 // controller, then an empty string is returned. It is not an error
@@ -42,13 +44,21 @@ or specify any other model on the command line using the "-m" flag.
 // If there is a current controller, but no current model for that
 // controller, then GetCurrentModel will return the string
 // "<controller>:". If there is a current model as well, it will
-// return "<controller>:<model>". Only when $JUJU_MODEL is set,
-// will the result possibly be unqualified.
+// return "<controller>:<model>". Only when $JUJU_MODEL is set, or
+// a local model file is in effect, will the result possibly be
+// unqualified.
 func GetCurrentModel(store jujuclient.ClientStore) (string, error) {
 	if model := os.Getenv(osenv.JujuModelEnvKey); model != "" {
 		return model, nil
 	}
 
+	model, err := localModel()
+	if err != nil {
+		return "", errors.Trace(err)
+	} else if model != "" {
+		return model, nil
+	}
+
 	currentController, err := store.CurrentController()
 	if errors.IsNotFound(err) {
 		return "", nil
@@ -82,7 +92,8 @@ type ModelCommand interface {
 	// name will also set the related controller name. The model name can
 	// be qualified with a controller name (controller:model), or
 	// unqualified, in which case it will be assumed to be within the
-	// current controller.
+	// current controller. An unqualified name which is a model alias
+	// refers to the alias's controller and model.
 	//
 	// SetModelName is called prior to the wrapped command's Init method
 	// with the active model name. The model name is guaranteed
@@ -130,6 +141,14 @@ func (c *ModelCommandBase) ClientStore() jujuclient.ClientStore {
 // SetModelName implements the ModelCommand interface.
 func (c *ModelCommandBase) SetModelName(modelName string) error {
 	controllerName, modelName := SplitModelName(modelName)
+	if controllerName == "" && jujuclient.ValidateModelAlias(modelName) == nil {
+		aliasController, aliasModel, err := c.store.ModelAlias(modelName)
+		if err == nil {
+			controllerName, modelName = aliasController, aliasModel
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
 	if controllerName == "" {
 		currentController, err := c.store.CurrentController()
 		if errors.IsNotFound(err) {
@@ -6,6 +6,7 @@ package modelcmd_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -84,6 +85,70 @@ func (s *ModelCommandSuite) TestGetCurrentModelBothSet(c *gc.C) {
 	c.Assert(env, gc.Equals, "admin@local/magic")
 }
 
+func (s *ModelCommandSuite) TestGetCurrentModelLocalModelFile(c *gc.C) {
+	err := s.store.UpdateModel("foo", "admin@local/mymodel", jujuclient.ModelDetails{"uuid"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentModel("foo", "admin@local/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+
+	dir := c.MkDir()
+	err = modelcmd.WriteLocalModel(dir, "foo:admin@local/project")
+	c.Assert(err, jc.ErrorIsNil)
+	subdir := filepath.Join(dir, "src")
+	err = os.Mkdir(subdir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.chdir(c, subdir)
+
+	env, err := modelcmd.GetCurrentModel(s.store)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, gc.Equals, "foo:admin@local/project")
+}
+
+func (s *ModelCommandSuite) TestGetCurrentModelJujuEnvSetOverridesLocalModelFile(c *gc.C) {
+	os.Setenv(osenv.JujuModelEnvKey, "admin@local/magic")
+	dir := c.MkDir()
+	err := modelcmd.WriteLocalModel(dir, "project")
+	c.Assert(err, jc.ErrorIsNil)
+	s.chdir(c, dir)
+
+	env, err := modelcmd.GetCurrentModel(s.store)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, gc.Equals, "admin@local/magic")
+}
+
+func (s *ModelCommandSuite) TestModelCommandInitModelAlias(c *gc.C) {
+	s.store.Controllers["baz"] = jujuclient.ControllerDetails{}
+	err := s.store.SetModelAlias("project", "baz", "bob@local/project")
+	c.Assert(err, jc.ErrorIsNil)
+	cmd, err := initTestCommand(c, s.store, "-m", "project")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.ControllerName(), gc.Equals, "baz")
+	c.Assert(cmd.ModelName(), gc.Equals, "bob@local/project")
+}
+
+func (s *ModelCommandSuite) TestModelCommandInitLocalModelAlias(c *gc.C) {
+	s.store.Controllers["baz"] = jujuclient.ControllerDetails{}
+	err := s.store.SetModelAlias("project", "baz", "bob@local/project")
+	c.Assert(err, jc.ErrorIsNil)
+	dir := c.MkDir()
+	err = modelcmd.WriteLocalModel(dir, "project")
+	c.Assert(err, jc.ErrorIsNil)
+	s.chdir(c, dir)
+
+	cmd, err := initTestCommand(c, s.store)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.ControllerName(), gc.Equals, "baz")
+	c.Assert(cmd.ModelName(), gc.Equals, "bob@local/project")
+}
+
+func (s *ModelCommandSuite) chdir(c *gc.C, dir string) {
+	wd, err := os.Getwd()
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Chdir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { os.Chdir(wd) })
+}
+
 func (s *ModelCommandSuite) TestModelCommandInitExplicit(c *gc.C) {
 	// Take model name from command line arg.
 	s.testEnsureModelName(c, "explicit", "-m", "explicit")
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -341,6 +342,18 @@ func (v *Value) attributesWithValues() (result map[string]interface{}) {
 	return result
 }
 
+// Attributes returns the sorted names of the attributes for which the
+// constraint has a value.
+func (v *Value) Attributes() []string {
+	attrValues := v.attributesWithValues()
+	result := make([]string, 0, len(attrValues))
+	for tag := range attrValues {
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result
+}
+
 // hasAny returns any attrTags for which the constraint has a non-nil value.
 func (v *Value) hasAny(attrTags ...string) []string {
 	attrValues := v.attributesWithValues()
@@ -584,6 +584,13 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestAttributes(c *gc.C) {
+	cons := constraints.MustParse("")
+	c.Check(cons.Attributes(), gc.HasLen, 0)
+	cons = constraints.MustParse("mem=4G arch=amd64 tags=")
+	c.Check(cons.Attributes(), jc.DeepEquals, []string{"arch", "mem", "tags"})
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cpu-cores=4 spaces=space1,^space2 tags=foo container=lxd instance-type=bar"
 
 var withoutTests = []struct {
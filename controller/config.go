@@ -4,7 +4,11 @@
 package controller
 
 import (
+	"net"
+	"net/mail"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -13,6 +17,7 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/charmrepository"
 )
 
 var logger = loggo.GetLogger("juju.controller")
@@ -43,6 +48,99 @@ const (
 	// NumaControlPolicyKey stores the value for this setting
 	SetNumaControlPolicyKey = "set-numa-control-policy"
 
+	// MaxStatusHistoryAge is the longest time for which the status
+	// history of an entity is kept.
+	MaxStatusHistoryAge = "max-status-history-age"
+
+	// MaxStatusHistoryEntries is the largest number of status history
+	// entries kept for each entity. Zero means no limit.
+	MaxStatusHistoryEntries = "max-status-history-entries"
+
+	// AgentPasswordMaxAge is the longest time for which a machine or
+	// unit agent may keep the same API password before it is asked to
+	// rotate it. If unset, passwords are only rotated on demand.
+	AgentPasswordMaxAge = "agent-password-max-age"
+
+	// BackupInterval is the time between scheduled backups of the
+	// controller. If unset, backups are only taken on demand.
+	BackupInterval = "backup-interval"
+
+	// BackupRetentionCount is the number of scheduled backups kept.
+	// Older scheduled backups are removed. Zero means no limit.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupTarget is where backup archives are stored: "controller"
+	// keeps them in the controller's database, "cloud" in storage
+	// provided by the controller's cloud.
+	BackupTarget = "backup-target"
+
+	// SlowOperationThreshold is the duration beyond which the
+	// controller records a mongo query or transaction as slow, for
+	// inspection through the controller's introspection socket. Zero
+	// disables the recording.
+	SlowOperationThreshold = "slow-operation-threshold"
+
+	// AlertSMTPServer is the host:port of the SMTP server through
+	// which the controller emails notifications of alerts. If unset,
+	// alert rules with an email address cannot notify.
+	AlertSMTPServer = "alert-smtp-server"
+
+	// AlertEmailFrom is the sender address of alert notification
+	// emails.
+	AlertEmailFrom = "alert-email-from"
+
+	// AlertWebhookHosts lists the hosts to which the controller may
+	// post notifications of alerts. If unset, alert rules may not
+	// have a webhook.
+	AlertWebhookHosts = "alert-webhook-hosts"
+
+	// SoftLimitModels, SoftLimitMachines and SoftLimitUnits are the
+	// numbers of models, machines and units beyond which the
+	// controller's capacity report warns that it should be scaled
+	// up. Zero means no limit.
+	SoftLimitModels   = "soft-limit-models"
+	SoftLimitMachines = "soft-limit-machines"
+	SoftLimitUnits    = "soft-limit-units"
+
+	// SoftLimitAPIWatchers is the number of watchers held for API
+	// connections by a controller machine beyond which the capacity
+	// report warns. Zero means no limit.
+	SoftLimitAPIWatchers = "soft-limit-api-watchers"
+
+	// SoftLimitDatabaseSizeMB is the size in megabytes of the
+	// controller's database beyond which the capacity report warns.
+	// Zero means no limit.
+	SoftLimitDatabaseSizeMB = "soft-limit-database-size-mb"
+
+	// SoftLimitLogRate is the number of log records written per
+	// minute beyond which the capacity report warns. Zero means no
+	// limit.
+	SoftLimitLogRate = "soft-limit-log-rate"
+
+	// MigrateLogs determines whether recent log records of a model are
+	// included when the model is migrated to another controller.
+	MigrateLogs = "migrate-logs"
+
+	// MigrationHistoryMaxAge is the longest time for which log records
+	// and status history are included when a model is migrated. If
+	// unset, all that is kept is included.
+	MigrationHistoryMaxAge = "migration-history-max-age"
+
+	// MigrationLogsMaxRecords is the largest number of log records
+	// included when a model is migrated; the most recent are included.
+	MigrationLogsMaxRecords = "migration-logs-max-records"
+
+	// MaxRelationSettingsSize is the largest total size, in bytes, of
+	// the keys and values of the settings a unit may set on a
+	// relation. Zero means no limit.
+	MaxRelationSettingsSize = "max-relation-settings-size"
+
+	// CharmRepository is the repository from which the controller's
+	// models deploy charms: "charmstore", an absolute path or file URL
+	// naming a directory on the controller, or an https URL. If unset,
+	// charms are deployed from the charm store.
+	CharmRepository = "charm-repository"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -53,6 +151,34 @@ const (
 	// Only use numactl if user specifically requests it
 	DefaultNumaControlPolicy = false
 
+	// DefaultMaxStatusHistoryAge is the default value for the
+	// MaxStatusHistoryAge config value: two weeks.
+	DefaultMaxStatusHistoryAge = 336 * time.Hour
+
+	// DefaultMaxStatusHistoryEntries is the default value for the
+	// MaxStatusHistoryEntries config value.
+	DefaultMaxStatusHistoryEntries = 100
+
+	// DefaultBackupTarget is the default value for the BackupTarget
+	// config value.
+	DefaultBackupTarget = BackupTargetController
+
+	// DefaultSlowOperationThreshold is the default value for the
+	// SlowOperationThreshold config value.
+	DefaultSlowOperationThreshold = time.Second
+
+	// DefaultAlertEmailFrom is the default value for the
+	// AlertEmailFrom config value.
+	DefaultAlertEmailFrom = "juju@localhost"
+
+	// DefaultMigrationLogsMaxRecords is the default value for the
+	// MigrationLogsMaxRecords config value.
+	DefaultMigrationLogsMaxRecords = 10000
+
+	// DefaultMaxRelationSettingsSize is the default value for the
+	// MaxRelationSettingsSize config value: 256KiB.
+	DefaultMaxRelationSettingsSize = 256 * 1024
+
 	// DefaultStatePort is the default port the controller is listening on.
 	DefaultStatePort int = 37017
 
@@ -60,6 +186,15 @@ const (
 	DefaultAPIPort int = 17070
 )
 
+const (
+	// BackupTargetController stores backup archives in the controller.
+	BackupTargetController = "controller"
+
+	// BackupTargetCloud stores backup archives in storage provided
+	// by the controller's cloud: S3 on AWS, or Swift on OpenStack.
+	BackupTargetCloud = "cloud"
+)
+
 // ControllerOnlyConfigAttributes are attributes which are only relevant
 // for a controller, never a model.
 var ControllerOnlyConfigAttributes = []string{
@@ -70,6 +205,38 @@ var ControllerOnlyConfigAttributes = []string{
 	IdentityURL,
 	IdentityPublicKey,
 	SetNumaControlPolicyKey,
+	MaxStatusHistoryAge,
+	MaxStatusHistoryEntries,
+	AgentPasswordMaxAge,
+	BackupInterval,
+	BackupRetentionCount,
+	BackupTarget,
+	SlowOperationThreshold,
+	AlertSMTPServer,
+	AlertEmailFrom,
+	AlertWebhookHosts,
+	SoftLimitModels,
+	SoftLimitMachines,
+	SoftLimitUnits,
+	SoftLimitAPIWatchers,
+	SoftLimitDatabaseSizeMB,
+	SoftLimitLogRate,
+	MigrateLogs,
+	MigrationHistoryMaxAge,
+	MigrationLogsMaxRecords,
+	MaxRelationSettingsSize,
+	CharmRepository,
+}
+
+// SoftLimitAttributes are the attributes which hold the soft limits
+// against which the controller's capacity report compares its usage.
+var SoftLimitAttributes = []string{
+	SoftLimitModels,
+	SoftLimitMachines,
+	SoftLimitUnits,
+	SoftLimitAPIWatchers,
+	SoftLimitDatabaseSizeMB,
+	SoftLimitLogRate,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -203,6 +370,209 @@ func (c Config) NumaCtlPreference() bool {
 	return DefaultNumaControlPolicy
 }
 
+// MaxStatusHistoryAge returns the longest time for which the status
+// history of an entity is kept.
+func (c Config) MaxStatusHistoryAge() time.Duration {
+	// Validate has already verified that the value parses.
+	if v, ok := c[MaxStatusHistoryAge].(string); ok {
+		age, _ := time.ParseDuration(v)
+		return age
+	}
+	return DefaultMaxStatusHistoryAge
+}
+
+// MaxStatusHistoryEntries returns the largest number of status history
+// entries kept for each entity, or zero if there is no limit.
+func (c Config) MaxStatusHistoryEntries() int {
+	switch v := c[MaxStatusHistoryEntries].(type) {
+	case int:
+		return v
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(v)
+	}
+	return DefaultMaxStatusHistoryEntries
+}
+
+// AgentPasswordMaxAge returns the longest time for which an agent may
+// keep the same API password, or zero if passwords are not rotated on
+// a schedule.
+func (c Config) AgentPasswordMaxAge() time.Duration {
+	// Validate has already verified that the value parses.
+	if v, ok := c[AgentPasswordMaxAge].(string); ok {
+		age, _ := time.ParseDuration(v)
+		return age
+	}
+	return 0
+}
+
+// MigrateLogs returns whether recent log records of a model are
+// included when the model is migrated. The default is false.
+func (c Config) MigrateLogs() bool {
+	if v, ok := c[MigrateLogs]; ok {
+		return v.(bool)
+	}
+	return false
+}
+
+// MigrationHistoryMaxAge returns the longest time for which log records
+// and status history are included when a model is migrated, or zero if
+// there is no limit.
+func (c Config) MigrationHistoryMaxAge() time.Duration {
+	// Validate has already verified that the value parses.
+	if v, ok := c[MigrationHistoryMaxAge].(string); ok {
+		age, _ := time.ParseDuration(v)
+		return age
+	}
+	return 0
+}
+
+// MigrationLogsMaxRecords returns the largest number of log records
+// included when a model is migrated.
+func (c Config) MigrationLogsMaxRecords() int {
+	switch v := c[MigrationLogsMaxRecords].(type) {
+	case int:
+		return v
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(v)
+	}
+	return DefaultMigrationLogsMaxRecords
+}
+
+// MaxRelationSettingsSize returns the largest total size, in bytes,
+// of the settings a unit may set on a relation, or zero if there is
+// no limit.
+func (c Config) MaxRelationSettingsSize() int {
+	switch v := c[MaxRelationSettingsSize].(type) {
+	case int:
+		return v
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(v)
+	}
+	return DefaultMaxRelationSettingsSize
+}
+
+// BackupInterval returns the time between scheduled backups, or zero
+// if backups are not taken on a schedule.
+func (c Config) BackupInterval() time.Duration {
+	// Validate has already verified that the value parses.
+	if v, ok := c[BackupInterval].(string); ok {
+		interval, _ := time.ParseDuration(v)
+		return interval
+	}
+	return 0
+}
+
+// BackupRetentionCount returns the number of scheduled backups to
+// keep, or zero if there is no limit.
+func (c Config) BackupRetentionCount() int {
+	switch v := c[BackupRetentionCount].(type) {
+	case int:
+		return v
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(v)
+	}
+	return 0
+}
+
+// SoftLimit returns the value of the given soft limit attribute, or
+// zero if there is no limit.
+func (c Config) SoftLimit(attr string) int {
+	switch v := c[attr].(type) {
+	case int:
+		return v
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(v)
+	}
+	return 0
+}
+
+// BackupTarget returns where backup archives are stored.
+func (c Config) BackupTarget() string {
+	if v := c.asString(BackupTarget); v != "" {
+		return v
+	}
+	return DefaultBackupTarget
+}
+
+// SlowOperationThreshold returns the duration beyond which a mongo
+// query or transaction is recorded as slow, or zero if slow operations
+// are not recorded.
+func (c Config) SlowOperationThreshold() time.Duration {
+	// Validate has already verified that the value parses.
+	if v, ok := c[SlowOperationThreshold].(string); ok {
+		threshold, _ := time.ParseDuration(v)
+		return threshold
+	}
+	return DefaultSlowOperationThreshold
+}
+
+// AlertSMTPServer returns the host:port of the SMTP server through
+// which alert notifications are emailed, or "" if there is none.
+func (c Config) AlertSMTPServer() string {
+	return c.asString(AlertSMTPServer)
+}
+
+// AlertEmailFrom returns the sender address of alert notification
+// emails.
+func (c Config) AlertEmailFrom() string {
+	if v := c.asString(AlertEmailFrom); v != "" {
+		return v
+	}
+	return DefaultAlertEmailFrom
+}
+
+// CharmRepository returns the location of the repository from which
+// charms are deployed, as accepted by charmrepository.ParseLocation.
+// An empty location refers to the charm store.
+func (c Config) CharmRepository() string {
+	return c.asString(CharmRepository)
+}
+
+// AlertWebhookHosts returns the hosts to which alert notifications may
+// be posted.
+func (c Config) AlertWebhookHosts() []string {
+	switch v := c[AlertWebhookHosts].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		// Values read from the database or obtained over the api
+		// are untyped.
+		hosts := make([]string, 0, len(v))
+		for _, host := range v {
+			if host, ok := host.(string); ok {
+				hosts = append(hosts, host)
+			}
+		}
+		return hosts
+	}
+	return nil
+}
+
+// AlertWebhookAllowed reports whether alert notifications may be
+// posted to the given webhook URL, which must be an http or https URL
+// whose host is one of the AlertWebhookHosts.
+func (c Config) AlertWebhookAllowed(webhook string) bool {
+	u, err := url.Parse(webhook)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host := u.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for _, allowed := range c.AlertWebhookHosts() {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityURL].(string); ok {
@@ -235,6 +605,105 @@ func Validate(c Config) error {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if v, ok := c[MaxStatusHistoryAge].(string); ok {
+		age, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", MaxStatusHistoryAge)
+		}
+		if age <= 0 {
+			return errors.Errorf("%s must be positive, got %v", MaxStatusHistoryAge, age)
+		}
+	}
+
+	if c.MaxStatusHistoryEntries() < 0 {
+		return errors.Errorf("%s must not be negative", MaxStatusHistoryEntries)
+	}
+
+	if v, ok := c[AgentPasswordMaxAge].(string); ok {
+		age, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", AgentPasswordMaxAge)
+		}
+		if age <= 0 {
+			return errors.Errorf("%s must be positive, got %v", AgentPasswordMaxAge, age)
+		}
+	}
+
+	if v, ok := c[BackupInterval].(string); ok {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupInterval)
+		}
+		if interval <= 0 {
+			return errors.Errorf("%s must be positive, got %v", BackupInterval, interval)
+		}
+	}
+
+	if c.BackupRetentionCount() < 0 {
+		return errors.Errorf("%s must not be negative", BackupRetentionCount)
+	}
+
+	if v, ok := c[MigrationHistoryMaxAge].(string); ok {
+		age, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", MigrationHistoryMaxAge)
+		}
+		if age <= 0 {
+			return errors.Errorf("%s must be positive, got %v", MigrationHistoryMaxAge, age)
+		}
+	}
+
+	if c.MigrationLogsMaxRecords() <= 0 {
+		return errors.Errorf("%s must be positive", MigrationLogsMaxRecords)
+	}
+
+	if c.MaxRelationSettingsSize() < 0 {
+		return errors.Errorf("%s must not be negative", MaxRelationSettingsSize)
+	}
+
+	for _, attr := range SoftLimitAttributes {
+		if c.SoftLimit(attr) < 0 {
+			return errors.Errorf("%s must not be negative", attr)
+		}
+	}
+
+	if v, ok := c[SlowOperationThreshold].(string); ok {
+		threshold, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", SlowOperationThreshold)
+		}
+		if threshold < 0 {
+			return errors.Errorf("%s must not be negative, got %v", SlowOperationThreshold, threshold)
+		}
+	}
+
+	if v := c.AlertSMTPServer(); v != "" {
+		if _, _, err := net.SplitHostPort(v); err != nil {
+			return errors.Annotatef(err, "invalid %s", AlertSMTPServer)
+		}
+	}
+
+	if _, err := mail.ParseAddress(c.AlertEmailFrom()); err != nil {
+		return errors.Annotatef(err, "invalid %s", AlertEmailFrom)
+	}
+
+	for _, host := range c.AlertWebhookHosts() {
+		if host == "" || strings.ContainsAny(host, "/:@ ") {
+			return errors.Errorf("invalid %s: host %q", AlertWebhookHosts, host)
+		}
+	}
+
+	if _, err := charmrepository.ParseLocation(c.CharmRepository()); err != nil {
+		return errors.Annotatef(err, "invalid %s", CharmRepository)
+	}
+
+	switch target := c.BackupTarget(); target {
+	case BackupTargetController, BackupTargetCloud:
+	default:
+		return errors.Errorf("%s: expected %q or %q, got %q",
+			BackupTarget, BackupTargetController, BackupTargetCloud, target)
+	}
+
 	return nil
 }
 
@@ -251,6 +720,27 @@ var configChecker = schema.FieldMap(schema.Fields{
 	IdentityURL:             schema.String(),
 	IdentityPublicKey:       schema.String(),
 	SetNumaControlPolicyKey: schema.Bool(),
+	MaxStatusHistoryAge:     schema.String(),
+	MaxStatusHistoryEntries: schema.ForceInt(),
+	AgentPasswordMaxAge:     schema.String(),
+	BackupInterval:          schema.String(),
+	BackupRetentionCount:    schema.ForceInt(),
+	BackupTarget:            schema.String(),
+	SlowOperationThreshold:  schema.String(),
+	AlertSMTPServer:         schema.String(),
+	AlertEmailFrom:          schema.String(),
+	AlertWebhookHosts:       schema.List(schema.String()),
+	SoftLimitModels:         schema.ForceInt(),
+	SoftLimitMachines:       schema.ForceInt(),
+	SoftLimitUnits:          schema.ForceInt(),
+	SoftLimitAPIWatchers:    schema.ForceInt(),
+	SoftLimitDatabaseSizeMB: schema.ForceInt(),
+	SoftLimitLogRate:        schema.ForceInt(),
+	MigrateLogs:             schema.Bool(),
+	MigrationHistoryMaxAge:  schema.String(),
+	MigrationLogsMaxRecords: schema.ForceInt(),
+	MaxRelationSettingsSize: schema.ForceInt(),
+	CharmRepository:         schema.String(),
 }, schema.Defaults{
 	ApiPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
@@ -258,4 +748,25 @@ var configChecker = schema.FieldMap(schema.Fields{
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
 	SetNumaControlPolicyKey: DefaultNumaControlPolicy,
+	MaxStatusHistoryAge:     schema.Omit,
+	MaxStatusHistoryEntries: schema.Omit,
+	AgentPasswordMaxAge:     schema.Omit,
+	BackupInterval:          schema.Omit,
+	BackupRetentionCount:    schema.Omit,
+	BackupTarget:            schema.Omit,
+	SlowOperationThreshold:  schema.Omit,
+	AlertSMTPServer:         schema.Omit,
+	AlertEmailFrom:          schema.Omit,
+	AlertWebhookHosts:       schema.Omit,
+	SoftLimitModels:         schema.Omit,
+	SoftLimitMachines:       schema.Omit,
+	SoftLimitUnits:          schema.Omit,
+	SoftLimitAPIWatchers:    schema.Omit,
+	SoftLimitDatabaseSizeMB: schema.Omit,
+	SoftLimitLogRate:        schema.Omit,
+	MigrateLogs:             schema.Omit,
+	MigrationHistoryMaxAge:  schema.Omit,
+	MigrationLogsMaxRecords: schema.Omit,
+	MaxRelationSettingsSize: schema.Omit,
+	CharmRepository:         schema.Omit,
 })
@@ -72,3 +72,279 @@ func (s *ConfigSuite) TestGenerateControllerCertAndKey(c *gc.C) {
 		c.Assert(sanIPs, jc.SameContents, test.sanValues)
 	}
 }
+
+func (s *ConfigSuite) TestStatusHistoryRetentionDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxStatusHistoryAge(), gc.Equals, controller.DefaultMaxStatusHistoryAge)
+	c.Assert(cfg.MaxStatusHistoryEntries(), gc.Equals, controller.DefaultMaxStatusHistoryEntries)
+}
+
+func (s *ConfigSuite) TestStatusHistoryRetention(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"max-status-history-age":     "72h",
+		"max-status-history-entries": 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxStatusHistoryAge(), gc.Equals, 72*time.Hour)
+	c.Assert(cfg.MaxStatusHistoryEntries(), gc.Equals, 10)
+
+	// Values read over the API are float64.
+	cfg[controller.MaxStatusHistoryEntries] = float64(20)
+	c.Assert(cfg.MaxStatusHistoryEntries(), gc.Equals, 20)
+}
+
+func (s *ConfigSuite) TestStatusHistoryRetentionInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"max-status-history-age": "forever"},
+		err:   `invalid max-status-history-age: time: invalid duration "?forever"?`,
+	}, {
+		attrs: map[string]interface{}{"max-status-history-age": "0s"},
+		err:   `max-status-history-age must be positive, got 0s?`,
+	}, {
+		attrs: map[string]interface{}{"max-status-history-entries": -1},
+		err:   `max-status-history-entries must not be negative`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		_, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestMigrationHistory(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MigrateLogs(), jc.IsFalse)
+	c.Assert(cfg.MigrationHistoryMaxAge(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.MigrationLogsMaxRecords(), gc.Equals, controller.DefaultMigrationLogsMaxRecords)
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"migrate-logs":               true,
+		"migration-history-max-age":  "72h",
+		"migration-logs-max-records": 500,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MigrateLogs(), jc.IsTrue)
+	c.Assert(cfg.MigrationHistoryMaxAge(), gc.Equals, 72*time.Hour)
+	c.Assert(cfg.MigrationLogsMaxRecords(), gc.Equals, 500)
+
+	_, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"migration-history-max-age": "0s",
+	})
+	c.Assert(err, gc.ErrorMatches, "migration-history-max-age must be positive, got 0s")
+
+	_, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"migration-logs-max-records": 0,
+	})
+	c.Assert(err, gc.ErrorMatches, "migration-logs-max-records must be positive")
+}
+
+func (s *ConfigSuite) TestMaxRelationSettingsSize(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxRelationSettingsSize(), gc.Equals, controller.DefaultMaxRelationSettingsSize)
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"max-relation-settings-size": 0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxRelationSettingsSize(), gc.Equals, 0)
+
+	_, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"max-relation-settings-size": -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "max-relation-settings-size must not be negative")
+}
+
+func (s *ConfigSuite) TestAgentPasswordMaxAge(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentPasswordMaxAge(), gc.Equals, time.Duration(0))
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"agent-password-max-age": "720h",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentPasswordMaxAge(), gc.Equals, 720*time.Hour)
+
+	_, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"agent-password-max-age": "-1h",
+	})
+	c.Assert(err, gc.ErrorMatches, `agent-password-max-age must be positive, got -1h0m0s`)
+}
+
+func (s *ConfigSuite) TestBackupConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupInterval(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 0)
+	c.Assert(cfg.BackupTarget(), gc.Equals, controller.BackupTargetController)
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"backup-interval":        "24h",
+		"backup-retention-count": 7,
+		"backup-target":          "cloud",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupInterval(), gc.Equals, 24*time.Hour)
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 7)
+	c.Assert(cfg.BackupTarget(), gc.Equals, controller.BackupTargetCloud)
+}
+
+func (s *ConfigSuite) TestBackupConfigValidation(c *gc.C) {
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"backup-interval": "daily"},
+		err:   `invalid backup-interval: time: invalid duration "?daily"?`,
+	}, {
+		attrs: map[string]interface{}{"backup-interval": "0s"},
+		err:   `backup-interval must be positive, got 0s?`,
+	}, {
+		attrs: map[string]interface{}{"backup-retention-count": -1},
+		err:   `backup-retention-count must not be negative`,
+	}, {
+		attrs: map[string]interface{}{"backup-target": "tape"},
+		err:   `backup-target: expected "controller" or "cloud", got "tape"`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		_, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestSlowOperationThreshold(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SlowOperationThreshold(), gc.Equals, controller.DefaultSlowOperationThreshold)
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"slow-operation-threshold": "250ms",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SlowOperationThreshold(), gc.Equals, 250*time.Millisecond)
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"slow-operation-threshold": "0s",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SlowOperationThreshold(), gc.Equals, time.Duration(0))
+
+	_, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"slow-operation-threshold": "-1s",
+	})
+	c.Assert(err, gc.ErrorMatches, `slow-operation-threshold must not be negative, got -1s`)
+}
+
+func (s *ConfigSuite) TestAlertConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AlertSMTPServer(), gc.Equals, "")
+	c.Assert(cfg.AlertEmailFrom(), gc.Equals, controller.DefaultAlertEmailFrom)
+	c.Assert(cfg.AlertWebhookHosts(), gc.HasLen, 0)
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"alert-smtp-server":   "smtp.example.com:25",
+		"alert-email-from":    "juju@example.com",
+		"alert-webhook-hosts": []interface{}{"hooks.example.com", "10.0.0.1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AlertSMTPServer(), gc.Equals, "smtp.example.com:25")
+	c.Assert(cfg.AlertEmailFrom(), gc.Equals, "juju@example.com")
+	c.Assert(cfg.AlertWebhookHosts(), jc.DeepEquals, []string{"hooks.example.com", "10.0.0.1"})
+}
+
+func (s *ConfigSuite) TestAlertWebhookAllowed(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"alert-webhook-hosts": []interface{}{"hooks.example.com"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		webhook string
+		allowed bool
+	}{
+		{"https://hooks.example.com/juju", true},
+		{"http://HOOKS.example.com:8080/juju", true},
+		{"https://169.254.169.254/latest/meta-data", false},
+		{"https://localhost/juju", false},
+		{"https://hooks.example.com.evil.com/juju", false},
+		{"ftp://hooks.example.com/juju", false},
+	} {
+		c.Logf("test %d: %s", i, test.webhook)
+		c.Check(cfg.AlertWebhookAllowed(test.webhook), gc.Equals, test.allowed)
+	}
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AlertWebhookAllowed("https://hooks.example.com/juju"), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestAlertConfigValidation(c *gc.C) {
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"alert-smtp-server": "smtp.example.com"},
+		err:   `invalid alert-smtp-server: .*missing port in address.*`,
+	}, {
+		attrs: map[string]interface{}{"alert-email-from": "juju"},
+		err:   `invalid alert-email-from: mail: .*`,
+	}, {
+		attrs: map[string]interface{}{"alert-webhook-hosts": []interface{}{"https://hooks.example.com"}},
+		err:   `invalid alert-webhook-hosts: host "https://hooks.example.com"`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		_, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestCharmRepository(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.CharmRepository(), gc.Equals, "")
+
+	for i, location := range []string{
+		"charmstore",
+		"/srv/charms",
+		"https://charms.example.com/repo",
+	} {
+		c.Logf("test %d: %s", i, location)
+		cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+			"charm-repository": location,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(cfg.CharmRepository(), gc.Equals, location)
+	}
+
+	_, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"charm-repository": "http://charms.example.com/repo",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid charm-repository: charm repository location "http://charms.example.com/repo" not valid`)
+}
+
+func (s *ConfigSuite) TestSoftLimits(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, attr := range controller.SoftLimitAttributes {
+		c.Check(cfg.SoftLimit(attr), gc.Equals, 0)
+	}
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"soft-limit-models":           50,
+		"soft-limit-database-size-mb": 4096,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SoftLimit(controller.SoftLimitModels), gc.Equals, 50)
+	c.Assert(cfg.SoftLimit(controller.SoftLimitDatabaseSizeMB), gc.Equals, 4096)
+	c.Assert(cfg.SoftLimit(controller.SoftLimitUnits), gc.Equals, 0)
+
+	_, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		"soft-limit-units": -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "soft-limit-units must not be negative")
+}
@@ -68,9 +68,17 @@ func (c ModelConfigCreator) NewModelConfig(
 	// However, before we can create a valid config, we need to make sure
 	// we copy across fields from the main config that aren't there.
 	baseAttrs := base.AllAttrs()
-	restrictedFields, err := RestrictedProviderFields(provider)
-	if err != nil {
-		return nil, errors.Trace(err)
+	var restrictedFields []string
+	if cloud.Type == base.Type() {
+		restrictedFields, err = RestrictedProviderFields(provider)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		// The model is in a different cloud to the controller, so
+		// there is nothing to share with the controller's provider
+		// config.
+		attrs[config.TypeKey] = cloud.Type
 	}
 	for _, field := range restrictedFields {
 		if _, ok := attrs[field]; !ok {
@@ -174,7 +182,8 @@ func (c *ModelConfigCreator) checkVersion(base *config.Config, attrs map[string]
 // config.
 func RestrictedProviderFields(provider environs.EnvironProvider) ([]string, error) {
 	var fields []string
-	// For now, all models in a controller must be of the same type.
+	// Models in the same cloud as the controller must be of the
+	// same type.
 	fields = append(fields, config.TypeKey)
 	fields = append(fields, provider.RestrictedConfigAttributes()...)
 	return fields, nil
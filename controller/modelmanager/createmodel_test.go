@@ -98,6 +98,20 @@ func (s *ModelConfigCreatorSuite) TestCreateModelValidatesConfig(c *gc.C) {
 	c.Assert(validateCall.Args[1], gc.IsNil)
 }
 
+func (s *ModelConfigCreatorSuite) TestCreateModelOtherCloud(c *gc.C) {
+	baseConfig, err := s.baseConfig.Apply(map[string]interface{}{"type": "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	cloudSpec := environs.CloudSpec{Type: "fake"}
+	cfg, err := s.creator.NewModelConfig(cloudSpec, coretesting.ModelTag.Id(), baseConfig, coretesting.Attrs{
+		"name": "new-model",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Type(), gc.Equals, "fake")
+	_, ok := cfg.AllAttrs()["restricted"]
+	c.Assert(ok, jc.IsFalse)
+	s.fake.Stub.CheckCallNames(c, "PrepareConfig", "Validate")
+}
+
 func (s *ModelConfigCreatorSuite) TestCreateModelBadConfig(c *gc.C) {
 	for i, test := range []struct {
 		key      string
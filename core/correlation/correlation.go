@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package correlation carries the correlation id of an API request
+// below the RPC layer, to the facades, state operations and workers
+// that act on it, so that the log lines written for a single user
+// action can be found together.
+package correlation
+
+import (
+	"github.com/juju/loggo"
+	"golang.org/x/net/context"
+)
+
+type contextKey struct{}
+
+// WithId returns a copy of ctx carrying the given correlation id.
+func WithId(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// Id returns the correlation id carried by ctx, or the empty string
+// if it carries none.
+func Id(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Logger writes messages to a loggo.Logger, prefixed with the
+// correlation id of the user action for which they are written.
+type Logger struct {
+	logger loggo.Logger
+	prefix string
+}
+
+// NewLogger returns a Logger which prefixes the messages it writes to
+// logger with the given correlation id. If id is empty, the messages
+// are written unchanged.
+func NewLogger(logger loggo.Logger, id string) Logger {
+	var prefix string
+	if id != "" {
+		prefix = "correlation-id=" + id + ": "
+	}
+	return Logger{logger: logger, prefix: prefix}
+}
+
+// ContextLogger returns a Logger which prefixes the messages it writes
+// to logger with the correlation id carried by ctx.
+func ContextLogger(ctx context.Context, logger loggo.Logger) Logger {
+	return NewLogger(logger, Id(ctx))
+}
+
+// Errorf logs a message at the ERROR level.
+func (l Logger) Errorf(message string, args ...interface{}) {
+	l.logger.LogCallf(2, loggo.ERROR, l.prefix+message, args...)
+}
+
+// Warningf logs a message at the WARNING level.
+func (l Logger) Warningf(message string, args ...interface{}) {
+	l.logger.LogCallf(2, loggo.WARNING, l.prefix+message, args...)
+}
+
+// Infof logs a message at the INFO level.
+func (l Logger) Infof(message string, args ...interface{}) {
+	l.logger.LogCallf(2, loggo.INFO, l.prefix+message, args...)
+}
+
+// Debugf logs a message at the DEBUG level.
+func (l Logger) Debugf(message string, args ...interface{}) {
+	l.logger.LogCallf(2, loggo.DEBUG, l.prefix+message, args...)
+}
+
+// Tracef logs a message at the TRACE level.
+func (l Logger) Tracef(message string, args ...interface{}) {
+	l.logger.LogCallf(2, loggo.TRACE, l.prefix+message, args...)
+}
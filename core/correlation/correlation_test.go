@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package correlation_test
+
+import (
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/correlation"
+)
+
+type correlationSuite struct {
+	testing.IsolationSuite
+
+	writer loggo.TestWriter
+	logger loggo.Logger
+}
+
+var _ = gc.Suite(&correlationSuite{})
+
+func (s *correlationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.writer.Clear()
+	c.Assert(loggo.RegisterWriter("correlation-tests", &s.writer), jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { loggo.RemoveWriter("correlation-tests") })
+	s.logger = loggo.GetLogger("juju.core.correlation.test")
+	s.logger.SetLogLevel(loggo.TRACE)
+}
+
+func (s *correlationSuite) TestId(c *gc.C) {
+	ctx := context.Background()
+	c.Assert(correlation.Id(ctx), gc.Equals, "")
+	ctx = correlation.WithId(ctx, "deadbeef")
+	c.Assert(correlation.Id(ctx), gc.Equals, "deadbeef")
+}
+
+func (s *correlationSuite) TestLogger(c *gc.C) {
+	logger := correlation.NewLogger(s.logger, "deadbeef")
+	logger.Errorf("error %d", 1)
+	logger.Warningf("warning %d", 2)
+	logger.Infof("info %d", 3)
+	logger.Debugf("debug %d", 4)
+	logger.Tracef("trace %d", 5)
+	c.Assert(s.writer.Log(), jc.LogMatches, []jc.SimpleMessage{
+		{loggo.ERROR, `correlation-id=deadbeef: error 1`},
+		{loggo.WARNING, `correlation-id=deadbeef: warning 2`},
+		{loggo.INFO, `correlation-id=deadbeef: info 3`},
+		{loggo.DEBUG, `correlation-id=deadbeef: debug 4`},
+		{loggo.TRACE, `correlation-id=deadbeef: trace 5`},
+	})
+	c.Assert(s.writer.Log()[0].Filename, gc.Matches, ".*correlation_test.go")
+}
+
+func (s *correlationSuite) TestLoggerWithoutId(c *gc.C) {
+	logger := correlation.ContextLogger(context.Background(), s.logger)
+	logger.Infof("info %d", 1)
+	c.Assert(s.writer.Log(), jc.LogMatches, []jc.SimpleMessage{
+		{loggo.INFO, `info 1`},
+	})
+}
+
+func (s *correlationSuite) TestContextLogger(c *gc.C) {
+	ctx := correlation.WithId(context.Background(), "cafef00d")
+	correlation.ContextLogger(ctx, s.logger).Infof("info")
+	c.Assert(s.writer.Log(), jc.LogMatches, []jc.SimpleMessage{
+		{loggo.INFO, `correlation-id=cafef00d: info`},
+	})
+}
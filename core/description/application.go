@@ -31,6 +31,11 @@ type application struct {
 	ForceCharm_ bool `yaml:"force-charm,omitempty"`
 	Exposed_    bool `yaml:"exposed,omitempty"`
 	MinUnits_   int  `yaml:"min-units,omitempty"`
+	MaxUnits_   int  `yaml:"max-units,omitempty"`
+
+	// ExposedEndpoints_ maps each exposed endpoint to the source
+	// CIDRs from which it may be reached.
+	ExposedEndpoints_ map[string][]string `yaml:"exposed-endpoints,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -50,9 +55,29 @@ type application struct {
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
+	HookSandbox_ *hookSandbox `yaml:"hook-sandbox,omitempty"`
+
 	// Storage Constraints
 }
 
+// hookSandbox describes the confinement in which the hooks of the
+// application's units are run.
+type hookSandbox struct {
+	User_         string `yaml:"user,omitempty"`
+	MemoryLimit_  uint64 `yaml:"memory-limit,omitempty"`
+	CPUQuota_     int    `yaml:"cpu-quota,omitempty"`
+	LXDContainer_ string `yaml:"lxd-container,omitempty"`
+}
+
+// HookSandboxArgs is an argument struct used to specify the confinement
+// in which the hooks of an application's units are run.
+type HookSandboxArgs struct {
+	User         string
+	MemoryLimit  uint64
+	CPUQuota     int
+	LXDContainer string
+}
+
 // ApplicationArgs is an argument struct used to add an application to the Model.
 type ApplicationArgs struct {
 	Tag                  names.ApplicationTag
@@ -63,12 +88,15 @@ type ApplicationArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedEndpoints     map[string][]string
 	MinUnits             int
+	MaxUnits             int
 	Settings             map[string]interface{}
 	SettingsRefCount     int
 	Leader               string
 	LeadershipSettings   map[string]interface{}
 	MetricsCredentials   []byte
+	HookSandbox          HookSandboxArgs
 }
 
 func newApplication(args ApplicationArgs) *application {
@@ -82,7 +110,9 @@ func newApplication(args ApplicationArgs) *application {
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
+		ExposedEndpoints_:     args.ExposedEndpoints,
 		MinUnits_:             args.MinUnits,
+		MaxUnits_:             args.MaxUnits,
 		Settings_:             args.Settings,
 		SettingsRefCount_:     args.SettingsRefCount,
 		Leader_:               args.Leader,
@@ -90,6 +120,14 @@ func newApplication(args ApplicationArgs) *application {
 		MetricsCredentials_:   creds,
 		StatusHistory_:        newStatusHistory(),
 	}
+	if args.HookSandbox != (HookSandboxArgs{}) {
+		svc.HookSandbox_ = &hookSandbox{
+			User_:         args.HookSandbox.User,
+			MemoryLimit_:  args.HookSandbox.MemoryLimit,
+			CPUQuota_:     args.HookSandbox.CPUQuota,
+			LXDContainer_: args.HookSandbox.LXDContainer,
+		}
+	}
 	svc.setUnits(nil)
 	return svc
 }
@@ -139,11 +177,34 @@ func (s *application) Exposed() bool {
 	return s.Exposed_
 }
 
+// ExposedEndpoints implements Application.
+func (s *application) ExposedEndpoints() map[string][]string {
+	return s.ExposedEndpoints_
+}
+
 // MinUnits implements Application.
 func (s *application) MinUnits() int {
 	return s.MinUnits_
 }
 
+// MaxUnits implements Application.
+func (s *application) MaxUnits() int {
+	return s.MaxUnits_
+}
+
+// HookSandbox implements Application.
+func (s *application) HookSandbox() HookSandboxArgs {
+	if s.HookSandbox_ == nil {
+		return HookSandboxArgs{}
+	}
+	return HookSandboxArgs{
+		User:         s.HookSandbox_.User_,
+		MemoryLimit:  s.HookSandbox_.MemoryLimit_,
+		CPUQuota:     s.HookSandbox_.CPUQuota_,
+		LXDContainer: s.HookSandbox_.LXDContainer_,
+	}
+}
+
 // Settings implements Application.
 func (s *application) Settings() map[string]interface{} {
 	return s.Settings_
@@ -307,7 +368,9 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"exposed-endpoints":   schema.StringMap(schema.List(schema.String())),
 		"min-units":           schema.Int(),
+		"max-units":           schema.Int(),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
 		"settings-refcount":   schema.Int(),
@@ -315,15 +378,19 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"leadership-settings": schema.StringMap(schema.Any()),
 		"metrics-creds":       schema.String(),
 		"units":               schema.StringMap(schema.Any()),
+		"hook-sandbox":        schema.StringMap(schema.Any()),
 	}
 
 	defaults := schema.Defaults{
-		"subordinate":   false,
-		"force-charm":   false,
-		"exposed":       false,
-		"min-units":     int64(0),
-		"leader":        "",
-		"metrics-creds": "",
+		"subordinate":       false,
+		"force-charm":       false,
+		"exposed":           false,
+		"exposed-endpoints": schema.Omit,
+		"min-units":         int64(0),
+		"max-units":         int64(0),
+		"leader":            "",
+		"metrics-creds":     "",
+		"hook-sandbox":      schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -347,12 +414,19 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		ForceCharm_:           valid["force-charm"].(bool),
 		Exposed_:              valid["exposed"].(bool),
 		MinUnits_:             int(valid["min-units"].(int64)),
+		MaxUnits_:             int(valid["max-units"].(int64)),
 		Settings_:             valid["settings"].(map[string]interface{}),
 		SettingsRefCount_:     int(valid["settings-refcount"].(int64)),
 		Leader_:               valid["leader"].(string),
 		LeadershipSettings_:   valid["leadership-settings"].(map[string]interface{}),
 		StatusHistory_:        newStatusHistory(),
 	}
+	if exposed, ok := valid["exposed-endpoints"]; ok {
+		result.ExposedEndpoints_ = make(map[string][]string)
+		for endpoint, cidrs := range exposed.(map[string]interface{}) {
+			result.ExposedEndpoints_[endpoint] = convertToStringSlice(cidrs)
+		}
+	}
 	result.importAnnotations(valid)
 	if err := result.importStatusHistory(valid); err != nil {
 		return nil, errors.Trace(err)
@@ -366,6 +440,14 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		result.Constraints_ = constraints
 	}
 
+	if sandboxMap, ok := valid["hook-sandbox"]; ok {
+		sandbox, err := importHookSandbox(sandboxMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.HookSandbox_ = sandbox
+	}
+
 	encodedCreds := valid["metrics-creds"].(string)
 	// The model stores the creds encoded, but we want to make sure that
 	// we are storing something that can be decoded.
@@ -388,3 +470,32 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 
 	return result, nil
 }
+
+func importHookSandbox(source map[string]interface{}) (*hookSandbox, error) {
+	fields := schema.Fields{
+		"user":          schema.String(),
+		"memory-limit":  schema.Uint(),
+		"cpu-quota":     schema.Int(),
+		"lxd-container": schema.String(),
+	}
+	// Some values don't have to be there.
+	defaults := schema.Defaults{
+		"user":          "",
+		"memory-limit":  uint64(0),
+		"cpu-quota":     int64(0),
+		"lxd-container": "",
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "hook sandbox schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	return &hookSandbox{
+		User_:         valid["user"].(string),
+		MemoryLimit_:  valid["memory-limit"].(uint64),
+		CPUQuota_:     int(valid["cpu-quota"].(int64)),
+		LXDContainer_: valid["lxd-container"].(string),
+	}, nil
+}
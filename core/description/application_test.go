@@ -110,7 +110,11 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 		CharmModifiedVersion: 1,
 		ForceCharm:           true,
 		Exposed:              true,
-		MinUnits:             42, // no judgement is made by the migration code
+		ExposedEndpoints: map[string][]string{
+			"www": {"10.0.0.0/8"},
+		},
+		MinUnits: 42, // no judgement is made by the migration code
+		MaxUnits: 24,
 		Settings: map[string]interface{}{
 			"key": "value",
 		},
@@ -120,6 +124,10 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 			"leader": true,
 		},
 		MetricsCredentials: []byte("sekrit"),
+		HookSandbox: HookSandboxArgs{
+			User:     "hooks",
+			CPUQuota: 50,
+		},
 	}
 	application := newApplication(args)
 
@@ -132,12 +140,17 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 	c.Assert(application.CharmModifiedVersion(), gc.Equals, 1)
 	c.Assert(application.ForceCharm(), jc.IsTrue)
 	c.Assert(application.Exposed(), jc.IsTrue)
+	c.Assert(application.ExposedEndpoints(), jc.DeepEquals, map[string][]string{
+		"www": {"10.0.0.0/8"},
+	})
 	c.Assert(application.MinUnits(), gc.Equals, 42)
+	c.Assert(application.MaxUnits(), gc.Equals, 24)
 	c.Assert(application.Settings(), jc.DeepEquals, args.Settings)
 	c.Assert(application.SettingsRefCount(), gc.Equals, 1)
 	c.Assert(application.Leader(), gc.Equals, "magic/1")
 	c.Assert(application.LeadershipSettings(), jc.DeepEquals, args.LeadershipSettings)
 	c.Assert(application.MetricsCredentials(), jc.DeepEquals, []byte("sekrit"))
+	c.Assert(application.HookSandbox(), jc.DeepEquals, args.HookSandbox)
 }
 
 func (s *ApplicationSerializationSuite) TestMinimalApplicationValid(c *gc.C) {
@@ -192,6 +205,19 @@ func (s *ApplicationSerializationSuite) TestAnnotations(c *gc.C) {
 	c.Assert(application.Annotations(), jc.DeepEquals, annotations)
 }
 
+func (s *ApplicationSerializationSuite) TestExposedEndpoints(c *gc.C) {
+	initial := minimalApplication()
+	initial.Exposed_ = true
+	initial.ExposedEndpoints_ = map[string][]string{
+		"":    nil,
+		"www": {"10.0.0.0/8", "192.168.0.0/16"},
+	}
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.Exposed(), jc.IsTrue)
+	c.Assert(application.ExposedEndpoints(), jc.DeepEquals, initial.ExposedEndpoints_)
+}
+
 func (s *ApplicationSerializationSuite) TestConstraints(c *gc.C) {
 	initial := minimalApplication()
 	args := ConstraintsArgs{
@@ -205,6 +231,20 @@ func (s *ApplicationSerializationSuite) TestConstraints(c *gc.C) {
 	c.Assert(application.Constraints(), jc.DeepEquals, newConstraints(args))
 }
 
+func (s *ApplicationSerializationSuite) TestHookSandbox(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.HookSandbox = HookSandboxArgs{
+		User:         "hooks",
+		MemoryLimit:  512,
+		CPUQuota:     50,
+		LXDContainer: "hook-jail",
+	}
+	initial := newApplication(args)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.HookSandbox(), jc.DeepEquals, args.HookSandbox)
+}
+
 func (s *ApplicationSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalApplicationArgs()
 	args.Leader = "ubuntu/1"
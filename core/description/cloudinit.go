@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// CloudInitArgs is an argument struct to construct a CloudInit.
+type CloudInitArgs struct {
+	Packages  []string
+	AptMirror string
+	RunCmds   []string
+	SSHCAKeys []string
+}
+
+func newCloudInit(args CloudInitArgs) *cloudInit {
+	// If the CloudInitArgs are all empty, then we return
+	// nil to indicate that there is no customization.
+	if args.empty() {
+		return nil
+	}
+	return &cloudInit{
+		Version:    1,
+		Packages_:  copyStrings(args.Packages),
+		AptMirror_: args.AptMirror,
+		RunCmds_:   copyStrings(args.RunCmds),
+		SSHCAKeys_: copyStrings(args.SSHCAKeys),
+	}
+}
+
+type cloudInit struct {
+	Version int `yaml:"version"`
+
+	Packages_  []string `yaml:"packages,omitempty"`
+	AptMirror_ string   `yaml:"apt-mirror,omitempty"`
+	RunCmds_   []string `yaml:"runcmds,omitempty"`
+	SSHCAKeys_ []string `yaml:"ssh-ca-keys,omitempty"`
+}
+
+// Packages implements CloudInit.
+func (c *cloudInit) Packages() []string {
+	return copyStrings(c.Packages_)
+}
+
+// AptMirror implements CloudInit.
+func (c *cloudInit) AptMirror() string {
+	return c.AptMirror_
+}
+
+// RunCmds implements CloudInit.
+func (c *cloudInit) RunCmds() []string {
+	return copyStrings(c.RunCmds_)
+}
+
+// SSHCAKeys implements CloudInit.
+func (c *cloudInit) SSHCAKeys() []string {
+	return copyStrings(c.SSHCAKeys_)
+}
+
+// copyStrings returns a copy of the given slice, or nil if it is empty.
+func copyStrings(values []string) []string {
+	var result []string
+	if count := len(values); count > 0 {
+		result = make([]string, count)
+		copy(result, values)
+	}
+	return result
+}
+
+func importCloudInit(source map[string]interface{}) (*cloudInit, error) {
+	version, err := getVersion(source)
+	if err != nil {
+		return nil, errors.Annotate(err, "cloud-init version schema check failed")
+	}
+
+	importFunc, ok := cloudInitDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+
+	return importFunc(source)
+}
+
+type cloudInitDeserializationFunc func(map[string]interface{}) (*cloudInit, error)
+
+var cloudInitDeserializationFuncs = map[int]cloudInitDeserializationFunc{
+	1: importCloudInitV1,
+}
+
+func importCloudInitV1(source map[string]interface{}) (*cloudInit, error) {
+	fields := schema.Fields{
+		"packages":    schema.List(schema.String()),
+		"apt-mirror":  schema.String(),
+		"runcmds":     schema.List(schema.String()),
+		"ssh-ca-keys": schema.List(schema.String()),
+	}
+	// Some values don't have to be there.
+	defaults := schema.Defaults{
+		"packages":    schema.Omit,
+		"apt-mirror":  "",
+		"runcmds":     schema.Omit,
+		"ssh-ca-keys": schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cloud-init v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	return &cloudInit{
+		Version:    1,
+		Packages_:  convertToStringSlice(valid["packages"]),
+		AptMirror_: valid["apt-mirror"].(string),
+		RunCmds_:   convertToStringSlice(valid["runcmds"]),
+		SSHCAKeys_: convertToStringSlice(valid["ssh-ca-keys"]),
+	}, nil
+}
+
+func addCloudInitSchema(fields schema.Fields, defaults schema.Defaults) {
+	fields["cloud-init"] = schema.StringMap(schema.Any())
+	defaults["cloud-init"] = schema.Omit
+}
+
+func (c CloudInitArgs) empty() bool {
+	return len(c.Packages) == 0 &&
+		c.AptMirror == "" &&
+		len(c.RunCmds) == 0 &&
+		len(c.SSHCAKeys) == 0
+}
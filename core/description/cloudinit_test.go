@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type CloudInitSerializationSuite struct {
+	SerializationSuite
+}
+
+var _ = gc.Suite(&CloudInitSerializationSuite{})
+
+func (s *CloudInitSerializationSuite) SetUpTest(c *gc.C) {
+	s.importName = "cloud-init"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importCloudInit(m)
+	}
+}
+
+func (s *CloudInitSerializationSuite) allArgs() CloudInitArgs {
+	return CloudInitArgs{
+		Packages:  []string{"htop", "nmap=7.01-2ubuntu2"},
+		AptMirror: "http://mirror.example.com/ubuntu",
+		RunCmds:   []string{"touch /tmp/provisioned"},
+		SSHCAKeys: []string{"ssh-rsa AAAA ca@example.com"},
+	}
+}
+
+func (s *CloudInitSerializationSuite) TestNewCloudInit(c *gc.C) {
+	args := s.allArgs()
+	instance := newCloudInit(args)
+
+	c.Assert(instance.AptMirror(), gc.Equals, args.AptMirror)
+	c.Assert(instance.RunCmds(), jc.DeepEquals, args.RunCmds)
+	c.Assert(instance.SSHCAKeys(), jc.DeepEquals, args.SSHCAKeys)
+
+	// Modifying the args, or the packages returned, doesn't modify
+	// the instance.
+	args.Packages[0] = "weird"
+	packages := instance.Packages()
+	c.Assert(packages, jc.DeepEquals, []string{"htop", "nmap=7.01-2ubuntu2"})
+	packages[0] = "weird"
+	c.Assert(instance.Packages(), jc.DeepEquals, []string{"htop", "nmap=7.01-2ubuntu2"})
+}
+
+func (s *CloudInitSerializationSuite) TestNewCloudInitEmpty(c *gc.C) {
+	instance := newCloudInit(CloudInitArgs{})
+	c.Assert(instance, gc.IsNil)
+}
+
+func (s *CloudInitSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := newCloudInit(s.allArgs())
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	instance, err := importCloudInit(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instance, jc.DeepEquals, initial)
+}
+
+func (s *CloudInitSerializationSuite) TestParsingSerializedPartial(c *gc.C) {
+	initial := newCloudInit(CloudInitArgs{AptMirror: "http://mirror.example.com/ubuntu"})
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	instance, err := importCloudInit(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instance, jc.DeepEquals, initial)
+}
@@ -24,6 +24,13 @@ type HasConstraints interface {
 	SetConstraints(ConstraintsArgs)
 }
 
+// HasCloudInit defines the common methods for setting and getting the
+// cloud-init customization of models and machines.
+type HasCloudInit interface {
+	CloudInit() CloudInit
+	SetCloudInit(CloudInitArgs)
+}
+
 // HasStatus defines the common methods for setting and getting status
 // entries for the various entities.
 type HasStatus interface {
@@ -42,6 +49,7 @@ type HasStatusHistory interface {
 type Model interface {
 	HasAnnotations
 	HasConstraints
+	HasCloudInit
 
 	Cloud() string
 	CloudRegion() string
@@ -88,6 +96,9 @@ type Model interface {
 	Actions() []Action
 	AddAction(ActionArgs) Action
 
+	LogRecords() []LogRecord
+	AddLogRecord(LogRecordArgs) LogRecord
+
 	Sequences() map[string]int
 	SetSequence(name string, value int)
 
@@ -136,6 +147,7 @@ type AgentTools interface {
 type Machine interface {
 	HasAnnotations
 	HasConstraints
+	HasCloudInit
 	HasStatus
 	HasStatusHistory
 
@@ -233,6 +245,15 @@ type Constraints interface {
 	VirtType() string
 }
 
+// CloudInit represents the extra cloud-init directives applied when
+// provisioning a model's machines, or a particular machine.
+type CloudInit interface {
+	Packages() []string
+	AptMirror() string
+	RunCmds() []string
+	SSHCAKeys() []string
+}
+
 // Status represents an agent, application, or workload status.
 type Status interface {
 	Value() string
@@ -257,7 +278,13 @@ type Application interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	ExposedEndpoints() map[string][]string
 	MinUnits() int
+	MaxUnits() int
+
+	// HookSandbox returns the confinement in which the hooks of the
+	// application's units are run.
+	HookSandbox() HookSandboxArgs
 
 	Settings() map[string]interface{}
 	SettingsRefCount() int
@@ -321,6 +348,12 @@ type Relation interface {
 	Id() int
 	Key() string
 
+	// ExcludeControllers and ExcludeMachines describe which principal
+	// units are given subordinate units by a relation with container
+	// scope.
+	ExcludeControllers() bool
+	ExcludeMachines() []string
+
 	Endpoints() []Endpoint
 	AddEndpoint(EndpointArgs) Endpoint
 }
@@ -412,6 +445,19 @@ type Action interface {
 	Message() string
 }
 
+// LogRecord represents a log record written by an agent of the model.
+// Log records are only included in a model migration when the source
+// controller is configured to migrate them.
+type LogRecord interface {
+	Time() time.Time
+	Entity() string
+	Version() string
+	Module() string
+	Location() string
+	Level() string
+	Message() string
+}
+
 // Volume represents a volume (disk, logical volume, etc.) in the model.
 type Volume interface {
 	HasStatus
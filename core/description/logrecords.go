@@ -0,0 +1,152 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+type logRecords struct {
+	Version     int          `yaml:"version"`
+	LogRecords_ []*logRecord `yaml:"log-records"`
+}
+
+type logRecord struct {
+	Time_     time.Time `yaml:"time"`
+	Entity_   string    `yaml:"entity"`
+	Version_  string    `yaml:"version"`
+	Module_   string    `yaml:"module"`
+	Location_ string    `yaml:"location"`
+	Level_    string    `yaml:"level"`
+	Message_  string    `yaml:"message"`
+}
+
+// Time implements LogRecord.
+func (r *logRecord) Time() time.Time {
+	return r.Time_
+}
+
+// Entity implements LogRecord.
+func (r *logRecord) Entity() string {
+	return r.Entity_
+}
+
+// Version implements LogRecord.
+func (r *logRecord) Version() string {
+	return r.Version_
+}
+
+// Module implements LogRecord.
+func (r *logRecord) Module() string {
+	return r.Module_
+}
+
+// Location implements LogRecord.
+func (r *logRecord) Location() string {
+	return r.Location_
+}
+
+// Level implements LogRecord.
+func (r *logRecord) Level() string {
+	return r.Level_
+}
+
+// Message implements LogRecord.
+func (r *logRecord) Message() string {
+	return r.Message_
+}
+
+// LogRecordArgs is an argument struct used to create a
+// new internal log record type that supports the LogRecord interface.
+type LogRecordArgs struct {
+	Time     time.Time
+	Entity   string
+	Version  string
+	Module   string
+	Location string
+	Level    string
+	Message  string
+}
+
+func newLogRecord(args LogRecordArgs) *logRecord {
+	return &logRecord{
+		Time_:     args.Time,
+		Entity_:   args.Entity,
+		Version_:  args.Version,
+		Module_:   args.Module,
+		Location_: args.Location,
+		Level_:    args.Level,
+		Message_:  args.Message,
+	}
+}
+
+func importLogRecords(source map[string]interface{}) ([]*logRecord, error) {
+	checker := versionedChecker("log-records")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "log records version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := logRecordDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["log-records"].([]interface{})
+	return importLogRecordList(sourceList, importFunc)
+}
+
+func importLogRecordList(sourceList []interface{}, importFunc logRecordDeserializationFunc) ([]*logRecord, error) {
+	result := make([]*logRecord, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for log record %d, %T", i, value)
+		}
+		record, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "log record %d", i)
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+type logRecordDeserializationFunc func(map[string]interface{}) (*logRecord, error)
+
+var logRecordDeserializationFuncs = map[int]logRecordDeserializationFunc{
+	1: importLogRecordV1,
+}
+
+func importLogRecordV1(source map[string]interface{}) (*logRecord, error) {
+	fields := schema.Fields{
+		"time":     schema.Time(),
+		"entity":   schema.String(),
+		"version":  schema.String(),
+		"module":   schema.String(),
+		"location": schema.String(),
+		"level":    schema.String(),
+		"message":  schema.String(),
+	}
+	checker := schema.FieldMap(fields, nil) // no defaults
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "log record v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	return &logRecord{
+		Time_:     valid["time"].(time.Time).UTC(),
+		Entity_:   valid["entity"].(string),
+		Version_:  valid["version"].(string),
+		Module_:   valid["module"].(string),
+		Location_: valid["location"].(string),
+		Level_:    valid["level"].(string),
+		Message_:  valid["message"].(string),
+	}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type LogRecordSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&LogRecordSerializationSuite{})
+
+func (s *LogRecordSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "log records"
+	s.sliceName = "log-records"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importLogRecords(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["log-records"] = []interface{}{}
+	}
+}
+
+func (s *LogRecordSerializationSuite) TestNewLogRecord(c *gc.C) {
+	args := LogRecordArgs{
+		Time:     time.Now(),
+		Entity:   "unit-foo-0",
+		Version:  "2.0.1",
+		Module:   "juju.worker.uniter",
+		Location: "uniter.go:42",
+		Level:    "INFO",
+		Message:  "hello",
+	}
+	record := newLogRecord(args)
+	c.Check(record.Time(), gc.Equals, args.Time)
+	c.Check(record.Entity(), gc.Equals, args.Entity)
+	c.Check(record.Version(), gc.Equals, args.Version)
+	c.Check(record.Module(), gc.Equals, args.Module)
+	c.Check(record.Location(), gc.Equals, args.Location)
+	c.Check(record.Level(), gc.Equals, args.Level)
+	c.Check(record.Message(), gc.Equals, args.Message)
+}
+
+func (s *LogRecordSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := logRecords{
+		Version: 1,
+		LogRecords_: []*logRecord{
+			newLogRecord(LogRecordArgs{
+				Time:     time.Now().UTC(),
+				Entity:   "unit-foo-0",
+				Version:  "2.0.1",
+				Module:   "juju.worker.uniter",
+				Location: "uniter.go:42",
+				Level:    "INFO",
+				Message:  "hello",
+			}),
+			newLogRecord(LogRecordArgs{
+				Time:     time.Now().UTC(),
+				Entity:   "machine-0",
+				Version:  "2.0.1",
+				Module:   "juju.worker.provisioner",
+				Location: "provisioner.go:7",
+				Level:    "ERROR",
+				Message:  "oops",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := importLogRecords(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(records, jc.DeepEquals, initial.LogRecords_)
+}
@@ -46,6 +46,8 @@ type machine struct {
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
+	CloudInit_ *cloudInit `yaml:"cloud-init,omitempty"`
+
 	BlockDevices_ blockdevices `yaml:"block-devices,omitempty"`
 }
 
@@ -319,6 +321,19 @@ func (m *machine) SetConstraints(args ConstraintsArgs) {
 	m.Constraints_ = newConstraints(args)
 }
 
+// CloudInit implements HasCloudInit.
+func (m *machine) CloudInit() CloudInit {
+	if m.CloudInit_ == nil {
+		return nil
+	}
+	return m.CloudInit_
+}
+
+// SetCloudInit implements HasCloudInit.
+func (m *machine) SetCloudInit(args CloudInitArgs) {
+	m.CloudInit_ = newCloudInit(args)
+}
+
 // Validate implements Machine.
 func (m *machine) Validate() error {
 	if m.Id_ == "" {
@@ -423,6 +438,7 @@ func importMachineV1(source map[string]interface{}) (*machine, error) {
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
+	addCloudInitSchema(fields, defaults)
 	addStatusHistorySchema(fields)
 	checker := schema.FieldMap(fields, defaults)
 
@@ -456,6 +472,14 @@ func importMachineV1(source map[string]interface{}) (*machine, error) {
 		result.Constraints_ = constraints
 	}
 
+	if cloudInitMap, ok := valid["cloud-init"]; ok {
+		cloudInit, err := importCloudInit(cloudInitMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.CloudInit_ = cloudInit
+	}
+
 	if supported, ok := valid["supported-containers"]; ok {
 		supportedList := supported.([]interface{})
 		s := make([]string, len(supportedList))
@@ -256,6 +256,17 @@ func (s *MachineSerializationSuite) TestConstraints(c *gc.C) {
 	c.Assert(machine.Constraints(), jc.DeepEquals, newConstraints(args))
 }
 
+func (s *MachineSerializationSuite) TestCloudInit(c *gc.C) {
+	initial := minimalMachine("42")
+	args := CloudInitArgs{
+		RunCmds: []string{"touch /tmp/provisioned"},
+	}
+	initial.SetCloudInit(args)
+
+	machine := s.exportImport(c, initial)
+	c.Assert(machine.CloudInit(), jc.DeepEquals, newCloudInit(args))
+}
+
 func (s *MachineSerializationSuite) exportImport(c *gc.C, machine_ *machine) *machine {
 	initial := machines{
 		Version:   1,
@@ -51,6 +51,7 @@ func NewModel(args ModelArgs) Model {
 	m.setIPAddresses(nil)
 	m.setSSHHostKeys(nil)
 	m.setActions(nil)
+	m.setLogRecords(nil)
 	m.setVolumes(nil)
 	m.setFilesystems(nil)
 	m.setStorages(nil)
@@ -125,6 +126,7 @@ type model struct {
 	IPAddresses_      ipaddresses      `yaml:"ipaddresses"`
 	Subnets_          subnets          `yaml:"subnets"`
 	Actions_          actions          `yaml:"actions"`
+	LogRecords_       logRecords       `yaml:"logs"`
 
 	SSHHostKeys_ sshHostKeys `yaml:"sshhostkeys"`
 
@@ -134,6 +136,8 @@ type model struct {
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
+	CloudInit_ *cloudInit `yaml:"cloud-init,omitempty"`
+
 	Cloud_           string `yaml:"cloud"`
 	CloudRegion_     string `yaml:"cloud-region,omitempty"`
 	CloudCredential_ string `yaml:"cloud-credential,omitempty"`
@@ -427,6 +431,29 @@ func (m *model) setActions(actionsList []*action) {
 	}
 }
 
+// LogRecords implements Model.
+func (m *model) LogRecords() []LogRecord {
+	var result []LogRecord
+	for _, record := range m.LogRecords_.LogRecords_ {
+		result = append(result, record)
+	}
+	return result
+}
+
+// AddLogRecord implements Model.
+func (m *model) AddLogRecord(args LogRecordArgs) LogRecord {
+	record := newLogRecord(args)
+	m.LogRecords_.LogRecords_ = append(m.LogRecords_.LogRecords_, record)
+	return record
+}
+
+func (m *model) setLogRecords(recordList []*logRecord) {
+	m.LogRecords_ = logRecords{
+		Version:     1,
+		LogRecords_: recordList,
+	}
+}
+
 // Sequences implements Model.
 func (m *model) Sequences() map[string]int {
 	return m.Sequences_
@@ -450,6 +477,19 @@ func (m *model) SetConstraints(args ConstraintsArgs) {
 	m.Constraints_ = newConstraints(args)
 }
 
+// CloudInit implements HasCloudInit.
+func (m *model) CloudInit() CloudInit {
+	if m.CloudInit_ == nil {
+		return nil
+	}
+	return m.CloudInit_
+}
+
+// SetCloudInit implements HasCloudInit.
+func (m *model) SetCloudInit(args CloudInitArgs) {
+	m.CloudInit_ = newCloudInit(args)
+}
+
 // Cloud implements Model.
 func (m *model) Cloud() string {
 	return m.Cloud_
@@ -853,6 +893,7 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"relations":        schema.StringMap(schema.Any()),
 		"sshhostkeys":      schema.StringMap(schema.Any()),
 		"actions":          schema.StringMap(schema.Any()),
+		"logs":             schema.StringMap(schema.Any()),
 		"ipaddresses":      schema.StringMap(schema.Any()),
 		"spaces":           schema.StringMap(schema.Any()),
 		"subnets":          schema.StringMap(schema.Any()),
@@ -867,9 +908,13 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"latest-tools": schema.Omit,
 		"blocks":       schema.Omit,
 		"cloud-region": schema.Omit,
+		// Log records are only included when the source
+		// controller is configured to migrate them.
+		"logs": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
+	addCloudInitSchema(fields, defaults)
 	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
@@ -902,6 +947,14 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		result.Constraints_ = constraints
 	}
 
+	if cloudInitMap, ok := valid["cloud-init"]; ok {
+		cloudInit, err := importCloudInit(cloudInitMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.CloudInit_ = cloudInit
+	}
+
 	if availableTools, ok := valid["latest-tools"]; ok {
 		num, err := version.Parse(availableTools.(string))
 		if err != nil {
@@ -988,6 +1041,15 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setActions(actions)
 
+	result.setLogRecords(nil)
+	if logsMap, ok := valid["logs"]; ok {
+		records, err := importLogRecords(logsMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "logs")
+		}
+		result.setLogRecords(records)
+	}
+
 	volumes, err := importVolumes(valid["volumes"].(map[string]interface{}))
 	if err != nil {
 		return nil, errors.Annotate(err, "volumes")
@@ -176,6 +176,22 @@ func (s *ModelSerializationSuite) TestConstraints(c *gc.C) {
 	c.Assert(model.Constraints(), jc.DeepEquals, newConstraints(args))
 }
 
+func (s *ModelSerializationSuite) TestCloudInit(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	args := CloudInitArgs{
+		Packages:  []string{"htop"},
+		AptMirror: "http://mirror.example.com/ubuntu",
+	}
+	initial.SetCloudInit(args)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.CloudInit(), jc.DeepEquals, newCloudInit(args))
+}
+
 func (*ModelSerializationSuite) TestModelValidation(c *gc.C) {
 	model := NewModel(ModelArgs{})
 	err := model.Validate()
@@ -658,6 +674,45 @@ func (s *ModelSerializationSuite) TestAction(c *gc.C) {
 	c.Assert(model.Actions(), jc.DeepEquals, actions)
 }
 
+func (s *ModelSerializationSuite) TestLogRecords(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	record := initial.AddLogRecord(LogRecordArgs{
+		Time:    time.Now().UTC(),
+		Entity:  "machine-0",
+		Module:  "juju.worker",
+		Level:   "INFO",
+		Message: "hello",
+	})
+	records := initial.LogRecords()
+	c.Assert(records, gc.HasLen, 1)
+	c.Assert(records[0], jc.DeepEquals, record)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.LogRecords(), jc.DeepEquals, records)
+}
+
+func (s *ModelSerializationSuite) TestLogRecordsOptional(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Models serialized without log records remain valid.
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	delete(source, "logs")
+	bytes, err = yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.LogRecords(), gc.HasLen, 0)
+}
+
 func (s *ModelSerializationSuite) TestVolumeValidation(c *gc.C) {
 	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	model.AddVolume(testVolumeArgs())
@@ -18,18 +18,28 @@ type relation struct {
 	Id_        int        `yaml:"id"`
 	Key_       string     `yaml:"key"`
 	Endpoints_ *endpoints `yaml:"endpoints"`
+
+	ExcludeControllers_ bool     `yaml:"exclude-controllers,omitempty"`
+	ExcludeMachines_    []string `yaml:"exclude-machines,omitempty"`
 }
 
 // RelationArgs is an argument struct used to specify a relation.
 type RelationArgs struct {
 	Id  int
 	Key string
+
+	// ExcludeControllers and ExcludeMachines describe the subordinate
+	// placement policy of a relation with container scope.
+	ExcludeControllers bool
+	ExcludeMachines    []string
 }
 
 func newRelation(args RelationArgs) *relation {
 	relation := &relation{
-		Id_:  args.Id,
-		Key_: args.Key,
+		Id_:                 args.Id,
+		Key_:                args.Key,
+		ExcludeControllers_: args.ExcludeControllers,
+		ExcludeMachines_:    args.ExcludeMachines,
 	}
 	relation.setEndpoints(nil)
 	return relation
@@ -45,6 +55,16 @@ func (r *relation) Key() string {
 	return r.Key_
 }
 
+// ExcludeControllers implements Relation.
+func (r *relation) ExcludeControllers() bool {
+	return r.ExcludeControllers_
+}
+
+// ExcludeMachines implements Relation.
+func (r *relation) ExcludeMachines() []string {
+	return r.ExcludeMachines_
+}
+
 // Endpoints implements Relation.
 func (r *relation) Endpoints() []Endpoint {
 	result := make([]Endpoint, len(r.Endpoints_.Endpoints_))
@@ -109,12 +129,18 @@ var relationDeserializationFuncs = map[int]relationDeserializationFunc{
 
 func importRelationV1(source map[string]interface{}) (*relation, error) {
 	fields := schema.Fields{
-		"id":        schema.Int(),
-		"key":       schema.String(),
-		"endpoints": schema.StringMap(schema.Any()),
+		"id":                  schema.Int(),
+		"key":                 schema.String(),
+		"endpoints":           schema.StringMap(schema.Any()),
+		"exclude-controllers": schema.Bool(),
+		"exclude-machines":    schema.List(schema.String()),
 	}
-
-	checker := schema.FieldMap(fields, nil) // no defaults
+	// Some values don't have to be there.
+	defaults := schema.Defaults{
+		"exclude-controllers": false,
+		"exclude-machines":    schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
@@ -124,8 +150,10 @@ func importRelationV1(source map[string]interface{}) (*relation, error) {
 	// From here we know that the map returned from the schema coercion
 	// contains fields of the right type.
 	result := &relation{
-		Id_:  int(valid["id"].(int64)),
-		Key_: valid["key"].(string),
+		Id_:                 int(valid["id"].(int64)),
+		Key_:                valid["key"].(string),
+		ExcludeControllers_: valid["exclude-controllers"].(bool),
+		ExcludeMachines_:    convertToStringSlice(valid["exclude-machines"]),
 	}
 
 	endpoints, err := importEndpoints(valid["endpoints"].(map[string]interface{}))
@@ -50,12 +50,16 @@ func (s *RelationSerializationSuite) completeRelation() *relation {
 
 func (s *RelationSerializationSuite) TestNewRelation(c *gc.C) {
 	relation := newRelation(RelationArgs{
-		Id:  42,
-		Key: "special",
+		Id:                 42,
+		Key:                "special",
+		ExcludeControllers: true,
+		ExcludeMachines:    []string{"1"},
 	})
 
 	c.Assert(relation.Id(), gc.Equals, 42)
 	c.Assert(relation.Key(), gc.Equals, "special")
+	c.Assert(relation.ExcludeControllers(), jc.IsTrue)
+	c.Assert(relation.ExcludeMachines(), jc.DeepEquals, []string{"1"})
 	c.Assert(relation.Endpoints(), gc.HasLen, 0)
 }
 
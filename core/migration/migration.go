@@ -4,6 +4,7 @@
 package migration
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/version"
@@ -28,6 +29,14 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// ArtifactsRemaining holds the number of charms and tools still
+	// to be transferred to the target controller.
+	ArtifactsRemaining int
+
+	// AgentsUnreported holds the number of agents which have not yet
+	// reported on the current phase.
+	AgentsUnreported int
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -45,3 +54,37 @@ type SerializedModel struct {
 	// source controller.
 	Tools map[version.Binary]string // version -> tools URI
 }
+
+// ModelUsage holds the number of machines and units in a model being
+// migrated, which the target controller must have capacity for.
+type ModelUsage struct {
+	Machines int
+	Units    int
+}
+
+// CapacityShortfall describes a resource of the target controller
+// whose capacity limit would be exceeded by importing a model.
+type CapacityShortfall struct {
+	// Resource is the resource that is short, such as "machines".
+	Resource string
+
+	// Incoming is the amount of the resource used by the model being
+	// migrated.
+	Incoming int
+
+	// Usage is the amount of the resource already used in the target
+	// controller.
+	Usage int
+
+	// Limit is the target controller's configured limit for the
+	// resource.
+	Limit int
+}
+
+// String returns a human readable description of the shortfall.
+func (s CapacityShortfall) String() string {
+	return fmt.Sprintf(
+		"%s limit is %d, with %d in use; cannot add %d",
+		s.Resource, s.Limit, s.Usage, s.Incoming,
+	)
+}
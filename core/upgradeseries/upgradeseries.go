@@ -0,0 +1,216 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries holds the rules by which the units of a machine
+// are coordinated while the machine's operating system series is
+// upgraded.
+//
+// A series upgrade has two phases. In the prepare phase, each unit runs
+// its pre-series-upgrade hook; once every unit has done so, the operator
+// upgrades the machine's operating system. In the complete phase, each
+// unit runs its post-series-upgrade hook. The units' hooks are ordered
+// by their relationship on the machine: subordinates are prepared before
+// their principal, so that nothing is left depending on a principal
+// workload when it is stopped, and are completed after it, so that the
+// principal workload is running before anything that depends on it is
+// restarted.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+)
+
+// Status describes how far a machine, or a unit on the machine, has
+// progressed through a series upgrade.
+type Status string
+
+const (
+	// NotStarted is the status of a unit which is waiting for its
+	// subordinates to be prepared.
+	NotStarted Status = "not started"
+
+	// PrepareStarted is the status of a unit which is due to run its
+	// pre-series-upgrade hook, and of a machine with such units.
+	PrepareStarted Status = "prepare started"
+
+	// PrepareCompleted is the status of a unit which has run its
+	// pre-series-upgrade hook, and of a machine all of whose units
+	// have; the machine's operating system may then be upgraded.
+	PrepareCompleted Status = "prepare completed"
+
+	// CompleteStarted is the status of a unit which is due to run its
+	// post-series-upgrade hook, and of a machine with such units.
+	CompleteStarted Status = "complete started"
+
+	// Completed is the status of a unit which has run its
+	// post-series-upgrade hook, and of a machine all of whose units
+	// have.
+	Completed Status = "completed"
+)
+
+// Unit holds the series upgrade status of a unit on the machine.
+type Unit struct {
+	// Principal is the name of the unit's principal, if the unit is
+	// a subordinate.
+	Principal string
+
+	// Status is the unit's progress through the series upgrade.
+	Status Status
+}
+
+// Lock holds the state of a machine's series upgrade.
+type Lock struct {
+	FromSeries    string
+	ToSeries      string
+	MachineStatus Status
+
+	// Units holds the status of each unit on the machine, keyed by
+	// unit name.
+	Units map[string]Unit
+}
+
+// Prepare returns the lock for an upgrade of a machine from one series
+// to another, with its prepare phase started. The principals map holds
+// the name of each unit on the machine, mapped to the name of its
+// principal if it is a subordinate, and to "" otherwise.
+func Prepare(fromSeries, toSeries string, principals map[string]string) Lock {
+	lock := Lock{
+		FromSeries:    fromSeries,
+		ToSeries:      toSeries,
+		MachineStatus: PrepareStarted,
+		Units:         make(map[string]Unit),
+	}
+	for name, principal := range principals {
+		status := NotStarted
+		if principal != "" {
+			status = PrepareStarted
+		}
+		lock.Units[name] = Unit{Principal: principal, Status: status}
+	}
+	lock.advance()
+	return lock
+}
+
+// Complete starts the complete phase of the series upgrade. It may only
+// be called once every unit on the machine has been prepared.
+func (l *Lock) Complete() error {
+	if l.MachineStatus != PrepareCompleted {
+		return errors.Errorf("cannot complete series upgrade with status %q", l.MachineStatus)
+	}
+	l.MachineStatus = CompleteStarted
+	for name, unit := range l.Units {
+		if !l.isSubordinate(unit) {
+			unit.Status = CompleteStarted
+			l.Units[name] = unit
+		}
+	}
+	l.advance()
+	return nil
+}
+
+// SetUnitStatus records that the named unit has run its
+// pre-series-upgrade hook, with status PrepareCompleted, or its
+// post-series-upgrade hook, with status Completed, and starts the
+// phase of any unit whose turn has come.
+func (l *Lock) SetUnitStatus(name string, status Status) error {
+	unit, ok := l.Units[name]
+	if !ok {
+		return errors.NotFoundf("unit %q in series upgrade", name)
+	}
+	var from Status
+	switch status {
+	case PrepareCompleted:
+		from = PrepareStarted
+	case Completed:
+		from = CompleteStarted
+	default:
+		return errors.NotValidf("unit series upgrade status %q", status)
+	}
+	if unit.Status != from {
+		return errors.Errorf("cannot set series upgrade status of unit %q to %q: status is %q", name, status, unit.Status)
+	}
+	unit.Status = status
+	l.Units[name] = unit
+	l.advance()
+	return nil
+}
+
+// CheckAbort returns an error if the series upgrade can no longer be
+// abandoned, because the machine's operating system may already have
+// been upgraded.
+func (l *Lock) CheckAbort() error {
+	switch l.MachineStatus {
+	case PrepareStarted, PrepareCompleted:
+		return nil
+	}
+	return errors.Errorf("cannot abort series upgrade with status %q", l.MachineStatus)
+}
+
+// RemoveUnit removes the named unit from the series upgrade, when it
+// has left the machine, and starts the phase of any unit that was
+// waiting for it.
+func (l *Lock) RemoveUnit(name string) {
+	delete(l.Units, name)
+	l.advance()
+}
+
+// isSubordinate returns whether the unit is a subordinate of another
+// unit in the lock.
+func (l *Lock) isSubordinate(unit Unit) bool {
+	if unit.Principal == "" {
+		return false
+	}
+	_, ok := l.Units[unit.Principal]
+	return ok
+}
+
+// advance starts the phase of each unit whose turn has come, and
+// updates the machine's status once every unit has finished the
+// current phase.
+func (l *Lock) advance() {
+	switch l.MachineStatus {
+	case PrepareStarted:
+		for name, unit := range l.Units {
+			if unit.Status == NotStarted && l.subordinatesHaveStatus(name, PrepareCompleted) {
+				unit.Status = PrepareStarted
+				l.Units[name] = unit
+			}
+		}
+		if l.allUnitsHaveStatus(PrepareCompleted) {
+			l.MachineStatus = PrepareCompleted
+		}
+	case CompleteStarted:
+		for name, unit := range l.Units {
+			if unit.Status != PrepareCompleted {
+				continue
+			}
+			// A subordinate whose principal has left the machine
+			// no longer waits for it.
+			if !l.isSubordinate(unit) || l.Units[unit.Principal].Status == Completed {
+				unit.Status = CompleteStarted
+				l.Units[name] = unit
+			}
+		}
+		if l.allUnitsHaveStatus(Completed) {
+			l.MachineStatus = Completed
+		}
+	}
+}
+
+func (l *Lock) subordinatesHaveStatus(principal string, status Status) bool {
+	for _, unit := range l.Units {
+		if unit.Principal == principal && unit.Status != status {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *Lock) allUnitsHaveStatus(status Status) bool {
+	for _, unit := range l.Units {
+		if unit.Status != status {
+			return false
+		}
+	}
+	return true
+}
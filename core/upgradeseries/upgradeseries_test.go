@@ -0,0 +1,153 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/upgradeseries"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+var principals = map[string]string{
+	"mysql/0":     "",
+	"logging/0":   "mysql/0",
+	"nrpe/0":      "mysql/0",
+	"wordpress/0": "",
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareOrdersSubordinatesFirst(c *gc.C) {
+	lock := upgradeseries.Prepare("trusty", "xenial", principals)
+	c.Assert(lock, jc.DeepEquals, upgradeseries.Lock{
+		FromSeries:    "trusty",
+		ToSeries:      "xenial",
+		MachineStatus: upgradeseries.PrepareStarted,
+		Units: map[string]upgradeseries.Unit{
+			"mysql/0":     {Status: upgradeseries.NotStarted},
+			"logging/0":   {Principal: "mysql/0", Status: upgradeseries.PrepareStarted},
+			"nrpe/0":      {Principal: "mysql/0", Status: upgradeseries.PrepareStarted},
+			"wordpress/0": {Status: upgradeseries.PrepareStarted},
+		},
+	})
+
+	err := lock.SetUnitStatus("logging/0", upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Units["mysql/0"].Status, gc.Equals, upgradeseries.NotStarted)
+	err = lock.SetUnitStatus("nrpe/0", upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Units["mysql/0"].Status, gc.Equals, upgradeseries.PrepareStarted)
+
+	for _, name := range []string{"mysql/0", "wordpress/0"} {
+		c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.PrepareStarted)
+		err = lock.SetUnitStatus(name, upgradeseries.PrepareCompleted)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.PrepareCompleted)
+}
+
+func (s *UpgradeSeriesSuite) TestCompleteOrdersPrincipalsFirst(c *gc.C) {
+	lock := upgradeseries.Prepare("trusty", "xenial", principals)
+	for _, name := range []string{"logging/0", "nrpe/0", "mysql/0", "wordpress/0"} {
+		err := lock.SetUnitStatus(name, upgradeseries.PrepareCompleted)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err := lock.Complete()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.CompleteStarted)
+	c.Assert(lock.Units, jc.DeepEquals, map[string]upgradeseries.Unit{
+		"mysql/0":     {Status: upgradeseries.CompleteStarted},
+		"logging/0":   {Principal: "mysql/0", Status: upgradeseries.PrepareCompleted},
+		"nrpe/0":      {Principal: "mysql/0", Status: upgradeseries.PrepareCompleted},
+		"wordpress/0": {Status: upgradeseries.CompleteStarted},
+	})
+
+	err = lock.SetUnitStatus("mysql/0", upgradeseries.Completed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Units["logging/0"].Status, gc.Equals, upgradeseries.CompleteStarted)
+	c.Assert(lock.Units["nrpe/0"].Status, gc.Equals, upgradeseries.CompleteStarted)
+
+	for _, name := range []string{"logging/0", "nrpe/0", "wordpress/0"} {
+		c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.CompleteStarted)
+		err = lock.SetUnitStatus(name, upgradeseries.Completed)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.Completed)
+}
+
+func (s *UpgradeSeriesSuite) TestNoUnits(c *gc.C) {
+	lock := upgradeseries.Prepare("trusty", "xenial", nil)
+	c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.PrepareCompleted)
+	err := lock.Complete()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.Completed)
+}
+
+func (s *UpgradeSeriesSuite) TestCompleteNotPrepared(c *gc.C) {
+	lock := upgradeseries.Prepare("trusty", "xenial", principals)
+	err := lock.Complete()
+	c.Assert(err, gc.ErrorMatches, `cannot complete series upgrade with status "prepare started"`)
+}
+
+func (s *UpgradeSeriesSuite) TestSetUnitStatusInvalid(c *gc.C) {
+	lock := upgradeseries.Prepare("trusty", "xenial", principals)
+	err := lock.SetUnitStatus("mongodb/0", upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = lock.SetUnitStatus("nrpe/0", upgradeseries.CompleteStarted)
+	c.Assert(err, gc.ErrorMatches, `unit series upgrade status "complete started" not valid`)
+	err = lock.SetUnitStatus("mysql/0", upgradeseries.PrepareCompleted)
+	c.Assert(err, gc.ErrorMatches, `cannot set series upgrade status of unit "mysql/0" to "prepare completed": status is "not started"`)
+	err = lock.SetUnitStatus("nrpe/0", upgradeseries.Completed)
+	c.Assert(err, gc.ErrorMatches, `cannot set series upgrade status of unit "nrpe/0" to "completed": status is "prepare started"`)
+}
+
+func (s *UpgradeSeriesSuite) TestCheckAbort(c *gc.C) {
+	lock := upgradeseries.Prepare("trusty", "xenial", principals)
+	c.Assert(lock.CheckAbort(), jc.ErrorIsNil)
+	for _, name := range []string{"logging/0", "nrpe/0", "mysql/0", "wordpress/0"} {
+		err := lock.SetUnitStatus(name, upgradeseries.PrepareCompleted)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(lock.CheckAbort(), jc.ErrorIsNil)
+
+	err := lock.Complete()
+	c.Assert(err, jc.ErrorIsNil)
+	err = lock.CheckAbort()
+	c.Assert(err, gc.ErrorMatches, `cannot abort series upgrade with status "complete started"`)
+}
+
+func (s *UpgradeSeriesSuite) TestRemoveUnitStartsWaitingPrincipal(c *gc.C) {
+	lock := upgradeseries.Prepare("trusty", "xenial", principals)
+	err := lock.SetUnitStatus("logging/0", upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+
+	lock.RemoveUnit("nrpe/0")
+	c.Assert(lock.Units["mysql/0"].Status, gc.Equals, upgradeseries.PrepareStarted)
+
+	lock.RemoveUnit("mysql/0")
+	lock.RemoveUnit("wordpress/0")
+	c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.PrepareCompleted)
+}
+
+func (s *UpgradeSeriesSuite) TestRemoveUnitStartsOrphanedSubordinate(c *gc.C) {
+	lock := upgradeseries.Prepare("trusty", "xenial", principals)
+	for _, name := range []string{"logging/0", "nrpe/0", "mysql/0", "wordpress/0"} {
+		err := lock.SetUnitStatus(name, upgradeseries.PrepareCompleted)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := lock.Complete()
+	c.Assert(err, jc.ErrorIsNil)
+
+	lock.RemoveUnit("mysql/0")
+	c.Assert(lock.Units["logging/0"].Status, gc.Equals, upgradeseries.CompleteStarted)
+	c.Assert(lock.Units["nrpe/0"].Status, gc.Equals, upgradeseries.CompleteStarted)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -45,6 +46,15 @@ type StartInstanceParams struct {
 	// high availability.
 	DistributionGroup func() ([]instance.Id, error)
 
+	// AvailabilityZones, if non-empty, holds the names of the
+	// availability zones in which the instance may be started, in
+	// order of preference. It is chosen by the provisioner according
+	// to the model's availability zone policy. An InstanceBroker that
+	// supports availability zones must try them in order, moving on
+	// to the next only when the instance cannot be started in one;
+	// a placement directive naming a zone takes precedence.
+	AvailabilityZones []string
+
 	// Volumes is a set of parameters for volumes that should be created.
 	//
 	// StartInstance need not check the value of the Attachment field,
@@ -70,6 +80,11 @@ type StartInstanceParams struct {
 	// that may be used to start this instance.
 	ImageMetadata []*imagemetadata.ImageMetadata
 
+	// InstanceTypes holds the instance types cached by the controller
+	// for the environ's provider. If non-empty, providers may use these
+	// to match constraints instead of querying the cloud.
+	InstanceTypes []instances.InstanceType
+
 	// StatusCallback is a callback to be used by the instance to report changes in status.
 	StatusCallback func(settableStatus status.Status, info string, data map[string]interface{}) error
 }
@@ -43,6 +43,16 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// ZoneSpread requests that the instances of each application be
+	// started in as many different availability zones as possible.
+	ZoneSpread = "spread"
+
+	// ZonePack requests that the instances of each application be
+	// started in as few availability zones as possible.
+	ZonePack = "pack"
+)
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -125,6 +135,15 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// AvailabilityZonePolicyKey determines how the provisioner
+	// distributes the instances of each application across the
+	// availability zones of the cloud.
+	AvailabilityZonePolicyKey = "availability-zone-policy"
+
+	// NetworkTrafficAccountingKey determines whether the network
+	// traffic of the model's machines is recorded by the controller.
+	NetworkTrafficAccountingKey = "network-traffic-accounting"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -763,6 +782,23 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// AvailabilityZonePolicy reports how the provisioner should distribute
+// the instances of each application across availability zones
+// (ZoneSpread or ZonePack).
+func (c *Config) AvailabilityZonePolicy() string {
+	if v, ok := c.defined[AvailabilityZonePolicyKey].(string); ok && v != "" {
+		return v
+	}
+	return ZoneSpread
+}
+
+// NetworkTrafficAccounting reports whether the controller should
+// record the network traffic of the model's machines.
+func (c *Config) NetworkTrafficAccounting() bool {
+	v, _ := c.defined[NetworkTrafficAccountingKey].(bool)
+	return v
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -955,6 +991,8 @@ var alwaysOptional = schema.Defaults{
 	"disable-network-management": schema.Omit,
 	IgnoreMachineAddresses:       schema.Omit,
 	AutomaticallyRetryHooks:      schema.Omit,
+	AvailabilityZonePolicyKey:    schema.Omit,
+	NetworkTrafficAccountingKey:  schema.Omit,
 	"test-mode":                  schema.Omit,
 }
 
@@ -1306,4 +1344,21 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	AvailabilityZonePolicyKey: {
+		Description: `How the instances of each application are distributed across availability zones.
+
+'spread' starts each instance in the zone holding fewest of the application's instances.
+
+'pack' starts each instance in the zone holding most of them.
+
+Placement directives naming a zone take precedence.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{ZoneSpread, ZonePack},
+		Group:  environschema.EnvironGroup,
+	},
+	NetworkTrafficAccountingKey: {
+		Description: "Whether the network traffic of the model's machines is recorded, to help attribute cloud egress costs (default false)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 }
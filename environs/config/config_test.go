@@ -285,6 +285,19 @@ var configTests = []configTest{
 			"firewall-mode": "illegal",
 		}),
 		err: `firewall-mode: expected one of \[instance global none\], got "illegal"`,
+	}, {
+		about:       "Pack availability zone policy",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"availability-zone-policy": config.ZonePack,
+		}),
+	}, {
+		about:       "Illegal availability zone policy",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"availability-zone-policy": "scatter",
+		}),
+		err: `availability-zone-policy: expected one of \[spread pack\], got "scatter"`,
 	}, {
 		about:       "ssl-hostname-verification off",
 		useDefaults: config.UseDefaults,
@@ -603,6 +616,12 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Assert(cfg.FirewallMode(), gc.Equals, m)
 	}
 
+	if p, _ := test.attrs["availability-zone-policy"].(string); p != "" {
+		c.Assert(cfg.AvailabilityZonePolicy(), gc.Equals, p)
+	} else {
+		c.Assert(cfg.AvailabilityZonePolicy(), gc.Equals, config.ZoneSpread)
+	}
+
 	keys, _ := test.attrs["authorized-keys"].(string)
 	c.Assert(cfg.AuthorizedKeys(), gc.Equals, keys)
 
@@ -967,6 +986,17 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestNetworkTrafficAccountingDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.NetworkTrafficAccounting(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestNetworkTrafficAccounting(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"network-traffic-accounting": true})
+	c.Assert(config.NetworkTrafficAccounting(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"io"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+)
+
+// CredentialPrompter is implemented by providers which offer a
+// provider-specific flow for interactively adding credentials, such
+// as signing in through a web browser, and which can check credentials
+// against the cloud before they are stored.
+type CredentialPrompter interface {
+	// PromptCredential interactively obtains a credential with the
+	// given auth type. If the prompter has no interactive flow for
+	// the auth type, PromptCredential returns an error satisfying
+	// errors.IsNotSupported, and the caller should prompt for the
+	// attributes of the auth type's credential schema instead.
+	PromptCredential(ctx CredentialPromptContext, authType cloud.AuthType) (*cloud.Credential, error)
+
+	// ValidateCredential checks that the credential in the given
+	// cloud spec may be used to access the cloud. The credential
+	// will have been finalized, so any file attributes will have
+	// been replaced by the contents of the files.
+	ValidateCredential(spec CloudSpec) error
+}
+
+// CredentialPromptContext provides a CredentialPrompter with the cloud
+// for which a credential is being added, and the means to interact
+// with the user.
+type CredentialPromptContext struct {
+	// Cloud describes the cloud for which a credential is being
+	// added. Its Credential field is nil.
+	Cloud CloudSpec
+
+	// Out is where instructions and progress are written.
+	Out io.Writer
+
+	// Prompt asks the user the given question, and returns the
+	// answer. If hidden is true, the answer is not echoed.
+	Prompt func(question string, hidden bool) (string, error)
+
+	// Select asks the user to choose one of the given options, and
+	// returns the chosen option.
+	Select func(question string, options []string) (string, error)
+}
+
+var (
+	credentialPromptersMu sync.RWMutex
+	credentialPrompters   = make(map[string]CredentialPrompter)
+)
+
+// RegisterCredentialPrompter registers the CredentialPrompter for the
+// provider with the given type, replacing any prompter previously
+// registered for the provider.
+func RegisterCredentialPrompter(providerType string, p CredentialPrompter) {
+	credentialPromptersMu.Lock()
+	defer credentialPromptersMu.Unlock()
+	credentialPrompters[providerType] = p
+}
+
+// UnregisterCredentialPrompter unregisters the CredentialPrompter for
+// the provider with the given type.
+func UnregisterCredentialPrompter(providerType string) {
+	credentialPromptersMu.Lock()
+	defer credentialPromptersMu.Unlock()
+	delete(credentialPrompters, providerType)
+}
+
+// CredentialPrompterFor returns the CredentialPrompter registered for
+// the provider with the given type. If there is none, an error
+// satisfying errors.IsNotFound is returned.
+func CredentialPrompterFor(providerType string) (CredentialPrompter, error) {
+	credentialPromptersMu.RLock()
+	defer credentialPromptersMu.RUnlock()
+	p, ok := credentialPrompters[providerType]
+	if !ok {
+		return nil, errors.NotFoundf("credential prompter for %q provider", providerType)
+	}
+	return p, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CredentialPromptersSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&CredentialPromptersSuite{})
+
+func (s *CredentialPromptersSuite) TestRegisterCredentialPrompter(c *gc.C) {
+	_, err := environs.CredentialPrompterFor("prompter-test")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `credential prompter for "prompter-test" provider not found`)
+
+	prompter := &testCredentialPrompter{}
+	environs.RegisterCredentialPrompter("prompter-test", prompter)
+	defer environs.UnregisterCredentialPrompter("prompter-test")
+	p, err := environs.CredentialPrompterFor("prompter-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p, gc.Equals, prompter)
+
+	other := &testCredentialPrompter{}
+	environs.RegisterCredentialPrompter("prompter-test", other)
+	p, err = environs.CredentialPrompterFor("prompter-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p, gc.Equals, other)
+}
+
+func (s *CredentialPromptersSuite) TestUnregisterCredentialPrompter(c *gc.C) {
+	environs.RegisterCredentialPrompter("prompter-test", &testCredentialPrompter{})
+	environs.UnregisterCredentialPrompter("prompter-test")
+	_, err := environs.CredentialPrompterFor("prompter-test")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type testCredentialPrompter struct {
+	environs.CredentialPrompter
+}
@@ -12,3 +12,62 @@ var (
 	ErrNoInstances      = errors.NotFoundf("instances")
 	ErrPartialInstances = errors.New("only some instances were found")
 )
+
+// StartInstanceErrorClass classifies the reason an instance could
+// not be started, so that the provisioner can decide whether and
+// when to try again.
+type StartInstanceErrorClass string
+
+const (
+	// StartInstanceErrorUnknown is the class of errors which have
+	// not been classified by the provider.
+	StartInstanceErrorUnknown StartInstanceErrorClass = ""
+
+	// StartInstanceErrorQuota is the class of errors caused by
+	// the cloud account's limits being reached.
+	StartInstanceErrorQuota StartInstanceErrorClass = "quota"
+
+	// StartInstanceErrorCapacity is the class of errors caused by
+	// the cloud temporarily lacking the capacity to satisfy the
+	// request.
+	StartInstanceErrorCapacity StartInstanceErrorClass = "capacity"
+
+	// StartInstanceErrorCredential is the class of errors caused
+	// by the cloud rejecting the model's credential.
+	StartInstanceErrorCredential StartInstanceErrorClass = "credential"
+
+	// StartInstanceErrorImageNotFound is the class of errors caused
+	// by no suitable image being available.
+	StartInstanceErrorImageNotFound StartInstanceErrorClass = "image-not-found"
+
+	// StartInstanceErrorTransient is the class of errors which are
+	// expected to go away by themselves, such as API rate limiting
+	// or internal cloud errors.
+	StartInstanceErrorTransient StartInstanceErrorClass = "transient"
+)
+
+// startInstanceError records the class of an error returned by
+// InstanceBroker.StartInstance.
+type startInstanceError struct {
+	error
+	class StartInstanceErrorClass
+}
+
+// NewStartInstanceError returns an error which wraps err and which
+// records its class. The class survives annotation of the error, and
+// may be retrieved with StartInstanceErrorClassOf.
+func NewStartInstanceError(err error, class StartInstanceErrorClass) error {
+	if err == nil {
+		return nil
+	}
+	return &startInstanceError{err, class}
+}
+
+// StartInstanceErrorClassOf returns the class recorded in err by
+// NewStartInstanceError, or StartInstanceErrorUnknown if none was.
+func StartInstanceErrorClassOf(err error) StartInstanceErrorClass {
+	if err, ok := errors.Cause(err).(*startInstanceError); ok {
+		return err.class
+	}
+	return StartInstanceErrorUnknown
+}
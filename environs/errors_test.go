@@ -0,0 +1,40 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type errorsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&errorsSuite{})
+
+func (s *errorsSuite) TestStartInstanceErrorClass(c *gc.C) {
+	err := environs.NewStartInstanceError(errors.New("no capacity"), environs.StartInstanceErrorCapacity)
+	c.Assert(err, gc.ErrorMatches, "no capacity")
+	c.Assert(environs.StartInstanceErrorClassOf(err), gc.Equals, environs.StartInstanceErrorCapacity)
+
+	err = errors.Annotate(errors.Trace(err), "cannot run instances")
+	c.Assert(err, gc.ErrorMatches, "cannot run instances: no capacity")
+	c.Assert(environs.StartInstanceErrorClassOf(err), gc.Equals, environs.StartInstanceErrorCapacity)
+}
+
+func (s *errorsSuite) TestStartInstanceErrorClassUnknown(c *gc.C) {
+	err := errors.New("boom")
+	c.Assert(environs.StartInstanceErrorClassOf(err), gc.Equals, environs.StartInstanceErrorUnknown)
+	c.Assert(environs.StartInstanceErrorClassOf(nil), gc.Equals, environs.StartInstanceErrorUnknown)
+}
+
+func (s *errorsSuite) TestNewStartInstanceErrorNil(c *gc.C) {
+	err := environs.NewStartInstanceError(nil, environs.StartInstanceErrorQuota)
+	c.Assert(err, jc.ErrorIsNil)
+}
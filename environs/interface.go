@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
@@ -51,8 +52,16 @@ type OpenParams struct {
 
 	// Config is the base configuration for the provider.
 	Config *config.Config
+
+	// InvalidateCredential, if non-nil, is called by the provider when
+	// the cloud rejects the credential in Cloud as no longer valid.
+	InvalidateCredential InvalidateCredentialFunc
 }
 
+// InvalidateCredentialFunc records that the cloud credential used by
+// an Environ is no longer valid, for the supplied reason.
+type InvalidateCredentialFunc func(reason string) error
+
 // ProviderSchema can be implemented by a provider to provide
 // access to its configuration schema. Once all providers implement
 // this, it will be included in the EnvironProvider type and the
@@ -286,6 +295,48 @@ type Firewaller interface {
 	Ports() ([]network.PortRange, error)
 }
 
+// FirewallRuleSetter is implemented by environs which can restrict
+// the source addresses of ingress for well-known services, to enforce
+// the model-level firewall rules.
+type FirewallRuleSetter interface {
+	// SetFirewallRule restricts ingress for the rule's well-known
+	// service to the rule's source CIDRs. If the environ has no
+	// resources to which the rule may yet be applied, such as when
+	// no machines have been started, an error satisfying
+	// errors.IsNotFound is returned, and the rule should be set again
+	// later. If the environ cannot enforce rules for the service, an
+	// error satisfying errors.IsNotSupported is returned.
+	SetFirewallRule(rule network.FirewallRule) error
+}
+
+// InstancePortSourceSetter is implemented by instances which can
+// restrict the source addresses of ingress to their opened ports, to
+// enforce the source CIDRs to which applications are exposed.
+type InstancePortSourceSetter interface {
+	// SetPortSources opens the given port ranges on the instance to
+	// ingress from the given source CIDRs only, revoking ingress from
+	// any other source. If no CIDRs are given, ingress is allowed
+	// from any address. Must only be used if the environment was
+	// setup with the FwInstance firewall mode.
+	SetPortSources(machineId string, ports []network.PortRange, sourceCIDRs []string) error
+}
+
+// PortSourceRestricter is implemented by environs whose instances
+// implement InstancePortSourceSetter.
+type PortSourceRestricter interface {
+	// SupportsPortSources reports whether the environ, with its
+	// current firewall mode, can restrict the source addresses of
+	// ingress to the ports opened on its instances.
+	SupportsPortSources() bool
+}
+
+// SupportsPortSources reports whether applications in the given
+// environ may be exposed to particular source CIDRs only.
+func SupportsPortSources(env Environ) bool {
+	restricter, ok := env.(PortSourceRestricter)
+	return ok && restricter.SupportsPortSources()
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -294,3 +345,54 @@ type InstanceTagger interface {
 	// same names, but other existing tags will be left alone.
 	TagInstance(id instance.Id, tags map[string]string) error
 }
+
+// InstanceTypesFetcher is implemented by environs which can list the
+// instance types offered by the cloud. The controller caches the
+// result, so that provisioning decisions need not query the cloud.
+type InstanceTypesFetcher interface {
+	// InstanceTypes returns all of the instance types available
+	// to the environ, in the environ's configured region.
+	InstanceTypes() ([]instances.InstanceType, error)
+}
+
+// QuotaChecker is implemented by environs which can report how much
+// of the cloud account's quota remains. The provisioner uses it to
+// fail fast when starting an instance cannot possibly succeed.
+type QuotaChecker interface {
+	// RemainingQuota returns the quota remaining for starting
+	// instances in the given availability zone. If the zone is
+	// empty, or the cloud's quotas are not per-zone, the quota
+	// remaining in the environ's region is returned.
+	RemainingQuota(zone string) (Quota, error)
+}
+
+// Quota describes the resources which may still be allocated in
+// a cloud account before its limits are reached. A nil limit is
+// either unknown or unlimited.
+type Quota struct {
+	// Location names the region or zone to which the quota
+	// applies, for reporting to the user.
+	Location string
+
+	// Instances is the number of instances which may still
+	// be started.
+	Instances *uint64
+
+	// Cores is the number of CPU cores which may still be
+	// allocated to instances.
+	Cores *uint64
+
+	// Volumes is the number of volumes which may still be
+	// created.
+	Volumes *uint64
+}
+
+// ConsoleLogger is implemented by environs which can fetch the console
+// output of their instances. The console output is most useful for
+// diagnosing machines which never start their agents.
+type ConsoleLogger interface {
+	// ConsoleLog returns the console output of the instance with
+	// the given ID, as recorded by the cloud. An error satisfying
+	// errors.IsNotFound is returned if the instance does not exist.
+	ConsoleLog(id instance.Id) (string, error)
+}
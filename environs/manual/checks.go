@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/series"
+	"github.com/juju/utils/ssh"
+)
+
+// MinDiskFreeMB is the minimum amount of free space, in megabytes,
+// that must be available on a host's root filesystem before it can
+// be enlisted.
+const MinDiskFreeMB = 2048
+
+// checkScript is the script to run on the remote machine to gather
+// the information needed by the pre-enlistment checks.
+const checkScript = `#!/bin/bash
+set -e
+lsb_release -cs
+uname -m
+df -P -k / | tail -n 1 | awk '{print $4}'`
+
+// HostCheckResult holds the outcome of running the pre-enlistment
+// checks against a single host.
+type HostCheckResult struct {
+	// Host is the [user@]host that was checked.
+	Host string
+
+	// Series is the OS series detected on the host.
+	Series string
+
+	// Arch is the architecture detected on the host.
+	Arch string
+
+	// DiskFreeMB is the free space, in megabytes, detected on the
+	// host's root filesystem.
+	DiskFreeMB uint64
+
+	// Error is non-nil if the host could not be reached, or if it
+	// failed any of the checks.
+	Error error
+}
+
+// CheckHosts runs the pre-enlistment checks against each of the
+// specified [user@]host strings in parallel. The results are returned
+// in the same order as the hosts.
+func CheckHosts(hosts []string) []HostCheckResult {
+	results := make([]HostCheckResult, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			results[i] = CheckHost(host)
+		}(i, host)
+	}
+	wg.Wait()
+	return results
+}
+
+// CheckHost connects to the specified [user@]host, and checks that it
+// is running a supported series and architecture, and that it has at
+// least MinDiskFreeMB of free disk space.
+func CheckHost(host string) HostCheckResult {
+	result := HostCheckResult{Host: host}
+	logger.Infof("Running pre-enlistment checks on %s", host)
+
+	cmd := ssh.Command(host, []string{"/bin/bash"}, nil)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Stdin = strings.NewReader(checkScript)
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() != 0 {
+			err = errors.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
+		}
+		result.Error = errors.Annotate(err, "cannot connect")
+		return result
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) < 3 {
+		result.Error = errors.Errorf("unexpected check output %q", stdout.String())
+		return result
+	}
+	result.Series = strings.TrimSpace(lines[0])
+	result.Arch = arch.NormaliseArch(strings.TrimSpace(lines[1]))
+	diskFreekB, err := strconv.ParseUint(strings.TrimSpace(lines[2]), 10, 64)
+	if err != nil {
+		result.Error = errors.Annotate(err, "parsing free disk space")
+		return result
+	}
+	result.DiskFreeMB = diskFreekB / 1024
+
+	if _, err := series.GetOSFromSeries(result.Series); err != nil {
+		result.Error = errors.Errorf("series %q not supported", result.Series)
+	} else if !arch.IsSupportedArch(result.Arch) {
+		result.Error = errors.Errorf("architecture %q not supported", result.Arch)
+	} else if result.DiskFreeMB < MinDiskFreeMB {
+		result.Error = errors.Errorf(
+			"not enough free disk space: %dM available, %dM required",
+			result.DiskFreeMB, MinDiskFreeMB,
+		)
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/testing"
+)
+
+type checksSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&checksSuite{})
+
+func checkOutput(lines ...string) string {
+	return strings.Join(lines, "\n")
+}
+
+func (s *checksSuite) TestCheckHost(c *gc.C) {
+	defer installFakeSSH(c, manual.CheckScript, checkOutput("xenial", "x86_64", "10485760"), 0)()
+	result := manual.CheckHost("ubuntu@10.0.0.1")
+	c.Assert(result.Error, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, manual.HostCheckResult{
+		Host:       "ubuntu@10.0.0.1",
+		Series:     "xenial",
+		Arch:       "amd64",
+		DiskFreeMB: 10240,
+	})
+}
+
+func (s *checksSuite) TestCheckHostConnectionError(c *gc.C) {
+	defer installFakeSSH(c, manual.CheckScript, []string{"", "no route to host"}, 255)()
+	result := manual.CheckHost("10.0.0.1")
+	c.Assert(result.Error, gc.ErrorMatches, `cannot connect: subprocess encountered error code 255 \(no route to host\)`)
+}
+
+func (s *checksSuite) TestCheckHostUnsupportedSeries(c *gc.C) {
+	defer installFakeSSH(c, manual.CheckScript, checkOutput("edgy", "x86_64", "10485760"), 0)()
+	result := manual.CheckHost("10.0.0.1")
+	c.Assert(result.Error, gc.ErrorMatches, `series "edgy" not supported`)
+	c.Assert(result.Series, gc.Equals, "edgy")
+}
+
+func (s *checksSuite) TestCheckHostUnsupportedArch(c *gc.C) {
+	defer installFakeSSH(c, manual.CheckScript, checkOutput("xenial", "mips", "10485760"), 0)()
+	result := manual.CheckHost("10.0.0.1")
+	c.Assert(result.Error, gc.ErrorMatches, `architecture "mips" not supported`)
+}
+
+func (s *checksSuite) TestCheckHostNotEnoughDisk(c *gc.C) {
+	defer installFakeSSH(c, manual.CheckScript, checkOutput("xenial", "x86_64", "1048576"), 0)()
+	result := manual.CheckHost("10.0.0.1")
+	c.Assert(result.Error, gc.ErrorMatches, `not enough free disk space: 1024M available, 2048M required`)
+	c.Assert(result.DiskFreeMB, gc.Equals, uint64(1024))
+}
+
+func (s *checksSuite) TestCheckHostUnexpectedOutput(c *gc.C) {
+	defer installFakeSSH(c, manual.CheckScript, "xenial", 0)()
+	result := manual.CheckHost("10.0.0.1")
+	c.Assert(result.Error, gc.ErrorMatches, `unexpected check output "xenial\\n"`)
+}
+
+func (s *checksSuite) TestCheckHosts(c *gc.C) {
+	defer installFakeSSH(c, manual.CheckScript, checkOutput("xenial", "x86_64", "10485760"), 0)()
+	results := manual.CheckHosts([]string{"10.0.0.1"})
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Host, gc.Equals, "10.0.0.1")
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
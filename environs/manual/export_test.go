@@ -10,4 +10,5 @@ var (
 
 const (
 	DetectionScript = detectionScript
+	CheckScript     = checkScript
 )
@@ -61,6 +61,15 @@ type ProvisionMachineArgs struct {
 	// ubuntu user's ~/.ssh/authorized_keys.
 	AuthorizedKeys string
 
+	// InstanceId, if set, is the id of the cloud instance at Host. The
+	// instance is adopted into the model as a machine with that id,
+	// rather than being recorded as a manually provisioned machine.
+	InstanceId instance.Id
+
+	// AvailabilityZone is the availability zone of the adopted
+	// instance, if known.
+	AvailabilityZone string
+
 	*params.UpdateBehavior
 }
 
@@ -94,7 +103,7 @@ func ProvisionMachine(args ProvisionMachineArgs) (machineId string, err error) {
 		return "", err
 	}
 
-	machineParams, err := gatherMachineParams(hostname)
+	machineParams, err := gatherMachineParams(hostname, args)
 	if err != nil {
 		return "", err
 	}
@@ -150,8 +159,10 @@ func recordMachineInState(client ProvisioningClientAPI, machineParams params.Add
 // we are about to provision. It will SSH into that machine as the ubuntu user.
 // The hostname supplied should not include a username.
 // If we can, we will reverse lookup the hostname by its IP address, and use
-// the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
+// the DNS resolved name, rather than the name that was supplied.
+// If the machine is being adopted, its instance id and availability
+// zone are taken from args.
+func gatherMachineParams(hostname string, args ProvisionMachineArgs) (*params.AddMachineParams, error) {
 
 	// Generate a unique nonce for the machine.
 	uuid, err := utils.NewUUID()
@@ -186,6 +197,16 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 	// if it isn't one that the environment provider knows about.
 
 	instanceId := instance.Id(manualInstancePrefix + hostname)
+	if args.InstanceId != "" {
+		// An adopted instance is known to the provider, and is
+		// managed like any other once its agent is running. The
+		// provisioner still leaves it alone when the machine is
+		// removed, as the instance was not started for the model.
+		instanceId = args.InstanceId
+		if args.AvailabilityZone != "" {
+			hc.AvailabilityZone = &args.AvailabilityZone
+		}
+	}
 	nonce := fmt.Sprintf("%s:%s", instanceId, uuid.String())
 	machineParams := &params.AddMachineParams{
 		Series:                  series,
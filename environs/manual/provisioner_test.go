@@ -182,3 +182,29 @@ func (s *provisionerSuite) TestProvisioningScript(c *gc.C) {
 	expectedScript := removeLogFile + shell.DumpFileOnErrorScript("/var/log/cloud-init-output.log") + provisioningScript
 	c.Assert(script, gc.Equals, expectedScript)
 }
+
+func (s *provisionerSuite) TestProvisionMachineAdoptInstance(c *gc.C) {
+	var series = series.LatestLts()
+	const arch = "amd64"
+	defer fakeSSH{
+		Series:         series,
+		Arch:           arch,
+		InitUbuntuUser: true,
+	}.install(c).Restore()
+	args := s.getArgs(c)
+	args.InstanceId = "i-adopted"
+	args.AvailabilityZone = "zone-a"
+	machineId, err := manual.ProvisionMachine(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceId, err := m.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceId, gc.Equals, instance.Id("i-adopted"))
+	hc, err := m.HardwareCharacteristics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hc.AvailabilityZone, gc.NotNil)
+	c.Assert(*hc.AvailabilityZone, gc.Equals, "zone-a")
+	c.Assert(*hc.Arch, gc.Equals, arch)
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/environs/tags"
+)
+
+// ResourceKind identifies a kind of provider resource.
+type ResourceKind string
+
+const (
+	// ResourceInstance identifies a machine instance.
+	ResourceInstance ResourceKind = "instance"
+
+	// ResourceVolume identifies a storage volume.
+	ResourceVolume ResourceKind = "volume"
+
+	// ResourceSecurityGroup identifies a security group, or
+	// the provider's equivalent firewall resource.
+	ResourceSecurityGroup ResourceKind = "security-group"
+)
+
+// TaggedResource describes a provider resource created by Juju, and
+// the tags it was created with.
+type TaggedResource struct {
+	// Kind is the kind of the resource.
+	Kind ResourceKind
+
+	// Id is the provider-specific id of the resource.
+	Id string
+
+	// Tags holds the resource's tags. Those set by Juju are
+	// described in the environs/tags package.
+	Tags map[string]string
+}
+
+// ModelUUID returns the UUID of the model the resource belongs to,
+// as recorded in its tags.
+func (r TaggedResource) ModelUUID() string {
+	return r.Tags[tags.JujuModel]
+}
+
+// ControllerUUID returns the UUID of the controller that manages
+// the resource, as recorded in its tags.
+func (r TaggedResource) ControllerUUID() string {
+	return r.Tags[tags.JujuController]
+}
+
+// TaggedResourceLister is an interface that may be implemented by an
+// Environ whose resources are tagged with the model and controller
+// they belong to. The tags allow the cost of resources to be
+// attributed to models, and resources left behind by models that no
+// longer exist to be found.
+type TaggedResourceLister interface {
+	// TaggedResources returns the instances, volumes and security
+	// groups tagged as managed by the controller with the given
+	// UUID, in all of the controller's models.
+	TaggedResources(controllerUUID string) ([]TaggedResource, error)
+}
+
+// SupportsTaggedResources is a convenience helper to check if an
+// environment can list the resources it has tagged.
+func SupportsTaggedResources(env Environ) (TaggedResourceLister, bool) {
+	lister, ok := env.(TaggedResourceLister)
+	return lister, ok
+}
+
+// OrphanedResources returns the resources managed by the specified
+// controller which belong to none of the given models. The error
+// returned satisfies errors.IsNotSupported if the environment cannot
+// list the resources it has tagged.
+func OrphanedResources(env Environ, controllerUUID string, modelUUIDs set.Strings) ([]TaggedResource, error) {
+	lister, ok := SupportsTaggedResources(env)
+	if !ok {
+		return nil, errors.NotSupportedf("listing tagged resources")
+	}
+	resources, err := lister.TaggedResources(controllerUUID)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing tagged resources")
+	}
+	var orphaned []TaggedResource
+	for _, resource := range resources {
+		if resource.ControllerUUID() != controllerUUID {
+			continue
+		}
+		if modelUUIDs.Contains(resource.ModelUUID()) {
+			continue
+		}
+		orphaned = append(orphaned, resource)
+	}
+	return orphaned, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type taggedResourcesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&taggedResourcesSuite{})
+
+type taggedResourcesEnviron struct {
+	environs.Environ
+	resources []environs.TaggedResource
+	err       error
+}
+
+func (e *taggedResourcesEnviron) TaggedResources(controllerUUID string) ([]environs.TaggedResource, error) {
+	return e.resources, e.err
+}
+
+func taggedResource(kind environs.ResourceKind, id, controllerUUID, modelUUID string) environs.TaggedResource {
+	return environs.TaggedResource{
+		Kind: kind,
+		Id:   id,
+		Tags: map[string]string{
+			"juju-controller-uuid": controllerUUID,
+			"juju-model-uuid":      modelUUID,
+		},
+	}
+}
+
+func (s *taggedResourcesSuite) TestOrphanedResources(c *gc.C) {
+	env := &taggedResourcesEnviron{
+		resources: []environs.TaggedResource{
+			taggedResource(environs.ResourceInstance, "i-live", "ctrl", "live"),
+			taggedResource(environs.ResourceInstance, "i-dead", "ctrl", "dead"),
+			taggedResource(environs.ResourceVolume, "vol-dead", "ctrl", "dead"),
+			taggedResource(environs.ResourceSecurityGroup, "sg-other", "other-ctrl", "dead"),
+		},
+	}
+	orphaned, err := environs.OrphanedResources(env, "ctrl", set.NewStrings("live"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(orphaned, jc.DeepEquals, []environs.TaggedResource{
+		env.resources[1], env.resources[2],
+	})
+	c.Assert(orphaned[0].ModelUUID(), gc.Equals, "dead")
+	c.Assert(orphaned[0].ControllerUUID(), gc.Equals, "ctrl")
+}
+
+func (s *taggedResourcesSuite) TestOrphanedResourcesError(c *gc.C) {
+	env := &taggedResourcesEnviron{err: errors.New("boom")}
+	_, err := environs.OrphanedResources(env, "ctrl", nil)
+	c.Assert(err, gc.ErrorMatches, "listing tagged resources: boom")
+}
+
+func (s *taggedResourcesSuite) TestOrphanedResourcesNotSupported(c *gc.C) {
+	type plainEnviron struct {
+		environs.Environ
+	}
+	_, err := environs.OrphanedResources(plainEnviron{}, "ctrl", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -39,3 +39,7 @@ const Migration = "migration"
 
 // DeveloperMode allows access to developer specific commands and behaviour.
 const DeveloperMode = "developer-mode"
+
+// ControllerBundles makes 'juju deploy' deploy bundles on the
+// controller, rather than applying each change from the client.
+const ControllerBundles = "controller-bundles"
@@ -48,7 +48,7 @@ func (s *cmdControllerSuite) run(c *gc.C, args ...string) *cmd.Context {
 func (s *cmdControllerSuite) createModelAdminUser(c *gc.C, modelname string, isServer bool) params.ModelInfo {
 	modelManager := modelmanager.NewClient(s.OpenControllerAPI(c))
 	defer modelManager.Close()
-	model, err := modelManager.CreateModel(modelname, s.AdminUserTag(c).Id(), "", names.CloudCredentialTag{}, map[string]interface{}{
+	model, err := modelManager.CreateModel(modelname, s.AdminUserTag(c).Id(), "", "", names.CloudCredentialTag{}, map[string]interface{}{
 		"controller": isServer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -59,7 +59,7 @@ func (s *cmdControllerSuite) createModelNormalUser(c *gc.C, modelname string, is
 	s.run(c, "add-user", "test")
 	modelManager := modelmanager.NewClient(s.OpenControllerAPI(c))
 	defer modelManager.Close()
-	_, err := modelManager.CreateModel(modelname, names.NewLocalUserTag("test").Id(), "", names.CloudCredentialTag{}, map[string]interface{}{
+	_, err := modelManager.CreateModel(modelname, names.NewLocalUserTag("test").Id(), "", "", names.CloudCredentialTag{}, map[string]interface{}{
 		"authorized-keys": "ssh-key",
 		"controller":      isServer,
 	})
@@ -116,6 +116,11 @@ models:
       display-name: admin
       access: admin
       last-connection: just now
+  health:
+    machines: [0-9]+
+    machines-down: [0-9]+
+(    .*
+)*    last-connection: just now
 current-model: controller
 `[1:])
 }
@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package juju
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/juju/jujuclient"
+)
+
+const (
+	// maxAddressFailures is the number of consecutive connection
+	// attempts an address may lose before it is only dialled if
+	// none of the controller's other addresses can be reached.
+	maxAddressFailures = 3
+
+	// addressHealthGranularity is how stale the recorded time of an
+	// address's last successful connection may become before it
+	// is updated. This avoids rewriting the client store on every
+	// connection.
+	addressHealthGranularity = time.Hour
+)
+
+// rankAddresses orders the given API addresses so that those most
+// recently connected to are dialled first, followed by those with no
+// record of success in their original order. Addresses which have
+// repeatedly failed to connect are returned separately in pruned, to
+// be dialled only if none of the ranked addresses can be reached.
+func rankAddresses(addrs []string, health map[string]jujuclient.AddressHealth) (ranked, pruned []string) {
+	var succeeded, unknown []string
+	for _, addr := range addrs {
+		h := health[addr]
+		switch {
+		case h.Failures >= maxAddressFailures:
+			pruned = append(pruned, addr)
+		case !h.LastSuccess.IsZero():
+			succeeded = append(succeeded, addr)
+		default:
+			unknown = append(unknown, addr)
+		}
+	}
+	sort.Stable(byLastSuccess{succeeded, health})
+	ranked = append(succeeded, unknown...)
+	if len(ranked) == 0 {
+		return pruned, nil
+	}
+	return ranked, pruned
+}
+
+type byLastSuccess struct {
+	addrs  []string
+	health map[string]jujuclient.AddressHealth
+}
+
+func (b byLastSuccess) Len() int {
+	return len(b.addrs)
+}
+
+func (b byLastSuccess) Swap(i, j int) {
+	b.addrs[i], b.addrs[j] = b.addrs[j], b.addrs[i]
+}
+
+func (b byLastSuccess) Less(i, j int) bool {
+	return b.health[b.addrs[i]].LastSuccess.After(b.health[b.addrs[j]].LastSuccess)
+}
+
+// recordAddressHealth updates the controller's address health records
+// after a connection was made to the connected address, having dialled
+// the given addresses in order. Addresses dialled before the connected
+// one lost the race to connect, and are counted as having failed. It
+// reports whether the records have changed enough to be worth saving.
+func recordAddressHealth(
+	controller *jujuclient.ControllerDetails,
+	dialled []string, connected string, now time.Time,
+) bool {
+	connectedIndex := -1
+	for i, addr := range dialled {
+		if addr == connected {
+			connectedIndex = i
+			break
+		}
+	}
+	if connectedIndex < 0 {
+		return false
+	}
+	if controller.AddressHealth == nil {
+		controller.AddressHealth = make(map[string]jujuclient.AddressHealth)
+	}
+	changed := false
+	for _, addr := range dialled[:connectedIndex] {
+		h := controller.AddressHealth[addr]
+		if h.Failures < maxAddressFailures {
+			h.Failures++
+			controller.AddressHealth[addr] = h
+			changed = true
+		}
+	}
+	h := controller.AddressHealth[connected]
+	if h.Failures > 0 || now.Sub(h.LastSuccess) >= addressHealthGranularity {
+		controller.AddressHealth[connected] = jujuclient.AddressHealth{
+			LastSuccess: now.UTC().Round(time.Second),
+		}
+		changed = true
+	}
+	return changed
+}
+
+// pruneAddressHealth removes the health records of any addresses that
+// the controller no longer has.
+func pruneAddressHealth(controller *jujuclient.ControllerDetails) {
+	if len(controller.AddressHealth) == 0 {
+		return
+	}
+	current := make(map[string]bool)
+	for _, addr := range controller.APIEndpoints {
+		current[addr] = true
+	}
+	for addr := range controller.AddressHealth {
+		if !current[addr] {
+			delete(controller.AddressHealth, addr)
+		}
+	}
+	if len(controller.AddressHealth) == 0 {
+		controller.AddressHealth = nil
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package juju_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju"
+	"github.com/juju/juju/jujuclient"
+)
+
+type AddressHealthSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&AddressHealthSuite{})
+
+func (s *AddressHealthSuite) TestRankAddresses(c *gc.C) {
+	now := time.Now()
+	ranked, pruned := juju.RankAddresses(
+		[]string{"0.1.2.1:17070", "0.1.2.2:17070", "0.1.2.3:17070", "0.1.2.4:17070", "0.1.2.5:17070"},
+		map[string]jujuclient.AddressHealth{
+			"0.1.2.2:17070": {LastSuccess: now.Add(-time.Hour)},
+			"0.1.2.3:17070": {Failures: juju.MaxAddressFailures},
+			"0.1.2.4:17070": {LastSuccess: now, Failures: 1},
+		},
+	)
+	c.Assert(ranked, jc.DeepEquals, []string{"0.1.2.4:17070", "0.1.2.2:17070", "0.1.2.1:17070", "0.1.2.5:17070"})
+	c.Assert(pruned, jc.DeepEquals, []string{"0.1.2.3:17070"})
+}
+
+func (s *AddressHealthSuite) TestRankAddressesAllFailing(c *gc.C) {
+	ranked, pruned := juju.RankAddresses(
+		[]string{"0.1.2.1:17070", "0.1.2.2:17070"},
+		map[string]jujuclient.AddressHealth{
+			"0.1.2.1:17070": {Failures: juju.MaxAddressFailures},
+			"0.1.2.2:17070": {Failures: juju.MaxAddressFailures},
+		},
+	)
+	c.Assert(ranked, jc.DeepEquals, []string{"0.1.2.1:17070", "0.1.2.2:17070"})
+	c.Assert(pruned, gc.HasLen, 0)
+}
+
+func (s *AddressHealthSuite) TestRecordAddressHealth(c *gc.C) {
+	now := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	var details jujuclient.ControllerDetails
+	changed := juju.RecordAddressHealth(&details,
+		[]string{"0.1.2.1:17070", "0.1.2.2:17070", "0.1.2.3:17070"}, "0.1.2.2:17070", now,
+	)
+	c.Assert(changed, jc.IsTrue)
+	c.Assert(details.AddressHealth, jc.DeepEquals, map[string]jujuclient.AddressHealth{
+		"0.1.2.1:17070": {Failures: 1},
+		"0.1.2.2:17070": {LastSuccess: now},
+	})
+
+	// Connecting to the same address again soon after is not
+	// worth recording.
+	changed = juju.RecordAddressHealth(&details,
+		[]string{"0.1.2.2:17070", "0.1.2.1:17070"}, "0.1.2.2:17070", now.Add(time.Minute),
+	)
+	c.Assert(changed, jc.IsFalse)
+
+	// Failures accumulate up to the maximum.
+	for i := 0; i < juju.MaxAddressFailures+1; i++ {
+		juju.RecordAddressHealth(&details,
+			[]string{"0.1.2.1:17070", "0.1.2.2:17070"}, "0.1.2.2:17070", now,
+		)
+	}
+	c.Assert(details.AddressHealth["0.1.2.1:17070"].Failures, gc.Equals, juju.MaxAddressFailures)
+
+	// A successful connection resets the failure count.
+	changed = juju.RecordAddressHealth(&details,
+		[]string{"0.1.2.1:17070"}, "0.1.2.1:17070", now,
+	)
+	c.Assert(changed, jc.IsTrue)
+	c.Assert(details.AddressHealth["0.1.2.1:17070"], jc.DeepEquals, jujuclient.AddressHealth{
+		LastSuccess: now,
+	})
+}
+
+func (s *AddressHealthSuite) TestRecordAddressHealthUnknownAddress(c *gc.C) {
+	var details jujuclient.ControllerDetails
+	changed := juju.RecordAddressHealth(&details,
+		[]string{"0.1.2.1:17070"}, "0.1.2.9:17070", time.Now(),
+	)
+	c.Assert(changed, jc.IsFalse)
+	c.Assert(details.AddressHealth, gc.HasLen, 0)
+}
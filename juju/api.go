@@ -5,6 +5,7 @@ package juju
 
 import (
 	"encoding/json"
+	"net/url"
 	"time"
 
 	"github.com/juju/errors"
@@ -71,8 +72,27 @@ func NewAPIConnection(args NewAPIConnectionParams) (api.Connection, error) {
 	if len(apiInfo.Addrs) == 0 {
 		return nil, errors.New("no API addresses")
 	}
+	dialOpts, err := controllerDialOpts(args.DialOpts, controller)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot work out how to connect")
+	}
+	// Dial the addresses that most recently worked first, and leave
+	// out those that keep failing unless nothing else can be reached.
+	ranked, pruned := rankAddresses(apiInfo.Addrs, controller.AddressHealth)
+	dialled := ranked
+	apiInfo.Addrs = ranked
 	logger.Infof("connecting to API addresses: %v", apiInfo.Addrs)
-	st, err := args.OpenAPI(apiInfo, args.DialOpts)
+	st, err := args.OpenAPI(apiInfo, dialOpts)
+	if err != nil && len(pruned) > 0 && !isAPIError(err) && !isRedirectError(err) {
+		logger.Infof("cannot connect to %v (%v); trying API addresses: %v", ranked, err, pruned)
+		// Copy the API info because it's possible that the
+		// first connection attempt is still using it concurrently.
+		prunedInfo := *apiInfo
+		prunedInfo.Addrs = pruned
+		apiInfo = &prunedInfo
+		dialled = append(ranked, pruned...)
+		st, err = args.OpenAPI(apiInfo, dialOpts)
+	}
 	if err != nil {
 		redirErr, ok := errors.Cause(err).(*api.RedirectError)
 		if !ok {
@@ -90,7 +110,7 @@ func NewAPIConnection(args NewAPIConnectionParams) (api.Connection, error) {
 			Addrs:    network.HostPortsToStrings(usableHostPorts(redirErr.Servers)),
 			CACert:   redirErr.CACert,
 		}
-		st, err = args.OpenAPI(apiInfo, args.DialOpts)
+		st, err = args.OpenAPI(apiInfo, dialOpts)
 		if err != nil {
 			return nil, errors.Annotatef(err, "cannot connect to redirected address")
 		}
@@ -108,8 +128,9 @@ func NewAPIConnection(args NewAPIConnectionParams) (api.Connection, error) {
 	// a problem in practice because the intended scenario for
 	// controllers that redirect involves them having well known
 	// public addresses that won't change over time.
+	healthChanged := recordAddressHealth(controller, dialled, st.Addr(), time.Now())
 	hostPorts := st.APIHostPorts()
-	err = updateControllerAddresses(args.Store, args.ControllerName, controller, hostPorts, addrConnectedTo)
+	err = updateControllerAddresses(args.Store, args.ControllerName, controller, healthChanged, hostPorts, addrConnectedTo)
 	if err != nil {
 		logger.Errorf("cannot cache API addresses: %v", err)
 	}
@@ -175,6 +196,33 @@ func connectionInfo(args NewAPIConnectionParams) (*api.Info, *jujuclient.Control
 	return apiInfo, controller, nil
 }
 
+// controllerDialOpts returns the given dial options, updated with
+// the connection options recorded for the controller in the client
+// store.
+func controllerDialOpts(opts api.DialOpts, controller *jujuclient.ControllerDetails) (api.DialOpts, error) {
+	if controller.Proxy != "" {
+		proxyURL, err := url.Parse(controller.Proxy)
+		if err != nil {
+			return api.DialOpts{}, errors.Annotate(err, "cannot parse proxy URL")
+		}
+		opts.ProxyURL = proxyURL
+	}
+	if len(controller.ExtraCACerts) > 0 {
+		extraCACerts := make([]string, 0, len(opts.ExtraCACerts)+len(controller.ExtraCACerts))
+		extraCACerts = append(extraCACerts, opts.ExtraCACerts...)
+		opts.ExtraCACerts = append(extraCACerts, controller.ExtraCACerts...)
+	}
+	if controller.DialTimeout > 0 {
+		opts.Timeout = controller.DialTimeout
+	}
+	return opts, nil
+}
+
+func isRedirectError(err error) bool {
+	_, ok := errors.Cause(err).(*api.RedirectError)
+	return ok
+}
+
 func isAPIError(err error) bool {
 	type errorCoder interface {
 		ErrorCode() string
@@ -304,25 +352,33 @@ func UpdateControllerAddresses(
 		return errors.Trace(err)
 	}
 	return updateControllerAddresses(
-		store, controllerName, controllerDetails,
+		store, controllerName, controllerDetails, false,
 		currentHostPorts, addrConnectedTo...,
 	)
 }
 
+// updateControllerAddresses writes any new api addresses to the client
+// controller file. If healthChanged is true, the controller details
+// are written even if the addresses have not changed, to save the
+// updated address health records.
 func updateControllerAddresses(
 	store jujuclient.ControllerStore,
 	controllerName string, controllerDetails *jujuclient.ControllerDetails,
+	healthChanged bool,
 	currentHostPorts [][]network.HostPort, addrConnectedTo ...network.HostPort,
 ) error {
 	// Get the new endpoint addresses.
 	addrs, unresolvedAddrs, addrsChanged := PrepareEndpointsForCaching(*controllerDetails, currentHostPorts, addrConnectedTo...)
-	if !addrsChanged {
+	if !addrsChanged && !healthChanged {
 		return nil
 	}
 
 	// Write the new controller data.
-	controllerDetails.APIEndpoints = addrs
-	controllerDetails.UnresolvedAPIEndpoints = unresolvedAddrs
+	if addrsChanged {
+		controllerDetails.APIEndpoints = addrs
+		controllerDetails.UnresolvedAPIEndpoints = unresolvedAddrs
+		pruneAddressHealth(controllerDetails)
+	}
 	err := store.UpdateController(controllerName, *controllerDetails)
 	return errors.Trace(err)
 }
@@ -8,6 +8,7 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -140,15 +141,24 @@ func (s *NewAPIClientSuite) TestWithBootstrapConfig(c *gc.C) {
 		[]string{"0.1.2.3:1234", "[2001:db8::1]:1234"},
 	)
 
+	// The first connection to one of the new addresses
+	// records its health.
+	stubStore := jujuclienttesting.WrapClientStore(store)
+	st, err = newAPIConnectionFromNames(c, "noconfig", "admin@local/admin", stubStore, apiOpen)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.Equals, expectState)
+	c.Assert(called, gc.Equals, 2)
+	stubStore.CheckCallNames(c, "AccountDetails", "ModelByName", "ControllerByName", "UpdateController")
+
 	controllerBefore, err := store.ControllerByName("noconfig")
 	c.Assert(err, jc.ErrorIsNil)
 
 	// If APIHostPorts haven't changed, then the store won't be updated.
-	stubStore := jujuclienttesting.WrapClientStore(store)
+	stubStore = jujuclienttesting.WrapClientStore(store)
 	st, err = newAPIConnectionFromNames(c, "noconfig", "admin@local/admin", stubStore, apiOpen)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(st, gc.Equals, expectState)
-	c.Assert(called, gc.Equals, 2)
+	c.Assert(called, gc.Equals, 3)
 	stubStore.CheckCallNames(c, "AccountDetails", "ModelByName", "ControllerByName")
 
 	controllerAfter, err := store.ControllerByName("noconfig")
@@ -219,6 +229,80 @@ func (s *NewAPIClientSuite) TestWithRedirect(c *gc.C) {
 	c.Assert(controllerBefore, gc.DeepEquals, controllerAfter)
 }
 
+func (s *NewAPIClientSuite) TestWithConnectionOptions(c *gc.C) {
+	store := newClientStore(c, "ctl")
+	err := store.UpdateController("ctl", jujuclient.ControllerDetails{
+		ControllerUUID: fakeUUID,
+		CACert:         "certificate",
+		APIEndpoints:   []string{"0.1.2.3:5678"},
+		Proxy:          "socks5://10.0.0.1:1080",
+		ExtraCACerts:   []string{coretesting.CACert},
+		DialTimeout:    time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	called := 0
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		called++
+		c.Check(apiInfo.Addrs, jc.DeepEquals, []string{"0.1.2.3:5678"})
+		c.Assert(opts.ProxyURL, gc.NotNil)
+		c.Check(opts.ProxyURL.String(), gc.Equals, "socks5://10.0.0.1:1080")
+		c.Check(opts.ExtraCACerts, jc.DeepEquals, []string{coretesting.CACert})
+		c.Check(opts.Timeout, gc.Equals, time.Minute)
+		c.Check(opts.RetryDelay, gc.Equals, api.DefaultDialOpts().RetryDelay)
+		return mockedAPIState(mockedHostPort | mockedModelTag), nil
+	}
+	_, err = newAPIConnectionFromNames(c, "ctl", "", store, apiOpen)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, gc.Equals, 1)
+}
+
+func (s *NewAPIClientSuite) TestWithAddressHealth(c *gc.C) {
+	store := newClientStore(c, "ctl")
+	lastSuccess := time.Now().Add(-time.Minute).UTC().Round(time.Second)
+	err := store.UpdateController("ctl", jujuclient.ControllerDetails{
+		ControllerUUID: fakeUUID,
+		CACert:         "certificate",
+		APIEndpoints:   []string{"0.1.2.4:1234", "0.1.2.3:1234", "[2001:db8::1]:1234"},
+		AddressHealth: map[string]jujuclient.AddressHealth{
+			"0.1.2.3:1234":       {Failures: juju.MaxAddressFailures},
+			"[2001:db8::1]:1234": {LastSuccess: lastSuccess},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	openCount := 0
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		openCount++
+		switch openCount {
+		case 1:
+			// The address that recently worked is dialled first,
+			// and the one that keeps failing is left out.
+			c.Check(apiInfo.Addrs, jc.DeepEquals, []string{"[2001:db8::1]:1234", "0.1.2.4:1234"})
+			return nil, errors.New("no route to host")
+		case 2:
+			c.Check(apiInfo.Addrs, jc.DeepEquals, []string{"0.1.2.3:1234"})
+			return mockedAPIState(mockedHostPort | mockedModelTag), nil
+		}
+		c.Errorf("OpenAPI called too many times")
+		return nil, fmt.Errorf("OpenAPI called too many times")
+	}
+	_, err = newAPIConnectionFromNames(c, "ctl", "", store, apiOpen)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(openCount, gc.Equals, 2)
+
+	details, err := store.ControllerByName("ctl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.APIEndpoints, jc.DeepEquals, []string{"0.1.2.3:1234", "[2001:db8::1]:1234"})
+	c.Assert(details.AddressHealth, gc.HasLen, 2)
+	c.Assert(details.AddressHealth["0.1.2.3:1234"].Failures, gc.Equals, 0)
+	c.Assert(details.AddressHealth["0.1.2.3:1234"].LastSuccess.IsZero(), jc.IsFalse)
+	c.Assert(details.AddressHealth["[2001:db8::1]:1234"], jc.DeepEquals, jujuclient.AddressHealth{
+		LastSuccess: lastSuccess,
+		Failures:    1,
+	})
+}
+
 func checkCommonAPIInfoAttrs(c *gc.C, apiInfo *api.Info, opts api.DialOpts) {
 	c.Check(apiInfo.Tag, gc.Equals, names.NewUserTag("admin@local"))
 	c.Check(string(apiInfo.CACert), gc.Equals, "certificate")
@@ -7,4 +7,8 @@ var (
 	ProviderConnectDelay   = &providerConnectDelay
 	ResolveOrDropHostnames = &resolveOrDropHostnames
 	ServerAddress          = &serverAddress
+	RankAddresses          = rankAddresses
+	RecordAddressHealth    = recordAddressHealth
 )
+
+const MaxAddressFailures = maxAddressFailures
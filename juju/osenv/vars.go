@@ -22,6 +22,15 @@ const (
 	JujuLoggingConfigEnvKey = "JUJU_LOGGING_CONFIG"
 	JujuFeatureFlagEnvKey   = "JUJU_DEV_FEATURE_FLAGS"
 
+	// JujuAPIConnectionEnvKey holds the description of the API
+	// connection passed to plugins which require a controller.
+	JujuAPIConnectionEnvKey = "JUJU_API_CONNECTION"
+
+	// JujuRecordOperationsEnvKey is the env var which if true, will
+	// cause each juju command, and the API calls it makes, to be
+	// recorded in the operation log.
+	JujuRecordOperationsEnvKey = "JUJU_RECORD_OPERATIONS"
+
 	// JujuStartupLoggingConfigEnvKey if set is used to configure the initial
 	// logging before the command objects are even created to allow debugging
 	// of the command creation and initialisation process.
@@ -43,9 +52,19 @@ const (
 	// timestamps to be written in RFC3339 format.
 	JujuStatusIsoTimeEnvKey = "JUJU_STATUS_ISO_TIME"
 
+	// JujuStoreKeyEnvKey is the env var which, if set, holds the
+	// base64-encoded key with which the client store's credentials
+	// are encrypted. It allows the key to be provided by a keychain
+	// or agent rather than by "juju login --unlock".
+	JujuStoreKeyEnvKey = "JUJU_STORE_KEY"
+
 	// XDGDataHome is a path where data for the running user
 	// should be stored according to the xdg standard.
 	XDGDataHome = "XDG_DATA_HOME"
+
+	// XDGRuntimeDir is a path where runtime files for the running
+	// user's session should be stored according to the xdg standard.
+	XDGRuntimeDir = "XDG_RUNTIME_DIR"
 )
 
 // FeatureFlags returns a map that can be merged with os.Environ.
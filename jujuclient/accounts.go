@@ -37,12 +37,20 @@ func ReadAccountsFile(file string) (map[string]AccountDetails, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := decryptAccounts(accounts); err != nil {
+		return nil, err
+	}
 	return accounts, nil
 }
 
 // WriteAccountsFile marshals to YAML details of the given accounts
-// and writes it to the accounts file.
+// and writes it to the accounts file. If the client store is
+// encrypted, the accounts' credentials are encrypted.
 func WriteAccountsFile(controllerAccounts map[string]AccountDetails) error {
+	controllerAccounts, err := encryptAccounts(controllerAccounts)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	data, err := yaml.Marshal(accountsCollection{controllerAccounts})
 	if err != nil {
 		return errors.Annotate(err, "cannot marshal accounts")
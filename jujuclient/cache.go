@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+// JujuCacheDir is the location where data cached from the named
+// controller is expected to be found.
+func JujuCacheDir(controllerName string) string {
+	return osenv.JujuXDGDataHomePath("cache", url.QueryEscape(controllerName))
+}
+
+// cachedData is the on-disk form of data cached from a controller.
+type cachedData struct {
+	Cached time.Time       `json:"cached"`
+	Data   json.RawMessage `json:"data"`
+}
+
+func cachePath(controllerName, name string) string {
+	return filepath.Join(JujuCacheDir(controllerName), url.QueryEscape(name)+".json")
+}
+
+// WriteCachedData records the given value, which must be marshallable
+// as JSON, as the last-seen data with the given name from the named
+// controller. Commands may later read it back with ReadCachedData when
+// the controller cannot be reached.
+func WriteCachedData(controllerName, name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.Annotatef(err, "cannot marshal %s", name)
+	}
+	data, err = json.Marshal(cachedData{
+		Cached: time.Now().UTC(),
+		Data:   data,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot marshal %s", name)
+	}
+	if err := os.MkdirAll(JujuCacheDir(controllerName), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	return utils.AtomicWriteFile(cachePath(controllerName, name), data, os.FileMode(0600))
+}
+
+// ReadCachedData reads the data with the given name last cached from the
+// named controller into value, and returns the time at which it was
+// cached. If no such data has been cached, an error satisfying
+// errors.IsNotFound is returned.
+func ReadCachedData(controllerName, name string, value interface{}) (time.Time, error) {
+	data, err := ioutil.ReadFile(cachePath(controllerName, name))
+	if os.IsNotExist(err) {
+		return time.Time{}, errors.NotFoundf("cached %s for controller %s", name, controllerName)
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	var cached cachedData
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return time.Time{}, errors.Annotatef(err, "cannot unmarshal cached %s", name)
+	}
+	if err := json.Unmarshal(cached.Data, value); err != nil {
+		return time.Time{}, errors.Annotatef(err, "cannot unmarshal cached %s", name)
+	}
+	return cached.Cached, nil
+}
+
+// RemoveCachedData removes all data cached from the named controller.
+func RemoveCachedData(controllerName string) error {
+	return errors.Trace(os.RemoveAll(JujuCacheDir(controllerName)))
+}
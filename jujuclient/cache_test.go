@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type CacheSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&CacheSuite{})
+
+type cachedThing struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func (s *CacheSuite) TestWriteReadCachedData(c *gc.C) {
+	before := time.Now()
+	err := jujuclient.WriteCachedData("ctrl", "admin@local/things", cachedThing{"foo", 3})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var thing cachedThing
+	cached, err := jujuclient.ReadCachedData("ctrl", "admin@local/things", &thing)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(thing, jc.DeepEquals, cachedThing{"foo", 3})
+	c.Assert(cached.Before(before), jc.IsFalse)
+	c.Assert(cached.After(time.Now()), jc.IsFalse)
+}
+
+func (s *CacheSuite) TestReadCachedDataNotFound(c *gc.C) {
+	var thing cachedThing
+	_, err := jujuclient.ReadCachedData("ctrl", "things", &thing)
+	c.Assert(err, gc.ErrorMatches, "cached things for controller ctrl not found")
+}
+
+func (s *CacheSuite) TestRemoveCachedData(c *gc.C) {
+	err := jujuclient.WriteCachedData("ctrl", "things", cachedThing{"foo", 3})
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuclient.WriteCachedData("other", "things", cachedThing{"bar", 4})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuclient.RemoveCachedData("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var thing cachedThing
+	_, err = jujuclient.ReadCachedData("ctrl", "things", &thing)
+	c.Assert(err, gc.ErrorMatches, "cached things for controller ctrl not found")
+	_, err = jujuclient.ReadCachedData("other", "things", &thing)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(thing, jc.DeepEquals, cachedThing{"bar", 4})
+}
@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+// JujuClientConfigPath is the location where the configuration of the
+// client itself is expected to be found.
+func JujuClientConfigPath() string {
+	return osenv.JujuXDGDataHomePath("client.yaml")
+}
+
+// ClientConfig holds configuration affecting how the client stores
+// its data.
+type ClientConfig struct {
+	// CredentialBackend names the backend in which the attributes of
+	// cloud credentials are stored. If it is empty or "file", they
+	// are stored in credentials.yaml.
+	CredentialBackend string `yaml:"credential-backend,omitempty"`
+}
+
+// ReadClientConfig loads the client configuration from the given file.
+// If the file is not found, the default configuration is returned.
+func ReadClientConfig(file string) (*ClientConfig, error) {
+	var config ClientConfig
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &config, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal client config")
+	}
+	return &config, nil
+}
+
+// WriteClientConfig marshals the given client configuration to YAML
+// and writes it to the client config file.
+func WriteClientConfig(config *ClientConfig) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal client config")
+	}
+	return utils.AtomicWriteFile(JujuClientConfigPath(), data, os.FileMode(0600))
+}
@@ -5,6 +5,7 @@ package jujuclient_test
 
 import (
 	"io/ioutil"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -35,6 +36,8 @@ controllers:
     api-endpoints: [this-is-another-of-many-api-endpoints, this-is-one-more-of-many-api-endpoints]
     ca-cert: this-is-another-ca-cert
     cloud: mallards
+    proxy: socks5://10.0.0.1:1080
+    dial-timeout: 30s
   mark-test-prodstack:
     unresolved-api-endpoints: [vm-23532.prodstack.canonical.com, great.test.server.hostname.co.nz]
     uuid: this-is-a-uuid
@@ -76,6 +79,10 @@ func parseControllers(c *gc.C) *jujuclient.Controllers {
 	// ensure that multiple server hostnames and eapi endpoints are parsed correctly
 	c.Assert(controllers.Controllers["mark-test-prodstack"].UnresolvedAPIEndpoints, gc.HasLen, 2)
 	c.Assert(controllers.Controllers["mallards"].APIEndpoints, gc.HasLen, 2)
+
+	// ensure that connection options are parsed correctly
+	c.Assert(controllers.Controllers["mallards"].Proxy, gc.Equals, "socks5://10.0.0.1:1080")
+	c.Assert(controllers.Controllers["mallards"].DialTimeout, gc.Equals, 30*time.Second)
 	return controllers
 }
 
@@ -4,6 +4,9 @@
 package jujuclient_test
 
 import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/jujuclient"
@@ -18,12 +21,12 @@ type ControllerValidationSuite struct {
 func (s *ControllerValidationSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.controller = jujuclient.ControllerDetails{
-		[]string{"test.server.hostname"},
-		"test.uuid",
-		[]string{"test.api.endpoint"},
-		"test.ca.cert",
-		"aws",
-		"southeastasia",
+		UnresolvedAPIEndpoints: []string{"test.server.hostname"},
+		ControllerUUID:         "test.uuid",
+		APIEndpoints:           []string{"test.api.endpoint"},
+		CACert:                 "test.ca.cert",
+		Cloud:                  "aws",
+		CloudRegion:            "southeastasia",
 	}
 }
 
@@ -43,6 +46,28 @@ func (s *ControllerValidationSuite) TestValidateControllerDetailsNoCACert(c *gc.
 	s.assertValidateControllerDetailsFails(c, "missing ca-cert, controller details not valid")
 }
 
+func (s *ControllerValidationSuite) TestValidateControllerDetailsConnectionOptions(c *gc.C) {
+	s.controller.Proxy = "socks5://10.0.0.1:1080"
+	s.controller.ExtraCACerts = []string{testing.CACert}
+	s.controller.DialTimeout = time.Minute
+	c.Assert(jujuclient.ValidateControllerDetails(s.controller), jc.ErrorIsNil)
+}
+
+func (s *ControllerValidationSuite) TestValidateControllerDetailsBadProxy(c *gc.C) {
+	s.controller.Proxy = "ftp://10.0.0.1:21"
+	s.assertValidateControllerDetailsFails(c, `proxy scheme "ftp", controller details not valid`)
+}
+
+func (s *ControllerValidationSuite) TestValidateControllerDetailsBadExtraCACert(c *gc.C) {
+	s.controller.ExtraCACerts = []string{"not a cert"}
+	s.assertValidateControllerDetailsFails(c, "extra-ca-certs, controller details not valid")
+}
+
+func (s *ControllerValidationSuite) TestValidateControllerDetailsNegativeDialTimeout(c *gc.C) {
+	s.controller.DialTimeout = -time.Second
+	s.assertValidateControllerDetailsFails(c, "negative dial-timeout, controller details not valid")
+}
+
 func (s *ControllerValidationSuite) assertValidateControllerDetailsFails(c *gc.C, failureMessage string) {
 	err := jujuclient.ValidateControllerDetails(s.controller)
 	c.Assert(err, gc.ErrorMatches, failureMessage)
@@ -0,0 +1,280 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+)
+
+const (
+	// FileCredentialBackend is the name of the default credential
+	// backend, which keeps credential attributes in credentials.yaml.
+	FileCredentialBackend = "file"
+
+	// KeyringCredentialBackend is the name of the credential backend
+	// which keeps credential attributes in the operating system's
+	// keyring: the Secret Service on Linux, or the Keychain on macOS.
+	KeyringCredentialBackend = "keyring"
+
+	// credentialBackendAttr is the attribute which, in place of its
+	// other attributes, identifies a credential whose attributes are
+	// kept in a credential backend.
+	credentialBackendAttr = "keyring-id"
+)
+
+// CredentialBackend stores secrets outside the client store's files.
+type CredentialBackend interface {
+	// Get returns the secret stored with the given key. If there
+	// is none, an error satisfying errors.IsNotFound is returned.
+	Get(key string) (string, error)
+
+	// Set stores the secret with the given key, replacing any
+	// secret already stored with it.
+	Set(key, secret string) error
+
+	// Delete removes the secret stored with the given key, if any.
+	Delete(key string) error
+}
+
+// NewCredentialBackend returns the named credential backend. For the
+// file backend, it returns nil.
+func NewCredentialBackend(name string) (CredentialBackend, error) {
+	switch name {
+	case "", FileCredentialBackend:
+		return nil, nil
+	case KeyringCredentialBackend:
+		return newKeyringBackend(runtime.GOOS)
+	}
+	return nil, errors.NotValidf("credential backend %q", name)
+}
+
+// configuredCredentialBackend returns the credential backend named in
+// the client config, or nil if credentials are stored in files.
+func configuredCredentialBackend() (CredentialBackend, error) {
+	config, err := ReadClientConfig(JujuClientConfigPath())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewCredentialBackend(config.CredentialBackend)
+}
+
+// externalizeCredentials moves the attributes of the given credentials
+// into the backend, leaving in their place a reference to where they
+// are stored. Credentials which were in previous, but which are no
+// longer in credentials, are removed from the backend.
+func externalizeCredentials(
+	backend CredentialBackend,
+	previous, credentials map[string]cloud.CloudCredential,
+) (map[string]cloud.CloudCredential, error) {
+	result := make(map[string]cloud.CloudCredential)
+	keep := make(map[string]bool)
+	for cloudName, cloudCredential := range credentials {
+		authCredentials := make(map[string]cloud.Credential)
+		for name, credential := range cloudCredential.AuthCredentials {
+			attrs := credential.Attributes()
+			if key, ok := backendKey(attrs); ok {
+				// Already externalized.
+				keep[key] = true
+				authCredentials[name] = credential
+				continue
+			}
+			key := cloudName + "/" + name
+			if len(attrs) > 0 {
+				data, err := json.Marshal(attrs)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if err := backend.Set(key, string(data)); err != nil {
+					return nil, errors.Annotatef(err, "cannot store credential %q for cloud %s", name, cloudName)
+				}
+				keep[key] = true
+				attrs = map[string]string{credentialBackendAttr: key}
+			}
+			externalized := cloud.NewCredential(credential.AuthType(), attrs)
+			externalized.Label = credential.Label
+			authCredentials[name] = externalized
+		}
+		cloudCredential.AuthCredentials = authCredentials
+		result[cloudName] = cloudCredential
+	}
+	for _, cloudCredential := range previous {
+		for _, credential := range cloudCredential.AuthCredentials {
+			if key, ok := backendKey(credential.Attributes()); ok && !keep[key] {
+				if err := backend.Delete(key); err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// internalizeCredentials replaces the references to credentials stored
+// in the backend with their attributes.
+func internalizeCredentials(backend CredentialBackend, credentials map[string]cloud.CloudCredential) error {
+	for cloudName, cloudCredential := range credentials {
+		for name, credential := range cloudCredential.AuthCredentials {
+			key, ok := backendKey(credential.Attributes())
+			if !ok {
+				continue
+			}
+			if backend == nil {
+				return errors.Errorf(
+					"credential %q for cloud %s is stored in a keyring, but no credential backend is configured",
+					name, cloudName,
+				)
+			}
+			data, err := backend.Get(key)
+			if err != nil {
+				return errors.Annotatef(err, "cannot get credential %q for cloud %s", name, cloudName)
+			}
+			var attrs map[string]string
+			if err := json.Unmarshal([]byte(data), &attrs); err != nil {
+				return errors.Annotatef(err, "cannot unmarshal credential %q for cloud %s", name, cloudName)
+			}
+			internalized := cloud.NewCredential(credential.AuthType(), attrs)
+			internalized.Label = credential.Label
+			cloudCredential.AuthCredentials[name] = internalized
+		}
+	}
+	return nil
+}
+
+func backendKey(attrs map[string]string) (string, bool) {
+	if len(attrs) != 1 {
+		return "", false
+	}
+	key, ok := attrs[credentialBackendAttr]
+	return key, ok
+}
+
+// keyringBackend is a CredentialBackend which keeps secrets in the
+// operating system's keyring, by way of its command line tools.
+type keyringBackend struct {
+	goos    string
+	service string
+}
+
+func newKeyringBackend(goos string) (CredentialBackend, error) {
+	switch goos {
+	case "linux", "darwin":
+	default:
+		return nil, errors.NotSupportedf("keyring credential backend on %s", goos)
+	}
+	// The keyring is shared by all of the user's juju data
+	// directories, so keep each one's secrets apart.
+	return &keyringBackend{
+		goos:    goos,
+		service: "juju-" + dataDirID(),
+	}, nil
+}
+
+// Get implements CredentialBackend.
+func (b *keyringBackend) Get(key string) (string, error) {
+	var out string
+	var err error
+	switch b.goos {
+	case "darwin":
+		out, err = runKeyringCommand("", "security", "find-generic-password", "-s", b.service, "-a", key, "-w")
+		if err, ok := err.(*keyringCommandError); ok && err.exitCode == 44 {
+			return "", errors.NotFoundf("keyring secret %q", key)
+		}
+	default:
+		out, err = runKeyringCommand("", "secret-tool", "lookup", "service", b.service, "key", key)
+		if err, ok := err.(*keyringCommandError); ok && err.exitCode == 1 && err.stderr == "" {
+			return "", errors.NotFoundf("keyring secret %q", key)
+		}
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// Set implements CredentialBackend.
+func (b *keyringBackend) Set(key, secret string) error {
+	switch b.goos {
+	case "darwin":
+		// As with secret-tool, the secret is passed on standard input
+		// rather than in the command line, where any user could see
+		// it. It's hex encoded so that it needn't be quoted.
+		command := fmt.Sprintf(
+			"add-generic-password -U -s %q -a %q -X %s\n",
+			b.service, key, hex.EncodeToString([]byte(secret)),
+		)
+		_, err := runKeyringCommand(command, "security", "-i")
+		return errors.Trace(err)
+	default:
+		_, err := runKeyringCommand(secret, "secret-tool", "store", "--label", "juju credential "+key, "service", b.service, "key", key)
+		return errors.Trace(err)
+	}
+}
+
+// Delete implements CredentialBackend.
+func (b *keyringBackend) Delete(key string) error {
+	switch b.goos {
+	case "darwin":
+		_, err := runKeyringCommand("", "security", "delete-generic-password", "-s", b.service, "-a", key)
+		if err, ok := err.(*keyringCommandError); ok && err.exitCode == 44 {
+			return nil
+		}
+		return errors.Trace(err)
+	default:
+		_, err := runKeyringCommand("", "secret-tool", "clear", "service", b.service, "key", key)
+		return errors.Trace(err)
+	}
+}
+
+// keyringCommandError is returned when a keyring command exits with
+// a non-zero status.
+type keyringCommandError struct {
+	command  string
+	exitCode int
+	stderr   string
+}
+
+func (e *keyringCommandError) Error() string {
+	if e.stderr == "" {
+		return fmt.Sprintf("%s exited with status %d", e.command, e.exitCode)
+	}
+	return fmt.Sprintf("%s exited with status %d: %s", e.command, e.exitCode, e.stderr)
+}
+
+// runKeyringCommand runs the named command with the given input, and
+// returns its output. If the command exits with a non-zero status,
+// a *keyringCommandError is returned.
+var runKeyringCommand = func(input, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitCode := 1
+		if status, ok := exitErr.Sys().(interface {
+			ExitStatus() int
+		}); ok {
+			exitCode = status.ExitStatus()
+		}
+		return "", &keyringCommandError{
+			command:  name,
+			exitCode: exitCode,
+			stderr:   strings.TrimSpace(stderr.String()),
+		}
+	} else if err != nil {
+		return "", errors.Annotatef(err, "cannot run %s", name)
+	}
+	return stdout.String(), nil
+}
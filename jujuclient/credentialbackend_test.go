@@ -0,0 +1,225 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type CredentialBackendSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	commands [][]string
+	secrets  map[string]string
+}
+
+var _ = gc.Suite(&CredentialBackendSuite{})
+
+func (s *CredentialBackendSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.commands = nil
+	s.secrets = make(map[string]string)
+	s.PatchValue(jujuclient.RunKeyringCommand, s.runSecretTool)
+}
+
+// runSecretTool emulates the secret-tool command with an in-memory
+// keyring.
+func (s *CredentialBackendSuite) runSecretTool(input, name string, args ...string) (string, error) {
+	s.commands = append(s.commands, append([]string{name}, args...))
+	if name != "secret-tool" {
+		return "", errors.Errorf("unexpected command %s", name)
+	}
+	key := args[len(args)-1]
+	switch args[0] {
+	case "store":
+		s.secrets[key] = input
+	case "lookup":
+		secret, ok := s.secrets[key]
+		if !ok {
+			return "", jujuclient.NewKeyringCommandError(name, 1, "")
+		}
+		return secret, nil
+	case "clear":
+		delete(s.secrets, key)
+	}
+	return "", nil
+}
+
+func (s *CredentialBackendSuite) TestNewCredentialBackend(c *gc.C) {
+	backend, err := jujuclient.NewCredentialBackend("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backend, gc.IsNil)
+	backend, err = jujuclient.NewCredentialBackend("file")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backend, gc.IsNil)
+	_, err = jujuclient.NewCredentialBackend("vault")
+	c.Assert(err, gc.ErrorMatches, `credential backend "vault" not valid`)
+	_, err = jujuclient.NewKeyringBackend("windows")
+	c.Assert(err, gc.ErrorMatches, "keyring credential backend on windows not supported")
+}
+
+func (s *CredentialBackendSuite) TestKeyringBackendLinux(c *gc.C) {
+	backend, err := jujuclient.NewKeyringBackend("linux")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = backend.Get("aws/peter")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = backend.Set("aws/peter", "secret")
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := backend.Get("aws/peter")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret, gc.Equals, "secret")
+	err = backend.Delete("aws/peter")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.secrets, gc.HasLen, 0)
+
+	c.Assert(s.commands, gc.HasLen, 4)
+	service := s.commands[0][3]
+	c.Assert(strings.HasPrefix(service, "juju-"), jc.IsTrue)
+	c.Assert(s.commands[1], jc.DeepEquals, []string{
+		"secret-tool", "store", "--label", "juju credential aws/peter", "service", service, "key", "aws/peter",
+	})
+	c.Assert(s.commands[3], jc.DeepEquals, []string{
+		"secret-tool", "clear", "service", service, "key", "aws/peter",
+	})
+}
+
+func (s *CredentialBackendSuite) TestKeyringBackendDarwin(c *gc.C) {
+	var commands [][]string
+	var inputs []string
+	s.PatchValue(jujuclient.RunKeyringCommand, func(input, name string, args ...string) (string, error) {
+		commands = append(commands, append([]string{name}, args...))
+		inputs = append(inputs, input)
+		if args[0] == "find-generic-password" {
+			return "", jujuclient.NewKeyringCommandError(name, 44, "The specified item could not be found in the keychain.")
+		}
+		return "", nil
+	})
+	backend, err := jujuclient.NewKeyringBackend("darwin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = backend.Set("aws/peter", "secret")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = backend.Get("aws/peter")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	c.Assert(commands, gc.HasLen, 2)
+	service := commands[1][3]
+	c.Assert(commands[0], jc.DeepEquals, []string{"security", "-i"})
+	// The secret is given to security on its standard input, hex encoded.
+	c.Assert(inputs[0], gc.Equals, fmt.Sprintf(
+		"add-generic-password -U -s %q -a \"aws/peter\" -X 736563726574\n", service,
+	))
+}
+
+func (s *CredentialBackendSuite) TestKeyringBackendError(c *gc.C) {
+	s.PatchValue(jujuclient.RunKeyringCommand, func(input, name string, args ...string) (string, error) {
+		return "", jujuclient.NewKeyringCommandError(name, 1, "Cannot autolaunch D-Bus")
+	})
+	backend, err := jujuclient.NewKeyringBackend("linux")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = backend.Get("aws/peter")
+	c.Assert(err, gc.ErrorMatches, "secret-tool exited with status 1: Cannot autolaunch D-Bus")
+}
+
+func (s *CredentialBackendSuite) TestExternalizeCredentialsKeepsLabel(c *gc.C) {
+	backend, err := jujuclient.NewKeyringBackend("linux")
+	c.Assert(err, jc.ErrorIsNil)
+	credential := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"access-key": "key",
+		"secret-key": "secret",
+	})
+	credential.Label = "peter's key"
+	credentials := map[string]cloud.CloudCredential{
+		"aws": {AuthCredentials: map[string]cloud.Credential{"peter": credential}},
+	}
+
+	external, err := jujuclient.ExternalizeCredentials(backend, nil, credentials)
+	c.Assert(err, jc.ErrorIsNil)
+	externalized := external["aws"].AuthCredentials["peter"]
+	c.Assert(externalized.Attributes(), jc.DeepEquals, map[string]string{"keyring-id": "aws/peter"})
+	c.Assert(externalized.Label, gc.Equals, "peter's key")
+
+	err = jujuclient.InternalizeCredentials(backend, external)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(external["aws"].AuthCredentials["peter"], jc.DeepEquals, credential)
+}
+
+func (s *CredentialBackendSuite) TestStoreCredentialsInKeyring(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("keyring emulated with secret-tool")
+	}
+	writeTestCredentialsFile(c)
+	err := jujuclient.WriteClientConfig(&jujuclient.ClientConfig{
+		CredentialBackend: "keyring",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	store := jujuclient.NewFileCredentialStore()
+	err = store.UpdateCredential("aws", cloud.CloudCredential{
+		DefaultCredential: "peter",
+		AuthCredentials: map[string]cloud.Credential{
+			"peter": cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+				"access-key": "key",
+				"secret-key": "new-secret",
+			}),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// All credentials, including those written before the backend
+	// was configured, are moved into the keyring.
+	data, err := ioutil.ReadFile(jujuclient.JujuCredentialsPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+credentials:
+  aws:
+    default-credential: peter
+    peter:
+      auth-type: access-key
+      keyring-id: aws/peter
+  aws-gov:
+    fbi:
+      auth-type: access-key
+      keyring-id: aws-gov/fbi
+`[1:])
+	c.Assert(s.secrets, gc.HasLen, 2)
+
+	credential, err := store.CredentialForCloud("aws")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential.AuthCredentials["peter"].Attributes(), jc.DeepEquals, map[string]string{
+		"access-key": "key",
+		"secret-key": "new-secret",
+	})
+
+	// Removing a credential removes it from the keyring.
+	err = store.UpdateCredential("aws-gov", cloud.CloudCredential{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.secrets, gc.HasLen, 1)
+	_, ok := s.secrets["aws/peter"]
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *CredentialBackendSuite) TestReadKeyringCredentialsWithoutBackend(c *gc.C) {
+	err := ioutil.WriteFile(jujuclient.JujuCredentialsPath(), []byte(`
+credentials:
+  aws:
+    peter:
+      auth-type: access-key
+      keyring-id: aws/peter
+`[1:]), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = jujuclient.NewFileCredentialStore().AllCredentials()
+	c.Assert(err, gc.ErrorMatches, `credential "peter" for cloud aws is stored in a keyring, but no credential backend is configured`)
+}
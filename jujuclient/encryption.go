@@ -0,0 +1,355 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+// ErrStoreLocked is returned when encrypted credentials are needed
+// from the client store, but the key with which they are encrypted
+// is not available.
+var ErrStoreLocked = errors.New(`client store is locked; run "juju login --unlock" to unlock it`)
+
+const (
+	// encryptedPrefix marks values in the client store which are
+	// encrypted.
+	encryptedPrefix = "encrypted:"
+
+	// storeKeyCheck is encrypted with the store key so that a
+	// passphrase can be checked when the store is unlocked.
+	storeKeyCheck = "juju client store"
+)
+
+// JujuStoreKeyPath is the location where the information needed to
+// derive the client store's key from a passphrase is expected to be
+// found. The client store is encrypted if the file exists.
+func JujuStoreKeyPath() string {
+	return osenv.JujuXDGDataHomePath("store-key.yaml")
+}
+
+type storeKeyFile struct {
+	Salt  string `yaml:"salt"`
+	Check string `yaml:"check"`
+}
+
+func readStoreKeyFile() (*storeKeyFile, error) {
+	data, err := ioutil.ReadFile(JujuStoreKeyPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var result storeKeyFile
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal store key")
+	}
+	return &result, nil
+}
+
+// StoreEncrypted reports whether the credentials in the client store
+// are encrypted.
+func StoreEncrypted() (bool, error) {
+	keyFile, err := readStoreKeyFile()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return keyFile != nil, nil
+}
+
+// EncryptStore encrypts the credentials in the client store with a key
+// derived from the given passphrase, and unlocks the store for the
+// rest of the user's session.
+func EncryptStore(passphrase string) error {
+	releaser, err := (&store{}).acquireLock()
+	if err != nil {
+		return errors.Annotate(err, "cannot encrypt client store")
+	}
+	defer releaser.Release()
+
+	keyFile, err := readStoreKeyFile()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if keyFile != nil {
+		return errors.AlreadyExistsf("client store encryption")
+	}
+	accounts, err := ReadAccountsFile(JujuAccountsPath())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return errors.Trace(err)
+	}
+	key, err := deriveStoreKey(passphrase, salt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	check, err := encryptValue(key, storeKeyCheck)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := yaml.Marshal(storeKeyFile{
+		Salt:  base64.StdEncoding.EncodeToString(salt),
+		Check: check,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal store key")
+	}
+	if err := writeUnlockedKey(key); err != nil {
+		return errors.Trace(err)
+	}
+	if err := utils.AtomicWriteFile(JujuStoreKeyPath(), data, os.FileMode(0600)); err != nil {
+		return errors.Trace(err)
+	}
+	if accounts == nil {
+		return nil
+	}
+	return errors.Trace(WriteAccountsFile(accounts))
+}
+
+// UnlockStore checks the given passphrase against the client store's
+// key, and unlocks the store for the rest of the user's session.
+func UnlockStore(passphrase string) error {
+	keyFile, err := readStoreKeyFile()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if keyFile == nil {
+		return errors.New("client store is not encrypted")
+	}
+	salt, err := base64.StdEncoding.DecodeString(keyFile.Salt)
+	if err != nil {
+		return errors.Annotate(err, "cannot decode store key salt")
+	}
+	key, err := deriveStoreKey(passphrase, salt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if check, err := decryptValue(key, keyFile.Check); err != nil || check != storeKeyCheck {
+		return errors.New("incorrect passphrase")
+	}
+	return errors.Trace(writeUnlockedKey(key))
+}
+
+// LockStore forgets the key of an unlocked client store, so that the
+// passphrase must be given again before encrypted credentials can be
+// used.
+func LockStore() error {
+	path, err := unlockedKeyPath()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// storeKey returns the key with which the client store's credentials
+// are encrypted, or nil if they are not encrypted. It returns
+// ErrStoreLocked if the store is encrypted but the key is not
+// available.
+func storeKey() (*[32]byte, error) {
+	keyFile, err := readStoreKeyFile()
+	if err != nil || keyFile == nil {
+		return nil, errors.Trace(err)
+	}
+	if encoded := os.Getenv(osenv.JujuStoreKeyEnvKey); encoded != "" {
+		key, err := decodeStoreKey(encoded)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid %s", osenv.JujuStoreKeyEnvKey)
+		}
+		return key, nil
+	}
+	path, err := unlockedKeyPath()
+	if err != nil {
+		return nil, ErrStoreLocked
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrStoreLocked
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return decodeStoreKey(string(data))
+}
+
+func deriveStoreKey(passphrase string, salt []byte) (*[32]byte, error) {
+	derived, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot derive store key")
+	}
+	var key [32]byte
+	copy(key[:], derived)
+	return &key, nil
+}
+
+func decodeStoreKey(encoded string) (*[32]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(data) != 32 {
+		return nil, errors.Errorf("expected 32 byte key, got %d bytes", len(data))
+	}
+	var key [32]byte
+	copy(key[:], data)
+	return &key, nil
+}
+
+// unlockedKeyPath returns the path of the file in which the key of an
+// unlocked client store is kept. The file is kept in the user's
+// runtime directory, so it does not outlive their session.
+func unlockedKeyPath() (string, error) {
+	dir := os.Getenv(osenv.XDGRuntimeDir)
+	if dir == "" {
+		return "", errors.Errorf("%s not set", osenv.XDGRuntimeDir)
+	}
+	// The runtime directory is shared by every juju data
+	// directory the user has, so name the file after this one.
+	return filepath.Join(dir, "juju", fmt.Sprintf("store-%s.key", dataDirID())), nil
+}
+
+// dataDirID returns a short identifier for the juju data directory,
+// with which things shared by all of the user's data directories may
+// be named.
+func dataDirID() string {
+	sum := sha256.Sum256([]byte(osenv.JujuXDGDataHomeDir()))
+	return fmt.Sprintf("%x", sum[:8])
+}
+
+func writeUnlockedKey(key *[32]byte) error {
+	path, err := unlockedKeyPath()
+	if err != nil {
+		return errors.Annotatef(err, "cannot unlock client store; set %s instead", osenv.JujuStoreKeyEnvKey)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	data := []byte(base64.StdEncoding.EncodeToString(key[:]))
+	return utils.AtomicWriteFile(path, data, os.FileMode(0600))
+}
+
+func isEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+func encryptValue(key *[32]byte, value string) (string, error) {
+	var nonce [24]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", errors.Trace(err)
+	}
+	sealed := secretbox.Seal(nonce[:], []byte(value), &nonce, key)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptValue(key *[32]byte, value string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(data) < 24 {
+		return "", errors.New("encrypted value too short")
+	}
+	var nonce [24]byte
+	copy(nonce[:], data)
+	plain, ok := secretbox.Open(nil, data[24:], &nonce, key)
+	if !ok {
+		return "", errors.New("cannot decrypt value")
+	}
+	return string(plain), nil
+}
+
+// encryptAccounts encrypts the credentials in the given accounts, if
+// the client store is encrypted. Credentials which are already
+// encrypted, because they were read while the store was locked, are
+// left alone.
+func encryptAccounts(accounts map[string]AccountDetails) (map[string]AccountDetails, error) {
+	key, err := storeKey()
+	if err == ErrStoreLocked {
+		for name, details := range accounts {
+			if hasPlainCredentials(details) {
+				return nil, errors.Annotatef(err, "cannot store account details for controller %s", name)
+			}
+		}
+		return accounts, nil
+	}
+	if err != nil || key == nil {
+		return accounts, errors.Trace(err)
+	}
+	result := make(map[string]AccountDetails)
+	for name, details := range accounts {
+		if details.Password, err = maybeEncrypt(key, details.Password); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if details.Macaroon, err = maybeEncrypt(key, details.Macaroon); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[name] = details
+	}
+	return result, nil
+}
+
+// decryptAccounts decrypts the credentials in the given accounts. If
+// the client store is locked they are left encrypted.
+func decryptAccounts(accounts map[string]AccountDetails) error {
+	key, err := storeKey()
+	if err == ErrStoreLocked || key == nil {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for name, details := range accounts {
+		if details.Password, err = maybeDecrypt(key, details.Password); err != nil {
+			return errors.Annotatef(err, "cannot decrypt password for controller %s", name)
+		}
+		if details.Macaroon, err = maybeDecrypt(key, details.Macaroon); err != nil {
+			return errors.Annotatef(err, "cannot decrypt macaroon for controller %s", name)
+		}
+		accounts[name] = details
+	}
+	return nil
+}
+
+func accountEncrypted(details AccountDetails) bool {
+	return isEncrypted(details.Password) || isEncrypted(details.Macaroon)
+}
+
+func hasPlainCredentials(details AccountDetails) bool {
+	return details.Password != "" && !isEncrypted(details.Password) ||
+		details.Macaroon != "" && !isEncrypted(details.Macaroon)
+}
+
+func maybeEncrypt(key *[32]byte, value string) (string, error) {
+	if value == "" || isEncrypted(value) {
+		return value, nil
+	}
+	return encryptValue(key, value)
+}
+
+func maybeDecrypt(key *[32]byte, value string) (string, error) {
+	if !isEncrypted(value) {
+		return value, nil
+	}
+	return decryptValue(key, value)
+}
@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type EncryptionSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store jujuclient.ClientStore
+}
+
+var _ = gc.Suite(&EncryptionSuite{})
+
+func (s *EncryptionSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.PatchEnvironment(osenv.XDGRuntimeDir, c.MkDir())
+	s.store = jujuclient.NewFileClientStore()
+	writeTestAccountsFile(c)
+}
+
+func (s *EncryptionSuite) TestNotEncrypted(c *gc.C) {
+	encrypted, err := jujuclient.StoreEncrypted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(encrypted, jc.IsFalse)
+
+	err = jujuclient.UnlockStore("passphrase")
+	c.Assert(err, gc.ErrorMatches, "client store is not encrypted")
+}
+
+func (s *EncryptionSuite) TestEncryptStore(c *gc.C) {
+	err := jujuclient.EncryptStore("passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+
+	encrypted, err := jujuclient.StoreEncrypted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(encrypted, jc.IsTrue)
+
+	data, err := ioutil.ReadFile(jujuclient.JujuAccountsPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.Contains(string(data), "hunter2"), jc.IsFalse)
+
+	// The store is left unlocked, so the credentials
+	// are decrypted transparently.
+	details, err := s.store.AccountDetails("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*details, jc.DeepEquals, ctrlAdminAccountDetails)
+
+	err = jujuclient.EncryptStore("passphrase")
+	c.Assert(err, gc.ErrorMatches, "client store encryption already exists")
+}
+
+func (s *EncryptionSuite) TestLockStore(c *gc.C) {
+	err := jujuclient.EncryptStore("passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuclient.LockStore()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.store.AccountDetails("ctrl")
+	c.Assert(err, gc.Equals, jujuclient.ErrStoreLocked)
+
+	// Accounts without credentials can still be read.
+	details, err := s.store.AccountDetails("kontroll")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*details, jc.DeepEquals, kontrollBobRemoteAccountDetails)
+
+	// New credentials cannot be stored.
+	err = s.store.UpdateAccount("kontroll", jujuclient.AccountDetails{
+		User:     "bob@remote",
+		Password: "sekrit",
+	})
+	c.Assert(err, gc.ErrorMatches, ".*client store is locked.*")
+
+	// Removing an account keeps the others' credentials encrypted.
+	err = s.store.RemoveAccount("kontroll")
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuclient.UnlockStore("passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+	details, err = s.store.AccountDetails("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*details, jc.DeepEquals, ctrlAdminAccountDetails)
+}
+
+func (s *EncryptionSuite) TestUnlockIncorrectPassphrase(c *gc.C) {
+	err := jujuclient.EncryptStore("passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuclient.LockStore()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuclient.UnlockStore("foobar")
+	c.Assert(err, gc.ErrorMatches, "incorrect passphrase")
+	_, err = s.store.AccountDetails("ctrl")
+	c.Assert(err, gc.Equals, jujuclient.ErrStoreLocked)
+}
+
+func (s *EncryptionSuite) TestStoreKeyEnvironment(c *gc.C) {
+	err := jujuclient.EncryptStore("passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuclient.LockStore()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.PatchEnvironment(osenv.JujuStoreKeyEnvKey, "foo")
+	_, err = s.store.AccountDetails("ctrl")
+	c.Assert(err, gc.ErrorMatches, "invalid JUJU_STORE_KEY: .*")
+
+	wrongKey := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	s.PatchEnvironment(osenv.JujuStoreKeyEnvKey, wrongKey)
+	_, err = s.store.AccountDetails("ctrl")
+	c.Assert(err, gc.ErrorMatches, "cannot decrypt password for controller ctrl: cannot decrypt value")
+}
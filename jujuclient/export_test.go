@@ -0,0 +1,21 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+var (
+	LockTimeout          = &lockTimeout
+	StoreLockName        = storeLockName
+	OperationLogLockName = operationLogLockName
+)
+
+var (
+	NewKeyringBackend      = newKeyringBackend
+	RunKeyringCommand      = &runKeyringCommand
+	ExternalizeCredentials = externalizeCredentials
+	InternalizeCredentials = internalizeCredentials
+)
+
+func NewKeyringCommandError(command string, exitCode int, stderr string) error {
+	return &keyringCommandError{command, exitCode, stderr}
+}
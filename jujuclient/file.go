@@ -7,6 +7,8 @@
 package jujuclient
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/juju/osenv"
 )
 
 var _ ClientStore = (*store)(nil)
@@ -26,6 +29,10 @@ var logger = loggo.GetLogger("juju.jujuclient")
 // reasonable time to get the lock.
 var lockTimeout = 5 * time.Second
 
+// lockDelay is how long to wait before trying again to acquire a
+// lock held by another process.
+var lockDelay = 20 * time.Millisecond
+
 // NewFileClientStore returns a new filesystem-based client store
 // that manages files in $XDG_DATA_HOME/juju.
 func NewFileClientStore() ClientStore {
@@ -40,21 +47,41 @@ func NewFileCredentialStore() CredentialStore {
 
 type store struct{}
 
+// acquireLock acquires the lock which serialises access to the files
+// of the client store, waiting for up to lockTimeout for any other juju
+// process that holds it. Every read of the store's files, as well as
+// every read-modify-write, must be done with the lock held; the files
+// themselves are replaced atomically, so a process which does not hold
+// the lock never sees a partially written file.
 func (s *store) acquireLock() (mutex.Releaser, error) {
-	const lockName = "store-lock"
 	spec := mutex.Spec{
-		Name:    lockName,
+		Name:    storeLockName(),
 		Clock:   clock.WallClock,
-		Delay:   20 * time.Millisecond,
+		Delay:   lockDelay,
 		Timeout: lockTimeout,
 	}
 	releaser, err := mutex.Acquire(spec)
+	if errors.Cause(err) == mutex.ErrTimeout {
+		return nil, errors.Errorf(
+			"timed out after %v waiting for another juju process to release the client store in %s",
+			lockTimeout, osenv.JujuXDGDataHomeDir(),
+		)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return releaser, nil
 }
 
+// storeLockName returns the name of the lock which guards the client
+// store in the current juju data directory. Processes using different
+// data directories, such as parallel CI jobs, do not contend for the
+// same lock.
+func storeLockName() string {
+	sum := sha256.Sum256([]byte(osenv.JujuXDGDataHomeDir()))
+	return fmt.Sprintf("store-lock-%x", sum[:8])
+}
+
 // AllControllers implements ControllersGetter.
 func (s *store) AllControllers() (map[string]ControllerDetails, error) {
 	releaser, err := s.acquireLock()
@@ -287,6 +314,33 @@ func (s *store) RemoveController(name string) error {
 		}
 	}
 
+	// Remove data cached from the controller.
+	for _, name := range names {
+		if err := RemoveCachedData(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	// Remove model aliases referring to the controller.
+	aliases, err := ReadModelAliasesFile(JujuModelAliasesPath())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	removedAlias := false
+	for _, name := range names {
+		for alias, target := range aliases {
+			if target.Controller == name {
+				delete(aliases, alias)
+				removedAlias = true
+			}
+		}
+	}
+	if removedAlias {
+		if err := WriteModelAliasesFile(aliases); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// Finally, remove the controllers. This must be done last
 	// so we don't end up with dangling entries in other files.
 	return WriteControllersFile(controllers)
@@ -515,6 +569,99 @@ func updateModels(
 	return nil
 }
 
+// SetModelAlias implements ModelAliasUpdater.
+func (s *store) SetModelAlias(alias, controllerName, modelName string) error {
+	if err := ValidateModelAlias(alias); err != nil {
+		return errors.Trace(err)
+	}
+	if err := ValidateControllerName(controllerName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := ValidateModelName(modelName); err != nil {
+		return errors.Trace(err)
+	}
+
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return errors.Annotatef(err, "cannot set model alias %s", alias)
+	}
+	defer releaser.Release()
+
+	aliases, err := ReadModelAliasesFile(JujuModelAliasesPath())
+	if err != nil {
+		return errors.Annotate(err, "cannot get model aliases")
+	}
+	if aliases == nil {
+		aliases = make(map[string]ModelAlias)
+	}
+	aliases[alias] = ModelAlias{Controller: controllerName, Model: modelName}
+	return WriteModelAliasesFile(aliases)
+}
+
+// RemoveModelAlias implements ModelAliasUpdater.
+func (s *store) RemoveModelAlias(alias string) error {
+	if err := ValidateModelAlias(alias); err != nil {
+		return errors.Trace(err)
+	}
+
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return errors.Annotatef(err, "cannot remove model alias %s", alias)
+	}
+	defer releaser.Release()
+
+	aliases, err := ReadModelAliasesFile(JujuModelAliasesPath())
+	if err != nil {
+		return errors.Annotate(err, "cannot get model aliases")
+	}
+	if _, ok := aliases[alias]; !ok {
+		return errors.NotFoundf("model alias %s", alias)
+	}
+	delete(aliases, alias)
+	return WriteModelAliasesFile(aliases)
+}
+
+// ModelAlias implements ModelAliasGetter.
+func (s *store) ModelAlias(alias string) (string, string, error) {
+	if err := ValidateModelAlias(alias); err != nil {
+		return "", "", errors.Trace(err)
+	}
+
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return "", "", errors.Annotatef(err, "cannot read model alias %s", alias)
+	}
+	defer releaser.Release()
+
+	aliases, err := ReadModelAliasesFile(JujuModelAliasesPath())
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	target, ok := aliases[alias]
+	if !ok {
+		return "", "", errors.NotFoundf("model alias %s", alias)
+	}
+	return target.Controller, target.Model, nil
+}
+
+// AllModelAliases implements ModelAliasGetter.
+func (s *store) AllModelAliases() (map[string]ModelAlias, error) {
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model aliases")
+	}
+	defer releaser.Release()
+
+	aliases, err := ReadModelAliasesFile(JujuModelAliasesPath())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if aliases == nil {
+		aliases = make(map[string]ModelAlias)
+	}
+	return aliases, nil
+}
+
 // UpdateAccount implements AccountUpdater.
 func (s *store) UpdateAccount(controllerName string, details AccountDetails) error {
 	if err := ValidateControllerName(controllerName); err != nil {
@@ -565,6 +712,9 @@ func (s *store) AccountDetails(controllerName string) (*AccountDetails, error) {
 	if !ok {
 		return nil, errors.NotFoundf("account details for controller %s", controllerName)
 	}
+	if accountEncrypted(details) {
+		return nil, ErrStoreLocked
+	}
 	return &details, nil
 }
 
@@ -600,13 +750,14 @@ func (s *store) UpdateCredential(cloudName string, details cloud.CloudCredential
 	}
 	defer releaser.Release()
 
-	all, err := ReadCredentialsFile(JujuCredentialsPath())
+	previous, err := ReadCredentialsFile(JujuCredentialsPath())
 	if err != nil {
 		return errors.Annotate(err, "cannot get credentials")
 	}
 
-	if len(all) == 0 {
-		all = make(map[string]cloud.CloudCredential)
+	all := make(map[string]cloud.CloudCredential)
+	for name, credential := range previous {
+		all[name] = credential
 	}
 
 	// Clear the default credential if we are removing that one.
@@ -624,12 +775,31 @@ func (s *store) UpdateCredential(cloudName string, details cloud.CloudCredential
 	}
 
 	all[cloudName] = details
+
+	// If a credential backend is configured, all credentials are
+	// moved into it, including any written before it was.
+	backend, err := configuredCredentialBackend()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if backend != nil {
+		all, err = externalizeCredentials(backend, previous, all)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return WriteCredentialsFile(all)
 }
 
 // CredentialForCloud implements CredentialGetter.
 func (s *store) CredentialForCloud(cloudName string) (*cloud.CloudCredential, error) {
-	cloudCredentials, err := s.AllCredentials()
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read credentials for %v", cloudName)
+	}
+	defer releaser.Release()
+
+	cloudCredentials, err := readCredentials()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -642,13 +812,36 @@ func (s *store) CredentialForCloud(cloudName string) (*cloud.CloudCredential, er
 
 // AllCredentials implements CredentialGetter.
 func (s *store) AllCredentials() (map[string]cloud.CloudCredential, error) {
-	cloudCredentials, err := ReadCredentialsFile(JujuCredentialsPath())
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read all credentials")
+	}
+	defer releaser.Release()
+
+	cloudCredentials, err := readCredentials()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return cloudCredentials, nil
 }
 
+// readCredentials reads the credentials file, fetching the attributes
+// of any credentials kept in a credential backend.
+func readCredentials() (map[string]cloud.CloudCredential, error) {
+	credentials, err := ReadCredentialsFile(JujuCredentialsPath())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, err := configuredCredentialBackend()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := internalizeCredentials(backend, credentials); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return credentials, nil
+}
+
 // UpdateBootstrapConfig implements BootstrapConfigUpdater.
 func (s *store) UpdateBootstrapConfig(controllerName string, cfg BootstrapConfig) error {
 	if err := ValidateControllerName(controllerName); err != nil {
@@ -678,6 +871,12 @@ func (s *store) UpdateBootstrapConfig(controllerName string, cfg BootstrapConfig
 
 // BootstrapConfigForController implements BootstrapConfigGetter.
 func (s *store) BootstrapConfigForController(controllerName string) (*BootstrapConfig, error) {
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read bootstrap config for controller %s", controllerName)
+	}
+	defer releaser.Release()
+
 	configs, err := ReadBootstrapConfigFile(JujuBootstrapConfigPath())
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/mutex"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type FileStoreSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store jujuclient.ClientStore
+}
+
+var _ = gc.Suite(&FileStoreSuite{})
+
+func (s *FileStoreSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewFileClientStore()
+}
+
+func (s *FileStoreSuite) TestConcurrentUpdates(c *gc.C) {
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- s.store.AddController(fmt.Sprintf("ctrl%d", i), jujuclient.ControllerDetails{
+				ControllerUUID: fmt.Sprintf("uuid-%d", i),
+				CACert:         "ca-cert",
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		c.Check(err, jc.ErrorIsNil)
+	}
+
+	// Every update is preserved, none is lost to another.
+	controllers, err := s.store.AllControllers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllers, gc.HasLen, n)
+}
+
+func (s *FileStoreSuite) TestLockTimeout(c *gc.C) {
+	s.PatchValue(jujuclient.LockTimeout, 50*time.Millisecond)
+	releaser, err := mutex.Acquire(mutex.Spec{
+		Name:  jujuclient.StoreLockName(),
+		Clock: clock.WallClock,
+		Delay: time.Millisecond,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer releaser.Release()
+
+	_, err = s.store.AllControllers()
+	c.Assert(err, gc.ErrorMatches, "cannot read all controllers: timed out after 50ms waiting for another juju process to release the client store in .*")
+}
+
+func (s *FileStoreSuite) TestLockNamePerDataDir(c *gc.C) {
+	name := jujuclient.StoreLockName()
+	defer osenv.SetJujuXDGDataHome(osenv.SetJujuXDGDataHome(c.MkDir()))
+	c.Assert(jujuclient.StoreLockName(), gc.Not(gc.Equals), name)
+}
@@ -4,6 +4,8 @@
 package jujuclient
 
 import (
+	"time"
+
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
 )
@@ -34,6 +36,39 @@ type ControllerDetails struct {
 	// CloudRegion is the name of the cloud region that this controller
 	// runs in. This will be empty for clouds without regions.
 	CloudRegion string `yaml:"region,omitempty"`
+
+	// Proxy is the URL of a proxy through which connections to the
+	// controller are made, such as "socks5://10.0.0.1:1080" or
+	// "http://squid.example.com:3128". If it is empty, the
+	// controller is connected to directly.
+	Proxy string `yaml:"proxy,omitempty"`
+
+	// ExtraCACerts holds PEM-encoded CA certificates which are
+	// trusted, in addition to CACert, when verifying the
+	// controller's certificate.
+	ExtraCACerts []string `yaml:"extra-ca-certs,omitempty"`
+
+	// DialTimeout is how long to keep trying to connect to the
+	// controller. If it is zero, the default is used.
+	DialTimeout time.Duration `yaml:"dial-timeout,omitempty"`
+
+	// AddressHealth records how recent attempts to connect to each
+	// of the controller's API addresses have fared, so that the
+	// addresses most likely to work can be tried first.
+	AddressHealth map[string]AddressHealth `yaml:"address-health,omitempty"`
+}
+
+// AddressHealth records how recent attempts to connect to a
+// controller API address have fared.
+type AddressHealth struct {
+	// LastSuccess is the last time a connection to the
+	// address was established.
+	LastSuccess time.Time `yaml:"last-success,omitempty"`
+
+	// Failures is the number of consecutive connection attempts in
+	// which the address was dialled, but another address was
+	// connected to first.
+	Failures int `yaml:"failures,omitempty"`
 }
 
 // ModelDetails holds details of a model.
@@ -42,6 +77,16 @@ type ModelDetails struct {
 	ModelUUID string `yaml:"uuid"`
 }
 
+// ModelAlias holds the controller and model to which a model alias
+// refers.
+type ModelAlias struct {
+	// Controller is the name of the controller hosting the model.
+	Controller string `yaml:"controller"`
+
+	// Model is the name of the model.
+	Model string `yaml:"model"`
+}
+
 // AccountDetails holds details of an account.
 type AccountDetails struct {
 	// User is the username for the account.
@@ -201,6 +246,30 @@ type ModelGetter interface {
 	ModelByName(controllerName, modelName string) (*ModelDetails, error)
 }
 
+// ModelAliasUpdater stores model aliases.
+type ModelAliasUpdater interface {
+	// SetModelAlias makes the given alias refer to the model with
+	// the specified controller and model names. If the alias
+	// already exists, it will be overwritten.
+	SetModelAlias(alias, controllerName, modelName string) error
+
+	// RemoveModelAlias removes the given model alias. If there is
+	// no such alias, an error satisfying errors.IsNotFound will be
+	// returned.
+	RemoveModelAlias(alias string) error
+}
+
+// ModelAliasGetter gets model aliases.
+type ModelAliasGetter interface {
+	// ModelAlias returns the controller and model names to which
+	// the given alias refers. If there is no such alias, an error
+	// satisfying errors.IsNotFound will be returned.
+	ModelAlias(alias string) (controllerName, modelName string, _ error)
+
+	// AllModelAliases returns all model aliases, keyed by alias.
+	AllModelAliases() (map[string]ModelAlias, error)
+}
+
 // AccountUpdater stores account details.
 type AccountUpdater interface {
 	// UpdateAccount updates the account associated with the
@@ -277,6 +346,13 @@ type ModelStore interface {
 	ModelGetter
 }
 
+// ModelAliasStore is an amalgamation of ModelAliasUpdater and
+// ModelAliasGetter.
+type ModelAliasStore interface {
+	ModelAliasUpdater
+	ModelAliasGetter
+}
+
 // AccountStore is an amalgamation of AccountUpdater, AccountRemover, and AccountGetter.
 type AccountStore interface {
 	AccountUpdater
@@ -298,11 +374,12 @@ type BootstrapConfigStore interface {
 }
 
 // ClientStore is an amalgamation of AccountStore, BootstrapConfigStore,
-// ControllerStore, CredentialStore, and ModelStore.
+// ControllerStore, CredentialStore, ModelStore, and ModelAliasStore.
 type ClientStore interface {
 	AccountStore
 	BootstrapConfigStore
 	ControllerStore
 	CredentialStore
 	ModelStore
+	ModelAliasStore
 }
@@ -20,6 +20,7 @@ type MemStore struct {
 	Accounts              map[string]jujuclient.AccountDetails
 	Credentials           map[string]cloud.CloudCredential
 	BootstrapConfig       map[string]jujuclient.BootstrapConfig
+	ModelAliases          map[string]jujuclient.ModelAlias
 }
 
 func NewMemStore() *MemStore {
@@ -29,6 +30,7 @@ func NewMemStore() *MemStore {
 		Accounts:        make(map[string]jujuclient.AccountDetails),
 		Credentials:     make(map[string]cloud.CloudCredential),
 		BootstrapConfig: make(map[string]jujuclient.BootstrapConfig),
+		ModelAliases:    make(map[string]jujuclient.ModelAlias),
 	}
 }
 
@@ -140,6 +142,11 @@ func (c *MemStore) RemoveController(name string) error {
 		delete(c.Accounts, name)
 		delete(c.BootstrapConfig, name)
 		delete(c.Controllers, name)
+		for alias, target := range c.ModelAliases {
+			if target.Controller == name {
+				delete(c.ModelAliases, alias)
+			}
+		}
 	}
 	return nil
 }
@@ -333,3 +340,47 @@ func (c *MemStore) BootstrapConfigForController(controllerName string) (*jujucli
 	return nil, errors.NotFoundf("bootstrap config for controller %s", controllerName)
 
 }
+
+// SetModelAlias implements ModelAliasUpdater.
+func (c *MemStore) SetModelAlias(alias, controllerName, modelName string) error {
+	if err := jujuclient.ValidateModelAlias(alias); err != nil {
+		return err
+	}
+	if err := jujuclient.ValidateControllerName(controllerName); err != nil {
+		return err
+	}
+	if err := jujuclient.ValidateModelName(modelName); err != nil {
+		return err
+	}
+	c.ModelAliases[alias] = jujuclient.ModelAlias{
+		Controller: controllerName,
+		Model:      modelName,
+	}
+	return nil
+}
+
+// RemoveModelAlias implements ModelAliasUpdater.
+func (c *MemStore) RemoveModelAlias(alias string) error {
+	if _, ok := c.ModelAliases[alias]; !ok {
+		return errors.NotFoundf("model alias %s", alias)
+	}
+	delete(c.ModelAliases, alias)
+	return nil
+}
+
+// ModelAlias implements ModelAliasGetter.
+func (c *MemStore) ModelAlias(alias string) (string, string, error) {
+	if target, ok := c.ModelAliases[alias]; ok {
+		return target.Controller, target.Model, nil
+	}
+	return "", "", errors.NotFoundf("model alias %s", alias)
+}
+
+// AllModelAliases implements ModelAliasGetter.
+func (c *MemStore) AllModelAliases() (map[string]jujuclient.ModelAlias, error) {
+	result := make(map[string]jujuclient.ModelAlias)
+	for k, v := range c.ModelAliases {
+		result[k] = v
+	}
+	return result, nil
+}
@@ -38,6 +38,11 @@ type StubStore struct {
 
 	BootstrapConfigForControllerFunc func(controllerName string) (*jujuclient.BootstrapConfig, error)
 	UpdateBootstrapConfigFunc        func(controllerName string, cfg jujuclient.BootstrapConfig) error
+
+	SetModelAliasFunc    func(alias, controllerName, modelName string) error
+	RemoveModelAliasFunc func(alias string) error
+	ModelAliasFunc       func(alias string) (string, string, error)
+	AllModelAliasesFunc  func() (map[string]jujuclient.ModelAlias, error)
 }
 
 func NewStubStore() *StubStore {
@@ -111,6 +116,19 @@ func NewStubStore() *StubStore {
 	result.UpdateBootstrapConfigFunc = func(controllerName string, cfg jujuclient.BootstrapConfig) error {
 		return result.Stub.NextErr()
 	}
+
+	result.SetModelAliasFunc = func(alias, controllerName, modelName string) error {
+		return result.Stub.NextErr()
+	}
+	result.RemoveModelAliasFunc = func(alias string) error {
+		return result.Stub.NextErr()
+	}
+	result.ModelAliasFunc = func(alias string) (string, string, error) {
+		return "", "", result.Stub.NextErr()
+	}
+	result.AllModelAliasesFunc = func() (map[string]jujuclient.ModelAlias, error) {
+		return nil, result.Stub.NextErr()
+	}
 	return result
 }
 
@@ -137,6 +155,10 @@ func WrapClientStore(underlying jujuclient.ClientStore) *StubStore {
 	stub.RemoveAccountFunc = underlying.RemoveAccount
 	stub.BootstrapConfigForControllerFunc = underlying.BootstrapConfigForController
 	stub.UpdateBootstrapConfigFunc = underlying.UpdateBootstrapConfig
+	stub.SetModelAliasFunc = underlying.SetModelAlias
+	stub.RemoveModelAliasFunc = underlying.RemoveModelAlias
+	stub.ModelAliasFunc = underlying.ModelAlias
+	stub.AllModelAliasesFunc = underlying.AllModelAliases
 	return stub
 }
 
@@ -265,3 +287,27 @@ func (c *StubStore) UpdateBootstrapConfig(controllerName string, cfg jujuclient.
 	c.MethodCall(c, "UpdateBootstrapConfig", controllerName, cfg)
 	return c.UpdateBootstrapConfigFunc(controllerName, cfg)
 }
+
+// SetModelAlias implements ModelAliasUpdater.
+func (c *StubStore) SetModelAlias(alias, controllerName, modelName string) error {
+	c.MethodCall(c, "SetModelAlias", alias, controllerName, modelName)
+	return c.SetModelAliasFunc(alias, controllerName, modelName)
+}
+
+// RemoveModelAlias implements ModelAliasUpdater.
+func (c *StubStore) RemoveModelAlias(alias string) error {
+	c.MethodCall(c, "RemoveModelAlias", alias)
+	return c.RemoveModelAliasFunc(alias)
+}
+
+// ModelAlias implements ModelAliasGetter.
+func (c *StubStore) ModelAlias(alias string) (string, string, error) {
+	c.MethodCall(c, "ModelAlias", alias)
+	return c.ModelAliasFunc(alias)
+}
+
+// AllModelAliases implements ModelAliasGetter.
+func (c *StubStore) AllModelAliases() (map[string]jujuclient.ModelAlias, error) {
+	c.MethodCall(c, "AllModelAliases")
+	return c.AllModelAliasesFunc()
+}
@@ -0,0 +1,62 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+// JujuModelAliasesPath is the location where model aliases are
+// expected to be found.
+func JujuModelAliasesPath() string {
+	return osenv.JujuXDGDataHomePath("model-aliases.yaml")
+}
+
+// ReadModelAliasesFile loads all model aliases defined in a given
+// file. If the file is not found, it is not an error.
+func ReadModelAliasesFile(file string) (map[string]ModelAlias, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	aliases, err := ParseModelAliases(data)
+	if err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// WriteModelAliasesFile marshals to YAML the given model aliases and
+// writes them to the model aliases file.
+func WriteModelAliasesFile(aliases map[string]ModelAlias) error {
+	data, err := yaml.Marshal(modelAliasesCollection{aliases})
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal model aliases")
+	}
+	return utils.AtomicWriteFile(JujuModelAliasesPath(), data, os.FileMode(0600))
+}
+
+// ParseModelAliases parses the given YAML bytes into model aliases.
+func ParseModelAliases(data []byte) (map[string]ModelAlias, error) {
+	var result modelAliasesCollection
+	err := yaml.Unmarshal(data, &result)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal model aliases")
+	}
+	return result.Aliases, nil
+}
+
+type modelAliasesCollection struct {
+	Aliases map[string]ModelAlias `yaml:"aliases"`
+}
@@ -0,0 +1,92 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"io/ioutil"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ModelAliasesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store jujuclient.ClientStore
+}
+
+var _ = gc.Suite(&ModelAliasesSuite{})
+
+const testModelAliasesYAML = `
+aliases:
+  web:
+    controller: mallards
+    model: admin@local/my-model
+`
+
+func (s *ModelAliasesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewFileClientStore()
+	writeTestControllersFile(c)
+	err := ioutil.WriteFile(jujuclient.JujuModelAliasesPath(), []byte(testModelAliasesYAML), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelAliasesSuite) TestModelAlias(c *gc.C) {
+	controllerName, modelName, err := s.store.ModelAlias("web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerName, gc.Equals, "mallards")
+	c.Assert(modelName, gc.Equals, "admin@local/my-model")
+}
+
+func (s *ModelAliasesSuite) TestModelAliasNotFound(c *gc.C) {
+	_, _, err := s.store.ModelAlias("db")
+	c.Assert(err, gc.ErrorMatches, "model alias db not found")
+}
+
+func (s *ModelAliasesSuite) TestModelAliasInvalid(c *gc.C) {
+	_, _, err := s.store.ModelAlias("mallards:web")
+	c.Assert(err, gc.ErrorMatches, `model alias "mallards:web" not valid`)
+}
+
+func (s *ModelAliasesSuite) TestSetModelAlias(c *gc.C) {
+	err := s.store.SetModelAlias("db", "ctrl", "bob@local/db")
+	c.Assert(err, jc.ErrorIsNil)
+	aliases, err := s.store.AllModelAliases()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aliases, jc.DeepEquals, map[string]jujuclient.ModelAlias{
+		"web": {Controller: "mallards", Model: "admin@local/my-model"},
+		"db":  {Controller: "ctrl", Model: "bob@local/db"},
+	})
+}
+
+func (s *ModelAliasesSuite) TestSetModelAliasUnqualifiedModel(c *gc.C) {
+	err := s.store.SetModelAlias("db", "ctrl", "db")
+	c.Assert(err, gc.ErrorMatches, `validating model name "db": unqualified model name "db" not valid`)
+}
+
+func (s *ModelAliasesSuite) TestRemoveModelAlias(c *gc.C) {
+	err := s.store.RemoveModelAlias("web")
+	c.Assert(err, jc.ErrorIsNil)
+	aliases, err := s.store.AllModelAliases()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aliases, gc.HasLen, 0)
+
+	err = s.store.RemoveModelAlias("web")
+	c.Assert(err, gc.ErrorMatches, "model alias web not found")
+}
+
+func (s *ModelAliasesSuite) TestRemoveControllerRemovesAliases(c *gc.C) {
+	err := s.store.SetModelAlias("db", "ctrl", "bob@local/db")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.RemoveController("mallards")
+	c.Assert(err, jc.ErrorIsNil)
+	aliases, err := s.store.AllModelAliases()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aliases, jc.DeepEquals, map[string]jujuclient.ModelAlias{
+		"db": {Controller: "ctrl", Model: "bob@local/db"},
+	})
+}
@@ -34,6 +34,13 @@ func (s *ModelValidationSuite) TestValidateModelName(c *gc.C) {
 	c.Assert(jujuclient.ValidateModelName("!/foo"), gc.ErrorMatches, `validating model name "!/foo": user name "!" not valid`)
 }
 
+func (s *ModelValidationSuite) TestValidateModelAlias(c *gc.C) {
+	c.Assert(jujuclient.ValidateModelAlias("web"), jc.ErrorIsNil)
+	c.Assert(jujuclient.ValidateModelAlias(""), gc.ErrorMatches, `empty model alias not valid`)
+	c.Assert(jujuclient.ValidateModelAlias("ctrl:web"), gc.ErrorMatches, `model alias "ctrl:web" not valid`)
+	c.Assert(jujuclient.ValidateModelAlias("bob/web"), gc.ErrorMatches, `model alias "bob/web" not valid`)
+}
+
 func (s *ModelValidationSuite) TestValidateModelDetailsNoModelUUID(c *gc.C) {
 	s.model.ModelUUID = ""
 	s.assertValidateModelDetailsFails(c, "missing uuid, model details not valid")
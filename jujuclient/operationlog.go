@@ -0,0 +1,221 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mutex"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+// JujuOperationLogPath is the location of the log in which juju
+// commands, and the API calls they make, are recorded.
+func JujuOperationLogPath() string {
+	return osenv.JujuXDGDataHomePath("operations.log")
+}
+
+// OperationCall describes an API call made while running a command.
+type OperationCall struct {
+	Facade    string
+	Version   int
+	Id        string
+	Method    string
+	ModelUUID string
+
+	// Error holds the error returned by the call, if any.
+	Error string
+}
+
+// OperationLog records a single juju command, and the API calls it
+// makes, so that they can be appended to an operation log.
+//
+// Each entry in the log is written as a shell script fragment: the
+// command is replayed by running the log (or part of it) with a shell,
+// while the API calls and the exit status appear as comments. The
+// arguments to API calls are not recorded, as they may hold secrets;
+// command arguments which may hold secrets, such as registration
+// tokens and password settings, are replaced with redactedArg, so
+// commands which take them must be edited before they are replayed.
+type OperationLog struct {
+	path  string
+	dir   string
+	args  []string
+	start time.Time
+
+	mu    sync.Mutex
+	calls []OperationCall
+}
+
+// NewOperationLog returns an OperationLog that records the command with
+// the given arguments, run in the given directory at the given time, to
+// the log file at the given path.
+func NewOperationLog(path, dir string, args []string, start time.Time) *OperationLog {
+	return &OperationLog{
+		path:  path,
+		dir:   dir,
+		args:  args,
+		start: start,
+	}
+}
+
+// AddCall records an API call made by the command. It is safe to call
+// concurrently.
+func (l *OperationLog) AddCall(call OperationCall) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, call)
+}
+
+// Write appends the command, the API calls recorded for it, and the
+// given exit status to the log file, creating the file if necessary.
+func (l *OperationLog) Write(exitCode int) error {
+	releaser, err := acquireOperationLogLock(filepath.Dir(l.path))
+	if err != nil {
+		return errors.Annotate(err, "cannot lock operation log")
+	}
+	defer releaser.Release()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(l.entry(exitCode)); err != nil {
+		return errors.Annotate(err, "cannot write operation log")
+	}
+	return errors.Trace(f.Close())
+}
+
+func (l *OperationLog) entry(exitCode int) []byte {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s\n", l.start.UTC().Format(time.RFC3339))
+	if l.dir != "" {
+		fmt.Fprintf(&buf, "cd %s\n", utils.ShQuote(l.dir))
+	}
+	args := redactArgs(l.args)
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = utils.ShQuote(arg)
+	}
+	fmt.Fprintf(&buf, "juju %s\n", strings.Join(quoted, " "))
+	for _, call := range l.calls {
+		fmt.Fprintf(&buf, "#   %s(%d).%s", call.Facade, call.Version, call.Method)
+		if call.Id != "" {
+			fmt.Fprintf(&buf, " id %q", call.Id)
+		}
+		if call.ModelUUID != "" {
+			fmt.Fprintf(&buf, " [model %s]", call.ModelUUID)
+		}
+		if call.Error != "" {
+			// Keep multi-line errors within the comment.
+			fmt.Fprintf(&buf, " (error: %s)", strings.Replace(call.Error, "\n", " ", -1))
+		}
+		buf.WriteString("\n")
+	}
+	fmt.Fprintf(&buf, "# exit status %d\n\n", exitCode)
+	return buf.Bytes()
+}
+
+// redactedArg replaces command arguments which may hold secrets.
+const redactedArg = "REDACTED"
+
+// sensitiveWords are the words which, found in the name of a setting
+// or flag, mark its value as secret.
+var sensitiveWords = []string{"password", "secret", "token", "key"}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range sensitiveWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactArgs returns a copy of the given command arguments with the
+// values which may hold secrets replaced: the positional arguments of
+// "juju register", which include the registration token, the values
+// of key=value settings whose key is sensitive, and the values of
+// sensitive flags.
+func redactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	register := len(args) > 0 && args[0] == "register"
+	redactNext := false
+	for i, arg := range args {
+		switch {
+		case redactNext:
+			redacted[i] = redactedArg
+			redactNext = false
+		case strings.HasPrefix(arg, "-"):
+			name := strings.TrimLeft(arg, "-")
+			if j := strings.Index(name, "="); j >= 0 {
+				value := name[j+1:]
+				if isSensitive(name[:j]) {
+					value = redactedArg
+				} else {
+					value = redactSetting(value)
+				}
+				redacted[i] = arg[:len(arg)-len(name)] + name[:j+1] + value
+			} else {
+				redacted[i] = arg
+				redactNext = isSensitive(name)
+			}
+		case register && i > 0:
+			redacted[i] = redactedArg
+		default:
+			redacted[i] = redactSetting(arg)
+		}
+	}
+	return redacted
+}
+
+// redactSetting replaces the value of a key=value setting whose key is
+// sensitive.
+func redactSetting(arg string) string {
+	i := strings.Index(arg, "=")
+	if i < 0 || !isSensitive(arg[:i]) {
+		return arg
+	}
+	return arg[:i+1] + redactedArg
+}
+
+// operationLogLockName returns the name of the lock which guards the
+// operation log in the given directory, so that processes writing to
+// logs in different data directories do not contend for the same lock.
+func operationLogLockName(dir string) string {
+	sum := sha256.Sum256([]byte(dir))
+	return fmt.Sprintf("oplog-lock-%x", sum[:8])
+}
+
+func acquireOperationLogLock(dir string) (mutex.Releaser, error) {
+	spec := mutex.Spec{
+		Name:    operationLogLockName(dir),
+		Clock:   clock.WallClock,
+		Delay:   20 * time.Millisecond,
+		Timeout: lockTimeout,
+	}
+	releaser, err := mutex.Acquire(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return releaser, nil
+}
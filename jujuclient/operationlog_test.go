@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type OperationLogSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&OperationLogSuite{})
+
+var operationLogStart = time.Date(2016, 10, 1, 12, 30, 0, 0, time.UTC)
+
+func (s *OperationLogSuite) TestJujuOperationLogPath(c *gc.C) {
+	c.Assert(jujuclient.JujuOperationLogPath(), gc.Equals, osenv.JujuXDGDataHomePath("operations.log"))
+}
+
+func (s *OperationLogSuite) TestWrite(c *gc.C) {
+	path := jujuclient.JujuOperationLogPath()
+	log := jujuclient.NewOperationLog(path, "/home/bob's", []string{"deploy", "mysql", "--config", "a b"}, operationLogStart)
+	log.AddCall(jujuclient.OperationCall{
+		Facade:    "Application",
+		Version:   1,
+		Method:    "Deploy",
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	})
+	log.AddCall(jujuclient.OperationCall{
+		Facade:  "Client",
+		Version: 1,
+		Id:      "machine-0",
+		Method:  "FullStatus",
+		Error:   "boom\nbang",
+	})
+	err := log.Write(1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+# 2016-10-01T12:30:00Z
+cd '/home/bob'"'"'s'
+juju 'deploy' 'mysql' '--config' 'a b'
+#   Application(1).Deploy [model deadbeef-0bad-400d-8000-4b1d0d06f00d]
+#   Client(1).FullStatus id "machine-0" (error: boom bang)
+# exit status 1
+
+`[1:])
+
+	info, err := os.Stat(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+}
+
+func (s *OperationLogSuite) TestWriteRedactsSecrets(c *gc.C) {
+	path := jujuclient.JujuOperationLogPath()
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{{
+		args:   []string{"register", "MFATA3JvZDAnExMxMDQuMTU0LjQyLjQ0OjE3MDcwExAxMC4xMjguMC4yOjE3MDcwBCBEFCaXerhNImkKKabuX5ULWf2Bp4AzPNJEbXVWgraLrAA="},
+		expect: "juju 'register' 'REDACTED'\n",
+	}, {
+		args:   []string{"config", "mysql", "root-password=hunter2", "dataset-size=80%"},
+		expect: "juju 'config' 'mysql' 'root-password=REDACTED' 'dataset-size=80%'\n",
+	}, {
+		args:   []string{"bootstrap", "--config", "admin-secret=s3cret", "--config=access-key=AKIA", "aws"},
+		expect: "juju 'bootstrap' '--config' 'admin-secret=REDACTED' '--config=access-key=REDACTED' 'aws'\n",
+	}, {
+		args:   []string{"login", "--token", "abc", "--token=def", "-c", "ctrl"},
+		expect: "juju 'login' '--token' 'REDACTED' '--token=REDACTED' '-c' 'ctrl'\n",
+	}} {
+		c.Logf("test %d", i)
+		err := os.RemoveAll(path)
+		c.Assert(err, jc.ErrorIsNil)
+		err = jujuclient.NewOperationLog(path, "", test.args, operationLogStart).Write(0)
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := ioutil.ReadFile(path)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(data), jc.Contains, test.expect)
+	}
+}
+
+func (s *OperationLogSuite) TestLockNamePerDataDir(c *gc.C) {
+	name := jujuclient.OperationLogLockName(c.MkDir())
+	c.Assert(jujuclient.OperationLogLockName(c.MkDir()), gc.Not(gc.Equals), name)
+}
+
+func (s *OperationLogSuite) TestWriteAppends(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "logs", "operations.log")
+	err := jujuclient.NewOperationLog(path, "", []string{"status"}, operationLogStart).Write(0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuclient.NewOperationLog(path, "", []string{"models"}, operationLogStart.Add(time.Minute)).Write(0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+# 2016-10-01T12:30:00Z
+juju 'status'
+# exit status 0
+
+# 2016-10-01T12:31:00Z
+juju 'models'
+# exit status 0
+
+`[1:])
+}
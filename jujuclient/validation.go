@@ -4,8 +4,13 @@
 package jujuclient
 
 import (
+	"net/url"
+	"strings"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cert"
 )
 
 // ValidateControllerDetails ensures that given controller details are valid.
@@ -16,6 +21,23 @@ func ValidateControllerDetails(details ControllerDetails) error {
 	if details.CACert == "" {
 		return errors.NotValidf("missing ca-cert, controller details")
 	}
+	if details.Proxy != "" {
+		proxyURL, err := url.Parse(details.Proxy)
+		if err != nil {
+			return errors.NotValidf("proxy %q, controller details", details.Proxy)
+		}
+		if proxyURL.Scheme != "socks5" && proxyURL.Scheme != "http" {
+			return errors.NotValidf("proxy scheme %q, controller details", proxyURL.Scheme)
+		}
+	}
+	for _, caCert := range details.ExtraCACerts {
+		if _, err := cert.ParseCert(caCert); err != nil {
+			return errors.NotValidf("extra-ca-certs, controller details")
+		}
+	}
+	if details.DialTimeout < 0 {
+		return errors.NotValidf("negative dial-timeout, controller details")
+	}
 	return nil
 }
 
@@ -66,6 +88,19 @@ func ValidateModelName(name string) error {
 	return nil
 }
 
+// ValidateModelAlias validates the given model alias. Aliases may
+// not contain ":" or "/", so that they cannot be confused with
+// controller- or owner-qualified model names.
+func ValidateModelAlias(alias string) error {
+	if alias == "" {
+		return errors.NotValidf("empty model alias")
+	}
+	if strings.ContainsAny(alias, ":/") {
+		return errors.NotValidf("model alias %q", alias)
+	}
+	return nil
+}
+
 // ValidateAccountName validates the given account name.
 func ValidateAccountName(name string) error {
 	// An account name is a domain-qualified user, e.g. bob@local.
@@ -15,7 +15,9 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/tools"
@@ -107,6 +109,11 @@ type UploadBinariesConfig struct {
 	Tools           map[version.Binary]string
 	ToolsDownloader ToolsDownloader
 	ToolsUploader   ToolsUploader
+
+	// Progress, if set, is called with the number of charms and
+	// tools still to be uploaded, before the first upload and after
+	// each one completes.
+	Progress func(remaining int)
 }
 
 // Validate makes sure that all the config values are non-nil.
@@ -132,10 +139,21 @@ func UploadBinaries(config UploadBinariesConfig) error {
 	if err := config.Validate(); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadCharms(config); err != nil {
+	remaining := len(config.Charms) + len(config.Tools)
+	reportProgress := func() {
+		if config.Progress != nil {
+			config.Progress(remaining)
+		}
+	}
+	uploaded := func() {
+		remaining--
+		reportProgress()
+	}
+	reportProgress()
+	if err := uploadCharms(config, uploaded); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadTools(config); err != nil {
+	if err := uploadTools(config, uploaded); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
@@ -165,7 +183,7 @@ func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err er
 	return tempFile, rmTempFile, nil
 }
 
-func uploadCharms(config UploadBinariesConfig) error {
+func uploadCharms(config UploadBinariesConfig, uploaded func()) error {
 	for _, charmUrl := range config.Charms {
 		logger.Debugf("sending charm %s to target", charmUrl)
 
@@ -189,11 +207,12 @@ func uploadCharms(config UploadBinariesConfig) error {
 		if _, err := config.CharmUploader.UploadCharm(curl, content); err != nil {
 			return errors.Annotate(err, "cannot upload charm")
 		}
+		uploaded()
 	}
 	return nil
 }
 
-func uploadTools(config UploadBinariesConfig) error {
+func uploadTools(config UploadBinariesConfig, uploaded func()) error {
 	for v, uri := range config.Tools {
 		logger.Debugf("sending tools to target: %s", v)
 
@@ -212,6 +231,7 @@ func uploadTools(config UploadBinariesConfig) error {
 		if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
 			return errors.Annotate(err, "cannot upload tools")
 		}
+		uploaded()
 	}
 	return nil
 }
@@ -234,3 +254,47 @@ func Precheck(backend PrecheckBackend) error {
 	}
 	return nil
 }
+
+// TargetPrecheckBackend is implemented by *state.State but defined as
+// an interface for easier testing.
+type TargetPrecheckBackend interface {
+	ControllerConfig() (controller.Config, error)
+	ControllerUsage() (state.ControllerUsage, error)
+}
+
+// TargetPrecheck checks that the target controller has the capacity
+// to host a migrated model with the given usage. It returns a
+// shortfall for each resource whose configured soft limit would be
+// exceeded by importing the model.
+func TargetPrecheck(backend TargetPrecheckBackend, incoming coremigration.ModelUsage) ([]coremigration.CapacityShortfall, error) {
+	cfg, err := backend.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving controller config")
+	}
+	usage, err := backend.ControllerUsage()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving controller usage")
+	}
+	var shortfalls []coremigration.CapacityShortfall
+	for _, resource := range []struct {
+		name     string
+		attr     string
+		incoming int
+		usage    int
+	}{
+		{"models", controller.SoftLimitModels, 1, usage.Models},
+		{"machines", controller.SoftLimitMachines, incoming.Machines, usage.Machines},
+		{"units", controller.SoftLimitUnits, incoming.Units, usage.Units},
+	} {
+		limit := cfg.SoftLimit(resource.attr)
+		if limit > 0 && resource.usage+resource.incoming > limit {
+			shortfalls = append(shortfalls, coremigration.CapacityShortfall{
+				Resource: resource.name,
+				Incoming: resource.incoming,
+				Usage:    resource.usage,
+				Limit:    limit,
+			})
+		}
+	}
+	return shortfalls, nil
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
@@ -138,6 +139,32 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 	c.Assert(uploader.tools, jc.DeepEquals, toolsMap)
 }
 
+func (s *ImportSuite) TestBinariesMigrationProgress(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{
+		charms: make(map[string]string),
+		tools:  make(map[version.Binary]string),
+	}
+
+	var progress []int
+	config := migration.UploadBinariesConfig{
+		Charms:          []string{"local:trusty/magic", "cs:trusty/postgresql-42"},
+		CharmDownloader: downloader,
+		CharmUploader:   uploader,
+		Tools: map[version.Binary]string{
+			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
+		},
+		ToolsDownloader: downloader,
+		ToolsUploader:   uploader,
+		Progress: func(remaining int) {
+			progress = append(progress, remaining)
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress, jc.DeepEquals, []int{3, 2, 1, 0})
+}
+
 type fakeDownloader struct {
 	charms []string
 	uris   []string
@@ -237,6 +264,79 @@ func (f *fakePrecheckBackend) NeedsCleanup() (bool, error) {
 	return f.cleanupNeeded, f.cleanupError
 }
 
+// Assert that *state.State implements the TargetPrecheckBackend
+var _ migration.TargetPrecheckBackend = (*state.State)(nil)
+
+func (*PrecheckSuite) TestTargetPrecheckNoLimits(c *gc.C) {
+	backend := &fakeTargetPrecheckBackend{
+		usage: state.ControllerUsage{Models: 100, Machines: 1000, Units: 5000},
+	}
+	shortfalls, err := migration.TargetPrecheck(backend, coremigration.ModelUsage{Machines: 10, Units: 20})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(shortfalls, gc.HasLen, 0)
+}
+
+func (*PrecheckSuite) TestTargetPrecheckWithinLimits(c *gc.C) {
+	backend := &fakeTargetPrecheckBackend{
+		config: controller.Config{
+			controller.SoftLimitModels:   3,
+			controller.SoftLimitMachines: 10,
+			controller.SoftLimitUnits:    20,
+		},
+		usage: state.ControllerUsage{Models: 2, Machines: 5, Units: 10},
+	}
+	shortfalls, err := migration.TargetPrecheck(backend, coremigration.ModelUsage{Machines: 5, Units: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(shortfalls, gc.HasLen, 0)
+}
+
+func (*PrecheckSuite) TestTargetPrecheckExceedsLimits(c *gc.C) {
+	backend := &fakeTargetPrecheckBackend{
+		config: controller.Config{
+			controller.SoftLimitModels:   3,
+			controller.SoftLimitMachines: 10,
+			controller.SoftLimitUnits:    20,
+		},
+		usage: state.ControllerUsage{Models: 2, Machines: 8, Units: 10},
+	}
+	shortfalls, err := migration.TargetPrecheck(backend, coremigration.ModelUsage{Machines: 3, Units: 11})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(shortfalls, jc.DeepEquals, []coremigration.CapacityShortfall{{
+		Resource: "machines",
+		Incoming: 3,
+		Usage:    8,
+		Limit:    10,
+	}, {
+		Resource: "units",
+		Incoming: 11,
+		Usage:    10,
+		Limit:    20,
+	}})
+	c.Check(shortfalls[0].String(), gc.Equals, "machines limit is 10, with 8 in use; cannot add 3")
+}
+
+func (*PrecheckSuite) TestTargetPrecheckUsageError(c *gc.C) {
+	backend := &fakeTargetPrecheckBackend{
+		usageErr: errors.New("boom"),
+	}
+	_, err := migration.TargetPrecheck(backend, coremigration.ModelUsage{})
+	c.Assert(err, gc.ErrorMatches, "retrieving controller usage: boom")
+}
+
+type fakeTargetPrecheckBackend struct {
+	config   controller.Config
+	usage    state.ControllerUsage
+	usageErr error
+}
+
+func (f *fakeTargetPrecheckBackend) ControllerConfig() (controller.Config, error) {
+	return f.config, nil
+}
+
+func (f *fakeTargetPrecheckBackend) ControllerUsage() (state.ControllerUsage, error) {
+	return f.usage, f.usageErr
+}
+
 type InternalSuite struct {
 	testing.BaseSuite
 }
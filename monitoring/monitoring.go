@@ -0,0 +1,38 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package monitoring holds the registry of metrics which describe the
+// operation of a controller. Packages which want their metrics exposed
+// by the API server register them here, usually from an init function;
+// the API server serves all registered metrics in the Prometheus
+// exposition format.
+package monitoring
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace is the namespace in which all controller metrics are
+// named, so that they are reported as "juju_<subsystem>_<name>".
+const Namespace = "juju"
+
+// MustRegister registers the given collectors with the registry. It
+// panics if any of them cannot be registered, which happens when a
+// collector describes a metric that is already registered.
+func MustRegister(collectors ...prometheus.Collector) {
+	prometheus.MustRegister(collectors...)
+}
+
+// Unregister removes the given collector from the registry, and
+// reports whether it was registered.
+func Unregister(collector prometheus.Collector) bool {
+	return prometheus.Unregister(collector)
+}
+
+// Handler returns an http.Handler which serves the current values of
+// all registered metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return prometheus.UninstrumentedHandler()
+}
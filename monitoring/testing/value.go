@@ -0,0 +1,26 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+)
+
+// Value returns the current value of the given counter or gauge.
+func Value(c *gc.C, metric prometheus.Metric) float64 {
+	var m dto.Metric
+	err := metric.Write(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	switch {
+	case m.Counter != nil:
+		return m.Counter.GetValue()
+	case m.Gauge != nil:
+		return m.Gauge.GetValue()
+	}
+	c.Fatalf("metric %v is neither a counter nor a gauge", metric.Desc())
+	return 0
+}
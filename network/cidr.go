@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"bytes"
+	"net"
+	"sort"
+)
+
+// MergeCIDRs returns the smallest set of CIDRs covering the same
+// addresses as the given ones. Duplicates and CIDRs contained in
+// another are dropped, and pairs of sibling CIDRs are replaced by
+// their parent. The valid CIDRs are returned sorted and in canonical
+// form, followed by any invalid ones in their original order.
+func MergeCIDRs(cidrs []string) []string {
+	var nets []*net.IPNet
+	var invalid []string
+	seenInvalid := make(map[string]bool)
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			if !seenInvalid[cidr] {
+				seenInvalid[cidr] = true
+				invalid = append(invalid, cidr)
+			}
+			continue
+		}
+		nets = append(nets, ipNet)
+	}
+	for {
+		nets = dropContainedNets(nets)
+		var merged bool
+		if nets, merged = mergeSiblingNets(nets); !merged {
+			break
+		}
+	}
+	var result []string
+	for _, ipNet := range nets {
+		result = append(result, ipNet.String())
+	}
+	return append(result, invalid...)
+}
+
+type ipNetSlice []*net.IPNet
+
+func (s ipNetSlice) Len() int      { return len(s) }
+func (s ipNetSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ipNetSlice) Less(i, j int) bool {
+	if len(s[i].IP) != len(s[j].IP) {
+		return len(s[i].IP) < len(s[j].IP)
+	}
+	if cmp := bytes.Compare(s[i].IP, s[j].IP); cmp != 0 {
+		return cmp < 0
+	}
+	onesI, _ := s[i].Mask.Size()
+	onesJ, _ := s[j].Mask.Size()
+	return onesI < onesJ
+}
+
+// dropContainedNets sorts nets, and returns them without those which
+// are contained in another.
+func dropContainedNets(nets []*net.IPNet) []*net.IPNet {
+	sort.Sort(ipNetSlice(nets))
+	var result []*net.IPNet
+	for _, ipNet := range nets {
+		if len(result) > 0 {
+			// Sorting guarantees that a containing network comes
+			// before all of the networks it contains.
+			last := result[len(result)-1]
+			if len(last.IP) == len(ipNet.IP) && last.Contains(ipNet.IP) {
+				continue
+			}
+		}
+		result = append(result, ipNet)
+	}
+	return result
+}
+
+// mergeSiblingNets replaces each pair of sibling networks in the
+// sorted nets with their parent, and reports whether any were merged.
+func mergeSiblingNets(nets []*net.IPNet) ([]*net.IPNet, bool) {
+	var result []*net.IPNet
+	merged := false
+	for i := 0; i < len(nets); i++ {
+		if i+1 < len(nets) {
+			if parent := parentNet(nets[i]); parent != nil && parent.String() == parentNetString(nets[i+1]) {
+				result = append(result, parent)
+				merged = true
+				i++
+				continue
+			}
+		}
+		result = append(result, nets[i])
+	}
+	return result, merged
+}
+
+// parentNet returns the network one bit shorter than ipNet which
+// contains it, or nil if ipNet covers the whole address space.
+func parentNet(ipNet *net.IPNet) *net.IPNet {
+	ones, bits := ipNet.Mask.Size()
+	if ones == 0 {
+		return nil
+	}
+	mask := net.CIDRMask(ones-1, bits)
+	return &net.IPNet{IP: ipNet.IP.Mask(mask), Mask: mask}
+}
+
+func parentNetString(ipNet *net.IPNet) string {
+	if parent := parentNet(ipNet); parent != nil {
+		return parent.String()
+	}
+	return ""
+}
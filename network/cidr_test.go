@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type CIDRSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&CIDRSuite{})
+
+func (*CIDRSuite) TestMergeCIDRs(c *gc.C) {
+	testCases := []struct {
+		about    string
+		cidrs    []string
+		expected []string
+	}{{
+		"no CIDRs",
+		nil,
+		nil,
+	}, {
+		"single CIDR",
+		[]string{"10.0.0.0/8"},
+		[]string{"10.0.0.0/8"},
+	}, {
+		"non-canonical CIDR",
+		[]string{"10.1.2.3/8"},
+		[]string{"10.0.0.0/8"},
+	}, {
+		"duplicates",
+		[]string{"10.0.0.0/8", "10.0.0.0/8"},
+		[]string{"10.0.0.0/8"},
+	}, {
+		"contained CIDRs",
+		[]string{"10.1.0.0/16", "10.0.0.0/8", "10.2.3.0/24"},
+		[]string{"10.0.0.0/8"},
+	}, {
+		"sibling CIDRs",
+		[]string{"192.168.1.0/24", "192.168.0.0/24"},
+		[]string{"192.168.0.0/23"},
+	}, {
+		"cascading siblings",
+		[]string{"10.0.3.0/24", "10.0.0.0/24", "10.0.2.0/24", "10.0.1.0/24"},
+		[]string{"10.0.0.0/22"},
+	}, {
+		"adjacent but not siblings",
+		[]string{"10.0.1.0/24", "10.0.2.0/24"},
+		[]string{"10.0.1.0/24", "10.0.2.0/24"},
+	}, {
+		"disjoint CIDRs are sorted",
+		[]string{"192.168.0.0/16", "10.0.0.0/8"},
+		[]string{"10.0.0.0/8", "192.168.0.0/16"},
+	}, {
+		"whole address space",
+		[]string{"0.0.0.0/1", "128.0.0.0/1", "10.0.0.0/8"},
+		[]string{"0.0.0.0/0"},
+	}, {
+		"IPv4 and IPv6",
+		[]string{"2001:db8::/33", "10.0.0.0/8", "2001:db8:8000::/33"},
+		[]string{"10.0.0.0/8", "2001:db8::/32"},
+	}, {
+		"invalid CIDRs are kept",
+		[]string{"bad", "10.0.0.0/8", "worse", "bad"},
+		[]string{"10.0.0.0/8", "bad", "worse"},
+	}}
+	for i, t := range testCases {
+		c.Logf("test %d: %s", i, t.about)
+		c.Check(network.MergeCIDRs(t.cidrs), jc.DeepEquals, t.expected)
+	}
+}
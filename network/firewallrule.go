@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// WellKnownServiceType identifies a kind of ingress to the machines of
+// a model which may be restricted by a model-level firewall rule.
+type WellKnownServiceType string
+
+const (
+	// SSHRule restricts the sources from which the machines of the
+	// model may be reached on the SSH port.
+	SSHRule WellKnownServiceType = "ssh"
+)
+
+// WellKnownServices holds the services for which firewall rules may
+// be set.
+var WellKnownServices = []WellKnownServiceType{
+	SSHRule,
+}
+
+// Validate returns an error if the service type is not known.
+func (v WellKnownServiceType) Validate() error {
+	for _, known := range WellKnownServices {
+		if v == known {
+			return nil
+		}
+	}
+	return errors.NotValidf("well known service type %q", string(v))
+}
+
+// FirewallRule restricts ingress for a well-known service to the
+// given source CIDRs. A rule with no CIDRs allows ingress from any
+// address.
+type FirewallRule struct {
+	WellKnownService WellKnownServiceType
+	WhitelistCIDRs   []string
+}
+
+// Validate returns an error if the rule's service is not known, or if
+// any of its CIDRs is not valid.
+func (r FirewallRule) Validate() error {
+	if err := r.WellKnownService.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, cidr := range r.WhitelistCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// SourceCIDRs returns the CIDRs from which ingress is allowed by the
+// rule. If the rule has no whitelist, ingress is allowed from any
+// address.
+func (r FirewallRule) SourceCIDRs() []string {
+	if len(r.WhitelistCIDRs) == 0 {
+		return []string{"0.0.0.0/0"}
+	}
+	return r.WhitelistCIDRs
+}
+
+func (r FirewallRule) String() string {
+	return string(r.WellKnownService) + ": " + strings.Join(r.SourceCIDRs(), ",")
+}
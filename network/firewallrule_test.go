@@ -0,0 +1,48 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type FirewallRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&FirewallRuleSuite{})
+
+func (*FirewallRuleSuite) TestValidate(c *gc.C) {
+	rule := network.FirewallRule{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8", "2001:db8::/32"},
+	}
+	c.Assert(rule.Validate(), jc.ErrorIsNil)
+
+	rule.WellKnownService = "telnet"
+	err := rule.Validate()
+	c.Assert(err, gc.ErrorMatches, `well known service type "telnet" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	rule.WellKnownService = network.SSHRule
+	rule.WhitelistCIDRs = []string{"10.0.0.1"}
+	err = rule.Validate()
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.1" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*FirewallRuleSuite) TestSourceCIDRs(c *gc.C) {
+	rule := network.FirewallRule{WellKnownService: network.SSHRule}
+	c.Assert(rule.SourceCIDRs(), jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Assert(rule.String(), gc.Equals, "ssh: 0.0.0.0/0")
+
+	rule.WhitelistCIDRs = []string{"10.0.0.0/8", "192.168.0.0/16"}
+	c.Assert(rule.SourceCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Assert(rule.String(), gc.Equals, "ssh: 10.0.0.0/8,192.168.0.0/16")
+}
@@ -82,6 +82,31 @@ func SortPortRanges(portRanges []PortRange) {
 	sort.Sort(portRangeSlice(portRanges))
 }
 
+// MergePortRanges returns the smallest set of port ranges covering the
+// same ports as the given ones, by combining overlapping and adjacent
+// ranges of the same protocol. The result is sorted, and the given
+// slice is left untouched.
+func MergePortRanges(portRanges []PortRange) []PortRange {
+	if len(portRanges) == 0 {
+		return nil
+	}
+	sorted := make([]PortRange, len(portRanges))
+	copy(sorted, portRanges)
+	SortPortRanges(sorted)
+	result := []PortRange{sorted[0]}
+	for _, pr := range sorted[1:] {
+		last := &result[len(result)-1]
+		if pr.Protocol == last.Protocol && pr.FromPort <= last.ToPort+1 {
+			if pr.ToPort > last.ToPort {
+				last.ToPort = pr.ToPort
+			}
+			continue
+		}
+		result = append(result, pr)
+	}
+	return result
+}
+
 // CollapsePorts collapses a slice of ports into port ranges.
 //
 // NOTE(dimitern): This is deprecated and should be removed when
@@ -215,6 +215,48 @@ func (*PortRangeSuite) TestCollapsePorts(c *gc.C) {
 	}
 }
 
+func (*PortRangeSuite) TestMergePortRanges(c *gc.C) {
+	testCases := []struct {
+		about    string
+		ranges   []network.PortRange
+		expected []network.PortRange
+	}{{
+		"no ranges",
+		nil,
+		nil,
+	}, {
+		"single range",
+		[]network.PortRange{{80, 90, "tcp"}},
+		[]network.PortRange{{80, 90, "tcp"}},
+	}, {
+		"adjacent ranges",
+		[]network.PortRange{{82, 82, "tcp"}, {80, 80, "tcp"}, {81, 81, "tcp"}},
+		[]network.PortRange{{80, 82, "tcp"}},
+	}, {
+		"overlapping ranges",
+		[]network.PortRange{{80, 90, "tcp"}, {85, 100, "tcp"}, {88, 88, "tcp"}},
+		[]network.PortRange{{80, 100, "tcp"}},
+	}, {
+		"contained range",
+		[]network.PortRange{{80, 100, "tcp"}, {85, 90, "tcp"}},
+		[]network.PortRange{{80, 100, "tcp"}},
+	}, {
+		"disjoint ranges",
+		[]network.PortRange{{8080, 8080, "tcp"}, {80, 90, "tcp"}},
+		[]network.PortRange{{80, 90, "tcp"}, {8080, 8080, "tcp"}},
+	}, {
+		"different protocols",
+		[]network.PortRange{{80, 80, "tcp"}, {81, 81, "udp"}, {81, 90, "tcp"}, {80, 80, "udp"}},
+		[]network.PortRange{{80, 90, "tcp"}, {80, 81, "udp"}},
+	}}
+	for i, t := range testCases {
+		c.Logf("test %d: %s", i, t.about)
+		original := append([]network.PortRange(nil), t.ranges...)
+		c.Check(network.MergePortRanges(t.ranges), jc.DeepEquals, t.expected)
+		c.Check(t.ranges, jc.DeepEquals, original)
+	}
+}
+
 func (*PortRangeSuite) TestParsePortRange(c *gc.C) {
 	portRange, err := network.ParsePortRange("8000-8099/tcp")
 	c.Assert(err, jc.ErrorIsNil)
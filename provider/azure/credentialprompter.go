@@ -0,0 +1,196 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/arm/resources/subscriptions"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+// azureCLIClientId is the application ID of the Azure CLI. It is a
+// public client application, which any user may sign in to using the
+// device code flow.
+const azureCLIClientId = "04b07795-8ddb-461a-bbee-02f9e1bf7b46"
+
+// credentialPrompter is the environs.CredentialPrompter for Azure. The
+// user signs in to Azure with a web browser, using the device code
+// flow, so that the subscription and tenant IDs can be looked up
+// rather than entered by hand.
+type credentialPrompter struct {
+	provider *azureEnvironProvider
+}
+
+// PromptCredential is part of the environs.CredentialPrompter interface.
+func (p credentialPrompter) PromptCredential(
+	ctx environs.CredentialPromptContext, authType cloud.AuthType,
+) (*cloud.Credential, error) {
+	if authType != cloud.UserPassAuthType {
+		return nil, errors.NotSupportedf("interactive %q auth-type", authType)
+	}
+	token, err := p.deviceCodeSignIn(ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "signing in to Azure")
+	}
+	subscriptionId, err := p.selectSubscription(ctx, token)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tenantId, err := p.selectTenant(ctx, token)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	fmt.Fprintln(ctx.Out, `
+Juju authenticates to Azure as an Active Directory application, with a
+service principal which has access to the subscription. To create one,
+run "az ad sp create-for-rbac" and enter its application ID and password.
+`[1:])
+	attrs := map[string]string{
+		credAttrSubscriptionId: subscriptionId,
+		credAttrTenantId:       tenantId,
+	}
+	for _, attr := range []struct {
+		name     string
+		question string
+		hidden   bool
+	}{
+		{credAttrAppId, "Enter application ID", false},
+		{credAttrAppPassword, "Enter application password", true},
+	} {
+		for attrs[attr.name] == "" {
+			value, err := ctx.Prompt(attr.question, attr.hidden)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			attrs[attr.name] = strings.TrimSpace(value)
+		}
+	}
+	credential := cloud.NewCredential(cloud.UserPassAuthType, attrs)
+	return &credential, nil
+}
+
+// deviceCodeSignIn signs the user in to Azure using the device code
+// flow, returning a token for the resource manager endpoint.
+func (p credentialPrompter) deviceCodeSignIn(ctx environs.CredentialPromptContext) (*azure.Token, error) {
+	cloudEnv := azure.Environment{ActiveDirectoryEndpoint: ctx.Cloud.IdentityEndpoint}
+	oauthConfig, err := cloudEnv.OAuthConfigForTenant("common")
+	if err != nil {
+		return nil, errors.Annotate(err, "getting OAuth configuration")
+	}
+	client := p.newClient()
+	deviceCode, err := azure.InitiateDeviceAuth(
+		&client, *oauthConfig, azureCLIClientId, resourceManagerResourceId(ctx.Cloud),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fmt.Fprintln(ctx.Out, to.String(deviceCode.Message))
+	token, err := azure.WaitForUserCompletion(&client, deviceCode)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return token, nil
+}
+
+// selectSubscription returns the ID of the subscription to use. If
+// the user has access to more than one, they are asked to choose.
+func (p credentialPrompter) selectSubscription(
+	ctx environs.CredentialPromptContext, token *azure.Token,
+) (string, error) {
+	client := subscriptions.NewClientWithBaseURI(ctx.Cloud.Endpoint)
+	client.Client = p.newClient()
+	client.Authorizer = token
+	result, err := client.List()
+	if err != nil {
+		return "", errors.Annotate(err, "listing subscriptions")
+	}
+	var ids []string
+	names := make(map[string]string)
+	if result.Value != nil {
+		for _, subscription := range *result.Value {
+			id := to.String(subscription.SubscriptionID)
+			ids = append(ids, id)
+			names[id] = to.String(subscription.DisplayName)
+		}
+	}
+	var id string
+	switch len(ids) {
+	case 0:
+		return "", errors.New("no subscriptions found")
+	case 1:
+		id = ids[0]
+	default:
+		if id, err = ctx.Select("Select subscription", ids); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	fmt.Fprintf(ctx.Out, "Using subscription %s (%s).\n", id, names[id])
+	return id, nil
+}
+
+// selectTenant returns the ID of the Active Directory tenant to use. If
+// the user belongs to more than one, they are asked to choose.
+func (p credentialPrompter) selectTenant(
+	ctx environs.CredentialPromptContext, token *azure.Token,
+) (string, error) {
+	client := subscriptions.NewTenantsClientWithBaseURI(ctx.Cloud.Endpoint)
+	client.Client = p.newClient()
+	client.Authorizer = token
+	result, err := client.List()
+	if err != nil {
+		return "", errors.Annotate(err, "listing tenants")
+	}
+	var ids []string
+	if result.Value != nil {
+		for _, tenant := range *result.Value {
+			ids = append(ids, to.String(tenant.TenantID))
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", errors.New("no tenants found")
+	case 1:
+		fmt.Fprintf(ctx.Out, "Using tenant %s.\n", ids[0])
+		return ids[0], nil
+	}
+	return ctx.Select("Select tenant", ids)
+}
+
+func (p credentialPrompter) newClient() autorest.Client {
+	client := autorest.NewClientWithUserAgent("juju")
+	client.Sender = p.provider.config.Sender
+	return client
+}
+
+// ValidateCredential is part of the environs.CredentialPrompter interface.
+func (p credentialPrompter) ValidateCredential(spec environs.CloudSpec) error {
+	if err := validateCloudSpec(spec); err != nil {
+		return errors.Trace(err)
+	}
+	env := azureEnviron{provider: p.provider, cloud: spec}
+	if err := env.initEnviron(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(verifyCredentials(&env))
+}
+
+// resourceManagerResourceId returns the ID of the resource manager
+// resource, for which tokens are requested. Azure demands that the
+// URL end with a '/'.
+func resourceManagerResourceId(spec environs.CloudSpec) string {
+	resource := spec.Endpoint
+	if !strings.HasSuffix(resource, "/") {
+		resource += "/"
+	}
+	return resource
+}
@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/arm/resources/subscriptions"
+	"github.com/Azure/go-autorest/autorest/mocks"
+	"github.com/Azure/go-autorest/autorest/to"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/azure"
+	"github.com/juju/juju/provider/azure/internal/azuretesting"
+	"github.com/juju/juju/testing"
+)
+
+type credentialPrompterSuite struct {
+	testing.BaseSuite
+	prompter environs.CredentialPrompter
+	spec     environs.CloudSpec
+	sender   azuretesting.Senders
+	out      bytes.Buffer
+	prompts  []string
+	answers  []string
+}
+
+var _ = gc.Suite(&credentialPrompterSuite{})
+
+func (s *credentialPrompterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.prompter = azure.NewCredentialPrompter(newProvider(c, azure.ProviderConfig{
+		Sender: &s.sender,
+	}))
+	s.spec = environs.CloudSpec{
+		Type:             "azure",
+		Name:             "azure",
+		Region:           "westus",
+		Endpoint:         "https://api.azurestack.local",
+		IdentityEndpoint: "https://login.azurestack.local",
+		StorageEndpoint:  "https://storage.azurestack.local",
+	}
+	s.sender = nil
+	s.out.Reset()
+	s.prompts = nil
+	s.answers = nil
+}
+
+func (s *credentialPrompterSuite) promptContext() environs.CredentialPromptContext {
+	answer := func(prompt string) (string, error) {
+		s.prompts = append(s.prompts, prompt)
+		if len(s.answers) == 0 {
+			return "", fmt.Errorf("unexpected prompt %q", prompt)
+		}
+		answer := s.answers[0]
+		s.answers = s.answers[1:]
+		return answer, nil
+	}
+	return environs.CredentialPromptContext{
+		Cloud: s.spec,
+		Out:   &s.out,
+		Prompt: func(question string, hidden bool) (string, error) {
+			return answer(fmt.Sprintf("%s (hidden=%v)", question, hidden))
+		},
+		Select: func(question string, options []string) (string, error) {
+			return answer(fmt.Sprintf("%s %v", question, options))
+		},
+	}
+}
+
+func (s *credentialPrompterSuite) signInSenders(subscriptionIds ...string) azuretesting.Senders {
+	deviceCodeSender := azuretesting.NewSenderWithValue(map[string]string{
+		"device_code": "device-code",
+		"user_code":   "user-code",
+		"interval":    "0",
+		"expires_in":  "900",
+		"message":     "To sign in, enter the code user-code.",
+	})
+	deviceCodeSender.PathPattern = "/common/oauth2/devicecode"
+	tokenSender := tokenRefreshSender()
+	tokenSender.PathPattern = "/common/oauth2/token"
+
+	var subscriptionList []subscriptions.Subscription
+	for _, id := range subscriptionIds {
+		subscriptionList = append(subscriptionList, subscriptions.Subscription{
+			SubscriptionID: to.StringPtr(id),
+			DisplayName:    to.StringPtr("name-" + id),
+		})
+	}
+	subscriptionsSender := azuretesting.NewSenderWithValue(subscriptions.SubscriptionListResult{
+		Value: &subscriptionList,
+	})
+	subscriptionsSender.PathPattern = "/subscriptions"
+	tenantsSender := azuretesting.NewSenderWithValue(subscriptions.TenantListResult{
+		Value: &[]subscriptions.TenantIDDescription{{TenantID: to.StringPtr(fakeTenantId)}},
+	})
+	tenantsSender.PathPattern = "/tenants"
+	return azuretesting.Senders{deviceCodeSender, tokenSender, subscriptionsSender, tenantsSender}
+}
+
+func (s *credentialPrompterSuite) TestPromptCredential(c *gc.C) {
+	s.sender = s.signInSenders(fakeSubscriptionId)
+	s.answers = []string{"", fakeApplicationId, "opensezme"}
+	credential, err := s.prompter.PromptCredential(s.promptContext(), cloud.UserPassAuthType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, jc.DeepEquals, fakeUserPassCredential())
+	c.Assert(s.prompts, jc.DeepEquals, []string{
+		"Enter application ID (hidden=false)",
+		"Enter application ID (hidden=false)",
+		"Enter application password (hidden=true)",
+	})
+	c.Assert(s.out.String(), gc.Matches, `(?s)To sign in, enter the code user-code.
+Using subscription `+fakeSubscriptionId+` \(name-`+fakeSubscriptionId+`\).
+Using tenant `+fakeTenantId+`.
+.*`)
+}
+
+func (s *credentialPrompterSuite) TestPromptCredentialSelectSubscription(c *gc.C) {
+	s.sender = s.signInSenders("sub-one", fakeSubscriptionId)
+	s.answers = []string{fakeSubscriptionId, fakeApplicationId, "opensezme"}
+	credential, err := s.prompter.PromptCredential(s.promptContext(), cloud.UserPassAuthType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, jc.DeepEquals, fakeUserPassCredential())
+	c.Assert(s.prompts[0], gc.Equals, "Select subscription [sub-one "+fakeSubscriptionId+"]")
+}
+
+func (s *credentialPrompterSuite) TestPromptCredentialNoSubscriptions(c *gc.C) {
+	s.sender = s.signInSenders()
+	_, err := s.prompter.PromptCredential(s.promptContext(), cloud.UserPassAuthType)
+	c.Assert(err, gc.ErrorMatches, "no subscriptions found")
+}
+
+func (s *credentialPrompterSuite) TestPromptCredentialSignInFails(c *gc.C) {
+	sender := mocks.NewSender()
+	sender.AppendResponse(mocks.NewResponseWithStatus("bad request", http.StatusBadRequest))
+	s.sender = azuretesting.Senders{sender}
+	_, err := s.prompter.PromptCredential(s.promptContext(), cloud.UserPassAuthType)
+	c.Assert(err, gc.ErrorMatches, "signing in to Azure: .*Error occurred while handling response from the Device Endpoint.*")
+}
+
+func (s *credentialPrompterSuite) TestPromptCredentialNotSupported(c *gc.C) {
+	_, err := s.prompter.PromptCredential(s.promptContext(), cloud.AccessKeyAuthType)
+	c.Assert(err, gc.ErrorMatches, `interactive "access-key" auth-type not supported`)
+}
+
+func (s *credentialPrompterSuite) TestValidateCredential(c *gc.C) {
+	s.spec.Credential = fakeUserPassCredential()
+	s.sender = azuretesting.Senders{tokenRefreshSender()}
+	err := s.prompter.ValidateCredential(s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.sender, gc.HasLen, 0)
+}
+
+func (s *credentialPrompterSuite) TestValidateCredentialFails(c *gc.C) {
+	s.spec.Credential = fakeUserPassCredential()
+	sender := mocks.NewSender()
+	sender.AppendResponse(mocks.NewResponseWithStatus("unauthorized", http.StatusUnauthorized))
+	s.sender = azuretesting.Senders{sender}
+	err := s.prompter.ValidateCredential(s.spec)
+	c.Assert(err, gc.ErrorMatches, ".*/oauth2/token.* failed with unauthorized: StatusCode=401")
+}
@@ -144,17 +144,12 @@ func (env *azureEnviron) initEnviron() error {
 	}
 
 	// We want to create a service principal token for the resource
-	// manager endpoint. Azure demands that the URL end with a '/'.
-	resource := env.cloud.Endpoint
-	if !strings.HasSuffix(resource, "/") {
-		resource += "/"
-	}
-
+	// manager endpoint.
 	token, err := azure.NewServicePrincipalToken(
 		*oauthConfig,
 		appId,
 		appPassword,
-		resource,
+		resourceManagerResourceId(env.cloud),
 	)
 	if err != nil {
 		return errors.Annotate(err, "constructing service principal token")
@@ -480,10 +475,19 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 		env.config,
 	)
 	imageStream := env.config.ImageStream()
-	instanceTypes, err := env.getInstanceTypesLocked()
-	if err != nil {
-		env.mu.Unlock()
-		return nil, errors.Trace(err)
+	availabilityZonePolicy := env.config.AvailabilityZonePolicy()
+	var instanceTypes map[string]instances.InstanceType
+	if len(args.InstanceTypes) > 0 {
+		// Use the instance types cached by the controller,
+		// rather than listing them again.
+		instanceTypes = instanceTypesByName(args.InstanceTypes)
+	} else {
+		var err error
+		instanceTypes, err = env.getInstanceTypesLocked()
+		if err != nil {
+			env.mu.Unlock()
+			return nil, errors.Trace(err)
+		}
 	}
 	storageAccount, err := env.getStorageAccountLocked(false)
 	if err != nil {
@@ -586,6 +590,7 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 		vmTags, envTags,
 		instanceSpec, args.InstanceConfig,
 		args.DistributionGroup,
+		availabilityZonePolicy,
 		env.Instances,
 		apiPortPtr, internalNetworkSubnet,
 		storageAccount,
@@ -628,6 +633,7 @@ func createVirtualMachine(
 	instanceSpec *instances.InstanceSpec,
 	instanceConfig *instancecfg.InstanceConfig,
 	distributionGroupFunc func() ([]instance.Id, error),
+	availabilityZonePolicy string,
 	instancesFunc func([]instance.Id) ([]instance.Instance, error),
 	apiPort *int,
 	internalNetworkSubnet *network.Subnet,
@@ -660,14 +666,24 @@ func createVirtualMachine(
 		return compute.VirtualMachine{}, errors.Annotate(err, "creating network profile")
 	}
 
-	availabilitySetId, err := createAvailabilitySet(
-		callAPI, availabilitySetClient,
-		vmName, resourceGroup, location,
-		vmTags, envTags,
-		distributionGroupFunc, instancesFunc,
-	)
-	if err != nil {
-		return compute.VirtualMachine{}, errors.Annotate(err, "creating availability set")
+	// Availability sets spread their machines across fault and update
+	// domains, which is what Azure offers in place of availability
+	// zones. Machines are only placed in one if the model spreads the
+	// instances of each application.
+	var availabilitySet *compute.SubResource
+	if availabilityZonePolicy == config.ZoneSpread {
+		availabilitySetId, err := createAvailabilitySet(
+			callAPI, availabilitySetClient,
+			vmName, resourceGroup, location,
+			vmTags, envTags,
+			distributionGroupFunc, instancesFunc,
+		)
+		if err != nil {
+			return compute.VirtualMachine{}, errors.Annotate(err, "creating availability set")
+		}
+		availabilitySet = &compute.SubResource{
+			ID: to.StringPtr(availabilitySetId),
+		}
 	}
 
 	logger.Debugf("- creating virtual machine")
@@ -680,12 +696,10 @@ func createVirtualMachine(
 					instanceSpec.InstanceType.Name,
 				),
 			},
-			StorageProfile: storageProfile,
-			OsProfile:      osProfile,
-			NetworkProfile: networkProfile,
-			AvailabilitySet: &compute.SubResource{
-				ID: to.StringPtr(availabilitySetId),
-			},
+			StorageProfile:  storageProfile,
+			OsProfile:       osProfile,
+			NetworkProfile:  networkProfile,
+			AvailabilitySet: availabilitySet,
 		},
 	}
 	if err := callAPI(func() (autorest.Response, error) {
@@ -1344,13 +1358,32 @@ func (env *azureEnviron) getInstanceTypes() (map[string]instances.InstanceType,
 	return instanceTypes, nil
 }
 
-// getInstanceTypesLocked returns the instance types for Azure, by listing the
-// role sizes available to the subscription.
+// getInstanceTypesLocked returns the instance types for Azure, keyed by
+// name, listing them the first time it is called.
 func (env *azureEnviron) getInstanceTypesLocked() (map[string]instances.InstanceType, error) {
 	if env.instanceTypes != nil {
 		return env.instanceTypes, nil
 	}
+	instanceTypes, err := env.listInstanceTypes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env.instanceTypes = instanceTypesByName(instanceTypes)
+	return env.instanceTypes, nil
+}
+
+// InstanceTypes is specified in the environs.InstanceTypesFetcher interface.
+func (env *azureEnviron) InstanceTypes() ([]instances.InstanceType, error) {
+	instanceTypes, err := env.listInstanceTypes()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting instance types")
+	}
+	return instanceTypes, nil
+}
 
+// listInstanceTypes returns the instance types for Azure, by listing the
+// role sizes available to the subscription.
+func (env *azureEnviron) listInstanceTypes() ([]instances.InstanceType, error) {
 	location := env.location
 	client := compute.VirtualMachineSizesClient{env.compute}
 
@@ -1362,21 +1395,29 @@ func (env *azureEnviron) getInstanceTypesLocked() (map[string]instances.Instance
 	}); err != nil {
 		return nil, errors.Annotate(err, "listing VM sizes")
 	}
-	instanceTypes := make(map[string]instances.InstanceType)
+	var instanceTypes []instances.InstanceType
 	if result.Value != nil {
 		for _, size := range *result.Value {
-			instanceType := newInstanceType(size)
-			instanceTypes[instanceType.Name] = instanceType
-			// Create aliases for standard role sizes.
-			if strings.HasPrefix(instanceType.Name, "Standard_") {
-				instanceTypes[instanceType.Name[len("Standard_"):]] = instanceType
-			}
+			instanceTypes = append(instanceTypes, newInstanceType(size))
 		}
 	}
-	env.instanceTypes = instanceTypes
 	return instanceTypes, nil
 }
 
+// instanceTypesByName returns the given instance types keyed by name,
+// with aliases for the standard role sizes.
+func instanceTypesByName(instanceTypes []instances.InstanceType) map[string]instances.InstanceType {
+	byName := make(map[string]instances.InstanceType)
+	for _, instanceType := range instanceTypes {
+		byName[instanceType.Name] = instanceType
+		// Create aliases for standard role sizes.
+		if strings.HasPrefix(instanceType.Name, "Standard_") {
+			byName[instanceType.Name[len("Standard_"):]] = instanceType
+		}
+	}
+	return byName
+}
+
 // getInternalSubnetLocked queries the internal subnet for the environment.
 func (env *azureEnviron) getInternalSubnetLocked() (*network.Subnet, error) {
 	return getInternalSubnet(env.callAPI, env.network, env.resourceGroup)
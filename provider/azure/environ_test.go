@@ -584,6 +584,37 @@ func (s *environSuite) TestStartInstance(c *gc.C) {
 	c.Assert(availabilitySetName, gc.Equals, "juju")
 }
 
+func (s *environSuite) TestInstanceTypes(c *gc.C) {
+	env := s.openEnviron(c)
+	s.sender = azuretesting.Senders{s.vmSizesSender()}
+	instanceTypes, err := env.(environs.InstanceTypesFetcher).InstanceTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceTypes, gc.HasLen, 1)
+	c.Assert(instanceTypes[0].Name, gc.Equals, "Standard_D1")
+	c.Assert(instanceTypes[0].CpuCores, gc.Equals, uint64(1))
+	c.Assert(instanceTypes[0].Mem, gc.Equals, uint64(3584))
+}
+
+func (s *environSuite) TestStartInstanceCachedInstanceTypes(c *gc.C) {
+	env := s.openEnviron(c)
+	s.sender = azuretesting.Senders{s.vmSizesSender()}
+	instanceTypes, err := env.(environs.InstanceTypesFetcher).InstanceTypes()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The VM sizes are not listed again when the instance
+	// types cached by the controller are supplied.
+	s.sender = s.startInstanceSenders(false)[1:]
+	s.requests = nil
+	args := makeStartInstanceParams(c, s.controllerUUID, "quantal")
+	args.InstanceTypes = instanceTypes
+	result, err := env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.NotNil)
+	for _, req := range s.requests {
+		c.Assert(req.URL.Path, gc.Not(gc.Matches), ".*/vmSizes")
+	}
+}
+
 func (s *environSuite) TestStartInstanceWindowsMinRootDisk(c *gc.C) {
 	// The minimum OS disk size for Windows machines is 127GiB.
 	cons := constraints.MustParse("root-disk=44G")
@@ -718,6 +749,24 @@ func (s *environSuite) TestStartInstanceServiceAvailabilitySet(c *gc.C) {
 	c.Assert(availabilitySetName, gc.Equals, "mysql")
 }
 
+func (s *environSuite) TestStartInstancePackPolicyNoAvailabilitySet(c *gc.C) {
+	env := s.openEnviron(c, testing.Attrs{"availability-zone-policy": "pack"})
+	senders := s.startInstanceSenders(false)
+	// Drop the availability set sender, which precedes the
+	// virtual machine create and get senders.
+	n := len(senders)
+	s.sender = append(senders[:n-3], senders[n-2:]...)
+	s.requests = nil
+
+	_, err := env.StartInstance(makeStartInstanceParams(c, s.controllerUUID, "quantal"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 11)
+	c.Assert(s.requests[9].Method, gc.Equals, "PUT") // create VM
+	var virtualMachine compute.VirtualMachine
+	unmarshalRequestBody(c, s.requests[9], &virtualMachine)
+	c.Assert(virtualMachine.Properties.AvailabilitySet, gc.IsNil)
+}
+
 func (s *environSuite) assertStartInstanceRequests(c *gc.C, requests []*http.Request) startInstanceRequests {
 	// The values defined here are the *request* values. They lack IDs,
 	// Names (in most places), and ProvisioningStates. The values defined
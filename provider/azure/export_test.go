@@ -15,3 +15,7 @@ func ForceVolumeSourceTokenRefresh(vs storage.VolumeSource) error {
 func ForceTokenRefresh(env environs.Environ) error {
 	return env.(*azureEnviron).token.Refresh()
 }
+
+func NewCredentialPrompter(p environs.EnvironProvider) environs.CredentialPrompter {
+	return credentialPrompter{p.(*azureEnvironProvider)}
+}
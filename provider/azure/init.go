@@ -26,7 +26,7 @@ func NewProvider(config ProviderConfig) (environs.EnvironProvider, error) {
 }
 
 func init() {
-	environProvider, err := NewProvider(ProviderConfig{
+	environProvider, err := NewEnvironProvider(ProviderConfig{
 		NewStorageClient:            azurestorage.NewClient,
 		StorageAccountNameGenerator: RandomStorageAccountName,
 		RetryClock:                  &clock.WallClock,
@@ -36,6 +36,7 @@ func init() {
 	}
 
 	environs.RegisterProvider(providerType, environProvider)
+	environs.RegisterCredentialPrompter(providerType, credentialPrompter{environProvider})
 
 	// TODO(axw) register an image metadata data source that queries
 	// the Azure image registry, and introduce a way to disable the
@@ -6,7 +6,10 @@ package common
 import (
 	"sort"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 )
 
@@ -131,6 +134,58 @@ func AvailabilityZoneAllocations(env ZonedEnviron, group []instance.Id) ([]Avail
 	return zoneInstances, nil
 }
 
+// byPopulationDescendingThenName orders availability zones with the
+// most populated first; zones with the same population are ordered
+// by name.
+type byPopulationDescendingThenName []AvailabilityZoneInstances
+
+func (b byPopulationDescendingThenName) Len() int {
+	return len(b)
+}
+
+func (b byPopulationDescendingThenName) Less(i, j int) bool {
+	switch {
+	case len(b[i].Instances) > len(b[j].Instances):
+		return true
+	case len(b[i].Instances) == len(b[j].Instances):
+		return b[i].ZoneName < b[j].ZoneName
+	}
+	return false
+}
+
+func (b byPopulationDescendingThenName) Swap(i, j int) {
+	b[i], b[j] = b[j], b[i]
+}
+
+// PreferredAvailabilityZones returns the names of the available zones
+// in which an instance of the specified distribution group should be
+// started, in order of preference under the given availability zone
+// policy. With config.ZoneSpread, the zones holding fewest of the
+// group's instances come first; with config.ZonePack, those holding
+// most. Zones with the same population are ordered by name.
+//
+// As with AvailabilityZoneAllocations, an empty group stands for all
+// of the environment's instances.
+func PreferredAvailabilityZones(env ZonedEnviron, group []instance.Id, policy string) ([]string, error) {
+	switch policy {
+	case config.ZoneSpread, config.ZonePack, "":
+	default:
+		return nil, errors.NotValidf("availability zone policy %q", policy)
+	}
+	zoneInstances, err := internalAvailabilityZoneAllocations(env, group)
+	if err != nil {
+		return nil, err
+	}
+	if policy == config.ZonePack {
+		sort.Sort(byPopulationDescendingThenName(zoneInstances))
+	}
+	zoneNames := make([]string, len(zoneInstances))
+	for i, zone := range zoneInstances {
+		zoneNames[i] = zone.ZoneName
+	}
+	return zoneNames, nil
+}
+
 var internalAvailabilityZoneAllocations = AvailabilityZoneAllocations
 
 // DistributeInstances is a common function for implement the
@@ -254,3 +254,41 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 		c.Assert(eligible, jc.SameContents, test.eligible)
 	}
 }
+
+func (s *AvailabilityZoneSuite) TestPreferredAvailabilityZones(c *gc.C) {
+	s.PatchValue(common.InternalAvailabilityZoneAllocations, func(_ common.ZonedEnviron, group []instance.Id) ([]common.AvailabilityZoneInstances, error) {
+		c.Assert(group, gc.DeepEquals, []instance.Id{"i0", "i1", "i2"})
+		return []common.AvailabilityZoneInstances{{
+			ZoneName: "az1",
+		}, {
+			ZoneName:  "az0",
+			Instances: []instance.Id{"i0"},
+		}, {
+			ZoneName:  "az2",
+			Instances: []instance.Id{"i1", "i2"},
+		}}, nil
+	})
+	group := []instance.Id{"i0", "i1", "i2"}
+
+	zones, err := common.PreferredAvailabilityZones(&s.env, group, "spread")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, []string{"az1", "az0", "az2"})
+
+	zones, err = common.PreferredAvailabilityZones(&s.env, group, "pack")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, []string{"az2", "az0", "az1"})
+}
+
+func (s *AvailabilityZoneSuite) TestPreferredAvailabilityZonesInvalidPolicy(c *gc.C) {
+	_, err := common.PreferredAvailabilityZones(&s.env, nil, "scatter")
+	c.Assert(err, gc.ErrorMatches, `availability zone policy "scatter" not valid`)
+}
+
+func (s *AvailabilityZoneSuite) TestPreferredAvailabilityZonesErrors(c *gc.C) {
+	resultErr := fmt.Errorf("whatever")
+	s.PatchValue(common.InternalAvailabilityZoneAllocations, func(_ common.ZonedEnviron, group []instance.Id) ([]common.AvailabilityZoneInstances, error) {
+		return nil, resultErr
+	})
+	_, err := common.PreferredAvailabilityZones(&s.env, nil, "spread")
+	c.Assert(err, gc.Equals, resultErr)
+}
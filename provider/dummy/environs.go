@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -37,6 +38,7 @@ import (
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/environschema.v1"
@@ -168,20 +170,21 @@ type OpSubnets struct {
 }
 
 type OpStartInstance struct {
-	Env              string
-	MachineId        string
-	MachineNonce     string
-	PossibleTools    coretools.List
-	Instance         instance.Instance
-	Constraints      constraints.Value
-	SubnetsToZones   map[network.Id][]string
-	NetworkInfo      []network.InterfaceInfo
-	Volumes          []storage.Volume
-	Info             *mongo.MongoInfo
-	Jobs             []multiwatcher.MachineJob
-	APIInfo          *api.Info
-	Secret           string
-	AgentEnvironment map[string]string
+	Env               string
+	MachineId         string
+	MachineNonce      string
+	PossibleTools     coretools.List
+	Instance          instance.Instance
+	Constraints       constraints.Value
+	AvailabilityZones []string
+	SubnetsToZones    map[network.Id][]string
+	NetworkInfo       []network.InterfaceInfo
+	Volumes           []storage.Volume
+	Info              *mongo.MongoInfo
+	Jobs              []multiwatcher.MachineJob
+	APIInfo           *api.Info
+	Secret            string
+	AgentEnvironment  map[string]string
 }
 
 type OpStopInstances struct {
@@ -203,6 +206,19 @@ type OpClosePorts struct {
 	Ports      []network.PortRange
 }
 
+type OpSetPortSources struct {
+	Env         string
+	MachineId   string
+	InstanceId  instance.Id
+	Ports       []network.PortRange
+	SourceCIDRs []string
+}
+
+type OpSetFirewallRule struct {
+	Env  string
+	Rule network.FirewallRule
+}
+
 type OpPutFile struct {
 	Env      string
 	FileName string
@@ -237,6 +253,7 @@ type environState struct {
 	maxAddr         int // maximum allocated address last byte
 	insts           map[instance.Id]*dummyInstance
 	globalPorts     map[network.PortRange]bool
+	firewallRules   map[network.WellKnownServiceType]network.FirewallRule
 	bootstrapped    bool
 	apiListener     net.Listener
 	apiServer       *apiserver.Server
@@ -401,6 +418,7 @@ func newState(name string, ops chan<- Operation, newStatePolicy state.NewPolicyF
 		newStatePolicy: newStatePolicy,
 		insts:          make(map[instance.Id]*dummyInstance),
 		globalPorts:    make(map[network.PortRange]bool),
+		firewallRules:  make(map[network.WellKnownServiceType]network.FirewallRule),
 	}
 	return s
 }
@@ -708,6 +726,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 		id:           BootstrapInstanceId,
 		addresses:    network.NewAddresses("localhost"),
 		ports:        make(map[network.PortRange]bool),
+		portSources:  make(map[network.PortRange][]string),
 		machineId:    agent.BootstrapMachineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -793,6 +812,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 			estate.apiStatePool = state.NewStatePool(st)
 
 			estate.apiServer, err = apiserver.NewServer(st, estate.apiListener, apiserver.ServerConfig{
+				Clock:       clock.WallClock,
 				Cert:        []byte(testing.ServerCert),
 				Key:         []byte(testing.ServerKey),
 				Tag:         names.NewMachineTag("0"),
@@ -962,6 +982,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		id:           instance.Id(idString),
 		addresses:    addrs,
 		ports:        make(map[network.PortRange]bool),
+		portSources:  make(map[network.PortRange][]string),
 		machineId:    machineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -1033,19 +1054,20 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	estate.insts[i.id] = i
 	estate.maxId++
 	estate.ops <- OpStartInstance{
-		Env:              e.name,
-		MachineId:        machineId,
-		MachineNonce:     args.InstanceConfig.MachineNonce,
-		PossibleTools:    args.Tools,
-		Constraints:      args.Constraints,
-		SubnetsToZones:   subnetsToZones,
-		Volumes:          volumes,
-		Instance:         i,
-		Jobs:             args.InstanceConfig.Jobs,
-		Info:             mongoInfo,
-		APIInfo:          args.InstanceConfig.APIInfo,
-		AgentEnvironment: args.InstanceConfig.AgentEnvironment,
-		Secret:           e.ecfg().secret(),
+		Env:               e.name,
+		MachineId:         machineId,
+		MachineNonce:      args.InstanceConfig.MachineNonce,
+		PossibleTools:     args.Tools,
+		Constraints:       args.Constraints,
+		AvailabilityZones: args.AvailabilityZones,
+		SubnetsToZones:    subnetsToZones,
+		Volumes:           volumes,
+		Instance:          i,
+		Jobs:              args.InstanceConfig.Jobs,
+		Info:              mongoInfo,
+		APIInfo:           args.InstanceConfig.APIInfo,
+		AgentEnvironment:  args.InstanceConfig.AgentEnvironment,
+		Secret:            e.ecfg().secret(),
 	}
 	return &environs.StartInstanceResult{
 		Instance: i,
@@ -1239,7 +1261,11 @@ func (env *environ) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string,
 	if err := env.checkBroken("InstanceAvailabilityZoneNames"); err != nil {
 		return nil, errors.NotSupportedf("instance availability zones")
 	}
-	return []string{"zone1"}, nil
+	zones := make([]string, len(ids))
+	for i := range zones {
+		zones[i] = "zone1"
+	}
+	return zones, nil
 }
 
 // Subnets implements environs.Environ.Subnets.
@@ -1352,6 +1378,12 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 	return insts, nil
 }
 
+// SupportsPortSources is part of the environs.PortSourceRestricter
+// interface.
+func (e *environ) SupportsPortSources() bool {
+	return e.ecfg().FirewallMode() == config.FwInstance
+}
+
 func (e *environ) OpenPorts(ports []network.PortRange) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
@@ -1401,6 +1433,22 @@ func (e *environ) Ports() (ports []network.PortRange, err error) {
 	return
 }
 
+// SetFirewallRule is part of the environs.FirewallRuleSetter interface.
+func (e *environ) SetFirewallRule(rule network.FirewallRule) error {
+	if err := rule.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	estate.firewallRules[rule.WellKnownService] = rule
+	estate.ops <- OpSetFirewallRule{Env: e.name, Rule: rule}
+	return nil
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &dummy
 }
@@ -1408,6 +1456,7 @@ func (*environ) Provider() environs.EnvironProvider {
 type dummyInstance struct {
 	state        *environState
 	ports        map[network.PortRange]bool
+	portSources  map[network.PortRange][]string
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1443,6 +1492,31 @@ func (inst *dummyInstance) Status() instance.InstanceStatus {
 
 }
 
+// FirewallRules returns the firewall rules set on the given dummy
+// environ, ordered by service.
+func FirewallRules(env environs.Environ) ([]network.FirewallRule, error) {
+	estate, err := env.(*environ).state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	var rules []network.FirewallRule
+	for _, rule := range estate.firewallRules {
+		rules = append(rules, rule)
+	}
+	sort.Sort(firewallRulesByService(rules))
+	return rules, nil
+}
+
+type firewallRulesByService []network.FirewallRule
+
+func (r firewallRulesByService) Len() int      { return len(r) }
+func (r firewallRulesByService) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r firewallRulesByService) Less(i, j int) bool {
+	return r[i].WellKnownService < r[j].WellKnownService
+}
+
 // SetInstanceAddresses sets the addresses associated with the given
 // dummy instance.
 func SetInstanceAddresses(inst instance.Instance, addrs []network.Address) {
@@ -1464,6 +1538,20 @@ func SetInstanceStatus(inst instance.Instance, status string) {
 
 // SetInstanceBroken marks the named methods of the instance as broken.
 // Any previously broken methods not in the set will no longer be broken.
+// InstancePortSources returns the source CIDRs to which each of the
+// ports opened on the given dummy instance have been restricted. Ports
+// open to any address are omitted.
+func InstancePortSources(inst instance.Instance) map[network.PortRange][]string {
+	inst0 := inst.(*dummyInstance)
+	inst0.state.mu.Lock()
+	defer inst0.state.mu.Unlock()
+	sources := make(map[network.PortRange][]string)
+	for p, cidrs := range inst0.portSources {
+		sources[p] = cidrs
+	}
+	return sources
+}
+
 func SetInstanceBroken(inst instance.Instance, methods ...string) {
 	inst0 := inst.(*dummyInstance)
 	inst0.mu.Lock()
@@ -1512,6 +1600,41 @@ func (inst *dummyInstance) OpenPorts(machineId string, ports []network.PortRange
 	}
 	for _, p := range ports {
 		inst.ports[p] = true
+		delete(inst.portSources, p)
+	}
+	return nil
+}
+
+// SetPortSources is part of the environs.InstancePortSourceSetter
+// interface.
+func (inst *dummyInstance) SetPortSources(machineId string, ports []network.PortRange, sourceCIDRs []string) error {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for setting port sources on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("SetPortSources with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("SetPortSources"); err != nil {
+		return err
+	}
+	inst.state.ops <- OpSetPortSources{
+		Env:         inst.state.name,
+		MachineId:   machineId,
+		InstanceId:  inst.Id(),
+		Ports:       ports,
+		SourceCIDRs: sourceCIDRs,
+	}
+	for _, p := range ports {
+		inst.ports[p] = true
+		if len(sourceCIDRs) == 0 {
+			delete(inst.portSources, p)
+		} else {
+			inst.portSources[p] = sourceCIDRs
+		}
 	}
 	return nil
 }
@@ -1538,6 +1661,7 @@ func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRang
 	}
 	for _, p := range ports {
 		delete(inst.ports, p)
+		delete(inst.portSources, p)
 	}
 	return nil
 }
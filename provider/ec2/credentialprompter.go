@@ -0,0 +1,32 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+// credentialPrompter is the environs.CredentialPrompter for EC2. Access
+// keys are entered according to the credential schema, and are checked
+// against EC2 before they are stored.
+type credentialPrompter struct{}
+
+// PromptCredential is part of the environs.CredentialPrompter interface.
+func (credentialPrompter) PromptCredential(
+	ctx environs.CredentialPromptContext, authType cloud.AuthType,
+) (*cloud.Credential, error) {
+	return nil, errors.NotSupportedf("interactive %q auth-type", authType)
+}
+
+// ValidateCredential is part of the environs.CredentialPrompter interface.
+func (credentialPrompter) ValidateCredential(spec environs.CloudSpec) error {
+	ec2Client, _, err := awsClients(spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return verifyCredentials(&environ{cloud: spec, ec2: ec2Client})
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/testing"
+)
+
+// Use local suite since this file lives in the ec2 package
+// for testing internals.
+type credentialPrompterSuite struct {
+	testing.BaseSuite
+	prompter environs.CredentialPrompter
+}
+
+var _ = gc.Suite(&credentialPrompterSuite{})
+
+func (s *credentialPrompterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	var err error
+	s.prompter, err = environs.CredentialPrompterFor("ec2")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *credentialPrompterSuite) cloudSpec(region string) environs.CloudSpec {
+	credential := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"access-key": "key",
+		"secret-key": "secret",
+	})
+	return environs.CloudSpec{
+		Type:       "ec2",
+		Name:       "aws",
+		Region:     region,
+		Credential: &credential,
+	}
+}
+
+func (s *credentialPrompterSuite) TestPromptCredentialNotSupported(c *gc.C) {
+	_, err := s.prompter.PromptCredential(environs.CredentialPromptContext{}, cloud.AccessKeyAuthType)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *credentialPrompterSuite) TestValidateCredential(c *gc.C) {
+	var verified *environ
+	s.PatchValue(&verifyCredentials, func(e *environ) error {
+		verified = e
+		return nil
+	})
+	err := s.prompter.ValidateCredential(s.cloudSpec("us-east-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(verified, gc.NotNil)
+	c.Assert(verified.ec2.Region.Name, gc.Equals, "us-east-1")
+	c.Assert(verified.ec2.Auth.AccessKey, gc.Equals, "key")
+	c.Assert(verified.ec2.Auth.SecretKey, gc.Equals, "secret")
+}
+
+func (s *credentialPrompterSuite) TestValidateCredentialFails(c *gc.C) {
+	s.PatchValue(&verifyCredentials, func(e *environ) error {
+		return errors.New("authentication failed.")
+	})
+	err := s.prompter.ValidateCredential(s.cloudSpec("us-east-1"))
+	c.Assert(err, gc.ErrorMatches, "authentication failed.")
+}
+
+func (s *credentialPrompterSuite) TestValidateCredentialInvalidRegion(c *gc.C) {
+	err := s.prompter.ValidateCredential(s.cloudSpec("mars-1"))
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: region name "mars-1" not valid`)
+}
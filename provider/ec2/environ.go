@@ -74,6 +74,10 @@ type environ struct {
 
 	availabilityZonesMutex sync.Mutex
 	availabilityZones      []common.AvailabilityZone
+
+	// invalidateCredential, if non-nil, is called when EC2
+	// rejects the credential the environ was opened with.
+	invalidateCredential environs.InvalidateCredentialFunc
 }
 
 func (e *environ) Config() *config.Config {
@@ -232,7 +236,7 @@ func (e *environ) AvailabilityZones() ([]common.AvailabilityZone, error) {
 		filter.Add("region-name", e.cloud.Region)
 		resp, err := ec2AvailabilityZones(e.ec2, filter)
 		if err != nil {
-			return nil, err
+			return nil, e.handleCredentialError(err)
 		}
 		logger.Debugf("availability zones: %+v", resp)
 		e.availabilityZones = make([]common.AvailabilityZone, len(resp.Zones))
@@ -396,9 +400,12 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 	}
 
-	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// If no availability zone is specified, then use those chosen by the
+	// provisioner; failing that, automatically spread across the known
+	// zones for optimal spread across the instance distribution group.
+	if len(availabilityZones) == 0 {
+		availabilityZones = args.AvailabilityZones
+	}
 	var zoneInstances []common.AvailabilityZoneInstances
 	if len(availabilityZones) == 0 {
 		var err error
@@ -541,6 +548,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}
 
 	if err != nil {
+		err = classifyStartInstanceError(e.handleCredentialError(err))
 		return nil, errors.Annotate(err, "cannot run instances")
 	}
 	if len(instResp.Instances) != 1 {
@@ -762,7 +770,7 @@ func (e *environ) gatherInstances(
 ) error {
 	resp, err := e.ec2.Instances(nil, filter)
 	if err != nil {
-		return err
+		return e.handleCredentialError(err)
 	}
 	n := 0
 	// For each requested id, add it to the returned instances
@@ -1035,7 +1043,7 @@ func (e *environ) allInstanceIDs(filter *ec2.Filter) ([]instance.Id, error) {
 func (e *environ) allInstances(filter *ec2.Filter) ([]instance.Instance, error) {
 	resp, err := e.ec2.Instances(nil, filter)
 	if err != nil {
-		return nil, errors.Annotate(err, "listing instances")
+		return nil, errors.Annotate(e.handleCredentialError(err), "listing instances")
 	}
 	var insts []instance.Instance
 	for _, r := range resp.Reservations {
@@ -1119,18 +1127,46 @@ func (e *environ) allControllerManagedVolumes(controllerUUID string) ([]string,
 }
 
 func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
+	return portsToIPPermsFrom(ports, []string{"0.0.0.0/0"})
+}
+
+// portsToIPPermsFrom returns the permissions which allow ingress to
+// the given ports from the given source CIDRs.
+func portsToIPPermsFrom(ports []network.PortRange, sourceCIDRs []string) []ec2.IPPerm {
 	ipPerms := make([]ec2.IPPerm, len(ports))
 	for i, p := range ports {
 		ipPerms[i] = ec2.IPPerm{
 			Protocol:  p.Protocol,
 			FromPort:  p.FromPort,
 			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+			SourceIPs: sourceCIDRs,
 		}
 	}
 	return ipPerms
 }
 
+// portsPerms returns the permissions which allow ingress to any of the
+// given ports from source addresses, as opposed to from other security
+// groups.
+func portsPerms(perms []ec2.IPPerm, ports []network.PortRange) []ec2.IPPerm {
+	wanted := make(map[network.PortRange]bool)
+	for _, p := range ports {
+		wanted[p] = true
+	}
+	var result []ec2.IPPerm
+	for _, perm := range perms {
+		portRange := network.PortRange{
+			Protocol: perm.Protocol,
+			FromPort: perm.FromPort,
+			ToPort:   perm.ToPort,
+		}
+		if wanted[portRange] && len(perm.SourceIPs) > 0 {
+			result = append(result, perm)
+		}
+	}
+	return result
+}
+
 func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error {
 	if len(ports) == 0 {
 		return nil
@@ -1168,40 +1204,102 @@ func (e *environ) closePortsInGroup(name string, ports []network.PortRange) erro
 	if len(ports) == 0 {
 		return nil
 	}
-	// Revoke permissions for anyone to access the given ports.
-	// Note that ec2 allows the revocation of permissions that aren't
-	// granted, so this is naturally idempotent.
-	g, err := e.groupByName(name)
+	// Revoke permissions for any source to access the given ports,
+	// whether they are open to anyone or have been restricted to
+	// particular sources.
+	info, err := e.groupInfoByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2.RevokeSecurityGroup(g, portsToIPPerms(ports))
+	revoke := newPermSetForGroup(portsPerms(info.IPPerms, ports), info.SecurityGroup)
+	if len(revoke) == 0 {
+		return nil
+	}
+	_, err = e.ec2.RevokeSecurityGroup(info.SecurityGroup, revoke.ipPerms())
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
 	return nil
 }
 
+// setPortSourcesInGroup allows ingress to the given ports in the named
+// security group from the given source CIDRs only, or from anywhere if
+// none are given.
+func (e *environ) setPortSourcesInGroup(name string, ports []network.PortRange, sourceCIDRs []string) error {
+	if len(ports) == 0 {
+		return nil
+	}
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{"0.0.0.0/0"}
+	}
+	info, err := e.groupInfoByName(name)
+	if err != nil {
+		return err
+	}
+	have := newPermSetForGroup(portsPerms(info.IPPerms, ports), info.SecurityGroup)
+	want := newPermSetForGroup(portsToIPPermsFrom(ports, sourceCIDRs), info.SecurityGroup)
+
+	// Authorize the new sources before revoking the old ones, so that
+	// sources in both are never locked out.
+	add := make(permSet)
+	for p := range want {
+		if !have[p] {
+			add[p] = true
+		}
+	}
+	if len(add) > 0 {
+		if _, err := e.ec2.AuthorizeSecurityGroup(info.SecurityGroup, add.ipPerms()); err != nil {
+			return fmt.Errorf("cannot open ports: %v", err)
+		}
+	}
+	revoke := make(permSet)
+	for p := range have {
+		if !want[p] {
+			revoke[p] = true
+		}
+	}
+	if len(revoke) > 0 {
+		if _, err := e.ec2.RevokeSecurityGroup(info.SecurityGroup, revoke.ipPerms()); err != nil {
+			return fmt.Errorf("cannot restrict ports: %v", err)
+		}
+	}
+	return nil
+}
+
 func (e *environ) portsInGroup(name string) (ports []network.PortRange, err error) {
 	group, err := e.groupInfoByName(name)
 	if err != nil {
 		return nil, err
 	}
+	// Ports may be open to several sources, if they have been
+	// restricted to them, but are only reported once.
+	seen := make(map[network.PortRange]bool)
 	for _, p := range group.IPPerms {
-		if len(p.SourceIPs) != 1 {
-			logger.Errorf("expected exactly one IP permission, found: %v", p)
+		if len(p.SourceIPs) == 0 {
+			logger.Errorf("expected at least one IP permission, found: %v", p)
 			continue
 		}
-		ports = append(ports, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: p.Protocol,
 			FromPort: p.FromPort,
 			ToPort:   p.ToPort,
-		})
+		}
+		if seen[portRange] {
+			continue
+		}
+		seen[portRange] = true
+		ports = append(ports, portRange)
 	}
 	network.SortPortRanges(ports)
 	return ports, nil
 }
 
+// SupportsPortSources is part of the environs.PortSourceRestricter
+// interface. Only the per-instance security groups may be restricted.
+func (e *environ) SupportsPortSources() bool {
+	return e.Config().FirewallMode() == config.FwInstance
+}
+
 func (e *environ) OpenPorts(ports []network.PortRange) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
@@ -1455,14 +1553,18 @@ func (e *environ) jujuGroupName() string {
 // addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
 func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]ec2.SecurityGroup, error) {
+	sshSourceIPs, err := e.sshSourceIPs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// Ensure there's a global group for Juju-related traffic.
 	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(),
 		[]ec2.IPPerm{{
 			Protocol:  "tcp",
-			FromPort:  22,
-			ToPort:    22,
-			SourceIPs: []string{"0.0.0.0/0"},
+			FromPort:  sshPort,
+			ToPort:    sshPort,
+			SourceIPs: sshSourceIPs,
 		}, {
 			Protocol:  "tcp",
 			FromPort:  apiPort,
@@ -1712,6 +1814,54 @@ func isSubnetConstrainedError(err error) bool {
 	return false
 }
 
+// isAuthFailureError returns whether err is an EC2 error code
+// indicating that the supplied credential was rejected.
+func isAuthFailureError(err error) bool {
+	switch ec2ErrCode(err) {
+	case "AuthFailure", "SignatureDoesNotMatch", "InvalidClientTokenId":
+		return true
+	}
+	return false
+}
+
+// classifyStartInstanceError records in err the class of failure
+// indicated by the EC2 error code, so that the provisioner can decide
+// whether to retry.
+func classifyStartInstanceError(err error) error {
+	if isAuthFailureError(err) {
+		return environs.NewStartInstanceError(err, environs.StartInstanceErrorCredential)
+	}
+	class := environs.StartInstanceErrorUnknown
+	switch ec2ErrCode(err) {
+	case "InstanceLimitExceeded", "VcpuLimitExceeded", "VolumeLimitExceeded",
+		"MaxSpotInstanceCountExceeded", "AddressLimitExceeded":
+		class = environs.StartInstanceErrorQuota
+	case "InsufficientInstanceCapacity", "InsufficientFreeAddressesInSubnet",
+		"InsufficientHostCapacity", "InsufficientReservedInstanceCapacity":
+		class = environs.StartInstanceErrorCapacity
+	case "InvalidAMIID.NotFound", "InvalidAMIID.Unavailable", "InvalidAMIID.Malformed":
+		class = environs.StartInstanceErrorImageNotFound
+	case "RequestLimitExceeded", "InternalError", "Unavailable", "ServiceUnavailable":
+		class = environs.StartInstanceErrorTransient
+	default:
+		return err
+	}
+	return environs.NewStartInstanceError(err, class)
+}
+
+// handleCredentialError reports the environ's cloud credential as
+// invalid if err indicates that EC2 rejected it. The original error
+// is always returned.
+func (e *environ) handleCredentialError(err error) error {
+	if e.invalidateCredential == nil || !isAuthFailureError(err) {
+		return err
+	}
+	if invalidateErr := e.invalidateCredential(err.Error()); invalidateErr != nil {
+		logger.Warningf("cannot invalidate cloud credential: %v", invalidateErr)
+	}
+	return err
+}
+
 // If the err is of type *ec2.Error, ec2ErrCode returns
 // its code, otherwise it returns the empty string.
 func ec2ErrCode(err error) string {
@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"github.com/juju/errors"
 	amzec2 "gopkg.in/amz.v3/ec2"
 	gc "gopkg.in/check.v1"
 
@@ -104,6 +105,26 @@ func pInt(i uint64) *uint64 {
 	return &i
 }
 
+func (*Suite) TestClassifyStartInstanceError(c *gc.C) {
+	for i, test := range []struct {
+		code  string
+		class environs.StartInstanceErrorClass
+	}{
+		{"AuthFailure", environs.StartInstanceErrorCredential},
+		{"InstanceLimitExceeded", environs.StartInstanceErrorQuota},
+		{"InsufficientInstanceCapacity", environs.StartInstanceErrorCapacity},
+		{"InvalidAMIID.NotFound", environs.StartInstanceErrorImageNotFound},
+		{"RequestLimitExceeded", environs.StartInstanceErrorTransient},
+		{"InvalidParameterValue", environs.StartInstanceErrorUnknown},
+	} {
+		c.Logf("test %d: %s", i, test.code)
+		err := classifyStartInstanceError(&amzec2.Error{Code: test.code, Message: "oops"})
+		c.Check(environs.StartInstanceErrorClassOf(err), gc.Equals, test.class)
+	}
+	err := classifyStartInstanceError(errors.New("boom"))
+	c.Check(environs.StartInstanceErrorClassOf(err), gc.Equals, environs.StartInstanceErrorUnknown)
+}
+
 func (*Suite) TestPortsToIPPerms(c *gc.C) {
 	testCases := []struct {
 		about    string
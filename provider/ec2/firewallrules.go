@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/network"
+)
+
+// sshPort is the port on which machines are reached with SSH.
+const sshPort = 22
+
+// SetFirewallRule is part of the environs.FirewallRuleSetter interface.
+// Rules are enforced on the model's juju security group, to which every
+// machine of the model belongs.
+func (e *environ) SetFirewallRule(rule network.FirewallRule) error {
+	if rule.WellKnownService != network.SSHRule {
+		return errors.NotSupportedf("firewall rule for %q", rule.WellKnownService)
+	}
+	if err := rule.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	name := e.jujuGroupName()
+	info, err := e.groupInfoByName(name)
+	if isNotFoundError(err) {
+		return errors.NotFoundf("security group %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "fetching security group %q", name)
+	}
+	have := newPermSetForGroup(sshPerms(info.IPPerms), info.SecurityGroup)
+	want := newPermSetForGroup([]ec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  sshPort,
+		ToPort:    sshPort,
+		SourceIPs: rule.SourceCIDRs(),
+	}}, info.SecurityGroup)
+
+	// Authorize the new sources before revoking the old ones, so that
+	// sources in both are never locked out.
+	add := make(permSet)
+	for p := range want {
+		if !have[p] {
+			add[p] = true
+		}
+	}
+	if len(add) > 0 {
+		if _, err := e.ec2.AuthorizeSecurityGroup(info.SecurityGroup, add.ipPerms()); err != nil {
+			return errors.Annotatef(err, "authorizing security group %q", info.Id)
+		}
+	}
+	revoke := make(permSet)
+	for p := range have {
+		if !want[p] {
+			revoke[p] = true
+		}
+	}
+	if len(revoke) > 0 {
+		if _, err := e.ec2.RevokeSecurityGroup(info.SecurityGroup, revoke.ipPerms()); err != nil {
+			return errors.Annotatef(err, "revoking security group %q", info.Id)
+		}
+	}
+	return nil
+}
+
+// sshSourceIPs returns the sources from which the model's juju security
+// group allows SSH, so that a firewall rule set for SSH is kept when
+// the group is updated as machines are started. If the group does not
+// exist yet, SSH is allowed from any address.
+func (e *environ) sshSourceIPs() ([]string, error) {
+	anywhere := []string{"0.0.0.0/0"}
+	info, err := e.groupInfoByName(e.jujuGroupName())
+	if isNotFoundError(err) {
+		return anywhere, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "fetching security group %q", e.jujuGroupName())
+	}
+	var sources []string
+	for _, perm := range sshPerms(info.IPPerms) {
+		sources = append(sources, perm.SourceIPs...)
+	}
+	if len(sources) == 0 {
+		return anywhere, nil
+	}
+	return sources, nil
+}
+
+// sshPerms returns the permissions which allow SSH from source
+// addresses, as opposed to from other security groups.
+func sshPerms(perms []ec2.IPPerm) []ec2.IPPerm {
+	var result []ec2.IPPerm
+	for _, perm := range perms {
+		if perm.Protocol == "tcp" && perm.FromPort == sshPort && perm.ToPort == sshPort && len(perm.SourceIPs) > 0 {
+			result = append(result, perm)
+		}
+	}
+	return result
+}
@@ -6,6 +6,7 @@ package ec2
 import (
 	"fmt"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 )
@@ -74,5 +75,9 @@ func findInstanceSpec(
 		itWithCost.Cost = cost
 		itypesWithCosts = append(itypesWithCosts, itWithCost)
 	}
-	return instances.FindInstanceSpec(images, ic, itypesWithCosts)
+	spec, err := instances.FindInstanceSpec(images, ic, itypesWithCosts)
+	if err != nil && len(suitableImages) == 0 {
+		err = environs.NewStartInstanceError(err, environs.StartInstanceErrorImageNotFound)
+	}
+	return spec, err
 }
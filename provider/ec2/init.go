@@ -11,4 +11,5 @@ const (
 
 func init() {
 	environs.RegisterProvider(providerType, environProvider{})
+	environs.RegisterCredentialPrompter(providerType, credentialPrompter{})
 }
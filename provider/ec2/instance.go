@@ -100,6 +100,21 @@ func (inst *ec2Instance) ClosePorts(machineId string, ports []network.PortRange)
 	return nil
 }
 
+// SetPortSources is part of the environs.InstancePortSourceSetter
+// interface. Ingress is restricted in the machine's security group.
+func (inst *ec2Instance) SetPortSources(machineId string, ports []network.PortRange, sourceCIDRs []string) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for setting port sources on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.setPortSourcesInGroup(name, ports, sourceCIDRs); err != nil {
+		return err
+	}
+	logger.Infof("set sources of ports in security group %s to %v: %v", name, sourceCIDRs, ports)
+	return nil
+}
+
 func (inst *ec2Instance) Ports(machineId string) ([]network.PortRange, error) {
 	if inst.e.Config().FirewallMode() != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
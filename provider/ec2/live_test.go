@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/ec2"
 	coretesting "github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
@@ -290,6 +291,83 @@ func (t *LiveTests) TestInstanceGroups(c *gc.C) {
 	c.Assert(instIds, jc.SameContents, idsFromInsts(allInsts))
 }
 
+func (t *LiveTests) TestSSHFirewallRule(c *gc.C) {
+	t.BootstrapOnce(c)
+	setter, ok := t.Env.(environs.FirewallRuleSetter)
+	c.Assert(ok, jc.IsTrue)
+	ec2conn := ec2.EnvironEC2(t.Env)
+	sshSources := func() []string {
+		groupsResp, err := ec2conn.SecurityGroups(amzec2.SecurityGroupNames(ec2.JujuGroupName(t.Env)), nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(groupsResp.Groups, gc.HasLen, 1)
+		var sources []string
+		for _, perm := range groupsResp.Groups[0].IPPerms {
+			if perm.FromPort == 22 && perm.ToPort == 22 {
+				sources = append(sources, perm.SourceIPs...)
+			}
+		}
+		return sources
+	}
+
+	rule := network.FirewallRule{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8", "192.168.0.0/16"},
+	}
+	err := setter.SetFirewallRule(rule)
+	c.Assert(err, jc.ErrorIsNil)
+	defer setter.SetFirewallRule(network.FirewallRule{WellKnownService: network.SSHRule})
+	c.Assert(sshSources(), jc.SameContents, rule.WhitelistCIDRs)
+
+	// Starting another machine must not reset the rule.
+	inst, _ := testing.AssertStartInstance(c, t.Env, t.ControllerUUID, "97")
+	defer t.Env.StopInstances(inst.Id())
+	c.Assert(sshSources(), jc.SameContents, rule.WhitelistCIDRs)
+
+	err = setter.SetFirewallRule(network.FirewallRule{WellKnownService: network.SSHRule})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sshSources(), jc.SameContents, []string{"0.0.0.0/0"})
+
+	err = setter.SetFirewallRule(network.FirewallRule{WellKnownService: "telnet"})
+	c.Assert(err, gc.ErrorMatches, `firewall rule for "telnet" not supported`)
+}
+
+func (t *LiveTests) TestInstancePortSources(c *gc.C) {
+	t.PrepareOnce(c)
+	inst, _ := testing.AssertStartInstance(c, t.Env, t.ControllerUUID, "96")
+	defer t.Env.StopInstances(inst.Id())
+	setter, ok := inst.(environs.InstancePortSourceSetter)
+	c.Assert(ok, jc.IsTrue)
+	ec2conn := ec2.EnvironEC2(t.Env)
+	portSources := func() []string {
+		groupsResp, err := ec2conn.SecurityGroups(amzec2.SecurityGroupNames(ec2.MachineGroupName(t.Env, "96")), nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(groupsResp.Groups, gc.HasLen, 1)
+		var sources []string
+		for _, perm := range groupsResp.Groups[0].IPPerms {
+			if perm.FromPort == 80 && perm.ToPort == 80 {
+				sources = append(sources, perm.SourceIPs...)
+			}
+		}
+		return sources
+	}
+	ports := []network.PortRange{{Protocol: "tcp", FromPort: 80, ToPort: 80}}
+
+	err := inst.OpenPorts("96", ports)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portSources(), jc.SameContents, []string{"0.0.0.0/0"})
+
+	err = setter.SetPortSources("96", ports, []string{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portSources(), jc.SameContents, []string{"10.0.0.0/8", "192.168.0.0/16"})
+	opened, err := inst.Ports("96")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, jc.DeepEquals, ports)
+
+	err = inst.ClosePorts("96", ports)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portSources(), gc.HasLen, 0)
+}
+
 func checkPortAllowed(c *gc.C, perms []amzec2.IPPerm, port int) {
 	for _, perm := range perms {
 		if perm.FromPort == port {
@@ -962,6 +962,32 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	c.Assert(azArgs, gc.DeepEquals, []string{"az1", "az2"})
 }
 
+func (t *localServerSuite) TestStartInstanceAuthFailureInvalidatesCredential(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	var reasons []string
+	env, err := environs.New(environs.OpenParams{
+		Cloud:  t.CloudSpec(),
+		Config: env.Config(),
+		InvalidateCredential: func(reason string) error {
+			reasons = append(reasons, reason)
+			return nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
+		return nil, &amzec2.Error{
+			Code:    "AuthFailure",
+			Message: "AWS was not able to validate the provided access credentials",
+		}
+	})
+	_, _, _, err = testing.StartInstance(env, t.ControllerUUID, "1")
+	c.Assert(err, gc.ErrorMatches, "cannot run instances: .* \\(AuthFailure\\)")
+	c.Assert(reasons, gc.HasLen, 1)
+	c.Assert(reasons[0], gc.Matches, ".*\\(AuthFailure\\)")
+}
+
 // addTestingSubnets adds a testing default VPC with 3 subnets in the EC2 test
 // server: 2 of the subnets are in the "test-available" AZ, the remaining - in
 // "test-unavailable". Returns a slice with the IDs of the created subnets.
@@ -1440,6 +1466,34 @@ func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	})
 }
 
+func (t *localServerSuite) TestTaggedResources(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	lister, ok := environs.SupportsTaggedResources(env)
+	c.Assert(ok, jc.IsTrue)
+	resources, err := lister.TaggedResources(t.ControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	kinds := make(map[environs.ResourceKind]int)
+	for _, resource := range resources {
+		kinds[resource.Kind]++
+		c.Check(resource.ControllerUUID(), gc.Equals, t.ControllerUUID)
+		c.Check(resource.ModelUUID(), gc.Equals, coretesting.ModelTag.Id())
+	}
+	c.Check(kinds[environs.ResourceInstance], gc.Equals, 1)
+	c.Check(kinds[environs.ResourceVolume], gc.Equals, 1)
+	c.Check(kinds[environs.ResourceSecurityGroup], gc.Not(gc.Equals), 0)
+
+	// No resources belong to a model that has gone.
+	orphaned, err := environs.OrphanedResources(env, t.ControllerUUID, set.NewStrings(coretesting.ModelTag.Id()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(orphaned, gc.HasLen, 0)
+
+	resources, err = lister.TaggedResources("not-" + t.ControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resources, gc.HasLen, 0)
+}
+
 // localNonUSEastSuite is similar to localServerSuite but the S3 mock server
 // behaves as if it is not in the us-east region.
 type localNonUSEastSuite struct {
@@ -41,6 +41,7 @@ func (p environProvider) Open(args environs.OpenParams) (environs.Environ, error
 	e := new(environ)
 	e.cloud = args.Cloud
 	e.name = args.Config.Name()
+	e.invalidateCredential = args.InvalidateCredential
 
 	var err error
 	e.ec2, e.s3, err = awsClients(args.Cloud)
@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs"
+)
+
+// maxInstancesAttribute is the account attribute holding the maximum
+// number of on-demand instances which may run in a region.
+const maxInstancesAttribute = "max-instances"
+
+// quotaAPIClient defines the subset of the goamz API calls needed to
+// determine the account's remaining instance quota.
+type quotaAPIClient interface {
+	// AccountAttributes, called with the "max-instances" attribute,
+	// is used to find the region's on-demand instance limit.
+	AccountAttributes(attributeNames ...string) (*ec2.AccountAttributesResp, error)
+
+	// Instances is used to count the instances counting towards
+	// the limit.
+	Instances(ids []string, filter *ec2.Filter) (*ec2.InstancesResp, error)
+}
+
+// RemainingQuota is part of the environs.QuotaChecker interface. EC2
+// limits the number of on-demand instances per region rather than per
+// zone, so the zone is ignored. The EC2 API does not report limits on
+// cores or volumes.
+func (e *environ) RemainingQuota(zone string) (environs.Quota, error) {
+	quota := environs.Quota{Location: e.cloud.Region}
+	remaining, err := remainingInstances(e.ec2)
+	if errors.IsNotFound(err) {
+		return quota, nil
+	} else if err != nil {
+		return environs.Quota{}, errors.Trace(e.handleCredentialError(err))
+	}
+	quota.Instances = &remaining
+	return quota, nil
+}
+
+// remainingInstances returns the number of on-demand instances which
+// may still be started in the client's region. All instances of the
+// account count towards the limit, not only those of the model.
+// Returns an error satisfying errors.IsNotFound() if the limit is
+// not known.
+func remainingInstances(apiClient quotaAPIClient) (uint64, error) {
+	response, err := apiClient.AccountAttributes(maxInstancesAttribute)
+	if err != nil {
+		return 0, errors.Annotatef(err, "getting %s account attribute", maxInstancesAttribute)
+	}
+	if len(response.Attributes) == 0 ||
+		len(response.Attributes[0].Values) == 0 ||
+		response.Attributes[0].Name != maxInstancesAttribute {
+		return 0, errors.NotFoundf("%s account attribute", maxInstancesAttribute)
+	}
+	maxInstances, err := strconv.ParseUint(response.Attributes[0].Values[0], 10, 64)
+	if err != nil {
+		return 0, errors.Annotatef(err, "parsing %s account attribute", maxInstancesAttribute)
+	}
+
+	filter := ec2.NewFilter()
+	filter.Add("instance-state-name", aliveInstanceStates...)
+	resp, err := apiClient.Instances(nil, filter)
+	if err != nil {
+		return 0, errors.Annotate(err, "listing instances")
+	}
+	var running uint64
+	for _, r := range resp.Reservations {
+		running += uint64(len(r.Instances))
+	}
+	if running >= maxInstances {
+		return 0, nil
+	}
+	return maxInstances - running, nil
+}
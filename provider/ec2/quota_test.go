@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+)
+
+type quotaSuite struct {
+	testing.IsolationSuite
+
+	stubAPI *stubQuotaAPIClient
+}
+
+var _ = gc.Suite(&quotaSuite{})
+
+func (s *quotaSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stubAPI = &stubQuotaAPIClient{
+		stubVPCAPIClient: stubVPCAPIClient{Stub: &testing.Stub{}},
+	}
+}
+
+func (s *quotaSuite) TestRemainingInstances(c *gc.C) {
+	s.stubAPI.SetAttributesResponse(map[string][]string{"max-instances": {"20"}})
+	s.stubAPI.setInstancesResponse(3, 2)
+
+	remaining, err := remainingInstances(s.stubAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(remaining, gc.Equals, uint64(15))
+	s.stubAPI.CheckCallNames(c, "AccountAttributes", "Instances")
+	s.stubAPI.CheckCall(c, 0, "AccountAttributes", "max-instances")
+}
+
+func (s *quotaSuite) TestRemainingInstancesExhausted(c *gc.C) {
+	s.stubAPI.SetAttributesResponse(map[string][]string{"max-instances": {"5"}})
+	s.stubAPI.setInstancesResponse(4, 2)
+
+	remaining, err := remainingInstances(s.stubAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(remaining, gc.Equals, uint64(0))
+}
+
+func (s *quotaSuite) TestRemainingInstancesNoAttribute(c *gc.C) {
+	s.stubAPI.SetAttributesResponse(nil)
+
+	_, err := remainingInstances(s.stubAPI)
+	c.Assert(err, gc.ErrorMatches, "max-instances account attribute not found")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	s.stubAPI.CheckCallNames(c, "AccountAttributes")
+}
+
+func (s *quotaSuite) TestRemainingInstancesInvalidAttribute(c *gc.C) {
+	s.stubAPI.SetAttributesResponse(map[string][]string{"max-instances": {"lots"}})
+
+	_, err := remainingInstances(s.stubAPI)
+	c.Assert(err, gc.ErrorMatches, `parsing max-instances account attribute: .*invalid syntax`)
+}
+
+func (s *quotaSuite) TestRemainingInstancesUnexpectedAWSError(c *gc.C) {
+	s.stubAPI.SetErrors(errors.New("AWS failed!"))
+
+	_, err := remainingInstances(s.stubAPI)
+	c.Assert(err, gc.ErrorMatches, "getting max-instances account attribute: AWS failed!")
+}
+
+type stubQuotaAPIClient struct {
+	stubVPCAPIClient
+
+	instancesResponse *ec2.InstancesResp
+}
+
+// Instances implements quotaAPIClient and is used to test counting
+// the running instances.
+func (s *stubQuotaAPIClient) Instances(ids []string, filter *ec2.Filter) (*ec2.InstancesResp, error) {
+	s.Stub.AddCall("Instances", ids, filter)
+	return s.instancesResponse, s.Stub.NextErr()
+}
+
+func (s *stubQuotaAPIClient) setInstancesResponse(instancesPerReservation ...int) {
+	s.instancesResponse = &ec2.InstancesResp{
+		RequestId:    "fake-request-id",
+		Reservations: make([]ec2.Reservation, len(instancesPerReservation)),
+	}
+	for i, n := range instancesPerReservation {
+		s.instancesResponse.Reservations[i].Instances = make([]ec2.Instance, n)
+	}
+}
@@ -28,6 +28,18 @@ func NewStorage(bucket *s3.Bucket) storage.Storage {
 	return &ec2storage{bucket: bucket}
 }
 
+// BackupStorage returns the storage in which the controller's backup
+// archives are kept when its backup-target is "cloud". The archives
+// are kept in an S3 bucket named after the controller model; the
+// bucket is created when the first archive is stored.
+func (e *environ) BackupStorage() (storage.Storage, error) {
+	bucket, err := e.s3.Bucket("juju-backups-" + e.Config().UUID())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get backups bucket")
+	}
+	return NewStorage(bucket), nil
+}
+
 // ec2storage implements storage.Storage on
 // an ec2.bucket.
 type ec2storage struct {
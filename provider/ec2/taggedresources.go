@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+)
+
+var _ environs.TaggedResourceLister = (*environ)(nil)
+
+// TaggedResources is part of the environs.TaggedResourceLister interface.
+func (e *environ) TaggedResources(controllerUUID string) ([]environs.TaggedResource, error) {
+	filter := ec2.NewFilter()
+	filter.Add("instance-state-name", aliveInstanceStates...)
+	e.addControllerFilter(filter, controllerUUID)
+	instResp, err := e.ec2.Instances(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing instances")
+	}
+	var resources []environs.TaggedResource
+	for _, r := range instResp.Reservations {
+		for _, inst := range r.Instances {
+			resources = append(resources, environs.TaggedResource{
+				Kind: environs.ResourceInstance,
+				Id:   inst.InstanceId,
+				Tags: tagsMap(inst.Tags),
+			})
+		}
+	}
+
+	filter = ec2.NewFilter()
+	e.addControllerFilter(filter, controllerUUID)
+	volResp, err := e.ec2.Volumes(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing volumes")
+	}
+	for _, vol := range volResp.Volumes {
+		resources = append(resources, environs.TaggedResource{
+			Kind: environs.ResourceVolume,
+			Id:   vol.Id,
+			Tags: tagsMap(vol.Tags),
+		})
+	}
+
+	// The tags of security groups are not reported by the EC2 API
+	// client, but the groups are named for their model.
+	groups, err := e.controllerSecurityGroups(controllerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, group := range groups {
+		resourceTags := map[string]string{tags.JujuController: controllerUUID}
+		if modelUUID, ok := groupModelUUID(group.Name); ok {
+			resourceTags[tags.JujuModel] = modelUUID
+		}
+		resources = append(resources, environs.TaggedResource{
+			Kind: environs.ResourceSecurityGroup,
+			Id:   group.Id,
+			Tags: resourceTags,
+		})
+	}
+	return resources, nil
+}
+
+// groupModelUUID returns the UUID of the model a security group
+// belongs to, extracted from its name as given by jujuGroupName,
+// globalGroupName or machineGroupName.
+func groupModelUUID(name string) (string, bool) {
+	const prefix = "juju-"
+	const uuidLength = 36
+	if !strings.HasPrefix(name, prefix) || len(name) < len(prefix)+uuidLength {
+		return "", false
+	}
+	modelUUID := name[len(prefix) : len(prefix)+uuidLength]
+	if !utils.IsValidUUIDString(modelUUID) {
+		return "", false
+	}
+	return modelUUID, true
+}
+
+func tagsMap(ec2Tags []ec2.Tag) map[string]string {
+	result := make(map[string]string, len(ec2Tags))
+	for _, tag := range ec2Tags {
+		result[tag.Key] = tag.Value
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+// credentialPrompter is the environs.CredentialPrompter for GCE. For
+// the "jsonfile" auth-type it ingests the service account key file
+// downloaded from the Google Cloud console, storing the credentials
+// within so that the file is no longer needed.
+type credentialPrompter struct{}
+
+// PromptCredential is part of the environs.CredentialPrompter interface.
+func (credentialPrompter) PromptCredential(
+	ctx environs.CredentialPromptContext, authType cloud.AuthType,
+) (*cloud.Credential, error) {
+	if authType != cloud.JSONFileAuthType {
+		return nil, errors.NotSupportedf("interactive %q auth-type", authType)
+	}
+	fmt.Fprintln(ctx.Out, `
+Create a service account key for your project in the Google Cloud console,
+under "IAM & Admin" > "Service accounts", and download it as a JSON file.
+`[1:])
+	for {
+		path, err := ctx.Prompt("Enter path to the .json file", false)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		credential, err := ingestJSONAuthFile(path)
+		if err != nil {
+			fmt.Fprintf(ctx.Out, "Invalid credentials file: %v\n", err)
+			continue
+		}
+		attrs := credential.Attributes()
+		fmt.Fprintf(ctx.Out,
+			"Read credentials for %s in project %q.\n",
+			attrs[credAttrClientEmail], attrs[credAttrProjectID],
+		)
+		return credential, nil
+	}
+}
+
+// ingestJSONAuthFile reads the OAuth2 credentials in the JSON file
+// with the given path.
+func ingestJSONAuthFile(path string) (*cloud.Credential, error) {
+	path, err := cloud.ValidateFileAttrValue(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	credential, err := parseJSONAuthFile(f)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &credential, nil
+}
+
+// ValidateCredential is part of the environs.CredentialPrompter interface.
+func (credentialPrompter) ValidateCredential(spec environs.CloudSpec) error {
+	conn, err := connect(spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(conn.VerifyCredentials())
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+type credentialPrompterSuite struct {
+	BaseSuite
+	prompter environs.CredentialPrompter
+}
+
+var _ = gc.Suite(&credentialPrompterSuite{})
+
+func (s *credentialPrompterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	var err error
+	s.prompter, err = environs.CredentialPrompterFor("gce")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// writeKeyFile writes a service account key file, as downloaded from
+// the Google Cloud console.
+func (s *credentialPrompterSuite) writeKeyFile(c *gc.C) string {
+	key, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   ProjectID,
+		"client_id":    ClientID,
+		"client_email": ClientEmail,
+		"private_key":  PrivateKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(c.MkDir(), "key.json")
+	err = ioutil.WriteFile(path, key, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *credentialPrompterSuite) TestPromptCredentialIngestsJSONFile(c *gc.C) {
+	answers := []string{"key.json", s.writeKeyFile(c)}
+	var out bytes.Buffer
+	ctx := environs.CredentialPromptContext{
+		Out: &out,
+		Prompt: func(question string, hidden bool) (string, error) {
+			c.Assert(question, gc.Equals, "Enter path to the .json file")
+			c.Assert(hidden, jc.IsFalse)
+			answer := answers[0]
+			answers = answers[1:]
+			return answer, nil
+		},
+	}
+	credential, err := s.prompter.PromptCredential(ctx, cloud.JSONFileAuthType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*credential, jc.DeepEquals, MakeTestCredential())
+	c.Assert(answers, gc.HasLen, 0)
+	c.Assert(out.String(), gc.Matches, `(?s).*
+Invalid credentials file: file path must be an absolute path: key.json
+Read credentials for `+ClientEmail+` in project "my-juju".
+`)
+}
+
+func (s *credentialPrompterSuite) TestPromptCredentialNotSupported(c *gc.C) {
+	_, err := s.prompter.PromptCredential(environs.CredentialPromptContext{}, cloud.OAuth2AuthType)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *credentialPrompterSuite) TestValidateCredential(c *gc.C) {
+	err := s.prompter.ValidateCredential(MakeTestCloudSpec())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+}
+
+func (s *credentialPrompterSuite) TestValidateCredentialFails(c *gc.C) {
+	s.FakeConn.Err = errors.New("invalid_grant")
+	err := s.prompter.ValidateCredential(MakeTestCloudSpec())
+	c.Assert(err, gc.ErrorMatches, "invalid_grant")
+}
@@ -26,6 +26,9 @@ type gceConnection interface {
 	// and returns it.
 	Instance(id, zone string) (google.Instance, error)
 	Instances(prefix string, statuses ...string) ([]google.Instance, error)
+	// InstanceConsoleOutput returns the output written to the
+	// given instance's serial console.
+	InstanceConsoleOutput(id, zone string) (string, error)
 	AddInstance(spec google.InstanceSpec, zones ...string) (*google.Instance, error)
 	RemoveInstances(prefix string, ids ...string) error
 
@@ -89,6 +92,29 @@ func newEnviron(cloud environs.CloudSpec, cfg *config.Config) (*environ, error)
 		return nil, errors.Annotate(err, "invalid config")
 	}
 
+	// Connect and authenticate.
+	conn, err := connect(cloud)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &environ{
+		name:      ecfg.config.Name(),
+		uuid:      ecfg.config.UUID(),
+		cloud:     cloud,
+		ecfg:      ecfg,
+		gce:       conn,
+		namespace: namespace,
+	}, nil
+}
+
+// connect connects and authenticates to GCE using the region and
+// credential of the given cloud spec.
+func connect(cloud environs.CloudSpec) (gceConnection, error) {
 	credAttrs := cloud.Credential.Attributes()
 	if cloud.Credential.AuthType() == jujucloud.JSONFileAuthType {
 		contents := credAttrs[credAttrFile]
@@ -109,25 +135,11 @@ func newEnviron(cloud environs.CloudSpec, cfg *config.Config) (*environ, error)
 		Region:    cloud.Region,
 		ProjectID: credential.ProjectID,
 	}
-
-	// Connect and authenticate.
 	conn, err := newConnection(connectionConfig, credential)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	namespace, err := instance.NewNamespace(cfg.UUID())
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	return &environ{
-		name:      ecfg.config.Name(),
-		uuid:      ecfg.config.UUID(),
-		cloud:     cloud,
-		ecfg:      ecfg,
-		gce:       conn,
-		namespace: namespace,
-	}, nil
+	return conn, nil
 }
 
 // Name returns the name of the environment.
@@ -81,10 +81,11 @@ var availabilityZoneAllocations = common.AvailabilityZoneAllocations
 
 // parseAvailabilityZones returns the availability zones that should be
 // tried for the given instance spec. If a placement argument was
-// provided then only that one is returned. Otherwise the environment is
-// queried for available zones. In that case, the resulting list is
-// roughly ordered such that the environment's instances are spread
-// evenly across the region.
+// provided then only that one is returned, and if the provisioner chose
+// zones then those are returned. Otherwise the environment is queried
+// for available zones. In that case, the resulting list is roughly
+// ordered such that the environment's instances are spread evenly
+// across the region.
 func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
 	if args.Placement != "" {
 		// args.Placement will always be a zone name or empty.
@@ -96,6 +97,10 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 		return []string{placement.Zone.Name()}, nil
 	}
 
+	if len(args.AvailabilityZones) > 0 {
+		return args.AvailabilityZones, nil
+	}
+
 	// If no availability zone is specified, then automatically spread across
 	// the known zones for optimal spread across the instance distribution
 	// group.
@@ -92,6 +92,20 @@ func (env *environ) instances() ([]instance.Instance, error) {
 	return results, err
 }
 
+// ConsoleLog is part of the environs.ConsoleLogger interface. GCE
+// records the output written to each instance's first serial port.
+func (env *environ) ConsoleLog(id instance.Id) (string, error) {
+	insts, err := env.Instances([]instance.Id{id})
+	if err == environs.ErrNoInstances {
+		return "", errors.NotFoundf("instance %q", id)
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	inst := insts[0].(*environInstance)
+	output, err := env.gce.InstanceConsoleOutput(string(id), inst.base.ZoneName)
+	return output, errors.Trace(err)
+}
+
 // ControllerInstances returns the IDs of the instances corresponding
 // to juju controllers.
 func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
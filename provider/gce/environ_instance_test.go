@@ -71,6 +71,27 @@ func (s *environInstSuite) TestInstancesNoMatch(c *gc.C) {
 	c.Check(errors.Cause(err), gc.Equals, environs.ErrNoInstances)
 }
 
+func (s *environInstSuite) TestConsoleLog(c *gc.C) {
+	s.FakeEnviron.Insts = []instance.Instance{s.NewInstance(c, "spam")}
+	s.FakeConn.ConsoleOutput = "Booting..."
+
+	output, err := s.Env.ConsoleLog("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(output, gc.Equals, "Booting...")
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "InstanceConsoleOutput")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+}
+
+func (s *environInstSuite) TestConsoleLogNotFound(c *gc.C) {
+	_, err := s.Env.ConsoleLog("spam")
+
+	c.Check(err, gc.ErrorMatches, `instance "spam" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *environInstSuite) TestBasicInstances(c *gc.C) {
 	spam := s.NewBaseInstance(c, "spam")
 	ham := s.NewBaseInstance(c, "ham")
@@ -18,6 +18,10 @@ type rawConnectionWrapper interface {
 	// specified instance. If the instance does not exist then an error
 	// will be returned.
 	GetInstance(projectID, id, zone string) (*compute.Instance, error)
+	// GetSerialPortOutput sends a request to the GCE API for the
+	// output written to the specified instance's serial console. If
+	// the instance does not exist then errors.NotFound is returned.
+	GetSerialPortOutput(projectID, zone, id string) (string, error)
 	// ListInstances sends a request to the GCE API for a list of all
 	// instances in project for which the name starts with the provided
 	// prefix. The result is also limited to those instances with one of
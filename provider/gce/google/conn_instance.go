@@ -73,6 +73,13 @@ func (gce *Connection) Instance(id, zone string) (Instance, error) {
 	return result, nil
 }
 
+// InstanceConsoleOutput returns the output written to the given
+// instance's serial console.
+func (gce *Connection) InstanceConsoleOutput(id, zone string) (string, error) {
+	output, err := gce.raw.GetSerialPortOutput(gce.projectID, zone, id)
+	return output, errors.Trace(err)
+}
+
 // Instances sends a request to the GCE API for a list of all instances
 // (in the Connection's project) for which the name starts with the
 // provided prefix. The result is also limited to those instances with
@@ -159,6 +159,20 @@ func (s *connSuite) TestConnectionInstance(c *gc.C) {
 	c.Check(spec, gc.IsNil)
 }
 
+func (s *connSuite) TestConnectionInstanceConsoleOutput(c *gc.C) {
+	s.FakeConn.ConsoleOutput = "Booting..."
+
+	output, err := s.Conn.InstanceConsoleOutput("ham", "a-zone")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(output, gc.Equals, "Booting...")
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetSerialPortOutput")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "ham")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "a-zone")
+}
+
 func (s *connSuite) TestConnectionInstanceAPI(c *gc.C) {
 	s.FakeConn.Instance = &s.RawInstanceFull
 
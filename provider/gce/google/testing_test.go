@@ -166,6 +166,7 @@ type fakeConn struct {
 	Project       *compute.Project
 	Instance      *compute.Instance
 	Instances     []*compute.Instance
+	ConsoleOutput string
 	Firewall      *compute.Firewall
 	Zones         []*compute.Zone
 	Err           error
@@ -205,6 +206,22 @@ func (rc *fakeConn) GetInstance(projectID, zone, id string) (*compute.Instance,
 	return rc.Instance, err
 }
 
+func (rc *fakeConn) GetSerialPortOutput(projectID, zone, id string) (string, error) {
+	call := fakeCall{
+		FuncName:  "GetSerialPortOutput",
+		ProjectID: projectID,
+		ZoneName:  zone,
+		ID:        id,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.ConsoleOutput, err
+}
+
 func (rc *fakeConn) ListInstances(projectID, prefix string, statuses ...string) ([]*compute.Instance, error) {
 	call := fakeCall{
 		FuncName:  "ListInstances",
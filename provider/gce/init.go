@@ -11,4 +11,5 @@ const (
 
 func init() {
 	environs.RegisterProvider(providerType, providerInstance)
+	environs.RegisterCredentialPrompter(providerType, credentialPrompter{})
 }
@@ -118,6 +118,7 @@ type BaseSuiteUnpatched struct {
 }
 
 var _ environs.Environ = (*environ)(nil)
+var _ environs.ConsoleLogger = (*environ)(nil)
 var _ simplestreams.HasRegion = (*environ)(nil)
 var _ instance.Instance = (*environInstance)(nil)
 
@@ -479,10 +480,11 @@ type fakeConnCall struct {
 type fakeConn struct {
 	Calls []fakeConnCall
 
-	Inst       *google.Instance
-	Insts      []google.Instance
-	PortRanges []network.PortRange
-	Zones      []google.AvailabilityZone
+	Inst          *google.Instance
+	Insts         []google.Instance
+	ConsoleOutput string
+	PortRanges    []network.PortRange
+	Zones         []google.AvailabilityZone
 
 	GoogleDisks   []*google.Disk
 	GoogleDisk    *google.Disk
@@ -516,6 +518,15 @@ func (fc *fakeConn) Instance(id, zone string) (google.Instance, error) {
 	return *fc.Inst, fc.err()
 }
 
+func (fc *fakeConn) InstanceConsoleOutput(id, zone string) (string, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "InstanceConsoleOutput",
+		ID:       id,
+		ZoneName: zone,
+	})
+	return fc.ConsoleOutput, fc.err()
+}
+
 func (fc *fakeConn) Instances(prefix string, statuses ...string) ([]google.Instance, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "Instances",
@@ -85,12 +85,12 @@ func (*joyentEnviron) MaintainInstance(args environs.StartInstanceParams) error
 func (env *joyentEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	series := args.Tools.OneSeries()
 	arches := args.Tools.Arches()
-	spec, err := env.FindInstanceSpec(&instances.InstanceConstraint{
+	spec, err := env.findInstanceSpec(&instances.InstanceConstraint{
 		Region:      env.cloud.Region,
 		Series:      series,
 		Arches:      arches,
 		Constraints: args.Constraints,
-	}, args.ImageMetadata)
+	}, args.ImageMetadata, args.InstanceTypes)
 	if err != nil {
 		return nil, err
 	}
@@ -315,6 +315,11 @@ func (env *joyentEnviron) pollMachineState(machineId, state string) bool {
 	return strings.EqualFold(instanceConfig.State, state)
 }
 
+// InstanceTypes is specified in the environs.InstanceTypesFetcher interface.
+func (env *joyentEnviron) InstanceTypes() ([]instances.InstanceType, error) {
+	return env.listInstanceTypes()
+}
+
 func (env *joyentEnviron) listInstanceTypes() ([]instances.InstanceType, error) {
 	packages, err := env.compute.cloudapi.ListPackages(nil)
 	if err != nil {
@@ -348,14 +353,28 @@ func (env *joyentEnviron) listInstanceTypes() ([]instances.InstanceType, error)
 func (env *joyentEnviron) FindInstanceSpec(
 	ic *instances.InstanceConstraint,
 	imageMetadata []*imagemetadata.ImageMetadata,
+) (*instances.InstanceSpec, error) {
+	return env.findInstanceSpec(ic, imageMetadata, nil)
+}
+
+// findInstanceSpec returns an InstanceSpec satisfying the supplied
+// instanceConstraint. The spec is chosen from allInstanceTypes if any
+// are supplied, and from the packages listed by the cloud otherwise.
+func (env *joyentEnviron) findInstanceSpec(
+	ic *instances.InstanceConstraint,
+	imageMetadata []*imagemetadata.ImageMetadata,
+	allInstanceTypes []instances.InstanceType,
 ) (*instances.InstanceSpec, error) {
 	// Require at least one VCPU so we get KVM rather than smart package.
 	if ic.Constraints.CpuCores == nil {
 		ic.Constraints.CpuCores = &defaultCpuCores
 	}
-	allInstanceTypes, err := env.listInstanceTypes()
-	if err != nil {
-		return nil, err
+	if len(allInstanceTypes) == 0 {
+		var err error
+		allInstanceTypes, err = env.listInstanceTypes()
+		if err != nil {
+			return nil, err
+		}
 	}
 	images := instances.ImageMetadataToImages(imageMetadata)
 	spec, err := instances.FindInstanceSpec(images, ic, allInstanceTypes)
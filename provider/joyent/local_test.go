@@ -354,6 +354,17 @@ func (s *localServerSuite) TestFindInstanceSpec(c *gc.C) {
 	c.Check(spec.InstanceType.CpuCores, gc.Equals, uint64(4))
 }
 
+func (s *localServerSuite) TestInstanceTypes(c *gc.C) {
+	env := s.Prepare(c)
+	instanceTypes, err := env.(environs.InstanceTypesFetcher).InstanceTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceTypes, gc.Not(gc.HasLen), 0)
+	for _, instanceType := range instanceTypes {
+		c.Check(instanceType.Arches, jc.DeepEquals, []string{"amd64"})
+		c.Check(instanceType.VirtType, gc.NotNil)
+	}
+}
+
 func (s *localServerSuite) TestFindImageBadDefaultImage(c *gc.C) {
 	env := s.Prepare(c)
 	// An error occurs if no suitable image is found.
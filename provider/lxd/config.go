@@ -7,6 +7,9 @@ package lxd
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
@@ -24,6 +27,9 @@ const (
 	cfgClientCert    = "client-cert"
 	cfgClientKey     = "client-key"
 	cfgServerPEMCert = "server-cert"
+
+	cfgImageRemotes     = "image-remotes"
+	cfgImageCacheExpiry = "image-cache-expiry"
 )
 
 // configSchema defines the schema for the configuration attributes
@@ -49,6 +55,14 @@ var configSchema = environschema.Fields{
 		Type:        environschema.Tstring,
 		Immutable:   true,
 	},
+	cfgImageRemotes: {
+		Description: `A comma-separated list of https:// simplestreams URLs to look in for images, before the model's image metadata sources.`,
+		Type:        environschema.Tstring,
+	},
+	cfgImageCacheExpiry: {
+		Description: `How long an image copied to the LXD host may go unused before it is removed, e.g. "168h". If empty, images are never removed.`,
+		Type:        environschema.Tstring,
+	},
 }
 
 var (
@@ -60,6 +74,9 @@ var (
 		cfgClientCert:    "",
 		cfgClientKey:     "",
 		cfgServerPEMCert: "",
+
+		cfgImageRemotes:     "",
+		cfgImageCacheExpiry: "",
 	}
 
 	configFields, configDefaults = func() (schema.Fields, schema.Defaults) {
@@ -197,6 +214,31 @@ func (c *environConfig) serverPEMCert() string {
 	return raw.(string)
 }
 
+// imageRemotes returns the URLs of the image sources configured for
+// the model, in order.
+func (c *environConfig) imageRemotes() []string {
+	raw, _ := c.attrs[cfgImageRemotes].(string)
+	var remotes []string
+	for _, remote := range strings.Split(raw, ",") {
+		if remote = strings.TrimSpace(remote); remote != "" {
+			remotes = append(remotes, remote)
+		}
+	}
+	return remotes
+}
+
+// imageCacheExpiry returns how long a copied image may go unused
+// before it is removed. Zero means images are never removed.
+func (c *environConfig) imageCacheExpiry() time.Duration {
+	raw, _ := c.attrs[cfgImageCacheExpiry].(string)
+	if raw == "" {
+		return 0
+	}
+	// The value has been checked by validate.
+	expiry, _ := time.ParseDuration(raw)
+	return expiry
+}
+
 // clientConfig builds a LXD Config based on the env config and returns it.
 func (c *environConfig) clientConfig() (lxdclient.Config, error) {
 	remote := lxdclient.Remote{
@@ -284,6 +326,23 @@ func (c *environConfig) validate() error {
 		return errors.Errorf("missing %s (got %s value %q)", cfgClientKey, cfgClientCert, c.clientCert())
 	}
 
+	for _, remote := range c.imageRemotes() {
+		// LXD only allows https:// URLs for simplestreams remotes.
+		remoteURL, err := url.Parse(remote)
+		if err != nil || remoteURL.Scheme != "https" || remoteURL.Host == "" {
+			return errors.Errorf("%s: expected https:// URL, got %q", cfgImageRemotes, remote)
+		}
+	}
+	if raw, _ := c.attrs[cfgImageCacheExpiry].(string); raw != "" {
+		expiry, err := time.ParseDuration(raw)
+		if err != nil {
+			return errors.Annotatef(err, "%s", cfgImageCacheExpiry)
+		}
+		if expiry < 0 {
+			return errors.Errorf("%s: must not be negative, got %q", cfgImageCacheExpiry, raw)
+		}
+	}
+
 	// Check sanity of complex provider-specific fields.
 	cfg, err := c.clientConfig()
 	if err != nil {
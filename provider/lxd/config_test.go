@@ -302,6 +302,22 @@ var newConfigTests = []configTestSpec{{
 	info:   "server-cert is optional",
 	remove: []string{"server-cert"},
 	expect: testing.Attrs{"server-cert": ""},
+}, {
+	info:   "image-remotes is optional",
+	remove: []string{"image-remotes"},
+	expect: testing.Attrs{"image-remotes": ""},
+}, {
+	info:   "image-remotes can be a list of https URLs",
+	insert: testing.Attrs{"image-remotes": "https://images.example.com/,https://mirror.example.com/"},
+	expect: testing.Attrs{"image-remotes": "https://images.example.com/,https://mirror.example.com/"},
+}, {
+	info:   "image-cache-expiry is optional",
+	remove: []string{"image-cache-expiry"},
+	expect: testing.Attrs{"image-cache-expiry": ""},
+}, {
+	info:   "image-cache-expiry can be a duration",
+	insert: testing.Attrs{"image-cache-expiry": "168h"},
+	expect: testing.Attrs{"image-cache-expiry": "168h"},
 }, {
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": 12345},
@@ -350,6 +366,30 @@ func (s *configSuite) TestValidateNewConfig(c *gc.C) {
 	}
 }
 
+var invalidConfigTests = []configTestSpec{{
+	info:   "image-remotes must be https URLs",
+	insert: testing.Attrs{"image-remotes": "https://images.example.com/,http://mirror.example.com/"},
+	err:    `image-remotes: expected https:// URL, got "http://mirror.example.com/"`,
+}, {
+	info:   "image-cache-expiry must be a duration",
+	insert: testing.Attrs{"image-cache-expiry": "a week"},
+	err:    `image-cache-expiry: time: invalid duration .*`,
+}, {
+	info:   "image-cache-expiry must not be negative",
+	insert: testing.Attrs{"image-cache-expiry": "-1h"},
+	err:    `image-cache-expiry: must not be negative, got "-1h"`,
+}}
+
+func (s *configSuite) TestValidateInvalidConfig(c *gc.C) {
+	for i, test := range invalidConfigTests {
+		c.Logf("test %d: %s", i, test.info)
+
+		testConfig := test.newConfig(c)
+		_, err := lxd.Provider.Validate(testConfig, nil)
+		test.checkFailure(c, err, "invalid config")
+	}
+}
+
 // TODO(wwitzel3) refactor to the provider_test file
 func (s *configSuite) TestValidateOldConfig(c *gc.C) {
 	for i, test := range newConfigTests {
@@ -62,7 +62,10 @@ func newEnviron(cfg *config.Config, newRawProvider newRawProviderFunc) (*environ
 		return nil, errors.Trace(err)
 	}
 
-	//TODO(wwitzel3) make sure we are also cleaning up profiles during destroy
+	// TODO(wwitzel3) make sure we are also cleaning up the model profile
+	// during destroy. It is named for the model, so it may be shared
+	// with a model of the same name in another controller; constraints
+	// profiles are removed by Destroy.
 	if err := env.initProfile(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -183,6 +186,10 @@ func (env *environ) Destroy() error {
 	if err := env.base.DestroyEnv(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := env.removeConstraintsProfiles(); err != nil {
+		return errors.Trace(err)
+	}
+	env.removeUnusedImages()
 	return nil
 }
 
@@ -8,6 +8,7 @@ package lxd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/arch"
@@ -43,8 +44,6 @@ func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.
 		return nil, errors.Trace(err)
 	}
 
-	// TODO(ericsnow) Handle constraints?
-
 	raw, err := env.newRawInstance(args)
 	if err != nil {
 		if args.StatusCallback != nil {
@@ -93,6 +92,13 @@ func (env *environ) getImageSources() ([]lxdclient.Remote, error) {
 		return nil, errors.Trace(err)
 	}
 	remotes := make([]lxdclient.Remote, 0)
+	for _, url := range env.ecfg.imageRemotes() {
+		remotes = append(remotes, lxdclient.Remote{
+			Name:     url,
+			Host:     url,
+			Protocol: lxdclient.SimplestreamsProtocol,
+		})
+	}
 	for _, source := range metadataSources {
 		url, err := source.URL("")
 		if err != nil {
@@ -159,6 +165,23 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 	if err := env.raw.EnsureImageExists(series, imageSources, callback); err != nil {
 		return nil, errors.Trace(err)
 	}
+	env.removeUnusedImages()
+
+	profiles := []string{
+		//TODO(wwitzel3) allow the user to specify lxc profiles to apply. This allows the
+		// user to setup any custom devices order config settings for their environment.
+		// Also we must ensure that a device with the parent: lxcbr0 exists in at least
+		// one of the profiles.
+		"default",
+		env.profileName(),
+	}
+	consProfile, err := env.ensureConstraintsProfile(args.Constraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if consProfile != "" {
+		profiles = append(profiles, consProfile)
+	}
 
 	metadata, err := getMetadata(args)
 	if err != nil {
@@ -179,14 +202,7 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 		//Disks:             getDisks(spec, args.Constraints),
 		//NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata: metadata,
-		Profiles: []string{
-			//TODO(wwitzel3) allow the user to specify lxc profiles to apply. This allows the
-			// user to setup any custom devices order config settings for their environment.
-			// Also we must ensure that a device with the parent: lxcbr0 exists in at least
-			// one of the profiles.
-			"default",
-			env.profileName(),
-		},
+		Profiles: profiles,
 		//Tags:              tags,
 		// Network is omitted (left empty).
 	}
@@ -205,6 +221,24 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 	return inst, nil
 }
 
+// removeUnusedImages removes the images copied to the LXD host that
+// have gone unused for longer than the model's image-cache-expiry, if
+// it is set. Failure is logged rather than returned, as the images are
+// only a cache.
+func (env *environ) removeUnusedImages() {
+	expiry := env.ecfg.imageCacheExpiry()
+	if expiry == 0 {
+		return
+	}
+	removed, err := env.raw.RemoveUnusedImages(time.Now().Add(-expiry))
+	if err != nil {
+		logger.Warningf("cannot remove unused images: %v", err)
+	}
+	if len(removed) > 0 {
+		logger.Infof("removed unused images: %s", strings.Join(removed, ", "))
+	}
+}
+
 // getMetadata builds the raw "user-defined" metadata for the new
 // instance (relative to the provided args) and returns it.
 func getMetadata(args environs.StartInstanceParams) (map[string]string, error) {
@@ -6,12 +6,16 @@
 package lxd_test
 
 import (
+	"time"
+
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
 )
 
 type environBrokerSuite struct {
@@ -37,6 +41,44 @@ func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
 	c.Assert(s.StartInstArgs.InstanceConfig.AgentVersion().Arch, gc.Equals, arch.ARM64)
 }
 
+func (s *environBrokerSuite) TestStartInstanceConstraintsProfile(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+	s.StartInstArgs.Constraints = constraints.MustParse("cpu-cores=2 mem=2G root-disk=8G")
+
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	profile := s.Prefix() + "cpu2-mem2048M-disk8192M"
+	s.Stub.CheckCallNames(c, "EnsureImageExists", "HasProfile", "CreateProfileWithDevices", "AddInstance")
+	s.Stub.CheckCall(c, 2, "CreateProfileWithDevices", profile, map[string]string{
+		"limits.cpu":    "2",
+		"limits.memory": "2048MB",
+	}, lxdclient.Devices{
+		"root": {"type": "disk", "path": "/", "size": "8192MB"},
+	})
+	spec := s.Stub.Calls()[3].Args[0].(lxdclient.InstanceSpec)
+	c.Check(spec.Profiles, jc.DeepEquals, []string{"default", "juju-" + s.Config.Name(), profile})
+
+	// The profile is reused for the next instance.
+	s.Stub.ResetCalls()
+	_, err = s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "EnsureImageExists", "HasProfile", "AddInstance")
+}
+
+func (s *environBrokerSuite) TestStartInstanceRemovesUnusedImages(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+	s.UpdateConfig(c, map[string]interface{}{"image-cache-expiry": "24h"})
+
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "EnsureImageExists", "RemoveUnusedImages", "AddInstance")
+	unusedSince := s.Stub.Calls()[1].Args[0].(time.Time)
+	c.Check(unusedSince.Before(time.Now().Add(-23*time.Hour)), jc.IsTrue)
+}
+
 func (s *environBrokerSuite) TestStartInstanceNoTools(c *gc.C) {
 	s.Client.Inst = s.RawInstance
 
@@ -84,6 +126,18 @@ func (s *environBrokerSuite) TestImageMetadataURLMungesHTTP(c *gc.C) {
 	})
 }
 
+func (s *environBrokerSuite) TestImageRemotes(c *gc.C) {
+	s.UpdateConfig(c, map[string]interface{}{
+		"image-remotes": "https://images.example.com/, https://mirror.example.com/ubuntu/",
+	})
+	s.checkSources(c, []string{
+		"https://images.example.com/",
+		"https://mirror.example.com/ubuntu/",
+		"https://streams.canonical.com/juju/images/releases/",
+		"https://cloud-images.ubuntu.com/releases/",
+	})
+}
+
 func (s *environBrokerSuite) checkSources(c *gc.C, expectedURLs []string) {
 	sources, err := lxd.GetImageSources(s.Env)
 	c.Assert(err, jc.ErrorIsNil)
@@ -6,6 +6,8 @@
 package lxd
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/environs"
@@ -114,6 +116,35 @@ func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, e
 	return results, nil
 }
 
+// TaggedResources is part of the environs.TaggedResourceLister
+// interface. The LXD provider tags only its containers.
+func (env *environ) TaggedResources(controllerUUID string) ([]environs.TaggedResource, error) {
+	instances, err := env.raw.Instances("juju-", lxdclient.AliveStatuses...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var results []environs.TaggedResource
+	for _, inst := range instances {
+		metadata := inst.Metadata()
+		if metadata[tags.JujuController] != controllerUUID {
+			continue
+		}
+		resourceTags := make(map[string]string)
+		for k, v := range metadata {
+			if strings.HasPrefix(k, tags.JujuTagPrefix) {
+				resourceTags[k] = v
+			}
+		}
+		results = append(results, environs.TaggedResource{
+			Kind: environs.ResourceInstance,
+			Id:   inst.Name,
+			Tags: resourceTags,
+		})
+	}
+	return results, nil
+}
+
 type instPlacement struct{}
 
 func (env *environ) parsePlacement(placement string) (*instPlacement, error) {
@@ -115,6 +115,29 @@ func (s *environInstSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
 	c.Check(err, gc.Equals, environs.ErrNotBootstrapped)
 }
 
+func (s *environInstSuite) TestTaggedResources(c *gc.C) {
+	other := lxdclient.NewInstance(lxdclient.InstanceSummary{Name: "juju-other"}, nil)
+	s.Client.Insts = []lxdclient.Instance{*s.RawInstance, *other}
+
+	resources, err := s.Env.TaggedResources(coretesting.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(resources, jc.DeepEquals, []environs.TaggedResource{{
+		Kind: environs.ResourceInstance,
+		Id:   "spam",
+		Tags: map[string]string{
+			"juju-is-controller":   "true",
+			"juju-controller-uuid": coretesting.ModelTag.Id(),
+			"juju-model-uuid":      s.Config.UUID(),
+		},
+	}})
+	s.BaseSuite.Client.CheckCall(
+		c, 0, "Instances",
+		"juju-",
+		[]string{"Starting", "Started", "Running", "Stopping", "Stopped"},
+	)
+}
+
 func (s *environInstSuite) TestControllerInstancesMixed(c *gc.C) {
 	other := lxdclient.NewInstance(lxdclient.InstanceSummary{}, nil)
 	s.Client.Insts = []lxdclient.Instance{*s.RawInstance, *other}
@@ -26,10 +26,10 @@ func (env *environ) PrecheckInstance(series string, cons constraints.Value, plac
 	return nil
 }
 
+// cpu-cores, mem and root-disk are applied to containers through
+// the model's constraints profiles.
 var unsupportedConstraints = []string{
-	constraints.CpuCores,
 	constraints.CpuPower,
-	//TODO(ericsnow) Add constraints.Mem as unsupported?
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
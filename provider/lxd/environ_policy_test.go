@@ -118,7 +118,6 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	expected := []string{
 		"tags",
 		"instance-type",
-		"cpu-cores",
 		"cpu-power",
 		"virt-type",
 	}
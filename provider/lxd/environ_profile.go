@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+// constraintsProfile returns the name, config and devices of the LXD
+// profile that applies the given constraints to a container. The name
+// is empty if none of the constraints are applied by a profile.
+//
+// Constraints profiles are shared by all the model's containers with
+// the same constraints. Their names start with the model's instance
+// namespace prefix, so that they can be removed with the model.
+func (env *environ) constraintsProfile(cons constraints.Value) (string, map[string]string, lxdclient.Devices) {
+	var parts []string
+	config := make(map[string]string)
+	devices := make(lxdclient.Devices)
+	if cons.CpuCores != nil && *cons.CpuCores > 0 {
+		parts = append(parts, fmt.Sprintf("cpu%d", *cons.CpuCores))
+		config["limits.cpu"] = fmt.Sprint(*cons.CpuCores)
+	}
+	if cons.Mem != nil && *cons.Mem > 0 {
+		parts = append(parts, fmt.Sprintf("mem%dM", *cons.Mem))
+		config["limits.memory"] = fmt.Sprintf("%dMB", *cons.Mem)
+	}
+	if cons.RootDisk != nil && *cons.RootDisk > 0 {
+		parts = append(parts, fmt.Sprintf("disk%dM", *cons.RootDisk))
+		devices["root"] = lxdclient.Device{
+			"type": "disk",
+			"path": "/",
+			"size": fmt.Sprintf("%dMB", *cons.RootDisk),
+		}
+	}
+	if len(parts) == 0 {
+		return "", nil, nil
+	}
+	return env.namespace.Prefix() + strings.Join(parts, "-"), config, devices
+}
+
+// ensureConstraintsProfile creates the profile that applies the given
+// constraints, if it does not already exist, and returns its name. The
+// name is empty if no profile is needed.
+func (env *environ) ensureConstraintsProfile(cons constraints.Value) (string, error) {
+	name, config, devices := env.constraintsProfile(cons)
+	if name == "" {
+		return "", nil
+	}
+	hasProfile, err := env.raw.HasProfile(name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if hasProfile {
+		return name, nil
+	}
+	logger.Infof("creating profile %q for constraints %q", name, cons)
+	if err := env.raw.CreateProfileWithDevices(name, config, devices); err != nil {
+		// The profile may have been created concurrently, for
+		// another instance with the same constraints.
+		if hasProfile, err2 := env.raw.HasProfile(name); err2 != nil || !hasProfile {
+			return "", errors.Annotatef(err, "creating profile %q", name)
+		}
+	}
+	return name, nil
+}
+
+// removeConstraintsProfiles removes the model's constraints profiles.
+// It must only be called once the model's containers are removed.
+func (env *environ) removeConstraintsProfiles() error {
+	profiles, err := env.raw.ListProfiles()
+	if err != nil {
+		return errors.Annotate(err, "listing profiles")
+	}
+	prefix := env.namespace.Prefix()
+	for _, name := range profiles {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if err := env.raw.ProfileDelete(name); err != nil {
+			return errors.Annotatef(err, "removing profile %q", name)
+		}
+	}
+	return nil
+}
@@ -6,6 +6,8 @@
 package lxd
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/network"
@@ -29,11 +31,15 @@ type lxdInstances interface {
 
 type lxdProfiles interface {
 	CreateProfile(string, map[string]string) error
+	CreateProfileWithDevices(string, map[string]string, lxdclient.Devices) error
 	HasProfile(string) (bool, error)
+	ListProfiles() ([]string, error)
+	ProfileDelete(string) error
 }
 
 type lxdImages interface {
 	EnsureImageExists(series string, sources []lxdclient.Remote, copyProgressHandler func(string)) error
+	RemoveUnusedImages(unusedSince time.Time) ([]string, error)
 }
 
 func newRawProvider(ecfg *environConfig) (*rawProvider, error) {
@@ -125,9 +125,25 @@ func (s *environSuite) TestDestroyAPI(c *gc.C) {
 	}, {
 		FuncName: "Destroy",
 		Args:     nil,
+	}, {
+		FuncName: "ListProfiles",
+		Args:     nil,
 	}})
 }
 
+func (s *environSuite) TestDestroyRemovesConstraintsProfiles(c *gc.C) {
+	s.Client.Profiles = []string{"default", "juju-lxd", s.Prefix() + "cpu2", "juju-abcdef-cpu2"}
+	s.UpdateConfig(c, map[string]interface{}{"image-cache-expiry": "24h"})
+
+	err := s.Env.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	fwname := common.EnvFullName(s.Env.Config().UUID())
+	s.Stub.CheckCallNames(c, "Ports", "Destroy", "ListProfiles", "ProfileDelete", "RemoveUnusedImages")
+	s.Stub.CheckCall(c, 0, "Ports", fwname)
+	s.Stub.CheckCall(c, 3, "ProfileDelete", s.Prefix()+"cpu2")
+}
+
 func (s *environSuite) TestDestroyHostedModels(c *gc.C) {
 	s.UpdateConfig(c, map[string]interface{}{
 		"controller-uuid": s.Config.UUID(),
@@ -157,6 +173,7 @@ func (s *environSuite) TestDestroyHostedModels(c *gc.C) {
 	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{
 		{"Ports", []interface{}{fwname}},
 		{"Destroy", nil},
+		{"ListProfiles", nil},
 		{"Instances", []interface{}{prefix, lxdclient.AliveStatuses}},
 		{"RemoveInstances", []interface{}{prefix, []string{machine1.Name}}},
 	})
@@ -56,8 +56,9 @@ func (environProvider) Validate(cfg, old *config.Config) (valid *config.Config,
 		return ecfg.Config, nil
 	}
 
-	// The defaults should be set already, so we pass nil.
-	ecfg, err := newValidConfig(old, nil)
+	// The defaults should be set already, except for any attributes
+	// added since the base config was validated.
+	ecfg, err := newValidConfig(old, configDefaults)
 	if err != nil {
 		return nil, errors.Annotate(err, "invalid base config")
 	}
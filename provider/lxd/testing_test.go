@@ -9,6 +9,7 @@ import (
 	"crypto/tls"
 	"encoding/pem"
 	"os"
+	"time"
 
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
@@ -71,19 +72,22 @@ const (
 // These are stub config values for use in tests.
 var (
 	ConfigAttrs = testing.FakeConfig().Merge(testing.Attrs{
-		"type":        "lxd",
-		"remote-url":  "",
-		"client-cert": "",
-		"client-key":  "",
-		"server-cert": "",
-		"uuid":        "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+		"type":               "lxd",
+		"remote-url":         "",
+		"client-cert":        "",
+		"client-key":         "",
+		"server-cert":        "",
+		"image-remotes":      "",
+		"image-cache-expiry": "",
+		"uuid":               "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
 	})
 )
 
 // We test these here since they are not exported.
 var (
-	_ environs.Environ  = (*environ)(nil)
-	_ instance.Instance = (*environInstance)(nil)
+	_ environs.Environ              = (*environ)(nil)
+	_ environs.TaggedResourceLister = (*environ)(nil)
+	_ instance.Instance             = (*environInstance)(nil)
 )
 
 type BaseSuiteUnpatched struct {
@@ -310,6 +314,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	// Patch out all expensive external deps.
 	s.Env.raw = &rawProvider{
 		lxdInstances: s.Client,
+		lxdProfiles:  s.Client,
 		lxdImages:    s.Client,
 		Firewaller:   s.Firewaller,
 	}
@@ -356,10 +361,12 @@ func NewCustomBaseConfig(c *gc.C, updates map[string]interface{}) *config.Config
 }
 
 type ConfigValues struct {
-	RemoteURL  string
-	ClientCert string
-	ClientKey  string
-	ServerCert string
+	RemoteURL        string
+	ClientCert       string
+	ClientKey        string
+	ServerCert       string
+	ImageRemotes     string
+	ImageCacheExpiry string
 }
 
 func (cv ConfigValues) CheckCert(c *gc.C) {
@@ -418,6 +425,10 @@ func (ecfg *Config) Values(c *gc.C) (ConfigValues, map[string]interface{}) {
 			values.ClientKey = v.(string)
 		case cfgServerPEMCert:
 			values.ServerCert = v.(string)
+		case cfgImageRemotes:
+			values.ImageRemotes = v.(string)
+		case cfgImageCacheExpiry:
+			values.ImageCacheExpiry = v.(string)
 		default:
 			extras[k] = v
 		}
@@ -471,8 +482,10 @@ func (sc *stubCommon) DestroyEnv() error {
 type StubClient struct {
 	*gitjujutesting.Stub
 
-	Insts []lxdclient.Instance
-	Inst  *lxdclient.Instance
+	Insts    []lxdclient.Instance
+	Inst     *lxdclient.Instance
+	Profiles []string
+	Removed  []string
 }
 
 func (conn *StubClient) Instances(prefix string, statuses ...string) ([]lxdclient.Instance, error) {
@@ -511,6 +524,67 @@ func (conn *StubClient) EnsureImageExists(series string, _ []lxdclient.Remote, _
 	return nil
 }
 
+func (conn *StubClient) RemoveUnusedImages(unusedSince time.Time) ([]string, error) {
+	conn.AddCall("RemoveUnusedImages", unusedSince)
+	if err := conn.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return conn.Removed, nil
+}
+
+func (conn *StubClient) CreateProfile(name string, config map[string]string) error {
+	conn.AddCall("CreateProfile", name, config)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	conn.Profiles = append(conn.Profiles, name)
+	return nil
+}
+
+func (conn *StubClient) CreateProfileWithDevices(name string, config map[string]string, devices lxdclient.Devices) error {
+	conn.AddCall("CreateProfileWithDevices", name, config, devices)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	conn.Profiles = append(conn.Profiles, name)
+	return nil
+}
+
+func (conn *StubClient) HasProfile(name string) (bool, error) {
+	conn.AddCall("HasProfile", name)
+	if err := conn.NextErr(); err != nil {
+		return false, errors.Trace(err)
+	}
+
+	for _, profile := range conn.Profiles {
+		if profile == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (conn *StubClient) ListProfiles() ([]string, error) {
+	conn.AddCall("ListProfiles")
+	if err := conn.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return conn.Profiles, nil
+}
+
+func (conn *StubClient) ProfileDelete(name string) error {
+	conn.AddCall("ProfileDelete", name)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (conn *StubClient) Addresses(name string) ([]network.Address, error) {
 	conn.AddCall("Addresses", name)
 	if err := conn.NextErr(); err != nil {
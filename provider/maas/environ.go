@@ -858,10 +858,13 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 		}
 	}
 
-	// If no placement is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// If no placement is specified, then use the zones chosen by the
+	// provisioner; failing that, automatically spread across the known
+	// zones for optimal spread across the instance distribution group.
 	if args.Placement == "" {
+		availabilityZones = args.AvailabilityZones
+	}
+	if args.Placement == "" && len(availabilityZones) == 0 {
 		var group []instance.Id
 		var err error
 		if args.DistributionGroup != nil {
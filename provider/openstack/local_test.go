@@ -294,6 +294,24 @@ func (s *localServerSuite) TestStartStop(c *gc.C) {
 
 // If the bootstrap node is configured to require a public IP address,
 // bootstrapping fails if an address cannot be allocated.
+func (s *localServerSuite) TestBackupStorage(c *gc.C) {
+	stor, err := s.env.(*openstack.Environ).BackupStorage()
+	c.Assert(err, jc.ErrorIsNil)
+
+	content := "backup-content"
+	err = stor.Put("juju-backup.tar.gz", bytes.NewBufferString(content), int64(len(content)))
+	c.Assert(err, jc.ErrorIsNil)
+	names, err := stor.List("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"juju-backup.tar.gz"})
+	r, err := stor.Get("juju-backup.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, content)
+}
+
 func (s *localServerSuite) TestBootstrapFailsWhenPublicIPError(c *gc.C) {
 	coretesting.SkipIfPPC64EL(c, "lp:1425242")
 
@@ -0,0 +1,383 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+	"gopkg.in/goose.v1/nova"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+)
+
+// The goose revision we depend on has no Neutron client, so the few
+// Neutron calls used to place instances in provider networks are made
+// directly against the Networking API v2.0.
+
+// neutronSubnet holds the details of a Neutron subnet.
+type neutronSubnet struct {
+	Id        string `json:"id"`
+	NetworkId string `json:"network_id"`
+	CIDR      string `json:"cidr"`
+}
+
+// neutronFixedIP identifies a subnet from which a port is assigned
+// an address.
+type neutronFixedIP struct {
+	SubnetId  string `json:"subnet_id"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+// neutronAddressPair holds an address, or a CIDR of addresses, from
+// which traffic is allowed through a port in addition to the port's
+// own fixed addresses.
+type neutronAddressPair struct {
+	IPAddress  string `json:"ip_address"`
+	MACAddress string `json:"mac_address,omitempty"`
+}
+
+// neutronPort holds the details of a Neutron port.
+type neutronPort struct {
+	Id                  string               `json:"id,omitempty"`
+	Name                string               `json:"name"`
+	NetworkId           string               `json:"network_id"`
+	DeviceId            string               `json:"device_id,omitempty"`
+	FixedIPs            []neutronFixedIP     `json:"fixed_ips,omitempty"`
+	SecurityGroups      []string             `json:"security_groups"`
+	AllowedAddressPairs []neutronAddressPair `json:"allowed_address_pairs,omitempty"`
+
+	// PortSecurityEnabled is only set to disable port security,
+	// so that clouds without the port security extension are not
+	// sent it.
+	PortSecurityEnabled *bool `json:"port_security_enabled,omitempty"`
+}
+
+// spaceNetwork holds a Neutron network in which an instance is to be
+// given a port, and the subnets of the network the port may use.
+type spaceNetwork struct {
+	networkId string
+
+	// fixedSubnets holds the ids of the subnets from which the
+	// port must be given addresses, if any were chosen by the
+	// machine's spaces constraint.
+	fixedSubnets []string
+
+	// cidrs holds the CIDRs of the subnets the port may use.
+	cidrs []string
+}
+
+// needsSpaceNetworks returns whether the machine's spaces constraint
+// or endpoint bindings choose the networks of the instance.
+func needsSpaceNetworks(args environs.StartInstanceParams) bool {
+	if len(args.SubnetsToZones) > 0 {
+		return true
+	}
+	for _, spaceProviderId := range args.EndpointBindings {
+		if spaceProviderId != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// spaceNetworks returns the Neutron networks in which an instance must
+// have ports for the machine's spaces constraint and the spaces bound
+// to its units' endpoints. The subnets chosen by a spaces constraint
+// are Neutron subnet ids; the provider ids of spaces are the ids of
+// the Neutron networks they stand for. Spaces without provider ids
+// are not placed in a particular network.
+func spaceNetworks(client *neutronClient, args environs.StartInstanceParams) ([]spaceNetwork, error) {
+	var networkIds []string
+	networks := make(map[string]*spaceNetwork)
+	addNetwork := func(networkId string) *spaceNetwork {
+		network, ok := networks[networkId]
+		if !ok {
+			network = &spaceNetwork{networkId: networkId}
+			networks[networkId] = network
+			networkIds = append(networkIds, networkId)
+		}
+		return network
+	}
+
+	subnetIds := set.NewStrings()
+	for subnetId := range args.SubnetsToZones {
+		subnetIds.Add(string(subnetId))
+	}
+	for _, subnetId := range subnetIds.SortedValues() {
+		subnet, err := client.GetSubnet(subnetId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		network := addNetwork(subnet.NetworkId)
+		network.fixedSubnets = append(network.fixedSubnets, subnet.Id)
+		network.cidrs = append(network.cidrs, subnet.CIDR)
+	}
+
+	boundIds := set.NewStrings()
+	for _, spaceProviderId := range args.EndpointBindings {
+		if spaceProviderId != "" {
+			boundIds.Add(string(spaceProviderId))
+		}
+	}
+	for _, networkId := range boundIds.SortedValues() {
+		if _, ok := networks[networkId]; ok {
+			// The spaces constraint has already chosen
+			// subnets in this network.
+			continue
+		}
+		subnets, err := client.ListNetworkSubnets(networkId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		network := addNetwork(networkId)
+		for _, subnet := range subnets {
+			network.cidrs = append(network.cidrs, subnet.CIDR)
+		}
+	}
+
+	result := make([]spaceNetwork, len(networkIds))
+	for i, networkId := range networkIds {
+		result[i] = *networks[networkId]
+	}
+	return result, nil
+}
+
+// createSpacePorts creates the ports named portName through which an
+// instance is connected to the given networks. Port security is
+// disabled if the model's firewall mode is "none". Otherwise the
+// ports are given the instance's security groups, and traffic from
+// any address in the ports' subnets is allowed through them, so that
+// containers on the instance can be given routable addresses.
+//
+// If any port cannot be created, those already created are deleted.
+func createSpacePorts(
+	client *neutronClient,
+	portName string,
+	networks []spaceNetwork,
+	firewallMode string,
+	groups []nova.SecurityGroup,
+) (_ []neutronPort, err error) {
+	var ports []neutronPort
+	defer func() {
+		if err != nil {
+			deletePorts(client, ports)
+		}
+	}()
+	for _, network := range networks {
+		port := neutronPort{
+			Name:           portName,
+			NetworkId:      network.networkId,
+			SecurityGroups: []string{},
+		}
+		for _, subnetId := range network.fixedSubnets {
+			port.FixedIPs = append(port.FixedIPs, neutronFixedIP{SubnetId: subnetId})
+		}
+		if firewallMode == config.FwNone {
+			disabled := false
+			port.PortSecurityEnabled = &disabled
+		} else {
+			for _, group := range groups {
+				port.SecurityGroups = append(port.SecurityGroups, group.Id)
+			}
+			for _, cidr := range network.cidrs {
+				port.AllowedAddressPairs = append(port.AllowedAddressPairs, neutronAddressPair{IPAddress: cidr})
+			}
+		}
+		created, err := client.CreatePort(port)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		logger.Debugf("created port %q in network %q", created.Id, network.networkId)
+		ports = append(ports, created)
+	}
+	return ports, nil
+}
+
+// deletePorts deletes the given ports, logging any failure.
+func deletePorts(client *neutronClient, ports []neutronPort) {
+	for _, port := range ports {
+		if err := client.DeletePort(port.Id); err != nil {
+			logger.Warningf("%v", err)
+		}
+	}
+}
+
+// instancePorts returns the ports created by Juju for the given
+// instances, which are named after the model's machines.
+func instancePorts(client *neutronClient, modelUUID string, ids []instance.Id) ([]neutronPort, error) {
+	prefix := fmt.Sprintf("juju-%s-machine-", modelUUID)
+	var result []neutronPort
+	for _, id := range ids {
+		ports, err := client.ListDevicePorts(string(id))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, port := range ports {
+			if strings.HasPrefix(port.Name, prefix) {
+				result = append(result, port)
+			}
+		}
+	}
+	return result, nil
+}
+
+// neutronClient makes requests of the Neutron API at endpoint,
+// authenticated by the token returned by token.
+type neutronClient struct {
+	endpoint *url.URL
+	token    func() string
+	client   *http.Client
+}
+
+// getNetworkEndpointURL returns the Neutron endpoint for the given
+// region, or a NotFound error if the cloud has no Neutron service.
+func getNetworkEndpointURL(client endpointResolver, region string) (*url.URL, error) {
+	endpoint, ok := client.EndpointsForRegion(region)["network"]
+	if !ok {
+		return nil, errors.NotFoundf(`endpoint "network" in region %q`, region)
+	}
+	return url.Parse(endpoint)
+}
+
+var newNeutronClient = func(env *Environ) (*neutronClient, error) {
+	env.ecfgMutex.Lock()
+	authClient := env.client
+	verify := env.ecfgUnlocked.SSLHostnameVerification()
+	env.ecfgMutex.Unlock()
+
+	endpointURL, err := getNetworkEndpointURL(authClient, env.cloud.Region)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotSupported(err, "Neutron networking not supported")
+		}
+		return nil, errors.Annotate(err, "getting network endpoint")
+	}
+	hostnameVerification := utils.VerifySSLHostnames
+	if !verify {
+		hostnameVerification = utils.NoVerifySSLHostnames
+	}
+	return &neutronClient{
+		endpoint: endpointURL,
+		token:    authClient.Token,
+		client:   utils.GetHTTPClient(hostnameVerification),
+	}, nil
+}
+
+// GetSubnet returns the subnet with the given id.
+func (c *neutronClient) GetSubnet(id string) (neutronSubnet, error) {
+	var resp struct {
+		Subnet neutronSubnet `json:"subnet"`
+	}
+	if err := c.request("GET", "subnets/"+id, nil, nil, &resp); err != nil {
+		return neutronSubnet{}, errors.Annotatef(err, "cannot get subnet %q", id)
+	}
+	return resp.Subnet, nil
+}
+
+// ListNetworkSubnets returns the subnets of the given network.
+func (c *neutronClient) ListNetworkSubnets(networkId string) ([]neutronSubnet, error) {
+	var resp struct {
+		Subnets []neutronSubnet `json:"subnets"`
+	}
+	query := url.Values{"network_id": {networkId}}
+	if err := c.request("GET", "subnets", query, nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "cannot list subnets of network %q", networkId)
+	}
+	return resp.Subnets, nil
+}
+
+// CreatePort creates the given port, returning its details.
+func (c *neutronClient) CreatePort(port neutronPort) (neutronPort, error) {
+	req := struct {
+		Port neutronPort `json:"port"`
+	}{port}
+	var resp struct {
+		Port neutronPort `json:"port"`
+	}
+	if err := c.request("POST", "ports", nil, req, &resp); err != nil {
+		return neutronPort{}, errors.Annotatef(err, "cannot create port in network %q", port.NetworkId)
+	}
+	return resp.Port, nil
+}
+
+// ListDevicePorts returns the ports attached to the given device,
+// which for an instance is its id.
+func (c *neutronClient) ListDevicePorts(deviceId string) ([]neutronPort, error) {
+	var resp struct {
+		Ports []neutronPort `json:"ports"`
+	}
+	query := url.Values{"device_id": {deviceId}}
+	if err := c.request("GET", "ports", query, nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "cannot list ports of %q", deviceId)
+	}
+	return resp.Ports, nil
+}
+
+// DeletePort deletes the port with the given id. Deleting a port that
+// doesn't exist is not an error.
+func (c *neutronClient) DeletePort(id string) error {
+	err := c.request("DELETE", "ports/"+id, nil, nil, nil)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "cannot delete port %q", id)
+	}
+	return nil
+}
+
+// request makes a request of the Neutron API, marshalling req, if
+// not nil, as the request body and unmarshalling the response body
+// into resp, if not nil.
+func (c *neutronClient) request(method, path string, query url.Values, req, resp interface{}) error {
+	u := *c.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, "/v2.0") {
+		u.Path += "/v2.0"
+	}
+	u.Path += "/" + path
+	u.RawQuery = query.Encode()
+
+	var body []byte
+	if req != nil {
+		var err error
+		if body, err = json.Marshal(req); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	httpReq, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-Auth-Token", c.token())
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer httpResp.Body.Close()
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case httpResp.StatusCode == http.StatusNotFound:
+		return errors.NotFoundf("%s", u.Path)
+	case httpResp.StatusCode >= 300:
+		return fmt.Errorf("%s %s: %s: %s", method, u.Path, httpResp.Status, bytes.TrimSpace(respBody))
+	}
+	if resp == nil || len(respBody) == 0 {
+		return nil
+	}
+	return errors.Trace(json.Unmarshal(respBody, resp))
+}
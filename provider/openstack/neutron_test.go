@@ -0,0 +1,246 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v1/identity"
+	"gopkg.in/goose.v1/nova"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+type neutronSuite struct {
+	gitjujutesting.IsolationSuite
+
+	server   *httptest.Server
+	requests []*http.Request
+	bodies   []string
+	ports    []neutronPort
+	client   *neutronClient
+}
+
+var _ = gc.Suite(&neutronSuite{})
+
+var neutronSubnets = map[string]neutronSubnet{
+	"subnet-a": {Id: "subnet-a", NetworkId: "net-1", CIDR: "10.0.1.0/24"},
+	"subnet-b": {Id: "subnet-b", NetworkId: "net-1", CIDR: "10.0.2.0/24"},
+	"subnet-c": {Id: "subnet-c", NetworkId: "net-2", CIDR: "10.0.3.0/24"},
+}
+
+func (s *neutronSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.ports = nil
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveNeutron))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	endpoint, err := url.Parse(s.server.URL + "/")
+	c.Assert(err, jc.ErrorIsNil)
+	s.client = &neutronClient{
+		endpoint: endpoint,
+		token:    func() string { return "token" },
+		client:   http.DefaultClient,
+	}
+}
+
+func (s *neutronSuite) serveNeutron(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	s.requests = append(s.requests, req)
+	s.bodies = append(s.bodies, string(body))
+	if req.Header.Get("X-Auth-Token") != "token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var resp interface{}
+	switch {
+	case req.Method == "GET" && req.URL.Path == "/v2.0/subnets":
+		var subnets []neutronSubnet
+		for _, id := range []string{"subnet-a", "subnet-b", "subnet-c"} {
+			if subnet := neutronSubnets[id]; subnet.NetworkId == req.URL.Query().Get("network_id") {
+				subnets = append(subnets, subnet)
+			}
+		}
+		resp = map[string]interface{}{"subnets": subnets}
+	case req.Method == "GET" && len(req.URL.Path) > len("/v2.0/subnets/"):
+		subnet, ok := neutronSubnets[req.URL.Path[len("/v2.0/subnets/"):]]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		resp = map[string]interface{}{"subnet": subnet}
+	case req.Method == "GET" && req.URL.Path == "/v2.0/ports":
+		resp = map[string]interface{}{"ports": s.ports}
+	case req.Method == "POST" && req.URL.Path == "/v2.0/ports":
+		var create struct {
+			Port neutronPort `json:"port"`
+		}
+		if err := json.Unmarshal(body, &create); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if create.Port.NetworkId == "net-bad" {
+			http.Error(w, "bad network", http.StatusConflict)
+			return
+		}
+		create.Port.Id = "port-" + create.Port.NetworkId
+		resp = create
+	case req.Method == "DELETE":
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+type neutronEndpointResolver map[string]identity.ServiceURLs
+
+func (r neutronEndpointResolver) EndpointsForRegion(region string) identity.ServiceURLs {
+	return r[region]
+}
+
+func (s *neutronSuite) TestGetNetworkEndpointURL(c *gc.C) {
+	resolver := neutronEndpointResolver{
+		"west": {"network": "http://neutron.testing:9696"},
+	}
+	url, err := getNetworkEndpointURL(resolver, "west")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(url.String(), gc.Equals, "http://neutron.testing:9696")
+
+	_, err = getNetworkEndpointURL(resolver, "east")
+	c.Assert(err, gc.ErrorMatches, `endpoint "network" in region "east" not found`)
+}
+
+func (s *neutronSuite) TestNeedsSpaceNetworks(c *gc.C) {
+	c.Assert(needsSpaceNetworks(environs.StartInstanceParams{}), jc.IsFalse)
+	c.Assert(needsSpaceNetworks(environs.StartInstanceParams{
+		EndpointBindings: map[string]network.Id{"db": ""},
+	}), jc.IsFalse)
+	c.Assert(needsSpaceNetworks(environs.StartInstanceParams{
+		EndpointBindings: map[string]network.Id{"db": "net-2"},
+	}), jc.IsTrue)
+	c.Assert(needsSpaceNetworks(environs.StartInstanceParams{
+		SubnetsToZones: map[network.Id][]string{"subnet-a": {"zone1"}},
+	}), jc.IsTrue)
+}
+
+func (s *neutronSuite) TestSpaceNetworks(c *gc.C) {
+	networks, err := spaceNetworks(s.client, environs.StartInstanceParams{
+		SubnetsToZones: map[network.Id][]string{
+			"subnet-b": {"zone1"},
+			"subnet-a": {"zone1"},
+		},
+		EndpointBindings: map[string]network.Id{
+			"db":      "net-2",
+			"website": "net-1",
+			"admin":   "",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(networks, jc.DeepEquals, []spaceNetwork{{
+		networkId:    "net-1",
+		fixedSubnets: []string{"subnet-a", "subnet-b"},
+		cidrs:        []string{"10.0.1.0/24", "10.0.2.0/24"},
+	}, {
+		networkId: "net-2",
+		cidrs:     []string{"10.0.3.0/24"},
+	}})
+	c.Assert(s.requests, gc.HasLen, 3)
+	c.Assert(s.requests[2].URL.RawQuery, gc.Equals, "network_id=net-2")
+}
+
+func (s *neutronSuite) TestSpaceNetworksUnknownSubnet(c *gc.C) {
+	_, err := spaceNetworks(s.client, environs.StartInstanceParams{
+		SubnetsToZones: map[network.Id][]string{"subnet-x": {"zone1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot get subnet "subnet-x": .* not found`)
+}
+
+var testSpaceNetworks = []spaceNetwork{{
+	networkId:    "net-1",
+	fixedSubnets: []string{"subnet-a"},
+	cidrs:        []string{"10.0.1.0/24"},
+}, {
+	networkId: "net-2",
+	cidrs:     []string{"10.0.3.0/24"},
+}}
+
+func (s *neutronSuite) TestCreateSpacePorts(c *gc.C) {
+	groups := []nova.SecurityGroup{{Id: "group-1"}, {Id: "group-2"}}
+	ports, err := createSpacePorts(s.client, "juju-uuid-machine-0", testSpaceNetworks, config.FwInstance, groups)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 2)
+	c.Assert(ports[0].Id, gc.Equals, "port-net-1")
+	c.Assert(ports[1].Id, gc.Equals, "port-net-2")
+	c.Assert(s.bodies[0], jc.JSONEquals, map[string]interface{}{
+		"port": map[string]interface{}{
+			"name":            "juju-uuid-machine-0",
+			"network_id":      "net-1",
+			"fixed_ips":       []map[string]string{{"subnet_id": "subnet-a"}},
+			"security_groups": []string{"group-1", "group-2"},
+			"allowed_address_pairs": []map[string]string{{
+				"ip_address": "10.0.1.0/24",
+			}},
+		},
+	})
+	c.Assert(s.bodies[1], jc.JSONEquals, map[string]interface{}{
+		"port": map[string]interface{}{
+			"name":            "juju-uuid-machine-0",
+			"network_id":      "net-2",
+			"security_groups": []string{"group-1", "group-2"},
+			"allowed_address_pairs": []map[string]string{{
+				"ip_address": "10.0.3.0/24",
+			}},
+		},
+	})
+}
+
+func (s *neutronSuite) TestCreateSpacePortsFirewallModeNone(c *gc.C) {
+	groups := []nova.SecurityGroup{{Id: "group-1"}}
+	_, err := createSpacePorts(s.client, "juju-uuid-machine-0", testSpaceNetworks[1:], config.FwNone, groups)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.bodies[0], jc.JSONEquals, map[string]interface{}{
+		"port": map[string]interface{}{
+			"name":                  "juju-uuid-machine-0",
+			"network_id":            "net-2",
+			"security_groups":       []string{},
+			"port_security_enabled": false,
+		},
+	})
+}
+
+func (s *neutronSuite) TestCreateSpacePortsDeletesOnFailure(c *gc.C) {
+	networks := append(testSpaceNetworks[:1:1], spaceNetwork{networkId: "net-bad"})
+	_, err := createSpacePorts(s.client, "juju-uuid-machine-0", networks, config.FwInstance, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot create port in network "net-bad": POST /v2.0/ports: 409 Conflict: bad network`)
+	c.Assert(s.requests, gc.HasLen, 3)
+	c.Assert(s.requests[2].Method, gc.Equals, "DELETE")
+	c.Assert(s.requests[2].URL.Path, gc.Equals, "/v2.0/ports/port-net-1")
+}
+
+func (s *neutronSuite) TestInstancePorts(c *gc.C) {
+	s.ports = []neutronPort{
+		{Id: "port-1", Name: "juju-uuid-machine-0"},
+		{Id: "port-2", Name: "other"},
+	}
+	ports, err := instancePorts(s.client, "uuid", []instance.Id{"server-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 1)
+	c.Assert(ports[0].Id, gc.Equals, "port-1")
+	c.Assert(s.requests[0].URL.RawQuery, gc.Equals, "device_id=server-1")
+}
@@ -859,9 +859,12 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 	}
 
-	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// If no availability zone is specified, then use those chosen by the
+	// provisioner; failing that, automatically spread across the known
+	// zones for optimal spread across the instance distribution group.
+	if len(availabilityZones) == 0 {
+		availabilityZones = args.AvailabilityZones
+	}
 	if len(availabilityZones) == 0 {
 		var group []instance.Id
 		var err error
@@ -921,9 +924,24 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	}
 	logger.Debugf("openstack user data; %d bytes", len(userData))
 
+	// The machine's spaces constraint and endpoint bindings choose the
+	// Neutron networks the instance is connected to; the configured
+	// network is only used when they choose none.
+	var neutron *neutronClient
+	var chosenNetworks []spaceNetwork
+	if needsSpaceNetworks(args) {
+		neutron, err = newNeutronClient(e)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot choose networks for spaces")
+		}
+		chosenNetworks, err = spaceNetworks(neutron, args)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot choose networks for spaces")
+		}
+	}
 	var networks = e.firewaller.InitialNetworks()
 	usingNetwork := e.ecfg().network()
-	if usingNetwork != "" {
+	if usingNetwork != "" && len(chosenNetworks) == 0 {
 		networkId, err := e.resolveNetwork(usingNetwork)
 		if err != nil {
 			return nil, err
@@ -963,6 +981,16 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		names.NewMachineTag(args.InstanceConfig.MachineId),
 		e.Config().UUID(),
 	)
+	var spacePorts []neutronPort
+	if len(chosenNetworks) > 0 {
+		spacePorts, err = createSpacePorts(neutron, machineName, chosenNetworks, e.Config().FirewallMode(), groups)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot create ports")
+		}
+		for _, port := range spacePorts {
+			networks = append(networks, nova.ServerNetworks{PortId: port.Id})
+		}
+	}
 
 	tryStartNovaInstance := func(
 		attempts utils.AttemptStrategy,
@@ -1013,6 +1041,7 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	}
 	server, err := tryStartNovaInstanceAcrossAvailZones(shortAttempt, e.nova(), opts, availabilityZones)
 	if err != nil {
+		deletePorts(neutron, spacePorts)
 		return nil, errors.Trace(err)
 	}
 
@@ -1034,6 +1063,7 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 				// ignore the failure at this stage, just log it
 				logger.Debugf("failed to terminate instance %q: %v", inst.Id(), err)
 			}
+			deletePorts(neutron, spacePorts)
 			return nil, errors.Annotatef(err, "cannot assign public address %s to instance %q", publicIP.IP, inst.Id())
 		}
 		inst.floatingIP = publicIP
@@ -1063,10 +1093,22 @@ func (e *Environ) StopInstances(ids ...instance.Id) error {
 	if err != nil {
 		return err
 	}
+	// Ports created for the instances' spaces are not deleted with
+	// them, so they're gathered now, while they're still attached.
+	// Failing to find them shouldn't stop the instances.
+	var spacePorts []neutronPort
+	neutron, err := newNeutronClient(e)
+	if err == nil {
+		spacePorts, err = instancePorts(neutron, e.Config().UUID(), ids)
+	}
+	if err != nil && !errors.IsNotSupported(err) {
+		logger.Warningf("cannot find ports of instances %v: %v", ids, err)
+	}
 	logger.Debugf("terminating instances %v", ids)
 	if err := e.terminateInstances(ids); err != nil {
 		return err
 	}
+	deletePorts(neutron, spacePorts)
 	if securityGroupNames != nil {
 		return e.deleteSecurityGroups(securityGroupNames)
 	}
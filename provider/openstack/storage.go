@@ -17,6 +17,21 @@ import (
 	"github.com/juju/juju/environs/storage"
 )
 
+// BackupStorage returns the storage in which the controller's backup
+// archives are kept when its backup-target is "cloud". The archives
+// are kept in a private Swift container named after the controller
+// model; the container is created when the first archive is stored.
+func (e *Environ) BackupStorage() (storage.Storage, error) {
+	e.ecfgMutex.Lock()
+	client := e.client
+	e.ecfgMutex.Unlock()
+	return &openstackstorage{
+		containerName: "juju-backups-" + e.Config().UUID(),
+		containerACL:  swift.Private,
+		swift:         swift.New(client),
+	}, nil
+}
+
 // openstackstorage implements storage.Storage on an OpenStack container.
 type openstackstorage struct {
 	sync.Mutex
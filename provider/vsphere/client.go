@@ -16,7 +16,9 @@ import (
 	"github.com/juju/govmomi/list"
 	"github.com/juju/govmomi/object"
 	"github.com/juju/govmomi/property"
+	"github.com/juju/govmomi/vim25/methods"
 	"github.com/juju/govmomi/vim25/mo"
+	"github.com/juju/govmomi/vim25/types"
 	"golang.org/x/net/context"
 
 	"github.com/juju/juju/environs"
@@ -87,6 +89,8 @@ type instanceSpec struct {
 	isController   bool
 	controllerUUID string
 	apiPort        int
+	datastore      string
+	resourcePool   string
 }
 
 // CreateInstance create new vm in vsphere and run it
@@ -212,6 +216,26 @@ func (c *client) getVm(name string) (*mo.VirtualMachine, error) {
 	return &vm, nil
 }
 
+// Datastore returns the datastore with the given name in the
+// datacenter.
+func (c *client) Datastore(name string) (*object.Datastore, error) {
+	datastore, err := c.finder.Datastore(context.TODO(), name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return datastore, nil
+}
+
+// ResourcePool returns the resource pool with the given inventory path
+// in the datacenter.
+func (c *client) ResourcePool(path string) (*object.ResourcePool, error) {
+	resourcePool, err := c.finder.ResourcePool(context.TODO(), path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resourcePool, nil
+}
+
 //AvailabilityZones retuns list of all root compute resources in the system
 func (c *client) AvailabilityZones() ([]*mo.ComputeResource, error) {
 	folders, err := c.datacenter.Folders(context.TODO())
@@ -294,3 +318,231 @@ func (c *client) Subnets(inst instance.Id, ids []network.Id) ([]network.SubnetIn
 	}
 	return res, nil
 }
+
+// VirtualMachineDatastore returns the name of the datastore holding the
+// files of the named virtual machine.
+func (c *client) VirtualMachineDatastore(vmName string) (string, error) {
+	vm, err := c.getVm(vmName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if vm.Config == nil || vm.Config.Files.VmPathName == "" {
+		return "", errors.Errorf("cannot determine datastore of %q", vmName)
+	}
+	datastore, ok := datastoreName(vm.Config.Files.VmPathName)
+	if !ok {
+		return "", errors.Errorf("cannot determine datastore of %q", vmName)
+	}
+	return datastore, nil
+}
+
+// AttachDisk attaches the virtual disk at the given datastore path to
+// the named virtual machine and returns the attached disk. If sizeMiB
+// is non-zero, a new disk of that size is created at the path. If the
+// disk is already attached to the virtual machine, it is returned
+// without change.
+func (c *client) AttachDisk(vmName, path string, sizeMiB uint64) (*types.VirtualDisk, error) {
+	vm, err := c.getVm(vmName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	devices := virtualMachineDevices(vm)
+	if disk := findDisk(devices, path); disk != nil {
+		return disk, nil
+	}
+	controller, unit, err := freeSCSIUnit(devices)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot attach disk to %q", vmName)
+	}
+	thin := true
+	disk := &types.VirtualDisk{
+		VirtualDevice: types.VirtualDevice{
+			Key:           -1,
+			ControllerKey: controller,
+			UnitNumber:    unit,
+			Backing: &types.VirtualDiskFlatVer2BackingInfo{
+				VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{
+					FileName: path,
+				},
+				DiskMode:        string(types.VirtualDiskModePersistent),
+				ThinProvisioned: &thin,
+			},
+		},
+		CapacityInKB: int64(sizeMiB * 1024),
+	}
+	spec := &types.VirtualDeviceConfigSpec{
+		Operation: types.VirtualDeviceConfigSpecOperationAdd,
+		Device:    disk,
+	}
+	if sizeMiB != 0 {
+		spec.FileOperation = types.VirtualDeviceConfigSpecFileOperationCreate
+	}
+	if err := c.reconfigureDevices(vm, spec); err != nil {
+		return nil, errors.Annotatef(err, "cannot attach disk %q to %q", path, vmName)
+	}
+	// Read the disk back, to learn the UUID that it was assigned.
+	if vm, err = c.getVm(vmName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if disk := findDisk(virtualMachineDevices(vm), path); disk != nil {
+		return disk, nil
+	}
+	return nil, errors.Errorf("disk %q not attached to %q", path, vmName)
+}
+
+// DetachDisk detaches the virtual disk at the given datastore path from
+// the named virtual machine, leaving the disk's files in place. It is
+// not an error if the disk is not attached.
+func (c *client) DetachDisk(vmName, path string) error {
+	vm, err := c.getVm(vmName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	disk := findDisk(virtualMachineDevices(vm), path)
+	if disk == nil {
+		return nil
+	}
+	spec := &types.VirtualDeviceConfigSpec{
+		Operation: types.VirtualDeviceConfigSpecOperationRemove,
+		Device:    disk,
+	}
+	if err := c.reconfigureDevices(vm, spec); err != nil {
+		return errors.Annotatef(err, "cannot detach disk %q from %q", path, vmName)
+	}
+	return nil
+}
+
+// DeleteDisk deletes the files of the virtual disk at the given
+// datastore path. The disk must not be attached to any virtual machine.
+func (c *client) DeleteDisk(path string) error {
+	datacenter := c.datacenter.Reference()
+	req := types.DeleteVirtualDisk_Task{
+		This:       *c.connection.ServiceContent.VirtualDiskManager,
+		Name:       path,
+		Datacenter: &datacenter,
+	}
+	res, err := methods.DeleteVirtualDisk_Task(context.TODO(), c.connection.Client, &req)
+	if err != nil {
+		return errors.Annotatef(err, "cannot delete disk %q", path)
+	}
+	task := object.NewTask(c.connection.Client, res.Returnval)
+	if err := task.Wait(context.TODO()); err != nil {
+		return errors.Annotatef(err, "cannot delete disk %q", path)
+	}
+	return nil
+}
+
+// Disks returns the virtual disks attached to the vms whose names
+// match the given prefix.
+func (c *client) Disks(prefix string) ([]*types.VirtualDisk, error) {
+	vms, err := c.Instances(prefix)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var disks []*types.VirtualDisk
+	for _, vm := range vms {
+		for _, device := range virtualMachineDevices(vm) {
+			if disk, ok := device.(*types.VirtualDisk); ok {
+				disks = append(disks, disk)
+			}
+		}
+	}
+	return disks, nil
+}
+
+func (c *client) reconfigureDevices(vm *mo.VirtualMachine, spec *types.VirtualDeviceConfigSpec) error {
+	task, err := object.NewVirtualMachine(c.connection.Client, vm.Reference()).Reconfigure(
+		context.TODO(),
+		types.VirtualMachineConfigSpec{
+			DeviceChange: []types.BaseVirtualDeviceConfigSpec{spec},
+		},
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(task.Wait(context.TODO()))
+}
+
+func virtualMachineDevices(vm *mo.VirtualMachine) []types.BaseVirtualDevice {
+	if vm.Config == nil {
+		return nil
+	}
+	return vm.Config.Hardware.Device
+}
+
+// findDisk returns the virtual disk backed by the file at the given
+// datastore path, or nil if there is none.
+func findDisk(devices []types.BaseVirtualDevice, path string) *types.VirtualDisk {
+	for _, device := range devices {
+		disk, ok := device.(*types.VirtualDisk)
+		if ok && diskPath(disk) == path {
+			return disk
+		}
+	}
+	return nil
+}
+
+// diskPath returns the datastore path of the file backing the disk.
+func diskPath(disk *types.VirtualDisk) string {
+	if backing, ok := disk.Backing.(types.BaseVirtualDeviceFileBackingInfo); ok {
+		return backing.GetVirtualDeviceFileBackingInfo().FileName
+	}
+	return ""
+}
+
+// diskUUID returns the UUID of the disk, or the empty string if it
+// has none.
+func diskUUID(disk *types.VirtualDisk) string {
+	if backing, ok := disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo); ok {
+		return backing.Uuid
+	}
+	return ""
+}
+
+// scsiControllerUnit is the unit number taken by a SCSI controller on
+// its own bus.
+const scsiControllerUnit = 7
+
+// freeSCSIUnit returns the key of a SCSI controller and a unit number
+// on that controller that no device is using.
+func freeSCSIUnit(devices []types.BaseVirtualDevice) (int, int, error) {
+	var controllers []int
+	used := make(map[int]map[int]bool)
+	for _, device := range devices {
+		if _, ok := device.(types.BaseVirtualSCSIController); ok {
+			key := device.GetVirtualDevice().Key
+			controllers = append(controllers, key)
+			used[key] = make(map[int]bool)
+		}
+	}
+	if len(controllers) == 0 {
+		return 0, 0, errors.New("no SCSI controller found")
+	}
+	for _, device := range devices {
+		d := device.GetVirtualDevice()
+		if units, ok := used[d.ControllerKey]; ok {
+			units[d.UnitNumber] = true
+		}
+	}
+	for _, key := range controllers {
+		for unit := 0; unit < 16; unit++ {
+			if unit != scsiControllerUnit && !used[key][unit] {
+				return key, unit, nil
+			}
+		}
+	}
+	return 0, 0, errors.New("all SCSI units are in use")
+}
+
+// datastoreName returns the name of the datastore in a datastore path
+// of the form "[datastore] dir/file".
+func datastoreName(path string) (string, bool) {
+	if !strings.HasPrefix(path, "[") {
+		return "", false
+	}
+	end := strings.Index(path, "]")
+	if end < 0 {
+		return "", false
+	}
+	return path[1:end], true
+}
@@ -116,7 +116,11 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, img *OvaFi
 		CpuPower: &cpuPower,
 		RootDisk: &rootDisk,
 	}
-	zones, err := env.parseAvailabilityZones(args)
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	zones, err := env.parseAvailabilityZones(args, placement)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -143,6 +147,10 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, img *OvaFi
 			controllerUUID: args.ControllerUUID,
 			apiPort:        apiPort,
 		}
+		if placement != nil {
+			spec.datastore = placement.datastore
+			spec.resourcePool = placement.resourcePool
+		}
 		inst, err = env.client.CreateInstance(env.ecfg, spec)
 		if err != nil {
 			logger.Warningf("Error while trying to create instance in %s availability zone: %s", zone, err)
@@ -104,26 +104,55 @@ func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, e
 	return results, nil
 }
 
-// parsePlacement extracts the availability zone from the placement
-// string and returns it. If no zone is found there then an error is
-// returned.
-func (env *environ) parsePlacement(placement string) (*vmwareAvailZone, error) {
+// vmwarePlacement holds the locations named by a placement string.
+type vmwarePlacement struct {
+	// zone is the availability zone to start the instance in, or nil
+	// if the zone is to be chosen automatically.
+	zone *vmwareAvailZone
+
+	// datastore is the name of the datastore to hold the instance's
+	// disks, or empty if the zone's first datastore is to be used.
+	datastore string
+
+	// resourcePool is the inventory path of the resource pool to
+	// start the instance in, or empty if the zone's root resource
+	// pool is to be used.
+	resourcePool string
+}
+
+// parsePlacement extracts the availability zone, datastore and resource
+// pool from the placement string, which holds one or more comma
+// separated directives, e.g. "zone=z1,datastore=ds1". An error is
+// returned if any directive is unknown, or if the zone does not exist.
+func (env *environ) parsePlacement(placement string) (*vmwarePlacement, error) {
 	if placement == "" {
 		return nil, nil
 	}
 
-	pos := strings.IndexRune(placement, '=')
-	if pos == -1 {
-		return nil, errors.Errorf("unknown placement directive: %v", placement)
-	}
-
-	switch key, value := placement[:pos], placement[pos+1:]; key {
-	case "zone":
-		zone, err := env.availZone(value)
-		if err != nil {
-			return nil, errors.Trace(err)
+	var result vmwarePlacement
+	for _, directive := range strings.Split(placement, ",") {
+		pos := strings.IndexRune(directive, '=')
+		if pos == -1 {
+			return nil, errors.Errorf("unknown placement directive: %v", placement)
+		}
+		key, value := directive[:pos], directive[pos+1:]
+		if value == "" {
+			return nil, errors.Errorf("placement directive %q has no value", key)
+		}
+		switch key {
+		case "zone":
+			zone, err := env.availZone(value)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result.zone = zone
+		case "datastore":
+			result.datastore = value
+		case "resource-pool":
+			result.resourcePool = value
+		default:
+			return nil, errors.Errorf("unknown placement directive: %v", placement)
 		}
-		return zone, nil
 	}
-	return nil, errors.Errorf("unknown placement directive: %v", placement)
+	return &result, nil
 }
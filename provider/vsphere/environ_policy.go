@@ -18,9 +18,23 @@ import (
 // PrecheckInstance verifies that the provided series and constraints
 // are valid for use in creating an instance in this environment.
 func (env *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
-	if placement != "" {
-		if _, err := env.parsePlacement(placement); err != nil {
-			return err
+	if placement == "" {
+		return nil
+	}
+	p, err := env.parsePlacement(placement)
+	if err != nil {
+		return err
+	}
+	// Check that the datastore and resource pool exist, so that
+	// mistakes are reported when deploying rather than provisioning.
+	if p.datastore != "" {
+		if _, err := env.client.Datastore(p.datastore); err != nil {
+			return errors.Annotatef(err, "invalid datastore %q", p.datastore)
+		}
+	}
+	if p.resourcePool != "" {
+		if _, err := env.client.ResourcePool(p.resourcePool); err != nil {
+			return errors.Annotatef(err, "invalid resource pool %q", p.resourcePool)
 		}
 	}
 	return nil
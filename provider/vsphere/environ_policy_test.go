@@ -66,3 +66,13 @@ func (s *environPolSuite) TestSupportNetworks(c *gc.C) {
 
 	c.Check(isSupported, jc.IsFalse)
 }
+
+func (s *environPolSuite) TestPrecheckInstancePlacementNoValue(c *gc.C) {
+	err := s.Env.PrecheckInstance("trusty", constraints.Value{}, "datastore=")
+	c.Check(err, gc.ErrorMatches, `placement directive "datastore" has no value`)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceUnknownPlacement(c *gc.C) {
+	err := s.Env.PrecheckInstance("trusty", constraints.Value{}, "resource-pool=rp1,host=h1")
+	c.Check(err, gc.ErrorMatches, `unknown placement directive: resource-pool=rp1,host=h1`)
+}
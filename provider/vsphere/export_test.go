@@ -7,6 +7,7 @@ package vsphere
 
 import (
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/storage"
 )
 
 var (
@@ -18,3 +19,11 @@ func ExposeEnvFakeClient(env *environ) *fakeClient {
 }
 
 var _ environs.Environ = (*environ)(nil)
+
+func NewVolumeSource(client diskClient, datastore, prefix string) storage.VolumeSource {
+	return &volumeSource{
+		client:    client,
+		datastore: datastore,
+		prefix:    prefix,
+	}
+}
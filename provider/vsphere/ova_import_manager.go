@@ -78,9 +78,19 @@ func (m *ovaImportManager) importOva(ecfg *environConfig, instSpec *instanceSpec
 	}
 
 	ovfManager := object.NewOvfManager(m.client.connection.Client)
-	resourcePool := object.NewReference(m.client.connection.Client, *instSpec.zone.r.ResourcePool)
+	rp := object.NewResourcePool(m.client.connection.Client, *instSpec.zone.r.ResourcePool)
+	if instSpec.resourcePool != "" {
+		if rp, err = m.client.ResourcePool(instSpec.resourcePool); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	datastore := object.NewReference(m.client.connection.Client, instSpec.zone.r.Datastore[0])
-	spec, err := ovfManager.CreateImportSpec(context.TODO(), string(ovf), resourcePool, datastore, cisp)
+	if instSpec.datastore != "" {
+		if datastore, err = m.client.Datastore(instSpec.datastore); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	spec, err := ovfManager.CreateImportSpec(context.TODO(), string(ovf), rp, datastore, cisp)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -98,6 +108,11 @@ func (m *ovaImportManager) importOva(ecfg *environConfig, instSpec *instanceSpec
 	s.ExtraConfig = append(s.ExtraConfig, &types.OptionValue{
 		Key: metadataKeyControllerUUID, Value: instSpec.controllerUUID,
 	})
+	// Expose the UUIDs of disks to the guest, so that the disks backing
+	// volumes can be identified by their hardware IDs.
+	s.ExtraConfig = append(s.ExtraConfig, &types.OptionValue{
+		Key: "disk.EnableUUID", Value: "TRUE",
+	})
 	if instSpec.isController {
 		s.ExtraConfig = append(s.ExtraConfig, &types.OptionValue{
 			Key: metadataKeyIsController, Value: metadataValueIsController,
@@ -135,7 +150,6 @@ func (m *ovaImportManager) importOva(ecfg *environConfig, instSpec *instanceSpec
 			},
 		})
 	}
-	rp := object.NewResourcePool(m.client.connection.Client, *instSpec.zone.r.ResourcePool)
 	lease, err := rp.ImportVApp(context.TODO(), spec.ImportSpec, folders.VmFolder, nil)
 	if err != nil {
 		return nil, errors.Annotatef(err, "failed to import vapp")
@@ -1,20 +1,307 @@
 // Copyright 2016 Canonical Ltd.
 // Licensed under the AGPLv3, see LICENCE file for details.
 
+// +build !gccgo
+
 package vsphere
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/errors"
+	"github.com/juju/govmomi/vim25/types"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/storage"
 )
 
+const (
+	storageProviderType = storage.ProviderType("vsphere")
+
+	// datastoreAttr is the storage pool attribute naming the datastore
+	// to create volumes in. If it is not set, volumes are created in
+	// the datastore holding the machine they are first attached to,
+	// which is the one named by the machine's placement, if any.
+	datastoreAttr = "datastore"
+)
+
 // StorageProviderTypes implements storage.ProviderRegistry.
 func (*environ) StorageProviderTypes() []storage.ProviderType {
-	return nil
+	return []storage.ProviderType{storageProviderType}
 }
 
 // StorageProvider implements storage.ProviderRegistry.
-func (*environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == storageProviderType {
+		return &storageProvider{env}, nil
+	}
 	return nil, errors.NotFoundf("storage provider %q", t)
 }
+
+// diskClient is the subset of the client used to manage the virtual
+// disks that back volumes.
+type diskClient interface {
+	VirtualMachineDatastore(vmName string) (string, error)
+	AttachDisk(vmName, path string, sizeMiB uint64) (*types.VirtualDisk, error)
+	DetachDisk(vmName, path string) error
+	DeleteDisk(path string) error
+	Disks(prefix string) ([]*types.VirtualDisk, error)
+}
+
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// ValidateConfig is defined on the storage.Provider interface.
+func (*storageProvider) ValidateConfig(cfg *storage.Config) error {
+	if v, ok := cfg.Attrs()[datastoreAttr]; ok {
+		if _, ok := v.(string); !ok {
+			return errors.Errorf("expected string for %q, got %T", datastoreAttr, v)
+		}
+	}
+	return nil
+}
+
+// Supports is defined on the storage.Provider interface.
+func (*storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the storage.Provider interface.
+func (*storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the storage.Provider interface.
+func (*storageProvider) Dynamic() bool {
+	return true
+}
+
+// DefaultPools is defined on the storage.Provider interface.
+func (*storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// FilesystemSource is defined on the storage.Provider interface.
+func (*storageProvider) FilesystemSource(*storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// VolumeSource is defined on the storage.Provider interface.
+func (p *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	if err := p.ValidateConfig(cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	datastore, _ := cfg.ValueString(datastoreAttr)
+	return &volumeSource{
+		client:    p.env.client,
+		datastore: datastore,
+		prefix:    p.env.namespace.Prefix(),
+	}, nil
+}
+
+// volumeSource is a storage.VolumeSource whose volumes are VMDK files,
+// attached to the machines' vms as SCSI disks.
+//
+// The disks are created at the root of the datastore, and are named
+// like the machines' vms, with the model's namespace prefix, to keep
+// them apart from the disks of other models sharing the datastore.
+//
+// Volumes are not persistent: vSphere deletes the disks attached to a
+// vm when the vm is destroyed.
+type volumeSource struct {
+	client    diskClient
+	datastore string
+	prefix    string
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// ValidateVolumeParams is defined on the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	if params.Attachment == nil || params.Attachment.InstanceId == "" {
+		// The disk is created by attaching it to a vm, as vSphere
+		// cannot create a disk without knowing the adapter it will
+		// be attached through.
+		return errors.NotSupportedf("creating volume %q without attaching it", params.Tag.Id())
+	}
+	return nil
+}
+
+// CreateVolumes is defined on the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(params))
+	for i, p := range params {
+		if err := v.ValidateVolumeParams(p); err != nil {
+			results[i].Error = err
+			continue
+		}
+		volume, attachment, err := v.createVolume(p)
+		if err != nil {
+			logger.Errorf("could not create volume %q: %v", p.Tag.Id(), err)
+			results[i].Error = err
+			continue
+		}
+		results[i].Volume = volume
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createVolume(p storage.VolumeParams) (*storage.Volume, *storage.VolumeAttachment, error) {
+	vmName := string(p.Attachment.InstanceId)
+	datastore := v.datastore
+	if datastore == "" {
+		var err error
+		if datastore, err = v.client.VirtualMachineDatastore(vmName); err != nil {
+			return nil, nil, errors.Annotatef(err, "cannot choose datastore for volume %q", p.Tag.Id())
+		}
+	}
+	path := fmt.Sprintf("[%s] %s%s.vmdk", datastore, v.prefix, p.Tag.String())
+	disk, err := v.client.AttachDisk(vmName, path, p.Size)
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "cannot create volume %q", p.Tag.Id())
+	}
+	volume := &storage.Volume{
+		p.Tag,
+		volumeInfo(path, disk),
+	}
+	attachment := &storage.VolumeAttachment{
+		p.Tag,
+		p.Attachment.Machine,
+		storage.VolumeAttachmentInfo{},
+	}
+	return volume, attachment, nil
+}
+
+// ListVolumes is defined on the storage.VolumeSource interface.
+//
+// Only volumes attached to the model's machines are listed, as the
+// datastores are not searched for detached disks.
+func (v *volumeSource) ListVolumes() ([]string, error) {
+	disks, err := v.volumeDisks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var volumeIds []string
+	for path := range disks {
+		volumeIds = append(volumeIds, path)
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	disks, err := v.volumeDisks()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot describe volumes")
+	}
+	results := make([]storage.DescribeVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		disk, ok := disks[volumeId]
+		if !ok {
+			results[i].Error = errors.NotFoundf("volume %q", volumeId)
+			continue
+		}
+		info := volumeInfo(volumeId, disk)
+		results[i].VolumeInfo = &info
+	}
+	return results, nil
+}
+
+// volumeDisks returns the disks attached to the model's machines that
+// back the model's volumes, keyed by volume ID.
+func (v *volumeSource) volumeDisks() (map[string]*types.VirtualDisk, error) {
+	disks, err := v.client.Disks(v.prefix)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeDisks := make(map[string]*types.VirtualDisk)
+	for _, disk := range disks {
+		path := diskPath(disk)
+		if v.isVolume(path) {
+			volumeDisks[path] = disk
+		}
+	}
+	return volumeDisks, nil
+}
+
+// isVolume reports whether the disk file at the given datastore path
+// backs one of the model's volumes.
+func (v *volumeSource) isVolume(path string) bool {
+	if _, ok := datastoreName(path); !ok {
+		return false
+	}
+	file := strings.TrimSpace(path[strings.Index(path, "]")+1:])
+	return strings.HasPrefix(file, v.prefix+names.VolumeTagKind+"-") &&
+		strings.HasSuffix(file, ".vmdk") &&
+		!strings.Contains(file, "/")
+}
+
+// DestroyVolumes is defined on the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if !v.isVolume(volumeId) {
+			results[i] = errors.NotValidf("volume ID %q", volumeId)
+			continue
+		}
+		if err := v.client.DeleteDisk(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "cannot destroy volume %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+// AttachVolumes is defined on the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(params))
+	for i, p := range params {
+		if _, err := v.client.AttachDisk(string(p.InstanceId), p.VolumeId, 0); err != nil {
+			logger.Errorf("could not attach %q to %q: %v", p.VolumeId, p.InstanceId, err)
+			results[i].Error = errors.Annotatef(err, "cannot attach volume %q", p.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = &storage.VolumeAttachment{
+			p.Volume,
+			p.Machine,
+			storage.VolumeAttachmentInfo{},
+		}
+	}
+	return results, nil
+}
+
+// DetachVolumes is defined on the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(params))
+	for i, p := range params {
+		if err := v.client.DetachDisk(string(p.InstanceId), p.VolumeId); err != nil {
+			results[i] = errors.Annotatef(err, "cannot detach volume %q", p.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+// volumeInfo returns the information of the volume backed by the disk.
+func volumeInfo(volumeId string, disk *types.VirtualDisk) storage.VolumeInfo {
+	return storage.VolumeInfo{
+		VolumeId:   volumeId,
+		HardwareId: diskHardwareId(disk),
+		Size:       uint64(disk.CapacityInKB / 1024),
+		Persistent: false,
+	}
+}
+
+// diskHardwareId returns the hardware ID that the machine reports for
+// the disk, which is derived from the disk's UUID. vms only expose the
+// UUIDs of their disks when disk.EnableUUID is set.
+func diskHardwareId(disk *types.VirtualDisk) string {
+	uuid := diskUUID(disk)
+	if uuid == "" {
+		return ""
+	}
+	return "scsi-3" + strings.ToLower(strings.Replace(uuid, "-", "", -1))
+}
@@ -0,0 +1,277 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !gccgo
+
+package vsphere_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/govmomi/vim25/types"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/provider/vsphere"
+	"github.com/juju/juju/storage"
+)
+
+type storageProviderSuite struct {
+	vsphere.BaseSuite
+	provider storage.Provider
+}
+
+var _ = gc.Suite(&storageProviderSuite{})
+
+func (s *storageProviderSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	c.Assert(s.Env.StorageProviderTypes(), jc.DeepEquals, []storage.ProviderType{"vsphere"})
+	var err error
+	s.provider, err = s.Env.StorageProvider("vsphere")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageProviderSuite) TestStorageProviderUnknown(c *gc.C) {
+	_, err := s.Env.StorageProvider("ebs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageProviderSuite) TestValidateConfig(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", "vsphere", map[string]interface{}{
+		"datastore": "datastore2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.provider.ValidateConfig(cfg), jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("pool", "vsphere", map[string]interface{}{
+		"datastore": 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.provider.ValidateConfig(cfg), gc.ErrorMatches, `expected string for "datastore", got int`)
+}
+
+func (s *storageProviderSuite) TestSupports(c *gc.C) {
+	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	c.Assert(s.provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(s.provider.Dynamic(), jc.IsTrue)
+}
+
+func (s *storageProviderSuite) TestFilesystemSource(c *gc.C) {
+	_, err := s.provider.FilesystemSource(&storage.Config{})
+	c.Assert(err, gc.ErrorMatches, "filesystems not supported")
+}
+
+type volumeSourceSuite struct {
+	gitjujutesting.IsolationSuite
+
+	client *fakeDiskClient
+	source storage.VolumeSource
+}
+
+var _ = gc.Suite(&volumeSourceSuite{})
+
+func (s *volumeSourceSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.client = &fakeDiskClient{
+		datastore: "datastore1",
+		disks:     make(map[string]*types.VirtualDisk),
+	}
+	s.source = vsphere.NewVolumeSource(s.client, "", "juju-06f00d-")
+}
+
+func (s *volumeSourceSuite) volumeParams(size uint64) storage.VolumeParams {
+	return storage.VolumeParams{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     size,
+		Provider: "vsphere",
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine:    names.NewMachineTag("1"),
+				InstanceId: "juju-06f00d-1",
+			},
+			Volume: names.NewVolumeTag("0"),
+		},
+	}
+}
+
+func (s *volumeSourceSuite) TestCreateVolumes(c *gc.C) {
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{s.volumeParams(2048)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId:   "[datastore1] juju-06f00d-volume-0.vmdk",
+			HardwareId: "scsi-36000c29abcdef0123456789abcdef012",
+			Size:       2048,
+		},
+	})
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		names.NewVolumeTag("0"),
+		names.NewMachineTag("1"),
+		storage.VolumeAttachmentInfo{},
+	})
+	s.client.CheckCalls(c, []gitjujutesting.StubCall{
+		{"VirtualMachineDatastore", []interface{}{"juju-06f00d-1"}},
+		{"AttachDisk", []interface{}{"juju-06f00d-1", "[datastore1] juju-06f00d-volume-0.vmdk", uint64(2048)}},
+	})
+}
+
+func (s *volumeSourceSuite) TestCreateVolumesPoolDatastore(c *gc.C) {
+	s.source = vsphere.NewVolumeSource(s.client, "datastore2", "juju-06f00d-")
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{s.volumeParams(1024)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeId, gc.Equals, "[datastore2] juju-06f00d-volume-0.vmdk")
+	s.client.CheckCallNames(c, "AttachDisk")
+}
+
+func (s *volumeSourceSuite) TestCreateVolumesNotAttached(c *gc.C) {
+	params := s.volumeParams(1024)
+	params.Attachment = nil
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{params})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating volume "0" without attaching it not supported`)
+	s.client.CheckNoCalls(c)
+}
+
+func (s *volumeSourceSuite) TestCreateVolumesAttachError(c *gc.C) {
+	s.client.SetErrors(nil, errors.New("no SCSI controller found"))
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{s.volumeParams(1024)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `cannot create volume "0": no SCSI controller found`)
+}
+
+func (s *volumeSourceSuite) TestListVolumes(c *gc.C) {
+	s.client.addDisk("[datastore1] juju-06f00d-volume-0.vmdk", 1024)
+	s.client.addDisk("[datastore1] juju-06f00d-1/juju-06f00d-1.vmdk", 8192)
+	s.client.addDisk("[datastore1] juju-f00d06-volume-0.vmdk", 1024)
+	volumeIds, err := s.source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"[datastore1] juju-06f00d-volume-0.vmdk"})
+	s.client.CheckCall(c, 0, "Disks", "juju-06f00d-")
+}
+
+func (s *volumeSourceSuite) TestDescribeVolumes(c *gc.C) {
+	s.client.addDisk("[datastore1] juju-06f00d-volume-0.vmdk", 1024)
+	results, err := s.source.DescribeVolumes([]string{
+		"[datastore1] juju-06f00d-volume-0.vmdk",
+		"[datastore1] juju-06f00d-volume-1.vmdk",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   "[datastore1] juju-06f00d-volume-0.vmdk",
+		HardwareId: "scsi-36000c29abcdef0123456789abcdef012",
+		Size:       1024,
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
+	results, err := s.source.DestroyVolumes([]string{
+		"[datastore1] juju-06f00d-volume-0.vmdk",
+		"[datastore1] juju-06f00d-1/juju-06f00d-1.vmdk",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, `volume ID .* not valid`)
+	s.client.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DeleteDisk", []interface{}{"[datastore1] juju-06f00d-volume-0.vmdk"}},
+	})
+}
+
+func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
+	params := *s.volumeParams(0).Attachment
+	params.VolumeId = "[datastore1] juju-06f00d-volume-0.vmdk"
+	results, err := s.source.AttachVolumes([]storage.VolumeAttachmentParams{params})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		names.NewVolumeTag("0"),
+		names.NewMachineTag("1"),
+		storage.VolumeAttachmentInfo{},
+	})
+	s.client.CheckCalls(c, []gitjujutesting.StubCall{
+		{"AttachDisk", []interface{}{"juju-06f00d-1", "[datastore1] juju-06f00d-volume-0.vmdk", uint64(0)}},
+	})
+}
+
+func (s *volumeSourceSuite) TestDetachVolumes(c *gc.C) {
+	params := *s.volumeParams(0).Attachment
+	params.VolumeId = "[datastore1] juju-06f00d-volume-0.vmdk"
+	s.client.SetErrors(errors.New("boom"))
+	results, err := s.source.DetachVolumes([]storage.VolumeAttachmentParams{params})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], gc.ErrorMatches, `cannot detach volume "0": boom`)
+	s.client.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DetachDisk", []interface{}{"juju-06f00d-1", "[datastore1] juju-06f00d-volume-0.vmdk"}},
+	})
+}
+
+type fakeDiskClient struct {
+	gitjujutesting.Stub
+	datastore string
+	disks     map[string]*types.VirtualDisk
+	order     []string
+}
+
+func (c *fakeDiskClient) addDisk(path string, sizeMiB uint64) *types.VirtualDisk {
+	disk := &types.VirtualDisk{
+		VirtualDevice: types.VirtualDevice{
+			Backing: &types.VirtualDiskFlatVer2BackingInfo{
+				VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{
+					FileName: path,
+				},
+				Uuid: "6000C29a-bcde-f012-3456-789abcdef012",
+			},
+		},
+		CapacityInKB: int64(sizeMiB * 1024),
+	}
+	c.disks[path] = disk
+	c.order = append(c.order, path)
+	return disk
+}
+
+func (c *fakeDiskClient) VirtualMachineDatastore(vmName string) (string, error) {
+	c.MethodCall(c, "VirtualMachineDatastore", vmName)
+	return c.datastore, c.NextErr()
+}
+
+func (c *fakeDiskClient) AttachDisk(vmName, path string, sizeMiB uint64) (*types.VirtualDisk, error) {
+	c.MethodCall(c, "AttachDisk", vmName, path, sizeMiB)
+	if err := c.NextErr(); err != nil {
+		return nil, err
+	}
+	if disk, ok := c.disks[path]; ok {
+		return disk, nil
+	}
+	return c.addDisk(path, sizeMiB), nil
+}
+
+func (c *fakeDiskClient) DetachDisk(vmName, path string) error {
+	c.MethodCall(c, "DetachDisk", vmName, path)
+	return c.NextErr()
+}
+
+func (c *fakeDiskClient) DeleteDisk(path string) error {
+	c.MethodCall(c, "DeleteDisk", path)
+	return c.NextErr()
+}
+
+func (c *fakeDiskClient) Disks(prefix string) ([]*types.VirtualDisk, error) {
+	c.MethodCall(c, "Disks", prefix)
+	var disks []*types.VirtualDisk
+	for _, path := range c.order {
+		disks = append(disks, c.disks[path])
+	}
+	return disks, c.NextErr()
+}
@@ -28,6 +28,7 @@ type BaseSuite struct {
 	stub     *testing.Stub
 	facade   *stubFacade
 	response *api.UploadResult
+	status   params.UploadStatus
 }
 
 func (s *BaseSuite) SetUpTest(c *gc.C) {
@@ -44,13 +45,15 @@ func (s *BaseSuite) Do(req *http.Request, body io.ReadSeeker, resp interface{})
 		return errors.Trace(err)
 	}
 
-	result, ok := resp.(*api.UploadResult)
-	if !ok {
+	switch result := resp.(type) {
+	case *api.UploadResult:
+		*result = *s.response
+	case *params.UploadStatus:
+		*result = s.status
+	default:
 		msg := fmt.Sprintf("bad response type %T, expected api.UploadResult", resp)
 		return errors.NewNotValid(nil, msg)
 	}
-
-	*result = *s.response
 	return nil
 }
 
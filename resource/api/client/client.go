@@ -6,18 +6,31 @@ package client
 import (
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 )
 
+var logger = loggo.GetLogger("juju.resource.api.client")
+
+// uploadAttempt defines how an interrupted upload is resumed.
+var uploadAttempt = utils.AttemptStrategy{
+	Total: time.Minute,
+	Delay: 5 * time.Second,
+}
+
 // TODO(ericsnow) Move FacadeCaller to a component-central package.
 
 // FacadeCaller has the api/base.FacadeCaller methods needed for the component.
@@ -89,19 +102,73 @@ func (c Client) Upload(service, name, filename string, reader io.ReadSeeker) err
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := c.upload(uReq, reader); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+// upload sends the resource blob up to Juju. If the connection to
+// the API server fails part way through, the upload is resumed from
+// where the API server left off.
+func (c Client) upload(uReq api.UploadRequest, reader io.ReadSeeker) error {
+	var err error
+	for a := uploadAttempt.Start(); a.Next(); {
+		if err != nil {
+			logger.Infof("resuming resource upload after error: %v", err)
+			if uReq.Offset, err = c.uploadOffset(uReq); err != nil {
+				return errors.Annotate(err, "cannot resume resource upload")
+			}
+		}
+		err = c.sendUpload(uReq, reader)
+		if err == nil {
+			return nil
+		}
+		if _, ok := errors.Cause(err).(*url.Error); !ok {
+			// Only a failed connection may be recovered
+			// from by resuming the upload.
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(err)
+}
+
+// sendUpload sends the resource blob, from the offset of the upload
+// request, up to Juju.
+func (c Client) sendUpload(uReq api.UploadRequest, reader io.ReadSeeker) error {
 	req, err := uReq.HTTPRequest()
 	if err != nil {
 		return errors.Trace(err)
 	}
+	body := reader
+	if uReq.Offset > 0 {
+		if body, err = newOffsetReader(reader, uReq.Offset); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	var response api.UploadResult // ignored
-	if err := c.doer.Do(req, reader, &response); err != nil {
+	if err := c.doer.Do(req, body, &response); err != nil {
 		return errors.Trace(err)
 	}
-
 	return nil
 }
 
+// uploadOffset returns the number of bytes of the resource blob
+// received so far by the API server.
+func (c Client) uploadOffset(uReq api.UploadRequest) (int64, error) {
+	req, err := uReq.HTTPStatusRequest()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var status params.UploadStatus
+	if err := c.doer.Do(req, nil, &status); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return status.Offset, nil
+}
+
 // AddPendingResourcesArgs holds the arguments to AddPendingResources().
 type AddPendingResourcesArgs struct {
 	// ApplicationID identifies the application being deployed.
@@ -168,13 +235,7 @@ func (c Client) AddPendingResource(applicationID string, res charmresource.Resou
 			return "", errors.Trace(err)
 		}
 		uReq.PendingID = pendingID
-		req, err := uReq.HTTPRequest()
-		if err != nil {
-			return "", errors.Trace(err)
-		}
-
-		var response api.UploadResult // ignored
-		if err := c.doer.Do(req, reader, &response); err != nil {
+		if err := c.upload(uReq, reader); err != nil {
 			return "", errors.Trace(err)
 		}
 	}
@@ -196,3 +257,26 @@ func resolveErrors(errs []error) error {
 		return errors.New(strings.Join(msgs, "\n"))
 	}
 }
+
+// offsetReader exposes the content of an io.ReadSeeker from the given
+// offset, as if it started there.
+type offsetReader struct {
+	io.ReadSeeker
+	offset int64
+}
+
+func newOffsetReader(r io.ReadSeeker, offset int64) (*offsetReader, error) {
+	if _, err := r.Seek(offset, 0); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &offsetReader{ReadSeeker: r, offset: offset}, nil
+}
+
+// Seek implements io.Seeker.
+func (r *offsetReader) Seek(offset int64, whence int) (int64, error) {
+	if whence == 0 {
+		offset += r.offset
+	}
+	pos, err := r.ReadSeeker.Seek(offset, whence)
+	return pos - r.offset, err
+}
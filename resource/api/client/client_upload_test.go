@@ -6,7 +6,9 @@ package client_test
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/juju/errors"
@@ -46,12 +48,44 @@ func (s *UploadSuite) TestOkay(c *gc.C) {
 	req.Header.Set("Content-SHA384", fp.String())
 	req.Header.Set("Content-Length", fmt.Sprint(len(data)))
 	req.Header.Set("Content-Disposition", "form-data; filename=foo.zip")
+	req.Header.Set("X-Juju-Upload-Offset", "0")
+	req.Header.Set("X-Juju-Upload-Length", fmt.Sprint(len(data)))
 	req.ContentLength = int64(len(data))
 
 	s.stub.CheckCallNames(c, "Read", "Read", "Seek", "Do")
 	s.stub.CheckCall(c, 3, "Do", req, reader, s.response)
 }
 
+func (s *UploadSuite) TestResumesUpload(c *gc.C) {
+	s.PatchValue(client.UploadAttempt, utils.AttemptStrategy{Min: 2})
+	data := "<data>"
+	reader := strings.NewReader(data)
+	cl := client.NewClient(s.facade, s, s.facade)
+	s.status.Offset = 2
+	failure := &url.Error{Op: "Put", URL: "/applications/a-application/resources/spam", Err: io.ErrUnexpectedEOF}
+	s.stub.SetErrors(failure)
+
+	err := cl.Upload("a-application", "spam", "foo.zip", reader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Do", "Do", "Do")
+	calls := s.stub.Calls()
+	statusReq := calls[1].Args[0].(*http.Request)
+	c.Check(statusReq.Method, gc.Equals, "GET")
+	c.Check(statusReq.URL.Path, gc.Equals, "/applications/a-application/resources/spam")
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusReq.URL.Query().Get("upload"), gc.Equals, fp.String())
+
+	req := calls[2].Args[0].(*http.Request)
+	c.Check(req.Header.Get("X-Juju-Upload-Offset"), gc.Equals, "2")
+	c.Check(req.Header.Get("X-Juju-Upload-Length"), gc.Equals, fmt.Sprint(len(data)))
+	c.Check(req.ContentLength, gc.Equals, int64(len(data)-2))
+	body, err := ioutil.ReadAll(calls[2].Args[1].(io.Reader))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(body), gc.Equals, data[2:])
+}
+
 func (s *UploadSuite) TestBadService(c *gc.C) {
 	cl := client.NewClient(s.facade, s, s.facade)
 
@@ -144,6 +178,8 @@ func (s *UploadSuite) TestPendingResourceOkay(c *gc.C) {
 	req.ContentLength = int64(len(data))
 	req.URL.RawQuery = "pendingid=" + expected
 	req.Header.Set("Content-Disposition", "form-data; filename=file.zip")
+	req.Header.Set("X-Juju-Upload-Offset", "0")
+	req.Header.Set("X-Juju-Upload-Length", fmt.Sprint(len(data)))
 
 	s.stub.CheckCall(c, 4, "Do", req, reader, s.response)
 	c.Check(uploadID, gc.Equals, expected)
@@ -59,6 +59,9 @@ const (
 	MediaTypeFormData = "form-data"
 	// QueryParamPendingID is the query parameter we use to send up the pending id.
 	QueryParamPendingID = "pendingid"
+	// QueryParamUpload is the query parameter we use to ask for the
+	// progress of the resumable upload with the given fingerprint.
+	QueryParamUpload = "upload"
 )
 
 const (
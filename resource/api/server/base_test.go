@@ -5,6 +5,7 @@ package server_test
 
 import (
 	"io"
+	"io/ioutil"
 	"time"
 
 	"github.com/juju/errors"
@@ -80,6 +81,11 @@ type stubDataStore struct {
 	ReturnGetPendingResource    resource.Resource
 	ReturnSetResource           resource.Resource
 	ReturnUpdatePendingResource resource.Resource
+
+	// readData indicates that the data passed to
+	// UpdatePendingResource should be read into uploaded.
+	readUploaded bool
+	uploaded     string
 }
 
 func (s *stubDataStore) ListResources(service string) (resource.ServiceResources, error) {
@@ -132,6 +138,13 @@ func (s *stubDataStore) UpdatePendingResource(applicationID, pendingID, userID s
 	if err := s.stub.NextErr(); err != nil {
 		return resource.Resource{}, errors.Trace(err)
 	}
+	if s.readUploaded {
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return resource.Resource{}, errors.Trace(err)
+		}
+		s.uploaded = string(data)
+	}
 
 	return s.ReturnUpdatePendingResource, nil
 }
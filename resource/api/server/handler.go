@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/resource/api"
 )
 
@@ -21,6 +22,9 @@ type LegacyHTTPHandler struct {
 
 	// HandleUpload provides the upload functionality.
 	HandleUpload func(username string, st DataStore, req *http.Request) (*api.UploadResult, error)
+
+	// HandleUploadStatus reports the progress of a resumable upload.
+	HandleUploadStatus func(username string, st DataStore, req *http.Request) (*params.UploadStatus, error)
 }
 
 // TODO(ericsnow) Can username be extracted from the request?
@@ -36,6 +40,13 @@ func NewLegacyHTTPHandler(connect func(*http.Request) (DataStore, names.Tag, err
 			}
 			return uh.HandleRequest(req)
 		},
+		HandleUploadStatus: func(username string, st DataStore, req *http.Request) (*params.UploadStatus, error) {
+			uh := UploadHandler{
+				Username: username,
+				Store:    st,
+			}
+			return uh.UploadStatus(req)
+		},
 	}
 }
 
@@ -68,6 +79,13 @@ func (h *LegacyHTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Reques
 		}
 		api.SendHTTPStatusAndJSON(resp, http.StatusOK, &response)
 		logger.Infof("resource upload request successful")
+	case "GET":
+		status, err := h.HandleUploadStatus(username, st, req)
+		if err != nil {
+			api.SendHTTPError(resp, err)
+			return
+		}
+		api.SendHTTPStatusAndJSON(resp, http.StatusOK, status)
 	default:
 		api.SendHTTPError(resp, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
 	}
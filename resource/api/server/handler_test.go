@@ -164,6 +164,37 @@ func (s *LegacyHTTPHandlerSuite) TestServeHTTPPutSuccess(c *gc.C) {
 	})
 }
 
+func (s *LegacyHTTPHandlerSuite) TestServeHTTPGetUploadStatus(c *gc.C) {
+	expected, err := json.Marshal(&params.UploadStatus{Offset: 42})
+	c.Assert(err, jc.ErrorIsNil)
+	s.username = "youknowwho"
+	handler := server.LegacyHTTPHandler{
+		Connect:      s.connect,
+		HandleUpload: s.handleUpload,
+		HandleUploadStatus: func(username string, st server.DataStore, req *http.Request) (*params.UploadStatus, error) {
+			s.stub.AddCall("HandleUploadStatus", username, st, req)
+			return &params.UploadStatus{Offset: 42}, s.stub.NextErr()
+		},
+	}
+	s.req.Method = "GET"
+	copied := *s.req
+	req := &copied
+
+	handler.ServeHTTP(s.resp, req)
+
+	s.stub.CheckCallNames(c,
+		"Connect",
+		"HandleUploadStatus",
+		"Header",
+		"Header",
+		"WriteHeader",
+		"Write",
+	)
+	s.stub.CheckCall(c, 1, "HandleUploadStatus", "youknowwho", s.data, req)
+	s.stub.CheckCall(c, 4, "WriteHeader", http.StatusOK)
+	s.stub.CheckCall(c, 5, "Write", string(expected))
+}
+
 func (s *LegacyHTTPHandlerSuite) TestServeHTTPPutHandleUploadFailure(c *gc.C) {
 	s.username = "youknowwho"
 	handler := server.LegacyHTTPHandler{
@@ -6,11 +6,14 @@ package server
 import (
 	"io"
 	"net/http"
+	"os"
 	"path"
 
 	"github.com/juju/errors"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 )
@@ -66,6 +69,7 @@ func (uh UploadHandler) HandleRequest(req *http.Request) (*api.UploadResult, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	defer uploaded.Data.Close()
 
 	var stored resource.Resource
 	if uploaded.PendingID != "" {
@@ -115,15 +119,61 @@ func (uh UploadHandler) ReadResource(req *http.Request) (*UploadedResource, erro
 		return nil, errors.Trace(err)
 	}
 
+	data := req.Body
+	if uReq.Resumable {
+		// The body may carry only the remainder of the data, so
+		// it is held until all of it has been received.
+		key := uReq.UploadKey()
+		upload := common.ResumableUpload{Offset: uReq.Offset, Length: uReq.Size}
+		filename, err := common.ReceiveUpload(key, upload, req.Body)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		f, err := os.Open(filename)
+		if err != nil {
+			common.DiscardUpload(key)
+			return nil, errors.Trace(err)
+		}
+		data = &receivedUpload{File: f, key: key}
+	}
+
 	uploaded := &UploadedResource{
 		Service:   uReq.Service,
 		PendingID: uReq.PendingID,
 		Resource:  chRes,
-		Data:      req.Body,
+		Data:      data,
 	}
 	return uploaded, nil
 }
 
+// UploadStatus returns the progress of the resumable upload described
+// by the request.
+func (uh UploadHandler) UploadStatus(req *http.Request) (*params.UploadStatus, error) {
+	uReq, err := api.ExtractUploadStatusRequest(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offset, err := common.UploadOffset(uReq.UploadKey())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.UploadStatus{Offset: offset}, nil
+}
+
+// receivedUpload holds the data of a complete resumable upload,
+// discarding it when closed.
+type receivedUpload struct {
+	*os.File
+	key string
+}
+
+// Close implements io.Closer.
+func (r *receivedUpload) Close() error {
+	err := r.File.Close()
+	common.DiscardUpload(r.key)
+	return errors.Trace(err)
+}
+
 // updateResource returns a copy of the provided resource, updated with
 // the given information.
 func (uh UploadHandler) updateResource(res charmresource.Resource, fp charmresource.Fingerprint, size int64) (charmresource.Resource, error) {
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/juju/errors"
@@ -15,6 +16,7 @@ import (
 	gc "gopkg.in/check.v1"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/resource/api"
 	"github.com/juju/juju/resource/api/server"
 )
@@ -114,6 +116,49 @@ func (s *UploadSuite) TestHandleRequestPending(c *gc.C) {
 	})
 }
 
+func (s *UploadSuite) TestHandleRequestResumable(c *gc.C) {
+	content := "<some data>"
+	res, _ := newResource(c, "spam", "a-user", content)
+	res.PendingID = "resumable-id"
+	stored, _ := newResource(c, "spam", "", "")
+	stored.PendingID = "resumable-id"
+	s.data.ReturnGetPendingResource = stored
+	s.data.ReturnUpdatePendingResource = res
+	uh := server.UploadHandler{
+		Username: "a-user",
+		Store:    s.data,
+	}
+
+	// Send the first part of the data only.
+	req, _ := newResumableUploadRequest(c, "spam", "a-application", content, 0, 4)
+	req.URL.RawQuery += "&pendingid=resumable-id"
+	_, err := uh.HandleRequest(req)
+	c.Assert(err, gc.ErrorMatches, "upload incomplete: received 4 of 11 bytes")
+
+	statusReq := newUploadStatusRequest(c, "spam", "a-application", content)
+	statusReq.URL.RawQuery += "&pendingid=resumable-id"
+	status, err := uh.UploadStatus(statusReq)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, &params.UploadStatus{Offset: 4})
+
+	// Then resume the upload with the rest of it.
+	req, _ = newResumableUploadRequest(c, "spam", "a-application", content, 4, len(content))
+	req.URL.RawQuery += "&pendingid=resumable-id"
+	s.data.ReturnGetPendingResource = stored
+	s.data.ReturnUpdatePendingResource = res
+	s.data.readUploaded = true
+	result, err := uh.HandleRequest(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, &api.UploadResult{
+		Resource: api.Resource2API(res),
+	})
+	c.Check(s.data.uploaded, gc.Equals, content)
+
+	status, err = uh.UploadStatus(statusReq)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, &params.UploadStatus{Offset: 0})
+}
+
 func (s *UploadSuite) TestHandleRequestSetResourceFailure(c *gc.C) {
 	content := "<some data>"
 	stored, _ := newResource(c, "spam", "", "")
@@ -260,3 +305,26 @@ func newUploadRequest(c *gc.C, name, service, content string) (*http.Request, io
 
 	return req, body
 }
+
+func newResumableUploadRequest(c *gc.C, name, service, content string, start, end int) (*http.Request, io.Reader) {
+	req, _ := newUploadRequest(c, name, service, content)
+	body := strings.NewReader(content[start:end])
+	req.Body = ioutil.NopCloser(body)
+	req.ContentLength = int64(end - start)
+	req.Header.Set("Content-Length", fmt.Sprint(end-start))
+	req.Header.Set(params.UploadOffsetHeader, fmt.Sprint(start))
+	req.Header.Set(params.UploadLengthHeader, fmt.Sprint(len(content)))
+	return req, body
+}
+
+func newUploadStatusRequest(c *gc.C, name, service, content string) *http.Request {
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+
+	urlStr := "https://api:17017/applications/%s/resources/%s"
+	urlStr += "?:application=%s&:resource=%s&upload=%s" // ...added by the mux.
+	urlStr = fmt.Sprintf(urlStr, service, name, service, name, url.QueryEscape(fp.String()))
+	req, err := http.NewRequest("GET", urlStr, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return req
+}
@@ -8,11 +8,14 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/juju/errors"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/resource"
 )
 
@@ -35,6 +38,14 @@ type UploadRequest struct {
 
 	// PendingID is the pending ID to associate with this upload, if any.
 	PendingID string
+
+	// Resumable indicates that the data is sent in a resumable
+	// upload, held by the API server until all of it is received.
+	Resumable bool
+
+	// Offset is the offset within the data at which the request body
+	// starts, when resuming an upload.
+	Offset int64
 }
 
 // NewUploadRequest generates a new upload request for the given resource.
@@ -54,6 +65,7 @@ func NewUploadRequest(service, name, filename string, r io.ReadSeeker) (UploadRe
 		Filename:    filename,
 		Size:        content.Size,
 		Fingerprint: content.Fingerprint,
+		Resumable:   true,
 	}
 	return ur, nil
 }
@@ -86,9 +98,16 @@ func ExtractUploadRequest(req *http.Request) (UploadRequest, error) {
 		return ur, errors.Trace(err)
 	}
 
-	size, err := strconv.ParseInt(sizeRaw, 10, 64)
+	upload, resumable, err := common.ParseResumableUpload(req)
 	if err != nil {
-		return ur, errors.Annotate(err, "invalid size")
+		return ur, errors.Trace(err)
+	}
+	size := upload.Length
+	if !resumable {
+		size, err = strconv.ParseInt(sizeRaw, 10, 64)
+		if err != nil {
+			return ur, errors.Annotate(err, "invalid size")
+		}
 	}
 
 	ur = UploadRequest{
@@ -98,6 +117,27 @@ func ExtractUploadRequest(req *http.Request) (UploadRequest, error) {
 		Size:        size,
 		Fingerprint: fp,
 		PendingID:   pendingID,
+		Resumable:   resumable,
+		Offset:      upload.Offset,
+	}
+	return ur, nil
+}
+
+// ExtractUploadStatusRequest pulls the details of the resumable upload
+// whose progress is requested from the HTTP request.
+func ExtractUploadStatusRequest(req *http.Request) (UploadRequest, error) {
+	service, name := ExtractEndpointDetails(req.URL)
+	query := req.URL.Query()
+	fp, err := charmresource.ParseFingerprint(query.Get(QueryParamUpload))
+	if err != nil {
+		return UploadRequest{}, errors.Annotate(err, "invalid fingerprint")
+	}
+	ur := UploadRequest{
+		Service:     service,
+		Name:        name,
+		Fingerprint: fp,
+		PendingID:   query.Get(QueryParamPendingID),
+		Resumable:   true,
 	}
 	return ur, nil
 }
@@ -158,10 +198,14 @@ func (ur UploadRequest) HTTPRequest() (*http.Request, error) {
 
 	req.Header.Set(HeaderContentType, ContentTypeRaw)
 	req.Header.Set(HeaderContentSha384, ur.Fingerprint.String())
-	req.Header.Set(HeaderContentLength, fmt.Sprint(ur.Size))
+	req.Header.Set(HeaderContentLength, fmt.Sprint(ur.Size-ur.Offset))
 	setFilename(ur.Filename, req)
+	if ur.Resumable {
+		req.Header.Set(params.UploadOffsetHeader, fmt.Sprint(ur.Offset))
+		req.Header.Set(params.UploadLengthHeader, fmt.Sprint(ur.Size))
+	}
 
-	req.ContentLength = ur.Size
+	req.ContentLength = ur.Size - ur.Offset
 
 	if ur.PendingID != "" {
 		query := req.URL.Query()
@@ -172,6 +216,31 @@ func (ur UploadRequest) HTTPRequest() (*http.Request, error) {
 	return req, nil
 }
 
+// HTTPStatusRequest generates a new HTTP request for the progress of
+// the resumable upload.
+func (ur UploadRequest) HTTPStatusRequest() (*http.Request, error) {
+	urlStr := NewEndpointPath(ur.Service, ur.Name)
+	req, err := http.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	query := req.URL.Query()
+	query.Set(QueryParamUpload, ur.Fingerprint.String())
+	if ur.PendingID != "" {
+		query.Set(QueryParamPendingID, ur.PendingID)
+	}
+	req.URL.RawQuery = query.Encode()
+	return req, nil
+}
+
+// UploadKey returns the key identifying the resumable upload on the
+// API server. As the key includes the fingerprint of the data, uploads
+// of the same data to a resource share a key.
+func (ur UploadRequest) UploadKey() string {
+	return strings.Join([]string{"resource", ur.Service, ur.Name, ur.PendingID, ur.Fingerprint.String()}, "/")
+}
+
 type encoder interface {
 	Encode(charset, s string) string
 }
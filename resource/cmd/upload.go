@@ -55,6 +55,7 @@ func NewUploadCommand(deps UploadDeps) *UploadCommand {
 func (c *UploadCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "attach",
+		Aliases: []string{"attach-resource"},
 		Args:    "application name=file",
 		Purpose: "upload a file as a resource for an application",
 		Doc: `
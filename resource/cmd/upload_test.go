@@ -90,6 +90,7 @@ func (s *UploadSuite) TestInfo(c *gc.C) {
 
 	c.Check(info, jc.DeepEquals, &jujucmd.Info{
 		Name:    "attach",
+		Aliases: []string{"attach-resource"},
 		Args:    "application name=file",
 		Purpose: "upload a file as a resource for an application",
 		Doc: `
@@ -59,13 +59,15 @@ func (conn *Conn) send(call *Call) {
 	conn.reqId++
 	reqId := conn.reqId
 	conn.clientPending[reqId] = call
+	correlationId := conn.correlationId
 	conn.mutex.Unlock()
 
 	// Encode and send the request.
 	hdr := &Header{
-		RequestId: reqId,
-		Request:   call.Request,
-		Version:   1,
+		RequestId:     reqId,
+		Request:       call.Request,
+		Version:       1,
+		CorrelationId: correlationId,
 	}
 	params := call.Params
 	if params == nil {
@@ -132,6 +134,16 @@ func (call *Call) done() {
 	}
 }
 
+// SetCorrelationId sets the correlation id sent with every subsequent
+// request made over the connection, so that the server attributes them
+// all to the same user action. If id is empty, the server assigns a
+// new correlation id to each request.
+func (conn *Conn) SetCorrelationId(id string) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.correlationId = id
+}
+
 // Call invokes the named action on the object of the given type with the given
 // id. The returned values will be stored in response, which should be a pointer.
 // If the action fails remotely, the error will have a cause of type RequestError.
@@ -0,0 +1,51 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rpc
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newCorrelationId is used by the server to assign a correlation id to
+// requests which do not carry one. It is a variable so that tests can
+// make the assigned ids predictable.
+var newCorrelationId = NewCorrelationId
+
+// maxCorrelationIdLength is the length of the longest correlation id the
+// server accepts from a client.
+const maxCorrelationIdLength = 32
+
+// validCorrelationId reports whether the given correlation id, supplied
+// by a client, may be used as it is: ids must be non-empty hex strings
+// of at most maxCorrelationIdLength characters, so that they cannot
+// bloat or forge log lines.
+func validCorrelationId(id string) bool {
+	if id == "" || len(id) > maxCorrelationIdLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case '0' <= r && r <= '9':
+		case 'a' <= r && r <= 'f':
+		case 'A' <= r && r <= 'F':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// NewCorrelationId returns a new random correlation id, with which all
+// the requests made for a single user action may be tagged.
+func NewCorrelationId() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		// crypto/rand only fails if the system's source of
+		// randomness is unavailable, in which case nothing
+		// much else will work either.
+		panic(err)
+	}
+	return hex.EncodeToString(buf[:])
+}
@@ -51,6 +51,7 @@ func (s *dispatchSuite) SetUpSuite(c *gc.C) {
 func (s *dispatchSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	loggo.GetLogger("juju.rpc").SetLogLevel(loggo.TRACE)
+	s.PatchValue(rpc.NewCorrelationIdHook, func() string { return "deadbeef" })
 }
 
 func (s *dispatchSuite) TestWSWithoutParamsV0(c *gc.C) {
@@ -65,12 +66,17 @@ func (s *dispatchSuite) TestWSWithParamsV0(c *gc.C) {
 
 func (s *dispatchSuite) TestWSWithoutParamsV1(c *gc.C) {
 	resp := s.request(c, `{"request-id":1,"type": "DispatchDummy","id": "without","request":"DoSomething"}`)
-	c.Assert(resp, gc.Equals, `{"request-id":1,"response":{}}`)
+	c.Assert(resp, gc.Equals, `{"request-id":1,"response":{},"correlation-id":"deadbeef"}`)
 }
 
 func (s *dispatchSuite) TestWSWithParamsV1(c *gc.C) {
 	resp := s.request(c, `{"request-id":2,"type": "DispatchDummy","id": "with","request":"DoSomething", "params": {}}`)
-	c.Assert(resp, gc.Equals, `{"request-id":2,"response":{}}`)
+	c.Assert(resp, gc.Equals, `{"request-id":2,"response":{},"correlation-id":"deadbeef"}`)
+}
+
+func (s *dispatchSuite) TestWSWithCorrelationIdV1(c *gc.C) {
+	resp := s.request(c, `{"request-id":3,"type": "DispatchDummy","id": "without","request":"DoSomething","correlation-id":"cafef00d"}`)
+	c.Assert(resp, gc.Equals, `{"request-id":3,"response":{},"correlation-id":"cafef00d"}`)
 }
 
 // request performs one request to the test server via websockets.
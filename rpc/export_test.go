@@ -12,3 +12,7 @@ const CodeNotImplemented = codeNotImplemented
 func (c *Conn) ClientRequestID() uint64 {
 	return c.reqId
 }
+
+// NewCorrelationIdHook exposes the function the server uses to assign
+// correlation ids to requests, so that tests can make them predictable.
+var NewCorrelationIdHook = &newCorrelationId
@@ -63,15 +63,16 @@ type inMsgV0 struct {
 }
 
 type inMsgV1 struct {
-	RequestId uint64          `json:"request-id"`
-	Type      string          `json:"type"`
-	Version   int             `json:"version"`
-	Id        string          `json:"id"`
-	Request   string          `json:"request"`
-	Params    json.RawMessage `json:"params"`
-	Error     string          `json:"error"`
-	ErrorCode string          `json:"error-code"`
-	Response  json.RawMessage `json:"response"`
+	RequestId     uint64          `json:"request-id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	Id            string          `json:"id"`
+	Request       string          `json:"request"`
+	Params        json.RawMessage `json:"params"`
+	Error         string          `json:"error"`
+	ErrorCode     string          `json:"error-code"`
+	Response      json.RawMessage `json:"response"`
+	CorrelationId string          `json:"correlation-id"`
 }
 
 // outMsg holds an outgoing message.
@@ -88,15 +89,16 @@ type outMsgV0 struct {
 }
 
 type outMsgV1 struct {
-	RequestId uint64      `json:"request-id,omitempty"`
-	Type      string      `json:"type,omitempty"`
-	Version   int         `json:"version,omitempty"`
-	Id        string      `json:"id,omitempty"`
-	Request   string      `json:"request,omitempty"`
-	Params    interface{} `json:"params,omitempty"`
-	Error     string      `json:"error,omitempty"`
-	ErrorCode string      `json:"error-code,omitempty"`
-	Response  interface{} `json:"response,omitempty"`
+	RequestId     uint64      `json:"request-id,omitempty"`
+	Type          string      `json:"type,omitempty"`
+	Version       int         `json:"version,omitempty"`
+	Id            string      `json:"id,omitempty"`
+	Request       string      `json:"request,omitempty"`
+	Params        interface{} `json:"params,omitempty"`
+	Error         string      `json:"error,omitempty"`
+	ErrorCode     string      `json:"error-code,omitempty"`
+	Response      interface{} `json:"response,omitempty"`
+	CorrelationId string      `json:"correlation-id,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -140,6 +142,7 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
 	hdr.Version = version
+	hdr.CorrelationId = c.msg.CorrelationId
 	return nil
 }
 
@@ -265,13 +268,14 @@ func newOutMsgV0(hdr *rpc.Header, body interface{}) outMsgV0 {
 // reflect, but no.
 func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 	result := outMsgV1{
-		RequestId: hdr.RequestId,
-		Type:      hdr.Request.Type,
-		Version:   hdr.Request.Version,
-		Id:        hdr.Request.Id,
-		Request:   hdr.Request.Action,
-		Error:     hdr.Error,
-		ErrorCode: hdr.ErrorCode,
+		RequestId:     hdr.RequestId,
+		Type:          hdr.Request.Type,
+		Version:       hdr.Request.Version,
+		Id:            hdr.Request.Id,
+		Request:       hdr.Request.Action,
+		Error:         hdr.Error,
+		ErrorCode:     hdr.ErrorCode,
+		CorrelationId: hdr.CorrelationId,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
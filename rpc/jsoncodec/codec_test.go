@@ -115,6 +115,19 @@ func (*suite) TestRead(c *gc.C) {
 			Version: 1,
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 5, "type": "foo", "id": "id", "request": "frob", "correlation-id": "deadbeef"}`,
+		expectHdr: rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Id:     "id",
+				Action: "frob",
+			},
+			Version:       1,
+			CorrelationId: "deadbeef",
+		},
+		expectBody: new(map[string]interface{}),
 	}} {
 		c.Logf("test %d", i)
 		codec := jsoncodec.New(&testConn{
@@ -236,6 +249,14 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"request-id": 4, "type": "foo", "version": 2, "request": "frob", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId:     5,
+			Version:       1,
+			CorrelationId: "deadbeef",
+		},
+		body:   &value{X: "result"},
+		expect: `{"request-id": 5, "response": {"X": "result"}, "correlation-id": "deadbeef"}`,
 	}} {
 		c.Logf("test %d", i)
 		var conn testConn
@@ -7,8 +7,10 @@ import (
 	"reflect"
 
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/correlation"
 	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/testing"
 )
@@ -32,6 +34,7 @@ func (*reflectSuite) TestTypeOf(c *gc.C) {
 	expect := map[string]reflect.Type{
 		"CallbackMethods":  reflect.TypeOf(&CallbackMethods{}),
 		"ChangeAPIMethods": reflect.TypeOf(&ChangeAPIMethods{}),
+		"ContextMethods":   reflect.TypeOf(&ContextMethods{}),
 		"DelayedMethods":   reflect.TypeOf(&DelayedMethods{}),
 		"ErrorMethods":     reflect.TypeOf(&ErrorMethods{}),
 		"InterfaceMethods": reflect.TypeOf((*InterfaceMethods)(nil)).Elem(),
@@ -94,6 +97,27 @@ func (*reflectSuite) TestObjTypeOf(c *gc.C) {
 	c.Check(m, gc.DeepEquals, rpcreflect.ObjMethod{})
 }
 
+func (*reflectSuite) TestObjTypeOfContextMethods(c *gc.C) {
+	objType := rpcreflect.ObjTypeOf(reflect.TypeOf(&ContextMethods{}))
+	c.Check(objType.DiscardedMethods(), gc.HasLen, 0)
+	c.Check(objType.MethodNames(), jc.SameContents, []string{"CorrelationId", "Echo"})
+
+	m, err := objType.Method("CorrelationId")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.Params, gc.IsNil)
+	c.Check(m.Result, gc.Equals, reflect.TypeOf(stringVal{}))
+
+	m, err = objType.Method("Echo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.Params, gc.Equals, reflect.TypeOf(stringVal{}))
+	c.Check(m.Result, gc.Equals, reflect.TypeOf(stringVal{}))
+
+	ctx := correlation.WithId(context.Background(), "deadbeef")
+	r, err := m.Call(ctx, reflect.ValueOf(&ContextMethods{}), reflect.ValueOf(stringVal{"arg"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(r.Interface(), gc.Equals, stringVal{"deadbeef arg"})
+}
+
 func (*reflectSuite) TestValueOf(c *gc.C) {
 	v := rpcreflect.ValueOf(reflect.ValueOf(nil))
 	c.Check(v.IsValid(), jc.IsFalse)
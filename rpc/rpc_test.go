@@ -9,15 +9,18 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/correlation"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -32,6 +35,11 @@ type rpcSuite struct {
 
 var _ = gc.Suite(&rpcSuite{})
 
+func (s *rpcSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.PatchValue(rpc.NewCorrelationIdHook, func() string { return "deadbeef" })
+}
+
 type callInfo struct {
 	rcvr   interface{}
 	method string
@@ -96,6 +104,10 @@ func (r *Root) Discard2(id string) error { return nil }
 
 func (r *Root) Discard3(id string) int { return 0 }
 
+func (r *Root) ContextMethods(string) (*ContextMethods, error) {
+	return &ContextMethods{}, nil
+}
+
 func (r *Root) CallbackMethods(string) (*CallbackMethods, error) {
 	return &CallbackMethods{r}, nil
 }
@@ -216,6 +228,16 @@ func (e *ErrorMethods) Call() error {
 	return e.err
 }
 
+type ContextMethods struct{}
+
+func (*ContextMethods) CorrelationId(ctx context.Context) stringVal {
+	return stringVal{correlation.Id(ctx)}
+}
+
+func (*ContextMethods) Echo(ctx context.Context, s stringVal) (stringVal, error) {
+	return stringVal{correlation.Id(ctx) + " " + s.Val}, nil
+}
+
 type CallbackMethods struct {
 	root *Root
 }
@@ -297,7 +319,7 @@ func (c customMethodCaller) ResultType() reflect.Type {
 	return c.objMethod.Result
 }
 
-func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+func (c customMethodCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
 	sm, err := c.root.SimpleMethods(objId)
 	if err != nil {
 		return reflect.Value{}, err
@@ -307,7 +329,7 @@ func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value
 		logger.Errorf("got the wrong type back, expected %s got %T", c.expectedType, obj)
 	}
 	logger.Debugf("calling: %T %v %#v", obj, obj, c.objMethod)
-	return c.objMethod.Call(obj, arg)
+	return c.objMethod.Call(ctx, obj, arg)
 }
 
 func (cc *CustomRoot) Kill() {
@@ -505,9 +527,10 @@ func (root *Root) assertServerNotified(c *gc.C, p testCallParams, requestId uint
 	c.Assert(p.serverNotifier.serverRequests, gc.HasLen, 1)
 	serverReq := p.serverNotifier.serverRequests[0]
 	c.Assert(serverReq.hdr, gc.DeepEquals, rpc.Header{
-		RequestId: requestId,
-		Request:   p.request(),
-		Version:   1,
+		RequestId:     requestId,
+		Request:       p.request(),
+		Version:       1,
+		CorrelationId: "deadbeef",
 	})
 	if p.narg > 0 {
 		c.Assert(serverReq.body, gc.Equals, stringVal{"arg"})
@@ -526,18 +549,89 @@ func (root *Root) assertServerNotified(c *gc.C, p testCallParams, requestId uint
 	}
 	if p.retErr && p.testErr {
 		c.Assert(serverReply.hdr, gc.Equals, rpc.Header{
-			RequestId: requestId,
-			Error:     p.errorMessage(),
-			Version:   1,
+			RequestId:     requestId,
+			Error:         p.errorMessage(),
+			Version:       1,
+			CorrelationId: "deadbeef",
 		})
 	} else {
 		c.Assert(serverReply.hdr, gc.Equals, rpc.Header{
-			RequestId: requestId,
-			Version:   1,
+			RequestId:     requestId,
+			Version:       1,
+			CorrelationId: "deadbeef",
 		})
 	}
 }
 
+func (*rpcSuite) TestClientCorrelationId(c *gc.C) {
+	root := SimpleRoot()
+	client, srvDone, serverNotifier := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+	client.SetCorrelationId("cafef00d")
+	err := client.Call(rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(serverNotifier.serverRequests, gc.HasLen, 1)
+	c.Assert(serverNotifier.serverRequests[0].hdr.CorrelationId, gc.Equals, "cafef00d")
+	c.Assert(serverNotifier.serverReplies, gc.HasLen, 1)
+	c.Assert(serverNotifier.serverReplies[0].hdr.CorrelationId, gc.Equals, "cafef00d")
+}
+
+func (*rpcSuite) TestClientCorrelationIdInvalid(c *gc.C) {
+	for i, id := range []string{
+		"not hex",
+		"cafef00d\nforged log line",
+		strings.Repeat("a", 33),
+	} {
+		c.Logf("test %d: %q", i, id)
+		root := SimpleRoot()
+		client, srvDone, serverNotifier := newRPCClientServer(c, root, nil, false)
+		client.SetCorrelationId(id)
+		err := client.Call(rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+		c.Check(err, jc.ErrorIsNil)
+		closeClient(c, client, srvDone)
+
+		c.Assert(serverNotifier.serverRequests, gc.HasLen, 1)
+		c.Check(serverNotifier.serverRequests[0].hdr.CorrelationId, gc.Equals, "deadbeef")
+	}
+}
+
+func (*rpcSuite) TestClientCorrelationIdMaxLength(c *gc.C) {
+	id := strings.Repeat("A0", 16)
+	root := SimpleRoot()
+	client, srvDone, serverNotifier := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+	client.SetCorrelationId(id)
+	err := client.Call(rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(serverNotifier.serverRequests, gc.HasLen, 1)
+	c.Assert(serverNotifier.serverRequests[0].hdr.CorrelationId, gc.Equals, id)
+}
+
+func (*rpcSuite) TestContextMethods(c *gc.C) {
+	root := SimpleRoot()
+	client, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	var r stringVal
+	err := client.Call(rpc.Request{"ContextMethods", 0, "", "CorrelationId"}, nil, &r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r, gc.Equals, stringVal{"deadbeef"})
+
+	client.SetCorrelationId("cafef00d")
+	err = client.Call(rpc.Request{"ContextMethods", 0, "", "Echo"}, stringVal{"arg"}, &r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r, gc.Equals, stringVal{"cafef00d arg"})
+}
+
+func (*rpcSuite) TestNewCorrelationId(c *gc.C) {
+	id0 := rpc.NewCorrelationId()
+	id1 := rpc.NewCorrelationId()
+	c.Assert(id0, gc.Matches, "[0-9a-f]{16}")
+	c.Assert(id1, gc.Not(gc.Equals), id0)
+}
+
 func (*rpcSuite) TestInterfaceMethods(c *gc.C) {
 	root := SimpleRoot()
 	client, srvDone, serverNotifier := newRPCClientServer(c, root, nil, false)
@@ -909,9 +1003,10 @@ func testBadCall(
 	}
 	c.Assert(serverNotifier.serverRequests[0], gc.DeepEquals, requestEvent{
 		hdr: rpc.Header{
-			RequestId: client.ClientRequestID(),
-			Request:   req,
-			Version:   1,
+			RequestId:     client.ClientRequestID(),
+			Request:       req,
+			Version:       1,
+			CorrelationId: "deadbeef",
 		},
 		body: expectBody,
 	})
@@ -921,10 +1016,11 @@ func testBadCall(
 	serverReply := serverNotifier.serverReplies[0]
 	c.Assert(serverReply, gc.DeepEquals, replyEvent{
 		hdr: rpc.Header{
-			RequestId: client.ClientRequestID(),
-			Error:     expectedErr,
-			ErrorCode: expectedErrCode,
-			Version:   1,
+			RequestId:     client.ClientRequestID(),
+			Error:         expectedErr,
+			ErrorCode:     expectedErrCode,
+			Version:       1,
+			CorrelationId: "deadbeef",
 		},
 		req:  req,
 		body: struct{}{},
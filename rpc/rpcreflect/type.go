@@ -8,11 +8,14 @@ import (
 	"reflect"
 	"sort"
 	"sync"
+
+	"golang.org/x/net/context"
 )
 
 var (
-	errorType  = reflect.TypeOf((*error)(nil)).Elem()
-	stringType = reflect.TypeOf("")
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	stringType  = reflect.TypeOf("")
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
 )
 
 var (
@@ -215,7 +218,9 @@ type ObjMethod struct {
 	// Call calls the method with the given argument
 	// on the given receiver value. If the method does
 	// not return a value, the returned value will not be valid.
-	Call func(rcvr, arg reflect.Value) (reflect.Value, error)
+	// If the method takes a context.Context as its first
+	// argument, it is passed ctx.
+	Call func(ctx context.Context, rcvr, arg reflect.Value) (reflect.Value, error)
 }
 
 // ObjTypeOf returns information on all RPC methods
@@ -269,7 +274,7 @@ func newMethod(m reflect.Method, receiverKind reflect.Kind) *ObjMethod {
 		return nil
 	}
 	var p ObjMethod
-	var assemble func(arg reflect.Value) []reflect.Value
+	var assemble func(ctx context.Context, arg reflect.Value) []reflect.Value
 	// N.B. The method type has the receiver as its first argument
 	// unless the receiver is an interface.
 	receiverArgCount := 1
@@ -277,33 +282,54 @@ func newMethod(m reflect.Method, receiverKind reflect.Kind) *ObjMethod {
 		receiverArgCount = 0
 	}
 	t := m.Type
-	switch {
-	case t.NumIn() == 0+receiverArgCount:
-		// Method() ...
-		assemble = func(arg reflect.Value) []reflect.Value {
+	// Methods may take a context.Context before their argument, through
+	// which the request's context, such as its correlation id, is passed.
+	if t.NumIn() > receiverArgCount && t.In(receiverArgCount) == contextType {
+		receiverArgCount++
+		switch {
+		case t.NumIn() == 0+receiverArgCount:
+			// Method(context.Context) ...
+			assemble = func(ctx context.Context, arg reflect.Value) []reflect.Value {
+				return []reflect.Value{reflect.ValueOf(&ctx).Elem()}
+			}
+		case t.NumIn() == 1+receiverArgCount:
+			// Method(context.Context, T) ...
+			p.Params = t.In(receiverArgCount)
+			assemble = func(ctx context.Context, arg reflect.Value) []reflect.Value {
+				return []reflect.Value{reflect.ValueOf(&ctx).Elem(), arg}
+			}
+		default:
 			return nil
 		}
-	case t.NumIn() == 1+receiverArgCount:
-		// Method(T) ...
-		p.Params = t.In(receiverArgCount)
-		assemble = func(arg reflect.Value) []reflect.Value {
-			return []reflect.Value{arg}
+	} else {
+		switch {
+		case t.NumIn() == 0+receiverArgCount:
+			// Method() ...
+			assemble = func(ctx context.Context, arg reflect.Value) []reflect.Value {
+				return nil
+			}
+		case t.NumIn() == 1+receiverArgCount:
+			// Method(T) ...
+			p.Params = t.In(receiverArgCount)
+			assemble = func(ctx context.Context, arg reflect.Value) []reflect.Value {
+				return []reflect.Value{arg}
+			}
+		default:
+			return nil
 		}
-	default:
-		return nil
 	}
 
 	switch {
 	case t.NumOut() == 0:
 		// Method(...)
-		p.Call = func(rcvr, arg reflect.Value) (r reflect.Value, err error) {
-			rcvr.Method(m.Index).Call(assemble(arg))
+		p.Call = func(ctx context.Context, rcvr, arg reflect.Value) (r reflect.Value, err error) {
+			rcvr.Method(m.Index).Call(assemble(ctx, arg))
 			return
 		}
 	case t.NumOut() == 1 && t.Out(0) == errorType:
 		// Method(...) error
-		p.Call = func(rcvr, arg reflect.Value) (r reflect.Value, err error) {
-			out := rcvr.Method(m.Index).Call(assemble(arg))
+		p.Call = func(ctx context.Context, rcvr, arg reflect.Value) (r reflect.Value, err error) {
+			out := rcvr.Method(m.Index).Call(assemble(ctx, arg))
 			if !out[0].IsNil() {
 				err = out[0].Interface().(error)
 			}
@@ -312,15 +338,15 @@ func newMethod(m reflect.Method, receiverKind reflect.Kind) *ObjMethod {
 	case t.NumOut() == 1:
 		// Method(...) R
 		p.Result = t.Out(0)
-		p.Call = func(rcvr, arg reflect.Value) (reflect.Value, error) {
-			out := rcvr.Method(m.Index).Call(assemble(arg))
+		p.Call = func(ctx context.Context, rcvr, arg reflect.Value) (reflect.Value, error) {
+			out := rcvr.Method(m.Index).Call(assemble(ctx, arg))
 			return out[0], nil
 		}
 	case t.NumOut() == 2 && t.Out(1) == errorType:
 		// Method(...) (R, error)
 		p.Result = t.Out(0)
-		p.Call = func(rcvr, arg reflect.Value) (r reflect.Value, err error) {
-			out := rcvr.Method(m.Index).Call(assemble(arg))
+		p.Call = func(ctx context.Context, rcvr, arg reflect.Value) (r reflect.Value, err error) {
+			out := rcvr.Method(m.Index).Call(assemble(ctx, arg))
 			r = out[0]
 			if !out[1].IsNil() {
 				err = out[1].Interface().(error)
@@ -10,7 +10,9 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"golang.org/x/net/context"
 
+	"github.com/juju/juju/core/correlation"
 	"github.com/juju/juju/rpc/rpcreflect"
 )
 
@@ -64,6 +66,11 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// CorrelationId identifies the user action of which the request
+	// is a part. The server assigns one to any request that does not
+	// carry one, and copies it into the reply.
+	CorrelationId string
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -148,6 +155,10 @@ type Conn struct {
 	inputLoopError error
 
 	observerFactory ObserverFactory
+
+	// correlationId holds the correlation id sent with every client
+	// request. It is guarded by mutex.
+	correlationId string
 }
 
 // NewConn creates a new connection that uses the given codec for
@@ -372,6 +383,9 @@ func (conn *Conn) readBody(resp interface{}, isRequest bool) error {
 }
 
 func (conn *Conn) handleRequest(hdr *Header) error {
+	if !validCorrelationId(hdr.CorrelationId) {
+		hdr.CorrelationId = newCorrelationId()
+	}
 	observer := conn.observerFactory.RPCObserver()
 	req, err := conn.bindRequest(hdr)
 	if err != nil {
@@ -431,8 +445,9 @@ func (conn *Conn) writeErrorResponse(reqHdr *Header, err error, observer Observe
 	conn.sending.Lock()
 	defer conn.sending.Unlock()
 	hdr := &Header{
-		RequestId: reqHdr.RequestId,
-		Version:   reqHdr.Version,
+		RequestId:     reqHdr.RequestId,
+		Version:       reqHdr.Version,
+		CorrelationId: reqHdr.CorrelationId,
 	}
 	if err, ok := err.(ErrorCoder); ok {
 		hdr.ErrorCode = err.ErrorCode()
@@ -487,13 +502,17 @@ func (conn *Conn) bindRequest(hdr *Header) (boundRequest, error) {
 // runRequest runs the given request and sends the reply.
 func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, observer Observer) {
 	defer conn.srvPending.Done()
-	rv, err := req.Call(req.hdr.Request.Id, arg)
+	// The correlation id is passed to the methods which take a
+	// context, so that they can log it and pass it on.
+	ctx := correlation.WithId(context.Background(), req.hdr.CorrelationId)
+	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
 	if err != nil {
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), observer)
 	} else {
 		hdr := &Header{
-			RequestId: req.hdr.RequestId,
-			Version:   version,
+			RequestId:     req.hdr.RequestId,
+			Version:       version,
+			CorrelationId: req.hdr.CorrelationId,
 		}
 		var rvi interface{}
 		if rv.IsValid() {
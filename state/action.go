@@ -13,6 +13,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/correlation"
 )
 
 const (
@@ -104,6 +106,10 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// CorrelationId is the id of the API request that enqueued the
+	// action, if any.
+	CorrelationId string `bson:"correlation-id,omitempty"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -136,6 +142,12 @@ func (a *action) Parameters() map[string]interface{} {
 	return a.doc.Parameters
 }
 
+// CorrelationId returns the id of the API request that enqueued the
+// action, if any.
+func (a *action) CorrelationId() string {
+	return a.doc.CorrelationId
+}
+
 // Enqueued returns the time the action was added to state as a pending
 // Action.
 func (a *action) Enqueued() time.Time {
@@ -247,13 +259,14 @@ func newAction(st *State, adoc actionDoc) Action {
 }
 
 // newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}, correlationId string) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
 		return actionDoc{}, actionNotificationDoc{}, err
 	}
-	actionLogger.Debugf("newActionDoc name: '%s', receiver: '%s', actionId: '%s'", actionName, receiverTag, actionId)
+	logger := correlation.NewLogger(actionLogger, correlationId)
+	logger.Debugf("newActionDoc name: '%s', receiver: '%s', actionId: '%s'", actionName, receiverTag, actionId)
 	modelUUID := st.ModelUUID()
 	return actionDoc{
 			DocId:      st.docID(actionId.String()),
@@ -263,6 +276,8 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			Parameters: parameters,
 			Enqueued:   nowToTheSecond(),
 			Status:     ActionPending,
+
+			CorrelationId: correlationId,
 		}, actionNotificationDoc{
 			DocId:     st.docID(prefix + actionId.String()),
 			ModelUUID: modelUUID,
@@ -356,6 +371,12 @@ func (st *State) FindActionsByName(name string) ([]Action, error) {
 
 // EnqueueAction
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return st.enqueueAction(receiver, actionName, payload, "")
+}
+
+// enqueueAction adds an action for the receiver, recording the id of the
+// API request that caused it.
+func (st *State) enqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}, correlationId string) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
@@ -365,7 +386,7 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload, correlationId)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
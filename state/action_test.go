@@ -293,6 +293,20 @@ func makeUnits(c *gc.C, s *ActionSuite, units map[string]*state.Unit, schemas ma
 	}
 }
 
+func (s *ActionSuite) TestAddCorrelatedAction(c *gc.C) {
+	action, err := s.unit.AddCorrelatedAction("deadbeef", "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.CorrelationId(), gc.Equals, "deadbeef")
+
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.CorrelationId(), gc.Equals, "deadbeef")
+
+	action, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.CorrelationId(), gc.Equals, "")
+}
+
 func (s *ActionSuite) TestEnqueueActionRequiresName(c *gc.C) {
 	name := ""
 
@@ -878,6 +892,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddCorrelatedAction(string, string, map[string]interface{}) (state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(state.Action) (state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher  { return nil }
 func (r mockAR) Actions() ([]state.Action, error)                { return nil, nil }
@@ -6,11 +6,11 @@ package state
 import (
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -119,7 +119,7 @@ func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemp
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
-	return st.addMachine(mdoc, ops)
+	return st.addMachine(mdoc, ops, 2)
 }
 
 // AddMachineInsideMachine adds a machine inside a container of the
@@ -129,7 +129,7 @@ func (st *State) AddMachineInsideMachine(template MachineTemplate, parentId stri
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
-	return st.addMachine(mdoc, ops)
+	return st.addMachine(mdoc, ops, 1)
 }
 
 // AddMachine adds a machine with the given series and jobs.
@@ -176,25 +176,43 @@ func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err er
 		return nil, errors.Trace(err)
 	}
 	ops = append(ops, ssOps...)
+	limitOps, err := st.modelLimitsOps(len(templates), 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, limitOps...)
 	ops = append(ops, assertModelActiveOp(st.ModelUUID()))
 	if err := st.runTransaction(ops); err != nil {
 		if errors.Cause(err) == txn.ErrAborted {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
+			if err := st.checkModelLimits(len(templates), 0); err != nil {
+				return nil, errors.Trace(err)
+			}
 		}
 		return nil, errors.Trace(err)
 	}
 	return ms, nil
 }
 
-func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op) (*Machine, error) {
+// addMachine runs the given operations, which add newMachines machines
+// including the one described by mdoc.
+func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op, newMachines int) (*Machine, error) {
+	limitOps, err := st.modelLimitsOps(newMachines, 0)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add a new machine")
+	}
 	ops = append([]txn.Op{assertModelActiveOp(st.ModelUUID())}, ops...)
+	ops = append(ops, limitOps...)
 	if err := st.runTransaction(ops); err != nil {
 		if errors.Cause(err) == txn.ErrAborted {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
+			if err := st.checkModelLimits(newMachines, 0); err != nil {
+				return nil, errors.Annotate(err, "cannot add a new machine")
+			}
 		}
 		return nil, errors.Trace(err)
 	}
@@ -465,13 +483,12 @@ func (st *State) insertNewMachineOps(mdoc *machineDoc, template MachineTemplate)
 	machineStatusDoc := statusDoc{
 		Status:    status.StatusPending,
 		ModelUUID: st.ModelUUID(),
-		// TODO(fwereade): 2016-03-17 lp:1558657
-		Updated: time.Now().UnixNano(),
+		Updated:   st.clock.Now().UnixNano(),
 	}
 	instanceStatusDoc := statusDoc{
 		Status:    status.StatusPending,
 		ModelUUID: st.ModelUUID(),
-		Updated:   time.Now().UnixNano(),
+		Updated:   st.clock.Now().UnixNano(),
 	}
 
 	prereqOps, machineOp = st.baseNewMachineOps(
@@ -787,6 +804,74 @@ func (st *State) EnableHA(
 	return change, nil
 }
 
+// RemoveControllerMachines takes the machines with the given ids out of
+// the controller cluster. Voting machines are demoted and marked for
+// removal; the peergrouper worker then removes their vote from the
+// replica set and calls RemoveControllerMachines again to remove their
+// JobManageModel job. Machines that neither want nor hold a vote are
+// removed immediately. The number of voting controllers remaining must
+// be odd.
+func (st *State) RemoveControllerMachines(ids ...string) (ControllersChanges, error) {
+	var change ControllersChanges
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		change = ControllersChanges{}
+		currentInfo, err := st.ControllerInfo()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		controllerIds := set.NewStrings(currentInfo.MachineIds...)
+		removing := set.NewStrings(currentInfo.RemovingMachineIds...)
+		var ops []txn.Op
+		for _, id := range set.NewStrings(ids...).SortedValues() {
+			if !controllerIds.Contains(id) {
+				return nil, errors.Errorf("machine %s is not a controller", id)
+			}
+			m, err := st.Machine(id)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			switch {
+			case m.WantsVote():
+				ops = append(ops, demoteControllerOps(m)...)
+				ops = append(ops, markRemovingControllerOps(m)...)
+				change.Demoted = append(change.Demoted, id)
+			case m.HasVote():
+				// The machine has already been demoted; it will be
+				// removed once the peergrouper has taken its vote.
+				if !removing.Contains(id) {
+					ops = append(ops, markRemovingControllerOps(m)...)
+				}
+				change.Maintained = append(change.Maintained, id)
+			default:
+				ops = append(ops, removeControllerOps(m)...)
+				change.Removed = append(change.Removed, id)
+			}
+		}
+		if len(change.Demoted) > 0 {
+			remaining := len(currentInfo.VotingMachineIds) - len(change.Demoted)
+			if remaining < 1 {
+				return nil, errors.New("cannot remove all voting controller machines")
+			}
+			if remaining%2 != 1 {
+				return nil, errors.Errorf("number of remaining voting controllers must be odd (got %d)", remaining)
+			}
+			ops = append(ops, txn.Op{
+				C:      controllersC,
+				Id:     modelGlobalKey,
+				Assert: bson.D{{"votingmachineids", bson.D{{"$size", len(currentInfo.VotingMachineIds)}}}},
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return ControllersChanges{}, errors.Annotate(err, "cannot remove controller machines")
+	}
+	return change, nil
+}
+
 // Change in controllers after the ensure availability txn has committed.
 type ControllersChanges struct {
 	Added      []string
@@ -897,8 +982,11 @@ type enableHAIntent struct {
 //   demoting unavailable, voting machines;
 //   removing unavailable, non-voting, non-vote-holding machines;
 //   gathering available, non-voting machines that may be promoted;
+//
+// Machines marked for removal are never promoted.
 func (st *State) enableHAIntentions(info *ControllerInfo, placement []string) (*enableHAIntent, error) {
 	var intent enableHAIntent
+	removing := set.NewStrings(info.RemovingMachineIds...)
 	for _, s := range placement {
 		// TODO(natefinch): unscoped placements shouldn't ever get here (though
 		// they do currently).  We should fix up the CLI to always add a scope
@@ -941,7 +1029,7 @@ func (st *State) enableHAIntentions(info *ControllerInfo, placement []string) (*
 			return nil, err
 		}
 		logger.Infof("machine %q, available %v, wants vote %v, has vote %v", m, available, m.WantsVote(), m.HasVote())
-		if available {
+		if available && !removing.Contains(mid) {
 			if m.WantsVote() {
 				intent.maintain = append(intent.maintain, m)
 			} else {
@@ -1025,8 +1113,19 @@ func removeControllerOps(m *Machine) []txn.Op {
 			{"$set", bson.D{{"novote", false}}},
 		},
 	}, {
+		C:  controllersC,
+		Id: modelGlobalKey,
+		Update: bson.D{{"$pull", bson.D{
+			{"machineids", m.doc.Id},
+			{"removingmachineids", m.doc.Id},
+		}}},
+	}}
+}
+
+func markRemovingControllerOps(m *Machine) []txn.Op {
+	return []txn.Op{{
 		C:      controllersC,
 		Id:     modelGlobalKey,
-		Update: bson.D{{"$pull", bson.D{{"machineids", m.doc.Id}}}},
+		Update: bson.D{{"$addToSet", bson.D{{"removingmachineids", m.doc.Id}}}},
 	}}
 }
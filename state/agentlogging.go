@@ -0,0 +1,208 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// AgentLogging holds the logging settings of an agent, or the defaults
+// for all the agents in a model.
+type AgentLogging struct {
+	// LoggingConfig holds loggo configuration which is applied on top
+	// of the model's logging-config. It is always empty for a model,
+	// whose log levels are held in its config.
+	LoggingConfig string
+
+	// MaxLogSize is the size in megabytes at which the agent's log
+	// file is rotated, or zero if not set.
+	MaxLogSize int
+
+	// MaxLogBackups is the number of rotated log files which the agent
+	// keeps, or zero if not set.
+	MaxLogBackups int
+}
+
+// agentLoggingDoc represents the MongoDB document that stores the
+// logging settings of an agent or model.
+type agentLoggingDoc struct {
+	DocID         string `bson:"_id"`
+	ModelUUID     string `bson:"model-uuid"`
+	LoggingConfig string `bson:"logging-config"`
+	MaxLogSize    int    `bson:"max-log-size"`
+	MaxLogBackups int    `bson:"max-log-backups"`
+}
+
+// agentLoggingKey returns the key of the logging settings document of
+// the agent or model with the given tag.
+func (st *State) agentLoggingKey(tag names.Tag) (string, error) {
+	if tag, ok := tag.(names.ModelTag); ok {
+		if tag != st.ModelTag() {
+			return "", errors.NotValidf("model %q", tag.Id())
+		}
+		return modelGlobalKey, nil
+	}
+	return agentTagToGlobalKey(tag)
+}
+
+// AgentLogging returns the logging settings of the agent or model with
+// the given tag. Settings which have not been set are left zero.
+func (st *State) AgentLogging(tag names.Tag) (AgentLogging, error) {
+	key, err := st.agentLoggingKey(tag)
+	if err != nil {
+		return AgentLogging{}, errors.Trace(err)
+	}
+	coll, closer := st.getCollection(agentLoggingC)
+	defer closer()
+
+	var doc agentLoggingDoc
+	err = coll.FindId(key).One(&doc)
+	if err == mgo.ErrNotFound {
+		return AgentLogging{}, nil
+	} else if err != nil {
+		return AgentLogging{}, errors.Annotatef(err, "cannot get logging settings of %s", tag)
+	}
+	return AgentLogging{
+		LoggingConfig: doc.LoggingConfig,
+		MaxLogSize:    doc.MaxLogSize,
+		MaxLogBackups: doc.MaxLogBackups,
+	}, nil
+}
+
+// SetAgentLogging replaces the logging settings of the agent or model
+// with the given tag. Settings for a model apply to each of its agents
+// which does not have its own.
+func (st *State) SetAgentLogging(tag names.Tag, settings AgentLogging) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set logging settings of %s", tag)
+	key, err := st.agentLoggingKey(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateAgentLogging(tag, settings); err != nil {
+		return errors.Trace(err)
+	}
+	var entityOp txn.Op
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		entityOp = txn.Op{C: machinesC, Id: tag.Id(), Assert: notDeadDoc}
+	case names.UnitTag:
+		entityOp = txn.Op{C: unitsC, Id: tag.Id(), Assert: notDeadDoc}
+	default:
+		entityOp = txn.Op{C: modelsC, Id: st.ModelUUID(), Assert: txn.DocExists}
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := st.checkAgentLoggingEntity(tag); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		coll, closer := st.getCollection(agentLoggingC)
+		defer closer()
+		count, err := coll.FindId(key).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		op := txn.Op{
+			C:  agentLoggingC,
+			Id: key,
+		}
+		if count == 0 {
+			op.Assert = txn.DocMissing
+			op.Insert = &agentLoggingDoc{
+				DocID:         st.docID(key),
+				ModelUUID:     st.ModelUUID(),
+				LoggingConfig: settings.LoggingConfig,
+				MaxLogSize:    settings.MaxLogSize,
+				MaxLogBackups: settings.MaxLogBackups,
+			}
+		} else {
+			op.Assert = txn.DocExists
+			op.Update = bson.D{{"$set", bson.D{
+				{"logging-config", settings.LoggingConfig},
+				{"max-log-size", settings.MaxLogSize},
+				{"max-log-backups", settings.MaxLogBackups},
+			}}}
+		}
+		return []txn.Op{entityOp, op}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// checkAgentLoggingEntity returns an error if the agent with the given
+// tag is not alive or dying.
+func (st *State) checkAgentLoggingEntity(tag names.Tag) error {
+	var life Life
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		m, err := st.Machine(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		life = m.Life()
+	case names.UnitTag:
+		u, err := st.Unit(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		life = u.Life()
+	default:
+		return nil
+	}
+	if life == Dead {
+		return errors.Errorf("%s is dead", tag)
+	}
+	return nil
+}
+
+// validateAgentLogging returns an error if the given logging settings
+// are not valid for the agent or model with the given tag.
+func validateAgentLogging(tag names.Tag, settings AgentLogging) error {
+	if settings.LoggingConfig != "" {
+		if _, ok := tag.(names.ModelTag); ok {
+			return errors.NotValidf("logging config for a model (set logging-config in model config instead)")
+		}
+		if _, err := loggo.ParseConfigString(settings.LoggingConfig); err != nil {
+			return errors.NotValidf("logging config %q", settings.LoggingConfig)
+		}
+	}
+	if settings.MaxLogSize < 0 {
+		return errors.NotValidf("negative max log size")
+	}
+	if settings.MaxLogBackups < 0 {
+		return errors.NotValidf("negative max log backups")
+	}
+	return nil
+}
+
+// WatchAgentLogging returns a watcher which notifies of changes which
+// may affect the logging settings of the agent with the given tag:
+// those of the agent itself, those of its model, and the model's
+// logging-config.
+func (st *State) WatchAgentLogging(tag names.Tag) (NotifyWatcher, error) {
+	key, err := agentTagToGlobalKey(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newDocWatcher(st, []docKey{
+		{settingsC, st.docID(modelGlobalKey)},
+		{agentLoggingC, st.docID(modelGlobalKey)},
+		{agentLoggingC, st.docID(key)},
+	}), nil
+}
+
+// removeAgentLoggingOp returns the operation needed to remove the
+// logging settings of the agent with the given global key.
+func removeAgentLoggingOp(st *State, globalKey string) txn.Op {
+	return txn.Op{
+		C:      agentLoggingC,
+		Id:     globalKey,
+		Remove: true,
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type AgentLoggingSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&AgentLoggingSuite{})
+
+func (s *AgentLoggingSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *AgentLoggingSuite) TestNotSet(c *gc.C) {
+	settings, err := s.State.AgentLogging(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.Equals, state.AgentLogging{})
+}
+
+func (s *AgentLoggingSuite) TestSetGet(c *gc.C) {
+	for _, settings := range []state.AgentLogging{{
+		LoggingConfig: "juju.worker=DEBUG",
+		MaxLogSize:    100,
+	}, {
+		MaxLogBackups: 5,
+	}} {
+		err := s.State.SetAgentLogging(s.machine.Tag(), settings)
+		c.Assert(err, jc.ErrorIsNil)
+		got, err := s.State.AgentLogging(s.machine.Tag())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(got, gc.Equals, settings)
+	}
+}
+
+func (s *AgentLoggingSuite) TestSetUnit(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	settings := state.AgentLogging{LoggingConfig: "unit=TRACE"}
+	err := s.State.SetAgentLogging(unit.Tag(), settings)
+	c.Assert(err, jc.ErrorIsNil)
+	got, err := s.State.AgentLogging(unit.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, gc.Equals, settings)
+
+	// The settings of other agents are unaffected.
+	got, err = s.State.AgentLogging(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, gc.Equals, state.AgentLogging{})
+}
+
+func (s *AgentLoggingSuite) TestSetModel(c *gc.C) {
+	settings := state.AgentLogging{MaxLogSize: 50, MaxLogBackups: 3}
+	err := s.State.SetAgentLogging(s.State.ModelTag(), settings)
+	c.Assert(err, jc.ErrorIsNil)
+	got, err := s.State.AgentLogging(s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, gc.Equals, settings)
+}
+
+func (s *AgentLoggingSuite) TestSetModelLoggingConfig(c *gc.C) {
+	err := s.State.SetAgentLogging(s.State.ModelTag(), state.AgentLogging{LoggingConfig: "juju=DEBUG"})
+	c.Assert(err, gc.ErrorMatches, `cannot set logging settings of model-.*: logging config for a model \(set logging-config in model config instead\) not valid`)
+}
+
+func (s *AgentLoggingSuite) TestSetOtherModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err := s.State.SetAgentLogging(st.ModelTag(), state.AgentLogging{MaxLogSize: 50})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *AgentLoggingSuite) TestSetInvalid(c *gc.C) {
+	for _, test := range []struct {
+		settings state.AgentLogging
+		err      string
+	}{{
+		settings: state.AgentLogging{LoggingConfig: "juju=LOUD"},
+		err:      `logging config "juju=LOUD" not valid`,
+	}, {
+		settings: state.AgentLogging{MaxLogSize: -1},
+		err:      "negative max log size not valid",
+	}, {
+		settings: state.AgentLogging{MaxLogBackups: -1},
+		err:      "negative max log backups not valid",
+	}} {
+		err := s.State.SetAgentLogging(s.machine.Tag(), test.settings)
+		c.Check(err, gc.ErrorMatches, "cannot set logging settings of machine-0: "+test.err)
+	}
+}
+
+func (s *AgentLoggingSuite) TestSetNotAgent(c *gc.C) {
+	err := s.State.SetAgentLogging(names.NewUserTag("bob"), state.AgentLogging{MaxLogSize: 50})
+	c.Assert(err, gc.ErrorMatches, "cannot set logging settings of user-bob: user-bob is not an agent tag")
+}
+
+func (s *AgentLoggingSuite) TestSetMachineNotFound(c *gc.C) {
+	err := s.State.SetAgentLogging(names.NewMachineTag("42"), state.AgentLogging{MaxLogSize: 50})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AgentLoggingSuite) TestRemovedWithMachine(c *gc.C) {
+	err := s.State.SetAgentLogging(s.machine.Tag(), state.AgentLogging{MaxLogSize: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := s.State.AgentLogging(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.Equals, state.AgentLogging{})
+}
+
+func (s *AgentLoggingSuite) TestWatch(c *gc.C) {
+	w, err := s.State.WatchAgentLogging(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.State.SetAgentLogging(s.machine.Tag(), state.AgentLogging{MaxLogSize: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.SetAgentLogging(s.State.ModelTag(), state.AgentLogging{MaxLogBackups: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"logging-config": "juju=DEBUG"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes to the settings of other agents are not reported.
+	other := s.Factory.MakeMachine(c, nil)
+	err = s.State.SetAgentLogging(other.Tag(), state.AgentLogging{MaxLogSize: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
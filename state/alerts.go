@@ -0,0 +1,446 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/status"
+)
+
+// AlertRuleKind identifies the condition which raises an alert.
+type AlertRuleKind string
+
+const (
+	// UnitErrorAlert is raised for units in the error state.
+	UnitErrorAlert AlertRuleKind = "unit-error"
+
+	// MachineDownAlert is raised for started machines whose agents
+	// are not communicating with the controller.
+	MachineDownAlert AlertRuleKind = "machine-down"
+)
+
+// Validate returns an error if the kind is not known.
+func (kind AlertRuleKind) Validate() error {
+	switch kind {
+	case UnitErrorAlert, MachineDownAlert:
+		return nil
+	}
+	return errors.NotValidf("alert rule kind %q", kind)
+}
+
+// AlertRule defines when an alert is raised for the entities in a
+// model, and where notifications of it are sent.
+type AlertRule struct {
+	// Name identifies the rule within its model.
+	Name string
+
+	// Kind identifies the condition which raises the alert.
+	Kind AlertRuleKind
+
+	// For is how long an entity must meet the condition before the
+	// alert is raised.
+	For time.Duration
+
+	// Webhook, if set, is the http or https URL to which
+	// notifications of the alert are posted. Its host must be one
+	// of the controller's alert-webhook-hosts.
+	Webhook string
+
+	// Email, if set, is the address to which notifications of the
+	// alert are sent.
+	Email string
+}
+
+var validAlertRuleName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Validate returns an error if the rule is not valid.
+func (rule AlertRule) Validate() error {
+	if !validAlertRuleName.MatchString(rule.Name) {
+		return errors.NotValidf("alert rule name %q", rule.Name)
+	}
+	if err := rule.Kind.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if rule.For < 0 {
+		return errors.NotValidf("negative duration")
+	}
+	if rule.Webhook != "" {
+		u, err := url.Parse(rule.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.NotValidf("webhook %q", rule.Webhook)
+		}
+	}
+	if rule.Email != "" {
+		if _, err := mail.ParseAddress(rule.Email); err != nil {
+			return errors.NotValidf("email address %q", rule.Email)
+		}
+	}
+	return nil
+}
+
+// Alert records that an entity has met the condition of an alert rule
+// for longer than the rule allows.
+type Alert struct {
+	// Rule is the name of the rule which raised the alert.
+	Rule string
+
+	// Entity is the tag of the entity the alert concerns.
+	Entity string
+
+	// Message describes the condition of the entity.
+	Message string
+
+	// Since is when the entity started to meet the condition.
+	Since time.Time
+
+	// Raised is when the alert was raised.
+	Raised time.Time
+}
+
+// AlertCondition describes an entity which meets the condition of an
+// alert rule kind.
+type AlertCondition struct {
+	// Entity is the tag of the entity.
+	Entity string
+
+	// Message describes the condition of the entity.
+	Message string
+
+	// Since is when the entity started to meet the condition, or
+	// zero if that is not known.
+	Since time.Time
+}
+
+// alertRuleDoc represents the MongoDB document that stores an alert
+// rule.
+type alertRuleDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Name      string `bson:"name"`
+	Kind      string `bson:"kind"`
+	For       int64  `bson:"for"`
+	Webhook   string `bson:"webhook,omitempty"`
+	Email     string `bson:"email,omitempty"`
+}
+
+func (doc alertRuleDoc) toRule() AlertRule {
+	return AlertRule{
+		Name:    doc.Name,
+		Kind:    AlertRuleKind(doc.Kind),
+		For:     time.Duration(doc.For),
+		Webhook: doc.Webhook,
+		Email:   doc.Email,
+	}
+}
+
+// alertDoc represents the MongoDB document that stores a raised
+// alert. It is keyed on the rule name and entity tag.
+type alertDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Rule      string `bson:"rule"`
+	Entity    string `bson:"entity"`
+	Message   string `bson:"message"`
+	Since     int64  `bson:"since"`
+	Raised    int64  `bson:"raised"`
+}
+
+func (doc alertDoc) toAlert() Alert {
+	return Alert{
+		Rule:    doc.Rule,
+		Entity:  doc.Entity,
+		Message: doc.Message,
+		Since:   time.Unix(0, doc.Since).UTC(),
+		Raised:  time.Unix(0, doc.Raised).UTC(),
+	}
+}
+
+func alertKey(rule, entity string) string {
+	return rule + "#" + entity
+}
+
+// AddAlertRule adds the given alert rule to the model. A rule may only
+// have a webhook on a host the controller's alert-webhook-hosts allow,
+// so that model admins cannot make the controller post to arbitrary
+// addresses.
+func (st *State) AddAlertRule(rule AlertRule) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add alert rule %q", rule.Name)
+	if err := rule.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if rule.Webhook != "" {
+		cfg, err := st.ControllerConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !cfg.AlertWebhookAllowed(rule.Webhook) {
+			return errors.NotValidf("webhook %q (host not in %s)", rule.Webhook, controller.AlertWebhookHosts)
+		}
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		if _, err := st.AlertRule(rule.Name); err == nil {
+			return nil, errors.AlreadyExistsf("alert rule")
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      modelsC,
+			Id:     st.ModelUUID(),
+			Assert: isAliveDoc,
+		}, {
+			C:      alertRulesC,
+			Id:     rule.Name,
+			Assert: txn.DocMissing,
+			Insert: &alertRuleDoc{
+				DocID:     st.docID(rule.Name),
+				ModelUUID: st.ModelUUID(),
+				Name:      rule.Name,
+				Kind:      string(rule.Kind),
+				For:       int64(rule.For),
+				Webhook:   rule.Webhook,
+				Email:     rule.Email,
+			},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// RemoveAlertRule removes the named alert rule, and any alerts it has
+// raised, from the model.
+func (st *State) RemoveAlertRule(name string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove alert rule %q", name)
+	buildTxn := func(int) ([]txn.Op, error) {
+		if _, err := st.AlertRule(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      alertRulesC,
+			Id:     name,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		alerts, err := st.alertDocs(bson.D{{"rule", name}})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, doc := range alerts {
+			ops = append(ops, txn.Op{
+				C:      alertsC,
+				Id:     alertKey(doc.Rule, doc.Entity),
+				Remove: true,
+			})
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// AlertRule returns the named alert rule.
+func (st *State) AlertRule(name string) (AlertRule, error) {
+	coll, closer := st.getCollection(alertRulesC)
+	defer closer()
+
+	var doc alertRuleDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return AlertRule{}, errors.NotFoundf("alert rule %q", name)
+	} else if err != nil {
+		return AlertRule{}, errors.Annotatef(err, "cannot get alert rule %q", name)
+	}
+	return doc.toRule(), nil
+}
+
+// AlertRules returns the model's alert rules, ordered by name.
+func (st *State) AlertRules() ([]AlertRule, error) {
+	coll, closer := st.getCollection(alertRulesC)
+	defer closer()
+
+	var docs []alertRuleDoc
+	if err := coll.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get alert rules")
+	}
+	rules := make([]AlertRule, len(docs))
+	for i, doc := range docs {
+		rules[i] = doc.toRule()
+	}
+	return rules, nil
+}
+
+// Alerts returns the alerts currently raised in the model, ordered by
+// rule and entity.
+func (st *State) Alerts() ([]Alert, error) {
+	docs, err := st.alertDocs(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get alerts")
+	}
+	alerts := make([]Alert, len(docs))
+	for i, doc := range docs {
+		alerts[i] = doc.toAlert()
+	}
+	return alerts, nil
+}
+
+func (st *State) alertDocs(query bson.D) ([]alertDoc, error) {
+	coll, closer := st.getCollection(alertsC)
+	defer closer()
+
+	var docs []alertDoc
+	if err := coll.Find(query).Sort("rule", "entity").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
+// RaiseAlert records the given alert. It fails if the alert's rule
+// does not exist, or if the rule has already raised an alert for the
+// entity.
+func (st *State) RaiseAlert(alert Alert) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot raise alert %q for %s", alert.Rule, alert.Entity)
+	if _, err := names.ParseTag(alert.Entity); err != nil {
+		return errors.Trace(err)
+	}
+	if alert.Raised.IsZero() {
+		alert.Raised = st.clock.Now()
+	}
+	key := alertKey(alert.Rule, alert.Entity)
+	buildTxn := func(int) ([]txn.Op, error) {
+		if _, err := st.AlertRule(alert.Rule); err != nil {
+			return nil, errors.Trace(err)
+		}
+		coll, closer := st.getCollection(alertsC)
+		defer closer()
+		if n, err := coll.FindId(key).Count(); err != nil {
+			return nil, errors.Trace(err)
+		} else if n > 0 {
+			return nil, errors.AlreadyExistsf("alert")
+		}
+		return []txn.Op{{
+			C:      alertRulesC,
+			Id:     alert.Rule,
+			Assert: txn.DocExists,
+		}, {
+			C:      alertsC,
+			Id:     key,
+			Assert: txn.DocMissing,
+			Insert: &alertDoc{
+				DocID:     st.docID(key),
+				ModelUUID: st.ModelUUID(),
+				Rule:      alert.Rule,
+				Entity:    alert.Entity,
+				Message:   alert.Message,
+				Since:     alert.Since.UnixNano(),
+				Raised:    alert.Raised.UnixNano(),
+			},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// ResolveAlert removes the alert raised by the named rule for the
+// entity with the given tag, if there is one.
+func (st *State) ResolveAlert(rule, entity string) error {
+	ops := []txn.Op{{
+		C:      alertsC,
+		Id:     alertKey(rule, entity),
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot resolve alert %q for %s", rule, entity)
+	}
+	return nil
+}
+
+// AlertConditions returns the entities in the model which currently
+// meet the condition of the given kind of alert rule.
+func (st *State) AlertConditions(kind AlertRuleKind) ([]AlertCondition, error) {
+	switch kind {
+	case UnitErrorAlert:
+		return st.unitErrorConditions()
+	case MachineDownAlert:
+		return st.machineDownConditions()
+	}
+	return nil, errors.NotValidf("alert rule kind %q", kind)
+}
+
+func (st *State) unitErrorConditions() ([]AlertCondition, error) {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var conditions []AlertCondition
+	for _, application := range applications {
+		units, err := application.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			info, err := unit.Status()
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if info.Status != status.StatusError {
+				continue
+			}
+			condition := AlertCondition{
+				Entity:  unit.Tag().String(),
+				Message: info.Message,
+			}
+			if info.Since != nil {
+				condition.Since = *info.Since
+			}
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions, nil
+}
+
+func (st *State) machineDownConditions() ([]AlertCondition, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var conditions []AlertCondition
+	for _, machine := range machines {
+		if machine.Life() == Dead {
+			continue
+		}
+		info, err := machine.Status()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.Status != status.StatusStarted {
+			continue
+		}
+		alive, err := machine.AgentPresence()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if alive {
+			continue
+		}
+		// The time at which the agent stopped communicating is not
+		// recorded, so the condition's start is left unknown.
+		condition := AlertCondition{
+			Entity:  machine.Tag().String(),
+			Message: "agent is not communicating with the server",
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type AlertsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AlertsSuite{})
+
+func (s *AlertsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	err := state.UpdateControllerConfig(s.State, map[string]interface{}{
+		controller.AlertWebhookHosts: []interface{}{"hooks.example.com"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AlertsSuite) TestAddAlertRule(c *gc.C) {
+	rules, err := s.State.AlertRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	rule := state.AlertRule{
+		Name:    "unit-errors",
+		Kind:    state.UnitErrorAlert,
+		For:     5 * time.Minute,
+		Webhook: "https://hooks.example.com/juju",
+		Email:   "ops@example.com",
+	}
+	err = s.State.AddAlertRule(rule)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddAlertRule(state.AlertRule{Name: "down", Kind: state.MachineDownAlert})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stored, err := s.State.AlertRule("unit-errors")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, rule)
+	rules, err = s.State.AlertRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []state.AlertRule{
+		{Name: "down", Kind: state.MachineDownAlert},
+		rule,
+	})
+}
+
+func (s *AlertsSuite) TestAddAlertRuleExists(c *gc.C) {
+	rule := state.AlertRule{Name: "down", Kind: state.MachineDownAlert}
+	err := s.State.AddAlertRule(rule)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddAlertRule(rule)
+	c.Assert(err, gc.ErrorMatches, `cannot add alert rule "down": alert rule already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *AlertsSuite) TestAddAlertRuleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		rule state.AlertRule
+		err  string
+	}{{
+		rule: state.AlertRule{Name: "Down", Kind: state.MachineDownAlert},
+		err:  `alert rule name "Down" not valid`,
+	}, {
+		rule: state.AlertRule{Name: "down", Kind: "machine-sad"},
+		err:  `alert rule kind "machine-sad" not valid`,
+	}, {
+		rule: state.AlertRule{Name: "down", Kind: state.MachineDownAlert, For: -time.Second},
+		err:  `negative duration not valid`,
+	}, {
+		rule: state.AlertRule{Name: "down", Kind: state.MachineDownAlert, Webhook: "ftp://example.com"},
+		err:  `webhook "ftp://example.com" not valid`,
+	}, {
+		rule: state.AlertRule{Name: "down", Kind: state.MachineDownAlert, Webhook: "http://169.254.169.254/latest"},
+		err:  `webhook "http://169.254.169.254/latest" \(host not in alert-webhook-hosts\) not valid`,
+	}, {
+		rule: state.AlertRule{Name: "down", Kind: state.MachineDownAlert, Email: "ops"},
+		err:  `email address "ops" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.State.AddAlertRule(test.rule)
+		c.Check(err, gc.ErrorMatches, `cannot add alert rule ".*": `+test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *AlertsSuite) TestAlertRuleNotFound(c *gc.C) {
+	_, err := s.State.AlertRule("down")
+	c.Assert(err, gc.ErrorMatches, `alert rule "down" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveAlertRule("down")
+	c.Assert(err, gc.ErrorMatches, `cannot remove alert rule "down": alert rule "down" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AlertsSuite) TestRaiseAndResolveAlert(c *gc.C) {
+	err := s.State.AddAlertRule(state.AlertRule{Name: "down", Kind: state.MachineDownAlert})
+	c.Assert(err, jc.ErrorIsNil)
+
+	since := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	raised := since.Add(5 * time.Minute)
+	alert := state.Alert{
+		Rule:    "down",
+		Entity:  "machine-1",
+		Message: "agent is not communicating with the server",
+		Since:   since,
+		Raised:  raised,
+	}
+	err = s.State.RaiseAlert(alert)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RaiseAlert(alert)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	alerts, err := s.State.Alerts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alerts, jc.DeepEquals, []state.Alert{alert})
+
+	err = s.State.ResolveAlert("down", "machine-1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResolveAlert("down", "machine-1")
+	c.Assert(err, jc.ErrorIsNil)
+	alerts, err = s.State.Alerts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alerts, gc.HasLen, 0)
+}
+
+func (s *AlertsSuite) TestRaiseAlertNoRule(c *gc.C) {
+	err := s.State.RaiseAlert(state.Alert{Rule: "down", Entity: "machine-1"})
+	c.Assert(err, gc.ErrorMatches, `cannot raise alert "down" for machine-1: alert rule "down" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AlertsSuite) TestRemoveAlertRuleRemovesAlerts(c *gc.C) {
+	for _, name := range []string{"down", "down-again"} {
+		err := s.State.AddAlertRule(state.AlertRule{Name: name, Kind: state.MachineDownAlert})
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.State.RaiseAlert(state.Alert{Rule: name, Entity: "machine-1"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err := s.State.RemoveAlertRule("down")
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := s.State.AlertRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 1)
+	alerts, err := s.State.Alerts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alerts, gc.HasLen, 1)
+	c.Assert(alerts[0].Rule, gc.Equals, "down-again")
+}
+
+func (s *AlertsSuite) TestUnitErrorConditions(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	conditions, err := s.State.AlertConditions(state.UnitErrorAlert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conditions, gc.HasLen, 0)
+
+	now := time.Now()
+	err = unit.Agent().SetStatus(status.StatusInfo{
+		Status:  status.StatusError,
+		Message: `hook failed: "install"`,
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	conditions, err = s.State.AlertConditions(state.UnitErrorAlert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conditions, gc.HasLen, 1)
+	c.Assert(conditions[0].Entity, gc.Equals, unit.Tag().String())
+	c.Assert(conditions[0].Message, gc.Equals, `hook failed: "install"`)
+	c.Assert(conditions[0].Since.IsZero(), jc.IsFalse)
+}
+
+func (s *AlertsSuite) TestMachineDownConditions(c *gc.C) {
+	// New machines are pending.
+	machine := s.Factory.MakeMachine(c, nil)
+	conditions, err := s.State.AlertConditions(state.MachineDownAlert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conditions, gc.HasLen, 0)
+
+	// The machine has no agent running to report its presence.
+	err = machine.SetStatus(status.StatusInfo{Status: status.StatusStarted})
+	c.Assert(err, jc.ErrorIsNil)
+	conditions, err = s.State.AlertConditions(state.MachineDownAlert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conditions, jc.DeepEquals, []state.AlertCondition{{
+		Entity:  machine.Tag().String(),
+		Message: "agent is not communicating with the server",
+	}})
+}
+
+func (s *AlertsSuite) TestAlertConditionsInvalidKind(c *gc.C) {
+	_, err := s.State.AlertConditions("machine-sad")
+	c.Assert(err, gc.ErrorMatches, `alert rule kind "machine-sad" not valid`)
+}
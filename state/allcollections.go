@@ -85,6 +85,10 @@ func allCollections() collectionSchema {
 		// upgrades and schema migrations.
 		upgradeInfoC: {global: true},
 
+		// This collection records the schema version of the documents
+		// in each collection, as upgraded by the schema upgrade steps.
+		schemaVersionsC: {global: true},
+
 		// This collection holds a convenient representation of the content of
 		// the simplestreams data source pointing to binaries required by juju.
 		//
@@ -166,6 +170,11 @@ func allCollections() collectionSchema {
 		// are inherited and then forked by new models.
 		globalSettingsC: {global: true},
 
+		// This collection holds the default constraints of the
+		// controller, and of clouds and their regions, which are
+		// inherited by models.
+		defaultConstraintsC: {global: true},
+
 		// This collection holds workload metrics reported by certain charms
 		// for passing onward to other tools.
 		metricsC: {global: true},
@@ -262,6 +271,11 @@ func allCollections() collectionSchema {
 		},
 		relationScopesC: {},
 
+		// This collection holds named snapshots of the config of a model
+		// and its applications, which may be restored to undo config
+		// changes.
+		configSnapshotsC: {},
+
 		// -----
 
 		// These collections hold information associated with machines.
@@ -271,10 +285,52 @@ func allCollections() collectionSchema {
 		rebootC:        {},
 		sshHostKeysC:   {},
 
+		// This collection holds the resource usage last reported by
+		// each machine agent, and its accumulated network traffic.
+		// It's written often and is of no value once superseded, or
+		// is only incremented, so it's not transactional.
+		machineUsageC: {
+			rawAccess: true,
+		},
+
 		// This collection contains information from removed machines
 		// that needs to be cleaned up in the provider.
 		machineRemovalsC: {},
 
+		// This collection counts the placements of each application's
+		// units on existing machines, so that concurrent placements
+		// which depend on one another can be serialised.
+		placementsC: {},
+
+		// This collection holds the log levels and log rotation settings
+		// of agents, and the model's default rotation settings.
+		agentLoggingC: {},
+
+		// These collections hold the model's alert rules, and the
+		// alerts they have raised which are not yet resolved.
+		alertRulesC: {},
+		alertsC:     {},
+
+		// This collection holds the most recent hook executions of
+		// each unit, with their queue wait times and exit codes.
+		hookTimingsC: {},
+
+		// This collection holds the extra cloud-init directives applied
+		// when provisioning the model's machines.
+		cloudInitC: {},
+
+		// This collection holds the canary upgrade in progress in each
+		// model, if any.
+		canaryUpgradesC: {},
+
+		// This collection holds the series upgrade in progress on each
+		// machine, if any, with the progress of each unit through it.
+		upgradeSeriesLocksC: {},
+
+		// This collection holds the operations the controller is to
+		// run on the model's applications at scheduled times.
+		scheduledOperationsC: {},
+
 		// -----
 
 		// These collections hold information associated with storage.
@@ -319,6 +375,10 @@ func allCollections() collectionSchema {
 		endpointBindingsC:     {},
 		openedPortsC:          {},
 
+		// This collection holds the model-level firewall rules, which
+		// restrict the sources of ingress for well-known services.
+		firewallRulesC: {},
+
 		// -----
 
 		// These collections hold information associated with actions.
@@ -375,6 +435,22 @@ func allCollections() collectionSchema {
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {},
 
+		// This collection holds the timeline of high-level events in
+		// each model, such as deployments and machine failures, for
+		// review after an incident. It is written outside of
+		// transactions, like the status history.
+		modelEventsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "time"},
+			}},
+		},
+
+		// This collection holds the instance types offered by a
+		// model's provider, cached to avoid querying the cloud for
+		// every provisioning decision.
+		instanceTypesC: {},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -397,30 +473,37 @@ const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
 	actionsC                 = "actions"
+	agentLoggingC            = "agentlogging"
 	annotationsC             = "annotations"
 	assignUnitC              = "assignUnits"
 	auditingC                = "audit.log"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
+	canaryUpgradesC          = "canaryupgrades"
 	charmsC                  = "charms"
 	cleanupsC                = "cleanups"
 	cloudimagemetadataC      = "cloudimagemetadata"
+	cloudInitC               = "cloudinit"
 	cloudsC                  = "clouds"
 	cloudCredentialsC        = "cloudCredentials"
+	configSnapshotsC         = "configsnapshots"
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
 	controllersC             = "controllers"
 	controllerUsersC         = "controllerusers"
+	defaultConstraintsC      = "defaultconstraints"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
 	globalSettingsC          = "globalSettings"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
 	instanceDataC            = "instanceData"
+	instanceTypesC           = "instanceTypes"
 	leasesC                  = "leases"
 	machinesC                = "machines"
 	machineRemovalsC         = "machineremovals"
+	machineUsageC            = "machineUsage"
 	meterStatusC             = "meterStatus"
 	metricsC                 = "metrics"
 	metricsManagerC          = "metricsmanager"
@@ -433,17 +516,25 @@ const (
 	modelUsersC              = "modelusers"
 	modelsC                  = "models"
 	modelEntityRefsC         = "modelEntityRefs"
+	modelEventsC             = "modelevents"
 	openedPortsC             = "openedPorts"
 	payloadsC                = "payloads"
 	permissionsC             = "permissions"
+	placementsC              = "placements"
 	providerIDsC             = "providerIDs"
 	rebootC                  = "reboot"
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	scheduledOperationsC     = "scheduledoperations"
+	schemaVersionsC          = "schemaVersions"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
+	alertRulesC              = "alertrules"
+	alertsC                  = "alerts"
 	endpointBindingsC        = "endpointbindings"
+	firewallRulesC           = "firewallRules"
+	hookTimingsC             = "hooktimings"
 	settingsC                = "settings"
 	settingsrefsC            = "settingsrefs"
 	sshHostKeysC             = "sshhostkeys"
@@ -462,6 +553,7 @@ const (
 	txnsC                    = "txns"
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
+	upgradeSeriesLocksC      = "upgradeserieslocks"
 	userLastLoginC           = "userLastLogin"
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
@@ -6,7 +6,6 @@ package state
 import (
 	"reflect"
 	"strings"
-	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -283,6 +282,18 @@ func unitAndAgentStatus(st *State, name string) (unitStatus, agentStatus status.
 	return unitStatusResult, agentStatusResult, nil
 }
 
+// getWorkloadVersion returns the workload version recorded for the
+// named unit, or an empty string if none has been set.
+func getWorkloadVersion(st *State, name string) (string, error) {
+	info, err := getStatus(st, globalWorkloadVersionKey(name), "workload")
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return info.Message, nil
+}
+
 func (u *backingUnit) updated(st *State, store *multiwatcherStore, id string) error {
 	info := &multiwatcher.UnitInfo{
 		ModelUUID:   st.ModelUUID(),
@@ -310,6 +321,11 @@ func (u *backingUnit) updated(st *State, store *multiwatcherStore, id string) er
 		if u.Tools != nil {
 			info.AgentStatus.Version = u.Tools.Version.Number.String()
 		}
+		workloadVersion, err := getWorkloadVersion(st, u.Name)
+		if err != nil {
+			return errors.Annotatef(err, "reading workload version for %q", u.Name)
+		}
+		info.WorkloadVersion = workloadVersion
 
 		portRanges, compatiblePorts, err := getUnitPortRangesAndPorts(st, u.Name)
 		if err != nil {
@@ -325,6 +341,7 @@ func (u *backingUnit) updated(st *State, store *multiwatcherStore, id string) er
 		// Unit and workload status.
 		info.AgentStatus = oldInfo.AgentStatus
 		info.WorkloadStatus = oldInfo.WorkloadStatus
+		info.WorkloadVersion = oldInfo.WorkloadVersion
 		info.Ports = oldInfo.Ports
 		info.PortRanges = oldInfo.PortRanges
 	}
@@ -423,8 +440,7 @@ func (svc *backingApplication) updated(st *State, store *multiwatcherStore, id s
 			// Not sure how status can even return NotFound as it is created
 			// with the application initially. For now, we'll log the error as per
 			// the above and return Unknown.
-			// TODO(fwereade): 2016-03-17 lp:1558657
-			now := time.Now()
+			now := st.clock.Now()
 			info.Status = multiwatcher.StatusInfo{
 				Current: status.StatusUnknown,
 				Since:   &now,
@@ -614,6 +630,13 @@ func (s *backingStatus) updated(st *State, store *multiwatcherStore, id string)
 		return nil
 	case *multiwatcher.UnitInfo:
 		newInfo := *info
+		if strings.HasSuffix(id, workloadVersionKeySuffix) {
+			// The workload version is stored as a status, but
+			// isn't a status of the unit or its agent.
+			newInfo.WorkloadVersion = s.StatusInfo
+			store.Update(&newInfo)
+			return nil
+		}
 		// Get the unit's current recorded status from state.
 		// It's needed to reset the unit status when a unit comes off error.
 		statusInfo, err := getStatus(st, unitGlobalKey(newInfo.Name), "unit")
@@ -947,6 +970,7 @@ func backingEntityIdForGlobalKey(modelUUID, key string) (multiwatcher.EntityId,
 		}).EntityId(), true
 	case 'u':
 		id = strings.TrimSuffix(id, "#charm")
+		id = strings.TrimSuffix(id, workloadVersionKeySuffix)
 		return (&multiwatcher.UnitInfo{
 			ModelUUID: modelUUID,
 			Name:      id,
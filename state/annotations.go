@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/juju/errors"
@@ -96,6 +97,47 @@ func (st *State) Annotation(entity GlobalEntity, key string) (string, error) {
 	return ann[key], nil
 }
 
+// FindEntitiesByAnnotations returns the tags of the entities in the
+// model whose annotations include all of the given key/value pairs,
+// sorted by tag. If kind is not empty, only entities with tags of that
+// kind (e.g. names.MachineTagKind) are returned.
+func (st *State) FindEntitiesByAnnotations(kind string, annotations map[string]string) ([]names.Tag, error) {
+	if len(annotations) == 0 {
+		return nil, errors.NotValidf("empty annotations filter")
+	}
+	query := bson.D{}
+	for key, value := range annotations {
+		if strings.Contains(key, ".") || strings.HasPrefix(key, "$") {
+			return nil, errors.NotValidf("annotation key %q", key)
+		}
+		query = append(query, bson.DocElem{"annotations." + key, value})
+	}
+	if kind != "" {
+		query = append(query, bson.DocElem{
+			"tag", bson.RegEx{Pattern: "^" + regexp.QuoteMeta(kind+"-")},
+		})
+	}
+	coll, closer := st.getCollection(annotationsC)
+	defer closer()
+
+	var docs []annotatorDoc
+	if err := coll.Find(query).Select(bson.M{"tag": 1}).Sort("tag").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot find annotated entities")
+	}
+	tags := make([]names.Tag, 0, len(docs))
+	for _, doc := range docs {
+		tag, err := names.ParseTag(doc.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if kind != "" && tag.Kind() != kind {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
 // insertAnnotationsOps returns the operations required to insert annotations in MongoDB.
 func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]string) ([]txn.Op, error) {
 	tag := entity.Tag()
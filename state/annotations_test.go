@@ -134,6 +134,38 @@ func (s *AnnotationsSuite) TestSetAnnotationsConcurrently(c *gc.C) {
 	assertAnnotation(c, s.State, s.testEntity, key, last)
 }
 
+func (s *AnnotationsSuite) TestFindEntitiesByAnnotations(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	err = s.State.SetAnnotations(s.testEntity, map[string]string{"env": "prod", "tier": "web"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAnnotations(other, map[string]string{"env": "staging"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAnnotations(app, map[string]string{"env": "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	tags, err := s.State.FindEntitiesByAnnotations("", map[string]string{"env": "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.Tag{app.Tag(), s.testEntity.Tag()})
+
+	tags, err = s.State.FindEntitiesByAnnotations(names.MachineTagKind, map[string]string{"env": "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.Tag{s.testEntity.Tag()})
+
+	tags, err = s.State.FindEntitiesByAnnotations("", map[string]string{"env": "prod", "tier": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, gc.HasLen, 0)
+}
+
+func (s *AnnotationsSuite) TestFindEntitiesByAnnotationsInvalid(c *gc.C) {
+	_, err := s.State.FindEntitiesByAnnotations("", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	_, err = s.State.FindEntitiesByAnnotations("", map[string]string{"a.b": "c"})
+	c.Assert(err, gc.ErrorMatches, `annotation key "a.b" not valid`)
+}
+
 type AnnotationsEnvSuite struct {
 	ConnSuite
 }
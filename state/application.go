@@ -6,14 +6,15 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -35,22 +36,32 @@ type Application struct {
 // serviceDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string     `bson:"_id"`
-	Name                 string     `bson:"name"`
-	ModelUUID            string     `bson:"model-uuid"`
-	Series               string     `bson:"series"`
-	Subordinate          bool       `bson:"subordinate"`
-	CharmURL             *charm.URL `bson:"charmurl"`
-	Channel              string     `bson:"cs-channel"`
-	CharmModifiedVersion int        `bson:"charmmodifiedversion"`
-	ForceCharm           bool       `bson:"forcecharm"`
-	Life                 Life       `bson:"life"`
-	UnitCount            int        `bson:"unitcount"`
-	RelationCount        int        `bson:"relationcount"`
-	Exposed              bool       `bson:"exposed"`
-	MinUnits             int        `bson:"minunits"`
-	TxnRevno             int64      `bson:"txn-revno"`
-	MetricCredentials    []byte     `bson:"metric-credentials"`
+	DocID                string               `bson:"_id"`
+	Name                 string               `bson:"name"`
+	ModelUUID            string               `bson:"model-uuid"`
+	Series               string               `bson:"series"`
+	Subordinate          bool                 `bson:"subordinate"`
+	CharmURL             *charm.URL           `bson:"charmurl"`
+	Channel              string               `bson:"cs-channel"`
+	CharmModifiedVersion int                  `bson:"charmmodifiedversion"`
+	ForceCharm           bool                 `bson:"forcecharm"`
+	Life                 Life                 `bson:"life"`
+	UnitCount            int                  `bson:"unitcount"`
+	RelationCount        int                  `bson:"relationcount"`
+	Exposed              bool                 `bson:"exposed"`
+	ExposedEndpoints     []exposedEndpointDoc `bson:"exposed-endpoints,omitempty"`
+	MinUnits             int                  `bson:"minunits"`
+	MaxUnits             int                  `bson:"maxunits"`
+	TxnRevno             int64                `bson:"txn-revno"`
+	MetricCredentials    []byte               `bson:"metric-credentials"`
+	HookSandbox          *hookSandboxDoc      `bson:"hook-sandbox,omitempty"`
+}
+
+// exposedEndpointDoc records the source CIDRs from which an endpoint
+// of an exposed application may be reached.
+type exposedEndpointDoc struct {
+	Endpoint string   `bson:"endpoint"`
+	ToCIDRs  []string `bson:"to-cidrs,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -191,7 +202,11 @@ func (s *Application) destroyOps() ([]txn.Op, error) {
 	// removed, the application can also be removed.
 	if s.doc.UnitCount == 0 && s.doc.RelationCount == removeCount {
 		hasLastRefs := bson.D{{"life", Alive}, {"unitcount", 0}, {"relationcount", removeCount}}
-		return append(ops, s.removeOps(hasLastRefs)...), nil
+		removeOps, err := s.removeOps(hasLastRefs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	// In all other cases, application removal will be handled as a consequence
 	// of the removal of the last unit or relation referencing it. If any
@@ -245,7 +260,7 @@ func removeResourcesOps(st *State, serviceID string) ([]txn.Op, error) {
 
 // removeOps returns the operations required to remove the service. Supplied
 // asserts will be included in the operation on the application document.
-func (s *Application) removeOps(asserts bson.D) []txn.Op {
+func (s *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	settingsDocID := s.st.docID(s.settingsKey())
 	ops := []txn.Op{
 		{
@@ -269,14 +284,20 @@ func (s *Application) removeOps(asserts bson.D) []txn.Op {
 		removeLeadershipSettingsOp(s.Name()),
 		removeStatusOp(s.st, s.globalKey()),
 		removeModelServiceRefOp(s.st, s.Name()),
+		removePlacementOp(s.st, s.Name()),
+	}
+	blockOps, err := removeEntityBlocksOps(s.st, s.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
+	ops = append(ops, blockOps...)
 	// For local charms, we also delete the charm itself since the
 	// charm is associated 1:1 with the service. Each different deploy
 	// of a local charm creates a new copy with a different revision.
 	if s.doc.CharmURL.Schema == "local" {
 		ops = append(ops, s.st.newCleanupOp(cleanupCharmForDyingService, s.doc.CharmURL.String()))
 	}
-	return ops
+	return ops, nil
 }
 
 // IsExposed returns whether this application is exposed. The explicitly open
@@ -292,26 +313,152 @@ func (s *Application) SetExposed() error {
 	return s.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the service.
-// See SetExposed and IsExposed.
+// ClearExposed removes the exposed flag from the service, along with
+// any exposed endpoints. See SetExposed and IsExposed.
 func (s *Application) ClearExposed() error {
 	return s.setExposed(false)
 }
 
 func (s *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"exposed-endpoints", nil}}})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for application %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	if !exposed {
+		s.doc.ExposedEndpoints = nil
+	}
+	return nil
+}
+
+// ExposedEndpoints returns the source CIDRs from which each exposed
+// endpoint of the application may be reached, keyed by endpoint name.
+// The empty endpoint name stands for all of the application's
+// endpoints, and an endpoint with no CIDRs may be reached from any
+// address. An exposed application with no exposed endpoints may be
+// reached on any endpoint from any address.
+func (s *Application) ExposedEndpoints() map[string][]string {
+	exposed := make(map[string][]string, len(s.doc.ExposedEndpoints))
+	for _, doc := range s.doc.ExposedEndpoints {
+		exposed[doc.Endpoint] = append([]string(nil), doc.ToCIDRs...)
+	}
+	return exposed
+}
+
+// ExposedSourceCIDRs returns the sorted source CIDRs from which the
+// ports opened by the units of the application may be reached, if it
+// is exposed: the union of those of its exposed endpoints, since ports
+// are not opened for particular endpoints. If the application may be
+// reached from any address, nil is returned.
+func (s *Application) ExposedSourceCIDRs() []string {
+	if len(s.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	cidrs := set.NewStrings()
+	for _, doc := range s.doc.ExposedEndpoints {
+		if len(doc.ToCIDRs) == 0 {
+			return nil
+		}
+		cidrs = cidrs.Union(set.NewStrings(doc.ToCIDRs...))
+	}
+	return cidrs.SortedValues()
+}
+
+// MergeExposeSettings marks the application as exposed, and records
+// that each of the given endpoints may be reached from its given source
+// CIDRs, replacing the CIDRs previously recorded for that endpoint.
+// The empty endpoint name stands for all of the application's
+// endpoints, and an endpoint with no CIDRs may be reached from any
+// address. See ExposedEndpoints.
+func (s *Application) MergeExposeSettings(exposed map[string][]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot expose application %q", s)
+	if err := s.validateExposedEndpoints(exposed); err != nil {
+		return errors.Trace(err)
+	}
+
+	var docs []exposedEndpointDoc
+	app := &Application{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		merged := app.ExposedEndpoints()
+		for endpoint, cidrs := range exposed {
+			merged[endpoint] = cidrs
+		}
+		docs = exposedEndpointDocs(merged)
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", app.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"exposed", true},
+				{"exposed-endpoints", docs},
+			}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	s.doc.Exposed = true
+	s.doc.ExposedEndpoints = docs
 	return nil
 }
 
+// validateExposedEndpoints returns an error if any of the given
+// endpoints is not an endpoint of the application's charm, or if any
+// of their CIDRs is not valid.
+func (s *Application) validateExposedEndpoints(exposed map[string][]string) error {
+	ch, _, err := s.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	endpoints := DefaultEndpointBindingsForCharm(ch.Meta())
+	for endpoint, cidrs := range exposed {
+		if _, ok := endpoints[endpoint]; endpoint != "" && !ok {
+			return errors.NotValidf("endpoint %q", endpoint)
+		}
+		for _, cidr := range cidrs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
+	return nil
+}
+
+// exposedEndpointDocs returns the documents recording the given exposed
+// endpoints, sorted by endpoint name.
+func exposedEndpointDocs(exposed map[string][]string) []exposedEndpointDoc {
+	endpoints := make([]string, 0, len(exposed))
+	for endpoint := range exposed {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	docs := make([]exposedEndpointDoc, 0, len(exposed))
+	for _, endpoint := range endpoints {
+		docs = append(docs, exposedEndpointDoc{
+			Endpoint: endpoint,
+			ToCIDRs:  exposed[endpoint],
+		})
+	}
+	return docs
+}
+
 // Charm returns the service's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (s *Application) Charm() (ch *Charm, force bool, err error) {
@@ -770,6 +917,44 @@ type SetCharmConfig struct {
 // If forceSeries is true, the charm will be used even if it's the service's series
 // is not supported by the charm.
 func (s *Application) SetCharm(cfg SetCharmConfig) error {
+	if err := s.validateSetCharm(cfg); err != nil {
+		return err
+	}
+
+	services, closer := s.st.getCollection(applicationsC)
+	defer closer()
+
+	// this value holds the *previous* charm modified version, before this
+	// transaction commits.
+	var charmModifiedVersion int
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			// NOTE: We're explicitly allowing SetCharm to succeed
+			// when the application is Dying, because service/charm
+			// upgrades should still be allowed to apply to dying
+			// services and units, so that bugs in departed/broken
+			// hooks can be addressed at runtime.
+			if notDead, err := isNotDeadWithSession(services, s.doc.DocID); err != nil {
+				return nil, errors.Trace(err)
+			} else if !notDead {
+				return nil, ErrDead
+			}
+		}
+		return s.setCharmOps(cfg)
+	}
+	err := s.st.run(buildTxn)
+	if err == nil {
+		s.doc.CharmURL = cfg.Charm.URL()
+		s.doc.Channel = string(cfg.Channel)
+		s.doc.ForceCharm = cfg.ForceUnits
+		s.doc.CharmModifiedVersion = charmModifiedVersion + 1
+	}
+	return err
+}
+
+// validateSetCharm returns an error if the charm in the supplied
+// config may not be used by the application.
+func (s *Application) validateSetCharm(cfg SetCharmConfig) error {
 	if cfg.Charm.Meta().Subordinate != s.doc.Subordinate {
 		return errors.Errorf("cannot change a service's subordinacy")
 	}
@@ -821,92 +1006,70 @@ func (s *Application) SetCharm(cfg SetCharmConfig) error {
 			return errors.Errorf("cannot upgrade charm, OS %q not supported by charm", currentOS)
 		}
 	}
+	return nil
+}
 
+// setCharmOps returns the operations necessary to set the charm in the
+// supplied config for the application.
+func (s *Application) setCharmOps(cfg SetCharmConfig) ([]txn.Op, error) {
 	services, closer := s.st.getCollection(applicationsC)
 	defer closer()
 
-	// this value holds the *previous* charm modified version, before this
-	// transaction commits.
+	// We can't update the in-memory application doc inside the transaction, so
+	// we manually udpate it at the end of the SetCharm method. However, we
+	// have no way of knowing what the charmModifiedVersion will be, since
+	// it's just incrementing the value in the DB (and that might be out of
+	// step with the value we have in memory).  What we have to do is read
+	// the DB, store the charmModifiedVersion we get, run the transaction,
+	// assert in the transaction that the charmModifiedVersion hasn't
+	// changed since we retrieved it, and then we know what its value must
+	// be after this transaction ends.  It's hacky, but there's no real
+	// other way to do it, thanks to the way mgo's transactions work.
+	var doc applicationDoc
+	err := services.FindId(s.doc.DocID).One(&doc)
 	var charmModifiedVersion int
-	channel := string(cfg.Channel)
-	buildTxn := func(attempt int) ([]txn.Op, error) {
-		if attempt > 0 {
-			// NOTE: We're explicitly allowing SetCharm to succeed
-			// when the application is Dying, because service/charm
-			// upgrades should still be allowed to apply to dying
-			// services and units, so that bugs in departed/broken
-			// hooks can be addressed at runtime.
-			if notDead, err := isNotDeadWithSession(services, s.doc.DocID); err != nil {
-				return nil, errors.Trace(err)
-			} else if !notDead {
-				return nil, ErrDead
-			}
-		}
+	switch {
+	case err == mgo.ErrNotFound:
+		// 0 is correct, since no previous charm existed.
+	case err != nil:
+		return nil, errors.Annotate(err, "can't open previous copy of charm")
+	default:
+		charmModifiedVersion = doc.CharmModifiedVersion
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: bson.D{{"charmmodifiedversion", charmModifiedVersion}},
+	}}
 
-		// We can't update the in-memory application doc inside the transaction, so
-		// we manually udpate it at the end of the SetCharm method. However, we
-		// have no way of knowing what the charmModifiedVersion will be, since
-		// it's just incrementing the value in the DB (and that might be out of
-		// step with the value we have in memory).  What we have to do is read
-		// the DB, store the charmModifiedVersion we get, run the transaction,
-		// assert in the transaction that the charmModifiedVersion hasn't
-		// changed since we retrieved it, and then we know what its value must
-		// be after this transaction ends.  It's hacky, but there's no real
-		// other way to do it, thanks to the way mgo's transactions work.
-		var doc applicationDoc
-		err := services.FindId(s.doc.DocID).One(&doc)
-		var charmModifiedVersion int
-		switch {
-		case err == mgo.ErrNotFound:
-			// 0 is correct, since no previous charm existed.
-		case err != nil:
-			return nil, errors.Annotate(err, "can't open previous copy of charm")
-		default:
-			charmModifiedVersion = doc.CharmModifiedVersion
-		}
-		ops := []txn.Op{{
+	// Make sure the application doesn't have this charm already.
+	channel := string(cfg.Channel)
+	sel := bson.D{{"_id", s.doc.DocID}, {"charmurl", cfg.Charm.URL()}}
+	count, err := services.Find(sel).Count()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if count > 0 {
+		// Charm URL already set; just update the force flag and channel.
+		sameCharm := bson.D{{"charmurl", cfg.Charm.URL()}}
+		ops = append(ops, []txn.Op{{
 			C:      applicationsC,
 			Id:     s.doc.DocID,
-			Assert: bson.D{{"charmmodifiedversion", charmModifiedVersion}},
-		}}
-
-		// Make sure the application doesn't have this charm already.
-		sel := bson.D{{"_id", s.doc.DocID}, {"charmurl", cfg.Charm.URL()}}
-		count, err := services.Find(sel).Count()
+			Assert: append(notDeadDoc, sameCharm...),
+			Update: bson.D{{"$set", bson.D{
+				{"cs-channel", channel},
+				{"forcecharm", cfg.ForceUnits},
+			}}},
+		}}...)
+	} else {
+		// Change the charm URL.
+		chng, err := s.changeCharmOps(cfg.Charm, channel, cfg.ForceUnits, cfg.ResourceIDs)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if count > 0 {
-			// Charm URL already set; just update the force flag and channel.
-			sameCharm := bson.D{{"charmurl", cfg.Charm.URL()}}
-			ops = append(ops, []txn.Op{{
-				C:      applicationsC,
-				Id:     s.doc.DocID,
-				Assert: append(notDeadDoc, sameCharm...),
-				Update: bson.D{{"$set", bson.D{
-					{"cs-channel", channel},
-					{"forcecharm", cfg.ForceUnits},
-				}}},
-			}}...)
-		} else {
-			// Change the charm URL.
-			chng, err := s.changeCharmOps(cfg.Charm, channel, cfg.ForceUnits, cfg.ResourceIDs)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			ops = append(ops, chng...)
-		}
-
-		return ops, nil
-	}
-	err := s.st.run(buildTxn)
-	if err == nil {
-		s.doc.CharmURL = cfg.Charm.URL()
-		s.doc.Channel = channel
-		s.doc.ForceCharm = cfg.ForceUnits
-		s.doc.CharmModifiedVersion = charmModifiedVersion + 1
+		ops = append(ops, chng...)
 	}
-	return err
+	return ops, nil
 }
 
 // String returns the application name.
@@ -950,6 +1113,10 @@ const MessageWaitForAgentInit = "Waiting for agent initialization to finish"
 // to include additional assertions for the application document.  This method
 // assumes that the application already exists in the db.
 func (s *Application) addUnitOps(principalName string, asserts bson.D) (string, []txn.Op, error) {
+	if s.doc.MaxUnits > 0 && s.doc.UnitCount >= s.doc.MaxUnits {
+		return "", nil, errors.Errorf("application already has the maximum of %d units", s.doc.MaxUnits)
+	}
+	asserts = append(asserts, addUnitMaxUnitsAssert(s.doc.MaxUnits)...)
 	var cons constraints.Value
 	if !s.doc.Subordinate {
 		scons, err := s.Constraints()
@@ -977,6 +1144,13 @@ func (s *Application) addUnitOps(principalName string, asserts bson.D) (string,
 	if err != nil {
 		return names, ops, err
 	}
+	if principalName == "" {
+		limitOps, err := s.st.modelLimitsOps(0, 1)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		ops = append(ops, limitOps...)
+	}
 	// we verify the application is alive
 	asserts = append(isAliveDoc, asserts...)
 	ops = append(ops, s.incUnitCountOp(asserts))
@@ -1029,8 +1203,7 @@ func (s *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		Principal:              args.principalName,
 		StorageAttachmentCount: numStorageAttachments,
 	}
-	// TODO(fwereade): 2016-03-17 lp:1558657
-	now := time.Now()
+	now := s.st.clock.Now()
 	agentStatusDoc := statusDoc{
 		Status:  status.StatusAllocating,
 		Updated: now.UnixNano(),
@@ -1129,6 +1302,15 @@ func (s *Application) AddUnit() (unit *Unit, err error) {
 		} else if !alive {
 			return nil, fmt.Errorf("application is not alive")
 		}
+		if err := s.Refresh(); err != nil {
+			return nil, err
+		}
+		if s.doc.MaxUnits > 0 && s.doc.UnitCount >= s.doc.MaxUnits {
+			return nil, errors.Errorf("application already has the maximum of %d units", s.doc.MaxUnits)
+		}
+		if err := s.st.checkModelLimits(0, 1); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("inconsistent state")
 	} else if err != nil {
 		return nil, err
@@ -1156,6 +1338,11 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		return nil, errors.Trace(err)
 	}
 	ops = append(ops, resOps...)
+	upgradeSeriesOps, err := removeUnitFromUpgradeSeriesLockOps(s.st, u)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, upgradeSeriesOps...)
 
 	observedFieldsMatch := bson.D{
 		{"charmurl", u.doc.CharmURL},
@@ -1172,7 +1359,10 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(s.st, u.globalAgentKey()),
 		removeStatusOp(s.st, u.globalKey()),
 		removeConstraintsOp(s.st, u.globalAgentKey()),
+		removeAgentLoggingOp(s.st, u.globalAgentKey()),
+		removeHookTimingsOp(s.st, u.globalKey()),
 		annotationRemoveOp(s.st, u.globalKey()),
+		removeModelUnitRefOp(s.st, u.doc.Name),
 		s.st.newCleanupOp(cleanupRemovedUnit, u.doc.Name),
 	)
 	ops = append(ops, portsOps...)
@@ -1188,7 +1378,11 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	}
 	if s.doc.Life == Dying && s.doc.RelationCount == 0 && s.doc.UnitCount == 1 {
 		hasLastRef := bson.D{{"life", Dying}, {"relationcount", 0}, {"unitcount", 1}}
-		return append(ops, s.removeOps(hasLastRef)...), nil
+		removeOps, err := s.removeOps(hasLastRef)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	svcOp := txn.Op{
 		C:      applicationsC,
@@ -1279,15 +1473,16 @@ func (s *Application) ConfigSettings() (charm.Settings, error) {
 }
 
 // UpdateConfigSettings changes a service's charm config settings. Values set
-// to nil will be deleted; unknown and invalid values will return an error.
+// to nil will be deleted; unknown and invalid values, including values not
+// among an option's declared choices, will return a *ConfigValidationError.
 func (s *Application) UpdateConfigSettings(changes charm.Settings) error {
 	charm, _, err := s.Charm()
 	if err != nil {
 		return err
 	}
-	changes, err = charm.Config().ValidateSettings(changes)
+	changes, err = validateConfigSettings(s.doc.Name, charm, changes)
 	if err != nil {
-		return err
+		return errors.Trace(err)
 	}
 	// TODO(fwereade) state.Settings is itself really problematic in just
 	// about every use case. This needs to be resolved some time; but at
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -875,11 +876,15 @@ var serviceUpdateConfigSettingsTests = []struct {
 }{{
 	about:  "unknown option",
 	update: charm.Settings{"foo": "bar"},
-	err:    `unknown option "foo"`,
+	err:    `invalid config for application "dummy-application": unknown option "foo"`,
 }, {
 	about:  "bad type",
 	update: charm.Settings{"skill-level": "profound"},
-	err:    `option "skill-level" expected int, got "profound"`,
+	err:    `invalid config for application "dummy-application": option "skill-level" expected int, got "profound"`,
+}, {
+	about:  "several invalid options",
+	update: charm.Settings{"skill-level": "profound", "foo": "bar", "title": "sir"},
+	err:    `invalid config for application "dummy-application": unknown option "foo"; option "skill-level" expected int, got "profound"`,
 }, {
 	about:  "set string",
 	update: charm.Settings{"outlook": "positive"},
@@ -944,6 +949,51 @@ func (s *ServiceSuite) TestUpdateConfigSettings(c *gc.C) {
 	}
 }
 
+func (s *ServiceSuite) TestUpdateConfigSettingsChoices(c *gc.C) {
+	curl := charm.MustParseURL("local:quantal/dummy-choices-1")
+	_, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("dummy"),
+		ID:          curl,
+		StoragePath: "dummy-path",
+		SHA256:      "dummy-choices-sha256",
+		ConfigChoices: map[string][]interface{}{
+			"outlook":     {"positive", "negative"},
+			"skill-level": {int64(1), int64(2)},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sch, err := s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.ConfigChoices(), jc.DeepEquals, map[string][]interface{}{
+		"outlook":     {"positive", "negative"},
+		"skill-level": {int64(1), int64(2)},
+	})
+	svc := s.AddTestingService(c, "dummy-application", sch)
+
+	err = svc.UpdateConfigSettings(charm.Settings{"outlook": "negative", "skill-level": 2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = svc.UpdateConfigSettings(charm.Settings{"outlook": "ambivalent", "skill-level": 3, "title": "sir"})
+	c.Assert(err, gc.ErrorMatches, `invalid config for application "dummy-application": `+
+		`option "outlook" must be one of \[positive negative\], got "ambivalent"; `+
+		`option "skill-level" must be one of \[1 2\], got 3`)
+	c.Assert(err, jc.Satisfies, state.IsConfigValidationError)
+	validationErr := errors.Cause(err).(*state.ConfigValidationError)
+	c.Assert(validationErr.Application, gc.Equals, "dummy-application")
+	c.Assert(validationErr.Errors, gc.HasLen, 2)
+	c.Assert(validationErr.Errors[0].Option, gc.Equals, "outlook")
+	c.Assert(validationErr.Errors[0].Value, gc.Equals, "ambivalent")
+	c.Assert(validationErr.Errors[1].Option, gc.Equals, "skill-level")
+	c.Assert(validationErr.Errors[1].Value, gc.Equals, int64(3))
+
+	// Options with choices may still be unset.
+	err = svc.UpdateConfigSettings(charm.Settings{"outlook": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := svc.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"skill-level": int64(2)})
+}
+
 func assertNoSettingsRef(c *gc.C, st *state.State, svcName string, sch *state.Charm) {
 	_, err := state.ServiceSettingsRefCount(st, svcName, sch.URL())
 	c.Assert(err, gc.Equals, mgo.ErrNotFound)
@@ -1343,6 +1393,69 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestMergeExposeSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string][]string{
+		"server": {"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedSourceCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.mysql.MergeExposeSettings(map[string][]string{
+		"": {"192.168.0.0/16"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string][]string{
+		"":       {"192.168.0.0/16"},
+		"server": {"10.0.0.0/8"},
+	})
+	c.Assert(s.mysql.ExposedSourceCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+
+	// An endpoint exposed to any address exposes the application's
+	// ports to any address.
+	err = s.mysql.MergeExposeSettings(map[string][]string{
+		"server": nil,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedSourceCIDRs(), gc.IsNil)
+
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string][]string{
+		"www": nil,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": endpoint "www" not valid`)
+	err = s.mysql.MergeExposeSettings(map[string][]string{
+		"server": {"10.0.0.0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": CIDR "10.0.0.0" not valid`)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ServiceSuite) TestMergeExposeSettingsNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string][]string{
+		"server": {"10.0.0.0/8"},
+	})
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -1249,6 +1249,42 @@ func (s *assignCleanSuite) TestAssignUnitPolicyWithContainers(c *gc.C) {
 	c.Assert(mid, gc.Equals, hostMachine.Id()+"/lxd/0")
 }
 
+func (s *assignCleanSuite) TestAssignUnitMachineUsedConcurrently(c *gc.C) {
+	m0, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	m1, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Make the first choice of machine unsuitable just before
+	// the unit is assigned to it.
+	defer state.SetBeforeHooks(c, s.State, func() {
+		if s.policy == state.AssignCleanEmpty {
+			_, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+				Series: "quantal",
+				Jobs:   []state.MachineJob{state.JobHostUnits},
+			}, m0.Id(), instance.LXD)
+			c.Assert(err, jc.ErrorIsNil)
+		} else {
+			other, err := s.wordpress.AddUnit()
+			c.Assert(err, jc.ErrorIsNil)
+			err = other.AssignToMachine(m0)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}).Check()
+
+	m, err := s.assignUnit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Not(gc.Equals), m0.Id())
+	id, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, m.Id())
+	if s.policy == state.AssignClean {
+		c.Assert(id, gc.Equals, m1.Id())
+	}
+}
+
 func (s *assignCleanSuite) TestAssignUnitPolicyConcurrently(c *gc.C) {
 	_, err := s.State.AddMachine("quantal", state.JobManageModel) // bootstrap machine
 	c.Assert(err, jc.ErrorIsNil)
@@ -77,3 +77,10 @@ func (st *State) strictLocalID(ID string) (string, error) {
 func (st *State) getCollection(name string) (mongo.Collection, func()) {
 	return st.database.GetCollection(name)
 }
+
+// getSecondaryCollection fetches a named collection, as getCollection
+// does, whose queries are served by a secondary where one is available.
+// The results may be stale; see Database.GetSecondaryCollection.
+func (st *State) getSecondaryCollection(name string) (mongo.Collection, func()) {
+	return st.database.GetSecondaryCollection(name)
+}
@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"path"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/storage"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// TargetStorager is implemented by environs that can keep backup
+// archives in storage provided by the cloud, such as an S3 bucket
+// or a Swift container.
+type TargetStorager interface {
+	// BackupStorage returns the storage in which backup
+	// archives are kept.
+	BackupStorage() (storage.Storage, error)
+}
+
+var newEnviron = stateenvirons.GetNewEnvironFunc(environs.New)
+
+// OpenTarget returns the storage in which backup archives are kept
+// according to the controller's backup-target setting, or nil if the
+// archives are kept in the controller.
+func OpenTarget(st *state.State) (storage.Storage, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.BackupTarget() != controller.BackupTargetCloud {
+		return nil, nil
+	}
+	env, err := newEnviron(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening controller environ")
+	}
+	storager, ok := env.(TargetStorager)
+	if !ok {
+		return nil, errors.NotSupportedf("backup-target %q in this cloud", controller.BackupTargetCloud)
+	}
+	stor, err := storager.BackupStorage()
+	return stor, errors.Trace(err)
+}
+
+// NewStorageWithTarget returns a new FileStorage to use for storing
+// backup archives. Metadata is always kept in the controller; archives
+// are kept in the storage returned by openTarget, or in the controller
+// if it returns nil. The target is opened when it is first needed.
+func NewStorageWithTarget(st DB, openTarget func() (storage.Storage, error)) filestorage.FileStorage {
+	modelUUID := st.ModelTag().Id()
+	db := st.MongoSession().DB(storageDBName)
+	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
+	defer dbWrap.Close()
+
+	files := &targetFileStorage{
+		controller: newFileStorage(dbWrap, backupStorageRoot),
+		openTarget: openTarget,
+	}
+	docs := newMetadataStorage(dbWrap)
+	return filestorage.NewFileStorage(docs, files)
+}
+
+// targetFileStorage is a filestorage.RawFileStorage which keeps
+// archives in a target storage, falling back to the controller's
+// storage for archives stored before the target was configured.
+type targetFileStorage struct {
+	controller filestorage.RawFileStorage
+	openTarget func() (storage.Storage, error)
+
+	once sync.Once
+	stor storage.Storage
+	err  error
+}
+
+func (s *targetFileStorage) target() (storage.Storage, error) {
+	s.once.Do(func() {
+		s.stor, s.err = s.openTarget()
+	})
+	return s.stor, s.err
+}
+
+func (s *targetFileStorage) path(id string) string {
+	return path.Join(backupStorageRoot, id)
+}
+
+// File returns the identified file from storage.
+func (s *targetFileStorage) File(id string) (io.ReadCloser, error) {
+	target, err := s.target()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if target != nil {
+		file, err := target.Get(s.path(id))
+		if !errors.IsNotFound(err) {
+			return file, errors.Trace(err)
+		}
+	}
+	return s.controller.File(id)
+}
+
+// AddFile adds the file to storage.
+func (s *targetFileStorage) AddFile(id string, file io.Reader, size int64) error {
+	target, err := s.target()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if target == nil {
+		return s.controller.AddFile(id, file, size)
+	}
+	return errors.Trace(target.Put(s.path(id), file, size))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *targetFileStorage) RemoveFile(id string) error {
+	target, err := s.target()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if target == nil {
+		return s.controller.RemoveFile(id)
+	}
+	if err := target.Remove(s.path(id)); err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.controller.RemoveFile(id); err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Close closes the storage.
+func (s *targetFileStorage) Close() error {
+	return s.controller.Close()
+}
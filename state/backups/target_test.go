@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/filestorage"
+	"github.com/juju/juju/environs/storage"
+	"github.com/juju/juju/state/backups"
+)
+
+const archiveData = "<compressed archive data>"
+
+func (s *storageSuite) archiveMetadata(c *gc.C) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.Origin.Model = s.State.ModelUUID()
+	meta.Origin.Machine = "0"
+	meta.Origin.Hostname = "localhost"
+	err := meta.MarkComplete(int64(len(archiveData)), "some hash")
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func (s *storageSuite) TestNewStorageWithTarget(c *gc.C) {
+	target, err := filestorage.NewFileStorageWriter(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	stor := backups.NewStorageWithTarget(s.State, func() (storage.Storage, error) {
+		return target, nil
+	})
+	defer stor.Close()
+
+	id, err := stor.Add(s.archiveMetadata(c), strings.NewReader(archiveData))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The archive is in the target, and can be read back.
+	r, err := target.Get("backups/" + id)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, archiveData)
+
+	_, r, err = stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = ioutil.ReadAll(r)
+	r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, archiveData)
+
+	err = stor.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = target.Get("backups/" + id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestNewStorageWithTargetFallsBackToController(c *gc.C) {
+	// Store an archive in the controller before the target
+	// is configured.
+	controllerStor := backups.NewStorage(s.State)
+	defer controllerStor.Close()
+	id, err := controllerStor.Add(s.archiveMetadata(c), strings.NewReader(archiveData))
+	c.Assert(err, jc.ErrorIsNil)
+
+	target, err := filestorage.NewFileStorageWriter(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	stor := backups.NewStorageWithTarget(s.State, func() (storage.Storage, error) {
+		return target, nil
+	})
+	defer stor.Close()
+
+	_, r, err := stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, archiveData)
+}
+
+func (s *storageSuite) TestNewStorageWithTargetError(c *gc.C) {
+	stor := backups.NewStorageWithTarget(s.State, func() (storage.Storage, error) {
+		return nil, errors.New("no bucket for you")
+	})
+	defer stor.Close()
+
+	_, err := stor.Add(s.archiveMetadata(c), strings.NewReader(archiveData))
+	c.Assert(err, gc.ErrorMatches, ".*no bucket for you")
+}
@@ -158,17 +158,120 @@ func (st *State) SwitchBlockOff(t BlockType) error {
 	return RemoveModelBlock(st, t)
 }
 
+// SwitchEntityBlockOn enables block of specified type for the given
+// application or machine only. Operations on other entities in the
+// model are not affected.
+func (st *State) SwitchEntityBlockOn(t BlockType, tag names.Tag, msg string) error {
+	if err := validateBlockEntity(t, tag); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := st.checkBlockEntityExists(tag); err != nil {
+			return nil, errors.Trace(err)
+		}
+		_, exists, err := st.getBlock(t, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if exists {
+			return nil, errors.Errorf("block %v is already ON for %s", t.String(), names.ReadableString(tag))
+		}
+		ops, err := createBlockOps(st, t, tag, msg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, blockEntityExistsOp(tag)), nil
+	}
+	return st.run(buildTxn)
+}
+
+// SwitchEntityBlockOff disables block of specified type for the given
+// application or machine.
+func (st *State) SwitchEntityBlockOff(t BlockType, tag names.Tag) error {
+	if err := validateBlockEntity(t, tag); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		return removeBlockOps(st, t, tag)
+	}
+	return st.run(buildTxn)
+}
+
+// validateBlockEntity checks that the tag identifies an entity which
+// may be blocked individually. Only the model can be protected from
+// destruction.
+func validateBlockEntity(t BlockType, tag names.Tag) error {
+	if t == DestroyBlock {
+		return errors.NotValidf("%v on %s", t.String(), names.ReadableString(tag))
+	}
+	switch tag.(type) {
+	case names.ApplicationTag, names.MachineTag:
+		return nil
+	}
+	return errors.NotValidf("blocking %s", names.ReadableString(tag))
+}
+
+// checkBlockEntityExists returns a NotFound error if the entity to be
+// blocked does not exist.
+func (st *State) checkBlockEntityExists(tag names.Tag) error {
+	var err error
+	switch tag := tag.(type) {
+	case names.ApplicationTag:
+		_, err = st.Application(tag.Id())
+	case names.MachineTag:
+		_, err = st.Machine(tag.Id())
+	}
+	return err
+}
+
+// blockEntityExistsOp returns an op asserting that the blocked entity
+// exists.
+func blockEntityExistsOp(tag names.Tag) txn.Op {
+	op := txn.Op{Id: tag.Id(), Assert: txn.DocExists}
+	switch tag.(type) {
+	case names.ApplicationTag:
+		op.C = applicationsC
+	case names.MachineTag:
+		op.C = machinesC
+	}
+	return op
+}
+
 // GetBlockForType returns the Block of the specified type for the current model
 // where
 //     not found -> nil, false, nil
 //     found -> block, true, nil
 //     error -> nil, false, err
+// Blocks on individual entities are not returned.
 func (st *State) GetBlockForType(t BlockType) (Block, bool, error) {
+	return st.getBlock(t, st.ModelTag())
+}
+
+// GetBlockForTypeAndEntities returns the Block of the specified type for
+// the current model or, if there is none, for the first of the given
+// entities which is blocked, with the same results as GetBlockForType.
+func (st *State) GetBlockForTypeAndEntities(t BlockType, tags ...names.Tag) (Block, bool, error) {
+	b, exists, err := st.GetBlockForType(t)
+	if err != nil || exists {
+		return b, exists, err
+	}
+	for _, tag := range tags {
+		b, exists, err := st.getBlock(t, tag)
+		if err != nil || exists {
+			return b, exists, err
+		}
+	}
+	return nil, false, nil
+}
+
+// getBlock returns the Block of the specified type for the entity
+// with the given tag.
+func (st *State) getBlock(t BlockType, tag names.Tag) (Block, bool, error) {
 	all, closer := st.getCollection(blocksC)
 	defer closer()
 
 	doc := blockDoc{}
-	err := all.Find(bson.D{{"type", t}}).One(&doc)
+	err := all.Find(bson.D{{"type", t}, {"tag", tag.String()}}).One(&doc)
 
 	switch err {
 	case nil:
@@ -253,11 +356,33 @@ func setModelBlock(st *State, t BlockType, msg string) error {
 		if exists {
 			return nil, errors.Errorf("block %v is already ON", t.String())
 		}
-		return createModelBlockOps(st, t, msg)
+		return createBlockOps(st, t, st.ModelTag(), msg)
 	}
 	return st.run(buildTxn)
 }
 
+// removeEntityBlocksOps returns the operations required to remove the
+// blocks on the application or machine with the given tag, when the
+// entity itself is removed.
+func removeEntityBlocksOps(st *State, tag names.Tag) ([]txn.Op, error) {
+	blocks, closer := st.getCollection(blocksC)
+	defer closer()
+
+	var docs []blockDoc
+	if err := blocks.Find(bson.D{{"tag", tag.String()}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get blocks on %s", names.ReadableString(tag))
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      blocksC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
 // newBlockId returns a sequential block id for this model.
 func newBlockId(st *State) (string, error) {
 	seq, err := st.sequence("block")
@@ -267,18 +392,17 @@ func newBlockId(st *State) (string, error) {
 	return fmt.Sprint(seq), nil
 }
 
-func createModelBlockOps(st *State, t BlockType, msg string) ([]txn.Op, error) {
+func createBlockOps(st *State, t BlockType, tag names.Tag, msg string) ([]txn.Op, error) {
 	id, err := newBlockId(st)
 	if err != nil {
 		return nil, errors.Annotatef(err, "getting new block id")
 	}
-	// NOTE: if at any time in the future, we change blocks so that the
-	// Tag is different from the model, then the migration of blocks will
-	// need to change format.
+	// NOTE: blocks on individual entities are not migrated; see
+	// exporter.readBlocks.
 	newDoc := blockDoc{
 		DocID:     st.docID(id),
 		ModelUUID: st.ModelUUID(),
-		Tag:       st.ModelTag().String(),
+		Tag:       tag.String(),
 		Type:      t,
 		Message:   msg,
 	}
@@ -299,7 +423,11 @@ func RemoveModelBlock(st *State, t BlockType) error {
 }
 
 func RemoveModelBlockOps(st *State, t BlockType) ([]txn.Op, error) {
-	tBlock, exists, err := st.GetBlockForType(t)
+	return removeBlockOps(st, t, st.ModelTag())
+}
+
+func removeBlockOps(st *State, t BlockType, tag names.Tag) ([]txn.Op, error) {
+	tBlock, exists, err := st.getBlock(t, tag)
 	if err != nil {
 		return nil, errors.Annotatef(err, "removing block %v", t.String())
 	}
@@ -310,5 +438,8 @@ func RemoveModelBlockOps(st *State, t BlockType) ([]txn.Op, error) {
 			Remove: true,
 		}}, nil
 	}
-	return nil, errors.Errorf("block %v is already OFF", t.String())
+	if _, ok := tag.(names.ModelTag); ok {
+		return nil, errors.Errorf("block %v is already OFF", t.String())
+	}
+	return nil, errors.Errorf("block %v is already OFF for %s", t.String(), names.ReadableString(tag))
 }
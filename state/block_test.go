@@ -218,3 +218,85 @@ func (s *blockSuite) TestConcurrentBlocked(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	assertEnvHasBlock(c, s.State, t, msg)
 }
+
+func (s *blockSuite) TestEntityBlock(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(state.ChangeBlock, app.Tag(), "production")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model itself is not blocked.
+	s.assertNoTypedBlock(c, state.ChangeBlock)
+
+	other := names.NewApplicationTag("other")
+	b, found, err := s.State.GetBlockForTypeAndEntities(state.ChangeBlock, other, app.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	tag, err := b.Tag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, app.Tag())
+	c.Assert(b.Message(), gc.Equals, "production")
+
+	_, found, err = s.State.GetBlockForTypeAndEntities(state.ChangeBlock, other)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+	_, found, err = s.State.GetBlockForTypeAndEntities(state.RemoveBlock, app.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+
+	err = s.State.SwitchEntityBlockOn(state.ChangeBlock, app.Tag(), "again")
+	c.Assert(err, gc.ErrorMatches, `block BlockChange is already ON for application .*`)
+
+	err = s.State.SwitchEntityBlockOff(state.ChangeBlock, app.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEnvBlock(c, s.State)
+	err = s.State.SwitchEntityBlockOff(state.ChangeBlock, app.Tag())
+	c.Assert(err, gc.ErrorMatches, `block BlockChange is already OFF for application .*`)
+}
+
+func (s *blockSuite) TestModelBlockCoversEntities(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	s.switchOnBlock(c, state.RemoveBlock)
+
+	b, found, err := s.State.GetBlockForTypeAndEntities(state.RemoveBlock, app.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	tag, err := b.Tag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, s.State.ModelTag())
+}
+
+func (s *blockSuite) TestEntityBlockInvalid(c *gc.C) {
+	err := s.State.SwitchEntityBlockOn(state.ChangeBlock, names.NewUnitTag("mysql/0"), "")
+	c.Assert(err, gc.ErrorMatches, `blocking unit mysql/0 not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	m := s.Factory.MakeMachine(c, nil)
+	err = s.State.SwitchEntityBlockOn(state.DestroyBlock, m.Tag(), "")
+	c.Assert(err, gc.ErrorMatches, `BlockDestroy on machine [0-9]+ not valid`)
+
+	err = s.State.SwitchEntityBlockOn(state.ChangeBlock, names.NewMachineTag("42"), "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	assertNoEnvBlock(c, s.State)
+}
+
+func (s *blockSuite) TestEntityBlockRemovedWithApplication(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(state.RemoveBlock, app.Tag(), "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEnvBlock(c, s.State)
+}
+
+func (s *blockSuite) TestEntityBlockRemovedWithMachine(c *gc.C) {
+	m := s.Factory.MakeMachine(c, nil)
+	err := s.State.SwitchEntityBlockOn(state.ChangeBlock, m.Tag(), "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = m.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEnvBlock(c, s.State)
+}
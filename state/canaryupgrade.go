@@ -0,0 +1,479 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// CanaryUpgradeArgs holds the parameters of a canary upgrade.
+type CanaryUpgradeArgs struct {
+	// TargetVersion is the agent version to which the model is
+	// upgraded.
+	TargetVersion version.Number
+
+	// Machines holds the ids of the canary machines, which are
+	// upgraded first.
+	Machines []string
+
+	// SoakPeriod is how long the canaries must remain healthy
+	// before the rest of the model is upgraded.
+	SoakPeriod time.Duration
+
+	// AutoProceed records whether the rest of the model is upgraded
+	// as soon as the soak period has passed, rather than when the
+	// upgrade is confirmed.
+	AutoProceed bool
+}
+
+// CanaryUpgrade describes a canary upgrade in progress: the canary
+// machines, and their units, are upgraded to the target version while
+// the rest of the model's agents keep running the model's agent
+// version until the upgrade proceeds.
+type CanaryUpgrade struct {
+	CanaryUpgradeArgs
+
+	// PreviousVersion is the model's agent version when the canary
+	// upgrade started.
+	PreviousVersion version.Number
+
+	// Started records when the canary upgrade started.
+	Started time.Time
+
+	// HealthySince records when the canaries were found healthy,
+	// having not been before. It is zero while they are not healthy.
+	HealthySince time.Time
+}
+
+// IsCanary returns whether the machine with the given id is one of the
+// upgrade's canaries.
+func (u CanaryUpgrade) IsCanary(machineId string) bool {
+	for _, id := range u.Machines {
+		if id == machineId {
+			return true
+		}
+	}
+	return false
+}
+
+// Soaked returns whether the canaries have been healthy for the soak
+// period at the given time.
+func (u CanaryUpgrade) Soaked(now time.Time) bool {
+	return !u.HealthySince.IsZero() && now.Sub(u.HealthySince) >= u.SoakPeriod
+}
+
+// canaryUpgradeDoc represents the MongoDB document that stores the
+// canary upgrade in progress in a model.
+type canaryUpgradeDoc struct {
+	DocID           string   `bson:"_id"`
+	ModelUUID       string   `bson:"model-uuid"`
+	PreviousVersion string   `bson:"previous-version"`
+	TargetVersion   string   `bson:"target-version"`
+	Machines        []string `bson:"machines"`
+	SoakPeriod      int64    `bson:"soak-period"`
+	AutoProceed     bool     `bson:"auto-proceed"`
+	Started         int64    `bson:"started"`
+	HealthySince    int64    `bson:"healthy-since"`
+}
+
+func (doc canaryUpgradeDoc) toCanaryUpgrade() (CanaryUpgrade, error) {
+	previous, err := version.Parse(doc.PreviousVersion)
+	if err != nil {
+		return CanaryUpgrade{}, errors.Trace(err)
+	}
+	target, err := version.Parse(doc.TargetVersion)
+	if err != nil {
+		return CanaryUpgrade{}, errors.Trace(err)
+	}
+	upgrade := CanaryUpgrade{
+		CanaryUpgradeArgs: CanaryUpgradeArgs{
+			TargetVersion: target,
+			Machines:      doc.Machines,
+			SoakPeriod:    time.Duration(doc.SoakPeriod),
+			AutoProceed:   doc.AutoProceed,
+		},
+		PreviousVersion: previous,
+		Started:         time.Unix(0, doc.Started),
+	}
+	if doc.HealthySince != 0 {
+		upgrade.HealthySince = time.Unix(0, doc.HealthySince)
+	}
+	return upgrade, nil
+}
+
+// CanaryUpgrade returns the canary upgrade in progress in the model.
+// It returns an error satisfying errors.IsNotFound if there is none.
+func (st *State) CanaryUpgrade() (CanaryUpgrade, error) {
+	doc, err := st.canaryUpgradeDoc()
+	if err != nil {
+		return CanaryUpgrade{}, errors.Trace(err)
+	}
+	return doc.toCanaryUpgrade()
+}
+
+func (st *State) canaryUpgradeDoc() (canaryUpgradeDoc, error) {
+	coll, closer := st.getCollection(canaryUpgradesC)
+	defer closer()
+
+	var doc canaryUpgradeDoc
+	err := coll.FindId(modelGlobalKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return canaryUpgradeDoc{}, errors.NotFoundf("canary upgrade")
+	} else if err != nil {
+		return canaryUpgradeDoc{}, errors.Annotate(err, "cannot get canary upgrade")
+	}
+	return doc, nil
+}
+
+// StartCanaryUpgrade starts upgrading the given canary machines to the
+// target version. The rest of the model is upgraded when the upgrade
+// proceeds, which it does automatically if requested once the
+// canaries have been healthy for the soak period. Controller machines
+// are not canaries: they are always upgraded first.
+func (st *State) StartCanaryUpgrade(args CanaryUpgradeArgs, now time.Time) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot start canary upgrade")
+	if args.TargetVersion.Compare(jujuversion.Current) > 0 && !st.IsController() {
+		return errors.Errorf("a hosted model cannot have a higher version than the server model: %s > %s",
+			args.TargetVersion.String(),
+			jujuversion.Current,
+		)
+	}
+	if len(args.Machines) == 0 {
+		return errors.New("no canary machines specified")
+	}
+	if args.SoakPeriod < 0 {
+		return errors.NotValidf("negative soak period")
+	}
+	var machineOps []txn.Op
+	for _, id := range args.Machines {
+		m, err := st.Machine(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if m.IsManager() {
+			return errors.Errorf("machine %s is a controller and cannot be a canary", id)
+		}
+		machineOps = append(machineOps, txn.Op{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+		})
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.canaryUpgradeDoc(); err == nil {
+			return nil, errors.New("a canary upgrade is already in progress")
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		settings, currentVersion, err := st.readAgentVersion()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if args.TargetVersion == currentVersion {
+			return nil, errors.Errorf("model is already running version %s", currentVersion)
+		}
+		if err := st.checkCanUpgrade(currentVersion.String(), args.TargetVersion.String()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      upgradeInfoC,
+			Id:     currentUpgradeId,
+			Assert: txn.DocMissing,
+		}, {
+			C:      settingsC,
+			Id:     st.docID(modelGlobalKey),
+			Assert: bson.D{{"version", settings.version}},
+		}, {
+			C:      canaryUpgradesC,
+			Id:     modelGlobalKey,
+			Assert: txn.DocMissing,
+			Insert: &canaryUpgradeDoc{
+				DocID:           st.docID(modelGlobalKey),
+				ModelUUID:       st.ModelUUID(),
+				PreviousVersion: currentVersion.String(),
+				TargetVersion:   args.TargetVersion.String(),
+				Machines:        args.Machines,
+				SoakPeriod:      int64(args.SoakPeriod),
+				AutoProceed:     args.AutoProceed,
+				Started:         now.UnixNano(),
+			},
+		}}
+		return append(ops, machineOps...), nil
+	}
+	return st.run(buildTxn)
+}
+
+// readAgentVersion returns the model's settings and the agent version
+// they hold.
+func (st *State) readAgentVersion() (*Settings, version.Number, error) {
+	settings, err := readSettings(st, settingsC, modelGlobalKey)
+	if err != nil {
+		return nil, version.Number{}, errors.Trace(err)
+	}
+	agentVersion, ok := settings.Get("agent-version")
+	if !ok {
+		return nil, version.Number{}, errors.Errorf("no agent version set in the model")
+	}
+	currentVersion, ok := agentVersion.(string)
+	if !ok {
+		return nil, version.Number{}, errors.Errorf("invalid agent version format: expected string, got %v", agentVersion)
+	}
+	vers, err := version.Parse(currentVersion)
+	if err != nil {
+		return nil, version.Number{}, errors.Trace(err)
+	}
+	return settings, vers, nil
+}
+
+// CanaryProblems returns the reasons for which the canaries of the
+// canary upgrade in progress are not healthy, or none if they are.
+// Canaries are healthy when their agents, and those of their units,
+// run the target version and none of them is in error or down.
+func (st *State) CanaryProblems() ([]string, error) {
+	upgrade, err := st.CanaryUpgrade()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.canaryProblems(upgrade)
+}
+
+func (st *State) canaryProblems(upgrade CanaryUpgrade) ([]string, error) {
+	var problems []string
+	for _, id := range upgrade.Machines {
+		m, err := st.Machine(id)
+		if errors.IsNotFound(err) {
+			problems = append(problems, fmt.Sprintf("machine %s not found", id))
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineProblems, err := st.canaryMachineProblems(m, upgrade.TargetVersion)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		problems = append(problems, machineProblems...)
+	}
+	return problems, nil
+}
+
+func (st *State) canaryMachineProblems(m *Machine, target version.Number) ([]string, error) {
+	if m.Life() != Alive {
+		return []string{fmt.Sprintf("machine %s is %s", m.Id(), m.Life())}, nil
+	}
+	if problem, err := canaryAgentVersionProblem(m, target); err != nil || problem != "" {
+		return []string{problem}, errors.Trace(err)
+	}
+	info, err := m.Status()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.Status != status.StatusStarted {
+		return []string{fmt.Sprintf("machine %s is %s", m.Id(), info.Status)}, nil
+	}
+	alive, err := m.AgentPresence()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !alive {
+		return []string{fmt.Sprintf("machine %s agent is not communicating with the server", m.Id())}, nil
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var problems []string
+	for _, u := range units {
+		if problem, err := canaryAgentVersionProblem(u, target); err != nil {
+			return nil, errors.Trace(err)
+		} else if problem != "" {
+			problems = append(problems, problem)
+			continue
+		}
+		info, err := u.AgentStatus()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.Status == status.StatusError {
+			problems = append(problems, fmt.Sprintf("unit %s is in error: %s", u.Name(), info.Message))
+		}
+	}
+	return problems, nil
+}
+
+// canaryAgentVersionProblem returns a description of the problem if
+// the given agent is not running the target version.
+func canaryAgentVersionProblem(agent AgentTooler, target version.Number) (string, error) {
+	name := names.ReadableString(agent.Tag())
+	tools, err := agent.AgentTools()
+	if errors.IsNotFound(err) {
+		return fmt.Sprintf("%s has not reported its version", name), nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	if tools.Version.Number != target {
+		return fmt.Sprintf("%s is running %s", name, tools.Version.Number), nil
+	}
+	return "", nil
+}
+
+// CheckCanaryUpgrade checks the health of the canaries of the canary
+// upgrade in progress, if any, at the given time. It records when they
+// became healthy, and proceeds with the upgrade if it is to proceed
+// automatically and they have been healthy for the soak period.
+func (st *State) CheckCanaryUpgrade(now time.Time) error {
+	doc, err := st.canaryUpgradeDoc()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	upgrade, err := doc.toCanaryUpgrade()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	problems, err := st.canaryProblems(upgrade)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	healthySince := doc.HealthySince
+	switch {
+	case len(problems) > 0:
+		healthySince = 0
+	case healthySince == 0:
+		healthySince = now.UnixNano()
+	}
+	if healthySince != doc.HealthySince {
+		ops := []txn.Op{{
+			C:      canaryUpgradesC,
+			Id:     modelGlobalKey,
+			Assert: bson.D{{"healthy-since", doc.HealthySince}},
+			Update: bson.D{{"$set", bson.D{{"healthy-since", healthySince}}}},
+		}}
+		if err := st.runTransaction(ops); err == txn.ErrAborted {
+			// The upgrade has proceeded, been aborted, or been
+			// checked concurrently; check again next time.
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "cannot record canary health")
+		}
+		upgrade.HealthySince = time.Time{}
+		if healthySince != 0 {
+			upgrade.HealthySince = time.Unix(0, healthySince)
+		}
+	}
+	if upgrade.AutoProceed && len(problems) == 0 && upgrade.Soaked(now) {
+		logger.Infof("canaries healthy for %v; upgrading model to %s", upgrade.SoakPeriod, upgrade.TargetVersion)
+		return errors.Trace(st.proceedCanaryUpgrade(upgrade))
+	}
+	return nil
+}
+
+// ProceedCanaryUpgrade upgrades the rest of the model to the target
+// version of the canary upgrade in progress. It fails unless the
+// canaries are healthy and have been for the soak period.
+func (st *State) ProceedCanaryUpgrade(now time.Time) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot proceed with canary upgrade")
+	upgrade, err := st.CanaryUpgrade()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	problems, err := st.canaryProblems(upgrade)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("canaries are not healthy: %s", problems[0])
+	}
+	if !upgrade.Soaked(now) {
+		remaining := upgrade.SoakPeriod
+		if !upgrade.HealthySince.IsZero() {
+			remaining -= now.Sub(upgrade.HealthySince)
+		}
+		return errors.Errorf("canaries must remain healthy for another %v", remaining)
+	}
+	return errors.Trace(st.proceedCanaryUpgrade(upgrade))
+}
+
+// proceedCanaryUpgrade sets the model's agent version to the target of
+// the given canary upgrade, which it removes.
+func (st *State) proceedCanaryUpgrade(upgrade CanaryUpgrade) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.canaryUpgradeDoc(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		settings, currentVersion, err := st.readAgentVersion()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := st.checkCanUpgrade(currentVersion.String(), upgrade.TargetVersion.String()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      canaryUpgradesC,
+			Id:     modelGlobalKey,
+			Assert: txn.DocExists,
+			Remove: true,
+		}, {
+			C:      upgradeInfoC,
+			Id:     currentUpgradeId,
+			Assert: txn.DocMissing,
+		}, {
+			C:      settingsC,
+			Id:     st.docID(modelGlobalKey),
+			Assert: bson.D{{"version", settings.version}},
+			Update: bson.D{
+				{"$set", bson.D{{"settings.agent-version", upgrade.TargetVersion.String()}}},
+			},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// AbortCanaryUpgrade abandons the canary upgrade in progress. The
+// canaries return to the model's agent version.
+func (st *State) AbortCanaryUpgrade() error {
+	ops := []txn.Op{{
+		C:      canaryUpgradesC,
+		Id:     modelGlobalKey,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("canary upgrade")
+	}
+	return errors.Annotate(err, "cannot abort canary upgrade")
+}
+
+// WatchCanaryUpgrade returns a watcher which notifies when a canary
+// upgrade starts, proceeds or is aborted in the model.
+func (st *State) WatchCanaryUpgrade() NotifyWatcher {
+	return newDocWatcher(st, []docKey{{canaryUpgradesC, st.docID(modelGlobalKey)}})
+}
+
+// noCanaryUpgradeOp returns an operation which asserts that no canary
+// upgrade is in progress in the model.
+func noCanaryUpgradeOp(st *State) txn.Op {
+	return txn.Op{
+		C:      canaryUpgradesC,
+		Id:     modelGlobalKey,
+		Assert: txn.DocMissing,
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+	jujuversion "github.com/juju/juju/version"
+	"github.com/juju/juju/worker"
+)
+
+type CanaryUpgradeSuite struct {
+	ConnSuite
+	current version.Number
+	target  version.Number
+	canary  *state.Machine
+	other   *state.Machine
+	now     time.Time
+}
+
+var _ = gc.Suite(&CanaryUpgradeSuite{})
+
+func (s *CanaryUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	var ok bool
+	s.current, ok = cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	s.target = s.current
+	s.target.Patch++
+	s.PatchValue(&jujuversion.Current, s.target)
+
+	s.canary = s.Factory.MakeMachine(c, nil)
+	s.other = s.Factory.MakeMachine(c, nil)
+	s.setVersion(c, s.canary, s.current)
+	s.setVersion(c, s.other, s.current)
+	s.now = time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *CanaryUpgradeSuite) setVersion(c *gc.C, agent state.AgentTooler, vers version.Number) {
+	binary := version.Binary{Number: vers, Series: "quantal", Arch: "amd64"}
+	err := agent.SetAgentVersion(binary)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CanaryUpgradeSuite) start(c *gc.C, autoProceed bool) {
+	err := s.State.StartCanaryUpgrade(state.CanaryUpgradeArgs{
+		TargetVersion: s.target,
+		Machines:      []string{s.canary.Id()},
+		SoakPeriod:    time.Hour,
+		AutoProceed:   autoProceed,
+	}, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// upgradeCanary makes the canary machine healthy at the target version,
+// returning a func which stops its agent presence.
+func (s *CanaryUpgradeSuite) upgradeCanary(c *gc.C) func() {
+	s.setVersion(c, s.canary, s.target)
+	err := s.canary.SetStatus(status.StatusInfo{Status: status.StatusStarted})
+	c.Assert(err, jc.ErrorIsNil)
+	pinger, err := s.canary.SetAgentPresence()
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	return func() {
+		c.Assert(worker.Stop(pinger), jc.ErrorIsNil)
+	}
+}
+
+func (s *CanaryUpgradeSuite) TestNoCanaryUpgrade(c *gc.C) {
+	_, err := s.State.CanaryUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.CheckCanaryUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CanaryUpgradeSuite) TestStartCanaryUpgrade(c *gc.C) {
+	s.start(c, false)
+	upgrade, err := s.State.CanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade, jc.DeepEquals, state.CanaryUpgrade{
+		CanaryUpgradeArgs: state.CanaryUpgradeArgs{
+			TargetVersion: s.target,
+			Machines:      []string{s.canary.Id()},
+			SoakPeriod:    time.Hour,
+		},
+		PreviousVersion: s.current,
+		Started:         time.Unix(0, s.now.UnixNano()),
+	})
+	c.Assert(upgrade.IsCanary(s.canary.Id()), jc.IsTrue)
+	c.Assert(upgrade.IsCanary(s.other.Id()), jc.IsFalse)
+
+	// The model's agent version is unchanged until the upgrade
+	// proceeds, and cannot be changed otherwise meanwhile.
+	assertAgentVersion(c, s.State, s.current.String())
+	err = s.State.SetModelAgentVersion(s.target)
+	c.Assert(err, gc.ErrorMatches, "a canary upgrade to .* is in progress")
+}
+
+func (s *CanaryUpgradeSuite) TestStartCanaryUpgradeInvalid(c *gc.C) {
+	for _, test := range []struct {
+		args state.CanaryUpgradeArgs
+		err  string
+	}{{
+		args: state.CanaryUpgradeArgs{TargetVersion: s.target},
+		err:  "no canary machines specified",
+	}, {
+		args: state.CanaryUpgradeArgs{TargetVersion: s.target, Machines: []string{"42"}},
+		err:  "machine 42 not found",
+	}, {
+		args: state.CanaryUpgradeArgs{TargetVersion: s.current, Machines: []string{s.canary.Id()}},
+		err:  "model is already running version .*",
+	}, {
+		args: state.CanaryUpgradeArgs{TargetVersion: s.target, Machines: []string{s.canary.Id()}, SoakPeriod: -time.Second},
+		err:  "negative soak period not valid",
+	}} {
+		err := s.State.StartCanaryUpgrade(test.args, s.now)
+		c.Check(err, gc.ErrorMatches, "cannot start canary upgrade: "+test.err)
+	}
+}
+
+func (s *CanaryUpgradeSuite) TestStartCanaryUpgradeController(c *gc.C) {
+	controller, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.StartCanaryUpgrade(state.CanaryUpgradeArgs{
+		TargetVersion: s.target,
+		Machines:      []string{controller.Id()},
+	}, s.now)
+	c.Assert(err, gc.ErrorMatches, "cannot start canary upgrade: machine .* is a controller and cannot be a canary")
+}
+
+func (s *CanaryUpgradeSuite) TestStartCanaryUpgradeInProgress(c *gc.C) {
+	s.start(c, false)
+	err := s.State.StartCanaryUpgrade(state.CanaryUpgradeArgs{
+		TargetVersion: s.target,
+		Machines:      []string{s.other.Id()},
+	}, s.now)
+	c.Assert(err, gc.ErrorMatches, "cannot start canary upgrade: a canary upgrade is already in progress")
+}
+
+func (s *CanaryUpgradeSuite) TestCanaryProblems(c *gc.C) {
+	s.start(c, false)
+	problems, err := s.State.CanaryProblems()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{
+		"machine " + s.canary.Id() + " is running " + s.current.String(),
+	})
+
+	defer s.upgradeCanary(c)()
+	problems, err = s.State.CanaryProblems()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 0)
+}
+
+func (s *CanaryUpgradeSuite) TestCanaryProblemsUnits(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: s.canary})
+	s.setVersion(c, unit, s.current)
+	s.start(c, false)
+	defer s.upgradeCanary(c)()
+
+	problems, err := s.State.CanaryProblems()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{
+		"unit " + unit.Name() + " is running " + s.current.String(),
+	})
+
+	s.setVersion(c, unit, s.target)
+	err = unit.Agent().SetStatus(status.StatusInfo{
+		Status:  status.StatusError,
+		Message: "hook failed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	problems, err = s.State.CanaryProblems()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{
+		"unit " + unit.Name() + " is in error: hook failed",
+	})
+}
+
+func (s *CanaryUpgradeSuite) TestCheckCanaryUpgradeRecordsHealth(c *gc.C) {
+	s.start(c, false)
+	err := s.State.CheckCanaryUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := s.State.CanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.HealthySince.IsZero(), jc.IsTrue)
+
+	defer s.upgradeCanary(c)()
+	healthy := s.now.Add(time.Minute)
+	err = s.State.CheckCanaryUpgrade(healthy)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckCanaryUpgrade(healthy.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err = s.State.CanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.HealthySince.Equal(healthy), jc.IsTrue)
+	c.Assert(upgrade.Soaked(healthy.Add(time.Hour)), jc.IsTrue)
+
+	// Without auto-proceed, the upgrade waits for confirmation.
+	err = s.State.CheckCanaryUpgrade(healthy.Add(2 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	assertAgentVersion(c, s.State, s.current.String())
+
+	// The canary failing resets its health.
+	err = s.canary.SetStatus(status.StatusInfo{Status: status.StatusError, Message: "boom"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckCanaryUpgrade(healthy.Add(3 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err = s.State.CanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.HealthySince.IsZero(), jc.IsTrue)
+}
+
+func (s *CanaryUpgradeSuite) TestCheckCanaryUpgradeAutoProceeds(c *gc.C) {
+	s.start(c, true)
+	defer s.upgradeCanary(c)()
+
+	err := s.State.CheckCanaryUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckCanaryUpgrade(s.now.Add(59 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	assertAgentVersion(c, s.State, s.current.String())
+
+	err = s.State.CheckCanaryUpgrade(s.now.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	assertAgentVersion(c, s.State, s.target.String())
+	_, err = s.State.CanaryUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CanaryUpgradeSuite) TestProceedCanaryUpgrade(c *gc.C) {
+	s.start(c, false)
+	err := s.State.ProceedCanaryUpgrade(s.now)
+	c.Assert(err, gc.ErrorMatches, "cannot proceed with canary upgrade: canaries are not healthy: machine .* is running .*")
+
+	defer s.upgradeCanary(c)()
+	err = s.State.CheckCanaryUpgrade(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ProceedCanaryUpgrade(s.now.Add(15 * time.Minute))
+	c.Assert(err, gc.ErrorMatches, "cannot proceed with canary upgrade: canaries must remain healthy for another 45m0s")
+
+	err = s.State.ProceedCanaryUpgrade(s.now.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	assertAgentVersion(c, s.State, s.target.String())
+	_, err = s.State.CanaryUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CanaryUpgradeSuite) TestAbortCanaryUpgrade(c *gc.C) {
+	err := s.State.AbortCanaryUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.start(c, false)
+	err = s.State.AbortCanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CanaryUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	assertAgentVersion(c, s.State, s.current.String())
+}
+
+func (s *CanaryUpgradeSuite) TestWatchCanaryUpgrade(c *gc.C) {
+	w := s.State.WatchCanaryUpgrade()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.start(c, false)
+	wc.AssertOneChange()
+
+	err := s.State.AbortCanaryUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -74,6 +74,10 @@ type charmDoc struct {
 	PendingUpload bool   `bson:"pendingupload"`
 	Placeholder   bool   `bson:"placeholder"`
 	Macaroon      []byte `bson:"macaroon"`
+
+	// ConfigChoices holds the values permitted for those of the
+	// charm's config options which restrict them.
+	ConfigChoices []configChoicesDoc `bson:"config-choices,omitempty"`
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -83,6 +87,10 @@ type CharmInfo struct {
 	StoragePath string
 	SHA256      string
 	Macaroon    macaroon.Slice
+
+	// ConfigChoices holds, for each of the charm's config options that
+	// declares them, the only values the option may be set to.
+	ConfigChoices map[string][]interface{}
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -102,6 +110,8 @@ func insertCharmOps(st *State, info CharmInfo) ([]txn.Op, error) {
 		Actions:      info.Charm.Actions(),
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
+
+		ConfigChoices: configChoicesDocs(info.ConfigChoices),
 	}
 	if info.Macaroon != nil {
 		mac, err := info.Macaroon.MarshalBinary()
@@ -170,6 +180,7 @@ func updateCharmOps(
 		{"bundlesha256", info.SHA256},
 		{"pendingupload", false},
 		{"placeholder", false},
+		{"config-choices", configChoicesDocs(info.ConfigChoices)},
 	}
 
 	if len(info.Macaroon) > 0 {
@@ -318,6 +329,19 @@ func (c *Charm) Config() *charm.Config {
 	return c.doc.Config
 }
 
+// ConfigChoices returns, for each of the charm's config options which
+// restricts its values, the values it may be set to.
+func (c *Charm) ConfigChoices() map[string][]interface{} {
+	if len(c.doc.ConfigChoices) == 0 {
+		return nil
+	}
+	choices := make(map[string][]interface{})
+	for _, doc := range c.doc.ConfigChoices {
+		choices[doc.Option] = doc.Values
+	}
+	return choices
+}
+
 // Metrics returns the metrics declared for the charm.
 func (c *Charm) Metrics() *charm.Metrics {
 	return c.doc.Metrics
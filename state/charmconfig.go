@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// configChoicesDoc records the values permitted for a charm config
+// option. Option names may contain characters which are not valid in
+// document keys, so the choices are stored as a list.
+type configChoicesDoc struct {
+	Option string        `bson:"option"`
+	Values []interface{} `bson:"values"`
+}
+
+// configChoicesDocs returns the documents recording the supplied
+// choices, sorted by option name.
+func configChoicesDocs(choices map[string][]interface{}) []configChoicesDoc {
+	if len(choices) == 0 {
+		return nil
+	}
+	docs := make([]configChoicesDoc, 0, len(choices))
+	for option, values := range choices {
+		docs = append(docs, configChoicesDoc{Option: option, Values: values})
+	}
+	sort.Sort(configChoicesDocsByOption(docs))
+	return docs
+}
+
+type configChoicesDocsByOption []configChoicesDoc
+
+func (d configChoicesDocsByOption) Len() int           { return len(d) }
+func (d configChoicesDocsByOption) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+func (d configChoicesDocsByOption) Less(i, j int) bool { return d[i].Option < d[j].Option }
+
+// ConfigOptionError describes why a value for a charm config option
+// is invalid.
+type ConfigOptionError struct {
+	// Option is the name of the config option.
+	Option string
+
+	// Value is the invalid value.
+	Value interface{}
+
+	// Reason describes why the value is invalid.
+	Reason string
+}
+
+// ConfigValidationError is returned when config settings for an
+// application do not satisfy its charm's config options.
+type ConfigValidationError struct {
+	Application string
+	Errors      []ConfigOptionError
+}
+
+// Error is part of the error interface.
+func (e *ConfigValidationError) Error() string {
+	reasons := make([]string, len(e.Errors))
+	for i, optionErr := range e.Errors {
+		reasons[i] = optionErr.Reason
+	}
+	return fmt.Sprintf("invalid config for application %q: %s", e.Application, strings.Join(reasons, "; "))
+}
+
+// IsConfigValidationError returns whether the cause of the supplied
+// error is a *ConfigValidationError.
+func IsConfigValidationError(err error) bool {
+	_, ok := errors.Cause(err).(*ConfigValidationError)
+	return ok
+}
+
+// validateConfigSettings checks each of the supplied settings against
+// the charm's declared config option types and choices, and returns
+// the settings with their values converted to the options' types.
+// Nil values, which unset options, are checked only for the option's
+// existence. All invalid settings are reported in a single
+// *ConfigValidationError.
+func validateConfigSettings(application string, ch *Charm, settings charm.Settings) (charm.Settings, error) {
+	config := ch.Config()
+	choices := ch.ConfigChoices()
+	options := make([]string, 0, len(settings))
+	for option := range settings {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+
+	validated := make(charm.Settings)
+	var optionErrs []ConfigOptionError
+	for _, option := range options {
+		value := settings[option]
+		result, err := config.ValidateSettings(charm.Settings{option: value})
+		if err != nil {
+			optionErrs = append(optionErrs, ConfigOptionError{
+				Option: option,
+				Value:  value,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		value = result[option]
+		if value != nil && len(choices[option]) > 0 && !isConfigChoice(value, choices[option]) {
+			optionErrs = append(optionErrs, ConfigOptionError{
+				Option: option,
+				Value:  value,
+				Reason: fmt.Sprintf("option %q must be one of %v, got %#v", option, choices[option], value),
+			})
+			continue
+		}
+		validated[option] = value
+	}
+	if len(optionErrs) > 0 {
+		return nil, &ConfigValidationError{
+			Application: application,
+			Errors:      optionErrs,
+		}
+	}
+	return validated, nil
+}
+
+func isConfigChoice(value interface{}, choices []interface{}) bool {
+	for _, choice := range choices {
+		if value == choice {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ApplicationCharmUpgrade describes the upgrade of a single
+// application's charm, as part of a call to SetCharms.
+type ApplicationCharmUpgrade struct {
+	// Application is the name of the application to upgrade.
+	Application string
+
+	// SetCharmConfig holds the charm, at the exact revision the
+	// application is to be pinned to, and the upgrade options.
+	SetCharmConfig
+}
+
+// CharmUpgradeError is returned by SetCharms when any of the requested
+// upgrades is invalid, in which case no application is upgraded.
+type CharmUpgradeError struct {
+	// Errors holds the reason each invalid upgrade was refused,
+	// keyed by application name.
+	Errors map[string]error
+}
+
+// Error is part of the error interface.
+func (e *CharmUpgradeError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	reasons := make([]string, len(names))
+	for i, name := range names {
+		reasons[i] = fmt.Sprintf("application %q: %v", name, e.Errors[name])
+	}
+	return "cannot upgrade charms: " + strings.Join(reasons, "; ")
+}
+
+// IsCharmUpgradeError returns whether the cause of the supplied error
+// is a *CharmUpgradeError.
+func IsCharmUpgradeError(err error) bool {
+	_, ok := errors.Cause(err).(*CharmUpgradeError)
+	return ok
+}
+
+// SetCharms upgrades the charms of several applications in a single
+// transaction, so that either every application is upgraded or none
+// is. Each upgrade is checked as it would be by Application.SetCharm,
+// including that the new charm does not remove or incompatibly change
+// any storage in use. In addition, and unlike SetCharm, which discards
+// any settings the new charm does not accept, each application's
+// existing config settings must remain valid for its new charm.
+//
+// If any upgrade is invalid, a *CharmUpgradeError describing every
+// invalid upgrade is returned.
+func (st *State) SetCharms(upgrades []ApplicationCharmUpgrade) error {
+	if len(upgrades) == 0 {
+		return nil
+	}
+	seen := set.NewStrings()
+	applications := make([]*Application, len(upgrades))
+	for i, upgrade := range upgrades {
+		if seen.Contains(upgrade.Application) {
+			return errors.Errorf("cannot upgrade charms: application %q specified more than once", upgrade.Application)
+		}
+		seen.Add(upgrade.Application)
+		application, err := st.Application(upgrade.Application)
+		if err != nil {
+			return errors.Annotate(err, "cannot upgrade charms")
+		}
+		applications[i] = application
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		invalid := make(map[string]error)
+		var ops []txn.Op
+		for i, upgrade := range upgrades {
+			application := applications[i]
+			if attempt > 0 {
+				if err := application.Refresh(); err != nil {
+					invalid[upgrade.Application] = err
+					continue
+				}
+			}
+			upgradeOps, err := application.charmUpgradeOps(upgrade.SetCharmConfig)
+			if err != nil {
+				invalid[upgrade.Application] = err
+				continue
+			}
+			ops = append(ops, upgradeOps...)
+		}
+		if len(invalid) > 0 {
+			return nil, &CharmUpgradeError{Errors: invalid}
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		if IsCharmUpgradeError(err) {
+			return err
+		}
+		return errors.Annotate(err, "cannot upgrade charms")
+	}
+	return nil
+}
+
+// charmUpgradeOps returns the operations necessary to upgrade the
+// application as part of a call to SetCharms.
+func (s *Application) charmUpgradeOps(cfg SetCharmConfig) ([]txn.Op, error) {
+	// As with SetCharm, dying applications may still be upgraded.
+	if s.doc.Life == Dead {
+		return nil, ErrDead
+	}
+	if err := s.validateSetCharm(cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := s.checkConfigUpgrade(cfg.Charm); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.setCharmOps(cfg)
+}
+
+// checkConfigUpgrade returns a *ConfigValidationError if any of the
+// application's current config settings would not be valid for the
+// supplied charm, and so would be discarded by an upgrade to it.
+func (s *Application) checkConfigUpgrade(ch *Charm) error {
+	settings, err := readSettings(s.st, settingsC, s.settingsKey())
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = validateConfigSettings(s.doc.Name, ch, charm.Settings(settings.Map()))
+	return errors.Trace(err)
+}
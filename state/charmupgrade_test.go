@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmUpgradeSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CharmUpgradeSuite{})
+
+func assertApplicationCharm(c *gc.C, application *state.Application, expectURL *charm.URL, expectForce bool) {
+	err := application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	url, force := application.CharmURL()
+	c.Assert(url, gc.DeepEquals, expectURL)
+	c.Assert(force, gc.Equals, expectForce)
+}
+
+func (s *CharmUpgradeSuite) TestSetCharms(c *gc.C) {
+	wordpressOld := s.AddConfigCharm(c, "wordpress", stringConfig, 1)
+	wordpressNew := s.AddConfigCharm(c, "wordpress", stringConfig, 2)
+	mysqlOld := s.AddMetaCharm(c, "mysql", mysqlBaseMeta, 2)
+	mysqlNew := s.AddMetaCharm(c, "mysql", mysqlBaseMeta, 3)
+	wordpress := s.AddTestingService(c, "wordpress", wordpressOld)
+	mysql := s.AddTestingService(c, "mysql", mysqlOld)
+
+	err := s.State.SetCharms([]state.ApplicationCharmUpgrade{{
+		Application:    "wordpress",
+		SetCharmConfig: state.SetCharmConfig{Charm: wordpressNew},
+	}, {
+		Application:    "mysql",
+		SetCharmConfig: state.SetCharmConfig{Charm: mysqlNew, ForceUnits: true},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	assertApplicationCharm(c, wordpress, wordpressNew.URL(), false)
+	assertApplicationCharm(c, mysql, mysqlNew.URL(), true)
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmsIncompatibleConfig(c *gc.C) {
+	wordpressOld := s.AddConfigCharm(c, "wordpress", stringConfig, 1)
+	wordpressNew := s.AddConfigCharm(c, "wordpress", emptyConfig, 2)
+	mysqlOld := s.AddMetaCharm(c, "mysql", mysqlBaseMeta, 2)
+	mysqlNew := s.AddMetaCharm(c, "mysql", mysqlBaseMeta, 3)
+	wordpress := s.AddTestingService(c, "wordpress", wordpressOld)
+	mysql := s.AddTestingService(c, "mysql", mysqlOld)
+	err := wordpress.UpdateConfigSettings(charm.Settings{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetCharms([]state.ApplicationCharmUpgrade{{
+		Application:    "mysql",
+		SetCharmConfig: state.SetCharmConfig{Charm: mysqlNew},
+	}, {
+		Application:    "wordpress",
+		SetCharmConfig: state.SetCharmConfig{Charm: wordpressNew},
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade charms: application "wordpress": invalid config for application "wordpress": unknown option "key"`)
+	c.Assert(err, jc.Satisfies, state.IsCharmUpgradeError)
+	upgradeErr := err.(*state.CharmUpgradeError)
+	c.Assert(upgradeErr.Errors, gc.HasLen, 1)
+	c.Assert(upgradeErr.Errors["wordpress"], jc.Satisfies, state.IsConfigValidationError)
+
+	// Neither application is upgraded.
+	assertApplicationCharm(c, wordpress, wordpressOld.URL(), false)
+	assertApplicationCharm(c, mysql, mysqlOld.URL(), false)
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmsIncompatibleStorage(c *gc.C) {
+	wordpressOld := s.AddConfigCharm(c, "wordpress", stringConfig, 1)
+	wordpressNew := s.AddConfigCharm(c, "wordpress", stringConfig, 2)
+	mysqlOld := s.AddMetaCharm(c, "mysql", mysqlBaseMeta+oneRequiredStorageMeta, 2)
+	mysqlNew := s.AddMetaCharm(c, "mysql", mysqlBaseMeta, 3)
+	wordpress := s.AddTestingService(c, "wordpress", wordpressOld)
+	mysql := s.AddTestingService(c, "mysql", mysqlOld)
+
+	err := s.State.SetCharms([]state.ApplicationCharmUpgrade{{
+		Application:    "wordpress",
+		SetCharmConfig: state.SetCharmConfig{Charm: wordpressNew},
+	}, {
+		Application:    "mysql",
+		SetCharmConfig: state.SetCharmConfig{Charm: mysqlNew},
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade charms: application "mysql": cannot upgrade application "mysql" to charm "mysql": required storage "data0" removed`)
+	c.Assert(err, jc.Satisfies, state.IsCharmUpgradeError)
+
+	assertApplicationCharm(c, wordpress, wordpressOld.URL(), false)
+	assertApplicationCharm(c, mysql, mysqlOld.URL(), false)
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmsDuplicateApplication(c *gc.C) {
+	wordpressOld := s.AddConfigCharm(c, "wordpress", stringConfig, 1)
+	wordpressNew := s.AddConfigCharm(c, "wordpress", stringConfig, 2)
+	s.AddTestingService(c, "wordpress", wordpressOld)
+
+	upgrade := state.ApplicationCharmUpgrade{
+		Application:    "wordpress",
+		SetCharmConfig: state.SetCharmConfig{Charm: wordpressNew},
+	}
+	err := s.State.SetCharms([]state.ApplicationCharmUpgrade{upgrade, upgrade})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade charms: application "wordpress" specified more than once`)
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmsApplicationNotFound(c *gc.C) {
+	wordpress := s.AddConfigCharm(c, "wordpress", stringConfig, 1)
+	err := s.State.SetCharms([]state.ApplicationCharmUpgrade{{
+		Application:    "wordpress",
+		SetCharmConfig: state.SetCharmConfig{Charm: wordpress},
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade charms: application "wordpress" not found`)
+}
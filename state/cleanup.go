@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -26,6 +27,7 @@ const (
 	cleanupServicesForDyingModel         cleanupKind = "applications"
 	cleanupDyingMachine                  cleanupKind = "dyingMachine"
 	cleanupForceDestroyedMachine         cleanupKind = "machine"
+	cleanupForceDestroyedUnit            cleanupKind = "forceDestroyedUnit"
 	cleanupAttachmentsForDyingStorage    cleanupKind = "storageAttachments"
 	cleanupAttachmentsForDyingVolume     cleanupKind = "volumeAttachments"
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
@@ -40,17 +42,30 @@ type cleanupDoc struct {
 	ModelUUID string `bson:"model-uuid"`
 	Kind      cleanupKind
 	Prefix    string
+
+	// Due, if set, is the time before which the cleanup is not run.
+	Due time.Time `bson:"due,omitempty"`
 }
 
 // newCleanupOp returns a txn.Op that creates a cleanup document with a unique
 // id and the supplied kind and prefix.
 func (st *State) newCleanupOp(kind cleanupKind, prefix string) txn.Op {
+	return st.newDelayedCleanupOp(kind, prefix, 0)
+}
+
+// newDelayedCleanupOp returns a txn.Op that creates a cleanup document
+// like newCleanupOp, which is not run until the supplied delay has
+// passed.
+func (st *State) newDelayedCleanupOp(kind cleanupKind, prefix string, delay time.Duration) txn.Op {
 	doc := &cleanupDoc{
 		DocID:     st.docID(fmt.Sprint(bson.NewObjectId())),
 		ModelUUID: st.ModelUUID(),
 		Kind:      kind,
 		Prefix:    prefix,
 	}
+	if delay > 0 {
+		doc.Due = st.clock.Now().Add(delay).UTC()
+	}
 	return txn.Op{
 		C:      cleanupsC,
 		Id:     doc.DocID,
@@ -58,6 +73,22 @@ func (st *State) newCleanupOp(kind cleanupKind, prefix string) txn.Op {
 	}
 }
 
+// nextCleanupDue returns the time at which the earliest delayed cleanup
+// that is not yet due becomes due. If there is no such cleanup, it
+// returns false.
+func (st *State) nextCleanupDue() (time.Time, bool, error) {
+	cleanups, closer := st.getCollection(cleanupsC)
+	defer closer()
+	var doc cleanupDoc
+	err := cleanups.Find(bson.D{{"due", bson.D{{"$gt", st.clock.Now()}}}}).Sort("due").One(&doc)
+	if err == mgo.ErrNotFound {
+		return time.Time{}, false, nil
+	} else if err != nil {
+		return time.Time{}, false, errors.Annotate(err, "cannot read delayed cleanups")
+	}
+	return doc.Due, true, nil
+}
+
 // NeedsCleanup returns true if documents previously marked for removal exist.
 func (st *State) NeedsCleanup() (bool, error) {
 	cleanups, closer := st.getCollection(cleanupsC)
@@ -78,7 +109,12 @@ func (st *State) Cleanup() (err error) {
 	defer closer()
 	iter := cleanups.Find(nil).Iter()
 	defer closeIter(iter, &err, "reading cleanup document")
+	now := st.clock.Now()
 	for iter.Next(&doc) {
+		if doc.Due.After(now) {
+			logger.Debugf("%q cleanup %q not due until %v", doc.Kind, doc.Prefix, doc.Due)
+			continue
+		}
 		var err error
 		logger.Debugf("running %q cleanup: %q", doc.Kind, doc.Prefix)
 		switch doc.Kind {
@@ -98,6 +134,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupDyingMachine(doc.Prefix)
 		case cleanupForceDestroyedMachine:
 			err = st.cleanupForceDestroyedMachine(doc.Prefix)
+		case cleanupForceDestroyedUnit:
+			err = st.cleanupForceDestroyedUnit(doc.Prefix)
 		case cleanupAttachmentsForDyingStorage:
 			err = st.cleanupAttachmentsForDyingStorage(doc.Prefix)
 		case cleanupAttachmentsForDyingVolume:
@@ -476,10 +514,19 @@ func cleanupDyingMachineResources(m *Machine) error {
 	return nil
 }
 
+// cleanupForceDestroyedUnit removes the supplied unit from state, along
+// with its subordinates and storage attachments, whether or not its agent
+// has shut down. It's expected to be used in response to remove-unit
+// --force, for units whose agents cannot complete their shutdown.
+func (st *State) cleanupForceDestroyedUnit(unitName string) error {
+	return st.obliterateUnit(unitName)
+}
+
 // obliterateUnit removes a unit from state completely. It is not safe or
-// sane to obliterate any unit in isolation; its only reasonable use is in
+// sane to obliterate any unit in isolation; its only reasonable uses are in
 // the context of machine obliteration, in which we can be sure that unclean
-// shutdown of units is not going to leave a machine in a difficult state.
+// shutdown of units is not going to leave a machine in a difficult state,
+// and when the user has explicitly forced the unit's removal.
 func (st *State) obliterateUnit(unitName string) error {
 	unit, err := st.Unit(unitName)
 	if errors.IsNotFound(err) {
@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -15,6 +16,8 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -374,6 +377,115 @@ func (s *CleanupSuite) TestCleanupForceDestroyedMachineWithContainer(c *gc.C) {
 	assertLife(c, machine, state.Dead)
 }
 
+func (s *CleanupSuite) TestCleanupForceDestroyedUnit(c *gc.C) {
+	// Create a dying principal unit with a subordinate, in scope.
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeContainer)
+	err := prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pu0.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.runCleanups(c)
+	assertLife(c, prr.pu0, state.Dying)
+
+	// Force its destruction, and check that it is removed along with
+	// its subordinate, leaving the relation scopes.
+	err = prr.pu0.ForceDestroy(0)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNeedsCleanup(c)
+	s.runCleanups(c)
+	assertRemoved(c, prr.pu0)
+	assertRemoved(c, prr.ru0)
+	assertNotInScope(c, prr.pru0)
+	assertNotInScope(c, prr.rru0)
+
+	// The other units are unaffected.
+	assertLife(c, prr.pu1, state.Alive)
+	assertLife(c, prr.ru1, state.Alive)
+}
+
+func (s *CleanupSuite) TestCleanupForceDestroyedUnitAfterWait(c *gc.C) {
+	clk := coretesting.NewClock(time.Now())
+	state.SetClock(s.State, clk)
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeContainer)
+
+	err := prr.pu0.ForceDestroy(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, prr.pu0, state.Dying)
+
+	// The unit is given time to shut down before it is removed.
+	s.runCleanups(c)
+	s.assertNeedsCleanup(c)
+	assertLife(c, prr.pu0, state.Dying)
+
+	clk.Advance(time.Minute)
+	s.runCleanups(c)
+	s.assertDoesNotNeedCleanup(c)
+	assertRemoved(c, prr.pu0)
+	assertRemoved(c, prr.ru0)
+}
+
+func (s *CleanupSuite) TestWatchCleanupsDelayed(c *gc.C) {
+	clk := coretesting.NewClock(time.Now())
+	state.SetClock(s.State, clk)
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeContainer)
+
+	w := s.State.WatchCleanups()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := prr.pu0.ForceDestroy(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	s.runCleanups(c)
+	wc.AssertOneChange()
+
+	// The watcher notifies when the delayed cleanup becomes due.
+	clk.Advance(time.Minute)
+	wc.AssertOneChange()
+}
+
+func (s *CleanupSuite) TestForceDestroyRemovedUnit(c *gc.C) {
+	unit, err := s.Factory.MakeApplication(c, nil).AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit's agent has not started, so it is removed directly,
+	// and there is nothing left to force.
+	err = unit.ForceDestroy(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	assertRemoved(c, unit)
+	s.runCleanups(c)
+	s.assertDoesNotNeedCleanup(c)
+}
+
+func (s *CleanupSuite) TestCleanupForceDestroyedMachineAfterWait(c *gc.C) {
+	clk := coretesting.NewClock(time.Now())
+	state.SetClock(s.State, clk)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeContainer)
+	err = prr.pu0.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The machine's units are destroyed, and given time to shut
+	// down before they are removed.
+	err = machine.ForceDestroyAfter(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, prr.pu0, state.Dying)
+	s.runCleanups(c)
+	assertLife(c, prr.pu0, state.Dying)
+	assertLife(c, machine, state.Alive)
+
+	clk.Advance(time.Minute)
+	s.runCleanups(c)
+	s.assertDoesNotNeedCleanup(c)
+	assertRemoved(c, prr.pu0)
+	assertRemoved(c, prr.ru0)
+	assertLife(c, machine, state.Dead)
+}
+
 func (s *CleanupSuite) TestCleanupDyingUnit(c *gc.C) {
 	// Create active unit, in a relation.
 	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
@@ -661,6 +773,20 @@ func (s *CleanupSuite) assertDoesNotNeedCleanup(c *gc.C) {
 	c.Assert(actual, jc.IsFalse)
 }
 
+// runCleanups runs cleanups until no more are queued to run now;
+// delayed cleanups that are not yet due may remain.
+func (s *CleanupSuite) runCleanups(c *gc.C) {
+	for i := 0; i < 10; i++ {
+		count, err := state.DueCleanupCount(s.State)
+		c.Assert(err, jc.ErrorIsNil)
+		if count == 0 {
+			return
+		}
+		s.assertCleanupRuns(c)
+	}
+	c.Fatalf("cleanups still queued after 10 runs")
+}
+
 // assertCleanupCount is useful because certain cleanups cause other cleanups
 // to be queued; it makes more sense to just run cleanup again than to unpick
 // object destruction so that we run the cleanups inline while running cleanups.
@@ -7,14 +7,15 @@ import (
 	"github.com/juju/utils/clock"
 )
 
-// GetClock exists to allow us to patch out time-handling; specifically
-// for the worker/uniter tests that want to know what happens when leases
-// expire unexpectedly.
+// GetClock returns the clock used by each State opened with Open, and
+// shared with the States derived from it; all of a State's timestamps,
+// lease expiries and delayed cleanups are taken from that clock, so
+// patching GetClock before opening a State makes its time-dependent
+// behaviour deterministic. It exists for the worker/uniter tests, which
+// want to know what happens when leases expire unexpectedly.
 //
 // TODO(fwereade): lp:1479653
-// This is *clearly* a bad idea, and we should be injecting the dependency
-// explicitly -- and using an injected clock across the codebase -- but,
-// time pressure.
+// The clock should be passed to Open explicitly, rather than patched.
 var GetClock = func() clock.Clock {
 	return clock.WallClock
 }
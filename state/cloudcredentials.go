@@ -14,6 +14,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/status"
 )
 
 // cloudCredentialDoc records information about a user's cloud credentials.
@@ -24,6 +25,11 @@ type cloudCredentialDoc struct {
 	Name       string            `bson:"name"`
 	AuthType   string            `bson:"auth-type"`
 	Attributes map[string]string `bson:"attributes,omitempty"`
+
+	// Invalid and InvalidReason record that the credential has been
+	// reported as no longer valid, and why.
+	Invalid       bool   `bson:"invalid,omitempty"`
+	InvalidReason string `bson:"invalid-reason,omitempty"`
 }
 
 // CloudCredential returns the cloud credential for the given tag.
@@ -102,9 +108,120 @@ func (st *State) UpdateCloudCredential(tag names.CloudCredentialTag, credential
 	if err := st.run(buildTxn); err != nil {
 		return errors.Annotate(err, "updating cloud credentials")
 	}
+	// Supplying a credential makes it valid again, so resume any
+	// models that were suspended while it was invalid.
+	if err := st.resumeCredentialModels(tag); err != nil {
+		return errors.Annotate(err, "resuming models")
+	}
+	return nil
+}
+
+// InvalidateCloudCredential marks the cloud credential with the given
+// tag as invalid, recording the supplied reason, and suspends all of
+// the models that use it. The models are resumed when the credential
+// is next updated.
+func (st *State) InvalidateCloudCredential(tag names.CloudCredentialTag, reason string) error {
+	ops := []txn.Op{{
+		C:      cloudCredentialsC,
+		Id:     cloudCredentialDocID(tag),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"invalid", true},
+			{"invalid-reason", reason},
+		}}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("cloud credential %q", tag.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "invalidating cloud credential %q", tag.Id())
+	}
+	uuids, err := st.credentialModelUUIDs(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := st.clock.Now()
+	for _, uuid := range uuids {
+		model, err := st.GetModel(names.NewModelTag(uuid))
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		err = model.SetStatus(status.StatusInfo{
+			Status:  status.StatusSuspended,
+			Message: "suspended since cloud credential is not valid",
+			Data:    map[string]interface{}{"reason": reason},
+			Since:   &now,
+		})
+		if err != nil {
+			return errors.Annotatef(err, "suspending model %q", uuid)
+		}
+	}
 	return nil
 }
 
+// WatchCloudCredential returns a NotifyWatcher that notifies of
+// changes to the cloud credential with the given tag.
+func (st *State) WatchCloudCredential(tag names.CloudCredentialTag) NotifyWatcher {
+	return newEntityWatcher(st, cloudCredentialsC, cloudCredentialDocID(tag))
+}
+
+// resumeCredentialModels sets the status of every suspended model
+// that uses the cloud credential with the given tag back to available.
+func (st *State) resumeCredentialModels(tag names.CloudCredentialTag) error {
+	uuids, err := st.credentialModelUUIDs(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := st.clock.Now()
+	for _, uuid := range uuids {
+		model, err := st.GetModel(names.NewModelTag(uuid))
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		current, err := model.Status()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if current.Status != status.StatusSuspended {
+			continue
+		}
+		err = model.SetStatus(status.StatusInfo{
+			Status: status.StatusAvailable,
+			Since:  &now,
+		})
+		if err != nil {
+			return errors.Annotatef(err, "resuming model %q", uuid)
+		}
+	}
+	return nil
+}
+
+// credentialModelUUIDs returns the UUIDs of the alive models that use
+// the cloud credential with the given tag.
+func (st *State) credentialModelUUIDs(tag names.CloudCredentialTag) ([]string, error) {
+	models, closer := st.getCollection(modelsC)
+	defer closer()
+
+	var docs []struct {
+		UUID string `bson:"_id"`
+	}
+	err := models.Find(bson.D{
+		{"cloud-credential", tag.Id()},
+		{"life", Alive},
+	}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "finding models using cloud credential %q", tag.Id())
+	}
+	uuids := make([]string, len(docs))
+	for i, doc := range docs {
+		uuids[i] = doc.UUID
+	}
+	return uuids, nil
+}
+
 // createCloudCredentialOp returns a txn.Op that will create
 // a cloud credential.
 func createCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential) txn.Op {
@@ -129,10 +246,16 @@ func updateCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential
 		C:      cloudCredentialsC,
 		Id:     cloudCredentialDocID(tag),
 		Assert: txn.DocExists,
-		Update: bson.D{{"$set", bson.D{
-			{"auth-type", string(cred.AuthType())},
-			{"attributes", cred.Attributes()},
-		}}},
+		Update: bson.D{
+			{"$set", bson.D{
+				{"auth-type", string(cred.AuthType())},
+				{"attributes", cred.Attributes()},
+			}},
+			{"$unset", bson.D{
+				{"invalid", nil},
+				{"invalid-reason", nil},
+			}},
+		},
 	}
 }
 
@@ -151,6 +274,8 @@ func (c cloudCredentialDoc) cloudCredentialTag() (names.CloudCredentialTag, erro
 func (c cloudCredentialDoc) toCredential() cloud.Credential {
 	out := cloud.NewCredential(cloud.AuthType(c.AuthType), c.Attributes)
 	out.Label = c.Name
+	out.Invalid = c.Invalid
+	out.InvalidReason = c.InvalidReason
 	return out
 }
 
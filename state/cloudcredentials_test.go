@@ -4,11 +4,16 @@
 package state_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
 )
 
 type CloudCredentialsSuite struct {
@@ -126,3 +131,89 @@ func (s *CloudCredentialsSuite) TestCloudCredentials(c *gc.C) {
 		tag3: cred2,
 	})
 }
+
+func (s *CloudCredentialsSuite) makeCredentialModel(c *gc.C) (names.CloudCredentialTag, *state.Model) {
+	err := s.State.AddCloud("stratus", cloud.Cloud{
+		Type:      "dummy",
+		AuthTypes: cloud.AuthTypes{cloud.AccessKeyAuthType},
+		Regions:   []cloud.Region{{Name: "dummy-region"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	controllerModel, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	owner := controllerModel.Owner()
+
+	tag := names.NewCloudCredentialTag("stratus/" + owner.Canonical() + "/foobar")
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"foo": "foo val",
+	})
+	err = s.State.UpdateCloudCredential(tag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		CloudName:       "stratus",
+		CloudCredential: tag,
+	})
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	return tag, model
+}
+
+func (s *CloudCredentialsSuite) TestInvalidateCloudCredential(c *gc.C) {
+	tag, model := s.makeCredentialModel(c)
+
+	err := s.State.InvalidateCloudCredential(tag, "revoked")
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Invalid, jc.IsTrue)
+	c.Assert(out.InvalidReason, gc.Equals, "revoked")
+
+	info, err := model.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, status.StatusSuspended)
+	c.Assert(info.Message, gc.Equals, "suspended since cloud credential is not valid")
+	c.Assert(info.Data, jc.DeepEquals, map[string]interface{}{"reason": "revoked"})
+}
+
+func (s *CloudCredentialsSuite) TestInvalidateCloudCredentialNotFound(c *gc.C) {
+	tag := names.NewCloudCredentialTag("dummy/bob@local/foobar")
+	err := s.State.InvalidateCloudCredential(tag, "revoked")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cloud credential "dummy/bob@local/foobar" not found`)
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialResumesModels(c *gc.C) {
+	tag, model := s.makeCredentialModel(c)
+	err := s.State.InvalidateCloudCredential(tag, "revoked")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"foo": "new foo val",
+	})
+	err = s.State.UpdateCloudCredential(tag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Invalid, jc.IsFalse)
+	c.Assert(out.InvalidReason, gc.Equals, "")
+
+	info, err := model.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, status.StatusAvailable)
+}
+
+func (s *CloudCredentialsSuite) TestWatchCloudCredential(c *gc.C) {
+	tag, _ := s.makeCredentialModel(c)
+	w := s.State.WatchCloudCredential(tag)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.InvalidateCloudCredential(tag, "revoked")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,247 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/ssh"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// CloudInitCustomization holds extra cloud-init directives applied
+// when provisioning machines. Those of a model apply to each of its
+// machines, on top of which those of the machine itself are applied.
+type CloudInitCustomization struct {
+	// Packages holds the names of extra packages to install, each
+	// optionally pinned to a version as "name=version".
+	Packages []string
+
+	// AptMirror, if set, overrides the APT mirror from which
+	// packages are installed.
+	AptMirror string
+
+	// RunCmds holds shell commands run before the machine agent is
+	// installed.
+	RunCmds []string
+
+	// SSHCAKeys holds the public keys of certificate authorities
+	// trusted by sshd to sign user certificates.
+	SSHCAKeys []string
+}
+
+// cloudInitDoc represents the MongoDB document that stores the
+// cloud-init customization of a model or machine.
+type cloudInitDoc struct {
+	DocID     string   `bson:"_id"`
+	ModelUUID string   `bson:"model-uuid"`
+	Packages  []string `bson:"packages"`
+	AptMirror string   `bson:"apt-mirror"`
+	RunCmds   []string `bson:"runcmds"`
+	SSHCAKeys []string `bson:"ssh-ca-keys"`
+}
+
+// validPackage matches a package name, optionally pinned to a version.
+var validPackage = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9+._-]*(=[a-zA-Z0-9+.~:_-]+)?$`)
+
+// cloudInitKey returns the key of the cloud-init customization
+// document of the model or machine with the given tag.
+func (st *State) cloudInitKey(tag names.Tag) (string, error) {
+	switch tag := tag.(type) {
+	case names.ModelTag:
+		if tag != st.ModelTag() {
+			return "", errors.NotValidf("model %q", tag.Id())
+		}
+		return modelGlobalKey, nil
+	case names.MachineTag:
+		return machineGlobalKey(tag.Id()), nil
+	}
+	return "", errors.NotValidf("cloud-init customization for %s", names.ReadableString(tag))
+}
+
+// CloudInitCustomization returns the cloud-init customization set for
+// the model or machine with the given tag, which is empty if none has
+// been set.
+func (st *State) CloudInitCustomization(tag names.Tag) (CloudInitCustomization, error) {
+	key, err := st.cloudInitKey(tag)
+	if err != nil {
+		return CloudInitCustomization{}, errors.Trace(err)
+	}
+	coll, closer := st.getCollection(cloudInitC)
+	defer closer()
+
+	var doc cloudInitDoc
+	err = coll.FindId(key).One(&doc)
+	if err == mgo.ErrNotFound {
+		return CloudInitCustomization{}, nil
+	} else if err != nil {
+		return CloudInitCustomization{}, errors.Annotatef(err, "cannot get cloud-init customization of %s", tag)
+	}
+	return CloudInitCustomization{
+		Packages:  doc.Packages,
+		AptMirror: doc.AptMirror,
+		RunCmds:   doc.RunCmds,
+		SSHCAKeys: doc.SSHCAKeys,
+	}, nil
+}
+
+// EffectiveCloudInitCustomization returns the cloud-init customization
+// applied when provisioning the machine with the given id: that of
+// the model merged with that of the machine.
+func (st *State) EffectiveCloudInitCustomization(machineId string) (CloudInitCustomization, error) {
+	model, err := st.CloudInitCustomization(st.ModelTag())
+	if err != nil {
+		return CloudInitCustomization{}, errors.Trace(err)
+	}
+	machine, err := st.CloudInitCustomization(names.NewMachineTag(machineId))
+	if err != nil {
+		return CloudInitCustomization{}, errors.Trace(err)
+	}
+	return mergeCloudInitCustomizations(model, machine), nil
+}
+
+// mergeCloudInitCustomizations applies the customization of a machine
+// on top of that of its model. Packages and SSH CA keys are combined,
+// the machine's commands run after the model's, and the machine's APT
+// mirror, if any, replaces the model's.
+func mergeCloudInitCustomizations(model, machine CloudInitCustomization) CloudInitCustomization {
+	result := CloudInitCustomization{
+		Packages:  mergeUnique(model.Packages, machine.Packages),
+		AptMirror: model.AptMirror,
+		SSHCAKeys: mergeUnique(model.SSHCAKeys, machine.SSHCAKeys),
+	}
+	if machine.AptMirror != "" {
+		result.AptMirror = machine.AptMirror
+	}
+	result.RunCmds = append(result.RunCmds, model.RunCmds...)
+	result.RunCmds = append(result.RunCmds, machine.RunCmds...)
+	return result
+}
+
+// mergeUnique returns the values of a followed by those of b which are
+// not in a, preserving their order.
+func mergeUnique(a, b []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, values := range [][]string{a, b} {
+		for _, value := range values {
+			if !seen[value] {
+				seen[value] = true
+				result = append(result, value)
+			}
+		}
+	}
+	return result
+}
+
+// SetCloudInitCustomization replaces the cloud-init customization of
+// the model or machine with the given tag. It only affects machines
+// provisioned afterwards.
+func (st *State) SetCloudInitCustomization(tag names.Tag, c CloudInitCustomization) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set cloud-init customization of %s", tag)
+	key, err := st.cloudInitKey(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateCloudInitCustomization(c); err != nil {
+		return errors.Trace(err)
+	}
+	var entityOp txn.Op
+	if tag, ok := tag.(names.MachineTag); ok {
+		entityOp = txn.Op{C: machinesC, Id: tag.Id(), Assert: notDeadDoc}
+	} else {
+		entityOp = txn.Op{C: modelsC, Id: st.ModelUUID(), Assert: txn.DocExists}
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if tag, ok := tag.(names.MachineTag); ok {
+				m, err := st.Machine(tag.Id())
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if m.Life() == Dead {
+					return nil, errors.Errorf("%s is dead", tag)
+				}
+			}
+		}
+		coll, closer := st.getCollection(cloudInitC)
+		defer closer()
+		count, err := coll.FindId(key).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		op := txn.Op{
+			C:  cloudInitC,
+			Id: key,
+		}
+		if count == 0 {
+			op.Assert = txn.DocMissing
+			op.Insert = &cloudInitDoc{
+				DocID:     st.docID(key),
+				ModelUUID: st.ModelUUID(),
+				Packages:  c.Packages,
+				AptMirror: c.AptMirror,
+				RunCmds:   c.RunCmds,
+				SSHCAKeys: c.SSHCAKeys,
+			}
+		} else {
+			op.Assert = txn.DocExists
+			op.Update = bson.D{{"$set", bson.D{
+				{"packages", c.Packages},
+				{"apt-mirror", c.AptMirror},
+				{"runcmds", c.RunCmds},
+				{"ssh-ca-keys", c.SSHCAKeys},
+			}}}
+		}
+		return []txn.Op{entityOp, op}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// validateCloudInitCustomization returns an error if the given
+// cloud-init customization is not valid.
+func validateCloudInitCustomization(c CloudInitCustomization) error {
+	for _, pkg := range c.Packages {
+		if !validPackage.MatchString(pkg) {
+			return errors.NotValidf("package %q", pkg)
+		}
+	}
+	if c.AptMirror != "" {
+		u, err := url.Parse(c.AptMirror)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.NotValidf("APT mirror %q", c.AptMirror)
+		}
+	}
+	for _, cmd := range c.RunCmds {
+		if strings.TrimSpace(cmd) == "" {
+			return errors.NotValidf("empty command")
+		}
+	}
+	for _, key := range c.SSHCAKeys {
+		if strings.Contains(key, "\n") {
+			return errors.NotValidf("multi-line SSH CA key")
+		}
+		if _, _, err := ssh.KeyFingerprint(key); err != nil {
+			return errors.NotValidf("SSH CA key %q", key)
+		}
+	}
+	return nil
+}
+
+// removeCloudInitOp returns the operation needed to remove the
+// cloud-init customization of the machine with the given global key.
+func removeCloudInitOp(st *State, globalKey string) txn.Op {
+	return txn.Op{
+		C:      cloudInitC,
+		Id:     globalKey,
+		Remove: true,
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	sshtesting "github.com/juju/utils/ssh/testing"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type CloudInitSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&CloudInitSuite{})
+
+func (s *CloudInitSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *CloudInitSuite) TestNotSet(c *gc.C) {
+	got, err := s.State.CloudInitCustomization(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, state.CloudInitCustomization{})
+}
+
+func (s *CloudInitSuite) TestSetGet(c *gc.C) {
+	for _, tag := range []names.Tag{s.State.ModelTag(), s.machine.Tag()} {
+		for _, custom := range []state.CloudInitCustomization{{
+			Packages:  []string{"htop", "nginx=1.10.0-0ubuntu0.16.04.4"},
+			AptMirror: "http://mirror.example.com/ubuntu",
+			RunCmds:   []string{"echo hello > /tmp/hello"},
+			SSHCAKeys: []string{sshtesting.ValidKeyOne.Key + " ca@example.com"},
+		}, {
+			Packages: []string{"tmux"},
+		}} {
+			err := s.State.SetCloudInitCustomization(tag, custom)
+			c.Assert(err, jc.ErrorIsNil)
+			got, err := s.State.CloudInitCustomization(tag)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(got, jc.DeepEquals, custom)
+		}
+	}
+}
+
+func (s *CloudInitSuite) TestEffective(c *gc.C) {
+	caKey := sshtesting.ValidKeyOne.Key
+	err := s.State.SetCloudInitCustomization(s.State.ModelTag(), state.CloudInitCustomization{
+		Packages:  []string{"htop", "tmux"},
+		AptMirror: "http://mirror.example.com/ubuntu",
+		RunCmds:   []string{"echo model"},
+		SSHCAKeys: []string{caKey},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetCloudInitCustomization(s.machine.Tag(), state.CloudInitCustomization{
+		Packages:  []string{"tmux", "nginx"},
+		AptMirror: "https://local.example.com/ubuntu",
+		RunCmds:   []string{"echo machine"},
+		SSHCAKeys: []string{caKey, sshtesting.ValidKeyTwo.Key},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.State.EffectiveCloudInitCustomization(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, state.CloudInitCustomization{
+		Packages:  []string{"htop", "tmux", "nginx"},
+		AptMirror: "https://local.example.com/ubuntu",
+		RunCmds:   []string{"echo model", "echo machine"},
+		SSHCAKeys: []string{caKey, sshtesting.ValidKeyTwo.Key},
+	})
+
+	// Other machines get only the model's customization.
+	other := s.Factory.MakeMachine(c, nil)
+	got, err = s.State.EffectiveCloudInitCustomization(other.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, state.CloudInitCustomization{
+		Packages:  []string{"htop", "tmux"},
+		AptMirror: "http://mirror.example.com/ubuntu",
+		RunCmds:   []string{"echo model"},
+		SSHCAKeys: []string{caKey},
+	})
+}
+
+func (s *CloudInitSuite) TestSetInvalid(c *gc.C) {
+	for _, test := range []struct {
+		custom state.CloudInitCustomization
+		err    string
+	}{{
+		custom: state.CloudInitCustomization{Packages: []string{"htop; rm -rf /"}},
+		err:    `package "htop; rm -rf /" not valid`,
+	}, {
+		custom: state.CloudInitCustomization{AptMirror: "mirror.example.com"},
+		err:    `APT mirror "mirror.example.com" not valid`,
+	}, {
+		custom: state.CloudInitCustomization{AptMirror: "ftp://mirror.example.com/ubuntu"},
+		err:    `APT mirror "ftp://mirror.example.com/ubuntu" not valid`,
+	}, {
+		custom: state.CloudInitCustomization{RunCmds: []string{"echo hello", " "}},
+		err:    "empty command not valid",
+	}, {
+		custom: state.CloudInitCustomization{SSHCAKeys: []string{"not-a-key"}},
+		err:    `SSH CA key "not-a-key" not valid`,
+	}} {
+		err := s.State.SetCloudInitCustomization(s.machine.Tag(), test.custom)
+		c.Check(err, gc.ErrorMatches, "cannot set cloud-init customization of machine-0: "+test.err)
+	}
+}
+
+func (s *CloudInitSuite) TestSetOtherModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err := s.State.SetCloudInitCustomization(st.ModelTag(), state.CloudInitCustomization{Packages: []string{"htop"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *CloudInitSuite) TestSetUnit(c *gc.C) {
+	err := s.State.SetCloudInitCustomization(names.NewUnitTag("foo/0"), state.CloudInitCustomization{})
+	c.Assert(err, gc.ErrorMatches, `cannot set cloud-init customization of unit-foo-0: cloud-init customization for unit foo/0 not valid`)
+}
+
+func (s *CloudInitSuite) TestSetMachineNotFound(c *gc.C) {
+	err := s.State.SetCloudInitCustomization(names.NewMachineTag("42"), state.CloudInitCustomization{Packages: []string{"htop"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloudInitSuite) TestRemovedWithMachine(c *gc.C) {
+	err := s.State.SetCloudInitCustomization(s.machine.Tag(), state.CloudInitCustomization{Packages: []string{"htop"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.State.CloudInitCustomization(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, state.CloudInitCustomization{})
+}
@@ -0,0 +1,312 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// maxConfigSnapshots is the maximum number of config snapshots kept
+// for a model. When a snapshot is created and the limit has been
+// reached, the snapshots with the lowest revisions are removed.
+var maxConfigSnapshots = 10
+
+var validConfigSnapshotName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// IsValidConfigSnapshotName reports whether name is a valid name for
+// a config snapshot.
+func IsValidConfigSnapshotName(name string) bool {
+	return validConfigSnapshotName.MatchString(name)
+}
+
+// ConfigSnapshot is a named snapshot of the config of a model and of
+// its applications, which may be restored to undo config changes.
+type ConfigSnapshot struct {
+	doc configSnapshotDoc
+}
+
+// configSnapshotDoc represents the MongoDB document that stores a
+// config snapshot.
+type configSnapshotDoc struct {
+	DocID        string                                  `bson:"_id"`
+	ModelUUID    string                                  `bson:"model-uuid"`
+	Name         string                                  `bson:"name"`
+	Revision     int                                     `bson:"revision"`
+	Created      time.Time                               `bson:"created"`
+	CreatedBy    string                                  `bson:"created-by"`
+	ModelConfig  settingsMap                             `bson:"model-config"`
+	Applications map[string]applicationConfigSnapshotDoc `bson:"applications"`
+}
+
+// applicationConfigSnapshotDoc holds the config of an application in a
+// config snapshot.
+type applicationConfigSnapshotDoc struct {
+	CharmURL string      `bson:"charm-url"`
+	Settings settingsMap `bson:"settings"`
+}
+
+// Name returns the name of the snapshot.
+func (s *ConfigSnapshot) Name() string {
+	return s.doc.Name
+}
+
+// Revision returns the revision of the snapshot. Revisions increase
+// with each snapshot created for the model.
+func (s *ConfigSnapshot) Revision() int {
+	return s.doc.Revision
+}
+
+// Created returns the time at which the snapshot was created.
+func (s *ConfigSnapshot) Created() time.Time {
+	return s.doc.Created
+}
+
+// CreatedBy returns the user who created the snapshot.
+func (s *ConfigSnapshot) CreatedBy() names.UserTag {
+	return names.NewUserTag(s.doc.CreatedBy)
+}
+
+// ModelConfig returns the model config attributes recorded in the
+// snapshot.
+func (s *ConfigSnapshot) ModelConfig() map[string]interface{} {
+	return copyMap(s.doc.ModelConfig, nil)
+}
+
+// ApplicationConfig returns the config settings recorded in the
+// snapshot, keyed by application name.
+func (s *ConfigSnapshot) ApplicationConfig() map[string]charm.Settings {
+	result := make(map[string]charm.Settings)
+	for name, app := range s.doc.Applications {
+		result[name] = charm.Settings(copyMap(app.Settings, nil))
+	}
+	return result
+}
+
+// CreateConfigSnapshot records the current config of the model and
+// of its applications as a snapshot with the given name. If the model
+// already has the maximum number of snapshots, the oldest snapshots
+// are removed.
+func (st *State) CreateConfigSnapshot(name string, createdBy names.UserTag) (_ *ConfigSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot create config snapshot %q", name)
+	if !IsValidConfigSnapshotName(name) {
+		return nil, errors.NotValidf("snapshot name %q", name)
+	}
+	modelSettings, err := readSettings(st, settingsC, modelGlobalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	revision, err := st.sequence("configsnapshot")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := configSnapshotDoc{
+		DocID:        st.docID(name),
+		ModelUUID:    st.ModelUUID(),
+		Name:         name,
+		Revision:     revision,
+		Created:      nowToTheSecond(),
+		CreatedBy:    createdBy.Id(),
+		ModelConfig:  copyMap(modelSettings.Map(), escapeReplacer.Replace),
+		Applications: make(map[string]applicationConfigSnapshotDoc),
+	}
+	for _, app := range applications {
+		settings, err := app.ConfigSettings()
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading config of application %q", app.Name())
+		}
+		curl, _ := app.CharmURL()
+		doc.Applications[app.Name()] = applicationConfigSnapshotDoc{
+			CharmURL: curl.String(),
+			Settings: copyMap(settings, escapeReplacer.Replace),
+		}
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.ConfigSnapshot(name); err == nil {
+				return nil, errors.AlreadyExistsf("snapshot %q", name)
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		existing, err := st.AllConfigSnapshots()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      configSnapshotsC,
+			Id:     name,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}
+		for i := 0; i < len(existing)+1-maxConfigSnapshots; i++ {
+			ops = append(ops, txn.Op{
+				C:      configSnapshotsC,
+				Id:     existing[i].Name(),
+				Assert: txn.DocExists,
+				Remove: true,
+			})
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc.ModelConfig = copyMap(doc.ModelConfig, unescapeReplacer.Replace)
+	for name, app := range doc.Applications {
+		app.Settings = copyMap(app.Settings, unescapeReplacer.Replace)
+		doc.Applications[name] = app
+	}
+	return &ConfigSnapshot{doc: doc}, nil
+}
+
+// ConfigSnapshot returns the model's config snapshot with the given
+// name.
+func (st *State) ConfigSnapshot(name string) (*ConfigSnapshot, error) {
+	coll, closer := st.getCollection(configSnapshotsC)
+	defer closer()
+
+	var doc configSnapshotDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("config snapshot %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get config snapshot %q", name)
+	}
+	return &ConfigSnapshot{doc: doc}, nil
+}
+
+// AllConfigSnapshots returns the model's config snapshots, ordered
+// by revision.
+func (st *State) AllConfigSnapshots() ([]*ConfigSnapshot, error) {
+	coll, closer := st.getCollection(configSnapshotsC)
+	defer closer()
+
+	var docs []configSnapshotDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get config snapshots")
+	}
+	snapshots := make([]*ConfigSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &ConfigSnapshot{doc: doc}
+	}
+	sort.Sort(configSnapshotsByRevision(snapshots))
+	return snapshots, nil
+}
+
+// RemoveConfigSnapshot removes the model's config snapshot with the
+// given name.
+func (st *State) RemoveConfigSnapshot(name string) error {
+	err := st.runTransaction([]txn.Op{{
+		C:      configSnapshotsC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Remove: true,
+	}})
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("config snapshot %q", name)
+	}
+	return errors.Annotatef(err, "cannot remove config snapshot %q", name)
+}
+
+// RestoreConfigSnapshot restores the config of the model, and of the
+// applications recorded in the named snapshot, to the values recorded
+// in the snapshot. The agent version is not restored. Applications
+// recorded in the snapshot which no longer exist are skipped, and
+// their names returned; applications created since the snapshot was
+// taken are left alone.
+//
+// The recorded settings of every application are validated against
+// the application's current charm before anything is written, so a
+// snapshot which no longer suits the charms deployed is rejected as a
+// whole. The model config and the config of each application are then
+// written separately, so if writing fails the config may have been
+// partially restored.
+func (st *State) RestoreConfigSnapshot(name string) (skipped []string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot restore config snapshot %q", name)
+	snapshot, err := st.ConfigSnapshot(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	appNames := make([]string, 0, len(snapshot.doc.Applications))
+	for appName := range snapshot.doc.Applications {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+	appConfig := snapshot.ApplicationConfig()
+	var apps []*Application
+	for _, appName := range appNames {
+		app, err := st.Application(appName)
+		if errors.IsNotFound(err) {
+			skipped = append(skipped, appName)
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		current, err := app.ConfigSettings()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changes := appConfig[appName]
+		for key := range current {
+			if _, ok := changes[key]; !ok {
+				changes[key] = nil
+			}
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := validateConfigSettings(appName, ch, changes); err != nil {
+			return nil, errors.Annotatef(err, "validating config of application %q", appName)
+		}
+		apps = append(apps, app)
+	}
+
+	modelSettings, err := readSettings(st, settingsC, modelGlobalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	updates := snapshot.ModelConfig()
+	delete(updates, config.AgentVersionKey)
+	var removes []string
+	for key := range modelSettings.Map() {
+		if _, ok := updates[key]; !ok && key != config.AgentVersionKey {
+			removes = append(removes, key)
+		}
+	}
+	if err := st.UpdateModelConfig(updates, removes, nil); err != nil {
+		return nil, errors.Annotate(err, "restoring model config")
+	}
+
+	for _, app := range apps {
+		if err := app.UpdateConfigSettings(appConfig[app.Name()]); err != nil {
+			return nil, errors.Annotatef(err, "restoring config of application %q", app.Name())
+		}
+	}
+	return skipped, nil
+}
+
+type configSnapshotsByRevision []*ConfigSnapshot
+
+func (s configSnapshotsByRevision) Len() int      { return len(s) }
+func (s configSnapshotsByRevision) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s configSnapshotsByRevision) Less(i, j int) bool {
+	return s[i].doc.Revision < s[j].doc.Revision
+}
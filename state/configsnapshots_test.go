@@ -0,0 +1,185 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type ConfigSnapshotsSuite struct {
+	ConnSuite
+	application *state.Application
+	user        names.UserTag
+}
+
+var _ = gc.Suite(&ConfigSnapshotsSuite{})
+
+func (s *ConfigSnapshotsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	s.user = names.NewUserTag("bob")
+}
+
+func (s *ConfigSnapshotsSuite) TestCreateConfigSnapshot(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"ftp-proxy": "ftp://proxy"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.UpdateConfigSettings(charm.Settings{"title": "before"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err := s.State.CreateConfigSnapshot("before-change", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Name(), gc.Equals, "before-change")
+	c.Assert(snapshot.Revision(), gc.Equals, 1)
+	c.Assert(snapshot.CreatedBy(), gc.Equals, s.user)
+	c.Assert(snapshot.Created().IsZero(), jc.IsFalse)
+	c.Assert(snapshot.ModelConfig()["ftp-proxy"], gc.Equals, "ftp://proxy")
+	c.Assert(snapshot.ApplicationConfig(), jc.DeepEquals, map[string]charm.Settings{
+		"dummy-application": {"title": "before"},
+	})
+
+	stored, err := s.State.ConfigSnapshot("before-change")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.Revision(), gc.Equals, 1)
+	c.Assert(stored.ModelConfig()["ftp-proxy"], gc.Equals, "ftp://proxy")
+	c.Assert(stored.ApplicationConfig(), jc.DeepEquals, snapshot.ApplicationConfig())
+}
+
+func (s *ConfigSnapshotsSuite) TestCreateConfigSnapshotInvalidName(c *gc.C) {
+	_, err := s.State.CreateConfigSnapshot("-foo", s.user)
+	c.Assert(err, gc.ErrorMatches, `cannot create config snapshot "-foo": snapshot name "-foo" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ConfigSnapshotsSuite) TestCreateConfigSnapshotAlreadyExists(c *gc.C) {
+	_, err := s.State.CreateConfigSnapshot("foo", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CreateConfigSnapshot("foo", s.user)
+	c.Assert(err, gc.ErrorMatches, `cannot create config snapshot "foo": snapshot "foo" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ConfigSnapshotsSuite) TestRetentionLimit(c *gc.C) {
+	s.PatchValue(state.MaxConfigSnapshots, 2)
+	for _, name := range []string{"one", "two", "three"} {
+		_, err := s.State.CreateConfigSnapshot(name, s.user)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	snapshots, err := s.State.AllConfigSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+	c.Assert(snapshots[0].Name(), gc.Equals, "two")
+	c.Assert(snapshots[0].Revision(), gc.Equals, 2)
+	c.Assert(snapshots[1].Name(), gc.Equals, "three")
+	c.Assert(snapshots[1].Revision(), gc.Equals, 3)
+
+	_, err = s.State.ConfigSnapshot("one")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ConfigSnapshotsSuite) TestRemoveConfigSnapshot(c *gc.C) {
+	_, err := s.State.CreateConfigSnapshot("foo", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveConfigSnapshot("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ConfigSnapshot("foo")
+	c.Assert(err, gc.ErrorMatches, `config snapshot "foo" not found`)
+
+	err = s.State.RemoveConfigSnapshot("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ConfigSnapshotsSuite) TestRestoreConfigSnapshot(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"ftp-proxy": "ftp://proxy"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.UpdateConfigSettings(charm.Settings{"title": "before"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CreateConfigSnapshot("good", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"ftp-proxy": "ftp://bad"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.UpdateConfigSettings(charm.Settings{"title": "after", "outlook": "bleak"})
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.AddTestingService(c, "other", s.AddTestingCharm(c, "dummy"))
+	err = other.UpdateConfigSettings(charm.Settings{"title": "untouched"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	skipped, err := s.State.RestoreConfigSnapshot("good")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(skipped, gc.HasLen, 0)
+
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.FtpProxy(), gc.Equals, "ftp://proxy")
+	settings, err := s.application.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"title": "before"})
+	settings, err = other.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"title": "untouched"})
+}
+
+func (s *ConfigSnapshotsSuite) TestRestoreConfigSnapshotInvalidForCharm(c *gc.C) {
+	ch := s.AddConfigCharm(c, "wordpress", `
+options:
+  colour:
+    type: string
+    default: red
+`, 1)
+	painted := s.AddTestingService(c, "painted", ch)
+	err := painted.UpdateConfigSettings(charm.Settings{"colour": "blue"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.UpdateConfigSettings(charm.Settings{"title": "before"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CreateConfigSnapshot("good", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The charm no longer has the option recorded in the snapshot.
+	ch = s.AddConfigCharm(c, "wordpress", `
+options:
+  shade:
+    type: string
+    default: light
+`, 2)
+	err = painted.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{"ftp-proxy": "ftp://after"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.UpdateConfigSettings(charm.Settings{"title": "after"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.RestoreConfigSnapshot("good")
+	c.Assert(err, gc.ErrorMatches, `cannot restore config snapshot "good": validating config of application "painted": .*colour.*`)
+
+	// Nothing was restored.
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.FtpProxy(), gc.Equals, "ftp://after")
+	settings, err := s.application.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"title": "after"})
+}
+
+func (s *ConfigSnapshotsSuite) TestRestoreConfigSnapshotSkipsRemovedApplications(c *gc.C) {
+	_, err := s.State.CreateConfigSnapshot("good", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	skipped, err := s.State.RestoreConfigSnapshot("good")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(skipped, jc.DeepEquals, []string{"dummy-application"})
+}
+
+func (s *ConfigSnapshotsSuite) TestRestoreConfigSnapshotNotFound(c *gc.C) {
+	_, err := s.State.RestoreConfigSnapshot("missing")
+	c.Assert(err, gc.ErrorMatches, `cannot restore config snapshot "missing": config snapshot "missing" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// logRateWindow is the period over which the rate at which log
+// records are written is measured.
+const logRateWindow = time.Hour
+
+// ControllerUsage describes the resources used by all the models
+// hosted by a controller.
+type ControllerUsage struct {
+	// Models, Machines and Units are the numbers of models,
+	// machines and units in the controller, including those that
+	// are being removed.
+	Models   int
+	Machines int
+	Units    int
+
+	// DatabaseSizeMB is the size in megabytes of the controller's
+	// database on disk, including its indexes.
+	DatabaseSizeMB int
+
+	// LogsSizeMB is the size in megabytes of the log records
+	// stored by the controller.
+	LogsSizeMB int
+
+	// LogRate is the average number of log records written per
+	// minute, across all models, over the last hour.
+	LogRate float64
+}
+
+// ControllerUsage returns the resources used by all the models hosted
+// by the controller. It may only be called on the controller model's
+// State.
+func (st *State) ControllerUsage() (ControllerUsage, error) {
+	if !st.IsController() {
+		return ControllerUsage{}, errors.New("only the controller model can report controller usage")
+	}
+	var usage ControllerUsage
+	for _, count := range []struct {
+		collection string
+		value      *int
+	}{
+		{modelsC, &usage.Models},
+		{machinesC, &usage.Machines},
+		{unitsC, &usage.Units},
+	} {
+		coll, closer := st.getRawCollection(count.collection)
+		n, err := coll.Count()
+		closer()
+		if err != nil {
+			return ControllerUsage{}, errors.Annotatef(err, "cannot count %s", count.collection)
+		}
+		*count.value = n
+	}
+
+	coll, closer := st.getRawCollection(modelsC)
+	defer closer()
+	var stats bson.M
+	err := coll.Database.Run(bson.D{
+		{"dbStats", 1},
+		{"scale", humanize.MiByte},
+	}, &stats)
+	if err != nil {
+		return ControllerUsage{}, errors.Annotate(err, "cannot get database size")
+	}
+	usage.DatabaseSizeMB = statsInt(stats["storageSize"]) + statsInt(stats["indexSize"])
+
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+	if usage.LogsSizeMB, err = getCollectionMB(logsColl); err != nil {
+		return ControllerUsage{}, errors.Annotate(err, "cannot get logs size")
+	}
+	if usage.LogRate, err = logRate(logsColl, st.clock.Now().Add(-logRateWindow)); err != nil {
+		return ControllerUsage{}, errors.Annotate(err, "cannot get log rate")
+	}
+	return usage, nil
+}
+
+// logRate returns the average number of log records written per
+// minute since the given time.
+func logRate(logsColl *mgo.Collection, since time.Time) (float64, error) {
+	modelUUIDs, err := getEnvsInLogs(logsColl)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	// Count the records of each model separately, to take advantage
+	// of the indexes on the logs collection.
+	var total int
+	for _, modelUUID := range modelUUIDs {
+		n, err := logsColl.Find(bson.M{
+			"e": modelUUID,
+			"t": bson.M{"$gte": since.UnixNano()},
+		}).Count()
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		total += n
+	}
+	return float64(total) / logRateWindow.Minutes(), nil
+}
+
+// statsInt returns the value of a numeric field in the result of a
+// mongo stats command, which may be encoded as any numeric type.
+func statsInt(v interface{}) int {
+	switch v := v.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
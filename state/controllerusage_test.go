@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type ControllerUsageSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerUsageSuite{})
+
+func (s *ControllerUsageSuite) TestCounts(c *gc.C) {
+	usage, err := s.State.ControllerUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Models, gc.Equals, 1)
+	c.Assert(usage.Machines, gc.Equals, 0)
+	c.Assert(usage.Units, gc.Equals, 0)
+
+	s.Factory.MakeUnit(c, nil)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err = st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err = s.State.ControllerUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Models, gc.Equals, 2)
+	c.Assert(usage.Machines, gc.Equals, 2)
+	c.Assert(usage.Units, gc.Equals, 1)
+}
+
+func (s *ControllerUsageSuite) TestLogRate(c *gc.C) {
+	logger := state.NewDbLogger(s.State, names.NewMachineTag("0"), jujuversion.Current)
+	defer logger.Close()
+	now := time.Now()
+	for _, t := range []time.Time{
+		now.Add(-2 * time.Hour),
+		now.Add(-30 * time.Minute),
+		now.Add(-time.Minute),
+	} {
+		err := logger.Log(t, "juju.worker", "foo.go:42", loggo.INFO, "hello")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	usage, err := s.State.ControllerUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.LogRate, gc.Equals, 2.0/60)
+}
+
+func (s *ControllerUsageSuite) TestHostedModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err := st.ControllerUsage()
+	c.Assert(err, gc.ErrorMatches, "only the controller model can report controller usage")
+}
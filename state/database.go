@@ -40,6 +40,17 @@ type Database interface {
 	// see modelStateCollection.
 	GetCollection(name string) (mongo.Collection, SessionCloser)
 
+	// GetSecondaryCollection returns the named Collection as GetCollection
+	// does, except that its queries are served by a secondary where one is
+	// available, relieving the primary of them in HA. The collection always
+	// has its own session, whose closer must be called regardless.
+	//
+	// Secondaries may lag behind the primary, so it must only be used for
+	// queries whose results are allowed to be stale, such as status history;
+	// never for anything that is compared with the transaction log, or used
+	// to build a transaction.
+	GetSecondaryCollection(name string) (mongo.Collection, SessionCloser)
+
 	// TransactionRunner() returns a runner responsible for making changes to
 	// the database, and a func that must be called when the runner is no longer
 	// needed. The returned Runner might or might not have its own session,
@@ -197,14 +208,14 @@ type database struct {
 
 // CopySession is part of the Database interface.
 func (db *database) CopySession() (Database, SessionCloser) {
-	session := db.raw.Session.Copy()
+	session, closer := sessionCounts.copySession(db.raw.Session)
 	return &database{
 		raw:        db.raw.With(session),
 		schema:     db.schema,
 		modelUUID:  db.modelUUID,
 		runner:     db.runner,
 		ownSession: true,
-	}, session.Close
+	}, closer
 }
 
 // GetCollection is part of the Database interface.
@@ -219,9 +230,27 @@ func (db *database) GetCollection(name string) (collection mongo.Collection, clo
 		collection = mongo.WrapCollection(db.raw.C(name))
 		closer = dontCloseAnything
 	} else {
-		collection, closer = mongo.CollectionFromName(db.raw, name)
+		var session *mgo.Session
+		session, closer = sessionCounts.copySession(db.raw.Session)
+		collection = mongo.WrapCollection(db.raw.C(name).With(session))
 	}
+	return db.wrapCollection(info, collection), closer
+}
 
+// GetSecondaryCollection is part of the Database interface.
+func (db *database) GetSecondaryCollection(name string) (mongo.Collection, SessionCloser) {
+	info, found := db.schema[name]
+	if !found {
+		logger.Errorf("using unknown collection %q", name)
+	}
+	session, closer := sessionCounts.copySecondarySession(db.raw.Session)
+	collection := mongo.WrapCollection(db.raw.C(name).With(session))
+	return db.wrapCollection(info, collection), closer
+}
+
+// wrapCollection applies the handling specified by the given schema info
+// to the supplied collection.
+func (db *database) wrapCollection(info collectionInfo, collection mongo.Collection) mongo.Collection {
 	// Apply model filtering.
 	if !info.global {
 		collection = &modelStateCollection{
@@ -236,7 +265,9 @@ func (db *database) GetCollection(name string) (collection mongo.Collection, clo
 		// interface a bit to drop Writeable in this situation, but it's
 		// not convenient yet.
 	}
-	return collection, closer
+
+	// Record slow queries.
+	return profilingCollection{collection}
 }
 
 // TransactionRunner is part of the Database interface.
@@ -246,15 +277,15 @@ func (db *database) TransactionRunner() (runner jujutxn.Runner, closer SessionCl
 	if runner == nil {
 		raw := db.raw
 		if !db.ownSession {
-			session := raw.Session.Copy()
+			var session *mgo.Session
+			session, closer = sessionCounts.copySession(raw.Session)
 			raw = raw.With(session)
-			closer = session.Close
 		}
 		params := jujutxn.RunnerParams{Database: raw}
 		runner = jujutxn.NewRunner(params)
 	}
 	return &multiModelRunner{
-		rawRunner: runner,
+		rawRunner: profilingRunner{countingRunner{runner}},
 		modelUUID: db.modelUUID,
 		schema:    db.schema,
 	}, closer
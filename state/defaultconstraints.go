@@ -0,0 +1,273 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/constraints"
+)
+
+// ConstraintsSource identifies the level of the constraints hierarchy
+// from which an effective constraint value is taken.
+type ConstraintsSource string
+
+// The levels of the constraints hierarchy, from lowest to highest
+// precedence.
+const (
+	ControllerConstraintsSource  ConstraintsSource = "controller"
+	CloudConstraintsSource       ConstraintsSource = "cloud"
+	RegionConstraintsSource      ConstraintsSource = "region"
+	ModelConstraintsSource       ConstraintsSource = "model"
+	ApplicationConstraintsSource ConstraintsSource = "application"
+)
+
+// EffectiveConstraints holds the constraints which result from merging
+// the levels of the constraints hierarchy.
+type EffectiveConstraints struct {
+	Value constraints.Value
+
+	// Sources maps the name of each attribute of Value to the level
+	// from which its value is taken.
+	Sources map[string]ConstraintsSource
+}
+
+// defaultConstraintsDoc represents the MongoDB document holding the
+// default constraints of the controller, of a cloud, or of a region of
+// a cloud.
+type defaultConstraintsDoc struct {
+	DocID       string `bson:"_id"`
+	Cloud       string `bson:"cloud,omitempty"`
+	Region      string `bson:"region,omitempty"`
+	Constraints string `bson:"constraints"`
+}
+
+// controllerDefaultConstraintsKey is the key for the default
+// constraints shared by all the models in the controller.
+const controllerDefaultConstraintsKey = "controller"
+
+// defaultConstraintsKey returns the key for the default constraints of
+// the given cloud region. An empty cloud denotes the controller, and
+// an empty region the whole cloud.
+func defaultConstraintsKey(cloud, region string) string {
+	if cloud == "" {
+		return controllerDefaultConstraintsKey
+	}
+	return regionSettingsGlobalKey(cloud, region)
+}
+
+// DefaultConstraints returns the default constraints of the given
+// cloud region. An empty cloud denotes the controller, and an empty
+// region the whole cloud.
+func (st *State) DefaultConstraints(cloud, region string) (constraints.Value, error) {
+	if cloud == "" && region != "" {
+		return constraints.Value{}, errors.NotValidf("region %q without cloud", region)
+	}
+	doc, err := st.defaultConstraintsDoc(defaultConstraintsKey(cloud, region))
+	if errors.IsNotFound(err) {
+		return constraints.Value{}, nil
+	} else if err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	return constraints.Parse(doc.Constraints)
+}
+
+func (st *State) defaultConstraintsDoc(key string) (defaultConstraintsDoc, error) {
+	coll, closer := st.getCollection(defaultConstraintsC)
+	defer closer()
+
+	var doc defaultConstraintsDoc
+	err := coll.FindId(key).One(&doc)
+	if err == mgo.ErrNotFound {
+		return doc, errors.NotFoundf("default constraints")
+	} else if err != nil {
+		return doc, errors.Trace(err)
+	}
+	return doc, nil
+}
+
+// SetDefaultConstraints replaces the default constraints of the given
+// cloud region, which are inherited by the models in the region unless
+// overridden by model or application constraints. An empty cloud
+// denotes the controller, and an empty region the whole cloud. Setting
+// empty constraints removes the defaults.
+func (st *State) SetDefaultConstraints(cloud, region string, cons constraints.Value) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set default constraints")
+	if cloud == "" && region != "" {
+		return errors.NotValidf("region %q without cloud", region)
+	}
+	if cloud != "" {
+		if err := st.checkCloudRegion(cloud, region); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	key := defaultConstraintsKey(cloud, region)
+	buildTxn := func(int) ([]txn.Op, error) {
+		_, err := st.defaultConstraintsDoc(key)
+		exists := err == nil
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		switch {
+		case constraints.IsEmpty(&cons) && !exists:
+			return nil, jujutxn.ErrNoOperations
+		case constraints.IsEmpty(&cons):
+			return []txn.Op{{
+				C:      defaultConstraintsC,
+				Id:     key,
+				Assert: txn.DocExists,
+				Remove: true,
+			}}, nil
+		case exists:
+			return []txn.Op{{
+				C:      defaultConstraintsC,
+				Id:     key,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"constraints", cons.String()}}}},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      defaultConstraintsC,
+			Id:     key,
+			Assert: txn.DocMissing,
+			Insert: &defaultConstraintsDoc{
+				DocID:       key,
+				Cloud:       cloud,
+				Region:      region,
+				Constraints: cons.String(),
+			},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// checkCloudRegion returns an error satisfying errors.IsNotFound if the
+// controller has no such cloud, or the cloud no such region.
+func (st *State) checkCloudRegion(cloudName, region string) error {
+	cloud, err := st.Cloud(cloudName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if region == "" {
+		return nil
+	}
+	for _, r := range cloud.Regions {
+		if r.Name == region {
+			return nil
+		}
+	}
+	return errors.NotFoundf("region %q in cloud %q", region, cloudName)
+}
+
+// constraintsLevel holds the constraints set at one level of the
+// constraints hierarchy.
+type constraintsLevel struct {
+	source ConstraintsSource
+	value  constraints.Value
+}
+
+// inheritedConstraintsLevels returns the default constraints inherited
+// by the model, from lowest to highest precedence.
+func (st *State) inheritedConstraintsLevels() ([]constraintsLevel, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	levels := []constraintsLevel{{source: ControllerConstraintsSource}}
+	keys := []string{controllerDefaultConstraintsKey}
+	if model.Cloud() != "" {
+		levels = append(levels, constraintsLevel{source: CloudConstraintsSource})
+		keys = append(keys, defaultConstraintsKey(model.Cloud(), ""))
+		if model.CloudRegion() != "" {
+			levels = append(levels, constraintsLevel{source: RegionConstraintsSource})
+			keys = append(keys, defaultConstraintsKey(model.Cloud(), model.CloudRegion()))
+		}
+	}
+	for i, key := range keys {
+		doc, err := st.defaultConstraintsDoc(key)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if levels[i].value, err = constraints.Parse(doc.Constraints); err != nil {
+			return nil, errors.Annotatef(err, "parsing %s constraints", levels[i].source)
+		}
+	}
+	return levels, nil
+}
+
+// modelConstraintsLevels returns the constraints hierarchy of the
+// model, from lowest to highest precedence.
+func (st *State) modelConstraintsLevels() ([]constraintsLevel, error) {
+	levels, err := st.inheritedConstraintsLevels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelCons, err := st.ModelConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(levels, constraintsLevel{ModelConstraintsSource, modelCons}), nil
+}
+
+// ResolveConstraints returns the constraints of the named application,
+// merged with the model constraints and the default constraints of the
+// model's cloud region and of the controller. Each level takes
+// precedence over those below it, overriding the values of conflicting
+// attributes as well as those of the same attributes. An empty name
+// resolves the constraints of the model itself.
+func (st *State) ResolveConstraints(application string) (EffectiveConstraints, error) {
+	levels, err := st.modelConstraintsLevels()
+	if err != nil {
+		return EffectiveConstraints{}, errors.Trace(err)
+	}
+	if application != "" {
+		app, err := st.Application(application)
+		if err != nil {
+			return EffectiveConstraints{}, errors.Trace(err)
+		}
+		appCons, err := app.Constraints()
+		if err != nil {
+			return EffectiveConstraints{}, errors.Trace(err)
+		}
+		levels = append(levels, constraintsLevel{ApplicationConstraintsSource, appCons})
+	}
+	validator, err := st.constraintsValidator()
+	if err != nil {
+		return EffectiveConstraints{}, errors.Trace(err)
+	}
+	return mergeConstraintsLevels(validator, levels)
+}
+
+// mergeConstraintsLevels merges the given levels of the constraints
+// hierarchy in order, recording the level from which each resulting
+// attribute is taken.
+func mergeConstraintsLevels(validator constraints.Validator, levels []constraintsLevel) (EffectiveConstraints, error) {
+	var result constraints.Value
+	sources := make(map[string]ConstraintsSource)
+	for _, level := range levels {
+		merged, err := validator.Merge(result, level.value)
+		if err != nil {
+			return EffectiveConstraints{}, errors.Annotatef(err, "merging %s constraints", level.source)
+		}
+		result = merged
+		for _, attr := range level.value.Attributes() {
+			sources[attr] = level.source
+		}
+	}
+	// Drop the sources of attributes overridden by conflicting ones.
+	effective := EffectiveConstraints{
+		Value:   result,
+		Sources: make(map[string]ConstraintsSource),
+	}
+	for _, attr := range result.Attributes() {
+		effective.Sources[attr] = sources[attr]
+	}
+	return effective, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+)
+
+type DefaultConstraintsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&DefaultConstraintsSuite{})
+
+func (s *DefaultConstraintsSuite) setDefaults(c *gc.C, cloud, region, cons string) {
+	err := s.State.SetDefaultConstraints(cloud, region, constraints.MustParse(cons))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DefaultConstraintsSuite) TestNotSet(c *gc.C) {
+	cons, err := s.State.DefaultConstraints("", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(constraints.IsEmpty(&cons), jc.IsTrue)
+}
+
+func (s *DefaultConstraintsSuite) TestSetDefaultConstraints(c *gc.C) {
+	for _, test := range []struct {
+		cloud, region string
+	}{
+		{"", ""},
+		{"dummy", ""},
+		{"dummy", "dummy-region"},
+	} {
+		s.setDefaults(c, test.cloud, test.region, "mem=4G")
+		s.setDefaults(c, test.cloud, test.region, "mem=8G cpu-cores=2")
+		cons, err := s.State.DefaultConstraints(test.cloud, test.region)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=8G cpu-cores=2"))
+	}
+
+	// The levels are independent.
+	s.setDefaults(c, "dummy", "", "")
+	cons, err := s.State.DefaultConstraints("dummy", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(constraints.IsEmpty(&cons), jc.IsTrue)
+	cons, err = s.State.DefaultConstraints("dummy", "dummy-region")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(constraints.IsEmpty(&cons), jc.IsFalse)
+}
+
+func (s *DefaultConstraintsSuite) TestSetDefaultConstraintsInvalid(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	err := s.State.SetDefaultConstraints("", "dummy-region", cons)
+	c.Assert(err, gc.ErrorMatches, `cannot set default constraints: region "dummy-region" without cloud not valid`)
+	err = s.State.SetDefaultConstraints("nimbus", "", cons)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.SetDefaultConstraints("dummy", "nowhere", cons)
+	c.Assert(err, gc.ErrorMatches, `cannot set default constraints: region "nowhere" in cloud "dummy" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *DefaultConstraintsSuite) TestResolveConstraints(c *gc.C) {
+	s.setDefaults(c, "", "", "mem=1G cpu-cores=1 arch=amd64")
+	s.setDefaults(c, "dummy", "", "mem=2G")
+	s.setDefaults(c, "dummy", "dummy-region", "cpu-cores=2")
+	err := s.State.SetModelConstraints(constraints.MustParse("root-disk=8G"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	effective, err := s.State.ResolveConstraints("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(effective, jc.DeepEquals, state.EffectiveConstraints{
+		Value: constraints.MustParse("arch=amd64 mem=2G cpu-cores=2 root-disk=8G"),
+		Sources: map[string]state.ConstraintsSource{
+			"arch":      state.ControllerConstraintsSource,
+			"mem":       state.CloudConstraintsSource,
+			"cpu-cores": state.RegionConstraintsSource,
+			"root-disk": state.ModelConstraintsSource,
+		},
+	})
+
+	app := s.Factory.MakeApplication(c, nil)
+	err = app.SetConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	effective, err = s.State.ResolveConstraints(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(effective.Value, jc.DeepEquals, constraints.MustParse("arch=amd64 mem=4G cpu-cores=2 root-disk=8G"))
+	c.Assert(effective.Sources["mem"], gc.Equals, state.ApplicationConstraintsSource)
+}
+
+func (s *DefaultConstraintsSuite) TestResolveConstraintsApplicationNotFound(c *gc.C) {
+	_, err := s.State.ResolveConstraints("nope")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *DefaultConstraintsSuite) TestDefaultsAppliedToMachines(c *gc.C) {
+	s.setDefaults(c, "", "", "mem=4G")
+	s.setDefaults(c, "dummy", "dummy-region", "cpu-cores=4")
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := m.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G cpu-cores=4"))
+}
@@ -14,6 +14,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	jujutxn "github.com/juju/txn"
 	txntesting "github.com/juju/txn/testing"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -47,6 +48,8 @@ var (
 	ImageStorageNewStorage               = &imageStorageNewStorage
 	MachineIdLessThan                    = machineIdLessThan
 	ControllerAvailable                  = &controllerAvailable
+	MaxConfigSnapshots                   = &maxConfigSnapshots
+	MaxHookTimings                       = &maxHookTimings
 	GetOrCreatePorts                     = getOrCreatePorts
 	GetPorts                             = getPorts
 	NowToTheSecond                       = nowToTheSecond
@@ -58,6 +61,7 @@ var (
 	ModelGlobalKey                       = modelGlobalKey
 	MergeBindings                        = mergeBindings
 	UpgradeInProgressError               = errUpgradeInProgress
+	MigrationPhases                      = migrationPhases
 )
 
 type (
@@ -450,8 +454,34 @@ func SpaceDoc(s *Space) spaceDoc {
 	return s.doc
 }
 
+// UpdateControllerConfig sets the given controller config attributes.
+func UpdateControllerConfig(st *State, attrs map[string]interface{}) error {
+	settings, err := readSettings(st, controllersC, controllerSettingsGlobalKey)
+	if err != nil {
+		return err
+	}
+	for key, value := range attrs {
+		settings.Set(key, value)
+	}
+	_, err = settings.Write()
+	return err
+}
+
+// SetClock replaces the clock used by the given state, and by any
+// states subsequently derived from it.
+func SetClock(st *State, clock clock.Clock) {
+	st.clock = clock
+}
+
+// DueCleanupCount returns the number of cleanups which are due to run.
+func DueCleanupCount(st *State) (int, error) {
+	cleanups, closer := st.getCollection(cleanupsC)
+	defer closer()
+	return cleanups.Find(bson.D{{"due", bson.D{{"$not", bson.D{{"$gt", st.clock.Now()}}}}}}).Count()
+}
+
 func ForceDestroyMachineOps(m *Machine) ([]txn.Op, error) {
-	return m.forceDestroyOps()
+	return m.forceDestroyOps(0)
 }
 
 func IsManagerMachineError(err error) bool {
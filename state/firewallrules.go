@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
+)
+
+// firewallRuleDoc represents the MongoDB document that stores the
+// firewall rule for a well-known service.
+type firewallRuleDoc struct {
+	DocID            string   `bson:"_id"`
+	ModelUUID        string   `bson:"model-uuid"`
+	WellKnownService string   `bson:"well-known-service"`
+	WhitelistCIDRs   []string `bson:"whitelist-source-cidrs"`
+}
+
+func (doc firewallRuleDoc) toRule() network.FirewallRule {
+	return network.FirewallRule{
+		WellKnownService: network.WellKnownServiceType(doc.WellKnownService),
+		WhitelistCIDRs:   doc.WhitelistCIDRs,
+	}
+}
+
+// SaveFirewallRule stores the given firewall rule, replacing any
+// rule previously stored for the rule's service.
+func (st *State) SaveFirewallRule(rule network.FirewallRule) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot save firewall rule for %q", rule.WellKnownService)
+	if err := rule.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	id := string(rule.WellKnownService)
+	buildTxn := func(int) ([]txn.Op, error) {
+		_, err := st.FirewallRule(rule.WellKnownService)
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      firewallRulesC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &firewallRuleDoc{
+					DocID:            st.docID(id),
+					ModelUUID:        st.ModelUUID(),
+					WellKnownService: id,
+					WhitelistCIDRs:   rule.WhitelistCIDRs,
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      firewallRulesC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"whitelist-source-cidrs", rule.WhitelistCIDRs},
+			}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// FirewallRule returns the firewall rule stored for the given
+// well-known service.
+func (st *State) FirewallRule(service network.WellKnownServiceType) (network.FirewallRule, error) {
+	coll, closer := st.getCollection(firewallRulesC)
+	defer closer()
+
+	var doc firewallRuleDoc
+	err := coll.FindId(string(service)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return network.FirewallRule{}, errors.NotFoundf("firewall rule for %q", service)
+	} else if err != nil {
+		return network.FirewallRule{}, errors.Annotatef(err, "cannot get firewall rule for %q", service)
+	}
+	return doc.toRule(), nil
+}
+
+// FirewallRules returns the firewall rules stored for the model,
+// ordered by service.
+func (st *State) FirewallRules() ([]network.FirewallRule, error) {
+	coll, closer := st.getCollection(firewallRulesC)
+	defer closer()
+
+	var docs []firewallRuleDoc
+	if err := coll.Find(nil).Sort("well-known-service").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get firewall rules")
+	}
+	rules := make([]network.FirewallRule, len(docs))
+	for i, doc := range docs {
+		rules[i] = doc.toRule()
+	}
+	return rules, nil
+}
+
+// WatchFirewallRules returns a NotifyWatcher which triggers whenever
+// the model's firewall rules change.
+func (st *State) WatchFirewallRules() NotifyWatcher {
+	return newNotifyCollWatcher(st, firewallRulesC, isLocalID(st))
+}
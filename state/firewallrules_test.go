@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type FirewallRulesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&FirewallRulesSuite{})
+
+func (s *FirewallRulesSuite) TestSaveFirewallRule(c *gc.C) {
+	rules, err := s.State.FirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	rule := network.FirewallRule{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	}
+	err = s.State.SaveFirewallRule(rule)
+	c.Assert(err, jc.ErrorIsNil)
+	stored, err := s.State.FirewallRule(network.SSHRule)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, rule)
+
+	rule.WhitelistCIDRs = []string{"192.168.0.0/16", "172.16.0.0/12"}
+	err = s.State.SaveFirewallRule(rule)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.State.FirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.FirewallRule{rule})
+}
+
+func (s *FirewallRulesSuite) TestSaveFirewallRuleInvalid(c *gc.C) {
+	err := s.State.SaveFirewallRule(network.FirewallRule{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot save firewall rule for "ssh": CIDR "10.0.0.1" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = s.State.SaveFirewallRule(network.FirewallRule{WellKnownService: "telnet"})
+	c.Assert(err, gc.ErrorMatches, `cannot save firewall rule for "telnet": well known service type "telnet" not valid`)
+}
+
+func (s *FirewallRulesSuite) TestFirewallRuleNotFound(c *gc.C) {
+	_, err := s.State.FirewallRule(network.SSHRule)
+	c.Assert(err, gc.ErrorMatches, `firewall rule for "ssh" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FirewallRulesSuite) TestWatchFirewallRules(c *gc.C) {
+	w := s.State.WatchFirewallRules()
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange() // Initial event.
+
+	err := s.State.SaveFirewallRule(network.FirewallRule{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.SaveFirewallRule(network.FirewallRule{WellKnownService: network.SSHRule})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
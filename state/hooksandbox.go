@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// HookSandbox describes the confinement in which the hooks and actions
+// of an application's units are run. The zero value runs them directly,
+// as the unit agent's user.
+type HookSandbox struct {
+	// User is the name of the user the hooks are run as.
+	User string
+
+	// MemoryLimit is the maximum memory, in MiB, available to a
+	// running hook and its children. Zero means no limit.
+	MemoryLimit uint64
+
+	// CPUQuota is the percentage of a single CPU's time available
+	// to a running hook and its children. Zero means no limit.
+	CPUQuota int
+
+	// LXDContainer is the name of an LXD container on the unit's
+	// machine in which the hooks are run.
+	LXDContainer string
+}
+
+var (
+	validSandboxUser      = regexp.MustCompile(`^[a-z_][a-z0-9_-]*$`)
+	validSandboxContainer = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{0,62}$`)
+)
+
+// Validate returns an error if the sandbox is not valid.
+func (sb HookSandbox) Validate() error {
+	if sb.User != "" && !validSandboxUser.MatchString(sb.User) {
+		return errors.NotValidf("user %q", sb.User)
+	}
+	if sb.CPUQuota < 0 {
+		return errors.NotValidf("CPU quota %d%%", sb.CPUQuota)
+	}
+	if sb.LXDContainer != "" && !validSandboxContainer.MatchString(sb.LXDContainer) {
+		return errors.NotValidf("LXD container %q", sb.LXDContainer)
+	}
+	return nil
+}
+
+// IsZero returns whether the sandbox imposes no confinement.
+func (sb HookSandbox) IsZero() bool {
+	return sb == HookSandbox{}
+}
+
+// hookSandboxDoc is the internal representation of a HookSandbox in
+// MongoDB.
+type hookSandboxDoc struct {
+	User         string `bson:"user,omitempty"`
+	MemoryLimit  uint64 `bson:"memory-limit,omitempty"`
+	CPUQuota     int    `bson:"cpu-quota,omitempty"`
+	LXDContainer string `bson:"lxd-container,omitempty"`
+}
+
+func newHookSandboxDoc(sb HookSandbox) *hookSandboxDoc {
+	if sb.IsZero() {
+		return nil
+	}
+	return &hookSandboxDoc{
+		User:         sb.User,
+		MemoryLimit:  sb.MemoryLimit,
+		CPUQuota:     sb.CPUQuota,
+		LXDContainer: sb.LXDContainer,
+	}
+}
+
+func (doc *hookSandboxDoc) sandbox() HookSandbox {
+	if doc == nil {
+		return HookSandbox{}
+	}
+	return HookSandbox{
+		User:         doc.User,
+		MemoryLimit:  doc.MemoryLimit,
+		CPUQuota:     doc.CPUQuota,
+		LXDContainer: doc.LXDContainer,
+	}
+}
+
+// HookSandbox returns the confinement in which the hooks of the
+// application's units are run.
+func (s *Application) HookSandbox() HookSandbox {
+	return s.doc.HookSandbox.sandbox()
+}
+
+// SetHookSandbox changes the confinement in which the hooks of the
+// application's units are run. The change applies to hooks started
+// after it is made; setting the zero value removes any confinement.
+func (s *Application) SetHookSandbox(sb HookSandbox) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set hook sandbox for application %q", s)
+	if err := sb.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := newHookSandboxDoc(sb)
+	update := bson.D{{"$set", bson.D{{"hook-sandbox", doc}}}}
+	if doc == nil {
+		update = bson.D{{"$unset", bson.D{{"hook-sandbox", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.New("application is no longer alive")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	s.doc.HookSandbox = doc
+	return nil
+}
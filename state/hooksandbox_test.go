@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type HookSandboxSuite struct {
+	ConnSuite
+	application *state.Application
+}
+
+var _ = gc.Suite(&HookSandboxSuite{})
+
+func (s *HookSandboxSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+}
+
+func (s *HookSandboxSuite) TestSetHookSandbox(c *gc.C) {
+	c.Assert(s.application.HookSandbox(), gc.Equals, state.HookSandbox{})
+	sandbox := state.HookSandbox{
+		User:         "hooks",
+		MemoryLimit:  512,
+		CPUQuota:     50,
+		LXDContainer: "hook-jail",
+	}
+	err := s.application.SetHookSandbox(sandbox)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.HookSandbox(), gc.Equals, sandbox)
+	c.Assert(s.application.Refresh(), jc.ErrorIsNil)
+	c.Assert(s.application.HookSandbox(), gc.Equals, sandbox)
+
+	err = s.application.SetHookSandbox(state.HookSandbox{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.Refresh(), jc.ErrorIsNil)
+	c.Assert(s.application.HookSandbox(), gc.Equals, state.HookSandbox{})
+}
+
+func (s *HookSandboxSuite) TestSetHookSandboxInvalid(c *gc.C) {
+	for i, test := range []struct {
+		sandbox state.HookSandbox
+		err     string
+	}{{
+		sandbox: state.HookSandbox{User: "Not A User"},
+		err:     `user "Not A User" not valid`,
+	}, {
+		sandbox: state.HookSandbox{CPUQuota: -1},
+		err:     `CPU quota -1% not valid`,
+	}, {
+		sandbox: state.HookSandbox{LXDContainer: "-jail"},
+		err:     `LXD container "-jail" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.application.SetHookSandbox(test.sandbox)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `cannot set hook sandbox for application "dummy-application": `+test.err)
+	}
+}
+
+func (s *HookSandboxSuite) TestSetHookSandboxNotAlive(c *gc.C) {
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetHookSandbox(state.HookSandbox{User: "hooks"})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook sandbox for application "dummy-application": application is no longer alive`)
+}
@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// maxHookTimings is the number of hook executions recorded for each
+// unit. When a unit records more, the oldest are discarded.
+var maxHookTimings = 50
+
+// HookTiming records the execution of a hook by a unit.
+type HookTiming struct {
+	// Hook is the name of the hook that was run.
+	Hook string
+
+	// Queued is the time at which the unit decided to run the hook.
+	Queued time.Time
+
+	// Started is the time at which the hook started running, once
+	// the unit had acquired the machine's hook execution lock.
+	Started time.Time
+
+	// Finished is the time at which the hook exited.
+	Finished time.Time
+
+	// ExitCode is the exit code of the hook's process, or -1 if the
+	// hook could not be run.
+	ExitCode int
+}
+
+// QueueWait returns how long the hook waited before it started running.
+func (t HookTiming) QueueWait() time.Duration {
+	return t.Started.Sub(t.Queued)
+}
+
+// Duration returns how long the hook ran for.
+func (t HookTiming) Duration() time.Duration {
+	return t.Finished.Sub(t.Started)
+}
+
+// hookTimingsDoc represents the MongoDB document that stores the most
+// recent hook executions of a unit.
+type hookTimingsDoc struct {
+	DocID     string          `bson:"_id"`
+	ModelUUID string          `bson:"model-uuid"`
+	Timings   []hookTimingDoc `bson:"timings"`
+	TxnRevno  int64           `bson:"txn-revno"`
+}
+
+type hookTimingDoc struct {
+	Hook     string `bson:"hook"`
+	Queued   int64  `bson:"queued"`
+	Started  int64  `bson:"started"`
+	Finished int64  `bson:"finished"`
+	ExitCode int    `bson:"exit-code"`
+}
+
+// RecordHookTiming records the execution of a hook by the unit. Only
+// the most recent executions are kept.
+func (u *Unit) RecordHookTiming(timing HookTiming) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot record %q hook timing for unit %q", timing.Hook, u.Name())
+	if timing.Hook == "" {
+		return errors.NotValidf("empty hook name")
+	}
+	if timing.Started.Before(timing.Queued) || timing.Finished.Before(timing.Started) {
+		return errors.NotValidf("hook times out of order")
+	}
+	newDoc := hookTimingDoc{
+		Hook:     timing.Hook,
+		Queued:   timing.Queued.UnixNano(),
+		Started:  timing.Started.UnixNano(),
+		Finished: timing.Finished.UnixNano(),
+		ExitCode: timing.ExitCode,
+	}
+	key := u.globalKey()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if u.Life() == Dead {
+				return nil, errors.Errorf("unit is dead")
+			}
+		}
+		coll, closer := u.st.getCollection(hookTimingsC)
+		defer closer()
+		var doc hookTimingsDoc
+		err := coll.FindId(key).One(&doc)
+		op := txn.Op{
+			C:  hookTimingsC,
+			Id: key,
+		}
+		switch err {
+		case mgo.ErrNotFound:
+			op.Assert = txn.DocMissing
+			op.Insert = &hookTimingsDoc{
+				DocID:     u.st.docID(key),
+				ModelUUID: u.st.ModelUUID(),
+				Timings:   []hookTimingDoc{newDoc},
+			}
+		case nil:
+			timings := append(doc.Timings, newDoc)
+			if len(timings) > maxHookTimings {
+				timings = timings[len(timings)-maxHookTimings:]
+			}
+			op.Assert = bson.D{{"txn-revno", doc.TxnRevno}}
+			op.Update = bson.D{{"$set", bson.D{{"timings", timings}}}}
+		default:
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}, op}, nil
+	}
+	return u.st.run(buildTxn)
+}
+
+// HookTimings returns the most recent hook executions recorded by the
+// unit, oldest first.
+func (u *Unit) HookTimings() ([]HookTiming, error) {
+	coll, closer := u.st.getCollection(hookTimingsC)
+	defer closer()
+
+	var doc hookTimingsDoc
+	err := coll.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook timings for unit %q", u.Name())
+	}
+	timings := make([]HookTiming, len(doc.Timings))
+	for i, t := range doc.Timings {
+		timings[i] = HookTiming{
+			Hook:     t.Hook,
+			Queued:   time.Unix(0, t.Queued).UTC(),
+			Started:  time.Unix(0, t.Started).UTC(),
+			Finished: time.Unix(0, t.Finished).UTC(),
+			ExitCode: t.ExitCode,
+		}
+	}
+	return timings, nil
+}
+
+// removeHookTimingsOp returns the operation needed to remove the hook
+// timings of the unit with the given global key.
+func removeHookTimingsOp(st *State, globalKey string) txn.Op {
+	return txn.Op{
+		C:      hookTimingsC,
+		Id:     globalKey,
+		Remove: true,
+	}
+}
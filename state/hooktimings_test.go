@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type HookTimingsSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookTimingsSuite{})
+
+func (s *HookTimingsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func hookTiming(hook string, exitCode int) state.HookTiming {
+	queued := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	return state.HookTiming{
+		Hook:     hook,
+		Queued:   queued,
+		Started:  queued.Add(2 * time.Second),
+		Finished: queued.Add(5 * time.Second),
+		ExitCode: exitCode,
+	}
+}
+
+func (s *HookTimingsSuite) TestNoTimings(c *gc.C) {
+	timings, err := s.unit.HookTimings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timings, gc.HasLen, 0)
+}
+
+func (s *HookTimingsSuite) TestRecord(c *gc.C) {
+	install := hookTiming("install", 0)
+	err := s.unit.RecordHookTiming(install)
+	c.Assert(err, jc.ErrorIsNil)
+	configChanged := hookTiming("config-changed", 1)
+	err = s.unit.RecordHookTiming(configChanged)
+	c.Assert(err, jc.ErrorIsNil)
+
+	timings, err := s.unit.HookTimings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timings, jc.DeepEquals, []state.HookTiming{install, configChanged})
+	c.Assert(timings[0].QueueWait(), gc.Equals, 2*time.Second)
+	c.Assert(timings[0].Duration(), gc.Equals, 3*time.Second)
+}
+
+func (s *HookTimingsSuite) TestRecordBounded(c *gc.C) {
+	s.PatchValue(state.MaxHookTimings, 3)
+	for i := 0; i < 5; i++ {
+		err := s.unit.RecordHookTiming(hookTiming(fmt.Sprintf("hook-%d", i), 0))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	timings, err := s.unit.HookTimings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timings, gc.HasLen, 3)
+	for i, timing := range timings {
+		c.Check(timing.Hook, gc.Equals, fmt.Sprintf("hook-%d", i+2))
+	}
+}
+
+func (s *HookTimingsSuite) TestRecordInvalid(c *gc.C) {
+	outOfOrder := hookTiming("install", 0)
+	outOfOrder.Finished = outOfOrder.Queued.Add(-time.Second)
+	for _, test := range []struct {
+		timing state.HookTiming
+		err    string
+	}{{
+		timing: state.HookTiming{},
+		err:    `cannot record "" hook timing for unit "mysql/0": empty hook name not valid`,
+	}, {
+		timing: outOfOrder,
+		err:    `cannot record "install" hook timing for unit "mysql/0": hook times out of order not valid`,
+	}} {
+		err := s.unit.RecordHookTiming(test.timing)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *HookTimingsSuite) TestRecordDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RecordHookTiming(hookTiming("stop", 0))
+	c.Assert(err, gc.ErrorMatches, `cannot record "stop" hook timing for unit "mysql/0": unit is dead`)
+}
+
+func (s *HookTimingsSuite) TestRemovedWithUnit(c *gc.C) {
+	err := s.unit.RecordHookTiming(hookTiming("install", 0))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	timings, err := s.unit.HookTimings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timings, gc.HasLen, 0)
+}
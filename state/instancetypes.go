@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/environs/instances"
+)
+
+// instanceTypesKey is the id of the single document holding a
+// model's cached instance types.
+const instanceTypesKey = "instancetypes"
+
+// instanceTypesDoc represents the MongoDB document that caches the
+// instance types offered by a model's provider.
+type instanceTypesDoc struct {
+	DocID         string            `bson:"_id"`
+	ModelUUID     string            `bson:"model-uuid"`
+	InstanceTypes []instanceTypeDoc `bson:"instance-types"`
+	Updated       int64             `bson:"updated"`
+}
+
+// instanceTypeDoc represents a single cached instance type.
+type instanceTypeDoc struct {
+	Id         string   `bson:"id,omitempty"`
+	Name       string   `bson:"name"`
+	Arches     []string `bson:"arches"`
+	CpuCores   uint64   `bson:"cpu-cores"`
+	Mem        uint64   `bson:"mem"`
+	Cost       uint64   `bson:"cost"`
+	RootDisk   uint64   `bson:"root-disk,omitempty"`
+	VirtType   *string  `bson:"virt-type,omitempty"`
+	CpuPower   *uint64  `bson:"cpu-power,omitempty"`
+	Tags       []string `bson:"tags,omitempty"`
+	Deprecated bool     `bson:"deprecated,omitempty"`
+}
+
+func newInstanceTypeDoc(itype instances.InstanceType) instanceTypeDoc {
+	return instanceTypeDoc{
+		Id:         itype.Id,
+		Name:       itype.Name,
+		Arches:     itype.Arches,
+		CpuCores:   itype.CpuCores,
+		Mem:        itype.Mem,
+		Cost:       itype.Cost,
+		RootDisk:   itype.RootDisk,
+		VirtType:   itype.VirtType,
+		CpuPower:   itype.CpuPower,
+		Tags:       itype.Tags,
+		Deprecated: itype.Deprecated,
+	}
+}
+
+func (doc instanceTypeDoc) toInstanceType() instances.InstanceType {
+	return instances.InstanceType{
+		Id:         doc.Id,
+		Name:       doc.Name,
+		Arches:     doc.Arches,
+		CpuCores:   doc.CpuCores,
+		Mem:        doc.Mem,
+		Cost:       doc.Cost,
+		RootDisk:   doc.RootDisk,
+		VirtType:   doc.VirtType,
+		CpuPower:   doc.CpuPower,
+		Tags:       doc.Tags,
+		Deprecated: doc.Deprecated,
+	}
+}
+
+// InstanceTypesInfo holds the instance types cached for a model, and
+// the time at which they were fetched from the provider.
+type InstanceTypesInfo struct {
+	InstanceTypes []instances.InstanceType
+	Updated       time.Time
+}
+
+// InstanceTypes returns the instance types cached for the model. If
+// no instance types have been cached, an error satisfying
+// errors.IsNotFound is returned.
+func (st *State) InstanceTypes() (InstanceTypesInfo, error) {
+	coll, closer := st.getCollection(instanceTypesC)
+	defer closer()
+
+	var doc instanceTypesDoc
+	err := coll.FindId(instanceTypesKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return InstanceTypesInfo{}, errors.NotFoundf("instance types")
+	} else if err != nil {
+		return InstanceTypesInfo{}, errors.Annotate(err, "cannot get instance types")
+	}
+	info := InstanceTypesInfo{
+		InstanceTypes: make([]instances.InstanceType, len(doc.InstanceTypes)),
+		Updated:       time.Unix(0, doc.Updated),
+	}
+	for i, itype := range doc.InstanceTypes {
+		info.InstanceTypes[i] = itype.toInstanceType()
+	}
+	return info, nil
+}
+
+// SetInstanceTypes replaces the instance types cached for the model,
+// recording the current time as the time they were fetched.
+func (st *State) SetInstanceTypes(instanceTypes []instances.InstanceType) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set instance types")
+	docs := make([]instanceTypeDoc, len(instanceTypes))
+	for i, itype := range instanceTypes {
+		docs[i] = newInstanceTypeDoc(itype)
+	}
+	updated := st.clock.Now().UnixNano()
+	buildTxn := func(int) ([]txn.Op, error) {
+		_, err := st.InstanceTypes()
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      instanceTypesC,
+				Id:     instanceTypesKey,
+				Assert: txn.DocMissing,
+				Insert: &instanceTypesDoc{
+					DocID:         st.docID(instanceTypesKey),
+					ModelUUID:     st.ModelUUID(),
+					InstanceTypes: docs,
+					Updated:       updated,
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      instanceTypesC,
+			Id:     instanceTypesKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"instance-types", docs},
+				{"updated", updated},
+			}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
@@ -0,0 +1,68 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type InstanceTypesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&InstanceTypesSuite{})
+
+func (s *InstanceTypesSuite) TestInstanceTypesNotFound(c *gc.C) {
+	_, err := s.State.InstanceTypes()
+	c.Assert(err, gc.ErrorMatches, "instance types not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *InstanceTypesSuite) TestSetInstanceTypes(c *gc.C) {
+	now := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	clk := coretesting.NewClock(now)
+	state.SetClock(s.State, clk)
+
+	instanceTypes := []instances.InstanceType{{
+		Id:       "1",
+		Name:     "small",
+		Arches:   []string{"amd64"},
+		CpuCores: 1,
+		Mem:      2048,
+		Cost:     10,
+	}, {
+		Name:     "large",
+		Arches:   []string{"amd64", "arm64"},
+		CpuCores: 4,
+		Mem:      16384,
+		Cost:     40,
+		RootDisk: 65536,
+		CpuPower: instances.CpuPower(400),
+		Tags:     []string{"fast"},
+	}}
+	err := s.State.SetInstanceTypes(instanceTypes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.State.InstanceTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.InstanceTypes, jc.DeepEquals, instanceTypes)
+	c.Assert(info.Updated.Equal(now), jc.IsTrue)
+
+	clk.Advance(time.Hour)
+	err = s.State.SetInstanceTypes(instanceTypes[:1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err = s.State.InstanceTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.InstanceTypes, jc.DeepEquals, instanceTypes[:1])
+	c.Assert(info.Updated.Equal(now.Add(time.Hour)), jc.IsTrue)
+}
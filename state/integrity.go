@@ -0,0 +1,325 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// IntegrityProblem describes a dangling reference between the documents
+// of a model: one which refers to an entity that no longer exists. Such
+// references should never be left behind by normal operation, but can
+// be after a crash or a bug.
+type IntegrityProblem struct {
+	// Collection and Id identify the document holding the dangling
+	// reference. Id is the document's id within the model.
+	Collection string
+	Id         string
+
+	// Description describes the dangling reference.
+	Description string
+
+	// Repairable reports whether CheckIntegrity knows how to remove
+	// the dangling reference without losing anything else.
+	Repairable bool
+
+	// Repaired reports whether the dangling reference was removed.
+	Repaired bool
+
+	ops []txn.Op
+}
+
+// CheckIntegrity scans the model's machines, units, volume attachments
+// and filesystem attachments for references to entities that do not
+// exist, and returns a problem describing each one found. If repair is
+// true, each repairable problem is fixed, in its own transaction;
+// problems that are resolved concurrently are left unrepaired, and can
+// be found to have gone on the next check.
+func (st *State) CheckIntegrity(repair bool) ([]IntegrityProblem, error) {
+	checker, err := st.newIntegrityChecker()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot check integrity")
+	}
+	for _, check := range []func() error{
+		checker.checkUnits,
+		checker.checkMachines,
+		checker.checkVolumeAttachments,
+		checker.checkFilesystemAttachments,
+	} {
+		if err := check(); err != nil {
+			return nil, errors.Annotate(err, "cannot check integrity")
+		}
+	}
+	problems := checker.problems
+	if !repair {
+		return problems, nil
+	}
+	for i, problem := range problems {
+		if !problem.Repairable {
+			continue
+		}
+		err := st.runTransaction(problem.ops)
+		if err == txn.ErrAborted {
+			logger.Debugf("not repairing %s %q: state changed", problem.Collection, problem.Id)
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "cannot repair %s %q", problem.Collection, problem.Id)
+		}
+		problems[i].Repaired = true
+	}
+	return problems, nil
+}
+
+// integrityChecker holds the ids of the entities in a model, against
+// which references are checked.
+type integrityChecker struct {
+	st           *State
+	applications idSet
+	units        idSet
+	machines     idSet
+	volumes      map[string]int
+	filesystems  map[string]int
+	problems     []IntegrityProblem
+}
+
+// idSet is a set of document ids.
+type idSet map[string]bool
+
+func (st *State) newIntegrityChecker() (*integrityChecker, error) {
+	checker := &integrityChecker{
+		st:           st,
+		applications: make(idSet),
+		units:        make(idSet),
+		machines:     make(idSet),
+		volumes:      make(map[string]int),
+		filesystems:  make(map[string]int),
+	}
+	for _, ids := range []struct {
+		collection string
+		field      string
+		ids        idSet
+	}{
+		{applicationsC, "name", checker.applications},
+		{unitsC, "name", checker.units},
+		{machinesC, "machineid", checker.machines},
+	} {
+		coll, closer := st.getCollection(ids.collection)
+		var doc bson.M
+		iter := coll.Find(nil).Select(bson.D{{ids.field, 1}}).Iter()
+		for iter.Next(&doc) {
+			id, _ := doc[ids.field].(string)
+			ids.ids[id] = true
+		}
+		err := iter.Close()
+		closer()
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", ids.collection)
+		}
+	}
+
+	// Attachment counts are recorded, so that repairs that remove
+	// attachments never take a count below zero.
+	volumes, closer := st.getCollection(volumesC)
+	defer closer()
+	var volDoc volumeDoc
+	iter := volumes.Find(nil).Select(bson.D{{"name", 1}, {"attachmentcount", 1}}).Iter()
+	for iter.Next(&volDoc) {
+		checker.volumes[volDoc.Name] = volDoc.AttachmentCount
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading volumes")
+	}
+	filesystems, closer := st.getCollection(filesystemsC)
+	defer closer()
+	var fsDoc filesystemDoc
+	iter = filesystems.Find(nil).Select(bson.D{{"filesystemid", 1}, {"attachmentcount", 1}}).Iter()
+	for iter.Next(&fsDoc) {
+		checker.filesystems[fsDoc.FilesystemId] = fsDoc.AttachmentCount
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading filesystems")
+	}
+	return checker, nil
+}
+
+func (c *integrityChecker) report(collection, id string, ops []txn.Op, format string, args ...interface{}) {
+	c.problems = append(c.problems, IntegrityProblem{
+		Collection:  collection,
+		Id:          id,
+		Description: fmt.Sprintf(format, args...),
+		Repairable:  len(ops) > 0,
+		ops:         ops,
+	})
+}
+
+// checkUnits reports units whose application, principal or machine
+// does not exist. Only missing machines are repaired, by unassigning
+// the unit, so that it can be assigned afresh. Every repair asserts
+// that the missing entity is still missing, so that it is abandoned
+// if the entity has been created since the check.
+func (c *integrityChecker) checkUnits() error {
+	units, closer := c.st.getCollection(unitsC)
+	defer closer()
+
+	var doc unitDoc
+	iter := units.Find(nil).Iter()
+	for iter.Next(&doc) {
+		if !c.applications[doc.Application] {
+			c.report(unitsC, doc.Name, nil, "unit %q belongs to missing application %q", doc.Name, doc.Application)
+		}
+		if doc.Principal != "" && !c.units[doc.Principal] {
+			c.report(unitsC, doc.Name, nil, "subordinate unit %q has missing principal %q", doc.Name, doc.Principal)
+		}
+		if doc.Principal == "" && doc.MachineId != "" && !c.machines[doc.MachineId] {
+			c.report(unitsC, doc.Name, []txn.Op{{
+				C:      unitsC,
+				Id:     doc.DocID,
+				Assert: bson.D{{"machineid", doc.MachineId}},
+				Update: bson.D{{"$set", bson.D{{"machineid", ""}}}},
+			}, {
+				C:      machinesC,
+				Id:     c.st.docID(doc.MachineId),
+				Assert: txn.DocMissing,
+			}}, "unit %q is assigned to missing machine %q", doc.Name, doc.MachineId)
+		}
+	}
+	return errors.Annotate(iter.Close(), "reading units")
+}
+
+// checkMachines reports, and repairs, machines that record missing
+// units as their principals.
+func (c *integrityChecker) checkMachines() error {
+	machines, closer := c.st.getCollection(machinesC)
+	defer closer()
+
+	var doc machineDoc
+	iter := machines.Find(nil).Select(bson.D{{"machineid", 1}, {"principals", 1}}).Iter()
+	for iter.Next(&doc) {
+		for _, unitName := range doc.Principals {
+			if c.units[unitName] {
+				continue
+			}
+			c.report(machinesC, doc.Id, []txn.Op{{
+				C:      machinesC,
+				Id:     doc.DocID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$pull", bson.D{{"principals", unitName}}}},
+			}, {
+				C:      unitsC,
+				Id:     c.st.docID(unitName),
+				Assert: txn.DocMissing,
+			}}, "machine %q has missing principal unit %q", doc.Id, unitName)
+		}
+	}
+	return errors.Annotate(iter.Close(), "reading machines")
+}
+
+// checkVolumeAttachments reports, and repairs, volume attachments to
+// missing machines or of missing volumes.
+func (c *integrityChecker) checkVolumeAttachments() error {
+	attachments, closer := c.st.getCollection(volumeAttachmentsC)
+	defer closer()
+
+	var doc volumeAttachmentDoc
+	iter := attachments.Find(nil).Iter()
+	for iter.Next(&doc) {
+		id := c.st.localID(doc.DocID)
+		count, volumeExists := c.volumes[doc.Volume]
+		machineExists := c.machines[doc.Machine]
+		if volumeExists && machineExists {
+			continue
+		}
+		ops := c.removeAttachmentOps(
+			volumeAttachmentsC, doc.DocID,
+			volumesC, doc.Volume, volumeExists, count,
+			"volumes", doc.Machine, machineExists,
+		)
+		if !machineExists {
+			c.report(volumeAttachmentsC, id, ops, "volume %q is attached to missing machine %q", doc.Volume, doc.Machine)
+		} else {
+			c.report(volumeAttachmentsC, id, ops, "machine %q has missing volume %q attached", doc.Machine, doc.Volume)
+		}
+	}
+	return errors.Annotate(iter.Close(), "reading volume attachments")
+}
+
+// checkFilesystemAttachments reports, and repairs, filesystem
+// attachments to missing machines or of missing filesystems.
+func (c *integrityChecker) checkFilesystemAttachments() error {
+	attachments, closer := c.st.getCollection(filesystemAttachmentsC)
+	defer closer()
+
+	var doc filesystemAttachmentDoc
+	iter := attachments.Find(nil).Iter()
+	for iter.Next(&doc) {
+		id := c.st.localID(doc.DocID)
+		count, filesystemExists := c.filesystems[doc.Filesystem]
+		machineExists := c.machines[doc.Machine]
+		if filesystemExists && machineExists {
+			continue
+		}
+		ops := c.removeAttachmentOps(
+			filesystemAttachmentsC, doc.DocID,
+			filesystemsC, doc.Filesystem, filesystemExists, count,
+			"filesystems", doc.Machine, machineExists,
+		)
+		if !machineExists {
+			c.report(filesystemAttachmentsC, id, ops, "filesystem %q is attached to missing machine %q", doc.Filesystem, doc.Machine)
+		} else {
+			c.report(filesystemAttachmentsC, id, ops, "machine %q has missing filesystem %q attached", doc.Machine, doc.Filesystem)
+		}
+	}
+	return errors.Annotate(iter.Close(), "reading filesystem attachments")
+}
+
+// removeAttachmentOps returns the operations required to remove a
+// dangling attachment, and the references to it held by whichever of
+// the attached storage and machine still exist, asserting that the
+// others are still missing.
+func (c *integrityChecker) removeAttachmentOps(
+	attachmentsCollection, attachmentDocID string,
+	storageCollection, storageId string, storageExists bool, attachmentCount int,
+	machineField, machineId string, machineExists bool,
+) []txn.Op {
+	ops := []txn.Op{{
+		C:      attachmentsCollection,
+		Id:     attachmentDocID,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	switch {
+	case !storageExists:
+		ops = append(ops, txn.Op{
+			C:      storageCollection,
+			Id:     c.st.docID(storageId),
+			Assert: txn.DocMissing,
+		})
+	case attachmentCount > 0:
+		ops = append(ops, txn.Op{
+			C:      storageCollection,
+			Id:     c.st.docID(storageId),
+			Assert: bson.D{{"attachmentcount", bson.D{{"$gt", 0}}}},
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", -1}}}},
+		})
+	}
+	if machineExists {
+		ops = append(ops, txn.Op{
+			C:      machinesC,
+			Id:     c.st.docID(machineId),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$pull", bson.D{{machineField, storageId}}}},
+		})
+	} else {
+		ops = append(ops, txn.Op{
+			C:      machinesC,
+			Id:     c.st.docID(machineId),
+			Assert: txn.DocMissing,
+		})
+	}
+	return ops
+}
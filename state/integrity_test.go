@@ -0,0 +1,141 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type IntegritySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&IntegritySuite{})
+
+func (s *IntegritySuite) removeDoc(c *gc.C, collection, id string) {
+	err := state.RunTransaction(s.State, []txn.Op{{
+		C:      collection,
+		Id:     state.DocID(s.State, id),
+		Remove: true,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *IntegritySuite) checkIntegrity(c *gc.C, repair bool) []state.IntegrityProblem {
+	problems, err := s.State.CheckIntegrity(repair)
+	c.Assert(err, jc.ErrorIsNil)
+	return problems
+}
+
+func (s *IntegritySuite) TestNoProblems(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	c.Assert(s.checkIntegrity(c, true), gc.HasLen, 0)
+}
+
+func (s *IntegritySuite) TestUnitAssignedToMissingMachine(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	s.removeDoc(c, "machines", machine.Id())
+
+	problems := s.checkIntegrity(c, false)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0].Collection, gc.Equals, "units")
+	c.Assert(problems[0].Id, gc.Equals, unit.Name())
+	c.Assert(problems[0].Description, gc.Equals, `unit "`+unit.Name()+`" is assigned to missing machine "`+machine.Id()+`"`)
+	c.Assert(problems[0].Repairable, jc.IsTrue)
+	c.Assert(problems[0].Repaired, jc.IsFalse)
+
+	problems = s.checkIntegrity(c, true)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0].Repaired, jc.IsTrue)
+	err := unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.AssignedMachineId()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+	c.Assert(s.checkIntegrity(c, false), gc.HasLen, 0)
+}
+
+func (s *IntegritySuite) TestRepairAbortedIfMissingMachineReappears(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	var machineDoc bson.M
+	machines := s.State.MongoSession().DB("juju").C("machines")
+	err := machines.FindId(state.DocID(s.State, machine.Id())).One(&machineDoc)
+	c.Assert(err, jc.ErrorIsNil)
+	delete(machineDoc, "txn-queue")
+	s.removeDoc(c, "machines", machine.Id())
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := machines.Insert(machineDoc)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+	problems := s.checkIntegrity(c, true)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0].Repairable, jc.IsTrue)
+	c.Assert(problems[0].Repaired, jc.IsFalse)
+	err = unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, machine.Id())
+}
+
+func (s *IntegritySuite) TestMachineWithMissingPrincipal(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	s.removeDoc(c, "units", unit.Name())
+
+	problems := s.checkIntegrity(c, true)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0].Collection, gc.Equals, "machines")
+	c.Assert(problems[0].Id, gc.Equals, machine.Id())
+	c.Assert(problems[0].Description, gc.Equals, `machine "`+machine.Id()+`" has missing principal unit "`+unit.Name()+`"`)
+	c.Assert(problems[0].Repaired, jc.IsTrue)
+	err := machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Principals(), gc.HasLen, 0)
+}
+
+func (s *IntegritySuite) TestUnitWithMissingApplicationNotRepaired(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	s.removeDoc(c, "applications", unit.ApplicationName())
+
+	problems := s.checkIntegrity(c, true)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0].Description, gc.Equals, `unit "`+unit.Name()+`" belongs to missing application "`+unit.ApplicationName()+`"`)
+	c.Assert(problems[0].Repairable, jc.IsFalse)
+	c.Assert(problems[0].Repaired, jc.IsFalse)
+	c.Assert(s.checkIntegrity(c, false), gc.HasLen, 1)
+}
+
+func (s *IntegritySuite) TestDanglingVolumeAttachment(c *gc.C) {
+	err := state.RunTransaction(s.State, []txn.Op{{
+		C:      "volumeattachments",
+		Id:     state.DocID(s.State, "m#42#0"),
+		Assert: txn.DocMissing,
+		Insert: bson.D{
+			{"model-uuid", s.State.ModelUUID()},
+			{"volumeid", "0"},
+			{"machineid", "42"},
+			{"life", state.Alive},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	problems := s.checkIntegrity(c, true)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0].Collection, gc.Equals, "volumeattachments")
+	c.Assert(problems[0].Id, gc.Equals, "m#42#0")
+	c.Assert(problems[0].Description, gc.Equals, `volume "0" is attached to missing machine "42"`)
+	c.Assert(problems[0].Repaired, jc.IsTrue)
+	c.Assert(s.checkIntegrity(c, false), gc.HasLen, 0)
+}
@@ -156,6 +156,10 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
+	// AddCorrelatedAction queues an action like AddAction, recording
+	// the id of the API request that caused it.
+	AddCorrelatedAction(correlationId, name string, payload map[string]interface{}) (Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action Action) (Action, error)
@@ -207,6 +211,10 @@ type Action interface {
 	// definition of the Action.
 	Parameters() map[string]interface{}
 
+	// CorrelationId returns the id of the API request that enqueued the
+	// action, if any.
+	CorrelationId() string
+
 	// Enqueued returns the time the action was added to state as a pending
 	// Action.
 	Enqueued() time.Time
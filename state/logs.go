@@ -294,10 +294,18 @@ type LogRecord struct {
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
 type LogTailerParams struct {
-	StartID       int64
-	StartTime     time.Time
-	MinLevel      loggo.Level
-	InitialLines  int
+	StartID   int64
+	StartTime time.Time
+	// EndTime, if set, excludes records logged at or after the given
+	// time. As no new records can match, the tailer stops once the
+	// existing records have been sent.
+	EndTime      time.Time
+	MinLevel     loggo.Level
+	InitialLines int
+	// Offset skips over this many of the most recent matching records,
+	// so that the backlog may be paged through. If Offset is set, the
+	// tailer stops once the existing records have been sent.
+	Offset        int
 	NoTail        bool
 	IncludeEntity []string
 	ExcludeEntity []string
@@ -398,7 +406,7 @@ func (t *logTailer) loop() error {
 		return errors.Trace(err)
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || !t.params.EndTime.IsZero() || t.params.Offset > 0 {
 		return nil
 	}
 
@@ -411,14 +419,25 @@ func (t *logTailer) processCollection() error {
 	sel := t.paramsToSelector(t.params, "")
 	query := t.logsColl.Find(sel)
 
-	if t.params.InitialLines > 0 {
+	if t.params.InitialLines > 0 || t.params.Offset > 0 {
 		// This is a little racy but it's good enough.
 		count, err := query.Count()
 		if err != nil {
 			return errors.Annotate(err, "query count failed")
 		}
-		if skipOver := count - t.params.InitialLines; skipOver > 0 {
-			query = query.Skip(skipOver)
+		// Only the records before the offset are considered, and
+		// of those, only the last InitialLines (if set).
+		end := count - t.params.Offset
+		if end <= 0 {
+			return nil
+		}
+		skipOver := 0
+		if t.params.InitialLines > 0 && end > t.params.InitialLines {
+			skipOver = end - t.params.InitialLines
+		}
+		query = query.Skip(skipOver)
+		if t.params.Offset > 0 {
+			query = query.Limit(end - skipOver)
 		}
 	}
 
@@ -515,8 +534,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
-	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+	if !params.StartTime.IsZero() || !params.EndTime.IsZero() {
+		timeSel := bson.M{}
+		if !params.StartTime.IsZero() {
+			timeSel["$gte"] = params.StartTime.UnixNano()
+		}
+		if !params.EndTime.IsZero() {
+			timeSel["$lt"] = params.EndTime.UnixNano()
+		}
+		sel = append(sel, bson.DocElem{"t", timeSel})
 	}
 	if !params.AllModels {
 		sel = append(sel, bson.DocElem{"e", t.modelUUID})
@@ -732,6 +758,14 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 	return nil
 }
 
+// removeModelLogs removes all the log records of the given model.
+func removeModelLogs(st MongoSessioner, modelUUID string) error {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+	_, err := logsColl.RemoveAll(bson.M{"e": modelUUID})
+	return errors.Annotate(err, "cannot remove model logs")
+}
+
 // initLogsSession creates a new session suitable for logging updates,
 // returning the session and a logs mgo.Collection connected to that
 // session.
@@ -347,6 +347,61 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestTimeRangeFiltering(c *gc.C) {
+	startT := time.Now()
+	endT := startT.Add(5 * time.Second)
+	s.writeLogsT(c,
+		startT.Add(-5*time.Second), startT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, startT, endT.Add(-time.Millisecond), 5, want)
+	s.writeLogsT(c, endT, endT.Add(5*time.Second), 5, logTemplate{Message: "too late"})
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		StartTime: startT,
+		EndTime:   endT,
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+	s.assertTailerStopped(c, tailer)
+}
+
+func (s *LogTailerSuite) TestOffset(c *gc.C) {
+	s.writeLogs(c, 3, logTemplate{Message: "dont want"})
+	expected := logTemplate{Message: "want"}
+	s.writeLogs(c, 4, expected)
+	s.writeLogs(c, 2, logTemplate{Message: "too recent"})
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		InitialLines: 4,
+		Offset:       2,
+		Oplog:        s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// Should see the 4 lines before the 2 most recent ones, and then
+	// stop, as there is nothing to tail when paging.
+	s.assertTailer(c, tailer, 4, expected)
+	s.assertTailerStopped(c, tailer)
+}
+
+func (s *LogTailerSuite) TestOffsetPastStart(c *gc.C) {
+	s.writeLogs(c, 3, logTemplate{Message: "dont want"})
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		InitialLines: 4,
+		Offset:       3,
+		Oplog:        s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailerStopped(c, tailer)
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -733,6 +788,26 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+// assertTailerStopped checks that the tailer sends no more records,
+// and stops itself.
+func (s *LogTailerSuite) assertTailerStopped(c *gc.C, tailer state.LogTailer) {
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+
+	select {
+	case <-tailer.Dying():
+		// Success.
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("tailer didn't stop itself")
+	}
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -152,6 +152,14 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// Maintenance is true while the machine is in maintenance mode,
+	// during which workers leave the machine and its instance alone.
+	Maintenance bool `bson:"maintenance,omitempty"`
+
+	// PasswordRotation records the rotation of the machine agent's
+	// password.
+	PasswordRotation passwordRotationDoc `bson:"password-rotation,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -333,6 +341,35 @@ func (m *Machine) StopMongoUntilVersion() (mongo.Version, error) {
 	return mongo.NewVersion(m.doc.StopMongoUntilVersion)
 }
 
+// InMaintenance returns whether the machine is in maintenance mode.
+// While it is, the provisioner, firewaller and instance poller leave
+// the machine alone, and its units defer nonessential hooks, so that
+// the host may safely be worked on by hand.
+func (m *Machine) InMaintenance() bool {
+	return m.doc.Maintenance
+}
+
+// SetMaintenance puts the machine into, or takes it out of,
+// maintenance mode. A dead machine cannot be put into maintenance
+// mode, but may be taken out of it.
+func (m *Machine) SetMaintenance(maintenance bool) error {
+	var assert interface{} = txn.DocExists
+	if maintenance {
+		assert = notDeadDoc
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: assert,
+		Update: bson.D{{"$set", bson.D{{"maintenance", maintenance}}}},
+	}}
+	if err := m.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, ErrDead), "cannot set maintenance mode of machine %v", m)
+	}
+	m.doc.Maintenance = maintenance
+	return nil
+}
+
 // IsManager returns true if the machine has JobManageModel.
 func (m *Machine) IsManager() bool {
 	return hasJob(m.doc.Jobs, JobManageModel)
@@ -426,11 +463,12 @@ func (m *Machine) SetPassword(password string) error {
 // to the value supplied. This is split out from SetPassword to allow direct
 // manipulation in tests (to check for backwards compatibility).
 func (m *Machine) setPasswordHash(passwordHash string) error {
+	update, rotation := setPasswordHashUpdate(m.doc.PasswordHash, passwordHash, m.doc.PasswordRotation, m.st.clock.Now())
 	ops := []txn.Op{{
 		C:      machinesC,
 		Id:     m.doc.DocID,
 		Assert: notDeadDoc,
-		Update: bson.D{{"$set", bson.D{{"passwordhash", passwordHash}}}},
+		Update: update,
 	}}
 	// A "raw" transaction is used here because this code has to work
 	// before the machine env UUID DB migration has run. In this case
@@ -440,6 +478,7 @@ func (m *Machine) setPasswordHash(passwordHash string) error {
 		return fmt.Errorf("cannot set password of machine %v: %v", m, onAbort(err, ErrDead))
 	}
 	m.doc.PasswordHash = passwordHash
+	m.doc.PasswordRotation = rotation
 	return nil
 }
 
@@ -451,10 +490,10 @@ func (m *Machine) getPasswordHash() string {
 }
 
 // PasswordValid returns whether the given password is valid
-// for the given machine.
+// for the given machine. A password replaced by a recent rotation
+// remains valid for a short time.
 func (m *Machine) PasswordValid(password string) bool {
-	agentHash := utils.AgentPasswordHash(password)
-	return agentHash == m.doc.PasswordHash
+	return agentPasswordValid(password, m.doc.PasswordHash, m.doc.PasswordRotation, m.st.clock.Now())
 }
 
 // Destroy sets the machine lifecycle to Dying if it is Alive. It does
@@ -469,7 +508,31 @@ func (m *Machine) Destroy() error {
 // ForceDestroy queues the machine for complete removal, including the
 // destruction of all units and containers on the machine.
 func (m *Machine) ForceDestroy() error {
-	ops, err := m.forceDestroyOps()
+	return m.ForceDestroyAfter(0)
+}
+
+// ForceDestroyAfter destroys the machine's principal units, giving their
+// agents up to maxWait to shut down cleanly, and then queues the machine
+// for complete removal as ForceDestroy does. If maxWait is zero, the
+// removal is queued immediately.
+func (m *Machine) ForceDestroyAfter(maxWait time.Duration) error {
+	if maxWait > 0 {
+		if m.IsManager() {
+			return errors.Trace(managerMachineError)
+		}
+		for _, unitName := range m.doc.Principals {
+			unit, err := m.st.Unit(unitName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if err := unit.Destroy(); err != nil {
+				return errors.Annotatef(err, "cannot destroy unit %q", unitName)
+			}
+		}
+	}
+	ops, err := m.forceDestroyOps(maxWait)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -481,7 +544,7 @@ func (m *Machine) ForceDestroy() error {
 
 var managerMachineError = errors.New("machine is required by the model")
 
-func (m *Machine) forceDestroyOps() ([]txn.Op, error) {
+func (m *Machine) forceDestroyOps(maxWait time.Duration) ([]txn.Op, error) {
 	if m.IsManager() {
 		return nil, errors.Trace(managerMachineError)
 	}
@@ -490,7 +553,7 @@ func (m *Machine) forceDestroyOps() ([]txn.Op, error) {
 		C:      machinesC,
 		Id:     m.doc.DocID,
 		Assert: bson.D{{"jobs", bson.D{{"$nin", []MachineJob{JobManageModel}}}}},
-	}, m.st.newCleanupOp(cleanupForceDestroyedMachine, m.doc.Id)}, nil
+	}, m.st.newDelayedCleanupOp(cleanupForceDestroyedMachine, m.doc.Id, maxWait)}, nil
 }
 
 // EnsureDead sets the machine lifecycle to Dead if it is Alive or Dying.
@@ -852,6 +915,9 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.st, m.globalKey()),
+		removeAgentLoggingOp(m.st, m.globalKey()),
+		removeCloudInitOp(m.st, m.globalKey()),
+		removeUpgradeSeriesLockOp(m.st, m.Id()),
 	}
 	linkLayerDevicesOps, err := m.removeAllLinkLayerDevicesOps()
 	if err != nil {
@@ -873,12 +939,17 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	blockOps, err := removeEntityBlocksOps(m.st, m.MachineTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	ops = append(ops, linkLayerDevicesOps...)
 	ops = append(ops, devicesAddressesOps...)
 	ops = append(ops, portsOps...)
 	ops = append(ops, removeContainerRefOps(m.st, m.Id())...)
 	ops = append(ops, filesystemOps...)
 	ops = append(ops, volumeOps...)
+	ops = append(ops, blockOps...)
 	return ops, nil
 }
 
@@ -907,7 +978,10 @@ func (m *Machine) Remove() (err error) {
 		}
 		return ops, nil
 	}
-	return m.st.run(buildTxn)
+	if err := m.st.run(buildTxn); err != nil {
+		return err
+	}
+	return removeMachineUsage(m.st, m.Id())
 }
 
 // Refresh refreshes the contents of the machine from the underlying
@@ -1421,6 +1495,23 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 		ops = append(ops, setPublicAddressOps...)
 		return ops, nil
 	}
+	// Addresses are refreshed frequently, mostly without changing the
+	// preferred addresses, so such updates are batched if batching is
+	// enabled; any other update, or one which is aborted, is run
+	// directly below.
+	if batcher := m.st.getTxnBatcher(); batcher != nil {
+		ops, err := buildTxn(0)
+		if err == nil && len(ops) == 1 {
+			err = batcher.update(machinesC, m.doc.DocID, notDeadDoc, bson.D{{fieldName, stateAddresses}})
+			if err == nil {
+				*field = stateAddresses
+				return nil
+			}
+			if err != txn.ErrAborted && err != errTxnBatcherStopped {
+				return errors.Trace(err)
+			}
+		}
+	}
 	err = m.st.run(buildTxn)
 	if err == txn.ErrAborted {
 		return ErrDead
@@ -1549,7 +1640,17 @@ func (m *Machine) SetStatus(statusInfo status.StatusInfo) error {
 	default:
 		return errors.Errorf("cannot set invalid status %q", statusInfo.Status)
 	}
-	return setStatus(m.st, setStatusParams{
+	var failed bool
+	if statusInfo.Status == status.StatusError {
+		// Only the machine's entry into the error state is of note;
+		// it may report the same error repeatedly.
+		previous, err := getStatus(m.st, m.globalKey(), "machine")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		failed = previous.Status != status.StatusError || previous.Message != statusInfo.Message
+	}
+	err := setStatus(m.st, setStatusParams{
 		badge:     "machine",
 		globalKey: m.globalKey(),
 		status:    statusInfo.Status,
@@ -1557,6 +1658,21 @@ func (m *Machine) SetStatus(statusInfo status.StatusInfo) error {
 		rawData:   statusInfo.Data,
 		updated:   statusInfo.Since,
 	})
+	if err != nil || !failed {
+		return err
+	}
+	event := ModelEvent{
+		Kind:    MachineFailureEvent,
+		Entity:  m.Tag().String(),
+		Message: statusInfo.Message,
+	}
+	if statusInfo.Since != nil {
+		event.Time = *statusInfo.Since
+	}
+	if err := m.st.AddModelEvent(event); err != nil {
+		logger.Errorf("failed to record failure of machine %s: %v", m.Id(), err)
+	}
+	return nil
 }
 
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
@@ -1662,8 +1778,7 @@ func (m *Machine) markInvalidContainers() error {
 			}
 			if statusInfo.Status == status.StatusPending {
 				containerType := ContainerTypeFromId(containerId)
-				// TODO(perrito666) 2016-05-02 lp:1558657
-				now := time.Now()
+				now := m.st.clock.Now()
 				s := status.StatusInfo{
 					Status:  status.StatusError,
 					Message: "unsupported container",
@@ -1691,6 +1806,11 @@ func (m *Machine) VolumeAttachments() ([]VolumeAttachment, error) {
 
 // AddAction is part of the ActionReceiver interface.
 func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return m.AddCorrelatedAction("", name, payload)
+}
+
+// AddCorrelatedAction is part of the ActionReceiver interface.
+func (m *Machine) AddCorrelatedAction(correlationId, name string, payload map[string]interface{}) (Action, error) {
 	spec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
 		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
@@ -1705,7 +1825,7 @@ func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action
 	if err != nil {
 		return nil, err
 	}
-	return m.st.EnqueueAction(m.Tag(), name, payloadWithDefaults)
+	return m.st.enqueueAction(m.Tag(), name, payloadWithDefaults, correlationId)
 }
 
 // CancelAction is part of the ActionReceiver interface.
@@ -211,6 +211,32 @@ func (s *MachineSuite) TestMachineIsManager(c *gc.C) {
 	c.Assert(s.machine.IsManager(), jc.IsFalse)
 }
 
+func (s *MachineSuite) TestSetMaintenance(c *gc.C) {
+	c.Assert(s.machine.InMaintenance(), jc.IsFalse)
+	err := s.machine.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.InMaintenance(), jc.IsTrue)
+
+	m, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.InMaintenance(), jc.IsTrue)
+
+	err = m.SetMaintenance(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.InMaintenance(), jc.IsFalse)
+}
+
+func (s *MachineSuite) TestSetMaintenanceDead(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetMaintenance(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set maintenance mode of machine 1: not found or dead`)
+	err = s.machine.SetMaintenance(false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *MachineSuite) TestMachineIsManualBootstrap(c *gc.C) {
 	cfg, err := s.State.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
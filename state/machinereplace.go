@@ -0,0 +1,286 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// retireMachineWait is the time for which the agents of the units on a
+// replaced machine are given to shut down cleanly, before the machine
+// is removed regardless.
+const retireMachineWait = 5 * time.Minute
+
+// MachineReplacement describes the outcome of ReplaceMachine.
+type MachineReplacement struct {
+	// Machine is the substitute machine.
+	Machine *Machine
+
+	// Units maps the name of each principal unit of the replaced
+	// machine to the name of the unit that takes its place on the
+	// substitute: the same unit, if its assignment was migrated, or
+	// a new unit of the same application, if it was redeployed.
+	Units map[string]string
+}
+
+// ReplaceMachine adds a substitute for the machine with the given id,
+// with the same series, jobs and constraints (and so the same spaces),
+// and retires the original machine.
+//
+// If the original machine has not been provisioned, nothing has been
+// deployed to it, so its units are simply reassigned to the substitute
+// and the original is destroyed. Otherwise, a new unit of each of its
+// principal units' applications is assigned to the substitute, and the
+// original machine and its units are destroyed; the units' agents are
+// given retireMachineWait to shut down before the machine is removed
+// regardless. Each new unit is given storage matching that of the unit
+// it replaces; the contents of the storage are not copied.
+//
+// If any unit cannot be placed on the substitute, the changes already
+// made are undone, and the substitute is destroyed.
+//
+// Controller machines, and machines hosting containers, cannot be
+// replaced.
+func (st *State) ReplaceMachine(id string) (_ *MachineReplacement, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot replace machine %s", id)
+
+	old, err := st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if old.Life() != Alive {
+		return nil, errors.New("machine is not alive")
+	}
+	if old.IsManager() {
+		return nil, errors.Trace(managerMachineError)
+	}
+	containers, err := old.Containers()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if len(containers) > 0 {
+		return nil, errors.Errorf("machine hosts containers %v", containers)
+	}
+	_, err = old.InstanceId()
+	provisioned := err == nil
+	if err != nil && !errors.IsNotProvisioned(err) {
+		return nil, errors.Trace(err)
+	}
+
+	// Check that every unit can be moved or redeployed before
+	// changing anything.
+	principals := old.Principals()
+	units := make([]*Unit, len(principals))
+	for i, name := range principals {
+		unit, err := st.Unit(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if unit.Life() != Alive {
+			return nil, errors.Errorf("unit %q is not alive", name)
+		}
+		units[i] = unit
+	}
+
+	cons, err := old.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	template := MachineTemplate{
+		Series:      old.Series(),
+		Jobs:        old.Jobs(),
+		Constraints: cons,
+	}
+	// undo holds the functions which reverse the changes made so
+	// far; they are run, latest first, if the replacement fails.
+	var undo []func() error
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(undo) - 1; i >= 0; i-- {
+			if undoErr := undo[i](); undoErr != nil {
+				logger.Errorf("cannot undo partial replacement of machine %s: %v", id, undoErr)
+			}
+		}
+	}()
+
+	var substitute *Machine
+	if parentId, ok := old.ParentId(); ok {
+		substitute, err = st.AddMachineInsideMachine(template, parentId, old.ContainerType())
+	} else {
+		substitute, err = st.AddOneMachine(template)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	undo = append(undo, substitute.ForceDestroy)
+
+	replacement := &MachineReplacement{
+		Machine: substitute,
+		Units:   make(map[string]string),
+	}
+	for _, unit := range units {
+		var name string
+		var undoUnit func() error
+		if provisioned {
+			name, undoUnit, err = redeployUnit(st, unit, substitute)
+		} else {
+			name, undoUnit, err = moveUnit(unit, old, substitute)
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "unit %q", unit.Name())
+		}
+		undo = append(undo, undoUnit)
+		replacement.Units[unit.Name()] = name
+	}
+
+	if provisioned {
+		err = old.ForceDestroyAfter(retireMachineWait)
+	} else if err = old.Refresh(); err == nil {
+		err = old.Destroy()
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot retire machine")
+	}
+	return replacement, nil
+}
+
+// moveUnit reassigns the given unit from the original machine to the
+// substitute, and returns its name, and a function which moves it back.
+// Units on unprovisioned machines cannot have been deployed, so may be
+// moved.
+func moveUnit(unit *Unit, original, substitute *Machine) (string, func() error, error) {
+	if err := unit.UnassignFromMachine(); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	moveBack := func() error {
+		if err := unit.UnassignFromMachine(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(unit.AssignToMachine(original))
+	}
+	if err := unit.AssignToMachine(substitute); err != nil {
+		if assignErr := unit.AssignToMachine(original); assignErr != nil {
+			logger.Errorf("cannot reassign unit %q to machine %s: %v", unit.Name(), original.Id(), assignErr)
+		}
+		return "", nil, errors.Trace(err)
+	}
+	return unit.Name(), moveBack, nil
+}
+
+// redeployUnit adds a new unit of the given unit's application, with
+// matching storage, to the substitute machine, and returns its name,
+// and a function which destroys it. Units on provisioned machines may
+// have been deployed, so are replaced.
+func redeployUnit(st *State, unit *Unit, substitute *Machine) (string, func() error, error) {
+	storage, err := unitStorageConstraints(st, unit)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	application, err := unit.Application()
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	newUnit, err := application.AddUnit()
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if err := newUnit.AssignToMachine(substitute); err != nil {
+		return "", nil, cleanupRedeployedUnit(newUnit, err)
+	}
+	if err := addMissingStorage(st, newUnit, storage); err != nil {
+		return "", nil, cleanupRedeployedUnit(newUnit, err)
+	}
+	return newUnit.Name(), newUnit.Destroy, nil
+}
+
+// cleanupRedeployedUnit destroys a unit which could not be redeployed,
+// and returns the error which prevented it.
+func cleanupRedeployedUnit(unit *Unit, err error) error {
+	if destroyErr := unit.Destroy(); destroyErr != nil {
+		logger.Errorf("cannot destroy unit %q: %v", unit.Name(), destroyErr)
+	}
+	return errors.Trace(err)
+}
+
+// unitStorageConstraints returns, keyed on storage name, the pool and
+// size of each storage instance owned by the unit.
+func unitStorageConstraints(st *State, unit *Unit) (map[string][]StorageConstraints, error) {
+	attachments, err := st.UnitStorageAttachments(unit.UnitTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string][]StorageConstraints)
+	for _, attachment := range attachments {
+		instance, err := st.storageInstance(attachment.StorageInstance())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if instance.Owner() != names.Tag(unit.UnitTag()) {
+			// Shared storage is owned by the application,
+			// and so survives the unit.
+			continue
+		}
+		cons, err := storageInstanceConstraints(st, instance)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		name := instance.StorageName()
+		result[name] = append(result[name], cons)
+	}
+	return result, nil
+}
+
+// storageInstanceConstraints returns the pool and size of the given
+// storage instance, preferring those with which it was provisioned.
+func storageInstanceConstraints(st *State, instance *storageInstance) (StorageConstraints, error) {
+	cons := StorageConstraints{Count: 1}
+	switch instance.Kind() {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(instance.StorageTag())
+		if err != nil {
+			return cons, errors.Trace(err)
+		}
+		if info, err := v.Info(); err == nil {
+			cons.Pool, cons.Size = info.Pool, info.Size
+		} else if params, ok := v.Params(); ok {
+			cons.Pool, cons.Size = params.Pool, params.Size
+		}
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(instance.StorageTag())
+		if err != nil {
+			return cons, errors.Trace(err)
+		}
+		if info, err := f.Info(); err == nil {
+			cons.Pool, cons.Size = info.Pool, info.Size
+		} else if params, ok := f.Params(); ok {
+			cons.Pool, cons.Size = params.Pool, params.Size
+		}
+	default:
+		return cons, errors.Errorf("unknown storage kind %v", instance.Kind())
+	}
+	return cons, nil
+}
+
+// addMissingStorage adds storage instances to the unit until it has, for
+// each storage name, as many as are described by the given constraints.
+// The unit's existing instances are taken to match the first of them.
+func addMissingStorage(st *State, unit *Unit, storage map[string][]StorageConstraints) error {
+	for name, all := range storage {
+		count, err := st.countEntityStorageInstancesForName(unit.Tag(), name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i := int(count); i < len(all); i++ {
+			if err := st.AddStorageForUnit(unit.UnitTag(), name, all[i]); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
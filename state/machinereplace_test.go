@@ -0,0 +1,190 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type MachineReplaceSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&MachineReplaceSuite{})
+
+func (s *MachineReplaceSuite) TestReplaceUnprovisionedMachineMovesUnits(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	old, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: cons,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: old})
+
+	replacement, err := s.State.ReplaceMachine(old.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replacement.Machine.Id(), gc.Not(gc.Equals), old.Id())
+	c.Assert(replacement.Machine.Series(), gc.Equals, "quantal")
+	c.Assert(replacement.Machine.Jobs(), jc.DeepEquals, []state.MachineJob{state.JobHostUnits})
+	newCons, err := replacement.Machine.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newCons, jc.DeepEquals, cons)
+	c.Assert(replacement.Units, jc.DeepEquals, map[string]string{
+		unit.Name(): unit.Name(),
+	})
+
+	err = unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, replacement.Machine.Id())
+
+	err = old.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(old.Life(), gc.Equals, state.Dying)
+}
+
+func (s *MachineReplaceSuite) TestReplaceProvisionedMachineRedeploysUnits(c *gc.C) {
+	old := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: old})
+
+	replacement, err := s.State.ReplaceMachine(old.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replacement.Units, gc.HasLen, 1)
+	newName := replacement.Units[unit.Name()]
+	c.Assert(newName, gc.Not(gc.Equals), unit.Name())
+
+	newUnit, err := s.State.Unit(newName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newUnit.ApplicationName(), gc.Equals, unit.ApplicationName())
+	machineId, err := newUnit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, replacement.Machine.Id())
+
+	err = unit.Refresh()
+	if err == nil {
+		c.Assert(unit.Life(), gc.Not(gc.Equals), state.Alive)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	err = old.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(old.Life(), gc.Not(gc.Equals), state.Alive)
+}
+
+func (s *MachineReplaceSuite) TestReplaceProvisionedMachineCarriesStorage(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	application := s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	})
+	old := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application, Machine: old})
+	err := s.State.AddStorageForUnit(unit.UnitTag(), "allecto", makeStorageCons("loop", 2048, 2))
+	c.Assert(err, jc.ErrorIsNil)
+
+	replacement, err := s.State.ReplaceMachine(old.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	newTag := names.NewUnitTag(replacement.Units[unit.Name()])
+
+	attachments, err := s.State.UnitStorageAttachments(newTag)
+	c.Assert(err, jc.ErrorIsNil)
+	sizes := make(map[string][]uint64)
+	for _, attachment := range attachments {
+		instance, err := s.State.StorageInstance(attachment.StorageInstance())
+		c.Assert(err, jc.ErrorIsNil)
+		volume, err := s.State.StorageInstanceVolume(instance.StorageTag())
+		c.Assert(err, jc.ErrorIsNil)
+		params, ok := volume.Params()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(params.Pool, gc.Equals, "loop")
+		sizes[instance.StorageName()] = append(sizes[instance.StorageName()], params.Size)
+	}
+	c.Assert(sizes, jc.DeepEquals, map[string][]uint64{
+		"data":    {1024},
+		"allecto": {2048, 2048},
+	})
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineUndoesChangesOnError(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	old := s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: old})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: old})
+
+	// No unit of a dying application can be added, so mysql/0
+	// cannot be redeployed once wordpress/0 has been.
+	err := mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ReplaceMachine(old.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 0: unit "mysql/0": .*`)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	redeployed, err := s.State.Unit("wordpress/1")
+	if err == nil {
+		c.Assert(redeployed.Life(), gc.Not(gc.Equals), state.Alive)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	substitute, err := s.State.Machine("1")
+	if err == nil {
+		c.Assert(substitute.Life(), gc.Not(gc.Equals), state.Alive)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	err = old.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(old.Life(), gc.Equals, state.Alive)
+}
+
+func (s *MachineReplaceSuite) TestReplaceContainer(c *gc.C) {
+	host := s.Factory.MakeMachine(c, nil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	replacement, err := s.State.ReplaceMachine(container.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	parentId, ok := replacement.Machine.ParentId()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(parentId, gc.Equals, host.Id())
+	c.Assert(replacement.Machine.ContainerType(), gc.Equals, instance.LXD)
+
+	_, err = s.State.ReplaceMachine(host.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 0: machine hosts containers \[0/lxd/[0-9]+( 0/lxd/[0-9]+)*\]`)
+}
+
+func (s *MachineReplaceSuite) TestReplaceManagerMachine(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ReplaceMachine(m.Id())
+	c.Assert(err, gc.ErrorMatches, "cannot replace machine 0: machine is required by the model")
+}
+
+func (s *MachineReplaceSuite) TestReplaceDyingMachine(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ReplaceMachine(m.Id())
+	c.Assert(err, gc.ErrorMatches, "cannot replace machine 0: machine is not alive")
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineNotFound(c *gc.C) {
+	_, err := s.State.ReplaceMachine("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,207 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MachineUsage holds the resource usage of a machine, and of the units
+// deployed to it, as last reported by the machine's agent.
+type MachineUsage struct {
+	MachineId string
+
+	// CPUPercent is the percentage of the machine's total CPU
+	// capacity in use.
+	CPUPercent float64
+
+	// MemoryUsed and MemoryTotal are the used and total memory of
+	// the machine, in bytes.
+	MemoryUsed  uint64
+	MemoryTotal uint64
+
+	// DiskUsed and DiskTotal are the used and total space of the
+	// machine's root filesystem, in bytes.
+	DiskUsed  uint64
+	DiskTotal uint64
+
+	// Units holds the resource usage of the units deployed to the
+	// machine.
+	Units []UnitUsage
+
+	// Received and Transmitted are the numbers of bytes received
+	// and transmitted by the machine's network devices, accounted
+	// since TrafficSince. When usage is set, they are instead the
+	// bytes since the usage was last set, and are added to the
+	// accounted totals.
+	Received     uint64
+	Transmitted  uint64
+	TrafficSince time.Time
+
+	// Updated is the time at which the usage was recorded.
+	Updated time.Time
+}
+
+// UnitUsage holds the resource usage of a unit, as measured by the
+// agent of the machine it is deployed to.
+type UnitUsage struct {
+	Unit string
+
+	// CPUPercent is the percentage of the machine's total CPU
+	// capacity used by the unit's processes.
+	CPUPercent float64
+
+	// MemoryUsed is the resident memory used by the unit's
+	// processes, in bytes.
+	MemoryUsed uint64
+
+	// DiskUsed is the space used by the unit's agent directory,
+	// including its charm, in bytes.
+	DiskUsed uint64
+}
+
+// machineUsageDoc represents the MongoDB document that stores the
+// last reported resource usage of a machine, and its accumulated
+// network traffic. Usage is reported often, and is either of no value
+// once superseded or only increments the traffic totals, so it's
+// written directly rather than by transaction.
+type machineUsageDoc struct {
+	DocID        string         `bson:"_id"`
+	ModelUUID    string         `bson:"model-uuid"`
+	MachineId    string         `bson:"machine-id"`
+	CPUPercent   float64        `bson:"cpu-percent"`
+	MemoryUsed   uint64         `bson:"memory-used"`
+	MemoryTotal  uint64         `bson:"memory-total"`
+	DiskUsed     uint64         `bson:"disk-used"`
+	DiskTotal    uint64         `bson:"disk-total"`
+	Units        []unitUsageDoc `bson:"units"`
+	Received     uint64         `bson:"received"`
+	Transmitted  uint64         `bson:"transmitted"`
+	TrafficSince time.Time      `bson:"traffic-since,omitempty"`
+	Updated      time.Time      `bson:"updated"`
+}
+
+type unitUsageDoc struct {
+	Unit       string  `bson:"unit"`
+	CPUPercent float64 `bson:"cpu-percent"`
+	MemoryUsed uint64  `bson:"memory-used"`
+	DiskUsed   uint64  `bson:"disk-used"`
+}
+
+func (doc machineUsageDoc) toUsage() MachineUsage {
+	usage := MachineUsage{
+		MachineId:   doc.MachineId,
+		CPUPercent:  doc.CPUPercent,
+		MemoryUsed:  doc.MemoryUsed,
+		MemoryTotal: doc.MemoryTotal,
+		DiskUsed:    doc.DiskUsed,
+		DiskTotal:   doc.DiskTotal,
+		Received:    doc.Received,
+		Transmitted: doc.Transmitted,
+		Updated:     doc.Updated.UTC(),
+	}
+	if !doc.TrafficSince.IsZero() {
+		usage.TrafficSince = doc.TrafficSince.UTC()
+	}
+	for _, unit := range doc.Units {
+		usage.Units = append(usage.Units, UnitUsage{
+			Unit:       unit.Unit,
+			CPUPercent: unit.CPUPercent,
+			MemoryUsed: unit.MemoryUsed,
+			DiskUsed:   unit.DiskUsed,
+		})
+	}
+	return usage
+}
+
+// SetUsage records the given resource usage of the machine, replacing
+// any usage previously recorded, and adds the given traffic to the
+// machine's accounted traffic. The machine id and update time of the
+// usage are set by the machine.
+func (m *Machine) SetUsage(usage MachineUsage) error {
+	now := nowToTheSecond()
+	var units []unitUsageDoc
+	for _, unit := range usage.Units {
+		units = append(units, unitUsageDoc{
+			Unit:       unit.Unit,
+			CPUPercent: unit.CPUPercent,
+			MemoryUsed: unit.MemoryUsed,
+			DiskUsed:   unit.DiskUsed,
+		})
+	}
+	update := bson.D{
+		{"$set", bson.D{
+			{"model-uuid", m.st.ModelUUID()},
+			{"machine-id", m.Id()},
+			{"cpu-percent", usage.CPUPercent},
+			{"memory-used", usage.MemoryUsed},
+			{"memory-total", usage.MemoryTotal},
+			{"disk-used", usage.DiskUsed},
+			{"disk-total", usage.DiskTotal},
+			{"units", units},
+			{"updated", now},
+		}},
+		{"$inc", bson.D{
+			{"received", usage.Received},
+			{"transmitted", usage.Transmitted},
+		}},
+	}
+	if usage.Received > 0 || usage.Transmitted > 0 {
+		// Traffic is accounted from the first time any is set.
+		update = append(update, bson.DocElem{Name: "$min", Value: bson.D{{"traffic-since", now}}})
+	}
+
+	usageColl, closer := m.st.getCollection(machineUsageC)
+	defer closer()
+	_, err := usageColl.Writeable().UpsertId(m.st.docID(m.Id()), update)
+	return errors.Annotatef(err, "cannot set usage of machine %s", m.Id())
+}
+
+// Usage returns the resource usage last recorded for the machine.
+func (m *Machine) Usage() (MachineUsage, error) {
+	usageColl, closer := m.st.getCollection(machineUsageC)
+	defer closer()
+
+	var doc machineUsageDoc
+	err := usageColl.FindId(m.Id()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return MachineUsage{}, errors.NotFoundf("usage of machine %s", m.Id())
+	} else if err != nil {
+		return MachineUsage{}, errors.Annotatef(err, "cannot get usage of machine %s", m.Id())
+	}
+	return doc.toUsage(), nil
+}
+
+// AllMachineUsage returns the resource usage last recorded for each
+// machine in the model which has reported its usage.
+func (st *State) AllMachineUsage() ([]MachineUsage, error) {
+	usageColl, closer := st.getCollection(machineUsageC)
+	defer closer()
+
+	var docs []machineUsageDoc
+	if err := usageColl.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get machine usage")
+	}
+	usage := make([]MachineUsage, len(docs))
+	for i, doc := range docs {
+		usage[i] = doc.toUsage()
+	}
+	return usage, nil
+}
+
+// removeMachineUsage removes the resource usage recorded for the
+// machine with the given id, if any.
+func removeMachineUsage(st *State, machineId string) error {
+	usageColl, closer := st.getCollection(machineUsageC)
+	defer closer()
+	err := usageColl.Writeable().RemoveId(st.docID(machineId))
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotatef(err, "cannot remove usage of machine %s", machineId)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type MachineUsageSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&MachineUsageSuite{})
+
+func (s *MachineUsageSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *MachineUsageSuite) TestUsageNotFound(c *gc.C) {
+	_, err := s.machine.Usage()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "usage of machine 0 not found")
+}
+
+func (s *MachineUsageSuite) TestSetUsage(c *gc.C) {
+	for _, cpu := range []float64{12.5, 50} {
+		err := s.machine.SetUsage(state.MachineUsage{
+			CPUPercent:  cpu,
+			MemoryUsed:  1 << 30,
+			MemoryTotal: 4 << 30,
+			DiskUsed:    10 << 30,
+			DiskTotal:   40 << 30,
+			Units: []state.UnitUsage{{
+				Unit:       "mysql/0",
+				CPUPercent: 5,
+				MemoryUsed: 512 << 20,
+				DiskUsed:   20 << 20,
+			}},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+
+		usage, err := s.machine.Usage()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(usage.Updated.IsZero(), jc.IsFalse)
+		usage.Updated = usage.Updated.UTC()
+		c.Check(usage, jc.DeepEquals, state.MachineUsage{
+			MachineId:   s.machine.Id(),
+			CPUPercent:  cpu,
+			MemoryUsed:  1 << 30,
+			MemoryTotal: 4 << 30,
+			DiskUsed:    10 << 30,
+			DiskTotal:   40 << 30,
+			Units: []state.UnitUsage{{
+				Unit:       "mysql/0",
+				CPUPercent: 5,
+				MemoryUsed: 512 << 20,
+				DiskUsed:   20 << 20,
+			}},
+			Updated: usage.Updated,
+		})
+	}
+}
+
+func (s *MachineUsageSuite) TestSetUsageAccumulatesTraffic(c *gc.C) {
+	err := s.machine.SetUsage(state.MachineUsage{CPUPercent: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	usage, err := s.machine.Usage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.TrafficSince.IsZero(), jc.IsTrue)
+
+	err = s.machine.SetUsage(state.MachineUsage{Received: 1000, Transmitted: 200})
+	c.Assert(err, jc.ErrorIsNil)
+	usage, err = s.machine.Usage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.TrafficSince.IsZero(), jc.IsFalse)
+	since := usage.TrafficSince
+
+	err = s.machine.SetUsage(state.MachineUsage{CPUPercent: 2, Received: 500, Transmitted: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	usage, err = s.machine.Usage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.CPUPercent, gc.Equals, 2.0)
+	c.Check(usage.Received, gc.Equals, uint64(1500))
+	c.Check(usage.Transmitted, gc.Equals, uint64(250))
+	c.Check(usage.TrafficSince, gc.Equals, since)
+}
+
+func (s *MachineUsageSuite) TestAllMachineUsage(c *gc.C) {
+	other := s.Factory.MakeMachine(c, nil)
+	c.Assert(s.machine.SetUsage(state.MachineUsage{CPUPercent: 1}), jc.ErrorIsNil)
+	c.Assert(other.SetUsage(state.MachineUsage{CPUPercent: 2}), jc.ErrorIsNil)
+
+	// Usage of machines in other models is not included.
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m := factory.NewFactory(st).MakeMachine(c, nil)
+	c.Assert(m.SetUsage(state.MachineUsage{CPUPercent: 3}), jc.ErrorIsNil)
+
+	usage, err := s.State.AllMachineUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.HasLen, 2)
+	cpu := map[string]float64{}
+	for _, u := range usage {
+		cpu[u.MachineId] = u.CPUPercent
+	}
+	c.Assert(cpu, jc.DeepEquals, map[string]float64{
+		s.machine.Id(): 1,
+		other.Id():     2,
+	})
+}
+
+func (s *MachineUsageSuite) TestRemoveMachineRemovesUsage(c *gc.C) {
+	c.Assert(s.machine.SetUsage(state.MachineUsage{CPUPercent: 1}), jc.ErrorIsNil)
+	c.Assert(s.machine.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(s.machine.Remove(), jc.ErrorIsNil)
+
+	usage, err := s.State.AllMachineUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.HasLen, 0)
+}
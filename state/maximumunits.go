@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// MaxUnits returns the maximum units count for the application. Zero
+// means that the number of units is not limited.
+func (s *Application) MaxUnits() int {
+	return s.doc.MaxUnits
+}
+
+// SetMaxUnits changes the maximum number of units allowed for the
+// application. Setting it to zero removes the limit. The maximum may
+// not be lower than the application's current minimum units, nor lower
+// than the number of units the application currently has.
+func (s *Application) SetMaxUnits(maxUnits int) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set maximum units for application %q", s)
+	defer func() {
+		if err == nil {
+			s.doc.MaxUnits = maxUnits
+		}
+	}()
+	if maxUnits < 0 {
+		return errors.New("cannot set a negative maximum number of units")
+	}
+	if s.doc.Subordinate {
+		return errors.New("application is a subordinate")
+	}
+	service := &Application{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := service.Refresh(); err != nil {
+				return nil, err
+			}
+		}
+		if service.doc.Life != Alive {
+			return nil, errors.New("application is no longer alive")
+		}
+		if maxUnits == service.doc.MaxUnits {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if maxUnits > 0 {
+			if maxUnits < service.doc.MinUnits {
+				return nil, errors.Errorf(
+					"maximum units %d is less than minimum units %d",
+					maxUnits, service.doc.MinUnits,
+				)
+			}
+			if maxUnits < service.doc.UnitCount {
+				return nil, errors.Errorf(
+					"maximum units %d is less than the current %d units",
+					maxUnits, service.doc.UnitCount,
+				)
+			}
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: service.doc.DocID,
+			Assert: append(isAliveDoc,
+				bson.DocElem{"minunits", service.doc.MinUnits},
+				bson.DocElem{"unitcount", service.doc.UnitCount},
+			),
+			Update: bson.D{{"$set", bson.D{{"maxunits", maxUnits}}}},
+		}}, nil
+	}
+	return s.st.run(buildTxn)
+}
+
+// addUnitMaxUnitsAssert returns the assertions required to ensure that
+// a unit can be added to an application limited to maxUnits units.
+func addUnitMaxUnitsAssert(maxUnits int) bson.D {
+	if maxUnits == 0 {
+		return bson.D{maxUnitsUnchangedAssert(0)}
+	}
+	return bson.D{
+		maxUnitsUnchangedAssert(maxUnits),
+		{"unitcount", bson.D{{"$lt", maxUnits}}},
+	}
+}
+
+// maxUnitsUnchangedAssert returns an assertion that the application's
+// maximum units are still maxUnits. Application documents written before
+// maximum units were introduced have no such field, which is equivalent
+// to a zero value.
+func maxUnitsUnchangedAssert(maxUnits int) bson.DocElem {
+	if maxUnits == 0 {
+		return bson.DocElem{"maxunits", bson.D{{"$in", []interface{}{0, nil}}}}
+	}
+	return bson.DocElem{"maxunits", maxUnits}
+}
@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type MaxUnitsSuite struct {
+	ConnSuite
+	service *state.Application
+}
+
+var _ = gc.Suite(&MaxUnitsSuite{})
+
+func (s *MaxUnitsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.service = s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+}
+
+func (s *MaxUnitsSuite) TestSetMaxUnits(c *gc.C) {
+	c.Assert(s.service.MaxUnits(), gc.Equals, 0)
+	for _, input := range []int{3, 5, 0} {
+		err := s.service.SetMaxUnits(input)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(s.service.MaxUnits(), gc.Equals, input)
+		c.Assert(s.service.Refresh(), jc.ErrorIsNil)
+		c.Assert(s.service.MaxUnits(), gc.Equals, input)
+	}
+}
+
+func (s *MaxUnitsSuite) TestInvalidMaxUnits(c *gc.C) {
+	err := s.service.SetMaxUnits(-1)
+	c.Assert(err, gc.ErrorMatches, `cannot set maximum units for application "dummy-application": cannot set a negative maximum number of units`)
+}
+
+func (s *MaxUnitsSuite) TestMaxUnitsLessThanMinUnits(c *gc.C) {
+	err := s.service.SetMinUnits(3)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetMaxUnits(2)
+	c.Assert(err, gc.ErrorMatches, `cannot set maximum units for application "dummy-application": maximum units 2 is less than minimum units 3`)
+	c.Assert(s.service.MaxUnits(), gc.Equals, 0)
+}
+
+func (s *MaxUnitsSuite) TestMinUnitsGreaterThanMaxUnits(c *gc.C) {
+	err := s.service.SetMaxUnits(2)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetMinUnits(3)
+	c.Assert(err, gc.ErrorMatches, `cannot set minimum units for application "dummy-application": minimum units 3 is greater than maximum units 2`)
+	c.Assert(s.service.MinUnits(), gc.Equals, 0)
+}
+
+func (s *MaxUnitsSuite) TestMaxUnitsLessThanUnitCount(c *gc.C) {
+	for i := 0; i < 2; i++ {
+		_, err := s.service.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.service.SetMaxUnits(1)
+	c.Assert(err, gc.ErrorMatches, `cannot set maximum units for application "dummy-application": maximum units 1 is less than the current 2 units`)
+}
+
+func (s *MaxUnitsSuite) TestMaxUnitsSubordinate(c *gc.C) {
+	logging := s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	err := logging.SetMaxUnits(1)
+	c.Assert(err, gc.ErrorMatches, `cannot set maximum units for application "logging": application is a subordinate`)
+}
+
+func (s *MaxUnitsSuite) TestAddUnitRespectsMaxUnits(c *gc.C) {
+	err := s.service.SetMaxUnits(2)
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 2; i++ {
+		_, err := s.service.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err = s.service.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "dummy-application": application already has the maximum of 2 units`)
+}
+
+func (s *MaxUnitsSuite) TestAddUnitMaxUnitsRace(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.State, func() {
+		service, err := s.State.Application(s.service.Name())
+		c.Assert(err, jc.ErrorIsNil)
+		err = service.SetMaxUnits(1)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = service.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.service.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "dummy-application": application already has the maximum of 1 units`)
+}
+
+func (s *MaxUnitsSuite) TestEnsureMinUnitsWithinMaxUnits(c *gc.C) {
+	err := s.service.SetMaxUnits(3)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetMinUnits(3)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.EnsureMinUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := s.service.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 3)
+}
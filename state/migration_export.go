@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/description"
 )
 
@@ -23,10 +24,19 @@ func (st *State) Export() (description.Model, error) {
 		return nil, errors.Trace(err)
 	}
 
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading controller config")
+	}
+
 	export := exporter{
-		st:      st,
-		dbModel: dbModel,
-		logger:  loggo.GetLogger("juju.state.export-model"),
+		st:               st,
+		dbModel:          dbModel,
+		controllerConfig: controllerConfig,
+		logger:           loggo.GetLogger("juju.state.export-model"),
+	}
+	if maxAge := controllerConfig.MigrationHistoryMaxAge(); maxAge > 0 {
+		export.historyCutoff = st.clock.Now().Add(-maxAge)
 	}
 	if err := export.readAllStatuses(); err != nil {
 		return nil, errors.Annotate(err, "reading statuses")
@@ -43,6 +53,9 @@ func (st *State) Export() (description.Model, error) {
 	if err := export.readAllConstraints(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.readAllCloudInit(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	modelConfig, found := export.modelSettings[modelGlobalKey]
 	if !found {
@@ -73,6 +86,7 @@ func (st *State) Export() (description.Model, error) {
 		return nil, errors.Trace(err)
 	}
 	export.model.SetConstraints(constraintsArgs)
+	export.model.SetCloudInit(export.cloudInitArgs(modelGlobalKey))
 
 	if err := export.modelUsers(); err != nil {
 		return nil, errors.Trace(err)
@@ -112,6 +126,10 @@ func (st *State) Export() (description.Model, error) {
 		return nil, errors.Trace(err)
 	}
 
+	if err := export.logs(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if err := export.model.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -122,13 +140,19 @@ func (st *State) Export() (description.Model, error) {
 }
 
 type exporter struct {
-	st      *State
-	dbModel *Model
-	model   description.Model
-	logger  loggo.Logger
+	st               *State
+	dbModel          *Model
+	controllerConfig controller.Config
+	model            description.Model
+	logger           loggo.Logger
+
+	// historyCutoff is the time before which log records and status
+	// history are not exported. It is zero if there is no limit.
+	historyCutoff time.Time
 
 	annotations   map[string]annotatorDoc
 	constraints   map[string]bson.M
+	cloudInit     map[string]cloudInitDoc
 	modelSettings map[string]settingsDoc
 	status        map[string]bson.M
 	statusHistory map[string][]historicalStatusDoc
@@ -161,11 +185,18 @@ func (e *exporter) readBlocks() (map[string]string, error) {
 		return nil, errors.Trace(err)
 	}
 
+	modelTag := e.st.ModelTag().String()
 	result := make(map[string]string)
 	for _, doc := range docs {
-		// We don't care about the id, uuid, or tag.
-		// The uuid and tag both refer to the model uuid, and the
-		// id is opaque - even though it is sequence generated.
+		// We don't care about the id or uuid. The uuid refers to
+		// the model uuid, and the id is opaque - even though it is
+		// sequence generated. Blocks on individual entities cannot
+		// be represented in the model description, and are left
+		// behind.
+		if doc.Tag != modelTag {
+			e.logger.Warningf("block %v on %s not migrated", doc.Type, doc.Tag)
+			continue
+		}
 		result[doc.Type.MigrationValue()] = doc.Message
 	}
 	return result, nil
@@ -375,6 +406,7 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 		return nil, errors.Trace(err)
 	}
 	exMachine.SetConstraints(constraintsArgs)
+	exMachine.SetCloudInit(e.cloudInitArgs(globalKey))
 
 	return exMachine, nil
 }
@@ -521,12 +553,15 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 		CharmModifiedVersion: application.doc.CharmModifiedVersion,
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
+		ExposedEndpoints:     application.ExposedEndpoints(),
 		MinUnits:             application.doc.MinUnits,
+		MaxUnits:             application.doc.MaxUnits,
 		Settings:             applicationSettingsDoc.Settings,
 		SettingsRefCount:     refCount,
 		Leader:               leader,
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   application.doc.MetricCredentials,
+		HookSandbox:          description.HookSandboxArgs(application.HookSandbox()),
 	}
 	exApplication := e.model.AddApplication(args)
 	// Find the current application status.
@@ -629,9 +664,12 @@ func (e *exporter) relations() error {
 	}
 
 	for _, relation := range rels {
+		placement := relation.SubordinatePlacement()
 		exRelation := e.model.AddRelation(description.RelationArgs{
-			Id:  relation.Id(),
-			Key: relation.String(),
+			Id:                 relation.Id(),
+			Key:                relation.String(),
+			ExcludeControllers: placement.ExcludeControllers,
+			ExcludeMachines:    placement.ExcludeMachines,
 		})
 		for _, ep := range relation.Endpoints() {
 			exEndPoint := exRelation.AddEndpoint(description.EndpointArgs{
@@ -793,6 +831,42 @@ func (e *exporter) actions() error {
 	return nil
 }
 
+// logs exports the model's most recent log records, if the controller
+// is configured to migrate them.
+func (e *exporter) logs() error {
+	if !e.controllerConfig.MigrateLogs() {
+		return nil
+	}
+	session, logsColl := initLogsSession(e.st)
+	defer session.Close()
+
+	query := bson.D{{"e", e.st.ModelUUID()}}
+	if !e.historyCutoff.IsZero() {
+		query = append(query, bson.DocElem{"t", bson.D{{"$gte", e.historyCutoff.UnixNano()}}})
+	}
+	var docs []logDoc
+	err := logsColl.Find(query).Sort("-t", "-_id").Limit(e.controllerConfig.MigrationLogsMaxRecords()).All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "failed to read log records")
+	}
+	e.logger.Debugf("read %d log records", len(docs))
+
+	// The most recent records were read; add them oldest first.
+	for i := len(docs) - 1; i >= 0; i-- {
+		doc := docs[i]
+		e.model.AddLogRecord(description.LogRecordArgs{
+			Time:     time.Unix(0, doc.Time).UTC(),
+			Entity:   doc.Entity,
+			Version:  doc.Version,
+			Module:   doc.Module,
+			Location: doc.Location,
+			Level:    loggo.Level(doc.Level).String(),
+			Message:  doc.Message,
+		})
+	}
+	return nil
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.getCollection(relationScopesC)
 	defer closer()
@@ -879,6 +953,36 @@ func (e *exporter) readAllAnnotations() error {
 	return nil
 }
 
+func (e *exporter) readAllCloudInit() error {
+	cloudInit, closer := e.st.getCollection(cloudInitC)
+	defer closer()
+
+	var docs []cloudInitDoc
+	if err := cloudInit.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "failed to read cloud-init collection")
+	}
+	e.logger.Debugf("read %d cloud-init docs", len(docs))
+
+	e.cloudInit = make(map[string]cloudInitDoc)
+	for _, doc := range docs {
+		e.cloudInit[e.st.localID(doc.DocID)] = doc
+	}
+	return nil
+}
+
+func (e *exporter) cloudInitArgs(globalKey string) description.CloudInitArgs {
+	doc, found := e.cloudInit[globalKey]
+	if !found {
+		return description.CloudInitArgs{}
+	}
+	return description.CloudInitArgs{
+		Packages:  doc.Packages,
+		AptMirror: doc.AptMirror,
+		RunCmds:   doc.RunCmds,
+		SSHCAKeys: doc.SSHCAKeys,
+	}
+}
+
 func (e *exporter) readAllConstraints() error {
 	constraintsCollection, closer := e.st.getCollection(constraintsC)
 	defer closer()
@@ -968,7 +1072,11 @@ func (e *exporter) readAllStatusHistory() error {
 	// In tests, sorting by time can leave the results
 	// underconstrained - include document id for deterministic
 	// ordering in those cases.
-	iter := statuses.Find(nil).Sort("-updated", "-_id").Iter()
+	var query bson.D
+	if !e.historyCutoff.IsZero() {
+		query = bson.D{{"updated", bson.D{{"$gte", e.historyCutoff.UnixNano()}}}}
+	}
+	iter := statuses.Find(query).Sort("-updated", "-_id").Iter()
 	defer iter.Close()
 	for iter.Next(&doc) {
 		history := e.statusHistory[doc.GlobalKey]
@@ -4,9 +4,11 @@
 package state_test
 
 import (
+	"fmt"
 	"math/rand"
 	"time"
 
+	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -66,6 +68,15 @@ func (s *MigrationSuite) primeStatusHistory(c *gc.C, entity statusSetter, status
 	}, 0)
 }
 
+func (s *MigrationSuite) writeLogs(c *gc.C, times ...time.Time) {
+	logger := state.NewDbLogger(s.State, names.NewMachineTag("0"), version.MustParse("2.0.1"))
+	defer logger.Close()
+	for i, t := range times {
+		err := logger.Log(t, "juju.test", "test.go:42", loggo.INFO, fmt.Sprintf("message %d", i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
 func (s *MigrationSuite) makeApplicationWithLeader(c *gc.C, applicationname string, count int, leader int) {
 	c.Assert(leader < count, jc.IsTrue)
 	units := make([]*state.Unit, count)
@@ -674,6 +685,33 @@ func (s *MigrationExportSuite) TestSSHHostKeys(c *gc.C) {
 	c.Assert(key.Keys(), jc.DeepEquals, []string{"bam", "mam"})
 }
 
+func (s *MigrationExportSuite) TestCloudInit(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := s.State.SetCloudInitCustomization(s.State.ModelTag(), state.CloudInitCustomization{
+		Packages:  []string{"htop"},
+		AptMirror: "http://mirror.example.com/ubuntu",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetCloudInitCustomization(machine.Tag(), state.CloudInitCustomization{
+		RunCmds: []string{"touch /tmp/provisioned"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cloudInit := model.CloudInit()
+	c.Assert(cloudInit, gc.NotNil)
+	c.Assert(cloudInit.Packages(), jc.DeepEquals, []string{"htop"})
+	c.Assert(cloudInit.AptMirror(), gc.Equals, "http://mirror.example.com/ubuntu")
+
+	machines := model.Machines()
+	c.Assert(machines, gc.HasLen, 1)
+	cloudInit = machines[0].CloudInit()
+	c.Assert(cloudInit, gc.NotNil)
+	c.Assert(cloudInit.RunCmds(), jc.DeepEquals, []string{"touch /tmp/provisioned"})
+}
+
 func (s *MigrationExportSuite) TestActions(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
@@ -693,6 +731,65 @@ func (s *MigrationExportSuite) TestActions(c *gc.C) {
 	c.Check(action.Message(), gc.Equals, "")
 }
 
+func (s *MigrationExportSuite) TestLogsNotExportedByDefault(c *gc.C) {
+	s.writeLogs(c, time.Now())
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.LogRecords(), gc.HasLen, 0)
+}
+
+func (s *MigrationExportSuite) TestLogs(c *gc.C) {
+	err := state.UpdateControllerConfig(s.State, map[string]interface{}{
+		"migrate-logs":               true,
+		"migration-logs-max-records": 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now().Truncate(time.Millisecond)
+	s.writeLogs(c, now.Add(-3*time.Minute), now.Add(-2*time.Minute), now.Add(-time.Minute))
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the most recent records are exported, oldest first.
+	records := model.LogRecords()
+	c.Assert(records, gc.HasLen, 2)
+	for i, record := range records {
+		c.Check(record.Time(), gc.Equals, now.Add(time.Duration(i-2)*time.Minute).UTC())
+		c.Check(record.Entity(), gc.Equals, "machine-0")
+		c.Check(record.Version(), gc.Equals, "2.0.1")
+		c.Check(record.Module(), gc.Equals, "juju.test")
+		c.Check(record.Location(), gc.Equals, "test.go:42")
+		c.Check(record.Level(), gc.Equals, "INFO")
+		c.Check(record.Message(), gc.Equals, fmt.Sprintf("message %d", i+1))
+	}
+}
+
+func (s *MigrationExportSuite) TestHistoryMaxAge(c *gc.C) {
+	err := state.UpdateControllerConfig(s.State, map[string]interface{}{
+		"migrate-logs":              true,
+		"migration-history-max-age": "1h",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	s.writeLogs(c, now.Add(-2*time.Hour), now.Add(-time.Minute))
+
+	machine := s.Factory.MakeMachine(c, nil)
+	s.primeStatusHistory(c, machine, status.Started, addedHistoryCount)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	records := model.LogRecords()
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Message(), gc.Equals, "message 1")
+
+	// Recent status history is still exported.
+	machines := model.Machines()
+	c.Assert(machines, gc.HasLen, 1)
+	c.Check(machines[0].StatusHistory(), gc.Not(gc.HasLen), 0)
+}
+
 type goodToken struct{}
 
 // Check implements leadership.Token
@@ -92,12 +92,20 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := newSt.SetModelConstraints(restore.constraints(model.Constraints())); err != nil {
 		return nil, nil, errors.Annotate(err, "model constraints")
 	}
+	if cloudInit := model.CloudInit(); cloudInit != nil {
+		if err := newSt.SetCloudInitCustomization(newSt.ModelTag(), restore.cloudInit(cloudInit)); err != nil {
+			return nil, nil, errors.Annotate(err, "model cloud-init customization")
+		}
+	}
 	if err := restore.sshHostKeys(); err != nil {
 		return nil, nil, errors.Annotate(err, "sshHostKeys")
 	}
 	if err := restore.actions(); err != nil {
 		return nil, nil, errors.Annotate(err, "actions")
 	}
+	if err := restore.logs(); err != nil {
+		return nil, nil, errors.Annotate(err, "logs")
+	}
 
 	if err := restore.modelUsers(); err != nil {
 		return nil, nil, errors.Annotate(err, "modelUsers")
@@ -149,6 +157,40 @@ type importer struct {
 	applicationUnits map[string][]*Unit
 }
 
+// logs writes the log records included with the model, if any, to
+// the controller's logs collection.
+func (i *importer) logs() error {
+	records := i.model.LogRecords()
+	if len(records) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(records))
+	for n, record := range records {
+		level, ok := loggo.ParseLevel(record.Level())
+		if !ok {
+			return errors.Errorf("log record %d: unrecognized level %q", n, record.Level())
+		}
+		docs[n] = &logDoc{
+			Id:        bson.NewObjectId(),
+			Time:      record.Time().UnixNano(),
+			ModelUUID: i.st.ModelUUID(),
+			Entity:    record.Entity(),
+			Version:   record.Version(),
+			Module:    record.Module(),
+			Location:  record.Location(),
+			Level:     int(level),
+			Message:   record.Message(),
+		}
+	}
+	session, logsColl := initLogsSession(i.st)
+	defer session.Close()
+	if err := logsColl.Insert(docs...); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("imported %d log records", len(docs))
+	return nil
+}
+
 func (i *importer) modelExtras() error {
 	if latest := i.model.LatestToolsVersion(); latest != version.Zero {
 		if err := i.dbModel.UpdateLatestToolsVersion(latest); err != nil {
@@ -336,6 +378,11 @@ func (i *importer) machine(m description.Machine) error {
 	if err := i.importMachineBlockDevices(machine, m); err != nil {
 		return errors.Trace(err)
 	}
+	if cloudInit := m.CloudInit(); cloudInit != nil {
+		if err := i.st.SetCloudInitCustomization(machine.Tag(), i.cloudInit(cloudInit)); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// Now that this machine exists in the database, process each of the
 	// containers in this machine.
@@ -732,8 +779,11 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		ExposedEndpoints:     exposedEndpointDocs(s.ExposedEndpoints()),
 		MinUnits:             s.MinUnits(),
+		MaxUnits:             s.MaxUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
+		HookSandbox:          newHookSandboxDoc(HookSandbox(s.HookSandbox())),
 	}, nil
 }
 
@@ -848,6 +898,12 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 		Endpoints: make([]Endpoint, len(endpoints)),
 		Life:      Alive,
 	}
+	if rel.ExcludeControllers() || len(rel.ExcludeMachines()) > 0 {
+		doc.SubordinatePlacement = &subordinatePlacementDoc{
+			ExcludeControllers: rel.ExcludeControllers(),
+			ExcludeMachines:    rel.ExcludeMachines(),
+		}
+	}
 	for i, ep := range endpoints {
 		doc.Endpoints[i] = Endpoint{
 			ApplicationName: ep.ApplicationName(),
@@ -1146,6 +1202,15 @@ func (i *importer) importStatusHistory(globalKey string, history []description.S
 	return nil
 }
 
+func (i *importer) cloudInit(cloudInit description.CloudInit) CloudInitCustomization {
+	return CloudInitCustomization{
+		Packages:  cloudInit.Packages(),
+		AptMirror: cloudInit.AptMirror(),
+		RunCmds:   cloudInit.RunCmds(),
+		SSHCAKeys: cloudInit.SSHCAKeys(),
+	}
+}
+
 func (i *importer) constraints(cons description.Constraints) constraints.Value {
 	var result constraints.Value
 	if cons == nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
@@ -320,7 +321,10 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	err = application.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
 	// Expose the application.
-	c.Assert(application.SetExposed(), jc.ErrorIsNil)
+	err = application.MergeExposeSettings(map[string][]string{
+		"": {"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(application, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, application, status.StatusActive, 5)
@@ -341,6 +345,7 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(imported.ApplicationTag(), gc.Equals, exported.ApplicationTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.ExposedEndpoints(), jc.DeepEquals, exported.ExposedEndpoints())
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -382,6 +387,24 @@ func (s *MigrationImportSuite) TestApplicationLeaders(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestApplicationHookSandbox(c *gc.C) {
+	application := state.AddTestingService(c, s.State, "wordpress", state.AddTestingCharm(c, s.State, "wordpress"))
+	sandbox := state.HookSandbox{
+		User:         "hooks",
+		MemoryLimit:  512,
+		CPUQuota:     50,
+		LXDContainer: "hook-jail",
+	}
+	err := application.SetHookSandbox(sandbox)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	newApplication, err := newSt.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newApplication.HookSandbox(), gc.Equals, sandbox)
+}
+
 func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	s.assertUnitsMigrated(c, constraints.MustParse("arch=amd64 mem=8G"))
 }
@@ -484,6 +507,27 @@ func (s *MigrationImportSuite) TestRelations(c *gc.C) {
 	c.Assert(settings.Map(), gc.DeepEquals, relSettings)
 }
 
+func (s *MigrationImportSuite) TestRelationSubordinatePlacement(c *gc.C) {
+	state.AddTestingService(c, s.State, "wordpress", state.AddTestingCharm(c, s.State, "wordpress"))
+	state.AddTestingService(c, s.State, "logging", state.AddTestingCharm(c, s.State, "logging"))
+	eps, err := s.State.InferEndpoints("logging", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	placement := state.SubordinatePlacement{
+		ExcludeControllers: true,
+		ExcludeMachines:    []string{"1"},
+	}
+	err = rel.SetSubordinatePlacement(placement)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	newRel, err := newSt.KeyRelation(rel.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newRel.SubordinatePlacement(), jc.DeepEquals, placement)
+}
+
 func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, nil)
 	err := unit.OpenPorts("tcp", 1234, 2345)
@@ -700,6 +744,30 @@ func (s *MigrationImportSuite) TestSSHHostKey(c *gc.C) {
 	c.Assert(keys, jc.DeepEquals, state.SSHHostKeys{"bam", "mam"})
 }
 
+func (s *MigrationImportSuite) TestCloudInit(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	modelCloudInit := state.CloudInitCustomization{
+		Packages:  []string{"htop"},
+		AptMirror: "http://mirror.example.com/ubuntu",
+	}
+	err := s.State.SetCloudInitCustomization(s.State.ModelTag(), modelCloudInit)
+	c.Assert(err, jc.ErrorIsNil)
+	machineCloudInit := state.CloudInitCustomization{
+		RunCmds: []string{"touch /tmp/provisioned"},
+	}
+	err = s.State.SetCloudInitCustomization(machine.Tag(), machineCloudInit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	cloudInit, err := newSt.CloudInitCustomization(newSt.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cloudInit, jc.DeepEquals, modelCloudInit)
+	cloudInit, err = newSt.CloudInitCustomization(machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cloudInit, jc.DeepEquals, machineCloudInit)
+}
+
 func (s *MigrationImportSuite) TestAction(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
@@ -720,6 +788,29 @@ func (s *MigrationImportSuite) TestAction(c *gc.C) {
 	c.Check(action.Status(), gc.Equals, state.ActionPending)
 }
 
+func (s *MigrationImportSuite) TestLogs(c *gc.C) {
+	err := state.UpdateControllerConfig(s.State, map[string]interface{}{
+		"migrate-logs": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	s.writeLogs(c, now.Add(-time.Minute), now)
+
+	_, newSt := s.importModel(c)
+
+	logsColl := s.State.MongoSession().DB("logs").C("logs")
+	count, err := logsColl.Find(bson.M{"e": newSt.ModelUUID()}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+
+	// Aborting the import removes the imported log records.
+	err = newSt.RemoveImportingModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = logsColl.Find(bson.M{"e": newSt.ModelUUID()}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
+
 func (s *MigrationImportSuite) TestVolumes(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Volumes: []state.MachineVolumeParams{{
@@ -35,6 +35,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		machinesC,
 		openedPortsC,
 
+		// cloud-init customizations of the model and its machines
+		cloudInitC,
+
 		// service / unit
 		leasesC,
 		applicationsC,
@@ -86,6 +89,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guimetadataC,
 		// This is controller global, not migrated.
 		guisettingsC,
+		// Default constraints are controller global, not migrated.
+		defaultConstraintsC,
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
@@ -101,6 +106,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// upgradeInfoC is used to coordinate upgrades and schema migrations,
 		// and aren't needed for model migrations.
 		upgradeInfoC,
+		// Schema versions are controller-wide.
+		schemaVersionsC,
 		// Not exported, but the tools will possibly need to be either bundled
 		// with the representation or sent separately.
 		toolsmetadataC,
@@ -136,6 +143,59 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// separately.
 		modelEntityRefsC,
 
+		// Config snapshots are a history of config changes made
+		// in the source controller, and are not migrated.
+		configSnapshotsC,
+
+		// Firewall rules are not yet part of the model description;
+		// they must be set again on the migrated model.
+		firewallRulesC,
+
+		// Placement counts only serialise concurrent assignments,
+		// and start again from nothing in the migrated model.
+		placementsC,
+
+		// Resource usage is transient, and is reported again by the
+		// machine agents once the model has been migrated. Network
+		// traffic is accounted for by the controller that recorded
+		// it; accounting starts afresh after migration.
+		machineUsageC,
+
+		// Cached instance types are fetched again from the provider
+		// by the migrated model.
+		instanceTypesC,
+
+		// The event timeline records what happened in the source
+		// controller, and is not migrated.
+		modelEventsC,
+
+		// Agent logging settings are not yet part of the model
+		// description; they must be set again on the migrated model.
+		agentLoggingC,
+
+		// Alert rules are not yet part of the model description;
+		// they must be added again on the migrated model, and the
+		// alerts they raised are raised again by its controller.
+		alertRulesC,
+		alertsC,
+
+		// Hook timings are a diagnostic history kept by the source
+		// controller, and are not migrated.
+		hookTimingsC,
+
+		// A canary upgrade in progress is not migrated; it must be
+		// started again on the migrated model.
+		canaryUpgradesC,
+
+		// Series upgrades in progress are not migrated; they must be
+		// finished before the model is migrated.
+		upgradeSeriesLocksC,
+
+		// Scheduled operations are not yet part of the model
+		// description; they must be scheduled again on the migrated
+		// model.
+		scheduledOperationsC,
+
 		// This is marked as deprecated, and should probably be removed.
 		actionresultsC,
 
@@ -207,6 +267,13 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"CloudRegion",
 		"CloudCredential",
 		"LatestAvailableTools",
+		// Limits are set by the administrators of each
+		// controller, and are not migrated.
+		"MaxMachines",
+		"MaxUnits",
+		// TimeOfDying is always zero, as only live models are
+		// migrated.
+		"TimeOfDying",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -281,6 +348,9 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// Password rotation state isn't migrated; agents in the
+		// target model will be asked to rotate if necessary.
+		"PasswordRotation",
 	)
 	todo := set.NewStrings(
 		"Volumes",
@@ -288,6 +358,7 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"Clean",
 		"Filesystems",
 		"HasVote",
+		"Maintenance",
 	)
 	s.AssertExportedFields(c, machineDoc{}, fields.Union(todo))
 }
@@ -340,8 +411,11 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedEndpoints",
 		"MinUnits",
+		"MaxUnits",
 		"MetricCredentials",
+		"HookSandbox",
 	)
 	s.AssertExportedFields(c, applicationDoc{}, migrated.Union(ignored))
 }
@@ -381,6 +455,8 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		// TxnRevno isn't migrated.
 		"TxnRevno",
 		"PasswordHash",
+		// Password rotation state isn't migrated.
+		"PasswordRotation",
 	)
 	todo := set.NewStrings(
 		"StorageAttachmentCount",
@@ -434,6 +510,7 @@ func (s *MigrationSuite) TestRelationDocFields(c *gc.C) {
 		// UnitCount isn't explicitly exported, but defined by the stored
 		// unit settings data for the relation endpoint.
 		"UnitCount",
+		"SubordinatePlacement",
 	)
 	s.AssertExportedFields(c, relationDoc{}, fields)
 	// We also need to check the Endpoint and nested charm.Relation field.
@@ -653,6 +730,9 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// The correlation id only traces the request that enqueued
+		// the action within the source controller.
+		"CorrelationId",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -59,6 +59,12 @@ func (s *Application) SetMinUnits(minUnits int) (err error) {
 		if minUnits == service.doc.MinUnits {
 			return nil, jujutxn.ErrNoOperations
 		}
+		if service.doc.MaxUnits > 0 && minUnits > service.doc.MaxUnits {
+			return nil, errors.Errorf(
+				"minimum units %d is greater than maximum units %d",
+				minUnits, service.doc.MaxUnits,
+			)
+		}
 		return setMinUnitsOps(service, minUnits), nil
 	}
 	return s.st.run(buildTxn)
@@ -72,7 +78,7 @@ func setMinUnitsOps(service *Application, minUnits int) []txn.Op {
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     state.docID(applicationname),
-		Assert: isAliveDoc,
+		Assert: append(isAliveDoc, maxUnitsUnchangedAssert(service.doc.MaxUnits)),
 		Update: bson.D{{"$set", bson.D{{"minunits", minUnits}}}},
 	}}
 	if service.doc.MinUnits == 0 {
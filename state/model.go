@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
@@ -83,6 +84,14 @@ type modelDoc struct {
 	// LatestAvailableTools is a string representing the newest version
 	// found while checking streams for new versions.
 	LatestAvailableTools string `bson:"available-tools,omitempty"`
+
+	// MaxMachines and MaxUnits hold the model's limits; see
+	// ModelLimits.
+	MaxMachines int `bson:"max-machines,omitempty"`
+	MaxUnits    int `bson:"max-units,omitempty"`
+
+	// TimeOfDying is the time at which the model started dying.
+	TimeOfDying time.Time `bson:"time-of-dying,omitempty"`
 }
 
 // modelEntityRefsDoc records references to the top-level entities
@@ -95,6 +104,9 @@ type modelEntityRefsDoc struct {
 
 	// Applicatons contains the names of the applications in the model.
 	Applications []string `bson:"applications"`
+
+	// Units contains the names of the principal units in the model.
+	Units []string `bson:"units"`
 }
 
 // ControllerModel returns the model that was bootstrapped.
@@ -221,23 +233,16 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 	if err := args.Validate(); err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-	// For now, the model cloud must be the same as the controller cloud.
-	controllerInfo, err := st.ControllerInfo()
+	// The model cloud may be any cloud known to the controller, not
+	// only the cloud that the controller itself is running in.
+	modelCloud, err := st.Cloud(args.CloudName)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-	if controllerInfo.CloudName != args.CloudName {
-		return nil, nil, errors.NewNotValid(
-			nil, fmt.Sprintf("controller cloud %s does not match model cloud %s", controllerInfo.CloudName, args.CloudName))
-	}
 
 	// Ensure that the cloud region is valid, or if one is not specified,
 	// that the cloud does not support regions.
-	controllerCloud, err := st.Cloud(args.CloudName)
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-	assertCloudRegionOp, err := validateCloudRegion(controllerCloud, args.CloudName, args.CloudRegion)
+	assertCloudRegionOp, err := validateCloudRegion(modelCloud, args.CloudName, args.CloudRegion)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -251,7 +256,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		return nil, nil, errors.Trace(err)
 	}
 	assertCloudCredentialOp, err := validateCloudCredential(
-		controllerCloud, args.CloudName, cloudCredentials, args.CloudCredential,
+		modelCloud, args.CloudName, cloudCredentials, args.CloudCredential,
 	)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -265,7 +270,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 
 	uuid := args.Config.UUID()
 	session := st.session.Copy()
-	newSt, err := newState(names.NewModelTag(uuid), session, st.mongoInfo, st.newPolicy)
+	newSt, err := newState(names.NewModelTag(uuid), session, st.mongoInfo, st.newPolicy, st.clock)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "could not create state for new model")
 	}
@@ -906,6 +911,23 @@ func removeModelServiceRefOp(st *State, applicationname string) txn.Op {
 	}
 }
 
+func addModelUnitRefOp(st *State, unitName string) txn.Op {
+	return txn.Op{
+		C:      modelEntityRefsC,
+		Id:     st.ModelUUID(),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"units", unitName}}}},
+	}
+}
+
+func removeModelUnitRefOp(st *State, unitName string) txn.Op {
+	return txn.Op{
+		C:      modelEntityRefsC,
+		Id:     st.ModelUUID(),
+		Update: bson.D{{"$pull", bson.D{{"units", unitName}}}},
+	}
+}
+
 // createModelOp returns the operation needed to create
 // an model document with the given name and UUID.
 func createModelOp(
@@ -815,7 +815,7 @@ var _ = gc.Suite(&ModelCloudValidationSuite{})
 // TODO(axw) concurrency tests when we can modify the cloud definition,
 // and update/remove credentials.
 
-func (s *ModelCloudValidationSuite) TestNewModelCloudNameMismatch(c *gc.C) {
+func (s *ModelCloudValidationSuite) TestNewModelUnknownCloud(c *gc.C) {
 	st, owner := s.initializeState(c, []cloud.Region{{Name: "some-region"}}, []cloud.AuthType{cloud.EmptyAuthType}, nil)
 	defer st.Close()
 	cfg, _ := createTestModelConfig(c, st.ModelUUID())
@@ -825,7 +825,30 @@ func (s *ModelCloudValidationSuite) TestNewModelCloudNameMismatch(c *gc.C) {
 		Owner:     owner,
 		StorageProviderRegistry: storage.StaticProviderRegistry{},
 	})
-	c.Assert(err, gc.ErrorMatches, "controller cloud dummy does not match model cloud another")
+	c.Assert(err, gc.ErrorMatches, `cloud "another" not found`)
+}
+
+func (s *ModelCloudValidationSuite) TestNewModelOtherCloud(c *gc.C) {
+	st, owner := s.initializeState(c, []cloud.Region{{Name: "some-region"}}, []cloud.AuthType{cloud.EmptyAuthType}, nil)
+	defer st.Close()
+	err := st.AddCloud("another", cloud.Cloud{
+		Type:      "dummy",
+		AuthTypes: []cloud.AuthType{cloud.EmptyAuthType},
+		Regions:   []cloud.Region{{Name: "another-region"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, _ := createTestModelConfig(c, st.ModelUUID())
+	model, st2, err := st.NewModel(state.ModelArgs{
+		CloudName:   "another",
+		CloudRegion: "another-region",
+		Config:      cfg,
+		Owner:       owner,
+		StorageProviderRegistry: storage.StaticProviderRegistry{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st2.Close()
+	c.Assert(model.Cloud(), gc.Equals, "another")
+	c.Assert(model.CloudRegion(), gc.Equals, "another-region")
 }
 
 func (s *ModelCloudValidationSuite) TestNewModelUnknownCloudRegion(c *gc.C) {
@@ -0,0 +1,166 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ModelEventKind identifies the kind of a model event.
+type ModelEventKind string
+
+const (
+	// DeployEvent is recorded when an application is deployed.
+	DeployEvent ModelEventKind = "deploy"
+
+	// UpgradeCharmEvent is recorded when an application's charm
+	// is changed.
+	UpgradeCharmEvent ModelEventKind = "upgrade-charm"
+
+	// AddUnitEvent is recorded when units are added to an
+	// application.
+	AddUnitEvent ModelEventKind = "add-unit"
+
+	// ConfigChangeEvent is recorded when an application's
+	// configuration is changed.
+	ConfigChangeEvent ModelEventKind = "config-change"
+
+	// MachineFailureEvent is recorded when a machine enters the
+	// error state.
+	MachineFailureEvent ModelEventKind = "machine-failure"
+
+	// CloudInitChangeEvent is recorded when the cloud-init
+	// customization of the model or a machine is changed.
+	CloudInitChangeEvent ModelEventKind = "cloudinit-change"
+)
+
+// ModelEvent describes a high-level event in the life of a model,
+// such as the deployment of an application.
+type ModelEvent struct {
+	// Kind identifies what happened.
+	Kind ModelEventKind
+
+	// Actor holds the tag of the entity which caused the event. It
+	// is empty for events caused by juju itself.
+	Actor string
+
+	// Entity holds the tag of the entity the event concerns.
+	Entity string
+
+	// Message describes the event.
+	Message string
+
+	// Time records when the event happened.
+	Time time.Time
+}
+
+// modelEventDoc is the document in which a ModelEvent is stored.
+type modelEventDoc struct {
+	ModelUUID string `bson:"model-uuid"`
+	Kind      string `bson:"kind"`
+	Actor     string `bson:"actor"`
+	Entity    string `bson:"entity"`
+	Message   string `bson:"message"`
+	Time      int64  `bson:"time"`
+}
+
+// AddModelEvent records the given event in the model's timeline. If
+// the event's time is zero, the current time is recorded.
+func (st *State) AddModelEvent(event ModelEvent) error {
+	if event.Kind == "" {
+		return errors.NotValidf("empty event kind")
+	}
+	if event.Time.IsZero() {
+		event.Time = st.clock.Now()
+	}
+	doc := &modelEventDoc{
+		Kind:    string(event.Kind),
+		Actor:   event.Actor,
+		Entity:  event.Entity,
+		Message: event.Message,
+		Time:    event.Time.UnixNano(),
+	}
+	events, closer := st.getCollection(modelEventsC)
+	defer closer()
+	if err := events.Writeable().Insert(doc); err != nil {
+		return errors.Annotatef(err, "cannot add %s event", event.Kind)
+	}
+	return nil
+}
+
+// ModelEventFilter selects the events returned by ModelEvents. Its
+// zero value selects all events.
+type ModelEventFilter struct {
+	// From, if not zero, excludes events which happened before it.
+	From time.Time
+
+	// To, if not zero, excludes events which happened at or
+	// after it.
+	To time.Time
+
+	// Kinds, if not empty, excludes events of other kinds.
+	Kinds []ModelEventKind
+
+	// Entity, if not empty, excludes events concerning other
+	// entities.
+	Entity string
+
+	// Limit, if positive, restricts the result to that number of
+	// the most recent events selected.
+	Limit int
+}
+
+// ModelEvents returns the events in the model's timeline selected by
+// the given filter, oldest first.
+func (st *State) ModelEvents(filter ModelEventFilter) ([]ModelEvent, error) {
+	events, closer := st.getSecondaryCollection(modelEventsC)
+	defer closer()
+
+	query := bson.D{}
+	timeRange := bson.D{}
+	if !filter.From.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", filter.From.UnixNano()})
+	}
+	if !filter.To.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lt", filter.To.UnixNano()})
+	}
+	if len(timeRange) > 0 {
+		query = append(query, bson.DocElem{"time", timeRange})
+	}
+	if len(filter.Kinds) > 0 {
+		kinds := make([]string, len(filter.Kinds))
+		for i, kind := range filter.Kinds {
+			kinds[i] = string(kind)
+		}
+		query = append(query, bson.DocElem{"kind", bson.D{{"$in", kinds}}})
+	}
+	if filter.Entity != "" {
+		query = append(query, bson.DocElem{"entity", filter.Entity})
+	}
+
+	// Find the most recent events, so that the limit discards the
+	// oldest; then put them back in order.
+	q := events.Find(query).Sort("-time", "-_id")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var docs []modelEventDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get model events")
+	}
+	result := make([]ModelEvent, len(docs))
+	for i, doc := range docs {
+		result[len(docs)-1-i] = ModelEvent{
+			Kind:    ModelEventKind(doc.Kind),
+			Actor:   doc.Actor,
+			Entity:  doc.Entity,
+			Message: doc.Message,
+			Time:    time.Unix(0, doc.Time).UTC(),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModelEventsSuite struct {
+	ConnSuite
+	clock *coretesting.Clock
+	start time.Time
+}
+
+var _ = gc.Suite(&ModelEventsSuite{})
+
+func (s *ModelEventsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.start = time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.clock = coretesting.NewClock(s.start)
+	state.SetClock(s.State, s.clock)
+}
+
+func (s *ModelEventsSuite) addEvents(c *gc.C) {
+	for _, event := range []state.ModelEvent{{
+		Kind:    state.DeployEvent,
+		Actor:   "user-bob@local",
+		Entity:  "application-mysql",
+		Message: "deployed cs:mysql-1",
+	}, {
+		Kind:    state.AddUnitEvent,
+		Actor:   "user-bob@local",
+		Entity:  "application-mysql",
+		Message: "added unit mysql/1",
+	}, {
+		Kind:    state.ConfigChangeEvent,
+		Actor:   "user-mary@local",
+		Entity:  "application-wordpress",
+		Message: "changed blog-title",
+	}} {
+		err := s.State.AddModelEvent(event)
+		c.Assert(err, jc.ErrorIsNil)
+		s.clock.Advance(time.Minute)
+	}
+}
+
+func (s *ModelEventsSuite) TestModelEvents(c *gc.C) {
+	s.addEvents(c)
+	events, err := s.State.ModelEvents(state.ModelEventFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 3)
+	c.Assert(events[0], jc.DeepEquals, state.ModelEvent{
+		Kind:    state.DeployEvent,
+		Actor:   "user-bob@local",
+		Entity:  "application-mysql",
+		Message: "deployed cs:mysql-1",
+		Time:    s.start,
+	})
+	c.Assert(events[1].Kind, gc.Equals, state.AddUnitEvent)
+	c.Assert(events[2].Kind, gc.Equals, state.ConfigChangeEvent)
+	c.Assert(events[2].Time.Equal(s.start.Add(2*time.Minute)), jc.IsTrue)
+}
+
+func (s *ModelEventsSuite) TestModelEventsTimeRange(c *gc.C) {
+	s.addEvents(c)
+	events, err := s.State.ModelEvents(state.ModelEventFilter{
+		From: s.start.Add(time.Minute),
+		To:   s.start.Add(2 * time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Kind, gc.Equals, state.AddUnitEvent)
+}
+
+func (s *ModelEventsSuite) TestModelEventsKindsAndEntity(c *gc.C) {
+	s.addEvents(c)
+	events, err := s.State.ModelEvents(state.ModelEventFilter{
+		Kinds: []state.ModelEventKind{state.DeployEvent, state.ConfigChangeEvent},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 2)
+	c.Assert(events[0].Kind, gc.Equals, state.DeployEvent)
+	c.Assert(events[1].Kind, gc.Equals, state.ConfigChangeEvent)
+
+	events, err = s.State.ModelEvents(state.ModelEventFilter{
+		Entity: "application-mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 2)
+}
+
+func (s *ModelEventsSuite) TestModelEventsLimit(c *gc.C) {
+	s.addEvents(c)
+	events, err := s.State.ModelEvents(state.ModelEventFilter{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 2)
+	c.Assert(events[0].Kind, gc.Equals, state.AddUnitEvent)
+	c.Assert(events[1].Kind, gc.Equals, state.ConfigChangeEvent)
+}
+
+func (s *ModelEventsSuite) TestModelEventsOtherModel(c *gc.C) {
+	s.addEvents(c)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	events, err := st.ModelEvents(state.ModelEventFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 0)
+}
+
+func (s *ModelEventsSuite) TestAddModelEventRequiresKind(c *gc.C) {
+	err := s.State.AddModelEvent(state.ModelEvent{Entity: "application-mysql"})
+	c.Assert(err, gc.ErrorMatches, "empty event kind not valid")
+}
+
+func (s *ModelEventsSuite) TestMachineFailure(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	now := s.clock.Now()
+	for i := 0; i < 2; i++ {
+		err = machine.SetStatus(status.StatusInfo{
+			Status:  status.StatusError,
+			Message: "no matching tools available",
+			Since:   &now,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	events, err := s.State.ModelEvents(state.ModelEventFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, []state.ModelEvent{{
+		Kind:    state.MachineFailureEvent,
+		Entity:  "machine-0",
+		Message: "no matching tools available",
+		Time:    now,
+	}})
+}
@@ -0,0 +1,265 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelLimits holds the caps, set by controller administrators, on the
+// number of machines and units in a model. A zero value means that the
+// resource is not limited.
+type ModelLimits struct {
+	// MaxMachines limits the number of machines, including
+	// containers, in the model.
+	MaxMachines int
+
+	// MaxUnits limits the number of principal units in the model.
+	// Subordinate units are created as a side effect of relations,
+	// and are not counted.
+	MaxUnits int
+}
+
+// ModelUsage holds the number of machines and units in a model, as
+// counted against its limits.
+type ModelUsage struct {
+	Machines int
+	Units    int
+}
+
+// QuotaExceededError is returned when adding machines or units would
+// take a model over its limits.
+type QuotaExceededError struct {
+	// Resource is the resource being added, "machines" or "units".
+	Resource string
+
+	// Limit is the maximum number of the resource in the model.
+	Limit int
+
+	// Usage is the number of the resource already in the model.
+	Usage int
+
+	// Requested is the number of the resource being added.
+	Requested int
+}
+
+// Error is part of the error interface.
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf(
+		"quota exceeded: model %s limit is %d, with %d in use; cannot add %d",
+		e.Resource, e.Limit, e.Usage, e.Requested,
+	)
+}
+
+// IsQuotaExceededError returns whether the cause of the given error is
+// a *QuotaExceededError.
+func IsQuotaExceededError(err error) bool {
+	_, ok := errors.Cause(err).(*QuotaExceededError)
+	return ok
+}
+
+// Limits returns the machine and unit limits of the model.
+func (m *Model) Limits() ModelLimits {
+	return ModelLimits{
+		MaxMachines: m.doc.MaxMachines,
+		MaxUnits:    m.doc.MaxUnits,
+	}
+}
+
+// Usage returns the number of machines and units in the model.
+func (m *Model) Usage() (ModelUsage, error) {
+	doc, err := readModelEntityRefs(m.st, m.UUID())
+	if err != nil {
+		return ModelUsage{}, errors.Trace(err)
+	}
+	return doc.usage(), nil
+}
+
+// SetLimits changes the machine and unit limits of the model. A limit
+// of zero removes it. Limits may not be lower than the model's current
+// usage.
+func (m *Model) SetLimits(limits ModelLimits) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set limits for model %q", m.Name())
+	if limits.MaxMachines < 0 || limits.MaxUnits < 0 {
+		return errors.New("limits may not be negative")
+	}
+	model := &Model{st: m.st, doc: m.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := model.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if model.doc.Life != Alive {
+			return nil, errors.New("model is no longer alive")
+		}
+		if limits == model.Limits() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		refs, err := readModelEntityRefs(m.st, model.UUID())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		usage := refs.usage()
+		if limits.MaxMachines > 0 && limits.MaxMachines < usage.Machines {
+			return nil, errors.Errorf(
+				"maximum machines %d is less than the current %d machines",
+				limits.MaxMachines, usage.Machines,
+			)
+		}
+		if limits.MaxUnits > 0 && limits.MaxUnits < usage.Units {
+			return nil, errors.Errorf(
+				"maximum units %d is less than the current %d units",
+				limits.MaxUnits, usage.Units,
+			)
+		}
+		ops := []txn.Op{{
+			C:  modelsC,
+			Id: model.UUID(),
+			Assert: append(isAliveDoc,
+				modelLimitUnchangedAssert("max-machines", model.doc.MaxMachines),
+				modelLimitUnchangedAssert("max-units", model.doc.MaxUnits),
+			),
+			Update: bson.D{{"$set", bson.D{
+				{"max-machines", limits.MaxMachines},
+				{"max-units", limits.MaxUnits},
+			}}},
+		}}
+		// Assert that the usage has not grown past the new limits
+		// since it was read.
+		var usageAssert bson.D
+		if limits.MaxMachines > 0 {
+			usageAssert = append(usageAssert, refsLengthAtMostAssert("machines", limits.MaxMachines))
+		}
+		if limits.MaxUnits > 0 {
+			usageAssert = append(usageAssert, refsLengthAtMostAssert("units", limits.MaxUnits))
+		}
+		if len(usageAssert) > 0 {
+			ops = append(ops, txn.Op{
+				C:      modelEntityRefsC,
+				Id:     model.UUID(),
+				Assert: usageAssert,
+			})
+		}
+		return ops, nil
+	}
+	if err := m.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return m.Refresh()
+}
+
+// modelLimitsOps returns the operations required to ensure that adding
+// the given numbers of machines and principal units will not take the
+// model over its limits. If it would already do so, a
+// *QuotaExceededError is returned.
+func (st *State) modelLimitsOps(newMachines, newUnits int) ([]txn.Op, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	limits := model.Limits()
+	ops := []txn.Op{{
+		C:  modelsC,
+		Id: st.ModelUUID(),
+		Assert: bson.D{
+			modelLimitUnchangedAssert("max-machines", limits.MaxMachines),
+			modelLimitUnchangedAssert("max-units", limits.MaxUnits),
+		},
+	}}
+	checkMachines := limits.MaxMachines > 0 && newMachines > 0
+	checkUnits := limits.MaxUnits > 0 && newUnits > 0
+	if !checkMachines && !checkUnits {
+		return ops, nil
+	}
+
+	refs, err := readModelEntityRefs(st, st.ModelUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	usage := refs.usage()
+	var usageAssert bson.D
+	if checkMachines {
+		if usage.Machines+newMachines > limits.MaxMachines {
+			return nil, &QuotaExceededError{
+				Resource:  "machines",
+				Limit:     limits.MaxMachines,
+				Usage:     usage.Machines,
+				Requested: newMachines,
+			}
+		}
+		usageAssert = append(usageAssert, refsLengthAtMostAssert("machines", limits.MaxMachines-newMachines))
+	}
+	if checkUnits {
+		if usage.Units+newUnits > limits.MaxUnits {
+			return nil, &QuotaExceededError{
+				Resource:  "units",
+				Limit:     limits.MaxUnits,
+				Usage:     usage.Units,
+				Requested: newUnits,
+			}
+		}
+		usageAssert = append(usageAssert, refsLengthAtMostAssert("units", limits.MaxUnits-newUnits))
+	}
+	return append(ops, txn.Op{
+		C:      modelEntityRefsC,
+		Id:     st.ModelUUID(),
+		Assert: usageAssert,
+	}), nil
+}
+
+// checkModelLimits returns a *QuotaExceededError if adding the given
+// numbers of machines and principal units would take the model over
+// its limits. It is used to explain aborted transactions.
+func (st *State) checkModelLimits(newMachines, newUnits int) error {
+	_, err := st.modelLimitsOps(newMachines, newUnits)
+	return err
+}
+
+// modelLimitUnchangedAssert returns an assertion that the given limit
+// field of a model document is still limit. Model documents written
+// before limits were introduced have no such field, which is
+// equivalent to a zero value.
+func modelLimitUnchangedAssert(field string, limit int) bson.DocElem {
+	if limit == 0 {
+		return bson.DocElem{field, bson.D{{"$in", []interface{}{0, nil}}}}
+	}
+	return bson.DocElem{field, limit}
+}
+
+// refsLengthAtMostAssert returns an assertion that the given array
+// field of a model entity references document holds at most n
+// elements; an array holds more than n elements only if it has an
+// element at index n.
+func refsLengthAtMostAssert(field string, n int) bson.DocElem {
+	return bson.DocElem{fmt.Sprintf("%s.%d", field, n), bson.D{{"$exists", false}}}
+}
+
+// readModelEntityRefs returns the entity references document of the
+// model with the given UUID.
+func readModelEntityRefs(st *State, modelUUID string) (*modelEntityRefsDoc, error) {
+	modelEntityRefs, closer := st.getCollection(modelEntityRefsC)
+	defer closer()
+
+	var doc modelEntityRefsDoc
+	if err := modelEntityRefs.FindId(modelUUID).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("entity references doc for model %s", modelUUID)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting entity references for model %s", modelUUID)
+	}
+	return &doc, nil
+}
+
+func (doc *modelEntityRefsDoc) usage() ModelUsage {
+	return ModelUsage{
+		Machines: len(doc.Machines),
+		Units:    len(doc.Units),
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type ModelLimitsSuite struct {
+	ConnSuite
+	model *state.Model
+}
+
+var _ = gc.Suite(&ModelLimitsSuite{})
+
+func (s *ModelLimitsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelLimitsSuite) setLimits(c *gc.C, maxMachines, maxUnits int) {
+	err := s.model.SetLimits(state.ModelLimits{
+		MaxMachines: maxMachines,
+		MaxUnits:    maxUnits,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelLimitsSuite) assertUsage(c *gc.C, machines, units int) {
+	usage, err := s.model.Usage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, state.ModelUsage{Machines: machines, Units: units})
+}
+
+func (s *ModelLimitsSuite) TestSetLimits(c *gc.C) {
+	c.Assert(s.model.Limits(), jc.DeepEquals, state.ModelLimits{})
+	for _, limits := range []state.ModelLimits{
+		{MaxMachines: 3},
+		{MaxMachines: 5, MaxUnits: 10},
+		{},
+	} {
+		err := s.model.SetLimits(limits)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(s.model.Limits(), jc.DeepEquals, limits)
+		model, err := s.State.Model()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(model.Limits(), jc.DeepEquals, limits)
+	}
+}
+
+func (s *ModelLimitsSuite) TestSetLimitsNegative(c *gc.C) {
+	err := s.model.SetLimits(state.ModelLimits{MaxUnits: -1})
+	c.Assert(err, gc.ErrorMatches, `cannot set limits for model "testenv": limits may not be negative`)
+}
+
+func (s *ModelLimitsSuite) TestSetLimitsBelowUsage(c *gc.C) {
+	for i := 0; i < 2; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.model.SetLimits(state.ModelLimits{MaxMachines: 1})
+	c.Assert(err, gc.ErrorMatches, `cannot set limits for model "testenv": maximum machines 1 is less than the current 2 machines`)
+	c.Assert(s.model.Limits(), jc.DeepEquals, state.ModelLimits{})
+}
+
+func (s *ModelLimitsSuite) TestUsage(c *gc.C) {
+	s.assertUsage(c, 0, 0)
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUsage(c, 2, 1)
+
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUsage(c, 2, 0)
+}
+
+func (s *ModelLimitsSuite) TestAddMachinesRespectsLimit(c *gc.C) {
+	s.setLimits(c, 2, 0)
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	_, err := s.State.AddMachines(template, template, template)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: quota exceeded: model machines limit is 2, with 0 in use; cannot add 3`)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+
+	_, err = s.State.AddMachines(template, template)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachineInsideMachine(template, "0", instance.LXD)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: quota exceeded: model machines limit is 2, with 2 in use; cannot add 1`)
+}
+
+func (s *ModelLimitsSuite) TestAddMachineInsideNewMachineRespectsLimit(c *gc.C) {
+	s.setLimits(c, 1, 0)
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	_, err := s.State.AddMachineInsideNewMachine(template, template, instance.LXD)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: quota exceeded: model machines limit is 1, with 0 in use; cannot add 2`)
+	s.assertUsage(c, 0, 0)
+}
+
+func (s *ModelLimitsSuite) TestAddMachineLimitRace(c *gc.C) {
+	s.setLimits(c, 1, 0)
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: quota exceeded: model machines limit is 1, with 1 in use; cannot add 1`)
+	s.assertUsage(c, 1, 0)
+}
+
+func (s *ModelLimitsSuite) TestAssignToNewMachineRespectsLimit(c *gc.C) {
+	s.setLimits(c, 1, 0)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.AssignToNewMachine()
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "dummy/0" to new machine: quota exceeded: .*`)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+}
+
+func (s *ModelLimitsSuite) TestAddUnitRespectsLimit(c *gc.C) {
+	s.setLimits(c, 0, 1)
+	application := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = application.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "dummy": quota exceeded: model units limit is 1, with 1 in use; cannot add 1`)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+}
+
+func (s *ModelLimitsSuite) TestAddApplicationRespectsUnitLimit(c *gc.C) {
+	s.setLimits(c, 0, 2)
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:     "dummy",
+		Charm:    s.AddTestingCharm(c, "dummy"),
+		NumUnits: 3,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "dummy": quota exceeded: model units limit is 2, with 0 in use; cannot add 3`)
+	s.assertUsage(c, 0, 0)
+}
@@ -58,6 +58,10 @@ type ModelMigration interface {
 	// progress of the migration.
 	StatusMessage() string
 
+	// ArtifactsRemaining returns the number of charms and tools
+	// which are still to be transferred to the target controller.
+	ArtifactsRemaining() int
+
 	// InitiatedBy returns username the initiated the migration.
 	InitiatedBy() string
 
@@ -74,6 +78,10 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// SetArtifactsRemaining records the number of charms and tools
+	// which are still to be transferred to the target controller.
+	SetArtifactsRemaining(count int) error
+
 	// MinionReport records a report from a migration minion worker
 	// about the success or failure to complete its actions for a
 	// given migration phase.
@@ -181,6 +189,10 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// ArtifactsRemaining holds the number of charms and tools still
+	// to be transferred to the target controller.
+	ArtifactsRemaining int `bson:"artifacts-remaining"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -246,6 +258,11 @@ func (mig *modelMigration) StatusMessage() string {
 	return mig.statusDoc.StatusMessage
 }
 
+// ArtifactsRemaining implements ModelMigration.
+func (mig *modelMigration) ArtifactsRemaining() int {
+	return mig.statusDoc.ArtifactsRemaining
+}
+
 // InitiatedBy implements ModelMigration.
 func (mig *modelMigration) InitiatedBy() string {
 	return mig.doc.InitiatedBy
@@ -268,7 +285,7 @@ func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 
 // SetPhase implements ModelMigration.
 func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
-	now := GetClock().Now().UnixNano()
+	now := mig.st.clock.Now().UnixNano()
 
 	phase, err := mig.Phase()
 	if err != nil {
@@ -333,6 +350,7 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	} else if err != nil {
 		return errors.Annotate(err, "failed to update phase")
 	}
+	migrationPhases.WithLabelValues(nextDoc.Phase).Inc()
 
 	mig.statusDoc = nextDoc
 	return nil
@@ -353,6 +371,24 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// SetArtifactsRemaining implements ModelMigration.
+func (mig *modelMigration) SetArtifactsRemaining(count int) error {
+	if count < 0 {
+		return errors.NotValidf("negative artifact count %d", count)
+	}
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{"artifacts-remaining": count}},
+		Assert: txn.DocExists,
+	}}
+	if err := mig.st.runTransaction(ops); err != nil {
+		return errors.Annotate(err, "failed to set remaining migration artifacts")
+	}
+	mig.statusDoc.ArtifactsRemaining = count
+	return nil
+}
+
 // MinionReport implements ModelMigration.
 func (mig *modelMigration) MinionReport(tag names.Tag, phase migration.Phase, success bool) error {
 	globalKey, err := agentTagToGlobalKey(tag)
@@ -365,7 +401,7 @@ func (mig *modelMigration) MinionReport(tag names.Tag, phase migration.Phase, su
 		MigrationId: mig.Id(),
 		Phase:       phase.String(),
 		EntityKey:   globalKey,
-		Time:        GetClock().Now().UnixNano(),
+		Time:        mig.st.clock.Now().UnixNano(),
 		Success:     success,
 	}
 	ops := []txn.Op{{
@@ -563,7 +599,7 @@ func (st *State) CreateModelMigration(spec ModelMigrationSpec) (ModelMigration,
 		return nil, errors.Trace(err)
 	}
 
-	now := GetClock().Now().UnixNano()
+	now := st.clock.Now().UnixNano()
 	modelUUID := st.ModelUUID()
 	var doc modelMigDoc
 	var statusDoc modelMigStatusDoc
@@ -10,11 +10,11 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
-	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/migration"
+	monitoringtesting "github.com/juju/juju/monitoring/testing"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -33,9 +33,7 @@ var _ = gc.Suite(new(ModelMigrationSuite))
 func (s *ModelMigrationSuite) SetUpTest(c *gc.C) {
 	s.ConnSuite.SetUpTest(c)
 	s.clock = coretesting.NewClock(time.Now().Truncate(time.Second))
-	s.PatchValue(&state.GetClock, func() clock.Clock {
-		return s.clock
-	})
+	state.SetClock(s.State, s.clock)
 
 	// Create a hosted model to migrate.
 	s.State2 = s.Factory.MakeModel(c, nil)
@@ -321,6 +319,20 @@ func (s *ModelMigrationSuite) TestRefresh(c *gc.C) {
 	assertPhase(c, mig2, migration.PRECHECK)
 }
 
+func (s *ModelMigrationSuite) TestPhaseChangeMetric(c *gc.C) {
+	counter := state.MigrationPhases.WithLabelValues("PRECHECK")
+	before := monitoringtesting.Value(c, counter)
+
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.PRECHECK), jc.ErrorIsNil)
+	c.Assert(monitoringtesting.Value(c, counter), gc.Equals, before+1)
+
+	// Setting the current phase again is not a change.
+	c.Assert(mig.SetPhase(migration.PRECHECK), jc.ErrorIsNil)
+	c.Assert(monitoringtesting.Value(c, counter), gc.Equals, before+1)
+}
+
 func (s *ModelMigrationSuite) TestSuccessfulPhaseTransitions(c *gc.C) {
 	st := s.State2
 
@@ -472,6 +484,29 @@ func (s *ModelMigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *ModelMigrationSuite) TestArtifactsRemaining(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.ArtifactsRemaining(), gc.Equals, 0)
+
+	err = mig.SetArtifactsRemaining(7)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.ArtifactsRemaining(), gc.Equals, 7)
+
+	mig2, err := s.State2.LatestModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.ArtifactsRemaining(), gc.Equals, 7)
+}
+
+func (s *ModelMigrationSuite) TestSetArtifactsRemainingNegative(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.SetArtifactsRemaining(-1)
+	c.Check(err, gc.ErrorMatches, "negative artifact count -1 not valid")
+	c.Check(mig.ArtifactsRemaining(), gc.Equals, 0)
+}
+
 func (s *ModelMigrationSuite) TestWatchForModelMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createMigrationWatcher(c, s.State2)
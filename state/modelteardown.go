@@ -0,0 +1,122 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// ModelTeardownProgress describes how far the destruction of a model
+// has got: the entities that must still be removed before the model
+// can die, and what the teardown is currently waiting for.
+type ModelTeardownProgress struct {
+	// Life is the life of the model.
+	Life Life
+
+	// TimeOfDying is the time at which the model started dying,
+	// or the zero time if it has not.
+	TimeOfDying time.Time
+
+	// Machines, Applications, Units, Volumes and Filesystems hold
+	// the numbers of each kind of entity remaining in the model.
+	// Machines includes containers; Units includes subordinates.
+	Machines     int
+	Applications int
+	Units        int
+	Volumes      int
+	Filesystems  int
+
+	// HostedModels holds the number of hosted models that are not
+	// yet dead. It is only ever non-zero for the controller model.
+	HostedModels int
+
+	// Blocker describes what the teardown is currently waiting for.
+	// It is empty if the model is not dying, or nothing remains.
+	Blocker string
+}
+
+// TeardownProgress returns the progress of the model's destruction.
+// While the model is alive, the entities remaining are reported, but
+// there is no blocker.
+func (m *Model) TeardownProgress() (ModelTeardownProgress, error) {
+	st, closeState, err := m.getState()
+	if err != nil {
+		return ModelTeardownProgress{}, errors.Trace(err)
+	}
+	defer closeState()
+
+	progress := ModelTeardownProgress{
+		Life:        m.Life(),
+		TimeOfDying: m.doc.TimeOfDying,
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return ModelTeardownProgress{}, errors.Trace(err)
+	}
+	progress.Machines = len(machines)
+	for _, count := range []struct {
+		collection string
+		n          *int
+	}{
+		{applicationsC, &progress.Applications},
+		{unitsC, &progress.Units},
+		{volumesC, &progress.Volumes},
+		{filesystemsC, &progress.Filesystems},
+	} {
+		coll, closer := st.getCollection(count.collection)
+		n, err := coll.Count()
+		closer()
+		if err != nil {
+			return ModelTeardownProgress{}, errors.Annotatef(err, "counting %s", count.collection)
+		}
+		*count.n = n
+	}
+	if m.UUID() == m.doc.ServerUUID {
+		models, err := st.AllModels()
+		if err != nil {
+			return ModelTeardownProgress{}, errors.Trace(err)
+		}
+		for _, model := range models {
+			if model.UUID() != m.UUID() && model.Life() != Dead {
+				progress.HostedModels++
+			}
+		}
+	}
+	if progress.Life != Alive {
+		progress.Blocker = teardownBlocker(progress, machines)
+	}
+	return progress, nil
+}
+
+// teardownBlocker returns a description of what the teardown of a
+// dying model with the given progress and machines is waiting for.
+// Entities are reported in the order in which teardown removes them.
+func teardownBlocker(progress ModelTeardownProgress, machines []*Machine) string {
+	for _, m := range machines {
+		// Maintenance mode stops the workers that would
+		// otherwise remove the machine.
+		if m.InMaintenance() {
+			return fmt.Sprintf("machine %s is in maintenance mode", m.Id())
+		}
+	}
+	switch {
+	case progress.HostedModels > 0:
+		return fmt.Sprintf("waiting for %d hosted model(s) to be destroyed", progress.HostedModels)
+	case progress.Units > 0:
+		return fmt.Sprintf("waiting for %d unit(s) to be removed", progress.Units)
+	case progress.Applications > 0:
+		return fmt.Sprintf("waiting for %d application(s) to be removed", progress.Applications)
+	case progress.Volumes > 0 || progress.Filesystems > 0:
+		return fmt.Sprintf(
+			"waiting for %d volume(s) and %d filesystem(s) to be removed",
+			progress.Volumes, progress.Filesystems,
+		)
+	case progress.Machines > 0:
+		return fmt.Sprintf("waiting for %d machine(s) to be removed", progress.Machines)
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelTeardownSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelTeardownSuite{})
+
+func (s *ModelTeardownSuite) makeHostedModel(c *gc.C) (*state.State, *factory.Factory) {
+	st := s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	return st, factory.NewFactory(st)
+}
+
+func (s *ModelTeardownSuite) TestTeardownProgressAlive(c *gc.C) {
+	st, f := s.makeHostedModel(c)
+	f.MakeUnit(c, nil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	progress, err := model.TeardownProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress, jc.DeepEquals, state.ModelTeardownProgress{
+		Life:         state.Alive,
+		Machines:     1,
+		Applications: 1,
+		Units:        1,
+	})
+}
+
+func (s *ModelTeardownSuite) TestTeardownProgressDying(c *gc.C) {
+	st, f := s.makeHostedModel(c)
+	unit := f.MakeUnit(c, nil)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err = st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	progress, err := model.TeardownProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress.Life, gc.Equals, state.Dying)
+	c.Assert(progress.TimeOfDying.IsZero(), jc.IsFalse)
+	c.Assert(progress.Units, gc.Equals, 1)
+	c.Assert(progress.Blocker, gc.Equals, "waiting for 1 unit(s) to be removed")
+
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	progress, err = model.TeardownProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress.Units, gc.Equals, 0)
+	c.Assert(progress.Blocker, gc.Equals, "waiting for 1 application(s) to be removed")
+}
+
+func (s *ModelTeardownSuite) TestTeardownProgressMaintenanceBlocks(c *gc.C) {
+	st, f := s.makeHostedModel(c)
+	machine := f.MakeMachine(c, nil)
+	err := machine.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err = st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	progress, err := model.TeardownProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress.Machines, gc.Equals, 1)
+	c.Assert(progress.Blocker, gc.Equals, "machine "+machine.Id()+" is in maintenance mode")
+}
+
+func (s *ModelTeardownSuite) TestTeardownProgressControllerHostedModels(c *gc.C) {
+	_, f := s.makeHostedModel(c)
+	f.MakeApplication(c, nil)
+	controllerModel, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = controllerModel.DestroyIncludingHosted()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerModel, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	progress, err := controllerModel.TeardownProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress.HostedModels, gc.Equals, 1)
+	c.Assert(progress.Blocker, gc.Equals, "waiting for 1 hosted model(s) to be destroyed")
+}
@@ -0,0 +1,68 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	jujutxn "github.com/juju/txn"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/monitoring"
+)
+
+var (
+	// mongoTxns counts the transactions run against mongo, by
+	// their result.
+	mongoTxns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: monitoring.Namespace,
+		Subsystem: "mongo",
+		Name:      "txns_total",
+		Help:      "Number of mongo transactions run, by result.",
+	}, []string{"result"})
+
+	// migrationPhases counts the model migrations which have
+	// entered each phase.
+	migrationPhases = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: monitoring.Namespace,
+		Subsystem: "migration",
+		Name:      "phase_changes_total",
+		Help:      "Number of model migrations which have entered each phase.",
+	}, []string{"phase"})
+)
+
+func init() {
+	monitoring.MustRegister(mongoTxns, migrationPhases)
+}
+
+// txnResult returns the label under which the result of a transaction
+// is counted.
+func txnResult(err error) string {
+	switch err {
+	case nil:
+		return "committed"
+	case txn.ErrAborted, jujutxn.ErrExcessiveContention:
+		return "aborted"
+	}
+	return "failed"
+}
+
+// countingRunner is a jujutxn.Runner which counts the results of the
+// transactions run by another.
+type countingRunner struct {
+	jujutxn.Runner
+}
+
+// RunTransaction is part of the jujutxn.Runner interface.
+func (r countingRunner) RunTransaction(ops []txn.Op) error {
+	err := r.Runner.RunTransaction(ops)
+	mongoTxns.WithLabelValues(txnResult(err)).Inc()
+	return err
+}
+
+// Run is part of the jujutxn.Runner interface.
+func (r countingRunner) Run(transactions jujutxn.TransactionSource) error {
+	err := r.Runner.Run(transactions)
+	mongoTxns.WithLabelValues(txnResult(err)).Inc()
+	return err
+}
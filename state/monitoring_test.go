@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	jujutxn "github.com/juju/txn"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/txn"
+
+	monitoringtesting "github.com/juju/juju/monitoring/testing"
+	"github.com/juju/juju/testing"
+)
+
+type CountingRunnerSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&CountingRunnerSuite{})
+
+func (s *CountingRunnerSuite) TestTxnResult(c *gc.C) {
+	c.Check(txnResult(nil), gc.Equals, "committed")
+	c.Check(txnResult(txn.ErrAborted), gc.Equals, "aborted")
+	c.Check(txnResult(jujutxn.ErrExcessiveContention), gc.Equals, "aborted")
+	c.Check(txnResult(errors.New("boom")), gc.Equals, "failed")
+}
+
+func (s *CountingRunnerSuite) TestCountsTransactions(c *gc.C) {
+	committed := mongoTxns.WithLabelValues("committed")
+	failed := mongoTxns.WithLabelValues("failed")
+	beforeCommitted := monitoringtesting.Value(c, committed)
+	beforeFailed := monitoringtesting.Value(c, failed)
+
+	runner := countingRunner{&recordingRunner{}}
+	err := runner.RunTransaction([]txn.Op{{C: "other", Id: "x"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = runner.Run(func(int) ([]txn.Op, error) {
+		return nil, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	c.Assert(monitoringtesting.Value(c, committed), gc.Equals, beforeCommitted+1)
+	c.Assert(monitoringtesting.Value(c, failed), gc.Equals, beforeFailed+1)
+}
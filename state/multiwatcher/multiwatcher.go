@@ -224,8 +224,9 @@ type UnitInfo struct {
 	PortRanges     []PortRange `json:"port-ranges"`
 	Subordinate    bool        `json:"subordinate"`
 	// Workload and agent state are modelled separately.
-	WorkloadStatus StatusInfo `json:"workload-status"`
-	AgentStatus    StatusInfo `json:"agent-status"`
+	WorkloadStatus  StatusInfo `json:"workload-status"`
+	AgentStatus     StatusInfo `json:"agent-status"`
+	WorkloadVersion string     `json:"workload-version,omitempty"`
 }
 
 // EntityId returns a unique identifier for a unit across
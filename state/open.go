@@ -6,10 +6,10 @@ package state
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/txn"
@@ -84,7 +84,7 @@ func open(tag names.ModelTag, info *mongo.MongoInfo, opts mongo.DialOpts, newPol
 		tag = ssInfo.ModelTag
 	}
 
-	st, err := newState(tag, session, info, newPolicy)
+	st, err := newState(tag, session, info, newPolicy, GetClock())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -318,8 +318,7 @@ func (st *State) modelSetupOps(args ModelArgs, inherited *lineage) ([]txn.Op, er
 	modelUUID := args.Config.UUID()
 	modelStatusDoc := statusDoc{
 		ModelUUID: modelUUID,
-		// TODO(fwereade): 2016-03-17 lp:1558657
-		Updated: time.Now().UnixNano(),
+		Updated:   st.clock.Now().UnixNano(),
 		// TODO(axw) 2016-04-13 lp:1569632
 		// We need to decide how we will
 		// represent migration in model status.
@@ -451,7 +450,13 @@ func isUnauthorized(err error) bool {
 //
 // newState takes responsibility for the supplied *mgo.Session, and will
 // close it if it cannot be returned under the aegis of a *State.
-func newState(modelTag names.ModelTag, session *mgo.Session, mongoInfo *mongo.MongoInfo, newPolicy NewPolicyFunc) (_ *State, err error) {
+func newState(
+	modelTag names.ModelTag,
+	session *mgo.Session,
+	mongoInfo *mongo.MongoInfo,
+	newPolicy NewPolicyFunc,
+	clock clock.Clock,
+) (_ *State, err error) {
 
 	defer func() {
 		if err != nil {
@@ -476,6 +481,7 @@ func newState(modelTag names.ModelTag, session *mgo.Session, mongoInfo *mongo.Mo
 		session:   session,
 		database:  database,
 		newPolicy: newPolicy,
+		clock:     clock,
 	}
 	if newPolicy != nil {
 		st.policy = newPolicy(st)
@@ -503,6 +509,14 @@ func (st *State) Close() (err error) {
 			errs = append(errs, errors.Annotatef(err, "error stopping %s", name))
 		}
 	}
+	// Pending batched updates are applied before anything else
+	// is stopped.
+	if batcher := st.getTxnBatcher(); batcher != nil {
+		handle("transaction batcher", worker.Stop(batcher))
+	}
+	if cache := st.getReadCache(); cache != nil {
+		handle("read cache", cache.stop())
+	}
 	if st.workers != nil {
 		handle("standard workers", worker.Stop(st.workers))
 	}
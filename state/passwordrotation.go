@@ -0,0 +1,133 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// passwordRotationWindow is the length of time for which an agent's
+// previous password remains valid after it has been changed. Agents
+// write their new password to disk before setting it in state, so the
+// window only needs to cover connections already in flight, and other
+// processes that have yet to reread the agent's config.
+const passwordRotationWindow = 10 * time.Minute
+
+// passwordRotationDoc records the rotation of a machine or unit agent's
+// API password.
+type passwordRotationDoc struct {
+	// Requested is true if the agent has been asked to rotate its
+	// password the next time it connects.
+	Requested bool `bson:"requested,omitempty"`
+
+	// Changed is the time at which the password was last changed.
+	Changed time.Time `bson:"changed,omitempty"`
+
+	// PreviousHash is the hash of the password that was replaced
+	// when the password was last changed.
+	PreviousHash string `bson:"previous-hash,omitempty"`
+
+	// PreviousExpires is the time at which PreviousHash is no
+	// longer accepted.
+	PreviousExpires time.Time `bson:"previous-expires,omitempty"`
+}
+
+// setPasswordHashUpdate returns the update that replaces an agent's
+// password hash, and the rotation record that results. Agents set
+// their current password each time they connect, so only an update
+// that changes the hash counts as a rotation: it completes any
+// requested rotation, and keeps the old hash valid for
+// passwordRotationWindow so that the agent cannot lock itself out.
+func setPasswordHashUpdate(oldHash, newHash string, rotation passwordRotationDoc, now time.Time) (bson.D, passwordRotationDoc) {
+	if newHash == oldHash {
+		return bson.D{{"$set", bson.D{{"passwordhash", newHash}}}}, rotation
+	}
+	now = now.UTC()
+	rotation = passwordRotationDoc{Changed: now}
+	if oldHash != "" {
+		rotation.PreviousHash = oldHash
+		rotation.PreviousExpires = now.Add(passwordRotationWindow)
+	}
+	return bson.D{{"$set", bson.D{
+		{"passwordhash", newHash},
+		{"password-rotation", rotation},
+	}}}, rotation
+}
+
+// agentPasswordValid returns whether the given password matches either
+// the current password hash, or a previous hash that has yet to expire
+// at the given time.
+func agentPasswordValid(password, hash string, rotation passwordRotationDoc, now time.Time) bool {
+	agentHash := utils.AgentPasswordHash(password)
+	if agentHash == hash {
+		return true
+	}
+	return rotation.PreviousHash != "" &&
+		agentHash == rotation.PreviousHash &&
+		now.Before(rotation.PreviousExpires)
+}
+
+// passwordRotationRequired returns whether an agent should rotate its
+// password: either because a rotation was requested, or because the
+// password is older than maxAge. A zero maxAge disables scheduled
+// rotation. Passwords set before rotations were recorded are treated
+// as being arbitrarily old.
+func passwordRotationRequired(rotation passwordRotationDoc, maxAge time.Duration, now time.Time) bool {
+	if rotation.Requested {
+		return true
+	}
+	return maxAge > 0 && now.Sub(rotation.Changed) >= maxAge
+}
+
+// requestPasswordRotationOps returns the operations that ask the agent
+// of the given entity document to rotate its password.
+func requestPasswordRotationOps(coll, docID string) []txn.Op {
+	return []txn.Op{{
+		C:      coll,
+		Id:     docID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"password-rotation.requested", true}}}},
+	}}
+}
+
+// PasswordRotationRequired returns whether the machine's agent should
+// rotate its API password, given the longest time for which a password
+// may be kept; a zero maxAge disables scheduled rotation.
+func (m *Machine) PasswordRotationRequired(maxAge time.Duration) bool {
+	return passwordRotationRequired(m.doc.PasswordRotation, maxAge, m.st.clock.Now())
+}
+
+// RequestPasswordRotation asks the machine's agent to rotate its API
+// password the next time it connects.
+func (m *Machine) RequestPasswordRotation() error {
+	ops := requestPasswordRotationOps(machinesC, m.doc.DocID)
+	if err := m.st.runTransaction(ops); err != nil {
+		return fmt.Errorf("cannot request password rotation for machine %v: %v", m, onAbort(err, ErrDead))
+	}
+	m.doc.PasswordRotation.Requested = true
+	return nil
+}
+
+// PasswordRotationRequired returns whether the unit's agent should
+// rotate its API password, given the longest time for which a password
+// may be kept; a zero maxAge disables scheduled rotation.
+func (u *Unit) PasswordRotationRequired(maxAge time.Duration) bool {
+	return passwordRotationRequired(u.doc.PasswordRotation, maxAge, u.st.clock.Now())
+}
+
+// RequestPasswordRotation asks the unit's agent to rotate its API
+// password the next time it connects.
+func (u *Unit) RequestPasswordRotation() error {
+	ops := requestPasswordRotationOps(unitsC, u.doc.DocID)
+	if err := u.st.runTransaction(ops); err != nil {
+		return fmt.Errorf("cannot request password rotation for unit %q: %v", u, onAbort(err, ErrDead))
+	}
+	u.doc.PasswordRotation.Requested = true
+	return nil
+}
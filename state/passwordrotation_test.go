@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type PasswordRotationSuite struct {
+	ConnSuite
+	clock *coretesting.Clock
+}
+
+var _ = gc.Suite(&PasswordRotationSuite{})
+
+func (s *PasswordRotationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Now().Truncate(time.Second))
+	state.SetClock(s.State, s.clock)
+}
+
+// rotatingAgent is implemented by *state.Machine and *state.Unit.
+type rotatingAgent interface {
+	state.Authenticator
+	PasswordRotationRequired(time.Duration) bool
+	RequestPasswordRotation() error
+}
+
+func (s *PasswordRotationSuite) addMachine(c *gc.C) *state.Machine {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *PasswordRotationSuite) addUnit(c *gc.C) *state.Unit {
+	application := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	u, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	return u
+}
+
+func (s *PasswordRotationSuite) TestMachinePreviousPasswordExpires(c *gc.C) {
+	s.testPreviousPasswordExpires(c, s.addMachine(c))
+}
+
+func (s *PasswordRotationSuite) TestUnitPreviousPasswordExpires(c *gc.C) {
+	s.testPreviousPasswordExpires(c, s.addUnit(c))
+}
+
+func (s *PasswordRotationSuite) testPreviousPasswordExpires(c *gc.C, agent rotatingAgent) {
+	err := agent.SetPassword(goodPassword)
+	c.Assert(err, jc.ErrorIsNil)
+	err = agent.SetPassword(alternatePassword)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = agent.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agent.PasswordValid(alternatePassword), jc.IsTrue)
+	c.Assert(agent.PasswordValid(goodPassword), jc.IsTrue)
+
+	s.clock.Advance(10 * time.Minute)
+	c.Assert(agent.PasswordValid(alternatePassword), jc.IsTrue)
+	c.Assert(agent.PasswordValid(goodPassword), jc.IsFalse)
+}
+
+func (s *PasswordRotationSuite) TestUnchangedPasswordKeepsPrevious(c *gc.C) {
+	m := s.addMachine(c)
+	err := m.SetPassword(goodPassword)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetPassword(alternatePassword)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Agents reset their current password whenever they connect;
+	// doing so does not revoke the previous password, nor restart
+	// the password's age.
+	s.clock.Advance(time.Minute)
+	err = m.SetPassword(alternatePassword)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.PasswordValid(goodPassword), jc.IsTrue)
+	c.Assert(m.PasswordRotationRequired(time.Hour), jc.IsFalse)
+	s.clock.Advance(59 * time.Minute)
+	c.Assert(m.PasswordRotationRequired(time.Hour), jc.IsTrue)
+}
+
+func (s *PasswordRotationSuite) TestMachineRequestPasswordRotation(c *gc.C) {
+	s.testRequestPasswordRotation(c, s.addMachine(c))
+}
+
+func (s *PasswordRotationSuite) TestUnitRequestPasswordRotation(c *gc.C) {
+	s.testRequestPasswordRotation(c, s.addUnit(c))
+}
+
+func (s *PasswordRotationSuite) testRequestPasswordRotation(c *gc.C, agent rotatingAgent) {
+	err := agent.SetPassword(goodPassword)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agent.PasswordRotationRequired(0), jc.IsFalse)
+
+	err = agent.RequestPasswordRotation()
+	c.Assert(err, jc.ErrorIsNil)
+	err = agent.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agent.PasswordRotationRequired(0), jc.IsTrue)
+
+	// Resetting the same password does not complete the rotation.
+	err = agent.SetPassword(goodPassword)
+	c.Assert(err, jc.ErrorIsNil)
+	err = agent.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agent.PasswordRotationRequired(0), jc.IsTrue)
+
+	err = agent.SetPassword(alternatePassword)
+	c.Assert(err, jc.ErrorIsNil)
+	err = agent.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agent.PasswordRotationRequired(0), jc.IsFalse)
+}
+
+func (s *PasswordRotationSuite) TestPasswordRotationRequiredByAge(c *gc.C) {
+	m := s.addMachine(c)
+	err := m.SetPassword(goodPassword)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.PasswordRotationRequired(time.Hour), jc.IsFalse)
+
+	s.clock.Advance(time.Hour)
+	c.Assert(m.PasswordRotationRequired(time.Hour), jc.IsTrue)
+	c.Assert(m.PasswordRotationRequired(0), jc.IsFalse)
+
+	err = m.SetPassword(alternatePassword)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.PasswordRotationRequired(time.Hour), jc.IsFalse)
+}
+
+func (s *PasswordRotationSuite) TestRequestPasswordRotationDead(c *gc.C) {
+	m := s.addMachine(c)
+	err := m.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.RequestPasswordRotation()
+	c.Assert(err, gc.ErrorMatches, `cannot request password rotation for machine 0: not found or dead`)
+}
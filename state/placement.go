@@ -0,0 +1,187 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
+
+// placementDoc counts the placements of an application's units on
+// existing machines. The choice of machine for a unit depends on where
+// the application's other units are, so that they can be spread across
+// availability zones; each placement asserts and increments the count,
+// so that concurrent placements of the same application's units are
+// serialised rather than all choosing the same zone.
+type placementDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+	Count       int64  `bson:"count"`
+}
+
+func placementDocID(st *State, application string) string {
+	return st.docID(applicationGlobalKey(application))
+}
+
+// placementToken records the placement count read for an
+// application, before choosing a machine for one of its units.
+type placementToken struct {
+	docID       string
+	application string
+	count       int64
+	exists      bool
+}
+
+// readPlacementToken returns a token recording the current placement
+// count of the named application.
+func readPlacementToken(st *State, application string) (placementToken, error) {
+	placements, closer := st.getCollection(placementsC)
+	defer closer()
+
+	token := placementToken{
+		docID:       placementDocID(st, application),
+		application: application,
+	}
+	var doc placementDoc
+	err := placements.FindId(token.docID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return token, nil
+	} else if err != nil {
+		return placementToken{}, errors.Annotatef(err, "cannot read placements of application %q", application)
+	}
+	token.count = doc.Count
+	token.exists = true
+	return token, nil
+}
+
+// op returns an operation which fails if the application's placement
+// count has changed since the token was read, and otherwise increments
+// it.
+func (t placementToken) op() txn.Op {
+	if !t.exists {
+		return txn.Op{
+			C:      placementsC,
+			Id:     t.docID,
+			Assert: txn.DocMissing,
+			Insert: &placementDoc{
+				Application: t.application,
+				Count:       1,
+			},
+		}
+	}
+	return txn.Op{
+		C:      placementsC,
+		Id:     t.docID,
+		Assert: bson.D{{"count", t.count}},
+		Update: bson.D{{"$inc", bson.D{{"count", 1}}}},
+	}
+}
+
+// removePlacementOp returns an operation which removes the placement
+// count of the named application.
+func removePlacementOp(st *State, application string) txn.Op {
+	return txn.Op{
+		C:      placementsC,
+		Id:     placementDocID(st, application),
+		Remove: true,
+	}
+}
+
+// cleanMachineCandidates returns the clean (and, if requireEmpty is
+// set, empty) machines matching the constraints on which the unit
+// might be placed, in order of preference. Provisioned machines are
+// ordered so as to spread the application's units across availability
+// zones, and precede unprovisioned machines.
+func (u *Unit) cleanMachineCandidates(requireEmpty bool, cons *constraints.Value) ([]*Machine, error) {
+	query, err := u.findCleanMachineQuery(requireEmpty, cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machinesCollection, closer := u.st.getCollection(machinesC)
+	defer closer()
+	var mdocs []*machineDoc
+	if err := machinesCollection.Find(query).All(&mdocs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var unprovisioned []*Machine
+	var instances []instance.Id
+	instanceMachines := make(map[instance.Id]*Machine)
+	for _, mdoc := range mdocs {
+		m := newMachine(u.st, mdoc)
+		instance, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			unprovisioned = append(unprovisioned, m)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		} else {
+			instances = append(instances, instance)
+			instanceMachines[instance] = m
+		}
+	}
+
+	// Filter the list of instances that are suitable for
+	// distribution, and then map them back to machines.
+	if instances, err = distributeUnit(u, instances); err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines := make([]*Machine, len(instances), len(instances)+len(unprovisioned))
+	for i, instance := range instances {
+		m, ok := instanceMachines[instance]
+		if !ok {
+			return nil, errors.Errorf("invalid instance returned: %v", instance)
+		}
+		machines[i] = m
+	}
+	return append(machines, unprovisioned...), nil
+}
+
+// assignToCleanMachineOps returns the operations which assign the unit
+// to the first suitable candidate machine, along with that machine.
+// The operations assert that the machine is still clean (and empty, if
+// requireEmpty is set) and that no other unit of the application has
+// been placed since the token was read.
+func (u *Unit) assignToCleanMachineOps(
+	candidates []*Machine,
+	requireEmpty bool,
+	storageParams *machineStorageParams,
+	token placementToken,
+) (*Machine, []txn.Op, error) {
+	for _, m := range candidates {
+		// Check that the unit storage is compatible with
+		// the machine in question.
+		if err := validateDynamicMachineStorageParams(m, storageParams); err != nil {
+			if errors.IsNotSupported(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		ops, err := u.assignToMachineOps(m, true)
+		switch errors.Cause(err) {
+		case nil:
+		case inUseErr, machineNotAliveErr:
+			continue
+		case jujutxn.ErrNoOperations:
+			// The unit is already assigned to the machine.
+			return m, nil, err
+		default:
+			return nil, nil, err
+		}
+		if requireEmpty {
+			ops = append(ops, txn.Op{
+				C:      containerRefsC,
+				Id:     m.doc.DocID,
+				Assert: bson.D{hasNoContainersTerm},
+			})
+		}
+		return m, append(ops, token.op()), nil
+	}
+	return nil, nil, noCleanMachines
+}
@@ -122,18 +122,23 @@ func (st *State) constraintsValidator() (constraints.Validator, error) {
 	return validator, nil
 }
 
-// resolveConstraints combines the given constraints with the environ constraints to get
-// a constraints which will be used to create a new instance.
+// resolveConstraints combines the given constraints with the environ constraints,
+// and the default constraints inherited by the model, to get a constraints which
+// will be used to create a new instance.
 func (st *State) resolveConstraints(cons constraints.Value) (constraints.Value, error) {
 	validator, err := st.constraintsValidator()
 	if err != nil {
 		return constraints.Value{}, err
 	}
-	envCons, err := st.ModelConstraints()
+	levels, err := st.modelConstraintsLevels()
 	if err != nil {
 		return constraints.Value{}, err
 	}
-	return validator.Merge(envCons, cons)
+	envCons, err := mergeConstraintsLevels(validator, levels)
+	if err != nil {
+		return constraints.Value{}, err
+	}
+	return validator.Merge(envCons.Value, cons)
 }
 
 // validateConstraints returns an error if the given constraints are not valid for the
@@ -146,6 +151,13 @@ func (st *State) validateConstraints(cons constraints.Value) ([]string, error) {
 	return validator.Validate(cons)
 }
 
+// ValidateConstraints returns an error if the given constraints are not
+// valid for the current model, and also any unsupported attributes. It
+// does not modify the model.
+func (st *State) ValidateConstraints(cons constraints.Value) ([]string, error) {
+	return st.validateConstraints(cons)
+}
+
 // validate calls the state's assigned policy, if non-nil, to obtain
 // a config.Validator, and calls Validate if a non-nil config.Validator is
 // returned.
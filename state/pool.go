@@ -22,9 +22,59 @@ func NewStatePool(systemState *State) *StatePool {
 // StatePool is a simple cache of State instances for multiple models.
 type StatePool struct {
 	systemState *State
-	// mu protects pool
-	mu   sync.Mutex
-	pool map[string]*State
+	// mu protects pool, readCache and txnBatching
+	mu          sync.Mutex
+	pool        map[string]*State
+	readCache   bool
+	txnBatching *TxnBatcherConfig
+}
+
+// EnableReadCache enables the read cache of the system State, and of
+// every State subsequently returned by Get. See State.EnableReadCache.
+func (p *StatePool) EnableReadCache() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.readCache {
+		return errors.AlreadyExistsf("read cache")
+	}
+	// The system State outlives the pool, and may already have
+	// had its read cache enabled by an earlier pool.
+	if err := p.systemState.EnableReadCache(); err != nil && !errors.IsAlreadyExists(err) {
+		return errors.Trace(err)
+	}
+	for _, st := range p.pool {
+		if err := st.EnableReadCache(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	p.readCache = true
+	return nil
+}
+
+// EnableTxnBatching enables the batching of document updates made
+// through the system State, and through every State subsequently
+// returned by Get. See State.EnableTxnBatching.
+func (p *StatePool) EnableTxnBatching(config TxnBatcherConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.txnBatching != nil {
+		return errors.AlreadyExistsf("transaction batching")
+	}
+	// The system State outlives the pool, and may already have
+	// had batching enabled by an earlier pool.
+	if err := p.systemState.EnableTxnBatching(config); err != nil && !errors.IsAlreadyExists(err) {
+		return errors.Trace(err)
+	}
+	for _, st := range p.pool {
+		if err := st.EnableTxnBatching(config); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	p.txnBatching = &config
+	return nil
 }
 
 // Get returns a State for a given model from the pool, creating
@@ -46,6 +96,18 @@ func (p *StatePool) Get(modelUUID string) (*State, error) {
 	if err != nil {
 		return nil, errors.Annotatef(err, "failed to create state for model %v", modelUUID)
 	}
+	if p.readCache {
+		if err := st.EnableReadCache(); err != nil {
+			st.Close()
+			return nil, errors.Trace(err)
+		}
+	}
+	if p.txnBatching != nil {
+		if err := st.EnableTxnBatching(*p.txnBatching); err != nil {
+			st.Close()
+			return nil, errors.Trace(err)
+		}
+	}
 	p.pool[modelUUID] = st
 	return st, nil
 }
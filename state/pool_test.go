@@ -4,11 +4,14 @@
 package state_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
 )
 
 type statePoolSuite struct {
@@ -103,3 +106,60 @@ func (s *statePoolSuite) TestClose(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(st2_, gc.Not(gc.Equals), st2)
 }
+
+func (s *statePoolSuite) TestEnableReadCache(c *gc.C) {
+	p := state.NewStatePool(s.State)
+	defer p.Close()
+
+	st1, err := p.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.EnableReadCache()
+	c.Assert(err, jc.ErrorIsNil)
+	st2, err := p.Get(s.ModelUUID2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, st := range []*state.State{s.State, st1, st2} {
+		err := st.EnableReadCache()
+		c.Check(err, gc.ErrorMatches, "read cache already exists")
+	}
+
+	err = p.EnableReadCache()
+	c.Assert(err, gc.ErrorMatches, "read cache already exists")
+
+	// A new pool for the same system State may enable caching.
+	p2 := state.NewStatePool(s.State)
+	defer p2.Close()
+	err = p2.EnableReadCache()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *statePoolSuite) TestEnableTxnBatching(c *gc.C) {
+	p := state.NewStatePool(s.State)
+	defer p.Close()
+	config := state.TxnBatcherConfig{
+		Clock:         testing.NewClock(time.Now()),
+		FlushInterval: time.Second,
+		MaxOps:        10,
+	}
+
+	st1, err := p.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.EnableTxnBatching(config)
+	c.Assert(err, jc.ErrorIsNil)
+	st2, err := p.Get(s.ModelUUID2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, st := range []*state.State{s.State, st1, st2} {
+		err := st.EnableTxnBatching(config)
+		c.Check(err, gc.ErrorMatches, "transaction batching already exists")
+	}
+
+	err = p.EnableTxnBatching(config)
+	c.Assert(err, gc.ErrorMatches, "transaction batching already exists")
+
+	// A new pool for the same system State may enable batching.
+	p2 := state.NewStatePool(s.State)
+	defer p2.Close()
+	err = p2.EnableTxnBatching(config)
+	c.Assert(err, jc.ErrorIsNil)
+}
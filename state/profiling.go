@@ -0,0 +1,285 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/mongo"
+)
+
+// slowOperationsKept is the number of slow operations kept; once it
+// is reached, each slow operation recorded replaces the oldest.
+const slowOperationsKept = 100
+
+// SlowOperation describes a mongo query or transaction which took
+// longer than the slow operation threshold.
+type SlowOperation struct {
+	// Kind is "query" for a query, or "txn" for a transaction.
+	Kind string
+
+	// Description describes the query or transaction: the collection
+	// queried and the method used, or the collections changed.
+	Description string
+
+	// Started is the time at which the operation started.
+	Started time.Time
+
+	// Duration is the time the operation took.
+	Duration time.Duration
+}
+
+// SetSlowOperationThreshold sets the duration beyond which the mongo
+// queries and transactions run by the States in this process are
+// recorded as slow. Zero stops them being recorded.
+func SetSlowOperationThreshold(threshold time.Duration) {
+	slowOperations.setThreshold(threshold)
+}
+
+// SlowOperationThreshold returns the duration beyond which the mongo
+// queries and transactions run by the States in this process are
+// recorded as slow, or zero if they are not recorded.
+func SlowOperationThreshold() time.Duration {
+	return slowOperations.getThreshold()
+}
+
+// SlowOperations returns the most recent slow operations recorded
+// for the States in this process, oldest first.
+func SlowOperations() []SlowOperation {
+	return slowOperations.operations()
+}
+
+// slowOperations records the slow operations of all databases.
+var slowOperations = newSlowOperationLog(controller.DefaultSlowOperationThreshold, slowOperationsKept)
+
+// slowOperationLog keeps the most recent operations which took longer
+// than its threshold in a ring buffer.
+type slowOperationLog struct {
+	mu        sync.Mutex
+	threshold time.Duration
+	ops       []SlowOperation
+	next      int
+}
+
+func newSlowOperationLog(threshold time.Duration, size int) *slowOperationLog {
+	return &slowOperationLog{
+		threshold: threshold,
+		ops:       make([]SlowOperation, 0, size),
+	}
+}
+
+func (l *slowOperationLog) setThreshold(threshold time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.threshold = threshold
+}
+
+func (l *slowOperationLog) getThreshold() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.threshold
+}
+
+// observe records the operation which started at the given time, if
+// it has taken longer than the threshold. The description is only
+// evaluated if the operation is recorded.
+func (l *slowOperationLog) observe(kind string, started time.Time, describe func() string) {
+	duration := time.Since(started)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.threshold <= 0 || duration < l.threshold {
+		return
+	}
+	op := SlowOperation{
+		Kind:        kind,
+		Description: describe(),
+		Started:     started,
+		Duration:    duration,
+	}
+	if len(l.ops) < cap(l.ops) {
+		l.ops = append(l.ops, op)
+		return
+	}
+	l.ops[l.next] = op
+	l.next = (l.next + 1) % len(l.ops)
+}
+
+func (l *slowOperationLog) operations() []SlowOperation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := make([]SlowOperation, 0, len(l.ops))
+	result = append(result, l.ops[l.next:]...)
+	return append(result, l.ops[:l.next]...)
+}
+
+// profilingCollection is a mongo.Collection whose queries are
+// recorded if they are slow.
+type profilingCollection struct {
+	mongo.Collection
+}
+
+// Count is part of the mongo.Collection interface.
+func (c profilingCollection) Count() (int, error) {
+	defer c.observe("Count", time.Now())
+	return c.Collection.Count()
+}
+
+// Find is part of the mongo.Collection interface.
+func (c profilingCollection) Find(query interface{}) mongo.Query {
+	return profilingQuery{c.Collection.Find(query), c.Name()}
+}
+
+// FindId is part of the mongo.Collection interface.
+func (c profilingCollection) FindId(id interface{}) mongo.Query {
+	return profilingQuery{c.Collection.FindId(id), c.Name()}
+}
+
+func (c profilingCollection) observe(method string, started time.Time) {
+	slowOperations.observe("query", started, func() string {
+		return c.Name() + " " + method
+	})
+}
+
+// profilingQuery is a mongo.Query which is recorded if it is slow.
+// Iterators are not profiled, as their results are read lazily.
+type profilingQuery struct {
+	mongo.Query
+	collection string
+}
+
+func (q profilingQuery) observe(method string, started time.Time) {
+	slowOperations.observe("query", started, func() string {
+		return q.collection + " " + method
+	})
+}
+
+func (q profilingQuery) All(result interface{}) error {
+	defer q.observe("All", time.Now())
+	return q.Query.All(result)
+}
+
+func (q profilingQuery) Apply(change mgo.Change, result interface{}) (*mgo.ChangeInfo, error) {
+	defer q.observe("Apply", time.Now())
+	return q.Query.Apply(change, result)
+}
+
+func (q profilingQuery) Count() (int, error) {
+	defer q.observe("Count", time.Now())
+	return q.Query.Count()
+}
+
+func (q profilingQuery) Distinct(key string, result interface{}) error {
+	defer q.observe("Distinct", time.Now())
+	return q.Query.Distinct(key, result)
+}
+
+func (q profilingQuery) One(result interface{}) error {
+	defer q.observe("One", time.Now())
+	return q.Query.One(result)
+}
+
+func (q profilingQuery) Batch(n int) mongo.Query {
+	return profilingQuery{q.Query.Batch(n), q.collection}
+}
+
+func (q profilingQuery) Comment(comment string) mongo.Query {
+	return profilingQuery{q.Query.Comment(comment), q.collection}
+}
+
+func (q profilingQuery) Hint(indexKey ...string) mongo.Query {
+	return profilingQuery{q.Query.Hint(indexKey...), q.collection}
+}
+
+func (q profilingQuery) Limit(n int) mongo.Query {
+	return profilingQuery{q.Query.Limit(n), q.collection}
+}
+
+func (q profilingQuery) LogReplay() mongo.Query {
+	return profilingQuery{q.Query.LogReplay(), q.collection}
+}
+
+func (q profilingQuery) Prefetch(p float64) mongo.Query {
+	return profilingQuery{q.Query.Prefetch(p), q.collection}
+}
+
+func (q profilingQuery) Select(selector interface{}) mongo.Query {
+	return profilingQuery{q.Query.Select(selector), q.collection}
+}
+
+func (q profilingQuery) SetMaxScan(n int) mongo.Query {
+	return profilingQuery{q.Query.SetMaxScan(n), q.collection}
+}
+
+func (q profilingQuery) SetMaxTime(d time.Duration) mongo.Query {
+	return profilingQuery{q.Query.SetMaxTime(d), q.collection}
+}
+
+func (q profilingQuery) Skip(n int) mongo.Query {
+	return profilingQuery{q.Query.Skip(n), q.collection}
+}
+
+func (q profilingQuery) Snapshot() mongo.Query {
+	return profilingQuery{q.Query.Snapshot(), q.collection}
+}
+
+func (q profilingQuery) Sort(fields ...string) mongo.Query {
+	return profilingQuery{q.Query.Sort(fields...), q.collection}
+}
+
+// describeTxn describes a transaction made up of the given operations,
+// which took the given number of attempts.
+func describeTxn(ops []txn.Op, attempts int) string {
+	seen := make(map[string]bool)
+	var collections []string
+	for _, op := range ops {
+		if !seen[op.C] {
+			seen[op.C] = true
+			collections = append(collections, op.C)
+		}
+	}
+	sort.Strings(collections)
+	description := fmt.Sprintf("%s (%d ops", strings.Join(collections, ", "), len(ops))
+	if attempts > 1 {
+		description += fmt.Sprintf(", %d attempts", attempts)
+	}
+	return description + ")"
+}
+
+// profilingRunner is a jujutxn.Runner which records the transactions
+// run by another if they are slow.
+type profilingRunner struct {
+	jujutxn.Runner
+}
+
+// RunTransaction is part of the jujutxn.Runner interface.
+func (r profilingRunner) RunTransaction(ops []txn.Op) error {
+	defer slowOperations.observe("txn", time.Now(), func() string {
+		return describeTxn(ops, 1)
+	})
+	return r.Runner.RunTransaction(ops)
+}
+
+// Run is part of the jujutxn.Runner interface. The transaction is
+// described by the operations of its last attempt.
+func (r profilingRunner) Run(transactions jujutxn.TransactionSource) error {
+	var lastOps []txn.Op
+	attempts := 0
+	defer slowOperations.observe("txn", time.Now(), func() string {
+		return describeTxn(lastOps, attempts)
+	})
+	return r.Runner.Run(func(attempt int) ([]txn.Op, error) {
+		ops, err := transactions(attempt)
+		lastOps, attempts = ops, attempt+1
+		return ops, err
+	})
+}
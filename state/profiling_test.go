@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/testing"
+)
+
+type ProfilingSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ProfilingSuite{})
+
+func (s *ProfilingSuite) describe(description string) func() string {
+	return func() string { return description }
+}
+
+func (s *ProfilingSuite) TestSlowOperationLogThreshold(c *gc.C) {
+	log := newSlowOperationLog(time.Hour, 3)
+	log.observe("query", time.Now(), s.describe("fast"))
+	c.Assert(log.operations(), gc.HasLen, 0)
+
+	started := time.Now().Add(-2 * time.Hour)
+	log.observe("txn", started, s.describe("slow"))
+	ops := log.operations()
+	c.Assert(ops, gc.HasLen, 1)
+	c.Assert(ops[0].Kind, gc.Equals, "txn")
+	c.Assert(ops[0].Description, gc.Equals, "slow")
+	c.Assert(ops[0].Started, gc.Equals, started)
+	c.Assert(ops[0].Duration >= 2*time.Hour, jc.IsTrue)
+
+	log.setThreshold(0)
+	log.observe("txn", started, s.describe("ignored"))
+	c.Assert(log.operations(), gc.HasLen, 1)
+}
+
+func (s *ProfilingSuite) TestSlowOperationLogKeepsMostRecent(c *gc.C) {
+	log := newSlowOperationLog(time.Nanosecond, 3)
+	started := time.Now().Add(-time.Second)
+	for _, description := range []string{"a", "b", "c", "d", "e"} {
+		log.observe("query", started, s.describe(description))
+	}
+	var descriptions []string
+	for _, op := range log.operations() {
+		descriptions = append(descriptions, op.Description)
+	}
+	c.Assert(descriptions, jc.DeepEquals, []string{"c", "d", "e"})
+}
+
+func (s *ProfilingSuite) TestDescribeTxn(c *gc.C) {
+	ops := []txn.Op{
+		{C: unitsC, Id: "a"},
+		{C: applicationsC, Id: "b"},
+		{C: unitsC, Id: "c"},
+	}
+	c.Check(describeTxn(ops, 1), gc.Equals, "applications, units (3 ops)")
+	c.Check(describeTxn(ops, 3), gc.Equals, "applications, units (3 ops, 3 attempts)")
+}
+
+func (s *ProfilingSuite) TestProfilingRunner(c *gc.C) {
+	log := newSlowOperationLog(time.Nanosecond, 10)
+	s.PatchValue(&slowOperations, log)
+
+	runner := profilingRunner{&recordingRunner{}}
+	err := runner.RunTransaction([]txn.Op{{C: machinesC, Id: "0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = runner.Run(func(int) ([]txn.Op, error) {
+		return []txn.Op{{C: unitsC, Id: "a"}, {C: unitsC, Id: "b"}}, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ops := log.operations()
+	c.Assert(ops, gc.HasLen, 2)
+	c.Check(ops[0].Kind, gc.Equals, "txn")
+	c.Check(ops[0].Description, gc.Equals, "machines (1 ops)")
+	c.Check(ops[1].Kind, gc.Equals, "txn")
+	c.Check(ops[1].Description, gc.Equals, "units (2 ops, 43 attempts)")
+}
+
+func (s *ProfilingSuite) TestProfilingCollection(c *gc.C) {
+	log := newSlowOperationLog(time.Nanosecond, 10)
+	s.PatchValue(&slowOperations, log)
+
+	coll := profilingCollection{stubCollection{}}
+	var doc struct{}
+	err := coll.FindId("0").Sort("_id").Limit(1).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+
+	ops := log.operations()
+	c.Assert(ops, gc.HasLen, 2)
+	c.Check(ops[0].Kind, gc.Equals, "query")
+	c.Check(ops[0].Description, gc.Equals, "machines One")
+	c.Check(ops[1].Kind, gc.Equals, "query")
+	c.Check(ops[1].Description, gc.Equals, "machines Count")
+}
+
+type stubCollection struct {
+	mongo.Collection
+}
+
+func (stubCollection) Name() string { return machinesC }
+
+func (stubCollection) Count() (int, error) { return 0, nil }
+
+func (stubCollection) FindId(interface{}) mongo.Query { return stubQuery{} }
+
+type stubQuery struct {
+	mongo.Query
+}
+
+func (q stubQuery) Sort(...string) mongo.Query { return q }
+
+func (q stubQuery) Limit(int) mongo.Query { return q }
+
+func (stubQuery) One(interface{}) error { return nil }
@@ -0,0 +1,295 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/state/workers"
+)
+
+// ReadCacheGroup identifies a set of collections from which values
+// held by the read cache are computed. A value cached for a group is
+// discarded whenever any of the group's collections changes.
+type ReadCacheGroup string
+
+const (
+	// StatusReadCache is the group of collections which make up
+	// the status of a model.
+	StatusReadCache ReadCacheGroup = "status"
+
+	// SpacesReadCache is the group of collections which describe
+	// the model's network spaces.
+	SpacesReadCache ReadCacheGroup = "spaces"
+
+	// StorageReadCache is the group of collections which describe
+	// the model's storage.
+	StorageReadCache ReadCacheGroup = "storage"
+)
+
+var readCacheCollections = map[ReadCacheGroup][]string{
+	StatusReadCache: {
+		applicationsC,
+		charmsC,
+		constraintsC,
+		containerRefsC,
+		endpointBindingsC,
+		instanceDataC,
+		leasesC,
+		machinesC,
+		meterStatusC,
+		migrationsStatusC,
+		modelsC,
+		openedPortsC,
+		relationScopesC,
+		relationsC,
+		settingsC,
+		statusesC,
+		unitsC,
+	},
+	SpacesReadCache: {
+		spacesC,
+		subnetsC,
+	},
+	StorageReadCache: {
+		filesystemAttachmentsC,
+		filesystemsC,
+		statusesC,
+		storageAttachmentsC,
+		storageInstancesC,
+		unitsC,
+		volumeAttachmentsC,
+		volumesC,
+	},
+}
+
+// readCacheMaxAge is the longest time for which a value is cached.
+// Some inputs to cached values, such as agent presence, are not
+// recorded in the transaction log, so values cannot be kept current
+// by the watchers alone.
+const readCacheMaxAge = 10 * time.Second
+
+// EnableReadCache causes CachedRead to reuse the values it computes
+// until the transaction log watcher reports a change to any of the
+// collections they were computed from, or for at most readCacheMaxAge.
+// As the watcher polls the log, a cached value may lag a change by up
+// to the watcher's polling period. Caching stops when the State is
+// closed.
+func (st *State) EnableReadCache() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.readCache != nil {
+		return errors.AlreadyExistsf("read cache")
+	}
+	st.readCache = newReadCache(st)
+	return nil
+}
+
+// getReadCache returns the read cache enabled for the State, or nil if
+// the read cache is not enabled.
+func (st *State) getReadCache() *readCache {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.readCache
+}
+
+// CachedRead returns the value last returned by read for the given
+// group and key, if none of the group's collections has changed since
+// it was computed; otherwise it calls read and caches its result.
+// Errors are never cached. If the read cache is not enabled, read is
+// always called.
+//
+// Values may be returned to many callers, which must not modify them.
+func (st *State) CachedRead(group ReadCacheGroup, key string, read func() (interface{}, error)) (interface{}, error) {
+	if _, ok := readCacheCollections[group]; !ok {
+		return nil, errors.NotValidf("read cache group %q", group)
+	}
+	cache := st.getReadCache()
+	if cache == nil {
+		return read()
+	}
+	g := cache.group(group)
+	if g == nil {
+		// The cache has been stopped.
+		return read()
+	}
+	if value, ok := g.lookup(key, st.clock.Now()); ok {
+		return value, nil
+	}
+	// The generation is recorded before reading, so that a change
+	// made while reading invalidates the value.
+	generation := g.currentGeneration()
+	now := st.clock.Now()
+	value, err := read()
+	if err != nil {
+		return nil, err
+	}
+	g.store(key, generation, now, value)
+	return value, nil
+}
+
+// readCache holds the cached values of a State, in one
+// readCacheGroup for each ReadCacheGroup used.
+type readCache struct {
+	st *State
+
+	// mu guards the fields below.
+	mu      sync.Mutex
+	stopped bool
+	groups  map[ReadCacheGroup]*readCacheGroup
+}
+
+func newReadCache(st *State) *readCache {
+	return &readCache{
+		st:     st,
+		groups: make(map[ReadCacheGroup]*readCacheGroup),
+	}
+}
+
+// group returns the running readCacheGroup for the given group,
+// starting it if necessary, or nil if the cache has been stopped.
+func (c *readCache) group(group ReadCacheGroup) *readCacheGroup {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return nil
+	}
+	if g, ok := c.groups[group]; ok {
+		select {
+		case <-g.tomb.Dead():
+			// The watcher failed; start again with a fresh one.
+			logger.Warningf("restarting %s read cache: %v", group, g.tomb.Err())
+		default:
+			return g
+		}
+	}
+	g := newReadCacheGroup(
+		c.st.workers.TxnLogWatcher(),
+		readCacheCollections[group],
+		isLocalOrModelID(c.st),
+	)
+	c.groups[group] = g
+	return g
+}
+
+// stop stops all the cache's groups. Subsequent reads are not cached.
+func (c *readCache) stop() error {
+	c.mu.Lock()
+	c.stopped = true
+	groups := c.groups
+	c.groups = nil
+	c.mu.Unlock()
+
+	var lastErr error
+	for _, g := range groups {
+		g.tomb.Kill(nil)
+		if err := g.tomb.Wait(); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// readCacheGroup holds the values computed from a group of
+// collections, and discards them when any of the collections changes.
+type readCacheGroup struct {
+	tomb    tomb.Tomb
+	watcher workers.TxnLogWatcher
+	in      chan watcher.Change
+
+	// mu guards generation and values.
+	mu         sync.Mutex
+	generation int64
+	values     map[string]readCacheValue
+}
+
+type readCacheValue struct {
+	generation int64
+	read       time.Time
+	value      interface{}
+}
+
+func newReadCacheGroup(w workers.TxnLogWatcher, collections []string, filter func(interface{}) bool) *readCacheGroup {
+	g := &readCacheGroup{
+		watcher: w,
+		in:      make(chan watcher.Change),
+		values:  make(map[string]readCacheValue),
+	}
+	// The collections are watched before the group is used, so
+	// that no change made after a value is read can be missed.
+	for _, coll := range collections {
+		w.WatchCollectionWithFilter(coll, g.in, filter)
+	}
+	go func() {
+		defer g.tomb.Done()
+		defer func() {
+			for _, coll := range collections {
+				w.UnwatchCollection(coll, g.in)
+			}
+		}()
+		g.tomb.Kill(g.loop())
+	}()
+	return g
+}
+
+func (g *readCacheGroup) loop() error {
+	for {
+		select {
+		case <-g.tomb.Dying():
+			return tomb.ErrDying
+		case <-g.watcher.Dead():
+			return stateWatcherDeadError(g.watcher.Err())
+		case <-g.in:
+			g.mu.Lock()
+			g.generation++
+			g.values = make(map[string]readCacheValue)
+			g.mu.Unlock()
+		}
+	}
+}
+
+func (g *readCacheGroup) currentGeneration() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.generation
+}
+
+func (g *readCacheGroup) lookup(key string, now time.Time) (interface{}, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	v, ok := g.values[key]
+	if !ok || v.generation != g.generation {
+		return nil, false
+	}
+	if now.Sub(v.read) >= readCacheMaxAge {
+		delete(g.values, key)
+		return nil, false
+	}
+	return v.value, true
+}
+
+func (g *readCacheGroup) store(key string, generation int64, read time.Time, value interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if generation != g.generation {
+		// The value is already out of date.
+		return
+	}
+	g.values[key] = readCacheValue{generation, read, value}
+}
+
+// isLocalOrModelID returns a filter which accepts the ids of documents
+// belonging to the State's model, including the model document itself.
+func isLocalOrModelID(st *State) func(interface{}) bool {
+	isLocal := isLocalID(st)
+	modelUUID := st.ModelUUID()
+	return func(id interface{}) bool {
+		return id == modelUUID || isLocal(id)
+	}
+}
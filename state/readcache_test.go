@@ -0,0 +1,130 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ReadCacheSuite struct {
+	statetesting.StateSuite
+	reads int
+}
+
+var _ = gc.Suite(&ReadCacheSuite{})
+
+func (s *ReadCacheSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.reads = 0
+}
+
+func (s *ReadCacheSuite) read() (interface{}, error) {
+	s.reads++
+	return s.reads, nil
+}
+
+func (s *ReadCacheSuite) cachedRead(c *gc.C, group state.ReadCacheGroup, key string) int {
+	value, err := s.State.CachedRead(group, key, s.read)
+	c.Assert(err, jc.ErrorIsNil)
+	return value.(int)
+}
+
+func (s *ReadCacheSuite) TestNotEnabled(c *gc.C) {
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "key"), gc.Equals, 1)
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "key"), gc.Equals, 2)
+}
+
+func (s *ReadCacheSuite) TestEnableTwice(c *gc.C) {
+	err := s.State.EnableReadCache()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.EnableReadCache()
+	c.Assert(err, gc.ErrorMatches, "read cache already exists")
+}
+
+func (s *ReadCacheSuite) TestInvalidGroup(c *gc.C) {
+	_, err := s.State.CachedRead("bogus", "key", s.read)
+	c.Assert(err, gc.ErrorMatches, `read cache group "bogus" not valid`)
+	c.Assert(s.reads, gc.Equals, 0)
+}
+
+func (s *ReadCacheSuite) TestCachesByKey(c *gc.C) {
+	err := s.State.EnableReadCache()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "a"), gc.Equals, 1)
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "a"), gc.Equals, 1)
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "b"), gc.Equals, 2)
+	c.Assert(s.cachedRead(c, state.StorageReadCache, "a"), gc.Equals, 3)
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "b"), gc.Equals, 2)
+}
+
+func (s *ReadCacheSuite) TestErrorsNotCached(c *gc.C) {
+	err := s.State.EnableReadCache()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.CachedRead(state.StatusReadCache, "key", func() (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "key"), gc.Equals, 1)
+}
+
+func (s *ReadCacheSuite) TestInvalidatedByChange(c *gc.C) {
+	err := s.State.EnableReadCache()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "key"), gc.Equals, 1)
+	c.Assert(s.cachedRead(c, state.SpacesReadCache, "key"), gc.Equals, 2)
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+
+	for a := coretesting.LongAttempt.Start(); ; {
+		if s.cachedRead(c, state.StatusReadCache, "key") != 1 {
+			break
+		}
+		if !a.Next() {
+			c.Fatalf("cached value not invalidated")
+		}
+	}
+	// Spaces are unaffected by machine changes.
+	c.Assert(s.cachedRead(c, state.SpacesReadCache, "key"), gc.Equals, 2)
+}
+
+func (s *ReadCacheSuite) TestExpires(c *gc.C) {
+	now := time.Now()
+	clk := coretesting.NewClock(now)
+	state.SetClock(s.State, clk)
+	err := s.State.EnableReadCache()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "key"), gc.Equals, 1)
+	clk.Advance(5 * time.Second)
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "key"), gc.Equals, 1)
+	clk.Advance(5 * time.Second)
+	c.Assert(s.cachedRead(c, state.StatusReadCache, "key"), gc.Equals, 2)
+}
+
+func (s *ReadCacheSuite) TestNotCachedAfterClose(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	err := st.EnableReadCache()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.CachedRead(state.StatusReadCache, "key", s.read)
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	value, err := st.CachedRead(state.StatusReadCache, "key", s.read)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, 2)
+}
@@ -43,6 +43,34 @@ type relationDoc struct {
 	Endpoints []Endpoint
 	Life      Life
 	UnitCount int
+
+	// SubordinatePlacement holds the policy controlling which
+	// principal units are given subordinate units, for relations
+	// with container scope.
+	SubordinatePlacement *subordinatePlacementDoc `bson:"subordinate-placement,omitempty"`
+}
+
+// subordinatePlacementDoc is the internal representation of a
+// SubordinatePlacement in MongoDB.
+type subordinatePlacementDoc struct {
+	ExcludeControllers bool     `bson:"exclude-controllers,omitempty"`
+	ExcludeMachines    []string `bson:"exclude-machines,omitempty"`
+}
+
+// SubordinatePlacement describes which principal units entering the
+// scope of a relation with container scope are given a subordinate
+// unit. As it is held per relation, a subordinate application related
+// to the endpoints of several principal applications may be placed
+// differently for each of them.
+type SubordinatePlacement struct {
+	// ExcludeControllers prevents subordinate units being created
+	// for principal units on controller machines.
+	ExcludeControllers bool
+
+	// ExcludeMachines holds the ids of machines whose principal
+	// units, including those in containers on them, are not given
+	// subordinate units.
+	ExcludeMachines []string
 }
 
 // Relation represents a relation between one or two service endpoints.
@@ -200,7 +228,11 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 			hasLastRef := bson.D{{"life", Dying}, {"unitcount", 0}, {"relationcount", 1}}
 			removable := append(bson.D{{"_id", ep.ApplicationName}}, hasLastRef...)
 			if err := applications.Find(removable).One(&svc.doc); err == nil {
-				ops = append(ops, svc.removeOps(hasLastRef)...)
+				removeOps, err := svc.removeOps(hasLastRef)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, removeOps...)
 				continue
 			} else if err != mgo.ErrNotFound {
 				return nil, err
@@ -248,6 +280,100 @@ func (r *Relation) Endpoints() []Endpoint {
 	return r.doc.Endpoints
 }
 
+// SubordinatePlacement returns the policy controlling which principal
+// units are given subordinate units by the relation.
+func (r *Relation) SubordinatePlacement() SubordinatePlacement {
+	doc := r.doc.SubordinatePlacement
+	if doc == nil {
+		return SubordinatePlacement{}
+	}
+	return SubordinatePlacement{
+		ExcludeControllers: doc.ExcludeControllers,
+		ExcludeMachines:    doc.ExcludeMachines,
+	}
+}
+
+// SetSubordinatePlacement sets the policy controlling which principal
+// units are given subordinate units by the relation, which must have
+// container scope. The policy is honoured as principal units enter the
+// relation's scope; existing subordinate units are not affected.
+func (r *Relation) SetSubordinatePlacement(placement SubordinatePlacement) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set subordinate placement for relation %q", r)
+	if !r.hasContainerScope() {
+		return errors.NotValidf("relation without container scope")
+	}
+	for _, id := range placement.ExcludeMachines {
+		if !names.IsValidMachine(id) {
+			return errors.NotValidf("machine id %q", id)
+		}
+	}
+	var doc *subordinatePlacementDoc
+	if placement.ExcludeControllers || len(placement.ExcludeMachines) > 0 {
+		doc = &subordinatePlacementDoc{
+			ExcludeControllers: placement.ExcludeControllers,
+			ExcludeMachines:    placement.ExcludeMachines,
+		}
+	}
+	update := bson.D{{"$set", bson.D{{"subordinate-placement", doc}}}}
+	if doc == nil {
+		update = bson.D{{"$unset", bson.D{{"subordinate-placement", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      relationsC,
+		Id:     r.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := r.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.New("relation is no longer alive")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	r.doc.SubordinatePlacement = doc
+	return nil
+}
+
+// hasContainerScope returns whether the relation has container scope.
+func (r *Relation) hasContainerScope() bool {
+	for _, ep := range r.doc.Endpoints {
+		if ep.Scope == charm.ScopeContainer {
+			return true
+		}
+	}
+	return false
+}
+
+// excludesUnit returns whether the subordinate placement policy of
+// the relation prevents the given principal unit from being given a
+// subordinate unit.
+func (p SubordinatePlacement) excludesUnit(st *State, unit *Unit) (bool, error) {
+	if !p.ExcludeControllers && len(p.ExcludeMachines) == 0 {
+		return false, nil
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	hostId := strings.SplitN(machineId, "/", 2)[0]
+	for _, id := range p.ExcludeMachines {
+		if id == machineId || id == hostId {
+			return true, nil
+		}
+	}
+	if p.ExcludeControllers {
+		host, err := st.Machine(hostId)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if host.IsManager() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // RelatedEndpoints returns the endpoints of the relation r with which
 // units of the named service will establish relations. If the service
 // is not part of the relation r, an error will be returned.
@@ -232,6 +232,59 @@ func (s *RelationSuite) TestAddContainerRelationWithTwoSubordinates(c *gc.C) {
 	assertOneRelation(c, logging2, 0, logging2EP, logging1EP)
 }
 
+func (s *RelationSuite) TestSetSubordinatePlacement(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.SubordinatePlacement(), jc.DeepEquals, state.SubordinatePlacement{})
+
+	placement := state.SubordinatePlacement{
+		ExcludeControllers: true,
+		ExcludeMachines:    []string{"1", "2/lxd/0"},
+	}
+	err = rel.SetSubordinatePlacement(placement)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.SubordinatePlacement(), jc.DeepEquals, placement)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.SubordinatePlacement(), jc.DeepEquals, placement)
+
+	err = rel.SetSubordinatePlacement(state.SubordinatePlacement{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.SubordinatePlacement(), jc.DeepEquals, state.SubordinatePlacement{})
+}
+
+func (s *RelationSuite) TestSetSubordinatePlacementErrors(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSubordinatePlacement(state.SubordinatePlacement{ExcludeControllers: true})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `cannot set subordinate placement for relation "wordpress:db mysql:server": relation without container scope not valid`)
+
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err = s.State.InferEndpoints("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSubordinatePlacement(state.SubordinatePlacement{ExcludeMachines: []string{"foo"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `cannot set subordinate placement for relation "logging:info wordpress:juju-info": machine id "foo" not valid`)
+
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSubordinatePlacement(state.SubordinatePlacement{ExcludeControllers: true})
+	c.Assert(err, gc.ErrorMatches, `cannot set subordinate placement for relation "logging:info wordpress:juju-info": relation is no longer alive`)
+}
+
 func (s *RelationSuite) TestDestroyRelation(c *gc.C) {
 	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
@@ -0,0 +1,300 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/status"
+)
+
+// Each unit in a relation scope records the counterpart units it has
+// joined, so that a unit leaving the relation can run relation-broken
+// only once every counterpart which joined it has run relation-departed
+// for it. A departing unit first calls PrepareLeaveScope, so that its
+// counterparts see it depart; it then waits for PendingDepartures to
+// become empty.
+//
+// Counterparts which have not recorded joining the unit, including
+// those run by agents which predate the records, are not waited for;
+// nor are counterparts which are no longer alive, or whose agents are
+// in error, since they may never run relation-departed.
+
+// SetCounterpartJoined records that the unit has run relation-joined
+// for the named counterpart unit. It is not an error if the unit is not
+// in scope.
+func (ru *RelationUnit) SetCounterpartJoined(unitName string) error {
+	return ru.updateJoinedUnits("$addToSet", unitName)
+}
+
+// SetCounterpartDeparted records that the unit has run relation-departed
+// for the named counterpart unit, acknowledging its departure. It is not
+// an error if the unit is not in scope.
+func (ru *RelationUnit) SetCounterpartDeparted(unitName string) error {
+	return ru.updateJoinedUnits("$pull", unitName)
+}
+
+func (ru *RelationUnit) updateJoinedUnits(operator, unitName string) error {
+	if !names.IsValidUnit(unitName) {
+		return errors.NotValidf("unit name %q", unitName)
+	}
+	if _, err := ru.unitKey(unitName); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      relationScopesC,
+		Id:     ru.key(),
+		Assert: txn.DocExists,
+		Update: bson.D{{operator, bson.D{{"joined-units", unitName}}}},
+	}}
+	err := ru.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		// The unit is not in scope, so there is nothing to record.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record %q in relation %q for unit %q", unitName, ru.relation, ru.unit)
+	}
+	return nil
+}
+
+// counterpartScopePrefix returns the prefix of the keys of the scope
+// documents of the unit's counterparts.
+func (ru *RelationUnit) counterpartScopePrefix() string {
+	return ru._key(string(counterpartRole(ru.endpoint.Role)), "")
+}
+
+// PendingDepartures returns the names, sorted, of the alive counterpart
+// units in scope which have run relation-joined for the unit, and not
+// yet run relation-departed, excluding those whose agents are in error.
+// A unit leaving the relation should not run relation-broken while any
+// remain.
+func (ru *RelationUnit) PendingDepartures() ([]string, error) {
+	relationScopes, closer := ru.st.getCollection(relationScopesC)
+	defer closer()
+
+	sel := bson.D{
+		{"key", bson.D{{"$regex", "^" + ru.counterpartScopePrefix()}}},
+		{"joined-units", ru.unit.Name()},
+	}
+	var docs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read pending departures of unit %q in relation %q", ru.unit, ru.relation)
+	}
+	var pending []string
+	for _, doc := range docs {
+		unitName := doc.unitName()
+		waiting, err := ru.awaitDeparture(unitName)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot read pending departures of unit %q in relation %q", ru.unit, ru.relation)
+		}
+		if waiting {
+			pending = append(pending, unitName)
+		}
+	}
+	sort.Strings(pending)
+	return pending, nil
+}
+
+// awaitDeparture returns whether the unit should wait for the named
+// counterpart to run relation-departed: that is, whether the counterpart
+// is alive and its agent is not in error.
+func (ru *RelationUnit) awaitDeparture(unitName string) (bool, error) {
+	unit, err := ru.st.Unit(unitName)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if unit.Life() != Alive {
+		return false, nil
+	}
+	agentStatus, err := unit.AgentStatus()
+	if errors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return agentStatus.Status != status.Error, nil
+}
+
+// WatchPendingDepartures returns a watcher which notifies of changes to
+// the scope documents of the unit's counterparts, and to the life and
+// agent status of the counterpart units, which may change the result of
+// PendingDepartures.
+func (ru *RelationUnit) WatchPendingDepartures() NotifyWatcher {
+	return newPendingDeparturesWatcher(ru)
+}
+
+// pendingDeparturesWatcher notifies of changes which may change the
+// result of a relation unit's PendingDepartures.
+type pendingDeparturesWatcher struct {
+	commonWatcher
+	scopePrefix  string
+	applications set.Strings
+	sink         chan struct{}
+}
+
+func newPendingDeparturesWatcher(ru *RelationUnit) NotifyWatcher {
+	applications := set.NewStrings()
+	for _, ep := range ru.relation.Endpoints() {
+		if ep.Role == counterpartRole(ru.endpoint.Role) {
+			applications.Add(ep.ApplicationName)
+		}
+	}
+	w := &pendingDeparturesWatcher{
+		commonWatcher: newCommonWatcher(ru.st),
+		scopePrefix:   ru.st.docID(ru.counterpartScopePrefix()),
+		applications:  applications,
+		sink:          make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.sink)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *pendingDeparturesWatcher) Changes() <-chan struct{} {
+	return w.sink
+}
+
+// scopeFilter accepts the ids of the counterparts' scope documents.
+func (w *pendingDeparturesWatcher) scopeFilter(id interface{}) bool {
+	k, ok := id.(string)
+	return ok && strings.HasPrefix(k, w.scopePrefix)
+}
+
+// unitFilter accepts the ids of the counterpart units' documents.
+func (w *pendingDeparturesWatcher) unitFilter(id interface{}) bool {
+	k, ok := id.(string)
+	if !ok {
+		return false
+	}
+	unitName, err := w.st.strictLocalID(k)
+	return err == nil && w.isCounterpart(unitName)
+}
+
+// agentStatusFilter accepts the ids of the status documents of the
+// counterpart units' agents.
+func (w *pendingDeparturesWatcher) agentStatusFilter(id interface{}) bool {
+	k, ok := id.(string)
+	if !ok {
+		return false
+	}
+	key, err := w.st.strictLocalID(k)
+	if err != nil || !strings.HasPrefix(key, "u#") || strings.Count(key, "#") != 1 {
+		return false
+	}
+	return w.isCounterpart(strings.TrimPrefix(key, "u#"))
+}
+
+func (w *pendingDeparturesWatcher) isCounterpart(unitName string) bool {
+	if !names.IsValidUnit(unitName) {
+		return false
+	}
+	applicationName, err := names.UnitApplication(unitName)
+	return err == nil && w.applications.Contains(applicationName)
+}
+
+func (w *pendingDeparturesWatcher) loop() error {
+	scopesCh := make(chan watcher.Change)
+	unitsCh := make(chan watcher.Change)
+	statusesCh := make(chan watcher.Change)
+
+	w.watcher.WatchCollectionWithFilter(relationScopesC, scopesCh, w.scopeFilter)
+	defer w.watcher.UnwatchCollection(relationScopesC, scopesCh)
+	w.watcher.WatchCollectionWithFilter(unitsC, unitsCh, w.unitFilter)
+	defer w.watcher.UnwatchCollection(unitsC, unitsCh)
+	w.watcher.WatchCollectionWithFilter(statusesC, statusesCh, w.agentStatusFilter)
+	defer w.watcher.UnwatchCollection(statusesC, statusesCh)
+
+	out := w.sink // out set so that initial event is sent.
+	for {
+		var in <-chan watcher.Change
+		var change watcher.Change
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change = <-scopesCh:
+			in = scopesCh
+		case change = <-unitsCh:
+			in = unitsCh
+		case change = <-statusesCh:
+			in = statusesCh
+		case out <- struct{}{}:
+			out = nil
+			continue
+		}
+		if _, ok := collect(change, in, w.tomb.Dying()); !ok {
+			return tomb.ErrDying
+		}
+		out = w.sink
+	}
+}
+
+// RelationScopeUnit describes a unit in a relation scope, for
+// diagnosing relations which are slow to be removed.
+type RelationScopeUnit struct {
+	// Unit is the name of the unit.
+	Unit string
+
+	// Role is the role of the unit's endpoint in the relation.
+	Role charm.RelationRole
+
+	// Departing is true if the unit is leaving the relation.
+	Departing bool
+
+	// JoinedUnits holds the counterpart units for which the unit
+	// has run relation-joined, but not yet relation-departed.
+	JoinedUnits []string
+}
+
+// ScopeUnits returns the units in the relation's scopes, sorted by
+// name. A relation cannot be removed until all have left.
+func (r *Relation) ScopeUnits() ([]RelationScopeUnit, error) {
+	relationScopes, closer := r.st.getCollection(relationScopesC)
+	defer closer()
+
+	prefix := fmt.Sprintf("r#%d#", r.Id())
+	sel := bson.D{{"key", bson.D{{"$regex", "^" + prefix}}}}
+	var docs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read scopes of relation %q", r)
+	}
+	units := make([]RelationScopeUnit, len(docs))
+	for i, doc := range docs {
+		parts := strings.Split(doc.Key, "#")
+		joined := append([]string(nil), doc.JoinedUnits...)
+		sort.Strings(joined)
+		units[i] = RelationScopeUnit{
+			Unit:        doc.unitName(),
+			Role:        charm.RelationRole(parts[len(parts)-2]),
+			Departing:   doc.Departing,
+			JoinedUnits: joined,
+		}
+	}
+	sort.Sort(relationScopeUnitsByName(units))
+	return units, nil
+}
+
+type relationScopeUnitsByName []RelationScopeUnit
+
+func (u relationScopeUnitsByName) Len() int           { return len(u) }
+func (u relationScopeUnitsByName) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
+func (u relationScopeUnitsByName) Less(i, j int) bool { return u[i].Unit < u[j].Unit }
@@ -0,0 +1,219 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+)
+
+type RelationDepartureSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RelationDepartureSuite{})
+
+func (s *RelationDepartureSuite) enterScopes(c *gc.C, rus ...*state.RelationUnit) {
+	for _, ru := range rus {
+		err := ru.EnterScope(nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func assertPendingDepartures(c *gc.C, ru *state.RelationUnit, expect ...string) {
+	pending, err := ru.PendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	if len(expect) == 0 {
+		c.Assert(pending, gc.HasLen, 0)
+	} else {
+		c.Assert(pending, jc.DeepEquals, expect)
+	}
+}
+
+func (s *RelationDepartureSuite) TestPendingDepartures(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	s.enterScopes(c, prr.pru0, prr.pru1, prr.rru0, prr.rru1)
+	assertPendingDepartures(c, prr.pru0)
+
+	// Both wordpress units join mysql/0; only one joins mysql/1.
+	err := prr.rru0.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru1.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru1.SetCounterpartJoined("mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	// Units of the same application are not counterparts.
+	err = prr.pru1.SetCounterpartJoined("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertPendingDepartures(c, prr.pru0, "wordpress/0", "wordpress/1")
+	assertPendingDepartures(c, prr.pru1, "wordpress/1")
+	assertPendingDepartures(c, prr.rru0, "mysql/1")
+
+	err = prr.rru1.SetCounterpartDeparted("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	assertPendingDepartures(c, prr.pru0, "wordpress/0")
+
+	// A counterpart which leaves scope is no longer pending.
+	err = prr.rru0.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	assertPendingDepartures(c, prr.pru0)
+}
+
+func (s *RelationDepartureSuite) TestPendingDeparturesIgnoresErrorAgents(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	s.enterScopes(c, prr.pru0, prr.rru0, prr.rru1)
+	err := prr.rru0.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru1.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	assertPendingDepartures(c, prr.pru0, "wordpress/0", "wordpress/1")
+
+	// A counterpart whose agent is in error may never run
+	// relation-departed, so is not waited for.
+	err = prr.ru0.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	assertPendingDepartures(c, prr.pru0, "wordpress/1")
+}
+
+func (s *RelationDepartureSuite) TestPendingDeparturesIgnoresDeadUnits(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	s.enterScopes(c, prr.pru0, prr.rru0, prr.rru1)
+	err := prr.rru0.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru1.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A counterpart which is no longer alive is not waited for.
+	err = prr.ru1.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertPendingDepartures(c, prr.pru0, "wordpress/0")
+}
+
+func (s *RelationDepartureSuite) TestPendingDeparturesPeer(c *gc.C) {
+	pr := NewPeerRelation(c, s.State)
+	s.enterScopes(c, pr.ru0, pr.ru1, pr.ru2)
+
+	err := pr.ru1.SetCounterpartJoined("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = pr.ru2.SetCounterpartJoined("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	assertPendingDepartures(c, pr.ru0, "riak/1", "riak/2")
+	assertPendingDepartures(c, pr.ru1)
+}
+
+func (s *RelationDepartureSuite) TestSetCounterpartNotInScope(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	s.enterScopes(c, prr.pru0)
+
+	err := prr.rru0.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.SetCounterpartDeparted("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	assertPendingDepartures(c, prr.pru0)
+}
+
+func (s *RelationDepartureSuite) TestSetCounterpartInvalid(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	s.enterScopes(c, prr.rru0)
+
+	err := prr.rru0.SetCounterpartJoined("mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+	err = prr.rru0.SetCounterpartDeparted("riak/0")
+	c.Assert(err, gc.ErrorMatches, `application "riak" is not a member of "wordpress:db mysql:server"`)
+}
+
+func (s *RelationDepartureSuite) TestWatchPendingDepartures(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	s.enterScopes(c, prr.pru0, prr.rru0)
+
+	w := prr.pru0.WatchPendingDepartures()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := prr.rru0.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes to the unit's own scope, and to those of units of
+	// its own application, are not reported.
+	err = prr.pru0.SetCounterpartJoined("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = prr.rru0.SetCounterpartDeparted("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *RelationDepartureSuite) TestWatchPendingDeparturesCounterpartUnits(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	s.enterScopes(c, prr.pru0, prr.rru0, prr.rru1)
+
+	w := prr.pru0.WatchPendingDepartures()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Changes to the agent status and life of counterpart units
+	// are reported.
+	err := prr.ru0.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = prr.ru1.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Those of units of the unit's own application are not.
+	err = prr.pu1.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *RelationDepartureSuite) TestScopeUnits(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	s.enterScopes(c, prr.pru0, prr.rru0, prr.rru1)
+	err := prr.rru1.SetCounterpartJoined("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru0.SetCounterpartJoined("wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru0.SetCounterpartJoined("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.PrepareLeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := prr.rel.ScopeUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []state.RelationScopeUnit{{
+		Unit:        "mysql/0",
+		Role:        charm.RoleProvider,
+		JoinedUnits: []string{"wordpress/0", "wordpress/1"},
+	}, {
+		Unit:      "wordpress/0",
+		Role:      charm.RoleRequirer,
+		Departing: true,
+	}, {
+		Unit:        "wordpress/1",
+		Role:        charm.RoleRequirer,
+		JoinedUnits: []string{"mysql/0"},
+	}})
+}
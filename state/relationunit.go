@@ -65,7 +65,8 @@ var ErrCannotEnterScopeYet = stderrors.New("cannot enter scope yet: non-alive su
 // If the unit is a principal and the relation has container scope, EnterScope
 // will also create the required subordinate unit, if it does not already exist;
 // this is because there's no point having a principal in scope if there is no
-// corresponding subordinate to join it.
+// corresponding subordinate to join it. No subordinate is created if the
+// relation's subordinate placement policy excludes the principal's machine.
 //
 // Once a unit has entered a scope, it stays in scope without further
 // intervention; the relation will not be able to become Dead until all units
@@ -221,6 +222,17 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 	selSubordinate := bson.D{{"application", applicationname}, {"principal", unitName}}
 	var lDoc lifeDoc
 	if err := units.Find(selSubordinate).One(&lDoc); err == mgo.ErrNotFound {
+		// Read the placement policy afresh, as it may have been
+		// changed since the relation was read.
+		rel, err := ru.st.KeyRelation(ru.relation.String())
+		if err != nil {
+			return nil, "", err
+		}
+		if excluded, err := rel.SubordinatePlacement().excludesUnit(ru.st, ru.unit); err != nil {
+			return nil, "", err
+		} else if excluded {
+			return nil, "", nil
+		}
 		application, err := ru.st.Application(applicationname)
 		if err != nil {
 			return nil, "", err
@@ -436,6 +448,10 @@ type relationScopeDoc struct {
 	Key       string `bson:"key"`
 	ModelUUID string `bson:"model-uuid"`
 	Departing bool
+
+	// JoinedUnits holds the names of the counterpart units for which
+	// the unit has run relation-joined, but not yet relation-departed.
+	JoinedUnits []string `bson:"joined-units,omitempty"`
 }
 
 func (d *relationScopeDoc) unitName() string {
@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -292,6 +293,59 @@ func (s *RelationUnitSuite) TestContainerCreateSubordinate(c *gc.C) {
 	assertJoined(c, pru)
 }
 
+func (s *RelationUnitSuite) TestContainerSubordinatePlacement(c *gc.C) {
+	psvc := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	rsvc := s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("mysql", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSubordinatePlacement(state.SubordinatePlacement{
+		ExcludeControllers: true,
+		ExcludeMachines:    []string{"1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	excluded, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	included, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, excluded.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	enterScope := func(m *state.Machine) {
+		punit, err := psvc.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = punit.AssignToMachine(m)
+		c.Assert(err, jc.ErrorIsNil)
+		pru, err := rel.Unit(punit)
+		c.Assert(err, jc.ErrorIsNil)
+		err = pru.EnterScope(nil)
+		c.Assert(err, jc.ErrorIsNil)
+		assertJoined(c, pru)
+	}
+	assertSubCount := func(expect int) {
+		runits, err := rsvc.AllUnits()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(runits, gc.HasLen, expect)
+	}
+
+	// Principals on excluded machines, or in containers on them,
+	// enter scope without subordinates being created.
+	enterScope(controller)
+	enterScope(excluded)
+	enterScope(container)
+	assertSubCount(0)
+
+	enterScope(included)
+	assertSubCount(1)
+}
+
 func (s *RelationUnitSuite) TestDestroyRelationWithUnitsInScope(c *gc.C) {
 	pr := NewPeerRelation(c, s.State)
 	rel := pr.ru0.Relation()
@@ -0,0 +1,447 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ScheduledOperationKind identifies what a scheduled operation does.
+type ScheduledOperationKind string
+
+const (
+	// UpgradeCharmOperation upgrades an application to a charm which
+	// has already been added to the model.
+	UpgradeCharmOperation ScheduledOperationKind = "upgrade-charm"
+
+	// ScaleApplicationOperation adds or removes units of an
+	// application until it has the requested number.
+	ScaleApplicationOperation ScheduledOperationKind = "scale-application"
+)
+
+// Validate returns an error if the kind is not known.
+func (kind ScheduledOperationKind) Validate() error {
+	switch kind {
+	case UpgradeCharmOperation, ScaleApplicationOperation:
+		return nil
+	}
+	return errors.NotValidf("scheduled operation kind %q", kind)
+}
+
+// ScheduledOperationStatus describes the progress of a scheduled
+// operation.
+type ScheduledOperationStatus string
+
+const (
+	// ScheduledOperationPending is the status of an operation which
+	// will run when it is next due.
+	ScheduledOperationPending ScheduledOperationStatus = "pending"
+
+	// ScheduledOperationFailed is the status of a one-off operation
+	// which failed to run. It is kept, so that the failure can be
+	// seen, until it is cancelled.
+	ScheduledOperationFailed ScheduledOperationStatus = "failed"
+)
+
+// ScheduledOperation is an operation on an application which the
+// controller runs at a given time, and optionally repeats.
+type ScheduledOperation struct {
+	// Id identifies the operation within its model. It is assigned
+	// when the operation is added.
+	Id string
+
+	// Kind identifies what the operation does.
+	Kind ScheduledOperationKind
+
+	// Application is the name of the application operated on.
+	Application string
+
+	// CharmURL is the URL of the charm to upgrade the application
+	// to, for UpgradeCharmOperation.
+	CharmURL string
+
+	// Units is the number of units to scale the application to, for
+	// ScaleApplicationOperation.
+	Units int
+
+	// Due is when the operation is next to run.
+	Due time.Time
+
+	// Every, if non-zero, is the interval at which the operation
+	// repeats. Otherwise the operation runs once.
+	Every time.Duration
+
+	// Status describes the progress of the operation.
+	Status ScheduledOperationStatus
+
+	// LastRun is when the operation last ran, or zero if it has not.
+	LastRun time.Time
+
+	// LastError describes why the operation last failed, if it did.
+	LastError string
+}
+
+// Validate returns an error if the operation is not valid.
+func (op ScheduledOperation) Validate() error {
+	if err := op.Kind.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if op.Application == "" {
+		return errors.NotValidf("empty application name")
+	}
+	switch op.Kind {
+	case UpgradeCharmOperation:
+		if _, err := charm.ParseURL(op.CharmURL); err != nil {
+			return errors.NotValidf("charm URL %q", op.CharmURL)
+		}
+	case ScaleApplicationOperation:
+		if op.Units < 0 {
+			return errors.NotValidf("negative unit count")
+		}
+	}
+	if op.Due.IsZero() {
+		return errors.NotValidf("missing due time")
+	}
+	if op.Every < 0 {
+		return errors.NotValidf("negative interval")
+	}
+	if op.Every > 0 && op.Every < time.Minute {
+		return errors.NotValidf("interval %v shorter than a minute", op.Every)
+	}
+	return nil
+}
+
+// scheduledOperationDoc represents the MongoDB document that stores a
+// scheduled operation.
+type scheduledOperationDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Id          string `bson:"id"`
+	Kind        string `bson:"kind"`
+	Application string `bson:"application"`
+	CharmURL    string `bson:"charm-url,omitempty"`
+	Units       int    `bson:"units"`
+	Due         int64  `bson:"due"`
+	Every       int64  `bson:"every"`
+	Status      string `bson:"status"`
+	LastRun     int64  `bson:"last-run"`
+	LastError   string `bson:"last-error,omitempty"`
+}
+
+func (doc scheduledOperationDoc) toOperation() ScheduledOperation {
+	op := ScheduledOperation{
+		Id:          doc.Id,
+		Kind:        ScheduledOperationKind(doc.Kind),
+		Application: doc.Application,
+		CharmURL:    doc.CharmURL,
+		Units:       doc.Units,
+		Due:         time.Unix(0, doc.Due).UTC(),
+		Every:       time.Duration(doc.Every),
+		Status:      ScheduledOperationStatus(doc.Status),
+		LastError:   doc.LastError,
+	}
+	if doc.LastRun != 0 {
+		op.LastRun = time.Unix(0, doc.LastRun).UTC()
+	}
+	return op
+}
+
+// AddScheduledOperation schedules the given operation, and returns
+// the id assigned to it. The application must exist, and so must the
+// charm to upgrade it to.
+func (st *State) AddScheduledOperation(op ScheduledOperation) (_ string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot schedule %s of application %q", op.Kind, op.Application)
+	if err := op.Validate(); err != nil {
+		return "", errors.Trace(err)
+	}
+	seq, err := st.sequence("scheduledoperation")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	buildTxn := func(int) ([]txn.Op, error) {
+		app, err := st.Application(op.Application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.New("application is not alive")
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		switch op.Kind {
+		case UpgradeCharmOperation:
+			curl := charm.MustParseURL(op.CharmURL)
+			if _, err := st.Charm(curl); err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, txn.Op{
+				C:      charmsC,
+				Id:     curl.String(),
+				Assert: txn.DocExists,
+			})
+		case ScaleApplicationOperation:
+			if !app.IsPrincipal() {
+				return nil, errors.NotSupportedf("scaling subordinate application")
+			}
+		}
+		return append(ops, txn.Op{
+			C:      scheduledOperationsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &scheduledOperationDoc{
+				DocID:       st.docID(id),
+				ModelUUID:   st.ModelUUID(),
+				Id:          id,
+				Kind:        string(op.Kind),
+				Application: op.Application,
+				CharmURL:    op.CharmURL,
+				Units:       op.Units,
+				Due:         op.Due.UnixNano(),
+				Every:       int64(op.Every),
+				Status:      string(ScheduledOperationPending),
+			},
+		}), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// CancelScheduledOperation removes the scheduled operation with the
+// given id, so that it does not run again.
+func (st *State) CancelScheduledOperation(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot cancel scheduled operation %s", id)
+	ops := []txn.Op{{
+		C:      scheduledOperationsC,
+		Id:     id,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("scheduled operation %s", id)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ScheduledOperation returns the scheduled operation with the given
+// id.
+func (st *State) ScheduledOperation(id string) (ScheduledOperation, error) {
+	coll, closer := st.getCollection(scheduledOperationsC)
+	defer closer()
+
+	var doc scheduledOperationDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ScheduledOperation{}, errors.NotFoundf("scheduled operation %s", id)
+	} else if err != nil {
+		return ScheduledOperation{}, errors.Annotatef(err, "cannot get scheduled operation %s", id)
+	}
+	return doc.toOperation(), nil
+}
+
+// ScheduledOperations returns the model's scheduled operations,
+// ordered by when they are due.
+func (st *State) ScheduledOperations() ([]ScheduledOperation, error) {
+	ops, err := st.scheduledOperations(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get scheduled operations")
+	}
+	return ops, nil
+}
+
+// DueScheduledOperations returns the model's pending scheduled
+// operations which are due to run at the given time, ordered by when
+// they are due.
+func (st *State) DueScheduledOperations(now time.Time) ([]ScheduledOperation, error) {
+	ops, err := st.scheduledOperations(bson.D{
+		{"status", string(ScheduledOperationPending)},
+		{"due", bson.D{{"$lte", now.UnixNano()}}},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get due scheduled operations")
+	}
+	return ops, nil
+}
+
+func (st *State) scheduledOperations(query bson.D) ([]ScheduledOperation, error) {
+	coll, closer := st.getCollection(scheduledOperationsC)
+	defer closer()
+
+	var docs []scheduledOperationDoc
+	if err := coll.Find(query).Sort("due", "id").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]ScheduledOperation, len(docs))
+	for i, doc := range docs {
+		ops[i] = doc.toOperation()
+	}
+	return ops, nil
+}
+
+// RunScheduledOperation runs the scheduled operation with the given
+// id, at the given time. A one-off operation is removed once it has
+// run successfully, and kept with a failed status otherwise; a
+// repeating operation is rescheduled either way. The error from
+// running the operation is recorded on it, and returned.
+func (st *State) RunScheduledOperation(id string, now time.Time) error {
+	op, err := st.ScheduledOperation(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	runErr := st.runScheduledOperation(op)
+	if runErr != nil {
+		// The cause is not kept, so that a NotFound error always
+		// means that the operation itself was not found.
+		runErr = errors.Errorf("cannot run scheduled %s of application %q: %v", op.Kind, op.Application, runErr)
+	}
+	if err := st.finishScheduledOperation(op, now, runErr); err != nil {
+		return errors.Trace(err)
+	}
+	return runErr
+}
+
+func (st *State) runScheduledOperation(op ScheduledOperation) error {
+	app, err := st.Application(op.Application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Blocks were checked when the operation was scheduled, but may
+	// have been switched on since.
+	if err := checkScheduledOperationBlock(st, ChangeBlock, app); err != nil {
+		return errors.Trace(err)
+	}
+	switch op.Kind {
+	case UpgradeCharmOperation:
+		ch, err := st.Charm(charm.MustParseURL(op.CharmURL))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return app.SetCharm(SetCharmConfig{Charm: ch})
+	case ScaleApplicationOperation:
+		return scaleApplication(app, op.Units)
+	}
+	return errors.NotValidf("scheduled operation kind %q", op.Kind)
+}
+
+// scaleApplication adds units to the application, each on a new
+// machine, or destroys its most recently added units, until it has
+// the given number of alive units.
+func scaleApplication(app *Application, n int) error {
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var alive []*Unit
+	for _, unit := range units {
+		if unit.Life() == Alive {
+			alive = append(alive, unit)
+		}
+	}
+	for i := len(alive); i < n; i++ {
+		unit, err := app.AddUnit()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := app.st.AssignUnit(unit, AssignNew); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(alive) <= n {
+		return nil
+	}
+	if err := checkScheduledOperationBlock(app.st, RemoveBlock, app); err != nil {
+		return errors.Trace(err)
+	}
+	sort.Sort(unitsByNumber(alive))
+	for _, unit := range alive[n:] {
+		if err := unit.Destroy(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// checkScheduledOperationBlock returns an error if a block of the given
+// type is switched on for the model or the application.
+func checkScheduledOperationBlock(st *State, t BlockType, app *Application) error {
+	b, blocked, err := st.GetBlockForTypeAndEntities(t, app.Tag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if blocked {
+		return errors.Errorf("blocked: %s", b.Message())
+	}
+	return nil
+}
+
+type unitsByNumber []*Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+func unitNumber(name string) int {
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return n
+}
+
+// finishScheduledOperation records that the given operation ran at
+// the given time, with the given result.
+func (st *State) finishScheduledOperation(op ScheduledOperation, now time.Time, runErr error) error {
+	if runErr == nil && op.Every == 0 {
+		err := st.CancelScheduledOperation(op.Id)
+		if errors.IsNotFound(err) {
+			// The operation was cancelled while it ran.
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	due, status := op.Due, ScheduledOperationFailed
+	if op.Every > 0 {
+		for !due.After(now) {
+			due = due.Add(op.Every)
+		}
+		status = ScheduledOperationPending
+	}
+	var lastError string
+	if runErr != nil {
+		lastError = runErr.Error()
+	}
+	ops := []txn.Op{{
+		C:      scheduledOperationsC,
+		Id:     op.Id,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"due", due.UnixNano()},
+			{"status", string(status)},
+			{"last-run", now.UnixNano()},
+			{"last-error", lastError},
+		}}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		// The operation was cancelled while it ran.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record run of scheduled operation %s", op.Id)
+	}
+	return nil
+}
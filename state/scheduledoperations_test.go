@@ -0,0 +1,269 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ScheduledOperationsSuite struct {
+	ConnSuite
+	app *state.Application
+	ch  *state.Charm
+	now time.Time
+}
+
+var _ = gc.Suite(&ScheduledOperationsSuite{})
+
+func (s *ScheduledOperationsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.AddTestingService(c, "wordpress", s.AddConfigCharm(c, "wordpress", emptyConfig, 1))
+	s.ch = s.AddConfigCharm(c, "wordpress", emptyConfig, 2)
+	s.now = time.Date(2016, 11, 5, 2, 0, 0, 0, time.UTC)
+}
+
+func (s *ScheduledOperationsSuite) upgradeOp() state.ScheduledOperation {
+	return state.ScheduledOperation{
+		Kind:        state.UpgradeCharmOperation,
+		Application: "wordpress",
+		CharmURL:    s.ch.URL().String(),
+		Due:         s.now,
+	}
+}
+
+func (s *ScheduledOperationsSuite) scaleOp(units int) state.ScheduledOperation {
+	return state.ScheduledOperation{
+		Kind:        state.ScaleApplicationOperation,
+		Application: "wordpress",
+		Units:       units,
+		Due:         s.now,
+	}
+}
+
+func (s *ScheduledOperationsSuite) aliveUnits(c *gc.C) []string {
+	units, err := s.app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, unit := range units {
+		if unit.Life() == state.Alive {
+			names = append(names, unit.Name())
+		}
+	}
+	return names
+}
+
+func (s *ScheduledOperationsSuite) TestAddScheduledOperation(c *gc.C) {
+	ops, err := s.State.ScheduledOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 0)
+
+	scale := s.scaleOp(0)
+	scale.Due = s.now.Add(time.Hour)
+	scale.Every = 7 * 24 * time.Hour
+	scaleId, err := s.State.AddScheduledOperation(scale)
+	c.Assert(err, jc.ErrorIsNil)
+	upgradeId, err := s.State.AddScheduledOperation(s.upgradeOp())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgradeId, gc.Not(gc.Equals), scaleId)
+
+	scale.Id = scaleId
+	scale.Status = state.ScheduledOperationPending
+	upgrade := s.upgradeOp()
+	upgrade.Id = upgradeId
+	upgrade.Status = state.ScheduledOperationPending
+
+	stored, err := s.State.ScheduledOperation(scaleId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, scale)
+	ops, err = s.State.ScheduledOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, jc.DeepEquals, []state.ScheduledOperation{upgrade, scale})
+}
+
+func (s *ScheduledOperationsSuite) TestAddScheduledOperationInvalid(c *gc.C) {
+	for i, test := range []struct {
+		op  state.ScheduledOperation
+		err string
+	}{{
+		op:  state.ScheduledOperation{Kind: "explode", Application: "wordpress", Due: s.now},
+		err: `cannot schedule explode of application "wordpress": scheduled operation kind "explode" not valid`,
+	}, {
+		op:  state.ScheduledOperation{Kind: state.ScaleApplicationOperation, Due: s.now},
+		err: `cannot schedule scale-application of application "": empty application name not valid`,
+	}, {
+		op:  state.ScheduledOperation{Kind: state.UpgradeCharmOperation, Application: "wordpress", CharmURL: "::", Due: s.now},
+		err: `cannot schedule upgrade-charm of application "wordpress": charm URL "::" not valid`,
+	}, {
+		op:  s.scaleOp(-1),
+		err: `cannot schedule scale-application of application "wordpress": negative unit count not valid`,
+	}, {
+		op:  state.ScheduledOperation{Kind: state.ScaleApplicationOperation, Application: "wordpress"},
+		err: `cannot schedule scale-application of application "wordpress": missing due time not valid`,
+	}, {
+		op:  state.ScheduledOperation{Kind: state.ScaleApplicationOperation, Application: "wordpress", Due: s.now, Every: time.Second},
+		err: `cannot schedule scale-application of application "wordpress": interval 1s shorter than a minute not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddScheduledOperation(test.op)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *ScheduledOperationsSuite) TestAddScheduledOperationMissingApplication(c *gc.C) {
+	op := s.scaleOp(1)
+	op.Application = "mysql"
+	_, err := s.State.AddScheduledOperation(op)
+	c.Assert(err, gc.ErrorMatches, `cannot schedule scale-application of application "mysql": application "mysql" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ScheduledOperationsSuite) TestAddScheduledOperationMissingCharm(c *gc.C) {
+	op := s.upgradeOp()
+	op.CharmURL = "cs:quantal/wordpress-9"
+	_, err := s.State.AddScheduledOperation(op)
+	c.Assert(err, gc.ErrorMatches, `cannot schedule upgrade-charm of application "wordpress": charm "cs:quantal/wordpress-9" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ScheduledOperationsSuite) TestCancelScheduledOperation(c *gc.C) {
+	id, err := s.State.AddScheduledOperation(s.upgradeOp())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CancelScheduledOperation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ScheduledOperation(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.CancelScheduledOperation(id)
+	c.Assert(err, gc.ErrorMatches, `cannot cancel scheduled operation .*: scheduled operation .* not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ScheduledOperationsSuite) TestDueScheduledOperations(c *gc.C) {
+	later := s.scaleOp(1)
+	later.Due = s.now.Add(time.Minute)
+	_, err := s.State.AddScheduledOperation(later)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := s.State.AddScheduledOperation(s.upgradeOp())
+	c.Assert(err, jc.ErrorIsNil)
+
+	due, err := s.State.DueScheduledOperations(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 1)
+	c.Assert(due[0].Id, gc.Equals, id)
+
+	due, err = s.State.DueScheduledOperations(s.now.Add(-time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 0)
+}
+
+func (s *ScheduledOperationsSuite) TestRunUpgradeCharm(c *gc.C) {
+	id, err := s.State.AddScheduledOperation(s.upgradeOp())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RunScheduledOperation(id, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := s.app.CharmURL()
+	c.Assert(curl, gc.DeepEquals, s.ch.URL())
+
+	_, err = s.State.ScheduledOperation(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ScheduledOperationsSuite) TestRunScaleApplication(c *gc.C) {
+	id, err := s.State.AddScheduledOperation(s.scaleOp(3))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RunScheduledOperation(id, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.aliveUnits(c), jc.SameContents, []string{"wordpress/0", "wordpress/1", "wordpress/2"})
+
+	unit, err := s.State.Unit("wordpress/2")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, err = s.State.AddScheduledOperation(s.scaleOp(1))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RunScheduledOperation(id, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.aliveUnits(c), jc.DeepEquals, []string{"wordpress/0"})
+}
+
+func (s *ScheduledOperationsSuite) TestRunBlocked(c *gc.C) {
+	id, err := s.State.AddScheduledOperation(s.upgradeOp())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchBlockOn(state.ChangeBlock, "frozen")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RunScheduledOperation(id, s.now)
+	c.Assert(err, gc.ErrorMatches, `cannot run scheduled upgrade-charm of application "wordpress": blocked: frozen`)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := s.app.CharmURL()
+	c.Assert(curl.Revision, gc.Equals, 1)
+}
+
+func (s *ScheduledOperationsSuite) TestRunScaleDownRemoveBlocked(c *gc.C) {
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.app})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.app})
+	id, err := s.State.AddScheduledOperation(s.scaleOp(1))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchBlockOn(state.RemoveBlock, "keep them")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RunScheduledOperation(id, s.now)
+	c.Assert(err, gc.ErrorMatches, `cannot run scheduled scale-application of application "wordpress": blocked: keep them`)
+	c.Assert(s.aliveUnits(c), gc.HasLen, 2)
+}
+
+func (s *ScheduledOperationsSuite) TestRunRepeating(c *gc.C) {
+	op := s.scaleOp(1)
+	op.Every = 7 * 24 * time.Hour
+	id, err := s.State.AddScheduledOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Run the operation late, after it missed two of its runs.
+	now := s.now.Add(15 * 24 * time.Hour)
+	err = s.State.RunScheduledOperation(id, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.aliveUnits(c), gc.HasLen, 1)
+
+	stored, err := s.State.ScheduledOperation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.Status, gc.Equals, state.ScheduledOperationPending)
+	c.Assert(stored.Due, gc.Equals, s.now.Add(21*24*time.Hour))
+	c.Assert(stored.LastRun, gc.Equals, now)
+	c.Assert(stored.LastError, gc.Equals, "")
+}
+
+func (s *ScheduledOperationsSuite) TestRunFailed(c *gc.C) {
+	id, err := s.State.AddScheduledOperation(s.scaleOp(1))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RunScheduledOperation(id, s.now)
+	c.Assert(err, gc.ErrorMatches, `cannot run scheduled scale-application of application "wordpress": application "wordpress" not found`)
+
+	stored, err := s.State.ScheduledOperation(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.Status, gc.Equals, state.ScheduledOperationFailed)
+	c.Assert(stored.Due, gc.Equals, s.now)
+	c.Assert(stored.LastRun, gc.Equals, s.now)
+	c.Assert(stored.LastError, gc.Equals, err.Error())
+
+	due, err := s.State.DueScheduledOperations(s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 0)
+}
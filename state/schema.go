@@ -0,0 +1,211 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// schemaUpgradeStep upgrades the documents of a collection to a new
+// schema version. The steps for each collection are numbered from 1,
+// and are run in order; a collection whose documents have never been
+// upgraded is at version 0.
+type schemaUpgradeStep struct {
+	collection  string
+	version     int
+	description string
+
+	// pending returns the number of documents which still need
+	// to be upgraded by the step. It is used to report the work
+	// a dry run would do, and to verify that the step succeeded.
+	pending func(*State) (int, error)
+
+	// run upgrades the documents. It must be idempotent, as an
+	// upgrade interrupted before its version is recorded will be
+	// run again.
+	run func(*State) error
+}
+
+// schemaUpgradeSteps holds every schema upgrade step, in the order in
+// which they are run. Steps must only ever be appended, with the next
+// version for their collection.
+var schemaUpgradeSteps = []schemaUpgradeStep{{
+	collection:  settingsC,
+	version:     1,
+	description: "move settings into a subdocument, with a version",
+	pending:     countSettingsNeedingMigration,
+	run:         migrateSettingsSchema,
+}, {
+	collection:  machinesC,
+	version:     1,
+	description: "add preferred addresses to machines",
+	pending:     countMachinesWithoutPreferredAddresses,
+	run:         addPreferredAddressesToMachines,
+}, {
+	collection:  statusesC,
+	version:     1,
+	description: "add status to filesystems",
+	pending:     countFilesystemsWithoutStatus,
+	run:         addFilesystemStatus,
+}, {
+	collection:  endpointBindingsC,
+	version:     1,
+	description: "add default endpoint bindings to applications",
+	pending:     countApplicationsWithoutBindings,
+	run:         addDefaultEndpointBindingsToServices,
+}, {
+	collection:  modelEntityRefsC,
+	version:     1,
+	description: "record principal units in model entity references",
+	pending:     countModelsWithoutUnitRefs,
+	run:         addUnitRefsToModels,
+}}
+
+// validateSchemaUpgradeSteps checks that the steps for each collection
+// are numbered consecutively from 1.
+func validateSchemaUpgradeSteps(steps []schemaUpgradeStep) error {
+	versions := make(map[string]int)
+	for _, step := range steps {
+		if step.version != versions[step.collection]+1 {
+			return errors.NotValidf(
+				"schema upgrade step %q for %s version %d following version %d",
+				step.description, step.collection, step.version, versions[step.collection],
+			)
+		}
+		if step.pending == nil || step.run == nil {
+			return errors.NotValidf("schema upgrade step %q without pending or run func", step.description)
+		}
+		versions[step.collection] = step.version
+	}
+	return nil
+}
+
+// schemaVersionDoc records the schema version of a collection's
+// documents, across all models.
+type schemaVersionDoc struct {
+	Collection string `bson:"_id"`
+	Version    int    `bson:"version"`
+}
+
+// SchemaUpgradeResult describes a schema upgrade step which has been
+// run, or would be run by a dry run.
+type SchemaUpgradeResult struct {
+	// Collection is the collection upgraded by the step.
+	Collection string
+
+	// Version is the schema version the step upgrades the
+	// collection to.
+	Version int
+
+	// Description describes what the step does.
+	Description string
+
+	// Pending is the number of documents that needed to be
+	// upgraded before the step was run. A step with no pending
+	// documents is not run; only its version is recorded.
+	Pending int
+}
+
+// SchemaVersions returns the schema version of each collection which
+// has been upgraded. Collections which have not been upgraded are
+// omitted, and are at version 0.
+func (st *State) SchemaVersions() (map[string]int, error) {
+	coll, closer := st.getCollection(schemaVersionsC)
+	defer closer()
+
+	var docs []schemaVersionDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read schema versions")
+	}
+	versions := make(map[string]int)
+	for _, doc := range docs {
+		versions[doc.Collection] = doc.Version
+	}
+	return versions, nil
+}
+
+// UpgradeSchema runs, in order, each schema upgrade step for a version
+// later than that recorded for its collection, and records the new
+// version. After a step runs, it is verified that no documents still
+// need it. If dryRun is true, the steps which would run are returned
+// with the number of documents each would change, but nothing is
+// changed. UpgradeSchema must be called on the controller's State.
+func (st *State) UpgradeSchema(dryRun bool) ([]SchemaUpgradeResult, error) {
+	if !st.IsController() {
+		return nil, errors.New("schema upgrades must be run on the controller")
+	}
+	if err := validateSchemaUpgradeSteps(schemaUpgradeSteps); err != nil {
+		return nil, errors.Trace(err)
+	}
+	versions, err := st.SchemaVersions()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []SchemaUpgradeResult
+	for _, step := range schemaUpgradeSteps {
+		current := versions[step.collection]
+		if step.version <= current {
+			continue
+		}
+		pending, err := step.pending(st)
+		if err != nil {
+			return results, errors.Annotatef(err, "checking schema upgrade step %q", step.description)
+		}
+		results = append(results, SchemaUpgradeResult{
+			Collection:  step.collection,
+			Version:     step.version,
+			Description: step.description,
+			Pending:     pending,
+		})
+		if dryRun {
+			versions[step.collection] = step.version
+			continue
+		}
+		if pending > 0 {
+			upgradesLogger.Infof("running schema upgrade step %q on %d documents", step.description, pending)
+			if err := step.run(st); err != nil {
+				return results, errors.Annotatef(err, "running schema upgrade step %q", step.description)
+			}
+			remaining, err := step.pending(st)
+			if err != nil {
+				return results, errors.Annotatef(err, "verifying schema upgrade step %q", step.description)
+			}
+			if remaining > 0 {
+				return results, errors.Errorf(
+					"schema upgrade step %q left %d documents not upgraded", step.description, remaining,
+				)
+			}
+		}
+		if err := st.setSchemaVersion(step.collection, current, step.version); err != nil {
+			return results, errors.Trace(err)
+		}
+		versions[step.collection] = step.version
+	}
+	return results, nil
+}
+
+// setSchemaVersion records the schema version of a collection, which
+// must still be at the given previous version.
+func (st *State) setSchemaVersion(collection string, previous, version int) error {
+	op := txn.Op{
+		C:  schemaVersionsC,
+		Id: collection,
+	}
+	if previous == 0 {
+		op.Assert = txn.DocMissing
+		op.Insert = &schemaVersionDoc{Version: version}
+	} else {
+		op.Assert = bson.D{{"version", previous}}
+		op.Update = bson.D{{"$set", bson.D{{"version", version}}}}
+	}
+	err := st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		return errors.Errorf("schema version of %s changed during upgrade", collection)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set schema version of %s", collection)
+	}
+	return nil
+}
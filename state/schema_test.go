@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type schemaSuite struct {
+	internalStateSuite
+}
+
+var _ = gc.Suite(&schemaSuite{})
+
+func (s *schemaSuite) TestSchemaUpgradeStepsValid(c *gc.C) {
+	err := validateSchemaUpgradeSteps(schemaUpgradeSteps)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *schemaSuite) TestValidateSchemaUpgradeSteps(c *gc.C) {
+	pending := func(*State) (int, error) { return 0, nil }
+	run := func(*State) error { return nil }
+	err := validateSchemaUpgradeSteps([]schemaUpgradeStep{
+		{collection: "a", version: 1, description: "a1", pending: pending, run: run},
+		{collection: "b", version: 1, description: "b1", pending: pending, run: run},
+		{collection: "a", version: 3, description: "a3", pending: pending, run: run},
+	})
+	c.Assert(err, gc.ErrorMatches, `schema upgrade step "a3" for a version 3 following version 1 not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = validateSchemaUpgradeSteps([]schemaUpgradeStep{
+		{collection: "a", version: 1, description: "a1", run: run},
+	})
+	c.Assert(err, gc.ErrorMatches, `schema upgrade step "a1" without pending or run func not valid`)
+}
+
+func (s *schemaSuite) addLegacySettings(c *gc.C) {
+	settingsColl, closer := s.state.getRawCollection(settingsC)
+	defer closer()
+	err := settingsColl.Insert(bson.D{
+		{"_id", s.state.docID("legacy")},
+		{"model-uuid", s.state.ModelUUID()},
+		{"txn-revno", int64(7)},
+		{"txn-queue", []string{}},
+		{"colour", "blue"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *schemaSuite) TestUpgradeSchemaDryRun(c *gc.C) {
+	s.addLegacySettings(c)
+
+	results, err := s.state.UpgradeSchema(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, len(schemaUpgradeSteps))
+	c.Assert(results[0], jc.DeepEquals, SchemaUpgradeResult{
+		Collection:  settingsC,
+		Version:     1,
+		Description: "move settings into a subdocument, with a version",
+		Pending:     1,
+	})
+
+	// Nothing was changed.
+	versions, err := s.state.SchemaVersions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions, gc.HasLen, 0)
+	count, err := countSettingsNeedingMigration(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+}
+
+func (s *schemaSuite) TestUpgradeSchema(c *gc.C) {
+	s.addLegacySettings(c)
+
+	results, err := s.state.UpgradeSchema(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, len(schemaUpgradeSteps))
+	c.Assert(results[0].Pending, gc.Equals, 1)
+
+	count, err := countSettingsNeedingMigration(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+	versions, err := s.state.SchemaVersions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions, jc.DeepEquals, map[string]int{
+		settingsC:         1,
+		machinesC:         1,
+		statusesC:         1,
+		endpointBindingsC: 1,
+		modelEntityRefsC:  1,
+	})
+
+	// Once upgraded, no steps are run again.
+	results, err = s.state.UpgradeSchema(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+}
+
+func (s *schemaSuite) TestUpgradeSchemaRunsNewSteps(c *gc.C) {
+	_, err := s.state.UpgradeSchema(false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var ran []string
+	steps := append([]schemaUpgradeStep(nil), schemaUpgradeSteps...)
+	steps = append(steps, schemaUpgradeStep{
+		collection:  settingsC,
+		version:     2,
+		description: "settings v2",
+		pending:     func(*State) (int, error) { return len(ran), nil },
+		run:         func(*State) error { ran = append(ran, "settings v2"); return nil },
+	})
+	s.PatchValue(&schemaUpgradeSteps, steps)
+
+	results, err := s.state.UpgradeSchema(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []SchemaUpgradeResult{{
+		Collection:  settingsC,
+		Version:     2,
+		Description: "settings v2",
+	}})
+	// No documents needed the step, so it was not run.
+	c.Assert(ran, gc.HasLen, 0)
+	versions, err := s.state.SchemaVersions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions[settingsC], gc.Equals, 2)
+}
+
+func (s *schemaSuite) TestUpgradeSchemaVerifies(c *gc.C) {
+	s.PatchValue(&schemaUpgradeSteps, []schemaUpgradeStep{{
+		collection:  settingsC,
+		version:     1,
+		description: "do nothing",
+		pending:     func(*State) (int, error) { return 2, nil },
+		run:         func(*State) error { return nil },
+	}})
+
+	_, err := s.state.UpgradeSchema(false)
+	c.Assert(err, gc.ErrorMatches, `schema upgrade step "do nothing" left 2 documents not upgraded`)
+	versions, err := s.state.SchemaVersions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions, gc.HasLen, 0)
+}
+
+func (s *schemaSuite) TestUpgradeSchemaStepFails(c *gc.C) {
+	s.PatchValue(&schemaUpgradeSteps, []schemaUpgradeStep{{
+		collection:  settingsC,
+		version:     1,
+		description: "fail",
+		pending:     func(*State) (int, error) { return 1, nil },
+		run:         func(*State) error { return errors.New("boom") },
+	}})
+
+	_, err := s.state.UpgradeSchema(false)
+	c.Assert(err, gc.ErrorMatches, `running schema upgrade step "fail": boom`)
+}
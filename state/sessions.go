@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/mgo.v2"
+)
+
+// SessionStats reports the use of mongo sessions by the States in
+// this process. Every copy of a State's session shares the socket pool
+// of the session it was copied from, so the counts describe demand on
+// that pool rather than the number of connections open.
+type SessionStats struct {
+	// Copied is the number of sessions copied, each for the use of
+	// a single operation or of a copied Database.
+	Copied int64
+
+	// Closed is the number of copied sessions since closed.
+	Closed int64
+
+	// SecondaryReads is the number of sessions copied for reads
+	// that may be served by a secondary.
+	SecondaryReads int64
+}
+
+// Open returns the number of copied sessions not yet closed.
+func (stats SessionStats) Open() int64 {
+	return stats.Copied - stats.Closed
+}
+
+// MongoSessionStats returns the mongo session usage of the States in
+// this process.
+func MongoSessionStats() SessionStats {
+	return sessionCounts.stats()
+}
+
+// sessionCounts counts the sessions copied by all databases.
+var sessionCounts sessionCounter
+
+// sessionCounter copies mongo sessions, and counts the copies made.
+// Its fields are only accessed atomically.
+type sessionCounter struct {
+	copied    int64
+	closed    int64
+	secondary int64
+}
+
+func (c *sessionCounter) stats() SessionStats {
+	return SessionStats{
+		Copied:         atomic.LoadInt64(&c.copied),
+		Closed:         atomic.LoadInt64(&c.closed),
+		SecondaryReads: atomic.LoadInt64(&c.secondary),
+	}
+}
+
+// copySession returns a copy of the given session, and a func that
+// closes it; the func may safely be called more than once.
+func (c *sessionCounter) copySession(session *mgo.Session) (*mgo.Session, SessionCloser) {
+	atomic.AddInt64(&c.copied, 1)
+	copied := session.Copy()
+	var once sync.Once
+	return copied, func() {
+		once.Do(func() {
+			copied.Close()
+			atomic.AddInt64(&c.closed, 1)
+		})
+	}
+}
+
+// copySecondarySession returns a copy of the given session which reads
+// from a secondary where one is available, as copySession does.
+func (c *sessionCounter) copySecondarySession(session *mgo.Session) (*mgo.Session, SessionCloser) {
+	atomic.AddInt64(&c.secondary, 1)
+	copied, closer := c.copySession(session)
+	copied.SetMode(mgo.SecondaryPreferred, true)
+	return copied, closer
+}
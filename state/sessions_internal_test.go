@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/testing"
+)
+
+type sessionCounterSuite struct {
+	jujutesting.MgoSuite
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&sessionCounterSuite{})
+
+func (s *sessionCounterSuite) SetUpSuite(c *gc.C) {
+	s.MgoSuite.SetUpSuite(c)
+	s.BaseSuite.SetUpSuite(c)
+}
+
+func (s *sessionCounterSuite) TearDownSuite(c *gc.C) {
+	s.BaseSuite.TearDownSuite(c)
+	s.MgoSuite.TearDownSuite(c)
+}
+
+func (s *sessionCounterSuite) SetUpTest(c *gc.C) {
+	s.MgoSuite.SetUpTest(c)
+	s.BaseSuite.SetUpTest(c)
+}
+
+func (s *sessionCounterSuite) TearDownTest(c *gc.C) {
+	s.BaseSuite.TearDownTest(c)
+	s.MgoSuite.TearDownTest(c)
+}
+
+func (s *sessionCounterSuite) TestCopySession(c *gc.C) {
+	var counter sessionCounter
+	session, closer := counter.copySession(s.Session)
+	c.Check(session, gc.Not(gc.Equals), s.Session)
+	c.Check(session.Mode(), gc.Equals, s.Session.Mode())
+	c.Check(counter.stats(), jc.DeepEquals, SessionStats{Copied: 1})
+	c.Check(counter.stats().Open(), gc.Equals, int64(1))
+
+	closer()
+	closer()
+	c.Check(counter.stats(), jc.DeepEquals, SessionStats{Copied: 1, Closed: 1})
+	c.Check(counter.stats().Open(), gc.Equals, int64(0))
+}
+
+func (s *sessionCounterSuite) TestCopySecondarySession(c *gc.C) {
+	var counter sessionCounter
+	session, closer := counter.copySecondarySession(s.Session)
+	defer closer()
+	c.Check(session.Mode(), gc.Equals, mgo.SecondaryPreferred)
+	c.Check(s.Session.Mode(), gc.Not(gc.Equals), mgo.SecondaryPreferred)
+	c.Check(counter.stats(), jc.DeepEquals, SessionStats{Copied: 1, SecondaryReads: 1})
+
+	// Reads are still served by a server that is not part of a
+	// replica set.
+	count, err := session.DB("juju").C("test").Count()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 0)
+}
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -12,8 +14,10 @@ import (
 	"github.com/juju/juju/core/lease"
 )
 
-// singularSecretary implements lease.Secretary to restrict claims to a single
-// lease (named for the environ uuid), holdable only by machine-tag strings.
+// singularSecretary implements lease.Secretary to restrict claims to the
+// model-wide lease (named for the environ uuid) and the leases for named
+// singular workers in that model (see SingularWorkerLease), holdable only
+// by machine-tag strings.
 //
 // It would be nicer to have a single controller-level component managing all
 // singular leases for all environments -- and thus be able to validate that
@@ -26,9 +30,16 @@ type singularSecretary struct {
 
 // CheckLease is part of the lease.Secretary interface.
 func (s singularSecretary) CheckLease(name string) error {
-	if name != s.uuid {
+	if name == s.uuid {
+		return nil
+	}
+	prefix := s.uuid + singularWorkerSeparator
+	if !strings.HasPrefix(name, prefix) {
 		return errors.New("expected environ UUID")
 	}
+	if worker := name[len(prefix):]; !validSingularWorker.MatchString(worker) {
+		return errors.Errorf("invalid singular worker name %q", worker)
+	}
 	return nil
 }
 
@@ -48,6 +59,23 @@ func (s singularSecretary) CheckDuration(duration time.Duration) error {
 	return nil
 }
 
+// singularWorkerSeparator separates the model UUID from the worker name
+// in the names of singular worker leases.
+const singularWorkerSeparator = ":"
+
+// validSingularWorker matches valid singular worker names, which follow
+// the conventions of dependency engine manifold names.
+var validSingularWorker = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// SingularWorkerLease returns the name of the singular lease that grants
+// a controller machine the exclusive right to run the named worker for
+// the model with the supplied UUID. Such leases are claimed and expire
+// independently of the model-wide lease, so that each named worker can
+// fail over to another controller on its own.
+func SingularWorkerLease(modelUUID, workerName string) string {
+	return modelUUID + singularWorkerSeparator + workerName
+}
+
 // SingularClaimer returns a lease.Claimer representing the exclusive right to
 // manage the environment.
 func (st *State) SingularClaimer() lease.Claimer {
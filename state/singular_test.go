@@ -40,6 +40,32 @@ func (s *SingularSuite) TestClaimBadLease(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `cannot claim lease "xxx": expected environ UUID`)
 }
 
+func (s *SingularSuite) TestClaimBadWorkerLease(c *gc.C) {
+	claimer := s.State.SingularClaimer()
+	err := claimer.Claim(state.SingularWorkerLease(s.modelTag.Id(), "Bad_Worker"), "machine-123", time.Minute)
+	c.Check(err, gc.ErrorMatches, `cannot claim lease ".*:Bad_Worker": invalid singular worker name "Bad_Worker"`)
+	err = claimer.Claim(state.SingularWorkerLease("xxx", "storage-provisioner"), "machine-123", time.Minute)
+	c.Check(err, gc.ErrorMatches, `cannot claim lease "xxx:storage-provisioner": expected environ UUID`)
+}
+
+func (s *SingularSuite) TestClaimWorkerLeases(c *gc.C) {
+	claimer := s.State.SingularClaimer()
+	storage := state.SingularWorkerLease(s.modelTag.Id(), "storage-provisioner")
+	firewaller := state.SingularWorkerLease(s.modelTag.Id(), "firewaller")
+
+	err := claimer.Claim(storage, "machine-123", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = claimer.Claim(storage, "machine-456", time.Minute)
+	c.Assert(err, gc.Equals, lease.ErrClaimDenied)
+
+	// Worker leases are independent of one another, and of the
+	// model-wide lease.
+	err = claimer.Claim(firewaller, "machine-456", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = claimer.Claim(s.modelTag.Id(), "machine-456", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *SingularSuite) TestClaimBadHolder(c *gc.C) {
 	claimer := s.State.SingularClaimer()
 	err := claimer.Claim(s.modelTag.Id(), "unit-foo-1", time.Minute)
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/loggo"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/os"
 	"github.com/juju/utils/series"
 	"github.com/juju/utils/set"
@@ -92,6 +93,12 @@ type State struct {
 	// relatively-skewed.
 	leaseClientId string
 
+	// clock is used for all of the state's time-dependent behaviour:
+	// timestamps, lease expiry, delayed cleanups and cached reads.
+	// It is taken from GetClock when the state is opened, and shared
+	// with the states derived from it.
+	clock clock.Clock
+
 	// workers is responsible for keeping the various sub-workers
 	// available by starting new ones as they fail. It doesn't do
 	// that yet, but having a type that collects them together is the
@@ -101,11 +108,14 @@ type State struct {
 	// folded in as well, but that feels like its own task.
 	workers workers.Workers
 
-	// mu guards allManager, allModelManager & allModelWatcherBacking
+	// mu guards allManager, allModelManager, allModelWatcherBacking,
+	// txnBatcher & readCache
 	mu                     sync.Mutex
 	allManager             *storeManager
 	allModelManager        *storeManager
 	allModelWatcherBacking Backing
+	txnBatcher             *txnBatcher
+	readCache              *readCache
 
 	// TODO(anastasiamac 2015-07-16) As state gets broken up, remove this.
 	CloudImageMetadataStorage cloudimagemetadata.Storage
@@ -164,14 +174,17 @@ func (st *State) RemoveAllModelDocs() error {
 }
 
 // RemoveImportingModelDocs removes all documents from multi-model collections
-// for the current model. This method asserts that the model's migration mode
-// is "importing".
+// for the current model, and any log records imported with it. This method
+// asserts that the model's migration mode is "importing".
 func (st *State) RemoveImportingModelDocs() error {
 	err := st.removeAllModelDocs(bson.D{{"migration-mode", MigrationModeImporting}})
 	if errors.Cause(err) == txn.ErrAborted {
 		return errors.New("can't remove model: model not being imported for migration")
+	} else if err != nil {
+		return errors.Trace(err)
 	}
-	return errors.Trace(err)
+	// Log records may have been imported with the model.
+	return errors.Trace(removeModelLogs(st, st.ModelUUID()))
 }
 
 // RemoveExportingModelDocs removes all documents from multi-model collections
@@ -301,7 +314,7 @@ func (st *State) removeInCollectionOps(name string, sel interface{}) ([]txn.Op,
 func (st *State) ForModel(model names.ModelTag) (*State, error) {
 	session := st.session.Copy()
 	newSt, err := newState(
-		model, session, st.mongoInfo, st.newPolicy,
+		model, session, st.mongoInfo, st.newPolicy, st.clock,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -350,15 +363,14 @@ func (st *State) start(controllerTag names.ModelTag) (err error) {
 	// now we've set up leaseClientId, we can use workersFactory
 
 	logger.Infof("starting standard state workers")
-	clock := GetClock()
 	factory := workersFactory{
 		st:    st,
-		clock: clock,
+		clock: st.clock,
 	}
 	workers, err := workers.NewRestartWorkers(workers.RestartConfig{
 		Factory: factory,
 		Logger:  loggo.GetLogger(logger.Name() + ".workers"),
-		Clock:   clock,
+		Clock:   st.clock,
 		Delay:   time.Second,
 	})
 	if err != nil {
@@ -383,7 +395,7 @@ func (st *State) getLeadershipLeaseClient() (lease.Client, error) {
 		Namespace:  applicationLeadershipNamespace,
 		Collection: leasesC,
 		Mongo:      &environMongo{st},
-		Clock:      GetClock(),
+		Clock:      st.clock,
 	})
 	if err != nil {
 		return nil, errors.Annotatef(err, "cannot create leadership lease client")
@@ -397,7 +409,7 @@ func (st *State) getSingularLeaseClient() (lease.Client, error) {
 		Namespace:  singularControllerNamespace,
 		Collection: leasesC,
 		Mongo:      &environMongo{st},
-		Clock:      GetClock(),
+		Clock:      st.clock,
 	})
 	if err != nil {
 		return nil, errors.Annotatef(err, "cannot create singular lease client")
@@ -635,8 +647,15 @@ func (st *State) SetModelAgentVersion(newVersion version.Number) (err error) {
 		if err := st.checkCanUpgrade(currentVersion, newVersion.String()); err != nil {
 			return nil, errors.Trace(err)
 		}
+		if upgrade, err := st.CanaryUpgrade(); err == nil {
+			return nil, errors.Errorf("a canary upgrade to %s is in progress", upgrade.TargetVersion)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
 
 		ops := []txn.Op{
+			// Can't set agent-version during a canary upgrade.
+			noCanaryUpgradeOp(st),
 			// Can't set agent-version if there's an active upgradeInfo doc.
 			{
 				C:      upgradeInfoC,
@@ -834,6 +853,12 @@ func (st *State) FindEntity(tag names.Tag) (Entity, error) {
 		return st.Volume(tag)
 	case names.FilesystemTag:
 		return st.Filesystem(tag)
+	case names.StorageTag:
+		s, err := st.storageInstance(tag)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
 	default:
 		return nil, errors.Errorf("unsupported tag %T", tag)
 	}
@@ -874,6 +899,9 @@ func (st *State) tagToCollectionAndId(tag names.Tag) (string, interface{}, error
 	case names.CharmTag:
 		coll = charmsC
 		id = tag.Id()
+	case names.StorageTag:
+		coll = storageInstancesC
+		id = st.docID(id)
 	default:
 		return "", nil, errors.Errorf("%q is not a valid collection tag", tag)
 	}
@@ -942,6 +970,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	if err := validateCharmVersion(args.Charm); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if len(args.Settings) > 0 {
+		if args.Settings, err = validateConfigSettings(args.Name, args.Charm, args.Settings); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	if exists, err := isNotDead(st, applicationsC, args.Name); err != nil {
 		return nil, errors.Trace(err)
@@ -1080,8 +1113,7 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		// behaviour.
 		Status:     status.StatusUnknown,
 		StatusInfo: MessageWaitForAgentInit,
-		// TODO(fwereade): 2016-03-17 lp:1558657
-		Updated: time.Now().UnixNano(),
+		Updated:    st.clock.Now().UnixNano(),
 		// This exists to preserve questionable unit-aggregation behaviour
 		// while we work out how to switch to an implementation that makes
 		// sense. It is also set in AddMissingServiceStatuses.
@@ -1140,6 +1172,13 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		}
 		ops = append(ops, assignUnitOps(unitName, placement)...)
 	}
+	if args.NumUnits > 0 {
+		limitOps, err := st.modelLimitsOps(0, args.NumUnits)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, limitOps...)
+	}
 	// At the last moment before inserting the service, prime status history.
 	probablyUpdateStatusHistory(st, svc.globalKey(), statusDoc)
 
@@ -1147,6 +1186,9 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		if err := checkModelActive(st); err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := st.checkModelLimits(0, args.NumUnits); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return nil, errors.Errorf("application already exists")
 	} else if err != nil {
 		return nil, errors.Trace(err)
@@ -1762,13 +1804,14 @@ func (st *State) SetAdminMongoPassword(password string) error {
 }
 
 type controllersDoc struct {
-	Id               string `bson:"_id"`
-	CloudName        string `bson:"cloud"`
-	ModelUUID        string `bson:"model-uuid"`
-	MachineIds       []string
-	VotingMachineIds []string
-	MongoSpaceName   string `bson:"mongo-space-name"`
-	MongoSpaceState  string `bson:"mongo-space-state"`
+	Id                 string `bson:"_id"`
+	CloudName          string `bson:"cloud"`
+	ModelUUID          string `bson:"model-uuid"`
+	MachineIds         []string
+	VotingMachineIds   []string
+	RemovingMachineIds []string
+	MongoSpaceName     string `bson:"mongo-space-name"`
+	MongoSpaceState    string `bson:"mongo-space-state"`
 }
 
 // ControllerInfo holds information about currently
@@ -1792,6 +1835,12 @@ type ControllerInfo struct {
 	// in peer election.
 	VotingMachineIds []string
 
+	// RemovingMachineIds holds the ids of controller machines
+	// that have been marked for removal from the controller
+	// cluster. They are removed once they no longer hold a
+	// vote in the replica set.
+	RemovingMachineIds []string
+
 	// MongoSpaceName is the space that contains all Mongo servers.
 	MongoSpaceName string
 
@@ -1836,12 +1885,13 @@ func readRawControllerInfo(session *mgo.Session) (*ControllerInfo, error) {
 		return nil, errors.Annotatef(err, "cannot get controllers document")
 	}
 	return &ControllerInfo{
-		CloudName:        doc.CloudName,
-		ModelTag:         names.NewModelTag(doc.ModelUUID),
-		MachineIds:       doc.MachineIds,
-		VotingMachineIds: doc.VotingMachineIds,
-		MongoSpaceName:   doc.MongoSpaceName,
-		MongoSpaceState:  MongoSpaceStates(doc.MongoSpaceState),
+		CloudName:          doc.CloudName,
+		ModelTag:           names.NewModelTag(doc.ModelUUID),
+		MachineIds:         doc.MachineIds,
+		VotingMachineIds:   doc.VotingMachineIds,
+		RemovingMachineIds: doc.RemovingMachineIds,
+		MongoSpaceName:     doc.MongoSpaceName,
+		MongoSpaceState:    MongoSpaceStates(doc.MongoSpaceState),
 	}, nil
 }
 
@@ -2708,8 +2708,14 @@ func testSetPassword(c *gc.C, getEntity func() (state.Authenticator, error)) {
 
 	err = e.SetPassword(alternatePassword)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(e.PasswordValid(goodPassword), jc.IsFalse)
 	c.Assert(e.PasswordValid(alternatePassword), jc.IsTrue)
+	if _, ok := e.(passwordRotator); ok {
+		// Agents' previous passwords remain valid for a short
+		// time after they are changed.
+		c.Assert(e.PasswordValid(goodPassword), jc.IsTrue)
+	} else {
+		c.Assert(e.PasswordValid(goodPassword), jc.IsFalse)
+	}
 
 	// Check that refreshing fetches the new password
 	err = e2.Refresh()
@@ -2723,6 +2729,10 @@ func testSetPassword(c *gc.C, getEntity func() (state.Authenticator, error)) {
 	}
 }
 
+type passwordRotator interface {
+	RequestPasswordRotation() error
+}
+
 type entity interface {
 	state.Entity
 	state.Lifer
@@ -3665,6 +3675,93 @@ func (s *StateSuite) TestEnableHAMaintainsVoteList(c *gc.C) {
 	c.Assert(m3.IsManager(), jc.IsTrue)
 }
 
+func (s *StateSuite) TestRemoveControllerMachines(c *gc.C) {
+	changes, err := s.State.EnableHA(5, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 5)
+	m3, err := s.State.Machine("3")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m3.SetHasVote(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes, err = s.State.RemoveControllerMachines("3", "4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Demoted, jc.SameContents, []string{"3", "4"})
+	c.Assert(changes.Removed, gc.HasLen, 0)
+
+	// Both machines remain controllers until they lose their vote.
+	s.assertControllerInfo(c, []string{"0", "1", "2", "3", "4"}, []string{"0", "1", "2"}, nil)
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.RemovingMachineIds, jc.SameContents, []string{"3", "4"})
+	err = m3.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m3.WantsVote(), jc.IsFalse)
+	c.Assert(m3.IsManager(), jc.IsTrue)
+
+	// Machine 4 never had a vote, so it is removed straight away;
+	// machine 3 must wait until its vote has been taken.
+	changes, err = s.State.RemoveControllerMachines("3", "4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Maintained, gc.DeepEquals, []string{"3"})
+	c.Assert(changes.Removed, gc.DeepEquals, []string{"4"})
+	s.assertControllerInfo(c, []string{"0", "1", "2", "3"}, []string{"0", "1", "2"}, nil)
+
+	err = m3.SetHasVote(false)
+	c.Assert(err, jc.ErrorIsNil)
+	changes, err = s.State.RemoveControllerMachines("3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Removed, gc.DeepEquals, []string{"3"})
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0", "1", "2"}, nil)
+	info, err = s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.RemovingMachineIds, gc.HasLen, 0)
+	err = m3.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m3.IsManager(), jc.IsFalse)
+}
+
+func (s *StateSuite) TestRemoveControllerMachinesNotController(c *gc.C) {
+	_, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RemoveControllerMachines(m.Id())
+	c.Assert(err, gc.ErrorMatches, "cannot remove controller machines: machine 3 is not a controller")
+}
+
+func (s *StateSuite) TestRemoveControllerMachinesRemainingCount(c *gc.C) {
+	_, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RemoveControllerMachines("2")
+	c.Assert(err, gc.ErrorMatches, `cannot remove controller machines: number of remaining voting controllers must be odd \(got 2\)`)
+	_, err = s.State.RemoveControllerMachines("0", "1", "2")
+	c.Assert(err, gc.ErrorMatches, "cannot remove controller machines: cannot remove all voting controller machines")
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0", "1", "2"}, nil)
+}
+
+func (s *StateSuite) TestEnableHADoesNotPromoteRemovingMachines(c *gc.C) {
+	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
+		return true, nil
+	})
+	_, err := s.State.EnableHA(5, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, id := range []string{"3", "4"} {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		err = m.SetHasVote(true)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err = s.State.RemoveControllerMachines("3", "4")
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Promoted, gc.HasLen, 0)
+	c.Assert(changes.Added, gc.HasLen, 0)
+	s.assertControllerInfo(c, []string{"0", "1", "2", "3", "4"}, []string{"0", "1", "2"}, nil)
+}
+
 func (s *StateSuite) TestEnableHADefaultsTo3(c *gc.C) {
 	changes, err := s.State.EnableHA(0, constraints.Value{}, "quantal", nil)
 	c.Assert(err, jc.ErrorIsNil)
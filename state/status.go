@@ -110,6 +110,21 @@ func setStatus(st *State, params setStatusParams) (err error) {
 	}
 	probablyUpdateStatusHistory(st, params.globalKey, doc)
 
+	// Status is updated frequently, so unconditional updates are
+	// batched if batching is enabled. As in updateStatusSource, the
+	// update is guarded by the document's txn-revno; if it's aborted,
+	// the update is retried, and any error reported, below.
+	if batcher := st.getTxnBatcher(); batcher != nil && params.token == nil {
+		txnRevno, err := st.readTxnRevno(statusesC, params.globalKey)
+		if err == nil {
+			assert := bson.D{{"txn-revno", txnRevno}}
+			err = batcher.update(statusesC, params.globalKey, assert, &doc)
+			if err != txn.ErrAborted && err != errTxnBatcherStopped {
+				return errors.Trace(err)
+			}
+		}
+	}
+
 	// Set the authoritative status document, or fail trying.
 	buildTxn := updateStatusSource(st, params.globalKey, doc)
 	if params.token != nil {
@@ -204,7 +219,7 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	if err := args.filter.Validate(); err != nil {
 		return nil, errors.Annotate(err, "validating arguments")
 	}
-	statusHistory, closer := args.st.getCollection(statusesHistoryC)
+	statusHistory, closer := args.st.getSecondaryCollection(statusesHistoryC)
 	defer closer()
 
 	var (
@@ -214,8 +229,7 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	baseQuery := bson.M{"globalkey": args.globalKey}
 	if filter.Delta != nil {
 		delta := *filter.Delta
-		// TODO(perrito666) 2016-05-02 lp:1558657
-		updated := time.Now().Add(-delta)
+		updated := args.st.clock.Now().Add(-delta)
 		baseQuery = bson.M{"updated": bson.M{"$gt": updated.UnixNano()}, "globalkey": args.globalKey}
 	}
 	if filter.Date != nil {
@@ -263,9 +277,8 @@ func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB in
 	defer closer()
 
 	// Status Record Age
-	// TODO(perrito666): 2016-04-26 lp:1558657
 	if maxHistoryTime > 0 {
-		t := time.Now().Add(-maxHistoryTime)
+		t := st.clock.Now().Add(-maxHistoryTime)
 		_, err := history.RemoveAll(bson.D{
 			{"updated", bson.M{"$lt": t.UnixNano()}},
 		})
@@ -316,3 +329,56 @@ func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB in
 	}
 	return nil
 }
+
+// PruneStatusHistoryEntries removes the oldest status history entries
+// of each entity, in every model, until no entity has more than
+// maxEntries entries. If maxEntries is 0, no entries are removed.
+func PruneStatusHistoryEntries(st *State, maxEntries int) error {
+	if maxEntries < 0 {
+		return errors.NotValidf("negative maxEntries")
+	}
+	if maxEntries == 0 {
+		return nil
+	}
+	history, closer := st.getRawCollection(statusesHistoryC)
+	defer closer()
+
+	var entities []struct {
+		Id struct {
+			ModelUUID string `bson:"model-uuid"`
+			GlobalKey string `bson:"globalkey"`
+		} `bson:"_id"`
+	}
+	err := history.Pipe([]bson.M{{
+		"$group": bson.M{
+			"_id":   bson.M{"model-uuid": "$model-uuid", "globalkey": "$globalkey"},
+			"count": bson.M{"$sum": 1},
+		},
+	}, {
+		"$match": bson.M{"count": bson.M{"$gt": maxEntries}},
+	}}).All(&entities)
+	if err != nil {
+		return errors.Annotate(err, "counting status history entries")
+	}
+	for _, entity := range entities {
+		query := bson.D{
+			{"model-uuid", entity.Id.ModelUUID},
+			{"globalkey", entity.Id.GlobalKey},
+		}
+		var docs []struct {
+			Id bson.ObjectId `bson:"_id"`
+		}
+		err := history.Find(query).Select(bson.M{"_id": 1}).Sort("-updated").Skip(maxEntries).All(&docs)
+		if err != nil {
+			return errors.Annotatef(err, "finding status history entries of %q", entity.Id.GlobalKey)
+		}
+		ids := make([]bson.ObjectId, len(docs))
+		for i, doc := range docs {
+			ids[i] = doc.Id
+		}
+		if _, err := history.RemoveAll(bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+			return errors.Annotatef(err, "removing status history entries of %q", entity.Id.GlobalKey)
+		}
+	}
+	return nil
+}
@@ -136,6 +136,41 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 	}
 }
 
+func (s *StatusHistorySuite) TestPruneStatusHistoryEntries(c *gc.C) {
+	service := s.Factory.MakeApplication(c, nil)
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: service})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: service})
+	primeUnitStatusHistory(c, unit0, 20, 0)
+	primeUnitStatusHistory(c, unit1, 5, 0)
+
+	err := state.PruneStatusHistoryEntries(s.State, 10)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit0.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 10)
+	for i, statusInfo := range history {
+		checkPrimedUnitStatus(c, statusInfo, 19-i, 0)
+	}
+
+	history, err = unit1.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 6)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryEntriesNoLimit(c *gc.C) {
+	service := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: service})
+	primeUnitStatusHistory(c, unit, 20, 0)
+
+	err := state.PruneStatusHistoryEntries(s.State, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 21)
+}
+
 func (s *StatusHistorySuite) TestStatusHistoryFiltersByDateAndDelta(c *gc.C) {
 	// TODO(perrito666) setup should be extracted into a fixture and the
 	// 6 or 7 test cases each get their own method.
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type MachineStatusSuite struct {
@@ -38,6 +39,22 @@ func (s *MachineStatusSuite) checkInitialStatus(c *gc.C) {
 	c.Check(statusInfo.Since, gc.NotNil)
 }
 
+func (s *MachineStatusSuite) TestInitialStatusUsesStateClock(c *gc.C) {
+	now := time.Date(2030, 11, 11, 11, 11, 11, 0, time.UTC)
+	state.SetClock(s.State, coretesting.NewClock(now))
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Since, gc.NotNil)
+	c.Check(statusInfo.Since.Equal(now), jc.IsTrue)
+	statusInfo, err = machine.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Since, gc.NotNil)
+	c.Check(statusInfo.Since.Equal(now), jc.IsTrue)
+}
+
 func (s *MachineStatusSuite) TestSetErrorStatusWithoutInfo(c *gc.C) {
 	now := time.Now()
 	sInfo := status.StatusInfo{
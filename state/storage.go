@@ -102,10 +102,20 @@ func parseStorageKind(value string) StorageKind {
 	}
 }
 
+// globalKey is required to implement GlobalEntity.
+func (s *storageInstance) globalKey() string {
+	return storageInstanceGlobalKey(s.doc.Id)
+}
+
+// Tag is required to implement GlobalEntity.
 func (s *storageInstance) Tag() names.Tag {
 	return s.StorageTag()
 }
 
+func storageInstanceGlobalKey(id string) string {
+	return "s#" + id
+}
+
 func (s *storageInstance) StorageTag() names.StorageTag {
 	return names.NewStorageTag(s.doc.Id)
 }
@@ -291,7 +301,7 @@ func removeStorageInstanceOps(
 		Id:     tag.Id(),
 		Assert: assert,
 		Remove: true,
-	}}
+	}, annotationRemoveOp(st, storageInstanceGlobalKey(tag.Id()))}
 
 	machineStorageOp := func(c string, id string) txn.Op {
 		return txn.Op{
@@ -927,6 +937,13 @@ func validateStoragePool(
 	return nil
 }
 
+// ValidateStoragePool returns an error if the given name does not
+// identify a storage pool or storage provider type in the model.
+func (st *State) ValidateStoragePool(poolName string) error {
+	_, _, err := poolStorageProvider(st, poolName)
+	return errors.Trace(err)
+}
+
 func poolStorageProvider(st *State, poolName string) (storage.ProviderType, storage.Provider, error) {
 	registry, err := st.storageProviderRegistry()
 	if err != nil {
@@ -0,0 +1,247 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+	"gopkg.in/tomb.v1"
+)
+
+// TxnBatcherConfig configures the batching of document updates enabled
+// by EnableTxnBatching.
+type TxnBatcherConfig struct {
+	// Clock is used to time the interval between transactions.
+	Clock clock.Clock
+
+	// FlushInterval is the longest time for which an update is held
+	// before it is applied.
+	FlushInterval time.Duration
+
+	// MaxOps is the largest number of documents updated by a single
+	// transaction. A batch is applied as soon as it reaches this
+	// size, without waiting for the flush interval.
+	MaxOps int
+}
+
+// Validate returns an error if the config cannot drive a txnBatcher.
+func (config TxnBatcherConfig) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.FlushInterval <= 0 {
+		return errors.NotValidf("non-positive FlushInterval")
+	}
+	if config.MaxOps <= 0 {
+		return errors.NotValidf("non-positive MaxOps")
+	}
+	return nil
+}
+
+// errTxnBatcherStopped is returned by txnBatcher.update when the
+// batcher has stopped, and the update must be applied directly.
+var errTxnBatcherStopped = errors.New("transaction batcher stopped")
+
+// EnableTxnBatching causes frequent, small document updates made
+// through the State, such as those of entity and instance status and
+// the refreshes of machines' provider addresses, to be coalesced and
+// applied in fewer transactions. Each update still
+// blocks until it has been applied, but may wait for up to the flush
+// interval to do so. Batching stops when the State is closed.
+//
+// Updates which must be made under some condition, such as those
+// which require leadership, are never batched.
+func (st *State) EnableTxnBatching(config TxnBatcherConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.txnBatcher != nil {
+		return errors.AlreadyExistsf("transaction batching")
+	}
+	st.txnBatcher = newTxnBatcher(config, st.runTransaction)
+	return nil
+}
+
+// getTxnBatcher returns the batcher enabled for the State, or nil if
+// batching is not enabled.
+func (st *State) getTxnBatcher() *txnBatcher {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.txnBatcher
+}
+
+// batchKey identifies a document updated by a batch.
+type batchKey struct {
+	collection string
+	id         interface{}
+}
+
+// batchedUpdate is a request to set fields of a document, if it
+// matches assert, which is sent to the txnBatcher's loop.
+type batchedUpdate struct {
+	key    batchKey
+	assert interface{}
+	set    interface{}
+	result chan error
+}
+
+// txnBatch holds the updates waiting to be applied together.
+type txnBatch struct {
+	keys    []batchKey
+	asserts map[batchKey]interface{}
+	sets    map[batchKey]interface{}
+	waiters map[batchKey][]chan error
+}
+
+func newTxnBatch() *txnBatch {
+	return &txnBatch{
+		asserts: make(map[batchKey]interface{}),
+		sets:    make(map[batchKey]interface{}),
+		waiters: make(map[batchKey][]chan error),
+	}
+}
+
+// add adds the update to the batch. An update of a document already
+// in the batch supersedes the earlier update, so that each document is
+// written once per transaction.
+func (b *txnBatch) add(u batchedUpdate) {
+	if _, ok := b.sets[u.key]; !ok {
+		b.keys = append(b.keys, u.key)
+	}
+	b.asserts[u.key] = u.assert
+	b.sets[u.key] = u.set
+	b.waiters[u.key] = append(b.waiters[u.key], u.result)
+}
+
+func (b *txnBatch) op(key batchKey) txn.Op {
+	assert := b.asserts[key]
+	if assert == nil {
+		assert = txn.DocExists
+	}
+	return txn.Op{
+		C:      key.collection,
+		Id:     key.id,
+		Assert: assert,
+		Update: bson.D{{"$set", b.sets[key]}},
+	}
+}
+
+// notify sends the result of applying the update of the document
+// with the given key to everything waiting for it.
+func (b *txnBatch) notify(key batchKey, err error) {
+	for _, result := range b.waiters[key] {
+		result <- err
+	}
+}
+
+// txnBatcher coalesces document updates, applying them together in
+// as few transactions as its config allows.
+type txnBatcher struct {
+	tomb           tomb.Tomb
+	config         TxnBatcherConfig
+	runTransaction func([]txn.Op) error
+	updates        chan batchedUpdate
+}
+
+func newTxnBatcher(config TxnBatcherConfig, runTransaction func([]txn.Op) error) *txnBatcher {
+	b := &txnBatcher{
+		config:         config,
+		runTransaction: runTransaction,
+		updates:        make(chan batchedUpdate),
+	}
+	go func() {
+		defer b.tomb.Done()
+		b.tomb.Kill(b.loop())
+	}()
+	return b
+}
+
+// Kill is part of the worker.Worker interface.
+func (b *txnBatcher) Kill() {
+	b.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (b *txnBatcher) Wait() error {
+	return b.tomb.Wait()
+}
+
+// update sets the given fields of the document with the given id in
+// the collection, if the document matches assert, returning once the
+// update has been applied. A nil assert requires only that the
+// document exists. txn.ErrAborted is returned if the document does
+// not exist or does not match, and errTxnBatcherStopped if the batcher
+// has stopped; in either case, the caller should fall back to its
+// unbatched update, which reports the reason.
+func (b *txnBatcher) update(collection string, id interface{}, assert, set interface{}) error {
+	u := batchedUpdate{
+		key:    batchKey{collection, id},
+		assert: assert,
+		set:    set,
+		result: make(chan error, 1),
+	}
+	select {
+	case b.updates <- u:
+	case <-b.tomb.Dying():
+		return errTxnBatcherStopped
+	}
+	// Every update accepted by the loop is applied, even if the
+	// batcher is stopped while it's pending.
+	return <-u.result
+}
+
+func (b *txnBatcher) loop() error {
+	var batch *txnBatch
+	var flush <-chan time.Time
+	for {
+		select {
+		case <-b.tomb.Dying():
+			if batch != nil {
+				b.apply(batch)
+			}
+			return tomb.ErrDying
+		case u := <-b.updates:
+			if batch == nil {
+				batch = newTxnBatch()
+				flush = b.config.Clock.After(b.config.FlushInterval)
+			}
+			batch.add(u)
+			if len(batch.keys) < b.config.MaxOps {
+				continue
+			}
+		case <-flush:
+		}
+		b.apply(batch)
+		batch, flush = nil, nil
+	}
+}
+
+// apply applies the updates in the batch in a single transaction. If
+// the transaction is aborted because some of the documents no longer
+// exist or match their assertions, the updates are applied one at a
+// time so that only those fail.
+func (b *txnBatcher) apply(batch *txnBatch) {
+	ops := make([]txn.Op, len(batch.keys))
+	for i, key := range batch.keys {
+		ops[i] = batch.op(key)
+	}
+	err := b.runTransaction(ops)
+	if err != txn.ErrAborted {
+		logger.Tracef("applied %d batched document updates: %v", len(ops), err)
+		for _, key := range batch.keys {
+			batch.notify(key, err)
+		}
+		return
+	}
+	logger.Debugf("batch of %d document updates aborted; applying individually", len(ops))
+	for _, key := range batch.keys {
+		batch.notify(key, b.runTransaction([]txn.Op{batch.op(key)}))
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type txnBatcherSuite struct {
+	jujutesting.IsolationSuite
+	clock  *testing.Clock
+	runner *fakeTxnRunner
+	config TxnBatcherConfig
+}
+
+var _ = gc.Suite(&txnBatcherSuite{})
+
+func (s *txnBatcherSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.runner = &fakeTxnRunner{}
+	s.config = TxnBatcherConfig{
+		Clock:         s.clock,
+		FlushInterval: time.Second,
+		MaxOps:        100,
+	}
+}
+
+func (s *txnBatcherSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*TxnBatcherConfig)
+		err    string
+	}{{
+		func(config *TxnBatcherConfig) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *TxnBatcherConfig) { config.FlushInterval = 0 },
+		"non-positive FlushInterval not valid",
+	}, {
+		func(config *TxnBatcherConfig) { config.MaxOps = 0 },
+		"non-positive MaxOps not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, test.err)
+	}
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *txnBatcherSuite) TestBatchSupersedesUpdates(c *gc.C) {
+	batch := newTxnBatch()
+	first, second := make(chan error, 1), make(chan error, 1)
+	batch.add(batchedUpdate{batchKey{"statuses", "u#mysql/0"}, nil, bson.M{"status": "maintenance"}, first})
+	batch.add(batchedUpdate{batchKey{"statuses", "u#mysql/1"}, bson.D{{"txn-revno", 3}}, bson.M{"status": "active"}, make(chan error, 1)})
+	batch.add(batchedUpdate{batchKey{"statuses", "u#mysql/0"}, bson.D{{"txn-revno", 5}}, bson.M{"status": "active"}, second})
+	c.Assert(batch.keys, jc.DeepEquals, []batchKey{
+		{"statuses", "u#mysql/0"},
+		{"statuses", "u#mysql/1"},
+	})
+	c.Check(batch.op(batch.keys[0]), jc.DeepEquals, txn.Op{
+		C:      "statuses",
+		Id:     "u#mysql/0",
+		Assert: bson.D{{"txn-revno", 5}},
+		Update: bson.D{{"$set", bson.M{"status": "active"}}},
+	})
+
+	// Both updates of the document are answered by the single write.
+	batch.notify(batch.keys[0], nil)
+	c.Check(<-first, jc.ErrorIsNil)
+	c.Check(<-second, jc.ErrorIsNil)
+}
+
+func (s *txnBatcherSuite) TestNilAssertRequiresDocument(c *gc.C) {
+	batch := newTxnBatch()
+	batch.add(batchedUpdate{batchKey{"statuses", "u#mysql/0"}, nil, bson.M{"status": "active"}, make(chan error, 1)})
+	c.Check(batch.op(batch.keys[0]).Assert, gc.Equals, txn.DocExists)
+}
+
+func (s *txnBatcherSuite) TestFlushInterval(c *gc.C) {
+	b := newTxnBatcher(s.config, s.runner.run)
+	defer workertest.CleanKill(c, b)
+
+	result := make(chan error, 1)
+	go func() { result <- b.update("statuses", "u#mysql/0", nil, bson.M{"status": "active"}) }()
+	s.waitAlarm(c)
+
+	// Nothing is applied until the interval has passed.
+	select {
+	case err := <-result:
+		c.Fatalf("update applied early: %v", err)
+	case <-time.After(testing.ShortWait):
+	}
+	c.Check(s.runner.transactions(), gc.HasLen, 0)
+
+	s.clock.Advance(time.Second)
+	select {
+	case err := <-result:
+		c.Check(err, jc.ErrorIsNil)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for update")
+	}
+	c.Check(s.runner.transactions(), jc.DeepEquals, [][]txn.Op{{{
+		C:      "statuses",
+		Id:     "u#mysql/0",
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.M{"status": "active"}}},
+	}}})
+}
+
+func (s *txnBatcherSuite) TestSimulatedLoad(c *gc.C) {
+	// Status updates from 1000 units at once are applied in 10
+	// transactions of 100 documents, rather than 1000 transactions,
+	// without waiting for the flush interval.
+	b := newTxnBatcher(s.config, s.runner.run)
+	defer workertest.CleanKill(c, b)
+
+	const units = 1000
+	var wg sync.WaitGroup
+	errs := make(chan error, units)
+	for i := 0; i < units; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("u#app/%d", i)
+			errs <- b.update("statuses", key, nil, bson.M{"status": "active"})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	txns := s.runner.transactions()
+	c.Assert(txns, gc.HasLen, units/s.config.MaxOps)
+	seen := make(map[interface{}]bool)
+	for _, ops := range txns {
+		c.Check(ops, gc.HasLen, s.config.MaxOps)
+		for _, op := range ops {
+			seen[op.Id] = true
+		}
+	}
+	c.Check(seen, gc.HasLen, units)
+}
+
+func (s *txnBatcherSuite) TestAbortedBatchAppliedIndividually(c *gc.C) {
+	s.config.MaxOps = 3
+	s.runner.missing = map[interface{}]bool{"u#gone/0": true}
+	b := newTxnBatcher(s.config, s.runner.run)
+	defer workertest.CleanKill(c, b)
+
+	results := make(map[interface{}]chan error)
+	for _, id := range []string{"u#app/0", "u#gone/0", "u#app/1"} {
+		result := make(chan error, 1)
+		results[id] = result
+		go func(id string) {
+			result <- b.update("statuses", id, nil, bson.M{"status": "active"})
+		}(id)
+	}
+	for id, result := range results {
+		select {
+		case err := <-result:
+			if id == "u#gone/0" {
+				c.Check(err, gc.Equals, txn.ErrAborted)
+			} else {
+				c.Check(err, jc.ErrorIsNil)
+			}
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for update of %v", id)
+		}
+	}
+	// The batch is tried first, then each update on its own.
+	txns := s.runner.transactions()
+	c.Assert(txns, gc.HasLen, 4)
+	c.Check(txns[0], gc.HasLen, 3)
+}
+
+func (s *txnBatcherSuite) TestError(c *gc.C) {
+	s.config.MaxOps = 1
+	s.runner.err = errors.New("boom")
+	b := newTxnBatcher(s.config, s.runner.run)
+	defer workertest.CleanKill(c, b)
+
+	err := b.update("statuses", "u#app/0", nil, bson.M{"status": "active"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *txnBatcherSuite) TestStopAppliesPending(c *gc.C) {
+	b := newTxnBatcher(s.config, s.runner.run)
+	result := make(chan error, 1)
+	go func() { result <- b.update("statuses", "u#app/0", nil, bson.M{"status": "active"}) }()
+	s.waitAlarm(c)
+
+	workertest.CleanKill(c, b)
+	select {
+	case err := <-result:
+		c.Check(err, jc.ErrorIsNil)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for update")
+	}
+	c.Check(s.runner.opCount(), gc.Equals, 1)
+
+	// Once stopped, updates must be applied directly.
+	err := b.update("statuses", "u#app/0", nil, bson.M{"status": "active"})
+	c.Check(err, gc.Equals, errTxnBatcherStopped)
+}
+
+func (s *txnBatcherSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for batch to start")
+	}
+}
+
+// fakeTxnRunner records the transactions it's asked to run, aborting
+// any which update a missing document.
+type fakeTxnRunner struct {
+	mu      sync.Mutex
+	txns    [][]txn.Op
+	missing map[interface{}]bool
+	err     error
+}
+
+func (r *fakeTxnRunner) run(ops []txn.Op) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.txns = append(r.txns, ops)
+	for _, op := range ops {
+		if r.missing[op.Id] {
+			return txn.ErrAborted
+		}
+	}
+	return r.err
+}
+
+func (r *fakeTxnRunner) transactions() [][]txn.Op {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]txn.Op(nil), r.txns...)
+}
+
+func (r *fakeTxnRunner) opCount() int {
+	count := 0
+	for _, ops := range r.transactions() {
+		count += len(ops)
+	}
+	return count
+}
@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type TxnBatcherSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&TxnBatcherSuite{})
+
+func (s *TxnBatcherSuite) enableBatching(c *gc.C) {
+	// A single op per transaction means that updates are applied
+	// without waiting for the clock.
+	err := s.State.EnableTxnBatching(state.TxnBatcherConfig{
+		Clock:         testing.NewClock(time.Now()),
+		FlushInterval: time.Minute,
+		MaxOps:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TxnBatcherSuite) TestEnableTwice(c *gc.C) {
+	s.enableBatching(c)
+	err := s.State.EnableTxnBatching(state.TxnBatcherConfig{
+		Clock:         testing.NewClock(time.Now()),
+		FlushInterval: time.Minute,
+		MaxOps:        1,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *TxnBatcherSuite) TestEnableInvalidConfig(c *gc.C) {
+	err := s.State.EnableTxnBatching(state.TxnBatcherConfig{})
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *TxnBatcherSuite) TestSetStatusBatched(c *gc.C) {
+	s.enableBatching(c)
+	unit := s.Factory.MakeUnit(c, nil)
+	now := time.Now()
+	err := unit.SetStatus(status.StatusInfo{
+		Status:  status.StatusActive,
+		Message: "batched",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := unit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.StatusActive)
+	c.Check(statusInfo.Message, gc.Equals, "batched")
+}
+
+func (s *TxnBatcherSuite) TestSetStatusBatchedRemoved(c *gc.C) {
+	s.enableBatching(c)
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	err = machine.SetInstanceStatus(status.StatusInfo{
+		Status: status.StatusRunning,
+		Since:  &now,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *TxnBatcherSuite) TestSetProviderAddressesBatched(c *gc.C) {
+	s.enableBatching(c)
+	machine := s.Factory.MakeMachine(c, nil)
+	addresses := network.NewAddresses("10.0.0.1", "8.8.8.8")
+	err := machine.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+	// The unchanged addresses are refreshed through the batcher.
+	err = machine.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machine.ProviderAddresses(), jc.DeepEquals, addresses)
+}
+
+func (s *TxnBatcherSuite) TestSetProviderAddressesBatchedDead(c *gc.C) {
+	s.enableBatching(c)
+	machine := s.Factory.MakeMachine(c, nil)
+	addresses := network.NewAddresses("10.0.0.1")
+	err := machine.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+	dead, err := s.State.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = dead.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The batched update is aborted, and the failure reported
+	// by the unbatched update.
+	err = machine.SetProviderAddresses(addresses...)
+	c.Assert(err, gc.ErrorMatches, "cannot set addresses of machine .*: not found or not alive")
+}
@@ -91,6 +91,10 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+
+	// PasswordRotation records the rotation of the unit agent's
+	// password.
+	PasswordRotation passwordRotationDoc `bson:"password-rotation,omitempty"`
 }
 
 // Unit represents the state of a service unit.
@@ -163,9 +167,13 @@ func unitGlobalKey(name string) string {
 // globalWorkloadVersionKey returns the global database key for the
 // workload version status key for this unit.
 func globalWorkloadVersionKey(name string) string {
-	return unitGlobalKey(name) + "#sat#workload-version"
+	return unitGlobalKey(name) + workloadVersionKeySuffix
 }
 
+// workloadVersionKeySuffix is appended to a unit's global key to
+// identify the status document holding its workload version.
+const workloadVersionKeySuffix = "#sat#workload-version"
+
 // globalAgentKey returns the global database key for the unit.
 func (u *Unit) globalAgentKey() string {
 	return unitAgentGlobalKey(u.doc.Name)
@@ -211,8 +219,7 @@ func (u *Unit) SetWorkloadVersion(version string) error {
 	// Store in status rather than an attribute of the unit doc - we
 	// want to avoid everything being an attr of the main docs to
 	// stop a swarm of watchers being notified for irrelevant changes.
-	// TODO(babbageclunk) lp:1558657 - should use clock stored on unit
-	now := time.Now()
+	now := u.st.clock.Now()
 	return setStatus(u.st, setStatusParams{
 		badge:     "workload",
 		globalKey: u.globalWorkloadVersionKey(),
@@ -222,9 +229,10 @@ func (u *Unit) SetWorkloadVersion(version string) error {
 	})
 }
 
-// WorkloadVersionHistory returns a HistoryGetter which enables the
-// caller to request past workload version changes.
-func (u *Unit) WorkloadVersionHistory() *HistoryGetter {
+// WorkloadVersionHistory returns a StatusHistoryGetter which enables
+// the caller to request past workload version changes, for instance to
+// audit upgrades of the workload.
+func (u *Unit) WorkloadVersionHistory() status.StatusHistoryGetter {
 	return &HistoryGetter{st: u.st, globalKey: u.globalWorkloadVersionKey()}
 }
 
@@ -272,17 +280,19 @@ func (u *Unit) SetPassword(password string) error {
 // to the value supplied. This is split out from SetPassword to allow direct
 // manipulation in tests (to check for backwards compatibility).
 func (u *Unit) setPasswordHash(passwordHash string) error {
+	update, rotation := setPasswordHashUpdate(u.doc.PasswordHash, passwordHash, u.doc.PasswordRotation, u.st.clock.Now())
 	ops := []txn.Op{{
 		C:      unitsC,
 		Id:     u.doc.DocID,
 		Assert: notDeadDoc,
-		Update: bson.D{{"$set", bson.D{{"passwordhash", passwordHash}}}},
+		Update: update,
 	}}
 	err := u.st.runTransaction(ops)
 	if err != nil {
 		return fmt.Errorf("cannot set password of unit %q: %v", u, onAbort(err, ErrDead))
 	}
 	u.doc.PasswordHash = passwordHash
+	u.doc.PasswordRotation = rotation
 	return nil
 }
 
@@ -294,13 +304,10 @@ func (u *Unit) getPasswordHash() string {
 }
 
 // PasswordValid returns whether the given password is valid
-// for the given unit.
+// for the given unit. A password replaced by a recent rotation
+// remains valid for a short time.
 func (u *Unit) PasswordValid(password string) bool {
-	agentHash := utils.AgentPasswordHash(password)
-	if agentHash == u.doc.PasswordHash {
-		return true
-	}
-	return false
+	return agentPasswordValid(password, u.doc.PasswordHash, u.doc.PasswordRotation, u.st.clock.Now())
 }
 
 // Destroy, when called on a Alive unit, advances its lifecycle as far as
@@ -346,6 +353,27 @@ func (u *Unit) Destroy() (err error) {
 	return err
 }
 
+// ForceDestroy destroys the unit as Destroy does, and queues the removal
+// from state of the unit, its subordinates and its storage attachments
+// once maxWait has passed, whether or not the unit's agent has shut down
+// by then. Unlike Destroy, it has an effect on a unit that is already
+// Dying, such as one whose agent is unreachable or whose hooks fail.
+func (u *Unit) ForceDestroy(maxWait time.Duration) error {
+	if err := u.Destroy(); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: txn.DocExists,
+	}, u.st.newDelayedCleanupOp(cleanupForceDestroyedUnit, u.doc.Name, maxWait)}
+	if err := u.st.runTransaction(ops); err != txn.ErrAborted {
+		return errors.Trace(err)
+	}
+	// The unit has already been removed.
+	return nil
+}
+
 func (u *Unit) eraseHistory() error {
 	history, closer := u.st.getCollection(statusesHistoryC)
 	defer closer()
@@ -1273,6 +1301,10 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	upgradeSeriesOps, err := assertNoUpgradeSeriesOps(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	storageOps, volumesAttached, filesystemsAttached, err := u.st.machineStorageOps(
 		&m.doc, storageParams,
 	)
@@ -1314,6 +1346,7 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 		removeStagedAssignmentOp(u.doc.DocID),
 	}
 	ops = append(ops, storageOps...)
+	ops = append(ops, upgradeSeriesOps...)
 	return ops, nil
 }
 
@@ -1473,9 +1506,10 @@ func (u *Unit) assignToNewMachine(template MachineTemplate, parentId string, con
 	template.Dirty = true
 
 	var (
-		mdoc *machineDoc
-		ops  []txn.Op
-		err  error
+		mdoc        *machineDoc
+		ops         []txn.Op
+		err         error
+		newMachines = 1
 	)
 	switch {
 	case parentId == "" && containerType == "":
@@ -1489,6 +1523,7 @@ func (u *Unit) assignToNewMachine(template MachineTemplate, parentId string, con
 		parentParams := template
 		parentParams.Jobs = []MachineJob{JobHostUnits}
 		mdoc, ops, err = u.st.addMachineInsideNewMachineOps(template, parentParams, containerType)
+		newMachines = 2
 	default:
 		// Container type is specified but no parent id.
 		mdoc, ops, err = u.st.addMachineInsideMachineOps(template, parentId, containerType)
@@ -1496,6 +1531,11 @@ func (u *Unit) assignToNewMachine(template MachineTemplate, parentId string, con
 	if err != nil {
 		return err
 	}
+	limitOps, err := u.st.modelLimitsOps(newMachines, 0)
+	if err != nil {
+		return err
+	}
+	ops = append(ops, limitOps...)
 	// Ensure the host machine is really clean.
 	if parentId != "" {
 		parentDocId := u.st.docID(parentId)
@@ -1537,6 +1577,7 @@ func (u *Unit) assignToNewMachine(template MachineTemplate, parentId string, con
 	//  * the unit has been assigned to a different machine
 	//  * the parent machine we want to create a container on was
 	//  clean but became dirty
+	//  * the model's limits would have been exceeded
 	unit, err := u.st.Unit(u.Name())
 	if err != nil {
 		return err
@@ -1547,6 +1588,9 @@ func (u *Unit) assignToNewMachine(template MachineTemplate, parentId string, con
 	case unit.doc.MachineId != "":
 		return alreadyAssignedErr
 	}
+	if err := u.st.checkModelLimits(newMachines, 0); err != nil {
+		return err
+	}
 	if parentId == "" {
 		return fmt.Errorf("cannot add top level machine: transaction aborted for unknown reason")
 	}
@@ -1994,63 +2038,13 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (m *Machine, er
 		assignContextf(&err, u.Name(), context)
 		return nil, err
 	}
-	query, err := u.findCleanMachineQuery(requireEmpty, cons)
-	if err != nil {
-		assignContextf(&err, u.Name(), context)
-		return nil, err
-	}
 
-	// Find all of the candidate machines, and associated
-	// instances for those that are provisioned. Instances
-	// will be distributed across in preference to
-	// unprovisioned machines.
-	machinesCollection, closer := u.st.getCollection(machinesC)
-	defer closer()
-	var mdocs []*machineDoc
-	if err := machinesCollection.Find(query).All(&mdocs); err != nil {
-		assignContextf(&err, u.Name(), context)
-		return nil, err
-	}
-	var unprovisioned []*Machine
-	var instances []instance.Id
-	instanceMachines := make(map[instance.Id]*Machine)
-	for _, mdoc := range mdocs {
-		m := newMachine(u.st, mdoc)
-		instance, err := m.InstanceId()
-		if errors.IsNotProvisioned(err) {
-			unprovisioned = append(unprovisioned, m)
-		} else if err != nil {
-			assignContextf(&err, u.Name(), context)
-			return nil, err
-		} else {
-			instances = append(instances, instance)
-			instanceMachines[instance] = m
-		}
-	}
-
-	// Filter the list of instances that are suitable for
-	// distribution, and then map them back to machines.
+	// The candidate machines are chosen afresh on each attempt, and
+	// the assignment asserts that nothing which influenced the
+	// choice has changed, so that concurrent assignments never place
+	// two units on the same clean machine or in the same zone on the
+	// basis of a stale view of the application's units.
 	//
-	// TODO(axw) 2014-05-30 #1324904
-	// Shuffle machines to reduce likelihood of collisions.
-	// The partition of provisioned/unprovisioned machines
-	// must be maintained.
-	if instances, err = distributeUnit(u, instances); err != nil {
-		assignContextf(&err, u.Name(), context)
-		return nil, err
-	}
-	machines := make([]*Machine, len(instances), len(instances)+len(unprovisioned))
-	for i, instance := range instances {
-		m, ok := instanceMachines[instance]
-		if !ok {
-			err := fmt.Errorf("invalid instance returned: %v", instance)
-			assignContextf(&err, u.Name(), context)
-			return nil, err
-		}
-		machines[i] = m
-	}
-	machines = append(machines, unprovisioned...)
-
 	// TODO(axw) 2014-05-30 #1253704
 	// We should not select a machine that is in the process
 	// of being provisioned. There's no point asserting that
@@ -2058,28 +2052,34 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (m *Machine, er
 	// be a period of time during which the machine may be
 	// provisioned without the fact having yet been recorded
 	// in state.
-	for _, m := range machines {
-		// Check that the unit storage is compatible with
-		// the machine in question.
-		if err := validateDynamicMachineStorageParams(m, storageParams); err != nil {
-			if errors.IsNotSupported(err) {
-				continue
+	unit := u
+	buildTxn := func(attempt int) (ops []txn.Op, err error) {
+		if attempt > 0 {
+			if unit, err = u.st.Unit(u.Name()); err != nil {
+				return nil, errors.Trace(err)
 			}
-			assignContextf(&err, u.Name(), context)
-			return nil, err
 		}
-		err := u.assignToMachine(m, true)
-		if err == nil {
-			return m, nil
+		token, err := readPlacementToken(u.st, u.doc.Application)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
-		switch errors.Cause(err) {
-		case inUseErr, machineNotAliveErr:
-		default:
-			assignContextf(&err, u.Name(), context)
-			return nil, err
+		candidates, err := unit.cleanMachineCandidates(requireEmpty, cons)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		m, ops, err = unit.assignToCleanMachineOps(candidates, requireEmpty, storageParams, token)
+		return ops, err
+	}
+	if err := u.st.run(buildTxn); err != nil {
+		if errors.Cause(err) == noCleanMachines {
+			return nil, noCleanMachines
 		}
+		assignContextf(&err, u.Name(), context)
+		return nil, err
 	}
-	return nil, noCleanMachines
+	u.doc.MachineId = m.doc.Id
+	m.doc.Clean = false
+	return m, nil
 }
 
 // UnassignFromMachine removes the assignment between this unit and the
@@ -2116,6 +2116,12 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddCorrelatedAction("", name, payload)
+}
+
+// AddCorrelatedAction adds a new Action like AddAction, recording the
+// id of the API request that caused it.
+func (u *Unit) AddCorrelatedAction(correlationId, name string, payload map[string]interface{}) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2141,7 +2147,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return u.st.enqueueAction(u.Tag(), name, payloadWithDefaults, correlationId)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -2301,7 +2307,7 @@ func addUnitOps(st *State, args addUnitOpsArgs) []txn.Op {
 	agentGlobalKey := unitAgentGlobalKey(name)
 	// TODO: consider the constraints op
 	// TODO: consider storageOps
-	return []txn.Op{
+	ops := []txn.Op{
 		createStatusOp(st, unitGlobalKey(name), args.workloadStatusDoc),
 		createStatusOp(st, agentGlobalKey, args.agentStatusDoc),
 		createStatusOp(st, globalWorkloadVersionKey(name), args.workloadVersionDoc),
@@ -2313,6 +2319,10 @@ func addUnitOps(st *State, args addUnitOpsArgs) []txn.Op {
 			Insert: args.unitDoc,
 		},
 	}
+	if args.unitDoc.Principal == "" {
+		ops = append(ops, addModelUnitRefOp(st, name))
+	}
+	return ops
 }
 
 // HistoryGetter allows getting the status history based on some identifying key.
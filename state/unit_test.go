@@ -1793,3 +1793,20 @@ func (s *UnitSuite) TestWorkloadVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(version, gc.Equals, "3.combined")
 }
+
+func (s *UnitSuite) TestWorkloadVersionHistory(c *gc.C) {
+	ch := state.AddTestingCharm(c, s.State, "dummy")
+	app := state.AddTestingService(c, s.State, "alexandrite", ch)
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, version := range []string{"5.6.30", "5.7.13"} {
+		err := unit.SetWorkloadVersion(version)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	history, err := unit.WorkloadVersionHistory().StatusHistory(status.StatusHistoryFilter{Size: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Message, gc.Equals, "5.7.13")
+	c.Check(history[1].Message, gc.Equals, "5.6.30")
+}
@@ -4,8 +4,6 @@
 package state
 
 import (
-	"time"
-
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -17,7 +15,9 @@ import (
 
 var upgradesLogger = loggo.GetLogger("juju.state.upgrade")
 
-func AddPreferredAddressesToMachines(st *State) error {
+// addPreferredAddressesToMachines sets the preferred public and
+// private addresses of each machine which is not dead.
+func addPreferredAddressesToMachines(st *State) error {
 	machines, err := st.AllMachines()
 	if err != nil {
 		return errors.Trace(err)
@@ -67,8 +67,8 @@ func runForAllEnvStates(st *State, runner func(st *State) error) error {
 	return nil
 }
 
-// AddFilesystemStatus ensures each filesystem has a status doc.
-func AddFilesystemStatus(st *State) error {
+// addFilesystemStatus ensures each filesystem has a status doc.
+func addFilesystemStatus(st *State) error {
 	return runForAllEnvStates(st, func(st *State) error {
 		filesystems, err := st.AllFilesystems()
 		if err != nil {
@@ -87,10 +87,9 @@ func AddFilesystemStatus(st *State) error {
 			if err != nil {
 				return errors.Annotate(err, "deciding filesystem status")
 			}
-			// TODO(perrito666) 2016-05-02 lp:1558657
 			ops = append(ops, createStatusOp(st, filesystem.globalKey(), statusDoc{
 				Status:  status,
-				Updated: time.Now().UnixNano(),
+				Updated: st.clock.Now().UnixNano(),
 			}))
 		}
 		if len(ops) > 0 {
@@ -120,13 +119,13 @@ func upgradingFilesystemStatus(st *State, filesystem Filesystem) (status.Status,
 	return status.StatusAttached, nil
 }
 
-// MigrateSettingsSchema migrates the schema of the settings collection,
+// migrateSettingsSchema migrates the schema of the settings collection,
 // moving non-reserved keys at the top-level into a subdoc, and introducing
 // a top-level "version" field with the initial value matching txn-revno.
 //
 // This migration takes place both before and after model-uuid migration,
 // to get the correct txn-revno value.
-func MigrateSettingsSchema(st *State) error {
+func migrateSettingsSchema(st *State) error {
 	coll, closer := st.getRawCollection(settingsC)
 	defer closer()
 
@@ -215,7 +214,7 @@ func settingsDocNeedsMigration(doc bson.M) bool {
 	return true
 }
 
-func addDefaultBindingsToServices(st *State) error {
+func addDefaultBindingsToApplications(st *State) error {
 	applications, err := st.AllApplications()
 	if err != nil {
 		return errors.Trace(err)
@@ -244,9 +243,142 @@ func addDefaultBindingsToServices(st *State) error {
 	return st.runTransaction(ops)
 }
 
-// AddDefaultEndpointBindingsToServices adds default endpoint bindings for each
+// addDefaultEndpointBindingsToServices adds default endpoint bindings for each
 // service. As long as the service has a charm URL set, each charm endpoint will
 // be bound to the default space.
-func AddDefaultEndpointBindingsToServices(st *State) error {
-	return runForAllEnvStates(st, addDefaultBindingsToServices)
+func addDefaultEndpointBindingsToServices(st *State) error {
+	return runForAllEnvStates(st, addDefaultBindingsToApplications)
+}
+
+// countMachinesWithoutPreferredAddresses returns the number of machines
+// which are not dead, and whose preferred addresses would be changed
+// by addPreferredAddressesToMachines. It selects the preferred
+// addresses just as setting the machine's addresses does, so machines
+// with no suitable address, such as those with only machine-local or
+// link-local addresses, are not counted.
+func countMachinesWithoutPreferredAddresses(st *State) (int, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	count := 0
+	for _, machine := range machines {
+		if machine.Life() == Dead {
+			continue
+		}
+		providerAddresses := machine.doc.Addresses
+		machineAddresses := machine.doc.MachineAddresses
+		_, _, changedPrivate := machine.setPrivateAddressOps(providerAddresses, machineAddresses)
+		_, _, changedPublic := machine.setPublicAddressOps(providerAddresses, machineAddresses)
+		if changedPrivate || changedPublic {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// countFilesystemsWithoutStatus returns the number of filesystems, in
+// all models, which have no status doc.
+func countFilesystemsWithoutStatus(st *State) (int, error) {
+	count := 0
+	err := runForAllEnvStates(st, func(st *State) error {
+		filesystems, err := st.AllFilesystems()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, filesystem := range filesystems {
+			if _, err := filesystem.Status(); errors.IsNotFound(err) {
+				count++
+			} else if err != nil {
+				return errors.Annotate(err, "getting status")
+			}
+		}
+		return nil
+	})
+	return count, errors.Trace(err)
+}
+
+// countSettingsNeedingMigration returns the number of settings docs
+// whose settings are not held in a subdocument.
+func countSettingsNeedingMigration(st *State) (int, error) {
+	coll, closer := st.getRawCollection(settingsC)
+	defer closer()
+
+	// It is not possible for there to exist a settings value
+	// which is a document; see settingsDocNeedsMigration.
+	count, err := coll.Find(bson.D{{"settings", bson.D{{"$not", bson.D{{"$type", 3}}}}}}).Count()
+	return count, errors.Trace(err)
+}
+
+// countApplicationsWithoutBindings returns the number of applications,
+// in all models, which have no endpoint bindings.
+func countApplicationsWithoutBindings(st *State) (int, error) {
+	count := 0
+	err := runForAllEnvStates(st, func(st *State) error {
+		applications, err := st.AllApplications()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, application := range applications {
+			if _, err := application.EndpointBindings(); errors.IsNotFound(err) {
+				count++
+			} else if err != nil {
+				return errors.Annotatef(err, "checking application %q for existing bindings", application.Name())
+			}
+		}
+		return nil
+	})
+	return count, errors.Trace(err)
+}
+
+// countModelsWithoutUnitRefs returns the number of models whose entity
+// references do not record their principal units.
+func countModelsWithoutUnitRefs(st *State) (int, error) {
+	coll, closer := st.getCollection(modelEntityRefsC)
+	defer closer()
+
+	count, err := coll.Find(bson.D{{"units", bson.D{{"$exists", false}}}}).Count()
+	return count, errors.Trace(err)
+}
+
+// addUnitRefsToModels records the principal units of each model in
+// its entity references, so that they can be counted against the
+// model's limits.
+func addUnitRefsToModels(st *State) error {
+	return runForAllEnvStates(st, func(st *State) error {
+		units, closer := st.getCollection(unitsC)
+		defer closer()
+
+		var docs []struct {
+			Name string `bson:"name"`
+		}
+		query := bson.D{{"principal", ""}}
+		if err := units.Find(query).Select(bson.D{{"name", 1}}).All(&docs); err != nil {
+			return errors.Annotate(err, "reading principal units")
+		}
+		op := txn.Op{
+			C:  modelEntityRefsC,
+			Id: st.ModelUUID(),
+		}
+		if len(docs) == 0 {
+			op.Assert = bson.D{{"units", bson.D{{"$exists", false}}}}
+			op.Update = bson.D{{"$set", bson.D{{"units", []string{}}}}}
+		} else {
+			// Units added concurrently are recorded too, so
+			// the names are added rather than set.
+			unitNames := make([]string, len(docs))
+			for i, doc := range docs {
+				unitNames[i] = doc.Name
+			}
+			op.Assert = txn.DocExists
+			op.Update = bson.D{{"$addToSet", bson.D{{"units", bson.D{{"$each", unitNames}}}}}}
+		}
+		err := st.runTransaction([]txn.Op{op})
+		if err == txn.ErrAborted {
+			// Either a unit was added concurrently, recording
+			// the model's units, or the model was removed.
+			return nil
+		}
+		return errors.Trace(err)
+	})
 }
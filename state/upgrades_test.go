@@ -135,7 +135,7 @@ func (s *upgradesSuite) TestAddPreferredAddressesToMachines(c *gc.C) {
 	m2 := machines[1]
 	m3 := machines[2]
 
-	err := AddPreferredAddressesToMachines(s.state)
+	err := addPreferredAddressesToMachines(s.state)
 	c.Assert(err, jc.ErrorIsNil)
 
 	assertMachineAddresses(c, m1, "8.8.8.8", "8.8.8.8")
@@ -149,14 +149,14 @@ func (s *upgradesSuite) TestAddPreferredAddressesToMachinesIdempotent(c *gc.C) {
 	m2 := machines[1]
 	m3 := machines[2]
 
-	err := AddPreferredAddressesToMachines(s.state)
+	err := addPreferredAddressesToMachines(s.state)
 	c.Assert(err, jc.ErrorIsNil)
 
 	assertMachineAddresses(c, m1, "8.8.8.8", "8.8.8.8")
 	assertMachineAddresses(c, m2, "8.8.4.4", "10.0.0.2")
 	assertMachineAddresses(c, m3, "", "")
 
-	err = AddPreferredAddressesToMachines(s.state)
+	err = addPreferredAddressesToMachines(s.state)
 	c.Assert(err, jc.ErrorIsNil)
 
 	assertMachineAddresses(c, m1, "8.8.8.8", "8.8.8.8")
@@ -187,7 +187,7 @@ func (s *upgradesSuite) TestAddPreferredAddressesToMachinesUpdatesExistingFields
 	assertMachineInitial(m2)
 	assertMachineInitial(m3)
 
-	err := AddPreferredAddressesToMachines(s.state)
+	err := addPreferredAddressesToMachines(s.state)
 	c.Assert(err, jc.ErrorIsNil)
 
 	assertMachineAddresses(c, m1, "8.8.8.8", "8.8.8.8")
@@ -195,6 +195,30 @@ func (s *upgradesSuite) TestAddPreferredAddressesToMachinesUpdatesExistingFields
 	assertMachineAddresses(c, m3, "", "")
 }
 
+func (s *upgradesSuite) TestCountMachinesWithoutPreferredAddresses(c *gc.C) {
+	machines := s.createMachinesWithAddresses(c)
+	m3 := machines[2]
+	s.setPreferredAddressFields(c, m3, "1.1.2.2")
+
+	// A machine with no address preferable to another is not
+	// counted, before or after the upgrade.
+	m5, err := s.state.AddMachine("quantal", JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m5.SetMachineAddresses(network.NewAddress("127.0.0.1"), network.NewAddress("fe80::1"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.removePreferredAddressFields(c, m5)
+
+	count, err := countMachinesWithoutPreferredAddresses(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 3)
+
+	err = addPreferredAddressesToMachines(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = countMachinesWithoutPreferredAddresses(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
+
 func (s *upgradesSuite) readDocIDs(c *gc.C, coll, regex string) []string {
 	settings, closer := s.state.getRawCollection(coll)
 	defer closer()
@@ -318,7 +342,7 @@ func (s *upgradesSuite) TestAddFilesystemStatusAttached(c *gc.C) {
 }
 
 func (s *upgradesSuite) assertAddFilesystemStatus(c *gc.C, filesystem Filesystem, expect status.Status) {
-	err := AddFilesystemStatus(s.state)
+	err := addFilesystemStatus(s.state)
 	c.Assert(err, jc.ErrorIsNil)
 
 	info, err := filesystem.Status()
@@ -441,7 +465,7 @@ func (s *upgradesSuite) TestMigrateSettingsSchema(c *gc.C) {
 
 	// Two rounds to check idempotency.
 	for i := 0; i < 2; i++ {
-		err = MigrateSettingsSchema(s.state)
+		err = migrateSettingsSchema(s.state)
 		c.Assert(err, jc.ErrorIsNil)
 
 		var docs []bson.M
@@ -567,12 +591,12 @@ func (s *upgradesSuite) testAddDefaultEndpointBindingsToServices(c *gc.C, runTwi
 		finalBindings := s.getServicesBindings(c, services)
 		c.Assert(finalBindings, jc.DeepEquals, expectedInitialAndFinal)
 	}
-	err := AddDefaultEndpointBindingsToServices(s.state)
+	err := addDefaultEndpointBindingsToServices(s.state)
 	c.Assert(err, jc.ErrorIsNil)
 	assertFinalBindings()
 
 	if runTwice {
-		err = AddDefaultEndpointBindingsToServices(s.state)
+		err = addDefaultEndpointBindingsToServices(s.state)
 		c.Assert(err, jc.ErrorIsNil, gc.Commentf("idempotency check failed!"))
 		assertFinalBindings()
 	}
@@ -585,3 +609,32 @@ func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServices(c *gc.C) {
 func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServicesIdempotent(c *gc.C) {
 	s.testAddDefaultEndpointBindingsToServices(c, true)
 }
+
+func (s *upgradesSuite) TestAddUnitRefsToModels(c *gc.C) {
+	wordpress := AddTestingService(c, s.state, "wordpress", AddTestingCharm(c, s.state, "wordpress"))
+	for i := 0; i < 2; i++ {
+		_, err := wordpress.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.state.runTransaction([]txn.Op{{
+		C:      modelEntityRefsC,
+		Id:     s.state.ModelUUID(),
+		Update: bson.D{{"$unset", bson.D{{"units", 1}}}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := countModelsWithoutUnitRefs(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+
+	for i := 0; i < 2; i++ {
+		err = addUnitRefsToModels(s.state)
+		c.Assert(err, jc.ErrorIsNil)
+		refs, err := readModelEntityRefs(s.state, s.state.ModelUUID())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(refs.Units, jc.SameContents, []string{"wordpress/0", "wordpress/1"})
+	}
+	count, err = countModelsWithoutUnitRefs(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
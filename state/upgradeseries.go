@@ -0,0 +1,386 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/upgradeseries"
+)
+
+// upgradeSeriesLockDoc represents the MongoDB document holding the
+// state of a machine's series upgrade. It exists from the time the
+// upgrade is prepared until every unit on the machine has completed it.
+type upgradeSeriesLockDoc struct {
+	DocID         string                          `bson:"_id"`
+	ModelUUID     string                          `bson:"model-uuid"`
+	MachineId     string                          `bson:"machine-id"`
+	FromSeries    string                          `bson:"from-series"`
+	ToSeries      string                          `bson:"to-series"`
+	MachineStatus upgradeseries.Status            `bson:"machine-status"`
+	Units         map[string]upgradeSeriesUnitDoc `bson:"units"`
+	TxnRevno      int64                           `bson:"txn-revno"`
+}
+
+type upgradeSeriesUnitDoc struct {
+	Principal string               `bson:"principal,omitempty"`
+	Status    upgradeseries.Status `bson:"status"`
+}
+
+func (doc *upgradeSeriesLockDoc) lock() upgradeseries.Lock {
+	lock := upgradeseries.Lock{
+		FromSeries:    doc.FromSeries,
+		ToSeries:      doc.ToSeries,
+		MachineStatus: doc.MachineStatus,
+		Units:         make(map[string]upgradeseries.Unit),
+	}
+	for name, unit := range doc.Units {
+		lock.Units[name] = upgradeseries.Unit{
+			Principal: unit.Principal,
+			Status:    unit.Status,
+		}
+	}
+	return lock
+}
+
+func upgradeSeriesUnitDocs(lock upgradeseries.Lock) map[string]upgradeSeriesUnitDoc {
+	units := make(map[string]upgradeSeriesUnitDoc)
+	for name, unit := range lock.Units {
+		units[name] = upgradeSeriesUnitDoc{
+			Principal: unit.Principal,
+			Status:    unit.Status,
+		}
+	}
+	return units
+}
+
+// PrepareUpgradeSeries starts an upgrade of the machine's operating
+// system to the given series, by asking the units on the machine to
+// run their pre-series-upgrade hooks. Once they all have, the machine
+// may be upgraded, and CompleteUpgradeSeries called.
+func (m *Machine) PrepareUpgradeSeries(toSeries string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot prepare series upgrade of machine %s", m.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, machineNotAliveErr
+		}
+		if err := checkUpgradeSeries(m.Series(), toSeries); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := m.upgradeSeriesLockDoc(); err == nil {
+			return nil, errors.AlreadyExistsf("series upgrade")
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := checkUnitsSupportSeries(units, toSeries); err != nil {
+			return nil, errors.Trace(err)
+		}
+		principals := make(map[string]string)
+		for _, unit := range units {
+			// A dead unit will never run its hooks, and is about
+			// to leave the machine.
+			if unit.Life() == Dead {
+				continue
+			}
+			principal, _ := unit.PrincipalName()
+			principals[unit.Name()] = principal
+		}
+		lock := upgradeseries.Prepare(m.Series(), toSeries, principals)
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &upgradeSeriesLockDoc{
+				DocID:         m.doc.DocID,
+				ModelUUID:     m.st.ModelUUID(),
+				MachineId:     m.Id(),
+				FromSeries:    lock.FromSeries,
+				ToSeries:      lock.ToSeries,
+				MachineStatus: lock.MachineStatus,
+				Units:         upgradeSeriesUnitDocs(lock),
+			},
+		}}, nil
+	}
+	return m.st.run(buildTxn)
+}
+
+// checkUpgradeSeries returns an error if a machine cannot be upgraded
+// from one series to the other.
+func checkUpgradeSeries(fromSeries, toSeries string) error {
+	if toSeries == fromSeries {
+		return errors.Errorf("machine is already running series %q", toSeries)
+	}
+	toOS, err := series.GetOSFromSeries(toSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fromOS, err := series.GetOSFromSeries(fromSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if toOS != fromOS {
+		return errors.Errorf("series %q is not a %s series", toSeries, fromOS)
+	}
+	return nil
+}
+
+// checkUnitsSupportSeries returns an error if the charm of any of the
+// given units does not support the given series.
+func checkUnitsSupportSeries(units []*Unit, toSeries string) error {
+	checked := make(map[string]bool)
+	for _, unit := range units {
+		if unit.Life() == Dead || checked[unit.ApplicationName()] {
+			continue
+		}
+		app, err := unit.Application()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// For old-style charms with the series in the URL, that
+		// series is the one and only supported series.
+		var supportedSeries []string
+		if series := ch.URL().Series; series != "" {
+			supportedSeries = []string{series}
+		} else {
+			supportedSeries = ch.Meta().Series
+		}
+		if !set.NewStrings(supportedSeries...).Contains(toSeries) {
+			return errors.Errorf(
+				"unit %q: charm %q does not support series %q",
+				unit.Name(), ch.URL(), toSeries,
+			)
+		}
+		checked[unit.ApplicationName()] = true
+	}
+	return nil
+}
+
+// UpgradeSeriesLock returns the state of the machine's series upgrade.
+// It returns an error satisfying errors.IsNotFound if no series upgrade
+// is in progress.
+func (m *Machine) UpgradeSeriesLock() (upgradeseries.Lock, error) {
+	doc, err := m.upgradeSeriesLockDoc()
+	if err != nil {
+		return upgradeseries.Lock{}, errors.Trace(err)
+	}
+	return doc.lock(), nil
+}
+
+func (m *Machine) upgradeSeriesLockDoc() (*upgradeSeriesLockDoc, error) {
+	return getUpgradeSeriesLockDoc(m.st, m.Id())
+}
+
+func getUpgradeSeriesLockDoc(st *State, machineId string) (*upgradeSeriesLockDoc, error) {
+	coll, closer := st.getCollection(upgradeSeriesLocksC)
+	defer closer()
+
+	var doc upgradeSeriesLockDoc
+	err := coll.FindId(machineId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("series upgrade of machine %s", machineId)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get series upgrade of machine %s", machineId)
+	}
+	return &doc, nil
+}
+
+// CompleteUpgradeSeries records that the machine's operating system has
+// been upgraded, and asks the units on the machine to run their
+// post-series-upgrade hooks. Once they all have, the series upgrade is
+// finished.
+func (m *Machine) CompleteUpgradeSeries() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot complete series upgrade of machine %s", m.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, machineNotAliveErr
+		}
+		doc, err := m.upgradeSeriesLockDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		lock := doc.lock()
+		if err := lock.Complete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"series", lock.ToSeries}}}},
+		}, updateUpgradeSeriesLockOp(doc, lock)}, nil
+	}
+	if err := m.st.run(buildTxn); err != nil {
+		return err
+	}
+	return m.Refresh()
+}
+
+// AbortUpgradeSeries abandons the machine's series upgrade, so long as
+// its operating system has not yet been upgraded. Units that have
+// already run their pre-series-upgrade hook are not asked to undo it.
+func (m *Machine) AbortUpgradeSeries() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot abort series upgrade of machine %s", m.Id())
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc, err := m.upgradeSeriesLockDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		lock := doc.lock()
+		if err := lock.CheckAbort(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      upgradeSeriesLocksC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Remove: true,
+		}}, nil
+	}
+	return m.st.run(buildTxn)
+}
+
+// updateUpgradeSeriesLockOp returns the operation needed to replace
+// the series upgrade held in the given document with the given lock,
+// or to remove the document once the upgrade is finished.
+func updateUpgradeSeriesLockOp(doc *upgradeSeriesLockDoc, lock upgradeseries.Lock) txn.Op {
+	op := txn.Op{
+		C:      upgradeSeriesLocksC,
+		Id:     doc.DocID,
+		Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+	}
+	if lock.MachineStatus == upgradeseries.Completed {
+		op.Remove = true
+		return op
+	}
+	op.Update = bson.D{{"$set", bson.D{
+		{"machine-status", lock.MachineStatus},
+		{"units", upgradeSeriesUnitDocs(lock)},
+	}}}
+	return op
+}
+
+// WatchUpgradeSeries returns a watcher that fires when the machine's
+// series upgrade is prepared, progresses, or finishes.
+func (m *Machine) WatchUpgradeSeries() NotifyWatcher {
+	return newEntityWatcher(m.st, upgradeSeriesLocksC, m.doc.DocID)
+}
+
+// removeUpgradeSeriesLockOp returns the operation needed to remove the
+// series upgrade of the machine with the given id, if any.
+func removeUpgradeSeriesLockOp(st *State, machineId string) txn.Op {
+	return txn.Op{
+		C:      upgradeSeriesLocksC,
+		Id:     st.docID(machineId),
+		Remove: true,
+	}
+}
+
+// removeUnitFromUpgradeSeriesLockOps returns the operations needed to
+// drop the given unit from the series upgrade of its machine, if any,
+// so that the upgrade does not wait for a unit that has left.
+func removeUnitFromUpgradeSeriesLockOps(st *State, u *Unit) ([]txn.Op, error) {
+	if u.doc.MachineId == "" {
+		return nil, nil
+	}
+	doc, err := getUpgradeSeriesLockDoc(st, u.doc.MachineId)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, ok := doc.Units[u.doc.Name]; !ok {
+		return nil, nil
+	}
+	lock := doc.lock()
+	lock.RemoveUnit(u.doc.Name)
+	return []txn.Op{updateUpgradeSeriesLockOp(doc, lock)}, nil
+}
+
+// assertNoUpgradeSeriesOps returns the operations needed to ensure
+// that no series upgrade of the given machine is in progress. Units
+// may not be placed on a machine while its series is being upgraded,
+// as they would not take part in the upgrade.
+func assertNoUpgradeSeriesOps(m *Machine) ([]txn.Op, error) {
+	if _, err := m.upgradeSeriesLockDoc(); err == nil {
+		return nil, errors.Errorf("machine %s is upgrading its series", m.Id())
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:      upgradeSeriesLocksC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocMissing,
+	}}, nil
+}
+
+// UpgradeSeriesStatus returns the unit's progress through the series
+// upgrade of its machine. It returns an error satisfying
+// errors.IsNotFound if no series upgrade of the machine is in progress,
+// or the unit was deployed to the machine after it was prepared.
+func (u *Unit) UpgradeSeriesStatus() (upgradeseries.Status, error) {
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	doc, err := getUpgradeSeriesLockDoc(u.st, machineId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	unit, ok := doc.Units[u.Name()]
+	if !ok {
+		return "", errors.NotFoundf("unit %q in series upgrade of machine %s", u.Name(), machineId)
+	}
+	return unit.Status, nil
+}
+
+// SetUpgradeSeriesStatus records that the unit has run its
+// pre-series-upgrade hook, with status PrepareCompleted, or its
+// post-series-upgrade hook, with status Completed. This may start the
+// series upgrade of other units on the machine.
+func (u *Unit) SetUpgradeSeriesStatus(status upgradeseries.Status) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set series upgrade status of unit %q", u.Name())
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc, err := getUpgradeSeriesLockDoc(u.st, machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		lock := doc.lock()
+		if err := lock.SetUnitStatus(u.Name(), status); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{updateUpgradeSeriesLockOp(doc, lock)}, nil
+	}
+	return u.st.run(buildTxn)
+}
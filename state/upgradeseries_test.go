@@ -0,0 +1,306 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/upgradeseries"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+const mysqlMeta = `
+name: mysql
+summary: "Database engine"
+description: "A pretty popular database"
+series:
+  - quantal
+  - trusty
+provides:
+  server: mysql
+`
+
+const loggingMeta = `
+name: logging
+summary: "Subordinate logging test charm"
+description: "Logs for its principal"
+subordinate: true
+series:
+  - quantal
+  - trusty
+requires:
+  info:
+    interface: juju-info
+    scope: container
+`
+
+type UpgradeSeriesSuite struct {
+	ConnSuite
+	machine     *state.Machine
+	principal   *state.Unit
+	subordinate *state.Unit
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := state.AddTestingServiceForSeries(c, s.State, "quantal", "mysql",
+		state.AddCustomCharm(c, s.State, "mysql", "metadata.yaml", mysqlMeta, "", -1),
+	)
+	s.principal, err = mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.principal.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	state.AddTestingServiceForSeries(c, s.State, "quantal", "logging",
+		state.AddCustomCharm(c, s.State, "logging", "metadata.yaml", loggingMeta, "", -1),
+	)
+	eps, err := s.State.InferEndpoints("mysql", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(s.principal)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.subordinate, err = s.State.Unit("logging/0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) assertUnitStatus(c *gc.C, unit *state.Unit, expect upgradeseries.Status) {
+	status, err := unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, expect)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeries(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	lock, err := s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock, jc.DeepEquals, upgradeseries.Lock{
+		FromSeries:    "quantal",
+		ToSeries:      "trusty",
+		MachineStatus: upgradeseries.PrepareStarted,
+		Units: map[string]upgradeseries.Unit{
+			"mysql/0":   {Status: upgradeseries.NotStarted},
+			"logging/0": {Principal: "mysql/0", Status: upgradeseries.PrepareStarted},
+		},
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeriesAlreadyPrepared(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, gc.ErrorMatches, `cannot prepare series upgrade of machine 0: series upgrade already exists`)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeriesInvalidSeries(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("quantal")
+	c.Assert(err, gc.ErrorMatches, `cannot prepare series upgrade of machine 0: machine is already running series "quantal"`)
+	err = s.machine.PrepareUpgradeSeries("win2012r2")
+	c.Assert(err, gc.ErrorMatches, `cannot prepare series upgrade of machine 0: series "win2012r2" is not a Ubuntu series`)
+	_, err = s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSeriesSuite) TestUpgradeSeries(c *gc.C) {
+	_, err := s.principal.UpgradeSeriesStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnitStatus(c, s.principal, upgradeseries.NotStarted)
+	s.assertUnitStatus(c, s.subordinate, upgradeseries.PrepareStarted)
+
+	// The machine cannot be completed until all units are prepared.
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot complete series upgrade of machine 0: .*`)
+
+	// The principal only prepares once its subordinate has.
+	err = s.subordinate.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnitStatus(c, s.principal, upgradeseries.PrepareStarted)
+	err = s.principal.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	lock, err := s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.PrepareCompleted)
+
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Series(), gc.Equals, "trusty")
+	s.assertUnitStatus(c, s.principal, upgradeseries.CompleteStarted)
+	s.assertUnitStatus(c, s.subordinate, upgradeseries.PrepareCompleted)
+
+	// The subordinate only completes once its principal has.
+	err = s.subordinate.SetUpgradeSeriesStatus(upgradeseries.Completed)
+	c.Assert(err, gc.ErrorMatches, `cannot set series upgrade status of unit "logging/0": .*`)
+	err = s.principal.SetUpgradeSeriesStatus(upgradeseries.Completed)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnitStatus(c, s.subordinate, upgradeseries.CompleteStarted)
+	err = s.subordinate.SetUpgradeSeriesStatus(upgradeseries.Completed)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once every unit has completed, the series upgrade is finished.
+	_, err = s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSeriesSuite) TestWatchUpgradeSeries(c *gc.C) {
+	w := s.machine.WatchUpgradeSeries()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.subordinate.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *UpgradeSeriesSuite) TestRemoveMachineRemovesUpgradeSeries(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machine.UpgradeSeriesLock()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeriesUnsupportedByCharm(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("xenial")
+	c.Assert(err, gc.ErrorMatches, `cannot prepare series upgrade of machine 0: unit "(mysql|logging)/0": charm "local:(mysql|logging)-1" does not support series "xenial"`)
+
+	// Charms with the series in their URL support only that series.
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, gc.ErrorMatches, `cannot prepare series upgrade of machine 1: unit "wordpress/0": charm "local:quantal/quantal-wordpress-3" does not support series "trusty"`)
+}
+
+func (s *UpgradeSeriesSuite) TestAbortUpgradeSeries(c *gc.C) {
+	err := s.machine.AbortUpgradeSeries()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.subordinate.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.AbortUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.machine.Series(), gc.Equals, "quantal")
+
+	// The series upgrade may be prepared again.
+	err = s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestAbortUpgradeSeriesAfterComplete(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	for _, unit := range []*state.Unit{s.subordinate, s.principal} {
+		err = unit.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.AbortUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot abort series upgrade of machine 0: cannot abort series upgrade with status "complete started"`)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeriesSkipsDeadUnits(c *gc.C) {
+	err := s.subordinate.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	lock, err := s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Units, jc.DeepEquals, map[string]upgradeseries.Unit{
+		"mysql/0": {Status: upgradeseries.PrepareStarted},
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestRemoveUnitRemovesItFromUpgradeSeries(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once its subordinate has left, the principal no longer waits
+	// for it.
+	err = s.subordinate.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.subordinate.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	lock, err := s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.Units, jc.DeepEquals, map[string]upgradeseries.Unit{
+		"mysql/0": {Status: upgradeseries.PrepareStarted},
+	})
+
+	err = s.principal.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.principal.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	lock, err = s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.MachineStatus, gc.Equals, upgradeseries.PrepareCompleted)
+}
+
+func (s *UpgradeSeriesSuite) TestAssignUnitBlockedByUpgradeSeries(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "mysql/1" to machine 0: machine 0 is upgrading its series`)
+
+	err = s.machine.AbortUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestAssignUnitUpgradeSeriesPreparedConcurrently(c *gc.C) {
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.machine.PrepareUpgradeSeries("trusty")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "mysql/1" to machine 0: machine 0 is upgrading its series`)
+}
@@ -278,6 +278,10 @@ type userDoc struct {
 	PasswordSalt string    `bson:"passwordsalt"`
 	CreatedBy    string    `bson:"createdby"`
 	DateCreated  time.Time `bson:"datecreated"`
+
+	// SecretKeyExpiry, if set, holds the time after which the
+	// secret key may no longer be used to register.
+	SecretKeyExpiry *time.Time `bson:"secretkeyexpiry,omitempty"`
 }
 
 type userLastLoginDoc struct {
@@ -402,6 +406,60 @@ func (u *User) SecretKey() []byte {
 	return u.doc.SecretKey
 }
 
+// SecretKeyExpiry returns the time after which the user's secret key
+// may no longer be used to register, or the zero time if it does not
+// expire.
+func (u *User) SecretKeyExpiry() time.Time {
+	if u.doc.SecretKeyExpiry == nil {
+		return time.Time{}
+	}
+	return *u.doc.SecretKeyExpiry
+}
+
+// SecretKeyExpired reports whether the user's secret key has expired
+// at the given time.
+func (u *User) SecretKeyExpired(now time.Time) bool {
+	return u.doc.SecretKeyExpiry != nil && now.After(*u.doc.SecretKeyExpiry)
+}
+
+// ResetSecretKey assigns the user a new, randomly generated secret key
+// with which they may register, replacing any existing secret key. The
+// secret key may not be used after the given expiry time; if the time
+// is zero, it does not expire. The user's password, if any, remains
+// valid until registration sets a new one.
+func (u *User) ResetSecretKey(expiry time.Time) ([]byte, error) {
+	if err := u.ensureNotDeleted(); err != nil {
+		return nil, errors.Annotate(err, "cannot reset secret key")
+	}
+	var secretKey [32]byte
+	if _, err := rand.Read(secretKey[:]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var expiryPtr *time.Time
+	set := bson.D{{"secretkey", secretKey[:]}}
+	update := bson.D{}
+	if expiry.IsZero() {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"secretkeyexpiry", ""}}})
+	} else {
+		expiry = expiry.UTC().Round(time.Second)
+		expiryPtr = &expiry
+		set = append(set, bson.DocElem{"secretkeyexpiry", expiry})
+	}
+	update = append(update, bson.DocElem{"$set", set})
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     u.Name(),
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := u.st.runTransaction(ops); err != nil {
+		return nil, errors.Annotatef(err, "cannot reset secret key of user %q", u.Name())
+	}
+	u.doc.SecretKey = secretKey[:]
+	u.doc.SecretKeyExpiry = expiryPtr
+	return u.doc.SecretKey, nil
+}
+
 // SetPassword sets the password associated with the User.
 func (u *User) SetPassword(password string) error {
 	if err := u.ensureNotDeleted(); err != nil {
@@ -429,7 +487,10 @@ func (u *User) SetPasswordHash(pwHash string, pwSalt string) error {
 	}}}
 	if u.doc.SecretKey != nil {
 		update = append(update,
-			bson.DocElem{"$unset", bson.D{{"secretkey", ""}}},
+			bson.DocElem{"$unset", bson.D{
+				{"secretkey", ""},
+				{"secretkeyexpiry", ""},
+			}},
 		)
 	}
 	ops := []txn.Op{{
@@ -444,6 +505,7 @@ func (u *User) SetPasswordHash(pwHash string, pwSalt string) error {
 	u.doc.PasswordHash = pwHash
 	u.doc.PasswordSalt = pwSalt
 	u.doc.SecretKey = nil
+	u.doc.SecretKeyExpiry = nil
 	return nil
 }
 
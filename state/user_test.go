@@ -374,3 +374,55 @@ func (s *UserSuite) TestSetPasswordClearsSecretKey(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(u.SecretKey(), gc.IsNil)
 }
+
+func (s *UserSuite) TestResetSecretKey(c *gc.C) {
+	u, err := s.State.AddUser("bob", "display", "pass", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.SecretKeyExpiry().IsZero(), jc.IsTrue)
+
+	expiry := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	key, err := u.ResetSecretKey(expiry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.HasLen, 32)
+
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.SecretKey(), gc.DeepEquals, key)
+	c.Assert(u.SecretKeyExpiry(), gc.Equals, expiry)
+	c.Assert(u.SecretKeyExpired(expiry), jc.IsFalse)
+	c.Assert(u.SecretKeyExpired(expiry.Add(time.Second)), jc.IsTrue)
+
+	// The password remains valid until registration sets a new one.
+	c.Assert(u.PasswordValid("pass"), jc.IsTrue)
+
+	// Resetting again replaces the key; a zero expiry never expires.
+	newKey, err := u.ResetSecretKey(time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newKey, gc.Not(gc.DeepEquals), key)
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.SecretKeyExpiry().IsZero(), jc.IsTrue)
+	c.Assert(u.SecretKeyExpired(expiry.Add(time.Hour)), jc.IsFalse)
+}
+
+func (s *UserSuite) TestSetPasswordClearsSecretKeyExpiry(c *gc.C) {
+	u, err := s.State.AddUser("bob", "display", "pass", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = u.ResetSecretKey(time.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.SetPassword("anything")
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.SecretKey(), gc.IsNil)
+	c.Assert(u.SecretKeyExpiry().IsZero(), jc.IsTrue)
+}
+
+func (s *UserSuite) TestResetSecretKeyDeletedUser(c *gc.C) {
+	u, err := s.State.AddUser("bob", "display", "pass", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveUser(u.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = u.ResetSecretKey(time.Time{})
+	c.Assert(err, gc.ErrorMatches, `cannot reset secret key: user "bob" deleted`)
+}
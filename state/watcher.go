@@ -1685,9 +1685,83 @@ func (w *machineAddressesWatcher) loop() error {
 	}
 }
 
-// WatchCleanups starts and returns a CleanupWatcher.
+// WatchCleanups starts and returns a CleanupWatcher. As well as
+// notifying of changes to the cleanups collection, the watcher notifies
+// when a delayed cleanup becomes due.
 func (st *State) WatchCleanups() NotifyWatcher {
-	return newNotifyCollWatcher(st, cleanupsC, isLocalID(st))
+	return newCleanupWatcher(st)
+}
+
+// cleanupWatcher notifies of changes to the cleanups collection, and
+// when the earliest delayed cleanup becomes due.
+type cleanupWatcher struct {
+	commonWatcher
+	sink chan struct{}
+}
+
+func newCleanupWatcher(st *State) NotifyWatcher {
+	w := &cleanupWatcher{
+		commonWatcher: newCommonWatcher(st),
+		sink:          make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.sink)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *cleanupWatcher) Changes() <-chan struct{} {
+	return w.sink
+}
+
+// nextDue returns a channel which receives when the earliest delayed
+// cleanup not yet due becomes due, or nil if there is none.
+func (w *cleanupWatcher) nextDue() (<-chan time.Time, error) {
+	due, ok, err := w.st.nextCleanupDue()
+	if err != nil || !ok {
+		return nil, errors.Trace(err)
+	}
+	clock := w.st.clock
+	return clock.After(due.Sub(clock.Now())), nil
+}
+
+func (w *cleanupWatcher) loop() error {
+	in := make(chan watcher.Change)
+
+	w.watcher.WatchCollectionWithFilter(cleanupsC, in, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(cleanupsC, in)
+
+	due, err := w.nextDue()
+	if err != nil {
+		return err
+	}
+	out := w.sink // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-in:
+			if _, ok := collect(change, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			if due, err = w.nextDue(); err != nil {
+				return err
+			}
+			out = w.sink
+		case <-due:
+			if due, err = w.nextDue(); err != nil {
+				return err
+			}
+			out = w.sink
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
 }
 
 // actionStatusWatcher is a StringsWatcher that filters notifications
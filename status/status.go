@@ -174,6 +174,10 @@ const (
 
 	// StatusAvailable indicates that the model is available for use.
 	StatusAvailable Status = "available"
+
+	// StatusSuspended indicates that the model's workers have been
+	// suspended, typically because its cloud credential is not valid.
+	StatusSuspended Status = "suspended"
 )
 
 const (
@@ -280,6 +284,7 @@ func ValidModelStatus(status Status) bool {
 	switch status {
 	case
 		StatusAvailable,
+		StatusSuspended,
 		StatusDestroying:
 		return true
 	default:
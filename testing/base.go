@@ -48,7 +48,11 @@ func (s *JujuOSEnvSuite) SetUpTest(c *gc.C) {
 		osenv.JujuModelEnvKey,
 		osenv.JujuLoggingConfigEnvKey,
 		osenv.JujuFeatureFlagEnvKey,
+		osenv.JujuAPIConnectionEnvKey,
+		osenv.JujuRecordOperationsEnvKey,
+		osenv.JujuStoreKeyEnvKey,
 		osenv.XDGDataHome,
+		osenv.XDGRuntimeDir,
 	} {
 		s.oldEnvironment[name] = os.Getenv(name)
 		os.Setenv(name, "")
@@ -7,18 +7,32 @@ package lxdclient
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/lxc/lxd"
+	"github.com/lxc/lxd/shared"
 
 	"github.com/juju/juju/utils/stringforwarder"
 )
 
 type rawImageClient interface {
 	GetAlias(string) string
+	ListImages() ([]shared.ImageInfo, error)
+	DeleteImage(image string) error
+	ListContainers() ([]shared.ContainerInfo, error)
 }
 
+// imageNamePrefix is the prefix of the aliases given to the images
+// copied from image sources by EnsureImageExists.
+const imageNamePrefix = "ubuntu-"
+
+// baseImageKey is the container config key holding the fingerprint
+// of the image the container was created from.
+const baseImageKey = "volatile.base_image"
+
 type remoteClient interface {
 	URL() string
 	GetAlias(name string) string
@@ -154,5 +168,59 @@ func (i *imageClient) EnsureImageExists(series string, sources []Remote, copyPro
 func (i imageClient) ImageNameForSeries(series string) string {
 	// TODO(jam) Do we need 'ubuntu' in there? We only need it if "series"
 	// would collide, but all our supported series are disjoint
-	return fmt.Sprintf("ubuntu-%s", series)
+	return imageNamePrefix + series
+}
+
+// RemoveUnusedImages removes the images copied by EnsureImageExists
+// from which no container has been created, and which have not been
+// used since the given time. Images that have never been used are
+// judged by the time they were copied. The aliases of the images
+// removed are returned.
+func (i *imageClient) RemoveUnusedImages(unusedSince time.Time) ([]string, error) {
+	containers, err := i.raw.ListContainers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	inUse := make(map[string]bool)
+	for _, container := range containers {
+		if fingerprint := container.Config[baseImageKey]; fingerprint != "" {
+			inUse[fingerprint] = true
+		}
+	}
+
+	images, err := i.raw.ListImages()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var removed []string
+	for _, image := range images {
+		name := cachedImageName(image)
+		if name == "" || inUse[image.Fingerprint] {
+			continue
+		}
+		lastUsed := image.LastUsedDate
+		if lastUsed.IsZero() {
+			lastUsed = image.UploadDate
+		}
+		if lastUsed.After(unusedSince) {
+			continue
+		}
+		logger.Infof("removing unused image %s (%s), last used %v", name, image.Fingerprint, lastUsed)
+		if err := i.raw.DeleteImage(image.Fingerprint); err != nil {
+			return removed, errors.Annotatef(err, "removing image %s", name)
+		}
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
+
+// cachedImageName returns the alias given to the image by
+// EnsureImageExists, or "" if the image was not copied by it.
+func cachedImageName(image shared.ImageInfo) string {
+	for _, alias := range image.Aliases {
+		if strings.HasPrefix(alias.Name, imageNamePrefix) {
+			return alias.Name
+		}
+	}
+	return ""
 }
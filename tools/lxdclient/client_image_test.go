@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared"
 	gc "gopkg.in/check.v1"
 
 	coretesting "github.com/juju/juju/testing"
@@ -308,3 +309,62 @@ func (s *imageSuite) TestEnsureImageExistsCallbackIncludesSourceURL(c *gc.C) {
 		c.Fatalf("no messages received")
 	}
 }
+
+func (s *imageSuite) TestRemoveUnusedImages(c *gc.C) {
+	now := time.Now()
+	raw := &stubClient{
+		stub: s.Stub,
+		Instances: []shared.ContainerInfo{{
+			Name:   "juju-f75cba-machine-0",
+			Config: map[string]string{"volatile.base_image": "in-use"},
+		}},
+		Images: []shared.ImageInfo{{
+			// Used by a container.
+			Fingerprint:  "in-use",
+			Aliases:      []shared.ImageAlias{{Name: "ubuntu-trusty"}},
+			LastUsedDate: now.Add(-48 * time.Hour),
+		}, {
+			// Not copied by juju.
+			Fingerprint:  "not-juju",
+			Aliases:      []shared.ImageAlias{{Name: "my-image"}},
+			LastUsedDate: now.Add(-48 * time.Hour),
+		}, {
+			// Used recently.
+			Fingerprint:  "recent",
+			Aliases:      []shared.ImageAlias{{Name: "ubuntu-precise"}},
+			LastUsedDate: now.Add(-time.Minute),
+		}, {
+			// Copied recently, and never used.
+			Fingerprint: "new",
+			Aliases:     []shared.ImageAlias{{Name: "ubuntu-yakkety"}},
+			UploadDate:  now.Add(-time.Minute),
+		}, {
+			Fingerprint:  "unused",
+			Aliases:      []shared.ImageAlias{{Name: "ubuntu-xenial"}},
+			LastUsedDate: now.Add(-48 * time.Hour),
+		}},
+	}
+	client := &imageClient{raw: raw}
+	removed, err := client.RemoveUnusedImages(now.Add(-24 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(removed, jc.DeepEquals, []string{"ubuntu-xenial"})
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"ListContainers", nil},
+		{"ListImages", nil},
+		{"DeleteImage", []interface{}{"unused"}},
+	})
+}
+
+func (s *imageSuite) TestRemoveUnusedImagesDeleteError(c *gc.C) {
+	raw := &stubClient{
+		stub: s.Stub,
+		Images: []shared.ImageInfo{{
+			Fingerprint: "unused",
+			Aliases:     []shared.ImageAlias{{Name: "ubuntu-xenial"}},
+		}},
+	}
+	client := &imageClient{raw: raw}
+	s.Stub.SetErrors(nil, nil, errors.New("boom"))
+	_, err := client.RemoveUnusedImages(time.Now())
+	c.Assert(err, gc.ErrorMatches, "removing image ubuntu-xenial: boom")
+}
@@ -58,6 +58,30 @@ func (p profileClient) CreateProfile(name string, config map[string]string) erro
 	return nil
 }
 
+// CreateProfileWithDevices creates a new lxc profile with the given
+// config, as CreateProfile does, and adds the given devices to it.
+func (p profileClient) CreateProfileWithDevices(name string, config map[string]string, devices Devices) error {
+	if err := p.CreateProfile(name, config); err != nil {
+		return errors.Trace(err)
+	}
+	for devname, device := range devices {
+		props := deviceProperties(device)
+		if _, err := p.raw.ProfileDeviceAdd(name, devname, device["type"], props); err != nil {
+			return errors.Annotatef(err, "adding device %q to profile %q", devname, name)
+		}
+	}
+	return nil
+}
+
+// ListProfiles returns the names of all the profiles.
+func (p profileClient) ListProfiles() ([]string, error) {
+	profiles, err := p.raw.ListProfiles()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return profiles, nil
+}
+
 // HasProfile returns true/false if the profile exists.
 func (p profileClient) HasProfile(name string) (bool, error) {
 	profiles, err := p.raw.ListProfiles()
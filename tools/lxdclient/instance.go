@@ -151,12 +151,12 @@ func newInstanceSummary(info *shared.ContainerInfo) InstanceSummary {
 	archStr := arch.NormaliseArch(info.Architecture)
 
 	var numCores uint = 0 // default to all
-	if raw := info.Config["limits.cpu"]; raw != "" {
+	if raw := expandedConfigValue(info, "limits.cpu"); raw != "" {
 		fmt.Sscanf(raw, "%d", &numCores)
 	}
 
 	var mem uint = 0 // default to all
-	if raw := info.Config["limits.memory"]; raw != "" {
+	if raw := expandedConfigValue(info, "limits.memory"); raw != "" {
 		result, err := shared.ParseByteSizeString(raw)
 		if err != nil {
 			logger.Errorf("failed to parse %s into bytes, ignoring err: %s", raw, err)
@@ -196,6 +196,15 @@ func newInstanceSummary(info *shared.ContainerInfo) InstanceSummary {
 	}
 }
 
+// expandedConfigValue returns the value of the given config key for
+// the container, including config applied by its profiles.
+func expandedConfigValue(info *shared.ContainerInfo, key string) string {
+	if value, ok := info.ExpandedConfig[key]; ok {
+		return value
+	}
+	return info.Config[key]
+}
+
 // Instance represents a single realized LXD container.
 type Instance struct {
 	InstanceSummary
@@ -57,6 +57,18 @@ func (s *instanceSuite) TestNewInstanceSummaryTemplate(c *gc.C) {
 	c.Check(summary.Metadata, gc.DeepEquals, map[string]string{"something": "something value"})
 }
 
+func (s *instanceSuite) TestNewInstanceSummaryProfileLimits(c *gc.C) {
+	info := templateContainerInfo
+	info.Config = map[string]string{}
+	info.ExpandedConfig = map[string]string{
+		"limits.cpu":    "4",
+		"limits.memory": "2GB",
+	}
+	summary := lxdclient.NewInstanceSummary(&info)
+	c.Check(summary.Hardware.NumCores, gc.Equals, uint(4))
+	c.Check(summary.Hardware.MemoryMB, gc.Equals, uint(2048))
+}
+
 func infoWithMemory(mem string) *lxdshared.ContainerInfo {
 	info := templateContainerInfo
 	info.Config = map[string]string{
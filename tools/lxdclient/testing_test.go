@@ -51,6 +51,7 @@ type stubClient struct {
 	ReturnCode int
 	Response   *lxd.Response
 	Aliases    map[string]string
+	Images     []shared.ImageInfo
 }
 
 func (s *stubClient) WaitForSuccess(waitURL string) error {
@@ -106,6 +107,24 @@ func (s *stubClient) GetAlias(alias string) string {
 	return s.Aliases[alias]
 }
 
+func (s *stubClient) ListImages() ([]shared.ImageInfo, error) {
+	s.stub.AddCall("ListImages")
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.Images, nil
+}
+
+func (s *stubClient) DeleteImage(image string) error {
+	s.stub.AddCall("DeleteImage", image)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (s *stubClient) Init(name, remote, image string, profiles *[]string, ephem bool) (*lxd.Response, error) {
 	s.stub.AddCall("AddInstance", name, remote, image, profiles, ephem)
 	if err := s.stub.NextErr(); err != nil {
@@ -11,4 +11,8 @@
 //     target      - the type of Juju node being upgraded
 //     context     - provides API access to Juju controllers
 //
+// Upgrades to the schema of documents in the database are not made
+// here: they are registered as schema upgrade steps in the state
+// package, and run by the controller's schemaupgrader worker.
+//
 package upgrades
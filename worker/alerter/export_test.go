@@ -0,0 +1,23 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerter
+
+import (
+	"net/http"
+	"net/smtp"
+)
+
+// NewNotifierForTest returns a Notifier which uses the given HTTP
+// client and function to send emails.
+func NewNotifierForTest(
+	backend ControllerConfigGetter,
+	client *http.Client,
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error,
+) Notifier {
+	return &notifier{
+		backend:  backend,
+		client:   client,
+		sendMail: sendMail,
+	}
+}
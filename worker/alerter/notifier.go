@@ -0,0 +1,155 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+// webhookTimeout is the longest time for which a notification is
+// posted to a webhook before the attempt is abandoned.
+const webhookTimeout = 30 * time.Second
+
+// ControllerConfigGetter provides the controller config, which holds
+// the hosts to which webhooks may post and the settings of the SMTP
+// server used to email notifications.
+type ControllerConfigGetter interface {
+	ControllerConfig() (controller.Config, error)
+}
+
+// sendMailFunc sends an email, as smtp.SendMail does.
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// NewNotifier returns a Notifier which posts each notification as
+// JSON to its rule's webhook, and emails it to its rule's email
+// address through the controller's alert-smtp-server. Webhooks are
+// only posted to if their host is one of the controller's
+// alert-webhook-hosts, and redirects are not followed.
+func NewNotifier(backend ControllerConfigGetter) Notifier {
+	return &notifier{
+		backend: backend,
+		client: &http.Client{
+			Timeout:       webhookTimeout,
+			CheckRedirect: refuseRedirect,
+		},
+		sendMail: smtp.SendMail,
+	}
+}
+
+// refuseRedirect stops a webhook from redirecting a notification to a
+// host which is not allowed.
+func refuseRedirect(req *http.Request, via []*http.Request) error {
+	return errors.Errorf("webhook redirected to %s", req.URL)
+}
+
+type notifier struct {
+	backend  ControllerConfigGetter
+	client   *http.Client
+	sendMail sendMailFunc
+}
+
+// Notify is part of the Notifier interface. Both destinations are
+// tried even if one of them fails.
+func (n *notifier) Notify(abort <-chan struct{}, rule state.AlertRule, notification Notification) error {
+	cfg, err := n.backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var failed []string
+	if rule.Webhook != "" {
+		if err := n.post(abort, cfg, rule.Webhook, notification); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if rule.Email != "" {
+		if err := n.email(cfg, rule.Email, notification); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func (n *notifier) post(abort <-chan struct{}, cfg controller.Config, url string, notification Notification) error {
+	// The rule was checked when it was added, but the allowed hosts
+	// may have changed since.
+	if !cfg.AlertWebhookAllowed(url) {
+		return errors.Errorf("cannot post to webhook %s: host not in %s", url, controller.AlertWebhookHosts)
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Cancel = abort
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "cannot post to webhook")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("webhook %s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+func (n *notifier) email(cfg controller.Config, to string, notification Notification) error {
+	server := cfg.AlertSMTPServer()
+	if server == "" {
+		return errors.Errorf("cannot email %s: %s not set", to, controller.AlertSMTPServer)
+	}
+	from := cfg.AlertEmailFrom()
+	// The SMTP envelope takes bare addresses; the headers keep any
+	// display names.
+	toAddr, err := mail.ParseAddress(to)
+	if err != nil {
+		return errors.Annotatef(err, "cannot email %s", to)
+	}
+	fromAddr, err := mail.ParseAddress(from)
+	if err != nil {
+		return errors.Annotatef(err, "cannot email %s from %s", to, from)
+	}
+	msg := emailMessage(from, to, notification)
+	if err := n.sendMail(server, nil, fromAddr.Address, []string{toAddr.Address}, msg); err != nil {
+		return errors.Annotatef(err, "cannot email %s", to)
+	}
+	return nil
+}
+
+// emailMessage returns the email which notifies the given address of
+// an alert.
+func emailMessage(from, to string, notification Notification) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: [juju] %s %s: %s\r\n", notification.Rule, notification.Status, notification.Entity)
+	fmt.Fprintf(&buf, "\r\n")
+	fmt.Fprintf(&buf, "Alert %q (%s) is %s for %s in model %s.\r\n",
+		notification.Rule, notification.Kind, notification.Status,
+		notification.Entity, notification.ModelUUID,
+	)
+	if notification.Message != "" {
+		fmt.Fprintf(&buf, "\r\n%s\r\n", notification.Message)
+	}
+	fmt.Fprintf(&buf, "\r\nSince: %s\r\n", notification.Since.UTC().Format(time.RFC3339))
+	return buf.Bytes()
+}
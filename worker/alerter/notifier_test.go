@@ -0,0 +1,198 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerter_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/alerter"
+)
+
+type NotifierSuite struct {
+	jujutesting.IsolationSuite
+
+	config       controller.Config
+	notification alerter.Notification
+	mails        []sentMail
+	mailErr      error
+}
+
+var _ = gc.Suite(&NotifierSuite{})
+
+type sentMail struct {
+	addr string
+	from string
+	to   []string
+	msg  string
+}
+
+func (s *NotifierSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = coretesting.FakeControllerConfig()
+	s.config[controller.AlertSMTPServer] = "smtp.example.com:25"
+	s.config[controller.AlertWebhookHosts] = []string{"127.0.0.1"}
+	s.mails = nil
+	s.mailErr = nil
+	since := time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+	s.notification = alerter.Notification{
+		ModelUUID: modelUUID,
+		Rule:      "unit-errors",
+		Kind:      "unit-error",
+		Entity:    "unit-mysql-0",
+		Message:   `hook failed: "install"`,
+		Status:    alerter.Firing,
+		Since:     since,
+		Time:      since.Add(5 * time.Minute),
+	}
+}
+
+func (s *NotifierSuite) newNotifier() alerter.Notifier {
+	return alerter.NewNotifierForTest(s, http.DefaultClient, s.sendMail)
+}
+
+func (s *NotifierSuite) ControllerConfig() (controller.Config, error) {
+	return s.config, nil
+}
+
+func (s *NotifierSuite) sendMail(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	s.mails = append(s.mails, sentMail{addr, from, to, string(msg)})
+	return s.mailErr
+}
+
+func (s *NotifierSuite) TestWebhook(c *gc.C) {
+	var received alerter.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		c.Check(json.NewDecoder(r.Body).Decode(&received), jc.ErrorIsNil)
+	}))
+	defer server.Close()
+
+	err := s.newNotifier().Notify(nil, state.AlertRule{Webhook: server.URL}, s.notification)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, s.notification)
+	c.Assert(s.mails, gc.HasLen, 0)
+}
+
+func (s *NotifierSuite) TestWebhookError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := s.newNotifier().Notify(nil, state.AlertRule{Webhook: server.URL}, s.notification)
+	c.Assert(err, gc.ErrorMatches, "webhook .* returned 500 Internal Server Error")
+}
+
+func (s *NotifierSuite) TestWebhookHostNotAllowed(c *gc.C) {
+	var posted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted = true
+	}))
+	defer server.Close()
+
+	s.config[controller.AlertWebhookHosts] = []string{"hooks.example.com"}
+	err := s.newNotifier().Notify(nil, state.AlertRule{Webhook: server.URL}, s.notification)
+	c.Assert(err, gc.ErrorMatches, "cannot post to webhook .*: host not in alert-webhook-hosts")
+	c.Assert(posted, jc.IsFalse)
+}
+
+func (s *NotifierSuite) TestWebhookRedirectRefused(c *gc.C) {
+	var redirected bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer server.Close()
+
+	err := alerter.NewNotifier(s).Notify(nil, state.AlertRule{Webhook: server.URL}, s.notification)
+	c.Assert(err, gc.ErrorMatches, "cannot post to webhook: .*webhook redirected to .*")
+	c.Assert(redirected, jc.IsFalse)
+}
+
+func (s *NotifierSuite) TestWebhookAborted(c *gc.C) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	abort := make(chan struct{})
+	close(abort)
+	err := s.newNotifier().Notify(abort, state.AlertRule{Webhook: server.URL}, s.notification)
+	c.Assert(err, gc.ErrorMatches, "cannot post to webhook: .*")
+}
+
+func (s *NotifierSuite) TestEmail(c *gc.C) {
+	err := s.newNotifier().Notify(nil, state.AlertRule{Email: "ops@example.com"}, s.notification)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mails, gc.HasLen, 1)
+	mail := s.mails[0]
+	c.Check(mail.addr, gc.Equals, "smtp.example.com:25")
+	c.Check(mail.from, gc.Equals, controller.DefaultAlertEmailFrom)
+	c.Check(mail.to, jc.DeepEquals, []string{"ops@example.com"})
+	c.Check(mail.msg, gc.Equals, ""+
+		"From: juju@localhost\r\n"+
+		"To: ops@example.com\r\n"+
+		"Subject: [juju] unit-errors firing: unit-mysql-0\r\n"+
+		"\r\n"+
+		"Alert \"unit-errors\" (unit-error) is firing for unit-mysql-0 in model "+modelUUID+".\r\n"+
+		"\r\n"+
+		"hook failed: \"install\"\r\n"+
+		"\r\n"+
+		"Since: 2016-10-03T12:00:00Z\r\n",
+	)
+}
+
+func (s *NotifierSuite) TestEmailNoServer(c *gc.C) {
+	delete(s.config, controller.AlertSMTPServer)
+	err := s.newNotifier().Notify(nil, state.AlertRule{Email: "ops@example.com"}, s.notification)
+	c.Assert(err, gc.ErrorMatches, "cannot email ops@example.com: alert-smtp-server not set")
+	c.Assert(s.mails, gc.HasLen, 0)
+}
+
+func (s *NotifierSuite) TestEmailDisplayNames(c *gc.C) {
+	s.config[controller.AlertEmailFrom] = "Juju <juju@example.com>"
+	err := s.newNotifier().Notify(nil, state.AlertRule{Email: "Ops <ops@example.com>"}, s.notification)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mails, gc.HasLen, 1)
+	mail := s.mails[0]
+	c.Check(mail.from, gc.Equals, "juju@example.com")
+	c.Check(mail.to, jc.DeepEquals, []string{"ops@example.com"})
+	c.Check(mail.msg, jc.HasPrefix, ""+
+		"From: Juju <juju@example.com>\r\n"+
+		"To: Ops <ops@example.com>\r\n",
+	)
+}
+
+func (s *NotifierSuite) TestTriesBothDestinations(c *gc.C) {
+	s.mailErr = errors.New("connection refused")
+	var posted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted = true
+	}))
+	defer server.Close()
+
+	err := s.newNotifier().Notify(nil, state.AlertRule{
+		Webhook: server.URL,
+		Email:   "ops@example.com",
+	}, s.notification)
+	c.Assert(err, gc.ErrorMatches, "cannot email ops@example.com: connection refused")
+	c.Assert(posted, jc.IsTrue)
+	c.Assert(s.mails, gc.HasLen, 1)
+}
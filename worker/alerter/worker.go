@@ -0,0 +1,328 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package alerter provides a controller worker which evaluates the
+// alert rules of every model, raising and resolving alerts and
+// dispatching notifications of them.
+package alerter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.alerter")
+
+const (
+	// Firing is the status of a notification sent when an alert is
+	// raised.
+	Firing = "firing"
+
+	// Resolved is the status of a notification sent when the
+	// condition which raised an alert no longer holds.
+	Resolved = "resolved"
+)
+
+// Notification describes an alert which has been raised or resolved.
+type Notification struct {
+	ModelUUID string    `json:"model-uuid"`
+	Rule      string    `json:"rule"`
+	Kind      string    `json:"kind"`
+	Entity    string    `json:"entity"`
+	Message   string    `json:"message"`
+	Status    string    `json:"status"`
+	Since     time.Time `json:"since"`
+	Time      time.Time `json:"time"`
+}
+
+// Backend exposes the controller's models to a Worker.
+type Backend interface {
+	// ModelUUIDs returns the UUIDs of the models whose alert rules
+	// are evaluated.
+	ModelUUIDs() ([]string, error)
+
+	// Model returns the ModelBackend for the model with the given
+	// UUID. It must be closed when no longer needed.
+	Model(uuid string) (ModelBackend, error)
+}
+
+// ModelBackend exposes a model's alert rules and alerts to a Worker.
+type ModelBackend interface {
+	AlertRules() ([]state.AlertRule, error)
+	Alerts() ([]state.Alert, error)
+	AlertConditions(state.AlertRuleKind) ([]state.AlertCondition, error)
+	RaiseAlert(state.Alert) error
+	ResolveAlert(rule, entity string) error
+	Close() error
+}
+
+// Notifier dispatches notifications of alerts to the destinations
+// given by their rules. A notification in progress is abandoned when
+// the abort channel is closed.
+type Notifier interface {
+	Notify(abort <-chan struct{}, rule state.AlertRule, notification Notification) error
+}
+
+// notificationQueueSize is the number of notifications which may wait
+// to be dispatched before further ones are dropped.
+const notificationQueueSize = 100
+
+// Config defines the parameters of the alerter worker.
+type Config struct {
+	Backend  Backend
+	Notifier Notifier
+	Clock    clock.Clock
+
+	// Interval defines how often the alert rules are evaluated.
+	Interval time.Duration
+}
+
+// Validate returns an error if Config cannot drive an alerter.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Notifier == nil {
+		return errors.NotValidf("nil Notifier")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &alerter{
+		config:    config,
+		firstSeen: make(map[conditionKey]time.Time),
+		queue:     make(chan pendingNotification, notificationQueueSize),
+	}
+	go func() {
+		defer w.tomb.Done()
+		dispatched := make(chan struct{})
+		go func() {
+			defer close(dispatched)
+			w.dispatch()
+		}()
+		w.tomb.Kill(w.run())
+		<-dispatched
+	}()
+	return w, nil
+}
+
+// conditionKey identifies an entity meeting the condition of an alert
+// rule in a model.
+type conditionKey struct {
+	modelUUID string
+	rule      string
+	entity    string
+}
+
+// alerter raises an alert when an entity has met the condition of an
+// alert rule for as long as the rule allows, and resolves it when the
+// condition no longer holds.
+type alerter struct {
+	tomb   tomb.Tomb
+	config Config
+
+	// firstSeen records when the worker first saw each entity meet
+	// a condition whose start is not recorded in state.
+	firstSeen map[conditionKey]time.Time
+
+	// queue holds the notifications waiting to be dispatched, so
+	// that slow destinations do not hold up evaluation.
+	queue chan pendingNotification
+}
+
+// pendingNotification is a notification waiting to be dispatched to
+// the destinations of its rule.
+type pendingNotification struct {
+	rule         state.AlertRule
+	notification Notification
+}
+
+// Kill implements worker.Worker.
+func (w *alerter) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *alerter) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *alerter) run() error {
+	for {
+		if err := w.evaluate(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// evaluate evaluates the alert rules of every model.
+func (w *alerter) evaluate() error {
+	uuids, err := w.config.Backend.ModelUUIDs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	firstSeen := make(map[conditionKey]time.Time)
+	for _, uuid := range uuids {
+		if err := w.evaluateModel(uuid, firstSeen); err != nil {
+			return errors.Annotatef(err, "cannot evaluate alert rules of model %s", uuid)
+		}
+	}
+	// Forget the conditions which no longer hold.
+	w.firstSeen = firstSeen
+	return nil
+}
+
+// evaluateModel raises and resolves the alerts of the model with the
+// given UUID, recording when conditions of unknown start were first
+// seen in firstSeen.
+func (w *alerter) evaluateModel(uuid string, firstSeen map[conditionKey]time.Time) error {
+	model, err := w.config.Backend.Model(uuid)
+	if errors.IsNotFound(err) {
+		// The model has been removed.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer model.Close()
+
+	rules, err := model.AlertRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	alerts, err := model.Alerts()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	raised := make(map[conditionKey]bool)
+	for _, alert := range alerts {
+		raised[conditionKey{uuid, alert.Rule, alert.Entity}] = true
+	}
+
+	now := w.config.Clock.Now()
+	rulesByName := make(map[string]state.AlertRule)
+	conditions := make(map[state.AlertRuleKind][]state.AlertCondition)
+	firing := make(map[conditionKey]bool)
+	for _, rule := range rules {
+		rulesByName[rule.Name] = rule
+		kindConditions, ok := conditions[rule.Kind]
+		if !ok {
+			kindConditions, err = model.AlertConditions(rule.Kind)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			conditions[rule.Kind] = kindConditions
+		}
+		for _, condition := range kindConditions {
+			key := conditionKey{uuid, rule.Name, condition.Entity}
+			since := condition.Since
+			if since.IsZero() {
+				since = now
+				if seen, ok := w.firstSeen[key]; ok {
+					since = seen
+				}
+				firstSeen[key] = since
+			}
+			if now.Sub(since) < rule.For {
+				continue
+			}
+			firing[key] = true
+			if raised[key] {
+				continue
+			}
+			alert := state.Alert{
+				Rule:    rule.Name,
+				Entity:  condition.Entity,
+				Message: condition.Message,
+				Since:   since,
+				Raised:  now,
+			}
+			err := model.RaiseAlert(alert)
+			if errors.IsNotFound(err) || errors.IsAlreadyExists(err) {
+				// The rule has been removed, or the alert raised,
+				// since they were read.
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			logger.Infof("alert %q raised for %s in model %s", rule.Name, alert.Entity, uuid)
+			w.notify(uuid, rule, alert, Firing, now)
+		}
+	}
+
+	for _, alert := range alerts {
+		if firing[conditionKey{uuid, alert.Rule, alert.Entity}] {
+			continue
+		}
+		if err := model.ResolveAlert(alert.Rule, alert.Entity); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("alert %q resolved for %s in model %s", alert.Rule, alert.Entity, uuid)
+		if rule, ok := rulesByName[alert.Rule]; ok {
+			w.notify(uuid, rule, alert, Resolved, now)
+		}
+	}
+	return nil
+}
+
+// notify queues a notification of the given alert for dispatch. If the
+// queue is full the notification is dropped, so that unreachable
+// destinations do not hold up the evaluation of other rules.
+func (w *alerter) notify(uuid string, rule state.AlertRule, alert state.Alert, status string, now time.Time) {
+	if rule.Webhook == "" && rule.Email == "" {
+		return
+	}
+	pending := pendingNotification{rule, Notification{
+		ModelUUID: uuid,
+		Rule:      rule.Name,
+		Kind:      string(rule.Kind),
+		Entity:    alert.Entity,
+		Message:   alert.Message,
+		Status:    status,
+		Since:     alert.Since,
+		Time:      now,
+	}}
+	select {
+	case w.queue <- pending:
+	default:
+		logger.Warningf("dropping notification of alert %q for %s: too many pending notifications", rule.Name, alert.Entity)
+	}
+}
+
+// dispatch sends queued notifications until the worker is dying.
+// Failures are logged rather than retried.
+func (w *alerter) dispatch() {
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return
+		case pending := <-w.queue:
+			rule, notification := pending.rule, pending.notification
+			if err := w.config.Notifier.Notify(w.tomb.Dying(), rule, notification); err != nil {
+				logger.Errorf("cannot send notification of alert %q for %s: %v", rule.Name, notification.Entity, err)
+			}
+		}
+	}
+}
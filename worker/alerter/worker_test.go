@@ -0,0 +1,368 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package alerter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/alerter"
+	"github.com/juju/juju/worker/workertest"
+)
+
+const modelUUID = "deadbeef-2f18-4fd2-967d-db9663db7bea"
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub     *jujutesting.Stub
+	clock    *coretesting.Clock
+	model    *stubModel
+	notifier *stubNotifier
+	config   alerter.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = new(jujutesting.Stub)
+	s.clock = coretesting.NewClock(time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC))
+	s.model = &stubModel{
+		stub:       s.stub,
+		conditions: make(map[state.AlertRuleKind][]state.AlertCondition),
+	}
+	s.notifier = &stubNotifier{notified: make(chan struct{}, 10)}
+	s.config = alerter.Config{
+		Backend:  &stubBackend{stub: s.stub, model: s.model},
+		Notifier: s.notifier,
+		Clock:    s.clock,
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*alerter.Config)
+		err    string
+	}{{
+		func(config *alerter.Config) { config.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(config *alerter.Config) { config.Notifier = nil },
+		"nil Notifier not valid",
+	}, {
+		func(config *alerter.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *alerter.Config) { config.Interval = 0 },
+		"non-positive Interval not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := alerter.New(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestNoRules(c *gc.C) {
+	s.runOnce(c)
+	s.stub.CheckCallNames(c, "ModelUUIDs", "Model", "AlertRules", "Alerts", "Close")
+}
+
+func (s *WorkerSuite) TestRaisesAlert(c *gc.C) {
+	rule := state.AlertRule{
+		Name:    "unit-errors",
+		Kind:    state.UnitErrorAlert,
+		For:     5 * time.Minute,
+		Webhook: "https://hooks.example.com/juju",
+	}
+	since := s.clock.Now().Add(-10 * time.Minute)
+	s.model.rules = []state.AlertRule{rule}
+	s.model.conditions[state.UnitErrorAlert] = []state.AlertCondition{{
+		Entity:  "unit-mysql-0",
+		Message: `hook failed: "install"`,
+		Since:   since,
+	}, {
+		// Not in error for long enough.
+		Entity:  "unit-mysql-1",
+		Message: `hook failed: "install"`,
+		Since:   s.clock.Now().Add(-time.Minute),
+	}}
+	s.runOnceNotifying(c, 1)
+
+	alert := state.Alert{
+		Rule:    "unit-errors",
+		Entity:  "unit-mysql-0",
+		Message: `hook failed: "install"`,
+		Since:   since,
+		Raised:  s.clock.Now(),
+	}
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelUUIDs", nil},
+		{"Model", []interface{}{modelUUID}},
+		{"AlertRules", nil},
+		{"Alerts", nil},
+		{"AlertConditions", []interface{}{state.UnitErrorAlert}},
+		{"RaiseAlert", []interface{}{alert}},
+		{"Close", nil},
+	})
+	c.Assert(s.notifier.sent, jc.DeepEquals, []alerter.Notification{{
+		ModelUUID: modelUUID,
+		Rule:      "unit-errors",
+		Kind:      "unit-error",
+		Entity:    "unit-mysql-0",
+		Message:   `hook failed: "install"`,
+		Status:    alerter.Firing,
+		Since:     since,
+		Time:      s.clock.Now(),
+	}})
+}
+
+func (s *WorkerSuite) TestAlreadyRaised(c *gc.C) {
+	s.model.rules = []state.AlertRule{{Name: "down", Kind: state.MachineDownAlert, Email: "ops@example.com"}}
+	s.model.alerts = []state.Alert{{Rule: "down", Entity: "machine-1"}}
+	s.model.conditions[state.MachineDownAlert] = []state.AlertCondition{{
+		Entity: "machine-1",
+		Since:  s.clock.Now().Add(-time.Hour),
+	}}
+	s.runOnce(c)
+
+	s.stub.CheckCallNames(c, "ModelUUIDs", "Model", "AlertRules", "Alerts", "AlertConditions", "Close")
+	c.Assert(s.notifier.sent, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestResolvesAlert(c *gc.C) {
+	since := s.clock.Now().Add(-time.Hour)
+	s.model.rules = []state.AlertRule{{Name: "down", Kind: state.MachineDownAlert, Email: "ops@example.com"}}
+	s.model.alerts = []state.Alert{{Rule: "down", Entity: "machine-1", Message: "gone", Since: since}}
+	s.runOnceNotifying(c, 1)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelUUIDs", nil},
+		{"Model", []interface{}{modelUUID}},
+		{"AlertRules", nil},
+		{"Alerts", nil},
+		{"AlertConditions", []interface{}{state.MachineDownAlert}},
+		{"ResolveAlert", []interface{}{"down", "machine-1"}},
+		{"Close", nil},
+	})
+	c.Assert(s.notifier.sent, jc.DeepEquals, []alerter.Notification{{
+		ModelUUID: modelUUID,
+		Rule:      "down",
+		Kind:      "machine-down",
+		Entity:    "machine-1",
+		Message:   "gone",
+		Status:    alerter.Resolved,
+		Since:     since,
+		Time:      s.clock.Now(),
+	}})
+}
+
+func (s *WorkerSuite) TestUnknownStartTimedFromFirstSeen(c *gc.C) {
+	s.model.rules = []state.AlertRule{{Name: "down", Kind: state.MachineDownAlert, For: 90 * time.Second}}
+	s.model.conditions[state.MachineDownAlert] = []state.AlertCondition{{
+		Entity:  "machine-1",
+		Message: "agent is not communicating with the server",
+	}}
+	started := s.clock.Now()
+
+	w, err := alerter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	// Not down for long enough at the first two evaluations.
+	s.waitAlarm(c)
+	s.clock.Advance(time.Minute)
+	s.waitAlarm(c)
+	c.Assert(s.model.raised, gc.HasLen, 0)
+
+	s.clock.Advance(time.Minute)
+	s.waitAlarm(c)
+	workertest.CleanKill(c, w)
+	c.Assert(s.model.raised, jc.DeepEquals, []state.Alert{{
+		Rule:    "down",
+		Entity:  "machine-1",
+		Message: "agent is not communicating with the server",
+		Since:   started,
+		Raised:  started.Add(2 * time.Minute),
+	}})
+}
+
+func (s *WorkerSuite) TestNotifyErrorIgnored(c *gc.C) {
+	s.model.rules = []state.AlertRule{{Name: "down", Kind: state.MachineDownAlert, Webhook: "http://example.com"}}
+	s.model.conditions[state.MachineDownAlert] = []state.AlertCondition{{
+		Entity: "machine-1",
+		Since:  s.clock.Now(),
+	}}
+	s.notifier.err = errors.New("connection refused")
+	s.runOnceNotifying(c, 1)
+	c.Assert(s.model.raised, gc.HasLen, 1)
+	c.Assert(s.notifier.sent, gc.HasLen, 1)
+}
+
+func (s *WorkerSuite) TestRuleRemovedConcurrently(c *gc.C) {
+	s.model.rules = []state.AlertRule{{Name: "down", Kind: state.MachineDownAlert, Webhook: "http://example.com"}}
+	s.model.conditions[state.MachineDownAlert] = []state.AlertCondition{{
+		Entity: "machine-1",
+		Since:  s.clock.Now(),
+	}}
+	s.stub.SetErrors(nil, nil, nil, nil, nil, errors.NotFoundf("alert rule %q", "down"))
+	s.runOnce(c)
+	c.Assert(s.notifier.sent, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestNotifyDoesNotBlockEvaluation(c *gc.C) {
+	s.model.rules = []state.AlertRule{{Name: "down", Kind: state.MachineDownAlert, Webhook: "http://example.com"}}
+	s.model.conditions[state.MachineDownAlert] = []state.AlertCondition{{
+		Entity: "machine-1",
+		Since:  s.clock.Now(),
+	}}
+	// A notifier which never completes until aborted.
+	s.config.Notifier = blockingNotifier{}
+	w, err := alerter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	// Evaluation goes on, and the worker stops, regardless.
+	s.waitAlarm(c)
+	c.Assert(s.model.raised, gc.HasLen, 1)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestModelRemoved(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotFoundf("model"))
+	s.runOnce(c)
+	s.stub.CheckCallNames(c, "ModelUUIDs", "Model")
+}
+
+func (s *WorkerSuite) TestBackendError(c *gc.C) {
+	s.stub.SetErrors(nil, nil, errors.New("blam"))
+	w, err := alerter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot evaluate alert rules of model "+modelUUID+": blam")
+	s.stub.CheckCallNames(c, "ModelUUIDs", "Model", "AlertRules", "Close")
+}
+
+// runOnce runs the worker until it has evaluated the alert rules once.
+func (s *WorkerSuite) runOnce(c *gc.C) {
+	s.runOnceNotifying(c, 0)
+}
+
+// runOnceNotifying runs the worker until it has evaluated the alert
+// rules once and dispatched the given number of notifications.
+func (s *WorkerSuite) runOnceNotifying(c *gc.C, notifications int) {
+	w, err := alerter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	s.waitAlarm(c)
+	for i := 0; i < notifications; i++ {
+		select {
+		case <-s.notifier.notified:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for notification")
+		}
+	}
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for alert rule evaluation")
+	}
+}
+
+type stubBackend struct {
+	stub  *jujutesting.Stub
+	model *stubModel
+}
+
+func (b *stubBackend) ModelUUIDs() ([]string, error) {
+	b.stub.AddCall("ModelUUIDs")
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return []string{modelUUID}, nil
+}
+
+func (b *stubBackend) Model(uuid string) (alerter.ModelBackend, error) {
+	b.stub.AddCall("Model", uuid)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.model, nil
+}
+
+type stubModel struct {
+	stub       *jujutesting.Stub
+	rules      []state.AlertRule
+	alerts     []state.Alert
+	conditions map[state.AlertRuleKind][]state.AlertCondition
+	raised     []state.Alert
+}
+
+func (m *stubModel) AlertRules() ([]state.AlertRule, error) {
+	m.stub.AddCall("AlertRules")
+	return m.rules, m.stub.NextErr()
+}
+
+func (m *stubModel) Alerts() ([]state.Alert, error) {
+	m.stub.AddCall("Alerts")
+	return m.alerts, m.stub.NextErr()
+}
+
+func (m *stubModel) AlertConditions(kind state.AlertRuleKind) ([]state.AlertCondition, error) {
+	m.stub.AddCall("AlertConditions", kind)
+	return m.conditions[kind], m.stub.NextErr()
+}
+
+func (m *stubModel) RaiseAlert(alert state.Alert) error {
+	m.stub.AddCall("RaiseAlert", alert)
+	if err := m.stub.NextErr(); err != nil {
+		return err
+	}
+	m.raised = append(m.raised, alert)
+	return nil
+}
+
+func (m *stubModel) ResolveAlert(rule, entity string) error {
+	m.stub.AddCall("ResolveAlert", rule, entity)
+	return m.stub.NextErr()
+}
+
+func (m *stubModel) Close() error {
+	m.stub.AddCall("Close")
+	return m.stub.NextErr()
+}
+
+type stubNotifier struct {
+	err      error
+	sent     []alerter.Notification
+	notified chan struct{}
+}
+
+func (n *stubNotifier) Notify(abort <-chan struct{}, rule state.AlertRule, notification alerter.Notification) error {
+	n.sent = append(n.sent, notification)
+	n.notified <- struct{}{}
+	return n.err
+}
+
+type blockingNotifier struct{}
+
+func (blockingNotifier) Notify(abort <-chan struct{}, rule state.AlertRule, notification alerter.Notification) error {
+	<-abort
+	return errors.New("aborted")
+}
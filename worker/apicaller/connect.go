@@ -167,6 +167,7 @@ func connectFallback(
 //     model we just connected to;
 //   * replaces insecure credentials with freshly (locally) generated ones
 //     (and returns ErrPasswordChanged, expecting to be reinvoked);
+//   * likewise replaces credentials the controller asks to be rotated;
 //   * unconditionally resets the remote-state password to its current value
 //     (for what seems like a bad reason).
 //
@@ -253,6 +254,28 @@ func ScaryConnect(a agent.Agent, apiOpen api.OpenFunc) (_ api.Connection, err er
 		return nil, ErrChangedPassword
 	}
 
+	// The controller may also ask us to rotate a password that is
+	// still perfectly valid, whether on demand or because it has
+	// grown too old. The current password remains valid for a while
+	// after we replace it, and is kept as our old password, so a
+	// failure part way through cannot lock us out.
+	rotate, err := facade.PasswordRotationRequired(entity)
+	if params.IsCodeNotImplemented(err) {
+		// Older controllers cannot ask for rotations.
+		rotate = false
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if rotate {
+		logger.Debugf("rotating password...")
+		err := changePassword(info.Password, a, facade)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		logger.Debugf("password rotated")
+		return nil, ErrChangedPassword
+	}
+
 	// If we *didn't* need to change the password, we apparently need
 	// to reset our password to its current value anyway. Reportedly,
 	// a machine agent promoted to controller status might have bad
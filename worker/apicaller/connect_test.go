@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
 	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	coretesting "github.com/juju/juju/testing"
@@ -65,6 +66,9 @@ func testEntityFine(c *gc.C, life apiagent.Life) {
 	stub.CheckCalls(c, []testing.StubCall{{
 		FuncName: "Life",
 		Args:     []interface{}{entity},
+	}, {
+		FuncName: "PasswordRotationRequired",
+		Args:     []interface{}{entity},
 	}, {
 		FuncName: "SetPassword",
 		Args:     []interface{}{entity, "new"},
@@ -75,7 +79,7 @@ func (*ScaryConnectSuite) TestModelTagCannotChangeConfig(c *gc.C) {
 	stub := checkModelTagUpdate(c, false, errors.New("oh noes"))
 	stub.CheckCallNames(c,
 		"ChangeConfig",
-		"Life", "SetPassword",
+		"Life", "PasswordRotationRequired", "SetPassword",
 	)
 }
 
@@ -83,7 +87,7 @@ func (*ScaryConnectSuite) TestModelTagCannotGetTag(c *gc.C) {
 	stub := checkModelTagUpdate(c, true)
 	stub.CheckCallNames(c,
 		"ChangeConfig", "ModelTag",
-		"Life", "SetPassword",
+		"Life", "PasswordRotationRequired", "SetPassword",
 	)
 }
 
@@ -91,7 +95,7 @@ func (*ScaryConnectSuite) TestModelTagCannotMigrate(c *gc.C) {
 	stub := checkModelTagUpdate(c, false, nil, errors.New("oh noes"))
 	stub.CheckCallNames(c,
 		"ChangeConfig", "ModelTag", "Migrate",
-		"Life", "SetPassword",
+		"Life", "PasswordRotationRequired", "SetPassword",
 	)
 	c.Check(stub.Calls()[2].Args, jc.DeepEquals, []interface{}{
 		agent.MigrateParams{Model: coretesting.ModelTag},
@@ -102,7 +106,7 @@ func (*ScaryConnectSuite) TestModelTagSuccess(c *gc.C) {
 	stub := checkModelTagUpdate(c, false)
 	stub.CheckCallNames(c,
 		"ChangeConfig", "ModelTag", "Migrate",
-		"Life", "SetPassword",
+		"Life", "PasswordRotationRequired", "SetPassword",
 	)
 	c.Check(stub.Calls()[2].Args, jc.DeepEquals, []interface{}{
 		agent.MigrateParams{Model: coretesting.ModelTag},
@@ -284,6 +288,72 @@ func (*ScaryConnectSuite) assertChangePasswordSuccess(c *gc.C, stub *testing.Stu
 	checkSaneChange(c, stub.Calls()[2:5])
 }
 
+func (*ScaryConnectSuite) TestRotatePassword(c *gc.C) {
+	stub, err := checkRotatePassword(c)
+	c.Check(err, gc.Equals, apicaller.ErrChangedPassword)
+	stub.CheckCallNames(c,
+		"Life", "PasswordRotationRequired", "ChangeConfig",
+		// Be careful, these are two different SetPassword receivers.
+		"SetPassword", "SetOldPassword", "SetPassword",
+		"Close",
+	)
+
+	// The current password becomes the old one.
+	calls := stub.Calls()[3:6]
+	chosePassword := calls[0].Args[0].(string)
+	switch chosePassword {
+	case "", "new", "old":
+		c.Fatalf("very bad new password: %q", chosePassword)
+	}
+	c.Check(calls[1].Args, jc.DeepEquals, []interface{}{"new"})
+	c.Check(calls[2].Args, jc.DeepEquals, []interface{}{
+		names.NewApplicationTag("omg"), chosePassword,
+	})
+}
+
+func (*ScaryConnectSuite) TestRotatePasswordNotImplemented(c *gc.C) {
+	stub, err := checkRotatePassword(c, nil, &params.Error{Code: params.CodeNotImplemented})
+	c.Check(err, jc.ErrorIsNil)
+	stub.CheckCallNames(c,
+		"Life", "PasswordRotationRequired", "SetPassword",
+	)
+}
+
+func (*ScaryConnectSuite) TestRotatePasswordDenied(c *gc.C) {
+	stub, err := checkRotatePassword(c, nil, apiagent.ErrDenied)
+	c.Check(err, gc.Equals, apicaller.ErrConnectImpossible)
+	stub.CheckCallNames(c,
+		"Life", "PasswordRotationRequired", "Close",
+	)
+}
+
+// checkRotatePassword connects as an agent whose controller asks it to
+// rotate its password.
+func checkRotatePassword(c *gc.C, errs ...error) (*testing.Stub, error) {
+	stub := &testing.Stub{}
+	stub.SetErrors(errs...)
+	expectConn := &mockConn{stub: stub}
+	apiOpen := func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		return expectConn, nil
+	}
+	newFacade := func(base.APICaller) (apiagent.ConnFacade, error) {
+		return &mockConnFacade{
+			stub:   stub,
+			life:   apiagent.Alive,
+			rotate: true,
+		}, nil
+	}
+	unpatch := testing.PatchValue(apicaller.NewConnFacade, newFacade)
+	defer unpatch()
+
+	_, err := apicaller.ScaryConnect(&mockAgent{
+		stub:   stub,
+		model:  coretesting.ModelTag,
+		entity: names.NewApplicationTag("omg"),
+	}, apiOpen)
+	return stub, err
+}
+
 func createUnauthorisedStub(errs ...error) *testing.Stub {
 	return createPasswordCheckStub(&params.Error{Code: params.CodeUnauthorized}, errs...)
 }
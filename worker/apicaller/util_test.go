@@ -123,8 +123,9 @@ func newMockConnFacade(stub *testing.Stub, life apiagent.Life) apiagent.ConnFaca
 }
 
 type mockConnFacade struct {
-	stub *testing.Stub
-	life apiagent.Life
+	stub   *testing.Stub
+	life   apiagent.Life
+	rotate bool
 }
 
 func (mock *mockConnFacade) Life(entity names.Tag) (apiagent.Life, error) {
@@ -140,6 +141,14 @@ func (mock *mockConnFacade) SetPassword(entity names.Tag, password string) error
 	return mock.stub.NextErr()
 }
 
+func (mock *mockConnFacade) PasswordRotationRequired(entity names.Tag) (bool, error) {
+	mock.stub.AddCall("PasswordRotationRequired", entity)
+	if err := mock.stub.NextErr(); err != nil {
+		return false, err
+	}
+	return mock.rotate, nil
+}
+
 type dummyWorker struct {
 	worker.Worker
 }
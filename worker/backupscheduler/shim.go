@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/environs/storage"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// NewStateBackups returns a Backups which creates backups of the
+// controller's state in the same way as the Backups facade, storing
+// them according to the controller's backup-target.
+func NewStateBackups(st *state.State, paths backups.Paths, machineId string) Backups {
+	return &stateBackups{
+		st:        st,
+		paths:     paths,
+		machineId: machineId,
+	}
+}
+
+type stateBackups struct {
+	st        *state.State
+	paths     backups.Paths
+	machineId string
+}
+
+func (b *stateBackups) open() (backups.Backups, func() error) {
+	stor := backups.NewStorageWithTarget(b.st, func() (storage.Storage, error) {
+		return backups.OpenTarget(b.st)
+	})
+	return backups.NewBackups(stor), stor.Close
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create(notes string) (*backups.Metadata, error) {
+	backupsMethods, closer := b.open()
+	defer closer()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+	dbInfo, err := backups.NewDBInfo(b.st.MongoConnectionInfo(), session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := b.st.Machine(b.machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.st, b.machineId, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = notes
+	if err := backupsMethods.Create(meta, &b.paths, dbInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	backupsMethods, closer := b.open()
+	defer closer()
+	return backupsMethods.List()
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	backupsMethods, closer := b.open()
+	defer closer()
+	return backupsMethods.Remove(id)
+}
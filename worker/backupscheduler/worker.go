@@ -0,0 +1,201 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// ScheduledNotes is recorded in the notes of every backup created by
+// the scheduler; only backups with these notes are subject to the
+// controller's backup-retention-count.
+const ScheduledNotes = "scheduled backup"
+
+// Backend exposes controller functionality to a Worker.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+}
+
+// Backups creates, lists and removes backups of the controller.
+type Backups interface {
+	Create(notes string) (*backups.Metadata, error)
+	List() ([]*backups.Metadata, error)
+	Remove(id string) error
+}
+
+// Config defines the parameters of the backupscheduler worker.
+type Config struct {
+	Backend Backend
+	Backups Backups
+	Clock   clock.Clock
+
+	// PollInterval defines how often the controller config is checked
+	// for changes to the backup schedule. Backups are never created
+	// more often than the configured backup-interval.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if Config cannot drive a backupscheduler.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &backupscheduler{config: config}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.run())
+	}()
+	return w, nil
+}
+
+// backupscheduler creates backups of the controller at the interval
+// given by its backup-interval setting, and removes the oldest
+// scheduled backups beyond its backup-retention-count.
+type backupscheduler struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+// Kill implements worker.Worker.
+func (w *backupscheduler) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *backupscheduler) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *backupscheduler) run() error {
+	for {
+		delay, err := w.maybeBackup()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+		}
+	}
+}
+
+// maybeBackup creates a backup if one is due, and returns the time
+// to wait before checking again.
+func (w *backupscheduler) maybeBackup() (time.Duration, error) {
+	poll := w.config.PollInterval
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	interval := cfg.BackupInterval()
+	if interval == 0 {
+		return poll, nil
+	}
+	scheduled, err := w.scheduledBackups()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(scheduled) > 0 {
+		due := scheduled[0].Started.Add(interval)
+		if delay := due.Sub(w.config.Clock.Now()); delay > 0 {
+			return minDuration(delay, poll), nil
+		}
+	}
+
+	meta, err := w.config.Backups.Create(ScheduledNotes)
+	if err != nil {
+		// A failed backup, for example because the replica set is
+		// not ready, is not worth restarting the worker for; try
+		// again at the next poll.
+		logger.Errorf("cannot create scheduled backup: %v", err)
+		return minDuration(interval, poll), nil
+	}
+	logger.Infof("created scheduled backup %q", meta.ID())
+	if err := w.prune(cfg.BackupRetentionCount()); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return minDuration(interval, poll), nil
+}
+
+// scheduledBackups returns the metadata for the backups created by
+// the scheduler, most recent first.
+func (w *backupscheduler) scheduledBackups() ([]*backups.Metadata, error) {
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if meta.Notes == ScheduledNotes {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	sort.Sort(byStartedDesc(scheduled))
+	return scheduled, nil
+}
+
+// prune removes the oldest scheduled backups, keeping the given number
+// of the most recent ones. A count of 0 keeps all of them.
+func (w *backupscheduler) prune(count int) error {
+	if count == 0 {
+		return nil
+	}
+	scheduled, err := w.scheduledBackups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(scheduled) <= count {
+		return nil
+	}
+	for _, meta := range scheduled[count:] {
+		if err := w.config.Backups.Remove(meta.ID()); err != nil {
+			return errors.Annotatef(err, "cannot remove backup %q", meta.ID())
+		}
+		logger.Infof("removed scheduled backup %q", meta.ID())
+	}
+	return nil
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+type byStartedDesc []*backups.Metadata
+
+func (b byStartedDesc) Len() int           { return len(b) }
+func (b byStartedDesc) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byStartedDesc) Less(i, j int) bool { return b[i].Started.After(b[j].Started) }
@@ -0,0 +1,233 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub    *jujutesting.Stub
+	clock   *coretesting.Clock
+	backend *stubBackend
+	backups *stubBackups
+	config  backupscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = new(jujutesting.Stub)
+	s.clock = coretesting.NewClock(time.Now())
+	s.backend = &stubBackend{
+		stub:   s.stub,
+		config: coretesting.FakeControllerConfig(),
+	}
+	s.backups = &stubBackups{stub: s.stub, clock: s.clock}
+	s.config = backupscheduler.Config{
+		Backend:      s.backend,
+		Backups:      s.backups,
+		Clock:        s.clock,
+		PollInterval: 10 * time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*backupscheduler.Config)
+		err    string
+	}{{
+		func(config *backupscheduler.Config) { config.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(config *backupscheduler.Config) { config.Backups = nil },
+		"nil Backups not valid",
+	}, {
+		func(config *backupscheduler.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *backupscheduler.Config) { config.PollInterval = 0 },
+		"non-positive PollInterval not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := backupscheduler.New(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestNoSchedule(c *gc.C) {
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(10 * time.Minute)
+	s.waitAlarm(c)
+
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c, "ControllerConfig", "ControllerConfig")
+}
+
+func (s *WorkerSuite) TestCreatesBackupWhenDue(c *gc.C) {
+	s.backend.config[controller.BackupInterval] = "1h"
+	s.backups.add("manual", "", s.clock.Now().Add(-time.Minute))
+	s.backups.add("old", backupscheduler.ScheduledNotes, s.clock.Now().Add(-2*time.Hour))
+
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ControllerConfig", nil},
+		{"List", nil},
+		{"Create", []interface{}{backupscheduler.ScheduledNotes}},
+	})
+}
+
+func (s *WorkerSuite) TestWaitsForInterval(c *gc.C) {
+	s.backend.config[controller.BackupInterval] = "15m"
+	s.backups.add("recent", backupscheduler.ScheduledNotes, s.clock.Now().Add(-10*time.Minute))
+
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	s.stub.CheckCallNames(c, "ControllerConfig", "List")
+
+	// The next backup is due in five minutes, before the next poll.
+	s.clock.Advance(5 * time.Minute)
+	s.waitAlarm(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c, "ControllerConfig", "List", "ControllerConfig", "List", "Create")
+}
+
+func (s *WorkerSuite) TestPrunesScheduledBackups(c *gc.C) {
+	s.backend.config[controller.BackupInterval] = "1h"
+	s.backend.config[controller.BackupRetentionCount] = 2
+	now := s.clock.Now()
+	s.backups.add("manual", "", now.Add(-5*time.Hour))
+	s.backups.add("oldest", backupscheduler.ScheduledNotes, now.Add(-4*time.Hour))
+	s.backups.add("older", backupscheduler.ScheduledNotes, now.Add(-3*time.Hour))
+	s.backups.add("old", backupscheduler.ScheduledNotes, now.Add(-2*time.Hour))
+
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ControllerConfig", nil},
+		{"List", nil},
+		{"Create", []interface{}{backupscheduler.ScheduledNotes}},
+		{"List", nil},
+		{"Remove", []interface{}{"older"}},
+		{"Remove", []interface{}{"oldest"}},
+	})
+}
+
+func (s *WorkerSuite) TestCreateErrorRetries(c *gc.C) {
+	s.backend.config[controller.BackupInterval] = "1h"
+	s.stub.SetErrors(nil, nil, errors.New("HA not ready"))
+
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(10 * time.Minute)
+	s.waitAlarm(c)
+
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c,
+		"ControllerConfig", "List", "Create",
+		"ControllerConfig", "List", "Create",
+	)
+}
+
+func (s *WorkerSuite) TestControllerConfigError(c *gc.C) {
+	s.stub.SetErrors(errors.New("blam"))
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "blam")
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup schedule")
+	}
+}
+
+type stubBackend struct {
+	stub   *jujutesting.Stub
+	config controller.Config
+}
+
+func (b *stubBackend) ControllerConfig() (controller.Config, error) {
+	b.stub.AddCall("ControllerConfig")
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.config, nil
+}
+
+type stubBackups struct {
+	stub  *jujutesting.Stub
+	clock *coretesting.Clock
+	list  []*backups.Metadata
+}
+
+func (b *stubBackups) add(id, notes string, started time.Time) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Notes = notes
+	meta.Started = started
+	b.list = append(b.list, meta)
+	return meta
+}
+
+func (b *stubBackups) Create(notes string) (*backups.Metadata, error) {
+	b.stub.AddCall("Create", notes)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.add("new", notes, b.clock.Now()), nil
+}
+
+func (b *stubBackups) List() ([]*backups.Metadata, error) {
+	b.stub.AddCall("List")
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.list, nil
+}
+
+func (b *stubBackups) Remove(id string) error {
+	b.stub.AddCall("Remove", id)
+	return b.stub.NextErr()
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package canaryupgrader
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// NewStateBackend returns a Backend which checks the canary upgrades
+// of the models in the controller of the given State.
+func NewStateBackend(st *state.State) Backend {
+	return stateBackend{st}
+}
+
+type stateBackend struct {
+	st *state.State
+}
+
+// ModelUUIDs is part of the Backend interface.
+func (b stateBackend) ModelUUIDs() ([]string, error) {
+	models, err := b.st.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var uuids []string
+	for _, model := range models {
+		if model.Life() != state.Alive {
+			continue
+		}
+		uuids = append(uuids, model.UUID())
+	}
+	return uuids, nil
+}
+
+// Model is part of the Backend interface.
+func (b stateBackend) Model(uuid string) (ModelBackend, error) {
+	st, err := b.st.ForModel(names.NewModelTag(uuid))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package canaryupgrader provides a controller worker which checks the
+// health of the canaries of every model's canary upgrade, proceeding
+// with the upgrades which are to proceed automatically once their
+// canaries have soaked.
+package canaryupgrader
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.canaryupgrader")
+
+// Backend exposes the controller's models to a Worker.
+type Backend interface {
+	// ModelUUIDs returns the UUIDs of the models whose canary
+	// upgrades are checked.
+	ModelUUIDs() ([]string, error)
+
+	// Model returns the ModelBackend for the model with the given
+	// UUID. It must be closed when no longer needed.
+	Model(uuid string) (ModelBackend, error)
+}
+
+// ModelBackend exposes a model's canary upgrade to a Worker.
+type ModelBackend interface {
+	CheckCanaryUpgrade(now time.Time) error
+	Close() error
+}
+
+// Config defines the parameters of the canary upgrader worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+
+	// Interval defines how often the canary upgrades are checked.
+	Interval time.Duration
+}
+
+// Validate returns an error if Config cannot drive a canary upgrader.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &canaryUpgrader{config: config}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.run())
+	}()
+	return w, nil
+}
+
+// canaryUpgrader periodically checks the canary upgrades of every
+// model.
+type canaryUpgrader struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+// Kill implements worker.Worker.
+func (w *canaryUpgrader) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *canaryUpgrader) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *canaryUpgrader) run() error {
+	for {
+		if err := w.check(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// check checks the canary upgrade of every model. A model's upgrade
+// failing to proceed is logged rather than returned, so that it does
+// not hold up the upgrades of other models.
+func (w *canaryUpgrader) check() error {
+	uuids, err := w.config.Backend.ModelUUIDs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, uuid := range uuids {
+		if err := w.checkModel(uuid); err != nil {
+			logger.Errorf("cannot check canary upgrade of model %s: %v", uuid, err)
+		}
+	}
+	return nil
+}
+
+func (w *canaryUpgrader) checkModel(uuid string) error {
+	model, err := w.config.Backend.Model(uuid)
+	if errors.IsNotFound(err) {
+		// The model has been removed.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer model.Close()
+	return errors.Trace(model.CheckCanaryUpgrade(w.config.Clock.Now()))
+}
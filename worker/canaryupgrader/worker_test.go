@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package canaryupgrader_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/canaryupgrader"
+	"github.com/juju/juju/worker/workertest"
+)
+
+const modelUUID = "deadbeef-2f18-4fd2-967d-db9663db7bea"
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub   *jujutesting.Stub
+	clock  *coretesting.Clock
+	config canaryupgrader.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = new(jujutesting.Stub)
+	s.clock = coretesting.NewClock(time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC))
+	s.config = canaryupgrader.Config{
+		Backend:  &stubBackend{stub: s.stub},
+		Clock:    s.clock,
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*canaryupgrader.Config)
+		err    string
+	}{{
+		func(config *canaryupgrader.Config) { config.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(config *canaryupgrader.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *canaryupgrader.Config) { config.Interval = 0 },
+		"non-positive Interval not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := canaryupgrader.New(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestChecksEachInterval(c *gc.C) {
+	started := s.clock.Now()
+	w, err := canaryupgrader.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(time.Minute)
+	s.waitAlarm(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelUUIDs", nil},
+		{"Model", []interface{}{modelUUID}},
+		{"CheckCanaryUpgrade", []interface{}{started}},
+		{"Close", nil},
+		{"ModelUUIDs", nil},
+		{"Model", []interface{}{modelUUID}},
+		{"CheckCanaryUpgrade", []interface{}{started.Add(time.Minute)}},
+		{"Close", nil},
+	})
+}
+
+func (s *WorkerSuite) TestModelRemoved(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotFoundf("model"))
+	s.runOnce(c)
+	s.stub.CheckCallNames(c, "ModelUUIDs", "Model")
+}
+
+func (s *WorkerSuite) TestCheckErrorIgnored(c *gc.C) {
+	s.stub.SetErrors(nil, nil, errors.New("canaries unhappy"))
+	s.runOnce(c)
+	s.stub.CheckCallNames(c, "ModelUUIDs", "Model", "CheckCanaryUpgrade", "Close")
+}
+
+func (s *WorkerSuite) TestBackendError(c *gc.C) {
+	s.stub.SetErrors(errors.New("blam"))
+	w, err := canaryupgrader.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "blam")
+	s.stub.CheckCallNames(c, "ModelUUIDs")
+}
+
+// runOnce runs the worker until it has checked the canary upgrades
+// once.
+func (s *WorkerSuite) runOnce(c *gc.C) {
+	w, err := canaryupgrader.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	s.waitAlarm(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for canary upgrade check")
+	}
+}
+
+type stubBackend struct {
+	stub *jujutesting.Stub
+}
+
+func (b *stubBackend) ModelUUIDs() ([]string, error) {
+	b.stub.AddCall("ModelUUIDs")
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return []string{modelUUID}, nil
+}
+
+func (b *stubBackend) Model(uuid string) (canaryupgrader.ModelBackend, error) {
+	b.stub.AddCall("Model", uuid)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &stubModel{b.stub}, nil
+}
+
+type stubModel struct {
+	stub *jujutesting.Stub
+}
+
+func (m *stubModel) CheckCanaryUpgrade(now time.Time) error {
+	m.stub.AddCall("CheckCanaryUpgrade", now)
+	return m.stub.NextErr()
+}
+
+func (m *stubModel) Close() error {
+	m.stub.AddCall("Close")
+	return m.stub.NextErr()
+}
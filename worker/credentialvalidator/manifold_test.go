@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/credentialvalidator"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (*ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := credentialvalidator.Manifold(validManifoldConfig())
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller"})
+}
+
+func (*ManifoldSuite) TestOutput(c *gc.C) {
+	manifold := credentialvalidator.Manifold(credentialvalidator.ManifoldConfig{})
+	in := &credentialvalidator.Worker{}
+	var out engine.Flag
+	err := manifold.Output(in, &out)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, in)
+}
+
+func (*ManifoldSuite) TestFilterErrChanged(c *gc.C) {
+	manifold := credentialvalidator.Manifold(credentialvalidator.ManifoldConfig{})
+	err := manifold.Filter(credentialvalidator.ErrChanged)
+	c.Check(err, gc.Equals, dependency.ErrBounce)
+}
+
+func (*ManifoldSuite) TestFilterOther(c *gc.C) {
+	manifold := credentialvalidator.Manifold(credentialvalidator.ManifoldConfig{})
+	expect := errors.New("whatever")
+	actual := manifold.Filter(expect)
+	c.Check(actual, gc.Equals, expect)
+}
+
+func (*ManifoldSuite) TestStartMissingAPICallerName(c *gc.C) {
+	config := validManifoldConfig()
+	config.APICallerName = ""
+	checkManifoldNotValid(c, config, "empty APICallerName not valid")
+}
+
+func (*ManifoldSuite) TestStartMissingNewFacade(c *gc.C) {
+	config := validManifoldConfig()
+	config.NewFacade = nil
+	checkManifoldNotValid(c, config, "nil NewFacade not valid")
+}
+
+func (*ManifoldSuite) TestStartMissingNewWorker(c *gc.C) {
+	config := validManifoldConfig()
+	config.NewWorker = nil
+	checkManifoldNotValid(c, config, "nil NewWorker not valid")
+}
+
+func (*ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+	})
+	manifold := credentialvalidator.Manifold(validManifoldConfig())
+
+	worker, err := manifold.Start(context)
+	c.Check(worker, gc.IsNil)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (*ManifoldSuite) TestStartSuccess(c *gc.C) {
+	expectCaller := &struct{ base.APICaller }{}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": expectCaller,
+	})
+	expectFacade := &struct{ credentialvalidator.Facade }{}
+	expectWorker := &struct{ worker.Worker }{}
+	config := validManifoldConfig()
+	config.NewFacade = func(caller base.APICaller) (credentialvalidator.Facade, error) {
+		c.Check(caller, gc.Equals, expectCaller)
+		return expectFacade, nil
+	}
+	config.NewWorker = func(workerConfig credentialvalidator.Config) (worker.Worker, error) {
+		c.Check(workerConfig.Facade, gc.Equals, expectFacade)
+		return expectWorker, nil
+	}
+	manifold := credentialvalidator.Manifold(config)
+
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
@@ -0,0 +1,132 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apicredentialvalidator "github.com/juju/juju/api/credentialvalidator"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/credentialvalidator"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+// newMockFacade returns a mock Facade that will add calls to the
+// supplied testing.Stub, and return errors in the sequences it
+// specifies; if any ModelCredential call does not return an error,
+// it will return a credential whose validity is consumed from the
+// head of the supplied list (or panic if it's empty).
+func newMockFacade(stub *testing.Stub, valid ...bool) *mockFacade {
+	return &mockFacade{
+		stub:  stub,
+		valid: valid,
+	}
+}
+
+// mockFacade implements credentialvalidator.Facade for use in the tests.
+type mockFacade struct {
+	stub  *testing.Stub
+	valid []bool
+}
+
+// ModelCredential is part of the credentialvalidator.Facade interface.
+func (mock *mockFacade) ModelCredential() (apicredentialvalidator.ModelCredential, error) {
+	mock.stub.AddCall("ModelCredential")
+	if err := mock.stub.NextErr(); err != nil {
+		return apicredentialvalidator.ModelCredential{}, err
+	}
+	valid := mock.valid[0]
+	mock.valid = mock.valid[1:]
+	return apicredentialvalidator.ModelCredential{
+		CloudCredential: credentialTag,
+		Exists:          true,
+		Valid:           valid,
+	}, nil
+}
+
+// WatchModelCredential is part of the credentialvalidator.Facade
+// interface.
+func (mock *mockFacade) WatchModelCredential() (watcher.NotifyWatcher, error) {
+	mock.stub.AddCall("WatchModelCredential")
+	if err := mock.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return newMockWatcher(), nil
+}
+
+// noCredentialFacade implements credentialvalidator.Facade for a
+// model that does not use a cloud credential.
+type noCredentialFacade struct {
+	credentialvalidator.Facade
+}
+
+// ModelCredential is part of the credentialvalidator.Facade interface.
+func (noCredentialFacade) ModelCredential() (apicredentialvalidator.ModelCredential, error) {
+	return apicredentialvalidator.ModelCredential{Valid: true}, nil
+}
+
+// newMockWatcher returns a watcher.NotifyWatcher that always
+// sends 3 changes and then sits quietly until killed.
+func newMockWatcher() *mockWatcher {
+	const count = 3
+	changes := make(chan struct{}, count)
+	for i := 0; i < count; i++ {
+		changes <- struct{}{}
+	}
+	return &mockWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: changes,
+	}
+}
+
+// mockWatcher implements watcher.NotifyWatcher for use in the tests.
+type mockWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (mock *mockWatcher) Changes() watcher.NotifyChannel {
+	return mock.changes
+}
+
+// credentialTag is the tag of the credential used in the tests.
+var credentialTag = names.NewCloudCredentialTag("dummy/fred/default")
+
+// panicFacade is a NewFacade that should not be called.
+func panicFacade(base.APICaller) (credentialvalidator.Facade, error) {
+	panic("panicFacade")
+}
+
+// panicWorker is a NewWorker that should not be called.
+func panicWorker(credentialvalidator.Config) (worker.Worker, error) {
+	panic("panicWorker")
+}
+
+// validManifoldConfig returns a minimal config stuffed with dummy objects
+// that will explode when used.
+func validManifoldConfig() credentialvalidator.ManifoldConfig {
+	return credentialvalidator.ManifoldConfig{
+		APICallerName: "api-caller",
+		NewFacade:     panicFacade,
+		NewWorker:     panicWorker,
+	}
+}
+
+// checkManifoldNotValid checks that the supplied ManifoldConfig creates
+// a manifold that cannot be started.
+func checkManifoldNotValid(c *gc.C, config credentialvalidator.ManifoldConfig, expect string) {
+	manifold := credentialvalidator.Manifold(config)
+	worker, err := manifold.Start(dt.StubContext(nil, nil))
+	c.Check(worker, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
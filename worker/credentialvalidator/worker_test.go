@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/credentialvalidator"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (*WorkerSuite) TestValidateNilFacade(c *gc.C) {
+	config := credentialvalidator.Config{}
+	err := config.Validate()
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	worker, err := credentialvalidator.New(config)
+	c.Check(worker, gc.IsNil)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*WorkerSuite) TestModelCredentialErrorOnStartup(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(errors.New("gaah"))
+	facade := newMockFacade(stub)
+	worker, err := credentialvalidator.New(credentialvalidator.Config{Facade: facade})
+	c.Check(worker, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "gaah")
+	stub.CheckCallNames(c, "ModelCredential")
+}
+
+func (*WorkerSuite) TestWatchError(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(nil, errors.New("boff"))
+	facade := newMockFacade(stub, true)
+	worker, err := credentialvalidator.New(credentialvalidator.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(worker.Check(), jc.IsTrue)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Check(err, gc.ErrorMatches, "boff")
+	stub.CheckCallNames(c, "ModelCredential", "WatchModelCredential")
+}
+
+func (*WorkerSuite) TestModelCredentialErrorWhileRunning(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(nil, nil, errors.New("glug"))
+	facade := newMockFacade(stub, true)
+	worker, err := credentialvalidator.New(credentialvalidator.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Check(err, gc.ErrorMatches, "glug")
+	stub.CheckCallNames(c, "ModelCredential", "WatchModelCredential", "ModelCredential")
+}
+
+func (*WorkerSuite) TestCredentialInvalidated(c *gc.C) {
+	stub := &testing.Stub{}
+	facade := newMockFacade(stub, true, true, false)
+	worker, err := credentialvalidator.New(credentialvalidator.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(worker.Check(), jc.IsTrue)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Check(err, gc.Equals, credentialvalidator.ErrChanged)
+	stub.CheckCallNames(c, "ModelCredential", "WatchModelCredential", "ModelCredential", "ModelCredential")
+}
+
+func (*WorkerSuite) TestCredentialUpdated(c *gc.C) {
+	stub := &testing.Stub{}
+	facade := newMockFacade(stub, false, true)
+	worker, err := credentialvalidator.New(credentialvalidator.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(worker.Check(), jc.IsFalse)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Check(err, gc.Equals, credentialvalidator.ErrChanged)
+	stub.CheckCallNames(c, "ModelCredential", "WatchModelCredential", "ModelCredential")
+}
+
+func (*WorkerSuite) TestNoRelevantChange(c *gc.C) {
+	stub := &testing.Stub{}
+	facade := newMockFacade(stub, true, true, true, true)
+	worker, err := credentialvalidator.New(credentialvalidator.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(worker.Check(), jc.IsTrue)
+
+	workertest.CheckAlive(c, worker)
+	workertest.CleanKill(c, worker)
+	stub.CheckCallNames(c,
+		"ModelCredential", "WatchModelCredential",
+		"ModelCredential", "ModelCredential", "ModelCredential",
+	)
+}
+
+func (*WorkerSuite) TestNoCredential(c *gc.C) {
+	worker, err := credentialvalidator.New(credentialvalidator.Config{
+		Facade: noCredentialFacade{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(worker.Check(), jc.IsTrue)
+
+	workertest.CheckAlive(c, worker)
+	workertest.CleanKill(c, worker)
+}
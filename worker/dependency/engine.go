@@ -516,6 +516,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	// If we told the worker to stop, we should start it again immediately,
 	// whatever else happened.
 	if info.stopping {
+		workerRestarts.WithLabelValues(name, "stopped").Inc()
 		engine.requestStart(name, engine.config.BounceDelay)
 	} else {
 		// If we didn't stop it ourselves, we need to interpret the error.
@@ -530,6 +531,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 			// anyway).
 		case ErrBounce:
 			// The task exited but wanted to restart immediately.
+			workerRestarts.WithLabelValues(name, "bounce").Inc()
 			engine.requestStart(name, engine.config.BounceDelay)
 		case ErrUninstall:
 			// The task should never run again, and can be removed completely.
@@ -537,6 +539,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 		default:
 			// Something went wrong but we don't know what. Try again soon.
 			logger.Errorf("%q manifold worker returned unexpected error: %v", name, err)
+			workerRestarts.WithLabelValues(name, "error").Inc()
 			engine.requestStart(name, engine.config.ErrorDelay)
 		}
 	}
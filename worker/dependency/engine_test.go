@@ -11,6 +11,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	monitoringtesting "github.com/juju/juju/monitoring/testing"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
@@ -552,6 +553,28 @@ func (s *EngineSuite) TestErrBounce(c *gc.C) {
 	})
 }
 
+func (s *EngineSuite) TestWorkerRestartsMetric(c *gc.C) {
+	bounces := dependency.WorkerRestarts.WithLabelValues("counted-task", "bounce")
+	failures := dependency.WorkerRestarts.WithLabelValues("counted-task", "error")
+	beforeBounces := monitoringtesting.Value(c, bounces)
+	beforeFailures := monitoringtesting.Value(c, failures)
+
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh := newManifoldHarness()
+		err := engine.Install("counted-task", mh.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh.AssertOneStart(c)
+
+		mh.InjectError(c, errors.Trace(dependency.ErrBounce))
+		mh.AssertOneStart(c)
+		c.Check(monitoringtesting.Value(c, bounces), gc.Equals, beforeBounces+1)
+
+		mh.InjectError(c, errors.New("arbitrary"))
+		mh.AssertOneStart(c)
+		c.Check(monitoringtesting.Value(c, failures), gc.Equals, beforeFailures+1)
+	})
+}
+
 func (s *EngineSuite) TestErrUninstall(c *gc.C) {
 	s.fix.run(c, func(engine *dependency.Engine) {
 
@@ -0,0 +1,25 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dependency
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/monitoring"
+)
+
+// workerRestarts counts the restarts of manifold workers, by manifold
+// name and the reason for the restart: "stopped" when the engine
+// stopped the worker because its inputs changed, "bounce" when the
+// worker asked to be restarted, and "error" when it failed.
+var workerRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: monitoring.Namespace,
+	Subsystem: "dependency",
+	Name:      "worker_restarts_total",
+	Help:      "Number of manifold worker restarts, by manifold and reason.",
+}, []string{"manifold", "reason"})
+
+func init() {
+	monitoring.MustRegister(workerRestarts)
+}
@@ -0,0 +1,298 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/series"
+	"github.com/juju/utils/shell"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/tools"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/service/common"
+)
+
+const lxcCommand = "lxc"
+
+// ContainerContext is a Context that deploys each unit agent in its own
+// LXD container, launched from an image and managed with the lxc
+// command. The container shares the machine's network, so that the
+// ports opened by the unit and the addresses it reports are those of
+// the machine. Only the unit agent's directory, its tools, read-only,
+// and its log file are shared with the container, at the same paths as
+// on the machine, and the agent is run by the container's init system.
+type ContainerContext struct {
+
+	// api is used to get the current controller addresses at the time the
+	// given unit is deployed.
+	api APICalls
+
+	// agentConfig returns the agent config for the machine agent that is
+	// running the deployer.
+	agentConfig agent.Config
+
+	// image is the image from which unit agent containers are launched.
+	image string
+
+	// runCommand is a surrogate for utils.RunCommand.
+	runCommand func(string, ...string) (string, error)
+
+	// newService is a surrogate for service.NewService.
+	newService func(string, common.Conf) (containerService, error)
+}
+
+var (
+	_ Context        = (*ContainerContext)(nil)
+	_ AgentLifecycle = (*ContainerContext)(nil)
+)
+
+// containerService is the part of a service.Service that installs and
+// starts a unit agent inside its container.
+type containerService interface {
+	InstallCommands() ([]string, error)
+	StartCommands() ([]string, error)
+}
+
+// NewContainerContext returns a new ContainerContext, acting on behalf
+// of the specified deployer, that deploys unit agents in containers
+// launched from the given image. If no image is specified, a release
+// of Ubuntu matching the machine's series is used.
+func NewContainerContext(agentConfig agent.Config, api APICalls, image string) (*ContainerContext, error) {
+	if _, err := exec.LookPath(lxcCommand); err != nil {
+		return nil, errors.Annotatef(err, "container deployment requires %q", lxcCommand)
+	}
+	if image == "" {
+		image = "ubuntu:" + series.HostSeries()
+	}
+	ctx := &ContainerContext{
+		api:         api,
+		agentConfig: agentConfig,
+		image:       image,
+		runCommand:  utils.RunCommand,
+		newService: func(name string, conf common.Conf) (containerService, error) {
+			return service.NewService(name, conf, series.HostSeries())
+		},
+	}
+	return ctx, nil
+}
+
+func (ctx *ContainerContext) AgentConfig() agent.Config {
+	return ctx.agentConfig
+}
+
+func (ctx *ContainerContext) DeployUnit(unitName, initialPassword string) (err error) {
+	deployed, err := ctx.deployedContainers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name := containerName(unitName)
+	if _, ok := deployed[unitName]; ok {
+		return errors.Errorf("unit %q is already deployed", unitName)
+	}
+
+	// Link the current tools for use by the new agent, and write
+	// its configuration.
+	tag := names.NewUnitTag(unitName)
+	dataDir := ctx.agentConfig.DataDir()
+	toolsDir := tools.ToolsDir(dataDir, tag.String())
+	defer removeOnErr(&err, toolsDir)
+	conf, err := writeUnitAgentConfig(ctx.api, ctx.agentConfig, tag, initialPassword)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer removeOnErr(&err, conf.Dir())
+
+	// The agent's tools link is shared as the directory of the tools
+	// it points to.
+	versionDir, err := os.Readlink(toolsDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !filepath.IsAbs(versionDir) {
+		versionDir = filepath.Join(filepath.Dir(toolsDir), versionDir)
+	}
+	logFile := filepath.Join(ctx.agentConfig.LogDir(), tag.String()+".log")
+	if err := touchFile(logFile); err != nil {
+		return errors.Annotatef(err, "cannot create log file for unit %q", unitName)
+	}
+
+	if _, err := ctx.lxc("init", ctx.image, name); err != nil {
+		return errors.Annotatef(err, "cannot create container for unit %q", unitName)
+	}
+	defer func() {
+		if err != nil {
+			if _, err := ctx.lxc("delete", "--force", name); err != nil {
+				logger.Errorf("cannot delete container %q: %v", name, err)
+			}
+		}
+	}()
+
+	// Mask the network device of the container's profile, and run the
+	// container in the machine's network namespace.
+	if _, err := ctx.lxc("config", "device", "add", name, "eth0", "none"); err != nil {
+		return errors.Annotatef(err, "cannot remove network device of container for unit %q", unitName)
+	}
+	if _, err := ctx.lxc("config", "set", name, "raw.lxc", "lxc.network.type = none"); err != nil {
+		return errors.Annotatef(err, "cannot share machine network with container for unit %q", unitName)
+	}
+
+	devices := []struct {
+		name     string
+		source   string
+		path     string
+		readOnly bool
+	}{
+		{"juju-agent", conf.Dir(), conf.Dir(), false},
+		{"juju-tools", versionDir, toolsDir, true},
+		{"juju-log", logFile, logFile, false},
+	}
+	for _, device := range devices {
+		args := []string{
+			"config", "device", "add", name, device.name, "disk",
+			"source=" + device.source, "path=" + device.path,
+		}
+		if device.readOnly {
+			args = append(args, "readonly=true")
+		}
+		if _, err := ctx.lxc(args...); err != nil {
+			return errors.Annotatef(err, "cannot share %q with container for unit %q", device.source, unitName)
+		}
+	}
+
+	if _, err := ctx.lxc("start", name); err != nil {
+		return errors.Annotatef(err, "cannot start container for unit %q", unitName)
+	}
+
+	// Install and start an init service that runs the unit agent
+	// inside the container.
+	svc, err := ctx.service(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	installCommands, err := svc.InstallCommands()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	startCommands, err := svc.StartCommands()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	script := strings.Join(append(installCommands, startCommands...), "\n")
+	if _, err := ctx.lxc("exec", name, "--", "/bin/bash", "-c", script); err != nil {
+		return errors.Annotatef(err, "cannot start agent of unit %q", unitName)
+	}
+	return nil
+}
+
+func (ctx *ContainerContext) RecallUnit(unitName string) error {
+	deployed, err := ctx.deployedContainers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name, ok := deployed[unitName]
+	if !ok {
+		return errors.Errorf("unit %q is not deployed", unitName)
+	}
+	if _, err := ctx.lxc("delete", "--force", name); err != nil {
+		return errors.Annotatef(err, "cannot delete container for unit %q", unitName)
+	}
+	return removeUnitAgent(ctx.agentConfig.DataDir(), names.NewUnitTag(unitName))
+}
+
+func (ctx *ContainerContext) DeployedUnits() ([]string, error) {
+	deployed, err := ctx.deployedContainers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var units []string
+	for unitName := range deployed {
+		units = append(units, unitName)
+	}
+	return units, nil
+}
+
+// UnitAgentRunning is part of the AgentLifecycle interface. The agent
+// of a unit is considered to be running while its container is.
+func (ctx *ContainerContext) UnitAgentRunning(unitName string) (bool, error) {
+	out, err := ctx.lxc("list", "--format", "csv", "-c", "s", "^"+containerName(unitName)+"$")
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return strings.TrimSpace(out) == "RUNNING", nil
+}
+
+// StartUnitAgent is part of the AgentLifecycle interface. Starting the
+// container of a unit starts its agent with the container's init
+// system.
+func (ctx *ContainerContext) StartUnitAgent(unitName string) error {
+	_, err := ctx.lxc("start", containerName(unitName))
+	return errors.Trace(err)
+}
+
+// deployedContainers returns the names of the unit agent containers
+// on the machine, keyed by unit name.
+func (ctx *ContainerContext) deployedContainers() (map[string]string, error) {
+	out, err := ctx.lxc("list", "--format", "csv", "-c", "n")
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list containers")
+	}
+	deployed := make(map[string]string)
+	for _, name := range strings.Fields(out) {
+		if groups := deployedRe.FindStringSubmatch(name); len(groups) > 0 {
+			unitName := groups[2] + "/" + groups[3]
+			if !names.IsValidUnit(unitName) {
+				continue
+			}
+			deployed[unitName] = groups[1]
+		}
+	}
+	return deployed, nil
+}
+
+// service returns the init service that runs the agent of the
+// specified unit inside its container.
+func (ctx *ContainerContext) service(unitName string) (containerService, error) {
+	renderer, err := shell.NewRenderer("")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := service.NewAgentInfo(
+		service.AgentKindUnit,
+		unitName,
+		ctx.agentConfig.DataDir(),
+		ctx.agentConfig.LogDir(),
+	)
+	conf := service.ContainerAgentConf(info, renderer, ctx.agentConfig.Value(agent.ContainerType))
+	return ctx.newService(containerName(unitName), conf)
+}
+
+// touchFile creates the file at the given path, if it does not exist,
+// so that it can be shared with a container.
+func touchFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return f.Close()
+}
+
+func (ctx *ContainerContext) lxc(args ...string) (string, error) {
+	out, err := ctx.runCommand(lxcCommand, args...)
+	return out, errors.Trace(err)
+}
+
+// containerName returns the name of the container in which the agent of
+// the specified unit is deployed, which matches the name of the init
+// service used by a SimpleContext.
+func containerName(unitName string) string {
+	return "jujud-" + names.NewUnitTag(unitName).String()
+}
@@ -0,0 +1,166 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/series"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/tools"
+	jujuversion "github.com/juju/juju/version"
+	"github.com/juju/juju/worker/deployer"
+)
+
+type ContainerContextSuite struct {
+	SimpleToolsFixture
+
+	containers map[string]string
+	commands   []string
+}
+
+var _ = gc.Suite(&ContainerContextSuite{})
+
+func (s *ContainerContextSuite) SetUpTest(c *gc.C) {
+	s.SimpleToolsFixture.SetUp(c, c.MkDir())
+	s.containers = map[string]string{"some-other-container": "RUNNING"}
+	s.commands = nil
+}
+
+func (s *ContainerContextSuite) TearDownTest(c *gc.C) {
+	s.SimpleToolsFixture.TearDown(c)
+}
+
+// runLXC fakes the lxc commands used by a ContainerContext, recording
+// them and keeping track of the containers they manage.
+func (s *ContainerContextSuite) runLXC(command string, args ...string) (string, error) {
+	if command != "lxc" {
+		return "", errors.Errorf("unexpected command %q", command)
+	}
+	s.commands = append(s.commands, strings.Join(args, " "))
+	switch args[0] {
+	case "init":
+		s.containers[args[2]] = "STOPPED"
+	case "delete":
+		delete(s.containers, args[2])
+	case "start":
+		s.containers[args[1]] = "RUNNING"
+	case "list":
+		var out []string
+		for name, status := range s.containers {
+			switch args[4] {
+			case "n":
+				out = append(out, name)
+			case "s":
+				if args[5] == "^"+name+"$" {
+					out = append(out, status)
+				}
+			}
+		}
+		return strings.Join(out, "\n") + "\n", nil
+	}
+	return "", nil
+}
+
+func (s *ContainerContextSuite) getContext(c *gc.C) *deployer.ContainerContext {
+	config := agentConfig(names.NewMachineTag("99"), s.dataDir, s.logDir)
+	return deployer.NewTestContainerContext(config, "ubuntu:xenial", s.runLXC)
+}
+
+func (s *ContainerContextSuite) TestDeployRecall(c *gc.C) {
+	ctx := s.getContext(c)
+	units, err := ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+
+	err = ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err = ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.DeepEquals, []string{"foo/123"})
+
+	tag := names.NewUnitTag("foo/123")
+	agentDir, toolsDir := s.paths(tag)
+	versionDir := tools.SharedToolsDir(s.dataDir, version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: series.HostSeries(),
+	})
+	logFile := filepath.Join(s.logDir, "unit-foo-123.log")
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"list --format csv -c n",
+		"init ubuntu:xenial jujud-unit-foo-123",
+		"config device add jujud-unit-foo-123 eth0 none",
+		"config set jujud-unit-foo-123 raw.lxc lxc.network.type = none",
+		"config device add jujud-unit-foo-123 juju-agent disk source=" + agentDir + " path=" + agentDir,
+		"config device add jujud-unit-foo-123 juju-tools disk source=" + versionDir + " path=" + toolsDir + " readonly=true",
+		"config device add jujud-unit-foo-123 juju-log disk source=" + logFile + " path=" + logFile,
+		"start jujud-unit-foo-123",
+		"exec jujud-unit-foo-123 -- /bin/bash -c install jujud-unit-foo-123\nstart jujud-unit-foo-123",
+		"list --format csv -c n",
+	})
+	_, err = os.Stat(logFile)
+	c.Assert(err, jc.ErrorIsNil)
+	conf, err := agent.ReadConfig(agent.ConfigPath(s.dataDir, tag))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Tag(), gc.Equals, tag)
+
+	err = ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, gc.ErrorMatches, `unit "foo/123" is already deployed`)
+
+	err = ctx.RecallUnit("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err = ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+	s.checkUnitRemoved(c, "foo/123")
+	c.Assert(s.containers, jc.DeepEquals, map[string]string{"some-other-container": "RUNNING"})
+
+	err = ctx.RecallUnit("foo/123")
+	c.Assert(err, gc.ErrorMatches, `unit "foo/123" is not deployed`)
+}
+
+func (s *ContainerContextSuite) TestDeployFailureRemovesContainer(c *gc.C) {
+	config := agentConfig(names.NewMachineTag("99"), s.dataDir, s.logDir)
+	ctx := deployer.NewTestContainerContext(config, "ubuntu:xenial", func(command string, args ...string) (string, error) {
+		if args[0] == "exec" {
+			return "", errors.New("boom")
+		}
+		return s.runLXC(command, args...)
+	})
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, gc.ErrorMatches, `cannot start agent of unit "foo/123": boom`)
+	c.Assert(s.containers, jc.DeepEquals, map[string]string{"some-other-container": "RUNNING"})
+	s.checkUnitRemoved(c, "foo/123")
+}
+
+func (s *ContainerContextSuite) TestUnitAgentLifecycle(c *gc.C) {
+	ctx := s.getContext(c)
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+
+	running, err := ctx.UnitAgentRunning("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, jc.IsTrue)
+
+	s.containers["jujud-unit-foo-123"] = "STOPPED"
+	running, err = ctx.UnitAgentRunning("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, jc.IsFalse)
+
+	err = ctx.StartUnitAgent("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	running, err = ctx.UnitAgentRunning("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, jc.IsTrue)
+}
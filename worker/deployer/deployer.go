@@ -52,6 +52,42 @@ type Context interface {
 	AgentConfig() agent.Config
 }
 
+// AgentLifecycle is implemented by Contexts that can tell whether the
+// agents they deployed are running, and start those that are not. The
+// Deployer uses it to restart the stopped agents of deployed units.
+type AgentLifecycle interface {
+	// UnitAgentRunning reports whether the agent of the specified
+	// deployed unit is running.
+	UnitAgentRunning(unitName string) (bool, error)
+
+	// StartUnitAgent starts the agent of the specified deployed unit.
+	StartUnitAgent(unitName string) error
+}
+
+const (
+	// DeploymentClassic deploys unit agents as services of the
+	// machine's init system. It is the default deployment mode.
+	DeploymentClassic = "classic"
+
+	// DeploymentContainer deploys each unit agent in its own LXD
+	// container, launched from the image named by the machine
+	// agent's UNIT_AGENT_IMAGE config value.
+	DeploymentContainer = "container"
+)
+
+// NewDeployContext returns the Context that deploys unit agents in the
+// mode named by the machine agent's UNIT_AGENT_DEPLOYMENT config value.
+func NewDeployContext(agentConfig agent.Config, api APICalls) (Context, error) {
+	switch mode := agentConfig.Value(agent.UnitAgentDeployment); mode {
+	case "", DeploymentClassic:
+		return NewSimpleContext(agentConfig, api), nil
+	case DeploymentContainer:
+		return NewContainerContext(agentConfig, api, agentConfig.Value(agent.UnitAgentImage))
+	default:
+		return nil, errors.NotValidf("unit agent deployment mode %q", mode)
+	}
+}
+
 // NewDeployer returns a Worker that deploys and recalls unit agents
 // via ctx, taking a machine id to operate on.
 func NewDeployer(st *apideployer.State, ctx Context) (worker.Worker, error) {
@@ -128,6 +164,8 @@ func (d *Deployer) changed(unitName string) error {
 			if err := d.recall(unitName); err != nil {
 				return err
 			}
+		} else if err := d.ensureRunning(unitName); err != nil {
+			return err
 		}
 	}
 	// The only units that should be deployed are those that (1) we are responsible
@@ -166,6 +204,27 @@ func (d *Deployer) deploy(unit *apideployer.Unit) error {
 	return nil
 }
 
+// ensureRunning starts the agent of the named deployed unit if it is not
+// running, when the deployer's manager supports it.
+func (d *Deployer) ensureRunning(unitName string) error {
+	lifecycle, ok := d.ctx.(AgentLifecycle)
+	if !ok {
+		return nil
+	}
+	running, err := lifecycle.UnitAgentRunning(unitName)
+	if err != nil {
+		return errors.Annotatef(err, "cannot check agent of unit %q", unitName)
+	}
+	if running {
+		return nil
+	}
+	logger.Infof("starting stopped agent of unit %q", unitName)
+	if err := lifecycle.StartUnitAgent(unitName); err != nil {
+		return errors.Annotatef(err, "cannot start agent of unit %q", unitName)
+	}
+	return nil
+}
+
 // recall will recall the named unit with the deployer's manager. It will
 // panic if it observes inconsistent internal state.
 func (d *Deployer) recall(unitName string) error {
@@ -6,10 +6,12 @@ package deployer_test
 import (
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api"
@@ -226,6 +228,57 @@ func (s *deployerSuite) TestNonAliveSubordinates(c *gc.C) {
 	s.waitFor(c, isRemoved(s.State, sub1.Name()))
 }
 
+// lifecycleContext is a SimpleContext whose deployed agents are never
+// running, recording the agents the deployer starts.
+type lifecycleContext struct {
+	*deployer.SimpleContext
+
+	mu      sync.Mutex
+	started set.Strings
+}
+
+func (ctx *lifecycleContext) UnitAgentRunning(unitName string) (bool, error) {
+	return false, nil
+}
+
+func (ctx *lifecycleContext) StartUnitAgent(unitName string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.started.Add(unitName)
+	return nil
+}
+
+func (ctx *lifecycleContext) isStarted(unitName string) func(*gc.C) bool {
+	return func(c *gc.C) bool {
+		ctx.mu.Lock()
+		defer ctx.mu.Unlock()
+		return ctx.started.Contains(unitName)
+	}
+}
+
+func (s *deployerSuite) TestStartsStoppedAgents(c *gc.C) {
+	svc := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	u0, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u0.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Deploy the unit, then start a deployer whose context reports
+	// that its agent is not running.
+	dep, ctx := s.makeDeployerAndContext(c)
+	s.waitFor(c, isDeployed(ctx, u0.Name()))
+	stop(c, dep)
+
+	lifecycle := &lifecycleContext{
+		SimpleContext: s.getContextForMachine(c, s.machine.Tag()),
+		started:       make(set.Strings),
+	}
+	dep, err = deployer.NewDeployer(s.deployerState, lifecycle)
+	c.Assert(err, jc.ErrorIsNil)
+	defer stop(c, dep)
+	s.waitFor(c, lifecycle.isStarted(u0.Name()))
+}
+
 func (s *deployerSuite) waitFor(c *gc.C, t func(c *gc.C) bool) {
 	s.BackingState.StartSync()
 	if t(c) {
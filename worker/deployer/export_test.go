@@ -33,3 +33,27 @@ func NewTestSimpleContext(agentConfig agent.Config, logDir string, data *svctest
 		},
 	}
 }
+
+type fakeContainerService struct {
+	name string
+}
+
+func (s fakeContainerService) InstallCommands() ([]string, error) {
+	return []string{"install " + s.name}, nil
+}
+
+func (s fakeContainerService) StartCommands() ([]string, error) {
+	return []string{"start " + s.name}, nil
+}
+
+func NewTestContainerContext(agentConfig agent.Config, image string, runCommand func(string, ...string) (string, error)) *ContainerContext {
+	return &ContainerContext{
+		api:         &fakeAPI{},
+		agentConfig: agentConfig,
+		image:       image,
+		runCommand:  runCommand,
+		newService: func(name string, conf common.Conf) (containerService, error) {
+			return fakeContainerService{name}, nil
+		},
+	}
+}
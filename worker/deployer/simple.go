@@ -49,7 +49,10 @@ type SimpleContext struct {
 	listServices func() ([]string, error)
 }
 
-var _ Context = (*SimpleContext)(nil)
+var (
+	_ Context        = (*SimpleContext)(nil)
+	_ AgentLifecycle = (*SimpleContext)(nil)
+)
 
 // recursiveChmod will change the permissions on all files and
 // folders inside path
@@ -107,30 +110,47 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 		return fmt.Errorf("unit %q is already deployed", unitName)
 	}
 
-	// Link the current tools for use by the new agent.
+	// Link the current tools for use by the new agent, and write
+	// its configuration.
 	tag := names.NewUnitTag(unitName)
-	dataDir := ctx.agentConfig.DataDir()
-	logDir := ctx.agentConfig.LogDir()
+	toolsDir := tools.ToolsDir(ctx.agentConfig.DataDir(), tag.String())
+	defer removeOnErr(&err, toolsDir)
+	conf, err := writeUnitAgentConfig(ctx.api, ctx.agentConfig, tag, initialPassword)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer removeOnErr(&err, conf.Dir())
+
+	// Install an init service that runs the unit agent.
+	if err := service.InstallAndStart(svc); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// writeUnitAgentConfig links the current tools for use by the agent of
+// the given unit, and writes the agent's configuration.
+func writeUnitAgentConfig(api APICalls, agentConfig agent.Config, tag names.UnitTag, initialPassword string) (agent.ConfigSetterWriter, error) {
+	dataDir := agentConfig.DataDir()
+	logDir := agentConfig.LogDir()
 	current := version.Binary{
 		Number: jujuversion.Current,
 		Arch:   arch.HostArch(),
 		Series: series.HostSeries(),
 	}
-	toolsDir := tools.ToolsDir(dataDir, tag.String())
-	defer removeOnErr(&err, toolsDir)
-	_, err = tools.ChangeAgentTools(dataDir, tag.String(), current)
+	_, err := tools.ChangeAgentTools(dataDir, tag.String(), current)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
-	result, err := ctx.api.ConnectionInfo()
+	result, err := api.ConnectionInfo()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	logger.Debugf("state addresses: %q", result.StateAddresses)
 	logger.Debugf("API addresses: %q", result.APIAddresses)
-	containerType := ctx.agentConfig.Value(agent.ContainerType)
-	namespace := ctx.agentConfig.Value(agent.Namespace)
+	containerType := agentConfig.Value(agent.ContainerType)
+	namespace := agentConfig.Value(agent.Namespace)
 	conf, err := agent.NewAgentConfig(
 		agent.AgentConfigParams{
 			Paths: agent.Paths{
@@ -142,35 +162,48 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 			Tag:               tag,
 			Password:          initialPassword,
 			Nonce:             "unused",
-			Model:             ctx.agentConfig.Model(),
+			Model:             agentConfig.Model(),
 			// TODO: remove the state addresses here and test when api only.
 			StateAddresses: result.StateAddresses,
 			APIAddresses:   result.APIAddresses,
-			CACert:         ctx.agentConfig.CACert(),
+			CACert:         agentConfig.CACert(),
 			Values: map[string]string{
 				agent.ContainerType: containerType,
 				agent.Namespace:     namespace,
 			},
 		})
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	if err := conf.Write(); err != nil {
-		return err
+		return nil, err
 	}
-	defer removeOnErr(&err, conf.Dir())
+	return conf, nil
+}
 
-	// Install an init service that runs the unit agent.
-	if err := service.InstallAndStart(svc); err != nil {
-		return errors.Trace(err)
+// removeUnitAgent removes the configuration and tools link of the
+// agent of the given unit.
+func removeUnitAgent(dataDir string, tag names.UnitTag) error {
+	agentDir := agent.Dir(dataDir, tag)
+	// Recursivley change mode to 777 on windows to avoid
+	// Operation not permitted errors when deleting the agentDir
+	err := recursiveChmod(agentDir, os.FileMode(0777))
+	if err != nil {
+		return err
 	}
-	return nil
+	if err := os.RemoveAll(agentDir); err != nil {
+		return err
+	}
+	// TODO(dfc) should take a Tag
+	toolsDir := tools.ToolsDir(dataDir, tag.String())
+	return os.Remove(toolsDir)
 }
 
 type deployerService interface {
 	Installed() (bool, error)
 	Install() error
 	Remove() error
+	Running() (bool, error)
 	Start() error
 	Stop() error
 }
@@ -208,21 +241,26 @@ func (ctx *SimpleContext) RecallUnit(unitName string) error {
 	if err := svc.Remove(); err != nil {
 		return err
 	}
-	tag := names.NewUnitTag(unitName)
-	dataDir := ctx.agentConfig.DataDir()
-	agentDir := agent.Dir(dataDir, tag)
-	// Recursivley change mode to 777 on windows to avoid
-	// Operation not permitted errors when deleting the agentDir
-	err = recursiveChmod(agentDir, os.FileMode(0777))
+	return removeUnitAgent(ctx.agentConfig.DataDir(), names.NewUnitTag(unitName))
+}
+
+// UnitAgentRunning is part of the AgentLifecycle interface.
+func (ctx *SimpleContext) UnitAgentRunning(unitName string) (bool, error) {
+	svc, err := ctx.findInitSystemJob(unitName)
 	if err != nil {
-		return err
+		return false, errors.Trace(err)
 	}
-	if err := os.RemoveAll(agentDir); err != nil {
-		return err
+	running, err := svc.Running()
+	return running, errors.Trace(err)
+}
+
+// StartUnitAgent is part of the AgentLifecycle interface.
+func (ctx *SimpleContext) StartUnitAgent(unitName string) error {
+	svc, err := ctx.findInitSystemJob(unitName)
+	if err != nil {
+		return errors.Trace(err)
 	}
-	// TODO(dfc) should take a Tag
-	toolsDir := tools.ToolsDir(dataDir, tag.String())
-	return os.Remove(toolsDir)
+	return errors.Trace(svc.Start())
 }
 
 var deployedRe = regexp.MustCompile("^(jujud-.*unit-([a-z0-9-]+)-([0-9]+))$")
@@ -78,6 +78,43 @@ func (s *SimpleContextSuite) TestDeployRecall(c *gc.C) {
 	s.checkUnitRemoved(c, "foo/123")
 }
 
+func (s *SimpleContextSuite) TestUnitAgentRunning(c *gc.C) {
+	mgr := s.getContext(c)
+	err := mgr.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.data.SetStatus("jujud-unit-foo-123", "running")
+	c.Assert(err, jc.ErrorIsNil)
+	running, err := mgr.UnitAgentRunning("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, jc.IsTrue)
+
+	err = s.data.SetStatus("jujud-unit-foo-123", "installed")
+	c.Assert(err, jc.ErrorIsNil)
+	running, err = mgr.UnitAgentRunning("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, jc.IsFalse)
+
+	_, err = mgr.UnitAgentRunning("foo/124")
+	c.Assert(err, gc.ErrorMatches, `unit "foo/124" is not deployed`)
+}
+
+func (s *SimpleContextSuite) TestNewDeployContext(c *gc.C) {
+	config := &mockConfig{tag: names.NewMachineTag("99"), datadir: s.dataDir, logdir: s.logDir}
+	ctx, err := deployer.NewDeployContext(config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx, gc.FitsTypeOf, &deployer.SimpleContext{})
+
+	config.values = map[string]string{agent.UnitAgentDeployment: "classic"}
+	ctx, err = deployer.NewDeployContext(config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx, gc.FitsTypeOf, &deployer.SimpleContext{})
+
+	config.values = map[string]string{agent.UnitAgentDeployment: "vm"}
+	_, err = deployer.NewDeployContext(config, nil)
+	c.Assert(err, gc.ErrorMatches, `unit agent deployment mode "vm" not valid`)
+}
+
 func (s *SimpleContextSuite) TestOldDeployedUnitsCanBeRecalled(c *gc.C) {
 	// After r1347 deployer tag is no longer part of the upstart conf filenames,
 	// now only the units' tags are used. This change is with the assumption only
@@ -296,6 +333,7 @@ type mockConfig struct {
 	logdir            string
 	upgradedToVersion version.Number
 	jobs              []multiwatcher.MachineJob
+	values            map[string]string
 }
 
 func (mock *mockConfig) Tag() names.Tag {
@@ -331,8 +369,8 @@ func (mock *mockConfig) CACert() string {
 	return testing.CACert
 }
 
-func (mock *mockConfig) Value(_ string) string {
-	return ""
+func (mock *mockConfig) Value(key string) string {
+	return mock.values[key]
 }
 
 func agentConfig(tag names.Tag, datadir, logdir string) agent.Config {
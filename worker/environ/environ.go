@@ -25,6 +25,10 @@ type ConfigObserver interface {
 type Config struct {
 	Observer       ConfigObserver
 	NewEnvironFunc environs.NewEnvironFunc
+
+	// InvalidateCredential, if non-nil, is passed to the environ so
+	// that it can report the model's cloud credential as invalid.
+	InvalidateCredential environs.InvalidateCredentialFunc
 }
 
 // Validate returns an error if the config cannot be used to start a Tracker.
@@ -56,7 +60,14 @@ func NewTracker(config Config) (*Tracker, error) {
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	environ, err := environs.GetEnviron(config.Observer, config.NewEnvironFunc)
+	newEnviron := config.NewEnvironFunc
+	if config.InvalidateCredential != nil {
+		newEnviron = func(args environs.OpenParams) (environs.Environ, error) {
+			args.InvalidateCredential = config.InvalidateCredential
+			return config.NewEnvironFunc(args)
+		}
+	}
+	environ, err := environs.GetEnviron(config.Observer, newEnviron)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create environ")
 	}
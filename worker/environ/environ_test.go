@@ -122,6 +122,30 @@ func (s *TrackerSuite) TestCloudSpec(c *gc.C) {
 	})
 }
 
+func (s *TrackerSuite) TestInvalidateCredential(c *gc.C) {
+	var reasons []string
+	invalidate := func(reason string) error {
+		reasons = append(reasons, reason)
+		return nil
+	}
+	fix := &fixture{}
+	fix.Run(c, func(context *runContext) {
+		tracker, err := environ.NewTracker(environ.Config{
+			Observer: context,
+			NewEnvironFunc: func(args environs.OpenParams) (environs.Environ, error) {
+				c.Assert(args.InvalidateCredential, gc.NotNil)
+				err := args.InvalidateCredential("revoked")
+				c.Check(err, jc.ErrorIsNil)
+				return nil, errors.NotValidf("credential")
+			},
+			InvalidateCredential: invalidate,
+		})
+		c.Check(err, gc.ErrorMatches, `cannot create environ: credential not valid`)
+		c.Check(tracker, gc.IsNil)
+	})
+	c.Check(reasons, jc.DeepEquals, []string{"revoked"})
+}
+
 func (s *TrackerSuite) TestWatchFails(c *gc.C) {
 	fix := &fixture{
 		observerErrs: []error{
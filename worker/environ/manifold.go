@@ -8,6 +8,8 @@ import (
 
 	"github.com/juju/juju/api/agent"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/credentialvalidator"
+	"github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
@@ -32,9 +34,11 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
 				return nil, errors.Trace(err)
 			}
+			credentialValidator := credentialvalidator.NewFacade(apiCaller, watcher.NewNotifyWatcher)
 			w, err := NewTracker(Config{
-				Observer:       agent.NewState(apiCaller),
-				NewEnvironFunc: config.NewEnvironFunc,
+				Observer:             agent.NewState(apiCaller),
+				NewEnvironFunc:       config.NewEnvironFunc,
+				InvalidateCredential: credentialValidator.InvalidateModelCredential,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
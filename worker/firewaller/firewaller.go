@@ -33,6 +33,7 @@ type Firewaller struct {
 	modelWatcher    watcher.NotifyWatcher
 	machinesWatcher watcher.StringsWatcher
 	portsWatcher    watcher.StringsWatcher
+	rulesWatcher    watcher.NotifyWatcher
 	machineds       map[names.MachineTag]*machineData
 	unitsChange     chan *unitsChange
 	unitds          map[names.UnitTag]*unitData
@@ -41,6 +42,12 @@ type Firewaller struct {
 	globalMode      bool
 	globalPortRef   map[network.PortRange]int
 	machinePorts    map[names.MachineTag]machineRanges
+
+	// ruleSetter is the environ, if it can enforce firewall rules.
+	ruleSetter environs.FirewallRuleSetter
+	// rulesPending records whether there are firewall rules which
+	// the environ could not yet enforce.
+	rulesPending bool
 }
 
 // NewFirewaller returns a new Firewaller or a new FirewallerV0,
@@ -115,6 +122,17 @@ func (fw *Firewaller) setUp() error {
 	}
 
 	logger.Debugf("started watching opened port ranges for the environment")
+
+	if setter, ok := fw.environ.(environs.FirewallRuleSetter); ok {
+		fw.ruleSetter = setter
+		fw.rulesWatcher, err = fw.st.WatchFirewallRules()
+		if err != nil {
+			return errors.Annotatef(err, "failed to start firewall rules watcher")
+		}
+		if err := fw.catacomb.Add(fw.rulesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -124,6 +142,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var rulesChange watcher.NotifyChannel
+	if fw.rulesWatcher != nil {
+		rulesChange = fw.rulesWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -182,6 +204,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.sourceCIDRs = change.sourceCIDRs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -189,10 +212,45 @@ func (fw *Firewaller) loop() error {
 			if err := fw.flushUnits(unitds); err != nil {
 				return errors.Annotate(err, "cannot change firewall ports")
 			}
+		case _, ok := <-rulesChange:
+			if !ok {
+				return errors.New("firewall rules watcher closed")
+			}
+			fw.rulesPending = true
+		}
+		if fw.rulesPending {
+			if err := fw.setFirewallRules(); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 }
 
+// setFirewallRules sets the model's firewall rules on the environ. Any
+// rules which the environ cannot yet enforce, such as when no machines
+// have been started, remain pending and are set again after the next
+// change seen by the firewaller.
+func (fw *Firewaller) setFirewallRules() error {
+	rules, err := fw.st.FirewallRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fw.rulesPending = false
+	for _, rule := range rules {
+		err := fw.ruleSetter.SetFirewallRule(rule)
+		switch {
+		case errors.IsNotFound(err):
+			logger.Debugf("cannot set firewall rule %v yet: %v", rule, err)
+			fw.rulesPending = true
+		case errors.IsNotSupported(err):
+			logger.Warningf("cannot set firewall rule %v: %v", rule, err)
+		case err != nil:
+			return errors.Annotatef(err, "cannot set firewall rule %v", rule)
+		}
+	}
+	return nil
+}
+
 // startMachine creates a new data value for tracking details of the
 // machine and starts watching the machine for units added or removed.
 func (fw *Firewaller) startMachine(tag names.MachineTag) error {
@@ -201,6 +259,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
 		openedPorts:  make([]network.PortRange, 0),
+		portSources:  make(map[network.PortRange][]string),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -313,16 +372,21 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	sourceCIDRs, err := service.ExposedSourceCIDRs()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
 		fw:          fw,
 		application: service,
 		exposed:     exposed,
+		sourceCIDRs: sourceCIDRs,
 		unitds:      make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, sourceCIDRs)
 		},
 	})
 	if err != nil {
@@ -356,10 +420,11 @@ func (fw *Firewaller) reconcileGlobal() error {
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
+	var collected []network.PortRange
 	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+		collected = append(collected, port)
 	}
+	wantedPorts := network.MergePortRanges(collected)
 	// Check which ports to open or to close.
 	toOpen := diffRanges(wantedPorts, initialPortRanges)
 	toClose := diffRanges(initialPortRanges, wantedPorts)
@@ -395,6 +460,14 @@ func (fw *Firewaller) reconcileInstances() error {
 		if err != nil {
 			return err
 		}
+		inMaintenance, err := m.InMaintenance()
+		if err != nil {
+			return err
+		}
+		if inMaintenance {
+			logger.Infof("machine %q is in maintenance mode; not reconciling its ports", machined.tag)
+			continue
+		}
 		instanceId, err := m.InstanceId()
 		if errors.IsNotProvisioned(err) {
 			logger.Errorf("Machine not yet provisioned: %v", err)
@@ -422,7 +495,7 @@ func (fw *Firewaller) reconcileInstances() error {
 		if len(toOpen) > 0 {
 			logger.Infof("opening instance port ranges %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			if err := fw.openInstancePorts(instances[0], machined, toOpen, nil, nil); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
@@ -556,8 +629,23 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
+	if !fw.globalMode {
+		// The ports of a machine in maintenance mode are left alone;
+		// they are brought up to date by the first change after it
+		// leaves maintenance.
+		inMaintenance, err := machined.inMaintenance()
+		if err != nil {
+			return err
+		}
+		if inMaintenance {
+			logger.Infof("machine %q is in maintenance mode; not changing its ports", machined.tag)
+			return nil
+		}
+	}
+	// Gather the ports to open, grouped by the sources to which they
+	// are restricted.
+	rawWant := make(map[string][]network.PortRange)
+	groupSources := make(map[string][]string)
 	for portRange, unitTag := range machined.definedPorts {
 		unitd, known := machined.unitds[unitTag]
 		if !known {
@@ -565,37 +653,77 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 			continue
 		}
 		if unitd.serviced.exposed {
+			sources := network.MergeCIDRs(unitd.serviced.sourceCIDRs)
+			key := strings.Join(sources, ",")
+			rawWant[key] = append(rawWant[key], portRange)
+			groupSources[key] = sources
+		}
+	}
+	// Merge the ranges sharing the same sources, so that the minimal
+	// set of rules is compared against those already applied, and only
+	// the differences are sent to the provider.
+	want := []network.PortRange{}
+	wantSources := make(map[network.PortRange][]string)
+	for key, portRanges := range rawWant {
+		for _, portRange := range network.MergePortRanges(portRanges) {
 			want = append(want, portRange)
+			if key != "" {
+				wantSources[portRange] = groupSources[key]
+			}
+		}
+	}
+	if fw.globalMode && len(wantSources) > 0 {
+		// Global ports are open to any address, so those which must
+		// be restricted to particular sources are left closed.
+		unrestricted := []network.PortRange{}
+		for _, portRange := range want {
+			if sources, ok := wantSources[portRange]; ok {
+				logger.Errorf("cannot restrict global port range %v to %v; not opening it", portRange, sources)
+				continue
+			}
+			unrestricted = append(unrestricted, portRange)
 		}
+		want = unrestricted
+		wantSources = make(map[network.PortRange][]string)
 	}
 	toOpen := diffRanges(want, machined.openedPorts)
 	toClose := diffRanges(machined.openedPorts, want)
+	// Ports which remain open, but whose sources have changed, are
+	// restricted to their new sources.
+	var toRestrict []network.PortRange
+	for _, portRange := range diffRanges(want, toOpen) {
+		if !sameSources(wantSources[portRange], machined.portSources[portRange]) {
+			toRestrict = append(toRestrict, portRange)
+		}
+	}
+	previousSources := machined.portSources
 	machined.openedPorts = want
+	machined.portSources = wantSources
 	if fw.globalMode {
 		return fw.flushGlobalPorts(toOpen, toClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return fw.flushInstancePorts(machined, toOpen, toClose, toRestrict, previousSources)
 }
 
 // flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
+// It keeps a reference count for ports, and compares the merged ranges
+// referenced before and after the change, so that only the rules which
+// actually change modify the environment.
 func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
 	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
+	before := fw.globalPortRanges()
 	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
-		}
 		fw.globalPortRef[portRange]++
 	}
 	for _, portRange := range rawClose {
 		fw.globalPortRef[portRange]--
 		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
 			delete(fw.globalPortRef, portRange)
 		}
 	}
+	after := fw.globalPortRanges()
+	toOpen := diffRanges(after, before)
+	toClose := diffRanges(before, after)
 	// Open and close the ports.
 	if len(toOpen) > 0 {
 		if err := fw.environ.OpenPorts(toOpen); err != nil {
@@ -616,13 +744,31 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) er
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// globalPortRanges returns the merged port ranges referenced by any
+// machine in global mode.
+func (fw *Firewaller) globalPortRanges() []network.PortRange {
+	var portRanges []network.PortRange
+	for portRange, ref := range fw.globalPortRef {
+		if ref > 0 {
+			portRanges = append(portRanges, portRange)
+		}
+	}
+	return network.MergePortRanges(portRanges)
+}
+
+// flushInstancePorts opens and closes ports global on the machine, and
+// restricts the sources of those whose sources have changed from the
+// previous ones.
+func (fw *Firewaller) flushInstancePorts(
+	machined *machineData,
+	toOpen, toClose, toRestrict []network.PortRange,
+	previousSources map[network.PortRange][]string,
+) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
 	// InstanceId will fail but we don't care.
-	if len(toOpen) == 0 && len(toClose) == 0 {
+	if len(toOpen) == 0 && len(toClose) == 0 && len(toRestrict) == 0 {
 		return nil
 	}
 	m, err := machined.machine()
@@ -642,13 +788,15 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 		return err
 	}
 	// Open and close the ports.
-	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+	if len(toOpen) > 0 || len(toRestrict) > 0 {
+		if err := fw.openInstancePorts(instances[0], machined, toOpen, toRestrict, previousSources); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		if len(toOpen) > 0 {
+			network.SortPortRanges(toOpen)
+			logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		}
 	}
 	if len(toClose) > 0 {
 		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
@@ -661,6 +809,76 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// openInstancePorts opens the given port ranges on the machine's
+// instance, restricting those with sources recorded for the machine to
+// those sources, and restricts the already opened toRestrict ranges to
+// their recorded sources, which have changed from the previous ones.
+// If the instance cannot restrict the sources of its ports, restricted
+// ranges are left closed rather than opened to any address.
+func (fw *Firewaller) openInstancePorts(
+	inst instance.Instance,
+	machined *machineData,
+	toOpen, toRestrict []network.PortRange,
+	previousSources map[network.PortRange][]string,
+) error {
+	machineId := machined.tag.Id()
+	setter, canRestrict := inst.(environs.InstancePortSourceSetter)
+	var unrestricted, toClose []network.PortRange
+	restricted := make(map[string][]network.PortRange)
+	for _, portRange := range toOpen {
+		sources, ok := machined.portSources[portRange]
+		switch {
+		case !ok:
+			unrestricted = append(unrestricted, portRange)
+		case !canRestrict:
+			logger.Errorf("cannot restrict port range %v on %q to %v; not opening it", portRange, machined.tag, sources)
+		default:
+			key := strings.Join(sources, ",")
+			restricted[key] = append(restricted[key], portRange)
+		}
+	}
+	for _, portRange := range toRestrict {
+		sources := machined.portSources[portRange]
+		if canRestrict {
+			key := strings.Join(sources, ",")
+			restricted[key] = append(restricted[key], portRange)
+			continue
+		}
+		// Without restrictions, a port range is only open on the
+		// instance while it may be reached from any address.
+		switch {
+		case len(sources) == 0:
+			unrestricted = append(unrestricted, portRange)
+		case len(previousSources[portRange]) == 0:
+			logger.Errorf("cannot restrict port range %v on %q to %v; closing it", portRange, machined.tag, sources)
+			toClose = append(toClose, portRange)
+		}
+	}
+
+	if len(unrestricted) > 0 {
+		if err := inst.OpenPorts(machineId, unrestricted); err != nil {
+			return err
+		}
+	}
+	if len(toClose) > 0 {
+		if err := inst.ClosePorts(machineId, toClose); err != nil {
+			return err
+		}
+	}
+	for key, ports := range restricted {
+		var sourceCIDRs []string
+		if key != "" {
+			sourceCIDRs = strings.Split(key, ",")
+		}
+		if err := setter.SetPortSources(machineId, ports, sourceCIDRs); err != nil {
+			return err
+		}
+		network.SortPortRanges(ports)
+		logger.Infof("restricted port ranges %v on %q to %v", ports, machined.tag, sourceCIDRs)
+	}
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -756,6 +974,9 @@ type machineData struct {
 	tag         names.MachineTag
 	unitds      map[names.UnitTag]*unitData
 	openedPorts []network.PortRange
+	// sources to which opened ports are restricted; ports which are
+	// open to any address are omitted
+	portSources map[network.PortRange][]string
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -764,6 +985,19 @@ func (md *machineData) machine() (*firewaller.Machine, error) {
 	return md.fw.st.Machine(md.tag)
 }
 
+// inMaintenance returns whether the machine is in maintenance mode.
+// A machine which no longer exists is not.
+func (md *machineData) inMaintenance() (bool, error) {
+	m, err := md.machine()
+	if params.IsCodeNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return m.InMaintenance()
+}
+
 // watchLoop watches the machine for units added or removed.
 func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
 	if err := md.catacomb.Add(unitw); err != nil {
@@ -805,10 +1039,12 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag, and the sources to
+// which exposure is restricted, for one specific service.
 type exposedChange struct {
-	serviced *serviceData
-	exposed  bool
+	serviced    *serviceData
+	exposed     bool
+	sourceCIDRs []string
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -817,11 +1053,15 @@ type serviceData struct {
 	fw          *Firewaller
 	application *firewaller.Application
 	exposed     bool
+	// sourceCIDRs holds the sources to which exposure is restricted;
+	// if empty, the service is exposed to any address.
+	sourceCIDRs []string
 	unitds      map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag, and the sources to
+// which exposure is restricted, for changes.
+func (sd *serviceData) watchLoop(exposed bool, sourceCIDRs []string) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -847,13 +1087,18 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			sourcesChange, err := sd.application.ExposedSourceCIDRs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if change == exposed && sameSources(sourcesChange, sourceCIDRs) {
 				continue
 			}
 
 			exposed = change
+			sourceCIDRs = sourcesChange
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, sourcesChange}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -885,6 +1130,20 @@ next:
 	return
 }
 
+// sameSources returns whether A and B hold the same source CIDRs, in
+// the same order.
+func sameSources(A, B []string) bool {
+	if len(A) != len(B) {
+		return false
+	}
+	for i := range A {
+		if A[i] != B[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // parsePortsKey parses a ports document global key coming from the ports
 // watcher (e.g. "42:0.1.2.0/24") and returns the machine and subnet tags from
 // its components (in the last example "machine-42" and "subnet-0.1.2.0/24").
@@ -89,6 +89,48 @@ func (s *firewallerBaseSuite) assertPorts(c *gc.C, inst instance.Instance, machi
 	}
 }
 
+// assertFirewallRules retrieves the firewall rules set on the environ
+// and compares them to the expected.
+func (s *firewallerBaseSuite) assertFirewallRules(c *gc.C, expected []network.FirewallRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := dummy.FirewallRules(s.Environ)
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v; got %v", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// assertPortSources retrieves the sources to which the ports of the
+// instance are restricted and compares them to the expected.
+func (s *firewallerBaseSuite) assertPortSources(c *gc.C, inst instance.Instance, expected map[network.PortRange][]string) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got := dummy.InstancePortSources(inst)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v; got %v", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
 // assertEnvironPorts retrieves the open ports of environment and compares them
 // to the expected.
 func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.PortRange) {
@@ -200,6 +242,115 @@ func (s *InstanceModeSuite) TestExposedService(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), []network.PortRange{{8080, 8080, "tcp"}})
 }
 
+func (s *InstanceModeSuite) TestExposedServiceToCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+
+	err = svc.MergeExposeSettings(map[string][]string{
+		"": {"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.PortRange{{80, 80, "tcp"}})
+	s.assertPortSources(c, inst, map[network.PortRange][]string{
+		{80, 80, "tcp"}: {"10.0.0.0/8"},
+	})
+
+	// Changing the sources restricts the open ports to them.
+	err = svc.MergeExposeSettings(map[string][]string{
+		"": {"10.0.0.0/8", "192.168.0.0/16"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPortSources(c, inst, map[network.PortRange][]string{
+		{80, 80, "tcp"}: {"10.0.0.0/8", "192.168.0.0/16"},
+	})
+
+	// Exposing to any address opens the ports to any address.
+	err = svc.MergeExposeSettings(map[string][]string{
+		"": nil,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPortSources(c, inst, map[network.PortRange][]string{})
+	s.assertPorts(c, inst, m.Id(), []network.PortRange{{80, 80, "tcp"}})
+
+	err = svc.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestExposedServiceMergesRules(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+
+	err = svc.MergeExposeSettings(map[string][]string{
+		"": {"10.0.1.0/24", "10.0.0.0/24", "10.0.0.128/25"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+
+	// Adjacent port ranges are opened as a single range, restricted
+	// to the merged sources.
+	err = u.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 91)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.PortRange{{80, 91, "tcp"}, {8080, 8080, "tcp"}})
+	s.assertPortSources(c, inst, map[network.PortRange][]string{
+		{80, 91, "tcp"}:     {"10.0.0.0/23"},
+		{8080, 8080, "tcp"}: {"10.0.0.0/23"},
+	})
+
+	// Closing part of a merged range replaces it with what remains.
+	err = u.ClosePorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.PortRange{{91, 91, "tcp"}, {8080, 8080, "tcp"}})
+}
+
+func (s *InstanceModeSuite) TestMachineInMaintenance(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	err = m.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ports are not opened while the machine is in maintenance.
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BackingState.StartSync()
+	time.Sleep(coretesting.ShortWait)
+	ports, err := inst.Ports(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 0)
+
+	// The next change after it leaves maintenance opens them all.
+	err = m.SetMaintenance(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.PortRange{{80, 80, "tcp"}, {8080, 8080, "tcp"}})
+}
+
 func (s *InstanceModeSuite) TestMultipleExposedServices(c *gc.C) {
 	fw, err := firewaller.NewFirewaller(s.firewaller)
 	c.Assert(err, jc.ErrorIsNil)
@@ -605,6 +756,28 @@ func (s *InstanceModeSuite) TestStartWithStateOpenPortsBroken(c *gc.C) {
 	}
 }
 
+func (s *InstanceModeSuite) TestFirewallRules(c *gc.C) {
+	rule := network.FirewallRule{
+		WellKnownService: network.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	}
+	err := s.State.SaveFirewallRule(rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	// Rules stored before the firewaller starts are set.
+	s.assertFirewallRules(c, []network.FirewallRule{rule})
+
+	// Changed rules are set.
+	rule.WhitelistCIDRs = []string{"192.168.0.0/16", "172.16.0.0/12"}
+	err = s.State.SaveFirewallRule(rule)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFirewallRules(c, []network.FirewallRule{rule})
+}
+
 type GlobalModeSuite struct {
 	firewallerBaseSuite
 }
@@ -670,6 +843,66 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestGlobalModeMergesRanges(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc1 := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc1.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m1 := s.addUnit(c, svc1)
+	s.startInstance(c, m1)
+	err = u1.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+
+	svc2 := s.AddTestingService(c, "moinmoin", s.charm)
+	err = svc2.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u2, m2 := s.addUnit(c, svc2)
+	s.startInstance(c, m2)
+	err = u2.OpenPorts("tcp", 85, 100)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Overlapping ranges opened on different machines are merged.
+	s.assertEnvironPorts(c, []network.PortRange{{80, 100, "tcp"}})
+
+	// Closing one of them leaves the other open.
+	err = u1.ClosePorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironPorts(c, []network.PortRange{{85, 100, "tcp"}})
+
+	err = u2.ClosePorts("tcp", 85, 100)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironPorts(c, nil)
+}
+
+func (s *GlobalModeSuite) TestGlobalModeLeavesRestrictedPortsClosed(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.MergeExposeSettings(map[string][]string{
+		"": {"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Global ports cannot be restricted, so they are not opened.
+	s.assertEnvironPorts(c, nil)
+
+	// Exposing to any address opens them.
+	err = svc.MergeExposeSettings(map[string][]string{
+		"": nil,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironPorts(c, []network.PortRange{{80, 80, "tcp"}})
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedService(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -5,8 +5,10 @@ package hostkeyreporter
 
 import (
 	"runtime"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
@@ -21,6 +23,8 @@ type ManifoldConfig struct {
 	AgentName     string
 	APICallerName string
 	RootDir       string
+	Clock         clock.Clock
+	PollInterval  time.Duration
 
 	NewFacade func(base.APICaller) (Facade, error)
 	NewWorker func(Config) (worker.Worker, error)
@@ -73,9 +77,11 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	}
 
 	worker, err := config.NewWorker(Config{
-		Facade:    facade,
-		MachineId: tag.Id(),
-		RootDir:   config.RootDir,
+		Facade:       facade,
+		MachineId:    tag.Id(),
+		RootDir:      config.RootDir,
+		Clock:        config.Clock,
+		PollInterval: config.PollInterval,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
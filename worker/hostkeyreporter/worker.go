@@ -7,9 +7,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/worker"
@@ -28,6 +30,15 @@ type Config struct {
 	Facade    Facade
 	MachineId string
 	RootDir   string
+
+	// Clock is used to schedule checks for changed host keys. It
+	// is only required when PollInterval is non-zero.
+	Clock clock.Clock
+
+	// PollInterval defines how often the SSH host keys are checked
+	// for changes after the initial report. If zero, the keys are
+	// reported once and the worker uninstalls itself.
+	PollInterval time.Duration
 }
 
 // Validate returns an error if Config cannot drive a hostkeyreporter.
@@ -38,6 +49,12 @@ func (config Config) Validate() error {
 	if config.MachineId == "" {
 		return errors.NotValidf("empty MachineId")
 	}
+	if config.PollInterval < 0 {
+		return errors.NotValidf("negative PollInterval")
+	}
+	if config.PollInterval > 0 && config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	return nil
 }
 
@@ -54,8 +71,9 @@ func New(config Config) (worker.Worker, error) {
 	return w, nil
 }
 
-// Worker waits for a model migration to be active, then locks down the
-// configured fortress and implements the migration.
+// hostkeyreporter reports the SSH host keys of a machine to the
+// controller, and optionally keeps reporting them whenever they
+// change.
 type hostkeyreporter struct {
 	tomb   tomb.Tomb
 	config Config
@@ -76,15 +94,59 @@ func (w *hostkeyreporter) run() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := w.reportKeys(keys); err != nil {
+		return errors.Trace(err)
+	}
+	if w.config.PollInterval == 0 {
+		return dependency.ErrUninstall
+	}
+
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(w.config.PollInterval):
+		}
+		newKeys, err := w.readSSHKeys()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if sameKeys(keys, newKeys) {
+			continue
+		}
+		logger.Infof("SSH host keys changed for machine %s", w.config.MachineId)
+		if err := w.reportKeys(newKeys); err != nil {
+			return errors.Trace(err)
+		}
+		keys = newKeys
+	}
+}
+
+func (w *hostkeyreporter) reportKeys(keys []string) error {
 	if len(keys) < 1 {
 		return errors.New("no SSH host keys found")
 	}
-	err = w.config.Facade.ReportKeys(w.config.MachineId, keys)
+	err := w.config.Facade.ReportKeys(w.config.MachineId, keys)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("%d SSH host keys reported for machine %s", len(keys), w.config.MachineId)
-	return dependency.ErrUninstall
+	return nil
+}
+
+// sameKeys returns true if both slices hold the same keys in the same
+// order. The keys are always read in a stable (sorted by filename)
+// order so this is sufficient to detect changes.
+func sameKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (w *hostkeyreporter) readSSHKeys() ([]string, error) {
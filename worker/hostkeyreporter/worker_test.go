@@ -8,12 +8,14 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/workertest"
@@ -23,6 +25,7 @@ type Suite struct {
 	jujutesting.IsolationSuite
 
 	dir    string
+	sshDir string
 	stub   *jujutesting.Stub
 	facade *stubFacade
 	config hostkeyreporter.Config
@@ -35,18 +38,12 @@ func (s *Suite) SetUpTest(c *gc.C) {
 
 	// Generate some dummy key files
 	s.dir = c.MkDir()
-	sshDir := filepath.Join(s.dir, "etc", "ssh")
-	err := os.MkdirAll(sshDir, 0755)
+	s.sshDir = filepath.Join(s.dir, "etc", "ssh")
+	err := os.MkdirAll(s.sshDir, 0755)
 	c.Assert(err, jc.ErrorIsNil)
-	writeKey := func(keyType string) {
-		baseName := fmt.Sprintf("ssh_host_%s_key.pub", keyType)
-		fileName := filepath.Join(sshDir, baseName)
-		err := ioutil.WriteFile(fileName, []byte(keyType), 0644)
-		c.Assert(err, jc.ErrorIsNil)
-	}
-	writeKey("dsa")
-	writeKey("rsa")
-	writeKey("ecdsa")
+	s.writeKey(c, "dsa", "dsa")
+	s.writeKey(c, "rsa", "rsa")
+	s.writeKey(c, "ecdsa", "ecdsa")
 
 	s.stub = new(jujutesting.Stub)
 	s.facade = newStubFacade(s.stub)
@@ -57,6 +54,13 @@ func (s *Suite) SetUpTest(c *gc.C) {
 	}
 }
 
+func (s *Suite) writeKey(c *gc.C, keyType, content string) {
+	baseName := fmt.Sprintf("ssh_host_%s_key.pub", keyType)
+	fileName := filepath.Join(s.sshDir, baseName)
+	err := ioutil.WriteFile(fileName, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *Suite) TestInvalidConfig(c *gc.C) {
 	s.config.MachineId = ""
 	_, err := hostkeyreporter.New(s.config)
@@ -104,6 +108,63 @@ func (s *Suite) TestSuccess(c *gc.C) {
 	}})
 }
 
+func (s *Suite) TestInvalidPollingConfig(c *gc.C) {
+	s.config.PollInterval = time.Minute
+	_, err := hostkeyreporter.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestPollingReportsChangedKeys(c *gc.C) {
+	clock := coretesting.NewClock(time.Now())
+	s.config.Clock = clock
+	s.config.PollInterval = time.Minute
+	w, err := hostkeyreporter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	// No change: nothing further is reported.
+	s.waitAlarm(c, clock)
+	clock.Advance(time.Minute)
+
+	// Regenerated key: the full set is reported again.
+	s.waitAlarm(c, clock)
+	s.writeKey(c, "rsa", "rsa2")
+	clock.Advance(time.Minute)
+	s.waitAlarm(c, clock)
+
+	workertest.CleanKill(c, w)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"ReportKeys", []interface{}{"42", []string{"dsa", "ecdsa", "rsa"}},
+	}, {
+		"ReportKeys", []interface{}{"42", []string{"dsa", "ecdsa", "rsa2"}},
+	}})
+}
+
+func (s *Suite) TestPollingReportKeysError(c *gc.C) {
+	clock := coretesting.NewClock(time.Now())
+	s.config.Clock = clock
+	s.config.PollInterval = time.Minute
+	w, err := hostkeyreporter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.waitAlarm(c, clock)
+	s.facade.reportErr = errors.New("blam")
+	s.writeKey(c, "rsa", "rsa2")
+	clock.Advance(time.Minute)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "blam")
+}
+
+func (s *Suite) waitAlarm(c *gc.C, clock *coretesting.Clock) {
+	select {
+	case <-clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for poll")
+	}
+}
+
 func newStubFacade(stub *jujutesting.Stub) *stubFacade {
 	return &stubFacade{
 		stub: stub,
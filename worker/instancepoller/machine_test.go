@@ -59,6 +59,32 @@ func (s *machineSuite) TestSetsInstanceInfoInitially(c *gc.C) {
 	c.Assert(m.instStatusInfo, gc.Equals, "running")
 }
 
+func (s *machineSuite) TestMachineInMaintenanceNotPolled(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: instanceInfoGetter(c, "i1234", testAddrs, "running", nil),
+		dyingc:          make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:         names.NewMachineTag("99"),
+		instanceId:  "i1234",
+		refresh:     func() error { return nil },
+		life:        params.Alive,
+		maintenance: true,
+	}
+	died := make(chan machine)
+	s.PatchValue(&ShortPoll, coretesting.ShortWait/10)
+	s.PatchValue(&LongPoll, coretesting.ShortWait/10)
+
+	go runMachine(context, m, nil, died)
+	time.Sleep(coretesting.ShortWait)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	c.Assert(m.addresses, gc.HasLen, 0)
+	c.Assert(m.setAddressCount, gc.Equals, 0)
+	c.Assert(m.instStatusInfo, gc.Equals, "")
+}
+
 func (s *machineSuite) TestShortPollIntervalWhenNoAddress(c *gc.C) {
 	s.PatchValue(&ShortPoll, 1*time.Millisecond)
 	s.PatchValue(&LongPoll, coretesting.LongWait)
@@ -305,6 +331,7 @@ type testMachine struct {
 	status          status.Status
 	refresh         func() error
 	setAddressesErr error
+	maintenance     bool
 	// mu protects the following fields.
 	mu              sync.Mutex
 	life            params.Life
@@ -351,6 +378,10 @@ func (m *testMachine) IsManual() (bool, error) {
 	return strings.HasPrefix(string(m.instanceId), "manual:"), nil
 }
 
+func (m *testMachine) InMaintenance() (bool, error) {
+	return m.maintenance, nil
+}
+
 func (m *testMachine) InstanceStatus() (params.StatusResult, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -46,6 +46,7 @@ type machine interface {
 	Life() params.Life
 	Status() (params.StatusResult, error)
 	IsManual() (bool, error)
+	InMaintenance() (bool, error)
 }
 
 type instanceInfo struct {
@@ -176,6 +177,18 @@ func machineLoop(context machineContext, m machine, changed <-chan struct{}) err
 	pollInterval := ShortPoll
 	pollInstance := true
 	for {
+		if pollInstance {
+			// The instance of a machine in maintenance mode is not
+			// polled; it is checked again at the next interval.
+			inMaintenance, err := m.InMaintenance()
+			if err != nil {
+				return err
+			}
+			if inMaintenance {
+				logger.Debugf("machine %q is in maintenance mode; not polling its instance", m.Id())
+				pollInstance = false
+			}
+		}
 		if pollInstance {
 			instInfo, err := pollInstanceInfo(context, m)
 			if err != nil && !params.IsCodeNotProvisioned(err) {
@@ -9,4 +9,13 @@
 //   - prints out all the goroutines in the agent
 // * `/debug/pprof/heap?debug=1`
 //   - prints out the heap profile
+// * `/debug/integrity` (controllers only)
+//   - reports dangling references between documents in state; a POST
+//     also repairs those that can safely be removed
+// * `/debug/mongo-sessions` (controllers only)
+//   - reports how many mongo sessions state has copied, closed and
+//     used for reads from secondaries
+// * `/debug/slow-operations` (controllers only)
+//   - lists the most recent mongo queries and transactions which took
+//     longer than the controller's slow-operation-threshold
 package introspection
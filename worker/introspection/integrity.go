@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/juju/juju/state"
+)
+
+// IntegrityChecker checks the referential integrity of a model's
+// state, optionally repairing the problems it finds. It is implemented
+// by *state.State.
+type IntegrityChecker interface {
+	CheckIntegrity(repair bool) ([]state.IntegrityProblem, error)
+}
+
+// integrityHandler reports the dangling references found in state by
+// its checker, one per line. GET requests only report the problems;
+// POST requests also repair those that can be repaired.
+type integrityHandler struct {
+	checker IntegrityChecker
+}
+
+// ServeHTTP implements http.Handler.
+func (h integrityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.checker == nil {
+		http.Error(w, "integrity checks are only available on controllers", http.StatusNotFound)
+		return
+	}
+	var repair bool
+	switch r.Method {
+	case "GET":
+	case "POST":
+		repair = true
+	default:
+		http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	problems, err := h.checker.CheckIntegrity(repair)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "integrity problems: %d\n", len(problems))
+	for _, problem := range problems {
+		var outcome string
+		switch {
+		case problem.Repaired:
+			outcome = " (repaired)"
+		case !problem.Repairable:
+			outcome = " (not repairable)"
+		}
+		fmt.Fprintf(w, "%s %s: %s%s\n", problem.Collection, problem.Id, problem.Description, outcome)
+	}
+}
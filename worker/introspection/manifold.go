@@ -10,8 +10,10 @@ import (
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
+	workerstate "github.com/juju/juju/worker/state"
 )
 
 var logger = loggo.GetLogger("juju.worker.introspection")
@@ -21,12 +23,22 @@ var logger = loggo.GetLogger("juju.worker.introspection")
 type ManifoldConfig struct {
 	AgentName  string
 	WorkerFunc func(Config) (worker.Worker, error)
+
+	// StateName, if set, names the manifold whose State is used to
+	// check referential integrity, and whose presence enables mongo
+	// session stats. The worker runs without either if that manifold
+	// is not running, as on non-controllers.
+	StateName string
 }
 
 // Manifold returns a Manifold which encapsulates the introspection worker.
 func Manifold(config ManifoldConfig) dependency.Manifold {
+	inputs := []string{config.AgentName}
+	if config.StateName != "" {
+		inputs = append(inputs, config.StateName)
+	}
 	return dependency.Manifold{
-		Inputs: []string{config.AgentName},
+		Inputs: inputs,
 		Start: func(context dependency.Context) (worker.Worker, error) {
 			// Since the worker listens on an abstract domain socket, this
 			// is only available on linux.
@@ -40,13 +52,49 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, errors.Trace(err)
 			}
 
+			var stTracker workerstate.StateTracker
+			if config.StateName != "" {
+				err := context.Get(config.StateName, &stTracker)
+				if err == dependency.ErrMissing {
+					stTracker = nil
+				} else if err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+			var checker IntegrityChecker
+			var sessionStats func() state.SessionStats
+			var slowOperations func() []state.SlowOperation
+			if stTracker != nil {
+				st, err := stTracker.Use()
+				if err != nil {
+					return nil, errors.Annotate(err, "acquiring state")
+				}
+				checker = st
+				sessionStats = state.MongoSessionStats
+				slowOperations = state.SlowOperations
+			}
+
 			socketName := "jujud-" + a.CurrentConfig().Tag().String()
 			w, err := config.WorkerFunc(Config{
-				SocketName: socketName,
+				SocketName:        socketName,
+				IntegrityChecker:  checker,
+				MongoSessionStats: sessionStats,
+				SlowOperations:    slowOperations,
 			})
 			if err != nil {
+				if stTracker != nil {
+					stTracker.Done()
+				}
 				return nil, errors.Trace(err)
 			}
+			if stTracker != nil {
+				// When the worker is done, indicate that we no
+				// longer need the State.
+				go func() {
+					w.Wait()
+					stTracker.Done()
+				}()
+			}
 			return w, nil
 		},
 	}
@@ -5,6 +5,7 @@ package introspection_test
 
 import (
 	"runtime"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -14,10 +15,14 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
 	dt "github.com/juju/juju/worker/dependency/testing"
 	"github.com/juju/juju/worker/introspection"
+	workerstate "github.com/juju/juju/worker/state"
+	"github.com/juju/juju/worker/workertest"
 )
 
 type ManifoldSuite struct {
@@ -105,6 +110,86 @@ func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
 	c.Assert(dummy.config.SocketName, gc.Equals, "jujud-machine-42")
 }
 
+func (s *ManifoldSuite) TestInputsWithState(c *gc.C) {
+	manifold := introspection.Manifold(introspection.ManifoldConfig{
+		AgentName: "agent-name",
+		StateName: "state-name",
+	})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"agent-name", "state-name"})
+}
+
+func (s *ManifoldSuite) TestStartStateMissing(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("introspection worker not supported on non-linux")
+	}
+
+	var config introspection.Config
+	manifold := introspection.Manifold(introspection.ManifoldConfig{
+		AgentName: "agent-name",
+		StateName: "state-name",
+		WorkerFunc: func(cfg introspection.Config) (worker.Worker, error) {
+			config = cfg
+			return workertest.NewDeadWorker(nil), nil
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent-name": &dummyAgent{},
+		"state-name": dependency.ErrMissing,
+	})
+
+	_, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(config.SocketName, gc.Equals, "jujud-machine-42")
+	c.Check(config.IntegrityChecker, gc.IsNil)
+	c.Check(config.MongoSessionStats, gc.IsNil)
+	c.Check(config.SlowOperations, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartWithState(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("introspection worker not supported on non-linux")
+	}
+
+	var config introspection.Config
+	manifold := introspection.Manifold(introspection.ManifoldConfig{
+		AgentName: "agent-name",
+		StateName: "state-name",
+		WorkerFunc: func(cfg introspection.Config) (worker.Worker, error) {
+			config = cfg
+			return workertest.NewDeadWorker(nil), nil
+		},
+	})
+	tracker := &fakeStateTracker{done: make(chan struct{})}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent-name": &dummyAgent{},
+		"state-name": workerstate.StateTracker(tracker),
+	})
+
+	_, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(config.IntegrityChecker, gc.NotNil)
+	c.Check(config.MongoSessionStats, gc.NotNil)
+	c.Check(config.SlowOperations, gc.NotNil)
+	select {
+	case <-tracker.done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("state not released")
+	}
+}
+
+type fakeStateTracker struct {
+	done chan struct{}
+}
+
+func (t *fakeStateTracker) Use() (*state.State, error) {
+	return &state.State{}, nil
+}
+
+func (t *fakeStateTracker) Done() error {
+	close(t.done)
+	return nil
+}
+
 type dummyAgent struct {
 	agent.Agent
 }
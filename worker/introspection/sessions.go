@@ -0,0 +1,31 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/juju/juju/state"
+)
+
+// sessionsHandler reports the mongo session usage of the agent's
+// States, as returned by its stats func.
+type sessionsHandler struct {
+	stats func() state.SessionStats
+}
+
+// ServeHTTP implements http.Handler.
+func (h sessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.stats == nil {
+		http.Error(w, "mongo session stats are only available on controllers", http.StatusNotFound)
+		return
+	}
+	stats := h.stats()
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "copied: %d\n", stats.Copied)
+	fmt.Fprintf(w, "closed: %d\n", stats.Closed)
+	fmt.Fprintf(w, "open: %d\n", stats.Open())
+	fmt.Fprintf(w, "secondary-reads: %d\n", stats.SecondaryReads)
+}
@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/juju/juju/state"
+)
+
+// slowOperationsHandler reports the most recent slow mongo queries and
+// transactions of the agent's States, as returned by its operations
+// func.
+type slowOperationsHandler struct {
+	operations func() []state.SlowOperation
+}
+
+// ServeHTTP implements http.Handler.
+func (h slowOperationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.operations == nil {
+		http.Error(w, "slow operations are only available on controllers", http.StatusNotFound)
+		return
+	}
+	ops := h.operations()
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "slow operations: %d\n", len(ops))
+	for _, op := range ops {
+		fmt.Fprintf(w, "%s %v %s %s\n",
+			op.Started.UTC().Format(time.RFC3339), op.Duration, op.Kind, op.Description,
+		)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/tomb.v1"
 
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/introspection/pprof"
 )
@@ -18,6 +19,15 @@ import (
 // Config describes the arguments required to create the introspection worker.
 type Config struct {
 	SocketName string
+
+	// IntegrityChecker, if set, is used to serve /debug/integrity.
+	IntegrityChecker IntegrityChecker
+
+	// MongoSessionStats, if set, is used to serve /debug/mongo-sessions.
+	MongoSessionStats func() state.SessionStats
+
+	// SlowOperations, if set, is used to serve /debug/slow-operations.
+	SlowOperations func() []state.SlowOperation
 }
 
 // Validate checks the config values to assert they are valid to create the worker.
@@ -32,6 +42,9 @@ func (c *Config) Validate() error {
 type socketListener struct {
 	tomb     tomb.Tomb
 	listener *net.UnixListener
+	checker  IntegrityChecker
+	sessions func() state.SessionStats
+	slowOps  func() []state.SlowOperation
 }
 
 // NewWorker starts an http server listening on an abstract domain socket
@@ -58,6 +71,9 @@ func NewWorker(config Config) (worker.Worker, error) {
 
 	w := &socketListener{
 		listener: l,
+		checker:  config.IntegrityChecker,
+		sessions: config.MongoSessionStats,
+		slowOps:  config.SlowOperations,
 	}
 	go w.serve()
 	go w.run()
@@ -70,6 +86,9 @@ func (w *socketListener) serve() {
 	mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
+	mux.Handle("/debug/integrity", integrityHandler{w.checker})
+	mux.Handle("/debug/mongo-sessions", sessionsHandler{w.sessions})
+	mux.Handle("/debug/slow-operations", slowOperationsHandler{w.slowOps})
 
 	srv := http.Server{
 		Handler: mux,
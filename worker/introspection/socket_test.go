@@ -11,11 +11,13 @@ import (
 	"net"
 	"regexp"
 	"runtime"
+	"time"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/workertest"
@@ -73,12 +75,18 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *introspectionSuite) call(c *gc.C, url string) []byte {
-	path := "@" + s.name
+	return request(c, s.name, "GET", url)
+}
+
+// request makes an HTTP request with the given method to the
+// introspection worker listening on the named socket.
+func request(c *gc.C, socketName, method, url string) []byte {
+	path := "@" + socketName
 	conn, err := net.Dial("unix", path)
 	c.Assert(err, jc.ErrorIsNil)
 	defer conn.Close()
 
-	_, err = fmt.Fprintf(conn, "GET %s HTTP/1.0\r\n\r\n", url)
+	_, err = fmt.Fprintf(conn, "%s %s HTTP/1.0\r\n\r\n", method, url)
 	c.Assert(err, jc.ErrorIsNil)
 
 	buf, err := ioutil.ReadAll(conn)
@@ -98,6 +106,162 @@ func (s *introspectionSuite) TestGoroutineProfile(c *gc.C) {
 	matches(c, buf, `^goroutine profile: total \d+`)
 }
 
+func (s *introspectionSuite) TestIntegrityUnavailable(c *gc.C) {
+	buf := s.call(c, "/debug/integrity")
+	matches(c, buf, `^HTTP/1.0 404 Not Found`)
+	matches(c, buf, `^integrity checks are only available on controllers$`)
+}
+
+func (s *introspectionSuite) TestMongoSessionsUnavailable(c *gc.C) {
+	buf := s.call(c, "/debug/mongo-sessions")
+	matches(c, buf, `^HTTP/1.0 404 Not Found`)
+	matches(c, buf, `^mongo session stats are only available on controllers$`)
+}
+
+func (s *introspectionSuite) TestSlowOperationsUnavailable(c *gc.C) {
+	buf := s.call(c, "/debug/slow-operations")
+	matches(c, buf, `^HTTP/1.0 404 Not Found`)
+	matches(c, buf, `^slow operations are only available on controllers$`)
+}
+
+type mongoSessionsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&mongoSessionsSuite{})
+
+func (s *mongoSessionsSuite) TestStats(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("introspection worker not supported on non-linux")
+	}
+	name := "introspection-mongo-sessions-test"
+	w, err := introspection.NewWorker(introspection.Config{
+		SocketName: name,
+		MongoSessionStats: func() state.SessionStats {
+			return state.SessionStats{
+				Copied:         10,
+				Closed:         7,
+				SecondaryReads: 2,
+			}
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	buf := request(c, name, "GET", "/debug/mongo-sessions")
+	matches(c, buf, `^copied: 10$`)
+	matches(c, buf, `^closed: 7$`)
+	matches(c, buf, `^open: 3$`)
+	matches(c, buf, `^secondary-reads: 2$`)
+}
+
+type slowOperationsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&slowOperationsSuite{})
+
+func (s *slowOperationsSuite) TestSlowOperations(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("introspection worker not supported on non-linux")
+	}
+	name := "introspection-slow-operations-test"
+	started := time.Date(2016, 10, 3, 12, 30, 0, 0, time.UTC)
+	w, err := introspection.NewWorker(introspection.Config{
+		SocketName: name,
+		SlowOperations: func() []state.SlowOperation {
+			return []state.SlowOperation{{
+				Kind:        "query",
+				Description: "units All",
+				Started:     started,
+				Duration:    1500 * time.Millisecond,
+			}, {
+				Kind:        "txn",
+				Description: "applications, units (4 ops, 2 attempts)",
+				Started:     started.Add(time.Minute),
+				Duration:    3 * time.Second,
+			}}
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	buf := request(c, name, "GET", "/debug/slow-operations")
+	matches(c, buf, `^slow operations: 2$`)
+	matches(c, buf, `^2016-10-03T12:30:00Z 1.5s query units All$`)
+	matches(c, buf, `^2016-10-03T12:31:00Z 3s txn applications, units \(4 ops, 2 attempts\)$`)
+}
+
+type integritySuite struct {
+	testing.IsolationSuite
+
+	name    string
+	checker *fakeIntegrityChecker
+}
+
+var _ = gc.Suite(&integritySuite{})
+
+func (s *integritySuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("introspection worker not supported on non-linux")
+	}
+
+	s.IsolationSuite.SetUpTest(c)
+
+	s.name = "introspection-integrity-test"
+	s.checker = &fakeIntegrityChecker{
+		problems: []state.IntegrityProblem{{
+			Collection:  "units",
+			Id:          "mysql/0",
+			Description: `unit "mysql/0" is assigned to missing machine "1"`,
+			Repairable:  true,
+		}, {
+			Collection:  "units",
+			Id:          "mysql/1",
+			Description: `unit "mysql/1" belongs to missing application "mysql"`,
+		}},
+	}
+	w, err := introspection.NewWorker(introspection.Config{
+		SocketName:       s.name,
+		IntegrityChecker: s.checker,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		workertest.CheckKill(c, w)
+	})
+}
+
+func (s *integritySuite) TestCheck(c *gc.C) {
+	buf := request(c, s.name, "GET", "/debug/integrity")
+	c.Assert(s.checker.repair, jc.IsFalse)
+	matches(c, buf, `^integrity problems: 2$`)
+	matches(c, buf, `^units mysql/0: unit "mysql/0" is assigned to missing machine "1"$`)
+	matches(c, buf, `^units mysql/1: unit "mysql/1" belongs to missing application "mysql" \(not repairable\)$`)
+}
+
+func (s *integritySuite) TestRepair(c *gc.C) {
+	buf := request(c, s.name, "POST", "/debug/integrity")
+	c.Assert(s.checker.repair, jc.IsTrue)
+	matches(c, buf, `^units mysql/0: unit "mysql/0" is assigned to missing machine "1" \(repaired\)$`)
+}
+
+type fakeIntegrityChecker struct {
+	problems []state.IntegrityProblem
+	repair   bool
+}
+
+func (f *fakeIntegrityChecker) CheckIntegrity(repair bool) ([]state.IntegrityProblem, error) {
+	f.repair = repair
+	problems := make([]state.IntegrityProblem, len(f.problems))
+	copy(problems, f.problems)
+	if repair {
+		for i := range problems {
+			problems[i].Repaired = problems[i].Repairable
+		}
+	}
+	return problems, nil
+}
+
 // matches fails if regex is not found in the contents of b.
 // b is expected to be the response from the pprof http server, and will
 // contain some HTTP preamble that should be ignored.
@@ -4,11 +4,14 @@
 package logger
 
 import (
+	"strconv"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/logger"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 )
@@ -16,19 +19,23 @@ import (
 var log = loggo.GetLogger("juju.worker.logger")
 
 // Logger is responsible for updating the loggo configuration when the
-// environment watcher tells the agent that the value has changed.
+// environment watcher tells the agent that the value has changed. It
+// also records the agent's log rotation settings in its config, from
+// which they are read when the agent starts.
 type Logger struct {
 	api         *logger.State
+	agent       agent.Agent
 	agentConfig agent.Config
 	lastConfig  string
 }
 
 // NewLogger returns a worker.Worker that uses the notify watcher returned
 // from the setup.
-func NewLogger(api *logger.State, agentConfig agent.Config) (worker.Worker, error) {
+func NewLogger(api *logger.State, a agent.Agent) (worker.Worker, error) {
 	logger := &Logger{
 		api:         api,
-		agentConfig: agentConfig,
+		agent:       a,
+		agentConfig: a.CurrentConfig(),
 		lastConfig:  loggo.LoggerInfo(),
 	}
 	log.Debugf("initial log config: %q", logger.lastConfig)
@@ -61,17 +68,68 @@ func (logger *Logger) setLogging() {
 	}
 }
 
+// setLogRotation records the agent's log rotation settings in its
+// config. Changes take effect when the agent next starts.
+func (logger *Logger) setLogRotation() error {
+	maxSize, maxBackups, err := logger.api.LogRotation(logger.agentConfig.Tag())
+	if params.IsCodeNotImplemented(err) {
+		// The controller does not support log rotation settings.
+		return nil
+	} else if err != nil {
+		log.Errorf("%v", err)
+		return nil
+	}
+	values := map[string]string{
+		agent.LogMaxSize:    rotationValue(maxSize),
+		agent.LogMaxBackups: rotationValue(maxBackups),
+	}
+	currentConfig := logger.agent.CurrentConfig()
+	changed := false
+	for key, value := range values {
+		if currentConfig.Value(key) != value {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	err = logger.agent.ChangeConfig(func(setter agent.ConfigSetter) error {
+		for key, value := range values {
+			setter.SetValue(key, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot record log rotation settings")
+	}
+	log.Infof("log rotation settings changed (max size %q, max backups %q); they will apply when the agent restarts",
+		values[agent.LogMaxSize], values[agent.LogMaxBackups])
+	return nil
+}
+
+// rotationValue returns the agent config value for the given log
+// rotation setting. Zero, meaning the default, is recorded as no value.
+func rotationValue(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
 func (logger *Logger) SetUp() (watcher.NotifyWatcher, error) {
 	log.Debugf("logger setup")
 	// We need to set this up initially as the NotifyWorker sucks up the first
 	// event.
 	logger.setLogging()
+	if err := logger.setLogRotation(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return logger.api.WatchLoggingConfig(logger.agentConfig.Tag())
 }
 
 func (logger *Logger) Handle(_ <-chan struct{}) error {
 	logger.setLogging()
-	return nil
+	return logger.setLogRotation()
 }
 
 func (logger *Logger) TearDown() error {
@@ -4,6 +4,7 @@
 package logger_test
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/loggo"
@@ -61,22 +62,53 @@ func (s *LoggerSuite) waitLoggingInfo(c *gc.C, expected string) {
 }
 
 type mockConfig struct {
-	agent.Config
-	c   *gc.C
-	tag names.Tag
+	agent.ConfigSetter
+	c      *gc.C
+	tag    names.Tag
+	mu     sync.Mutex
+	values map[string]string
 }
 
 func (mock *mockConfig) Tag() names.Tag {
 	return mock.tag
 }
 
+func (mock *mockConfig) Value(key string) string {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return mock.values[key]
+}
+
+func (mock *mockConfig) SetValue(key, value string) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if value == "" {
+		delete(mock.values, key)
+	} else {
+		mock.values[key] = value
+	}
+}
+
 func agentConfig(c *gc.C, tag names.Tag) *mockConfig {
-	return &mockConfig{c: c, tag: tag}
+	return &mockConfig{c: c, tag: tag, values: make(map[string]string)}
+}
+
+type mockAgent struct {
+	agent.Agent
+	config *mockConfig
+}
+
+func (mock *mockAgent) CurrentConfig() agent.Config {
+	return mock.config
+}
+
+func (mock *mockAgent) ChangeConfig(mutate agent.ConfigMutator) error {
+	return mutate(mock.config)
 }
 
 func (s *LoggerSuite) makeLogger(c *gc.C) (worker.Worker, *mockConfig) {
 	config := agentConfig(c, s.machine.Tag())
-	w, err := logger.NewLogger(s.loggerApi, config)
+	w, err := logger.NewLogger(s.loggerApi, &mockAgent{config: config})
 	c.Assert(err, jc.ErrorIsNil)
 	return w, config
 }
@@ -103,3 +135,50 @@ func (s *LoggerSuite) TestInitialState(c *gc.C) {
 
 	s.waitLoggingInfo(c, expected)
 }
+
+func (s *LoggerSuite) waitLogRotation(c *gc.C, config *mockConfig, maxSize, maxBackups int) {
+	timeout := time.After(worstCase)
+	for {
+		select {
+		case <-timeout:
+			c.Fatalf("timeout while waiting for log rotation settings to change")
+		case <-time.After(10 * time.Millisecond):
+			s.BackingState.StartSync()
+			gotSize, gotBackups := agent.LogRotation(config)
+			if gotSize != maxSize || gotBackups != maxBackups {
+				c.Logf("log rotation is %d/%d, still waiting", gotSize, gotBackups)
+				continue
+			}
+			return
+		}
+	}
+}
+
+func (s *LoggerSuite) TestLogRotation(c *gc.C) {
+	loggingWorker, config := s.makeLogger(c)
+	defer worker.Stop(loggingWorker)
+	s.waitLogRotation(c, config, agent.DefaultLogMaxSize, agent.DefaultLogMaxBackups)
+
+	err := s.State.SetAgentLogging(s.machine.Tag(), state.AgentLogging{MaxLogSize: 100, MaxLogBackups: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitLogRotation(c, config, 100, 5)
+	c.Assert(config.Value(agent.LogMaxSize), gc.Equals, "100")
+
+	err = s.State.SetAgentLogging(s.machine.Tag(), state.AgentLogging{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitLogRotation(c, config, agent.DefaultLogMaxSize, agent.DefaultLogMaxBackups)
+	c.Assert(config.Value(agent.LogMaxSize), gc.Equals, "")
+}
+
+func (s *LoggerSuite) TestAgentLoggingConfig(c *gc.C) {
+	config, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	loggingWorker, _ := s.makeLogger(c)
+	defer worker.Stop(loggingWorker)
+	s.waitLoggingInfo(c, config.LoggingConfig())
+
+	err = s.State.SetAgentLogging(s.machine.Tag(), state.AgentLogging{LoggingConfig: "wibble=TRACE"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitLoggingInfo(c, config.LoggingConfig()+";wibble=TRACE")
+}
@@ -25,7 +25,6 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 
 // newWorker trivially wraps NewLogger to specialise a engine.AgentApiManifold.
 var newWorker = func(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
-	currentConfig := a.CurrentConfig()
 	loggerFacade := logger.NewState(apiCaller)
-	return NewLogger(loggerFacade, currentConfig)
+	return NewLogger(loggerFacade, a)
 }
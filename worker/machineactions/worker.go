@@ -8,6 +8,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/juju/api/machineactions"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/correlation"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	"github.com/juju/loggo"
@@ -103,16 +104,19 @@ func (h *handler) Handle(_ <-chan struct{}, actionsSlice []string) error {
 			return errors.Annotatef(err, "could not retrieve action %s", actionId)
 		}
 
+		logger := correlation.NewLogger(logger, action.CorrelationId())
 		err = h.config.Facade.ActionBegin(actionTag)
 		if err != nil {
 			return errors.Annotatef(err, "could not begin action %s", action.Name())
 		}
+		logger.Infof("running action %q (%s)", action.Name(), actionId)
 
 		// We try to handle the action. The result returned from handling the action is
 		// sent through using ActionFinish. We only stop the loop if ActionFinish fails.
 		var finishErr error
 		results, err := h.config.HandleAction(action.Name(), action.Params())
 		if err != nil {
+			logger.Infof("action %q (%s) failed: %v", action.Name(), actionId, err)
 			finishErr = h.config.Facade.ActionFinish(actionTag, params.ActionFailed, nil, err.Error())
 		} else {
 			finishErr = h.config.Facade.ActionFinish(actionTag, params.ActionCompleted, results, "")
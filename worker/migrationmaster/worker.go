@@ -65,6 +65,14 @@ type Facade interface {
 	// progress of a migration.
 	SetStatusMessage(string) error
 
+	// SetArtifactsRemaining records the number of charms and tools
+	// still to be transferred to the target controller.
+	SetArtifactsRemaining(int) error
+
+	// ModelUsage returns the number of machines and units in the
+	// model associated with the API connection.
+	ModelUsage() (coremigration.ModelUsage, error)
+
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() (coremigration.SerializedModel, error)
@@ -188,7 +196,7 @@ func (w *Worker) run() error {
 		case coremigration.QUIESCE:
 			phase, err = w.doQUIESCE(status)
 		case coremigration.PRECHECK:
-			phase, err = w.doPRECHECK()
+			phase, err = w.doPRECHECK(status.TargetInfo)
 		case coremigration.IMPORT:
 			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
 		case coremigration.VALIDATION:
@@ -278,12 +286,39 @@ func (w *Worker) doQUIESCE(status coremigration.MigrationStatus) (coremigration.
 	return coremigration.PRECHECK, nil
 }
 
-func (w *Worker) doPRECHECK() (coremigration.Phase, error) {
-	// TODO(mjs) - To be implemented.
-	// w.setInfoStatus("performing prechecks")
+func (w *Worker) doPRECHECK(targetInfo coremigration.TargetInfo) (coremigration.Phase, error) {
+	w.setInfoStatus("performing prechecks")
+	shortfalls, err := w.targetPrechecks(targetInfo)
+	if err != nil {
+		w.setErrorStatus("target controller prechecks failed, %v", err)
+		return coremigration.ABORT, nil
+	}
+	if len(shortfalls) > 0 {
+		reasons := make([]string, len(shortfalls))
+		for i, shortfall := range shortfalls {
+			reasons[i] = shortfall.String()
+		}
+		w.setErrorStatus("target controller lacks capacity: %s", strings.Join(reasons, "; "))
+		return coremigration.ABORT, nil
+	}
 	return coremigration.IMPORT, nil
 }
 
+func (w *Worker) targetPrechecks(targetInfo coremigration.TargetInfo) ([]coremigration.CapacityShortfall, error) {
+	usage, err := w.config.Facade.ModelUsage()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving model usage")
+	}
+	conn, err := w.openAPIConn(targetInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to connect to target controller")
+	}
+	defer conn.Close()
+	targetClient := migrationtarget.NewClient(conn)
+	shortfalls, err := targetClient.Prechecks(usage)
+	return shortfalls, errors.Trace(err)
+}
+
 func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
 	err := w.transferModel(targetInfo, modelUUID)
 	if err != nil {
@@ -326,10 +361,19 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		Tools:           serialized.Tools,
 		ToolsDownloader: w.config.ToolsDownloader,
 		ToolsUploader:   targetModelClient,
+		Progress:        w.setArtifactsRemaining,
 	})
 	return errors.Annotate(err, "failed migration binaries")
 }
 
+func (w *Worker) setArtifactsRemaining(count int) {
+	if err := w.config.Facade.SetArtifactsRemaining(count); err != nil {
+		// As with the status message, progress reporting isn't
+		// critical to the migration.
+		w.logger.Errorf("failed to set remaining artifacts: %v", err)
+	}
+}
+
 func (w *Worker) doVALIDATION(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
 	// TODO(mjs) - Wait for all agents to report back.
 
@@ -70,6 +70,14 @@ var (
 			api.DialOpts{},
 		},
 	}
+	prechecksCall = jujutesting.StubCall{
+		"APICall:MigrationTarget.Prechecks",
+		[]interface{}{
+			params.TargetPrechecksArgs{
+				Usage: params.ModelUsage{Machines: 2, Units: 3},
+			},
+		},
+	}
 	importCall = jujutesting.StubCall{
 		"APICall:MigrationTarget.Import",
 		[]interface{}{
@@ -181,7 +189,10 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 		{"masterFacade.SetPhase", []interface{}{coremigration.PRECHECK}},
 
 		// PRECHECK
-		// Nothing yet.
+		{"masterFacade.ModelUsage", nil},
+		apiOpenCallController,
+		prechecksCall,
+		connCloseCall,
 
 		//IMPORT
 		{"masterFacade.SetPhase", []interface{}{coremigration.IMPORT}},
@@ -197,6 +208,7 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 			},
 			fakeToolsDownloader,
 		}},
+		{"masterFacade.SetArtifactsRemaining", []interface{}{0}},
 		connCloseCall, // for target model
 		connCloseCall, // for target controller
 		{"masterFacade.SetPhase", []interface{}{coremigration.VALIDATION}},
@@ -391,6 +403,38 @@ func (s *Suite) TestQUIESCEFailedAgent(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestPRECHECKCapacityShortfall(c *gc.C) {
+	s.masterFacade.status.Phase = coremigration.PRECHECK
+	s.connection.shortfalls = []params.TargetCapacityShortfall{{
+		Resource: "units",
+		Incoming: 3,
+		Usage:    99,
+		Limit:    100,
+	}}
+	worker, err := migrationmaster.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, worker)
+	s.triggerMigration()
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrInactive)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterFacade.Watch", nil},
+		{"masterFacade.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterFacade.ModelUsage", nil},
+		apiOpenCallController,
+		prechecksCall,
+		connCloseCall,
+		{"masterFacade.SetPhase", []interface{}{coremigration.ABORT}},
+		apiOpenCallController,
+		abortCall,
+		connCloseCall,
+		{"masterFacade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+	})
+}
+
 func (s *Suite) TestExportFailure(c *gc.C) {
 	s.masterFacade.status.Phase = coremigration.IMPORT
 	s.masterFacade.exportErr = errors.New("boom")
@@ -752,6 +796,11 @@ func (c *stubMasterFacade) GetMinionReports() (coremigration.MinionReports, erro
 	return r, nil
 }
 
+func (c *stubMasterFacade) ModelUsage() (coremigration.ModelUsage, error) {
+	c.stub.AddCall("masterFacade.ModelUsage")
+	return coremigration.ModelUsage{Machines: 2, Units: 3}, nil
+}
+
 func (c *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 	c.stub.AddCall("masterFacade.Export")
 	if c.exportErr != nil {
@@ -775,6 +824,11 @@ func (c *stubMasterFacade) SetStatusMessage(message string) error {
 	return nil
 }
 
+func (c *stubMasterFacade) SetArtifactsRemaining(count int) error {
+	c.stub.AddCall("masterFacade.SetArtifactsRemaining", count)
+	return nil
+}
+
 func (c *stubMasterFacade) Reap() error {
 	c.stub.AddCall("masterFacade.Reap")
 	return nil
@@ -798,19 +852,25 @@ func (w *mockWatcher) Changes() watcher.NotifyChannel {
 
 type stubConnection struct {
 	api.Connection
-	stub      *jujutesting.Stub
-	importErr error
+	stub       *jujutesting.Stub
+	importErr  error
+	shortfalls []params.TargetCapacityShortfall
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
 	return 1
 }
 
-func (c *stubConnection) APICall(objType string, version int, id, request string, params, response interface{}) error {
-	c.stub.AddCall("APICall:"+objType+"."+request, params)
+func (c *stubConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
+	c.stub.AddCall("APICall:"+objType+"."+request, args)
 
 	if objType == "MigrationTarget" {
 		switch request {
+		case "Prechecks":
+			*(response.(*params.TargetPrechecksResult)) = params.TargetPrechecksResult{
+				Shortfalls: c.shortfalls,
+			}
+			return nil
 		case "Import":
 			return c.importErr
 		case "Activate":
@@ -840,6 +900,7 @@ func makeStubUploadBinaries(stub *jujutesting.Stub) func(migration.UploadBinarie
 			config.Tools,
 			config.ToolsDownloader,
 		)
+		config.Progress(0)
 		return nil
 	}
 }
@@ -0,0 +1,150 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package operationscheduler provides a controller worker which runs
+// the scheduled operations of every model when they fall due.
+package operationscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.operationscheduler")
+
+// Backend exposes the controller's models to a Worker.
+type Backend interface {
+	// ModelUUIDs returns the UUIDs of the models whose scheduled
+	// operations are run.
+	ModelUUIDs() ([]string, error)
+
+	// Model returns the ModelBackend for the model with the given
+	// UUID. It must be closed when no longer needed.
+	Model(uuid string) (ModelBackend, error)
+}
+
+// ModelBackend exposes a model's scheduled operations to a Worker.
+type ModelBackend interface {
+	DueScheduledOperations(now time.Time) ([]state.ScheduledOperation, error)
+	RunScheduledOperation(id string, now time.Time) error
+	Close() error
+}
+
+// Config defines the parameters of the operation scheduler worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+
+	// Interval defines how often the worker looks for operations
+	// which have fallen due.
+	Interval time.Duration
+}
+
+// Validate returns an error if Config cannot drive an operation
+// scheduler.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &scheduler{config: config}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.run())
+	}()
+	return w, nil
+}
+
+// scheduler runs each model's scheduled operations once they are due.
+type scheduler struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+// Kill implements worker.Worker.
+func (w *scheduler) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *scheduler) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *scheduler) run() error {
+	for {
+		if err := w.runDue(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// runDue runs the due operations of every model.
+func (w *scheduler) runDue() error {
+	uuids, err := w.config.Backend.ModelUUIDs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, uuid := range uuids {
+		if err := w.runModelDue(uuid); err != nil {
+			return errors.Annotatef(err, "cannot run scheduled operations of model %s", uuid)
+		}
+	}
+	return nil
+}
+
+// runModelDue runs the due operations of the model with the given
+// UUID. An operation which fails is recorded as such by the model, so
+// its failure is logged rather than stopping the worker.
+func (w *scheduler) runModelDue(uuid string) error {
+	model, err := w.config.Backend.Model(uuid)
+	if errors.IsNotFound(err) {
+		// The model has been removed.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer model.Close()
+
+	now := w.config.Clock.Now()
+	ops, err := model.DueScheduledOperations(now)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, op := range ops {
+		logger.Infof("running scheduled %s of application %q in model %s", op.Kind, op.Application, uuid)
+		err := model.RunScheduledOperation(op.Id, now)
+		if errors.IsNotFound(err) {
+			// The operation was cancelled since it was read.
+			continue
+		} else if err != nil {
+			logger.Errorf("scheduled operation %s in model %s failed: %v", op.Id, uuid, err)
+		}
+	}
+	return nil
+}
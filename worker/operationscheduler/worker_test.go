@@ -0,0 +1,199 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/operationscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+const modelUUID = "deadbeef-2f18-4fd2-967d-db9663db7bea"
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub   *jujutesting.Stub
+	clock  *coretesting.Clock
+	model  *stubModel
+	config operationscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = new(jujutesting.Stub)
+	s.clock = coretesting.NewClock(time.Date(2016, 11, 5, 2, 0, 0, 0, time.UTC))
+	s.model = &stubModel{stub: s.stub}
+	s.config = operationscheduler.Config{
+		Backend:  &stubBackend{stub: s.stub, model: s.model},
+		Clock:    s.clock,
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*operationscheduler.Config)
+		err    string
+	}{{
+		func(config *operationscheduler.Config) { config.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(config *operationscheduler.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *operationscheduler.Config) { config.Interval = 0 },
+		"non-positive Interval not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := operationscheduler.New(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestNothingDue(c *gc.C) {
+	s.runOnce(c)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelUUIDs", nil},
+		{"Model", []interface{}{modelUUID}},
+		{"DueScheduledOperations", []interface{}{s.clock.Now()}},
+		{"Close", nil},
+	})
+}
+
+func (s *WorkerSuite) TestRunsDue(c *gc.C) {
+	s.model.due = []state.ScheduledOperation{{
+		Id:          "1",
+		Kind:        state.UpgradeCharmOperation,
+		Application: "mysql",
+	}, {
+		Id:          "2",
+		Kind:        state.ScaleApplicationOperation,
+		Application: "mysql",
+	}}
+	s.runOnce(c)
+	now := s.clock.Now()
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelUUIDs", nil},
+		{"Model", []interface{}{modelUUID}},
+		{"DueScheduledOperations", []interface{}{now}},
+		{"RunScheduledOperation", []interface{}{"1", now}},
+		{"RunScheduledOperation", []interface{}{"2", now}},
+		{"Close", nil},
+	})
+}
+
+func (s *WorkerSuite) TestOperationErrorIgnored(c *gc.C) {
+	s.model.due = []state.ScheduledOperation{{Id: "1"}, {Id: "2"}}
+	s.stub.SetErrors(nil, nil, nil, errors.New("blam"))
+	s.runOnce(c)
+	s.stub.CheckCallNames(c,
+		"ModelUUIDs", "Model", "DueScheduledOperations",
+		"RunScheduledOperation", "RunScheduledOperation", "Close",
+	)
+}
+
+func (s *WorkerSuite) TestRunsAgainAfterInterval(c *gc.C) {
+	w, err := operationscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	s.waitAlarm(c)
+	s.clock.Advance(time.Minute)
+	s.waitAlarm(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c,
+		"ModelUUIDs", "Model", "DueScheduledOperations", "Close",
+		"ModelUUIDs", "Model", "DueScheduledOperations", "Close",
+	)
+}
+
+func (s *WorkerSuite) TestModelRemoved(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotFoundf("model"))
+	s.runOnce(c)
+	s.stub.CheckCallNames(c, "ModelUUIDs", "Model")
+}
+
+func (s *WorkerSuite) TestBackendError(c *gc.C) {
+	s.stub.SetErrors(nil, nil, errors.New("blam"))
+	w, err := operationscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot run scheduled operations of model "+modelUUID+": blam")
+	s.stub.CheckCallNames(c, "ModelUUIDs", "Model", "DueScheduledOperations", "Close")
+}
+
+// runOnce runs the worker until it has run the due operations once.
+func (s *WorkerSuite) runOnce(c *gc.C) {
+	w, err := operationscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	s.waitAlarm(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for scheduled operations to run")
+	}
+}
+
+type stubBackend struct {
+	stub  *jujutesting.Stub
+	model *stubModel
+}
+
+func (b *stubBackend) ModelUUIDs() ([]string, error) {
+	b.stub.AddCall("ModelUUIDs")
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return []string{modelUUID}, nil
+}
+
+func (b *stubBackend) Model(uuid string) (operationscheduler.ModelBackend, error) {
+	b.stub.AddCall("Model", uuid)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.model, nil
+}
+
+type stubModel struct {
+	stub *jujutesting.Stub
+	due  []state.ScheduledOperation
+}
+
+func (m *stubModel) DueScheduledOperations(now time.Time) ([]state.ScheduledOperation, error) {
+	m.stub.AddCall("DueScheduledOperations", now)
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.due, nil
+}
+
+func (m *stubModel) RunScheduledOperation(id string, now time.Time) error {
+	m.stub.AddCall("RunScheduledOperation", id, now)
+	return m.stub.NextErr()
+}
+
+func (m *stubModel) Close() error {
+	m.stub.AddCall("Close")
+	return m.stub.NextErr()
+}
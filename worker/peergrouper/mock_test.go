@@ -220,6 +220,43 @@ func (st *fakeState) setControllers(ids ...string) {
 	})
 }
 
+func (st *fakeState) setRemovingControllers(ids ...string) {
+	info := deepCopy(st.controllers.Get()).(*state.ControllerInfo)
+	info.RemovingMachineIds = ids
+	st.controllers.Set(info)
+}
+
+func (st *fakeState) RemoveControllerMachines(ids ...string) (state.ControllersChanges, error) {
+	if err := st.errors.errorFor("State.RemoveControllerMachines", ids); err != nil {
+		return state.ControllersChanges{}, err
+	}
+	info := deepCopy(st.controllers.Get()).(*state.ControllerInfo)
+	var change state.ControllersChanges
+	for _, id := range ids {
+		if m := st.machine(id); m != nil && m.HasVote() {
+			change.Maintained = append(change.Maintained, id)
+			continue
+		}
+		info.MachineIds = withoutString(info.MachineIds, id)
+		info.RemovingMachineIds = withoutString(info.RemovingMachineIds, id)
+		change.Removed = append(change.Removed, id)
+	}
+	if len(change.Removed) > 0 {
+		st.controllers.Set(info)
+	}
+	return change, nil
+}
+
+func withoutString(ss []string, s string) []string {
+	var result []string
+	for _, t := range ss {
+		if t != s {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
 func (st *fakeState) ControllerInfo() (*state.ControllerInfo, error) {
 	if err := st.errors.errorFor("State.ControllerInfo"); err != nil {
 		return nil, err
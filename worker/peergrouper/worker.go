@@ -30,6 +30,7 @@ type stateInterface interface {
 	Space(id string) (SpaceReader, error)
 	SetOrGetMongoSpaceName(spaceName network.SpaceName) (network.SpaceName, error)
 	SetMongoSpaceState(mongoSpaceState state.MongoSpaceStates) error
+	RemoveControllerMachines(ids ...string) (state.ControllersChanges, error)
 }
 
 type stateMachine interface {
@@ -493,6 +494,30 @@ func (w *pgWorker) updateReplicaset() error {
 	if err := setHasVote(removed, false); err != nil {
 		return errors.Annotate(err, "cannot set HasVote removed")
 	}
+	if err := w.removeControllerMachines(); err != nil {
+		return errors.Annotate(err, "cannot remove controller machines")
+	}
+	return nil
+}
+
+// removeControllerMachines takes any machines that have been marked
+// for removal out of the controller cluster once they have lost
+// their vote. Machines that still hold a vote are left alone.
+func (w *pgWorker) removeControllerMachines() error {
+	info, err := w.st.ControllerInfo()
+	if err != nil {
+		return errors.Annotate(err, "cannot get controller info")
+	}
+	if len(info.RemovingMachineIds) == 0 {
+		return nil
+	}
+	change, err := w.st.RemoveControllerMachines(info.RemovingMachineIds...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(change.Removed) > 0 {
+		logger.Infof("removed machines %v from the controller cluster", change.Removed)
+	}
 	return nil
 }
 
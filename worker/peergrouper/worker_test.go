@@ -172,6 +172,54 @@ func (s *workerSuite) TestSetsAndUpdatesMembers(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) TestRemovesControllerMachinesOnceVoteRemoved(c *gc.C) {
+	DoTestForIPv4AndIPv6(func(ipVersion TestIPVersion) {
+		s.PatchValue(&pollInterval, 5*time.Millisecond)
+
+		st := NewFakeState()
+		InitState(c, st, 3, ipVersion)
+
+		memberWatcher := st.session.members.Watch()
+		mustNext(c, memberWatcher)
+		assertMembers(c, memberWatcher.Value(), mkMembers("0v", ipVersion))
+
+		w, err := newWorker(st, noPublisher{}, false)
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.CleanKill(c, w)
+
+		mustNext(c, memberWatcher)
+		assertMembers(c, memberWatcher.Value(), mkMembers("0v 1 2", ipVersion))
+		st.session.setStatus(mkStatuses("0p 1s 2s", ipVersion))
+		mustNext(c, memberWatcher)
+		assertMembers(c, memberWatcher.Value(), mkMembers("0v 1v 2v", ipVersion))
+
+		m13 := st.addMachine("13", false)
+		m13.setStateHostPort(fmt.Sprintf(ipVersion.formatHostPort, 13, mongoPort))
+		st.setControllers("10", "11", "12", "13")
+		mustNext(c, memberWatcher)
+		assertMembers(c, memberWatcher.Value(), mkMembers("0v 1v 2v 3", ipVersion))
+
+		// Mark machine 10 for removal and give its vote to machine 13.
+		c.Logf("marking machine 10 for removal")
+		st.setRemovingControllers("10")
+		st.machine("10").setWantsVote(false)
+		st.machine("13").setWantsVote(true)
+		st.session.setStatus(mkStatuses("0p 1s 2s 3s", ipVersion))
+		mustNext(c, memberWatcher)
+		assertMembers(c, memberWatcher.Value(), mkMembers("0 1v 2v 3v", ipVersion))
+
+		// Once machine 10 has lost its vote, the worker takes it out
+		// of the controllers, and so out of the replica set.
+		c.Logf("waiting for removal")
+		mustNext(c, memberWatcher)
+		assertMembers(c, memberWatcher.Value(), mkMembers("1v 2v 3v", ipVersion))
+		info, err := st.ControllerInfo()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(info.MachineIds, jc.SameContents, []string{"11", "12", "13"})
+		c.Assert(info.RemovingMachineIds, gc.HasLen, 0)
+	})
+}
+
 func (s *workerSuite) TestHasVoteMaintainedEvenWhenReplicaSetFails(c *gc.C) {
 	DoTestForIPv4AndIPv6(func(ipVersion TestIPVersion) {
 		st := NewFakeState()
@@ -33,4 +33,9 @@ var (
 	RetryStrategyCount     = &retryStrategyCount
 )
 
-var ClassifyMachine = classifyMachine
+var (
+	ClassifyMachine = classifyMachine
+	QuotaSufficient = quotaSufficient
+	RetryDelayFor   = RetryStrategy.delayFor
+	MaxRetryDelay   = &maxRetryDelay
+)
@@ -31,6 +31,10 @@ var _ Provisioner = (*containerProvisioner)(nil)
 var (
 	retryStrategyDelay = 10 * time.Second
 	retryStrategyCount = 3
+
+	// maxRetryDelay caps the delay between attempts to start an
+	// instance when backing off after capacity or transient errors.
+	maxRetryDelay = 5 * time.Minute
 )
 
 // Provisioner represents a running provisioner worker.
@@ -85,6 +89,33 @@ func NewRetryStrategy(delay time.Duration, count int) RetryStrategy {
 	}
 }
 
+// delayFor returns how long to wait before trying again to start an
+// instance, after the given number of previous failed attempts ended
+// with an error of the given class. If retrying cannot help, false is
+// returned.
+func (s RetryStrategy) delayFor(class environs.StartInstanceErrorClass, failures int) (time.Duration, bool) {
+	switch class {
+	case environs.StartInstanceErrorQuota,
+		environs.StartInstanceErrorCredential,
+		environs.StartInstanceErrorImageNotFound:
+		// These need the user to intervene.
+		return 0, false
+	case environs.StartInstanceErrorCapacity,
+		environs.StartInstanceErrorTransient:
+		// These should clear up by themselves, so back off
+		// to give the cloud time to recover.
+		delay := s.retryDelay
+		for i := 1; i < failures && delay < maxRetryDelay; i++ {
+			delay *= 2
+		}
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+		return delay, true
+	}
+	return s.retryDelay, true
+}
+
 // configObserver is implemented so that tests can see
 // when the environment configuration changes.
 type configObserver struct {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	apiprovisioner "github.com/juju/juju/api/provisioner"
@@ -20,9 +21,11 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/status"
@@ -91,6 +94,7 @@ func NewProvisionerTask(
 		harvestMode:                harvestMode,
 		harvestModeChan:            make(chan config.HarvestMode, 1),
 		machines:                   make(map[string]*apiprovisioner.Machine),
+		inMaintenance:              make(set.Strings),
 		imageStream:                imageStream,
 		secureServerConnection:     secureServerConnection,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
@@ -125,6 +129,11 @@ type provisionerTask struct {
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
 	machines map[string]*apiprovisioner.Machine
+	// inMaintenance holds the ids of machines which were left alone
+	// because they are in maintenance mode. They are processed again
+	// whenever the retry watcher fires, so that they are picked up
+	// once they leave maintenance.
+	inMaintenance set.Strings
 }
 
 // Kill implements worker.Worker.Kill.
@@ -180,6 +189,11 @@ func (task *provisionerTask) loop() error {
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
 			}
+			if !task.inMaintenance.IsEmpty() {
+				if err := task.processMachines(task.inMaintenance.SortedValues()); err != nil {
+					return errors.Annotate(err, "failed to process machines in maintenance")
+				}
+			}
 		}
 	}
 }
@@ -205,6 +219,13 @@ func (task *provisionerTask) processMachinesWithTransientErrors() error {
 			continue
 		}
 		machine := machines[i]
+		if inMaintenance, err := machine.InMaintenance(); err != nil {
+			logger.Errorf("cannot check maintenance mode of machine %q: %v", statusResult.Id, err)
+			continue
+		} else if inMaintenance {
+			logger.Infof("not retrying provisioning of machine %q in maintenance", statusResult.Id)
+			continue
+		}
 		if err := machine.SetStatus(status.StatusPending, "", nil); err != nil {
 			logger.Errorf("cannot reset status of machine %q: %v", statusResult.Id, err)
 			continue
@@ -317,6 +338,7 @@ func (task *provisionerTask) populateMachineMaps(ids []string) error {
 		case params.IsCodeNotFoundOrCodeUnauthorized(err):
 			logger.Debugf("machine %q not found in state", id)
 			delete(task.machines, id)
+			task.inMaintenance.Remove(id)
 		case err == nil:
 			task.machines[id] = machine
 		default:
@@ -340,6 +362,11 @@ func (task *provisionerTask) pendingOrDeadOrMaintain(ids []string) (pending, dea
 		if err != nil {
 			return // return the error
 		}
+		if classification == InMaintenance {
+			task.inMaintenance.Add(id)
+		} else {
+			task.inMaintenance.Remove(id)
+		}
 		switch classification {
 		case Pending:
 			pending = append(pending, machine)
@@ -356,6 +383,7 @@ func (task *provisionerTask) pendingOrDeadOrMaintain(ids []string) (pending, dea
 
 type ClassifiableMachine interface {
 	Life() params.Life
+	InMaintenance() (bool, error)
 	InstanceId() (instance.Id, error)
 	EnsureDead() error
 	Status() (status.Status, string, error)
@@ -369,10 +397,22 @@ const (
 	Pending  MachineClassification = "Pending"
 	Dead     MachineClassification = "Dead"
 	Maintain MachineClassification = "Maintain"
+
+	// InMaintenance classifies machines in maintenance mode, which
+	// are neither started, stopped nor maintained.
+	InMaintenance MachineClassification = "InMaintenance"
 )
 
 func classifyMachine(machine ClassifiableMachine) (
 	MachineClassification, error) {
+	inMaintenance, err := machine.InMaintenance()
+	if err != nil {
+		return None, errors.Annotatef(err, "failed to check maintenance mode id:%s, details:%v", machine.Id(), machine)
+	}
+	if inMaintenance {
+		logger.Infof("machine %s is in maintenance mode; leaving it alone", machine.Id())
+		return InMaintenance, nil
+	}
 	switch machine.Life() {
 	case params.Dying:
 		if _, err := machine.InstanceId(); err == nil {
@@ -510,6 +550,7 @@ func (task *provisionerTask) constructInstanceConfig(
 	}
 
 	instanceConfig.Tags = pInfo.Tags
+	instanceConfig.CloudInitCustomization = pInfo.CloudInitCustomization
 	if len(pInfo.Jobs) > 0 {
 		instanceConfig.Jobs = pInfo.Jobs
 	}
@@ -605,6 +646,23 @@ func constructStartInstanceParams(
 		}
 	}
 
+	var instanceTypes []instances.InstanceType
+	for _, itype := range provisioningInfo.InstanceTypes {
+		instanceTypes = append(instanceTypes, instances.InstanceType{
+			Id:         itype.Id,
+			Name:       itype.Name,
+			Arches:     itype.Arches,
+			CpuCores:   itype.CpuCores,
+			Mem:        itype.Mem,
+			Cost:       itype.Cost,
+			RootDisk:   itype.RootDisk,
+			VirtType:   itype.VirtType,
+			CpuPower:   itype.CpuPower,
+			Tags:       itype.Tags,
+			Deprecated: itype.Deprecated,
+		})
+	}
+
 	return environs.StartInstanceParams{
 		ControllerUUID:    controllerUUID,
 		Constraints:       provisioningInfo.Constraints,
@@ -616,6 +674,7 @@ func constructStartInstanceParams(
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
+		InstanceTypes:     instanceTypes,
 		StatusCallback:    machine.SetInstanceStatus,
 	}, nil
 }
@@ -672,6 +731,7 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 		if err != nil {
 			return task.setErrorStatus("cannot construct params for machine %q: %v", m, err)
 		}
+		startInstanceParams.AvailabilityZones = task.availabilityZones(startInstanceParams)
 
 		if err := task.startMachine(m, pInfo, startInstanceParams); err != nil {
 			return errors.Annotatef(err, "cannot start machine %v", m)
@@ -680,9 +740,113 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 	return nil
 }
 
+// availabilityZones returns the availability zones in which the
+// instance described by args should be started, in order of preference
+// under the model's availability zone policy. It returns nil, leaving
+// the choice to the broker, if the broker does not support zones, if
+// the instance has a placement directive, or if the zones cannot be
+// determined.
+func (task *provisionerTask) availabilityZones(args environs.StartInstanceParams) []string {
+	zonedEnv, ok := task.broker.(common.ZonedEnviron)
+	if !ok || args.Placement != "" {
+		return nil
+	}
+	var group []instance.Id
+	if args.DistributionGroup != nil {
+		var err error
+		group, err = args.DistributionGroup()
+		if err != nil {
+			logger.Warningf("cannot get distribution group for machine %q: %v", args.InstanceConfig.MachineId, err)
+			return nil
+		}
+	}
+	policy := zonedEnv.Config().AvailabilityZonePolicy()
+	zones, err := common.PreferredAvailabilityZones(zonedEnv, group, policy)
+	if err != nil {
+		logger.Warningf("cannot choose availability zones for machine %q: %v", args.InstanceConfig.MachineId, err)
+		return nil
+	}
+	return zones
+}
+
+// checkQuota returns an error describing the exhausted quota if the
+// broker reports that the instance described by args cannot be started
+// in any of its candidate availability zones. No error is returned if
+// the broker cannot report its quota.
+func (task *provisionerTask) checkQuota(args environs.StartInstanceParams) error {
+	checker, ok := task.broker.(environs.QuotaChecker)
+	if !ok {
+		return nil
+	}
+	zones := args.AvailabilityZones
+	if len(zones) == 0 {
+		zones = []string{""}
+	}
+	cores := uint64(1)
+	if args.Constraints.CpuCores != nil {
+		cores = *args.Constraints.CpuCores
+	}
+	volumes := uint64(len(args.Volumes))
+
+	var firstErr error
+	for _, zone := range zones {
+		quota, err := checker.RemainingQuota(zone)
+		if err != nil {
+			logger.Warningf("cannot check quota for machine %q: %v", args.InstanceConfig.MachineId, err)
+			return nil
+		}
+		err = quotaSufficient(quota, cores, volumes)
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// quotaSufficient returns an error if the given quota cannot
+// accommodate a new instance with the given number of cores and
+// volumes.
+func quotaSufficient(quota environs.Quota, cores, volumes uint64) error {
+	for _, limit := range []struct {
+		resource  string
+		remaining *uint64
+		required  uint64
+	}{
+		{"instance", quota.Instances, 1},
+		{"vCPU", quota.Cores, cores},
+		{"volume", quota.Volumes, volumes},
+	} {
+		if limit.remaining == nil || *limit.remaining >= limit.required {
+			continue
+		}
+		var location string
+		if quota.Location != "" {
+			location = " in " + quota.Location
+		}
+		return errors.Errorf(
+			"%s quota exhausted%s: %d required, %d remaining",
+			limit.resource, location, limit.required, *limit.remaining,
+		)
+	}
+	return nil
+}
+
+// errorClassStatusKey is the key of the machine status data which
+// records the class of error that prevented its instance starting.
+const errorClassStatusKey = "error-class"
+
 func (task *provisionerTask) setErrorStatus(message string, machine *apiprovisioner.Machine, err error) error {
 	logger.Errorf(message, machine, err)
-	if err1 := machine.SetStatus(status.StatusError, err.Error(), nil); err1 != nil {
+	var data map[string]interface{}
+	if class := environs.StartInstanceErrorClassOf(err); class != environs.StartInstanceErrorUnknown {
+		// Record why the instance could not be started, so
+		// that clients can tell the user what to do about it.
+		data = map[string]interface{}{errorClassStatusKey: string(class)}
+	}
+	if err1 := machine.SetStatus(status.StatusError, err.Error(), data); err1 != nil {
 		// Something is wrong with this machine, better report it back.
 		return errors.Annotatef(err1, "cannot set error status for machine %q", machine)
 	}
@@ -694,13 +858,24 @@ func (task *provisionerTask) startMachine(
 	provisioningInfo *params.ProvisioningInfo,
 	startInstanceParams environs.StartInstanceParams,
 ) error {
+	if err := task.checkQuota(startInstanceParams); err != nil {
+		// Retrying cannot help until the quota is raised or
+		// resources are released, so fail straight away.
+		err = environs.NewStartInstanceError(err, environs.StartInstanceErrorQuota)
+		return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
+	}
+
 	var result *environs.StartInstanceResult
 	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; attemptsLeft-- {
 		attemptResult, err := task.broker.StartInstance(startInstanceParams)
 		if err == nil {
 			result = attemptResult
 			break
-		} else if attemptsLeft <= 0 {
+		}
+		class := environs.StartInstanceErrorClassOf(err)
+		failures := task.retryStartInstanceStrategy.retryCount - attemptsLeft + 1
+		retryDelay, retry := task.retryStartInstanceStrategy.delayFor(class, failures)
+		if attemptsLeft <= 0 || !retry {
 			// Set the state to error, so the machine will be skipped
 			// next time until the error is resolved, but don't return
 			// an error; just keep going with the other machines.
@@ -708,7 +883,7 @@ func (task *provisionerTask) startMachine(
 		}
 
 		logger.Warningf("%v", errors.Annotate(err, "starting instance"))
-		retryMsg := fmt.Sprintf("will retry to start instance in %v", task.retryStartInstanceStrategy.retryDelay)
+		retryMsg := fmt.Sprintf("will retry to start instance in %v", retryDelay)
 		if err2 := machine.SetStatus(status.StatusPending, retryMsg, nil); err2 != nil {
 			logger.Errorf("%v", err2)
 		}
@@ -717,7 +892,7 @@ func (task *provisionerTask) startMachine(
 		select {
 		case <-task.catacomb.Dying():
 			return task.catacomb.ErrDying()
-		case <-time.After(task.retryStartInstanceStrategy.retryDelay):
+		case <-time.After(retryDelay):
 		}
 	}
 
@@ -460,6 +460,46 @@ func (s *ProvisionerSuite) TestSimple(c *gc.C) {
 	s.waitRemoved(c, m)
 }
 
+func (s *ProvisionerSuite) TestProvisionerChoosesAvailabilityZones(c *gc.C) {
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	_, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case o := <-s.op:
+		o1, ok := o.(dummy.OpStartInstance)
+		c.Assert(ok, jc.IsTrue, gc.Commentf("unexpected operation %#v", o))
+		// The dummy provider has a single available zone.
+		c.Assert(o1.AvailabilityZones, jc.DeepEquals, []string{"zone1"})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for startinstance operation")
+	}
+}
+
+func (s *ProvisionerSuite) TestMachineInMaintenanceLeftAlone(c *gc.C) {
+	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	instance := s.checkStartInstance(c, m)
+
+	// The instance of a dead machine in maintenance is not stopped.
+	err = m.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.EnsureDead(), gc.IsNil)
+	s.checkNoOperations(c)
+
+	// Once out of maintenance, the dead machine is dealt with as
+	// usual when the retry watcher next fires.
+	err = m.SetMaintenance(false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStopInstances(c, instance)
+	s.waitRemoved(c, m)
+}
+
 func (s *ProvisionerSuite) TestConstraints(c *gc.C) {
 	// Create a machine with non-standard constraints.
 	m, err := s.addMachine()
@@ -623,6 +663,49 @@ func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedRetrya
 	s.checkStartInstance(c, m)
 }
 
+func (s *ProvisionerSuite) TestProvisionerDoesNotRetryQuotaError(c *gc.C) {
+	// Set the retry delay to 0, and retry count to 2 to keep tests short
+	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
+	s.PatchValue(provisioner.RetryStrategyCount, 2)
+
+	errorInjectionChannel := make(chan error, 1)
+
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	cleanup := dummy.PatchTransientErrorInjectionChannel(errorInjectionChannel)
+	defer cleanup()
+
+	// The error is only injected once, so the instance would
+	// start if the provisioner were to retry.
+	quotaError := environs.NewStartInstanceError(
+		errors.New("instance limit exceeded"),
+		environs.StartInstanceErrorQuota,
+	)
+	errorInjectionChannel <- quotaError
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+
+	t0 := time.Now()
+	for time.Since(t0) < coretesting.LongWait {
+		statusInfo, err := m.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if statusInfo.Status == status.StatusPending {
+			time.Sleep(coretesting.ShortWait)
+			continue
+		}
+		c.Assert(statusInfo.Status, gc.Equals, status.StatusError)
+		c.Assert(statusInfo.Message, gc.Equals, "instance limit exceeded")
+		c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+			"error-class": "quota",
+		})
+		s.checkNoOperations(c)
+		return
+	}
+	c.Fatal("Test took too long to complete")
+}
+
 func (s *ProvisionerSuite) TestProvisionerStopRetryingIfDying(c *gc.C) {
 	// Create the error injection channel and inject
 	// a retryable error
@@ -718,12 +801,17 @@ type MockMachine struct {
 	idErr         error
 	ensureDeadErr error
 	statusErr     error
+	maintenance   bool
 }
 
 func (m *MockMachine) Life() params.Life {
 	return m.life
 }
 
+func (m *MockMachine) InMaintenance() (bool, error) {
+	return m.maintenance, nil
+}
+
 func (m *MockMachine) InstanceId() (instance.Id, error) {
 	return instance.Id(m.id), m.idErr
 }
@@ -749,6 +837,7 @@ type machineClassificationTest struct {
 	expectErrCode  string
 	expectErrFmt   string
 	statusErr      string
+	maintenance    bool
 	classification provisioner.MachineClassification
 }
 
@@ -806,6 +895,19 @@ var machineClassificationTests = []machineClassificationTest{{
 	expectErrCode:  params.CodeNotFound,
 	ensureDeadErr:  params.CodeNotFound,
 	expectErrFmt:   "failed to ensure machine dead id:%s.*",
+}, {
+	description:    "Dead machine in maintenance is left alone",
+	life:           params.Dead,
+	status:         status.StatusStarted,
+	maintenance:    true,
+	classification: provisioner.InMaintenance,
+}, {
+	description:    "Pending machine in maintenance is left alone",
+	life:           params.Alive,
+	status:         status.StatusPending,
+	idErr:          params.CodeNotProvisioned,
+	maintenance:    true,
+	classification: provisioner.InMaintenance,
 }}
 
 var machineClassificationTestsRequireMaintenance = machineClassificationTest{
@@ -834,7 +936,7 @@ func (s *MachineClassifySuite) TestMachineClassification(c *gc.C) {
 		}
 
 		c.Logf("%s: %s", id, t.description)
-		machine := MockMachine{t.life, t.status, id, s2e(t.idErr), s2e(t.ensureDeadErr), s2e(t.statusErr)}
+		machine := MockMachine{t.life, t.status, id, s2e(t.idErr), s2e(t.ensureDeadErr), s2e(t.statusErr), t.maintenance}
 		classification, err := provisioner.ClassifyMachine(&machine)
 		if err != nil {
 			c.Assert(err, gc.ErrorMatches, fmt.Sprintf(t.expectErrFmt, machine.Id()))
@@ -858,6 +960,80 @@ func (s *MachineClassifySuite) TestMachineClassification(c *gc.C) {
 	}
 }
 
+type RetryStrategySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&RetryStrategySuite{})
+
+func (s *RetryStrategySuite) TestDelayFor(c *gc.C) {
+	s.PatchValue(provisioner.MaxRetryDelay, 60*time.Second)
+	strategy := provisioner.NewRetryStrategy(10*time.Second, 5)
+	for i, test := range []struct {
+		class    environs.StartInstanceErrorClass
+		failures int
+		delay    time.Duration
+		retry    bool
+	}{
+		{environs.StartInstanceErrorUnknown, 1, 10 * time.Second, true},
+		{environs.StartInstanceErrorUnknown, 4, 10 * time.Second, true},
+		{environs.StartInstanceErrorTransient, 1, 10 * time.Second, true},
+		{environs.StartInstanceErrorTransient, 2, 20 * time.Second, true},
+		{environs.StartInstanceErrorCapacity, 3, 40 * time.Second, true},
+		{environs.StartInstanceErrorCapacity, 4, 60 * time.Second, true},
+		{environs.StartInstanceErrorQuota, 1, 0, false},
+		{environs.StartInstanceErrorCredential, 1, 0, false},
+		{environs.StartInstanceErrorImageNotFound, 1, 0, false},
+	} {
+		c.Logf("test %d: %q after %d failures", i, test.class, test.failures)
+		delay, retry := provisioner.RetryDelayFor(strategy, test.class, test.failures)
+		c.Check(delay, gc.Equals, test.delay)
+		c.Check(retry, gc.Equals, test.retry)
+	}
+}
+
+type QuotaSuite struct{}
+
+var _ = gc.Suite(&QuotaSuite{})
+
+func (s *QuotaSuite) TestQuotaSufficient(c *gc.C) {
+	uint64p := func(n uint64) *uint64 { return &n }
+	for i, test := range []struct {
+		quota   environs.Quota
+		cores   uint64
+		volumes uint64
+		err     string
+	}{{
+		quota: environs.Quota{},
+		cores: 8,
+	}, {
+		quota:   environs.Quota{Instances: uint64p(1), Cores: uint64p(2), Volumes: uint64p(1)},
+		cores:   2,
+		volumes: 1,
+	}, {
+		quota: environs.Quota{Location: "us-east-1", Instances: uint64p(0)},
+		cores: 1,
+		err:   "instance quota exhausted in us-east-1: 1 required, 0 remaining",
+	}, {
+		quota: environs.Quota{Location: "us-east-1a", Instances: uint64p(5), Cores: uint64p(1)},
+		cores: 2,
+		err:   "vCPU quota exhausted in us-east-1a: 2 required, 1 remaining",
+	}, {
+		quota:   environs.Quota{Volumes: uint64p(1)},
+		cores:   1,
+		volumes: 2,
+		err:     "volume quota exhausted: 2 required, 1 remaining",
+	}} {
+		c.Logf("test %d", i)
+		err := provisioner.QuotaSufficient(test.quota, test.cores, test.volumes)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
 func (s *ProvisionerSuite) TestProvisioningMachinesWithSpacesSuccess(c *gc.C) {
 	p := s.newEnvironProvisioner(c)
 	defer stop(c, p)
@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schemaupgrader
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.schemaupgrader")
+
+// SchemaUpgrader defines the interface for types capable of upgrading
+// the schema of the controller's collections.
+type SchemaUpgrader interface {
+	UpgradeSchema(dryRun bool) ([]state.SchemaUpgradeResult, error)
+}
+
+// New returns a worker which upgrades the schema of the controller's
+// collections, and then finishes. The upgrade is first verified with
+// a dry run, whose plan is logged; any error from the dry run stops
+// the worker before anything is changed.
+func New(upgrader SchemaUpgrader) worker.Worker {
+	return worker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
+		return upgradeSchema(upgrader)
+	})
+}
+
+func upgradeSchema(upgrader SchemaUpgrader) error {
+	planned, err := upgrader.UpgradeSchema(true)
+	if err != nil {
+		return errors.Annotate(err, "schema upgrade dry run failed")
+	}
+	if len(planned) == 0 {
+		logger.Debugf("schema is up to date")
+		return nil
+	}
+	for _, step := range planned {
+		logger.Infof(
+			"schema upgrade planned for %s version %d: %s (%d documents)",
+			step.Collection, step.Version, step.Description, step.Pending,
+		)
+	}
+	results, err := upgrader.UpgradeSchema(false)
+	if err != nil {
+		return errors.Annotate(err, "schema upgrade failed")
+	}
+	for _, step := range results {
+		logger.Infof("upgraded schema of %s to version %d", step.Collection, step.Version)
+	}
+	return nil
+}
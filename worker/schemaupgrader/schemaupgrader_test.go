@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schemaupgrader_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/schemaupgrader"
+)
+
+type SchemaUpgraderSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SchemaUpgraderSuite{})
+
+func (s *SchemaUpgraderSuite) TestUpgrades(c *gc.C) {
+	upgrader := &fakeSchemaUpgrader{
+		results: []state.SchemaUpgradeResult{{
+			Collection:  "settings",
+			Version:     1,
+			Description: "upgrade settings",
+			Pending:     3,
+		}},
+	}
+	w := schemaupgrader.New(upgrader)
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+	c.Assert(upgrader.calls, jc.DeepEquals, []bool{true, false})
+}
+
+func (s *SchemaUpgraderSuite) TestUpToDate(c *gc.C) {
+	upgrader := &fakeSchemaUpgrader{}
+	w := schemaupgrader.New(upgrader)
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+	c.Assert(upgrader.calls, jc.DeepEquals, []bool{true})
+}
+
+func (s *SchemaUpgraderSuite) TestDryRunFails(c *gc.C) {
+	upgrader := &fakeSchemaUpgrader{
+		errs: []error{errors.New("boom")},
+	}
+	w := schemaupgrader.New(upgrader)
+	c.Assert(w.Wait(), gc.ErrorMatches, "schema upgrade dry run failed: boom")
+	c.Assert(upgrader.calls, jc.DeepEquals, []bool{true})
+}
+
+func (s *SchemaUpgraderSuite) TestUpgradeFails(c *gc.C) {
+	upgrader := &fakeSchemaUpgrader{
+		results: []state.SchemaUpgradeResult{{Collection: "settings", Version: 1}},
+		errs:    []error{nil, errors.New("boom")},
+	}
+	w := schemaupgrader.New(upgrader)
+	c.Assert(w.Wait(), gc.ErrorMatches, "schema upgrade failed: boom")
+	c.Assert(upgrader.calls, jc.DeepEquals, []bool{true, false})
+}
+
+type fakeSchemaUpgrader struct {
+	results []state.SchemaUpgradeResult
+	errs    []error
+	calls   []bool
+}
+
+func (f *fakeSchemaUpgrader) UpgradeSchema(dryRun bool) ([]state.SchemaUpgradeResult, error) {
+	var err error
+	if len(f.calls) < len(f.errs) {
+		err = f.errs[len(f.calls)]
+	}
+	f.calls = append(f.calls, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	return f.results, nil
+}
@@ -22,6 +22,20 @@ func NewFacade(apiCaller base.APICaller, controllerTag names.MachineTag) (Facade
 	return facade, nil
 }
 
+// NewWorkerFacade returns a function that creates a Facade for the
+// exclusive right to run the named worker for the APICaller's model. It's
+// a suitable value for ManifoldConfig.NewFacade, when the flag is to
+// guard a single worker rather than administration of the whole model.
+func NewWorkerFacade(workerName string) func(base.APICaller, names.MachineTag) (Facade, error) {
+	return func(apiCaller base.APICaller, controllerTag names.MachineTag) (Facade, error) {
+		facade, err := singular.NewWorkerAPI(apiCaller, controllerTag, workerName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return facade, nil
+	}
+}
+
 // NewWorker calls NewFlagWorker but returns a more convenient type. It's
 // a suitable default value for ManifoldConfig.NewWorker.
 func NewWorker(config FlagConfig) (worker.Worker, error) {
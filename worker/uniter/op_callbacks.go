@@ -12,9 +12,11 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -62,6 +64,10 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 		return opc.u.relations.CommitHook(hi)
 	case hi.Kind.IsStorage():
 		return opc.u.storage.CommitHook(hi)
+	case hi.Kind == hook.PreSeriesUpgrade:
+		return opc.u.unit.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	case hi.Kind == hook.PostSeriesUpgrade:
+		return opc.u.unit.SetUpgradeSeriesStatus(upgradeseries.Completed)
 	}
 	return nil
 }
@@ -91,6 +97,24 @@ func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context)
 	}
 }
 
+// RecordHookTiming is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) RecordHookTiming(timing operation.HookTiming) {
+	err := opc.u.unit.RecordHookTiming(params.HookTiming{
+		Hook:     timing.Hook,
+		Queued:   timing.Queued,
+		Started:  timing.Started,
+		Finished: timing.Finished,
+		ExitCode: timing.ExitCode,
+	})
+	if params.IsCodeNotImplemented(err) {
+		// The controller does not record hook timings.
+		return
+	}
+	if err != nil {
+		logger.Warningf("cannot record timing of %q hook: %v", timing.Hook, err)
+	}
+}
+
 // FailAction is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	if !names.IsValidAction(actionId) {
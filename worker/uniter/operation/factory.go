@@ -5,6 +5,7 @@ package operation
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -20,11 +21,18 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// Clock is used to time hook executions. If nil, the wall
+	// clock is used.
+	Clock clock.Clock
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
 // parameters.
 func NewFactory(params FactoryParams) Factory {
+	if params.Clock == nil {
+		params.Clock = clock.WallClock
+	}
 	return &factory{
 		config: params,
 	}
@@ -81,6 +89,8 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 		info:          hookInfo,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		clock:         f.config.Clock,
+		queued:        f.config.Clock.Now(),
 	}, nil
 }
 
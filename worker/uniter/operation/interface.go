@@ -138,6 +138,12 @@ type Callbacks interface {
 	NotifyHookCompleted(string, runner.Context)
 	NotifyHookFailed(string, runner.Context)
 
+	// RecordHookTiming records how long a hook waited to run, how
+	// long it ran for, and how it exited. It's only used by RunHook
+	// operations; failures are logged rather than returned, because
+	// they must not affect the hook's outcome.
+	RecordHookTiming(HookTiming)
+
 	// The following methods exist primarily to allow us to test operation code
 	// without using a live api connection.
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/correlation"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -17,8 +18,9 @@ type runAction struct {
 	callbacks     Callbacks
 	runnerFactory runner.Factory
 
-	name   string
-	runner runner.Runner
+	name          string
+	correlationId string
+	runner        runner.Runner
 
 	RequiresMachineLock
 }
@@ -54,6 +56,7 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 		return nil, errors.Trace(err)
 	}
 	ra.name = actionData.Name
+	ra.correlationId = actionData.CorrelationId
 	ra.runner = rnr
 	return stateChange{
 		Kind:     RunAction,
@@ -72,6 +75,9 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
+	logger := correlation.NewLogger(logger, ra.correlationId)
+	logger.Infof("running action %q (%s)", ra.name, ra.actionId)
+
 	err := ra.runner.RunAction(ra.name)
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
@@ -5,6 +5,7 @@ package operation_test
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -227,6 +228,28 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteLogsCorrelationId(c *gc.C) {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("runaction-tests", &tw), jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { loggo.RemoveWriter("runaction-tests") })
+
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	runnerFactory.MockNewActionRunner.runner.context.(*MockContext).actionData.CorrelationId = "deadbeef"
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Log(), jc.LogMatches, jc.SimpleMessages{{
+		loggo.INFO, `correlation-id=deadbeef: running action "some-action-name" \(` + someActionId + `\)`,
+	}})
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -5,8 +5,12 @@ package operation
 
 import (
 	"fmt"
+	"os/exec"
+	"syscall"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/status"
@@ -25,9 +29,32 @@ type runHook struct {
 	name   string
 	runner runner.Runner
 
+	clock  clock.Clock
+	queued time.Time
+
 	RequiresMachineLock
 }
 
+// HookTiming describes a single execution of a hook.
+type HookTiming struct {
+	// Hook is the name of the hook that was run.
+	Hook string
+
+	// Queued is the time at which the uniter decided to run the hook.
+	Queued time.Time
+
+	// Started is the time at which the hook started running, after
+	// the machine lock was acquired.
+	Started time.Time
+
+	// Finished is the time at which the hook exited.
+	Finished time.Time
+
+	// ExitCode is the exit code of the hook's process, or -1 if the
+	// hook failed without exiting.
+	ExitCode int
+}
+
 // String is part of the Operation interface.
 func (rh *runHook) String() string {
 	suffix := ""
@@ -91,7 +118,13 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
+	timing := HookTiming{
+		Hook:    rh.name,
+		Queued:  rh.queued,
+		Started: rh.clock.Now(),
+	}
 	err := rh.runner.RunHook(rh.name)
+	timing.Finished = rh.clock.Now()
 	cause := errors.Cause(err)
 	switch {
 	case context.IsMissingHookError(cause):
@@ -105,12 +138,15 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
+		timing.ExitCode = hookExitCode(cause)
+		rh.callbacks.RecordHookTiming(timing)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
 
 	if ranHook {
 		logger.Infof("ran %q hook", rh.name)
+		rh.callbacks.RecordHookTiming(timing)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
 		logger.Infof("skipped %q hook (missing)", rh.name)
@@ -129,6 +165,20 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// hookExitCode returns the exit code of a hook that failed with the
+// supplied error, or -1 if the hook's process did not exit.
+func hookExitCode(err error) int {
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return -1
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok {
+		return -1
+	}
+	return status.ExitStatus()
+}
+
 func (rh *runHook) beforeHook() error {
 	var err error
 	switch rh.info.Kind {
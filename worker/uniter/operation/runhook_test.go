@@ -4,12 +4,16 @@
 package operation_test
 
 import (
+	"os/exec"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -24,6 +28,8 @@ var _ = gc.Suite(&RunHookSuite{})
 
 type newHook func(operation.Factory, hook.Info) (operation.Operation, error)
 
+var hookTime = time.Date(2016, 10, 3, 12, 0, 0, 0, time.UTC)
+
 func (s *RunHookSuite) testPrepareHookError(
 	c *gc.C, newHook newHook, expectClearResolvedFlag, expectSkip bool,
 ) {
@@ -184,10 +190,12 @@ func (s *RunHookSuite) getExecuteRunnerTest(c *gc.C, newHook newHook, kind hooks
 		PrepareHookCallbacks:    NewPrepareHookCallbacks(),
 		MockNotifyHookCompleted: &MockNotify{},
 		MockNotifyHookFailed:    &MockNotify{},
+		MockRecordHookTiming:    &MockRecordHookTiming{},
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
 		Callbacks:     callbacks,
+		Clock:         coretesting.NewClock(hookTime),
 	})
 	op, err := newHook(factory, hook.Info{Kind: kind})
 	c.Assert(err, jc.ErrorIsNil)
@@ -212,6 +220,7 @@ func (s *RunHookSuite) TestExecuteMissingHookError(c *gc.C) {
 		c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
 		c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 		c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+		c.Assert(callbacks.MockRecordHookTiming.gotTimings, gc.HasLen, 0)
 
 		status, err := runnerFactory.MockNewHookRunner.runner.Context().UnitStatus()
 		c.Assert(err, jc.ErrorIsNil)
@@ -270,6 +279,25 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	c.Assert(callbacks.MockRecordHookTiming.gotTimings, jc.DeepEquals, []operation.HookTiming{{
+		Hook:     "some-hook-name",
+		Queued:   hookTime,
+		Started:  hookTime,
+		Finished: hookTime,
+		ExitCode: -1,
+	}})
+}
+
+func (s *RunHookSuite) TestExecuteExitError(c *gc.C) {
+	runErr := errors.Trace(exec.Command("sh", "-c", "exit 3").Run())
+	op, callbacks, _ := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(callbacks.MockRecordHookTiming.gotTimings, gc.HasLen, 1)
+	c.Assert(callbacks.MockRecordHookTiming.gotTimings[0].ExitCode, gc.Equals, 3)
 }
 
 func (s *RunHookSuite) testExecuteSuccess(
@@ -285,6 +313,12 @@ func (s *RunHookSuite) testExecuteSuccess(
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(newState, gc.DeepEquals, &after)
 	c.Check(callbacks.executingMessage, gc.Equals, "running some-hook-name hook")
+	c.Check(callbacks.MockRecordHookTiming.gotTimings, jc.DeepEquals, []operation.HookTiming{{
+		Hook:     "some-hook-name",
+		Queued:   hookTime,
+		Started:  hookTime,
+		Finished: hookTime,
+	}})
 }
 
 func (s *RunHookSuite) TestExecuteSuccess_BlankSlate(c *gc.C) {
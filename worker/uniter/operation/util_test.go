@@ -181,10 +181,23 @@ func (mock *MockNotify) Call(hookName string, ctx runner.Context) {
 	mock.gotContext = &ctx
 }
 
+type MockRecordHookTiming struct {
+	gotTimings []operation.HookTiming
+}
+
+func (mock *MockRecordHookTiming) Call(timing operation.HookTiming) {
+	mock.gotTimings = append(mock.gotTimings, timing)
+}
+
 type ExecuteHookCallbacks struct {
 	*PrepareHookCallbacks
 	MockNotifyHookCompleted *MockNotify
 	MockNotifyHookFailed    *MockNotify
+	MockRecordHookTiming    *MockRecordHookTiming
+}
+
+func (cb *ExecuteHookCallbacks) RecordHookTiming(timing operation.HookTiming) {
+	cb.MockRecordHookTiming.Call(timing)
 }
 
 func (cb *ExecuteHookCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
@@ -15,9 +15,10 @@ import (
 
 // Relationer manages a unit's presence in a relation.
 type Relationer struct {
-	ru    *apiuniter.RelationUnit
-	dir   *StateDir
-	dying bool
+	ru      *apiuniter.RelationUnit
+	dir     *StateDir
+	dying   bool
+	leaving bool
 }
 
 // NewRelationer creates a new Relationer. The unit will not join the
@@ -75,6 +76,20 @@ func (r *Relationer) SetDying() error {
 	return nil
 }
 
+// PrepareLeave causes the unit to be reported as departed to its
+// counterparts, without leaving relation scope, so that they run
+// relation-departed for it before it runs relation-broken.
+func (r *Relationer) PrepareLeave() error {
+	if r.leaving {
+		return nil
+	}
+	if err := r.ru.PrepareLeaveScope(); err != nil {
+		return err
+	}
+	r.leaving = true
+	return nil
+}
+
 // die is run when the relationer has no further responsibilities; it leaves
 // relation scope, and removes the local relation state directory.
 func (r *Relationer) die() error {
@@ -104,8 +119,19 @@ func (r *Relationer) CommitHook(hi hook.Info) error {
 	if r.IsImplicit() {
 		panic("implicit relations must not run hooks")
 	}
-	if hi.Kind == hooks.RelationBroken {
+	// The counterpart is recorded before the local state, so that
+	// a failure to record it causes the hook to be run again.
+	switch hi.Kind {
+	case hooks.RelationBroken:
 		return r.die()
+	case hooks.RelationJoined:
+		if err := r.ru.SetCounterpartJoined(hi.RemoteUnit); err != nil {
+			return err
+		}
+	case hooks.RelationDeparted:
+		if err := r.ru.SetCounterpartDeparted(hi.RemoteUnit); err != nil {
+			return err
+		}
 	}
 	return r.dir.Write(hi)
 }
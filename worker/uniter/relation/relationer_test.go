@@ -245,6 +245,63 @@ func (s *RelationerSuite) TestPrepareCommitHooks(c *gc.C) {
 	assertMembers(map[string]int64{"u/1": 7, "u/2": 3})
 }
 
+func (s *RelationerSuite) TestCommitHookRecordsCounterparts(c *gc.C) {
+	ru1, _ := s.AddRelationUnit(c, "u/1")
+	err := ru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	r := relation.NewRelationer(s.apiRelUnit, s.dir)
+	err = r.Join()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = r.CommitHook(hook.Info{Kind: hooks.RelationJoined, RemoteUnit: "u/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := ru1.PendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.DeepEquals, []string{"u/0"})
+
+	err = r.CommitHook(hook.Info{Kind: hooks.RelationDeparted, RemoteUnit: "u/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err = ru1.PendingDepartures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+}
+
+func (s *RelationerSuite) TestPrepareLeave(c *gc.C) {
+	ru1, _ := s.AddRelationUnit(c, "u/1")
+	err := ru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	r := relation.NewRelationer(s.apiRelUnit, s.dir)
+	err = r.Join()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := ru1.Watch()
+	defer stop(c, w)
+	s.State.StartSync()
+	ch, ok := <-w.Changes()
+	c.Assert(ok, jc.IsTrue)
+	_, found := ch.Changed["u/0"]
+	c.Assert(found, jc.IsTrue)
+
+	// u/0 prepares to leave; u/1 observes it depart, but it
+	// remains in scope.
+	err = r.PrepareLeave()
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	select {
+	case ch, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(ch.Changed, gc.HasLen, 0)
+		c.Assert(ch.Departed, gc.DeepEquals, []string{"u/0"})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for absence detection")
+	}
+	units, err := s.rel.ScopeUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	c.Assert(units[0].Unit, gc.Equals, "u/0")
+	c.Assert(units[0].Departing, jc.IsTrue)
+}
+
 func (s *RelationerSuite) TestSetDying(c *gc.C) {
 	ru1, u := s.AddRelationUnit(c, "u/1")
 	settings := map[string]interface{}{"unit": "settings"}
@@ -190,6 +190,7 @@ func (r *relations) NextHook(
 			continue
 		}
 		var remoteBroken bool
+		pendingDepartures := relationSnapshot.PendingDepartures
 		if remoteState.Life == params.Dying || relationSnapshot.Life == params.Dying {
 			relationSnapshot = remotestate.RelationSnapshot{}
 			remoteBroken = true
@@ -197,11 +198,27 @@ func (r *relations) NextHook(
 		}
 		// If either the unit or the relation are Dying,
 		// then the relation should be broken.
-		hook, err := nextRelationHook(relationer.dir.State(), relationSnapshot, remoteBroken)
+		nextHook, err := nextRelationHook(relationer.dir.State(), relationSnapshot, remoteBroken)
 		if err == resolver.ErrNoOperation {
 			continue
 		}
-		return hook, err
+		if err == nil && nextHook.Kind == hooks.RelationBroken && len(pendingDepartures) > 0 {
+			// Counterparts which joined the unit must run
+			// relation-departed for it before it breaks the
+			// relation; make sure they can see it depart, and
+			// wait for them. Counterparts which stop being alive,
+			// or whose agents fail, drop out of the pending set,
+			// so the wait cannot outlast them.
+			if err := relationer.PrepareLeave(); err != nil {
+				return hook.Info{}, errors.Trace(err)
+			}
+			logger.Debugf(
+				"relation %d: waiting for %v to depart before running relation-broken",
+				relationId, pendingDepartures,
+			)
+			continue
+		}
+		return nextHook, err
 	}
 	return hook.Info{}, resolver.ErrNoOperation
 }
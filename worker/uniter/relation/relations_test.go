@@ -237,10 +237,18 @@ func relationJoinedApiCalls() []apiCall {
 		uniterApiCall("Watch", unitEntity, params.NotifyWatchResults{Results: []params.NotifyWatchResult{{NotifyWatcherId: "1"}}}, nil),
 		uniterApiCall("EnterScope", relationUnits, params.ErrorResults{Results: []params.ErrorResult{{}}}, nil),
 		uniterApiCall("GetPrincipal", unitEntity, params.StringBoolResults{Results: []params.StringBoolResult{{Result: "", Ok: false}}}, nil),
+		setCounterpartApiCall("SetCounterpartJoined"),
 	}
 	return apiCalls
 }
 
+func setCounterpartApiCall(method string) apiCall {
+	relationUnitPairs := params.RelationUnitPairs{RelationUnitPairs: []params.RelationUnitPair{
+		{Relation: "relation-wordpress.db#mysql.db", LocalUnit: "unit-wordpress-0", RemoteUnit: "unit-mysql-0"},
+	}}
+	return uniterApiCall(method, relationUnitPairs, params.ErrorResults{Results: []params.ErrorResult{{}}}, nil)
+}
+
 func (s *relationsSuite) assertHookRelationJoined(c *gc.C, numCalls *int32, apiCalls ...apiCall) relation.Relations {
 	unitTag := names.NewUnitTag("wordpress/0")
 	abort := make(chan struct{})
@@ -261,7 +269,7 @@ func (s *relationsSuite) assertHookRelationJoined(c *gc.C, numCalls *int32, apiC
 			1: remotestate.RelationSnapshot{
 				Life: params.Alive,
 				Members: map[string]int64{
-					"wordpress": 1,
+					"mysql/0": 1,
 				},
 			},
 		},
@@ -336,25 +344,25 @@ func (s *relationsSuite) TestHookRelationChanged(c *gc.C) {
 		Life: params.Alive,
 	}, &numCalls)
 
-	// wordpress starts at 1, changing to 2 should trigger a
+	// mysql/0 starts at 1, changing to 2 should trigger a
 	// relation-changed hook.
 	s.assertHookRelationChanged(c, r, remotestate.RelationSnapshot{
 		Life: params.Alive,
 		Members: map[string]int64{
-			"wordpress": 2,
+			"mysql/0": 2,
 		},
 	}, &numCalls)
 
 	// NOTE(axw) this is a test for the temporary to fix lp:1495542.
 	//
-	// wordpress is at 2, changing to 1 should trigger a
+	// mysql/0 is at 2, changing to 1 should trigger a
 	// relation-changed hook. This is to cater for the scenario
 	// where the relation settings document is removed and
 	// recreated, thus resetting the txn-revno.
 	s.assertHookRelationChanged(c, r, remotestate.RelationSnapshot{
 		Life: params.Alive,
 		Members: map[string]int64{
-			"wordpress": 1,
+			"mysql/0": 1,
 		},
 	}, &numCalls)
 }
@@ -376,7 +384,7 @@ func (s *relationsSuite) assertHookRelationDeparted(c *gc.C, numCalls *int32, ap
 			1: remotestate.RelationSnapshot{
 				Life: params.Dying,
 				Members: map[string]int64{
-					"wordpress": 1,
+					"mysql/0": 1,
 				},
 			},
 		},
@@ -400,6 +408,7 @@ func (s *relationsSuite) TestHookRelationDeparted(c *gc.C) {
 	apiCalls := relationJoinedApiCalls()
 
 	apiCalls = append(apiCalls, getPrincipalApiCalls(2)...)
+	apiCalls = append(apiCalls, setCounterpartApiCall("SetCounterpartDeparted"))
 	s.assertHookRelationDeparted(c, &numCalls, apiCalls...)
 }
 
@@ -407,7 +416,9 @@ func (s *relationsSuite) TestHookRelationBroken(c *gc.C) {
 	var numCalls int32
 	apiCalls := relationJoinedApiCalls()
 
-	apiCalls = append(apiCalls, getPrincipalApiCalls(3)...)
+	apiCalls = append(apiCalls, getPrincipalApiCalls(2)...)
+	apiCalls = append(apiCalls, setCounterpartApiCall("SetCounterpartDeparted"))
+	apiCalls = append(apiCalls, getPrincipalApiCalls(1)...)
 	r := s.assertHookRelationDeparted(c, &numCalls, apiCalls...)
 
 	localState := resolver.LocalState{
@@ -425,7 +436,53 @@ func (s *relationsSuite) TestHookRelationBroken(c *gc.C) {
 	relationsResolver := relation.NewRelationsResolver(r)
 	op, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
 	c.Assert(err, jc.ErrorIsNil)
-	assertNumCalls(c, &numCalls, 11)
+	assertNumCalls(c, &numCalls, 13)
+	c.Assert(op.String(), gc.Equals, "run hook relation-broken on unit with relation 1")
+}
+
+func (s *relationsSuite) TestHookRelationBrokenWaitsForDepartures(c *gc.C) {
+	var numCalls int32
+	apiCalls := relationJoinedApiCalls()
+	relationUnits := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: "relation-wordpress.db#mysql.db", Unit: "unit-wordpress-0"},
+	}}
+
+	apiCalls = append(apiCalls, getPrincipalApiCalls(2)...)
+	apiCalls = append(apiCalls, setCounterpartApiCall("SetCounterpartDeparted"))
+	apiCalls = append(apiCalls, getPrincipalApiCalls(1)...)
+	apiCalls = append(apiCalls,
+		uniterApiCall("PrepareLeaveScope", relationUnits, params.ErrorResults{Results: []params.ErrorResult{{}}}, nil),
+	)
+	apiCalls = append(apiCalls, getPrincipalApiCalls(2)...)
+	r := s.assertHookRelationDeparted(c, &numCalls, apiCalls...)
+
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{
+			1: remotestate.RelationSnapshot{
+				Life:              params.Dying,
+				PendingDepartures: []string{"mysql/0"},
+			},
+		},
+	}
+	relationsResolver := relation.NewRelationsResolver(r)
+	_, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+	assertNumCalls(c, &numCalls, 14)
+
+	// The unit is only prepared to leave scope once.
+	_, err = relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+	assertNumCalls(c, &numCalls, 15)
+
+	remoteState.Relations[1] = remotestate.RelationSnapshot{Life: params.Dying}
+	op, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	assertNumCalls(c, &numCalls, 16)
 	c.Assert(op.String(), gc.Equals, "run hook relation-broken on unit with relation 1")
 }
 
@@ -436,9 +493,10 @@ func (s *relationsSuite) TestCommitHook(c *gc.C) {
 		{Relation: "relation-wordpress.db#mysql.db", Unit: "unit-wordpress-0"},
 	}}
 	apiCalls = append(apiCalls,
+		setCounterpartApiCall("SetCounterpartDeparted"),
 		uniterApiCall("LeaveScope", relationUnits, params.ErrorResults{Results: []params.ErrorResult{{}}}, nil),
 	)
-	stateFile := filepath.Join(s.relationsDir, "1", "wordpress")
+	stateFile := filepath.Join(s.relationsDir, "1", "mysql-0")
 	c.Assert(stateFile, jc.DoesNotExist)
 	r := s.assertHookRelationJoined(c, &numCalls, apiCalls...)
 
@@ -448,7 +506,7 @@ func (s *relationsSuite) TestCommitHook(c *gc.C) {
 
 	err = r.CommitHook(hook.Info{
 		Kind:          hooks.RelationChanged,
-		RemoteUnit:    "wordpress",
+		RemoteUnit:    "mysql/0",
 		RelationId:    1,
 		ChangeVersion: 2,
 	})
@@ -459,7 +517,7 @@ func (s *relationsSuite) TestCommitHook(c *gc.C) {
 
 	err = r.CommitHook(hook.Info{
 		Kind:       hooks.RelationDeparted,
-		RemoteUnit: "wordpress",
+		RemoteUnit: "mysql/0",
 		RelationId: 1,
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -513,7 +571,7 @@ func (s *relationsSuite) TestImplicitRelationNoHooks(c *gc.C) {
 			1: remotestate.RelationSnapshot{
 				Life: params.Alive,
 				Members: map[string]int64{
-					"wordpress": 1,
+					"mysql/0": 1,
 				},
 			},
 		},
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/remotestate"
 )
@@ -102,6 +103,20 @@ type mockState struct {
 	storageAttachment         map[params.StorageAttachmentId]params.StorageAttachment
 	relationUnitsWatchers     map[names.RelationTag]*mockRelationUnitsWatcher
 	storageAttachmentWatchers map[names.StorageTag]*mockNotifyWatcher
+	pendingDepartures         map[names.RelationTag][]string
+	pendingDeparturesWatchers map[names.RelationTag]*mockNotifyWatcher
+}
+
+func (st *mockState) PendingDepartures(
+	relationTag names.RelationTag, unitTag names.UnitTag,
+) ([]string, error) {
+	if unitTag != st.unit.tag {
+		return nil, &params.Error{Code: params.CodeNotFound}
+	}
+	if _, ok := st.relations[relationTag]; !ok {
+		return nil, &params.Error{Code: params.CodeNotFound}
+	}
+	return st.pendingDepartures[relationTag], nil
 }
 
 func (st *mockState) Relation(tag names.RelationTag) (remotestate.Relation, error) {
@@ -168,6 +183,22 @@ func (st *mockState) WatchRelationUnits(
 	return watcher, nil
 }
 
+func (st *mockState) WatchPendingDepartures(
+	relationTag names.RelationTag, unitTag names.UnitTag,
+) (watcher.NotifyWatcher, error) {
+	if unitTag != st.unit.tag {
+		return nil, &params.Error{Code: params.CodeNotFound}
+	}
+	watcher, ok := st.pendingDeparturesWatchers[relationTag]
+	if !ok {
+		// Tests which are not concerned with pending
+		// departures need not supply a watcher.
+		watcher = newMockNotifyWatcher()
+		st.pendingDeparturesWatchers[relationTag] = watcher
+	}
+	return watcher, nil
+}
+
 func (st *mockState) WatchStorageAttachment(
 	storageTag names.StorageTag, unitTag names.UnitTag,
 ) (watcher.NotifyWatcher, error) {
@@ -191,6 +222,9 @@ type mockUnit struct {
 	configSettingsWatcher *mockNotifyWatcher
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
+	upgradeSeriesWatcher  *mockNotifyWatcher
+	maintenance           bool
+	upgradeSeriesStatus   upgradeseries.Status
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -233,6 +267,18 @@ func (u *mockUnit) WatchActionNotifications() (watcher.StringsWatcher, error) {
 	return u.actionWatcher, nil
 }
 
+func (u *mockUnit) MachineInMaintenance() (bool, error) {
+	return u.maintenance, nil
+}
+
+func (u *mockUnit) UpgradeSeriesStatus() (upgradeseries.Status, error) {
+	return u.upgradeSeriesStatus, nil
+}
+
+func (u *mockUnit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	return u.upgradeSeriesWatcher, nil
+}
+
 type mockService struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
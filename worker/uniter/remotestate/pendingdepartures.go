@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remotestate
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+type PendingDeparturesAccessor interface {
+	// PendingDepartures returns the names of the counterpart units
+	// which have yet to run relation-departed for the unit in the
+	// relation.
+	PendingDepartures(names.RelationTag, names.UnitTag) ([]string, error)
+}
+
+// pendingDeparturesWatcher watches for changes to the pending departures
+// of a unit in a relation, and sends them to the specified channel.
+type pendingDeparturesWatcher struct {
+	catacomb catacomb.Catacomb
+
+	st          PendingDeparturesAccessor
+	changes     watcher.NotifyChannel
+	relationId  int
+	relationTag names.RelationTag
+	unitTag     names.UnitTag
+	out         chan<- pendingDeparturesChange
+}
+
+type pendingDeparturesChange struct {
+	relationId int
+	pending    []string
+}
+
+// newPendingDeparturesWatcher creates a new worker that wakes on input
+// from the supplied watcher's Changes chan, reads the unit's pending
+// departures, and delivers them on the supplied out chan.
+//
+// The caller releases responsibility for stopping the supplied watcher and
+// waiting for errors, *whether or not this method succeeds*.
+func newPendingDeparturesWatcher(
+	st PendingDeparturesAccessor,
+	watcher watcher.NotifyWatcher,
+	relationId int,
+	relationTag names.RelationTag,
+	unitTag names.UnitTag,
+	out chan<- pendingDeparturesChange,
+) (*pendingDeparturesWatcher, error) {
+	w := &pendingDeparturesWatcher{
+		st:          st,
+		changes:     watcher.Changes(),
+		relationId:  relationId,
+		relationTag: relationTag,
+		unitTag:     unitTag,
+		out:         out,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{watcher},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *pendingDeparturesWatcher) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-w.changes:
+			if !ok {
+				return errors.New("pending departures watcher closed")
+			}
+			pending, err := w.st.PendingDepartures(w.relationTag, w.unitTag)
+			if params.IsCodeNotFoundOrCodeUnauthorized(err) {
+				// The relation was removed, so we
+				// can stop watching.
+				return nil
+			} else if err != nil {
+				return errors.Annotate(err, "refreshing pending departures")
+			}
+			change := pendingDeparturesChange{w.relationId, pending}
+			select {
+			case <-w.catacomb.Dying():
+				return w.catacomb.ErrDying()
+			case w.out <- change:
+			}
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *pendingDeparturesWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *pendingDeparturesWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 )
 
 // Snapshot is a snapshot of the remote state of the unit.
@@ -61,6 +62,14 @@ type Snapshot struct {
 	// update-status hook is supposed to run.
 	UpdateStatusVersion int
 
+	// MachineInMaintenance is true if the unit's machine was in
+	// maintenance mode when the update-status hook was last due.
+	MachineInMaintenance bool
+
+	// UpgradeSeriesStatus is the unit's progress through the
+	// series upgrade of its machine, if any.
+	UpgradeSeriesStatus upgradeseries.Status
+
 	// Actions is the list of pending actions to
 	// be peformed by this unit.
 	Actions []string
@@ -73,6 +82,12 @@ type Snapshot struct {
 type RelationSnapshot struct {
 	Life    params.Life
 	Members map[string]int64
+
+	// PendingDepartures holds the names of the alive counterpart
+	// units, with agents not in error, which have joined the unit in
+	// the relation, and not yet run relation-departed for it. The unit
+	// must not run relation-broken while any remain.
+	PendingDepartures []string
 }
 
 // StorageSnapshot has information relating to a storage
@@ -9,14 +9,17 @@ import (
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/watcher"
 )
 
 type State interface {
+	PendingDepartures(names.RelationTag, names.UnitTag) ([]string, error)
 	Relation(names.RelationTag) (Relation, error)
 	StorageAttachment(names.StorageTag, names.UnitTag) (params.StorageAttachment, error)
 	StorageAttachmentLife([]params.StorageAttachmentId) ([]params.LifeResult, error)
 	Unit(names.UnitTag) (Unit, error)
+	WatchPendingDepartures(names.RelationTag, names.UnitTag) (watcher.NotifyWatcher, error)
 	WatchRelationUnits(names.RelationTag, names.UnitTag) (watcher.RelationUnitsWatcher, error)
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) (watcher.NotifyWatcher, error)
 }
@@ -32,6 +35,9 @@ type Unit interface {
 	WatchConfigSettings() (watcher.NotifyWatcher, error)
 	WatchStorage() (watcher.StringsWatcher, error)
 	WatchActionNotifications() (watcher.StringsWatcher, error)
+	MachineInMaintenance() (bool, error)
+	UpgradeSeriesStatus() (upgradeseries.Status, error)
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
 }
 
 type Application interface {
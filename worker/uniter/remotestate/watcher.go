@@ -29,6 +29,8 @@ type RemoteStateWatcher struct {
 	service                   Application
 	relations                 map[names.RelationTag]*relationUnitsWatcher
 	relationUnitsChanges      chan relationUnitsChange
+	pendingDepartures         map[names.RelationTag]*pendingDeparturesWatcher
+	pendingDeparturesChanges  chan pendingDeparturesChange
 	storageAttachmentWatchers map[names.StorageTag]*storageAttachmentWatcher
 	storageAttachmentChanges  chan storageAttachmentChange
 	leadershipTracker         leadership.Tracker
@@ -61,6 +63,8 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		st:                        config.State,
 		relations:                 make(map[names.RelationTag]*relationUnitsWatcher),
 		relationUnitsChanges:      make(chan relationUnitsChange),
+		pendingDepartures:         make(map[names.RelationTag]*pendingDeparturesWatcher),
+		pendingDeparturesChanges:  make(chan pendingDeparturesChange),
 		storageAttachmentWatchers: make(map[names.StorageTag]*storageAttachmentWatcher),
 		storageAttachmentChanges:  make(chan storageAttachmentChange),
 		leadershipTracker:         config.LeadershipTracker,
@@ -116,6 +120,10 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 		for name, version := range relationSnapshot.Members {
 			relationSnapshotCopy.Members[name] = version
 		}
+		if len(relationSnapshot.PendingDepartures) > 0 {
+			relationSnapshotCopy.PendingDepartures = make([]string, len(relationSnapshot.PendingDepartures))
+			copy(relationSnapshotCopy.PendingDepartures, relationSnapshot.PendingDepartures)
+		}
 		snapshot.Relations[id] = relationSnapshotCopy
 	}
 	snapshot.Storage = make(map[names.StorageTag]StorageSnapshot)
@@ -256,6 +264,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenUpgradeSeriesChange bool
+	upgradeSeriesw, err := w.unit.WatchUpgradeSeriesNotifications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(upgradeSeriesw); err != nil {
+		return errors.Trace(err)
+	}
+	requiredEvents++
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -364,6 +382,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenActionsChange)
 
+		case _, ok := <-upgradeSeriesw.Changes():
+			logger.Debugf("got upgrade series change: ok=%t", ok)
+			if !ok {
+				return errors.New("upgrade series watcher closed")
+			}
+			if err := w.upgradeSeriesChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenUpgradeSeriesChange)
+
 		case keys, ok := <-relationsw.Changes():
 			logger.Debugf("got relations change: ok=%t", ok)
 			if !ok {
@@ -412,6 +440,12 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 				return errors.Trace(err)
 			}
 
+		case change := <-w.pendingDeparturesChanges:
+			logger.Debugf("got a pending departures change: %v", change)
+			if err := w.pendingDeparturesChanged(change); err != nil {
+				return errors.Trace(err)
+			}
+
 		case <-w.updateStatusChannel():
 			logger.Debugf("update status timer triggered")
 			if err := w.updateStatusChanged(); err != nil {
@@ -444,8 +478,29 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 
 // updateStatusChanged is called when the update status timer expires.
 func (w *RemoteStateWatcher) updateStatusChanged() error {
+	inMaintenance, err := w.unit.MachineInMaintenance()
+	if params.IsCodeNotImplemented(err) {
+		// The controller predates maintenance mode.
+		inMaintenance = false
+	} else if err != nil {
+		return errors.Annotate(err, "checking machine maintenance mode")
+	}
 	w.mu.Lock()
 	w.current.UpdateStatusVersion++
+	w.current.MachineInMaintenance = inMaintenance
+	w.mu.Unlock()
+	return nil
+}
+
+// upgradeSeriesChanged is called when the series upgrade of the unit's
+// machine changes.
+func (w *RemoteStateWatcher) upgradeSeriesChanged() error {
+	status, err := w.unit.UpgradeSeriesStatus()
+	if err != nil {
+		return errors.Annotate(err, "getting series upgrade status")
+	}
+	w.mu.Lock()
+	w.current.UpgradeSeriesStatus = status
 	w.mu.Unlock()
 	return nil
 }
@@ -546,6 +601,10 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 				delete(w.relations, relationTag)
 				delete(w.current.Relations, ruw.relationId)
 			}
+			if pdw, ok := w.pendingDepartures[relationTag]; ok {
+				worker.Stop(pdw)
+				delete(w.pendingDepartures, relationTag)
+			}
 		} else if err != nil {
 			return errors.Trace(err)
 		} else {
@@ -568,6 +627,9 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 			if err := w.watchRelationUnits(rel, relationTag, ruw); err != nil {
 				return errors.Trace(err)
 			}
+			if err := w.watchPendingDepartures(rel, relationTag); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 	return nil
@@ -623,6 +685,40 @@ func (w *RemoteStateWatcher) relationUnitsChanged(change relationUnitsChange) er
 	return nil
 }
 
+// watchPendingDepartures starts watching the unit's pending departures
+// in the given relation. Changes are recorded in the current snapshot
+// as they arrive.
+func (w *RemoteStateWatcher) watchPendingDepartures(rel Relation, relationTag names.RelationTag) error {
+	pdw, err := w.st.WatchPendingDepartures(relationTag, w.unit.Tag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	innerPDW, err := newPendingDeparturesWatcher(
+		w.st, pdw, rel.Id(), relationTag, w.unit.Tag(), w.pendingDeparturesChanges,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(innerPDW); err != nil {
+		return errors.Trace(err)
+	}
+	w.pendingDepartures[relationTag] = innerPDW
+	return nil
+}
+
+// pendingDeparturesChanged responds to pending departures changes.
+func (w *RemoteStateWatcher) pendingDeparturesChanged(change pendingDeparturesChange) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	snapshot, ok := w.current.Relations[change.relationId]
+	if !ok {
+		return nil
+	}
+	snapshot.PendingDepartures = change.pending
+	w.current.Relations[change.relationId] = snapshot
+	return nil
+}
+
 // storageAttachmentChanged responds to storage attachment changes.
 func (w *RemoteStateWatcher) storageAttachmentChanged(change storageAttachmentChange) error {
 	w.mu.Lock()
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -52,11 +53,14 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			configSettingsWatcher: newMockNotifyWatcher(),
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
+			upgradeSeriesWatcher:  newMockNotifyWatcher(),
 		},
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
 		relationUnitsWatchers:     make(map[names.RelationTag]*mockRelationUnitsWatcher),
 		storageAttachmentWatchers: make(map[names.StorageTag]*mockNotifyWatcher),
+		pendingDepartures:         make(map[names.RelationTag][]string),
+		pendingDeparturesWatchers: make(map[names.RelationTag]*mockNotifyWatcher),
 	}
 
 	s.leadership = &mockLeadershipTracker{
@@ -106,6 +110,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.configSettingsWatcher.changes <- struct{}{}
 	s.st.unit.storageWatcher.changes <- []string{}
 	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 	s.st.unit.service.serviceWatcher.changes <- struct{}{}
 	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.service.relationsWatcher.changes <- []string{}
@@ -119,6 +124,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.configSettingsWatcher.changes <- struct{}{}
 	st.unit.storageWatcher.changes <- []string{}
 	st.unit.actionWatcher.changes <- []string{}
+	st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 	st.unit.service.serviceWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.service.relationsWatcher.changes <- []string{}
@@ -460,6 +466,36 @@ func (s *WatcherSuite) TestRelationsChanged(c *gc.C) {
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
 	c.Assert(s.watcher.Snapshot().Relations, gc.HasLen, 0)
 	c.Assert(s.st.relationUnitsWatchers[relationTag].Stopped(), jc.IsTrue)
+	c.Assert(s.st.pendingDeparturesWatchers[relationTag].Stopped(), jc.IsTrue)
+}
+
+func (s *WatcherSuite) TestPendingDeparturesChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	relationTag := names.NewRelationTag("mysql:peer")
+	s.st.relations[relationTag] = &mockRelation{
+		id: 123, life: params.Alive,
+	}
+	s.st.relationUnitsWatchers[relationTag] = newMockRelationUnitsWatcher()
+	s.st.pendingDeparturesWatchers[relationTag] = newMockNotifyWatcher()
+
+	s.st.unit.service.relationsWatcher.changes <- []string{relationTag.Id()}
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {1}},
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].PendingDepartures, gc.HasLen, 0)
+
+	s.st.pendingDepartures[relationTag] = []string{"mysql/1"}
+	s.st.pendingDeparturesWatchers[relationTag].changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].PendingDepartures, jc.DeepEquals, []string{"mysql/1"})
+
+	s.st.pendingDepartures[relationTag] = nil
+	s.st.pendingDeparturesWatchers[relationTag].changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].PendingDepartures, gc.HasLen, 0)
 }
 
 func (s *WatcherSuite) TestRelationUnitsChanged(c *gc.C) {
@@ -547,6 +583,29 @@ func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
 
+func (s *WatcherSuite) TestUpdateStatusTickerMachineInMaintenance(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().MachineInMaintenance, jc.IsFalse)
+
+	s.st.unit.maintenance = true
+	s.waitAlarmsStable(c)
+	s.clock.Advance(11 * time.Second)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().MachineInMaintenance, jc.IsTrue)
+}
+
+func (s *WatcherSuite) TestUpgradeSeriesStatusChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpgradeSeriesStatus, gc.Equals, upgradeseries.Status(""))
+
+	s.st.unit.upgradeSeriesStatus = upgradeseries.PrepareStarted
+	s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpgradeSeriesStatus, gc.Equals, upgradeseries.PrepareStarted)
+}
+
 // waitAlarmsStable is used to wait until the remote watcher's loop has
 // stopped churning (at least for testing.ShortWait), so that we can
 // then Advance the clock with some confidence that the SUT really is
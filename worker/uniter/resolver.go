@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -271,13 +272,28 @@ func (s *uniterResolver) nextOp(
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
 	}
 
+	switch remoteState.UpgradeSeriesStatus {
+	case upgradeseries.PrepareStarted:
+		if localState.UpgradeSeriesStatus != upgradeseries.PrepareCompleted {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PreSeriesUpgrade})
+		}
+	case upgradeseries.CompleteStarted:
+		if localState.UpgradeSeriesStatus != upgradeseries.Completed {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PostSeriesUpgrade})
+		}
+	}
+
 	op, err := s.config.Relations.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
 	}
 
-	// UpdateStatus hook runs if nothing else needs to.
-	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
+	// UpdateStatus hook runs if nothing else needs to, and is
+	// skipped while the unit's machine is in maintenance mode, or
+	// the unit has been prepared for its machine's series upgrade.
+	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion &&
+		!remoteState.MachineInMaintenance &&
+		remoteState.UpgradeSeriesStatus != upgradeseries.PrepareCompleted {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
 	}
 
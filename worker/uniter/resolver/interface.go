@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
 )
@@ -90,6 +91,11 @@ type LocalState struct {
 	// been committed.
 	LeaderSettingsVersion int
 
+	// UpgradeSeriesStatus is the status of the series upgrade of the
+	// unit's machine for which a pre-series-upgrade or
+	// post-series-upgrade hook has been committed.
+	UpgradeSeriesStatus upgradeseries.Status
+
 	// CompletedActions is the set of actions that have been completed.
 	// This is used to prevent us re running actions requested by the
 	// controller.
@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/utils/os"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ExecutionBackend creates the processes in which hooks and actions
+// are run. A backend may run them within a confinement layer, limiting
+// the damage a misbehaving charm hook can do to the rest of the machine.
+type ExecutionBackend interface {
+	// Command returns a command that runs the given command line with
+	// the given environment, in the given working directory.
+	Command(args, env []string, dir string) (*exec.Cmd, error)
+}
+
+// DirectBackend is an ExecutionBackend that runs hooks directly, as
+// the unit agent's user and without any resource limits.
+var DirectBackend ExecutionBackend = directBackend{}
+
+type directBackend struct{}
+
+// Command is part of the ExecutionBackend interface.
+func (directBackend) Command(args, env []string, dir string) (*exec.Cmd, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no command specified")
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = env
+	cmd.Dir = dir
+	return cmd, nil
+}
+
+const (
+	systemdRunCommand = "systemd-run"
+	lxcCommand        = "lxc"
+)
+
+// sandboxLookPath is used to find the commands a sandbox relies on.
+var sandboxLookPath = exec.LookPath
+
+// NewExecutionBackend returns an ExecutionBackend that runs hooks in
+// the given sandbox. A user, memory limit or CPU quota runs each hook in
+// a transient systemd scope, as that user and in a cgroup with those
+// limits; the user must be able to read the charm directory and connect
+// to the jujuc socket. An LXD container runs each hook with "lxc exec"
+// in that container, which must share the charm directory and jujuc
+// socket at the same paths as the host. An empty sandbox runs hooks
+// directly.
+func NewExecutionBackend(sandbox params.HookSandbox) (ExecutionBackend, error) {
+	if sandbox == (params.HookSandbox{}) {
+		return DirectBackend, nil
+	}
+	if jujuos.HostOS() == jujuos.Windows {
+		return nil, errors.NotSupportedf("hook sandbox on windows")
+	}
+	backend := &sandboxBackend{sandbox: sandbox}
+	if backend.confined() {
+		if _, err := sandboxLookPath(systemdRunCommand); err != nil {
+			return nil, errors.Annotatef(err, "hook sandbox requires %q", systemdRunCommand)
+		}
+	}
+	if sandbox.LXDContainer != "" {
+		if _, err := sandboxLookPath(lxcCommand); err != nil {
+			return nil, errors.Annotatef(err, "hook sandbox requires %q", lxcCommand)
+		}
+	}
+	return backend, nil
+}
+
+// sandboxBackend is an ExecutionBackend that runs hooks in a sandbox.
+type sandboxBackend struct {
+	sandbox params.HookSandbox
+}
+
+// Command is part of the ExecutionBackend interface.
+func (b *sandboxBackend) Command(args, env []string, dir string) (*exec.Cmd, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no command specified")
+	}
+	args = b.sandboxArgs(args, env, dir)
+	logger.Debugf("running hook in sandbox: %q", args)
+	return directBackend{}.Command(args, env, dir)
+}
+
+// confined returns whether hooks run in a systemd scope.
+func (b *sandboxBackend) confined() bool {
+	return b.sandbox.User != "" || b.sandbox.MemoryLimit > 0 || b.sandbox.CPUQuota > 0
+}
+
+// sandboxArgs returns the command line running the given command line
+// in the sandbox.
+func (b *sandboxBackend) sandboxArgs(args, env []string, dir string) []string {
+	if b.confined() {
+		scope := []string{systemdRunCommand, "--scope", "--quiet"}
+		if b.sandbox.User != "" {
+			scope = append(scope, "--uid="+b.sandbox.User)
+		}
+		if b.sandbox.MemoryLimit > 0 {
+			scope = append(scope, "-p", fmt.Sprintf("MemoryLimit=%dM", b.sandbox.MemoryLimit))
+		}
+		if b.sandbox.CPUQuota > 0 {
+			scope = append(scope, "-p", fmt.Sprintf("CPUQuota=%d%%", b.sandbox.CPUQuota))
+		}
+		args = append(append(scope, "--"), args...)
+	}
+	if b.sandbox.LXDContainer != "" {
+		// The environment and working directory of lxc are not
+		// passed into the container, so they are set explicitly.
+		lxc := []string{lxcCommand, "exec", b.sandbox.LXDContainer, "--mode=non-interactive"}
+		for _, e := range env {
+			lxc = append(lxc, "--env", e)
+		}
+		lxc = append(lxc, "--", "/bin/sh", "-c", `cd "$0" && exec "$@"`, dir)
+		args = append(lxc, args...)
+	}
+	return args
+}
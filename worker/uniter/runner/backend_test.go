@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"os/exec"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/os"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+type BackendSuite struct {
+	testing.IsolationSuite
+	lookedUp []string
+}
+
+var _ = gc.Suite(&BackendSuite{})
+
+func (s *BackendSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.lookedUp = nil
+	s.PatchValue(&os.HostOS, func() os.OSType { return os.Ubuntu })
+	s.PatchValue(runner.SandboxLookPath, func(file string) (string, error) {
+		s.lookedUp = append(s.lookedUp, file)
+		return "/usr/bin/" + file, nil
+	})
+}
+
+func (s *BackendSuite) command(c *gc.C, sandbox params.HookSandbox) *exec.Cmd {
+	backend, err := runner.NewExecutionBackend(sandbox)
+	c.Assert(err, jc.ErrorIsNil)
+	cmd, err := backend.Command([]string{"/charm/hooks/install"}, []string{"FOO=bar"}, "/charm")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.Env, jc.DeepEquals, []string{"FOO=bar"})
+	c.Assert(cmd.Dir, gc.Equals, "/charm")
+	return cmd
+}
+
+func (s *BackendSuite) TestDirect(c *gc.C) {
+	backend, err := runner.NewExecutionBackend(params.HookSandbox{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backend, gc.Equals, runner.DirectBackend)
+
+	cmd := s.command(c, params.HookSandbox{})
+	c.Assert(cmd.Args, jc.DeepEquals, []string{"/charm/hooks/install"})
+	c.Assert(s.lookedUp, gc.HasLen, 0)
+}
+
+func (s *BackendSuite) TestConfined(c *gc.C) {
+	cmd := s.command(c, params.HookSandbox{
+		User:        "hooks",
+		MemoryLimit: 512,
+		CPUQuota:    50,
+	})
+	c.Assert(cmd.Args, jc.DeepEquals, []string{
+		"systemd-run", "--scope", "--quiet", "--uid=hooks",
+		"-p", "MemoryLimit=512M",
+		"-p", "CPUQuota=50%",
+		"--", "/charm/hooks/install",
+	})
+	c.Assert(s.lookedUp, jc.DeepEquals, []string{"systemd-run"})
+}
+
+func (s *BackendSuite) TestLXDContainer(c *gc.C) {
+	cmd := s.command(c, params.HookSandbox{LXDContainer: "hook-jail"})
+	c.Assert(cmd.Args, jc.DeepEquals, []string{
+		"lxc", "exec", "hook-jail", "--mode=non-interactive",
+		"--env", "FOO=bar",
+		"--", "/bin/sh", "-c", `cd "$0" && exec "$@"`, "/charm",
+		"/charm/hooks/install",
+	})
+	c.Assert(s.lookedUp, jc.DeepEquals, []string{"lxc"})
+}
+
+func (s *BackendSuite) TestLXDContainerConfined(c *gc.C) {
+	cmd := s.command(c, params.HookSandbox{
+		User:         "hooks",
+		LXDContainer: "hook-jail",
+	})
+	c.Assert(cmd.Args, jc.DeepEquals, []string{
+		"lxc", "exec", "hook-jail", "--mode=non-interactive",
+		"--env", "FOO=bar",
+		"--", "/bin/sh", "-c", `cd "$0" && exec "$@"`, "/charm",
+		"systemd-run", "--scope", "--quiet", "--uid=hooks",
+		"--", "/charm/hooks/install",
+	})
+}
+
+func (s *BackendSuite) TestMissingCommand(c *gc.C) {
+	s.PatchValue(runner.SandboxLookPath, func(file string) (string, error) {
+		return "", errors.New("not found")
+	})
+	_, err := runner.NewExecutionBackend(params.HookSandbox{CPUQuota: 50})
+	c.Assert(err, gc.ErrorMatches, `hook sandbox requires "systemd-run": not found`)
+}
+
+func (s *BackendSuite) TestWindowsNotSupported(c *gc.C) {
+	s.PatchValue(&os.HostOS, func() os.OSType { return os.Windows })
+	_, err := runner.NewExecutionBackend(params.HookSandbox{User: "hooks"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
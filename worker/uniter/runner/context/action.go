@@ -15,6 +15,10 @@ type ActionData struct {
 	Failed         bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+
+	// CorrelationId is the id of the API request that enqueued the
+	// action, if any.
+	CorrelationId string
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
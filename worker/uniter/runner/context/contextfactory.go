@@ -143,6 +143,10 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		f.state.LeadershipSettings,
 		f.tracker,
 	)
+	schemas, err := ReadRelationSchemas(f.paths.GetCharmDir())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read relation schema")
+	}
 	ctx := &HookContext{
 		unit:               f.unit,
 		state:              f.state,
@@ -151,7 +155,7 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		envName:            f.envName,
 		unitName:           f.unit.Name(),
 		assignedMachineTag: f.machineTag,
-		relations:          f.getContextRelations(),
+		relations:          f.getContextRelations(schemas),
 		relationId:         -1,
 		pendingPorts:       make(map[PortRange]PortRangeInfo),
 		storage:            f.storage,
@@ -234,8 +238,8 @@ func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext,
 }
 
 // getContextRelations updates the factory's relation caches, and uses them
-// to construct ContextRelations for a fresh context.
-func (f *contextFactory) getContextRelations() map[int]*ContextRelation {
+// and the given schemas to construct ContextRelations for a fresh context.
+func (f *contextFactory) getContextRelations(schemas RelationSchemas) map[int]*ContextRelation {
 	contextRelations := map[int]*ContextRelation{}
 	relationInfos := f.getRelationInfos()
 	relationCaches := map[int]*RelationCache{}
@@ -249,7 +253,9 @@ func (f *contextFactory) getContextRelations() map[int]*ContextRelation {
 			cache = NewRelationCache(relationUnit.ReadSettings, memberNames)
 		}
 		relationCaches[id] = cache
-		contextRelations[id] = NewContextRelation(relationUnit, cache)
+		contextRelation := NewContextRelation(relationUnit, cache)
+		contextRelation.schema = schemas[contextRelation.Name()]
+		contextRelations[id] = contextRelation
 	}
 	f.relationCaches = relationCaches
 	return contextRelations
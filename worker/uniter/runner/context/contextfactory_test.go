@@ -4,7 +4,9 @@
 package context_test
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/errors"
@@ -162,6 +164,18 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestHookContextInvalidRelationSchema(c *gc.C) {
+	charmDir := s.paths.GetCharmDir()
+	err := os.MkdirAll(charmDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	schema := "ring:\n  port:\n    type: integer\n"
+	err = ioutil.WriteFile(filepath.Join(charmDir, context.RelationSchemaFile), []byte(schema), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.factory.HookContext(hook.Info{Kind: hooks.Install})
+	c.Assert(err, gc.ErrorMatches, `cannot read relation schema: invalid schema for setting "port" of relation endpoint "ring": type "integer" not valid`)
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
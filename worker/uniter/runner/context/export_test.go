@@ -184,3 +184,7 @@ func CachedSettings(cf0 ContextFactory, relId int, unitName string) (params.Sett
 	settings, found := cf.relationCaches[relId].members[unitName]
 	return settings, found
 }
+
+func SetRelationSchema(ctx *ContextRelation, schema RelationSettingsSchema) {
+	ctx.schema = schema
+}
@@ -6,6 +6,8 @@ package context
 import (
 	"fmt"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -27,6 +29,10 @@ type ContextRelation struct {
 
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
+
+	// schema, if not nil, is the schema declared by the charm for
+	// the unit's settings.
+	schema RelationSettingsSchema
 }
 
 // NewContextRelation creates a new context for the given relation unit.
@@ -71,9 +77,15 @@ func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 	return ctx.settings, nil
 }
 
-// WriteSettings persists all changes made to the unit's relation settings.
+// WriteSettings persists all changes made to the unit's relation settings,
+// after checking them against the schema declared by the charm.
 func (ctx *ContextRelation) WriteSettings() (err error) {
 	if ctx.settings != nil {
+		if ctx.schema != nil {
+			if err := ctx.schema.Validate(ctx.settings.Map()); err != nil {
+				return errors.Annotatef(err, "invalid settings for relation endpoint %q", ctx.endpointName)
+			}
+		}
 		err = ctx.settings.Write()
 	}
 	return
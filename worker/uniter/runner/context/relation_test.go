@@ -161,6 +161,29 @@ func (s *ContextRelationSuite) TestLocalSettings(c *gc.C) {
 	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"change": "exciting"})
 }
 
+func (s *ContextRelationSuite) TestWriteSettingsChecksSchema(c *gc.C) {
+	ctx := context.NewContextRelation(s.apiRelUnit, nil)
+	context.SetRelationSchema(ctx, context.RelationSettingsSchema{
+		"port": {Type: context.SettingTypeInt},
+	})
+	node, err := ctx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	node.Set("port", "many")
+
+	err = ctx.WriteSettings()
+	c.Assert(err, gc.ErrorMatches, `invalid settings for relation endpoint "ring": setting "port": expected int, got "many"`)
+	settings, err := s.ru.ReadSettings("u/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	node.Set("port", "5432")
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = s.ru.ReadSettings("u/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"port": "5432"})
+}
+
 func convertSettings(settings params.Settings) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range settings {
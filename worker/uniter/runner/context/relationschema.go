@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RelationSchemaFile is the name of the file, in the charm directory,
+// in which a charm may declare the schema of the settings its units
+// set on their relations. It maps each relation endpoint name to the
+// settings of that endpoint, for example:
+//
+//	db:
+//	  host:
+//	    type: string
+//	    pattern: '[a-z0-9.-]+'
+//	  port:
+//	    type: int
+//
+// Settings that are not declared are not checked.
+const RelationSchemaFile = "relation-schema.yaml"
+
+// Relation setting types that may be declared in a schema.
+const (
+	SettingTypeString  = "string"
+	SettingTypeInt     = "int"
+	SettingTypeFloat   = "float"
+	SettingTypeBoolean = "boolean"
+	SettingTypeJSON    = "json"
+)
+
+// RelationSchemas holds the relation settings schemas declared by a
+// charm, keyed by relation endpoint name.
+type RelationSchemas map[string]RelationSettingsSchema
+
+// RelationSettingsSchema holds the schema of the settings of one
+// relation endpoint, keyed by setting name.
+type RelationSettingsSchema map[string]SettingSchema
+
+// SettingSchema describes the values a relation setting may hold.
+type SettingSchema struct {
+	// Type is the type of the setting's values, one of "string",
+	// "int", "float", "boolean" or "json". It defaults to "string".
+	Type string `yaml:"type,omitempty"`
+
+	// Pattern, if set, is a regular expression that must match
+	// the whole of the setting's values.
+	Pattern string `yaml:"pattern,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// ReadRelationSchemas reads the relation settings schemas declared by
+// the charm in the given directory. A charm without a schema file
+// declares no schemas.
+func ReadRelationSchemas(charmDir string) (RelationSchemas, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, RelationSchemaFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var schemas RelationSchemas
+	if err := goyaml.Unmarshal(data, &schemas); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse %s", RelationSchemaFile)
+	}
+	for endpoint, schema := range schemas {
+		for name, setting := range schema {
+			if err := setting.init(); err != nil {
+				return nil, errors.Annotatef(err, "invalid schema for setting %q of relation endpoint %q", name, endpoint)
+			}
+			schema[name] = setting
+		}
+	}
+	return schemas, nil
+}
+
+// init checks the setting's schema and compiles its pattern.
+func (s *SettingSchema) init() error {
+	switch s.Type {
+	case "":
+		s.Type = SettingTypeString
+	case SettingTypeString, SettingTypeInt, SettingTypeFloat, SettingTypeBoolean, SettingTypeJSON:
+	default:
+		return errors.NotValidf("type %q", s.Type)
+	}
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile("^(?:" + s.Pattern + ")$")
+		if err != nil {
+			return errors.Annotatef(err, "invalid pattern %q", s.Pattern)
+		}
+		s.pattern = pattern
+	}
+	return nil
+}
+
+// Validate returns an error if any of the given settings does not
+// match its schema.
+func (schema RelationSettingsSchema) Validate(settings params.Settings) error {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		setting, ok := schema[name]
+		if !ok {
+			continue
+		}
+		if err := setting.validate(settings[name]); err != nil {
+			return errors.Annotatef(err, "setting %q", name)
+		}
+	}
+	return nil
+}
+
+// validate returns an error if the value does not match the schema.
+func (s SettingSchema) validate(value string) error {
+	var err error
+	switch s.Type {
+	case SettingTypeInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case SettingTypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case SettingTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	case SettingTypeJSON:
+		var v interface{}
+		err = json.Unmarshal([]byte(value), &v)
+	}
+	if err != nil {
+		return errors.Errorf("expected %s, got %q", s.Type, value)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		return errors.Errorf("value %q does not match pattern %q", value, s.Pattern)
+	}
+	return nil
+}
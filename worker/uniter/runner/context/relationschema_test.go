@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type RelationSchemaSuite struct {
+	testing.IsolationSuite
+	charmDir string
+}
+
+var _ = gc.Suite(&RelationSchemaSuite{})
+
+func (s *RelationSchemaSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+}
+
+func (s *RelationSchemaSuite) writeSchema(c *gc.C, content string) {
+	err := ioutil.WriteFile(filepath.Join(s.charmDir, context.RelationSchemaFile), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RelationSchemaSuite) TestReadRelationSchemasNoFile(c *gc.C) {
+	schemas, err := context.ReadRelationSchemas(s.charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, gc.HasLen, 0)
+}
+
+func (s *RelationSchemaSuite) TestReadRelationSchemas(c *gc.C) {
+	s.writeSchema(c, `
+db:
+  host:
+    pattern: '[a-z0-9.-]+'
+  port:
+    type: int
+`)
+	schemas, err := context.ReadRelationSchemas(s.charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, gc.HasLen, 1)
+	schema := schemas["db"]
+	c.Assert(schema, gc.HasLen, 2)
+	c.Check(schema["host"].Type, gc.Equals, context.SettingTypeString)
+	c.Check(schema["host"].Pattern, gc.Equals, "[a-z0-9.-]+")
+	c.Check(schema["port"].Type, gc.Equals, context.SettingTypeInt)
+}
+
+func (s *RelationSchemaSuite) TestReadRelationSchemasInvalid(c *gc.C) {
+	for i, test := range []struct {
+		content string
+		err     string
+	}{{
+		content: "db: [",
+		err:     "cannot parse relation-schema.yaml: .*",
+	}, {
+		content: "db:\n  port:\n    type: integer\n",
+		err:     `invalid schema for setting "port" of relation endpoint "db": type "integer" not valid`,
+	}, {
+		content: "db:\n  host:\n    pattern: '['\n",
+		err:     `invalid schema for setting "host" of relation endpoint "db": invalid pattern "\[": .*`,
+	}} {
+		c.Logf("test %d: %q", i, test.content)
+		s.writeSchema(c, test.content)
+		_, err := context.ReadRelationSchemas(s.charmDir)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RelationSchemaSuite) TestValidate(c *gc.C) {
+	s.writeSchema(c, `
+db:
+  host:
+    pattern: '[a-z0-9.-]+'
+  port:
+    type: int
+  ratio:
+    type: float
+  secure:
+    type: boolean
+  extra:
+    type: json
+`)
+	schemas, err := context.ReadRelationSchemas(s.charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	schema := schemas["db"]
+
+	err = schema.Validate(params.Settings{
+		"host":            "db-0.example.com",
+		"port":            "5432",
+		"ratio":           "0.5",
+		"secure":          "true",
+		"extra":           `{"replicas": ["db-1"]}`,
+		"private-address": "10.0.0.1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		settings params.Settings
+		err      string
+	}{{
+		settings: params.Settings{"host": "DB_0"},
+		err:      `setting "host": value "DB_0" does not match pattern "\[a-z0-9.-\]\+"`,
+	}, {
+		settings: params.Settings{"port": "5432/tcp"},
+		err:      `setting "port": expected int, got "5432/tcp"`,
+	}, {
+		settings: params.Settings{"ratio": "half"},
+		err:      `setting "ratio": expected float, got "half"`,
+	}, {
+		settings: params.Settings{"secure": "yes please"},
+		err:      `setting "secure": expected boolean, got "yes please"`,
+	}, {
+		settings: params.Settings{"extra": "{"},
+		err:      `setting "extra": expected json, got "{"`,
+	}} {
+		c.Logf("test %d: %v", i, test.settings)
+		err := schema.Validate(test.settings)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
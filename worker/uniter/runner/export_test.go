@@ -17,3 +17,9 @@ var (
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+func RunnerBackend(rnr Runner) ExecutionBackend {
+	return rnr.(*runner).backend
+}
+
+var SandboxLookPath = &sandboxLookPath
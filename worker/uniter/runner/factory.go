@@ -80,7 +80,11 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	backend, err := f.executionBackend(ctx.UnitName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	runner := NewSandboxedRunner(ctx, f.paths, backend)
 	return runner, nil
 }
 
@@ -122,11 +126,36 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 	}
 
 	actionData := context.NewActionData(name, &tag, params)
+	actionData.CorrelationId = action.CorrelationId()
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, err := f.executionBackend(ctx.UnitName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	runner := NewSandboxedRunner(ctx, f.paths, backend)
 	return runner, nil
 }
 
+// executionBackend returns the backend running the unit's hooks and
+// actions, in the sandbox currently configured for its application.
+func (f *factory) executionBackend(unitName string) (ExecutionBackend, error) {
+	unit, err := f.state.Unit(names.NewUnitTag(unitName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sandbox, err := unit.HookSandbox()
+	if params.IsCodeNotImplemented(err) {
+		// The controller predates hook sandboxes.
+		return DirectBackend, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get hook sandbox")
+	}
+	return NewExecutionBackend(sandbox)
+}
+
 func getCharm(charmPath string) (charm.Charm, error) {
 	ch, err := charm.ReadCharm(charmPath)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	jujuos "github.com/juju/utils/os"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 	"gopkg.in/juju/names.v2"
@@ -113,6 +114,28 @@ func (s *FactorySuite) TestNewHookRunner(c *gc.C) {
 	s.AssertPaths(c, rnr)
 }
 
+func (s *FactorySuite) TestNewHookRunnerDirect(c *gc.C) {
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerBackend(rnr), gc.Equals, runner.DirectBackend)
+}
+
+func (s *FactorySuite) TestNewHookRunnerSandboxed(c *gc.C) {
+	s.PatchValue(&jujuos.HostOS, func() jujuos.OSType { return jujuos.Ubuntu })
+	s.PatchValue(runner.SandboxLookPath, func(file string) (string, error) {
+		return "/usr/bin/" + file, nil
+	})
+	err := s.service.SetHookSandbox(state.HookSandbox{User: "hooks"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertPaths(c, rnr)
+	cmd, err := runner.RunnerBackend(rnr).Command([]string{"hook"}, nil, s.paths.GetCharmDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.Args[:4], jc.DeepEquals, []string{"systemd-run", "--scope", "--quiet", "--uid=hooks"})
+}
+
 func (s *FactorySuite) TestNewHookRunnerWithBadHook(c *gc.C) {
 	rnr, err := s.factory.NewHookRunner(hook.Info{})
 	c.Assert(rnr, gc.IsNil)
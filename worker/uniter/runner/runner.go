@@ -7,7 +7,6 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"time"
 	"unicode/utf8"
@@ -57,15 +56,24 @@ type Context interface {
 	Flush(badge string, failure error) error
 }
 
-// NewRunner returns a Runner backed by the supplied context and paths.
+// NewRunner returns a Runner backed by the supplied context and paths,
+// which runs hooks directly.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return NewSandboxedRunner(context, paths, DirectBackend)
+}
+
+// NewSandboxedRunner returns a Runner backed by the supplied context and
+// paths, which runs hooks and actions using the supplied backend. Commands
+// run with juju-run are not confined by the backend.
+func NewSandboxedRunner(context Context, paths context.Paths, backend ExecutionBackend) Runner {
+	return &runner{context, paths, backend}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+	backend ExecutionBackend
 }
 
 func (runner *runner) Context() Context {
@@ -237,10 +245,10 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err != nil {
 		return err
 	}
-	hookCmd := hookCommand(hook)
-	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
-	ps.Env = env
-	ps.Dir = charmDir
+	ps, err := runner.backend.Command(hookCommand(hook), env, charmDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
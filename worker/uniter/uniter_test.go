@@ -244,6 +244,27 @@ func (s *UniterSuite) TestUniterUpdateStatusHook(c *gc.C) {
 	})
 }
 
+func (s *UniterSuite) TestNoUniterUpdateStatusHookInMaintenance(c *gc.C) {
+	s.runUniterTests(c, []uniterTest{
+		ut(
+			"update status hook doesn't run while machine in maintenance",
+			createCharm{},
+			serveCharm{},
+			createUniter{},
+			waitHooks(startupHooks(false)),
+			waitUnitAgent{status: status.StatusIdle},
+			setMachineMaintenance{true},
+			updateStatusHookTick{},
+			waitHooks{},
+
+			// Leave maintenance and the hook should run.
+			setMachineMaintenance{false},
+			updateStatusHookTick{},
+			waitHooks{"update-status"},
+		),
+	})
+}
+
 func (s *UniterSuite) TestNoUniterUpdateStatusHookInError(c *gc.C) {
 	s.runUniterTests(c, []uniterTest{
 		ut(
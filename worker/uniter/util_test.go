@@ -933,6 +933,19 @@ func (s updateStatusHookTick) step(c *gc.C, ctx *context) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+type setMachineMaintenance struct {
+	maintenance bool
+}
+
+func (s setMachineMaintenance) step(c *gc.C, ctx *context) {
+	machineId, err := ctx.unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := ctx.st.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetMaintenance(s.maintenance)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type changeConfig map[string]interface{}
 
 func (s changeConfig) step(c *gc.C, ctx *context) {
@@ -0,0 +1,322 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usagereporter
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// NewCollector returns a Collector which measures the usage of the
+// machine from the proc and sysfs filesystems mounted at procDir and
+// sysDir, and the disk usage of the filesystem holding diskPath. The
+// disk usage of each unit is measured from its agent directory in
+// agentsDir.
+//
+// CPU usage is measured over the period since the previous
+// measurement, so the first measurement covers the time since the
+// machine booted, and no CPU usage is reported for a unit until it
+// has been measured twice. Network traffic is likewise measured since
+// the previous measurement, but none is reported by the first.
+func NewCollector(procDir, sysDir, diskPath, agentsDir string) Collector {
+	return &procCollector{
+		procDir:   procDir,
+		sysDir:    sysDir,
+		diskPath:  diskPath,
+		agentsDir: agentsDir,
+		pageSize:  uint64(os.Getpagesize()),
+		unitTicks: make(map[string]uint64),
+	}
+}
+
+// procCollector is a Collector which reads usage from the Linux proc
+// filesystem.
+type procCollector struct {
+	procDir   string
+	sysDir    string
+	diskPath  string
+	agentsDir string
+	pageSize  uint64
+
+	// totalTicks and idleTicks hold the CPU time counters of the
+	// machine at the previous measurement, and unitTicks those of
+	// each unit's processes.
+	totalTicks uint64
+	idleTicks  uint64
+	unitTicks  map[string]uint64
+
+	// trafficCounters holds the traffic counters of each network
+	// interface at the previous measurement, or nil if none has
+	// succeeded yet.
+	trafficCounters map[string]trafficCounters
+}
+
+// Collect is part of the Collector interface.
+func (c *procCollector) Collect() (params.MachineUsage, error) {
+	var usage params.MachineUsage
+	totalTicks, idleTicks, err := c.readCPUTicks()
+	if err != nil {
+		return usage, errors.Annotate(err, "cannot measure CPU usage")
+	}
+	var elapsedTicks uint64
+	if totalTicks > c.totalTicks && idleTicks >= c.idleTicks {
+		elapsedTicks = totalTicks - c.totalTicks
+		usage.CPUPercent = percent(elapsedTicks-(idleTicks-c.idleTicks), elapsedTicks)
+	}
+	c.totalTicks, c.idleTicks = totalTicks, idleTicks
+
+	usage.MemoryUsed, usage.MemoryTotal, err = c.readMemory()
+	if err != nil {
+		return usage, errors.Annotate(err, "cannot measure memory usage")
+	}
+	usage.DiskUsed, usage.DiskTotal, err = diskUsage(c.diskPath)
+	if err != nil {
+		return usage, errors.Annotate(err, "cannot measure disk usage")
+	}
+	usage.Received, usage.Transmitted, err = c.measureTraffic()
+	if err != nil {
+		// Traffic is only accounted if the model asks for it, so
+		// a failure to measure it shouldn't stop the rest of the
+		// usage being reported. Any traffic in the meantime will
+		// be counted at the next successful measurement.
+		logger.Debugf("cannot measure network traffic: %v", err)
+	}
+
+	procs, err := c.readProcesses()
+	if err != nil {
+		return usage, errors.Annotate(err, "cannot list processes")
+	}
+	unitTicks := make(map[string]uint64)
+	for _, root := range procs {
+		if root.unit == "" {
+			continue
+		}
+		var ticks, rss uint64
+		for _, proc := range descendants(root, procs) {
+			ticks += proc.ticks
+			rss += proc.rss
+		}
+		tag := names.NewUnitTag(root.unit).String()
+		unitUsage := params.UnitUsage{
+			Tag:        tag,
+			MemoryUsed: rss * c.pageSize,
+			DiskUsed:   dirSize(filepath.Join(c.agentsDir, tag)),
+		}
+		if previous, ok := c.unitTicks[root.unit]; ok && ticks >= previous {
+			unitUsage.CPUPercent = percent(ticks-previous, elapsedTicks)
+		}
+		unitTicks[root.unit] = ticks
+		usage.Units = append(usage.Units, unitUsage)
+	}
+	c.unitTicks = unitTicks
+	return usage, nil
+}
+
+// readCPUTicks returns the total and idle CPU time of the machine,
+// summed over all CPUs, in clock ticks.
+func (c *procCollector) readCPUTicks() (total, idle uint64, err error) {
+	data, err := ioutil.ReadFile(filepath.Join(c.procDir, "stat"))
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	line := string(data)
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0, errors.Errorf("unexpected CPU statistics %q", line)
+	}
+	for i, field := range fields[1:] {
+		ticks, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, 0, errors.Errorf("unexpected CPU statistics %q", line)
+		}
+		total += ticks
+		// The fourth and fifth counters are idle and iowait time.
+		if i == 3 || i == 4 {
+			idle += ticks
+		}
+	}
+	return total, idle, nil
+}
+
+// readMemory returns the used and total memory of the machine, in
+// bytes.
+func (c *procCollector) readMemory() (used, total uint64, err error) {
+	data, err := ioutil.ReadFile(filepath.Join(c.procDir, "meminfo"))
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		// Values are reported in kB.
+		values[strings.TrimSuffix(fields[0], ":")] = value * 1024
+	}
+	total, ok := values["MemTotal"]
+	if !ok {
+		return 0, 0, errors.New("total memory not reported")
+	}
+	available, ok := values["MemAvailable"]
+	if !ok {
+		// Older kernels don't estimate the available memory.
+		available = values["MemFree"] + values["Buffers"] + values["Cached"]
+	}
+	if available > total {
+		available = total
+	}
+	return total - available, total, nil
+}
+
+// process holds the details of a running process needed to measure
+// the usage of units.
+type process struct {
+	pid  int
+	ppid int
+
+	// ticks holds the CPU time used by the process and its
+	// reaped children, in clock ticks.
+	ticks uint64
+
+	// rss holds the resident memory of the process, in pages.
+	rss uint64
+
+	// unit holds the name of the unit if the process is a unit
+	// agent.
+	unit string
+}
+
+// readProcesses returns the processes running on the machine. Any
+// process which exits while it is being read is skipped.
+func (c *procCollector) readProcesses() ([]process, error) {
+	entries, err := ioutil.ReadDir(c.procDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var procs []process
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		proc, err := c.readProcess(pid)
+		if err != nil {
+			logger.Tracef("skipping process %d: %v", pid, err)
+			continue
+		}
+		procs = append(procs, proc)
+	}
+	return procs, nil
+}
+
+func (c *procCollector) readProcess(pid int) (process, error) {
+	dir := filepath.Join(c.procDir, strconv.Itoa(pid))
+	stat, err := ioutil.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return process{}, errors.Trace(err)
+	}
+	// The command name may contain spaces and parentheses, so
+	// the fields are only split after its closing parenthesis.
+	i := bytes.LastIndexByte(stat, ')')
+	if i < 0 {
+		return process{}, errors.Errorf("unexpected process statistics %q", stat)
+	}
+	fields := strings.Fields(string(stat[i+1:]))
+	if len(fields) < 22 {
+		return process{}, errors.Errorf("unexpected process statistics %q", stat)
+	}
+	values := make([]uint64, len(fields))
+	for _, index := range []int{1, 11, 12, 13, 14, 21} {
+		values[index], err = strconv.ParseUint(fields[index], 10, 64)
+		if err != nil {
+			return process{}, errors.Errorf("unexpected process statistics %q", stat)
+		}
+	}
+	proc := process{
+		pid:   pid,
+		ppid:  int(values[1]),
+		ticks: values[11] + values[12] + values[13] + values[14],
+		rss:   values[21],
+	}
+	cmdline, err := ioutil.ReadFile(filepath.Join(dir, "cmdline"))
+	if err != nil {
+		return process{}, errors.Trace(err)
+	}
+	proc.unit = unitAgentName(strings.Split(string(cmdline), "\x00"))
+	return proc, nil
+}
+
+// unitAgentName returns the name of the unit run by the agent with
+// the given command line, or "" if the command line is not that of a
+// unit agent.
+func unitAgentName(args []string) string {
+	if len(args) < 2 || filepath.Base(args[0]) != "jujud" || args[1] != "unit" {
+		return ""
+	}
+	for i, arg := range args {
+		if arg == "--unit-name" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(arg, "--unit-name=") {
+			return strings.TrimPrefix(arg, "--unit-name=")
+		}
+	}
+	return ""
+}
+
+// descendants returns the given root process and all its descendants
+// in procs.
+func descendants(root process, procs []process) []process {
+	result := []process{root}
+	for i := 0; i < len(result); i++ {
+		for _, proc := range procs {
+			if proc.ppid == result[i].pid {
+				result = append(result, proc)
+			}
+		}
+	}
+	return result
+}
+
+// dirSize returns the total size of the regular files under the given
+// directory, in bytes. Files which cannot be read are not counted.
+func dirSize(dir string) uint64 {
+	var size uint64
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	return size
+}
+
+func percent(n, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
@@ -0,0 +1,219 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package usagereporter_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/usagereporter"
+)
+
+type CollectorSuite struct {
+	jujutesting.IsolationSuite
+
+	procDir   string
+	sysDir    string
+	agentsDir string
+	collector usagereporter.Collector
+}
+
+var _ = gc.Suite(&CollectorSuite{})
+
+func (s *CollectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.procDir = c.MkDir()
+	s.sysDir = c.MkDir()
+	s.agentsDir = c.MkDir()
+	s.writeFile(c, filepath.Join(s.procDir, "meminfo"), `
+MemTotal:        4000000 kB
+MemFree:          500000 kB
+MemAvailable:    1000000 kB
+Buffers:          100000 kB
+`[1:])
+	s.writeCPU(c, 1000, 800)
+	s.collector = usagereporter.NewCollector(s.procDir, s.sysDir, c.MkDir(), s.agentsDir)
+}
+
+func (s *CollectorSuite) writeFile(c *gc.C, path, content string) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// writeCPU writes the CPU statistics of the machine, splitting the
+// busy time between user and system time.
+func (s *CollectorSuite) writeCPU(c *gc.C, total, idle int) {
+	busy := total - idle
+	content := fmt.Sprintf("cpu  %d 0 %d %d 0 0 0 0 0 0\ncpu0 0 0 0 0 0 0 0 0 0 0\n", busy/2, busy-busy/2, idle)
+	s.writeFile(c, filepath.Join(s.procDir, "stat"), content)
+}
+
+// writeProcess writes the details of a process with the given parent,
+// CPU time in ticks and resident memory in pages.
+func (s *CollectorSuite) writeProcess(c *gc.C, pid, ppid, ticks, rss int, args ...string) {
+	dir := filepath.Join(s.procDir, fmt.Sprint(pid))
+	fields := make([]string, 22)
+	for i := range fields {
+		fields[i] = "0"
+	}
+	fields[0] = "S"
+	fields[1] = fmt.Sprint(ppid)
+	fields[11] = fmt.Sprint(ticks)
+	fields[21] = fmt.Sprint(rss)
+	stat := fmt.Sprintf("%d (%s) %s\n", pid, filepath.Base(args[0]), strings.Join(fields, " "))
+	s.writeFile(c, filepath.Join(dir, "stat"), stat)
+	s.writeFile(c, filepath.Join(dir, "cmdline"), strings.Join(args, "\x00")+"\x00")
+}
+
+func (s *CollectorSuite) TestMachineUsage(c *gc.C) {
+	usage, err := s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.CPUPercent, gc.Equals, 20.0)
+	c.Check(usage.MemoryUsed, gc.Equals, uint64(3000000*1024))
+	c.Check(usage.MemoryTotal, gc.Equals, uint64(4000000*1024))
+	c.Check(usage.DiskTotal > 0, jc.IsTrue)
+	c.Check(usage.DiskUsed <= usage.DiskTotal, jc.IsTrue)
+	c.Check(usage.Units, gc.HasLen, 0)
+
+	// Subsequent measurements cover the time since the previous one.
+	s.writeCPU(c, 2000, 1050)
+	usage, err = s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.CPUPercent, gc.Equals, 75.0)
+}
+
+func (s *CollectorSuite) TestMemoryWithoutAvailable(c *gc.C) {
+	s.writeFile(c, filepath.Join(s.procDir, "meminfo"), `
+MemTotal:        4000000 kB
+MemFree:          500000 kB
+Buffers:          100000 kB
+Cached:           400000 kB
+`[1:])
+	usage, err := s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.MemoryUsed, gc.Equals, uint64(3000000*1024))
+}
+
+func (s *CollectorSuite) TestUnitUsage(c *gc.C) {
+	pageSize := uint64(os.Getpagesize())
+	s.writeProcess(c, 1, 0, 50, 100, "/sbin/init")
+	s.writeProcess(c, 10, 1, 10, 200, "/var/lib/juju/tools/machine-0/jujud", "machine", "--machine-id", "0")
+	s.writeProcess(c, 20, 1, 20, 300, "/var/lib/juju/tools/unit-mysql-0/jujud", "unit", "--data-dir", "/var/lib/juju", "--unit-name", "mysql/0")
+	s.writeProcess(c, 21, 20, 30, 400, "/bin/bash", "hooks/config-changed")
+	s.writeProcess(c, 22, 21, 40, 500, "mysqld")
+	s.writeProcess(c, 30, 1, 5, 50, "/var/lib/juju/tools/unit-wordpress-1/jujud", "unit", "--unit-name=wordpress/1")
+	s.writeFile(c, filepath.Join(s.agentsDir, "unit-mysql-0", "charm", "metadata.yaml"), "0123456789")
+	s.writeFile(c, filepath.Join(s.agentsDir, "unit-mysql-0", "agent.conf"), "01234")
+
+	usage, err := s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Units, gc.HasLen, 2)
+	c.Check(usage.Units[0].Tag, gc.Equals, "unit-mysql-0")
+	c.Check(usage.Units[0].CPUPercent, gc.Equals, 0.0)
+	c.Check(usage.Units[0].MemoryUsed, gc.Equals, 1200*pageSize)
+	c.Check(usage.Units[0].DiskUsed, gc.Equals, uint64(15))
+	c.Check(usage.Units[1].Tag, gc.Equals, "unit-wordpress-1")
+	c.Check(usage.Units[1].MemoryUsed, gc.Equals, 50*pageSize)
+	c.Check(usage.Units[1].DiskUsed, gc.Equals, uint64(0))
+
+	// CPU usage of units is measured since the previous measurement.
+	s.writeCPU(c, 2000, 1050)
+	s.writeProcess(c, 20, 1, 120, 300, "/var/lib/juju/tools/unit-mysql-0/jujud", "unit", "--data-dir", "/var/lib/juju", "--unit-name", "mysql/0")
+	err = os.RemoveAll(filepath.Join(s.procDir, "22"))
+	c.Assert(err, jc.ErrorIsNil)
+	usage, err = s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Units, gc.HasLen, 2)
+	c.Check(usage.Units[0].CPUPercent, gc.Equals, 6.0)
+	c.Check(usage.Units[0].MemoryUsed, gc.Equals, 700*pageSize)
+	c.Check(usage.Units[1].CPUPercent, gc.Equals, 0.0)
+}
+
+// writeTraffic writes the traffic counters of the machine's network
+// interfaces. Only eth0 and ens4 are backed by devices.
+func (s *CollectorSuite) writeTraffic(c *gc.C, eth0Received, eth0Transmitted, ens4Received int) {
+	for _, name := range []string{"eth0", "ens4"} {
+		s.writeFile(c, filepath.Join(s.sysDir, "class", "net", name, "device", "uevent"), "")
+	}
+	err := os.MkdirAll(filepath.Join(s.sysDir, "class", "net", "lxdbr0"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.writeFile(c, filepath.Join(s.procDir, "net", "dev"), fmt.Sprintf(`
+Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo: 5000      50    0    0    0     0          0         0     5000      50    0    0    0     0       0          0
+  eth0: %d   900    0    0    0     0          0         0   %d     700    0    0    0     0       0          0
+  ens4: %d       3    0    0    0     0          0         0      400       4    0    0    0     0       0          0
+lxdbr0: 7000      70    0    0    0     0          0         0     8000      80    0    0    0     0       0          0
+`[1:], eth0Received, eth0Transmitted, ens4Received))
+}
+
+func (s *CollectorSuite) TestTraffic(c *gc.C) {
+	s.writeTraffic(c, 1000000, 200000, 300)
+	usage, err := s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.Received, gc.Equals, uint64(0))
+	c.Check(usage.Transmitted, gc.Equals, uint64(0))
+
+	// Subsequent measurements report the traffic through devices
+	// since the previous one.
+	s.writeTraffic(c, 1500000, 250000, 400)
+	usage, err = s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.Received, gc.Equals, uint64(500100))
+	c.Check(usage.Transmitted, gc.Equals, uint64(50000))
+}
+
+func (s *CollectorSuite) TestTrafficCountersReset(c *gc.C) {
+	s.writeTraffic(c, 1000000, 200000, 300)
+	_, err := s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.writeTraffic(c, 1000, 200000, 300)
+	usage, err := s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.Received, gc.Equals, uint64(1000))
+	c.Check(usage.Transmitted, gc.Equals, uint64(0))
+}
+
+func (s *CollectorSuite) TestTrafficMissing(c *gc.C) {
+	usage, err := s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.MemoryTotal, gc.Equals, uint64(4000000*1024))
+	c.Check(usage.Received, gc.Equals, uint64(0))
+}
+
+func (s *CollectorSuite) TestTrafficInvalid(c *gc.C) {
+	s.writeTraffic(c, 1000000, 200000, 300)
+	_, err := s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Invalid counters are skipped, and the traffic in the
+	// meantime is counted at the next valid measurement.
+	s.writeFile(c, filepath.Join(s.procDir, "net", "dev"), "  eth0: 1 2 3\n")
+	usage, err := s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.Received, gc.Equals, uint64(0))
+
+	s.writeTraffic(c, 1000100, 200000, 300)
+	usage, err = s.collector.Collect()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.Received, gc.Equals, uint64(100))
+}
+
+func (s *CollectorSuite) TestMissingProc(c *gc.C) {
+	collector := usagereporter.NewCollector(c.MkDir(), c.MkDir(), c.MkDir(), s.agentsDir)
+	_, err := collector.Collect()
+	c.Assert(err, gc.ErrorMatches, "cannot measure CPU usage: .*")
+}
@@ -0,0 +1,24 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package usagereporter
+
+import (
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+// diskUsage returns the used and total space of the filesystem holding
+// the given path, in bytes.
+func diskUsage(path string) (used, total uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	total = stat.Blocks * uint64(stat.Bsize)
+	used = (stat.Blocks - stat.Bfree) * uint64(stat.Bsize)
+	return used, total, nil
+}
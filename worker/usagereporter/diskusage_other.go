@@ -0,0 +1,18 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package usagereporter
+
+import (
+	"runtime"
+
+	"github.com/juju/errors"
+)
+
+// diskUsage returns the used and total space of the filesystem holding
+// the given path, in bytes.
+func diskUsage(path string) (used, total uint64, err error) {
+	return 0, 0, errors.NotSupportedf("disk usage on %s", runtime.GOOS)
+}
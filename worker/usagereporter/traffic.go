@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usagereporter
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// trafficCounters holds the numbers of bytes received and transmitted
+// through a network interface.
+type trafficCounters struct {
+	received    uint64
+	transmitted uint64
+}
+
+// measureTraffic returns the numbers of bytes received and transmitted
+// by the machine since the previous measurement. The first measurement
+// only establishes the counters from which traffic is measured, so
+// traffic is never counted twice when the worker restarts, at the cost
+// of leaving out the traffic while it was stopped.
+func (c *procCollector) measureTraffic() (received, transmitted uint64, err error) {
+	counters, err := c.readTrafficCounters()
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	previous := c.trafficCounters
+	c.trafficCounters = counters
+	if previous == nil {
+		return 0, 0, nil
+	}
+	for name, current := range counters {
+		last := previous[name]
+		received += increase(last.received, current.received)
+		transmitted += increase(last.transmitted, current.transmitted)
+	}
+	return received, transmitted, nil
+}
+
+// readTrafficCounters returns the traffic counters of each of the
+// machine's network interfaces, keyed by interface name.
+//
+// Only interfaces backed by a device are read. Traffic forwarded from
+// containers or virtual machines crosses both virtual interfaces, such
+// as bridges and veth pairs, and the host's devices, so reading only
+// the devices counts it once. It does mean that the traffic of a
+// container is counted by its host rather than by the container.
+func (c *procCollector) readTrafficCounters() (map[string]trafficCounters, error) {
+	data, err := ioutil.ReadFile(filepath.Join(c.procDir, "net", "dev"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	counters := make(map[string]trafficCounters)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		// Each interface is described by a line of the form
+		//   name: rx-bytes rx-packets ... tx-bytes tx-packets ...
+		// following two header lines, which have no colon.
+		line := scanner.Text()
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			continue
+		}
+		name := strings.TrimSpace(line[:i])
+		fields := strings.Fields(line[i+1:])
+		if len(fields) < 16 {
+			return nil, errors.Errorf("unexpected statistics for interface %q", name)
+		}
+		if !c.hasDevice(name) {
+			continue
+		}
+		received, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("unexpected statistics for interface %q", name)
+		}
+		transmitted, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("unexpected statistics for interface %q", name)
+		}
+		counters[name] = trafficCounters{
+			received:    received,
+			transmitted: transmitted,
+		}
+	}
+	return counters, nil
+}
+
+// hasDevice returns whether the named interface is backed by a
+// device, rather than being a virtual interface.
+func (c *procCollector) hasDevice(name string) bool {
+	_, err := os.Stat(filepath.Join(c.sysDir, "class", "net", name, "device"))
+	return err == nil
+}
+
+// increase returns the increase in a counter from last to current.
+// Counters are reset when the machine reboots or an interface is
+// recreated, in which case the whole current value is counted.
+func increase(last, current uint64) uint64 {
+	if current < last {
+		return current
+	}
+	return current - last
+}
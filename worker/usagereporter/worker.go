@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usagereporter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.usagereporter")
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	ReportUsage(machineId string, usage params.MachineUsage) error
+}
+
+// Collector measures the resource usage of a machine, and of the units
+// deployed to it.
+type Collector interface {
+	Collect() (params.MachineUsage, error)
+}
+
+// Config defines the parameters of the usagereporter worker.
+type Config struct {
+	Facade    Facade
+	MachineId string
+	Collector Collector
+	Clock     clock.Clock
+
+	// ReportInterval defines how often the usage of the machine is
+	// measured and reported, after the initial report.
+	ReportInterval time.Duration
+}
+
+// Validate returns an error if Config cannot drive a usagereporter.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.Collector == nil {
+		return errors.NotValidf("nil Collector")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.ReportInterval <= 0 {
+		return errors.NotValidf("non-positive ReportInterval")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &usagereporter{config: config}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.run())
+	}()
+	return w, nil
+}
+
+// usagereporter periodically reports the resource usage of a machine
+// to the controller.
+type usagereporter struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+// Kill implements worker.Worker.
+func (w *usagereporter) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *usagereporter) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *usagereporter) run() error {
+	for {
+		if err := w.report(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(w.config.ReportInterval):
+		}
+	}
+}
+
+func (w *usagereporter) report() error {
+	usage, err := w.config.Collector.Collect()
+	if err != nil {
+		// Usage is reported for information only, so a failure to
+		// measure it is not worth restarting the worker for; the
+		// next measurement may well succeed.
+		logger.Warningf("cannot measure usage of machine %s: %v", w.config.MachineId, err)
+		return nil
+	}
+	if err := w.config.Facade.ReportUsage(w.config.MachineId, usage); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Tracef("usage reported for machine %s", w.config.MachineId)
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usagereporter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/usagereporter"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub      *jujutesting.Stub
+	clock     *coretesting.Clock
+	collector *stubCollector
+	config    usagereporter.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = new(jujutesting.Stub)
+	s.clock = coretesting.NewClock(time.Now())
+	s.collector = &stubCollector{stub: s.stub}
+	s.config = usagereporter.Config{
+		Facade:         &stubFacade{stub: s.stub},
+		MachineId:      "42",
+		Collector:      s.collector,
+		Clock:          s.clock,
+		ReportInterval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*usagereporter.Config)
+		err    string
+	}{{
+		func(config *usagereporter.Config) { config.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(config *usagereporter.Config) { config.MachineId = "" },
+		"empty MachineId not valid",
+	}, {
+		func(config *usagereporter.Config) { config.Collector = nil },
+		"nil Collector not valid",
+	}, {
+		func(config *usagereporter.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *usagereporter.Config) { config.ReportInterval = 0 },
+		"non-positive ReportInterval not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := usagereporter.New(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestReportsPeriodically(c *gc.C) {
+	w, err := usagereporter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	s.collector.usage.CPUPercent = 50
+	s.clock.Advance(time.Minute)
+	s.waitAlarm(c)
+
+	workertest.CleanKill(c, w)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Collect", nil},
+		{"ReportUsage", []interface{}{"42", params.MachineUsage{}}},
+		{"Collect", nil},
+		{"ReportUsage", []interface{}{"42", params.MachineUsage{CPUPercent: 50}}},
+	})
+}
+
+func (s *WorkerSuite) TestCollectErrorSkipsReport(c *gc.C) {
+	s.stub.SetErrors(errors.New("no proc"))
+	w, err := usagereporter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(time.Minute)
+	s.waitAlarm(c)
+
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c, "Collect", "Collect", "ReportUsage")
+}
+
+func (s *WorkerSuite) TestReportError(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("blam"))
+	w, err := usagereporter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "blam")
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for report interval")
+	}
+}
+
+type stubFacade struct {
+	stub *jujutesting.Stub
+}
+
+func (f *stubFacade) ReportUsage(machineId string, usage params.MachineUsage) error {
+	f.stub.AddCall("ReportUsage", machineId, usage)
+	return f.stub.NextErr()
+}
+
+type stubCollector struct {
+	stub  *jujutesting.Stub
+	usage params.MachineUsage
+}
+
+func (c *stubCollector) Collect() (params.MachineUsage, error) {
+	c.stub.AddCall("Collect")
+	if err := c.stub.NextErr(); err != nil {
+		return params.MachineUsage{}, err
+	}
+	return c.usage, nil
+}